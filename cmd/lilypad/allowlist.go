@@ -0,0 +1,136 @@
+package lilypad
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	optionsfactory "github.com/lilypad-tech/lilypad/pkg/options"
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/lilypad-tech/lilypad/pkg/solver/matcher"
+	"github.com/spf13/cobra"
+)
+
+func newAllowlistCmd() *cobra.Command {
+	options := optionsfactory.GetDefaultAllowlistOptions()
+
+	allowlistCmd := &cobra.Command{
+		Use:   "allowlist",
+		Short: "Inspect and check a solver module allowlist.",
+		Long:  "Inspect and check the module allowlist at ALLOWLIST_PATH.",
+	}
+	optionsfactory.AddAllowlistCliFlags(allowlistCmd, &options)
+
+	allowlistCmd.AddCommand(&cobra.Command{
+		Use:     "list",
+		Short:   "List the modules in the allowlist.",
+		Example: "lilypad allowlist list --allowlist-path ./allowlist.json",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runAllowlistList(cmd, options)
+		},
+	})
+	allowlistCmd.AddCommand(&cobra.Command{
+		Use:     "check <module>",
+		Short:   "Check whether a module would be allowed by the allowlist.",
+		Long:    "Check whether job offers for a module would be allowed. The module is given in the same module:version form as MODULE_NAME.",
+		Example: "lilypad allowlist check cowsay:v0.0.4 --allowlist-path ./allowlist.json",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAllowlistCheck(cmd, options, args[0])
+		},
+	})
+	allowlistCmd.AddCommand(&cobra.Command{
+		Use:     "validate <file>",
+		Short:   "Validate an allowlist file.",
+		Example: "lilypad allowlist validate ./allowlist.json",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAllowlistValidate(cmd, args[0])
+		},
+	})
+
+	return allowlistCmd
+}
+
+func loadAllowlistFromOptions(options solver.AllowlistOptions) (map[string]data.AllowlistItem, error) {
+	if options.Path == "" {
+		return nil, fmt.Errorf("ALLOWLIST_PATH is required")
+	}
+	return solver.LoadAllowlist(options.Path)
+}
+
+func runAllowlistList(cmd *cobra.Command, options solver.AllowlistOptions) error {
+	allowlist, err := loadAllowlistFromOptions(options)
+	if err != nil {
+		return err
+	}
+	items := []data.AllowlistItem{}
+	for _, item := range allowlist {
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Module.Repo != items[j].Module.Repo {
+			return items[i].Module.Repo < items[j].Module.Repo
+		}
+		return items[i].Module.Hash < items[j].Module.Hash
+	})
+
+	writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "MODULE\tVERSION\tMODULE ID\tENABLED\tMINIMUM SPEC\tMAX RUNTIME\tVERIFIERS")
+	for _, item := range items {
+		maxRuntime := "-"
+		if item.MaxRuntime > 0 {
+			maxRuntime = fmt.Sprintf("%ds", item.MaxRuntime)
+		}
+		verifiers := "default"
+		if item.Verifiers != nil {
+			verifiers = strings.Join(item.Verifiers, ",")
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%t\tcpu=%d gpu=%d ram=%d\t%s\t%s\n",
+			item.Module.Repo,
+			item.Module.Hash,
+			item.ModuleID,
+			!item.Disabled,
+			item.MinimumSpec.CPU,
+			item.MinimumSpec.GPU,
+			item.MinimumSpec.RAM,
+			maxRuntime,
+			verifiers,
+		)
+	}
+	return writer.Flush()
+}
+
+func runAllowlistCheck(cmd *cobra.Command, options solver.AllowlistOptions, name string) error {
+	allowlist, err := loadAllowlistFromOptions(options)
+	if err != nil {
+		return err
+	}
+	// resolve the module the same way the job creator does
+	// so we check the same module ID the solver will see
+	module, err := optionsfactory.ProcessModuleOptions(data.ModuleConfig{Name: name})
+	if err != nil {
+		return err
+	}
+	allowed, reason, err := matcher.IsModuleAllowed(module, allowlist)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return fmt.Errorf("%s is not allowed: %s", name, reason)
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "%s is allowed: %s\n", name, reason)
+	return nil
+}
+
+func runAllowlistValidate(cmd *cobra.Command, path string) error {
+	items, err := solver.ValidateAllowlist(path)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "%s is valid with %d entries\n", path, len(items))
+	return nil
+}
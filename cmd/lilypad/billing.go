@@ -0,0 +1,129 @@
+package lilypad
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/jobcreator"
+	optionsfactory "github.com/lilypad-tech/lilypad/pkg/options"
+	"github.com/spf13/cobra"
+)
+
+func newBillingCmd() *cobra.Command {
+	options := optionsfactory.NewJobCreatorOptions()
+
+	billingCmd := &cobra.Command{
+		Use:   "billing",
+		Short: "Reconcile what your deals used and cost.",
+		Long:  "Reconcile what your deals used and cost. The solver meters each deal when its result is added, recording the instruction count, how long the job ran, the GPU-seconds it used and its price.",
+	}
+	optionsfactory.AddJobCreatorCliFlags(billingCmd, &options)
+
+	from := ""
+	to := ""
+	format := "csv"
+	asResourceProvider := false
+	exportCmd := &cobra.Command{
+		Use:     "export",
+		Short:   "Write the billing records of your deals as CSV or JSON.",
+		Example: "lilypad billing export --from 2026-09-01 --to 2026-10-01 > september.csv",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			fromTime, err := parseBillingTime("from", from)
+			if err != nil {
+				return err
+			}
+			toTime, err := parseBillingTime("to", to)
+			if err != nil {
+				return err
+			}
+			if format != "csv" && format != "json" {
+				return fmt.Errorf("format must be csv or json not %s", format)
+			}
+			return runJobCreatorCommand(cmd, options, func(jobCreator *jobcreator.JobCreator) error {
+				records, err := jobCreator.GetBillingRecords(asResourceProvider, fromTime, toTime)
+				if err != nil {
+					return err
+				}
+				if format == "json" {
+					encoder := json.NewEncoder(cmd.OutOrStdout())
+					encoder.SetIndent("", "  ")
+					return encoder.Encode(records)
+				}
+				return data.WriteBillingCSV(cmd.OutOrStdout(), records)
+			})
+		},
+	}
+	exportCmd.Flags().StringVar(&from, "from", from, "Only deals metered on or after this date, as YYYY-MM-DD or RFC3339.")
+	exportCmd.Flags().StringVar(&to, "to", to, "Only deals metered before this date, as YYYY-MM-DD or RFC3339.")
+	exportCmd.Flags().StringVar(&format, "format", format, "Either csv or json.")
+	exportCmd.Flags().BoolVar(&asResourceProvider, "resource-provider", asResourceProvider, "Export the deals your address ran as a resource provider rather than the ones it paid for.")
+	billingCmd.AddCommand(exportCmd)
+
+	budgetCmd := &cobra.Command{
+		Use:     "budget",
+		Short:   "Show what you have spent and have left of your daily and weekly budget.",
+		Long:    "Show what you have spent and have left of your daily and weekly budget. Spend is what your deals were billed, and the payment collateral of your open job offers and deals is reserved against the budget. The lower of --budget-daily / --budget-weekly and any budget the solver holds you to is used.",
+		Example: "lilypad billing budget --budget-daily 100",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runJobCreatorCommand(cmd, options, func(jobCreator *jobcreator.JobCreator) error {
+				status, err := jobCreator.GetBudget()
+				if err != nil {
+					return err
+				}
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(status)
+			})
+		},
+	}
+	billingCmd.AddCommand(budgetCmd)
+
+	instructions := uint64(1)
+	estimateCmd := &cobra.Command{
+		Use:     "estimate <module>",
+		Short:   "Show what a job would cost before you run it.",
+		Long:    "Show what a job would cost before you run it. The solver looks at the unmatched resource offers that could run the module on the machine it needs and reports the lowest, median and p90 instruction price, along with the cost of --instructions at the lowest and p90 price. A price cap at the top of that range should find a match most of the time.",
+		Example: "lilypad billing estimate cowsay:v0.0.4 --instructions 1000",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			offer := options.Offer
+			offer.Module.Name = args[0]
+			module, err := optionsfactory.ProcessModuleOptions(offer.Module)
+			if err != nil {
+				return err
+			}
+			offer.Module = module
+			return runJobCreatorCommand(cmd, options, func(jobCreator *jobcreator.JobCreator) error {
+				estimate, err := jobCreator.GetCostEstimate(offer, instructions)
+				if err != nil {
+					return err
+				}
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(estimate)
+			})
+		},
+	}
+	estimateCmd.Flags().Uint64Var(&instructions, "instructions", instructions, "How many instructions the job is expected to take.")
+	billingCmd.AddCommand(estimateCmd)
+
+	return billingCmd
+}
+
+// unix seconds for a date flag, zero if it was not given
+func parseBillingTime(name string, value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	for _, layout := range []string{time.DateOnly, time.RFC3339} {
+		parsed, err := time.Parse(layout, value)
+		if err == nil {
+			return parsed.Unix(), nil
+		}
+	}
+	return 0, fmt.Errorf("--%s must be YYYY-MM-DD or RFC3339 not %s", name, value)
+}
@@ -3,7 +3,6 @@ package lilypad
 import (
 	"fmt"
 
-	"github.com/lilypad-tech/lilypad/pkg/executor/bacalhau"
 	"github.com/lilypad-tech/lilypad/pkg/ipfs"
 	"github.com/lilypad-tech/lilypad/pkg/mediator"
 	optionsfactory "github.com/lilypad-tech/lilypad/pkg/options"
@@ -53,12 +52,12 @@ func runMediator(cmd *cobra.Command, options mediator.MediatorOptions) error {
 		return fmt.Errorf("error creating IPFS client: %s", err.Error())
 	}
 
-	executor, err := bacalhau.NewBacalhauExecutor(options.Bacalhau, ipfsClient)
+	executor, err := mediator.NewReplayExecutor(options, ipfsClient)
 	if err != nil {
 		return err
 	}
 
-	mediatorService, err := mediator.NewMediator(options, web3SDK, executor)
+	mediatorService, err := mediator.NewMediator(options, web3SDK, executor, ipfsClient)
 	if err != nil {
 		return err
 	}
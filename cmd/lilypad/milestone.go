@@ -0,0 +1,93 @@
+package lilypad
+
+import (
+	"fmt"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/jobcreator"
+	optionsfactory "github.com/lilypad-tech/lilypad/pkg/options"
+	"github.com/spf13/cobra"
+)
+
+func newMilestoneCmd() *cobra.Command {
+	options := optionsfactory.NewJobCreatorOptions()
+
+	milestoneCmd := &cobra.Command{
+		Use:   "milestone",
+		Short: "Pay for a long running job as it goes.",
+		Long:  "Look at and accept the milestones of a deal whose job offer asked for them with --milestones. Each milestone is a checkpoint of the running job and accepting it has the solver pay the resource provider its share of the payment collateral.",
+	}
+	optionsfactory.AddJobCreatorCliFlags(milestoneCmd, &options)
+
+	milestoneCmd.AddCommand(&cobra.Command{
+		Use:   "list <deal-id>",
+		Short: "List the milestones of a deal.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJobCreatorCommand(cmd, options, func(jobCreator *jobcreator.JobCreator) error {
+				deal, err := jobCreator.GetDeal(args[0])
+				if err != nil {
+					return err
+				}
+				printDealMilestones(cmd, deal)
+				return nil
+			})
+		},
+	})
+
+	milestoneCmd.AddCommand(&cobra.Command{
+		Use:     "accept <deal-id> <index>",
+		Short:   "Accept the checkpoint of a milestone and pay for it.",
+		Example: "lilypad milestone accept 1a2b3c... 0",
+		Args:    cobra.ExactArgs(2), //nolint:gomnd
+		RunE: func(cmd *cobra.Command, args []string) error {
+			index, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("%s is not a milestone index", args[1])
+			}
+			return runJobCreatorCommand(cmd, options, func(jobCreator *jobcreator.JobCreator) error {
+				deal, err := jobCreator.AcceptDealMilestone(args[0], index)
+				if err != nil {
+					return err
+				}
+				printDealMilestones(cmd, deal)
+				return nil
+			})
+		},
+	})
+
+	return milestoneCmd
+}
+
+func printDealMilestones(cmd *cobra.Command, deal data.DealContainer) {
+	if deal.Deal.JobOffer.Milestones == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "deal %s is paid when its result is accepted\n", deal.ID)
+		return
+	}
+	writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "INDEX\tCHECKPOINT\tAMOUNT\tSTATE\tTRANSACTION\tERROR")
+	for _, milestone := range deal.Milestones {
+		fmt.Fprintf(writer, "%d\t%s\t%s\t%s\t%s\t%s\n",
+			milestone.Index,
+			milestone.CheckpointCID,
+			milestone.Amount,
+			milestone.State,
+			orDash(milestone.TransactionHash),
+			orDash(milestone.Error),
+		)
+	}
+	writer.Flush()
+	fmt.Fprintf(cmd.OutOrStdout(), "%d of %d milestones paid\n", countPaidMilestones(deal), deal.Deal.JobOffer.Milestones)
+}
+
+func countPaidMilestones(deal data.DealContainer) int {
+	paid := 0
+	for _, milestone := range deal.Milestones {
+		if milestone.State == data.DealMilestonePaid {
+			paid++
+		}
+	}
+	return paid
+}
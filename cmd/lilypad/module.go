@@ -0,0 +1,372 @@
+package lilypad
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	executorlib "github.com/lilypad-tech/lilypad/pkg/executor"
+	"github.com/lilypad-tech/lilypad/pkg/executor/container"
+	"github.com/lilypad-tech/lilypad/pkg/executor/wasm"
+	"github.com/lilypad-tech/lilypad/pkg/module"
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/spf13/cobra"
+)
+
+func newModuleCmd() *cobra.Command {
+	moduleCmd := &cobra.Command{
+		Use:   "module",
+		Short: "Write and try out lilypad modules.",
+	}
+	moduleCmd.AddCommand(newModuleInitCmd())
+	moduleCmd.AddCommand(newModuleRunLocalCmd())
+	moduleCmd.AddCommand(newModuleSubmitCmd())
+	return moduleCmd
+}
+
+func newModuleInitCmd() *cobra.Command {
+	image := ""
+
+	initCmd := &cobra.Command{
+		Use:     "init <name> [dir]",
+		Short:   "Start a new module.",
+		Long:    "Write a Dockerfile, a module template that follows the module contract and example inputs to get a new module going. The folder defaults to the module name.",
+		Example: "lilypad module init hello-world",
+		Args:    cobra.RangeArgs(1, 2), //nolint:gomnd
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := args[0]
+			if len(args) == 2 { //nolint:gomnd
+				dir = args[1]
+			}
+			files, err := module.ScaffoldModule(dir, module.ScaffoldOptions{
+				Name:  args[0],
+				Image: image,
+			})
+			if err != nil {
+				return err
+			}
+			for _, file := range files {
+				fmt.Fprintf(cmd.OutOrStdout(), "created %s\n", filepath.Join(dir, file))
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "\ntry it with\n    cd %s && lilypad module run-local --build\n", dir)
+			return nil
+		},
+	}
+	initCmd.Flags().StringVar(&image, "image", image, "The image the module runs, defaults to <name>:latest")
+
+	return initCmd
+}
+
+type moduleRunLocalOptions struct {
+	Runtime    string
+	Wasmtime   string
+	Build      bool
+	Inputs     map[string]string
+	InputsFile string
+	Env        map[string]string
+}
+
+func newModuleRunLocalCmd() *cobra.Command {
+	options := moduleRunLocalOptions{
+		Runtime:  container.RUNTIME_DOCKER,
+		Wasmtime: wasm.DEFAULT_WASMTIME,
+		Inputs:   map[string]string{},
+		Env:      map[string]string{},
+	}
+
+	runLocalCmd := &cobra.Command{
+		Use:   "run-local [dir]",
+		Short: "Run a module from a local folder the way a resource provider would.",
+		Long: "Load the module template in a local folder and run it on docker, podman or wasmtime with the same checks, environment and output " +
+			"folders a resource provider uses. Nothing is sent to the network and the results are left on disk.",
+		Example: "lilypad module run-local ./hello-world --build -i Message=world",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "."
+			if len(args) == 1 {
+				dir = args[0]
+			}
+			return runModuleLocal(cmd, dir, options)
+		},
+	}
+	runLocalCmd.Flags().StringVar(&options.Runtime, "runtime", options.Runtime, "The container runtime to run the module on, docker or podman")
+	runLocalCmd.Flags().StringVar(&options.Wasmtime, "wasmtime", options.Wasmtime, "The wasmtime binary to run wasm modules with")
+	runLocalCmd.Flags().BoolVar(&options.Build, "build", options.Build, "Build the Dockerfile in the folder as the module image first")
+	runLocalCmd.Flags().StringToStringVarP(&options.Inputs, "input", "i", options.Inputs, "Input key-value pairs, added on top of the inputs file")
+	runLocalCmd.Flags().StringVar(&options.InputsFile, "inputs-file", options.InputsFile,
+		fmt.Sprintf("A JSON file of inputs, defaults to %s in the folder if there is one", module.SCAFFOLD_INPUTS_FILE))
+	runLocalCmd.Flags().StringToStringVar(&options.Env, "env", options.Env, "Environment variables to run the job container with e.g. SEED=42")
+
+	return runLocalCmd
+}
+
+func loadModuleRunLocalInputs(dir string, options moduleRunLocalOptions) (map[string]string, error) {
+	inputs := map[string]string{}
+	inputsFile := options.InputsFile
+	if inputsFile == "" {
+		inputsFile = filepath.Join(dir, module.SCAFFOLD_INPUTS_FILE)
+		if _, err := os.Stat(inputsFile); errors.Is(err, fs.ErrNotExist) {
+			inputsFile = ""
+		}
+	}
+	if inputsFile != "" {
+		bs, err := os.ReadFile(inputsFile)
+		if err != nil {
+			return nil, err
+		}
+		err = json.Unmarshal(bs, &inputs)
+		if err != nil {
+			return nil, fmt.Errorf("error reading inputs file %s: %s", inputsFile, err.Error())
+		}
+	}
+	for name, value := range options.Inputs {
+		inputs[name] = value
+	}
+	return inputs, nil
+}
+
+// the same steps the resource provider takes in runJob
+// less the module being fetched from git and the results published
+func runModuleLocal(cmd *cobra.Command, dir string, options moduleRunLocalOptions) error {
+	inputs, err := loadModuleRunLocalInputs(dir, options)
+	if err != nil {
+		return err
+	}
+	loadedModule, err := module.LoadLocalModule(module.GetLocalModuleTemplatePath(dir), inputs)
+	if err != nil {
+		return fmt.Errorf("error loading module: %s", err.Error())
+	}
+	deal := data.DealContainer{
+		ID: fmt.Sprintf("local-%d", time.Now().UnixMilli()),
+		Deal: data.Deal{
+			JobOffer: data.JobOffer{
+				Inputs: inputs,
+				Env:    options.Env,
+				Spec:   loadedModule.Machine,
+			},
+		},
+	}
+	deal.Deal.ID = deal.ID
+	err = module.ApplyDealEnv(loadedModule, deal.ID, options.Env, inputs)
+	if err != nil {
+		return err
+	}
+
+	isWasm := module.GetModuleEngine(*loadedModule) == data.ModuleEngineWasm
+	if options.Build && !isWasm {
+		docker, err := container.GetDockerSpec(loadedModule.Job.Spec)
+		if err != nil {
+			return err
+		}
+		build := exec.Command(options.Runtime, "build", "--tag", docker.Image, dir)
+		build.Stdout = cmd.ErrOrStderr()
+		build.Stderr = cmd.ErrOrStderr()
+		err = build.Run()
+		if err != nil {
+			return fmt.Errorf("error building %s: %s", docker.Image, err.Error())
+		}
+	}
+
+	var executor executorlib.LogStreamingExecutor
+	if isWasm {
+		executor, err = wasm.NewWasmExecutor(wasm.WasmExecutorOptions{
+			Wasmtime:  options.Wasmtime,
+			LocalOnly: true,
+		}, nil)
+	} else {
+		executor, err = container.NewContainerExecutor(container.ContainerExecutorOptions{
+			Runtime:   options.Runtime,
+			LocalOnly: true,
+		}, nil)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = executor.IsAvailable()
+	if err != nil {
+		return err
+	}
+	results, err := executor.RunJobWithLogs(deal, *loadedModule, func(stream string, chunk []byte) {
+		if stream == data.DealLogStderr {
+			cmd.ErrOrStderr().Write(chunk) //nolint:errcheck
+			return
+		}
+		cmd.OutOrStdout().Write(chunk) //nolint:errcheck
+	})
+	if err != nil {
+		code := data.GetErrorCode(err)
+		if code == data.ErrInvalidRequest {
+			return fmt.Errorf("the module refused the inputs: %s", err.Error())
+		}
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "\n🍂 Module ran, the results are in\n    %s\n", results.ResultsDir)
+	manifestPath := filepath.Join(results.ResultsDir, module.CONTRACT_OUTPUTS_NAME, module.CONTRACT_RESULT_MANIFEST)
+	if manifest, err := os.ReadFile(manifestPath); err == nil {
+		fmt.Fprintf(cmd.OutOrStdout(), "    %s\n", strings.TrimSpace(string(manifest)))
+	}
+	return nil
+}
+
+type moduleSubmitOptions struct {
+	moduleRunLocalOptions
+	module.SubmitOptions
+	SkipRun   bool
+	Output    string
+	Allowlist string
+}
+
+func newModuleSubmitCmd() *cobra.Command {
+	options := moduleSubmitOptions{
+		moduleRunLocalOptions: moduleRunLocalOptions{
+			Runtime:  container.RUNTIME_DOCKER,
+			Wasmtime: wasm.DEFAULT_WASMTIME,
+			Inputs:   map[string]string{},
+			Env:      map[string]string{},
+		},
+	}
+
+	submitCmd := &cobra.Command{
+		Use:   "submit [dir]",
+		Short: "Check a module is ready for the allowlist and write its entry.",
+		Long: "Check the module template and the resources it declares, check the checked out commit is a pinned release, " +
+			"run the module locally and write the allowlist entry for it with its module ID.",
+		Example: "lilypad module submit ./hello-world --allowlist ./allowlist.json",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "."
+			if len(args) == 1 {
+				dir = args[0]
+			}
+			return runModuleSubmit(cmd, dir, options)
+		},
+	}
+	submitCmd.Flags().StringVar(&options.Repo, "repo", options.Repo, "The http url of the module repo, defaults to the origin remote")
+	submitCmd.Flags().StringVar(&options.Version, "version", options.Version, "The release tag or commit to list, defaults to the release tag on the checked out commit")
+	submitCmd.Flags().StringVar(&options.ImageDigest, "image-digest", options.ImageDigest, "The digest of the reviewed module image e.g. sha256:...")
+	submitCmd.Flags().IntVar(&options.MaxRuntime, "max-runtime", options.MaxRuntime, "The default max runtime for jobs using the module (seconds)")
+	submitCmd.Flags().StringSliceVar(&options.Verifiers, "verifier", options.Verifiers, "The solver result verifiers to run for the module")
+	submitCmd.Flags().BoolVar(&options.SkipRun, "skip-run", options.SkipRun, "Do not run the module locally")
+	submitCmd.Flags().StringVar(&options.Output, "output", options.Output, "Write the allowlist entry to this file rather than stdout")
+	submitCmd.Flags().StringVar(&options.Allowlist, "allowlist", options.Allowlist, "Add the entry to this allowlist file")
+	submitCmd.Flags().StringVar(&options.Runtime, "runtime", options.Runtime, "The container runtime to run the module on, docker or podman")
+	submitCmd.Flags().StringVar(&options.Wasmtime, "wasmtime", options.Wasmtime, "The wasmtime binary to run wasm modules with")
+	submitCmd.Flags().BoolVar(&options.Build, "build", options.Build, "Build the Dockerfile in the folder as the module image first")
+	submitCmd.Flags().StringToStringVarP(&options.Inputs, "input", "i", options.Inputs, "Input key-value pairs, added on top of the inputs file")
+	submitCmd.Flags().StringVar(&options.InputsFile, "inputs-file", options.InputsFile,
+		fmt.Sprintf("A JSON file of inputs, defaults to %s in the folder if there is one", module.SCAFFOLD_INPUTS_FILE))
+	submitCmd.Flags().StringToStringVar(&options.Env, "env", options.Env, "Environment variables to run the job container with e.g. SEED=42")
+
+	return submitCmd
+}
+
+func runModuleSubmit(cmd *cobra.Command, dir string, options moduleSubmitOptions) error {
+	inputs, err := loadModuleRunLocalInputs(dir, options.moduleRunLocalOptions)
+	if err != nil {
+		return err
+	}
+	loadedModule, err := module.LoadLocalModule(module.GetLocalModuleTemplatePath(dir), inputs)
+	if err != nil {
+		return fmt.Errorf("error loading module: %s", err.Error())
+	}
+	err = errors.Join(module.CheckModuleSubmission(*loadedModule), checkModuleResources(*loadedModule))
+	if err != nil {
+		return fmt.Errorf("the module is not ready for the allowlist:\n%s", err.Error())
+	}
+	checkout, err := module.GetModuleCheckout(dir, options.SubmitOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "✅ %s %s at %s\n", checkout.Repo, checkout.Version, checkout.Path)
+
+	if !options.SkipRun {
+		err = runModuleLocal(cmd, dir, options.moduleRunLocalOptions)
+		if err != nil {
+			return fmt.Errorf("the module did not run: %s", err.Error())
+		}
+	}
+
+	entry, err := module.GetAllowlistEntry(*loadedModule, checkout, options.SubmitOptions)
+	if err != nil {
+		return err
+	}
+	if options.Allowlist != "" {
+		err = addAllowlistEntry(options.Allowlist, entry)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "added %s to %s\n", entry.ModuleID, options.Allowlist)
+	}
+	bs, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	if options.Output != "" {
+		return os.WriteFile(options.Output, append(bs, '\n'), 0644) //nolint:gomnd
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), string(bs))
+	return nil
+}
+
+// the container a job runs in has to fit on the machine the module asks for
+// or a resource provider matched on the machine spec could not run it
+func checkModuleResources(loadedModule data.Module) error {
+	resources := loadedModule.Job.Spec.Resources
+	machine := loadedModule.Machine
+	problems := []error{}
+	cpu, err := container.ParseCPU(resources.CPU)
+	if err != nil {
+		problems = append(problems, err)
+	} else if int(cpu*1000) > machine.CPU { //nolint:gomnd
+		problems = append(problems, fmt.Errorf("the job asks for %s cpu but machine declares %d milli-cpu", resources.CPU, machine.CPU))
+	}
+	memory, err := container.ParseMemory(resources.Memory)
+	if err != nil {
+		problems = append(problems, err)
+	} else if memory > int64(machine.RAM)<<20 { //nolint:gomnd
+		problems = append(problems, fmt.Errorf("the job asks for %s memory but machine declares %dMB", resources.Memory, machine.RAM))
+	}
+	if resources.GPU != "" {
+		gpu, err := strconv.Atoi(resources.GPU)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("error parsing gpu %q: %s", resources.GPU, err.Error()))
+		} else if gpu*1000 > machine.GPU { //nolint:gomnd
+			problems = append(problems, fmt.Errorf("the job asks for %d gpu but machine declares %d milli-gpu", gpu, machine.GPU))
+		}
+	}
+	return errors.Join(problems...)
+}
+
+// append the entry to an allowlist file that has to stay valid afterwards
+func addAllowlistEntry(path string, entry data.AllowlistItem) error {
+	allowlist, err := solver.LoadAllowlist(path)
+	if err != nil {
+		return err
+	}
+	if _, ok := allowlist[entry.ModuleID]; ok {
+		return fmt.Errorf("module %s is already in %s", entry.ModuleID, path)
+	}
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	items := []data.AllowlistItem{}
+	err = json.Unmarshal(bs, &items)
+	if err != nil {
+		return err
+	}
+	bs, err = json.MarshalIndent(append(items, entry), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(bs, '\n'), 0644) //nolint:gomnd
+}
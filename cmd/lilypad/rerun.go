@@ -0,0 +1,83 @@
+package lilypad
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/jobcreator"
+	optionsfactory "github.com/lilypad-tech/lilypad/pkg/options"
+	"github.com/spf13/cobra"
+)
+
+func newRerunCmd() *cobra.Command {
+	options := optionsfactory.NewJobCreatorOptions()
+	sameProvider := false
+
+	rerunCmd := &cobra.Command{
+		Use:     "rerun <deal-id>",
+		Short:   "Run the job of a deal again.",
+		Long:    "Add a job offer with the same module and inputs as a deal. The deal it makes records the deal it came from so retries can be traced with lilypad lineage.",
+		Example: "lilypad rerun 1a2b3c... --same-provider",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJobCreatorCommand(cmd, options, func(jobCreator *jobcreator.JobCreator) error {
+				jobOffer, err := jobCreator.RerunDeal(args[0], sameProvider)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "job offer %s runs deal %s again\n", jobOffer.ID, args[0])
+				return nil
+			})
+		},
+	}
+	optionsfactory.AddJobCreatorCliFlags(rerunCmd, &options)
+	rerunCmd.Flags().BoolVar(&sameProvider, "same-provider", sameProvider, "Only run the job on the resource provider that ran the deal")
+
+	return rerunCmd
+}
+
+func newLineageCmd() *cobra.Command {
+	options := optionsfactory.NewJobCreatorOptions()
+
+	lineageCmd := &cobra.Command{
+		Use:   "lineage <deal-id>",
+		Short: "Show the deals a deal was run again from and the deals that ran it again.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJobCreatorCommand(cmd, options, func(jobCreator *jobcreator.JobCreator) error {
+				lineage, err := jobCreator.GetDealLineage(args[0])
+				if err != nil {
+					return err
+				}
+				printDealLineage(cmd, lineage)
+				return nil
+			})
+		},
+	}
+	optionsfactory.AddJobCreatorCliFlags(lineageCmd, &options)
+
+	return lineageCmd
+}
+
+func printDealLineage(cmd *cobra.Command, lineage data.DealLineage) {
+	writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "DEAL\tPARENT\tRESOURCE PROVIDER\tSTATE")
+	printDeal := func(deal data.DealContainer, marker string) {
+		fmt.Fprintf(writer, "%s%s\t%s\t%s\t%s\n",
+			deal.ID,
+			marker,
+			orDash(deal.ParentDealID),
+			deal.ResourceProvider,
+			data.GetAgreementStateString(deal.State),
+		)
+	}
+	for _, deal := range lineage.Ancestors {
+		printDeal(deal, "")
+	}
+	printDeal(lineage.Deal, " *")
+	for _, deal := range lineage.Descendants {
+		printDeal(deal, "")
+	}
+	writer.Flush()
+}
@@ -0,0 +1,165 @@
+package lilypad
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/jobcreator"
+	optionsfactory "github.com/lilypad-tech/lilypad/pkg/options"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+	"github.com/spf13/cobra"
+)
+
+func newReservationCmd() *cobra.Command {
+	options := optionsfactory.NewJobCreatorOptions()
+
+	reservationCmd := &cobra.Command{
+		Use:   "reservation",
+		Short: "Reserve the capacity of a resource provider for a future window.",
+		Long:  "Reserve resource offers of a resource provider between two times. The solver holds them from other jobs and adds one job offer for each of them at the start time. The job offers are agreed to by a running lilypad jobcreator with the same key.",
+	}
+	optionsfactory.AddJobCreatorCliFlags(reservationCmd, &options)
+
+	var resourceProvider string
+	var count int
+	var start string
+	var end string
+	addCmd := &cobra.Command{
+		Use:     "add <module>",
+		Short:   "Reserve capacity for a module.",
+		Example: "lilypad reservation add sdxl:v0.9 --resource-provider 0x... --count 8 --start 2026-01-02T09:00:00Z --end 2026-01-02T17:00:00Z",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			network, _ := cmd.Flags().GetString("network")
+			options, err := optionsfactory.ProcessJobCreatorOptions(options, args, network)
+			if err != nil {
+				return err
+			}
+			startAt, err := time.Parse(time.RFC3339, start)
+			if err != nil {
+				return fmt.Errorf("invalid start time %s: %s", start, err.Error())
+			}
+			endAt, err := time.Parse(time.RFC3339, end)
+			if err != nil {
+				return fmt.Errorf("invalid end time %s: %s", end, err.Error())
+			}
+			// the solver adds the jobs so there is nobody to send the inputs
+			if len(options.Offer.EncryptedInputs) > 0 {
+				return fmt.Errorf("reserved jobs cannot have encrypted inputs")
+			}
+			return runReservationAdd(cmd, options, network, resourceProvider, count, startAt.Unix(), endAt.Unix())
+		},
+	}
+	addCmd.Flags().StringVar(&resourceProvider, "resource-provider", "", "The address of the resource provider to reserve.")
+	addCmd.Flags().IntVar(&count, "count", 1, "The number of resource offers to reserve.")
+	addCmd.Flags().StringVar(&start, "start", "", "When the reservation starts as an RFC3339 time.")
+	addCmd.Flags().StringVar(&end, "end", "", "When the reservation ends as an RFC3339 time.")
+	addCmd.PersistentFlags().StringVarP(
+		&options.SpecFile, "file", "f", options.SpecFile,
+		`A YAML or JSON file with the module, inputs, env, resources, pricing and placement of the job (JOB_SPEC_FILE).`,
+	)
+	reservationCmd.AddCommand(addCmd)
+
+	var open bool
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List your capacity reservations.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runJobCreatorCommand(cmd, options, func(jobCreator *jobcreator.JobCreator) error {
+				reservations, err := jobCreator.GetCapacityReservations(open)
+				if err != nil {
+					return err
+				}
+				printCapacityReservations(cmd, reservations)
+				return nil
+			})
+		},
+	}
+	listCmd.Flags().BoolVar(&open, "open", false, "Only list reservations that are pending or active.")
+	reservationCmd.AddCommand(listCmd)
+
+	reservationCmd.AddCommand(&cobra.Command{
+		Use:   "get <id>",
+		Short: "Show a capacity reservation and the job offers it added.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJobCreatorCommand(cmd, options, func(jobCreator *jobcreator.JobCreator) error {
+				reservation, err := jobCreator.GetCapacityReservation(args[0])
+				if err != nil {
+					return err
+				}
+				printCapacityReservations(cmd, []data.CapacityReservation{reservation})
+				for _, id := range reservation.JobOffers {
+					fmt.Fprintf(cmd.OutOrStdout(), "job offer %s\n", id)
+				}
+				return nil
+			})
+		},
+	})
+	reservationCmd.AddCommand(&cobra.Command{
+		Use:   "cancel <id>",
+		Short: "Cancel a capacity reservation. Its jobs that already have a deal are left to finish.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJobCreatorCommand(cmd, options, func(jobCreator *jobcreator.JobCreator) error {
+				reservation, err := jobCreator.CancelCapacityReservation(args[0])
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "cancelled capacity reservation %s\n", reservation.ID)
+				return nil
+			})
+		},
+	})
+
+	return reservationCmd
+}
+
+func runReservationAdd(cmd *cobra.Command, options jobcreator.JobCreatorOptions, network string, resourceProvider string, count int, startAt int64, endAt int64) error {
+	commandCtx := system.NewCommandContext(cmd)
+	defer commandCtx.Cleanup()
+
+	jobCreator, err := newCommandJobCreator(commandCtx, options, network)
+	if err != nil {
+		return err
+	}
+	offer, err := jobCreator.GetJobOfferFromOptions(options.Offer)
+	if err != nil {
+		return err
+	}
+	reservation, err := jobCreator.AddCapacityReservation(offer, resourceProvider, count, startAt, endAt)
+	if err != nil {
+		return err
+	}
+	printCapacityReservations(cmd, []data.CapacityReservation{reservation})
+	return nil
+}
+
+func printCapacityReservations(cmd *cobra.Command, reservations []data.CapacityReservation) {
+	formatTime := func(ts int64) string {
+		return time.Unix(ts, 0).UTC().Format(time.RFC3339)
+	}
+	writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ID\tMODULE\tRESOURCE PROVIDER\tCOUNT\tSTART\tEND\tSTATE\tJOBS\tERROR")
+	for _, reservation := range reservations {
+		module := reservation.JobOffer.Module.Name
+		if module == "" {
+			module = reservation.JobOffer.Module.Repo
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%d\t%s\t%s\t%s\t%d\t%s\n",
+			reservation.ID,
+			module,
+			reservation.ResourceProvider,
+			reservation.Count,
+			formatTime(reservation.StartAt),
+			formatTime(reservation.EndAt),
+			reservation.State,
+			len(reservation.JobOffers),
+			orDash(reservation.Error),
+		)
+	}
+	writer.Flush()
+}
@@ -3,7 +3,6 @@ package lilypad
 import (
 	"fmt"
 
-	"github.com/lilypad-tech/lilypad/pkg/executor/bacalhau"
 	"github.com/lilypad-tech/lilypad/pkg/ipfs"
 	optionsfactory "github.com/lilypad-tech/lilypad/pkg/options"
 	"github.com/lilypad-tech/lilypad/pkg/resourceprovider"
@@ -58,7 +57,7 @@ func runResourceProvider(cmd *cobra.Command, options resourceprovider.ResourcePr
 		return fmt.Errorf("error creating IPFS client: %s", err.Error())
 	}
 
-	executor, err := bacalhau.NewBacalhauExecutor(options.Bacalhau, ipfsClient)
+	executor, err := resourceprovider.NewExecutor(options, ipfsClient)
 	if err != nil {
 		return err
 	}
@@ -32,6 +32,17 @@ func NewRootCmd() *cobra.Command {
 	RootCmd.AddCommand(newRunCmd())
 	RootCmd.AddCommand(newMediatorCmd())
 	RootCmd.AddCommand(newJobCreatorCmd())
+	RootCmd.AddCommand(newAllowlistCmd())
+	RootCmd.AddCommand(newScheduleCmd())
+	RootCmd.AddCommand(newReservationCmd())
+	RootCmd.AddCommand(newWorkflowCmd())
+	RootCmd.AddCommand(newStageCmd())
+	RootCmd.AddCommand(newBillingCmd())
+	RootCmd.AddCommand(newMilestoneCmd())
+	RootCmd.AddCommand(newRerunCmd())
+	RootCmd.AddCommand(newLineageCmd())
+	RootCmd.AddCommand(newServiceRequestCmd())
+	RootCmd.AddCommand(newModuleCmd())
 	RootCmd.AddCommand(newVersionCmd())
 	return RootCmd
 }
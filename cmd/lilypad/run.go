@@ -4,18 +4,22 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"sort"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/fiat"
 	"github.com/lilypad-tech/lilypad/pkg/jobcreator"
 	optionsfactory "github.com/lilypad-tech/lilypad/pkg/options"
 	"github.com/lilypad-tech/lilypad/pkg/solver"
 	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/theckman/yacspin"
 )
@@ -26,7 +30,7 @@ func newRunCmd() *cobra.Command {
 		Use:     "run",
 		Short:   "Run a job on the Lilypad network.",
 		Long:    "Run a job on the Lilypad network.",
-		Example: "run cowsay:v0.0.1 -i Message=moo",
+		Example: "run cowsay:v0.0.1 -i Message=moo\n  run -f job.yaml -i Message=moo\n  run cowsay:v0.0.1 --array Message=moo,baa,oink",
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			network, _ := cmd.Flags().GetString("network")
@@ -39,6 +43,7 @@ func newRunCmd() *cobra.Command {
 	}
 
 	optionsfactory.AddJobCreatorCliFlags(runCmd, &options)
+	optionsfactory.AddJobCreatorRunCliFlags(runCmd, &options)
 
 	return runCmd
 }
@@ -92,34 +97,42 @@ func runJob(cmd *cobra.Command, options jobcreator.JobCreatorOptions, network st
 	commandCtx.Cm.RegisterCallbackWithContext(telemetry.Shutdown)
 	tracer := telemetry.TracerProvider.Tracer(system.GetOTelServiceName(system.JobCreatorService))
 
+	if options.Offer.Array != "" {
+		return runJobGroup(commandCtx, options, tracer)
+	}
+
+	var logSub jobcreator.DealLogSubscriber
+	if options.Watch {
+		logSub = printDealLog
+	}
+
+	// updates come in on their own goroutines
+	var watchMutex sync.Mutex
+	dealID := ""
+	serviceAnnounced := false
 	result, err := jobcreator.RunJob(commandCtx, options, tracer, func(evOffer data.JobOfferContainer) {
-		spinner.Stop()
-		st := data.GetAgreementStateString(evOffer.State)
-		var desc string
-		var emoji string
-		switch st {
-		case "DealNegotiating":
-			desc = "Job submitted. Negotiating deal..."
-			emoji = "🤝"
-		case "DealAgreed":
-			desc = "Deal agreed. Running job..."
-			emoji = "💌"
-		case "ResultsSubmitted":
-			desc = "Results submitted. Awaiting verification..."
-			emoji = "🤔"
-		case "ResultsAccepted":
-			desc = "Results accepted. Downloading result..."
-			emoji = "✅"
-		case "ResultsRejected":
-			desc = "Results rejected! Getting refund..."
-			emoji = "🙀"
-		case "JobOfferCancelled":
-			desc = "Job cancelled..."
-			emoji = "😭"
-		default:
-			desc = st
-			emoji = "🌟"
+		desc, emoji := describeJobOfferState(data.GetAgreementStateString(evOffer.State))
+		if options.Offer.Serve.Port != 0 && evOffer.State == data.GetAgreementStateIndex("DealAgreed") {
+			watchMutex.Lock()
+			if !serviceAnnounced {
+				serviceAnnounced = true
+				fmt.Printf("\n🛎️  The service is starting, send it requests with\n    lilypad service-request %s --path / --data ...\n", evOffer.DealID)
+			}
+			watchMutex.Unlock()
+		}
+		if options.Watch {
+			watchMutex.Lock()
+			defer watchMutex.Unlock()
+			// the logs are printed in between so there is no spinner
+			if evOffer.DealID != "" && evOffer.DealID != dealID {
+				dealID = evOffer.DealID
+				fmt.Printf("%s 🔗 Deal %s\n", time.Now().Format(time.TimeOnly), dealID)
+			}
+			fmt.Printf("%s %s %s\n", time.Now().Format(time.TimeOnly), emoji, desc)
+			return
 		}
+
+		spinner.Stop()
 		spinner, err = createSpinner(desc, emoji)
 		if err != nil {
 			fmt.Printf("failed to make spinner from config struct: %v\n", err)
@@ -136,21 +149,105 @@ func runJob(cmd *cobra.Command, options jobcreator.JobCreatorOptions, network st
 		// fmt.Printf("evOffer: %s --------------------------------------\n")
 		// spew.Dump(evOffer)
 
-	})
+	}, logSub)
 	if err != nil {
 		fmt.Printf("Error: %s", err)
 		return err
 	}
 	spinner.Stop()
+	if result.JobOffer.ReusedResult {
+		fmt.Printf("\n♻️  Reused the result of deal %s which ran the same module version on the same inputs\n", result.JobOffer.DealID)
+	}
 	fmt.Printf("\n🍂 Lilypad job completed, try 👇\n    open %s\n    cat %s/stdout\n    cat %s/stderr\n    https://ipfs.io/ipfs/%s\n",
 		solver.GetDownloadsFilePath(result.JobOffer.DealID),
 		solver.GetDownloadsFilePath(result.JobOffer.DealID),
 		solver.GetDownloadsFilePath(result.JobOffer.DealID),
 		result.Result.DataID,
 	)
+	if options.Fiat.Enabled() {
+		printFiatCost(fiat.NewConverter(options.Fiat), result.JobOffer.JobOffer, result.Result)
+	}
 	return err
 }
 
+// a rough idea of what the job cost, the oracle is only a guide
+func printFiatCost(converter *fiat.Converter, jobOffer data.JobOffer, result data.Result) {
+	prices, err := converter.GetFiatPrices(jobOffer.Pricing, jobOffer.PaymentToken)
+	if err != nil {
+		log.Debug().Msgf("no fiat prices: %s", err)
+		return
+	}
+	cost := jobOffer.Pricing.InstructionPrice * result.InstructionCount
+	fmt.Printf("\n💵 The job cost %d tokens, about %.2f %s at %s\n",
+		cost,
+		float64(cost)*prices.Rate,
+		prices.Currency,
+		time.Unix(prices.RateUpdatedAt, 0).Format(time.RFC3339),
+	)
+}
+
+// array jobs print a line each time the count of their jobs in each state changes
+func runJobGroup(commandCtx *system.CommandContext, options jobcreator.JobCreatorOptions, tracer trace.Tracer) error {
+	lastLine := ""
+	result, err := jobcreator.RunJobGroup(commandCtx, options, tracer, func(status data.JobGroupStatus) {
+		states := []string{}
+		for state, count := range status.States {
+			states = append(states, fmt.Sprintf("%s %d", state, count))
+		}
+		sort.Strings(states)
+		line := fmt.Sprintf("%d jobs: %s", len(status.Group.JobOffers), strings.Join(states, ", "))
+		if line == lastLine {
+			return
+		}
+		if lastLine == "" {
+			fmt.Printf("📚 Job group %s\n", status.Group.ID)
+		}
+		lastLine = line
+		fmt.Printf("%s %s\n", time.Now().Format(time.TimeOnly), line)
+	})
+	if err != nil {
+		fmt.Printf("Error: %s", err)
+		return err
+	}
+	fmt.Printf("\n🍂 Lilypad job group completed, %d of %d jobs have results\n", len(result.Results), len(result.Group.Group.JobOffers))
+	for _, jobResult := range result.Results {
+		fmt.Printf("    %s=%s    %s\n",
+			result.Group.Group.Parameter,
+			jobResult.JobOffer.JobOffer.Inputs[result.Group.Group.Parameter],
+			solver.GetDownloadsFilePath(jobResult.JobOffer.DealID),
+		)
+	}
+	return nil
+}
+
+func describeJobOfferState(st string) (string, string) {
+	switch st {
+	case "DealNegotiating":
+		return "Job submitted. Negotiating deal...", "🤝"
+	case "DealAgreed":
+		return "Deal agreed. Running job...", "💌"
+	case "ResultsSubmitted":
+		return "Results submitted. Awaiting verification...", "🤔"
+	case "ResultsAccepted":
+		return "Results accepted. Downloading result...", "✅"
+	case "ResultsRejected":
+		return "Results rejected! Getting refund...", "🙀"
+	case "JobOfferCancelled":
+		return "Job cancelled...", "😭"
+	default:
+		return st, "🌟"
+	}
+}
+
+// the job's own output goes to ours as it comes in
+func printDealLog(chunk data.DealLogChunk) {
+	if chunk.Stream == data.DealLogStderr {
+		fmt.Fprint(os.Stderr, chunk.Data)
+		return
+	}
+	fmt.Print(chunk.Data)
+}
+
 func createSpinner(message string, emoji string) (*yacspin.Spinner, error) {
 	// build the configuration, each field is documented
 	cfg := yacspin.Config{
@@ -0,0 +1,196 @@
+package lilypad
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/jobcreator"
+	optionsfactory "github.com/lilypad-tech/lilypad/pkg/options"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+	"github.com/lilypad-tech/lilypad/pkg/web3"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func newScheduleCmd() *cobra.Command {
+	options := optionsfactory.NewJobCreatorOptions()
+
+	scheduleCmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Manage recurring jobs run by the solver.",
+		Long:  "Manage jobs the solver adds again at each tick of a cron expression. The job offers are agreed to by a running lilypad jobcreator with the same key.",
+	}
+	optionsfactory.AddJobCreatorCliFlags(scheduleCmd, &options)
+
+	addCmd := &cobra.Command{
+		Use:     "add <module>",
+		Short:   "Add a recurring job.",
+		Example: "lilypad schedule add cowsay:v0.0.4 -i Message=moo --cron \"0 * * * *\"\n  lilypad schedule add -f job.yaml --cron @daily --overlap-policy replace",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			network, _ := cmd.Flags().GetString("network")
+			options, err := optionsfactory.ProcessJobCreatorOptions(options, args, network)
+			if err != nil {
+				return err
+			}
+			err = optionsfactory.CheckJobCreatorScheduleOptions(options.Schedule)
+			if err != nil {
+				return err
+			}
+			// the solver adds the jobs so there is nobody to send the inputs
+			if len(options.Offer.EncryptedInputs) > 0 {
+				return fmt.Errorf("recurring jobs cannot have encrypted inputs")
+			}
+			return runScheduleAdd(cmd, options, network)
+		},
+	}
+	optionsfactory.AddJobCreatorScheduleCliFlags(addCmd, &options.Schedule)
+	addCmd.PersistentFlags().StringVarP(
+		&options.SpecFile, "file", "f", options.SpecFile,
+		`A YAML or JSON file with the module, inputs, env, resources, pricing and placement of the job (JOB_SPEC_FILE).`,
+	)
+	scheduleCmd.AddCommand(addCmd)
+
+	scheduleCmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List your recurring jobs.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runJobCreatorCommand(cmd, options, func(jobCreator *jobcreator.JobCreator) error {
+				return runScheduleList(cmd, jobCreator)
+			})
+		},
+	})
+	scheduleCmd.AddCommand(newScheduleUpdateCmd(&options, "pause", "Stop adding job offers for a recurring job until it is resumed.", true))
+	scheduleCmd.AddCommand(newScheduleUpdateCmd(&options, "resume", "Start adding job offers for a paused recurring job from the next tick.", false))
+	scheduleCmd.AddCommand(&cobra.Command{
+		Use:   "remove <id>",
+		Short: "Remove a recurring job. The jobs it already added are left to finish.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJobCreatorCommand(cmd, options, func(jobCreator *jobcreator.JobCreator) error {
+				schedule, err := jobCreator.RemoveJobSchedule(args[0])
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "removed recurring job %s\n", schedule.ID)
+				return nil
+			})
+		},
+	})
+
+	return scheduleCmd
+}
+
+func newScheduleUpdateCmd(options *jobcreator.JobCreatorOptions, use string, short string, paused bool) *cobra.Command {
+	return &cobra.Command{
+		Use:   fmt.Sprintf("%s <id>", use),
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJobCreatorCommand(cmd, *options, func(jobCreator *jobcreator.JobCreator) error {
+				schedule, err := jobCreator.UpdateJobSchedule(args[0], data.JobScheduleUpdate{
+					Paused: &paused,
+				})
+				if err != nil {
+					return err
+				}
+				printJobSchedules(cmd, []data.JobSchedule{schedule})
+				return nil
+			})
+		},
+	}
+}
+
+// commands that only talk to the solver need the key but not a module
+func runJobCreatorCommand(cmd *cobra.Command, options jobcreator.JobCreatorOptions, handler func(jobCreator *jobcreator.JobCreator) error) error {
+	network, _ := cmd.Flags().GetString("network")
+	options, err := optionsfactory.ProcessOnChainJobCreatorOptions(options, []string{}, network)
+	if err != nil {
+		return err
+	}
+	commandCtx := system.NewCommandContext(cmd)
+	defer commandCtx.Cleanup()
+
+	jobCreator, err := newCommandJobCreator(commandCtx, options, network)
+	if err != nil {
+		return err
+	}
+	return handler(jobCreator)
+}
+
+func newCommandJobCreator(commandCtx *system.CommandContext, options jobcreator.JobCreatorOptions, network string) (*jobcreator.JobCreator, error) {
+	telemetry, err := configureTelemetry(commandCtx.Ctx, system.JobCreatorService, network, options.Telemetry, options.Web3)
+	if err != nil {
+		log.Warn().Msgf("failed to setup opentelemetry: %s", err)
+	}
+	commandCtx.Cm.RegisterCallbackWithContext(telemetry.Shutdown)
+	tracer := telemetry.TracerProvider.Tracer(system.GetOTelServiceName(system.JobCreatorService))
+
+	web3SDK, err := web3.NewContractSDK(commandCtx.Ctx, options.Web3, tracer)
+	if err != nil {
+		return nil, err
+	}
+	return jobcreator.NewJobCreator(options, web3SDK, tracer)
+}
+
+func runScheduleAdd(cmd *cobra.Command, options jobcreator.JobCreatorOptions, network string) error {
+	commandCtx := system.NewCommandContext(cmd)
+	defer commandCtx.Cleanup()
+
+	jobCreator, err := newCommandJobCreator(commandCtx, options, network)
+	if err != nil {
+		return err
+	}
+	offer, err := jobCreator.GetJobOfferFromOptions(options.Offer)
+	if err != nil {
+		return err
+	}
+	schedule, err := jobCreator.AddJobSchedule(offer, options.Schedule)
+	if err != nil {
+		return err
+	}
+	printJobSchedules(cmd, []data.JobSchedule{schedule})
+	return nil
+}
+
+func runScheduleList(cmd *cobra.Command, jobCreator *jobcreator.JobCreator) error {
+	schedules, err := jobCreator.GetJobSchedules()
+	if err != nil {
+		return err
+	}
+	printJobSchedules(cmd, schedules)
+	return nil
+}
+
+func printJobSchedules(cmd *cobra.Command, schedules []data.JobSchedule) {
+	formatTime := func(ts int64) string {
+		if ts == 0 {
+			return "-"
+		}
+		return time.Unix(ts, 0).UTC().Format(time.RFC3339)
+	}
+	writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ID\tMODULE\tCRON\tOVERLAP\tPAUSED\tNEXT RUN\tLAST RUN\tRUNS\tSKIPPED\tLAST ERROR")
+	for _, schedule := range schedules {
+		module := schedule.JobOffer.Module.Name
+		if module == "" {
+			module = schedule.JobOffer.Module.Repo
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%t\t%s\t%s\t%d\t%d\t%s\n",
+			schedule.ID,
+			module,
+			schedule.Cron,
+			schedule.OverlapPolicy,
+			schedule.Paused,
+			formatTime(schedule.NextRunAt),
+			formatTime(schedule.LastRunAt),
+			schedule.Runs,
+			schedule.Skipped,
+			orDash(schedule.LastError),
+		)
+	}
+	writer.Flush()
+}
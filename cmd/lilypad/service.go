@@ -0,0 +1,67 @@
+package lilypad
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/jobcreator"
+	optionsfactory "github.com/lilypad-tech/lilypad/pkg/options"
+	"github.com/spf13/cobra"
+)
+
+func newServiceRequestCmd() *cobra.Command {
+	options := optionsfactory.NewJobCreatorOptions()
+	method := "POST"
+	path := "/"
+	body := ""
+	bodyFile := ""
+	headers := map[string]string{}
+
+	serviceRequestCmd := &cobra.Command{
+		Use:     "service-request <deal-id>",
+		Short:   "Send a request to the module a service deal is running.",
+		Long:    "Send a request through the solver to the module a deal started with --service-port is running and print its answer. Each answered request is paid for at the instruction price of the deal.",
+		Example: `lilypad service-request 1a2b3c... --path /v1/completions --data '{"prompt": "hello"}'`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			request := data.ServiceRequest{
+				Method:  method,
+				Path:    path,
+				Headers: headers,
+				Body:    []byte(body),
+			}
+			if bodyFile != "" {
+				bs, err := os.ReadFile(bodyFile)
+				if err != nil {
+					return err
+				}
+				request.Body = bs
+			}
+			err := data.CheckServiceRequest(request)
+			if err != nil {
+				return err
+			}
+			return runJobCreatorCommand(cmd, options, func(jobCreator *jobcreator.JobCreator) error {
+				response, err := jobCreator.SendServiceRequest(args[0], request)
+				if err != nil {
+					return err
+				}
+				if response.Error != "" {
+					return fmt.Errorf("the service did not answer: %s", response.Error)
+				}
+				fmt.Fprintf(cmd.ErrOrStderr(), "status %d\n", response.StatusCode)
+				_, err = cmd.OutOrStdout().Write(response.Body)
+				return err
+			})
+		},
+	}
+	optionsfactory.AddJobCreatorCliFlags(serviceRequestCmd, &options)
+	serviceRequestCmd.Flags().StringVarP(&method, "method", "X", method, "The HTTP method of the request")
+	serviceRequestCmd.Flags().StringVar(&path, "path", path, "The path of the request on the service")
+	serviceRequestCmd.Flags().StringVarP(&body, "data", "d", body, "The body of the request")
+	serviceRequestCmd.Flags().StringVar(&bodyFile, "data-file", bodyFile, "Read the body of the request from this file")
+	serviceRequestCmd.Flags().StringToStringVarP(&headers, "header", "H", headers, "Headers of the request e.g. Content-Type=application/json")
+
+	return serviceRequestCmd
+}
@@ -3,6 +3,7 @@ package lilypad
 import (
 	optionsfactory "github.com/lilypad-tech/lilypad/pkg/options"
 	"github.com/lilypad-tech/lilypad/pkg/solver"
+	cachestore "github.com/lilypad-tech/lilypad/pkg/solver/store/cache"
 	memorystore "github.com/lilypad-tech/lilypad/pkg/solver/store/memory"
 	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/lilypad-tech/lilypad/pkg/web3"
@@ -30,6 +31,10 @@ func newSolverCmd() *cobra.Command {
 	}
 
 	optionsfactory.AddSolverCliFlags(solverCmd, &options)
+	solverCmd.AddCommand(newSolverDebugCmd(&options))
+	solverCmd.AddCommand(newSolverExportCmd(&options))
+	solverCmd.AddCommand(newSolverImportCmd(&options))
+	solverCmd.AddCommand(newSolverBenchCmd())
 
 	return solverCmd
 }
@@ -50,16 +55,29 @@ func runSolver(cmd *cobra.Command, options solver.SolverOptions, network string)
 		return err
 	}
 
-	solverStore, err := memorystore.NewSolverStoreMemory()
+	memoryStore, err := memorystore.NewSolverStoreMemory(options.Store)
 	if err != nil {
 		return err
 	}
+	// the matcher and the API ask for the unmatched offers over and over
+	solverStore := cachestore.NewSolverStoreCache(memoryStore)
 
 	solverService, err := solver.NewSolver(options, solverStore, web3SDK, tracer)
 	if err != nil {
 		return err
 	}
 
+	for _, chainOptions := range options.Chains.Chains {
+		chainSDK, err := web3.NewContractSDK(commandCtx.Ctx, chainOptions, tracer)
+		if err != nil {
+			return err
+		}
+		err = solverService.AddChain(chainOptions.ChainID, chainSDK)
+		if err != nil {
+			return err
+		}
+	}
+
 	solverErrors := solverService.Start(commandCtx.Ctx, commandCtx.Cm, telemetry.TracerProvider)
 
 	for {
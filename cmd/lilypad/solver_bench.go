@@ -0,0 +1,69 @@
+package lilypad
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/lilypad-tech/lilypad/pkg/solver/matcher"
+	memorystore "github.com/lilypad-tech/lilypad/pkg/solver/store/memory"
+	"github.com/spf13/cobra"
+)
+
+func newSolverBenchCmd() *cobra.Command {
+	options := matcher.BenchOptions{
+		JobOffers:      100,
+		ResourceOffers: 100,
+		Modules:        10,
+		Seed:           1,
+	}
+	benchCmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Time a matching pass over a synthetic pool of offers.",
+		Long: "Generate job and resource offers with a mix of specs, modules and prices, add them to an in-memory store and time one matching pass over them. " +
+			"Reports how many pairs were looked at each second and how many records the pass wrote to the store. The store writes its logs to a temporary directory that is removed afterwards.",
+		Example: "lilypad solver bench --job-offers 500 --resource-offers 100",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runSolverBench(cmd, options)
+		},
+	}
+	benchCmd.Flags().IntVar(&options.JobOffers, "job-offers", options.JobOffers, "The number of job offers in the pool")
+	benchCmd.Flags().IntVar(&options.ResourceOffers, "resource-offers", options.ResourceOffers, "The number of resource offers in the pool")
+	benchCmd.Flags().IntVar(&options.Modules, "modules", options.Modules, "The number of modules the offers are spread over")
+	benchCmd.Flags().Int64Var(&options.Seed, "seed", options.Seed, "The seed for the pool, the same seed makes the same pool")
+	return benchCmd
+}
+
+func runSolverBench(cmd *cobra.Command, options matcher.BenchOptions) error {
+	logDir, err := os.MkdirTemp("", "lilypad-bench")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(logDir)
+	db, err := memorystore.NewSolverStoreMemory(memorystore.SolverStoreMemoryOptions{LogDir: logDir})
+	if err != nil {
+		return err
+	}
+	result, err := matcher.RunBench(cmd.Context(), db, options)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "job offers:      %d\n", result.JobOffers)
+	fmt.Fprintf(out, "resource offers: %d\n", result.ResourceOffers)
+	fmt.Fprintf(out, "deals:           %d\n", result.Deals)
+	fmt.Fprintf(out, "duration:        %s\n", result.Duration)
+	fmt.Fprintf(out, "pairs/s:         %.0f\n", result.PairsPerSecond)
+	fmt.Fprintf(out, "writes/deal:     %.2f\n", result.WritesPerDeal)
+	tables := make([]string, 0, len(result.Writes))
+	for table := range result.Writes {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	for _, table := range tables {
+		fmt.Fprintf(out, "  %-15s %d\n", table, result.Writes[table])
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package lilypad
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/http"
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/lilypad-tech/lilypad/pkg/solver/matcher"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+	"github.com/spf13/cobra"
+)
+
+func newSolverDebugCmd(options *solver.SolverOptions) *cobra.Command {
+	debugCmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Tools for debugging a running solver.",
+		Long:  "Tools for debugging a running solver.",
+	}
+	debugCmd.AddCommand(newSolverDebugMatchCmd(options))
+	return debugCmd
+}
+
+func newSolverDebugMatchCmd(options *solver.SolverOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:     "match <job-offer-id>",
+		Short:   "Show why a job offer does or does not match each resource offer.",
+		Long:    "Load a job offer and the current resource offers from the solver at SERVER_URL and print the result of every match check.",
+		Example: "lilypad solver debug match QmXyz --server-url http://localhost:8080",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSolverDebugMatch(cmd, *options, args[0])
+		},
+	}
+}
+
+func runSolverDebugMatch(cmd *cobra.Command, options solver.SolverOptions, jobOfferID string) error {
+	if options.Server.URL == "" {
+		return fmt.Errorf("SERVER_URL is required")
+	}
+
+	allowlist, err := solver.LoadAllowlist(options.Allowlist.Path)
+	if err != nil {
+		return err
+	}
+
+	client, err := solver.NewSolverClient(http.ClientOptions{
+		URL: options.Server.URL,
+	})
+	if err != nil {
+		return err
+	}
+
+	jobOffers, err := client.GetJobOffers(store.GetJobOffersQuery{
+		IncludeCancelled: true,
+	})
+	if err != nil {
+		return err
+	}
+	var jobOffer *data.JobOfferContainer
+	for i := range jobOffers {
+		if jobOffers[i].ID == jobOfferID {
+			jobOffer = &jobOffers[i]
+			break
+		}
+	}
+	if jobOffer == nil {
+		return fmt.Errorf("job offer not found: %s", jobOfferID)
+	}
+
+	resourceOffers, err := client.GetResourceOffers(store.GetResourceOffersQuery{})
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "job offer %s (%s) state %s\n\n", jobOffer.ID, jobOffer.JobCreator, data.GetAgreementStateString(jobOffer.State))
+	if len(resourceOffers) == 0 {
+		fmt.Fprintf(out, "no resource offers\n")
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for i, resourceOffer := range resourceOffers {
+		checks := matcher.DebugMatch(resourceOffer.ResourceOffer, jobOffer.JobOffer, allowlist)
+
+		// every offer runs the same checks so the first one gives us the headers
+		if i == 0 {
+			headers := []string{"RESOURCE OFFER", "PROVIDER", "AVAILABLE"}
+			for _, check := range checks {
+				headers = append(headers, strings.ToUpper(check.Name))
+			}
+			headers = append(headers, "RESULT", "REASONS")
+			fmt.Fprintln(writer, strings.Join(headers, "\t"))
+		}
+
+		available := resourceOffer.DealID == ""
+		row := []string{resourceOffer.ID, resourceOffer.ResourceProvider, formatCheck(available)}
+		reasons := []string{}
+		if !available {
+			reasons = append(reasons, fmt.Sprintf("already matched to deal %s", resourceOffer.DealID))
+		}
+		for _, check := range checks {
+			row = append(row, formatCheck(check.Passed))
+			if !check.Passed {
+				reasons = append(reasons, check.Message)
+			}
+		}
+		result := "no match"
+		if available && matcher.AllChecksPassed(checks) {
+			result = "match"
+		}
+		row = append(row, result, strings.Join(reasons, "; "))
+		fmt.Fprintln(writer, strings.Join(row, "\t"))
+	}
+
+	return writer.Flush()
+}
+
+func formatCheck(passed bool) string {
+	if passed {
+		return "ok"
+	}
+	return "fail"
+}
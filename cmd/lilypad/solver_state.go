@@ -0,0 +1,72 @@
+package lilypad
+
+import (
+	"fmt"
+
+	"github.com/lilypad-tech/lilypad/pkg/http"
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/spf13/cobra"
+)
+
+func newSolverExportCmd(options *solver.SolverOptions) *cobra.Command {
+	var out string
+	exportCmd := &cobra.Command{
+		Use:     "export",
+		Short:   "Export the state of a running solver.",
+		Long:    "Export every table of the store of the solver at SERVER_URL as a zstd compressed tar that another solver can import. The request is signed with WEB3_PRIVATE_KEY which must be an operator of the solver.",
+		Example: "lilypad solver export --out state.tar.zst --server-url http://localhost:8080",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			client, err := getSolverAdminClient(*options)
+			if err != nil {
+				return err
+			}
+			err = client.ExportState(out)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "exported solver state to %s\n", out)
+			return nil
+		},
+	}
+	exportCmd.Flags().StringVar(&out, "out", "state.tar.zst", "The file to write the state to")
+	return exportCmd
+}
+
+func newSolverImportCmd(options *solver.SolverOptions) *cobra.Command {
+	var in string
+	importCmd := &cobra.Command{
+		Use:     "import",
+		Short:   "Import the state of another solver.",
+		Long:    "Import a state export into the solver at SERVER_URL. The solver must not have any offers or deals yet. The request is signed with WEB3_PRIVATE_KEY which must be an operator of the solver.",
+		Example: "lilypad solver import --in state.tar.zst --server-url http://localhost:8080",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if in == "" {
+				return fmt.Errorf("--in is required")
+			}
+			client, err := getSolverAdminClient(*options)
+			if err != nil {
+				return err
+			}
+			action, err := client.ImportState(in)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "imported solver state from %s: %s\n", in, action.Reason)
+			return nil
+		},
+	}
+	importCmd.Flags().StringVar(&in, "in", "", "The state export to import")
+	return importCmd
+}
+
+func getSolverAdminClient(options solver.SolverOptions) (*solver.SolverClient, error) {
+	if options.Server.URL == "" {
+		return nil, fmt.Errorf("SERVER_URL is required")
+	}
+	return solver.NewSolverClient(http.ClientOptions{
+		URL:        options.Server.URL,
+		PrivateKey: options.Web3.PrivateKey,
+	})
+}
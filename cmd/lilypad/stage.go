@@ -0,0 +1,119 @@
+package lilypad
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/jobcreator"
+	optionsfactory "github.com/lilypad-tech/lilypad/pkg/options"
+	"github.com/spf13/cobra"
+)
+
+// how often lilypad stage add --wait checks on the resource provider
+const STAGING_POLL_INTERVAL = 5 * time.Second
+
+func newStageCmd() *cobra.Command {
+	options := optionsfactory.NewJobCreatorOptions()
+	wait := false
+
+	stageCmd := &cobra.Command{
+		Use:   "stage",
+		Short: "Have a resource provider fetch job inputs ahead of time.",
+		Long:  "Have a resource provider pin input CIDs on its IPFS node before the job that uses them is submitted, so a large dataset is not downloaded after the deal has been agreed. Target the job at the same resource provider with --target.",
+	}
+	optionsfactory.AddJobCreatorCliFlags(stageCmd, &options)
+
+	addCmd := &cobra.Command{
+		Use:     "add <resource-provider> <cid>...",
+		Short:   "Ask a resource provider to fetch inputs.",
+		Example: "lilypad stage add 0x1234... bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi --wait",
+		Args:    cobra.MinimumNArgs(2), //nolint:gomnd
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !common.IsHexAddress(args[0]) {
+				return fmt.Errorf("%s is not a valid resource provider address", args[0])
+			}
+			err := data.CheckInputCIDs(args[1:])
+			if err != nil {
+				return err
+			}
+			return runJobCreatorCommand(cmd, options, func(jobCreator *jobcreator.JobCreator) error {
+				staging, err := jobCreator.AddInputStaging(args[0], args[1:])
+				if err != nil {
+					return err
+				}
+				for wait && staging.State == data.InputStagingRequested {
+					time.Sleep(STAGING_POLL_INTERVAL)
+					staging, err = jobCreator.GetInputStaging(staging.ID)
+					if err != nil {
+						return err
+					}
+				}
+				printInputStagings(cmd, []data.InputStaging{staging})
+				if staging.State == data.InputStagingFailed {
+					return fmt.Errorf("staging failed: %s", staging.Error)
+				}
+				return nil
+			})
+		},
+	}
+	addCmd.Flags().BoolVar(&wait, "wait", wait, "Wait until the resource provider has fetched the inputs.")
+	stageCmd.AddCommand(addCmd)
+
+	stageCmd.AddCommand(&cobra.Command{
+		Use:   "status <id>",
+		Short: "Show a staging request.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJobCreatorCommand(cmd, options, func(jobCreator *jobcreator.JobCreator) error {
+				staging, err := jobCreator.GetInputStaging(args[0])
+				if err != nil {
+					return err
+				}
+				printInputStagings(cmd, []data.InputStaging{staging})
+				return nil
+			})
+		},
+	})
+
+	stageCmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List your staging requests.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runJobCreatorCommand(cmd, options, func(jobCreator *jobcreator.JobCreator) error {
+				stagings, err := jobCreator.GetInputStagings()
+				if err != nil {
+					return err
+				}
+				printInputStagings(cmd, stagings)
+				return nil
+			})
+		},
+	})
+
+	return stageCmd
+}
+
+func printInputStagings(cmd *cobra.Command, stagings []data.InputStaging) {
+	writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ID\tRESOURCE PROVIDER\tCIDS\tSTATE\tSIZE\tERROR")
+	for _, staging := range stagings {
+		size := "-"
+		if staging.Size > 0 {
+			size = fmt.Sprintf("%dMB", (staging.Size+1024*1024-1)/(1024*1024))
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			staging.ID,
+			staging.ResourceProvider,
+			strings.Join(staging.CIDs, ","),
+			staging.State,
+			size,
+			orDash(staging.Error),
+		)
+	}
+	writer.Flush()
+}
@@ -46,11 +46,10 @@ func configureTelemetry(ctx context.Context,
 	options system.TelemetryOptions,
 	web3Options web3.Web3Options,
 ) (*system.Telemetry, error) {
-	privateKey, err := web3.ParsePrivateKey(web3Options.PrivateKey)
+	address, err := web3.GetSignerAddress(web3Options)
 	if err != nil {
 		return nil, err
 	}
-	address := web3.GetAddress(privateKey)
 
 	tc := system.TelemetryConfig{
 		TelemetryURL:   options.URL,
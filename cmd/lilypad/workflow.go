@@ -0,0 +1,152 @@
+package lilypad
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/jobcreator"
+	optionsfactory "github.com/lilypad-tech/lilypad/pkg/options"
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func newWorkflowCmd() *cobra.Command {
+	options := optionsfactory.NewJobCreatorOptions()
+
+	workflowCmd := &cobra.Command{
+		Use:   "workflow",
+		Short: "Run pipelines of jobs where stages take the results of the stages before them.",
+		Long:  "Run pipelines of jobs where stages take the results of the stages before them. The solver adds the job offer for each stage once the stages it depends on have results.",
+	}
+	optionsfactory.AddJobCreatorCliFlags(workflowCmd, &options)
+
+	workflowCmd.AddCommand(&cobra.Command{
+		Use:     "run <file>",
+		Short:   "Run a workflow and wait for it to finish.",
+		Example: "lilypad workflow run pipeline.yaml",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			network, _ := cmd.Flags().GetString("network")
+			options, err := optionsfactory.ProcessOnChainJobCreatorOptions(options, []string{}, network)
+			if err != nil {
+				return err
+			}
+			workflow, err := jobcreator.LoadWorkflowFile(args[0])
+			if err != nil {
+				return err
+			}
+			stages, err := optionsfactory.ProcessWorkflowFile(options, workflow)
+			if err != nil {
+				return err
+			}
+			return runWorkflow(cmd, options, network, stages, workflow.FailurePolicy)
+		},
+	})
+	workflowCmd.AddCommand(&cobra.Command{
+		Use:   "status <id>",
+		Short: "Print the state of each stage of a workflow.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJobCreatorCommand(cmd, options, func(jobCreator *jobcreator.JobCreator) error {
+				workflow, err := jobCreator.GetWorkflow(args[0])
+				if err != nil {
+					return err
+				}
+				printWorkflow(cmd, workflow)
+				return nil
+			})
+		},
+	})
+	workflowCmd.AddCommand(&cobra.Command{
+		Use:   "cancel <id>",
+		Short: "Cancel the running stages of a workflow and skip the rest.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runJobCreatorCommand(cmd, options, func(jobCreator *jobcreator.JobCreator) error {
+				workflow, err := jobCreator.CancelWorkflow(args[0])
+				if err != nil {
+					return err
+				}
+				printWorkflow(cmd, workflow)
+				return nil
+			})
+		},
+	})
+
+	return workflowCmd
+}
+
+// a line is printed each time a stage changes state
+func runWorkflow(cmd *cobra.Command, options jobcreator.JobCreatorOptions, network string, stages []jobcreator.WorkflowStageOptions, failurePolicy string) error {
+	commandCtx := system.NewCommandContext(cmd)
+	defer commandCtx.Cleanup()
+
+	telemetry, err := configureTelemetry(commandCtx.Ctx, system.JobCreatorService, network, options.Telemetry, options.Web3)
+	if err != nil {
+		log.Warn().Msgf("failed to setup opentelemetry: %s", err)
+	}
+	commandCtx.Cm.RegisterCallbackWithContext(telemetry.Shutdown)
+	tracer := telemetry.TracerProvider.Tracer(system.GetOTelServiceName(system.JobCreatorService))
+
+	lastStates := map[string]string{}
+	result, err := jobcreator.RunWorkflow(commandCtx, options, tracer, stages, failurePolicy, func(workflow data.Workflow) {
+		if len(lastStates) == 0 {
+			fmt.Printf("🔀 Workflow %s\n", workflow.ID)
+		}
+		for _, status := range workflow.StageStates {
+			if lastStates[status.Name] == status.State {
+				continue
+			}
+			lastStates[status.Name] = status.State
+			line := fmt.Sprintf("%s %s", status.Name, status.State)
+			if status.Error != "" {
+				line = fmt.Sprintf("%s: %s", line, status.Error)
+			}
+			fmt.Printf("%s %s\n", time.Now().Format(time.TimeOnly), line)
+		}
+	})
+	if err != nil {
+		fmt.Printf("Error: %s", err)
+		return err
+	}
+	fmt.Printf("\n🍂 Lilypad workflow %s, %d of %d stages have results\n", result.Workflow.State, len(result.Results), len(result.Workflow.Stages))
+	for _, status := range result.Workflow.StageStates {
+		if status.State == data.WorkflowStageSucceeded {
+			fmt.Printf("    %s    %s\n", status.Name, solver.GetDownloadsFilePath(status.DealID))
+		}
+	}
+	if result.Workflow.State != data.WorkflowStageSucceeded {
+		return fmt.Errorf("workflow %s", result.Workflow.State)
+	}
+	return nil
+}
+
+func printWorkflow(cmd *cobra.Command, workflow data.Workflow) {
+	fmt.Fprintf(cmd.OutOrStdout(), "workflow %s is %s\n", workflow.ID, workflow.State)
+	writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "STAGE\tSTATE\tDEPENDS ON\tDEAL\tRESULTS\tERROR")
+	for i, status := range workflow.StageStates {
+		dependencies := strings.Join(data.GetWorkflowStageDependencies(workflow.Stages[i]), ",")
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			status.Name,
+			status.State,
+			orDash(dependencies),
+			orDash(status.DealID),
+			orDash(status.ResultsID),
+			orDash(status.Error),
+		)
+	}
+	writer.Flush()
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
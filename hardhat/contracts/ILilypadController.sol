@@ -12,6 +12,24 @@ interface ILilypadController {
     SharedStructs.DealPricing memory pricing
   ) external returns (SharedStructs.Agreement memory);
 
+  // agree to a deal that is paid in an ERC-20 token other than the LilypadToken
+  function agreeWithPaymentToken(
+    string memory dealId,
+    SharedStructs.DealMembers memory members,
+    SharedStructs.DealTimeouts memory timeouts,
+    SharedStructs.DealPricing memory pricing,
+    address paymentToken
+  ) external returns (SharedStructs.Agreement memory);
+
+  // the solver pays the RP part of the job payment as the JC accepts
+  // checkpoints of a long running job, the JC signs each milestone
+  function payMilestone(
+    string memory dealId,
+    uint256 index,
+    uint256 amount,
+    bytes memory jobCreatorSignature
+  ) external;
+
   function addResult(
     string memory dealId,
     string memory resultsId,
@@ -11,6 +11,15 @@ interface ILilypadPayments {
     address _tokenAddress
   ) external;
 
+  function setDealPaymentToken(
+    string memory dealId,
+    address paymentToken
+  ) external;
+
+  function getDealPaymentToken(
+    string memory dealId
+  ) external view returns (address);
+
   /**
    * Agreements
    */
@@ -27,6 +36,19 @@ interface ILilypadPayments {
     uint256 timeoutCollateral
   ) external;
 
+  /**
+   * Milestones
+   */
+
+  function payMilestone(
+    string memory dealId,
+    uint256 index,
+    address resourceProvider,
+    address jobCreator,
+    uint256 amount,
+    uint256 paymentCollateral
+  ) external;
+
   /**
    * Results
    */
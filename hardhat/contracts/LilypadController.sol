@@ -3,6 +3,7 @@ pragma solidity ^0.8.6;
 
 import "@openzeppelin/contracts/access/Ownable.sol";
 import "@openzeppelin/contracts-upgradeable/proxy/utils/Initializable.sol";
+import "@openzeppelin/contracts/utils/cryptography/ECDSA.sol";
 import "./SharedStructs.sol";
 import "./ILilypadController.sol";
 import "./ILilypadStorage.sol";
@@ -126,6 +127,31 @@ contract LilypadController is ILilypadController, Ownable, Initializable {
     SharedStructs.DealTimeouts memory timeouts,
     SharedStructs.DealPricing memory pricing
   ) public override returns (SharedStructs.Agreement memory) {
+    return _agree(dealId, members, timeouts, pricing, address(0));
+  }
+
+  // the same as agree but the collateral and payment are in the given
+  // ERC-20 token which the caller must have approved the payments contract to spend
+  function agreeWithPaymentToken(
+    string memory dealId,
+    SharedStructs.DealMembers memory members,
+    SharedStructs.DealTimeouts memory timeouts,
+    SharedStructs.DealPricing memory pricing,
+    address paymentToken
+  ) public override returns (SharedStructs.Agreement memory) {
+    return _agree(dealId, members, timeouts, pricing, paymentToken);
+  }
+
+  // * the first party to agree picks the payment token
+  // * the second party must agree to the same one
+  // * address(0) is the LilypadToken
+  function _agree(
+    string memory dealId,
+    SharedStructs.DealMembers memory members,
+    SharedStructs.DealTimeouts memory timeouts,
+    SharedStructs.DealPricing memory pricing,
+    address paymentToken
+  ) private returns (SharedStructs.Agreement memory) {
     SharedStructs.Deal memory deal = storageContract.ensureDeal(
       dealId,
       members,
@@ -136,6 +162,13 @@ contract LilypadController is ILilypadController, Ownable, Initializable {
     bool isJobCreator = tx.origin == deal.members.jobCreator;
     require(isResourceProvider || isJobCreator, "Only RP / JC");
 
+    SharedStructs.Agreement memory agreement = storageContract.getAgreement(dealId);
+    if(agreement.resourceProviderAgreedAt == 0 && agreement.jobCreatorAgreedAt == 0) {
+      paymentsContract.setDealPaymentToken(dealId, paymentToken);
+    } else {
+      require(paymentsContract.getDealPaymentToken(dealId) == paymentToken, "Payment token");
+    }
+
     if(isResourceProvider) {
       storageContract.agreeResourceProvider(dealId);
       paymentsContract.agreeResourceProvider(
@@ -157,6 +190,48 @@ contract LilypadController is ILilypadController, Ownable, Initializable {
     return storageContract.getAgreement(dealId);
   }
 
+  /**
+   * Milestones
+   */
+
+  // the hash the JC signs to release a milestone
+  // it names the chain and this contract so the signature is only good here
+  function getMilestoneHash(
+    string memory dealId,
+    uint256 index,
+    uint256 amount
+  ) public view returns (bytes32) {
+    return keccak256(abi.encode(block.chainid, address(this), dealId, index, amount));
+  }
+
+  // * check the solver of the deal is sending this
+  // * check the job is still running
+  // * check the JC signed this milestone and amount
+  // * pay the RP the amount out of the JC payment collateral
+  function payMilestone(
+    string memory dealId,
+    uint256 index,
+    uint256 amount,
+    bytes memory jobCreatorSignature
+  ) public override {
+    require(storageContract.isState(dealId, SharedStructs.AgreementState.DealAgreed), "DealAgreed");
+    SharedStructs.Deal memory deal = storageContract.getDeal(dealId);
+    require(deal.members.solver == _msgSender(), "Only solver");
+
+    // the solver cannot release the JC collateral on its own
+    bytes32 signedHash = ECDSA.toEthSignedMessageHash(getMilestoneHash(dealId, index, amount));
+    require(ECDSA.recover(signedHash, jobCreatorSignature) == deal.members.jobCreator, "JC signature");
+
+    paymentsContract.payMilestone(
+      dealId,
+      index,
+      deal.members.resourceProvider,
+      deal.members.jobCreator,
+      amount,
+      deal.pricing.paymentCollateral
+    );
+  }
+
   /**
    * Results
    */
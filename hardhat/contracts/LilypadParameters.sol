@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.6;
+
+import "@openzeppelin/contracts-upgradeable/proxy/utils/Initializable.sol";
+import "@openzeppelin/contracts-upgradeable/access/OwnableUpgradeable.sol";
+
+// network wide tunables that all services read at startup
+// and watch for changes - e.g. default timeouts, collateral
+// multipliers and minimum prices
+// a value of zero means "not set" and services fall back to
+// their own defaults
+contract LilypadParameters is Initializable, OwnableUpgradeable {
+    mapping(string => uint256) private parameters;
+    string[] private keys;
+    mapping(string => bool) private knownKeys;
+
+    event ParameterSet(string key, uint256 value);
+
+    /**
+     * Init
+     */
+
+    // https://docs.openzeppelin.com/upgrades-plugins/1.x/writing-upgradeable
+    function initialize() public initializer {
+        __Ownable_init();
+    }
+
+    /**
+     * Parameters
+     */
+
+    function getParameter(string memory key) public view returns (uint256) {
+        return parameters[key];
+    }
+
+    function getKeys() public view returns (string[] memory) {
+        return keys;
+    }
+
+    function setParameter(string memory key, uint256 value) public onlyOwner {
+        require(bytes(key).length > 0, "Key cannot be empty");
+        if (!knownKeys[key]) {
+            knownKeys[key] = true;
+            keys.push(key);
+        }
+        parameters[key] = value;
+        emit ParameterSet(key, value);
+    }
+}
@@ -2,6 +2,8 @@
 pragma solidity ^0.8.6;
 
 import "@openzeppelin/contracts-upgradeable/proxy/utils/Initializable.sol";
+import "@openzeppelin/contracts/token/ERC20/IERC20.sol";
+import "@openzeppelin/contracts/token/ERC20/utils/SafeERC20.sol";
 import "./ILilypadToken.sol";
 import "./ControllerOwnable.sol";
 import "./ILilypadPayments.sol";
@@ -13,6 +15,7 @@ import "./ILilypadPayments.sol";
 // console.log(Strings.toString(uint256(agreements[dealId].state)));
 
 contract LilypadPayments is ControllerOwnable, Initializable {
+  using SafeERC20 for IERC20;
 
   /**
    * Types
@@ -81,6 +84,20 @@ contract LilypadPayments is ControllerOwnable, Initializable {
   // used for debugging
   mapping(address => string) private accountNames;
 
+  // the ERC-20 tokens other than the LilypadToken that deals can be paid in
+  mapping(address => bool) private paymentTokens;
+
+  // the token each deal is paid in, deals that are not here use the LilypadToken
+  mapping(string => address) private dealPaymentTokens;
+
+  // how much of each deal's payment collateral has already been paid
+  // to the RP as milestones while the job was running
+  mapping(string => uint256) private dealMilestonePayments;
+
+  // how many milestones of each deal have been paid
+  // milestones are paid in order so this is the index of the next one
+  mapping(string => uint256) private dealMilestoneCounts;
+
   // https://docs.openzeppelin.com/upgrades-plugins/1.x/writing-upgradeable
   function initialize(
     address _tokenAddress
@@ -114,6 +131,31 @@ contract LilypadPayments is ControllerOwnable, Initializable {
     canChangeTokenAddress = false;
   }
 
+  // allow or stop deals being paid in an ERC-20 token
+  // stopping a token does not affect deals that were already agreed
+  function setPaymentToken(address _paymentToken, bool allowed) public onlyOwner {
+    require(_paymentToken != address(0), "LilypadPayments: Payment token must be defined");
+    require(_paymentToken != tokenAddress, "LilypadPayments: The LilypadToken is always allowed");
+    paymentTokens[_paymentToken] = allowed;
+  }
+
+  function isPaymentToken(address _paymentToken) public view returns(bool) {
+    return paymentTokens[_paymentToken];
+  }
+
+  // called by the controller when the first party agrees to a deal
+  function setDealPaymentToken(
+    string memory dealId,
+    address paymentToken
+  ) public onlyController {
+    require(paymentToken == address(0) || paymentTokens[paymentToken], "LilypadPayments: Payment token is not allowed");
+    dealPaymentTokens[dealId] = paymentToken;
+  }
+
+  function getDealPaymentToken(string memory dealId) public view returns(address) {
+    return dealPaymentTokens[dealId];
+  }
+
   /**
    * Controller handlers
    * 
@@ -161,6 +203,44 @@ contract LilypadPayments is ControllerOwnable, Initializable {
     );
   }
 
+  /**
+   * Milestones
+   */
+
+  // * pay the RP part of the job payment from the JC payment collateral
+  // * the milestones of a deal cannot add up to more than that collateral
+  // * whatever is paid here is taken off what is paid when the deal settles
+  // * each milestone can only be paid once
+  function payMilestone(
+    string memory dealId,
+    uint256 index,
+    address resourceProvider,
+    address jobCreator,
+    uint256 amount,
+    uint256 paymentCollateral
+  ) public onlyController {
+    require(index == dealMilestoneCounts[dealId], "LilypadPayments: Milestone is not the next to be paid");
+    dealMilestoneCounts[dealId] = index + 1;
+    uint256 paid = dealMilestonePayments[dealId] + amount;
+    require(paid <= paymentCollateral, "LilypadPayments: Milestones are more than the payment collateral");
+    dealMilestonePayments[dealId] = paid;
+    _payOut(
+      dealId,
+      jobCreator,
+      resourceProvider,
+      amount,
+      PaymentReason.JobPayment
+    );
+  }
+
+  function getMilestonePayments(string memory dealId) public view returns(uint256) {
+    return dealMilestonePayments[dealId];
+  }
+
+  function getMilestoneCount(string memory dealId) public view returns(uint256) {
+    return dealMilestoneCounts[dealId];
+  }
+
   /**
    * Results
    */
@@ -206,19 +286,24 @@ contract LilypadPayments is ControllerOwnable, Initializable {
     // well - we have to cap the job cost at that collateral
     // true - the RP has lost money but they agreed to the deal
     uint256 actualPayment = jobCost;
-    uint256 jcRefund = 0;
     if(jobCost > paymentCollateral) {
       actualPayment = paymentCollateral;
-    } else {
-      jcRefund = paymentCollateral - jobCost;
     }
 
-    // pay the RP the actualPayment
+    // the milestones are part of the job payment and are not paid back
+    // even if the job cost less than they added up to
+    uint256 milestonePayments = dealMilestonePayments[dealId];
+    if(actualPayment < milestonePayments) {
+      actualPayment = milestonePayments;
+    }
+    uint256 jcRefund = paymentCollateral - actualPayment;
+
+    // pay the RP the actualPayment less what the milestones already paid
     _payOut(
       dealId,
       jobCreator,
       resourceProvider,
-      actualPayment,
+      actualPayment - milestonePayments,
       PaymentReason.JobPayment
     );
 
@@ -296,19 +381,24 @@ contract LilypadPayments is ControllerOwnable, Initializable {
     uint256 mediationFee
   ) public onlyController {
     uint256 actualPayment = jobCost;
-    uint256 jcRefund = 0;
     if(jobCost > paymentCollateral) {
       actualPayment = paymentCollateral;
-    } else {
-      jcRefund = paymentCollateral - jobCost;
     }
+
+    // the milestones are part of the job payment and are not paid back
+    // even if the job cost less than they added up to
+    uint256 milestonePayments = dealMilestonePayments[dealId];
+    if(actualPayment < milestonePayments) {
+      actualPayment = milestonePayments;
+    }
+    uint256 jcRefund = paymentCollateral - actualPayment;
     
-    // pay the RP the job cost from the JC
+    // pay the RP the job cost from the JC less what the milestones already paid
     _payOut(
       dealId,
       jobCreator,
       resourceProvider,
-      actualPayment,
+      actualPayment - milestonePayments,
       PaymentReason.JobPayment
     );
 
@@ -360,7 +450,7 @@ contract LilypadPayments is ControllerOwnable, Initializable {
     _refundEscrow(
       dealId,
       jobCreator,
-      paymentCollateral,
+      _unpaidCollateral(dealId, paymentCollateral),
       PaymentReason.PaymentCollateral
     );
 
@@ -411,7 +501,7 @@ contract LilypadPayments is ControllerOwnable, Initializable {
     _refundEscrow(
       dealId,
       jobCreator,
-      paymentCollateral,
+      _unpaidCollateral(dealId, paymentCollateral),
       PaymentReason.PaymentCollateral
     );
 
@@ -439,7 +529,7 @@ contract LilypadPayments is ControllerOwnable, Initializable {
     _refundEscrow(
       dealId,
       jobCreator,
-      paymentCollateral,
+      _unpaidCollateral(dealId, paymentCollateral),
       PaymentReason.PaymentCollateral
     );
 
@@ -513,7 +603,7 @@ contract LilypadPayments is ControllerOwnable, Initializable {
     _refundEscrow(
       dealId,
       jobCreator,
-      paymentCollateral,
+      _unpaidCollateral(dealId, paymentCollateral),
       PaymentReason.PaymentCollateral
     );
 
@@ -530,21 +620,35 @@ contract LilypadPayments is ControllerOwnable, Initializable {
    * Payment utils
    */
 
+  // the payment collateral that has not been paid out as milestones
+  function _unpaidCollateral(
+    string memory dealId,
+    uint256 paymentCollateral
+  ) private view returns (uint256) {
+    return paymentCollateral - dealMilestonePayments[dealId];
+  }
+
 
   function _payEscrow(
     string memory dealId,
     uint256 amount,
     PaymentReason reason
   ) private {
-    // we check they have that much in their token balance before moving to tokens to us
-    require(tokenContract.balanceOf(tx.origin) >= amount, "LilypadPayments: Insufficient balance");
+    address paymentToken = dealPaymentTokens[dealId];
+    if(paymentToken != address(0)) {
+      // the payer has approved us to take the collateral so we hold it
+      IERC20(paymentToken).safeTransferFrom(tx.origin, address(this), amount);
+    } else {
+      // we check they have that much in their token balance before moving to tokens to us
+      require(tokenContract.balanceOf(tx.origin) >= amount, "LilypadPayments: Insufficient balance");
 
-    // console.log("_payEscrow");
-    // console.log(accountNames[tx.origin]);
-    // console.log(amount);
+      // console.log("_payEscrow");
+      // console.log(accountNames[tx.origin]);
+      // console.log(amount);
 
-    bool success = tokenContract.payEscrow(amount);
-    require(success, "LilypadPayments: Pay escrow failed");
+      bool success = tokenContract.payEscrow(amount);
+      require(success, "LilypadPayments: Pay escrow failed");
+    }
 
     emit Payment(
       dealId,
@@ -565,8 +669,13 @@ contract LilypadPayments is ControllerOwnable, Initializable {
     // console.log(accountNames[toAddress]);
     // console.log(amount);
 
-    bool success = tokenContract.refundEscrow(toAddress, amount);
-    require(success, "LilypadPayments: Refund escrow failed");
+    address paymentToken = dealPaymentTokens[dealId];
+    if(paymentToken != address(0)) {
+      IERC20(paymentToken).safeTransfer(toAddress, amount);
+    } else {
+      bool success = tokenContract.refundEscrow(toAddress, amount);
+      require(success, "LilypadPayments: Refund escrow failed");
+    }
 
     emit Payment(
       dealId,
@@ -589,8 +698,14 @@ contract LilypadPayments is ControllerOwnable, Initializable {
     // console.log(accountNames[toAddress]);
     // console.log(amount);
 
-    bool success = tokenContract.payJob(fromAddress, toAddress, amount);
-    require(success, "LilypadPayments: Pay job failed");
+    address paymentToken = dealPaymentTokens[dealId];
+    if(paymentToken != address(0)) {
+      // we already hold what fromAddress paid in
+      IERC20(paymentToken).safeTransfer(toAddress, amount);
+    } else {
+      bool success = tokenContract.payJob(fromAddress, toAddress, amount);
+      require(success, "LilypadPayments: Pay job failed");
+    }
 
     emit Payment(
       dealId,
@@ -611,8 +726,14 @@ contract LilypadPayments is ControllerOwnable, Initializable {
     // console.log(accountNames[slashedAddress]);
     // console.log(amount);
 
-    bool success = tokenContract.slashEscrow(slashedAddress, amount);
-    require(success, "LilypadPayments: Slash escrow failed");
+    address paymentToken = dealPaymentTokens[dealId];
+    if(paymentToken != address(0)) {
+      // the same as the LilypadToken, slashed funds go to the owner
+      IERC20(paymentToken).safeTransfer(owner(), amount);
+    } else {
+      bool success = tokenContract.slashEscrow(slashedAddress, amount);
+      require(success, "LilypadPayments: Slash escrow failed");
+    }
 
     emit Payment(
       dealId,
@@ -0,0 +1,28 @@
+import { HardhatRuntimeEnvironment } from 'hardhat/types'
+import { DeployFunction } from 'hardhat-deploy/types'
+
+const deployParameters: DeployFunction = async function (hre: HardhatRuntimeEnvironment) {
+  const { deployments, getNamedAccounts } = hre
+  const { deploy, execute } = deployments
+  const {
+    admin,
+  } = await getNamedAccounts()
+  await deploy("LilypadParameters", {
+    from: admin,
+    args: [],
+    log: true,
+  })
+  await execute(
+    'LilypadParameters',
+    {
+      from: admin,
+      log: true,
+    },
+    'initialize'
+  )
+  return true
+}
+
+deployParameters.id = 'deployParameters'
+
+export default deployParameters
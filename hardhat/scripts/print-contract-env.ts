@@ -6,7 +6,8 @@ import {
   getPaymentsAddress,
   getStorageAddress,
   getUsersAddress,
-  getPoWAddress
+  getPoWAddress,
+  getParametersAddress
 } from '../utils/web3'
 
 async function main() {
@@ -18,6 +19,7 @@ async function main() {
   const storageAddress = await getStorageAddress()
   const usersAddress = await getUsersAddress()
   const powAddress = await getPoWAddress()
+  const parametersAddress = await getParametersAddress()
 
   console.log(`export WEB3_RPC_URL=ws://localhost:8546`)
   console.log(`export WEB3_CONTROLLER_ADDRESS=${controllerAddress}`)
@@ -28,6 +30,7 @@ async function main() {
   console.log(`export WEB3_STORAGE_ADDRESS=${storageAddress}`)
   console.log(`export WEB3_USERS_ADDRESS=${usersAddress}`)
   console.log(`export WEB3_POW_ADDRESS=${powAddress}`)
+  console.log(`export WEB3_PARAMETERS_ADDRESS=${parametersAddress}`)
 }
 
 main().catch((error) => {
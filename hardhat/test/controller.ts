@@ -1,5 +1,6 @@
 import {
   loadFixture,
+  time,
 } from '@nomicfoundation/hardhat-toolbox/network-helpers'
 import chai from 'chai'
 import bluebird from 'bluebird'
@@ -19,6 +20,7 @@ import {
 } from '../utils/accounts'
 import {
   setupControllerFixture,
+  setupPaymentTokenFixture,
   getDefaultTimeouts,
   getDefaultPricing,
   DEFAULT_VALUES,
@@ -94,7 +96,8 @@ describe("Controller", () => {
     expect(agreement.state).to.equal(getAgreementState(desiredState))
   }
 
-  async function agree(controller: LilypadController, party: string) {
+  // with a payment token the deal is paid in that rather than the LilypadToken
+  async function agree(controller: LilypadController, party: string, paymentToken?: string) {
 
     const members: SharedStructs.DealMembersStruct = {
       solver: getAddress('solver'), 
@@ -105,6 +108,17 @@ describe("Controller", () => {
     const timeouts = getDefaultTimeouts()
     const pricing = getDefaultPricing()
 
+    if(paymentToken) {
+      return controller
+        .connect(getWallet(party))
+        .agreeWithPaymentToken(
+          DEAL_ID,
+          members,
+          timeouts,
+          pricing,
+          paymentToken,
+        )
+    }
     return controller
       .connect(getWallet(party))
       .agree(
@@ -427,6 +441,260 @@ describe("Controller", () => {
     })
   })
 
+  describe("Milestones", () => {
+
+    const milestone = ethers.parseEther("5")
+
+    async function signMilestone(controller: LilypadController, index: number, amount: bigint, party = 'job_creator') {
+      const hash = await controller.getMilestoneHash(DEAL_ID, index, amount)
+      return getWallet(party).signMessage(ethers.getBytes(hash))
+    }
+
+    async function payMilestone(controller: LilypadController, index: number, amount: bigint, party = 'solver') {
+      const signature = await signMilestone(controller, index, amount)
+      return controller
+        .connect(getWallet(party))
+        .payMilestone(
+          DEAL_ID,
+          index,
+          amount,
+          signature,
+        )
+    }
+
+    it("Pays a milestone the JC signed", async function () {
+      const {
+        token,
+        payments,
+        controller,
+      } = await loadFixture(setupControllerWithDeal)
+
+      const balancesBeforeRP = await getBalances(token, 'resource_provider')
+
+      await expect(payMilestone(controller, 0, milestone))
+        .to.emit(payments, 'Payment')
+        .withArgs(
+          DEAL_ID,
+          getAddress('resource_provider'),
+          milestone,
+          getPaymentReason('JobPayment'),
+          getPaymentDirection('PaidOut'),
+        )
+
+      const balancesAfterRP = await getBalances(token, 'resource_provider')
+      expect(balancesAfterRP.tokens).to.equal(balancesBeforeRP.tokens + milestone)
+      expect(await payments.getMilestonePayments(DEAL_ID)).to.equal(milestone)
+    })
+
+    it("Only the solver can pay a milestone", async function () {
+      const { controller } = await loadFixture(setupControllerWithDeal)
+      await expect(payMilestone(controller, 0, milestone, 'resource_provider'))
+        .to.be.revertedWith('Only solver')
+      await expect(payMilestone(controller, 0, milestone, 'job_creator'))
+        .to.be.revertedWith('Only solver')
+    })
+
+    it("Reverts a milestone the JC did not sign", async function () {
+      const { controller } = await loadFixture(setupControllerWithDeal)
+
+      // the solver cannot sign for the JC
+      const solverSignature = await signMilestone(controller, 0, milestone, 'solver')
+      await expect(controller
+        .connect(getWallet('solver'))
+        .payMilestone(DEAL_ID, 0, milestone, solverSignature)
+      ).to.be.revertedWith('JC signature')
+
+      // nor change the amount or index the JC signed
+      const signature = await signMilestone(controller, 0, milestone)
+      await expect(controller
+        .connect(getWallet('solver'))
+        .payMilestone(DEAL_ID, 0, milestone * 2n, signature)
+      ).to.be.revertedWith('JC signature')
+      await expect(controller
+        .connect(getWallet('solver'))
+        .payMilestone(DEAL_ID, 1, milestone, signature)
+      ).to.be.revertedWith('JC signature')
+    })
+
+    it("Cannot pay a signed milestone twice", async function () {
+      const { controller } = await loadFixture(setupControllerWithDeal)
+      const signature = await signMilestone(controller, 0, milestone)
+      await controller
+        .connect(getWallet('solver'))
+        .payMilestone(DEAL_ID, 0, milestone, signature)
+      await expect(controller
+        .connect(getWallet('solver'))
+        .payMilestone(DEAL_ID, 0, milestone, signature)
+      ).to.be.revertedWith('LilypadPayments: Milestone is not the next to be paid')
+    })
+
+    it("Cannot pay a milestone once the results are in", async function () {
+      const { controller } = await loadFixture(setupControllerWithResults)
+      await expect(payMilestone(controller, 0, milestone))
+        .to.be.revertedWith('DealAgreed')
+    })
+
+    it("Runs a job paid in milestones", async function () {
+      const {
+        token,
+        storage,
+        controller,
+      } = await loadFixture(setupController)
+
+      const balancesBeforeJC = await getBalances(token, 'job_creator')
+      const balancesBeforeRP = await getBalances(token, 'resource_provider')
+
+      await agree(controller, 'job_creator')
+      await agree(controller, 'resource_provider')
+      await payMilestone(controller, 0, milestone)
+      await controller
+        .connect(getWallet('resource_provider'))
+        .addResult(
+          DEAL_ID,
+          RESULTS_ID,
+          DATA_ID,
+          instructionCount
+        )
+      await controller
+        .connect(getWallet('job_creator'))
+        .acceptResult(
+          DEAL_ID,
+        )
+
+      // the milestone was part of the job cost not on top of it
+      const balancesAfterJC = await getBalances(token, 'job_creator')
+      const balancesAfterRP = await getBalances(token, 'resource_provider')
+      expect(balancesAfterRP.tokens).to.equal(balancesBeforeRP.tokens + jobCost)
+      expect(balancesAfterJC.tokens).to.equal(balancesBeforeJC.tokens - jobCost)
+      expect(balancesAfterJC.escrow).to.equal(balancesBeforeJC.escrow)
+
+      await checkAgreement(storage, 'ResultsAccepted')
+    })
+
+    it("Refunds the JC what the milestones did not pay when the results time out", async function () {
+      const {
+        token,
+        storage,
+        controller,
+      } = await loadFixture(setupController)
+
+      const balancesBeforeJC = await getBalances(token, 'job_creator')
+      const balancesBeforeRP = await getBalances(token, 'resource_provider')
+
+      await agree(controller, 'job_creator')
+      await agree(controller, 'resource_provider')
+      await payMilestone(controller, 0, milestone)
+      await time.increase(timeout + 1n)
+      await controller
+        .connect(getWallet('job_creator'))
+        .timeoutSubmitResult(
+          DEAL_ID,
+        )
+
+      // the RP keeps the milestone but loses its timeout collateral
+      const balancesAfterJC = await getBalances(token, 'job_creator')
+      const balancesAfterRP = await getBalances(token, 'resource_provider')
+      expect(balancesAfterJC.tokens).to.equal(balancesBeforeJC.tokens - milestone)
+      expect(balancesAfterJC.escrow).to.equal(balancesBeforeJC.escrow)
+      expect(balancesAfterRP.tokens).to.equal(balancesBeforeRP.tokens + milestone - timeoutCollateral)
+
+      await checkAgreement(storage, 'TimeoutSubmitResults')
+    })
+
+  })
+
+  describe("Payment tokens", () => {
+
+    async function setupControllerWithPaymentToken() {
+      const ret = await setupController()
+      const paymentToken = await setupPaymentTokenFixture({
+        paymentsAddress: await ret.payments.getAddress(),
+      })
+      const paymentTokenAddress = await paymentToken.getAddress()
+      await ret.payments
+        .connect(getWallet('admin'))
+        .setPaymentToken(paymentTokenAddress, true)
+      return {
+        ...ret,
+        paymentToken,
+        paymentTokenAddress,
+      }
+    }
+
+    it("Runs a job paid in a payment token", async function () {
+      const {
+        token,
+        payments,
+        storage,
+        controller,
+        paymentToken,
+        paymentTokenAddress,
+      } = await loadFixture(setupControllerWithPaymentToken)
+
+      const lilypadBalancesBeforeJC = await getBalances(token, 'job_creator')
+      const jcBefore = await paymentToken.balanceOf(getAddress('job_creator'))
+      const rpBefore = await paymentToken.balanceOf(getAddress('resource_provider'))
+
+      await agree(controller, 'job_creator', paymentTokenAddress)
+      expect(await payments.getDealPaymentToken(DEAL_ID)).to.equal(paymentTokenAddress)
+      await agree(controller, 'resource_provider', paymentTokenAddress)
+      await checkAgreement(storage, 'DealAgreed')
+
+      await controller
+        .connect(getWallet('resource_provider'))
+        .addResult(
+          DEAL_ID,
+          RESULTS_ID,
+          DATA_ID,
+          instructionCount
+        )
+      await controller
+        .connect(getWallet('job_creator'))
+        .acceptResult(
+          DEAL_ID,
+        )
+
+      expect(await paymentToken.balanceOf(getAddress('resource_provider'))).to.equal(rpBefore + jobCost)
+      expect(await paymentToken.balanceOf(getAddress('job_creator'))).to.equal(jcBefore - jobCost)
+      expect(await paymentToken.balanceOf(await payments.getAddress())).to.equal(0)
+
+      const lilypadBalancesAfterJC = await getBalances(token, 'job_creator')
+      expect(lilypadBalancesAfterJC.tokens).to.equal(lilypadBalancesBeforeJC.tokens)
+
+      await checkAgreement(storage, 'ResultsAccepted')
+    })
+
+    it("Reverts agreeing with a payment token that is not allowed", async function () {
+      const {
+        payments,
+        controller,
+        paymentTokenAddress,
+      } = await loadFixture(setupControllerWithPaymentToken)
+      await payments
+        .connect(getWallet('admin'))
+        .setPaymentToken(paymentTokenAddress, false)
+      await expect(
+        agree(controller, 'job_creator', paymentTokenAddress)
+      ).to.be.revertedWith('LilypadPayments: Payment token is not allowed')
+    })
+
+    it("Reverts when the parties agree to different payment tokens", async function () {
+      const {
+        payments,
+        controller,
+        paymentTokenAddress,
+      } = await loadFixture(setupControllerWithPaymentToken)
+
+      // agree is the same as agreeing to pay in the LilypadToken
+      await agree(controller, 'job_creator')
+      expect(await payments.getDealPaymentToken(DEAL_ID)).to.equal(ethers.ZeroAddress)
+      await expect(
+        agree(controller, 'resource_provider', paymentTokenAddress)
+      ).to.be.revertedWith('Payment token')
+    })
+
+  })
+
   describe("End to end", () => {
 
     it("Runs a job in the happy path", async function () {
@@ -1,4 +1,5 @@
 import { ethers } from 'hardhat'
+import bluebird from 'bluebird'
 import {
   BigNumberish,
   AddressLike,
@@ -17,6 +18,8 @@ import {
   LilypadUsers,
   LilypadController,
   LilypadMediationRandom,
+  LilypadParameters,
+  PaymentTokenTestable,
 } from '../typechain-types'
 import {
   SharedStructs,
@@ -52,6 +55,17 @@ export async function deployToken(
   ])
 }
 
+export async function deployPaymentToken(
+  signer: Signer,
+  tokenSupply: BigNumberish = DEFAULT_TOKEN_SUPPLY,
+) {
+  return deployContract<PaymentTokenTestable>('PaymentTokenTestable', signer, [
+    'Payment Token',
+    'PAY',
+    tokenSupply,
+  ])
+}
+
 export async function deployPayments(
   signer: Signer,
   tokenAddress: AddressLike,
@@ -83,6 +97,12 @@ export async function deployMediation(
   return deployContract<LilypadMediationRandom>('LilypadMediationRandom', signer)
 }
 
+export async function deployParameters(
+  signer: Signer
+) {
+  return deployContract<LilypadParameters>('LilypadParameters', signer)
+}
+
 export async function deployController(
   signer: Signer,
   storageAddress: AddressLike,
@@ -143,6 +163,34 @@ export async function setupTokenFixture({
   return token
 }
 
+/*
+
+  PAYMENT TOKEN
+
+*/
+
+// an ERC-20 token other than the LilypadToken that the given accounts
+// hold and have approved the payments contract to take
+export async function setupPaymentTokenFixture({
+  paymentsAddress,
+  accounts = ['job_creator', 'resource_provider'],
+}: {
+  paymentsAddress: AddressLike,
+  accounts?: string[],
+}) {
+  const admin = getWallet('admin')
+  const paymentToken = await deployPaymentToken(admin)
+  await bluebird.mapSeries(accounts, async (name) => {
+    await paymentToken
+      .connect(admin)
+      .transfer(getWallet(name).address, DEFAULT_TOKENS_PER_ACCOUNT)
+    await paymentToken
+      .connect(getWallet(name))
+      .approve(paymentsAddress, ethers.MaxUint256)
+  })
+  return paymentToken
+}
+
 /*
 
   PAYMENTS
@@ -250,6 +298,29 @@ export async function setupMediationFixture({
   return mediation
 }
 
+/*
+
+  PARAMETERS
+
+*/
+
+export async function setupParametersFixture({
+  initialize = true,
+}: {
+  initialize?: boolean,
+}) {
+  const admin = getWallet('admin')
+  const parameters = await deployParameters(
+    admin,
+  )
+  if(initialize) {
+    await parameters
+      .connect(admin)
+      .initialize()
+  }
+  return parameters
+}
+
 /*
 
   CONTROLLER
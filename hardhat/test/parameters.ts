@@ -0,0 +1,132 @@
+import {
+  loadFixture,
+} from '@nomicfoundation/hardhat-toolbox/network-helpers'
+import chai from 'chai'
+import chaiAsPromised from 'chai-as-promised'
+import {
+  getWallet,
+  getAddress,
+} from '../utils/web3'
+import {
+  setupParametersFixture,
+} from './fixtures'
+
+chai.use(chaiAsPromised)
+const { expect } = chai
+
+describe("Parameters", () => {
+
+  function setupParameters() {
+    return setupParametersFixture({})
+  }
+
+  function setupParametersUninitialized() {
+    return setupParametersFixture({
+      initialize: false,
+    })
+  }
+
+  describe("Initialize", () => {
+
+    it("Should make the deployer the owner", async function () {
+      const parameters = await loadFixture(setupParameters)
+      expect(await parameters.owner()).to.equal(getAddress('admin'))
+    })
+
+    it("Should make whoever initializes it the owner", async function () {
+      const parameters = await loadFixture(setupParametersUninitialized)
+      await expect(parameters
+        .connect(getWallet('solver'))
+        .initialize()
+      ).to.not.be.reverted
+      expect(await parameters.owner()).to.equal(getAddress('solver'))
+    })
+
+    it("Should only initialize once", async function () {
+      const parameters = await loadFixture(setupParameters)
+      await expect(parameters
+        .connect(getWallet('admin'))
+        .initialize()
+      ).to.be.revertedWith('Initializable: contract is already initialized')
+    })
+
+  })
+
+  describe("Setters", () => {
+
+    it("Should let the owner set a parameter", async function () {
+      const parameters = await loadFixture(setupParameters)
+      await expect(parameters
+        .connect(getWallet('admin'))
+        .setParameter('job_timeout', 600)
+      )
+        .to.emit(parameters, "ParameterSet")
+        .withArgs('job_timeout', 600)
+      expect(await parameters.getParameter('job_timeout')).to.equal(600)
+    })
+
+    it("Should not let anyone else set a parameter", async function () {
+      const parameters = await loadFixture(setupParameters)
+      await expect(parameters
+        .connect(getWallet('resource_provider'))
+        .setParameter('job_timeout', 600)
+      ).to.be.revertedWith('Ownable: caller is not the owner')
+      expect(await parameters.getParameter('job_timeout')).to.equal(0)
+    })
+
+    it("Should let a new owner set parameters and stop the old one", async function () {
+      const parameters = await loadFixture(setupParameters)
+      await parameters
+        .connect(getWallet('admin'))
+        .transferOwnership(getAddress('solver'))
+      await expect(parameters
+        .connect(getWallet('solver'))
+        .setParameter('job_timeout', 600)
+      ).to.not.be.reverted
+      await expect(parameters
+        .connect(getWallet('admin'))
+        .setParameter('job_timeout', 60)
+      ).to.be.revertedWith('Ownable: caller is not the owner')
+    })
+
+    it("Should not set an empty key", async function () {
+      const parameters = await loadFixture(setupParameters)
+      await expect(parameters
+        .connect(getWallet('admin'))
+        .setParameter('', 600)
+      ).to.be.revertedWith('Key cannot be empty')
+    })
+
+  })
+
+  describe("Getters", () => {
+
+    it("Should return zero for a parameter that is not set", async function () {
+      const parameters = await loadFixture(setupParameters)
+      expect(await parameters.getParameter('missing')).to.equal(0)
+      expect(await parameters.getKeys()).to.deep.equal([])
+    })
+
+    it("Should list each key once in the order they were first set", async function () {
+      const parameters = await loadFixture(setupParameters)
+      const admin = getWallet('admin')
+      await parameters.connect(admin).setParameter('job_timeout', 600)
+      await parameters.connect(admin).setParameter('collateral_multiplier', 2)
+      await parameters.connect(admin).setParameter('job_timeout', 900)
+      expect(await parameters.getKeys()).to.deep.equal(['job_timeout', 'collateral_multiplier'])
+      expect(await parameters.getParameter('job_timeout')).to.equal(900)
+      expect(await parameters.getParameter('collateral_multiplier')).to.equal(2)
+    })
+
+    it("Should keep a key that is set back to zero", async function () {
+      const parameters = await loadFixture(setupParameters)
+      const admin = getWallet('admin')
+      await parameters.connect(admin).setParameter('min_price', 5)
+      await parameters.connect(admin).setParameter('min_price', 0)
+      expect(await parameters.getKeys()).to.deep.equal(['min_price'])
+      expect(await parameters.getParameter('min_price')).to.equal(0)
+    })
+
+  })
+
+})
@@ -18,9 +18,12 @@ import {
 } from '../utils/accounts'
 import {
   setupPaymentsFixture,
+  setupPaymentTokenFixture,
 } from './fixtures'
 import {
   LilypadToken,
+  LilypadPayments,
+  PaymentTokenTestable,
 } from '../typechain-types'
 
 chai.use(chaiAsPromised)
@@ -835,6 +838,439 @@ describe("Payments", () => {
 
   })
 
+  describe("Milestones", () => {
+
+    const milestone = ethers.parseEther("5")
+
+    function payMilestone(payments: LilypadPayments, index: number, amount: bigint) {
+      return payments
+        .connect(getWallet('solver'))
+        .payMilestone(
+          dealID,
+          index,
+          getAddress('resource_provider'),
+          getAddress('job_creator'),
+          amount,
+          paymentCollateral,
+        )
+    }
+
+    it("Should pay a milestone out of the payment collateral", async function () {
+      const {
+        token,
+        payments,
+      } = await loadFixture(setupPaymentsWithAgreement)
+
+      const balanceBeforeJC = await getBalances(token, 'job_creator')
+      const balanceBeforeRP = await getBalances(token, 'resource_provider')
+
+      await expect(payMilestone(payments, 0, milestone))
+        .to.emit(payments, 'Payment')
+        .withArgs(
+          dealID,
+          getAddress('resource_provider'),
+          milestone,
+          getPaymentReason('JobPayment'),
+          getPaymentDirection('PaidOut'),
+        )
+
+      const balanceAfterJC = await getBalances(token, 'job_creator')
+      const balanceAfterRP = await getBalances(token, 'resource_provider')
+
+      expect(balanceAfterRP.tokens).to.equal(balanceBeforeRP.tokens + milestone)
+      expect(balanceAfterJC.escrow).to.equal(balanceBeforeJC.escrow - milestone)
+      expect(await payments.getMilestonePayments(dealID)).to.equal(milestone)
+      expect(await payments.getMilestoneCount(dealID)).to.equal(1)
+    })
+
+    it("Cannot pay a milestone twice or out of order", async function () {
+      const { payments } = await loadFixture(setupPaymentsWithAgreement)
+      await expect(payMilestone(payments, 1, milestone))
+        .to.be.revertedWith('LilypadPayments: Milestone is not the next to be paid')
+      await payMilestone(payments, 0, milestone)
+      await expect(payMilestone(payments, 0, milestone))
+        .to.be.revertedWith('LilypadPayments: Milestone is not the next to be paid')
+      await payMilestone(payments, 1, milestone)
+      expect(await payments.getMilestonePayments(dealID)).to.equal(milestone * 2n)
+    })
+
+    it("Cannot pay more than the payment collateral in milestones", async function () {
+      const { payments } = await loadFixture(setupPaymentsWithAgreement)
+      await payMilestone(payments, 0, paymentCollateral - milestone)
+      await expect(payMilestone(payments, 1, milestone + 1n))
+        .to.be.revertedWith('LilypadPayments: Milestones are more than the payment collateral')
+      await payMilestone(payments, 1, milestone)
+      expect(await payments.getMilestonePayments(dealID)).to.equal(paymentCollateral)
+    })
+
+    it("Should take the milestones off the job payment", async function () {
+      const {
+        token,
+        payments,
+      } = await loadFixture(setupPaymentsWithResults)
+
+      const balanceBeforeJC = await getBalances(token, 'job_creator')
+      const balanceBeforeRP = await getBalances(token, 'resource_provider')
+
+      await payMilestone(payments, 0, milestone)
+      await expect(payments
+        .connect(getWallet('job_creator'))
+        .acceptResult(
+          dealID,
+          getAddress('resource_provider'),
+          getAddress('job_creator'),
+          jobCost,
+          paymentCollateral,
+          resultsCollateral,
+          timeoutCollateral,
+        )
+      )
+        .to.emit(payments, 'Payment')
+        .withArgs(
+          dealID,
+          getAddress('resource_provider'),
+          jobCost - milestone,
+          getPaymentReason('JobPayment'),
+          getPaymentDirection('PaidOut'),
+        )
+        .to.emit(payments, 'Payment')
+        .withArgs(
+          dealID,
+          getAddress('job_creator'),
+          paymentCollateral - jobCost,
+          getPaymentReason('PaymentCollateral'),
+          getPaymentDirection('Refunded'),
+        )
+
+      const balanceAfterJC = await getBalances(token, 'job_creator')
+      const balanceAfterRP = await getBalances(token, 'resource_provider')
+
+      // all in the RP is paid the job cost once
+      expect(balanceAfterRP.tokens).to.equal(balanceBeforeRP.tokens + jobCost + resultsCollateral)
+      expect(balanceAfterJC.tokens).to.equal(balanceBeforeJC.tokens + paymentCollateral - jobCost + timeoutCollateral)
+      expect(balanceAfterJC.escrow).to.equal(0)
+    })
+
+    it("Should not pay back milestones that add up to more than the job cost", async function () {
+      const {
+        token,
+        payments,
+      } = await loadFixture(setupPaymentsWithResults)
+
+      const milestones = jobCost + milestone
+      const balanceBeforeJC = await getBalances(token, 'job_creator')
+      const balanceBeforeRP = await getBalances(token, 'resource_provider')
+
+      await payMilestone(payments, 0, milestones)
+      await expect(payments
+        .connect(getWallet('job_creator'))
+        .acceptResult(
+          dealID,
+          getAddress('resource_provider'),
+          getAddress('job_creator'),
+          jobCost,
+          paymentCollateral,
+          resultsCollateral,
+          timeoutCollateral,
+        )
+      )
+        .to.emit(payments, 'Payment')
+        .withArgs(
+          dealID,
+          getAddress('resource_provider'),
+          0,
+          getPaymentReason('JobPayment'),
+          getPaymentDirection('PaidOut'),
+        )
+        .to.emit(payments, 'Payment')
+        .withArgs(
+          dealID,
+          getAddress('job_creator'),
+          paymentCollateral - milestones,
+          getPaymentReason('PaymentCollateral'),
+          getPaymentDirection('Refunded'),
+        )
+
+      const balanceAfterJC = await getBalances(token, 'job_creator')
+      const balanceAfterRP = await getBalances(token, 'resource_provider')
+
+      expect(balanceAfterRP.tokens).to.equal(balanceBeforeRP.tokens + milestones + resultsCollateral)
+      expect(balanceAfterJC.tokens).to.equal(balanceBeforeJC.tokens + paymentCollateral - milestones + timeoutCollateral)
+    })
+
+    it("Should only refund the collateral the milestones did not pay", async function () {
+      const {
+        token,
+        payments,
+      } = await loadFixture(setupPaymentsWithAgreement)
+
+      const balanceBeforeJC = await getBalances(token, 'job_creator')
+
+      await payMilestone(payments, 0, milestone)
+      await expect(payments
+        .connect(getWallet('job_creator'))
+        .timeoutSubmitResults(
+          dealID,
+          getAddress('resource_provider'),
+          getAddress('job_creator'),
+          paymentCollateral,
+          timeoutCollateral,
+        )
+      )
+        .to.emit(payments, 'Payment')
+        .withArgs(
+          dealID,
+          getAddress('job_creator'),
+          paymentCollateral - milestone,
+          getPaymentReason('PaymentCollateral'),
+          getPaymentDirection('Refunded'),
+        )
+
+      const balanceAfterJC = await getBalances(token, 'job_creator')
+
+      expect(balanceAfterJC.tokens).to.equal(balanceBeforeJC.tokens + paymentCollateral - milestone + timeoutCollateral)
+      expect(balanceAfterJC.escrow).to.equal(0)
+    })
+
+  })
+
+  describe("Payment tokens", () => {
+
+    async function setupPaymentsWithPaymentToken() {
+      const ret = await setupPayments()
+      const paymentToken = await setupPaymentTokenFixture({
+        paymentsAddress: await ret.payments.getAddress(),
+      })
+      const paymentTokenAddress = await paymentToken.getAddress()
+      await ret.payments
+        .connect(getWallet('admin'))
+        .setPaymentToken(paymentTokenAddress, true)
+      await ret.payments
+        .setDealPaymentToken(dealID, paymentTokenAddress)
+      return {
+        ...ret,
+        paymentToken,
+        paymentTokenAddress,
+      }
+    }
+
+    async function setupPaymentTokenWithResults() {
+      const ret = await setupPaymentsWithPaymentToken()
+      await ret.payments
+        .connect(getWallet('resource_provider'))
+        .agreeResourceProvider(
+          dealID,
+          getAddress('resource_provider'),
+          timeoutCollateral,
+        )
+      await ret.payments
+        .connect(getWallet('job_creator'))
+        .agreeJobCreator(
+          dealID,
+          getAddress('job_creator'),
+          paymentCollateral,
+          timeoutCollateral,
+        )
+      await ret.payments
+        .connect(getWallet('resource_provider'))
+        .addResult(
+          dealID,
+          getAddress('resource_provider'),
+          resultsCollateral,
+          timeoutCollateral,
+        )
+      return ret
+    }
+
+    async function getPaymentTokenBalances(paymentToken: PaymentTokenTestable, accountNames: string[]) {
+      return bluebird.mapSeries(accountNames, (accountName) => paymentToken.balanceOf(getAddress(accountName)))
+    }
+
+    it("Should allow and stop payment tokens", async function () {
+      const { payments } = await loadFixture(setupPayments)
+      const paymentToken = await setupPaymentTokenFixture({
+        paymentsAddress: await payments.getAddress(),
+      })
+      const paymentTokenAddress = await paymentToken.getAddress()
+
+      expect(await payments.isPaymentToken(paymentTokenAddress)).to.equal(false)
+      await payments
+        .connect(getWallet('admin'))
+        .setPaymentToken(paymentTokenAddress, true)
+      expect(await payments.isPaymentToken(paymentTokenAddress)).to.equal(true)
+      await payments
+        .connect(getWallet('admin'))
+        .setPaymentToken(paymentTokenAddress, false)
+      expect(await payments.isPaymentToken(paymentTokenAddress)).to.equal(false)
+    })
+
+    it("Can only set payment tokens as the owner", async function () {
+      const { payments } = await loadFixture(setupPayments)
+      await expect(payments
+        .connect(getWallet('job_creator'))
+        .setPaymentToken(getAddress('job_creator'), true)
+      ).to.be.revertedWith('Ownable: caller is not the owner')
+    })
+
+    it("Cannot allow the zero address or the LilypadToken", async function () {
+      const {
+        payments,
+        tokenAddress,
+      } = await loadFixture(setupPayments)
+      await expect(payments
+        .connect(getWallet('admin'))
+        .setPaymentToken(ethers.ZeroAddress, true)
+      ).to.be.revertedWith('LilypadPayments: Payment token must be defined')
+      await expect(payments
+        .connect(getWallet('admin'))
+        .setPaymentToken(tokenAddress, true)
+      ).to.be.revertedWith('LilypadPayments: The LilypadToken is always allowed')
+    })
+
+    it("Should reject deals paid in a token that is not allowed", async function () {
+      const { payments } = await loadFixture(setupPayments)
+      const paymentToken = await setupPaymentTokenFixture({
+        paymentsAddress: await payments.getAddress(),
+      })
+      await expect(payments
+        .setDealPaymentToken(dealID, await paymentToken.getAddress())
+      ).to.be.revertedWith('LilypadPayments: Payment token is not allowed')
+      expect(await payments.getDealPaymentToken(dealID)).to.equal(ethers.ZeroAddress)
+    })
+
+    it("Should escrow the payment token on agree", async function () {
+      const {
+        payments,
+        token,
+        paymentToken,
+        paymentTokenAddress,
+      } = await loadFixture(setupPaymentsWithPaymentToken)
+      const paymentsAddress = await payments.getAddress()
+
+      expect(await payments.getDealPaymentToken(dealID)).to.equal(paymentTokenAddress)
+      const lilypadBalancesBefore = await getBalances(token, 'job_creator')
+      const [jcBefore] = await getPaymentTokenBalances(paymentToken, ['job_creator'])
+
+      await expect(payments
+        .connect(getWallet('job_creator'))
+        .agreeJobCreator(
+          dealID,
+          getAddress('job_creator'),
+          paymentCollateral,
+          timeoutCollateral,
+        )
+      )
+        .to.emit(payments, 'Payment')
+        .withArgs(
+          dealID,
+          getAddress('job_creator'),
+          paymentCollateral,
+          getPaymentReason('PaymentCollateral'),
+          getPaymentDirection('PaidIn'),
+        )
+        .to.emit(paymentToken, 'Transfer')
+        .withArgs(
+          getAddress('job_creator'),
+          paymentsAddress,
+          paymentCollateral,
+        )
+
+      const [jcAfter] = await getPaymentTokenBalances(paymentToken, ['job_creator'])
+      expect(jcAfter).to.equal(jcBefore - paymentCollateral - timeoutCollateral)
+      expect(await paymentToken.balanceOf(paymentsAddress)).to.equal(paymentCollateral + timeoutCollateral)
+
+      // none of it came out of the LilypadToken
+      const lilypadBalancesAfter = await getBalances(token, 'job_creator')
+      expect(lilypadBalancesAfter.tokens).to.equal(lilypadBalancesBefore.tokens)
+      expect(lilypadBalancesAfter.escrow).to.equal(lilypadBalancesBefore.escrow)
+    })
+
+    it("Should pay out in the payment token on accept", async function () {
+      const {
+        payments,
+        paymentToken,
+      } = await loadFixture(setupPaymentTokenWithResults)
+      const paymentsAddress = await payments.getAddress()
+      const [jcBefore, rpBefore] = await getPaymentTokenBalances(paymentToken, ['job_creator', 'resource_provider'])
+
+      await expect(payments
+        .connect(getWallet('job_creator'))
+        .acceptResult(
+          dealID,
+          getAddress('resource_provider'),
+          getAddress('job_creator'),
+          jobCost,
+          paymentCollateral,
+          resultsCollateral,
+          timeoutCollateral,
+        )
+      )
+        .to.emit(payments, 'Payment')
+        .withArgs(
+          dealID,
+          getAddress('resource_provider'),
+          jobCost,
+          getPaymentReason('JobPayment'),
+          getPaymentDirection('PaidOut'),
+        )
+        .to.emit(paymentToken, 'Transfer')
+        .withArgs(
+          paymentsAddress,
+          getAddress('resource_provider'),
+          jobCost,
+        )
+
+      const [jcAfter, rpAfter] = await getPaymentTokenBalances(paymentToken, ['job_creator', 'resource_provider'])
+      expect(rpAfter).to.equal(rpBefore + jobCost + resultsCollateral)
+      expect(jcAfter).to.equal(jcBefore + paymentCollateral - jobCost + timeoutCollateral)
+      expect(await paymentToken.balanceOf(paymentsAddress)).to.equal(0)
+    })
+
+    it("Should slash in the payment token", async function () {
+      const {
+        payments,
+        paymentToken,
+      } = await loadFixture(setupPaymentTokenWithResults)
+      await payments
+        .connect(getWallet('job_creator'))
+        .checkResult(
+          dealID,
+          getAddress('job_creator'),
+          timeoutCollateral,
+          mediationFee,
+        )
+      const [jcBefore, adminBefore, mediatorBefore] = await getPaymentTokenBalances(paymentToken, ['job_creator', 'admin', 'mediator'])
+
+      await expect(payments
+        .connect(getWallet('mediator'))
+        .mediationRejectResult(
+          dealID,
+          getAddress('resource_provider'),
+          getAddress('job_creator'),
+          paymentCollateral,
+          resultsCollateral,
+          mediationFee,
+        )
+      )
+        .to.emit(payments, 'Payment')
+        .withArgs(
+          dealID,
+          getAddress('resource_provider'),
+          resultsCollateral,
+          getPaymentReason('ResultsCollateral'),
+          getPaymentDirection('Slashed'),
+        )
+
+      // the slashed results collateral goes to the owner the same as the LilypadToken
+      const [jcAfter, adminAfter, mediatorAfter] = await getPaymentTokenBalances(paymentToken, ['job_creator', 'admin', 'mediator'])
+      expect(adminAfter).to.equal(adminBefore + resultsCollateral)
+      expect(mediatorAfter).to.equal(mediatorBefore + mediationFee)
+      expect(jcAfter).to.equal(jcBefore + paymentCollateral)
+      expect(await paymentToken.balanceOf(await payments.getAddress())).to.equal(0)
+    })
+
+  })
+
   describe("Access control", () => {
     it("Can only run agreeResourceProvider if there is a controller address set", async function () {
       const { payments } = await loadFixture(setupPaymentsNoTest)
@@ -1118,5 +1554,20 @@ describe("Payments", () => {
       ).to.be.revertedWith('ControllerOwnable: Only the controller can call this method')
     })
 
+    it("Can only run payMilestone by the controller", async function () {
+      const { payments } = await loadFixture(setupPaymentsNoTestWithController)
+      await expect(payments
+        .connect(getWallet('solver'))
+        .payMilestone(
+          dealID,
+          0,
+          getAddress('resource_provider'),
+          getAddress('job_creator'),
+          ethers.parseEther("1"),
+          ethers.parseEther("1"),
+        )
+      ).to.be.revertedWith('ControllerOwnable: Only the controller can call this method')
+    })
+
   })
 })
\ No newline at end of file
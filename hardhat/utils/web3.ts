@@ -20,6 +20,7 @@ import {
   LilypadUsers,
   ExampleClient,
   LilypadPow,
+  LilypadParameters,
 } from '../typechain-types'
 
 /*
@@ -261,3 +262,16 @@ export async function connectPow() {
 export async function getPoWAddress() {
   return getContractAddress('LilypadPow')
 }
+
+/*
+
+  parameters
+
+*/
+export async function connectParameters() {
+  return connectContract<LilypadParameters>('LilypadParameters')
+}
+
+export async function getParametersAddress() {
+  return getContractAddress('LilypadParameters')
+}
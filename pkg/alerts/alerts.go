@@ -0,0 +1,146 @@
+package alerts
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const ALERT_REQUEST_TIMEOUT = 10 * time.Second
+
+const DEFAULT_PAGERDUTY_URL = "https://events.pagerduty.com/v2/enqueue"
+
+// the PagerDuty severities, Slack just shows it in the text
+const (
+	SeverityCritical = "critical"
+	SeverityError    = "error"
+	SeverityWarning  = "warning"
+	SeverityInfo     = "info"
+)
+
+type AlertOptions struct {
+	// incoming webhook URLs that take a Slack style {"text": "..."} body
+	Webhooks []string
+	// the routing key of a PagerDuty Events API v2 integration
+	// empty means we do not page anyone
+	PagerDutyRoutingKey string
+	// where the PagerDuty events are sent
+	PagerDutyURL string
+}
+
+func (options AlertOptions) Enabled() bool {
+	return len(options.Webhooks) > 0 || options.PagerDutyRoutingKey != ""
+}
+
+type Alert struct {
+	Summary  string
+	Severity string
+	// the address of the operator the alert is about
+	Source string
+	// alerts with the same key are one incident in PagerDuty
+	DedupKey string
+	Details  map[string]string
+}
+
+// sends alerts to everywhere the operator asked for them
+type Alerter struct {
+	options AlertOptions
+	client  *http.Client
+}
+
+func NewAlerter(options AlertOptions) *Alerter {
+	if options.PagerDutyURL == "" {
+		options.PagerDutyURL = DEFAULT_PAGERDUTY_URL
+	}
+	return &Alerter{
+		options: options,
+		client:  &http.Client{Timeout: ALERT_REQUEST_TIMEOUT},
+	}
+}
+
+// try every destination even if one of them fails
+func (alerter *Alerter) Send(alert Alert) error {
+	errs := []error{}
+	for _, webhook := range alerter.options.Webhooks {
+		err := alerter.post(webhook, getWebhookPayload(alert))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("webhook %s: %s", webhook, err.Error()))
+		}
+	}
+	if alerter.options.PagerDutyRoutingKey != "" {
+		err := alerter.post(alerter.options.PagerDutyURL, getPagerDutyPayload(alerter.options.PagerDutyRoutingKey, alert))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("pagerduty: %s", err.Error()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (alerter *Alerter) post(url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	res, err := alerter.client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("returned %s", res.Status)
+	}
+	return nil
+}
+
+type webhookPayload struct {
+	Text string `json:"text"`
+}
+
+func getWebhookPayload(alert Alert) webhookPayload {
+	lines := []string{fmt.Sprintf("[%s] %s", strings.ToUpper(alert.Severity), alert.Summary)}
+	keys := []string{}
+	for key := range alert.Details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		lines = append(lines, fmt.Sprintf("%s: %s", key, alert.Details[key]))
+	}
+	return webhookPayload{Text: strings.Join(lines, "\n")}
+}
+
+// https://developer.pagerduty.com/docs/events-api-v2/trigger-events/
+type pagerDutyPayload struct {
+	RoutingKey  string                `json:"routing_key"`
+	EventAction string                `json:"event_action"`
+	DedupKey    string                `json:"dedup_key,omitempty"`
+	Payload     pagerDutyEventPayload `json:"payload"`
+}
+
+type pagerDutyEventPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+func getPagerDutyPayload(routingKey string, alert Alert) pagerDutyPayload {
+	return pagerDutyPayload{
+		RoutingKey:  routingKey,
+		EventAction: "trigger",
+		DedupKey:    alert.DedupKey,
+		Payload: pagerDutyEventPayload{
+			Summary:       alert.Summary,
+			Source:        alert.Source,
+			Severity:      alert.Severity,
+			CustomDetails: alert.Details,
+		},
+	}
+}
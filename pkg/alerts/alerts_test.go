@@ -0,0 +1,78 @@
+package alerts
+
+import (
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/escrow"
+	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/payments"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const operator = "0x1111111111111111111111111111111111111111"
+
+func slashing(payee string, direction uint8) payments.PaymentsPayment {
+	return payments.PaymentsPayment{
+		DealId:    "deal",
+		Payee:     common.HexToAddress(payee),
+		Amount:    big.NewInt(30),
+		Reason:    escrow.ReasonResultsCollateral,
+		Direction: direction,
+	}
+}
+
+func TestIsSlashingOf(t *testing.T) {
+	assert.True(t, IsSlashingOf(slashing(operator, escrow.DirectionSlashed), operator))
+	assert.False(t, IsSlashingOf(slashing(operator, escrow.DirectionRefunded), operator))
+	assert.False(t, IsSlashingOf(slashing("0x2222222222222222222222222222222222222222", escrow.DirectionSlashed), operator))
+
+	removed := slashing(operator, escrow.DirectionSlashed)
+	removed.Raw.Removed = true
+	assert.False(t, IsSlashingOf(removed, operator))
+}
+
+func TestSendSlashingAlert(t *testing.T) {
+	bodies := map[string]map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		bs, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		body := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(bs, &body))
+		bodies[req.URL.Path] = body
+	}))
+	defer server.Close()
+
+	deal := &data.DealContainer{
+		ID:               "deal",
+		JobCreator:       "0xjc",
+		ResourceProvider: operator,
+		State:            data.GetAgreementStateIndex("TimeoutSubmitResults"),
+		Deal:             data.Deal{JobOffer: data.JobOffer{Module: data.ModuleConfig{Name: "cowsay:v0.0.1"}}},
+	}
+	alert := GetSlashingAlert(slashing(operator, escrow.DirectionSlashed), deal)
+	assert.Equal(t, SeverityCritical, alert.Severity)
+	assert.Equal(t, "cowsay:v0.0.1", alert.Details["module"])
+	assert.Equal(t, "TimeoutSubmitResults", alert.Details["state"])
+
+	alerter := NewAlerter(AlertOptions{
+		Webhooks:            []string{server.URL + "/slack"},
+		PagerDutyRoutingKey: "key",
+		PagerDutyURL:        server.URL + "/pagerduty",
+	})
+	require.NoError(t, alerter.Send(alert))
+
+	assert.Contains(t, bodies["/slack"]["text"], "[CRITICAL] ResultsCollateral of")
+	assert.Contains(t, bodies["/slack"]["text"], "job_creator: 0xjc")
+	assert.Equal(t, "key", bodies["/pagerduty"]["routing_key"])
+	assert.Equal(t, "trigger", bodies["/pagerduty"]["event_action"])
+	payload := bodies["/pagerduty"]["payload"].(map[string]interface{})
+	assert.Equal(t, "critical", payload["severity"])
+	assert.Equal(t, "deal", payload["custom_details"].(map[string]interface{})["deal_id"])
+}
@@ -0,0 +1,102 @@
+package alerts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/escrow"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+	"github.com/lilypad-tech/lilypad/pkg/web3"
+	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/payments"
+)
+
+// watches the payments contract for collateral of ours being slashed
+// the solver keeps every payment of its deals in the store, see GET /slashings
+// so this only has to tell the operator about it as soon as it happens
+type SlashingMonitor struct {
+	address string
+	// how we find out what the deal was, this is the solver client
+	getDeal func(id string) (data.DealContainer, error)
+	alerter *Alerter
+	log     *system.ServiceLogger
+}
+
+func NewSlashingMonitor(
+	service system.Service,
+	address string,
+	getDeal func(id string) (data.DealContainer, error),
+	alerter *Alerter,
+) *SlashingMonitor {
+	return &SlashingMonitor{
+		address: address,
+		getDeal: getDeal,
+		alerter: alerter,
+		log:     system.NewServiceLogger(service),
+	}
+}
+
+func (monitor *SlashingMonitor) Subscribe(events *web3.EventChannels) {
+	events.Payment.SubscribePayment(func(ev payments.PaymentsPayment) {
+		if !IsSlashingOf(ev, monitor.address) {
+			return
+		}
+		monitor.handleSlashing(ev)
+	})
+}
+
+// a slashing that took collateral from the address
+// a payment removed by a reorg never happened
+func IsSlashingOf(ev payments.PaymentsPayment, address string) bool {
+	return !ev.Raw.Removed &&
+		ev.Direction == escrow.DirectionSlashed &&
+		strings.EqualFold(ev.Payee.String(), address)
+}
+
+func (monitor *SlashingMonitor) handleSlashing(ev payments.PaymentsPayment) {
+	var deal *data.DealContainer
+	dealContainer, err := monitor.getDeal(ev.DealId)
+	if err != nil {
+		// we would rather alert without the deal than not at all
+		monitor.log.Error("error getting slashed deal", err)
+	} else {
+		deal = &dealContainer
+	}
+	alert := GetSlashingAlert(ev, deal)
+	monitor.log.Info("collateral slashed", alert.Summary)
+	if monitor.alerter == nil {
+		return
+	}
+	err = monitor.alerter.Send(alert)
+	if err != nil {
+		monitor.log.Error("error sending slashing alert", err)
+	}
+}
+
+// deal can be nil if we could not find out about it
+func GetSlashingAlert(ev payments.PaymentsPayment, deal *data.DealContainer) Alert {
+	payee := ev.Payee.String()
+	details := map[string]string{
+		"deal_id":     ev.DealId,
+		"payee":       payee,
+		"reason":      escrow.ReasonName(ev.Reason),
+		"amount":      ev.Amount.String(),
+		"transaction": ev.Raw.TxHash.String(),
+	}
+	if deal != nil {
+		details["state"] = data.GetAgreementStateString(deal.State)
+		details["job_creator"] = deal.JobCreator
+		details["resource_provider"] = deal.ResourceProvider
+		details["module"] = data.GetModuleLabel(deal.Deal.JobOffer.Module)
+		if deal.Mediator != "" {
+			details["mediator"] = deal.Mediator
+		}
+	}
+	return Alert{
+		Summary:  fmt.Sprintf("%s of %s slashed on deal %s", escrow.ReasonName(ev.Reason), payee, ev.DealId),
+		Severity: SeverityCritical,
+		Source:   payee,
+		DedupKey: fmt.Sprintf("slashing-%s-%d", ev.Raw.TxHash.String(), ev.Raw.Index),
+		Details:  details,
+	}
+}
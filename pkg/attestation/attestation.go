@@ -0,0 +1,143 @@
+package attestation
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+// the size of the user data every TEE type puts in its report
+const REPORT_DATA_SIZE = 64
+
+// where each part of a report is, these follow the Intel DCAP quote
+// formats and the AMD SEV-SNP attestation report layout
+const (
+	quoteHeaderSize  = 48
+	quoteVersionSize = 2
+	teeTypeOffset    = 4
+
+	sgxQuoteVersion      = 3
+	sgxReportBodySize    = 384
+	sgxMeasurementOffset = quoteHeaderSize + 64
+	sgxMeasurementSize   = 32
+	sgxReportDataOffset  = quoteHeaderSize + 320
+
+	tdxQuoteVersion      = 4
+	tdxTeeType           = 0x81
+	tdxReportBodySize    = 584
+	tdxMeasurementOffset = quoteHeaderSize + 136
+	tdxMeasurementSize   = 48
+	tdxReportDataOffset  = quoteHeaderSize + 520
+
+	snpReportSize         = 0x4A0
+	snpVersionFieldLength = 4
+	snpMinimumVersion     = 2
+	snpReportDataOffset   = 0x50
+	snpMeasurementOffset  = 0x90
+	snpMeasurementSize    = 48
+)
+
+// what we read out of a quote or report
+type Report struct {
+	Measurement []byte
+	ReportData  []byte
+}
+
+// the report data a resource provider puts in its evidence
+// this ties the evidence to its address so it cannot be used by anyone else
+func GetReportData(resourceProvider string) []byte {
+	reportData := make([]byte, REPORT_DATA_SIZE)
+	copy(reportData, crypto.Keccak256(common.HexToAddress(resourceProvider).Bytes()))
+	return reportData
+}
+
+func ParseReport(teeType string, evidence []byte) (Report, error) {
+	switch teeType {
+	case data.TEETypeSGX:
+		if len(evidence) < quoteHeaderSize+sgxReportBodySize {
+			return Report{}, fmt.Errorf("sgx quote is %d bytes which is too short", len(evidence))
+		}
+		if version := binary.LittleEndian.Uint16(evidence[:quoteVersionSize]); version != sgxQuoteVersion {
+			return Report{}, fmt.Errorf("sgx quote version %d is not supported", version)
+		}
+		return Report{
+			Measurement: evidence[sgxMeasurementOffset : sgxMeasurementOffset+sgxMeasurementSize],
+			ReportData:  evidence[sgxReportDataOffset : sgxReportDataOffset+REPORT_DATA_SIZE],
+		}, nil
+	case data.TEETypeTDX:
+		if len(evidence) < quoteHeaderSize+tdxReportBodySize {
+			return Report{}, fmt.Errorf("tdx quote is %d bytes which is too short", len(evidence))
+		}
+		if version := binary.LittleEndian.Uint16(evidence[:quoteVersionSize]); version != tdxQuoteVersion {
+			return Report{}, fmt.Errorf("tdx quote version %d is not supported", version)
+		}
+		if teeType := binary.LittleEndian.Uint32(evidence[teeTypeOffset : teeTypeOffset+4]); teeType != tdxTeeType {
+			return Report{}, fmt.Errorf("quote is for TEE type %#x not tdx", teeType)
+		}
+		return Report{
+			Measurement: evidence[tdxMeasurementOffset : tdxMeasurementOffset+tdxMeasurementSize],
+			ReportData:  evidence[tdxReportDataOffset : tdxReportDataOffset+REPORT_DATA_SIZE],
+		}, nil
+	case data.TEETypeSEVSNP:
+		if len(evidence) < snpReportSize {
+			return Report{}, fmt.Errorf("sev-snp report is %d bytes which is too short", len(evidence))
+		}
+		if version := binary.LittleEndian.Uint32(evidence[:snpVersionFieldLength]); version < snpMinimumVersion {
+			return Report{}, fmt.Errorf("sev-snp report version %d is not supported", version)
+		}
+		return Report{
+			Measurement: evidence[snpMeasurementOffset : snpMeasurementOffset+snpMeasurementSize],
+			ReportData:  evidence[snpReportDataOffset : snpReportDataOffset+REPORT_DATA_SIZE],
+		}, nil
+	default:
+		return Report{}, data.CheckTEEType(teeType)
+	}
+}
+
+// check the evidence is a report of the type it claims to be, that it
+// has the measurement the offer says it has and that it was made for
+// the resource provider posting it
+// none of this means anything unless the signatures are checked too
+// which is why only the Verifier calls this
+func checkReport(attestation data.TEEAttestation, evidence []byte, resourceProvider string) error {
+	report, err := ParseReport(attestation.Type, evidence)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(hex.EncodeToString(report.Measurement), strings.TrimPrefix(attestation.Measurement, "0x")) {
+		return fmt.Errorf("%s evidence has a different measurement to the one given", attestation.Type)
+	}
+	if !bytes.Equal(report.ReportData, GetReportData(resourceProvider)) {
+		return fmt.Errorf("%s evidence was not made for resource provider %s", attestation.Type, resourceProvider)
+	}
+	return nil
+}
+
+// produce evidence that we are running inside a TEE of the given type
+// the certificates are PEM and only needed for sev-snp whose reports do
+// not carry the VCEK and ASK they are signed with, DCAP quotes carry theirs
+func NewAttestation(teeType string, resourceProvider string, certificates string, now time.Time) (data.TEEAttestation, error) {
+	evidence, err := GetEvidence(teeType, GetReportData(resourceProvider))
+	if err != nil {
+		return data.TEEAttestation{}, err
+	}
+	report, err := ParseReport(teeType, evidence)
+	if err != nil {
+		return data.TEEAttestation{}, err
+	}
+	return data.TEEAttestation{
+		Type:         teeType,
+		Measurement:  hex.EncodeToString(report.Measurement),
+		Evidence:     base64.StdEncoding.EncodeToString(evidence),
+		Certificates: certificates,
+		CreatedAt:    now.Unix(),
+	}, nil
+}
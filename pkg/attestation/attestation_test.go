@@ -0,0 +1,317 @@
+package attestation
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+const testResourceProvider = "0x2546BcD3c84621e976D8185a91A922aE77ECEc30"
+
+type testCertificate struct {
+	certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+}
+
+func newTestCertificate(t *testing.T, name string, curve elliptic.Curve, parent *testCertificate) testCertificate {
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	signer := testCertificate{certificate: template, key: key}
+	if parent != nil {
+		signer = *parent
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer.certificate, &key.PublicKey, signer.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testCertificate{certificate: certificate, key: key}
+}
+
+func encodeCertificates(certificates ...testCertificate) []byte {
+	bs := []byte{}
+	for _, certificate := range certificates {
+		bs = append(bs, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.certificate.Raw})...)
+	}
+	return bs
+}
+
+// stand ins for the Intel and AMD certificate chains
+type testPKI struct {
+	intelRoot testCertificate
+	pck       []testCertificate
+	amdRoot   testCertificate
+	vcek      []testCertificate
+}
+
+func newTestPKI(t *testing.T) testPKI {
+	intelRoot := newTestCertificate(t, "Intel SGX Root CA", elliptic.P256(), nil)
+	platform := newTestCertificate(t, "Intel SGX PCK Platform CA", elliptic.P256(), &intelRoot)
+	pck := newTestCertificate(t, "Intel SGX PCK Certificate", elliptic.P256(), &platform)
+	ark := newTestCertificate(t, "ARK-Milan", elliptic.P384(), nil)
+	ask := newTestCertificate(t, "SEV-Milan", elliptic.P384(), &ark)
+	vcek := newTestCertificate(t, "SEV-VCEK", elliptic.P384(), &ask)
+	return testPKI{
+		intelRoot: intelRoot,
+		pck:       []testCertificate{pck, platform, intelRoot},
+		amdRoot:   ark,
+		vcek:      []testCertificate{vcek, ask},
+	}
+}
+
+func (pki testPKI) verifier() *Verifier {
+	intelRoots := x509.NewCertPool()
+	intelRoots.AddCert(pki.intelRoot.certificate)
+	amdRoots := x509.NewCertPool()
+	amdRoots.AddCert(pki.amdRoot.certificate)
+	return &Verifier{intelRoots: intelRoots, amdRoots: amdRoots}
+}
+
+func signP256(t *testing.T, key *ecdsa.PrivateKey, message []byte) []byte {
+	digest := sha256.Sum256(message)
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature := make([]byte, ecdsaP256SignatureSize)
+	r.FillBytes(signature[:ecdsaP256SignatureSize/2])
+	s.FillBytes(signature[ecdsaP256SignatureSize/2:])
+	return signature
+}
+
+func appendUint16(bs []byte, value int) []byte {
+	return binary.LittleEndian.AppendUint16(bs, uint16(value))
+}
+
+func appendUint32(bs []byte, value int) []byte {
+	return binary.LittleEndian.AppendUint32(bs, uint32(value))
+}
+
+// the signature data a quoting enclave adds to the header and body
+func signQuote(t *testing.T, pki testPKI, signed []byte, wrappedQEReport bool) []byte {
+	attestationKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attestationKeyBytes := make([]byte, ecdsaP256KeySize)
+	attestationKey.X.FillBytes(attestationKeyBytes[:ecdsaP256KeySize/2])
+	attestationKey.Y.FillBytes(attestationKeyBytes[ecdsaP256KeySize/2:])
+	qeAuthData := []byte("qe authentication data")
+	qeReport := make([]byte, qeReportSize)
+	keyHash := sha256.Sum256(append(append([]byte{}, attestationKeyBytes...), qeAuthData...))
+	copy(qeReport[qeReportDataOffset:], keyHash[:])
+
+	pckChain := encodeCertificates(pki.pck...)
+	qeCertificationData := append([]byte{}, qeReport...)
+	qeCertificationData = append(qeCertificationData, signP256(t, pki.pck[0].key, qeReport)...)
+	qeCertificationData = appendUint16(qeCertificationData, len(qeAuthData))
+	qeCertificationData = append(qeCertificationData, qeAuthData...)
+	qeCertificationData = appendUint16(qeCertificationData, certificationDataPCKChain)
+	qeCertificationData = appendUint32(qeCertificationData, len(pckChain))
+	qeCertificationData = append(qeCertificationData, pckChain...)
+
+	signatureData := append(signP256(t, attestationKey, signed), attestationKeyBytes...)
+	if wrappedQEReport {
+		signatureData = appendUint16(signatureData, certificationDataQEReport)
+		signatureData = appendUint32(signatureData, len(qeCertificationData))
+	}
+	signatureData = append(signatureData, qeCertificationData...)
+
+	quote := append([]byte{}, signed...)
+	quote = appendUint32(quote, len(signatureData))
+	return append(quote, signatureData...)
+}
+
+func signSNPReport(t *testing.T, pki testPKI, report []byte) {
+	binary.LittleEndian.PutUint32(report[snpSignatureAlgoOffset:], snpSignatureAlgoP384)
+	digest := sha512.Sum384(report[:snpSignatureOffset])
+	r, s, err := ecdsa.Sign(rand.Reader, pki.vcek[0].key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	copy(report[snpSignatureOffset:], reverse(r.FillBytes(make([]byte, snpP384ComponentSize))))
+	copy(report[snpSignatureOffset+snpSignatureComponent:], reverse(s.FillBytes(make([]byte, snpP384ComponentSize))))
+}
+
+// signed evidence of the given type with a known measurement and our report data
+func newTestEvidence(t *testing.T, pki testPKI, teeType string, reportData []byte) ([]byte, []byte, string) {
+	var evidence []byte
+	var measurement []byte
+	certificates := ""
+	setMeasurement := func(offset int, size int) {
+		measurement = make([]byte, size)
+		for i := range measurement {
+			measurement[i] = byte(i + 1)
+		}
+		copy(evidence[offset:], measurement)
+	}
+	switch teeType {
+	case data.TEETypeSGX:
+		evidence = make([]byte, quoteHeaderSize+sgxReportBodySize)
+		binary.LittleEndian.PutUint16(evidence, sgxQuoteVersion)
+		setMeasurement(sgxMeasurementOffset, sgxMeasurementSize)
+		copy(evidence[sgxReportDataOffset:], reportData)
+		evidence = signQuote(t, pki, evidence, false)
+	case data.TEETypeTDX:
+		evidence = make([]byte, quoteHeaderSize+tdxReportBodySize)
+		binary.LittleEndian.PutUint16(evidence, tdxQuoteVersion)
+		binary.LittleEndian.PutUint32(evidence[teeTypeOffset:], tdxTeeType)
+		setMeasurement(tdxMeasurementOffset, tdxMeasurementSize)
+		copy(evidence[tdxReportDataOffset:], reportData)
+		evidence = signQuote(t, pki, evidence, true)
+	case data.TEETypeSEVSNP:
+		evidence = make([]byte, snpReportSize)
+		binary.LittleEndian.PutUint32(evidence, snpMinimumVersion)
+		setMeasurement(snpMeasurementOffset, snpMeasurementSize)
+		copy(evidence[snpReportDataOffset:], reportData)
+		signSNPReport(t, pki, evidence)
+		certificates = string(encodeCertificates(pki.vcek...))
+	default:
+		t.Fatalf("unknown TEE type %s", teeType)
+	}
+	return evidence, measurement, certificates
+}
+
+func TestVerify(t *testing.T) {
+	pki := newTestPKI(t)
+	verifier := pki.verifier()
+	for _, teeType := range data.TEETypes {
+		t.Run(teeType, func(t *testing.T) {
+			evidence, measurement, certificates := newTestEvidence(t, pki, teeType, GetReportData(testResourceProvider))
+			attestation := data.TEEAttestation{
+				Type:         teeType,
+				Measurement:  hex.EncodeToString(measurement),
+				Evidence:     base64.StdEncoding.EncodeToString(evidence),
+				Certificates: certificates,
+			}
+			if err := verifier.Verify(attestation, testResourceProvider); err != nil {
+				t.Fatalf("valid evidence: %v", err)
+			}
+			if err := verifier.Verify(attestation, "0x0000000000000000000000000000000000000001"); err == nil {
+				t.Errorf("expected an error for evidence made for another resource provider")
+			}
+			wrongMeasurement := attestation
+			wrongMeasurement.Measurement = "00"
+			if err := verifier.Verify(wrongMeasurement, testResourceProvider); err == nil {
+				t.Errorf("expected an error for a different measurement")
+			}
+			truncated := attestation
+			truncated.Evidence = base64.StdEncoding.EncodeToString(evidence[:quoteHeaderSize])
+			if err := verifier.Verify(truncated, testResourceProvider); err == nil {
+				t.Errorf("expected an error for truncated evidence")
+			}
+
+			// a forger can write whatever measurement they like into the
+			// report but then the signature no longer covers it
+			forgedMeasurement := make([]byte, len(measurement))
+			forged := append([]byte{}, evidence...)
+			switch teeType {
+			case data.TEETypeSGX:
+				copy(forged[sgxMeasurementOffset:], forgedMeasurement)
+			case data.TEETypeTDX:
+				copy(forged[tdxMeasurementOffset:], forgedMeasurement)
+			case data.TEETypeSEVSNP:
+				copy(forged[snpMeasurementOffset:], forgedMeasurement)
+			}
+			tampered := attestation
+			tampered.Measurement = hex.EncodeToString(forgedMeasurement)
+			tampered.Evidence = base64.StdEncoding.EncodeToString(forged)
+			if err := verifier.Verify(tampered, testResourceProvider); err == nil {
+				t.Errorf("expected an error for a tampered report")
+			}
+
+			// signed by a chain we do not trust
+			other := newTestPKI(t)
+			if err := other.verifier().Verify(attestation, testResourceProvider); err == nil {
+				t.Errorf("expected an error for evidence from an untrusted root")
+			}
+
+			// nothing is trusted without a root
+			if (&Verifier{}).CanVerify(teeType) {
+				t.Errorf("expected a verifier without roots to not verify %s", teeType)
+			}
+			if err := (&Verifier{}).Verify(attestation, testResourceProvider); err == nil {
+				t.Errorf("expected an error without a root CA")
+			}
+		})
+	}
+}
+
+// the attestation key is only trusted because the QE report vouches for it
+func TestVerifySwappedAttestationKey(t *testing.T) {
+	pki := newTestPKI(t)
+	evidence, measurement, _ := newTestEvidence(t, pki, data.TEETypeSGX, GetReportData(testResourceProvider))
+	signedSize := quoteHeaderSize + sgxReportBodySize
+	signatureDataOffset := signedSize + 4
+
+	forgedKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged := append([]byte{}, evidence...)
+	copy(forged[signatureDataOffset:], signP256(t, forgedKey, forged[:signedSize]))
+	forgedKey.X.FillBytes(forged[signatureDataOffset+ecdsaP256SignatureSize : signatureDataOffset+ecdsaP256SignatureSize+ecdsaP256KeySize/2])
+	forgedKey.Y.FillBytes(forged[signatureDataOffset+ecdsaP256SignatureSize+ecdsaP256KeySize/2 : signatureDataOffset+ecdsaP256SignatureSize+ecdsaP256KeySize])
+
+	err = pki.verifier().Verify(data.TEEAttestation{
+		Type:        data.TEETypeSGX,
+		Measurement: hex.EncodeToString(measurement),
+		Evidence:    base64.StdEncoding.EncodeToString(forged),
+	}, testResourceProvider)
+	if err == nil {
+		t.Fatalf("expected an error for an attestation key the QE did not vouch for")
+	}
+}
+
+func TestGetGramineQuote(t *testing.T) {
+	dir := t.TempDir()
+	reportData := GetReportData(testResourceProvider)
+	evidence, _, _ := newTestEvidence(t, newTestPKI(t), data.TEETypeSGX, reportData)
+	err := os.WriteFile(filepath.Join(dir, "quote"), evidence, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	quote, err := getGramineQuote(dir, reportData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	written, err := os.ReadFile(filepath.Join(dir, "user_report_data"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(written) != string(reportData) {
+		t.Errorf("report data was not written for the enclave")
+	}
+	if len(quote) != len(evidence) {
+		t.Errorf("expected the quote back, got %d bytes", len(quote))
+	}
+}
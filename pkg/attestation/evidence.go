@@ -0,0 +1,74 @@
+package attestation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+// the Linux configfs interface SEV-SNP and TDX guests get reports from
+const TSM_REPORT_PATH = "/sys/kernel/config/tsm/report"
+
+// the pseudo files Gramine gives SGX enclaves to get quotes from
+const GRAMINE_ATTESTATION_PATH = "/dev/attestation"
+
+// the configfs provider that backs each TEE type
+var tsmProviders = map[string]string{
+	data.TEETypeSEVSNP: "sev_guest",
+	data.TEETypeTDX:    "tdx_guest",
+}
+
+// ask the TEE we are running in for evidence carrying the report data
+func GetEvidence(teeType string, reportData []byte) ([]byte, error) {
+	if len(reportData) != REPORT_DATA_SIZE {
+		return nil, fmt.Errorf("report data must be %d bytes", REPORT_DATA_SIZE)
+	}
+	switch teeType {
+	case data.TEETypeSGX:
+		return getGramineQuote(GRAMINE_ATTESTATION_PATH, reportData)
+	case data.TEETypeSEVSNP, data.TEETypeTDX:
+		return getTSMReport(TSM_REPORT_PATH, tsmProviders[teeType], reportData)
+	default:
+		return nil, data.CheckTEEType(teeType)
+	}
+}
+
+func getGramineQuote(path string, reportData []byte) ([]byte, error) {
+	err := os.WriteFile(filepath.Join(path, "user_report_data"), reportData, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("could not set sgx report data, are we running in Gramine? %s", err.Error())
+	}
+	quote, err := os.ReadFile(filepath.Join(path, "quote"))
+	if err != nil {
+		return nil, fmt.Errorf("could not read sgx quote: %s", err.Error())
+	}
+	return quote, nil
+}
+
+// each report is made in a fresh directory which is removed after
+func getTSMReport(path string, provider string, reportData []byte) ([]byte, error) {
+	dir, err := os.MkdirTemp(path, "lilypad-")
+	if err != nil {
+		return nil, fmt.Errorf("could not create a TSM report, is configfs-tsm available? %s", err.Error())
+	}
+	defer os.Remove(dir)
+	err = os.WriteFile(filepath.Join(dir, "inblob"), reportData, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("could not set TSM report data: %s", err.Error())
+	}
+	actualProvider, err := os.ReadFile(filepath.Join(dir, "provider"))
+	if err != nil {
+		return nil, fmt.Errorf("could not read TSM provider: %s", err.Error())
+	}
+	if strings.TrimSpace(string(actualProvider)) != provider {
+		return nil, fmt.Errorf("TSM reports come from %s not %s", strings.TrimSpace(string(actualProvider)), provider)
+	}
+	report, err := os.ReadFile(filepath.Join(dir, "outblob"))
+	if err != nil {
+		return nil, fmt.Errorf("could not read TSM report: %s", err.Error())
+	}
+	return report, nil
+}
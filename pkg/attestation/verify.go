@@ -0,0 +1,333 @@
+package attestation
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+// the signed parts of DCAP quotes and SEV-SNP reports
+const (
+	ecdsaP256SignatureSize = 64
+	ecdsaP256KeySize       = 64
+	qeReportSize           = 384
+	qeReportDataOffset     = 320
+	// the QE certification data types we understand
+	certificationDataPCKChain = 5
+	certificationDataQEReport = 6
+
+	snpSignatureAlgoOffset = 0x34
+	snpSignatureAlgoP384   = 1
+	snpSignatureOffset     = 0x2A0
+	snpSignatureComponent  = 72
+	snpP384ComponentSize   = 48
+)
+
+type VerifierOptions struct {
+	// a PEM file of the Intel SGX root CA, the PCK certificate chain in
+	// SGX and TDX quotes has to lead to it
+	IntelRootCAPath string
+	// a PEM file of the AMD root key (ARK), the VCEK and ASK that come
+	// with SEV-SNP reports have to lead to it
+	AMDRootCAPath string
+}
+
+// checks attestations are signed by the hardware they claim to come from
+type Verifier struct {
+	intelRoots *x509.CertPool
+	amdRoots   *x509.CertPool
+}
+
+func NewVerifier(options VerifierOptions) (*Verifier, error) {
+	verifier := &Verifier{}
+	var err error
+	if options.IntelRootCAPath != "" {
+		verifier.intelRoots, err = loadRoots(options.IntelRootCAPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if options.AMDRootCAPath != "" {
+		verifier.amdRoots, err = loadRoots(options.AMDRootCAPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return verifier, nil
+}
+
+func loadRoots(path string) (*x509.CertPool, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading TEE root CA: %s", err.Error())
+	}
+	certificates, err := ParseCertificates(bs)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing TEE root CA %s: %s", path, err.Error())
+	}
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("TEE root CA %s has no certificates", path)
+	}
+	roots := x509.NewCertPool()
+	for _, certificate := range certificates {
+		roots.AddCert(certificate)
+	}
+	return roots, nil
+}
+
+// every certificate in PEM data, in the order they appear
+func ParseCertificates(bs []byte) ([]*x509.Certificate, error) {
+	certificates := []*x509.Certificate{}
+	for {
+		var block *pem.Block
+		block, bs = pem.Decode(bs)
+		if block == nil {
+			return certificates, nil
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+}
+
+func (verifier *Verifier) getRoots(teeType string) *x509.CertPool {
+	switch teeType {
+	case data.TEETypeSGX, data.TEETypeTDX:
+		return verifier.intelRoots
+	case data.TEETypeSEVSNP:
+		return verifier.amdRoots
+	default:
+		return nil
+	}
+}
+
+// without the root of the TEE type we cannot tell real evidence from forged
+func (verifier *Verifier) CanVerify(teeType string) bool {
+	return verifier.getRoots(teeType) != nil
+}
+
+// check the evidence is a report of the type it claims to be, that it is
+// signed by a chain leading to the root we trust for that type, that it
+// has the measurement the offer says it has and that it was made for
+// the resource provider posting it
+func (verifier *Verifier) Verify(attestation data.TEEAttestation, resourceProvider string) error {
+	roots := verifier.getRoots(attestation.Type)
+	if roots == nil {
+		return fmt.Errorf("no root CA is configured for %s so its evidence cannot be verified", attestation.Type)
+	}
+	evidence, err := base64.StdEncoding.DecodeString(attestation.Evidence)
+	if err != nil {
+		return fmt.Errorf("invalid %s evidence: %s", attestation.Type, err.Error())
+	}
+	err = checkReport(attestation, evidence, resourceProvider)
+	if err != nil {
+		return err
+	}
+	switch attestation.Type {
+	case data.TEETypeSGX:
+		return verifyQuote(evidence, quoteHeaderSize+sgxReportBodySize, false, roots)
+	case data.TEETypeTDX:
+		return verifyQuote(evidence, quoteHeaderSize+tdxReportBodySize, true, roots)
+	case data.TEETypeSEVSNP:
+		certificates, err := ParseCertificates([]byte(attestation.Certificates))
+		if err != nil {
+			return fmt.Errorf("invalid sev-snp certificates: %s", err.Error())
+		}
+		return verifySNPReport(evidence, certificates, roots)
+	default:
+		return data.CheckTEEType(attestation.Type)
+	}
+}
+
+// reads what it is given in order and fails once it runs out
+type quoteReader struct {
+	bs  []byte
+	err error
+}
+
+func (reader *quoteReader) next(name string, size int) []byte {
+	if reader.err != nil {
+		return nil
+	}
+	if len(reader.bs) < size {
+		reader.err = fmt.Errorf("quote is too short for its %s", name)
+		return nil
+	}
+	value := reader.bs[:size]
+	reader.bs = reader.bs[size:]
+	return value
+}
+
+func (reader *quoteReader) uint16(name string) int {
+	value := reader.next(name, 2)
+	if value == nil {
+		return 0
+	}
+	return int(binary.LittleEndian.Uint16(value))
+}
+
+func (reader *quoteReader) uint32(name string) int {
+	value := reader.next(name, 4)
+	if value == nil {
+		return 0
+	}
+	return int(binary.LittleEndian.Uint32(value))
+}
+
+// the certification data of a quote which is a type, a size and then the data
+func (reader *quoteReader) certificationData(wantType int) []byte {
+	certificationType := reader.uint16("certification data type")
+	size := reader.uint32("certification data size")
+	value := reader.next("certification data", size)
+	if reader.err == nil && certificationType != wantType {
+		reader.err = fmt.Errorf("quote has certification data type %d not %d", certificationType, wantType)
+	}
+	return value
+}
+
+// an ECDSA quote signs the header and body with the attestation key, the
+// quoting enclave vouches for that key in its report and the PCK key
+// the report is signed with is certified by Intel
+// version 4 quotes (tdx) wrap the QE report in certification data
+func verifyQuote(evidence []byte, signedSize int, wrappedQEReport bool, roots *x509.CertPool) error {
+	reader := &quoteReader{bs: evidence[signedSize:]}
+	signatureDataLength := reader.uint32("signature data length")
+	signatureData := reader.next("signature data", signatureDataLength)
+	if reader.err != nil {
+		return reader.err
+	}
+	reader = &quoteReader{bs: signatureData}
+	quoteSignature := reader.next("quote signature", ecdsaP256SignatureSize)
+	attestationKeyBytes := reader.next("attestation key", ecdsaP256KeySize)
+	if wrappedQEReport {
+		reader = &quoteReader{bs: reader.certificationData(certificationDataQEReport), err: reader.err}
+	}
+	qeReport := reader.next("QE report", qeReportSize)
+	qeReportSignature := reader.next("QE report signature", ecdsaP256SignatureSize)
+	qeAuthData := reader.next("QE authentication data", reader.uint16("QE authentication data size"))
+	pckChain := reader.certificationData(certificationDataPCKChain)
+	if reader.err != nil {
+		return reader.err
+	}
+
+	attestationKey, err := getP256Key(attestationKeyBytes)
+	if err != nil {
+		return err
+	}
+	if !verifyP256(attestationKey, evidence[:signedSize], quoteSignature) {
+		return fmt.Errorf("quote is not signed by its attestation key")
+	}
+
+	// the QE puts a hash of the attestation key in its report so the
+	// key is only trusted if the report is
+	keyHash := sha256.Sum256(append(append([]byte{}, attestationKeyBytes...), qeAuthData...))
+	if !bytes.Equal(qeReport[qeReportDataOffset:qeReportDataOffset+len(keyHash)], keyHash[:]) {
+		return fmt.Errorf("QE report does not vouch for the attestation key")
+	}
+
+	certificates, err := ParseCertificates(pckChain)
+	if err != nil {
+		return fmt.Errorf("invalid PCK certificate chain: %s", err.Error())
+	}
+	pck, err := verifyChain(certificates, roots)
+	if err != nil {
+		return fmt.Errorf("PCK certificate: %s", err.Error())
+	}
+	pckKey, ok := pck.PublicKey.(*ecdsa.PublicKey)
+	if !ok || pckKey.Curve != elliptic.P256() {
+		return fmt.Errorf("PCK certificate does not have a P-256 key")
+	}
+	if !verifyP256(pckKey, qeReport, qeReportSignature) {
+		return fmt.Errorf("QE report is not signed by the PCK certificate")
+	}
+	return nil
+}
+
+// the report is signed by the VCEK of the chip it ran on
+// which is certified by the ASK and that by the ARK
+func verifySNPReport(evidence []byte, certificates []*x509.Certificate, roots *x509.CertPool) error {
+	if algo := binary.LittleEndian.Uint32(evidence[snpSignatureAlgoOffset:]); algo != snpSignatureAlgoP384 {
+		return fmt.Errorf("sev-snp report signature algorithm %d is not supported", algo)
+	}
+	vcek, err := verifyChain(certificates, roots)
+	if err != nil {
+		return fmt.Errorf("VCEK certificate: %s", err.Error())
+	}
+	vcekKey, ok := vcek.PublicKey.(*ecdsa.PublicKey)
+	if !ok || vcekKey.Curve != elliptic.P384() {
+		return fmt.Errorf("VCEK certificate does not have a P-384 key")
+	}
+	// the components are little endian and padded out to 72 bytes
+	signature := evidence[snpSignatureOffset:]
+	r := new(big.Int).SetBytes(reverse(signature[:snpP384ComponentSize]))
+	s := new(big.Int).SetBytes(reverse(signature[snpSignatureComponent : snpSignatureComponent+snpP384ComponentSize]))
+	digest := sha512.Sum384(evidence[:snpSignatureOffset])
+	if !ecdsa.Verify(vcekKey, digest[:], r, s) {
+		return fmt.Errorf("sev-snp report is not signed by the VCEK")
+	}
+	return nil
+}
+
+// the first certificate is the leaf and the rest lead to one of the roots
+func verifyChain(certificates []*x509.Certificate, roots *x509.CertPool) (*x509.Certificate, error) {
+	if len(certificates) == 0 {
+		return nil, fmt.Errorf("there are no certificates")
+	}
+	intermediates := x509.NewCertPool()
+	for _, certificate := range certificates[1:] {
+		intermediates.AddCert(certificate)
+	}
+	_, err := certificates[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return certificates[0], nil
+}
+
+func getP256Key(bs []byte) (*ecdsa.PublicKey, error) {
+	key := &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(bs[:ecdsaP256KeySize/2]),
+		Y:     new(big.Int).SetBytes(bs[ecdsaP256KeySize/2:]),
+	}
+	if !key.Curve.IsOnCurve(key.X, key.Y) {
+		return nil, fmt.Errorf("attestation key is not a P-256 point")
+	}
+	return key, nil
+}
+
+// quote signatures are r and s as 32 big endian bytes each
+func verifyP256(key *ecdsa.PublicKey, message []byte, signature []byte) bool {
+	digest := sha256.Sum256(message)
+	r := new(big.Int).SetBytes(signature[:ecdsaP256SignatureSize/2])
+	s := new(big.Int).SetBytes(signature[ecdsaP256SignatureSize/2:])
+	return ecdsa.Verify(key, digest[:], r, s)
+}
+
+func reverse(bs []byte) []byte {
+	reversed := make([]byte, len(bs))
+	for i, b := range bs {
+		reversed[len(bs)-1-i] = b
+	}
+	return reversed
+}
@@ -0,0 +1,98 @@
+// Package bus is an optional message bus the solver and the services that
+// talk to it can use in place of polling and websockets. Offers are sent as
+// requests and the solver broadcasts its events to whoever is subscribed.
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	DEFAULT_SUBJECT_PREFIX = "lilypad"
+	// how long a request waits for an answer when the context has no deadline
+	DEFAULT_REQUEST_TIMEOUT = 30 * time.Second
+)
+
+var ErrClosed = errors.New("bus is closed")
+
+type BusOptions struct {
+	// where the bus is e.g. nats://localhost:4222
+	// empty means the bus is not used
+	URL string
+	// put in front of every subject so that several networks can share a bus
+	SubjectPrefix string
+}
+
+type Message struct {
+	Subject string
+	// where to send an answer, empty when nobody is waiting for one
+	Reply string
+	Data  []byte
+}
+
+type Bus interface {
+	Publish(subject string, data []byte) error
+	// send a message and wait for the first answer
+	Request(ctx context.Context, subject string, data []byte) ([]byte, error)
+	// the handler is called in the order messages arrive, the returned
+	// function stops the subscription
+	Subscribe(subject string, handler func(Message)) (func(), error)
+	Close() error
+}
+
+func NewBus(options BusOptions) (Bus, error) {
+	u, err := url.Parse(options.URL)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "nats", "tls":
+		return NewNATSBus(options.URL)
+	default:
+		return nil, fmt.Errorf("unknown bus %s", options.URL)
+	}
+}
+
+// the subjects everyone agrees on, they all start with the prefix
+type Subjects struct {
+	prefix string
+}
+
+func NewSubjects(prefix string) Subjects {
+	if prefix == "" {
+		prefix = DEFAULT_SUBJECT_PREFIX
+	}
+	return Subjects{prefix: prefix}
+}
+
+// requests to add a job offer
+func (subjects Subjects) JobOffers() string {
+	return subjects.prefix + ".solver.job_offers"
+}
+
+// requests to add a resource offer
+func (subjects Subjects) ResourceOffers() string {
+	return subjects.prefix + ".solver.resource_offers"
+}
+
+// resource providers on the bus say they are still there
+func (subjects Subjects) Heartbeats() string {
+	return subjects.prefix + ".solver.heartbeats"
+}
+
+// every event the solver has
+func (subjects Subjects) Events() string {
+	return subjects.prefix + ".solver.events"
+}
+
+// the context we wait for an answer with
+func requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, DEFAULT_REQUEST_TIMEOUT)
+}
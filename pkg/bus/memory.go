@@ -0,0 +1,139 @@
+package bus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// a bus that only reaches this process, it is what the tests use and lets
+// services that run together share a bus without a server
+type MemoryBus struct {
+	mutex   sync.Mutex
+	subs    map[int]*memorySubscription
+	nextID  int
+	inboxes int
+	closed  bool
+}
+
+type memorySubscription struct {
+	subject  string
+	messages chan Message
+	done     chan struct{}
+}
+
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{
+		subs: map[int]*memorySubscription{},
+	}
+}
+
+func (b *MemoryBus) Publish(subject string, data []byte) error {
+	return b.publish(Message{Subject: subject, Data: append([]byte{}, data...)})
+}
+
+func (b *MemoryBus) publish(msg Message) error {
+	b.mutex.Lock()
+	if b.closed {
+		b.mutex.Unlock()
+		return ErrClosed
+	}
+	subs := []*memorySubscription{}
+	for _, sub := range b.subs {
+		if sub.subject == msg.Subject {
+			subs = append(subs, sub)
+		}
+	}
+	b.mutex.Unlock()
+	// a handler can publish so we must not hold the lock while we wait
+	for _, sub := range subs {
+		select {
+		case sub.messages <- msg:
+		case <-sub.done:
+		}
+	}
+	return nil
+}
+
+func (b *MemoryBus) Request(ctx context.Context, subject string, data []byte) ([]byte, error) {
+	ctx, cancel := requestContext(ctx)
+	defer cancel()
+	b.mutex.Lock()
+	b.inboxes++
+	inbox := fmt.Sprintf("_INBOX.%d", b.inboxes)
+	b.mutex.Unlock()
+	answers := make(chan []byte, 1)
+	unsubscribe, err := b.Subscribe(inbox, func(msg Message) {
+		select {
+		case answers <- msg.Data:
+		default:
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer unsubscribe()
+	err = b.publish(Message{Subject: subject, Reply: inbox, Data: append([]byte{}, data...)})
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case answer := <-answers:
+		return answer, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (b *MemoryBus) Subscribe(subject string, handler func(Message)) (func(), error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.closed {
+		return nil, ErrClosed
+	}
+	id := b.nextID
+	b.nextID++
+	sub := &memorySubscription{
+		subject:  subject,
+		messages: make(chan Message, 64), //nolint:gomnd
+		done:     make(chan struct{}),
+	}
+	b.subs[id] = sub
+	go func() {
+		for {
+			select {
+			case msg := <-sub.messages:
+				handler(msg)
+			case <-sub.done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mutex.Lock()
+			defer b.mutex.Unlock()
+			// Close has already stopped every subscription
+			if _, ok := b.subs[id]; !ok {
+				return
+			}
+			delete(b.subs, id)
+			close(sub.done)
+		})
+	}, nil
+}
+
+func (b *MemoryBus) Close() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	for id, sub := range b.subs {
+		delete(b.subs, id)
+		close(sub.done)
+	}
+	return nil
+}
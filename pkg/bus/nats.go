@@ -0,0 +1,480 @@
+package bus
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/system"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	NATS_DEFAULT_PORT = "4222"
+	NATS_DIAL_TIMEOUT = 10 * time.Second
+	// the backoff between reconnects doubles up to the max
+	NATS_RECONNECT_WAIT     = time.Second
+	NATS_MAX_RECONNECT_WAIT = 30 * time.Second
+	// how many messages a subscription can have waiting for its handler
+	NATS_SUBSCRIPTION_BUFFER = 256
+)
+
+var ErrNotConnected = errors.New("bus is not connected")
+
+type natsInfo struct {
+	TLSRequired bool `json:"tls_required"`
+	MaxPayload  int  `json:"max_payload"`
+}
+
+type natsConnect struct {
+	Verbose   bool   `json:"verbose"`
+	Pedantic  bool   `json:"pedantic"`
+	Name      string `json:"name"`
+	Lang      string `json:"lang"`
+	Version   string `json:"version"`
+	Protocol  int    `json:"protocol"`
+	User      string `json:"user,omitempty"`
+	Pass      string `json:"pass,omitempty"`
+	AuthToken string `json:"auth_token,omitempty"`
+}
+
+type natsSubscription struct {
+	subject  string
+	messages chan Message
+	done     chan struct{}
+}
+
+// speaks the core NATS protocol, which is all we need for publishing,
+// subscribing and request / reply - there is no JetStream here
+//
+// the connection is made again whenever it drops and the subscriptions
+// are sent again when it is, messages published while we are disconnected
+// are not kept so Publish returns ErrNotConnected
+type NATSBus struct {
+	url *url.URL
+
+	mutex      sync.Mutex
+	conn       net.Conn
+	writer     *bufio.Writer
+	maxPayload int
+	subs       map[int]*natsSubscription
+	nextSID    int
+	inbox      string
+	requests   int
+	// the Flush calls waiting for the server to answer their PING, oldest first
+	pongs  []chan error
+	closed bool
+}
+
+func NewNATSBus(rawURL string) (*NATSBus, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Port() == "" {
+		u.Host = net.JoinHostPort(u.Hostname(), NATS_DEFAULT_PORT)
+	}
+	random := make([]byte, 8) //nolint:gomnd
+	_, err = rand.Read(random)
+	if err != nil {
+		return nil, err
+	}
+	b := &NATSBus{
+		url:   u,
+		subs:  map[int]*natsSubscription{},
+		inbox: "_INBOX." + hex.EncodeToString(random),
+	}
+	conn, reader, err := b.connect()
+	if err != nil {
+		return nil, err
+	}
+	go b.read(conn, reader)
+	return b, nil
+}
+
+func (b *NATSBus) connect() (net.Conn, *bufio.Reader, error) {
+	conn, err := net.DialTimeout("tcp", b.url.Host, NATS_DIAL_TIMEOUT)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = conn.SetDeadline(time.Now().Add(NATS_DIAL_TIMEOUT))
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	reader := bufio.NewReader(conn)
+	line, err := readLine(reader)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		conn.Close()
+		return nil, nil, fmt.Errorf("expected INFO from nats server, got %q", line)
+	}
+	var info natsInfo
+	err = json.Unmarshal([]byte(strings.TrimPrefix(line, "INFO ")), &info)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	if info.TLSRequired || b.url.Scheme == "tls" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: b.url.Hostname(), MinVersion: tls.VersionTLS12})
+		err = tlsConn.Handshake()
+		if err != nil {
+			conn.Close()
+			return nil, nil, err
+		}
+		conn = tlsConn
+		reader = bufio.NewReader(conn)
+	}
+
+	connect := natsConnect{
+		Name:     "lilypad",
+		Lang:     "go",
+		Version:  system.Version,
+		Protocol: 1,
+	}
+	if b.url.User != nil {
+		if password, ok := b.url.User.Password(); ok {
+			connect.User = b.url.User.Username()
+			connect.Pass = password
+		} else {
+			connect.AuthToken = b.url.User.Username()
+		}
+	}
+	connectBytes, err := json.Marshal(connect)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	writer := bufio.NewWriter(conn)
+	fmt.Fprintf(writer, "CONNECT %s\r\nPING\r\n", connectBytes)
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	for sid, sub := range b.subs {
+		fmt.Fprintf(writer, "SUB %s %d\r\n", sub.subject, sid)
+	}
+	err = writer.Flush()
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	// the server answers the PING once it has taken the CONNECT
+	for {
+		line, err := readLine(reader)
+		if err != nil {
+			conn.Close()
+			return nil, nil, err
+		}
+		if line == "PONG" {
+			break
+		}
+		if strings.HasPrefix(line, "-ERR") {
+			conn.Close()
+			return nil, nil, fmt.Errorf("nats server refused us: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+	err = conn.SetDeadline(time.Time{})
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	if b.closed {
+		conn.Close()
+		return nil, nil, ErrClosed
+	}
+	b.conn = conn
+	b.writer = writer
+	b.maxPayload = info.MaxPayload
+	return conn, reader, nil
+}
+
+func readLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// handle what the server sends until the connection drops then make it again
+func (b *NATSBus) read(conn net.Conn, reader *bufio.Reader) {
+	for {
+		err := b.readMessages(reader)
+		conn.Close()
+		b.mutex.Lock()
+		b.conn = nil
+		b.writer = nil
+		for _, pong := range b.pongs {
+			pong <- ErrNotConnected
+		}
+		b.pongs = nil
+		closed := b.closed
+		b.mutex.Unlock()
+		if closed {
+			return
+		}
+		log.Error().Msgf("nats connection lost: %s", err.Error())
+		conn, reader = b.reconnect()
+		if conn == nil {
+			return
+		}
+	}
+}
+
+func (b *NATSBus) reconnect() (net.Conn, *bufio.Reader) {
+	wait := NATS_RECONNECT_WAIT
+	for {
+		time.Sleep(wait)
+		conn, reader, err := b.connect()
+		if err == nil {
+			log.Info().Msgf("nats connection back")
+			return conn, reader
+		}
+		if errors.Is(err, ErrClosed) {
+			return nil, nil
+		}
+		log.Error().Msgf("error reconnecting to nats, retry in %s: %s", wait, err.Error())
+		wait *= 2
+		if wait > NATS_MAX_RECONNECT_WAIT {
+			wait = NATS_MAX_RECONNECT_WAIT
+		}
+	}
+}
+
+func (b *NATSBus) readMessages(reader *bufio.Reader) error {
+	for {
+		line, err := readLine(reader)
+		if err != nil {
+			return err
+		}
+		switch {
+		case strings.HasPrefix(line, "MSG "):
+			// MSG <subject> <sid> [reply-to] <#bytes>
+			parts := strings.Fields(line)
+			if len(parts) != 4 && len(parts) != 5 {
+				return fmt.Errorf("bad MSG from nats server: %q", line)
+			}
+			size, err := strconv.Atoi(parts[len(parts)-1])
+			if err != nil {
+				return err
+			}
+			sid, err := strconv.Atoi(parts[2])
+			if err != nil {
+				return err
+			}
+			payload := make([]byte, size+2) //nolint:gomnd
+			_, err = io.ReadFull(reader, payload)
+			if err != nil {
+				return err
+			}
+			msg := Message{Subject: parts[1], Data: payload[:size]}
+			if len(parts) == 5 {
+				msg.Reply = parts[3]
+			}
+			b.deliver(sid, msg)
+		case line == "PONG":
+			b.mutex.Lock()
+			if len(b.pongs) > 0 {
+				b.pongs[0] <- nil
+				b.pongs = b.pongs[1:]
+			}
+			b.mutex.Unlock()
+		case line == "PING":
+			err := b.write("PONG\r\n")
+			if err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			log.Error().Msgf("nats server error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+func (b *NATSBus) deliver(sid int, msg Message) {
+	b.mutex.Lock()
+	sub, ok := b.subs[sid]
+	b.mutex.Unlock()
+	if !ok {
+		return
+	}
+	select {
+	case sub.messages <- msg:
+	case <-sub.done:
+	}
+}
+
+func (b *NATSBus) write(format string, args ...interface{}) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.writeLocked([]byte(fmt.Sprintf(format, args...)))
+}
+
+func (b *NATSBus) writeLocked(data []byte) error {
+	if b.closed {
+		return ErrClosed
+	}
+	if b.writer == nil {
+		return ErrNotConnected
+	}
+	_, err := b.writer.Write(data)
+	if err != nil {
+		return err
+	}
+	return b.writer.Flush()
+}
+
+func (b *NATSBus) publish(subject string, reply string, data []byte) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.maxPayload > 0 && len(data) > b.maxPayload {
+		return fmt.Errorf("message of %d bytes is over the nats limit of %d", len(data), b.maxPayload)
+	}
+	header := fmt.Sprintf("PUB %s %d\r\n", subject, len(data))
+	if reply != "" {
+		header = fmt.Sprintf("PUB %s %s %d\r\n", subject, reply, len(data))
+	}
+	message := append([]byte(header), data...)
+	message = append(message, '\r', '\n')
+	return b.writeLocked(message)
+}
+
+func (b *NATSBus) Publish(subject string, data []byte) error {
+	return b.publish(subject, "", data)
+}
+
+func (b *NATSBus) Request(ctx context.Context, subject string, data []byte) ([]byte, error) {
+	ctx, cancel := requestContext(ctx)
+	defer cancel()
+	b.mutex.Lock()
+	b.requests++
+	inbox := fmt.Sprintf("%s.%d", b.inbox, b.requests)
+	b.mutex.Unlock()
+	answers := make(chan []byte, 1)
+	unsubscribe, err := b.Subscribe(inbox, func(msg Message) {
+		select {
+		case answers <- msg.Data:
+		default:
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer unsubscribe()
+	err = b.publish(subject, inbox, data)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case answer := <-answers:
+		return answer, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (b *NATSBus) Subscribe(subject string, handler func(Message)) (func(), error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.closed {
+		return nil, ErrClosed
+	}
+	b.nextSID++
+	sid := b.nextSID
+	sub := &natsSubscription{
+		subject:  subject,
+		messages: make(chan Message, NATS_SUBSCRIPTION_BUFFER),
+		done:     make(chan struct{}),
+	}
+	b.subs[sid] = sub
+	// while we are disconnected the subscription is sent when we connect again
+	if b.writer != nil {
+		err := b.writeLocked([]byte(fmt.Sprintf("SUB %s %d\r\n", subject, sid)))
+		if err != nil {
+			delete(b.subs, sid)
+			return nil, err
+		}
+	}
+	go func() {
+		for {
+			select {
+			case msg := <-sub.messages:
+				handler(msg)
+			case <-sub.done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mutex.Lock()
+			defer b.mutex.Unlock()
+			// Close has already stopped every subscription
+			if _, ok := b.subs[sid]; !ok {
+				return
+			}
+			delete(b.subs, sid)
+			close(sub.done)
+			if b.writer != nil {
+				err := b.writeLocked([]byte(fmt.Sprintf("UNSUB %d\r\n", sid)))
+				if err != nil {
+					log.Debug().Msgf("error unsubscribing from %s: %s", subject, err.Error())
+				}
+			}
+		})
+	}, nil
+}
+
+// wait until the server has handled everything we have sent it, after this
+// a subscription we made will see messages anyone publishes
+func (b *NATSBus) Flush(ctx context.Context) error {
+	ctx, cancel := requestContext(ctx)
+	defer cancel()
+	pong := make(chan error, 1)
+	b.mutex.Lock()
+	err := b.writeLocked([]byte("PING\r\n"))
+	if err != nil {
+		b.mutex.Unlock()
+		return err
+	}
+	b.pongs = append(b.pongs, pong)
+	b.mutex.Unlock()
+	select {
+	case err := <-pong:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (b *NATSBus) Close() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	for sid, sub := range b.subs {
+		delete(b.subs, sid)
+		close(sub.done)
+	}
+	if b.conn != nil {
+		return b.conn.Close()
+	}
+	return nil
+}
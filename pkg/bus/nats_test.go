@@ -0,0 +1,159 @@
+package bus
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// just enough of a nats server to route messages between connections
+type fakeNATSServer struct {
+	listener net.Listener
+	mutex    sync.Mutex
+	conns    map[net.Conn]map[string]string
+}
+
+func newFakeNATSServer(t *testing.T) *fakeNATSServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := &fakeNATSServer{
+		listener: listener,
+		conns:    map[net.Conn]map[string]string{},
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	t.Cleanup(func() {
+		listener.Close()
+		server.dropAll()
+	})
+	return server
+}
+
+func (server *fakeNATSServer) url() string {
+	return "nats://" + server.listener.Addr().String()
+}
+
+func (server *fakeNATSServer) dropAll() {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	for conn := range server.conns {
+		conn.Close()
+		delete(server.conns, conn)
+	}
+}
+
+func (server *fakeNATSServer) serve(conn net.Conn) {
+	server.mutex.Lock()
+	server.conns[conn] = map[string]string{}
+	server.mutex.Unlock()
+	fmt.Fprintf(conn, "INFO {\"max_payload\":1048576}\r\n")
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := readLine(reader)
+		if err != nil {
+			return
+		}
+		parts := strings.Fields(line)
+		if len(parts) == 0 {
+			continue
+		}
+		switch parts[0] {
+		case "PING":
+			fmt.Fprintf(conn, "PONG\r\n")
+		case "SUB":
+			server.mutex.Lock()
+			server.conns[conn][parts[2]] = parts[1]
+			server.mutex.Unlock()
+		case "UNSUB":
+			server.mutex.Lock()
+			delete(server.conns[conn], parts[1])
+			server.mutex.Unlock()
+		case "PUB":
+			size, _ := strconv.Atoi(parts[len(parts)-1])
+			payload := make([]byte, size+2)
+			_, err := io.ReadFull(reader, payload)
+			if err != nil {
+				return
+			}
+			reply := ""
+			if len(parts) == 4 {
+				reply = parts[2] + " "
+			}
+			server.mutex.Lock()
+			for other, subs := range server.conns {
+				for sid, subject := range subs {
+					if subject == parts[1] {
+						fmt.Fprintf(other, "MSG %s %s %s%d\r\n%s", subject, sid, reply, size, payload)
+					}
+				}
+			}
+			server.mutex.Unlock()
+		}
+	}
+}
+
+func TestNATSBus(t *testing.T) {
+	server := newFakeNATSServer(t)
+	solver, err := NewNATSBus(server.url())
+	require.NoError(t, err)
+	defer solver.Close()
+	client, err := NewNATSBus(server.url())
+	require.NoError(t, err)
+	defer client.Close()
+
+	subjects := NewSubjects("")
+	_, err = solver.Subscribe(subjects.JobOffers(), func(msg Message) {
+		assert.NoError(t, solver.Publish(msg.Reply, append([]byte("added "), msg.Data...)))
+	})
+	require.NoError(t, err)
+	require.NoError(t, solver.Flush(context.Background()))
+	answer, err := client.Request(context.Background(), subjects.JobOffers(), []byte("offer\r\nwith lines"))
+	require.NoError(t, err)
+	assert.Equal(t, "added offer\r\nwith lines", string(answer))
+
+	events := make(chan string, 10)
+	unsubscribe, err := client.Subscribe(subjects.Events(), func(msg Message) {
+		events <- string(msg.Data)
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.Flush(context.Background()))
+	require.NoError(t, solver.Publish(subjects.Events(), []byte("one")))
+	assert.Equal(t, "one", <-events)
+
+	// the subscriptions come back when the connection does
+	server.dropAll()
+	require.Eventually(t, func() bool {
+		return client.Flush(context.Background()) == nil && solver.Flush(context.Background()) == nil
+	}, 10*time.Second, 100*time.Millisecond)
+	require.NoError(t, solver.Publish(subjects.Events(), []byte("two")))
+	assert.Equal(t, "two", <-events)
+
+	unsubscribe()
+	require.NoError(t, client.Flush(context.Background()))
+	require.NoError(t, solver.Publish(subjects.Events(), []byte("three")))
+	require.NoError(t, solver.Flush(context.Background()))
+	require.NoError(t, client.Flush(context.Background()))
+	assert.Empty(t, events)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = client.Request(ctx, "nobody.listening", nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
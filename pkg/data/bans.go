@@ -0,0 +1,86 @@
+package data
+
+import (
+	"fmt"
+	"strings"
+)
+
+// why an address was banned
+const (
+	// flooding the solver with offers it cannot match
+	BanReasonSpam = "spam"
+	// results that were made up or tampered with
+	BanReasonFraud = "fraud"
+	// agreeing to deals and then not running them
+	BanReasonNoShow = "no_show"
+	// not paying for the jobs it ran
+	BanReasonNonPayment = "non_payment"
+	// anything else, the reason says what
+	BanReasonOther = "other"
+)
+
+var BanReasons = []string{
+	BanReasonSpam,
+	BanReasonFraud,
+	BanReasonNoShow,
+	BanReasonNonPayment,
+	BanReasonOther,
+}
+
+// the longest a ban can be given a duration for, longer bans have no expiry
+const MAX_BAN_DURATION = 365 * 24 * 60 * 60
+
+func CheckBanSubmission(submission BanSubmission) error {
+	if submission.Address == "" {
+		return fmt.Errorf("address is required")
+	}
+	found := false
+	for _, code := range BanReasons {
+		if submission.Code == code {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("unknown ban reason code %s, must be one of %s", submission.Code, strings.Join(BanReasons, ", "))
+	}
+	if submission.Code == BanReasonOther && submission.Reason == "" {
+		return fmt.Errorf("a reason is required for a ban with the %s code", BanReasonOther)
+	}
+	if submission.Duration < 0 {
+		return fmt.Errorf("ban duration cannot be negative")
+	}
+	if submission.Duration > MAX_BAN_DURATION {
+		return fmt.Errorf("ban duration %d is more than %d seconds, leave it empty for a ban that does not expire", submission.Duration, MAX_BAN_DURATION)
+	}
+	return nil
+}
+
+func NewBannedAddress(submission BanSubmission, bannedBy string, now int64) BannedAddress {
+	ban := BannedAddress{
+		Address:   submission.Address,
+		Code:      submission.Code,
+		Reason:    submission.Reason,
+		BannedBy:  bannedBy,
+		CreatedAt: now,
+	}
+	if submission.Duration > 0 {
+		ban.ExpiresAt = now + submission.Duration
+	}
+	return ban
+}
+
+func IsBanActive(ban BannedAddress, now int64) bool {
+	return ban.ExpiresAt == 0 || now < ban.ExpiresAt
+}
+
+// the bans that are still in force keyed by lower case address
+func GetActiveBans(bans []BannedAddress, now int64) map[string]BannedAddress {
+	active := map[string]BannedAddress{}
+	for _, ban := range bans {
+		if IsBanActive(ban, now) {
+			active[strings.ToLower(ban.Address)] = ban
+		}
+	}
+	return active
+}
@@ -0,0 +1,47 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckBanSubmission(t *testing.T) {
+	testCases := []struct {
+		name       string
+		submission BanSubmission
+		wantErr    bool
+	}{
+		{name: "Spam", submission: BanSubmission{Address: "0xabc", Code: BanReasonSpam}},
+		{name: "Expiring", submission: BanSubmission{Address: "0xabc", Code: BanReasonNoShow, Duration: 3600}},
+		{name: "No address", submission: BanSubmission{Code: BanReasonSpam}, wantErr: true},
+		{name: "Unknown code", submission: BanSubmission{Address: "0xabc", Code: "bad"}, wantErr: true},
+		{name: "Other without a reason", submission: BanSubmission{Address: "0xabc", Code: BanReasonOther}, wantErr: true},
+		{name: "Negative duration", submission: BanSubmission{Address: "0xabc", Code: BanReasonSpam, Duration: -1}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckBanSubmission(tc.submission)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetActiveBans(t *testing.T) {
+	bans := []BannedAddress{
+		NewBannedAddress(BanSubmission{Address: "0xForever", Code: BanReasonFraud}, "0xadmin", 100),
+		NewBannedAddress(BanSubmission{Address: "0xExpired", Code: BanReasonSpam, Duration: 50}, "0xadmin", 100),
+		NewBannedAddress(BanSubmission{Address: "0xExpiring", Code: BanReasonSpam, Duration: 500}, "0xadmin", 100),
+	}
+
+	active := GetActiveBans(bans, 200)
+	assert.Len(t, active, 2)
+	assert.Contains(t, active, "0xforever")
+	assert.Contains(t, active, "0xexpiring")
+	assert.NotContains(t, active, "0xexpired")
+}
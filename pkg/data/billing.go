@@ -0,0 +1,91 @@
+package data
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// the columns of a billing export in the order they are written
+var BillingCSVHeader = []string{
+	"deal_id",
+	"job_creator",
+	"resource_provider",
+	"module",
+	"chain_id",
+	"instruction_count",
+	"wall_clock_seconds",
+	"gpu",
+	"gpu_seconds",
+	"instruction_price",
+	"price",
+	"error",
+	"created_at",
+}
+
+// a readable name for a module that does not need its CID
+func GetModuleLabel(module ModuleConfig) string {
+	if module.Name != "" {
+		return module.Name
+	}
+	return fmt.Sprintf("%s:%s", module.Repo, module.Hash)
+}
+
+// meter a deal from the result its resource provider sent
+// the price is the same instruction price times count the contract pays out
+func GetBillingRecord(deal DealContainer, result Result, now time.Time) BillingRecord {
+	wallClock := float64(result.Runtime) / 1000 //nolint:gomnd
+	gpu := deal.Deal.JobOffer.Spec.GPU
+	return BillingRecord{
+		DealID:           deal.ID,
+		JobCreator:       deal.JobCreator,
+		ResourceProvider: deal.ResourceProvider,
+		Module:           GetModuleLabel(deal.Deal.JobOffer.Module),
+		ChainID:          deal.ChainID,
+		InstructionCount: result.InstructionCount,
+		WallClockSeconds: wallClock,
+		GPU:              gpu,
+		GPUSeconds:       float64(gpu) / 1000 * wallClock, //nolint:gomnd
+		InstructionPrice: deal.Deal.Pricing.InstructionPrice,
+		Price:            deal.Deal.Pricing.InstructionPrice * result.InstructionCount,
+		Error:            result.Error,
+		CreatedAt:        now.Unix(),
+	}
+}
+
+func formatSeconds(seconds float64) string {
+	return strconv.FormatFloat(seconds, 'f', 3, 64) //nolint:gomnd
+}
+
+// write the records as CSV with a header row
+func WriteBillingCSV(writer io.Writer, records []BillingRecord) error {
+	csvWriter := csv.NewWriter(writer)
+	err := csvWriter.Write(BillingCSVHeader)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		err = csvWriter.Write([]string{
+			record.DealID,
+			record.JobCreator,
+			record.ResourceProvider,
+			record.Module,
+			strconv.Itoa(record.ChainID),
+			strconv.FormatUint(record.InstructionCount, 10),
+			formatSeconds(record.WallClockSeconds),
+			strconv.Itoa(record.GPU),
+			formatSeconds(record.GPUSeconds),
+			strconv.FormatUint(record.InstructionPrice, 10),
+			strconv.FormatUint(record.Price, 10),
+			record.Error,
+			time.Unix(record.CreatedAt, 0).UTC().Format(time.RFC3339),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
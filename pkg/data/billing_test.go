@@ -0,0 +1,40 @@
+package data
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBillingRecord(t *testing.T) {
+	deal := DealContainer{
+		ID:               "deal",
+		JobCreator:       "0xjc",
+		ResourceProvider: "0xrp",
+		Deal: Deal{
+			JobOffer: JobOffer{
+				Module: ModuleConfig{Repo: "https://github.com/lilypad-tech/lilypad-module-cowsay", Hash: "v0.0.4"},
+				Spec:   MachineSpec{GPU: 2000},
+			},
+			Pricing: DealPricing{InstructionPrice: 5},
+		},
+	}
+	result := Result{DealID: "deal", InstructionCount: 3, Runtime: 90500}
+
+	record := GetBillingRecord(deal, result, time.Unix(1700000000, 0))
+	assert.Equal(t, "https://github.com/lilypad-tech/lilypad-module-cowsay:v0.0.4", record.Module)
+	assert.Equal(t, uint64(15), record.Price)
+	assert.Equal(t, 90.5, record.WallClockSeconds)
+	assert.Equal(t, 181.0, record.GPUSeconds)
+
+	var buf bytes.Buffer
+	err := WriteBillingCSV(&buf, []BillingRecord{record})
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"deal_id,job_creator,resource_provider,module,chain_id,instruction_count,wall_clock_seconds,gpu,gpu_seconds,instruction_price,price,error,created_at\n"+
+			"deal,0xjc,0xrp,https://github.com/lilypad-tech/lilypad-module-cowsay:v0.0.4,0,3,90.500,2000,181.000,5,15,,2023-11-14T22:13:20Z\n",
+		buf.String(),
+	)
+}
@@ -0,0 +1,119 @@
+package data
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// budgets are measured over these rolling windows
+const (
+	BUDGET_DAY  = 24 * time.Hour
+	BUDGET_WEEK = 7 * BUDGET_DAY
+)
+
+// the most a job creator can spend, in the units of the deal pricing
+// a zero limit means there is no cap for that window
+type Budget struct {
+	Address string `json:"address"`
+	Daily   uint64 `json:"daily"`
+	Weekly  uint64 `json:"weekly"`
+}
+
+type BudgetWindow struct {
+	// zero means there is no cap
+	Limit uint64 `json:"limit"`
+	// what the deals whose results came in during the window were billed
+	Spent uint64 `json:"spent"`
+	// what is left once the spent and reserved amounts are taken off
+	// this is only meaningful when there is a limit
+	Remaining uint64 `json:"remaining"`
+}
+
+type BudgetStatus struct {
+	Address string `json:"address"`
+	// the payment collateral of the job offers and deals that have not been billed yet
+	// this is the most they can cost so it counts against every window
+	Reserved uint64       `json:"reserved"`
+	Daily    BudgetWindow `json:"daily"`
+	Weekly   BudgetWindow `json:"weekly"`
+}
+
+// the cost we hold a job offer to before we know what it will be billed
+func GetJobOfferReservation(jobOffer JobOffer) uint64 {
+	return jobOffer.Pricing.PaymentCollateral
+}
+
+func CheckBudgets(budgets []Budget) error {
+	for i, budget := range budgets {
+		if !common.IsHexAddress(budget.Address) {
+			return fmt.Errorf("budget %d has %q which is not an address", i, budget.Address)
+		}
+		if budget.Daily > 0 && budget.Weekly > 0 && budget.Daily > budget.Weekly {
+			return fmt.Errorf("budget of %s allows more per day than per week", budget.Address)
+		}
+	}
+	return nil
+}
+
+// the records are the billing records of the address from at least a week ago
+func GetBudgetStatus(budget Budget, records []BillingRecord, reserved uint64, now time.Time) BudgetStatus {
+	status := BudgetStatus{
+		Address:  budget.Address,
+		Reserved: reserved,
+		Daily:    BudgetWindow{Limit: budget.Daily},
+		Weekly:   BudgetWindow{Limit: budget.Weekly},
+	}
+	dayStart := now.Add(-BUDGET_DAY).Unix()
+	weekStart := now.Add(-BUDGET_WEEK).Unix()
+	for _, record := range records {
+		if record.CreatedAt >= weekStart {
+			status.Weekly.Spent += record.Price
+		}
+		if record.CreatedAt >= dayStart {
+			status.Daily.Spent += record.Price
+		}
+	}
+	return status.WithLimits(budget.Daily, budget.Weekly)
+}
+
+// the same spend held to other limits e.g. the ones a job creator sets itself
+// the lower of the two limits is used where both are set
+func (status BudgetStatus) WithLimits(daily uint64, weekly uint64) BudgetStatus {
+	status.Daily = status.Daily.withLimit(daily, status.Reserved)
+	status.Weekly = status.Weekly.withLimit(weekly, status.Reserved)
+	return status
+}
+
+func (window BudgetWindow) withLimit(limit uint64, reserved uint64) BudgetWindow {
+	if limit > 0 && (window.Limit == 0 || limit < window.Limit) {
+		window.Limit = limit
+	}
+	window.Remaining = 0
+	if used := window.Spent + reserved; used < window.Limit {
+		window.Remaining = window.Limit - used
+	}
+	return window
+}
+
+// can the address take on something that could cost this much
+func CheckBudget(status BudgetStatus, cost uint64) error {
+	windows := []struct {
+		name   string
+		window BudgetWindow
+	}{
+		{"daily", status.Daily},
+		{"weekly", status.Weekly},
+	}
+	for _, item := range windows {
+		window := item.window
+		if window.Limit == 0 {
+			continue
+		}
+		if cost > window.Remaining {
+			return NewError(ErrBudgetExceeded, "%s would go over its %s budget of %d, %d is spent, %d is reserved and this could cost %d", status.Address, item.name, window.Limit, window.Spent, status.Reserved, cost)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package data
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBudgetStatus(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	records := []BillingRecord{
+		{Price: 10, CreatedAt: now.Add(-time.Hour).Unix()},
+		{Price: 20, CreatedAt: now.Add(-3 * BUDGET_DAY).Unix()},
+		// too old for either window
+		{Price: 40, CreatedAt: now.Add(-8 * BUDGET_DAY).Unix()},
+	}
+	status := GetBudgetStatus(Budget{Address: "0xjc", Daily: 50, Weekly: 100}, records, 5, now)
+	assert.Equal(t, BudgetWindow{Limit: 50, Spent: 10, Remaining: 35}, status.Daily)
+	assert.Equal(t, BudgetWindow{Limit: 100, Spent: 30, Remaining: 65}, status.Weekly)
+
+	assert.NoError(t, CheckBudget(status, 35))
+	err := CheckBudget(status, 36)
+	assert.True(t, errors.Is(err, ErrBudgetExceeded))
+	assert.ErrorContains(t, err, "daily budget of 50")
+
+	// the job creator can hold itself to less than the solver does
+	tighter := status.WithLimits(0, 40)
+	assert.Equal(t, uint64(50), tighter.Daily.Limit)
+	assert.Equal(t, BudgetWindow{Limit: 40, Spent: 30, Remaining: 5}, tighter.Weekly)
+	assert.ErrorContains(t, CheckBudget(tighter, 6), "weekly budget of 40")
+
+	// no budget is no cap but the spend is still there to see
+	unlimited := GetBudgetStatus(Budget{Address: "0xjc"}, records, 5, now)
+	assert.Equal(t, uint64(30), unlimited.Weekly.Spent)
+	assert.NoError(t, CheckBudget(unlimited, 1000000))
+}
+
+func TestCheckBudgets(t *testing.T) {
+	assert.NoError(t, CheckBudgets([]Budget{{Address: "0x1111111111111111111111111111111111111111", Daily: 10, Weekly: 50}}))
+	assert.Error(t, CheckBudgets([]Budget{{Address: "jc", Daily: 10}}))
+	assert.Error(t, CheckBudgets([]Budget{{Address: "0x1111111111111111111111111111111111111111", Daily: 60, Weekly: 50}}))
+}
@@ -0,0 +1,74 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+
+	mdag "github.com/ipfs/go-merkledag"
+)
+
+// offers, deals and the other records we give IDs to are identified by the
+// CID of their canonical JSON so anyone holding one can check its ID
+//
+// the canonical JSON of a value is:
+//
+//   - the value encoded with its json tags, so fields tagged omitempty are
+//     left out when they are empty, after the fields named by its exclusion
+//     rules are cleared (see the Get*ID functions, an ID never covers itself)
+//     a cleared field without omitempty is still there, e.g. "id":""
+//   - object keys sorted by their bytes, at every depth
+//   - no whitespace between tokens and no trailing newline
+//   - strings with only ", \ and control characters escaped, plus U+2028
+//     and U+2029, < > and & are written as they are
+//   - numbers as they were encoded, integers in full and floats in the
+//     shortest form that reads back the same with exponents from 1e21
+//
+// the CID is the CIDv0 (sha2-256, base58) of a dag-pb node with the
+// canonical JSON as its data and no links
+//
+// the golden vectors in testdata/canonical_cids.json are there for other
+// implementations to check themselves against
+// records stored before keys were sorted keep the IDs they were given
+
+// CanonicalJSON encodes a value as the canonical JSON its CID is taken from
+func CanonicalJSON(v interface{}) ([]byte, error) {
+	encoded, err := encodeJSON(v)
+	if err != nil {
+		return nil, err
+	}
+	// go writes struct fields in the order they are declared so we read
+	// the fields back as a map which it writes with sorted keys
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var generic interface{}
+	err = decoder.Decode(&generic)
+	if err != nil {
+		return nil, err
+	}
+	return encodeJSON(generic)
+}
+
+func encodeJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	err := encoder.Encode(v)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// GetCanonicalCID is the CID of canonical JSON that is already encoded
+func GetCanonicalCID(canonical []byte) string {
+	return mdag.NodeWithData(canonical).Cid().String()
+}
+
+// CalculateCID takes an interface, serializes it to canonical JSON, and returns its IPFS CID
+func CalculateCID(v interface{}) (string, error) {
+	canonical, err := CanonicalJSON(v)
+	if err != nil {
+		return "", err
+	}
+	return GetCanonicalCID(canonical), nil
+}
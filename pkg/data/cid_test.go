@@ -0,0 +1,81 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type canonicalCIDVector struct {
+	Name      string          `json:"name"`
+	Type      string          `json:"type"`
+	Value     json.RawMessage `json:"value"`
+	Canonical string          `json:"canonical"`
+	CID       string          `json:"cid"`
+}
+
+// the vectors are shared with other implementations so if one of these
+// fails the IDs we give things have changed, which is a protocol change
+func TestCanonicalCIDGolden(t *testing.T) {
+	bs, err := os.ReadFile("testdata/canonical_cids.json")
+	assert.NoError(t, err)
+	var vectors []canonicalCIDVector
+	err = json.Unmarshal(bs, &vectors)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, vectors)
+
+	for _, vector := range vectors {
+		t.Run(vector.Name, func(t *testing.T) {
+			var value interface{}
+			var id string
+			switch vector.Type {
+			case "job_offer":
+				var offer JobOffer
+				assert.NoError(t, json.Unmarshal(vector.Value, &offer))
+				id, err = GetJobOfferID(offer)
+				offer.ID = ""
+				value = offer
+			case "resource_offer":
+				var offer ResourceOffer
+				assert.NoError(t, json.Unmarshal(vector.Value, &offer))
+				id, err = GetResourceOfferID(offer)
+				offer.ID = ""
+				value = offer
+			case "deal":
+				var deal Deal
+				assert.NoError(t, json.Unmarshal(vector.Value, &deal))
+				id, err = GetDealID(deal)
+				deal.ID = ""
+				value = deal
+			case "module":
+				var module ModuleConfig
+				assert.NoError(t, json.Unmarshal(vector.Value, &module))
+				id, err = GetModuleID(module)
+				module.ImageDigest = ""
+				value = module
+			default:
+				value = vector.Value
+				id, err = CalculateCID(vector.Value)
+			}
+			assert.NoError(t, err)
+			canonical, err := CanonicalJSON(value)
+			assert.NoError(t, err)
+			assert.Equal(t, vector.Canonical, string(canonical))
+			assert.Equal(t, vector.CID, GetCanonicalCID(canonical))
+			assert.Equal(t, vector.CID, id)
+		})
+	}
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	first, err := CanonicalJSON(map[string]interface{}{"b": 1, "a": map[string]string{"d": "<&>", "c": ""}})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":{"c":"","d":"<&>"},"b":1}`, string(first))
+
+	// the same fields in a different order are the same JSON
+	second, err := CanonicalJSON(json.RawMessage(`{ "b": 1, "a": { "d": "<&>", "c": "" } }`))
+	assert.NoError(t, err)
+	assert.Equal(t, first, second)
+}
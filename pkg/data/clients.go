@@ -0,0 +1,141 @@
+package data
+
+import (
+	"fmt"
+	"strings"
+)
+
+// bumped when offers, deals or the solver API change in a way older
+// clients cannot follow, the solver serves each version from the oldest
+// it supports so clients can be upgraded one at a time
+//
+//	1: errors are sent as plain text
+//	2: errors are sent as JSON with a code
+const PROTOCOL_VERSION = 2
+
+// clients on an older protocol than this are turned away
+const MIN_PROTOCOL_VERSION = 1
+
+// the optional features a job creator or resource provider has
+const (
+	// can decrypt inputs sent for its key
+	CapabilityEncryptedInputs = "encrypted_inputs"
+	// can save checkpoints of a running job and start one from them
+	CapabilityCheckpoints = "checkpoints"
+	// streams the output of jobs to the solver
+	CapabilityLogs = "logs"
+	// follows its offers and deals with the solver sync API
+	CapabilityDeltaSync = "delta_sync"
+	// runs WebAssembly modules and nothing else
+	CapabilityWasm = "wasm"
+	// measures the cpu and gpu time of the jobs it runs
+	CapabilityMetering = "metering"
+)
+
+const (
+	ClientRoleJobCreator       = "job_creator"
+	ClientRoleResourceProvider = "resource_provider"
+)
+
+// what a job creator or resource provider says about the software it runs
+// offers from before this was added have none and are matched as before
+type ClientMetadata struct {
+	// the release of lilypad, e.g. v2.13.0
+	Version         string   `json:"version,omitempty"`
+	ProtocolVersion int      `json:"protocol_version,omitempty"`
+	Capabilities    []string `json:"capabilities,omitempty"`
+}
+
+// what the solver says about the protocol versions it serves
+type ProtocolInfo struct {
+	// the release of lilypad the solver is running
+	Version            string `json:"version"`
+	ProtocolVersion    int    `json:"protocol_version"`
+	MinProtocolVersion int    `json:"min_protocol_version"`
+}
+
+// the last we heard from a client so operators can see who is
+// running what before they ask everyone to upgrade
+type ClientRecord struct {
+	Address string `json:"address"`
+	Role    string `json:"role"`
+	ClientMetadata
+	// unix seconds
+	FirstSeen int64 `json:"first_seen"`
+	LastSeen  int64 `json:"last_seen"`
+}
+
+func GetClientRecordID(address string, role string) string {
+	return fmt.Sprintf("%s-%s", strings.ToLower(address), role)
+}
+
+func HasCapability(client *ClientMetadata, capability string) bool {
+	if client == nil {
+		return false
+	}
+	for _, c := range client.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// the capabilities a resource provider needs to run a job
+func GetRequiredCapabilities(jobOffer JobOffer) []string {
+	required := []string{}
+	if len(jobOffer.EncryptedInputs) > 0 {
+		required = append(required, CapabilityEncryptedInputs)
+	}
+	if jobOffer.ResumeFrom != "" || jobOffer.Milestones > 0 {
+		required = append(required, CapabilityCheckpoints)
+	}
+	if jobOffer.Engine == ModuleEngineWasm {
+		required = append(required, CapabilityWasm)
+	}
+	if jobOffer.Metered {
+		required = append(required, CapabilityMetering)
+	}
+	return required
+}
+
+func IsProtocolVersionSupported(protocolVersion int) bool {
+	return protocolVersion >= MIN_PROTOCOL_VERSION && protocolVersion <= PROTOCOL_VERSION
+}
+
+// a client that says nothing about itself is taken to be compatible
+// so offers from before metadata keep matching
+// clients on different protocol versions are matched as long as we still
+// serve both, we speak to each in its own version
+func CheckClientsCompatible(resourceOffer ResourceOffer, jobOffer JobOffer) error {
+	if jobOffer.Client != nil && jobOffer.Client.ProtocolVersion > 0 && !IsProtocolVersionSupported(jobOffer.Client.ProtocolVersion) {
+		return fmt.Errorf("job creator is on protocol version %d which is no longer supported", jobOffer.Client.ProtocolVersion)
+	}
+	if resourceOffer.Client == nil {
+		return nil
+	}
+	if resourceOffer.Client.ProtocolVersion > 0 && !IsProtocolVersionSupported(resourceOffer.Client.ProtocolVersion) {
+		return fmt.Errorf("resource provider is on protocol version %d which is no longer supported", resourceOffer.Client.ProtocolVersion)
+	}
+	for _, capability := range GetRequiredCapabilities(jobOffer) {
+		if !HasCapability(resourceOffer.Client, capability) {
+			return fmt.Errorf("resource provider does not have the %s capability", capability)
+		}
+	}
+	if HasCapability(resourceOffer.Client, CapabilityWasm) && jobOffer.Engine != ModuleEngineWasm {
+		return fmt.Errorf("resource provider only runs WebAssembly modules")
+	}
+	return nil
+}
+
+// offers from a protocol newer than ours cannot be followed
+// and ones older than we still serve will not be
+func CheckClientMetadata(client *ClientMetadata) error {
+	if client == nil || client.ProtocolVersion == 0 {
+		return nil
+	}
+	if !IsProtocolVersionSupported(client.ProtocolVersion) {
+		return fmt.Errorf("protocol version %d is not supported, we support %d to %d", client.ProtocolVersion, MIN_PROTOCOL_VERSION, PROTOCOL_VERSION)
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckClientsCompatibleWasm(t *testing.T) {
+	wasmProvider := ResourceOffer{Client: &ClientMetadata{Capabilities: []string{CapabilityWasm}}}
+	dockerProvider := ResourceOffer{Client: &ClientMetadata{}}
+	wasmJob := JobOffer{Engine: ModuleEngineWasm}
+	dockerJob := JobOffer{}
+
+	assert.NoError(t, CheckClientsCompatible(wasmProvider, wasmJob))
+	assert.Error(t, CheckClientsCompatible(wasmProvider, dockerJob), "containers cannot run on wasmtime")
+	assert.Error(t, CheckClientsCompatible(dockerProvider, wasmJob))
+	assert.NoError(t, CheckClientsCompatible(dockerProvider, dockerJob))
+}
@@ -0,0 +1,112 @@
+package data
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// what the solver does with a mediator that looks to be run
+// by the resource provider it would be judging
+const (
+	COLLUSION_POLICY_OFF = "off"
+	// make the deal but record the relation on the mediator selection
+	COLLUSION_POLICY_FLAG = "flag"
+	// leave the mediator out of the candidates for the deal
+	COLLUSION_POLICY_REFUSE = "refuse"
+)
+
+var CollusionPolicies = []string{COLLUSION_POLICY_OFF, COLLUSION_POLICY_FLAG, COLLUSION_POLICY_REFUSE}
+
+func IsCollusionPolicy(policy string) bool {
+	for _, known := range CollusionPolicies {
+		if policy == known {
+			return true
+		}
+	}
+	return false
+}
+
+// why a mediator looks to be run by the resource provider
+const (
+	MediatorRelationSameAddress = "same_address"
+	// both addresses are in one of the operator groups the solver was given
+	MediatorRelationOperator = "operator"
+	// one of them sent the other LP tokens
+	MediatorRelationTransfer = "transfer"
+	// the same address sent both of them LP tokens
+	MediatorRelationSharedFunder = "shared_funder"
+)
+
+// addresses the solver has been told are run by the same operator
+type OperatorGroup struct {
+	Name      string   `json:"name"`
+	Addresses []string `json:"addresses"`
+}
+
+type MediatorRelation struct {
+	Mediator string `json:"mediator"`
+	Reason   string `json:"reason"`
+	// the operator group or the funding address behind the relation
+	Detail string `json:"detail,omitempty"`
+	// the mediator was left out of the candidates rather than just flagged
+	Excluded bool `json:"excluded,omitempty"`
+}
+
+func CheckOperatorGroups(groups []OperatorGroup) error {
+	for i, group := range groups {
+		if len(group.Addresses) < 2 { //nolint:gomnd
+			return fmt.Errorf("operator group %d %q needs at least two addresses", i, group.Name)
+		}
+		for _, address := range group.Addresses {
+			if !common.IsHexAddress(address) {
+				return fmt.Errorf("operator group %d %q has %q which is not an address", i, group.Name, address)
+			}
+		}
+	}
+	return nil
+}
+
+// nil if nothing ties the mediator to the resource provider
+// funders is the LP token senders of each address in lower case and
+// can be nil when the solver is not looking at funding
+func GetMediatorRelation(resourceProvider string, mediator string, groups []OperatorGroup, funders map[string][]string) *MediatorRelation {
+	relation := &MediatorRelation{Mediator: mediator}
+	resourceProvider = strings.ToLower(resourceProvider)
+	mediator = strings.ToLower(mediator)
+	if resourceProvider == mediator {
+		relation.Reason = MediatorRelationSameAddress
+		return relation
+	}
+	for _, group := range groups {
+		if containsAddress(group.Addresses, resourceProvider) && containsAddress(group.Addresses, mediator) {
+			relation.Reason = MediatorRelationOperator
+			relation.Detail = group.Name
+			return relation
+		}
+	}
+	providerFunders := funders[resourceProvider]
+	mediatorFunders := funders[mediator]
+	if containsAddress(providerFunders, mediator) || containsAddress(mediatorFunders, resourceProvider) {
+		relation.Reason = MediatorRelationTransfer
+		return relation
+	}
+	for _, funder := range providerFunders {
+		if containsAddress(mediatorFunders, funder) {
+			relation.Reason = MediatorRelationSharedFunder
+			relation.Detail = funder
+			return relation
+		}
+	}
+	return nil
+}
+
+func containsAddress(addresses []string, address string) bool {
+	for _, candidate := range addresses {
+		if strings.EqualFold(candidate, address) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package data
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMediatorRelation(t *testing.T) {
+	groups := []OperatorGroup{{Name: "acme", Addresses: []string{"0xAAA", "0xbbb"}}}
+	funders := map[string][]string{
+		"0xaaa": {"0xf00"},
+		"0xccc": {"0xf00"},
+		"0xddd": {"0xaaa"},
+		"0xeee": {"0xf11"},
+	}
+
+	relation := GetMediatorRelation("0xaaa", "0xAAA", groups, funders)
+	assert.Equal(t, MediatorRelationSameAddress, relation.Reason)
+	assert.Equal(t, "0xAAA", relation.Mediator)
+
+	relation = GetMediatorRelation("0xaaa", "0xbbb", groups, funders)
+	assert.Equal(t, MediatorRelationOperator, relation.Reason)
+	assert.Equal(t, "acme", relation.Detail)
+
+	relation = GetMediatorRelation("0xaaa", "0xddd", nil, funders)
+	assert.Equal(t, MediatorRelationTransfer, relation.Reason)
+
+	relation = GetMediatorRelation("0xaaa", "0xccc", nil, funders)
+	assert.Equal(t, MediatorRelationSharedFunder, relation.Reason)
+	assert.Equal(t, "0xf00", relation.Detail)
+
+	assert.Nil(t, GetMediatorRelation("0xaaa", "0xeee", groups, funders))
+	assert.Nil(t, GetMediatorRelation("0xaaa", "0xccc", groups, nil))
+}
+
+func TestSelectMediatorsRelated(t *testing.T) {
+	mutual := []string{"0xa", "0xb", "0xc"}
+	jobOffer := JobOffer{ID: "job"}
+	resourceOffer := ResourceOffer{ID: "resource", ResourceProvider: "0xa"}
+	relations := []MediatorRelation{{Mediator: "0xa", Reason: MediatorRelationSameAddress}}
+
+	// flagged mediators can still be picked
+	selection, err := SelectMediators(mutual, jobOffer, resourceOffer, MediatorPolicy{Name: MEDIATOR_POLICY_ROUND_ROBIN, Relations: relations})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0xa"}, selection.Mediators)
+	assert.Equal(t, []MediatorRelation{{Mediator: "0xa", Reason: MediatorRelationSameAddress}}, selection.Related)
+
+	selection, err = SelectMediators(mutual, jobOffer, resourceOffer, MediatorPolicy{Name: MEDIATOR_POLICY_ROUND_ROBIN, Relations: relations, RefuseRelated: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0xb", "0xc"}, selection.Candidates)
+	assert.Equal(t, []string{"0xb"}, selection.Mediators)
+	assert.True(t, selection.Related[0].Excluded)
+	assert.NoError(t, VerifyMediatorSelection(Deal{
+		JobOffer:          jobOffer,
+		ResourceOffer:     resourceOffer,
+		Members:           DealMembers{Mediators: selection.Mediators},
+		MediatorSelection: &selection,
+	}))
+
+	// there are not enough unrelated mediators for the quorum
+	jobOffer.MediatorQuorum = &MediatorQuorum{Size: 3, Threshold: 2}
+	_, err = SelectMediators(mutual, jobOffer, resourceOffer, MediatorPolicy{Name: MEDIATOR_POLICY_RANDOM, Relations: relations, RefuseRelated: true})
+	assert.True(t, errors.Is(err, ErrForbidden))
+}
@@ -0,0 +1,67 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCronNext(t *testing.T) {
+	// a Wednesday
+	now := time.Date(2024, 5, 15, 10, 17, 30, 0, time.UTC)
+	tests := []struct {
+		expression string
+		expected   time.Time
+	}{
+		{"* * * * *", time.Date(2024, 5, 15, 10, 18, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 5, 15, 10, 30, 0, 0, time.UTC)},
+		{"0 9-17 * * *", time.Date(2024, 5, 15, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, 5, 16, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, 5, 15, 11, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		// sunday as 7
+		{"30 8 * * 7", time.Date(2024, 5, 19, 8, 30, 0, 0, time.UTC)},
+		{"0 0 * * 1-5", time.Date(2024, 5, 16, 0, 0, 0, 0, time.UTC)},
+		// either the 1st or a friday when both day fields are set
+		{"0 0 1 * 5", time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		cron, err := ParseCron(test.expression)
+		require.NoError(t, err, test.expression)
+		assert.Equal(t, test.expected, cron.Next(now), test.expression)
+	}
+
+	cron, err := ParseCron("0 0 31 2 *")
+	require.NoError(t, err)
+	assert.True(t, cron.Next(now).IsZero())
+}
+
+func TestParseCronErrors(t *testing.T) {
+	for _, expression := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "5-1 * * * *", "*/0 * * * *", "a * * * *", "@sometimes"} {
+		_, err := ParseCron(expression)
+		assert.Error(t, err, expression)
+	}
+}
+
+func TestScheduledJobOffers(t *testing.T) {
+	schedule, err := NewJobSchedule(JobScheduleSubmission{
+		JobOffer: JobOffer{JobCreator: "0xjc"},
+		Cron:     "@hourly",
+	}, time.Date(2024, 5, 15, 10, 17, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, ScheduleOverlapSkip, schedule.OverlapPolicy)
+	assert.Equal(t, time.Date(2024, 5, 15, 11, 0, 0, 0, time.UTC).Unix(), schedule.NextRunAt)
+
+	first := GetScheduledJobOffer(schedule, time.Unix(schedule.NextRunAt, 0))
+	second := GetScheduledJobOffer(schedule, time.Unix(schedule.NextRunAt+3600, 0))
+	assert.Equal(t, schedule.ID, first.ScheduleID)
+	firstID, err := GetJobOfferID(first)
+	require.NoError(t, err)
+	secondID, err := GetJobOfferID(second)
+	require.NoError(t, err)
+	assert.NotEqual(t, firstID, secondID)
+}
@@ -0,0 +1,147 @@
+package data
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/ecies"
+)
+
+// ECIES over the same curve as our wallets
+// the job creator encrypts for the public key in the resource offer
+const InputEncryptionScheme = "ecies-secp256k1"
+
+// the largest encrypted inputs the solver keeps for a deal (base64 bytes)
+const MAX_ENCRYPTED_INPUTS_SIZE = 1024 * 1024
+
+// a resource provider key from its hex private key
+func ParseInputEncryptionKey(privateKey string) (*ecies.PrivateKey, error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(privateKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %s", err.Error())
+	}
+	return ecies.ImportECDSA(key), nil
+}
+
+// the hex public key a resource provider puts in its resource offers
+func GetInputEncryptionPublicKey(key *ecies.PrivateKey) string {
+	return hex.EncodeToString(crypto.CompressPubkey(key.PublicKey.ExportECDSA()))
+}
+
+func parseInputEncryptionPublicKey(publicKey string) (*ecies.PublicKey, error) {
+	bytes, err := hex.DecodeString(strings.TrimPrefix(publicKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption public key: %s", err.Error())
+	}
+	key, err := crypto.DecompressPubkey(bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption public key: %s", err.Error())
+	}
+	return ecies.ImportECDSAPublic(key), nil
+}
+
+// the encrypted inputs must be named in the job offer
+// and not also be sent in the clear
+func CheckEncryptedInputNames(jobOffer JobOffer) error {
+	seen := map[string]bool{}
+	for _, name := range jobOffer.EncryptedInputs {
+		if name == "" {
+			return fmt.Errorf("job offer encrypted inputs must have names")
+		}
+		if seen[name] {
+			return fmt.Errorf("job offer encrypted input %s is named more than once", name)
+		}
+		if _, ok := jobOffer.Inputs[name]; ok {
+			return fmt.Errorf("job offer input %s cannot be both encrypted and in the clear", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// the values must be exactly the inputs the job offer said would be encrypted
+func CheckEncryptedInputValues(jobOffer JobOffer, inputs map[string]string) error {
+	if len(inputs) != len(jobOffer.EncryptedInputs) {
+		return fmt.Errorf("job offer has %d encrypted inputs but %d were given", len(jobOffer.EncryptedInputs), len(inputs))
+	}
+	for _, name := range jobOffer.EncryptedInputs {
+		if _, ok := inputs[name]; !ok {
+			return fmt.Errorf("encrypted input %s is missing", name)
+		}
+	}
+	return nil
+}
+
+// encrypt the inputs for the resource provider of the deal
+func EncryptDealInputs(deal DealContainer, inputs map[string]string) (DealEncryptedInputs, error) {
+	publicKey := deal.Deal.ResourceOffer.EncryptionKey
+	if publicKey == "" {
+		return DealEncryptedInputs{}, fmt.Errorf("resource offer for deal %s has no encryption key", deal.ID)
+	}
+	key, err := parseInputEncryptionPublicKey(publicKey)
+	if err != nil {
+		return DealEncryptedInputs{}, err
+	}
+	plaintext, err := json.Marshal(inputs)
+	if err != nil {
+		return DealEncryptedInputs{}, err
+	}
+	ciphertext, err := ecies.Encrypt(rand.Reader, key, plaintext, nil, nil)
+	if err != nil {
+		return DealEncryptedInputs{}, err
+	}
+	return DealEncryptedInputs{
+		DealID:        deal.ID,
+		Scheme:        InputEncryptionScheme,
+		EncryptionKey: publicKey,
+		Ciphertext:    base64.StdEncoding.EncodeToString(ciphertext),
+	}, nil
+}
+
+func DecryptDealInputs(key *ecies.PrivateKey, encrypted DealEncryptedInputs) (map[string]string, error) {
+	if encrypted.Scheme != InputEncryptionScheme {
+		return nil, fmt.Errorf("unknown input encryption scheme %q", encrypted.Scheme)
+	}
+	if encrypted.EncryptionKey != GetInputEncryptionPublicKey(key) {
+		return nil, fmt.Errorf("inputs were encrypted for a different key")
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(encrypted.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encrypted inputs: %s", err.Error())
+	}
+	plaintext, err := key.Decrypt(ciphertext, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt inputs: %s", err.Error())
+	}
+	inputs := map[string]string{}
+	err = json.Unmarshal(plaintext, &inputs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encrypted inputs: %s", err.Error())
+	}
+	return inputs, nil
+}
+
+// the encrypted inputs a job creator posts for a deal
+func CheckDealEncryptedInputs(deal DealContainer, encrypted DealEncryptedInputs) error {
+	if len(deal.Deal.JobOffer.EncryptedInputs) == 0 {
+		return fmt.Errorf("job offer for deal %s has no encrypted inputs", deal.ID)
+	}
+	if encrypted.Scheme != InputEncryptionScheme {
+		return fmt.Errorf("unknown input encryption scheme %q", encrypted.Scheme)
+	}
+	if encrypted.EncryptionKey != deal.Deal.ResourceOffer.EncryptionKey {
+		return fmt.Errorf("inputs must be encrypted for the resource offer key")
+	}
+	if encrypted.Ciphertext == "" {
+		return fmt.Errorf("encrypted inputs are empty")
+	}
+	if len(encrypted.Ciphertext) > MAX_ENCRYPTED_INPUTS_SIZE {
+		return fmt.Errorf("encrypted inputs are %d bytes, the most we take is %d", len(encrypted.Ciphertext), MAX_ENCRYPTED_INPUTS_SIZE)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package data
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/ecies"
+)
+
+func newTestEncryptionKey(t *testing.T) *ecies.PrivateKey {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseInputEncryptionKey(hex.EncodeToString(crypto.FromECDSA(key)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+func TestEncryptDealInputs(t *testing.T) {
+	key := newTestEncryptionKey(t)
+	deal := DealContainer{ID: "deal"}
+	deal.Deal.JobOffer.EncryptedInputs = []string{"Secret"}
+	deal.Deal.ResourceOffer.EncryptionKey = GetInputEncryptionPublicKey(key)
+
+	encrypted, err := EncryptDealInputs(deal, map[string]string{"Secret": "hunter2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = CheckDealEncryptedInputs(deal, encrypted)
+	if err != nil {
+		t.Fatalf("encrypted inputs should be valid: %v", err)
+	}
+	inputs, err := DecryptDealInputs(key, encrypted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inputs["Secret"] != "hunter2" {
+		t.Errorf("expected the secret back, got %v", inputs)
+	}
+	err = CheckEncryptedInputValues(deal.Deal.JobOffer, inputs)
+	if err != nil {
+		t.Errorf("decrypted inputs should match the job offer: %v", err)
+	}
+
+	_, err = DecryptDealInputs(newTestEncryptionKey(t), encrypted)
+	if err == nil {
+		t.Errorf("expected an error decrypting with another key")
+	}
+
+	deal.Deal.ResourceOffer.EncryptionKey = ""
+	_, err = EncryptDealInputs(deal, map[string]string{"Secret": "hunter2"})
+	if err == nil {
+		t.Errorf("expected an error for a resource offer without a key")
+	}
+}
+
+func TestCheckEncryptedInputNames(t *testing.T) {
+	offer := JobOffer{
+		Inputs:          map[string]string{"Message": "moo"},
+		EncryptedInputs: []string{"Secret"},
+	}
+	if err := CheckEncryptedInputNames(offer); err != nil {
+		t.Errorf("valid names: %v", err)
+	}
+	offer.EncryptedInputs = []string{"Message"}
+	if err := CheckEncryptedInputNames(offer); err == nil {
+		t.Errorf("expected an error for an input that is also in the clear")
+	}
+	offer.EncryptedInputs = []string{"Secret", "Secret"}
+	if err := CheckEncryptedInputNames(offer); err == nil {
+		t.Errorf("expected an error for a name given twice")
+	}
+}
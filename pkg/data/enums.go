@@ -15,6 +15,8 @@ var ServiceType = []string{
 }
 
 // AgreementState corresponds to AgreementState in TypeScript
+// the order must match the AgreementState enum in SharedStructs.sol
+// JobOfferCancelled is only used off-chain so it goes at the end
 var AgreementState = []string{
 	"DealNegotiating",
 	"DealAgreed",
@@ -23,6 +25,7 @@ var AgreementState = []string{
 	"ResultsChecked",
 	"MediationAccepted",
 	"MediationRejected",
+	"TimeoutAgree",
 	"TimeoutSubmitResults",
 	"TimeoutJudgeResults",
 	"TimeoutMediateResults",
@@ -84,7 +87,7 @@ func IsActiveAgreementState(itemType uint8) bool {
 }
 
 func IsTerminalAgreementState(itemType uint8) bool {
-	return itemType == GetAgreementStateIndex("JobOfferCancelled") || itemType == GetAgreementStateIndex("ResultsAccepted") || itemType == GetAgreementStateIndex("MediationAccepted") || itemType == GetAgreementStateIndex("MediationRejected")
+	return DealState(itemType).IsTerminal()
 }
 
 // GetPaymentReason corresponds to getPaymentReason in TypeScript
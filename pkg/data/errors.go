@@ -0,0 +1,125 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+)
+
+// the class of a failure so clients can act on it without reading the message
+// a code is an error itself so errors.Is(err, data.ErrTimeout) works
+type ErrorCode string
+
+const (
+	// the request was malformed or failed validation
+	ErrInvalidRequest ErrorCode = "invalid_request"
+	// the request was not signed or the signature was bad
+	ErrUnauthorized ErrorCode = "unauthorized"
+	// the signer is not allowed to do this
+	ErrForbidden ErrorCode = "forbidden"
+	// the address is banned by the solver
+	ErrBanned   ErrorCode = "banned"
+	ErrNotFound ErrorCode = "not_found"
+	// the request clashes with the state something is in
+	ErrConflict ErrorCode = "conflict"
+	// there is not the capacity to run the job, or the job ran out of it
+	ErrInsufficientResources ErrorCode = "insufficient_resources"
+	// the module is not on the allowlist or could not be loaded
+	ErrModuleNotAllowed ErrorCode = "module_not_allowed"
+	ErrModuleLoadFailed ErrorCode = "module_load_failed"
+	// the offer prices do not meet
+	ErrPriceMismatch ErrorCode = "price_mismatch"
+	// the job creator would spend more than its budget allows
+	ErrBudgetExceeded ErrorCode = "budget_exceeded"
+	// the job or a step of the deal took too long
+	ErrTimeout ErrorCode = "timeout"
+	// the job ran and failed
+	ErrJobFailed ErrorCode = "job_failed"
+	// the job creator cancelled the job
+	ErrJobCancelled ErrorCode = "job_cancelled"
+	// the resource provider took back the spot capacity
+	ErrPreempted ErrorCode = "preempted"
+	// the results could not be uploaded or stored
+	ErrResultsUploadFailed ErrorCode = "results_upload_failed"
+	// a mediator got different results when it ran the job again
+	ErrResultMismatch ErrorCode = "result_mismatch"
+	// the client speaks a protocol version the solver no longer serves
+	ErrUnsupportedProtocol ErrorCode = "unsupported_protocol"
+	// the solver only serves reads, changes go to the one that matches
+	ErrReadOnly ErrorCode = "read_only"
+	// anything we did not expect
+	ErrInternal ErrorCode = "internal"
+)
+
+func (code ErrorCode) Error() string {
+	return string(code)
+}
+
+// an error with a code, this is the JSON the solver API sends back for errors
+type Error struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+	// what caused it, not sent over the wire
+	Err error `json:"-"`
+}
+
+func NewError(code ErrorCode, format string, args ...interface{}) *Error {
+	return &Error{
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// give an error a code keeping the message it has
+func WrapError(code ErrorCode, err error) *Error {
+	return &Error{
+		Code:    code,
+		Message: err.Error(),
+		Err:     err,
+	}
+}
+
+func (err *Error) Error() string {
+	return err.Message
+}
+
+func (err *Error) Unwrap() error {
+	return err.Err
+}
+
+func (err *Error) Is(target error) bool {
+	code, ok := target.(ErrorCode)
+	return ok && code == err.Code
+}
+
+// errors from other packages can say what class they are without depending on Error
+type CodedError interface {
+	ErrorCode() ErrorCode
+}
+
+// the code of the first coded error in the chain, empty if there is none
+func GetErrorCode(err error) ErrorCode {
+	if err == nil {
+		return ""
+	}
+	var dataErr *Error
+	if errors.As(err, &dataErr) {
+		return dataErr.Code
+	}
+	var coded CodedError
+	if errors.As(err, &coded) {
+		return coded.ErrorCode()
+	}
+	var code ErrorCode
+	if errors.As(err, &code) {
+		return code
+	}
+	return ""
+}
+
+// the code of an error or the fallback if it does not have one
+func GetErrorCodeOr(err error, fallback ErrorCode) ErrorCode {
+	if code := GetErrorCode(err); code != "" {
+		return code
+	}
+	return fallback
+}
@@ -0,0 +1,43 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testCodedError struct{}
+
+func (testCodedError) Error() string { return "out of memory" }
+
+func (testCodedError) ErrorCode() ErrorCode { return ErrInsufficientResources }
+
+func TestGetErrorCode(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+		code ErrorCode
+	}{
+		{name: "No error", err: nil, code: ""},
+		{name: "Plain error", err: errors.New("boom"), code: ""},
+		{name: "Data error", err: NewError(ErrTimeout, "job %s took too long", "a"), code: ErrTimeout},
+		{name: "Wrapped data error", err: fmt.Errorf("error running job: %w", NewError(ErrTimeout, "late")), code: ErrTimeout},
+		{name: "Coded error", err: fmt.Errorf("error running job: %w", testCodedError{}), code: ErrInsufficientResources},
+		{name: "Bare code", err: ErrModuleNotAllowed, code: ErrModuleNotAllowed},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.code, GetErrorCode(tc.err))
+		})
+	}
+}
+
+func TestErrorIs(t *testing.T) {
+	err := fmt.Errorf("error running job: %w", WrapError(ErrTimeout, errors.New("late")))
+	assert.True(t, errors.Is(err, ErrTimeout))
+	assert.False(t, errors.Is(err, ErrJobFailed))
+	assert.Equal(t, "error running job: late", err.Error())
+}
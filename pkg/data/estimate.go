@@ -0,0 +1,68 @@
+package data
+
+import "sort"
+
+// the job a cost estimate is for
+type CostEstimateQuery struct {
+	// either a module name such as cowsay:v0.0.4 or a repo, hash and path
+	Module ModuleConfig `json:"module"`
+	Spec   MachineSpec  `json:"spec"`
+	// how many instructions the job is expected to take, the cost is per instruction so this defaults to one
+	Instructions uint64 `json:"instructions"`
+	// zero means offers on any chain
+	ChainID int `json:"chain_id"`
+}
+
+// the lowest and highest a job is likely to cost, in the units of the deal pricing
+type CostRange struct {
+	Min uint64 `json:"min"`
+	Max uint64 `json:"max"`
+}
+
+// what the open resource offers that could run a job are asking
+// so a job creator can pick a price before it posts a job offer
+type CostEstimate struct {
+	ModuleID string `json:"module_id"`
+	// the instruction count the cost is worked out for
+	Instructions uint64 `json:"instructions"`
+	// how many unmatched resource offers could run the job
+	// the prices are all zero when there are none
+	ResourceOffers         int    `json:"resource_offers"`
+	MinInstructionPrice    uint64 `json:"min_instruction_price"`
+	MedianInstructionPrice uint64 `json:"median_instruction_price"`
+	P90InstructionPrice    uint64 `json:"p90_instruction_price"`
+	// from the cheapest offer up to the p90 price
+	// a price cap at the top of the range should find a match most of the time
+	ExpectedCost CostRange `json:"expected_cost"`
+}
+
+// the prices are the instruction prices of the resource offers that could run the job
+func GetCostEstimate(moduleID string, instructions uint64, prices []uint64) CostEstimate {
+	estimate := CostEstimate{
+		ModuleID:       moduleID,
+		Instructions:   instructions,
+		ResourceOffers: len(prices),
+	}
+	if len(prices) == 0 {
+		return estimate
+	}
+	sorted := append([]uint64{}, prices...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	estimate.MinInstructionPrice = sorted[0]
+	estimate.MedianInstructionPrice = getPercentile(sorted, 50) //nolint:gomnd
+	estimate.P90InstructionPrice = getPercentile(sorted, 90)    //nolint:gomnd
+	estimate.ExpectedCost = CostRange{
+		Min: estimate.MinInstructionPrice * instructions,
+		Max: estimate.P90InstructionPrice * instructions,
+	}
+	return estimate
+}
+
+// the nearest rank percentile of sorted values, so it is always one of them
+func getPercentile(sorted []uint64, percentile int) uint64 {
+	rank := (percentile*len(sorted) + 99) / 100 //nolint:gomnd
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
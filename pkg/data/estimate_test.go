@@ -0,0 +1,26 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCostEstimate(t *testing.T) {
+	prices := []uint64{50, 10, 40, 20, 30, 60, 70, 80, 90, 100}
+	estimate := GetCostEstimate("module", 3, prices)
+	assert.Equal(t, 10, estimate.ResourceOffers)
+	assert.Equal(t, uint64(10), estimate.MinInstructionPrice)
+	assert.Equal(t, uint64(50), estimate.MedianInstructionPrice)
+	assert.Equal(t, uint64(90), estimate.P90InstructionPrice)
+	assert.Equal(t, CostRange{Min: 30, Max: 270}, estimate.ExpectedCost)
+	// the prices we were given are left as they were
+	assert.Equal(t, uint64(50), prices[0])
+
+	single := GetCostEstimate("module", 1, []uint64{7})
+	assert.Equal(t, uint64(7), single.MedianInstructionPrice)
+	assert.Equal(t, uint64(7), single.P90InstructionPrice)
+
+	empty := GetCostEstimate("module", 1, []uint64{})
+	assert.Equal(t, CostEstimate{ModuleID: "module", Instructions: 1}, empty)
+}
@@ -0,0 +1,68 @@
+package data
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ipfs/go-cid"
+)
+
+// the most input CIDs one job offer or staging request can name
+const MAX_INPUT_CIDS = 100
+
+func CheckInputCIDs(cids []string) error {
+	if len(cids) > MAX_INPUT_CIDS {
+		return fmt.Errorf("%d input CIDs is more than the limit of %d", len(cids), MAX_INPUT_CIDS)
+	}
+	seen := map[string]bool{}
+	for _, id := range cids {
+		_, err := cid.Decode(id)
+		if err != nil {
+			return fmt.Errorf("input %q is not a valid CID: %s", id, err.Error())
+		}
+		if seen[id] {
+			return fmt.Errorf("input %s is named more than once", id)
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+func CheckInputStagingRequest(request InputStagingRequest) error {
+	if request.ResourceProvider == "" {
+		return fmt.Errorf("staging request must name the resource provider")
+	}
+	if len(request.CIDs) == 0 {
+		return fmt.Errorf("staging request must have at least one CID")
+	}
+	return CheckInputCIDs(request.CIDs)
+}
+
+func CheckInputStagingUpdate(update InputStagingUpdate) error {
+	switch update.State {
+	case InputStagingStaged, InputStagingFailed:
+		return nil
+	default:
+		return fmt.Errorf("staging can only be updated to %s or %s", InputStagingStaged, InputStagingFailed)
+	}
+}
+
+func GetInputStagingID(request InputStagingRequest) (string, error) {
+	return CalculateCID(request)
+}
+
+func NewInputStaging(request InputStagingRequest, now time.Time) (InputStaging, error) {
+	id, err := GetInputStagingID(request)
+	if err != nil {
+		return InputStaging{}, err
+	}
+	return InputStaging{
+		ID:               id,
+		JobCreator:       request.JobCreator,
+		ResourceProvider: request.ResourceProvider,
+		CIDs:             request.CIDs,
+		State:            InputStagingRequested,
+		CreatedAt:        now.Unix(),
+		UpdatedAt:        now.Unix(),
+	}, nil
+}
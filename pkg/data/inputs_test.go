@@ -0,0 +1,50 @@
+package data
+
+import (
+	"testing"
+)
+
+const testInputCID = "bafybeigdyrzt5sfp7udm7hu76uh7y26nf3efuylqabf3oclgtqy55fbzdi"
+
+func TestCheckInputCIDs(t *testing.T) {
+	tests := []struct {
+		name    string
+		cids    []string
+		wantErr bool
+	}{
+		{name: "none", cids: nil},
+		{name: "valid", cids: []string{testInputCID}},
+		{name: "not a cid", cids: []string{"not-a-cid"}, wantErr: true},
+		{name: "duplicate", cids: []string{testInputCID, testInputCID}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckInputCIDs(tt.cids)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckInputCIDs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckInputStaging(t *testing.T) {
+	request := InputStagingRequest{
+		JobCreator:       "0x1",
+		ResourceProvider: "0x2",
+		CIDs:             []string{testInputCID},
+	}
+	if err := CheckInputStagingRequest(request); err != nil {
+		t.Errorf("valid request: %v", err)
+	}
+	noProvider := request
+	noProvider.ResourceProvider = ""
+	if err := CheckInputStagingRequest(noProvider); err == nil {
+		t.Errorf("expected an error for a request without a resource provider")
+	}
+	if err := CheckInputStagingUpdate(InputStagingUpdate{State: InputStagingRequested}); err == nil {
+		t.Errorf("expected an error for an update back to requested")
+	}
+	if err := CheckInputStagingUpdate(InputStagingUpdate{State: InputStagingStaged, Size: 10}); err != nil {
+		t.Errorf("valid update: %v", err)
+	}
+}
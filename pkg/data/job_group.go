@@ -0,0 +1,110 @@
+package data
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// the most job offers one array job can expand into
+const MAX_JOB_GROUP_SIZE = 1000
+
+func GetJobGroupID(submission JobGroupSubmission) (string, error) {
+	submission.JobOffer.ID = ""
+	return CalculateCID(submission)
+}
+
+func CheckJobGroupSubmission(submission JobGroupSubmission) error {
+	if submission.Parameter == "" {
+		return fmt.Errorf("array job must name the input it sets")
+	}
+	if len(submission.Values) == 0 {
+		return fmt.Errorf("array job must have at least one value for %s", submission.Parameter)
+	}
+	if len(submission.Values) > MAX_JOB_GROUP_SIZE {
+		return fmt.Errorf("array job has %d values, the most it can have is %d", len(submission.Values), MAX_JOB_GROUP_SIZE)
+	}
+	seen := map[string]bool{}
+	for _, value := range submission.Values {
+		if seen[value] {
+			return fmt.Errorf("array job has the value %q for %s more than once", value, submission.Parameter)
+		}
+		seen[value] = true
+	}
+	return CheckJobOffer(submission.JobOffer)
+}
+
+// one job offer for each value in the same order as the values
+func ExpandJobGroup(submission JobGroupSubmission, groupID string) []JobOffer {
+	offers := []JobOffer{}
+	for _, value := range submission.Values {
+		offer := submission.JobOffer
+		offer.ID = ""
+		offer.GroupID = groupID
+		// the key is for the submission, the offers in it would all share it
+		offer.IdempotencyKey = ""
+		offer.Inputs = map[string]string{}
+		for name, input := range submission.JobOffer.Inputs {
+			offer.Inputs[name] = input
+		}
+		offer.Inputs[submission.Parameter] = value
+		offers = append(offers, offer)
+	}
+	return offers
+}
+
+// the values of an array job parameter from the command line
+// either an inclusive range like 1..100 or a list like small,medium,large
+func ParseJobGroupValues(spec string) ([]string, error) {
+	if from, to, ok := strings.Cut(spec, ".."); ok {
+		start, err := strconv.Atoi(strings.TrimSpace(from))
+		if err != nil {
+			return nil, fmt.Errorf("range %q does not start with a number", spec)
+		}
+		end, err := strconv.Atoi(strings.TrimSpace(to))
+		if err != nil {
+			return nil, fmt.Errorf("range %q does not end with a number", spec)
+		}
+		if end < start {
+			return nil, fmt.Errorf("range %q ends before it starts", spec)
+		}
+		if end-start >= MAX_JOB_GROUP_SIZE {
+			return nil, fmt.Errorf("range %q has more than %d values", spec, MAX_JOB_GROUP_SIZE)
+		}
+		values := []string{}
+		for i := start; i <= end; i++ {
+			values = append(values, strconv.Itoa(i))
+		}
+		return values, nil
+	}
+	values := []string{}
+	for _, value := range strings.Split(spec, ",") {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("no values in %q", spec)
+	}
+	return values, nil
+}
+
+// count the job offers of a group by state
+// job offers are only archived once they are finished so any
+// the store no longer has do not hold the group up
+func GetJobGroupStatus(group JobGroup, jobOffers []JobOfferContainer) JobGroupStatus {
+	status := JobGroupStatus{
+		Group:     group,
+		JobOffers: jobOffers,
+		States:    map[string]int{},
+		Finished:  true,
+	}
+	for _, jobOffer := range jobOffers {
+		status.States[GetAgreementStateString(jobOffer.State)]++
+		if !IsTerminalAgreementState(jobOffer.State) {
+			status.Finished = false
+		}
+	}
+	return status
+}
@@ -0,0 +1,49 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJobGroupValues(t *testing.T) {
+	values, err := ParseJobGroupValues("1..5")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "3", "4", "5"}, values)
+
+	values, err = ParseJobGroupValues("small, medium,large")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"small", "medium", "large"}, values)
+
+	for _, spec := range []string{"5..1", "a..3", "1..100000", ","} {
+		_, err = ParseJobGroupValues(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestExpandJobGroup(t *testing.T) {
+	submission := JobGroupSubmission{
+		JobOffer: JobOffer{
+			JobCreator: "0xjc",
+			Inputs:     map[string]string{"Prompt": "a cat", "Seed": "0"},
+		},
+		Parameter: "Seed",
+		Values:    []string{"1", "2", "3"},
+	}
+	offers := ExpandJobGroup(submission, "group")
+	require.Len(t, offers, 3)
+
+	ids := map[string]bool{}
+	for i, offer := range offers {
+		assert.Equal(t, "group", offer.GroupID)
+		assert.Equal(t, "a cat", offer.Inputs["Prompt"])
+		assert.Equal(t, submission.Values[i], offer.Inputs["Seed"])
+		id, err := GetJobOfferID(offer)
+		require.NoError(t, err)
+		ids[id] = true
+	}
+	assert.Len(t, ids, 3, "every job offer in the group should have its own ID")
+	// the template is left as it was
+	assert.Equal(t, "0", submission.JobOffer.Inputs["Seed"])
+}
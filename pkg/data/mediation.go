@@ -0,0 +1,239 @@
+package data
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+func CheckMediatorQuorum(quorum MediatorQuorum) error {
+	if quorum.Threshold < 1 {
+		return fmt.Errorf("mediator quorum threshold must be at least one")
+	}
+	if quorum.Size < quorum.Threshold {
+		return fmt.Errorf("mediator quorum of %d cannot need %d verdicts", quorum.Size, quorum.Threshold)
+	}
+	return nil
+}
+
+const (
+	MEDIATOR_POLICY_RANDOM      = "random"
+	MEDIATOR_POLICY_STAKE       = "stake"
+	MEDIATOR_POLICY_REPUTATION  = "reputation"
+	MEDIATOR_POLICY_ROUND_ROBIN = "round-robin"
+)
+
+var MediatorPolicies = []string{MEDIATOR_POLICY_RANDOM, MEDIATOR_POLICY_STAKE, MEDIATOR_POLICY_REPUTATION, MEDIATOR_POLICY_ROUND_ROBIN}
+
+func IsMediatorPolicy(policy string) bool {
+	for _, known := range MediatorPolicies {
+		if policy == known {
+			return true
+		}
+	}
+	return false
+}
+
+// what the solver knows about the mediators when it makes a deal
+type MediatorPolicy struct {
+	Name string
+	// address -> weight for the stake and reputation policies
+	// mediators missing from here have no weight
+	Weights map[string]*big.Int
+	// where round-robin starts in the sorted candidates
+	Offset int
+	// the mutual mediators that look to be run by the resource provider
+	Relations []MediatorRelation
+	// leave the related mediators out rather than just recording them
+	RefuseRelated bool
+}
+
+// the mediators that are put on the deal
+// one of them without a quorum and the quorum size of them with one
+// the picks are drawn from a seed made from the two offers so that
+// anyone can run this again with the recorded weights and get the same answer
+func SelectMediators(mutualMediators []string, jobOffer JobOffer, resourceOffer ResourceOffer, policy MediatorPolicy) (MediatorSelection, error) {
+	if !IsMediatorPolicy(policy.Name) {
+		return MediatorSelection{}, fmt.Errorf("unknown mediator policy %q", policy.Name)
+	}
+	count := 1
+	if jobOffer.MediatorQuorum != nil {
+		count = jobOffer.MediatorQuorum.Size
+	}
+	candidates := []string{}
+	relations := []MediatorRelation{}
+	for _, mediator := range mutualMediators {
+		relation := getMediatorRelation(policy.Relations, mediator)
+		if relation != nil {
+			relation.Excluded = policy.RefuseRelated
+			relations = append(relations, *relation)
+			if relation.Excluded {
+				continue
+			}
+		}
+		candidates = append(candidates, mediator)
+	}
+	if len(candidates) < count {
+		if len(candidates) < len(mutualMediators) {
+			return MediatorSelection{}, NewError(ErrForbidden, "%d mutual mediators are not related to resource provider %s, a quorum needs %d", len(candidates), resourceOffer.ResourceProvider, count)
+		}
+		return MediatorSelection{}, fmt.Errorf("%d mutual mediators is not enough for a quorum of %d", len(mutualMediators), count)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return strings.ToLower(candidates[i]) < strings.ToLower(candidates[j])
+	})
+	seed := sha256.Sum256([]byte(jobOffer.ID + "/" + resourceOffer.ID))
+	selection := MediatorSelection{
+		Policy:     policy.Name,
+		Candidates: candidates,
+		Seed:       hex.EncodeToString(seed[:]),
+	}
+	if len(relations) > 0 {
+		selection.Related = relations
+	}
+	if policy.Name == MEDIATOR_POLICY_ROUND_ROBIN {
+		selection.Offset = policy.Offset % len(candidates)
+		for i := 0; i < count; i++ {
+			selection.Mediators = append(selection.Mediators, candidates[(selection.Offset+i)%len(candidates)])
+		}
+		return selection, nil
+	}
+	weights := []*big.Int{}
+	for _, candidate := range candidates {
+		weight := big.NewInt(1)
+		if policy.Name != MEDIATOR_POLICY_RANDOM {
+			weight = getMediatorWeight(policy.Weights, candidate)
+		}
+		weights = append(weights, weight)
+		selection.Weights = append(selection.Weights, weight.String())
+	}
+	selection.Mediators = drawMediators(candidates, weights, seed[:], count)
+	return selection, nil
+}
+
+func getMediatorRelation(relations []MediatorRelation, mediator string) *MediatorRelation {
+	for _, relation := range relations {
+		if strings.EqualFold(relation.Mediator, mediator) {
+			found := relation
+			return &found
+		}
+	}
+	return nil
+}
+
+func getMediatorWeight(weights map[string]*big.Int, mediator string) *big.Int {
+	for address, weight := range weights {
+		if strings.EqualFold(address, mediator) && weight != nil && weight.Sign() > 0 {
+			return new(big.Int).Set(weight)
+		}
+	}
+	return big.NewInt(0)
+}
+
+// weighted draws without replacement, each from its own hash of the seed
+// if every remaining weight is zero they are all drawn from evenly
+func drawMediators(candidates []string, weights []*big.Int, seed []byte, count int) []string {
+	remaining := append([]string{}, candidates...)
+	remainingWeights := append([]*big.Int{}, weights...)
+	drawn := []string{}
+	for draw := 0; draw < count; draw++ {
+		total := big.NewInt(0)
+		for _, weight := range remainingWeights {
+			total.Add(total, weight)
+		}
+		if total.Sign() == 0 {
+			for i := range remainingWeights {
+				remainingWeights[i] = big.NewInt(1)
+			}
+			total.SetInt64(int64(len(remainingWeights)))
+		}
+		hash := sha256.Sum256(append(append([]byte{}, seed...), byte(draw)))
+		point := new(big.Int).Mod(new(big.Int).SetBytes(hash[:]), total)
+		index := 0
+		for ; index < len(remainingWeights)-1; index++ {
+			if point.Cmp(remainingWeights[index]) < 0 {
+				break
+			}
+			point.Sub(point, remainingWeights[index])
+		}
+		drawn = append(drawn, remaining[index])
+		remaining = append(remaining[:index], remaining[index+1:]...)
+		remainingWeights = append(remainingWeights[:index], remainingWeights[index+1:]...)
+	}
+	return drawn
+}
+
+// run the recorded selection again and check the deal has the mediators it gives
+func VerifyMediatorSelection(deal Deal) error {
+	selection := deal.MediatorSelection
+	if selection == nil {
+		return fmt.Errorf("deal %s has no record of how its mediators were chosen", deal.ID)
+	}
+	policy := MediatorPolicy{
+		Name:    selection.Policy,
+		Weights: map[string]*big.Int{},
+		Offset:  selection.Offset,
+	}
+	for i, weight := range selection.Weights {
+		if i >= len(selection.Candidates) {
+			break
+		}
+		value, ok := new(big.Int).SetString(weight, 10)
+		if !ok {
+			return fmt.Errorf("mediator weight %q is not a number", weight)
+		}
+		policy.Weights[selection.Candidates[i]] = value
+	}
+	expected, err := SelectMediators(selection.Candidates, deal.JobOffer, deal.ResourceOffer, policy)
+	if err != nil {
+		return err
+	}
+	if expected.Seed != selection.Seed || strings.Join(expected.Mediators, ",") != strings.Join(selection.Mediators, ",") {
+		return fmt.Errorf("deal %s mediators %v do not match the %s policy which picks %v", deal.ID, selection.Mediators, selection.Policy, expected.Mediators)
+	}
+	if strings.Join(deal.Members.Mediators, ",") != strings.Join(selection.Mediators, ",") {
+		return fmt.Errorf("deal %s members are not the mediators that were chosen", deal.ID)
+	}
+	return nil
+}
+
+func IsDealMediator(deal DealContainer, address string) bool {
+	for _, mediator := range deal.Deal.Members.Mediators {
+		if strings.EqualFold(mediator, address) {
+			return true
+		}
+	}
+	return false
+}
+
+// decided is true once the verdicts so far settle the mediation
+// the result is accepted when the threshold of mediators accept it and
+// rejected as soon as too many have rejected it for that to happen
+func GetMediationOutcome(deal DealContainer) (decided bool, accept bool) {
+	quorum := deal.Deal.JobOffer.MediatorQuorum
+	if quorum == nil {
+		return false, false
+	}
+	accepts := 0
+	rejects := 0
+	for _, verdict := range deal.MediationVerdicts {
+		if !IsDealMediator(deal, verdict.Mediator) {
+			continue
+		}
+		if verdict.Accept {
+			accepts++
+		} else {
+			rejects++
+		}
+	}
+	if accepts >= quorum.Threshold {
+		return true, true
+	}
+	if rejects > quorum.Size-quorum.Threshold {
+		return true, false
+	}
+	return false, false
+}
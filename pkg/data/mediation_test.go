@@ -0,0 +1,136 @@
+package data
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestSelectMediators(t *testing.T) {
+	mutual := []string{"0xd", "0xa", "0xc", "0xb", "0xe"}
+	jobOffer := JobOffer{ID: "job"}
+	resourceOffer := ResourceOffer{ID: "resource"}
+
+	selection, err := SelectMediators(mutual, jobOffer, resourceOffer, MediatorPolicy{Name: MEDIATOR_POLICY_RANDOM})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(selection.Mediators) != 1 {
+		t.Errorf("Expected one mediator without a quorum, got %v", selection.Mediators)
+	}
+
+	// the same offers get the same mediator whatever order they come in
+	again, err := SelectMediators([]string{"0xe", "0xc", "0xa", "0xd", "0xb"}, jobOffer, resourceOffer, MediatorPolicy{Name: MEDIATOR_POLICY_RANDOM})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(again.Mediators) != fmt.Sprint(selection.Mediators) {
+		t.Errorf("Expected %v, got %v", selection.Mediators, again.Mediators)
+	}
+
+	// all of the stake is with one mediator
+	selection, err = SelectMediators(mutual, jobOffer, resourceOffer, MediatorPolicy{
+		Name:    MEDIATOR_POLICY_STAKE,
+		Weights: map[string]*big.Int{"0xC": big.NewInt(1000)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(selection.Mediators) != "[0xc]" {
+		t.Errorf("Expected the only staked mediator, got %v", selection.Mediators)
+	}
+
+	selection, err = SelectMediators(mutual, jobOffer, resourceOffer, MediatorPolicy{Name: MEDIATOR_POLICY_ROUND_ROBIN, Offset: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(selection.Mediators) != "[0xc]" {
+		t.Errorf("Expected the third mediator, got %v", selection.Mediators)
+	}
+
+	jobOffer.MediatorQuorum = &MediatorQuorum{Size: 3, Threshold: 2}
+	selection, err = SelectMediators(mutual, jobOffer, resourceOffer, MediatorPolicy{Name: MEDIATOR_POLICY_RANDOM})
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := map[string]bool{}
+	for _, mediator := range selection.Mediators {
+		if seen[mediator] {
+			t.Errorf("Expected different mediators, got %v", selection.Mediators)
+		}
+		seen[mediator] = true
+	}
+	if len(seen) != 3 {
+		t.Errorf("Expected 3 mediators, got %v", selection.Mediators)
+	}
+
+	_, err = SelectMediators(mutual[:2], jobOffer, resourceOffer, MediatorPolicy{Name: MEDIATOR_POLICY_RANDOM})
+	if err == nil {
+		t.Errorf("Expected an error with too few mediators for the quorum")
+	}
+}
+
+func TestVerifyMediatorSelection(t *testing.T) {
+	jobOffer := JobOffer{ID: "job", Services: ServiceConfig{Mediator: []string{"0xa", "0xb", "0xc"}}}
+	resourceOffer := ResourceOffer{ID: "resource", Services: ServiceConfig{Mediator: []string{"0xa", "0xb", "0xc"}}}
+	deal, err := GetDeal(jobOffer, resourceOffer, MediatorPolicy{
+		Name:    MEDIATOR_POLICY_REPUTATION,
+		Weights: map[string]*big.Int{"0xa": big.NewInt(100), "0xb": big.NewInt(50), "0xc": big.NewInt(300)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deal.Mediator == "" || fmt.Sprint(deal.Members.Mediators) != fmt.Sprintf("[%s]", deal.Mediator) {
+		t.Errorf("Expected the deal members to be the chosen mediator %s, got %v", deal.Mediator, deal.Members.Mediators)
+	}
+	if err := VerifyMediatorSelection(deal); err != nil {
+		t.Errorf("Expected the selection to verify, got %s", err.Error())
+	}
+
+	// a solver that swaps in another mediator is caught
+	for _, mediator := range deal.MediatorSelection.Candidates {
+		if mediator != deal.Mediator {
+			deal.Members.Mediators = []string{mediator}
+			deal.MediatorSelection.Mediators = []string{mediator}
+			break
+		}
+	}
+	if err := VerifyMediatorSelection(deal); err == nil {
+		t.Errorf("Expected a changed mediator not to verify")
+	}
+}
+
+func TestGetMediationOutcome(t *testing.T) {
+	verdict := func(mediator string, accept bool) MediationVerdict {
+		return MediationVerdict{Mediator: mediator, Accept: accept}
+	}
+	testCases := []struct {
+		name     string
+		verdicts []MediationVerdict
+		decided  bool
+		accept   bool
+	}{
+		{name: "no verdicts", verdicts: nil, decided: false},
+		{name: "one accept", verdicts: []MediationVerdict{verdict("0xa", true)}, decided: false},
+		{name: "two accepts", verdicts: []MediationVerdict{verdict("0xa", true), verdict("0xb", true)}, decided: true, accept: true},
+		{name: "split", verdicts: []MediationVerdict{verdict("0xa", true), verdict("0xb", false)}, decided: false},
+		{name: "two rejects", verdicts: []MediationVerdict{verdict("0xa", false), verdict("0xc", false)}, decided: true, accept: false},
+		{name: "outsider ignored", verdicts: []MediationVerdict{verdict("0xa", true), verdict("0xf", true)}, decided: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			deal := DealContainer{
+				Deal: Deal{
+					Members:  DealMembers{Mediators: []string{"0xA", "0xB", "0xC"}},
+					JobOffer: JobOffer{MediatorQuorum: &MediatorQuorum{Size: 3, Threshold: 2}},
+				},
+				MediationVerdicts: tc.verdicts,
+			}
+			decided, accept := GetMediationOutcome(deal)
+			if decided != tc.decided || accept != tc.accept {
+				t.Errorf("Expected decided=%t accept=%t, got decided=%t accept=%t", tc.decided, tc.accept, decided, accept)
+			}
+		})
+	}
+}
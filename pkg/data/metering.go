@@ -0,0 +1,112 @@
+package data
+
+import (
+	"fmt"
+)
+
+// how the executor measured the work a job did
+const (
+	// nothing was measured, the job counts as one instruction
+	MeteringJob = "job"
+	// cpu time from the cgroup of the container
+	// and the gpus the container was given for as long as it ran
+	MeteringCgroup = "cgroup"
+	// cpu time of the process that ran the job e.g. wasmtime
+	MeteringProcess = "process"
+)
+
+const (
+	// a metered deal is billed one instruction per cpu second
+	METERED_CPU_MILLIS_PER_INSTRUCTION = 1000
+	// and one instruction per tenth of a gpu second
+	METERED_GPU_MILLIS_PER_INSTRUCTION = 100
+	// what a meter can read over the spec of the deal
+	// cpu quotas are enforced per period and the meter samples
+	// so the last period and the start up of the job can go over
+	METERING_SLACK_PERCENT = 10
+	METERING_SLACK_MILLIS  = 2000
+)
+
+// the count for a metered deal
+// cpu and gpu time are rounded up and every job is at least one instruction
+func GetMeteredInstructionCount(metering ResultMetering) uint64 {
+	if metering.Method == "" || metering.Method == MeteringJob {
+		return 1
+	}
+	count := divideRoundingUp(metering.CPUTime, METERED_CPU_MILLIS_PER_INSTRUCTION) +
+		divideRoundingUp(metering.GPUTime, METERED_GPU_MILLIS_PER_INSTRUCTION)
+	if count == 0 {
+		return 1
+	}
+	return count
+}
+
+func divideRoundingUp(value uint64, divisor uint64) uint64 {
+	return (value + divisor - 1) / divisor
+}
+
+// the most of a resource in milli units a job could have used in runtime milliseconds
+func getMeteringLimit(milliUnits int, runtime uint64) uint64 {
+	limit := uint64(milliUnits) * runtime / 1000 //nolint:gomnd
+
+	return limit + limit*METERING_SLACK_PERCENT/100 + METERING_SLACK_MILLIS //nolint:gomnd
+}
+
+// check the instruction count and metering of a result could have come
+// from running the deal, the solver does this before it accepts a result
+// the runtime is also held to how long the deal has been agreed for
+func CheckResultMetering(deal DealContainer, result Result, now int64) error {
+	// a service deal is paid for the requests the solver saw answered
+	if IsServiceDeal(deal) {
+		return nil
+	}
+	jobOffer := deal.Deal.JobOffer
+	if jobOffer.Metered && result.Error == "" {
+		if result.Metering == nil {
+			return fmt.Errorf("deal %s is metered but the result has no metering", deal.ID)
+		}
+		expected := GetMeteredInstructionCount(*result.Metering)
+		if result.InstructionCount != expected {
+			return fmt.Errorf("deal %s metered %d instructions but the result claims %d", deal.ID, expected, result.InstructionCount)
+		}
+	}
+	runtime := result.Runtime
+	if deal.State == GetAgreementStateIndex("DealAgreed") && deal.StateUpdatedAt > 0 {
+		agreedFor := uint64(0)
+		if now > deal.StateUpdatedAt {
+			agreedFor = uint64(now-deal.StateUpdatedAt) * 1000 //nolint:gomnd
+		}
+		if runtime > agreedFor+METERING_SLACK_MILLIS {
+			return fmt.Errorf("deal %s has been agreed for %dms but the result ran for %dms", deal.ID, agreedFor, runtime)
+		}
+	}
+	if result.Metering == nil {
+		return nil
+	}
+	metering := *result.Metering
+	switch metering.Method {
+	case MeteringJob, MeteringCgroup, MeteringProcess:
+	default:
+		return fmt.Errorf("deal %s result was metered with unknown method %q", deal.ID, metering.Method)
+	}
+	// a job with nothing in its spec gets whatever the resource provider gives it
+	if cpu := jobOffer.Spec.CPU; cpu > 0 {
+		// a process is not held to a cpu quota but it runs on one thread
+		if metering.Method == MeteringProcess && cpu < 1000 {
+			cpu = 1000
+		}
+		limit := getMeteringLimit(cpu, runtime)
+		if metering.CPUTime > limit {
+			return fmt.Errorf("deal %s used %dms of cpu time but %d milli-cpu for %dms allows %dms", deal.ID, metering.CPUTime, cpu, runtime, limit)
+		}
+	}
+	if jobOffer.Spec.GPU > 0 {
+		// gpus are handed out whole
+		gpus := int(divideRoundingUp(uint64(jobOffer.Spec.GPU), 1000)) * 1000 //nolint:gomnd
+		limit := getMeteringLimit(gpus, runtime)
+		if metering.GPUTime > limit {
+			return fmt.Errorf("deal %s used %dms of gpu time but %d milli-gpu for %dms allows %dms", deal.ID, metering.GPUTime, jobOffer.Spec.GPU, runtime, limit)
+		}
+	}
+	return nil
+}
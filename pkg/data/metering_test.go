@@ -0,0 +1,66 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMeteredInstructionCount(t *testing.T) {
+	assert.Equal(t, uint64(1), GetMeteredInstructionCount(ResultMetering{Method: MeteringJob}))
+	assert.Equal(t, uint64(1), GetMeteredInstructionCount(ResultMetering{Method: MeteringCgroup}))
+	// 2.5 cpu seconds and 0.15 gpu seconds
+	assert.Equal(t, uint64(5), GetMeteredInstructionCount(ResultMetering{Method: MeteringCgroup, CPUTime: 2500, GPUTime: 150}))
+	assert.Equal(t, uint64(3), GetMeteredInstructionCount(ResultMetering{Method: MeteringProcess, CPUTime: 3000}))
+}
+
+func TestCheckResultMetering(t *testing.T) {
+	deal := DealContainer{
+		ID:             "deal",
+		State:          GetAgreementStateIndex("DealAgreed"),
+		StateUpdatedAt: 1000,
+		Deal: Deal{
+			JobOffer: JobOffer{
+				Spec:    MachineSpec{CPU: 2000, GPU: 500},
+				Metered: true,
+			},
+		},
+	}
+	metering := ResultMetering{Method: MeteringCgroup, CPUTime: 100000, GPUTime: 60000}
+	result := Result{Runtime: 60000, InstructionCount: 700, Metering: &metering}
+	assert.NoError(t, CheckResultMetering(deal, result, 1060))
+
+	wrongCount := result
+	wrongCount.InstructionCount = 701
+	assert.Error(t, CheckResultMetering(deal, wrongCount, 1060))
+
+	noMetering := result
+	noMetering.Metering = nil
+	assert.Error(t, CheckResultMetering(deal, noMetering, 1060))
+
+	// the job cannot have run for longer than the deal has been agreed
+	assert.Error(t, CheckResultMetering(deal, result, 1030))
+
+	// two cpus for a minute is at most two minutes of cpu time
+	tooMuchCPU := ResultMetering{Method: MeteringCgroup, CPUTime: 140000, GPUTime: 60000}
+	overCPU := result
+	overCPU.Metering = &tooMuchCPU
+	overCPU.InstructionCount = GetMeteredInstructionCount(tooMuchCPU)
+	assert.Error(t, CheckResultMetering(deal, overCPU, 1060))
+
+	// half a gpu is a whole card
+	tooMuchGPU := ResultMetering{Method: MeteringCgroup, CPUTime: 100000, GPUTime: 120000}
+	overGPU := result
+	overGPU.Metering = &tooMuchGPU
+	overGPU.InstructionCount = GetMeteredInstructionCount(tooMuchGPU)
+	assert.Error(t, CheckResultMetering(deal, overGPU, 1060))
+
+	// an unmetered deal still has its metering checked but not its count
+	unmetered := deal
+	unmetered.Deal.JobOffer.Metered = false
+	oneJob := result
+	oneJob.InstructionCount = 1
+	assert.NoError(t, CheckResultMetering(unmetered, oneJob, 1060))
+	overCPU.InstructionCount = 1
+	assert.Error(t, CheckResultMetering(unmetered, overCPU, 1060))
+}
@@ -0,0 +1,83 @@
+package data
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// more than this and each milestone is too small to be worth the gas
+const MAX_DEAL_MILESTONES = 100
+
+func CheckMilestones(jobOffer JobOffer) error {
+	if jobOffer.Milestones == 0 {
+		return nil
+	}
+	if jobOffer.Milestones > MAX_DEAL_MILESTONES {
+		return fmt.Errorf("job offer asks for %d milestones which is more than %d", jobOffer.Milestones, MAX_DEAL_MILESTONES)
+	}
+	if jobOffer.Pricing.PaymentCollateral == 0 {
+		return fmt.Errorf("job offer asks for milestones but has no payment collateral to pay them from")
+	}
+	return nil
+}
+
+// each milestone is an equal share of the payment collateral
+// the share that is left over is paid when the result is accepted
+func GetMilestoneAmount(deal Deal) *big.Int {
+	collateral := ConvertDealPricing(deal.Pricing, GetDealDecimals(deal)).PaymentCollateral
+	return new(big.Int).Div(collateral, new(big.Int).SetUint64(deal.JobOffer.Milestones+1))
+}
+
+func GetDealMilestone(deal DealContainer, index uint64) *DealMilestone {
+	for _, milestone := range deal.Milestones {
+		if milestone.Index == index {
+			return &milestone
+		}
+	}
+	return nil
+}
+
+// what a new checkpoint does to the milestones of a deal
+// a milestone the job creator has not looked at yet moves on to the
+// newer checkpoint, otherwise the next milestone is started
+// nil if the deal has no milestones left or one is being paid
+func GetCheckpointMilestone(deal DealContainer, checkpointCID string, now int64) *DealMilestone {
+	if len(deal.Milestones) > 0 {
+		last := deal.Milestones[len(deal.Milestones)-1]
+		switch last.State {
+		case DealMilestoneSubmitted:
+			last.CheckpointCID = checkpointCID
+			last.UpdatedAt = now
+			return &last
+		case DealMilestoneAccepted, DealMilestoneFailed:
+			return nil
+		}
+	}
+	if uint64(len(deal.Milestones)) >= deal.Deal.JobOffer.Milestones {
+		return nil
+	}
+	return &DealMilestone{
+		DealID:        deal.ID,
+		Index:         uint64(len(deal.Milestones)),
+		CheckpointCID: checkpointCID,
+		Amount:        GetMilestoneAmount(deal.Deal).String(),
+		State:         DealMilestoneSubmitted,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// the total the resource provider has been paid in milestones
+func GetMilestonesPaid(deal DealContainer) *big.Int {
+	paid := new(big.Int)
+	for _, milestone := range deal.Milestones {
+		if milestone.State != DealMilestonePaid {
+			continue
+		}
+		amount, ok := new(big.Int).SetString(milestone.Amount, 10) //nolint:gomnd
+		if ok {
+			paid.Add(paid, amount)
+		}
+	}
+	return paid
+}
@@ -0,0 +1,49 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCheckpointMilestone(t *testing.T) {
+	deal := DealContainer{
+		ID: "deal",
+		Deal: Deal{
+			Pricing:  DealPricing{PaymentCollateral: 3},
+			JobOffer: JobOffer{Milestones: 2},
+		},
+	}
+
+	first := GetCheckpointMilestone(deal, "QmFirst", 10)
+	assert.Equal(t, uint64(0), first.Index)
+	assert.Equal(t, DealMilestoneSubmitted, first.State)
+	assert.Equal(t, EtherToWei(1).String(), first.Amount)
+	deal.Milestones = []DealMilestone{*first}
+
+	// a newer checkpoint replaces one that has not been accepted yet
+	moved := GetCheckpointMilestone(deal, "QmSecond", 20)
+	assert.Equal(t, uint64(0), moved.Index)
+	assert.Equal(t, "QmSecond", moved.CheckpointCID)
+
+	// nothing new while a milestone is being paid
+	deal.Milestones[0].State = DealMilestoneAccepted
+	assert.Nil(t, GetCheckpointMilestone(deal, "QmThird", 30))
+
+	deal.Milestones[0].State = DealMilestonePaid
+	second := GetCheckpointMilestone(deal, "QmThird", 30)
+	assert.Equal(t, uint64(1), second.Index)
+	second.State = DealMilestonePaid
+	deal.Milestones = append(deal.Milestones, *second)
+
+	// the last share is paid with the result
+	assert.Nil(t, GetCheckpointMilestone(deal, "QmFourth", 40))
+	assert.Equal(t, EtherToWei(2).String(), GetMilestonesPaid(deal).String())
+}
+
+func TestCheckMilestones(t *testing.T) {
+	assert.NoError(t, CheckMilestones(JobOffer{}))
+	assert.Error(t, CheckMilestones(JobOffer{Milestones: 2}))
+	assert.Error(t, CheckMilestones(JobOffer{Milestones: MAX_DEAL_MILESTONES + 1, Pricing: DealPricing{PaymentCollateral: 1}}))
+	assert.NoError(t, CheckMilestones(JobOffer{Milestones: 2, Pricing: DealPricing{PaymentCollateral: 1}}))
+}
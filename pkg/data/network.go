@@ -0,0 +1,153 @@
+package data
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+var NetworkPolicyModes = []string{NetworkPolicyNone, NetworkPolicyEgressAllowlist, NetworkPolicyFull}
+
+var networkDomainPattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]*[a-z0-9])?\.)+[a-z]{2,}$`)
+
+func CheckNetworkPolicy(policy NetworkPolicy) error {
+	switch policy.Mode {
+	case NetworkPolicyNone, NetworkPolicyFull:
+		if len(policy.Allow) > 0 {
+			return fmt.Errorf("only the %s network policy takes a list of destinations", NetworkPolicyEgressAllowlist)
+		}
+	case NetworkPolicyEgressAllowlist:
+		if len(policy.Allow) == 0 {
+			return fmt.Errorf("the %s network policy needs at least one domain or CIDR", NetworkPolicyEgressAllowlist)
+		}
+		for _, entry := range policy.Allow {
+			err := CheckNetworkDestination(entry)
+			if err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unknown network policy %q, it must be one of %v", policy.Mode, NetworkPolicyModes)
+	}
+	return nil
+}
+
+// a domain, an IP address or a CIDR
+func CheckNetworkDestination(entry string) error {
+	if net.ParseIP(entry) != nil || networkDomainPattern.MatchString(entry) {
+		return nil
+	}
+	if _, _, err := net.ParseCIDR(entry); err == nil {
+		return nil
+	}
+	return fmt.Errorf("network destination %q is not a domain, IP address or CIDR", entry)
+}
+
+// is a destination a job wants to reach on the list
+// a domain covers its subdomains and a CIDR covers the IPs and
+// smaller CIDRs inside it
+func NetworkDestinationAllowed(allow []string, destination string) bool {
+	destination = strings.ToLower(strings.TrimSuffix(destination, "."))
+	destinationIP := net.ParseIP(destination)
+	_, destinationNet, _ := net.ParseCIDR(destination)
+	for _, entry := range allow {
+		entry = strings.ToLower(entry)
+		if entry == destination || strings.HasSuffix(destination, "."+entry) {
+			return true
+		}
+		if ip := net.ParseIP(entry); ip != nil && destinationIP != nil && ip.Equal(destinationIP) {
+			return true
+		}
+		_, allowNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			continue
+		}
+		if destinationIP != nil && allowNet.Contains(destinationIP) {
+			return true
+		}
+		if destinationNet != nil && allowNet.Contains(destinationNet.IP) {
+			allowBits, _ := allowNet.Mask.Size()
+			destinationBits, _ := destinationNet.Mask.Size()
+			if allowBits <= destinationBits {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// the network access a resource offer gives jobs for a module
+func GetResourceOfferNetworkPolicy(resourceOffer ResourceOffer, moduleID string) NetworkPolicy {
+	if policy, ok := resourceOffer.ModuleNetwork[moduleID]; ok {
+		return policy
+	}
+	if resourceOffer.Network != nil {
+		return *resourceOffer.Network
+	}
+	return NetworkPolicy{Mode: NetworkPolicyFull}
+}
+
+// does the resource provider give jobs at least the access the job offer needs
+func AllowsNetworkPolicy(provider NetworkPolicy, job NetworkPolicy) bool {
+	switch job.Mode {
+	case NetworkPolicyNone:
+		return true
+	case NetworkPolicyFull:
+		return provider.Mode == NetworkPolicyFull
+	case NetworkPolicyEgressAllowlist:
+		if provider.Mode == NetworkPolicyFull {
+			return true
+		}
+		if provider.Mode != NetworkPolicyEgressAllowlist {
+			return false
+		}
+		for _, entry := range job.Allow {
+			if !NetworkDestinationAllowed(provider.Allow, entry) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// the access both sides agree to, a job offer that does not say
+// what it needs gets what the resource provider gives
+func GetEffectiveNetworkPolicy(job *NetworkPolicy, provider NetworkPolicy) NetworkPolicy {
+	if job == nil {
+		return provider
+	}
+	if job.Mode == NetworkPolicyNone || provider.Mode == NetworkPolicyNone {
+		return NetworkPolicy{Mode: NetworkPolicyNone}
+	}
+	if job.Mode == NetworkPolicyFull {
+		return provider
+	}
+	if provider.Mode == NetworkPolicyFull {
+		return *job
+	}
+	allow := []string{}
+	for _, entry := range job.Allow {
+		if NetworkDestinationAllowed(provider.Allow, entry) {
+			allow = append(allow, entry)
+		}
+	}
+	if len(allow) == 0 {
+		return NetworkPolicy{Mode: NetworkPolicyNone}
+	}
+	return NetworkPolicy{Mode: NetworkPolicyEgressAllowlist, Allow: allow}
+}
+
+// the network access of a deal, deals made before the solver
+// recorded it get what the offers allow
+func GetDealNetworkPolicy(deal Deal) (NetworkPolicy, error) {
+	if deal.Network != nil {
+		return *deal.Network, nil
+	}
+	moduleID, err := GetModuleID(deal.JobOffer.Module)
+	if err != nil {
+		return NetworkPolicy{}, err
+	}
+	return GetEffectiveNetworkPolicy(deal.JobOffer.Network, GetResourceOfferNetworkPolicy(deal.ResourceOffer, moduleID)), nil
+}
@@ -0,0 +1,54 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworkDestinationAllowed(t *testing.T) {
+	allow := []string{"huggingface.co", "10.0.0.0/8", "192.168.1.10"}
+
+	tests := map[string]struct {
+		destination string
+		allowed     bool
+	}{
+		"domain":              {destination: "huggingface.co", allowed: true},
+		"subdomain":           {destination: "cdn-lfs.huggingface.co", allowed: true},
+		"lookalike domain":    {destination: "evilhuggingface.co", allowed: false},
+		"ip in cidr":          {destination: "10.1.2.3", allowed: true},
+		"cidr in cidr":        {destination: "10.1.0.0/16", allowed: true},
+		"wider cidr":          {destination: "10.0.0.0/7", allowed: false},
+		"ip":                  {destination: "192.168.1.10", allowed: true},
+		"other ip":            {destination: "192.168.1.11", allowed: false},
+		"domain with a dot":   {destination: "huggingface.co.", allowed: true},
+		"domain not on list":  {destination: "example.com", allowed: false},
+		"upper case":          {destination: "HuggingFace.co", allowed: true},
+		"ip outside any cidr": {destination: "8.8.8.8", allowed: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.allowed, NetworkDestinationAllowed(allow, test.destination))
+		})
+	}
+}
+
+func TestGetEffectiveNetworkPolicy(t *testing.T) {
+	none := NetworkPolicy{Mode: NetworkPolicyNone}
+	full := NetworkPolicy{Mode: NetworkPolicyFull}
+	provider := NetworkPolicy{Mode: NetworkPolicyEgressAllowlist, Allow: []string{"huggingface.co"}}
+	job := NetworkPolicy{Mode: NetworkPolicyEgressAllowlist, Allow: []string{"cdn.huggingface.co", "github.com"}}
+
+	assert.Equal(t, provider, GetEffectiveNetworkPolicy(nil, provider))
+	assert.Equal(t, none, GetEffectiveNetworkPolicy(&none, full))
+	assert.Equal(t, none, GetEffectiveNetworkPolicy(&full, none))
+	assert.Equal(t, provider, GetEffectiveNetworkPolicy(&full, provider))
+	assert.Equal(t, job, GetEffectiveNetworkPolicy(&job, full))
+	assert.Equal(t,
+		NetworkPolicy{Mode: NetworkPolicyEgressAllowlist, Allow: []string{"cdn.huggingface.co"}},
+		GetEffectiveNetworkPolicy(&job, provider),
+	)
+	assert.False(t, AllowsNetworkPolicy(provider, job))
+	assert.True(t, AllowsNetworkPolicy(full, job))
+}
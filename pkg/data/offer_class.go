@@ -0,0 +1,103 @@
+package data
+
+import "fmt"
+
+// reserved capacity runs the job to the end or the deal times out
+// spot capacity is cheaper but the resource provider can take it back
+const (
+	OfferClassReserved = "reserved"
+	OfferClassSpot     = "spot"
+)
+
+// a resource offer that does not say is reserved
+func GetOfferClass(resourceOffer ResourceOffer) string {
+	if resourceOffer.Class == "" {
+		return OfferClassReserved
+	}
+	return resourceOffer.Class
+}
+
+// a job offer that does not say only runs on reserved capacity
+func GetJobOfferClasses(jobOffer JobOffer) []string {
+	if len(jobOffer.OfferClasses) == 0 {
+		return []string{OfferClassReserved}
+	}
+	return jobOffer.OfferClasses
+}
+
+func CheckOfferClass(class string) error {
+	if class != OfferClassReserved && class != OfferClassSpot {
+		return fmt.Errorf("offer class %q must be %s or %s", class, OfferClassReserved, OfferClassSpot)
+	}
+	return nil
+}
+
+func CheckResourceOfferClass(resourceOffer ResourceOffer) error {
+	class := GetOfferClass(resourceOffer)
+	err := CheckOfferClass(class)
+	if err != nil {
+		return err
+	}
+	if resourceOffer.PreemptionNotice < 0 {
+		return fmt.Errorf("resource offer preemption notice cannot be negative")
+	}
+	if resourceOffer.ReservedDuration < 0 {
+		return fmt.Errorf("resource offer reserved duration cannot be negative")
+	}
+	if class == OfferClassReserved && resourceOffer.PreemptionNotice > 0 {
+		return fmt.Errorf("reserved resource offers cannot be preempted so have no preemption notice")
+	}
+	if class == OfferClassSpot && resourceOffer.ReservedDuration > 0 {
+		return fmt.Errorf("spot resource offers do not reserve a duration")
+	}
+	return nil
+}
+
+func CheckJobOfferClasses(jobOffer JobOffer) error {
+	for _, class := range jobOffer.OfferClasses {
+		err := CheckOfferClass(class)
+		if err != nil {
+			return err
+		}
+	}
+	if jobOffer.MinPreemptionNotice < 0 {
+		return fmt.Errorf("job offer min preemption notice cannot be negative")
+	}
+	return nil
+}
+
+// the job offer takes this class of capacity and the terms of the
+// class cover the job - enough notice for spot and a long enough
+// reservation for reserved
+func AcceptsOfferClass(resourceOffer ResourceOffer, jobOffer JobOffer) bool {
+	class := GetOfferClass(resourceOffer)
+	accepted := false
+	for _, jobClass := range GetJobOfferClasses(jobOffer) {
+		if jobClass == class {
+			accepted = true
+			break
+		}
+	}
+	if !accepted {
+		return false
+	}
+	switch class {
+	case OfferClassSpot:
+		return resourceOffer.PreemptionNotice >= jobOffer.MinPreemptionNotice
+	case OfferClassReserved:
+		// a job without a max runtime might run for as long as it likes
+		if resourceOffer.ReservedDuration > 0 {
+			return jobOffer.MaxRuntime > 0 && jobOffer.MaxRuntime <= resourceOffer.ReservedDuration
+		}
+	}
+	return true
+}
+
+func IsSpotDeal(deal DealContainer) bool {
+	return GetOfferClass(deal.Deal.ResourceOffer) == OfferClassSpot
+}
+
+// the job of a preempted deal keeps running until the notice is up
+func IsPreemptionDue(deal DealContainer, now int64) bool {
+	return deal.Preemption != nil && now >= deal.Preemption.StopAt
+}
@@ -0,0 +1,31 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcceptsOfferClass(t *testing.T) {
+	reserved := ResourceOffer{}
+	limited := ResourceOffer{Class: OfferClassReserved, ReservedDuration: 600}
+	spot := ResourceOffer{Class: OfferClassSpot, PreemptionNotice: 60}
+
+	assert.True(t, AcceptsOfferClass(reserved, JobOffer{}))
+	assert.False(t, AcceptsOfferClass(spot, JobOffer{}))
+	assert.True(t, AcceptsOfferClass(spot, JobOffer{OfferClasses: []string{OfferClassSpot}, MinPreemptionNotice: 60}))
+	assert.False(t, AcceptsOfferClass(spot, JobOffer{OfferClasses: []string{OfferClassSpot}, MinPreemptionNotice: 120}))
+	assert.False(t, AcceptsOfferClass(reserved, JobOffer{OfferClasses: []string{OfferClassSpot}}))
+
+	assert.True(t, AcceptsOfferClass(limited, JobOffer{MaxRuntime: 600}))
+	assert.False(t, AcceptsOfferClass(limited, JobOffer{MaxRuntime: 601}))
+	assert.False(t, AcceptsOfferClass(limited, JobOffer{}))
+}
+
+func TestCheckResourceOfferClass(t *testing.T) {
+	assert.NoError(t, CheckResourceOfferClass(ResourceOffer{}))
+	assert.NoError(t, CheckResourceOfferClass(ResourceOffer{Class: OfferClassSpot, PreemptionNotice: 30}))
+	assert.Error(t, CheckResourceOfferClass(ResourceOffer{Class: "preemptible"}))
+	assert.Error(t, CheckResourceOfferClass(ResourceOffer{PreemptionNotice: 30}))
+	assert.Error(t, CheckResourceOfferClass(ResourceOffer{Class: OfferClassSpot, ReservedDuration: 30}))
+}
@@ -0,0 +1,56 @@
+package data
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// the decimals of the network token that deals are paid in by default
+const NETWORK_TOKEN_DECIMALS = 18
+
+// beyond this a whole token does not fit in a uint256
+const MAX_PAYMENT_TOKEN_DECIMALS = 36
+
+func CheckPaymentToken(token PaymentToken) error {
+	if !common.IsHexAddress(token.Address) {
+		return fmt.Errorf("payment token %s is not an address", token.Address)
+	}
+	if token.Decimals > MAX_PAYMENT_TOKEN_DECIMALS {
+		return fmt.Errorf("payment token %s has %d decimals which is more than %d", token.Address, token.Decimals, MAX_PAYMENT_TOKEN_DECIMALS)
+	}
+	return nil
+}
+
+// an amount in whole tokens in the smallest unit of a token with these decimals
+func ToTokenUnits(amount float64, decimals uint8) *big.Int {
+	multiplier := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)) //nolint:gomnd
+	// the same float64 precision the amounts have always been converted with
+	units := new(big.Float).SetPrec(53).Mul(new(big.Float).SetFloat64(amount), multiplier) //nolint:gomnd
+	ret := new(big.Int)
+	units.Int(ret)
+	return ret
+}
+
+// the decimals the amounts of a deal are converted with on chain
+func GetDealDecimals(deal Deal) uint8 {
+	if deal.JobOffer.PaymentToken == nil {
+		return NETWORK_TOKEN_DECIMALS
+	}
+	return deal.JobOffer.PaymentToken.Decimals
+}
+
+// the network token is always accepted, other tokens only if the
+// resource provider lists them
+func AcceptsPaymentToken(resourceOffer ResourceOffer, token *PaymentToken) bool {
+	if token == nil {
+		return true
+	}
+	for _, accepted := range resourceOffer.PaymentTokens {
+		if common.HexToAddress(accepted) == common.HexToAddress(token.Address) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,22 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToTokenUnits(t *testing.T) {
+	assert.Equal(t, "1500000", ToTokenUnits(1.5, 6).String())
+	assert.Equal(t, EtherToWei(3).String(), ToTokenUnits(3, NETWORK_TOKEN_DECIMALS).String())
+	assert.Equal(t, "3", ToTokenUnits(3, 0).String())
+}
+
+func TestAcceptsPaymentToken(t *testing.T) {
+	usdc := "0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238"
+	resourceOffer := ResourceOffer{PaymentTokens: []string{usdc}}
+
+	assert.True(t, AcceptsPaymentToken(ResourceOffer{}, nil))
+	assert.True(t, AcceptsPaymentToken(resourceOffer, &PaymentToken{Address: "0x1c7d4b196cb0c7b01d743fbc6116a902379c7238", Decimals: 6}))
+	assert.False(t, AcceptsPaymentToken(ResourceOffer{}, &PaymentToken{Address: usdc, Decimals: 6}))
+}
@@ -0,0 +1,26 @@
+package data
+
+import "time"
+
+// the most deals we walk up or down when tracing where a deal came from
+const MAX_DEAL_LINEAGE_DEPTH = 100
+
+// a job offer that runs the job of a deal again with the same module and inputs
+// the offer is not part of the group, schedule, workflow or reservation
+// the original came from and a targeted resource offer will have gone
+func NewRerunJobOffer(deal DealContainer, request DealRerunRequest, now time.Time) JobOffer {
+	jobOffer := deal.Deal.JobOffer
+	jobOffer.ID = ""
+	jobOffer.CreatedAt = int(now.UnixMilli())
+	jobOffer.IdempotencyKey = ""
+	jobOffer.GroupID = ""
+	jobOffer.ScheduleID = ""
+	jobOffer.WorkflowID = ""
+	jobOffer.ReservationID = ""
+	jobOffer.Target.ResourceOffer = ""
+	if request.SameProvider {
+		jobOffer.Target.Address = deal.ResourceProvider
+	}
+	jobOffer.ParentDealID = deal.ID
+	return jobOffer
+}
@@ -0,0 +1,150 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// how far ahead a window can start and how long it can be (seconds)
+const MAX_RESERVATION_LEAD = 30 * 24 * 60 * 60
+const MAX_RESERVATION_WINDOW = 7 * 24 * 60 * 60
+
+// the most resource offers one reservation can hold
+const MAX_RESERVATION_COUNT = 64
+
+// a job offer without a max runtime is taken to run this long (seconds)
+// when working out if it would still be running when a window starts
+const RESERVATION_DEFAULT_JOB_RUNTIME = 60 * 60
+
+func CheckCapacityReservationSubmission(submission CapacityReservationSubmission, now int64) error {
+	if !common.IsHexAddress(submission.ResourceProvider) {
+		return fmt.Errorf("resource provider %s is not an address", submission.ResourceProvider)
+	}
+	if submission.Count < 1 || submission.Count > MAX_RESERVATION_COUNT {
+		return fmt.Errorf("a reservation holds between 1 and %d resource offers", MAX_RESERVATION_COUNT)
+	}
+	if submission.StartAt <= now {
+		return fmt.Errorf("a reservation has to start in the future")
+	}
+	if submission.StartAt-now > MAX_RESERVATION_LEAD {
+		return fmt.Errorf("a reservation cannot start more than %d seconds ahead", MAX_RESERVATION_LEAD)
+	}
+	window := submission.EndAt - submission.StartAt
+	if window <= 0 {
+		return fmt.Errorf("a reservation has to end after it starts")
+	}
+	if window > MAX_RESERVATION_WINDOW {
+		return fmt.Errorf("a reservation cannot be longer than %d seconds", MAX_RESERVATION_WINDOW)
+	}
+	if int64(submission.JobOffer.MaxRuntime) > window {
+		return fmt.Errorf("the job max runtime of %d seconds does not fit in the %d second window", submission.JobOffer.MaxRuntime, window)
+	}
+	// the reservation already says which resource provider runs the job
+	if HasTarget(submission.JobOffer) {
+		return fmt.Errorf("a reserved job offer cannot target a resource provider")
+	}
+	return CheckJobOffer(submission.JobOffer)
+}
+
+func GetCapacityReservationID(submission CapacityReservationSubmission) (string, error) {
+	submission.JobOffer.ID = ""
+	return CalculateCID(submission)
+}
+
+func NewCapacityReservation(submission CapacityReservationSubmission, now int64) (CapacityReservation, error) {
+	id, err := GetCapacityReservationID(submission)
+	if err != nil {
+		return CapacityReservation{}, err
+	}
+	jobOffer := submission.JobOffer
+	// the job can use the whole window unless it says otherwise
+	if jobOffer.MaxRuntime == 0 {
+		jobOffer.MaxRuntime = int(submission.EndAt - submission.StartAt)
+	}
+	return CapacityReservation{
+		ID:               id,
+		JobCreator:       jobOffer.JobCreator,
+		ResourceProvider: submission.ResourceProvider,
+		JobOffer:         jobOffer,
+		Count:            submission.Count,
+		StartAt:          submission.StartAt,
+		EndAt:            submission.EndAt,
+		State:            ReservationPending,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}, nil
+}
+
+// one of the job offers added when the window starts
+// the index makes each of them a different offer
+func GetReservationJobOffer(reservation CapacityReservation, index int) JobOffer {
+	offer := reservation.JobOffer
+	offer.ID = ""
+	offer.ReservationID = reservation.ID
+	offer.IdempotencyKey = ""
+	offer.TrustedProviders = []string{reservation.ResourceProvider}
+	offer.CreatedAt = int(reservation.StartAt*1000) + index //nolint:gomnd
+	return offer
+}
+
+// the reservation still holds capacity
+func IsReservationOpen(reservation CapacityReservation) bool {
+	return reservation.State == ReservationPending || reservation.State == ReservationActive
+}
+
+// a job offer from outside the reservation that could still be running
+// when the window starts conflicts with it
+func ReservationConflicts(reservation CapacityReservation, jobOffer JobOffer, now int64) bool {
+	if jobOffer.ReservationID == reservation.ID || !IsReservationOpen(reservation) || now >= reservation.EndAt {
+		return false
+	}
+	runtime := int64(jobOffer.MaxRuntime)
+	if runtime == 0 {
+		runtime = RESERVATION_DEFAULT_JOB_RUNTIME
+	}
+	return now+runtime > reservation.StartAt
+}
+
+// which resource offers each open reservation holds keyed by resource offer ID
+// a pending reservation holds its count and an active one holds as many as
+// it has job offers still waiting for a match, the offers with the lowest
+// index are held first and reservations that start first take theirs first
+func GetReservationHolds(reservations []CapacityReservation, resourceOffers []ResourceOffer, waiting map[string]int) map[string]CapacityReservation {
+	open := []CapacityReservation{}
+	for _, reservation := range reservations {
+		if IsReservationOpen(reservation) {
+			open = append(open, reservation)
+		}
+	}
+	sort.SliceStable(open, func(i, j int) bool {
+		return open[i].StartAt < open[j].StartAt
+	})
+	offers := append([]ResourceOffer{}, resourceOffers...)
+	sort.SliceStable(offers, func(i, j int) bool {
+		if offers[i].Index != offers[j].Index {
+			return offers[i].Index < offers[j].Index
+		}
+		return offers[i].ID < offers[j].ID
+	})
+	holds := map[string]CapacityReservation{}
+	for _, reservation := range open {
+		count := reservation.Count
+		if reservation.State == ReservationActive {
+			count = waiting[reservation.ID]
+		}
+		for _, offer := range offers {
+			if count <= 0 {
+				break
+			}
+			if _, held := holds[offer.ID]; held || !strings.EqualFold(offer.ResourceProvider, reservation.ResourceProvider) {
+				continue
+			}
+			holds[offer.ID] = reservation
+			count--
+		}
+	}
+	return holds
+}
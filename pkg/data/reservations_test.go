@@ -0,0 +1,90 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const reservedProvider = "0x2546BcD3c84621e976D8185a91A922aE77ECEc30"
+
+func getTestReservationSubmission() CapacityReservationSubmission {
+	return CapacityReservationSubmission{
+		JobOffer: JobOffer{
+			JobCreator: "0xjc",
+			Services:   ServiceConfig{Solver: "0xsolver", Mediator: []string{"0xmediator"}},
+		},
+		ResourceProvider: reservedProvider,
+		Count:            2,
+		StartAt:          1000,
+		EndAt:            4600,
+	}
+}
+
+func TestCheckCapacityReservationSubmission(t *testing.T) {
+	submission := getTestReservationSubmission()
+	assert.NoError(t, CheckCapacityReservationSubmission(submission, 500))
+	// the window has already started
+	assert.Error(t, CheckCapacityReservationSubmission(submission, 1000))
+
+	tooLong := submission
+	tooLong.JobOffer.MaxRuntime = 3601
+	assert.Error(t, CheckCapacityReservationSubmission(tooLong, 500))
+
+	backwards := submission
+	backwards.EndAt = backwards.StartAt
+	assert.Error(t, CheckCapacityReservationSubmission(backwards, 500))
+
+	noCount := submission
+	noCount.Count = 0
+	assert.Error(t, CheckCapacityReservationSubmission(noCount, 500))
+}
+
+func TestNewCapacityReservation(t *testing.T) {
+	reservation, err := NewCapacityReservation(getTestReservationSubmission(), 500)
+	assert.NoError(t, err)
+	assert.Equal(t, ReservationPending, reservation.State)
+	assert.Equal(t, 3600, reservation.JobOffer.MaxRuntime)
+
+	first := GetReservationJobOffer(reservation, 0)
+	second := GetReservationJobOffer(reservation, 1)
+	assert.Equal(t, reservation.ID, first.ReservationID)
+	assert.Equal(t, []string{reservedProvider}, first.TrustedProviders)
+	assert.NotEqual(t, first.CreatedAt, second.CreatedAt)
+}
+
+func TestReservationConflicts(t *testing.T) {
+	reservation, err := NewCapacityReservation(getTestReservationSubmission(), 500)
+	assert.NoError(t, err)
+
+	// done before the window starts
+	assert.False(t, ReservationConflicts(reservation, JobOffer{MaxRuntime: 400}, 500))
+	assert.True(t, ReservationConflicts(reservation, JobOffer{MaxRuntime: 600}, 500))
+	// no max runtime is taken as an hour
+	assert.True(t, ReservationConflicts(reservation, JobOffer{}, 500))
+	assert.False(t, ReservationConflicts(reservation, JobOffer{ReservationID: reservation.ID}, 2000))
+	assert.False(t, ReservationConflicts(reservation, JobOffer{}, 4600))
+}
+
+func TestGetReservationHolds(t *testing.T) {
+	reservation, err := NewCapacityReservation(getTestReservationSubmission(), 500)
+	assert.NoError(t, err)
+	offers := []ResourceOffer{
+		{ID: "c", ResourceProvider: reservedProvider, Index: 2},
+		{ID: "a", ResourceProvider: reservedProvider, Index: 0},
+		{ID: "b", ResourceProvider: reservedProvider, Index: 1},
+		{ID: "other", ResourceProvider: "0xother", Index: 0},
+	}
+	holds := GetReservationHolds([]CapacityReservation{reservation}, offers, nil)
+	assert.Len(t, holds, 2)
+	assert.Contains(t, holds, "a")
+	assert.Contains(t, holds, "b")
+
+	// once active only the job offers still waiting hold capacity
+	reservation.State = ReservationActive
+	holds = GetReservationHolds([]CapacityReservation{reservation}, offers, map[string]int{reservation.ID: 1})
+	assert.Len(t, holds, 1)
+
+	reservation.State = ReservationCompleted
+	assert.Empty(t, GetReservationHolds([]CapacityReservation{reservation}, offers, nil))
+}
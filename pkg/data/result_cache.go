@@ -0,0 +1,67 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// the parts of a job offer that decide what result it gets
+type jobResultKey struct {
+	Module     ModuleConfig      `json:"module"`
+	Inputs     map[string]string `json:"inputs"`
+	InputCIDs  []string          `json:"input_cids"`
+	Env        map[string]string `json:"env"`
+	ResumeFrom string            `json:"resume_from"`
+}
+
+// a job can only be recognised again if the module cannot move to new code
+// and the solver can see all of its inputs
+func CheckReusableJob(jobOffer JobOffer) error {
+	err := CheckModuleVersionPinned(jobOffer.Module)
+	if err != nil {
+		return fmt.Errorf("job offer cannot reuse results: %s", err.Error())
+	}
+	if len(jobOffer.EncryptedInputs) > 0 {
+		return fmt.Errorf("job offer cannot reuse results when it has encrypted inputs")
+	}
+	return nil
+}
+
+// the CID of the module version and inputs of the job
+// two deals with the same key ran the same work
+// empty if the job cannot be recognised again
+func GetJobResultKey(jobOffer JobOffer) string {
+	if CheckReusableJob(jobOffer) != nil {
+		return ""
+	}
+	inputCIDs := append([]string{}, jobOffer.InputCIDs...)
+	sort.Strings(inputCIDs)
+	key, err := CalculateCID(jobResultKey{
+		Module:     jobOffer.Module,
+		Inputs:     jobOffer.Inputs,
+		InputCIDs:  inputCIDs,
+		Env:        jobOffer.Env,
+		ResumeFrom: jobOffer.ResumeFrom,
+	})
+	if err != nil {
+		return ""
+	}
+	return key
+}
+
+// the deal finished with a result the job creator accepted
+// that can be handed back to a job offer of theirs for the same work
+func IsReusableDeal(deal DealContainer, result *Result, jobOffer JobOffer) bool {
+	if deal.ResultKey == "" || deal.ResultKey != GetJobResultKey(jobOffer) {
+		return false
+	}
+	if !strings.EqualFold(deal.JobCreator, jobOffer.JobCreator) {
+		return false
+	}
+	state := DealState(deal.State)
+	if state != ResultsAccepted && state != MediationAccepted {
+		return false
+	}
+	return result != nil && result.DataID != "" && result.Error == "" && !result.Preempted
+}
@@ -0,0 +1,64 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetJobResultKey(t *testing.T) {
+	jobOffer := JobOffer{
+		JobCreator: "0xjc",
+		Module:     ModuleConfig{Repo: "https://github.com/lilypad-tech/lilypad-module-cowsay", Hash: "v0.0.4"},
+		Inputs:     map[string]string{"Message": "moo"},
+		InputCIDs:  []string{"QmB", "QmA"},
+	}
+	key := GetJobResultKey(jobOffer)
+	assert.NotEmpty(t, key)
+
+	// what the offer pays or where it runs does not change the work
+	other := jobOffer
+	other.Pricing.InstructionPrice = 10
+	other.CreatedAt = 1
+	other.InputCIDs = []string{"QmA", "QmB"}
+	assert.Equal(t, key, GetJobResultKey(other))
+
+	other.Inputs = map[string]string{"Message": "baa"}
+	assert.NotEqual(t, key, GetJobResultKey(other))
+
+	// a branch can move to new code
+	unpinned := jobOffer
+	unpinned.Module.Hash = "main"
+	assert.Empty(t, GetJobResultKey(unpinned))
+	assert.Error(t, CheckReusableJob(unpinned))
+
+	encrypted := jobOffer
+	encrypted.EncryptedInputs = []string{"Secret"}
+	assert.Empty(t, GetJobResultKey(encrypted))
+}
+
+func TestIsReusableDeal(t *testing.T) {
+	jobOffer := JobOffer{
+		JobCreator: "0xJC",
+		Module:     ModuleConfig{Repo: "https://github.com/lilypad-tech/lilypad-module-cowsay", Hash: "v0.0.4"},
+		Inputs:     map[string]string{"Message": "moo"},
+	}
+	deal := GetDealContainer(Deal{ID: "deal", JobOffer: jobOffer})
+	deal.JobCreator = "0xjc"
+	deal.State = uint8(ResultsAccepted)
+	result := &Result{DealID: "deal", DataID: "QmResult"}
+
+	assert.True(t, IsReusableDeal(deal, result, jobOffer))
+	assert.False(t, IsReusableDeal(deal, nil, jobOffer))
+	assert.False(t, IsReusableDeal(deal, &Result{DealID: "deal", Error: "exit 1"}, jobOffer))
+
+	other := jobOffer
+	other.JobCreator = "0xsomeoneelse"
+	assert.False(t, IsReusableDeal(deal, result, other))
+	other = jobOffer
+	other.Inputs = map[string]string{"Message": "baa"}
+	assert.False(t, IsReusableDeal(deal, result, other))
+
+	deal.State = uint8(ResultsSubmitted)
+	assert.False(t, IsReusableDeal(deal, result, jobOffer))
+}
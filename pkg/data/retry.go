@@ -0,0 +1,80 @@
+package data
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// more than this and a broken job keeps the solver busy for too long
+const MAX_RETRY_ATTEMPTS = 10
+
+func CheckRetryPolicy(jobOffer JobOffer) error {
+	policy := jobOffer.Retry
+	if policy.MaxAttempts < 1 {
+		return fmt.Errorf("retry policy must allow at least one attempt")
+	}
+	if policy.MaxAttempts > MAX_RETRY_ATTEMPTS {
+		return fmt.Errorf("retry policy asks for %d attempts which is more than %d", policy.MaxAttempts, MAX_RETRY_ATTEMPTS)
+	}
+	if !policy.OnTimeout && !policy.OnError {
+		return fmt.Errorf("retry policy must retry on timeout, on error or both")
+	}
+	if policy.ExcludeFailedProviders && jobOffer.Target.Address != "" {
+		return fmt.Errorf("retry policy cannot exclude failed providers from a job that targets one")
+	}
+	return nil
+}
+
+// the deal failed in a way its retry policy runs the job again for
+// a cancelled job offer is never retried
+func IsRetryableDeal(deal DealContainer, result *Result) bool {
+	policy := deal.Deal.JobOffer.Retry
+	if policy == nil || deal.CancelledAt != 0 {
+		return false
+	}
+	state := DealState(deal.State)
+	if state.IsTimeout() {
+		return policy.OnTimeout
+	}
+	if state == MediationRejected {
+		return policy.OnError
+	}
+	// the same inputs will be refused again wherever the job runs
+	if result != nil && result.ErrorCode == ErrInvalidRequest {
+		return false
+	}
+	if state.IsTerminal() && result != nil && (result.Error != "" || result.Preempted) {
+		return policy.OnError
+	}
+	return false
+}
+
+// attempts is how many deals the job has had including this one
+func ShouldRetryDeal(deal DealContainer, result *Result, attempts int) bool {
+	if !IsRetryableDeal(deal, result) {
+		return false
+	}
+	return attempts < deal.Deal.JobOffer.Retry.MaxAttempts
+}
+
+// the job offer for the next attempt at a failed deal
+// the idempotency key means a deal is only retried once
+// however many times its final state is seen
+func NewRetryJobOffer(deal DealContainer, now time.Time) JobOffer {
+	jobOffer := NewRerunJobOffer(deal, DealRerunRequest{}, now)
+	jobOffer.IdempotencyKey = "retry:" + deal.ID
+	if jobOffer.Retry != nil && jobOffer.Retry.ExcludeFailedProviders {
+		excluded := false
+		for _, provider := range jobOffer.ExcludedProviders {
+			if strings.EqualFold(provider, deal.ResourceProvider) {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			jobOffer.ExcludedProviders = append(append([]string{}, jobOffer.ExcludedProviders...), deal.ResourceProvider)
+		}
+	}
+	return jobOffer
+}
@@ -0,0 +1,90 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		offer   JobOffer
+		wantErr bool
+	}{
+		{"on error", JobOffer{Retry: &RetryPolicy{MaxAttempts: 3, OnError: true}}, false},
+		{"no attempts", JobOffer{Retry: &RetryPolicy{OnError: true}}, true},
+		{"too many attempts", JobOffer{Retry: &RetryPolicy{MaxAttempts: MAX_RETRY_ATTEMPTS + 1, OnError: true}}, true},
+		{"retries nothing", JobOffer{Retry: &RetryPolicy{MaxAttempts: 3}}, true},
+		{"excludes its target", JobOffer{
+			Retry:  &RetryPolicy{MaxAttempts: 3, OnTimeout: true, ExcludeFailedProviders: true},
+			Target: TargetConfig{Address: "0xrp"},
+		}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := CheckRetryPolicy(test.offer)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestShouldRetryDeal(t *testing.T) {
+	getDeal := func(policy RetryPolicy, state DealState) DealContainer {
+		return DealContainer{
+			ID:    "deal",
+			State: uint8(state),
+			Deal:  Deal{JobOffer: JobOffer{Retry: &policy}},
+		}
+	}
+	onTimeout := RetryPolicy{MaxAttempts: 2, OnTimeout: true}
+	onError := RetryPolicy{MaxAttempts: 2, OnError: true}
+
+	assert.True(t, ShouldRetryDeal(getDeal(onTimeout, TimeoutSubmitResults), nil, 1))
+	assert.False(t, ShouldRetryDeal(getDeal(onTimeout, TimeoutSubmitResults), nil, 2))
+	assert.False(t, ShouldRetryDeal(getDeal(onError, TimeoutSubmitResults), nil, 1))
+
+	assert.True(t, ShouldRetryDeal(getDeal(onError, MediationRejected), nil, 1))
+	assert.True(t, ShouldRetryDeal(getDeal(onError, ResultsAccepted), &Result{Error: "exit 1"}, 1))
+	assert.True(t, ShouldRetryDeal(getDeal(onError, ResultsAccepted), &Result{Preempted: true}, 1))
+	assert.False(t, ShouldRetryDeal(getDeal(onError, ResultsAccepted), &Result{DataID: "QmResult"}, 1))
+	assert.False(t, ShouldRetryDeal(getDeal(onError, ResultsAccepted), &Result{Error: "exit 65", ErrorCode: ErrInvalidRequest}, 1))
+	assert.False(t, ShouldRetryDeal(getDeal(onTimeout, MediationRejected), nil, 1))
+	// not finished yet
+	assert.False(t, ShouldRetryDeal(getDeal(onError, ResultsSubmitted), &Result{Error: "exit 1"}, 1))
+
+	cancelled := getDeal(onTimeout, TimeoutAgree)
+	cancelled.CancelledAt = 1
+	assert.False(t, ShouldRetryDeal(cancelled, nil, 1))
+}
+
+func TestNewRetryJobOffer(t *testing.T) {
+	deal := GetDealContainer(Deal{
+		ID: "deal",
+		JobOffer: JobOffer{
+			ID:                "offer",
+			IdempotencyKey:    "first",
+			ExcludedProviders: []string{"0xother"},
+			Retry:             &RetryPolicy{MaxAttempts: 3, OnError: true, ExcludeFailedProviders: true},
+		},
+		ResourceOffer: ResourceOffer{ResourceProvider: "0xrp"},
+	})
+
+	retry := NewRetryJobOffer(deal, time.UnixMilli(1000))
+	assert.Equal(t, "", retry.ID)
+	assert.Equal(t, "retry:deal", retry.IdempotencyKey)
+	assert.Equal(t, "deal", retry.ParentDealID)
+	assert.Equal(t, 1000, retry.CreatedAt)
+	assert.Equal(t, []string{"0xother", "0xrp"}, retry.ExcludedProviders)
+	// the deal keeps its own list
+	assert.Equal(t, []string{"0xother"}, deal.Deal.JobOffer.ExcludedProviders)
+
+	// a provider is only excluded once
+	deal.Deal.JobOffer.ExcludedProviders = []string{"0xRP"}
+	assert.Equal(t, []string{"0xRP"}, NewRetryJobOffer(deal, time.UnixMilli(1000)).ExcludedProviders)
+}
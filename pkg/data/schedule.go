@@ -0,0 +1,80 @@
+package data
+
+import (
+	"fmt"
+	"time"
+)
+
+var ScheduleOverlapPolicies = []string{ScheduleOverlapAllow, ScheduleOverlapSkip, ScheduleOverlapReplace}
+
+func CheckScheduleOverlapPolicy(policy string) error {
+	for _, known := range ScheduleOverlapPolicies {
+		if policy == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown overlap policy %q, it must be one of %v", policy, ScheduleOverlapPolicies)
+}
+
+func CheckJobScheduleSubmission(submission JobScheduleSubmission) error {
+	_, err := ParseCron(submission.Cron)
+	if err != nil {
+		return err
+	}
+	if submission.OverlapPolicy != "" {
+		err = CheckScheduleOverlapPolicy(submission.OverlapPolicy)
+		if err != nil {
+			return err
+		}
+	}
+	return CheckJobOffer(submission.JobOffer)
+}
+
+func GetJobScheduleID(submission JobScheduleSubmission) (string, error) {
+	submission.JobOffer.ID = ""
+	return CalculateCID(submission)
+}
+
+func NewJobSchedule(submission JobScheduleSubmission, now time.Time) (JobSchedule, error) {
+	id, err := GetJobScheduleID(submission)
+	if err != nil {
+		return JobSchedule{}, err
+	}
+	schedule := JobSchedule{
+		ID:            id,
+		JobCreator:    submission.JobOffer.JobCreator,
+		JobOffer:      submission.JobOffer,
+		Cron:          submission.Cron,
+		OverlapPolicy: submission.OverlapPolicy,
+		CreatedAt:     now.Unix(),
+	}
+	if schedule.OverlapPolicy == "" {
+		schedule.OverlapPolicy = ScheduleOverlapSkip
+	}
+	return schedule, ScheduleNextRun(&schedule, now)
+}
+
+// work out the tick after now
+func ScheduleNextRun(schedule *JobSchedule, now time.Time) error {
+	cron, err := ParseCron(schedule.Cron)
+	if err != nil {
+		return err
+	}
+	next := cron.Next(now)
+	if next.IsZero() {
+		return fmt.Errorf("cron expression %q never runs", schedule.Cron)
+	}
+	schedule.NextRunAt = next.Unix()
+	return nil
+}
+
+// the job offer for one tick of the schedule
+// the tick time makes each of them a different offer
+func GetScheduledJobOffer(schedule JobSchedule, tick time.Time) JobOffer {
+	offer := schedule.JobOffer
+	offer.ID = ""
+	offer.ScheduleID = schedule.ID
+	offer.IdempotencyKey = ""
+	offer.CreatedAt = int(tick.UnixMilli())
+	return offer
+}
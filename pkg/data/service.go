@@ -0,0 +1,114 @@
+package data
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// a week, the deal collateral is locked up for as long as the service runs
+	MAX_SERVICE_DURATION = 7 * 24 * 60 * 60
+	// the most body a request or response can carry through the solver
+	MAX_SERVICE_REQUEST_SIZE  = 1024 * 1024
+	MAX_SERVICE_RESPONSE_SIZE = 8 * 1024 * 1024
+	MAX_SERVICE_HEADERS       = 64
+)
+
+var serviceRequestMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+
+func CheckServiceDealConfig(jobOffer JobOffer) error {
+	serve := jobOffer.Serve
+	if serve.Port < 1 || serve.Port > 65535 {
+		return fmt.Errorf("service port %d is not a valid port", serve.Port)
+	}
+	if serve.Duration < 1 || serve.Duration > MAX_SERVICE_DURATION {
+		return fmt.Errorf("service duration must be between 1 and %d seconds", MAX_SERVICE_DURATION)
+	}
+	// the resource provider submits the result once the service stops
+	if jobOffer.Timeouts.SubmitResults.Timeout <= uint64(serve.Duration) {
+		return fmt.Errorf("the submit results timeout of %d seconds must be longer than the service runs for", jobOffer.Timeouts.SubmitResults.Timeout)
+	}
+	if jobOffer.ReuseResults {
+		return fmt.Errorf("a service deal has no result to reuse")
+	}
+	if jobOffer.Metered {
+		return fmt.Errorf("a service deal is paid for its requests and cannot be metered")
+	}
+	return nil
+}
+
+func IsServiceDeal(deal DealContainer) bool {
+	return deal.Deal.JobOffer.Serve != nil
+}
+
+func CheckServiceRequest(request ServiceRequest) error {
+	found := false
+	for _, method := range serviceRequestMethods {
+		if request.Method == method {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("service request method must be one of %s", strings.Join(serviceRequestMethods, ", "))
+	}
+	if !strings.HasPrefix(request.Path, "/") {
+		return fmt.Errorf("service request path %q must start with /", request.Path)
+	}
+	if len(request.Path) > MAX_OFFER_STRING_LENGTH {
+		return fmt.Errorf("service request path is longer than %d characters", MAX_OFFER_STRING_LENGTH)
+	}
+	if len(request.Headers) > MAX_SERVICE_HEADERS {
+		return fmt.Errorf("service request has more than %d headers", MAX_SERVICE_HEADERS)
+	}
+	if len(request.Body) > MAX_SERVICE_REQUEST_SIZE {
+		return fmt.Errorf("service request body is %d bytes, the most we take is %d", len(request.Body), MAX_SERVICE_REQUEST_SIZE)
+	}
+	return nil
+}
+
+func CheckServiceResponse(response ServiceResponse) error {
+	if response.RequestID == "" {
+		return fmt.Errorf("service response must name its request")
+	}
+	if response.Error == "" && (response.StatusCode < 100 || response.StatusCode > 599) {
+		return fmt.Errorf("service response status %d is not an HTTP status", response.StatusCode)
+	}
+	if len(response.Headers) > MAX_SERVICE_HEADERS {
+		return fmt.Errorf("service response has more than %d headers", MAX_SERVICE_HEADERS)
+	}
+	if len(response.Body) > MAX_SERVICE_RESPONSE_SIZE {
+		return fmt.Errorf("service response body is %d bytes, the most we take is %d", len(response.Body), MAX_SERVICE_RESPONSE_SIZE)
+	}
+	return nil
+}
+
+// count a response the job creator was sent
+func AddServiceUsage(usage *ServiceUsage, response ServiceResponse, now int64) ServiceUsage {
+	updated := ServiceUsage{}
+	if usage != nil {
+		updated = *usage
+	}
+	if response.Error == "" {
+		updated.Requests++
+	} else {
+		updated.Failed++
+	}
+	updated.UpdatedAt = now
+	return updated
+}
+
+// a service deal is paid for the requests we metered and no more
+func CheckServiceResult(deal DealContainer, result Result) error {
+	if !IsServiceDeal(deal) {
+		return nil
+	}
+	var requests uint64
+	if deal.ServiceUsage != nil {
+		requests = deal.ServiceUsage.Requests
+	}
+	if result.InstructionCount > requests {
+		return fmt.Errorf("service deal %s answered %d requests but the result claims %d", deal.ID, requests, result.InstructionCount)
+	}
+	return nil
+}
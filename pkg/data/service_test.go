@@ -0,0 +1,75 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckServiceDealConfig(t *testing.T) {
+	jobOffer := JobOffer{
+		Serve: &ServiceDealConfig{Port: 8080, Duration: 3600},
+		Timeouts: DealTimeouts{
+			SubmitResults: DealTimeout{Timeout: 3900},
+		},
+	}
+	assert.NoError(t, CheckServiceDealConfig(jobOffer))
+
+	badPort := jobOffer
+	badPort.Serve = &ServiceDealConfig{Port: 70000, Duration: 3600}
+	assert.Error(t, CheckServiceDealConfig(badPort))
+
+	tooLong := jobOffer
+	tooLong.Serve = &ServiceDealConfig{Port: 8080, Duration: MAX_SERVICE_DURATION + 1}
+	assert.Error(t, CheckServiceDealConfig(tooLong))
+
+	// the result is due after the service stops
+	shortTimeout := jobOffer
+	shortTimeout.Timeouts.SubmitResults.Timeout = 3600
+	assert.Error(t, CheckServiceDealConfig(shortTimeout))
+
+	reused := jobOffer
+	reused.ReuseResults = true
+	assert.Error(t, CheckServiceDealConfig(reused))
+}
+
+func TestCheckServiceRequest(t *testing.T) {
+	request := ServiceRequest{Method: "POST", Path: "/v1/completions", Body: []byte(`{"prompt":"hi"}`)}
+	assert.NoError(t, CheckServiceRequest(request))
+
+	badMethod := request
+	badMethod.Method = "CONNECT"
+	assert.Error(t, CheckServiceRequest(badMethod))
+
+	badPath := request
+	badPath.Path = "http://example.com/"
+	assert.Error(t, CheckServiceRequest(badPath))
+
+	tooBig := request
+	tooBig.Body = make([]byte, MAX_SERVICE_REQUEST_SIZE+1)
+	assert.Error(t, CheckServiceRequest(tooBig))
+
+	assert.NoError(t, CheckServiceResponse(ServiceResponse{RequestID: "r1", StatusCode: 200}))
+	assert.NoError(t, CheckServiceResponse(ServiceResponse{RequestID: "r1", Error: "connection refused"}))
+	assert.Error(t, CheckServiceResponse(ServiceResponse{RequestID: "r1"}))
+	assert.Error(t, CheckServiceResponse(ServiceResponse{StatusCode: 200}))
+}
+
+func TestServiceUsage(t *testing.T) {
+	usage := AddServiceUsage(nil, ServiceResponse{RequestID: "r1", StatusCode: 200}, 1)
+	usage = AddServiceUsage(&usage, ServiceResponse{RequestID: "r2", StatusCode: 500}, 2)
+	usage = AddServiceUsage(&usage, ServiceResponse{RequestID: "r3", Error: "timeout"}, 3)
+	assert.Equal(t, ServiceUsage{Requests: 2, Failed: 1, UpdatedAt: 3}, usage)
+
+	deal := DealContainer{
+		ID:           "deal",
+		Deal:         Deal{JobOffer: JobOffer{Serve: &ServiceDealConfig{Port: 8080, Duration: 60}}},
+		ServiceUsage: &usage,
+	}
+	assert.NoError(t, CheckServiceResult(deal, Result{InstructionCount: 2}))
+	assert.Error(t, CheckServiceResult(deal, Result{InstructionCount: 3}))
+
+	// batch deals are metered by the resource provider
+	deal.Deal.JobOffer.Serve = nil
+	assert.NoError(t, CheckServiceResult(deal, Result{InstructionCount: 3}))
+}
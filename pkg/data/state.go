@@ -0,0 +1,96 @@
+package data
+
+import "fmt"
+
+// DealState is an index into AgreementState
+// the deal, job offer and resource offer containers store it as a raw uint8
+// because that is what the storage contract emits
+type DealState uint8
+
+const (
+	DealNegotiating DealState = iota
+	DealAgreed
+	ResultsSubmitted
+	ResultsAccepted
+	ResultsChecked
+	MediationAccepted
+	MediationRejected
+	TimeoutAgree
+	TimeoutSubmitResults
+	TimeoutJudgeResults
+	TimeoutMediateResults
+	JobOfferCancelled
+)
+
+// the states each state is allowed to move to next
+// these mirror the state checks in LilypadStorage.sol with the addition
+// of JobOfferCancelled which the solver uses for job offers it cannot match
+// running a job is not a contract state - a deal stays DealAgreed until
+// the resource provider submits results or times out
+var dealStateTransitions = map[DealState][]DealState{
+	DealNegotiating:  {DealAgreed, TimeoutAgree, JobOfferCancelled},
+	DealAgreed:       {ResultsSubmitted, TimeoutSubmitResults},
+	ResultsSubmitted: {ResultsAccepted, ResultsChecked, TimeoutJudgeResults},
+	ResultsChecked:   {MediationAccepted, MediationRejected, TimeoutMediateResults},
+}
+
+func (state DealState) String() string {
+	if int(state) >= len(AgreementState) {
+		return fmt.Sprintf("DealState(%d)", state)
+	}
+	return AgreementState[state]
+}
+
+func (state DealState) IsValid() bool {
+	return int(state) < len(AgreementState)
+}
+
+// a terminal state has nowhere left to go
+func (state DealState) IsTerminal() bool {
+	return state.IsValid() && len(dealStateTransitions[state]) == 0
+}
+
+func (state DealState) IsTimeout() bool {
+	return state == TimeoutAgree ||
+		state == TimeoutSubmitResults ||
+		state == TimeoutJudgeResults ||
+		state == TimeoutMediateResults
+}
+
+// can we move directly from this state to the next one
+func (state DealState) CanTransitionTo(next DealState) bool {
+	for _, allowed := range dealStateTransitions[state] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// can we get to the target state by some number of allowed transitions
+// we might miss events from the chain so the solver accepts skipping
+// ahead but never going backwards or leaving a terminal state
+func (state DealState) CanReach(target DealState) bool {
+	for _, next := range dealStateTransitions[state] {
+		if next == target || next.CanReach(target) {
+			return true
+		}
+	}
+	return false
+}
+
+// check a state update is allowed - repeating the current state is a no-op
+func ValidateDealStateTransition(from uint8, to uint8) error {
+	fromState := DealState(from)
+	toState := DealState(to)
+	if !toState.IsValid() {
+		return fmt.Errorf("unknown deal state %d", to)
+	}
+	if fromState == toState {
+		return nil
+	}
+	if !fromState.CanReach(toState) {
+		return fmt.Errorf("invalid deal state transition from %s to %s", fromState, toState)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDealStateMatchesAgreementState(t *testing.T) {
+	assert.Equal(t, "TimeoutAgree", TimeoutAgree.String())
+	assert.Equal(t, "JobOfferCancelled", JobOfferCancelled.String())
+	assert.Equal(t, uint8(JobOfferCancelled), GetAgreementStateIndex("JobOfferCancelled"))
+}
+
+func TestValidateDealStateTransition(t *testing.T) {
+	testCases := []struct {
+		name  string
+		from  DealState
+		to    DealState
+		valid bool
+	}{
+		{name: "Agree", from: DealNegotiating, to: DealAgreed, valid: true},
+		{name: "Same state", from: DealAgreed, to: DealAgreed, valid: true},
+		{name: "Skip missed events", from: DealAgreed, to: ResultsAccepted, valid: true},
+		{name: "Mediation", from: ResultsChecked, to: MediationRejected, valid: true},
+		{name: "Backwards", from: ResultsSubmitted, to: DealAgreed, valid: false},
+		{name: "Leave terminal state", from: ResultsAccepted, to: ResultsChecked, valid: false},
+		{name: "Cancel agreed deal", from: DealAgreed, to: JobOfferCancelled, valid: false},
+		{name: "Mediate accepted results", from: ResultsSubmitted, to: ResultsAccepted, valid: true},
+		{name: "Timeout after results", from: ResultsSubmitted, to: TimeoutSubmitResults, valid: false},
+		{name: "Unknown state", from: DealNegotiating, to: DealState(100), valid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateDealStateTransition(uint8(tc.from), uint8(tc.to))
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestDealStateIsTerminal(t *testing.T) {
+	assert.False(t, DealAgreed.IsTerminal())
+	assert.False(t, ResultsChecked.IsTerminal())
+	assert.True(t, ResultsAccepted.IsTerminal())
+	assert.True(t, TimeoutSubmitResults.IsTerminal())
+	assert.True(t, JobOfferCancelled.IsTerminal())
+}
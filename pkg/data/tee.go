@@ -0,0 +1,60 @@
+package data
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+var TEETypes = []string{TEETypeSGX, TEETypeSEVSNP, TEETypeTDX}
+
+func CheckTEEType(teeType string) error {
+	for _, known := range TEETypes {
+		if teeType == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown TEE type %q, it must be one of %v", teeType, TEETypes)
+}
+
+func CheckTEERequirement(requirement TEERequirement) error {
+	for _, teeType := range requirement.Types {
+		err := CheckTEEType(teeType)
+		if err != nil {
+			return err
+		}
+	}
+	for _, measurement := range requirement.Measurements {
+		_, err := hex.DecodeString(strings.TrimPrefix(measurement, "0x"))
+		if err != nil {
+			return fmt.Errorf("TEE measurement %q is not hex", measurement)
+		}
+	}
+	return nil
+}
+
+// the job offer trusts the environment the resource offer attests to
+// the solver has already checked the attestation when it took the offer
+func MeetsTEERequirement(requirement TEERequirement, attestation *TEEAttestation) bool {
+	if attestation == nil {
+		return false
+	}
+	if len(requirement.Types) > 0 && !containsHexFold(requirement.Types, attestation.Type) {
+		return false
+	}
+	if len(requirement.Measurements) > 0 && !containsHexFold(requirement.Measurements, attestation.Measurement) {
+		return false
+	}
+	return true
+}
+
+// measurements are compared as hex so case and a 0x prefix do not matter
+func containsHexFold(values []string, value string) bool {
+	value = strings.TrimPrefix(value, "0x")
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimPrefix(v, "0x"), value) {
+			return true
+		}
+	}
+	return false
+}
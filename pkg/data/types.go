@@ -1,6 +1,8 @@
 package data
 
 import (
+	"encoding/json"
+
 	"github.com/lilypad-tech/lilypad/pkg/data/bacalhau"
 )
 
@@ -45,6 +47,58 @@ type Module struct {
 
 	// the bacalhau job spec
 	Job bacalhau.Job `json:"job"`
+
+	// optional per-platform builds of the module image
+	// when present the executor picks the variant that matches
+	// its hardware and swaps the image in the job spec
+	Variants []ModuleVariant `json:"variants"`
+
+	// set by modules that can pick up where they left off
+	Checkpoint *ModuleCheckpoint `json:"checkpoint,omitempty"`
+
+	// set by modules that follow the input and output contract
+	// see pkg/module/contract.go for what they are given and must write
+	Contract *ModuleContract `json:"contract,omitempty"`
+}
+
+// what a module takes and promises to write back
+type ModuleContract struct {
+	// the inputs the module reads, others are refused
+	Inputs []ModuleContractInput `json:"inputs,omitempty"`
+	// the module always writes a result manifest to its outputs
+	Manifest bool `json:"manifest,omitempty"`
+}
+
+type ModuleContractInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	// a regular expression the whole value must match
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// the checkpoint protocol of a module
+// the job writes whatever it needs to carry on into the folder at Path
+// and when it is resumed finds the last checkpoint already in that folder
+type ModuleCheckpoint struct {
+	// the folder in the container the job keeps its checkpoints in
+	Path string `json:"path"`
+	// how often the resource provider uploads the folder (seconds)
+	// zero means the resource provider default
+	Interval int `json:"interval,omitempty"`
+}
+
+// a single build of a module image for a given platform
+// an empty Arch or Accelerator means "any"
+type ModuleVariant struct {
+	// the GOARCH style architecture e.g. amd64 or arm64
+	Arch string `json:"arch"`
+	// the accelerator the image was built for e.g. cuda, rocm or cpu
+	Accelerator string `json:"accelerator"`
+	// the image to run (without the digest)
+	Image string `json:"image"`
+	// the content digest of the image e.g. sha256:...
+	Digest string `json:"digest"`
 }
 
 // describes a workload to be run
@@ -69,6 +123,35 @@ type ModuleConfig struct {
 	// this is the path to the module template
 	// within the repo
 	Path string `json:"path"`
+	// the digest the module image must have e.g. sha256:...
+	// the resource provider refuses to run any other image
+	// this is not part of the module ID so allowlist entries still match
+	ImageDigest string `json:"image_digest,omitempty"`
+}
+
+// an entry in the list of modules the solver will match jobs for
+type AllowlistItem struct {
+	// the module this entry is for
+	Module ModuleConfig `json:"module"`
+	// the CID of the module config above
+	// if this is empty it is calculated when the allowlist is loaded
+	ModuleID string `json:"module_id"`
+	// the smallest spec the module is known to run on
+	// job offers that ask for less than this will be rejected
+	// because they are guaranteed to fail
+	MinimumSpec MachineSpec `json:"minimum_spec"`
+	// the names of the solver result verifiers to run for this module
+	// leave this empty to use the solver defaults
+	Verifiers []string `json:"verifiers"`
+	// the default max runtime for jobs using this module (seconds)
+	// it applies to deals where the job offer does not set its own
+	MaxRuntime int `json:"max_runtime"`
+	// job offers for a disabled module are cancelled rather than matched
+	// this lets a bad module version be pulled without removing its entry
+	Disabled bool `json:"disabled"`
+	// the digest of the reviewed module image
+	// it is pinned onto deals so resource providers only run that image
+	ImageDigest string `json:"image_digest,omitempty"`
 }
 
 type Result struct {
@@ -76,9 +159,42 @@ type Result struct {
 	ID     string `json:"id"`
 	DealID string `json:"deal_id"`
 	// the CID of the actual results
-	DataID           string `json:"results_id"`
-	Error            string `json:"error"`
-	InstructionCount uint64 `json:"instruction_count"`
+	DataID string `json:"results_id"`
+	Error  string `json:"error"`
+	// what class of failure the error is, see ErrorCode
+	ErrorCode        ErrorCode `json:"error_code,omitempty"`
+	InstructionCount uint64    `json:"instruction_count"`
+	// the digest of the module image variant that produced the result
+	// this is empty for modules that do not publish variants
+	VariantDigest string `json:"variant_digest"`
+	// the digest of the image that ran and how the resource provider checked it
+	ImageDigest       string `json:"image_digest,omitempty"`
+	ImageVerification string `json:"image_verification,omitempty"`
+	// how long the job ran on the executor in milliseconds
+	Runtime uint64 `json:"runtime,omitempty"`
+	// the job was stopped because the resource provider preempted the spot deal
+	Preempted bool `json:"preempted,omitempty"`
+	// the storage backends the result files have been put in
+	Locations []ResultLocation `json:"locations,omitempty"`
+	// what the executor measured the job using, nil if it measured nothing
+	Metering *ResultMetering `json:"metering,omitempty"`
+}
+
+// the work a job did as measured by the executor, see MeteringJob
+type ResultMetering struct {
+	Method string `json:"method"`
+	// milliseconds of cpu time across all cores
+	CPUTime uint64 `json:"cpu_time,omitempty"`
+	// milliseconds each gpu was held for added up
+	GPUTime uint64 `json:"gpu_time,omitempty"`
+}
+
+// where a copy of the result files can be fetched from
+type ResultLocation struct {
+	// the storage backend e.g. ipfs, s3 or http
+	Backend string `json:"backend"`
+	// a CID for ipfs, s3://bucket/key for s3 or a URL for http
+	URI string `json:"uri"`
 }
 
 // MarketPrice means - get me the best deal
@@ -101,8 +217,14 @@ type ServiceConfig struct {
 	APIHost  string   `json:"api_host" toml:"api_host"`
 }
 
+// a job offer with a target is only matched with the offers of that
+// resource provider or with the one resource offer it names
 type TargetConfig struct {
+	// the resource provider address
 	Address string `json:"address" toml:"address"`
+	// the ID of a resource offer, if the address is set as well
+	// the offer must belong to that resource provider
+	ResourceOffer string `json:"resource_offer,omitempty" toml:"resource_offer"`
 }
 
 // posted to the solver by a job creator
@@ -113,6 +235,9 @@ type JobOffer struct {
 	CreatedAt int `json:"created_at"`
 	// the address of the job creator
 	JobCreator string `json:"job_creator"`
+	// the chain the deal will be made on
+	// zero is whichever chain the solver treats as its main one
+	ChainID int `json:"chain_id,omitempty"`
 	// the actual module that is being offered
 	// this must hash to the ModuleID above
 	Module ModuleConfig `json:"module"`
@@ -122,6 +247,14 @@ type JobOffer struct {
 	// the user inputs to the module
 	// these values will power the go template
 	Inputs map[string]string `json:"inputs"`
+	// the declared size of the data the job will pull in (Megabytes)
+	// this is checked against provider limits and solver quotas
+	// before a deal is made
+	InputSize int `json:"input_size"`
+	// the longest the job is allowed to run for (seconds)
+	// if this is zero the solver fills in the module default from the allowlist
+	// and a zero max runtime after that means the executor default applies
+	MaxRuntime int `json:"max_runtime,omitempty"`
 	// tells the solver how to match these prices
 	// for JC this will normally be MarketPrice
 	Mode PricingMode `json:"mode"`
@@ -134,6 +267,538 @@ type JobOffer struct {
 
 	// which node(s) (if any) to target
 	Target TargetConfig `json:"target"`
+
+	// if set only these resource providers can take the job
+	TrustedProviders []string `json:"trusted_providers,omitempty"`
+	// these resource providers will never be given the job
+	ExcludedProviders []string `json:"excluded_providers,omitempty"`
+
+	// resource offer labels that must match e.g. region=eu-west
+	RequiredLabels map[string]string `json:"required_labels,omitempty"`
+	// resource offer labels we would like to match
+	// offers matching more of these are chosen before cheaper ones
+	PreferredLabels map[string]string `json:"preferred_labels,omitempty"`
+
+	// the CID of a checkpoint from an earlier deal for the same module
+	// the job starts from it instead of from scratch
+	ResumeFrom string `json:"resume_from,omitempty"`
+
+	// if set disputed results are judged by a quorum of mediators
+	// rather than the one the mediation contract picks
+	MediatorQuorum *MediatorQuorum `json:"mediator_quorum,omitempty"`
+
+	// environment variables the job container is run with
+	// on top of the ones the module sets
+	Env map[string]string `json:"env,omitempty"`
+
+	// the array job this offer was expanded from
+	GroupID string `json:"group_id,omitempty"`
+
+	// the recurring job this offer was added for
+	ScheduleID string `json:"schedule_id,omitempty"`
+
+	// the workflow this offer is a stage of
+	WorkflowID string `json:"workflow_id,omitempty"`
+
+	// the capacity reservation this offer was added for
+	ReservationID string `json:"reservation_id,omitempty"`
+
+	// picked by the job creator so a retried submission gets back
+	// the offer it already added rather than a second one
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+
+	// the version and capabilities of the job creator
+	Client *ClientMetadata `json:"client,omitempty"`
+
+	// the IPFS CIDs the job reads
+	// the solver can check these can be fetched before the job offer is matched
+	InputCIDs []string `json:"input_cids,omitempty"`
+
+	// the inputs the job creator sends encrypted once the offer is matched
+	// they are encrypted for the key in the resource offer and only
+	// the resource provider that runs the job can read them
+	// a mediator cannot read them either so cannot run the job again
+	EncryptedInputs []string `json:"encrypted_inputs,omitempty"`
+
+	// only match resource offers that run jobs inside an attested TEE
+	TEE *TEERequirement `json:"tee,omitempty"`
+
+	// the network access the module needs
+	// nil leaves it to the module spec and the resource provider
+	Network *NetworkPolicy `json:"network,omitempty"`
+
+	// the ERC-20 token the deal is paid in
+	// nil is the network token and the prices are in units of this token
+	PaymentToken *PaymentToken `json:"payment_token,omitempty"`
+
+	// pay the resource provider in this many milestones as the job creator
+	// accepts checkpoints of the job, each one is an equal share of the
+	// payment collateral with one share left for when the result is accepted
+	// 0 pays everything when the result is accepted
+	Milestones uint64 `json:"milestones,omitempty"`
+
+	// the resource offer classes the job can run on
+	// empty means reserved only
+	OfferClasses []string `json:"offer_classes,omitempty"`
+	// the least notice in seconds a spot resource offer must give
+	// before it stops the job
+	MinPreemptionNotice int `json:"min_preemption_notice,omitempty"`
+
+	// the deal this job offer runs again
+	ParentDealID string `json:"parent_deal_id,omitempty"`
+
+	// if set the solver adds the job offer again when a deal for it fails
+	Retry *RetryPolicy `json:"retry,omitempty"`
+
+	// if an earlier deal of ours ran the same module version on the same
+	// inputs the solver hands back its result rather than matching the offer
+	ReuseResults bool `json:"reuse_results,omitempty"`
+
+	// if set the module runs as a service that takes requests through
+	// the solver for a while rather than a job that runs to an end
+	Serve *ServiceDealConfig `json:"serve,omitempty"`
+
+	// the engine the module runs on, empty for docker
+	// it is taken from the module so the job is only matched
+	// with resource providers that can run it
+	Engine string `json:"engine,omitempty"`
+
+	// bill the job by the cpu and gpu time the executor metered
+	// the instruction price is then per metered instruction rather than per job
+	Metered bool `json:"metered,omitempty"`
+}
+
+// modules built to WebAssembly rather than a container image
+const ModuleEngineWasm = "wasm"
+
+// an ERC-20 token that the payments contract allows deals to be paid in
+type PaymentToken struct {
+	Address string `json:"address"`
+	// the decimals the token contract reports, the solver checks this
+	Decimals uint8 `json:"decimals"`
+}
+
+// how much of the network a job container can reach
+const (
+	NetworkPolicyNone            = "none"
+	NetworkPolicyEgressAllowlist = "egress-allowlist"
+	NetworkPolicyFull            = "full"
+)
+
+type NetworkPolicy struct {
+	// none, egress-allowlist or full
+	Mode string `json:"mode"`
+	// the domains, IPs and CIDRs the job can connect to
+	// a domain also allows its subdomains
+	// only used by egress-allowlist
+	Allow []string `json:"allow,omitempty"`
+}
+
+// how the resource provider checked the module image it ran
+const (
+	// the image was run by tag so it could be anything
+	ImageVerificationUnverified = "unverified"
+	// the image was pulled by its digest
+	ImageVerificationDigest = "digest"
+	// the image was pulled by its digest and its signature was checked
+	ImageVerificationSigned = "signed"
+)
+
+// the kinds of trusted execution environment a resource provider can attest to
+const (
+	TEETypeSGX    = "sgx"
+	TEETypeSEVSNP = "sev-snp"
+	TEETypeTDX    = "tdx"
+)
+
+// the trusted execution a job offer needs
+type TEERequirement struct {
+	// the TEE types the job can run in, empty means any of them
+	Types []string `json:"types,omitempty"`
+	// the hex measurements of the environments the job trusts
+	// empty means any environment that attests
+	Measurements []string `json:"measurements,omitempty"`
+}
+
+// the evidence a resource provider gives that its jobs run in a TEE
+// the solver checks it before it takes the resource offer
+type TEEAttestation struct {
+	Type string `json:"type"`
+	// the hex measurement of the environment taken from the evidence
+	Measurement string `json:"measurement"`
+	// the raw quote or report (base64)
+	Evidence string `json:"evidence"`
+	// PEM certificates the evidence is signed with for TEE types whose
+	// evidence does not carry them, for sev-snp the VCEK and then the ASK
+	Certificates string `json:"certificates,omitempty"`
+	// when the evidence was produced (unix seconds)
+	CreatedAt int64 `json:"created_at"`
+}
+
+// K-of-N mediation where the solver puts Size mediators on the deal
+// and Threshold of them have to accept the result for it to stand
+type MediatorQuorum struct {
+	Size      int `json:"size"`
+	Threshold int `json:"threshold"`
+}
+
+// how the solver runs a job again when a deal for it fails
+type RetryPolicy struct {
+	// the most deals the job gets including the first one
+	MaxAttempts int `json:"max_attempts"`
+	// retry deals that timed out
+	OnTimeout bool `json:"on_timeout,omitempty"`
+	// retry deals whose result was an error, was preempted or lost mediation
+	OnError bool `json:"on_error,omitempty"`
+	// do not match the retry with the resource providers that failed it before
+	ExcludeFailedProviders bool `json:"exclude_failed_providers,omitempty"`
+}
+
+// a service deal runs its module for Duration seconds and the job creator
+// sends it HTTP requests through the solver, each answered request is one
+// instruction so the deal is paid per request at the instruction price
+type ServiceDealConfig struct {
+	// the port the module takes requests on inside its container
+	Port int `json:"port"`
+	// how long the service runs for (seconds)
+	Duration int `json:"duration"`
+}
+
+// an HTTP request the solver routes to the service of a deal
+type ServiceRequest struct {
+	ID     string `json:"id"`
+	DealID string `json:"deal_id"`
+	Method string `json:"method"`
+	// the path and query the request is sent to on the service
+	Path    string            `json:"path"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    []byte            `json:"body,omitempty"`
+	// unix milliseconds
+	CreatedAt int64 `json:"created_at"`
+}
+
+// what the service of a deal answered to a request
+type ServiceResponse struct {
+	RequestID  string            `json:"request_id"`
+	StatusCode int               `json:"status_code"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       []byte            `json:"body,omitempty"`
+	// set when there was no answer from the service
+	// these requests are not paid for
+	Error string `json:"error,omitempty"`
+}
+
+// the requests the solver has metered for a service deal
+type ServiceUsage struct {
+	// requests the service answered
+	Requests uint64 `json:"requests"`
+	// requests the service gave no answer to
+	Failed uint64 `json:"failed"`
+	// unix milliseconds
+	UpdatedAt int64 `json:"updated_at"`
+}
+
+// the body of a request to cancel a job offer
+type JobOfferCancellation struct {
+	JobOfferID string `json:"job_offer_id"`
+}
+
+// the body of a request to run the job of a deal again
+type DealRerunRequest struct {
+	// target the resource provider that ran the deal
+	SameProvider bool `json:"same_provider,omitempty"`
+}
+
+// the deals a deal was run again from and the deals that ran it again
+type DealLineage struct {
+	Deal DealContainer `json:"deal"`
+	// the original deal first
+	Ancestors []DealContainer `json:"ancestors"`
+	// every deal run again from this one or from one of those
+	// in the order they were made
+	Descendants []DealContainer `json:"descendants"`
+}
+
+// the body of a request to submit an array job
+// the job offer is copied once for each of the values
+// with the parameter input set to that value
+type JobGroupSubmission struct {
+	JobOffer  JobOffer `json:"job_offer"`
+	Parameter string   `json:"parameter"`
+	Values    []string `json:"values"`
+}
+
+// the job offers that came from one array job
+type JobGroup struct {
+	ID         string   `json:"id"`
+	JobCreator string   `json:"job_creator"`
+	Parameter  string   `json:"parameter"`
+	Values     []string `json:"values"`
+	// the job offer IDs in the same order as the values
+	JobOffers []string `json:"job_offers"`
+	CreatedAt int      `json:"created_at"`
+}
+
+const (
+	// add a job offer at every tick whatever the last one is doing
+	ScheduleOverlapAllow = "allow"
+	// leave out a tick while the job offer from the last one is still going
+	ScheduleOverlapSkip = "skip"
+	// cancel the job offer from the last tick and add a new one
+	ScheduleOverlapReplace = "replace"
+)
+
+// the body of a request to add a recurring job
+type JobScheduleSubmission struct {
+	JobOffer JobOffer `json:"job_offer"`
+	// five field cron expression in UTC or a shortcut like @daily
+	Cron string `json:"cron"`
+	// allow, skip or replace, skip if not set
+	OverlapPolicy string `json:"overlap_policy"`
+}
+
+// a job offer the solver adds for the job creator on a cron schedule
+type JobSchedule struct {
+	ID            string   `json:"id"`
+	JobCreator    string   `json:"job_creator"`
+	JobOffer      JobOffer `json:"job_offer"`
+	Cron          string   `json:"cron"`
+	OverlapPolicy string   `json:"overlap_policy"`
+	Paused        bool     `json:"paused"`
+	// unix seconds of the next tick, missed ticks are not made up
+	NextRunAt int64 `json:"next_run_at"`
+	LastRunAt int64 `json:"last_run_at"`
+	// the job offer added at the last tick
+	LastJobOffer string `json:"last_job_offer"`
+	// how many job offers have been added and how many ticks were skipped
+	Runs    int `json:"runs"`
+	Skipped int `json:"skipped"`
+	// why the last tick did not add a job offer
+	LastError string `json:"last_error"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// the body of a request to change a recurring job
+// fields that are not set are left as they are
+type JobScheduleUpdate struct {
+	Cron          string `json:"cron,omitempty"`
+	OverlapPolicy string `json:"overlap_policy,omitempty"`
+	Paused        *bool  `json:"paused,omitempty"`
+}
+
+// the body of a request to remove a recurring job
+type JobScheduleRemoval struct {
+	JobScheduleID string `json:"job_schedule_id"`
+}
+
+const (
+	// the window has not started, the resource provider's capacity is
+	// held back from jobs that would still be running at the start
+	ReservationPending = "pending"
+	// the job offers have been added for the window
+	ReservationActive = "active"
+	// the window is over
+	ReservationCompleted = "completed"
+	ReservationCancelled = "cancelled"
+	// the job offers could not be added at the start
+	ReservationFailed = "failed"
+)
+
+// the body of a request to reserve a resource provider's capacity
+// for a window in the future
+type CapacityReservationSubmission struct {
+	// the job that is run on the capacity, its spec is what one
+	// resource offer has to have and its max runtime has to fit the window
+	JobOffer         JobOffer `json:"job_offer"`
+	ResourceProvider string   `json:"resource_provider"`
+	// how many resource offers to hold e.g. 8 one GPU offers for 8 GPUs
+	// the job offer is added this many times at the start
+	Count int `json:"count"`
+	// unix seconds
+	StartAt int64 `json:"start_at"`
+	EndAt   int64 `json:"end_at"`
+}
+
+// capacity the solver holds for a job creator and turns into
+// job offers for the resource provider when the window starts
+type CapacityReservation struct {
+	ID               string   `json:"id"`
+	JobCreator       string   `json:"job_creator"`
+	ResourceProvider string   `json:"resource_provider"`
+	JobOffer         JobOffer `json:"job_offer"`
+	Count            int      `json:"count"`
+	StartAt          int64    `json:"start_at"`
+	EndAt            int64    `json:"end_at"`
+	State            string   `json:"state"`
+	// the job offers added when the window started
+	JobOffers []string `json:"job_offers,omitempty"`
+	// why the reservation failed
+	Error     string `json:"error,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// the body of a request to cancel a capacity reservation
+type CapacityReservationCancellation struct {
+	ReservationID string `json:"reservation_id"`
+}
+
+// an address the solver operator has stopped from adding offers
+type BannedAddress struct {
+	Address string `json:"address"`
+	// one of the BanReason constants so bans can be counted by cause
+	Code   string `json:"code"`
+	Reason string `json:"reason"`
+	// the admin that banned the address
+	BannedBy  string `json:"banned_by"`
+	CreatedAt int64  `json:"created_at"`
+	// unix seconds, zero means the ban does not expire
+	ExpiresAt int64 `json:"expires_at,omitempty"`
+	// unix seconds, set on the record logged when the ban is lifted
+	LiftedAt int64 `json:"lifted_at,omitempty"`
+}
+
+// the body of a request to ban an address
+type BanSubmission struct {
+	Address string `json:"address"`
+	Code    string `json:"code"`
+	Reason  string `json:"reason"`
+	// how many seconds the ban lasts, zero means until it is lifted
+	Duration int64 `json:"duration,omitempty"`
+}
+
+// the body of a request to an admin endpoint, the reason is kept in the admin log
+type AdminRequest struct {
+	Reason string `json:"reason"`
+}
+
+// something an admin did through the solver admin API
+type AdminAction struct {
+	ID     string `json:"id"`
+	Admin  string `json:"admin"`
+	Role   string `json:"role"`
+	Action string `json:"action"`
+	// the offer or address the action was taken on
+	Target string `json:"target,omitempty"`
+	Reason string `json:"reason,omitempty"`
+	// why the action failed
+	Error     string `json:"error,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+const (
+	// stop the workflow at the first stage that fails
+	WorkflowFailureStop = "stop"
+	// carry on with the stages that do not depend on the one that failed
+	WorkflowFailureContinue = "continue"
+)
+
+// the states of a workflow stage
+// a workflow as a whole is running, succeeded or failed
+const (
+	WorkflowStagePending   = "pending"
+	WorkflowStageRunning   = "running"
+	WorkflowStageSucceeded = "succeeded"
+	WorkflowStageFailed    = "failed"
+	WorkflowStageSkipped   = "skipped"
+)
+
+// one job in a workflow
+type WorkflowStage struct {
+	Name     string   `json:"name"`
+	JobOffer JobOffer `json:"job_offer"`
+	// the stages that must finish with results before this one is added
+	DependsOn []string `json:"depends_on,omitempty"`
+	// inputs that are set to the results CID of an upstream stage
+	// input name -> stage name
+	InputsFrom map[string]string `json:"inputs_from,omitempty"`
+}
+
+// the body of a request to run a workflow
+type WorkflowSubmission struct {
+	JobCreator string          `json:"job_creator"`
+	Stages     []WorkflowStage `json:"stages"`
+	// stop or continue, stop if not set
+	FailurePolicy string `json:"failure_policy"`
+}
+
+// where one stage of a workflow has got to
+type WorkflowStageStatus struct {
+	Name     string `json:"name"`
+	State    string `json:"state"`
+	JobOffer string `json:"job_offer,omitempty"`
+	DealID   string `json:"deal_id,omitempty"`
+	// the CID of the stage results that downstream stages are given
+	ResultsID string `json:"results_id,omitempty"`
+	// why the stage failed or was skipped
+	Error string `json:"error,omitempty"`
+}
+
+// a set of job offers the solver adds stage by stage
+// as the stages they depend on finish
+type Workflow struct {
+	ID            string          `json:"id"`
+	JobCreator    string          `json:"job_creator"`
+	Stages        []WorkflowStage `json:"stages"`
+	FailurePolicy string          `json:"failure_policy"`
+	// in the same order as the stages
+	StageStates []WorkflowStageStatus `json:"stage_states"`
+	State       string                `json:"state"`
+	CreatedAt   int64                 `json:"created_at"`
+	FinishedAt  int64                 `json:"finished_at,omitempty"`
+}
+
+// the body of a request to cancel a workflow
+type WorkflowCancellation struct {
+	WorkflowID string `json:"workflow_id"`
+}
+
+const (
+	// waiting for the resource provider to fetch the inputs
+	InputStagingRequested = "requested"
+	// the inputs are pinned on the resource provider's IPFS node
+	InputStagingStaged = "staged"
+	InputStagingFailed = "failed"
+)
+
+// the body of a request to have a resource provider fetch inputs
+// before a job that uses them is submitted
+type InputStagingRequest struct {
+	JobCreator       string   `json:"job_creator"`
+	ResourceProvider string   `json:"resource_provider"`
+	CIDs             []string `json:"cids"`
+}
+
+// inputs a job creator has asked a resource provider to pull in ahead of time
+// so a large dataset is not downloaded after the deal has been agreed
+type InputStaging struct {
+	ID               string   `json:"id"`
+	JobCreator       string   `json:"job_creator"`
+	ResourceProvider string   `json:"resource_provider"`
+	CIDs             []string `json:"cids"`
+	State            string   `json:"state"`
+	Error            string   `json:"error,omitempty"`
+	// the bytes the resource provider fetched
+	Size      uint64 `json:"size,omitempty"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// the body of a request from the resource provider to say how staging went
+type InputStagingUpdate struct {
+	State string `json:"state"`
+	Error string `json:"error,omitempty"`
+	Size  uint64 `json:"size,omitempty"`
+}
+
+// where each job offer of an array job has got to
+type JobGroupStatus struct {
+	Group     JobGroup            `json:"group"`
+	JobOffers []JobOfferContainer `json:"job_offers"`
+	// how many of the job offers are in each agreement state
+	States map[string]int `json:"states"`
+	// every job offer is in a terminal state
+	Finished bool `json:"finished"`
 }
 
 // this is what the solver keeps track of so we can know
@@ -144,6 +809,26 @@ type JobOfferContainer struct {
 	JobCreator string   `json:"job_creator"`
 	State      uint8    `json:"state"`
 	JobOffer   JobOffer `json:"job_offer"`
+	// the deal is an earlier one whose result the solver handed back
+	// rather than matching this offer
+	ReusedResult bool `json:"reused_result,omitempty"`
+	// the prices in a fiat currency when the solver has a price oracle
+	// these are worked out when the offer is read and never stored
+	Fiat *FiatPrices `json:"fiat,omitempty"`
+}
+
+// deal prices converted to a fiat currency with an exchange rate oracle
+// only a guide so users can sanity check costs, deals are never paid in fiat
+type FiatPrices struct {
+	// e.g. USD
+	Currency string `json:"currency"`
+	// what one whole payment token is worth
+	Rate              float64 `json:"rate"`
+	InstructionPrice  float64 `json:"instruction_price"`
+	PaymentCollateral float64 `json:"payment_collateral"`
+	MediationFee      float64 `json:"mediation_fee"`
+	// when the oracle gave us the rate (unix seconds)
+	RateUpdatedAt int64 `json:"rate_updated_at"`
 }
 
 // posted to the solver by a resource provider
@@ -154,11 +839,22 @@ type ResourceOffer struct {
 	CreatedAt int `json:"created_at"`
 	// the address of the resource provider
 	ResourceProvider string `json:"resource_provider"`
+	// picked by the resource provider so a retried submission gets back
+	// the offer it already added rather than a second one
+	IdempotencyKey string `json:"idempotency_key,omitempty"`
+	// the version and capabilities of the resource provider
+	Client *ClientMetadata `json:"client,omitempty"`
+	// the chain the resource provider takes deals on
+	// zero is whichever chain the solver treats as its main one
+	ChainID int `json:"chain_id,omitempty"`
 	// allows a resource provider to manage multiple offers
 	// that are essentially the same
 	Index int `json:"index"`
 	// the spec being offered
 	Spec MachineSpec `json:"spec"`
+	// the largest job input this resource provider will accept (Megabytes)
+	// zero means there is no limit
+	MaxInputSize int `json:"max_input_size"`
 	// the module ID's that this resource provider can run
 	// an empty list means ALL modules
 	Modules []string `json:"modules"`
@@ -178,6 +874,38 @@ type ResourceOffer struct {
 
 	// which parties are trusted by the resource provider
 	Services ServiceConfig `json:"trusted_parties"`
+
+	// if set only jobs from these job creators are matched
+	// this lets a resource provider dedicate hardware to a customer
+	AllowedJobCreators []string `json:"allowed_job_creators,omitempty"`
+
+	// describe where and what the hardware is
+	// e.g. region=eu-west or tier=datacenter
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// the public key job creators encrypt private inputs for (hex)
+	// an empty key means we do not take jobs with encrypted inputs
+	EncryptionKey string `json:"encryption_key,omitempty"`
+
+	// evidence that jobs run inside a trusted execution environment
+	TEE *TEEAttestation `json:"tee,omitempty"`
+
+	// the most network access we give jobs, nil means full
+	Network *NetworkPolicy `json:"network,omitempty"`
+	// the network access for each module, this wins over the default
+	ModuleNetwork map[string]NetworkPolicy `json:"module_network,omitempty"`
+
+	// the ERC-20 tokens we take payment in as well as the network token
+	PaymentTokens []string `json:"payment_tokens,omitempty"`
+
+	// reserved or spot, empty is reserved
+	Class string `json:"class,omitempty"`
+	// how many seconds we give a spot deal between preempting it
+	// and stopping its job
+	PreemptionNotice int `json:"preemption_notice,omitempty"`
+	// the longest job a reserved offer guarantees to run (seconds)
+	// zero means there is no limit
+	ReservedDuration int `json:"reserved_duration,omitempty"`
 }
 
 // this is what the solver keeps track of so we can know
@@ -226,6 +954,36 @@ type MatchDecision struct {
 	Result        bool   `json:"result"`
 }
 
+// the states an audit of a completed deal can be in
+const (
+	AuditSkipped = "skipped"
+	AuditPending = "pending"
+	AuditPassed  = "passed"
+	AuditFailed  = "failed"
+)
+
+// represents the solver deciding whether to audit a deal
+// the solver picks a random sample of deals with results to re-verify
+// even if the job creator has not disputed them
+type Audit struct {
+	DealID           string `json:"deal_id"`
+	ResourceProvider string `json:"resource_provider"`
+	JobCreator       string `json:"job_creator"`
+	State            string `json:"state"`
+	// why the audit failed
+	Message   string `json:"message"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// a summary of how a resource provider has done in audits
+type ResourceProviderReputation struct {
+	ResourceProvider string `json:"resource_provider"`
+	AuditsPassed     int    `json:"audits_passed"`
+	AuditsFailed     int    `json:"audits_failed"`
+	// spot deals taken back with notice, these are not failures
+	Preemptions int `json:"preemptions"`
+}
+
 // this is the struct that will have it's ID taken and used
 // as the reference for what both parties agreed to
 // the solver will publish this deal to the directory
@@ -237,6 +995,31 @@ type Deal struct {
 	Timeouts      DealTimeouts  `json:"timeouts"`
 	JobOffer      JobOffer      `json:"job_offer"`
 	ResourceOffer ResourceOffer `json:"resource_offer"`
+	// the mediator the solver chose, empty for deals with a mediator quorum
+	Mediator string `json:"mediator,omitempty"`
+	// how the mediators were chosen so it can be checked
+	MediatorSelection *MediatorSelection `json:"mediator_selection,omitempty"`
+	// the network access the executor gives the job
+	// what both the job offer and the resource offer allow
+	Network *NetworkPolicy `json:"network,omitempty"`
+}
+
+// the inputs and outcome of choosing the mediators of a deal
+// VerifyMediatorSelection runs it again from these
+type MediatorSelection struct {
+	Policy string `json:"policy"`
+	// the mutual mediators sorted by address
+	Candidates []string `json:"candidates"`
+	// the weight of each candidate as a decimal, not used by round-robin
+	Weights []string `json:"weights,omitempty"`
+	// where round-robin started
+	Offset int `json:"offset,omitempty"`
+	// hex sha256 of the job offer and resource offer IDs the draws are made from
+	Seed      string   `json:"seed"`
+	Mediators []string `json:"mediators"`
+	// the mutual mediators that looked to be run by the resource provider
+	// the ones that were excluded are not in the candidates
+	Related []MediatorRelation `json:"related,omitempty"`
 }
 
 // we keep track of tx ids on behalf of resource providers
@@ -282,6 +1065,389 @@ type DealContainer struct {
 	Deal             Deal             `json:"deal"`
 	Transactions     DealTransactions `json:"transactions"`
 	Mediator         string           `json:"mediator"`
+	// the chain the deal is on, both offers are on this chain
+	ChainID int `json:"chain_id,omitempty"`
+	// when the deal last changed state (unix seconds)
+	// the timeout watchdog measures the timeouts from this
+	StateUpdatedAt int64 `json:"state_updated_at"`
+	// the latest checkpoint the resource provider has uploaded
+	Checkpoint *DealCheckpoint `json:"checkpoint,omitempty"`
+	// when the job creator cancelled the deal (unix seconds)
+	// the resource provider stops the job and settles the deal by
+	// submitting an error result, a deal that was never agreed times out
+	CancelledAt int64 `json:"cancelled_at,omitempty"`
+	// what each mediator in the quorum made of the result
+	// only used for deals whose job offer asks for a mediator quorum
+	MediationVerdicts []MediationVerdict `json:"mediation_verdicts,omitempty"`
+	// the private inputs of the job encrypted for the resource provider
+	// the resource provider does not agree to the deal until these are here
+	EncryptedInputs *DealEncryptedInputs `json:"encrypted_inputs,omitempty"`
+	// the payments made while the job is running
+	// only used for deals whose job offer asks for milestones
+	Milestones []DealMilestone `json:"milestones,omitempty"`
+	// set when the resource provider of a spot deal takes its capacity back
+	Preemption *DealPreemption `json:"preemption,omitempty"`
+	// the requests the solver has routed to the service of a service deal
+	ServiceUsage *ServiceUsage `json:"service_usage,omitempty"`
+	// the prices in a fiat currency when the solver has a price oracle
+	// these are worked out when the deal is read and never stored
+	Fiat *FiatPrices `json:"fiat,omitempty"`
+	// the deal this one runs the job of again
+	ParentDealID string `json:"parent_deal_id,omitempty"`
+	// deals with the same key did the same work, empty if the
+	// job cannot be recognised again
+	ResultKey string `json:"result_key,omitempty"`
+}
+
+const (
+	// the resource provider has uploaded a checkpoint for the job creator to look at
+	DealMilestoneSubmitted = "submitted"
+	// the job creator accepted the checkpoint and the solver is paying it
+	DealMilestoneAccepted = "accepted"
+	DealMilestonePaid     = "paid"
+	// the payment did not go through, the job creator can accept it again
+	DealMilestoneFailed = "failed"
+)
+
+// part of the job payment released when the job creator accepts a checkpoint
+type DealMilestone struct {
+	DealID string `json:"deal_id"`
+	// counts up from 0
+	Index         uint64 `json:"index"`
+	CheckpointCID string `json:"checkpoint_cid"`
+	// in the smallest unit of the deal's payment token as a decimal string
+	Amount string `json:"amount"`
+	State  string `json:"state"`
+	// the job creator's signature of the milestone hash, the controller
+	// contract will only pay the milestone with it
+	Signature       string `json:"signature,omitempty"`
+	TransactionHash string `json:"transaction_hash,omitempty"`
+	Error           string `json:"error,omitempty"`
+	// unix seconds
+	CreatedAt int64 `json:"created_at"`
+	UpdatedAt int64 `json:"updated_at"`
+}
+
+// a resource provider taking back the capacity of a spot deal
+// the job is stopped at StopAt and the deal is settled with
+// a preempted result which is not held against the resource provider
+type DealPreemption struct {
+	DealID string `json:"deal_id"`
+	Reason string `json:"reason"`
+	// unix seconds
+	CreatedAt int64 `json:"created_at"`
+	StopAt    int64 `json:"stop_at"`
+}
+
+// the body of a request from the job creator to accept a milestone
+type DealMilestoneAcceptance struct {
+	DealID string `json:"deal_id"`
+	Index  uint64 `json:"index"`
+	// see DealMilestone.Signature
+	Signature string `json:"signature"`
+}
+
+// the inputs a job creator encrypted for the resource provider of a deal
+type DealEncryptedInputs struct {
+	DealID string `json:"deal_id"`
+	// how the inputs were encrypted so the resource provider knows how to read them
+	Scheme string `json:"scheme"`
+	// the resource offer key the inputs were encrypted for
+	EncryptionKey string `json:"encryption_key"`
+	// the encrypted JSON of the input names and values (base64)
+	Ciphertext string `json:"ciphertext"`
+	// unix seconds
+	CreatedAt int64 `json:"created_at"`
+}
+
+// one mediator's verdict on the result of a deal
+type MediationVerdict struct {
+	DealID   string `json:"deal_id"`
+	Mediator string `json:"mediator"`
+	Accept   bool   `json:"accept"`
+	// the results the mediator got when it ran the job again
+	ResultsCID string `json:"results_cid"`
+	// why the mediator rejected the result, empty if it accepted it
+	Code      ErrorCode `json:"code,omitempty"`
+	CreatedAt int64     `json:"created_at"`
+}
+
+// where the job of a deal had got to, a new job offer can
+// resume from it if the resource provider goes away
+type DealCheckpoint struct {
+	DealID string `json:"deal_id"`
+	CID    string `json:"cid"`
+	// unix seconds
+	CreatedAt int64 `json:"created_at"`
+}
+
+// the terms of a deal signed by the solver that matched it as EIP-712
+// typed data so either side can prove what was agreed
+type DealReceipt struct {
+	DealID           string      `json:"deal_id"`
+	JobOffer         string      `json:"job_offer"`
+	ResourceOffer    string      `json:"resource_offer"`
+	JobCreator       string      `json:"job_creator"`
+	ResourceProvider string      `json:"resource_provider"`
+	Solver           string      `json:"solver"`
+	Pricing          DealPricing `json:"pricing"`
+	// the chain the receipt is for which goes in the EIP-712 domain
+	ChainID   int64 `json:"chain_id"`
+	CreatedAt int64 `json:"created_at"`
+	// hex encoded 65 byte signature over the typed data hash
+	Signature string `json:"signature"`
+}
+
+// what a deal used and what it cost, recorded when its result is added
+// so providers and creators can reconcile their invoices
+type BillingRecord struct {
+	DealID           string `json:"deal_id"`
+	JobCreator       string `json:"job_creator"`
+	ResourceProvider string `json:"resource_provider"`
+	// the module name or repo:hash if it has no name
+	Module           string `json:"module"`
+	ChainID          int    `json:"chain_id,omitempty"`
+	InstructionCount uint64 `json:"instruction_count"`
+	// how long the job ran for as reported by the resource provider
+	WallClockSeconds float64 `json:"wall_clock_seconds"`
+	// the milli-GPUs the job offer asked for
+	GPU        int     `json:"gpu"`
+	GPUSeconds float64 `json:"gpu_seconds"`
+	// the price per instruction and the total for the deal in wei
+	InstructionPrice uint64 `json:"instruction_price"`
+	Price            uint64 `json:"price"`
+	// the job failed but the deal is still settled
+	Error string `json:"error,omitempty"`
+	// unix seconds
+	CreatedAt int64 `json:"created_at"`
+}
+
+// a deal the solver found stuck past one of its timeouts
+// and the on-chain timeout transaction we sent for it
+type DealTimeoutEvent struct {
+	DealID string `json:"deal_id"`
+	// the agreement state the deal was stuck in
+	FromState uint8 `json:"from_state"`
+	// the timeout state we asked the controller contract to move it to
+	State           uint8  `json:"state"`
+	TransactionHash string `json:"transaction_hash"`
+	Error           string `json:"error"`
+	CreatedAt       int64  `json:"created_at"`
+}
+
+// a job offer and resource offer that would have matched apart from price
+// the suggested prices are the counter-offer each side would need to make
+type PriceGap struct {
+	JobOffer         string `json:"job_offer"`
+	ResourceOffer    string `json:"resource_offer"`
+	JobCreator       string `json:"job_creator"`
+	ResourceProvider string `json:"resource_provider"`
+	ModuleID         string `json:"module_id"`
+	// the instruction price the job offer will pay
+	JobOfferPrice uint64 `json:"job_offer_price"`
+	// the instruction price the resource offer asks for this module
+	ResourceOfferPrice uint64 `json:"resource_offer_price"`
+	// raising the job offer to this would match
+	SuggestedJobOfferPrice uint64 `json:"suggested_job_offer_price"`
+	// lowering the resource offer to this would match
+	SuggestedResourceOfferPrice uint64 `json:"suggested_resource_offer_price"`
+	CreatedAt                   int64  `json:"created_at"`
+}
+
+const (
+	ResultPinQueued  = "queued"
+	ResultPinPinning = "pinning"
+	ResultPinPinned  = "pinned"
+	ResultPinFailed  = "failed"
+)
+
+// a request for a pinning service to keep a copy of a result
+// the status is the one from the IPFS pinning service API and
+// a pin the service no longer knows about is marked failed
+type ResultPin struct {
+	DealID    string `json:"deal_id"`
+	CID       string `json:"cid"`
+	Service   string `json:"service"`
+	RequestID string `json:"request_id"`
+	Status    string `json:"status"`
+	Error     string `json:"error"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+// the pin is there or on its way
+func (pin ResultPin) IsHealthy() bool {
+	return pin.Status == ResultPinQueued || pin.Status == ResultPinPinning || pin.Status == ResultPinPinned
+}
+
+const (
+	TransactionPending  = "pending"
+	TransactionMined    = "mined"
+	TransactionReverted = "reverted"
+	TransactionDropped  = "dropped"
+)
+
+// a transaction we sent to the chain, every fee bump of it has the same
+// nonce so they share one record, the ID is made from the chain, sender
+// and nonce and the latest signed version is kept so it can be sent again
+type Transaction struct {
+	ID      string `json:"id"`
+	ChainID int    `json:"chain_id"`
+	From    string `json:"from"`
+	Nonce   uint64 `json:"nonce"`
+	// the contract call e.g. controller.Agree
+	Method string `json:"method"`
+	DealID string `json:"deal_id,omitempty"`
+	// the hash of the latest version and every version we sent
+	Hash      string   `json:"hash"`
+	Hashes    []string `json:"hashes"`
+	RawTx     string   `json:"raw_tx"`
+	Status    string   `json:"status"`
+	Error     string   `json:"error"`
+	CreatedAt int64    `json:"created_at"`
+	UpdatedAt int64    `json:"updated_at"`
+}
+
+// a block the chain sync has got up to
+type ChainBlock struct {
+	Number uint64 `json:"number"`
+	Hash   string `json:"hash"`
+}
+
+// how far the chain sync has got with the logs of one contract on one chain
+// the logs up to and including BlockNumber have been handled
+type ChainCheckpoint struct {
+	ID          string `json:"id"`
+	ChainID     int    `json:"chain_id"`
+	Contract    string `json:"contract"`
+	Address     string `json:"address"`
+	BlockNumber uint64 `json:"block_number"`
+	BlockHash   string `json:"block_hash"`
+	// the blocks we last synced up to, oldest first
+	// a reorg is rolled back to the newest of these still on the chain
+	Blocks    []ChainBlock `json:"blocks"`
+	UpdatedAt int64        `json:"updated_at"`
+}
+
+const (
+	DealLogStdout = "stdout"
+	DealLogStderr = "stderr"
+)
+
+// the largest piece of output in bytes a resource provider can send at once
+// longer output is split across several chunks
+const DEAL_LOG_MAX_CHUNK_SIZE = 16 * 1024
+
+// a piece of the output of a running job
+// the solver numbers the chunks of each deal in the order they arrive
+type DealLogChunk struct {
+	DealID    string `json:"deal_id"`
+	Sequence  uint64 `json:"sequence"`
+	Stream    string `json:"stream"`
+	Data      string `json:"data"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// a Payment event from the payments contract for one of our deals
+// amounts are in wei as decimal strings because they do not fit in a uint64
+type EscrowPayment struct {
+	DealID string `json:"deal_id"`
+	Payee  string `json:"payee"`
+	Amount string `json:"amount"`
+	// the PaymentReason and PaymentDirection enums from LilypadPayments.sol
+	Reason          uint8  `json:"reason"`
+	Direction       uint8  `json:"direction"`
+	TransactionHash string `json:"transaction_hash"`
+	LogIndex        uint   `json:"log_index"`
+	CreatedAt       int64  `json:"created_at"`
+}
+
+type StoreEventType string
+
+const (
+	JobOfferAddedEvent                       StoreEventType = "JobOfferAdded"
+	JobOfferStateUpdatedEvent                StoreEventType = "JobOfferStateUpdated"
+	JobOfferRemovedEvent                     StoreEventType = "JobOfferRemoved"
+	JobGroupAddedEvent                       StoreEventType = "JobGroupAdded"
+	JobScheduleUpdatedEvent                  StoreEventType = "JobScheduleUpdated"
+	JobScheduleRemovedEvent                  StoreEventType = "JobScheduleRemoved"
+	CapacityReservationUpdatedEvent          StoreEventType = "CapacityReservationUpdated"
+	AddressBannedEvent                       StoreEventType = "AddressBanned"
+	AddressUnbannedEvent                     StoreEventType = "AddressUnbanned"
+	AdminActionAddedEvent                    StoreEventType = "AdminActionAdded"
+	WorkflowUpdatedEvent                     StoreEventType = "WorkflowUpdated"
+	InputStagingUpdatedEvent                 StoreEventType = "InputStagingUpdated"
+	ResourceOfferAddedEvent                  StoreEventType = "ResourceOfferAdded"
+	ResourceOfferStateUpdatedEvent           StoreEventType = "ResourceOfferStateUpdated"
+	ResourceOfferRemovedEvent                StoreEventType = "ResourceOfferRemoved"
+	MatchDecisionAddedEvent                  StoreEventType = "MatchDecisionAdded"
+	DealAddedEvent                           StoreEventType = "DealAdded"
+	DealStateUpdatedEvent                    StoreEventType = "DealStateUpdated"
+	DealStateRolledBackEvent                 StoreEventType = "DealStateRolledBack"
+	DealMediatorUpdatedEvent                 StoreEventType = "DealMediatorUpdated"
+	DealCheckpointUpdatedEvent               StoreEventType = "DealCheckpointUpdated"
+	DealMilestoneUpdatedEvent                StoreEventType = "DealMilestoneUpdated"
+	DealEncryptedInputsAddedEvent            StoreEventType = "DealEncryptedInputsAdded"
+	DealCancelledEvent                       StoreEventType = "DealCancelled"
+	DealPreemptedEvent                       StoreEventType = "DealPreempted"
+	DealServiceUsageUpdatedEvent             StoreEventType = "DealServiceUsageUpdated"
+	MediationVerdictAddedEvent               StoreEventType = "MediationVerdictAdded"
+	ResourceProviderTransactionsUpdatedEvent StoreEventType = "ResourceProviderTransactionsUpdated"
+	JobCreatorTransactionsUpdatedEvent       StoreEventType = "JobCreatorTransactionsUpdated"
+	MediatorTransactionsUpdatedEvent         StoreEventType = "MediatorTransactionsUpdated"
+	ResultAddedEvent                         StoreEventType = "ResultAdded"
+	AuditAddedEvent                          StoreEventType = "AuditAdded"
+	AuditStateUpdatedEvent                   StoreEventType = "AuditStateUpdated"
+	TimeoutEventAddedEvent                   StoreEventType = "TimeoutEventAdded"
+	EscrowPaymentAddedEvent                  StoreEventType = "EscrowPaymentAdded"
+	EscrowPaymentRemovedEvent                StoreEventType = "EscrowPaymentRemoved"
+	PriceGapAddedEvent                       StoreEventType = "PriceGapAdded"
+	ResultPinUpdatedEvent                    StoreEventType = "ResultPinUpdated"
+	DealReceiptAddedEvent                    StoreEventType = "DealReceiptAdded"
+	BillingRecordAddedEvent                  StoreEventType = "BillingRecordAdded"
+	TransactionUpdatedEvent                  StoreEventType = "TransactionUpdated"
+	DealArchivedEvent                        StoreEventType = "DealArchived"
+)
+
+// an append only record of a change the solver made to its store
+// the sequence only goes up so clients can page through with it
+type StoreEvent struct {
+	Sequence uint64         `json:"sequence"`
+	Type     StoreEventType `json:"type"`
+	// the ID of the offer, deal or decision that changed
+	ObjectID string `json:"object_id"`
+	// the address that caused the change
+	// this is empty for changes we picked up from the chain
+	Actor string `json:"actor"`
+	// the object as it was after the change
+	Data      json.RawMessage `json:"data"`
+	CreatedAt int64           `json:"created_at"`
+}
+
+// a page of store events, pass NextCursor back to get the next page
+type StoreEventPage struct {
+	Events     []StoreEvent `json:"events"`
+	NextCursor uint64       `json:"next_cursor"`
+}
+
+// what changed for a resource provider since the version it last synced
+// pass Version back to get the changes after this
+type ResourceProviderSync struct {
+	// the store event sequence this brings the resource provider up to
+	Version uint64 `json:"version"`
+	// changes each time the solver starts and its sequence starts over
+	// send it back with the version
+	Epoch string `json:"epoch"`
+	// the solver could not give the changes since the version asked for
+	// so this is everything it has, drop what you have and use this
+	Reset bool `json:"reset"`
+	// the changed records as they are now
+	ResourceOffers []ResourceOfferContainer `json:"resource_offers"`
+	Deals          []DealContainer          `json:"deals"`
+	MatchDecisions []MatchDecision          `json:"match_decisions"`
+	// the IDs of records the solver no longer has
+	RemovedResourceOffers []string `json:"removed_resource_offers"`
+	RemovedDeals          []string `json:"removed_deals"`
 }
 
 type MinerHashRate struct {
@@ -1,33 +1,17 @@
 package data
 
 import (
-	"encoding/json"
 	"fmt"
 	"math/big"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/controller"
-
-	mdag "github.com/ipfs/go-merkledag"
 )
 
-// CalculateCID takes an interface, serializes it to JSON, and returns its IPFS CID
-func CalculateCID(v interface{}) (string, error) {
-	// Serialize the struct to JSON
-	data, err := json.Marshal(v)
-	if err != nil {
-		return "", err
-	}
-
-	// Create a Dag Node from the JSON bytes
-	node := mdag.NodeWithData(data)
-
-	// Compute CID of the Dag Node
-	c := node.Cid()
-
-	return c.String(), nil
-}
-
+// the ID of a job offer is the CID of everything in it but the ID
 func GetJobOfferID(offer JobOffer) (string, error) {
 	offer.ID = ""
 	return CalculateCID(offer)
@@ -41,6 +25,7 @@ func GetJobOfferContainerIDs(jobOffers []JobOfferContainer) []string {
 	return ids
 }
 
+// the ID of a resource offer is the CID of everything in it but the ID
 func GetResourceOfferID(offer ResourceOffer) (string, error) {
 	offer.ID = ""
 	return CalculateCID(offer)
@@ -62,6 +47,8 @@ func GetResourceOfferContainerIDs(resourceOffers []ResourceOfferContainer) []str
 	return ids
 }
 
+// the ID of a deal is the CID of its members, offers and terms
+// the offers in it keep their own IDs
 func GetDealID(deal Deal) (string, error) {
 	deal.ID = ""
 	return CalculateCID(deal)
@@ -75,10 +62,92 @@ func GetDealIDs(deals []Deal) []string {
 	return ids
 }
 
+// the image digest is left out so pinning an image does not
+// move the module off its allowlist entry
 func GetModuleID(module ModuleConfig) (string, error) {
+	module.ImageDigest = ""
 	return CalculateCID(module)
 }
 
+var (
+	commitHashPattern  = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
+	releaseTagPattern  = regexp.MustCompile(`^v?[0-9]+\.[0-9]+\.[0-9]+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`)
+	envNamePattern     = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	imageDigestPattern = regexp.MustCompile(`^sha256:[0-9a-f]{64}$`)
+	// UUIDs, ULIDs and the like, the key is only compared so anything printable will do
+	idempotencyKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_.:-]{1,128}$`)
+)
+
+// an empty key means the offer is only deduplicated by its ID
+func CheckIdempotencyKey(key string) error {
+	if key == "" {
+		return nil
+	}
+	if !idempotencyKeyPattern.MatchString(key) {
+		return fmt.Errorf("idempotency key %q must be up to 128 letters, digits or _.:- characters", key)
+	}
+	return nil
+}
+
+// the job offer names the resource provider or resource offer it runs on
+func HasTarget(jobOffer JobOffer) bool {
+	return jobOffer.Target.Address != "" || jobOffer.Target.ResourceOffer != ""
+}
+
+// a module version is pinned if it is a full commit hash or a release tag
+// branches like main can move to new code without the job offer changing
+func CheckModuleVersionPinned(module ModuleConfig) error {
+	if commitHashPattern.MatchString(module.Hash) || releaseTagPattern.MatchString(module.Hash) {
+		return nil
+	}
+	return fmt.Errorf("module %s version %q is not pinned, use a full commit hash or a release tag such as v1.2.3", module.Repo, module.Hash)
+}
+
+// an image digest as the registries give it e.g. sha256:<64 hex characters>
+func CheckImageDigest(digest string) error {
+	if !imageDigestPattern.MatchString(digest) {
+		return fmt.Errorf("image digest %q must be sha256: followed by 64 lowercase hex characters", digest)
+	}
+	return nil
+}
+
+// the pricing a resource offer asks for a module
+// falling back to the default pricing if the module has no specific price
+func GetResourceOfferPricing(resourceOffer ResourceOffer, moduleID string) DealPricing {
+	if pricing, ok := resourceOffer.ModulePricing[moduleID]; ok {
+		return pricing
+	}
+	return resourceOffer.DefaultPricing
+}
+
+// the timeouts a resource offer asks for a module
+// falling back to the default timeouts if the module has no specific timeouts
+func GetResourceOfferTimeouts(resourceOffer ResourceOffer, moduleID string) DealTimeouts {
+	if timeouts, ok := resourceOffer.ModuleTimeouts[moduleID]; ok {
+		return timeouts
+	}
+	return resourceOffer.DefaultTimeouts
+}
+
+// labels as sorted key=value pairs so they read the same every time
+func FormatLabels(labels map[string]string) string {
+	pairs := []string{}
+	for key, value := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// a transaction and its fee bumps share the sender and nonce
+func GetTransactionID(chainID int, from string, nonce uint64) string {
+	return fmt.Sprintf("%d-%s-%d", chainID, strings.ToLower(from), nonce)
+}
+
+func GetChainCheckpointID(chainID int, contract string) string {
+	return fmt.Sprintf("%d-%s", chainID, contract)
+}
+
 func GetMutualServices(a []string, b []string) []string {
 	mutual := []string{}
 	for _, aParty := range a {
@@ -94,31 +163,47 @@ func GetMutualServices(a []string, b []string) []string {
 func GetDeal(
 	jobOffer JobOffer,
 	resourceOffer ResourceOffer,
+	mediatorPolicy MediatorPolicy,
 ) (Deal, error) {
 	mutualMediators := GetMutualServices(resourceOffer.Services.Mediator, jobOffer.Services.Mediator)
 	if len(mutualMediators) <= 0 {
 		return Deal{}, fmt.Errorf("no mutual mediators")
 	}
+	// the deal members only have the chosen mediators so the
+	// mediation contract has to use them
+	selection, err := SelectMediators(mutualMediators, jobOffer, resourceOffer, mediatorPolicy)
+	if err != nil {
+		return Deal{}, err
+	}
 
 	if jobOffer.Services.Solver != resourceOffer.Services.Solver {
 		return Deal{}, fmt.Errorf("no mutual solver")
 	}
 
+	moduleID, err := GetModuleID(jobOffer.Module)
+	if err != nil {
+		return Deal{}, err
+	}
+
 	dealData := Deal{
 		Members: DealMembers{
 			Solver:           jobOffer.Services.Solver,
 			JobCreator:       jobOffer.JobCreator,
 			ResourceProvider: resourceOffer.ResourceProvider,
-			Mediators:        mutualMediators,
+			Mediators:        selection.Mediators,
 		},
 		// TODO: this assumes marketing pricing for the client
 		// this should be configurable
-		Pricing: resourceOffer.DefaultPricing,
+		Pricing: GetResourceOfferPricing(resourceOffer, moduleID),
 		// TODO: this assumes resource provider timeouts
 		// this should be configurable
-		Timeouts:      resourceOffer.DefaultTimeouts,
-		JobOffer:      jobOffer,
-		ResourceOffer: resourceOffer,
+		Timeouts:          GetResourceOfferTimeouts(resourceOffer, moduleID),
+		JobOffer:          jobOffer,
+		ResourceOffer:     resourceOffer,
+		MediatorSelection: &selection,
+	}
+	if jobOffer.MediatorQuorum == nil {
+		dealData.Mediator = selection.Mediators[0]
 	}
 
 	id, err := GetDealID(dealData)
@@ -166,10 +251,18 @@ func GetDealContainer(
 		ResourceOffer:    deal.ResourceOffer.ID,
 		State:            GetDefaultAgreementState(),
 		Deal:             deal,
+		ChainID:          deal.JobOffer.ChainID,
+		ParentDealID:     deal.JobOffer.ParentDealID,
+		ResultKey:        GetJobResultKey(deal.JobOffer),
 	}
 }
 
 func CheckResourceOffer(resourceOffer ResourceOffer) error {
+	err := CheckResourceOfferBounds(resourceOffer)
+	if err != nil {
+		return err
+	}
+
 	if resourceOffer.Mode == MarketPrice {
 		return fmt.Errorf("resource offer mode cannot be market price")
 	}
@@ -182,10 +275,60 @@ func CheckResourceOffer(resourceOffer ResourceOffer) error {
 		return fmt.Errorf("resource offer must have at least one trusted mediator")
 	}
 
+	if resourceOffer.MaxInputSize < 0 {
+		return fmt.Errorf("resource offer max input size cannot be negative")
+	}
+
+	err = CheckIdempotencyKey(resourceOffer.IdempotencyKey)
+	if err != nil {
+		return err
+	}
+
+	err = CheckClientMetadata(resourceOffer.Client)
+	if err != nil {
+		return err
+	}
+
+	if resourceOffer.TEE != nil {
+		err := CheckTEEType(resourceOffer.TEE.Type)
+		if err != nil {
+			return err
+		}
+	}
+
+	if resourceOffer.Network != nil {
+		err := CheckNetworkPolicy(*resourceOffer.Network)
+		if err != nil {
+			return err
+		}
+	}
+	for moduleID, policy := range resourceOffer.ModuleNetwork {
+		err := CheckNetworkPolicy(policy)
+		if err != nil {
+			return fmt.Errorf("module %s: %s", moduleID, err.Error())
+		}
+	}
+
+	for _, token := range resourceOffer.PaymentTokens {
+		if !common.IsHexAddress(token) {
+			return fmt.Errorf("payment token %s is not an address", token)
+		}
+	}
+
+	err = CheckResourceOfferClass(resourceOffer)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
 func CheckJobOffer(jobOffer JobOffer) error {
+	err := CheckJobOfferBounds(jobOffer)
+	if err != nil {
+		return err
+	}
+
 	if jobOffer.Services.Solver == "" {
 		return fmt.Errorf("job offer must name it's solver")
 	}
@@ -194,6 +337,105 @@ func CheckJobOffer(jobOffer JobOffer) error {
 		return fmt.Errorf("job offer must have at least one trusted mediator")
 	}
 
+	if jobOffer.InputSize < 0 {
+		return fmt.Errorf("job offer input size cannot be negative")
+	}
+
+	err = CheckIdempotencyKey(jobOffer.IdempotencyKey)
+	if err != nil {
+		return err
+	}
+
+	err = CheckClientMetadata(jobOffer.Client)
+	if err != nil {
+		return err
+	}
+
+	if jobOffer.MediatorQuorum != nil {
+		err := CheckMediatorQuorum(*jobOffer.MediatorQuorum)
+		if err != nil {
+			return err
+		}
+		if len(jobOffer.Services.Mediator) < jobOffer.MediatorQuorum.Size {
+			return fmt.Errorf("job offer trusts %d mediators which is not enough for a quorum of %d", len(jobOffer.Services.Mediator), jobOffer.MediatorQuorum.Size)
+		}
+	}
+
+	for name := range jobOffer.Env {
+		if !envNamePattern.MatchString(name) {
+			return fmt.Errorf("job offer env %q is not a valid variable name", name)
+		}
+	}
+
+	err = CheckInputCIDs(jobOffer.InputCIDs)
+	if err != nil {
+		return err
+	}
+
+	err = CheckEncryptedInputNames(jobOffer)
+	if err != nil {
+		return err
+	}
+
+	if jobOffer.TEE != nil {
+		err = CheckTEERequirement(*jobOffer.TEE)
+		if err != nil {
+			return err
+		}
+	}
+
+	if jobOffer.Module.ImageDigest != "" {
+		err = CheckImageDigest(jobOffer.Module.ImageDigest)
+		if err != nil {
+			return err
+		}
+	}
+
+	if jobOffer.Network != nil {
+		err = CheckNetworkPolicy(*jobOffer.Network)
+		if err != nil {
+			return err
+		}
+	}
+
+	if jobOffer.PaymentToken != nil {
+		err = CheckPaymentToken(*jobOffer.PaymentToken)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = CheckMilestones(jobOffer)
+	if err != nil {
+		return err
+	}
+
+	err = CheckJobOfferClasses(jobOffer)
+	if err != nil {
+		return err
+	}
+
+	if jobOffer.Retry != nil {
+		err = CheckRetryPolicy(jobOffer)
+		if err != nil {
+			return err
+		}
+	}
+
+	if jobOffer.ReuseResults {
+		err = CheckReusableJob(jobOffer)
+		if err != nil {
+			return err
+		}
+	}
+
+	if jobOffer.Serve != nil {
+		err = CheckServiceDealConfig(jobOffer)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -204,6 +446,16 @@ func CheckResult(result Result) error {
 	return nil
 }
 
+func CheckDealLogChunk(chunk DealLogChunk) error {
+	if chunk.Stream != DealLogStdout && chunk.Stream != DealLogStderr {
+		return fmt.Errorf("log chunk stream must be %s or %s", DealLogStdout, DealLogStderr)
+	}
+	if len(chunk.Data) > DEAL_LOG_MAX_CHUNK_SIZE {
+		return fmt.Errorf("log chunk is %d bytes, the most we take is %d", len(chunk.Data), DEAL_LOG_MAX_CHUNK_SIZE)
+	}
+	return nil
+}
+
 func ConvertDealMembers(
 	members DealMembers,
 ) controller.SharedStructsDealMembers {
@@ -220,23 +472,18 @@ func ConvertDealMembers(
 }
 
 func EtherToWei(etherAmount float64) *big.Int {
-	ether := new(big.Float).SetFloat64(etherAmount)
-	weiMultiplier := new(big.Float).SetFloat64(1e18)
-	wei := new(big.Float).Mul(ether, weiMultiplier)
-
-	weiInt := new(big.Int)
-	wei.Int(weiInt)
-
-	return weiInt
+	return ToTokenUnits(etherAmount, NETWORK_TOKEN_DECIMALS)
 }
 
+// decimals is the decimals of the token the deal is paid in
 func ConvertDealTimeout(
 	timeout DealTimeout,
 	withCollateral bool,
+	decimals uint8,
 ) controller.SharedStructsDealTimeout {
-	collateral := EtherToWei(0)
+	collateral := ToTokenUnits(0, decimals)
 	if withCollateral {
-		collateral = EtherToWei(float64(timeout.Collateral))
+		collateral = ToTokenUnits(float64(timeout.Collateral), decimals)
 	}
 	return controller.SharedStructsDealTimeout{
 		Timeout:    big.NewInt(int64(timeout.Timeout)),
@@ -246,22 +493,24 @@ func ConvertDealTimeout(
 
 func ConvertDealTimeouts(
 	timeouts DealTimeouts,
+	decimals uint8,
 ) controller.SharedStructsDealTimeouts {
 	return controller.SharedStructsDealTimeouts{
-		Agree:          ConvertDealTimeout(timeouts.Agree, false),
-		SubmitResults:  ConvertDealTimeout(timeouts.SubmitResults, true),
-		JudgeResults:   ConvertDealTimeout(timeouts.JudgeResults, true),
-		MediateResults: ConvertDealTimeout(timeouts.MediateResults, false),
+		Agree:          ConvertDealTimeout(timeouts.Agree, false, decimals),
+		SubmitResults:  ConvertDealTimeout(timeouts.SubmitResults, true, decimals),
+		JudgeResults:   ConvertDealTimeout(timeouts.JudgeResults, true, decimals),
+		MediateResults: ConvertDealTimeout(timeouts.MediateResults, false, decimals),
 	}
 }
 
 func ConvertDealPricing(
 	pricing DealPricing,
+	decimals uint8,
 ) controller.SharedStructsDealPricing {
 	return controller.SharedStructsDealPricing{
-		InstructionPrice:          EtherToWei(float64(pricing.InstructionPrice)),
-		PaymentCollateral:         EtherToWei(float64(pricing.PaymentCollateral)),
+		InstructionPrice:          ToTokenUnits(float64(pricing.InstructionPrice), decimals),
+		PaymentCollateral:         ToTokenUnits(float64(pricing.PaymentCollateral), decimals),
 		ResultsCollateralMultiple: big.NewInt(int64(pricing.ResultsCollateralMultiple)),
-		MediationFee:              EtherToWei(float64(pricing.MediationFee)),
+		MediationFee:              ToTokenUnits(float64(pricing.MediationFee), decimals),
 	}
 }
@@ -0,0 +1,87 @@
+package data
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckModuleVersionPinned(t *testing.T) {
+	testCases := []struct {
+		hash   string
+		pinned bool
+	}{
+		{hash: "v0.0.4", pinned: true},
+		{hash: "1.2.3", pinned: true},
+		{hash: "v1.0.0-rc.1", pinned: true},
+		{hash: "a3f1c2e9b7d6a3f1c2e9b7d6a3f1c2e9b7d6a3f1", pinned: true},
+		{hash: "main", pinned: false},
+		{hash: "feature/faster", pinned: false},
+		{hash: "v1", pinned: false},
+		{hash: "a3f1c2e", pinned: false},
+		{hash: "", pinned: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.hash, func(t *testing.T) {
+			err := CheckModuleVersionPinned(ModuleConfig{Repo: "https://github.com/Lilypad-Tech/lilypad-module-cowsay", Hash: tc.hash})
+			if tc.pinned && err != nil {
+				t.Errorf("Expected %q to be pinned, but got %s", tc.hash, err.Error())
+			}
+			if !tc.pinned && err == nil {
+				t.Errorf("Expected %q not to be pinned", tc.hash)
+			}
+		})
+	}
+}
+
+func TestCheckDealLogChunk(t *testing.T) {
+	testCases := []struct {
+		name  string
+		chunk DealLogChunk
+		valid bool
+	}{
+		{name: "Stdout", chunk: DealLogChunk{Stream: DealLogStdout, Data: "ok"}, valid: true},
+		{name: "Stderr", chunk: DealLogChunk{Stream: DealLogStderr, Data: "ok"}, valid: true},
+		{name: "Unknown stream", chunk: DealLogChunk{Stream: "stdin", Data: "ok"}, valid: false},
+		{name: "Too big", chunk: DealLogChunk{Stream: DealLogStdout, Data: strings.Repeat("x", DEAL_LOG_MAX_CHUNK_SIZE+1)}, valid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckDealLogChunk(tc.chunk)
+			if tc.valid && err != nil {
+				t.Errorf("Expected the chunk to be valid, but got %s", err.Error())
+			}
+			if !tc.valid && err == nil {
+				t.Errorf("Expected the chunk to be invalid")
+			}
+		})
+	}
+}
+
+func TestCheckIdempotencyKey(t *testing.T) {
+	testCases := []struct {
+		key   string
+		valid bool
+	}{
+		{key: "", valid: true},
+		{key: "3f2b8c1e-9a4d-4e6f-8b2a-7c5d1e0f9a3b", valid: true},
+		{key: "01HZX3K5V8Q2N7M4P6R9T1W3Y5", valid: true},
+		{key: "nightly:2026-01-02", valid: true},
+		{key: "has space", valid: false},
+		{key: "slash/in/it", valid: false},
+		{key: strings.Repeat("a", 129), valid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			err := CheckIdempotencyKey(tc.key)
+			if tc.valid && err != nil {
+				t.Errorf("Expected %q to be valid, but got %s", tc.key, err.Error())
+			}
+			if !tc.valid && err == nil {
+				t.Errorf("Expected %q to be invalid", tc.key)
+			}
+		})
+	}
+}
@@ -0,0 +1,487 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ipfs/go-cid"
+)
+
+// the limits on what an offer can carry so a malformed or hostile
+// payload is turned away before it reaches the store or the matcher
+const (
+	// names, addresses, IDs, labels and the like
+	MAX_OFFER_STRING_LENGTH = 1024
+	// a module input or env value, these can hold prompts
+	MAX_OFFER_VALUE_LENGTH = 64 * 1024
+	// the entries in any one list or map on an offer
+	MAX_OFFER_LIST_LENGTH = 256
+	// the GPUs described on one spec
+	MAX_OFFER_GPUS = 64
+
+	// 1M milli-CPU is 1000 cores
+	MAX_SPEC_CPU = 1000 * 1000
+	// 1000 GPUs in milli-GPU
+	MAX_SPEC_GPU = 1000 * 1000
+	// 64TB in megabytes
+	MAX_SPEC_RAM = 64 * 1024 * 1024
+	// 1PB in megabytes
+	MAX_SPEC_DISK = 1024 * 1024 * 1024
+	// 1TB in megabytes
+	MAX_SPEC_VRAM = 1024 * 1024
+
+	// the results collateral is this many times the job cost
+	MAX_RESULTS_COLLATERAL_MULTIPLE = 1000
+)
+
+// the schemes git can clone a module repo over
+var moduleRepoSchemes = map[string]bool{
+	"http":  true,
+	"https": true,
+	"ssh":   true,
+	"git":   true,
+	"file":  true,
+}
+
+func checkOfferString(field, value string) error {
+	return checkOfferStringLength(field, value, MAX_OFFER_STRING_LENGTH)
+}
+
+func checkOfferStringLength(field, value string, max int) error {
+	if len(value) > max {
+		return fmt.Errorf("%s is %d bytes, the most we take is %d", field, len(value), max)
+	}
+	if !utf8.ValidString(value) {
+		return fmt.Errorf("%s is not valid utf-8", field)
+	}
+	return nil
+}
+
+func checkOfferStrings(field string, values []string) error {
+	if len(values) > MAX_OFFER_LIST_LENGTH {
+		return fmt.Errorf("%s has %d entries, the most we take is %d", field, len(values), MAX_OFFER_LIST_LENGTH)
+	}
+	for _, value := range values {
+		err := checkOfferString(field, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkOfferMap(field string, values map[string]string, maxValue int) error {
+	if len(values) > MAX_OFFER_LIST_LENGTH {
+		return fmt.Errorf("%s has %d entries, the most we take is %d", field, len(values), MAX_OFFER_LIST_LENGTH)
+	}
+	for key, value := range values {
+		err := checkOfferString(field+" key", key)
+		if err != nil {
+			return err
+		}
+		err = checkOfferStringLength(fmt.Sprintf("%s %q", field, key), value, maxValue)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkOfferCount(field string, value int) error {
+	if value < 0 {
+		return fmt.Errorf("%s cannot be negative", field)
+	}
+	return nil
+}
+
+func checkOfferAddresses(field string, addresses []string) error {
+	if len(addresses) > MAX_OFFER_LIST_LENGTH {
+		return fmt.Errorf("%s has %d entries, the most we take is %d", field, len(addresses), MAX_OFFER_LIST_LENGTH)
+	}
+	for _, address := range addresses {
+		if !common.IsHexAddress(address) {
+			return fmt.Errorf("%s %q is not an address", field, address)
+		}
+	}
+	return nil
+}
+
+func CheckMachineSpecBounds(spec MachineSpec) error {
+	limits := []struct {
+		field string
+		value int
+		max   int
+	}{
+		{"cpu", spec.CPU, MAX_SPEC_CPU},
+		{"gpu", spec.GPU, MAX_SPEC_GPU},
+		{"ram", spec.RAM, MAX_SPEC_RAM},
+		{"disk", spec.Disk, MAX_SPEC_DISK},
+	}
+	for _, limit := range limits {
+		if limit.value < 0 {
+			return fmt.Errorf("spec %s cannot be negative", limit.field)
+		}
+		if limit.value > limit.max {
+			return fmt.Errorf("spec %s of %d is more than %d", limit.field, limit.value, limit.max)
+		}
+	}
+	if len(spec.GPUs) > MAX_OFFER_GPUS {
+		return fmt.Errorf("spec has %d gpus, the most we take is %d", len(spec.GPUs), MAX_OFFER_GPUS)
+	}
+	for _, gpu := range spec.GPUs {
+		if gpu.VRAM < 0 {
+			return fmt.Errorf("spec gpu vram cannot be negative")
+		}
+		if gpu.VRAM > MAX_SPEC_VRAM {
+			return fmt.Errorf("spec gpu vram of %d is more than %d", gpu.VRAM, MAX_SPEC_VRAM)
+		}
+		err := checkOfferString("spec gpu name", gpu.Name)
+		if err != nil {
+			return err
+		}
+		err = checkOfferString("spec gpu vendor", gpu.Vendor)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// the deal amounts are added together on chain so they must
+// not wrap around when the solver does the same sums
+func CheckDealPricingBounds(pricing DealPricing, timeouts DealTimeouts) error {
+	if pricing.ResultsCollateralMultiple > MAX_RESULTS_COLLATERAL_MULTIPLE {
+		return fmt.Errorf("results collateral multiple of %d is more than %d", pricing.ResultsCollateralMultiple, MAX_RESULTS_COLLATERAL_MULTIPLE)
+	}
+	if pricing.ResultsCollateralMultiple > 0 && pricing.InstructionPrice > math.MaxUint64/pricing.ResultsCollateralMultiple {
+		return fmt.Errorf("instruction price of %d is too large for a results collateral multiple of %d", pricing.InstructionPrice, pricing.ResultsCollateralMultiple)
+	}
+	amounts := []uint64{
+		pricing.PaymentCollateral,
+		pricing.MediationFee,
+		timeouts.Agree.Collateral,
+		timeouts.SubmitResults.Collateral,
+		timeouts.JudgeResults.Collateral,
+		timeouts.MediateResults.Collateral,
+	}
+	total := uint64(0)
+	for _, amount := range amounts {
+		if amount > math.MaxUint64-total {
+			return fmt.Errorf("the collateral and fees of the offer add up to more than %d", uint64(math.MaxUint64))
+		}
+		total += amount
+	}
+	return nil
+}
+
+func CheckModuleConfigBounds(module ModuleConfig) error {
+	for field, value := range map[string]string{
+		"module name":  module.Name,
+		"module repo":  module.Repo,
+		"module hash":  module.Hash,
+		"module path":  module.Path,
+		"image digest": module.ImageDigest,
+	} {
+		err := checkOfferString(field, value)
+		if err != nil {
+			return err
+		}
+	}
+	if module.Repo != "" {
+		repo, err := url.Parse(module.Repo)
+		if err != nil {
+			return fmt.Errorf("module repo %q is not a valid URL: %s", module.Repo, err.Error())
+		}
+		if !moduleRepoSchemes[repo.Scheme] {
+			return fmt.Errorf("module repo %q must be a http, https, ssh, git or file URL", module.Repo)
+		}
+	}
+	return nil
+}
+
+func checkPricingMode(mode PricingMode) error {
+	switch mode {
+	case "", MarketPrice, FixedPrice:
+		return nil
+	default:
+		return fmt.Errorf("pricing mode %q must be %s or %s", mode, MarketPrice, FixedPrice)
+	}
+}
+
+func checkServiceConfigBounds(services ServiceConfig) error {
+	err := checkOfferString("solver", services.Solver)
+	if err != nil {
+		return err
+	}
+	err = checkOfferString("api host", services.APIHost)
+	if err != nil {
+		return err
+	}
+	return checkOfferStrings("mediators", services.Mediator)
+}
+
+// the limits that apply to every field of a job offer
+// the rules about what the fields mean are in CheckJobOffer
+func CheckJobOfferBounds(jobOffer JobOffer) error {
+	for field, value := range map[string]string{
+		"id":              jobOffer.ID,
+		"job creator":     jobOffer.JobCreator,
+		"target address":  jobOffer.Target.Address,
+		"target offer":    jobOffer.Target.ResourceOffer,
+		"resume from":     jobOffer.ResumeFrom,
+		"group id":        jobOffer.GroupID,
+		"schedule id":     jobOffer.ScheduleID,
+		"workflow id":     jobOffer.WorkflowID,
+		"reservation id":  jobOffer.ReservationID,
+		"idempotency key": jobOffer.IdempotencyKey,
+		"parent deal id":  jobOffer.ParentDealID,
+	} {
+		err := checkOfferString(field, value)
+		if err != nil {
+			return fmt.Errorf("job offer %s", err.Error())
+		}
+	}
+
+	for field, value := range map[string]int{
+		"created at":            jobOffer.CreatedAt,
+		"chain id":              jobOffer.ChainID,
+		"input size":            jobOffer.InputSize,
+		"max runtime":           jobOffer.MaxRuntime,
+		"min preemption notice": jobOffer.MinPreemptionNotice,
+	} {
+		err := checkOfferCount(field, value)
+		if err != nil {
+			return fmt.Errorf("job offer %s", err.Error())
+		}
+	}
+
+	err := checkPricingMode(jobOffer.Mode)
+	if err != nil {
+		return fmt.Errorf("job offer %s", err.Error())
+	}
+
+	err = CheckMachineSpecBounds(jobOffer.Spec)
+	if err != nil {
+		return fmt.Errorf("job offer %s", err.Error())
+	}
+
+	err = CheckModuleConfigBounds(jobOffer.Module)
+	if err != nil {
+		return fmt.Errorf("job offer %s", err.Error())
+	}
+
+	err = CheckDealPricingBounds(jobOffer.Pricing, jobOffer.Timeouts)
+	if err != nil {
+		return fmt.Errorf("job offer %s", err.Error())
+	}
+
+	err = checkServiceConfigBounds(jobOffer.Services)
+	if err != nil {
+		return fmt.Errorf("job offer %s", err.Error())
+	}
+
+	if jobOffer.Target.Address != "" && !common.IsHexAddress(jobOffer.Target.Address) {
+		return fmt.Errorf("job offer target %q is not an address", jobOffer.Target.Address)
+	}
+
+	if jobOffer.ResumeFrom != "" {
+		_, err = cid.Decode(jobOffer.ResumeFrom)
+		if err != nil {
+			return fmt.Errorf("job offer resume from %q is not a valid CID: %s", jobOffer.ResumeFrom, err.Error())
+		}
+	}
+
+	if jobOffer.Target.ResourceOffer != "" {
+		_, err = cid.Decode(jobOffer.Target.ResourceOffer)
+		if err != nil {
+			return fmt.Errorf("job offer target offer %q is not a valid resource offer ID: %s", jobOffer.Target.ResourceOffer, err.Error())
+		}
+	}
+
+	err = checkOfferAddresses("job offer trusted providers", jobOffer.TrustedProviders)
+	if err != nil {
+		return err
+	}
+	err = checkOfferAddresses("job offer excluded providers", jobOffer.ExcludedProviders)
+	if err != nil {
+		return err
+	}
+
+	err = checkOfferMap("job offer inputs", jobOffer.Inputs, MAX_OFFER_VALUE_LENGTH)
+	if err != nil {
+		return err
+	}
+	err = checkOfferMap("job offer env", jobOffer.Env, MAX_OFFER_VALUE_LENGTH)
+	if err != nil {
+		return err
+	}
+	err = checkOfferMap("job offer required labels", jobOffer.RequiredLabels, MAX_OFFER_STRING_LENGTH)
+	if err != nil {
+		return err
+	}
+	err = checkOfferMap("job offer preferred labels", jobOffer.PreferredLabels, MAX_OFFER_STRING_LENGTH)
+	if err != nil {
+		return err
+	}
+
+	for field, values := range map[string][]string{
+		"job offer encrypted inputs": jobOffer.EncryptedInputs,
+		"job offer offer classes":    jobOffer.OfferClasses,
+	} {
+		err = checkOfferStrings(field, values)
+		if err != nil {
+			return err
+		}
+	}
+
+	if jobOffer.TEE != nil {
+		err = checkOfferStrings("job offer tee types", jobOffer.TEE.Types)
+		if err != nil {
+			return err
+		}
+		err = checkOfferStrings("job offer tee measurements", jobOffer.TEE.Measurements)
+		if err != nil {
+			return err
+		}
+	}
+
+	if jobOffer.Network != nil {
+		err = checkOfferStrings("job offer network allow list", jobOffer.Network.Allow)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// the limits that apply to every field of a resource offer
+// the rules about what the fields mean are in CheckResourceOffer
+func CheckResourceOfferBounds(resourceOffer ResourceOffer) error {
+	for field, value := range map[string]string{
+		"id":                resourceOffer.ID,
+		"resource provider": resourceOffer.ResourceProvider,
+		"idempotency key":   resourceOffer.IdempotencyKey,
+		"encryption key":    resourceOffer.EncryptionKey,
+		"class":             resourceOffer.Class,
+	} {
+		err := checkOfferString(field, value)
+		if err != nil {
+			return fmt.Errorf("resource offer %s", err.Error())
+		}
+	}
+
+	for field, value := range map[string]int{
+		"created at":        resourceOffer.CreatedAt,
+		"chain id":          resourceOffer.ChainID,
+		"index":             resourceOffer.Index,
+		"max input size":    resourceOffer.MaxInputSize,
+		"preemption notice": resourceOffer.PreemptionNotice,
+		"reserved duration": resourceOffer.ReservedDuration,
+	} {
+		err := checkOfferCount(field, value)
+		if err != nil {
+			return fmt.Errorf("resource offer %s", err.Error())
+		}
+	}
+
+	err := checkPricingMode(resourceOffer.Mode)
+	if err != nil {
+		return fmt.Errorf("resource offer %s", err.Error())
+	}
+
+	err = CheckMachineSpecBounds(resourceOffer.Spec)
+	if err != nil {
+		return fmt.Errorf("resource offer %s", err.Error())
+	}
+
+	err = CheckDealPricingBounds(resourceOffer.DefaultPricing, resourceOffer.DefaultTimeouts)
+	if err != nil {
+		return fmt.Errorf("resource offer %s", err.Error())
+	}
+
+	err = checkServiceConfigBounds(resourceOffer.Services)
+	if err != nil {
+		return fmt.Errorf("resource offer %s", err.Error())
+	}
+
+	err = checkOfferStrings("resource offer modules", resourceOffer.Modules)
+	if err != nil {
+		return err
+	}
+	err = checkOfferAddresses("resource offer allowed job creators", resourceOffer.AllowedJobCreators)
+	if err != nil {
+		return err
+	}
+	err = checkOfferStrings("resource offer payment tokens", resourceOffer.PaymentTokens)
+	if err != nil {
+		return err
+	}
+	err = checkOfferMap("resource offer labels", resourceOffer.Labels, MAX_OFFER_STRING_LENGTH)
+	if err != nil {
+		return err
+	}
+
+	// module timeouts without module pricing are paired with the default pricing
+	if len(resourceOffer.ModulePricing) > MAX_OFFER_LIST_LENGTH || len(resourceOffer.ModuleTimeouts) > MAX_OFFER_LIST_LENGTH || len(resourceOffer.ModuleNetwork) > MAX_OFFER_LIST_LENGTH {
+		return fmt.Errorf("resource offer has settings for more than %d modules", MAX_OFFER_LIST_LENGTH)
+	}
+	for moduleID, pricing := range resourceOffer.ModulePricing {
+		err = checkOfferString("resource offer module pricing key", moduleID)
+		if err != nil {
+			return err
+		}
+		err = CheckDealPricingBounds(pricing, GetResourceOfferTimeouts(resourceOffer, moduleID))
+		if err != nil {
+			return fmt.Errorf("resource offer module %s: %s", moduleID, err.Error())
+		}
+	}
+	for moduleID, timeouts := range resourceOffer.ModuleTimeouts {
+		err = checkOfferString("resource offer module timeouts key", moduleID)
+		if err != nil {
+			return err
+		}
+		err = CheckDealPricingBounds(GetResourceOfferPricing(resourceOffer, moduleID), timeouts)
+		if err != nil {
+			return fmt.Errorf("resource offer module %s: %s", moduleID, err.Error())
+		}
+	}
+	for moduleID, policy := range resourceOffer.ModuleNetwork {
+		err = checkOfferString("resource offer module network key", moduleID)
+		if err != nil {
+			return err
+		}
+		err = checkOfferStrings("resource offer module network allow list", policy.Allow)
+		if err != nil {
+			return err
+		}
+	}
+
+	if resourceOffer.TEE != nil {
+		for field, value := range map[string]string{
+			"tee type":        resourceOffer.TEE.Type,
+			"tee measurement": resourceOffer.TEE.Measurement,
+		} {
+			err = checkOfferString(field, value)
+			if err != nil {
+				return fmt.Errorf("resource offer %s", err.Error())
+			}
+		}
+		err = checkOfferStringLength("resource offer tee evidence", resourceOffer.TEE.Evidence, MAX_OFFER_VALUE_LENGTH)
+		if err != nil {
+			return err
+		}
+	}
+
+	if resourceOffer.Network != nil {
+		err = checkOfferStrings("resource offer network allow list", resourceOffer.Network.Allow)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
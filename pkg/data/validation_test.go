@@ -0,0 +1,177 @@
+package data
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testOfferAddress = "0x1111111111111111111111111111111111111111"
+
+func getValidationJobOffer() JobOffer {
+	return JobOffer{
+		JobCreator: testOfferAddress,
+		Module: ModuleConfig{
+			Name: "cowsay",
+			Repo: "https://github.com/Lilypad-Tech/lilypad-module-cowsay",
+			Hash: "v0.0.4",
+			Path: "/lilypad_module.json.tmpl",
+		},
+		Spec:   MachineSpec{CPU: 1000, RAM: 1024},
+		Inputs: map[string]string{"Message": "moo"},
+		Mode:   MarketPrice,
+		Services: ServiceConfig{
+			Solver:   testOfferAddress,
+			Mediator: []string{testOfferAddress},
+		},
+	}
+}
+
+func getValidationResourceOffer() ResourceOffer {
+	return ResourceOffer{
+		ResourceProvider: testOfferAddress,
+		Spec:             MachineSpec{CPU: 4000, RAM: 8192, GPUs: []GPUSpec{{Name: "a100", Vendor: "nvidia", VRAM: 40960}}},
+		Mode:             FixedPrice,
+		DefaultPricing: DealPricing{
+			InstructionPrice:          10,
+			PaymentCollateral:         100,
+			ResultsCollateralMultiple: 2,
+		},
+		Services: ServiceConfig{
+			Solver:   testOfferAddress,
+			Mediator: []string{testOfferAddress},
+		},
+	}
+}
+
+func TestCheckJobOfferBounds(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(offer *JobOffer)
+		wantErr bool
+	}{
+		{name: "valid", change: func(offer *JobOffer) {}},
+		{name: "negative cpu", change: func(offer *JobOffer) { offer.Spec.CPU = -1 }, wantErr: true},
+		{name: "huge ram", change: func(offer *JobOffer) { offer.Spec.RAM = MAX_SPEC_RAM + 1 }, wantErr: true},
+		{name: "negative vram", change: func(offer *JobOffer) { offer.Spec.GPUs = []GPUSpec{{VRAM: -1}} }, wantErr: true},
+		{name: "negative max runtime", change: func(offer *JobOffer) { offer.MaxRuntime = -1 }, wantErr: true},
+		{name: "long job creator", change: func(offer *JobOffer) { offer.JobCreator = strings.Repeat("a", MAX_OFFER_STRING_LENGTH+1) }, wantErr: true},
+		{name: "long input", change: func(offer *JobOffer) { offer.Inputs["Message"] = strings.Repeat("a", MAX_OFFER_VALUE_LENGTH+1) }, wantErr: true},
+		{name: "invalid utf-8", change: func(offer *JobOffer) { offer.Inputs["Message"] = "\xff" }, wantErr: true},
+		{name: "repo not a url", change: func(offer *JobOffer) { offer.Module.Repo = "://nope" }, wantErr: true},
+		{name: "repo scheme", change: func(offer *JobOffer) { offer.Module.Repo = "javascript:alert(1)" }, wantErr: true},
+		{name: "resume from not a cid", change: func(offer *JobOffer) { offer.ResumeFrom = "not-a-cid" }, wantErr: true},
+		{name: "target not an address", change: func(offer *JobOffer) { offer.Target.Address = "nope" }, wantErr: true},
+		{name: "trusted provider not an address", change: func(offer *JobOffer) { offer.TrustedProviders = []string{"nope"} }, wantErr: true},
+		{name: "unknown pricing mode", change: func(offer *JobOffer) { offer.Mode = "Free" }, wantErr: true},
+		{name: "collateral overflow", change: func(offer *JobOffer) {
+			offer.Pricing.PaymentCollateral = math.MaxUint64
+			offer.Pricing.MediationFee = 1
+		}, wantErr: true},
+		{name: "results collateral overflow", change: func(offer *JobOffer) {
+			offer.Pricing.InstructionPrice = math.MaxUint64 / 2
+			offer.Pricing.ResultsCollateralMultiple = 3
+		}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offer := getValidationJobOffer()
+			tt.change(&offer)
+			err := CheckJobOffer(offer)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckJobOffer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckResourceOfferBounds(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(offer *ResourceOffer)
+		wantErr bool
+	}{
+		{name: "valid", change: func(offer *ResourceOffer) {}},
+		{name: "negative index", change: func(offer *ResourceOffer) { offer.Index = -1 }, wantErr: true},
+		{name: "huge disk", change: func(offer *ResourceOffer) { offer.Spec.Disk = MAX_SPEC_DISK + 1 }, wantErr: true},
+		{name: "too many gpus", change: func(offer *ResourceOffer) { offer.Spec.GPUs = make([]GPUSpec, MAX_OFFER_GPUS+1) }, wantErr: true},
+		{name: "too many modules", change: func(offer *ResourceOffer) { offer.Modules = make([]string, MAX_OFFER_LIST_LENGTH+1) }, wantErr: true},
+		{name: "allowed job creator not an address", change: func(offer *ResourceOffer) { offer.AllowedJobCreators = []string{"nope"} }, wantErr: true},
+		{name: "results collateral multiple", change: func(offer *ResourceOffer) {
+			offer.DefaultPricing.ResultsCollateralMultiple = MAX_RESULTS_COLLATERAL_MULTIPLE + 1
+		}, wantErr: true},
+		{name: "module pricing overflow", change: func(offer *ResourceOffer) {
+			offer.ModulePricing = map[string]DealPricing{"cowsay:v0.0.4": {PaymentCollateral: math.MaxUint64}}
+			offer.DefaultTimeouts.Agree.Collateral = 1
+		}, wantErr: true},
+		{name: "module timeouts overflow", change: func(offer *ResourceOffer) {
+			offer.ModuleTimeouts = map[string]DealTimeouts{"cowsay:v0.0.4": {Agree: DealTimeout{Collateral: math.MaxUint64}}}
+		}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			offer := getValidationResourceOffer()
+			tt.change(&offer)
+			err := CheckResourceOffer(offer)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckResourceOffer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// whatever is sent the checks must not panic and an offer
+// they accept must be safe to hash and price
+func FuzzCheckJobOffer(f *testing.F) {
+	seed, err := json.Marshal(getValidationJobOffer())
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(seed)
+	f.Add([]byte(`{"spec":{"cpu":-1}}`))
+	f.Add([]byte(`{"module":{"repo":"::"},"resume_from":"x","pricing":{"payment_collateral":18446744073709551615,"mediation_fee":1}}`))
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		var offer JobOffer
+		if json.Unmarshal(payload, &offer) != nil {
+			return
+		}
+		if CheckJobOffer(offer) != nil {
+			return
+		}
+		assert.GreaterOrEqual(t, offer.Spec.CPU, 0)
+		assert.GreaterOrEqual(t, offer.InputSize, 0)
+		_, err := GetJobOfferID(offer)
+		assert.NoError(t, err)
+		_, _ = GetModuleID(offer.Module)
+	})
+}
+
+func FuzzCheckResourceOffer(f *testing.F) {
+	seed, err := json.Marshal(getValidationResourceOffer())
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(seed)
+	f.Add([]byte(`{"spec":{"gpus":[{"vram":-1}]}}`))
+	f.Add([]byte(`{"module_pricing":{"a":{"instruction_price":18446744073709551615,"results_collateral_multiple":2}}}`))
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		var offer ResourceOffer
+		if json.Unmarshal(payload, &offer) != nil {
+			return
+		}
+		if CheckResourceOffer(offer) != nil {
+			return
+		}
+		assert.GreaterOrEqual(t, offer.Spec.RAM, 0)
+		assert.GreaterOrEqual(t, offer.MaxInputSize, 0)
+		_, err := GetResourceOfferID(offer)
+		assert.NoError(t, err)
+		for _, moduleID := range offer.Modules {
+			pricing := GetResourceOfferPricing(offer, moduleID)
+			assert.LessOrEqual(t, pricing.ResultsCollateralMultiple, uint64(MAX_RESULTS_COLLATERAL_MULTIPLE))
+		}
+	})
+}
@@ -0,0 +1,274 @@
+package data
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+)
+
+// the most stages one workflow can have
+const MAX_WORKFLOW_STAGES = 100
+
+var WorkflowFailurePolicies = []string{WorkflowFailureStop, WorkflowFailureContinue}
+
+func CheckWorkflowFailurePolicy(policy string) error {
+	for _, known := range WorkflowFailurePolicies {
+		if policy == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown failure policy %q, it must be one of %v", policy, WorkflowFailurePolicies)
+}
+
+func GetWorkflowID(submission WorkflowSubmission) (string, error) {
+	for i := range submission.Stages {
+		submission.Stages[i].JobOffer.ID = ""
+	}
+	return CalculateCID(submission)
+}
+
+// the stages this one needs results from
+// inputs taken from a stage count as depending on it
+func GetWorkflowStageDependencies(stage WorkflowStage) []string {
+	seen := map[string]bool{}
+	dependencies := []string{}
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			dependencies = append(dependencies, name)
+		}
+	}
+	for _, name := range stage.DependsOn {
+		add(name)
+	}
+	inputs := []string{}
+	for input := range stage.InputsFrom {
+		inputs = append(inputs, input)
+	}
+	sort.Strings(inputs)
+	for _, input := range inputs {
+		add(stage.InputsFrom[input])
+	}
+	return dependencies
+}
+
+func CheckWorkflowSubmission(submission WorkflowSubmission) error {
+	if len(submission.Stages) == 0 {
+		return fmt.Errorf("workflow must have at least one stage")
+	}
+	if len(submission.Stages) > MAX_WORKFLOW_STAGES {
+		return fmt.Errorf("workflow has %d stages, the most it can have is %d", len(submission.Stages), MAX_WORKFLOW_STAGES)
+	}
+	if submission.FailurePolicy != "" {
+		err := CheckWorkflowFailurePolicy(submission.FailurePolicy)
+		if err != nil {
+			return err
+		}
+	}
+	stages := map[string]WorkflowStage{}
+	for _, stage := range submission.Stages {
+		if stage.Name == "" {
+			return fmt.Errorf("every workflow stage must have a name")
+		}
+		if _, ok := stages[stage.Name]; ok {
+			return fmt.Errorf("workflow has more than one stage called %s", stage.Name)
+		}
+		if stage.JobOffer.JobCreator != submission.JobCreator {
+			return fmt.Errorf("job offer for stage %s is not from the workflow job creator", stage.Name)
+		}
+		err := CheckJobOffer(stage.JobOffer)
+		if err != nil {
+			return fmt.Errorf("stage %s: %s", stage.Name, err.Error())
+		}
+		stages[stage.Name] = stage
+	}
+	for _, stage := range submission.Stages {
+		for _, dependency := range GetWorkflowStageDependencies(stage) {
+			if _, ok := stages[dependency]; !ok {
+				return fmt.Errorf("stage %s depends on %s which is not in the workflow", stage.Name, dependency)
+			}
+			if dependency == stage.Name {
+				return fmt.Errorf("stage %s depends on itself", stage.Name)
+			}
+		}
+	}
+
+	// a stage in a cycle would never be added
+	visiting := map[string]bool{}
+	visited := map[string]bool{}
+	var visit func(name string) error
+	visit = func(name string) error {
+		if visited[name] {
+			return nil
+		}
+		if visiting[name] {
+			return fmt.Errorf("workflow stages depend on each other in a cycle through %s", name)
+		}
+		visiting[name] = true
+		for _, dependency := range GetWorkflowStageDependencies(stages[name]) {
+			err := visit(dependency)
+			if err != nil {
+				return err
+			}
+		}
+		visiting[name] = false
+		visited[name] = true
+		return nil
+	}
+	for _, stage := range submission.Stages {
+		err := visit(stage.Name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func NewWorkflow(submission WorkflowSubmission, now time.Time) (Workflow, error) {
+	id, err := GetWorkflowID(submission)
+	if err != nil {
+		return Workflow{}, err
+	}
+	workflow := Workflow{
+		ID:            id,
+		JobCreator:    submission.JobCreator,
+		Stages:        submission.Stages,
+		FailurePolicy: submission.FailurePolicy,
+		State:         WorkflowStageRunning,
+		CreatedAt:     now.Unix(),
+	}
+	if workflow.FailurePolicy == "" {
+		workflow.FailurePolicy = WorkflowFailureStop
+	}
+	for _, stage := range submission.Stages {
+		workflow.StageStates = append(workflow.StageStates, WorkflowStageStatus{
+			Name:  stage.Name,
+			State: WorkflowStagePending,
+		})
+	}
+	return workflow, nil
+}
+
+func IsTerminalWorkflowStageState(state string) bool {
+	return state == WorkflowStageSucceeded || state == WorkflowStageFailed || state == WorkflowStageSkipped
+}
+
+// the job offer for a stage whose upstream stages have all succeeded
+// with the inputs it takes from them set to their results CIDs
+func GetWorkflowStageJobOffer(workflow Workflow, index int, now time.Time) JobOffer {
+	stage := workflow.Stages[index]
+	results := map[string]string{}
+	for _, status := range workflow.StageStates {
+		results[status.Name] = status.ResultsID
+	}
+	offer := stage.JobOffer
+	offer.ID = ""
+	offer.WorkflowID = workflow.ID
+	offer.IdempotencyKey = ""
+	offer.CreatedAt = int(now.UnixMilli())
+	offer.Inputs = map[string]string{}
+	for name, input := range stage.JobOffer.Inputs {
+		offer.Inputs[name] = input
+	}
+	inputs := []string{}
+	for input := range stage.InputsFrom {
+		inputs = append(inputs, input)
+	}
+	sort.Strings(inputs)
+	offer.InputCIDs = append([]string{}, stage.JobOffer.InputCIDs...)
+	for _, input := range inputs {
+		resultsID := results[stage.InputsFrom[input]]
+		offer.Inputs[input] = resultsID
+		if !slices.Contains(offer.InputCIDs, resultsID) {
+			offer.InputCIDs = append(offer.InputCIDs, resultsID)
+		}
+	}
+	return offer
+}
+
+// move the workflow on once the running stages have been brought up to date
+// it returns the stages that can be added now and the running stages to cancel
+func PlanWorkflow(workflow *Workflow, now time.Time) ([]int, []int) {
+	ready := []int{}
+	cancel := []int{}
+	if IsTerminalWorkflowStageState(workflow.State) {
+		return ready, cancel
+	}
+	index := map[string]int{}
+	failed := ""
+	for i, status := range workflow.StageStates {
+		index[status.Name] = i
+		if status.State == WorkflowStageFailed && failed == "" {
+			failed = status.Name
+		}
+	}
+
+	if failed != "" && workflow.FailurePolicy == WorkflowFailureStop {
+		for i := range workflow.StageStates {
+			status := &workflow.StageStates[i]
+			switch status.State {
+			case WorkflowStageRunning:
+				cancel = append(cancel, i)
+				fallthrough
+			case WorkflowStagePending:
+				status.State = WorkflowStageSkipped
+				status.Error = fmt.Sprintf("stage %s failed", failed)
+			}
+		}
+	} else {
+		// skipping a stage can mean the ones after it are skipped too
+		for changed := true; changed; {
+			changed = false
+			for i, stage := range workflow.Stages {
+				status := &workflow.StageStates[i]
+				if status.State != WorkflowStagePending {
+					continue
+				}
+				for _, dependency := range GetWorkflowStageDependencies(stage) {
+					upstream := workflow.StageStates[index[dependency]]
+					if upstream.State == WorkflowStageFailed || upstream.State == WorkflowStageSkipped {
+						status.State = WorkflowStageSkipped
+						status.Error = fmt.Sprintf("stage %s did not succeed", dependency)
+						changed = true
+						break
+					}
+				}
+			}
+		}
+		for i, stage := range workflow.Stages {
+			if workflow.StageStates[i].State != WorkflowStagePending {
+				continue
+			}
+			canStart := true
+			for _, dependency := range GetWorkflowStageDependencies(stage) {
+				if workflow.StageStates[index[dependency]].State != WorkflowStageSucceeded {
+					canStart = false
+					break
+				}
+			}
+			if canStart {
+				ready = append(ready, i)
+			}
+		}
+	}
+
+	finished := true
+	succeeded := true
+	for _, status := range workflow.StageStates {
+		if !IsTerminalWorkflowStageState(status.State) {
+			finished = false
+		}
+		if status.State != WorkflowStageSucceeded {
+			succeeded = false
+		}
+	}
+	if finished && len(ready) == 0 {
+		workflow.State = WorkflowStageFailed
+		if succeeded {
+			workflow.State = WorkflowStageSucceeded
+		}
+		workflow.FinishedAt = now.Unix()
+	}
+	return ready, cancel
+}
@@ -0,0 +1,95 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testWorkflowSubmission(policy string) WorkflowSubmission {
+	offer := JobOffer{
+		JobCreator: "0xjc",
+		Inputs:     map[string]string{},
+		Services:   ServiceConfig{Solver: "0xsolver", Mediator: []string{"0xmediator"}},
+	}
+	return WorkflowSubmission{
+		JobCreator:    "0xjc",
+		FailurePolicy: policy,
+		Stages: []WorkflowStage{
+			{Name: "preprocess", JobOffer: offer},
+			{Name: "train", JobOffer: offer, InputsFrom: map[string]string{"Dataset": "preprocess"}},
+			{Name: "evaluate", JobOffer: offer, InputsFrom: map[string]string{"Model": "train"}},
+			{Name: "report", JobOffer: offer},
+		},
+	}
+}
+
+func TestCheckWorkflowSubmission(t *testing.T) {
+	require.NoError(t, CheckWorkflowSubmission(testWorkflowSubmission("")))
+
+	cycle := testWorkflowSubmission("")
+	cycle.Stages[0].DependsOn = []string{"evaluate"}
+	assert.ErrorContains(t, CheckWorkflowSubmission(cycle), "cycle")
+
+	missing := testWorkflowSubmission("")
+	missing.Stages[1].InputsFrom["Extra"] = "nowhere"
+	assert.ErrorContains(t, CheckWorkflowSubmission(missing), "not in the workflow")
+
+	duplicate := testWorkflowSubmission("")
+	duplicate.Stages[3].Name = "train"
+	assert.ErrorContains(t, CheckWorkflowSubmission(duplicate), "more than one stage")
+
+	assert.Error(t, CheckWorkflowSubmission(testWorkflowSubmission("retry")))
+}
+
+func TestPlanWorkflow(t *testing.T) {
+	now := time.Now()
+	workflow, err := NewWorkflow(testWorkflowSubmission(""), now)
+	require.NoError(t, err)
+	assert.Equal(t, WorkflowFailureStop, workflow.FailurePolicy)
+
+	ready, cancel := PlanWorkflow(&workflow, now)
+	assert.Equal(t, []int{0, 3}, ready)
+	assert.Empty(t, cancel)
+
+	workflow.StageStates[0].State = WorkflowStageSucceeded
+	workflow.StageStates[0].ResultsID = "QmPreprocessed"
+	workflow.StageStates[3].State = WorkflowStageRunning
+	ready, _ = PlanWorkflow(&workflow, now)
+	assert.Equal(t, []int{1}, ready)
+
+	offer := GetWorkflowStageJobOffer(workflow, 1, now)
+	assert.Equal(t, "QmPreprocessed", offer.Inputs["Dataset"])
+	assert.Equal(t, workflow.ID, offer.WorkflowID)
+	assert.Empty(t, workflow.Stages[1].JobOffer.Inputs)
+
+	// stopping cancels the report stage that was still running
+	workflow.StageStates[1].State = WorkflowStageFailed
+	ready, cancel = PlanWorkflow(&workflow, now)
+	assert.Empty(t, ready)
+	assert.Equal(t, []int{3}, cancel)
+	assert.Equal(t, WorkflowStageSkipped, workflow.StageStates[2].State)
+	assert.Equal(t, WorkflowStageFailed, workflow.State)
+}
+
+func TestPlanWorkflowContinue(t *testing.T) {
+	now := time.Now()
+	workflow, err := NewWorkflow(testWorkflowSubmission(WorkflowFailureContinue), now)
+	require.NoError(t, err)
+
+	workflow.StageStates[0].State = WorkflowStageFailed
+	workflow.StageStates[3].State = WorkflowStageRunning
+	ready, cancel := PlanWorkflow(&workflow, now)
+	assert.Empty(t, ready)
+	assert.Empty(t, cancel)
+	assert.Equal(t, WorkflowStageSkipped, workflow.StageStates[1].State)
+	assert.Equal(t, WorkflowStageSkipped, workflow.StageStates[2].State)
+	assert.Equal(t, WorkflowStageRunning, workflow.StageStates[3].State)
+	assert.Equal(t, WorkflowStageRunning, workflow.State)
+
+	workflow.StageStates[3].State = WorkflowStageSucceeded
+	PlanWorkflow(&workflow, now)
+	assert.Equal(t, WorkflowStageFailed, workflow.State)
+}
@@ -0,0 +1,195 @@
+package escrow
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+// these mirror the enums in LilypadPayments.sol
+const (
+	ReasonPaymentCollateral uint8 = iota
+	ReasonResultsCollateral
+	ReasonTimeoutCollateral
+	ReasonJobPayment
+	ReasonMediationFee
+)
+
+const (
+	DirectionPaidIn uint8 = iota
+	DirectionPaidOut
+	DirectionRefunded
+	DirectionSlashed
+)
+
+var reasonNames = []string{
+	"PaymentCollateral",
+	"ResultsCollateral",
+	"TimeoutCollateral",
+	"JobPayment",
+	"MediationFee",
+}
+
+var directionNames = []string{
+	"PaidIn",
+	"PaidOut",
+	"Refunded",
+	"Slashed",
+}
+
+func ReasonName(reason uint8) string {
+	if int(reason) >= len(reasonNames) {
+		return "Unknown"
+	}
+	return reasonNames[reason]
+}
+
+func DirectionName(direction uint8) string {
+	if int(direction) >= len(directionNames) {
+		return "Unknown"
+	}
+	return directionNames[direction]
+}
+
+// a payment we expect the payments contract to have made for a deal
+// amounts are in wei as decimal strings like data.EscrowPayment
+type Expectation struct {
+	Party     string `json:"party"`
+	Reason    string `json:"reason"`
+	Direction string `json:"direction"`
+	Amount    string `json:"amount"`
+}
+
+// an expectation that the payments we have seen do not add up to
+type Discrepancy struct {
+	Expectation
+	Actual string `json:"actual"`
+}
+
+// the escrow accounting for a single deal
+type DealAccount struct {
+	DealID        string               `json:"deal_id"`
+	State         string               `json:"state"`
+	Expected      []Expectation        `json:"expected"`
+	Payments      []data.EscrowPayment `json:"payments"`
+	Discrepancies []Discrepancy        `json:"discrepancies"`
+	Reconciled    bool                 `json:"reconciled"`
+}
+
+// has the deal got to or past the given state
+func hasReached(state data.DealState, target data.DealState) bool {
+	return state == target || target.CanReach(state)
+}
+
+func expect(party string, reason uint8, direction uint8, amount *big.Int) Expectation {
+	return Expectation{
+		Party:     party,
+		Reason:    ReasonName(reason),
+		Direction: DirectionName(direction),
+		Amount:    amount.String(),
+	}
+}
+
+// the payments the contracts should have made for the deal so far
+// these come from the deal pricing and timeouts the same way the
+// controller contract works them out
+// the results collateral and job payment depend on the instruction
+// count so are only expected once we have the result
+func GetExpectations(deal data.DealContainer, result *data.Result) []Expectation {
+	state := data.DealState(deal.State)
+	pricing := data.ConvertDealPricing(deal.Deal.Pricing, data.GetDealDecimals(deal.Deal))
+	timeouts := data.ConvertDealTimeouts(deal.Deal.Timeouts, data.GetDealDecimals(deal.Deal))
+	members := deal.Deal.Members
+
+	expected := []Expectation{}
+	if hasReached(state, data.DealAgreed) {
+		expected = append(expected,
+			expect(members.ResourceProvider, ReasonTimeoutCollateral, DirectionPaidIn, timeouts.SubmitResults.Collateral),
+			expect(members.JobCreator, ReasonPaymentCollateral, DirectionPaidIn, pricing.PaymentCollateral),
+			expect(members.JobCreator, ReasonTimeoutCollateral, DirectionPaidIn, timeouts.JudgeResults.Collateral),
+		)
+	}
+
+	// the milestones are paid out of the job payment while the job runs
+	jobPayment := data.GetMilestonesPaid(deal)
+	settled := false
+	if result != nil && hasReached(state, data.ResultsSubmitted) {
+		jobCost := new(big.Int).Mul(pricing.InstructionPrice, new(big.Int).SetUint64(result.InstructionCount))
+		resultsCollateral := new(big.Int).Mul(pricing.ResultsCollateralMultiple, jobCost)
+		expected = append(expected,
+			expect(members.ResourceProvider, ReasonResultsCollateral, DirectionPaidIn, resultsCollateral),
+		)
+		// the resource provider keeps the milestones even if the job cost less
+		settled = state == data.ResultsAccepted || state == data.MediationAccepted
+		if settled && jobCost.Cmp(jobPayment) > 0 {
+			jobPayment = jobCost
+		}
+	}
+	if settled || jobPayment.Sign() > 0 {
+		expected = append(expected,
+			expect(members.ResourceProvider, ReasonJobPayment, DirectionPaidOut, jobPayment),
+		)
+	}
+
+	if hasReached(state, data.ResultsChecked) {
+		expected = append(expected,
+			expect(members.JobCreator, ReasonMediationFee, DirectionPaidIn, pricing.MediationFee),
+		)
+	}
+	return expected
+}
+
+// add up the payments for each party, reason and direction
+func sumPayments(payments []data.EscrowPayment) map[string]*big.Int {
+	totals := map[string]*big.Int{}
+	for _, payment := range payments {
+		amount, ok := new(big.Int).SetString(payment.Amount, 10)
+		if !ok {
+			continue
+		}
+		key := paymentKey(payment.Payee, ReasonName(payment.Reason), DirectionName(payment.Direction))
+		if totals[key] == nil {
+			totals[key] = new(big.Int)
+		}
+		totals[key].Add(totals[key], amount)
+	}
+	return totals
+}
+
+func paymentKey(party string, reason string, direction string) string {
+	return strings.ToLower(party) + "/" + reason + "/" + direction
+}
+
+// compare the payments we have seen on-chain with what we expect
+// payment events can arrive a little after the deal state changes
+// so a discrepancy on a deal that has just moved is not always a problem
+func Reconcile(deal data.DealContainer, result *data.Result, payments []data.EscrowPayment) DealAccount {
+	sort.SliceStable(payments, func(i, j int) bool {
+		return payments[i].CreatedAt < payments[j].CreatedAt
+	})
+	account := DealAccount{
+		DealID:        deal.ID,
+		State:         data.GetAgreementStateString(deal.State),
+		Expected:      GetExpectations(deal, result),
+		Payments:      payments,
+		Discrepancies: []Discrepancy{},
+	}
+
+	totals := sumPayments(payments)
+	for _, expectation := range account.Expected {
+		actual := totals[paymentKey(expectation.Party, expectation.Reason, expectation.Direction)]
+		if actual == nil {
+			actual = new(big.Int)
+		}
+		if actual.String() != expectation.Amount {
+			account.Discrepancies = append(account.Discrepancies, Discrepancy{
+				Expectation: expectation,
+				Actual:      actual.String(),
+			})
+		}
+	}
+	account.Reconciled = len(account.Discrepancies) == 0
+	return account
+}
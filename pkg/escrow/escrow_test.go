@@ -0,0 +1,142 @@
+package escrow
+
+import (
+	"testing"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcile(t *testing.T) {
+	rp := "0x2546BcD3c84621e976D8185a91A922aE77ECEc30"
+	jc := "0xbDA5747bFD65F08deb54cb465eB87D40e51B197E"
+
+	deal := func(state data.DealState) data.DealContainer {
+		return data.DealContainer{
+			ID:    "deal1",
+			State: uint8(state),
+			Deal: data.Deal{
+				Members: data.DealMembers{
+					ResourceProvider: rp,
+					JobCreator:       jc,
+				},
+				Pricing: data.DealPricing{
+					InstructionPrice:          1,
+					PaymentCollateral:         2,
+					ResultsCollateralMultiple: 2,
+					MediationFee:              1,
+				},
+				Timeouts: data.DealTimeouts{
+					SubmitResults: data.DealTimeout{Collateral: 3},
+					JudgeResults:  data.DealTimeout{Collateral: 4},
+				},
+			},
+		}
+	}
+	wei := func(ether int64) string {
+		return data.EtherToWei(float64(ether)).String()
+	}
+	payment := func(payee string, reason uint8, direction uint8, amount string) data.EscrowPayment {
+		return data.EscrowPayment{
+			DealID:    "deal1",
+			Payee:     payee,
+			Amount:    amount,
+			Reason:    reason,
+			Direction: direction,
+		}
+	}
+	agreePayments := []data.EscrowPayment{
+		payment(rp, ReasonTimeoutCollateral, DirectionPaidIn, wei(3)),
+		payment(jc, ReasonPaymentCollateral, DirectionPaidIn, wei(2)),
+		payment(jc, ReasonTimeoutCollateral, DirectionPaidIn, wei(4)),
+	}
+
+	// one milestone paid while the job runs
+	milestoneDeal := func(state data.DealState) data.DealContainer {
+		deal := deal(state)
+		deal.Milestones = []data.DealMilestone{
+			{DealID: "deal1", Index: 0, Amount: wei(1), State: data.DealMilestonePaid},
+		}
+		return deal
+	}
+
+	testCases := []struct {
+		name          string
+		deal          data.DealContainer
+		result        *data.Result
+		payments      []data.EscrowPayment
+		expected      int
+		discrepancies int
+	}{
+		{
+			name:     "Nothing is expected before agreement",
+			deal:     deal(data.DealNegotiating),
+			payments: []data.EscrowPayment{},
+		},
+		{
+			name:     "Agreement collateral paid",
+			deal:     deal(data.DealAgreed),
+			payments: agreePayments,
+			expected: 3,
+		},
+		{
+			name:          "Agreement collateral missing",
+			deal:          deal(data.DealAgreed),
+			payments:      agreePayments[:2],
+			expected:      3,
+			discrepancies: 1,
+		},
+		{
+			name:          "Wrong amount",
+			deal:          deal(data.DealAgreed),
+			payments:      append([]data.EscrowPayment{payment(rp, ReasonTimeoutCollateral, DirectionPaidIn, wei(1))}, agreePayments[1:]...),
+			expected:      3,
+			discrepancies: 1,
+		},
+		{
+			name:   "Results collateral and job payment",
+			deal:   deal(data.ResultsAccepted),
+			result: &data.Result{DealID: "deal1", InstructionCount: 5},
+			payments: append([]data.EscrowPayment{
+				payment(rp, ReasonResultsCollateral, DirectionPaidIn, wei(10)),
+				payment(rp, ReasonJobPayment, DirectionPaidOut, wei(5)),
+			}, agreePayments...),
+			expected: 5,
+		},
+		{
+			name: "Milestone paid while the job runs",
+			deal: milestoneDeal(data.DealAgreed),
+			payments: append([]data.EscrowPayment{
+				payment(rp, ReasonJobPayment, DirectionPaidOut, wei(1)),
+			}, agreePayments...),
+			expected: 4,
+		},
+		{
+			name:   "Milestones count towards the job payment",
+			deal:   milestoneDeal(data.ResultsAccepted),
+			result: &data.Result{DealID: "deal1", InstructionCount: 5},
+			payments: append([]data.EscrowPayment{
+				payment(rp, ReasonJobPayment, DirectionPaidOut, wei(1)),
+				payment(rp, ReasonResultsCollateral, DirectionPaidIn, wei(10)),
+				payment(rp, ReasonJobPayment, DirectionPaidOut, wei(4)),
+			}, agreePayments...),
+			expected: 5,
+		},
+		{
+			name:          "Mediation fee missing",
+			deal:          deal(data.ResultsChecked),
+			payments:      agreePayments,
+			expected:      4,
+			discrepancies: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			account := Reconcile(tc.deal, tc.result, tc.payments)
+			assert.Len(t, account.Expected, tc.expected)
+			assert.Len(t, account.Discrepancies, tc.discrepancies)
+			assert.Equal(t, tc.discrepancies == 0, account.Reconciled)
+		})
+	}
+}
@@ -5,21 +5,30 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/lilypad-tech/lilypad/pkg/data"
 	"github.com/lilypad-tech/lilypad/pkg/data/bacalhau"
 	executorlib "github.com/lilypad-tech/lilypad/pkg/executor"
 	"github.com/lilypad-tech/lilypad/pkg/ipfs"
+	modulelib "github.com/lilypad-tech/lilypad/pkg/module"
 	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/rs/zerolog/log"
 )
 
 const RESULTS_DIR = "bacalhau-results"
 
+// how long to wait between checking on a job we are following the logs of
+const JOB_STATE_POLL_INTERVAL = time.Second
+
 type BacalhauExecutorOptions struct {
 	ApiHost string
 	ApiPort string
@@ -133,15 +142,58 @@ func (executor *BacalhauExecutor) RunJob(
 	deal data.DealContainer,
 	module data.Module,
 ) (*executorlib.ExecutorResults, error) {
-	id, err := executor.getJobID(deal, module)
+	return executor.RunJobWithLogs(deal, module, nil)
+}
+
+// run the job passing its output to the handler as it is written
+// a nil handler runs the job without following its output
+func (executor *BacalhauExecutor) RunJobWithLogs(
+	deal data.DealContainer,
+	module data.Module,
+	handler executorlib.LogHandler,
+) (*executorlib.ExecutorResults, error) {
+	variantDigest, err := executorlib.ApplyModuleVariant(executor, &module)
 	if err != nil {
 		return nil, err
 	}
 
-	jobState, err := executor.getJobState(deal.ID, id)
+	// the deal max runtime comes from the job offer or the module default
+	if maxRuntime := deal.Deal.JobOffer.MaxRuntime; maxRuntime > 0 {
+		module.Job.Spec.Timeout = int64(maxRuntime)
+	}
+
+	network, err := modulelib.GetDealNetworkPolicy(deal, module)
 	if err != nil {
 		return nil, err
 	}
+	err = applyNetworkPolicy(&module, network)
+	if err != nil {
+		return nil, err
+	}
+
+	var id string
+	var jobState *bacalhau.JobWithInfo
+	if handler == nil {
+		id, err = executor.getJobID(deal, module, true)
+		if err != nil {
+			return nil, err
+		}
+		jobState, err = executor.getJobState(deal.ID, id)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// we need the job ID to follow the logs so we cannot wait on create
+		id, err = executor.getJobID(deal, module, false)
+		if err != nil {
+			return nil, err
+		}
+		executor.followLogs(id, handler)
+		jobState, err = executor.waitForJob(deal.ID, id)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	if len(jobState.State.Executions) <= 0 {
 		return nil, fmt.Errorf("no executions found for job %s", id)
@@ -165,16 +217,20 @@ func (executor *BacalhauExecutor) RunJob(
 		ResultsDir:       resultsDir,
 		ResultsCID:       jobState.State.Executions[0].PublishedResult.CID,
 		InstructionCount: 1,
+		VariantDigest:    variantDigest,
 	}
 
 	return results, nil
 }
 
 // run the bacalhau job and return the job ID
+// if wait is set we only return once the job has finished
 func (executor *BacalhauExecutor) getJobID(
 	deal data.DealContainer,
 	module data.Module,
+	wait bool,
 ) (string, error) {
+	applyDealSpec(&module, deal.Deal.JobOffer.Spec)
 	// get a JSON string of the job
 	jsonBytes, err := json.Marshal(module.Job)
 	if err != nil {
@@ -190,13 +246,11 @@ func (executor *BacalhauExecutor) getJobID(
 		return "", fmt.Errorf("error writing job JSON %s -> %s", deal.ID, err.Error())
 	}
 
-	runCmd := exec.Command(
-		"bacalhau",
-		"create",
-		"--id-only",
-		"--wait",
-		jobPath,
-	)
+	args := []string{"create", "--id-only"}
+	if wait {
+		args = append(args, "--wait")
+	}
+	runCmd := exec.Command("bacalhau", append(args, jobPath)...)
 	runCmd.Env = executor.bacalhauEnv
 
 	runOutputRaw, err := runCmd.CombinedOutput()
@@ -217,6 +271,39 @@ func (executor *BacalhauExecutor) getJobID(
 	return id, nil
 }
 
+// bacalhau enforces the resources of the job so we give it what
+// the deal is paying for rather than what the module asked for
+func applyDealSpec(module *data.Module, spec data.MachineSpec) {
+	resources := &module.Job.Spec.Resources
+	if spec.CPU > 0 {
+		resources.CPU = fmt.Sprintf("%dm", spec.CPU)
+	}
+	if spec.RAM > 0 {
+		resources.Memory = fmt.Sprintf("%dmb", spec.RAM)
+	}
+	if spec.GPU > 0 {
+		resources.GPU = strconv.Itoa((spec.GPU + 999) / 1000) //nolint:gomnd
+	}
+}
+
+// bacalhau filters http traffic by domain so that is all an allowlist can hold
+func applyNetworkPolicy(module *data.Module, policy data.NetworkPolicy) error {
+	switch policy.Mode {
+	case data.NetworkPolicyFull:
+		module.Job.Spec.Network = bacalhau.NetworkConfig{Type: bacalhau.NetworkFull}
+	case data.NetworkPolicyEgressAllowlist:
+		for _, entry := range policy.Allow {
+			if net.ParseIP(entry) != nil || strings.Contains(entry, "/") {
+				return fmt.Errorf("the bacalhau executor can only allow domains not %s", entry)
+			}
+		}
+		module.Job.Spec.Network = bacalhau.NetworkConfig{Type: bacalhau.NetworkHTTP, Domains: policy.Allow}
+	default:
+		module.Job.Spec.Network = bacalhau.NetworkConfig{Type: bacalhau.NetworkNone}
+	}
+	return nil
+}
+
 func (executor *BacalhauExecutor) getJobState(dealID string, jobID string) (*bacalhau.JobWithInfo, error) {
 	var job bacalhau.JobWithInfo
 
@@ -243,5 +330,83 @@ func (executor *BacalhauExecutor) getJobState(dealID string, jobID string) (*bac
 	return &job, nil
 }
 
+// poll the job until it has finished
+// for jobs we did not create with --wait
+func (executor *BacalhauExecutor) waitForJob(dealID string, jobID string) (*bacalhau.JobWithInfo, error) {
+	for {
+		job, err := executor.getJobState(dealID, jobID)
+		if err != nil {
+			return nil, err
+		}
+		if job.State.State.IsTerminal() {
+			return job, nil
+		}
+		time.Sleep(JOB_STATE_POLL_INTERVAL)
+	}
+}
+
+// pass the output of the job to the handler until the job finishes
+// the logs are a nice to have so a failure here does not fail the job
+func (executor *BacalhauExecutor) followLogs(jobID string, handler executorlib.LogHandler) {
+	logsCmd := exec.Command(
+		"bacalhau",
+		"logs",
+		"--follow",
+		jobID,
+	)
+	logsCmd.Env = executor.bacalhauEnv
+
+	stdout, err := logsCmd.StdoutPipe()
+	if err != nil {
+		log.Warn().Msgf("error following logs for job %s: %s", jobID, err.Error())
+		return
+	}
+	stderr, err := logsCmd.StderrPipe()
+	if err != nil {
+		log.Warn().Msgf("error following logs for job %s: %s", jobID, err.Error())
+		return
+	}
+	err = logsCmd.Start()
+	if err != nil {
+		log.Warn().Msgf("error following logs for job %s: %s", jobID, err.Error())
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		readLogs(stdout, data.DealLogStdout, handler)
+	}()
+	go func() {
+		defer wg.Done()
+		readLogs(stderr, data.DealLogStderr, handler)
+	}()
+	// the pipes must be drained before we wait on the command
+	wg.Wait()
+
+	err = logsCmd.Wait()
+	if err != nil {
+		log.Warn().Msgf("error following logs for job %s: %s", jobID, err.Error())
+	}
+}
+
+// hand whatever has been written to the handler in chunks
+// that are small enough to send on to the solver
+func readLogs(reader io.Reader, stream string, handler executorlib.LogHandler) {
+	buf := make([]byte, data.DEAL_LOG_MAX_CHUNK_SIZE)
+	for {
+		n, err := reader.Read(buf)
+		if n > 0 {
+			chunk := make([]byte, n)
+			copy(chunk, buf[:n])
+			handler(stream, chunk)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
 // Compile-time interface check:
-var _ executorlib.Executor = (*BacalhauExecutor)(nil)
+var _ executorlib.LogStreamingExecutor = (*BacalhauExecutor)(nil)
@@ -0,0 +1,42 @@
+package container
+
+import (
+	"sync"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+// the jobs that have been cancelled so that when the runtime
+// stops them we know it was on purpose
+// they are tracked by container name which comes from the deal id
+type Cancellations struct {
+	mutex sync.Mutex
+	names map[string]bool
+}
+
+func NewCancellations() *Cancellations {
+	return &Cancellations{names: map[string]bool{}}
+}
+
+func (cancellations *Cancellations) Cancel(name string) {
+	cancellations.mutex.Lock()
+	defer cancellations.mutex.Unlock()
+	cancellations.names[name] = true
+}
+
+func (cancellations *Cancellations) IsCancelled(name string) bool {
+	cancellations.mutex.Lock()
+	defer cancellations.mutex.Unlock()
+	return cancellations.names[name]
+}
+
+// once the job has finished there is nothing left to stop
+func (cancellations *Cancellations) Forget(name string) {
+	cancellations.mutex.Lock()
+	defer cancellations.mutex.Unlock()
+	delete(cancellations.names, name)
+}
+
+func GetCancelledError(dealID string) error {
+	return data.NewError(data.ErrJobCancelled, "job %s was cancelled by the job creator", dealID)
+}
@@ -0,0 +1,136 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/system"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	CHECKPOINTS_DIR = "container-checkpoints"
+
+	// how often checkpoints are uploaded for modules that do not say
+	DEFAULT_CHECKPOINT_INTERVAL = 10 * time.Minute
+)
+
+// uploads the checkpoint folder of a running job whenever it changes
+type checkpointer struct {
+	dealID   string
+	path     string
+	interval time.Duration
+	put      func(ctx context.Context, path string) (string, error)
+	handler  func(dealID string, cid string)
+
+	mutex sync.Mutex
+	// what the folder looked like the last time we uploaded it
+	lastState string
+	stop      chan struct{}
+	stopped   chan struct{}
+}
+
+// make the checkpoint folder for the deal and fill it with the
+// checkpoint we are resuming from if there is one
+func (executor *ContainerExecutor) prepareCheckpoint(ctx context.Context, dealID string, checkpoint ContainerCheckpoint) (string, error) {
+	checkpointsDir, err := system.EnsureDataDir(CHECKPOINTS_DIR)
+	if err != nil {
+		return "", fmt.Errorf("error creating a local folder for checkpoints %s -> %s", dealID, err.Error())
+	}
+	checkpointPath := filepath.Join(checkpointsDir, dealID)
+	// a job that is run again starts from what it had
+	_, err = os.Stat(checkpointPath)
+	if err == nil {
+		return checkpointPath, nil
+	}
+	if checkpoint.ResumeFrom == "" {
+		return checkpointPath, os.MkdirAll(checkpointPath, 0755) //nolint:gomnd
+	}
+	err = executor.ipfsClient.Get(ctx, checkpoint.ResumeFrom, checkpointPath)
+	if err != nil {
+		return "", fmt.Errorf("error getting checkpoint %s for %s -> %s", checkpoint.ResumeFrom, dealID, err.Error())
+	}
+	return checkpointPath, nil
+}
+
+func newCheckpointer(dealID string, path string, interval time.Duration, put func(ctx context.Context, path string) (string, error), handler func(dealID string, cid string)) *checkpointer {
+	checkpointer := &checkpointer{
+		dealID:   dealID,
+		path:     path,
+		interval: interval,
+		put:      put,
+		handler:  handler,
+		stop:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	// the checkpoint we resumed from is already known
+	checkpointer.lastState, _ = getFolderState(path)
+	return checkpointer
+}
+
+func (checkpointer *checkpointer) start() {
+	go func() {
+		defer close(checkpointer.stopped)
+		ticker := time.NewTicker(checkpointer.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-checkpointer.stop:
+				return
+			case <-ticker.C:
+				checkpointer.upload()
+			}
+		}
+	}()
+}
+
+// stop uploading on a timer and upload whatever the job left behind
+// a job that failed half way is when the checkpoint matters most
+func (checkpointer *checkpointer) close() {
+	close(checkpointer.stop)
+	<-checkpointer.stopped
+	checkpointer.upload()
+}
+
+func (checkpointer *checkpointer) upload() {
+	checkpointer.mutex.Lock()
+	defer checkpointer.mutex.Unlock()
+	state, err := getFolderState(checkpointer.path)
+	if err != nil {
+		log.Debug().Msgf("error reading checkpoint folder of %s: %s", checkpointer.dealID, err.Error())
+		return
+	}
+	if state == "" || state == checkpointer.lastState {
+		return
+	}
+	cid, err := checkpointer.put(context.Background(), checkpointer.path)
+	if err != nil {
+		log.Error().Msgf("error uploading checkpoint of %s: %s", checkpointer.dealID, err.Error())
+		return
+	}
+	checkpointer.lastState = state
+	if checkpointer.handler != nil {
+		checkpointer.handler(checkpointer.dealID, cid)
+	}
+}
+
+// a cheap summary of the files in a folder that changes when they do
+// empty means there is nothing in the folder yet
+func getFolderState(path string) (string, error) {
+	state := ""
+	err := filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		state += fmt.Sprintf("%s:%d:%d;", filePath, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	return state, err
+}
@@ -0,0 +1,42 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointer(t *testing.T) {
+	dir := t.TempDir()
+	puts := 0
+	recorded := []string{}
+	put := func(ctx context.Context, path string) (string, error) {
+		puts++
+		return fmt.Sprintf("QmCheckpoint%d", puts), nil
+	}
+	checkpointer := newCheckpointer("deal1", dir, time.Hour, put, func(dealID string, cid string) {
+		assert.Equal(t, "deal1", dealID)
+		recorded = append(recorded, cid)
+	})
+
+	// nothing has been written yet
+	checkpointer.upload()
+	assert.Equal(t, 0, puts)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "state"), []byte("step 1"), 0644))
+	checkpointer.upload()
+	checkpointer.upload()
+	assert.Equal(t, []string{"QmCheckpoint1"}, recorded)
+
+	// the job leaves one last checkpoint as it exits
+	checkpointer.start()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "state"), []byte("step 2 of 2"), 0644))
+	checkpointer.close()
+	assert.Equal(t, []string{"QmCheckpoint1", "QmCheckpoint2"}, recorded)
+}
@@ -0,0 +1,506 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	executorlib "github.com/lilypad-tech/lilypad/pkg/executor"
+	"github.com/lilypad-tech/lilypad/pkg/ipfs"
+	modulelib "github.com/lilypad-tech/lilypad/pkg/module"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	RESULTS_DIR = "container-results"
+	INPUTS_DIR  = "container-inputs"
+
+	RUNTIME_DOCKER = "docker"
+	RUNTIME_PODMAN = "podman"
+)
+
+type ContainerExecutorOptions struct {
+	// docker or podman, they take the same arguments for what we need
+	Runtime string
+	Sandbox SandboxOptions
+	// how often checkpoints are uploaded for modules that do not say
+	CheckpointInterval time.Duration
+	// how jobs with a network allowlist are kept to it
+	Egress EgressOptions
+	// the network services without network access are attached to
+	// so we can reach them, see ServiceOptions
+	Service ServiceOptions
+	// run without IPFS and leave the results in the results folder
+	// for lilypad module run-local, inputs and checkpoints cannot come from IPFS
+	LocalOnly bool
+}
+
+// runs jobs straight on the local container runtime without bacalhau
+type ContainerExecutor struct {
+	Options    ContainerExecutorOptions
+	ipfsClient *ipfs.Client
+	gpus       *gpuAllocator
+	cancelled  *Cancellations
+	// nil unless egress is configured
+	egress *egressProxy
+	// told about each checkpoint we upload
+	checkpointHandler executorlib.CheckpointHandler
+	services          *runningServices
+}
+
+func NewContainerExecutor(options ContainerExecutorOptions, ipfsClient *ipfs.Client) (*ContainerExecutor, error) {
+	if options.Runtime != RUNTIME_DOCKER && options.Runtime != RUNTIME_PODMAN {
+		return nil, fmt.Errorf("unknown container runtime %s", options.Runtime)
+	}
+	executor := &ContainerExecutor{
+		Options:    options,
+		ipfsClient: ipfsClient,
+		gpus:       newGPUAllocator(),
+		cancelled:  NewCancellations(),
+		services:   newRunningServices(),
+	}
+	if options.Egress.Enabled() {
+		egress, err := newEgressProxy(options.Egress)
+		if err != nil {
+			return nil, err
+		}
+		executor.egress = egress
+	}
+	return executor, nil
+}
+
+func (executor *ContainerExecutor) Id() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s-%s", executor.Options.Runtime, hostname), nil
+}
+
+func (executor *ContainerExecutor) IsAvailable() (bool, error) {
+	output, err := exec.Command(executor.Options.Runtime, "version").CombinedOutput()
+	if err != nil {
+		return false, fmt.Errorf("%s is not currently available. Please ensure that it is installed and running, then try again. %s, %s", executor.Options.Runtime, err.Error(), output)
+	}
+	return true, nil
+}
+
+// the runtime knows the cores and memory of the machine but not its GPUs
+// so providers with GPUs should use OFFER_AUTODETECT to offer them
+func (executor *ContainerExecutor) GetMachineSpecs() ([]data.MachineSpec, error) {
+	format := "{{.NCPU}} {{.MemTotal}}"
+	if executor.Options.Runtime == RUNTIME_PODMAN {
+		format = "{{.Host.CPUs}} {{.Host.MemTotal}}"
+	}
+	output, err := exec.Command(executor.Options.Runtime, "info", "--format", format).Output()
+	if err != nil {
+		return nil, fmt.Errorf("error getting %s info: %s", executor.Options.Runtime, err.Error())
+	}
+	fields := strings.Fields(string(output))
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("unexpected %s info output %q", executor.Options.Runtime, output)
+	}
+	cpus, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("error parsing cpus %q: %s", fields[0], err.Error())
+	}
+	memory, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing memory %q: %s", fields[1], err.Error())
+	}
+	return []data.MachineSpec{{
+		CPU:  cpus * 1000,               //nolint:gomnd
+		RAM:  int(memory / 1024 / 1024), //nolint:gomnd
+		GPUs: []data.GPUSpec{},
+	}}, nil
+}
+
+// removing the container stops it and the run sees that it was cancelled
+func (executor *ContainerExecutor) CancelJob(dealID string) error {
+	name := GetContainerName(dealID)
+	executor.cancelled.Cancel(name)
+	output, err := exec.Command(executor.Options.Runtime, "rm", "--force", name).CombinedOutput()
+	if err != nil && !strings.Contains(strings.ToLower(string(output)), "no such container") {
+		return fmt.Errorf("error stopping container %s: %s, %s", name, err.Error(), output)
+	}
+	return nil
+}
+
+func (executor *ContainerExecutor) SetCheckpointHandler(handler executorlib.CheckpointHandler) {
+	executor.checkpointHandler = handler
+}
+
+func (executor *ContainerExecutor) RunJob(
+	deal data.DealContainer,
+	module data.Module,
+) (*executorlib.ExecutorResults, error) {
+	return executor.RunJobWithLogs(deal, module, nil)
+}
+
+func (executor *ContainerExecutor) RunJobWithLogs(
+	deal data.DealContainer,
+	module data.Module,
+	handler executorlib.LogHandler,
+) (*executorlib.ExecutorResults, error) {
+	if executor.ipfsClient == nil && !executor.Options.LocalOnly {
+		return nil, fmt.Errorf("an IPFS node is needed to publish the results of container jobs")
+	}
+	variantDigest, err := executorlib.ApplyModuleVariant(executor, &module)
+	if err != nil {
+		return nil, err
+	}
+	job, err := NewContainerJob(deal, module)
+	if err != nil {
+		return nil, err
+	}
+	moduleID, _ := data.GetModuleID(deal.Deal.JobOffer.Module)
+	err = ApplySandbox(&job, executor.Options.Sandbox, moduleID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Network && len(job.NetworkAllow) > 0 {
+		if executor.egress == nil {
+			return nil, fmt.Errorf("job %s has a network allowlist but no egress network and proxy are configured", deal.ID)
+		}
+		proxyURL, release, err := executor.egress.add(job.Name, job.NetworkAllow)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+		applyEgress(&job, executor.Options.Egress, proxyURL)
+	}
+	if job.ServicePort > 0 {
+		err = applyService(&job, executor.Options.Service)
+		if err != nil {
+			return nil, err
+		}
+		executor.services.add(deal.ID, job.ServicePort)
+		defer executor.services.remove(deal.ID)
+	}
+	job.GPUDevices, err = executor.gpus.take(job.Name, job.GPU)
+	if err != nil {
+		return nil, err
+	}
+	defer executor.gpus.release(job.Name)
+
+	ctx := context.Background()
+	resultsDir, err := system.EnsureDataDir(filepath.Join(RESULTS_DIR, deal.ID))
+	if err != nil {
+		return nil, fmt.Errorf("error creating a local folder of results %s -> %s", deal.ID, err.Error())
+	}
+	volumes, err := executor.prepareVolumes(ctx, deal.ID, job, resultsDir)
+	if err != nil {
+		return nil, err
+	}
+	if job.Checkpoint != nil {
+		checkpointPath, err := executor.prepareCheckpoint(ctx, deal.ID, *job.Checkpoint)
+		if err != nil {
+			return nil, err
+		}
+		volumes = append(volumes, fmt.Sprintf("%s:%s", checkpointPath, job.Checkpoint.Path))
+		interval := job.Checkpoint.Interval
+		if interval == 0 {
+			interval = executor.Options.CheckpointInterval
+		}
+		if interval == 0 {
+			interval = DEFAULT_CHECKPOINT_INTERVAL
+		}
+		// local runs leave the checkpoints in the folder
+		if executor.ipfsClient != nil {
+			checkpointer := newCheckpointer(deal.ID, checkpointPath, interval, executor.ipfsClient.Put, executor.checkpointHandler)
+			checkpointer.start()
+			defer checkpointer.close()
+		}
+	}
+
+	defer executor.cancelled.Forget(job.Name)
+	if executor.cancelled.IsCancelled(job.Name) {
+		return nil, GetCancelledError(deal.ID)
+	}
+	meter := newMeter(executor.Options.Runtime, job.Name, job.GPU)
+	meter.start()
+	exitCode, oomKilled, err := executor.run(ctx, job, volumes, resultsDir, handler)
+	metering := meter.close()
+	if executor.cancelled.IsCancelled(job.Name) {
+		return nil, GetCancelledError(deal.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = system.WriteFile(filepath.Join(resultsDir, "exitCode"), []byte(strconv.Itoa(exitCode)))
+	if err != nil {
+		return nil, fmt.Errorf("error creating exitCode file %s -> %s", deal.ID, err.Error())
+	}
+	manifest, err := modulelib.CheckModuleResult(module, resultsDir, exitCode)
+	if err != nil {
+		return nil, err
+	}
+	if oomKilled {
+		return nil, &executorlib.ResourceLimitError{
+			DealID:   deal.ID,
+			Resource: executorlib.RESOURCE_MEMORY,
+			Limit:    fmt.Sprintf("%dMB", job.Memory/1024/1024), //nolint:gomnd
+		}
+	}
+	if exitCode != 0 {
+		return nil, modulelib.GetExitCodeError(module, deal.ID, exitCode, manifest)
+	}
+
+	if executor.ipfsClient == nil {
+		return &executorlib.ExecutorResults{
+			ResultsDir:       resultsDir,
+			InstructionCount: 1,
+			VariantDigest:    variantDigest,
+			Metering:         metering,
+		}, nil
+	}
+	resultsCID, err := executor.ipfsClient.Put(ctx, resultsDir)
+	if err != nil {
+		return nil, fmt.Errorf("error adding results to IPFS %s -> %s", deal.ID, err.Error())
+	}
+
+	return &executorlib.ExecutorResults{
+		ResultsDir:       resultsDir,
+		ResultsCID:       resultsCID,
+		InstructionCount: 1,
+		VariantDigest:    variantDigest,
+		Metering:         metering,
+	}, nil
+}
+
+func (executor *ContainerExecutor) GetMeteringMethod() string {
+	return data.MeteringCgroup
+}
+
+// fetch the inputs and make the output folders and return them as
+// host:container volumes
+func (executor *ContainerExecutor) prepareVolumes(ctx context.Context, dealID string, job ContainerJob, resultsDir string) ([]string, error) {
+	volumes := []string{}
+	if len(job.Inputs) > 0 {
+		inputsDir, err := system.EnsureDataDir(filepath.Join(INPUTS_DIR, dealID))
+		if err != nil {
+			return nil, fmt.Errorf("error creating a local folder for inputs %s -> %s", dealID, err.Error())
+		}
+		for index, input := range job.Inputs {
+			inputPath := filepath.Join(inputsDir, strconv.Itoa(index))
+			if input.CID != "" && executor.ipfsClient == nil {
+				return nil, fmt.Errorf("an IPFS node is needed to get input %s", input.CID)
+			}
+			if input.CID != "" {
+				err = executor.ipfsClient.Get(ctx, input.CID, inputPath)
+			} else {
+				err = DownloadInput(ctx, input.URL, inputPath)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("error getting input for %s -> %s", input.Path, err.Error())
+			}
+			volumes = append(volumes, fmt.Sprintf("%s:%s:ro", inputPath, input.Path))
+		}
+	}
+	for _, output := range job.Outputs {
+		outputPath := filepath.Join(resultsDir, output.Name)
+		err := os.MkdirAll(outputPath, 0755) //nolint:gomnd
+		if err != nil {
+			return nil, fmt.Errorf("error creating output folder %s -> %s", output.Name, err.Error())
+		}
+		volumes = append(volumes, fmt.Sprintf("%s:%s", outputPath, output.Path))
+	}
+	return volumes, nil
+}
+
+// the wasm executor fetches its inputs the same way
+func DownloadInput(ctx context.Context, url string, path string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("downloading %s returned %s", url, resp.Status)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(file, resp.Body)
+	return err
+}
+
+// run the container and wait for it, returning its exit code and
+// whether the kernel killed it for going over its memory limit
+// the output goes to the results folder and the handler if there is one
+func (executor *ContainerExecutor) run(ctx context.Context, job ContainerJob, volumes []string, resultsDir string, handler executorlib.LogHandler) (int, bool, error) {
+	if job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, job.Timeout)
+		defer cancel()
+	}
+	// stopping the client does not stop the container so we always remove it
+	defer executor.remove(job.Name)
+
+	stdoutFile, err := os.Create(filepath.Join(resultsDir, "stdout"))
+	if err != nil {
+		return 0, false, err
+	}
+	defer stdoutFile.Close()
+	stderrFile, err := os.Create(filepath.Join(resultsDir, "stderr"))
+	if err != nil {
+		return 0, false, err
+	}
+	defer stderrFile.Close()
+
+	cmd := exec.CommandContext(ctx, executor.Options.Runtime, GetRunArgs(executor.Options.Runtime, job, volumes)...)
+	cmd.Stdout = NewLogWriter(stdoutFile, data.DealLogStdout, handler)
+	cmd.Stderr = NewLogWriter(stderrFile, data.DealLogStderr, handler)
+	log.Debug().Msgf("running %s %s", executor.Options.Runtime, strings.Join(cmd.Args[1:], " "))
+
+	err = cmd.Run()
+	// a service runs until its time is up
+	if ctx.Err() == context.DeadlineExceeded && job.ServicePort > 0 {
+		return 0, false, nil
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return 0, false, data.NewError(data.ErrTimeout, "job %s did not finish within %s", job.Name, job.Timeout)
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), executor.oomKilled(job.Name), nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("error running %s: %s", executor.Options.Runtime, err.Error())
+	}
+	return 0, false, nil
+}
+
+// the container is still there because we do not run it with --rm
+func (executor *ContainerExecutor) oomKilled(name string) bool {
+	output, err := exec.Command(executor.Options.Runtime, "inspect", "--format", "{{.State.OOMKilled}}", name).Output()
+	if err != nil {
+		log.Debug().Msgf("error inspecting container %s: %s", name, err.Error())
+		return false
+	}
+	return strings.TrimSpace(string(output)) == "true"
+}
+
+func (executor *ContainerExecutor) remove(name string) {
+	output, err := exec.Command(executor.Options.Runtime, "rm", "--force", name).CombinedOutput()
+	if err != nil && !strings.Contains(strings.ToLower(string(output)), "no such container") {
+		log.Debug().Msgf("error removing container %s: %s, %s", name, err.Error(), output)
+	}
+}
+
+// the arguments to run a job with docker or podman
+// the container is left behind when it exits so we can see why it stopped
+func GetRunArgs(runtime string, job ContainerJob, volumes []string) []string {
+	args := []string{"run", "--name", job.Name, "--pull=missing"}
+	if job.Runtime != "" {
+		args = append(args, "--runtime="+job.Runtime)
+	}
+	if job.Sandbox != "" {
+		args = append(args,
+			"--cap-drop=ALL",
+			"--security-opt=no-new-privileges",
+			fmt.Sprintf("--pids-limit=%d", SANDBOX_PIDS_LIMIT),
+		)
+	}
+	if !job.Network {
+		args = append(args, "--network=none")
+	} else if job.NetworkName != "" {
+		args = append(args, "--network="+job.NetworkName)
+	}
+	if job.CPU > 0 {
+		// the quota caps the job and the shares split the cores fairly
+		// between jobs when the machine is busy
+		args = append(args, fmt.Sprintf("--cpus=%g", job.CPU), fmt.Sprintf("--cpu-shares=%d", int(job.CPU*1024))) //nolint:gomnd
+	}
+	if job.Memory > 0 {
+		// no swap on top or the limit would not hold
+		args = append(args, fmt.Sprintf("--memory=%db", job.Memory), fmt.Sprintf("--memory-swap=%db", job.Memory))
+	}
+	if job.GPU > 0 {
+		args = append(args, getGPUArgs(runtime, job)...)
+	}
+	if job.WorkingDirectory != "" {
+		args = append(args, "--workdir="+job.WorkingDirectory)
+	}
+	for _, env := range job.Env {
+		args = append(args, "--env="+env)
+	}
+	for _, volume := range volumes {
+		args = append(args, "--volume="+volume)
+	}
+	command := job.Parameters
+	if len(job.Entrypoint) > 0 {
+		args = append(args, "--entrypoint="+job.Entrypoint[0])
+		command = append(append([]string{}, job.Entrypoint[1:]...), job.Parameters...)
+	}
+	args = append(args, job.Image)
+	return append(args, command...)
+}
+
+func getGPUArgs(runtime string, job ContainerJob) []string {
+	if runtime == RUNTIME_PODMAN {
+		// podman reaches nvidia cards through CDI
+		if len(job.GPUDevices) == 0 {
+			return []string{"--device=nvidia.com/gpu=all"}
+		}
+		args := []string{}
+		for _, device := range job.GPUDevices {
+			args = append(args, "--device=nvidia.com/gpu="+device)
+		}
+		return args
+	}
+	if len(job.GPUDevices) == 0 {
+		return []string{fmt.Sprintf("--gpus=%d", job.GPU)}
+	}
+	return []string{fmt.Sprintf(`--gpus="device=%s"`, strings.Join(job.GPUDevices, ","))}
+}
+
+// writes output to a file and passes it on to the log handler in chunks
+// that are small enough to send on to the solver
+type logWriter struct {
+	file    io.Writer
+	stream  string
+	handler executorlib.LogHandler
+}
+
+func NewLogWriter(file io.Writer, stream string, handler executorlib.LogHandler) io.Writer {
+	return &logWriter{file: file, stream: stream, handler: handler}
+}
+
+func (writer *logWriter) Write(p []byte) (int, error) {
+	n, err := writer.file.Write(p)
+	if writer.handler != nil {
+		for start := 0; start < len(p); start += data.DEAL_LOG_MAX_CHUNK_SIZE {
+			end := start + data.DEAL_LOG_MAX_CHUNK_SIZE
+			if end > len(p) {
+				end = len(p)
+			}
+			chunk := make([]byte, end-start)
+			copy(chunk, p[start:end])
+			writer.handler(writer.stream, chunk)
+		}
+	}
+	return n, err
+}
+
+// Compile-time interface checks:
+var _ executorlib.LogStreamingExecutor = (*ContainerExecutor)(nil)
+var _ executorlib.CheckpointingExecutor = (*ContainerExecutor)(nil)
+var _ executorlib.CancellableExecutor = (*ContainerExecutor)(nil)
+var _ executorlib.MeteringExecutor = (*ContainerExecutor)(nil)
@@ -0,0 +1,199 @@
+package container
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/rs/zerolog/log"
+)
+
+// how long the proxy waits to connect to a destination
+const EGRESS_DIAL_TIMEOUT = 30 * time.Second
+
+// jobs with a network allowlist are attached to a network that can only
+// reach our proxy and the proxy only connects them to what they are allowed
+type EgressOptions struct {
+	// the container network the jobs are attached to
+	// e.g. one made with docker network create --internal
+	Network string
+	// the address the proxy listens on e.g. 0.0.0.0:3128
+	ProxyAddress string
+	// the proxy as the jobs on that network reach it e.g. http://lilypad-rp:3128
+	ProxyURL string
+}
+
+func (options EgressOptions) Enabled() bool {
+	return options.Network != "" && options.ProxyAddress != "" && options.ProxyURL != ""
+}
+
+// an http proxy that knows which job is asking from the credentials
+// we give each one in its proxy settings
+type egressProxy struct {
+	options EgressOptions
+	mutex   sync.RWMutex
+	// token -> the destinations that job can reach
+	allow map[string][]string
+}
+
+func newEgressProxy(options EgressOptions) (*egressProxy, error) {
+	proxyURL, err := url.Parse(options.ProxyURL)
+	if err != nil || proxyURL.Host == "" {
+		return nil, fmt.Errorf("egress proxy url %q is not valid", options.ProxyURL)
+	}
+	listener, err := net.Listen("tcp", options.ProxyAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error starting egress proxy: %s", err.Error())
+	}
+	proxy := &egressProxy{
+		options: options,
+		allow:   map[string][]string{},
+	}
+	go func() {
+		err := http.Serve(listener, proxy)
+		log.Error().Msgf("egress proxy stopped: %s", err)
+	}()
+	return proxy, nil
+}
+
+// give the job its own credentials and return the proxy url it should use
+// the returned func stops the job reaching anything
+func (proxy *egressProxy) add(name string, allow []string) (string, func(), error) {
+	bytes := make([]byte, 16) //nolint:gomnd
+	_, err := rand.Read(bytes)
+	if err != nil {
+		return "", nil, err
+	}
+	token := hex.EncodeToString(bytes)
+	proxy.mutex.Lock()
+	proxy.allow[token] = allow
+	proxy.mutex.Unlock()
+
+	proxyURL, _ := url.Parse(proxy.options.ProxyURL)
+	proxyURL.User = url.UserPassword(name, token)
+	return proxyURL.String(), func() {
+		proxy.mutex.Lock()
+		defer proxy.mutex.Unlock()
+		delete(proxy.allow, token)
+	}, nil
+}
+
+func (proxy *egressProxy) getAllow(req *http.Request) ([]string, bool) {
+	header := req.Header.Get("Proxy-Authorization")
+	encoded, ok := strings.CutPrefix(header, "Basic ")
+	if !ok {
+		return nil, false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, false
+	}
+	_, token, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return nil, false
+	}
+	proxy.mutex.RLock()
+	defer proxy.mutex.RUnlock()
+	allow, ok := proxy.allow[token]
+	return allow, ok
+}
+
+func (proxy *egressProxy) ServeHTTP(res http.ResponseWriter, req *http.Request) {
+	allow, ok := proxy.getAllow(req)
+	if !ok {
+		res.Header().Set("Proxy-Authenticate", `Basic realm="lilypad"`)
+		http.Error(res, "proxy credentials are not valid", http.StatusProxyAuthRequired)
+		return
+	}
+	host := req.Host
+	if req.Method != http.MethodConnect {
+		host = req.URL.Host
+	}
+	hostname, _, err := net.SplitHostPort(host)
+	if err != nil {
+		hostname = host
+	}
+	if !data.NetworkDestinationAllowed(allow, hostname) {
+		http.Error(res, fmt.Sprintf("%s is not in the network allowlist of this job", hostname), http.StatusForbidden)
+		return
+	}
+	if req.Method == http.MethodConnect {
+		proxy.tunnel(res, host)
+		return
+	}
+	proxy.forward(res, req)
+}
+
+func (proxy *egressProxy) tunnel(res http.ResponseWriter, host string) {
+	destination, err := net.DialTimeout("tcp", host, EGRESS_DIAL_TIMEOUT)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusBadGateway)
+		return
+	}
+	hijacker, ok := res.(http.Hijacker)
+	if !ok {
+		destination.Close()
+		http.Error(res, "the connection cannot be tunnelled", http.StatusInternalServerError)
+		return
+	}
+	source, _, err := hijacker.Hijack()
+	if err != nil {
+		destination.Close()
+		return
+	}
+	_, err = source.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+	if err != nil {
+		source.Close()
+		destination.Close()
+		return
+	}
+	go pipe(destination, source)
+	go pipe(source, destination)
+}
+
+func pipe(to net.Conn, from net.Conn) {
+	defer to.Close()
+	defer from.Close()
+	_, _ = io.Copy(to, from)
+}
+
+func (proxy *egressProxy) forward(res http.ResponseWriter, req *http.Request) {
+	outgoing := req.Clone(req.Context())
+	outgoing.RequestURI = ""
+	outgoing.Header.Del("Proxy-Authorization")
+	outgoing.Header.Del("Proxy-Connection")
+	transport := &http.Transport{
+		DialContext: (&net.Dialer{Timeout: EGRESS_DIAL_TIMEOUT}).DialContext,
+	}
+	defer transport.CloseIdleConnections()
+	response, err := transport.RoundTrip(outgoing)
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer response.Body.Close()
+	for name, values := range response.Header {
+		for _, value := range values {
+			res.Header().Add(name, value)
+		}
+	}
+	res.WriteHeader(response.StatusCode)
+	_, _ = io.Copy(res, response.Body)
+}
+
+// point the job at the egress network and proxy
+func applyEgress(job *ContainerJob, options EgressOptions, proxyURL string) {
+	job.NetworkName = options.Network
+	for _, name := range []string{"HTTP_PROXY", "HTTPS_PROXY", "http_proxy", "https_proxy"} {
+		job.Env = append(job.Env, fmt.Sprintf("%s=%s", name, proxyURL))
+	}
+}
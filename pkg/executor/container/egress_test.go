@@ -0,0 +1,43 @@
+package container
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEgressProxy(t *testing.T) {
+	proxy := &egressProxy{
+		options: EgressOptions{ProxyURL: "http://lilypad-rp:3128"},
+		allow:   map[string][]string{},
+	}
+	proxyURL, release, err := proxy.add("lilypad-deal1", []string{"huggingface.co"})
+	require.NoError(t, err)
+	parsed, err := url.Parse(proxyURL)
+	require.NoError(t, err)
+	token, _ := parsed.User.Password()
+
+	request := func(target string, user *url.Userinfo) int {
+		req := httptest.NewRequest(http.MethodConnect, "http://"+target, nil)
+		req.Host = target
+		if user != nil {
+			password, _ := user.Password()
+			req.SetBasicAuth(user.Username(), password)
+			req.Header.Set("Proxy-Authorization", req.Header.Get("Authorization"))
+		}
+		res := httptest.NewRecorder()
+		proxy.ServeHTTP(res, req)
+		return res.Code
+	}
+
+	assert.Equal(t, http.StatusProxyAuthRequired, request("huggingface.co:443", nil))
+	assert.Equal(t, http.StatusProxyAuthRequired, request("huggingface.co:443", url.UserPassword("lilypad-deal1", "wrong")))
+	assert.Equal(t, http.StatusForbidden, request("example.com:443", url.UserPassword("lilypad-deal1", token)))
+
+	release()
+	assert.Equal(t, http.StatusProxyAuthRequired, request("example.com:443", url.UserPassword("lilypad-deal1", token)))
+}
@@ -0,0 +1,78 @@
+package container
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// hands each job its own GPUs so jobs running side by side
+// never share a card that the deal says is theirs
+type gpuAllocator struct {
+	mutex sync.Mutex
+	// nil until we have asked nvidia-smi
+	devices []string
+	// device -> the job using it
+	used map[string]string
+	// so that tests do not need nvidia-smi
+	listDevices func() ([]string, error)
+}
+
+func newGPUAllocator() *gpuAllocator {
+	return &gpuAllocator{
+		used:        map[string]string{},
+		listDevices: listNvidiaDevices,
+	}
+}
+
+func listNvidiaDevices() ([]string, error) {
+	output, err := exec.Command("nvidia-smi", "--query-gpu=index", "--format=csv,noheader").Output()
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(output)), nil
+}
+
+// take count free GPUs for the job
+// nil means we could not list the GPUs and the runtime picks them instead
+func (allocator *gpuAllocator) take(jobName string, count int) ([]string, error) {
+	if count <= 0 {
+		return nil, nil
+	}
+	allocator.mutex.Lock()
+	defer allocator.mutex.Unlock()
+	if allocator.devices == nil {
+		devices, err := allocator.listDevices()
+		if err != nil {
+			return nil, nil
+		}
+		allocator.devices = devices
+	}
+	taken := []string{}
+	for _, device := range allocator.devices {
+		if len(taken) == count {
+			break
+		}
+		if _, ok := allocator.used[device]; !ok {
+			taken = append(taken, device)
+		}
+	}
+	if len(taken) < count {
+		return nil, fmt.Errorf("job %s needs %d GPUs but only %d of %d are free", jobName, count, len(taken), len(allocator.devices))
+	}
+	for _, device := range taken {
+		allocator.used[device] = jobName
+	}
+	return taken, nil
+}
+
+func (allocator *gpuAllocator) release(jobName string) {
+	allocator.mutex.Lock()
+	defer allocator.mutex.Unlock()
+	for device, user := range allocator.used {
+		if user == jobName {
+			delete(allocator.used, device)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+package container
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/data/bacalhau"
+	modulelib "github.com/lilypad-tech/lilypad/pkg/module"
+)
+
+// the parts of a module job spec a container runtime needs to run it
+// modules are written for bacalhau so this is read from the docker
+// engine spec and the resources, network and volumes around it
+type ContainerJob struct {
+	// a name for the container that is unique to the deal
+	Name             string
+	Image            string
+	Entrypoint       []string
+	Parameters       []string
+	Env              []string
+	WorkingDirectory string
+	// cores, zero means no limit
+	CPU float64
+	// bytes, zero means no limit
+	Memory int64
+	GPU    int
+	// the host GPUs the job is given, empty leaves the choice to the runtime
+	GPUDevices []string
+	// jobs without network access are run with networking turned off
+	Network bool
+	// the destinations a job with network access is limited to
+	// empty means it can reach anything
+	NetworkAllow []string
+	// the runtime network the job is attached to, empty for the default
+	NetworkName string
+	// zero means the job can run until it finishes
+	Timeout time.Duration
+	Inputs  []ContainerInput
+	Outputs []ContainerOutput
+	// set for modules that write checkpoints
+	Checkpoint *ContainerCheckpoint
+	// set when the job runs in a sandbox, see ApplySandbox
+	Sandbox string
+	// the OCI runtime or kubernetes runtime class for the sandbox
+	Runtime string
+	// set for service deals, the port the module takes requests on
+	// and the timeout is how long the service runs rather than a limit
+	ServicePort int
+}
+
+// data that is put in the container before the job runs
+type ContainerInput struct {
+	// one of these is set
+	CID string
+	URL string
+	// where it is mounted in the container
+	Path string
+}
+
+// the folder a job keeps its checkpoints in
+type ContainerCheckpoint struct {
+	// where it is mounted in the container
+	Path string
+	// zero means the executor default
+	Interval time.Duration
+	// the checkpoint the folder starts with
+	ResumeFrom string
+}
+
+// a folder the job writes its results to
+type ContainerOutput struct {
+	// the name of the folder in the results
+	Name string
+	// where it is mounted in the container
+	Path string
+}
+
+func NewContainerJob(deal data.DealContainer, module data.Module) (ContainerJob, error) {
+	spec := module.Job.Spec
+	docker, err := GetDockerSpec(spec)
+	if err != nil {
+		return ContainerJob{}, err
+	}
+	if docker.Image == "" {
+		return ContainerJob{}, fmt.Errorf("module has no image to run")
+	}
+	network, err := modulelib.GetDealNetworkPolicy(deal, module)
+	if err != nil {
+		return ContainerJob{}, err
+	}
+	job := ContainerJob{
+		Name:             GetContainerName(deal.ID),
+		Image:            docker.Image,
+		Entrypoint:       docker.Entrypoint,
+		Parameters:       docker.Parameters,
+		Env:              docker.EnvironmentVariables,
+		WorkingDirectory: docker.WorkingDirectory,
+		Network:          network.Mode != data.NetworkPolicyNone,
+		NetworkAllow:     network.Allow,
+		Timeout:          time.Duration(spec.Timeout) * time.Second,
+		Inputs:           []ContainerInput{},
+		Outputs:          []ContainerOutput{},
+	}
+	// the deal max runtime comes from the job offer or the module default
+	if maxRuntime := deal.Deal.JobOffer.MaxRuntime; maxRuntime > 0 {
+		job.Timeout = time.Duration(maxRuntime) * time.Second
+	}
+	if serve := deal.Deal.JobOffer.Serve; serve != nil {
+		job.ServicePort = serve.Port
+		job.Timeout = time.Duration(serve.Duration) * time.Second
+	}
+
+	job.CPU, err = ParseCPU(spec.Resources.CPU)
+	if err != nil {
+		return ContainerJob{}, err
+	}
+	job.Memory, err = ParseMemory(spec.Resources.Memory)
+	if err != nil {
+		return ContainerJob{}, err
+	}
+	if spec.Resources.GPU != "" {
+		job.GPU, err = strconv.Atoi(spec.Resources.GPU)
+		if err != nil {
+			return ContainerJob{}, fmt.Errorf("error parsing gpu %q: %s", spec.Resources.GPU, err.Error())
+		}
+	}
+	applyDealSpec(&job, deal.Deal.JobOffer.Spec)
+
+	for _, input := range spec.Inputs {
+		switch input.StorageSource {
+		case bacalhau.StorageSourceIPFS:
+			job.Inputs = append(job.Inputs, ContainerInput{CID: input.CID, Path: input.Path})
+		case bacalhau.StorageSourceURLDownload:
+			job.Inputs = append(job.Inputs, ContainerInput{URL: input.URL, Path: input.Path})
+		default:
+			return ContainerJob{}, fmt.Errorf("input %s from %s is not supported by container executors", input.Name, input.StorageSource.String())
+		}
+	}
+	for _, output := range spec.Outputs {
+		if output.Name == "" || output.Path == "" {
+			return ContainerJob{}, fmt.Errorf("outputs need a name and a path")
+		}
+		job.Outputs = append(job.Outputs, ContainerOutput{Name: output.Name, Path: output.Path})
+	}
+
+	resumeFrom := deal.Deal.JobOffer.ResumeFrom
+	if module.Checkpoint == nil {
+		if resumeFrom != "" {
+			return ContainerJob{}, fmt.Errorf("module does not write checkpoints so it cannot resume from %s", resumeFrom)
+		}
+		return job, nil
+	}
+	if module.Checkpoint.Path == "" {
+		return ContainerJob{}, fmt.Errorf("module checkpoints need a path")
+	}
+	job.Checkpoint = &ContainerCheckpoint{
+		Path:       module.Checkpoint.Path,
+		Interval:   time.Duration(module.Checkpoint.Interval) * time.Second,
+		ResumeFrom: resumeFrom,
+	}
+	return job, nil
+}
+
+// the job gets what the deal is paying for, no more
+// parts of the spec that are not set leave the module's own resources alone
+func applyDealSpec(job *ContainerJob, spec data.MachineSpec) {
+	if spec.CPU > 0 {
+		job.CPU = float64(spec.CPU) / 1000 //nolint:gomnd
+	}
+	if spec.RAM > 0 {
+		job.Memory = int64(spec.RAM) * 1024 * 1024 //nolint:gomnd
+	}
+	if spec.GPU > 0 {
+		// milli-GPUs, a part of a card is still a whole card to a container
+		job.GPU = (spec.GPU + 999) / 1000 //nolint:gomnd
+	}
+}
+
+// the docker engine params can come from the newer engine spec
+// or the deprecated docker field that most modules still use
+func GetDockerSpec(spec bacalhau.Spec) (bacalhau.JobSpecDocker, error) {
+	if spec.EngineSpec.Type == "" {
+		if spec.Engine != bacalhau.EngineDocker && spec.Engine != 0 {
+			return bacalhau.JobSpecDocker{}, fmt.Errorf("engine %s is not supported by container executors", spec.Engine.String())
+		}
+		return spec.Docker, nil
+	}
+	if !strings.EqualFold(spec.EngineSpec.Type, "docker") {
+		return bacalhau.JobSpecDocker{}, fmt.Errorf("engine %s is not supported by container executors", spec.EngineSpec.Type)
+	}
+	var docker bacalhau.JobSpecDocker
+	params, err := json.Marshal(spec.EngineSpec.Params)
+	if err != nil {
+		return docker, err
+	}
+	err = json.Unmarshal(params, &docker)
+	if err != nil {
+		return docker, fmt.Errorf("error reading docker engine params: %s", err.Error())
+	}
+	return docker, nil
+}
+
+func GetContainerName(dealID string) string {
+	name := "lilypad-" + strings.ToLower(dealID)
+	// kubernetes names are the strictest we deal with
+	if len(name) > 63 { //nolint:gomnd
+		name = name[:63]
+	}
+	return strings.TrimRight(name, "-")
+}
+
+// cpu is given the kubernetes way e.g. 2, 0.5 or 500m
+func ParseCPU(cpu string) (float64, error) {
+	cpu = strings.TrimSpace(cpu)
+	if cpu == "" {
+		return 0, nil
+	}
+	if strings.HasSuffix(cpu, "m") {
+		milli, err := strconv.ParseFloat(strings.TrimSuffix(cpu, "m"), 64)
+		if err != nil {
+			return 0, fmt.Errorf("error parsing cpu %q: %s", cpu, err.Error())
+		}
+		return milli / 1000, nil //nolint:gomnd
+	}
+	cores, err := strconv.ParseFloat(cpu, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing cpu %q: %s", cpu, err.Error())
+	}
+	return cores, nil
+}
+
+var memoryPattern = regexp.MustCompile(`^([0-9.]+)\s*([a-zA-Z]*)$`)
+
+var memoryUnits = map[string]int64{
+	"":  1,
+	"b": 1,
+	"k": 1 << 10, "kb": 1 << 10, "ki": 1 << 10, "kib": 1 << 10,
+	"m": 1 << 20, "mb": 1 << 20, "mi": 1 << 20, "mib": 1 << 20,
+	"g": 1 << 30, "gb": 1 << 30, "gi": 1 << 30, "gib": 1 << 30,
+	"t": 1 << 40, "tb": 1 << 40, "ti": 1 << 40, "tib": 1 << 40,
+}
+
+// memory is given like bacalhau takes it e.g. 8gb, 512mb or 1Gi
+func ParseMemory(memory string) (int64, error) {
+	memory = strings.TrimSpace(memory)
+	if memory == "" {
+		return 0, nil
+	}
+	match := memoryPattern.FindStringSubmatch(memory)
+	if match == nil {
+		return 0, fmt.Errorf("error parsing memory %q", memory)
+	}
+	unit, ok := memoryUnits[strings.ToLower(match[2])]
+	if !ok {
+		return 0, fmt.Errorf("error parsing memory %q: unknown unit %s", memory, match[2])
+	}
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing memory %q: %s", memory, err.Error())
+	}
+	return int64(value * float64(unit)), nil
+}
@@ -0,0 +1,100 @@
+package container
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/data/bacalhau"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewContainerJob(t *testing.T) {
+	module := data.Module{Job: bacalhau.Job{Spec: bacalhau.Spec{
+		EngineSpec: bacalhau.EngineSpec{Type: "docker", Params: map[string]interface{}{
+			"Image":                "example/sdxl:v1",
+			"Entrypoint":           []interface{}{"python", "run.py"},
+			"EnvironmentVariables": []interface{}{"PROMPT=a cat"},
+		}},
+		Resources: bacalhau.ResourceUsageConfig{CPU: "500m", Memory: "8gb", GPU: "1"},
+		Network:   bacalhau.NetworkConfig{Type: bacalhau.NetworkNone},
+		Timeout:   600,
+		Inputs:    []bacalhau.StorageSpec{{StorageSource: bacalhau.StorageSourceIPFS, CID: "QmInput", Path: "/inputs/data"}},
+		Outputs:   []bacalhau.StorageSpec{{Name: "outputs", Path: "/outputs"}},
+	}}}
+	deal := data.DealContainer{ID: "Deal1", Deal: data.Deal{JobOffer: data.JobOffer{MaxRuntime: 120}}}
+
+	job, err := NewContainerJob(deal, module)
+	require.NoError(t, err)
+	assert.Equal(t, ContainerJob{
+		Name:       "lilypad-deal1",
+		Image:      "example/sdxl:v1",
+		Entrypoint: []string{"python", "run.py"},
+		Env:        []string{"PROMPT=a cat"},
+		CPU:        0.5,
+		Memory:     8 << 30,
+		GPU:        1,
+		Network:    false,
+		Timeout:    120 * time.Second,
+		Inputs:     []ContainerInput{{CID: "QmInput", Path: "/inputs/data"}},
+		Outputs:    []ContainerOutput{{Name: "outputs", Path: "/outputs"}},
+	}, job)
+
+	assert.Equal(t, []string{
+		"run", "--name", "lilypad-deal1", "--pull=missing", "--network=none",
+		"--cpus=0.5", "--cpu-shares=512", "--memory=8589934592b", "--memory-swap=8589934592b",
+		"--gpus=1", "--env=PROMPT=a cat",
+		"--volume=/data/0:/inputs/data:ro", "--entrypoint=python", "example/sdxl:v1", "run.py",
+	}, GetRunArgs(RUNTIME_DOCKER, job, []string{"/data/0:/inputs/data:ro"}))
+
+	// the deal spec is what the job gets
+	deal.Deal.JobOffer.Spec = data.MachineSpec{CPU: 2000, RAM: 4096, GPU: 1500}
+	job, err = NewContainerJob(deal, module)
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, job.CPU)
+	assert.Equal(t, int64(4<<30), job.Memory)
+	assert.Equal(t, 2, job.GPU)
+
+	job.GPUDevices = []string{"0", "1"}
+	assert.Equal(t, []string{`--gpus="device=0,1"`}, getGPUArgs(RUNTIME_DOCKER, job))
+	assert.Equal(t, []string{"--device=nvidia.com/gpu=0", "--device=nvidia.com/gpu=1"}, getGPUArgs(RUNTIME_PODMAN, job))
+
+	// wasm modules need an executor that can run them
+	module.Job.Spec.EngineSpec.Type = "wasm"
+	_, err = NewContainerJob(deal, module)
+	assert.Error(t, err)
+}
+
+func TestGPUAllocator(t *testing.T) {
+	allocator := newGPUAllocator()
+	allocator.listDevices = func() ([]string, error) { return []string{"0", "1", "2"}, nil }
+
+	devices, err := allocator.take("job1", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0", "1"}, devices)
+	_, err = allocator.take("job2", 2)
+	assert.Error(t, err)
+	devices, err = allocator.take("job2", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2"}, devices)
+
+	allocator.release("job1")
+	devices, err = allocator.take("job3", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0", "1"}, devices)
+}
+
+func TestParseResources(t *testing.T) {
+	cpu, err := ParseCPU("2")
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, cpu)
+
+	for memory, expected := range map[string]int64{"": 0, "512mb": 512 << 20, "1Gi": 1 << 30, "32930148Ki": 32930148 << 10} {
+		bytes, err := ParseMemory(memory)
+		require.NoError(t, err)
+		assert.Equal(t, expected, bytes, memory)
+	}
+	_, err = ParseMemory("8 parsecs")
+	assert.Error(t, err)
+}
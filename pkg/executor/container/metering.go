@@ -0,0 +1,177 @@
+package container
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	CGROUP_ROOT = "/sys/fs/cgroup"
+	// how often the cgroup of a running job is read
+	// the cgroup goes when the container exits so up to this much is lost
+	METER_INTERVAL = time.Second
+)
+
+// reads the cpu time of a running container from its cgroup
+// gpus are counted as held for as long as the container runs
+type meter struct {
+	runtime string
+	name    string
+	gpus    int
+
+	mutex     sync.Mutex
+	startedAt time.Time
+	cpuTime   uint64
+	// the cpu time was read at least once
+	measured bool
+	cgroup   string
+	stop     chan struct{}
+	stopped  chan struct{}
+}
+
+func newMeter(runtime string, name string, gpus int) *meter {
+	return &meter{
+		runtime: runtime,
+		name:    name,
+		gpus:    gpus,
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+func (meter *meter) start() {
+	meter.startedAt = time.Now()
+	go func() {
+		defer close(meter.stopped)
+		ticker := time.NewTicker(METER_INTERVAL)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-meter.stop:
+				return
+			case <-ticker.C:
+				meter.read()
+			}
+		}
+	}()
+}
+
+// stop reading and return what the job used
+// the job counts as one instruction if the cgroup could never be read
+func (meter *meter) close() data.ResultMetering {
+	close(meter.stop)
+	<-meter.stopped
+	meter.mutex.Lock()
+	defer meter.mutex.Unlock()
+	if !meter.measured {
+		return data.ResultMetering{Method: data.MeteringJob}
+	}
+	return data.ResultMetering{
+		Method:  data.MeteringCgroup,
+		CPUTime: meter.cpuTime,
+		GPUTime: uint64(meter.gpus) * uint64(time.Since(meter.startedAt).Milliseconds()),
+	}
+}
+
+func (meter *meter) read() {
+	if meter.cgroup == "" {
+		cgroup, err := meter.findCgroup()
+		if err != nil {
+			// the container might not have started yet
+			log.Debug().Msgf("error finding the cgroup of %s: %s", meter.name, err.Error())
+			return
+		}
+		meter.cgroup = cgroup
+	}
+	cpuTime, err := readCgroupCPUTime(meter.cgroup)
+	if err != nil {
+		log.Debug().Msgf("error reading the cpu time of %s: %s", meter.name, err.Error())
+		return
+	}
+	meter.mutex.Lock()
+	defer meter.mutex.Unlock()
+	// the counter only goes up so an earlier reading is never lost
+	if cpuTime > meter.cpuTime {
+		meter.cpuTime = cpuTime
+	}
+	meter.measured = true
+}
+
+// the cgroup of the first process in the container
+func (meter *meter) findCgroup() (string, error) {
+	output, err := exec.Command(meter.runtime, "inspect", "--format", "{{.State.Pid}}", meter.name).Output()
+	if err != nil {
+		return "", err
+	}
+	pid := strings.TrimSpace(string(output))
+	if pid == "" || pid == "0" {
+		return "", fmt.Errorf("container %s is not running", meter.name)
+	}
+	procCgroup, err := os.ReadFile(filepath.Join("/proc", pid, "cgroup"))
+	if err != nil {
+		return "", err
+	}
+	return getCgroupPath(string(procCgroup))
+}
+
+// the folder of the cgroup in /proc/<pid>/cgroup
+// cgroup v2 has a single 0:: line, v1 has one for the cpuacct controller
+func getCgroupPath(procCgroup string) (string, error) {
+	for _, line := range strings.Split(strings.TrimSpace(procCgroup), "\n") {
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if parts[0] == "0" && parts[1] == "" {
+			return filepath.Join(CGROUP_ROOT, parts[2]), nil
+		}
+		for _, controller := range strings.Split(parts[1], ",") {
+			if controller == "cpuacct" {
+				return filepath.Join(CGROUP_ROOT, parts[1], parts[2]), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no cpu cgroup found")
+}
+
+// milliseconds of cpu time used by everything in the cgroup
+func readCgroupCPUTime(cgroup string) (uint64, error) {
+	// cgroup v1 counts nanoseconds
+	usage, err := os.ReadFile(filepath.Join(cgroup, "cpuacct.usage"))
+	if err == nil {
+		nanoseconds, err := strconv.ParseUint(strings.TrimSpace(string(usage)), 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		return nanoseconds / uint64(time.Millisecond), nil
+	}
+	stat, err := os.ReadFile(filepath.Join(cgroup, "cpu.stat"))
+	if err != nil {
+		return 0, err
+	}
+	return parseCPUStat(string(stat))
+}
+
+// the usage_usec line of a cgroup v2 cpu.stat file in milliseconds
+func parseCPUStat(stat string) (uint64, error) {
+	for _, line := range strings.Split(stat, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "usage_usec" {
+			microseconds, err := strconv.ParseUint(fields[1], 10, 64)
+			if err != nil {
+				return 0, err
+			}
+			return microseconds / 1000, nil //nolint:gomnd
+		}
+	}
+	return 0, fmt.Errorf("cpu.stat has no usage_usec")
+}
@@ -0,0 +1,29 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCgroupPath(t *testing.T) {
+	path, err := getCgroupPath("0::/system.slice/docker-abc.scope\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "/sys/fs/cgroup/system.slice/docker-abc.scope", path)
+
+	path, err = getCgroupPath("12:memory:/docker/abc\n4:cpu,cpuacct:/docker/abc\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "/sys/fs/cgroup/cpu,cpuacct/docker/abc", path)
+
+	_, err = getCgroupPath("12:memory:/docker/abc\n")
+	assert.Error(t, err)
+}
+
+func TestParseCPUStat(t *testing.T) {
+	cpuTime, err := parseCPUStat("usage_usec 2500123\nuser_usec 2000000\nsystem_usec 500123\n")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2500), cpuTime)
+
+	_, err = parseCPUStat("nr_periods 0\n")
+	assert.Error(t, err)
+}
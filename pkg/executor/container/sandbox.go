@@ -0,0 +1,110 @@
+package container
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	SANDBOX_NONE        = "none"
+	SANDBOX_GVISOR      = "gvisor"
+	SANDBOX_FIRECRACKER = "firecracker"
+)
+
+var SandboxTypes = []string{SANDBOX_NONE, SANDBOX_GVISOR, SANDBOX_FIRECRACKER}
+
+// the process limit for sandboxed jobs so a fork bomb stays in its box
+const SANDBOX_PIDS_LIMIT = 4096
+
+// running modules in a sandbox instead of a plain container
+// gvisor runs them against a kernel in user space and firecracker in a
+// micro VM through kata containers
+type SandboxOptions struct {
+	// none, gvisor or firecracker for every job
+	Default string
+	// module id -> sandbox, this wins over the default
+	Modules map[string]string
+	// sandbox -> the name of the OCI runtime for docker and podman or the
+	// runtime class for kubernetes
+	Runtimes map[string]string
+	// sandboxed jobs have no network unless this is set
+	AllowNetwork bool
+	// the limits given to sandboxed jobs whose module does not set its own
+	// in the same format modules use
+	DefaultCPU    string
+	DefaultMemory string
+}
+
+func IsSandboxType(sandbox string) bool {
+	for _, sandboxType := range SandboxTypes {
+		if sandbox == sandboxType {
+			return true
+		}
+	}
+	return false
+}
+
+func CheckSandboxOptions(options SandboxOptions) error {
+	if !IsSandboxType(options.Default) {
+		return fmt.Errorf("unknown sandbox %q, expected one of %s", options.Default, strings.Join(SandboxTypes, ", "))
+	}
+	for moduleID, sandbox := range options.Modules {
+		if !IsSandboxType(sandbox) {
+			return fmt.Errorf("unknown sandbox %q for module %s, expected one of %s", sandbox, moduleID, strings.Join(SandboxTypes, ", "))
+		}
+		if sandbox != SANDBOX_NONE && options.Runtimes[sandbox] == "" {
+			return fmt.Errorf("no runtime is set for the %s sandbox", sandbox)
+		}
+	}
+	if options.Default != SANDBOX_NONE && options.Runtimes[options.Default] == "" {
+		return fmt.Errorf("no runtime is set for the %s sandbox", options.Default)
+	}
+	_, err := ParseCPU(options.DefaultCPU)
+	if err != nil {
+		return err
+	}
+	_, err = ParseMemory(options.DefaultMemory)
+	return err
+}
+
+func (options SandboxOptions) GetSandbox(moduleID string) string {
+	if sandbox, ok := options.Modules[moduleID]; ok {
+		return sandbox
+	}
+	if options.Default == "" {
+		return SANDBOX_NONE
+	}
+	return options.Default
+}
+
+// harden the job if its module is to be sandboxed
+func ApplySandbox(job *ContainerJob, options SandboxOptions, moduleID string) error {
+	sandbox := options.GetSandbox(moduleID)
+	if sandbox == SANDBOX_NONE {
+		return nil
+	}
+	if sandbox == SANDBOX_FIRECRACKER && job.GPU > 0 {
+		return fmt.Errorf("jobs that need a GPU cannot run in the firecracker sandbox")
+	}
+	job.Sandbox = sandbox
+	job.Runtime = options.Runtimes[sandbox]
+	if !options.AllowNetwork {
+		job.Network = false
+		job.NetworkAllow = nil
+	}
+	if job.CPU == 0 {
+		cpu, err := ParseCPU(options.DefaultCPU)
+		if err != nil {
+			return err
+		}
+		job.CPU = cpu
+	}
+	if job.Memory == 0 {
+		memory, err := ParseMemory(options.DefaultMemory)
+		if err != nil {
+			return err
+		}
+		job.Memory = memory
+	}
+	return nil
+}
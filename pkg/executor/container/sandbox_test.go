@@ -0,0 +1,46 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplySandbox(t *testing.T) {
+	options := SandboxOptions{
+		Default:       SANDBOX_GVISOR,
+		Modules:       map[string]string{"trusted": SANDBOX_NONE, "untrusted": SANDBOX_FIRECRACKER},
+		Runtimes:      map[string]string{SANDBOX_GVISOR: "runsc", SANDBOX_FIRECRACKER: "kata-fc"},
+		DefaultCPU:    "1",
+		DefaultMemory: "2gb",
+	}
+	require.NoError(t, CheckSandboxOptions(options))
+
+	job := ContainerJob{Name: "lilypad-deal1", Image: "example/sdxl:v1", Network: true, Memory: 8 << 30}
+	require.NoError(t, ApplySandbox(&job, options, "other"))
+	assert.Equal(t, SANDBOX_GVISOR, job.Sandbox)
+	assert.Equal(t, "runsc", job.Runtime)
+	assert.False(t, job.Network)
+	assert.Equal(t, 1.0, job.CPU)
+	assert.Equal(t, int64(8<<30), job.Memory)
+	assert.Equal(t, []string{
+		"run", "--name", "lilypad-deal1", "--pull=missing", "--runtime=runsc",
+		"--cap-drop=ALL", "--security-opt=no-new-privileges", "--pids-limit=4096", "--network=none",
+		"--cpus=1", "--cpu-shares=1024", "--memory=8589934592b", "--memory-swap=8589934592b", "example/sdxl:v1",
+	}, GetRunArgs(RUNTIME_DOCKER, job, []string{}))
+
+	job = ContainerJob{Network: true}
+	require.NoError(t, ApplySandbox(&job, options, "trusted"))
+	assert.Equal(t, ContainerJob{Network: true}, job)
+
+	// there is no GPU passthrough into the micro VMs
+	job = ContainerJob{GPU: 1}
+	assert.Error(t, ApplySandbox(&job, options, "untrusted"))
+
+	options.Default = "chroot"
+	assert.Error(t, CheckSandboxOptions(options))
+	options.Default = SANDBOX_GVISOR
+	delete(options.Runtimes, SANDBOX_GVISOR)
+	assert.Error(t, CheckSandboxOptions(options))
+}
@@ -0,0 +1,80 @@
+package container
+
+import (
+	"fmt"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// services are sent requests on the address of their container so the
+// runtime has to put it on a network the host can reach
+type ServiceOptions struct {
+	// the container network services without network access are attached to
+	// e.g. one made with docker network create --internal
+	// empty means we do not run services that have no network access
+	Network string
+}
+
+// the ports of the services we are running keyed by deal ID
+type runningServices struct {
+	mutex sync.Mutex
+	ports map[string]int
+}
+
+func newRunningServices() *runningServices {
+	return &runningServices{ports: map[string]int{}}
+}
+
+func (services *runningServices) add(dealID string, port int) {
+	services.mutex.Lock()
+	defer services.mutex.Unlock()
+	services.ports[dealID] = port
+}
+
+func (services *runningServices) remove(dealID string) {
+	services.mutex.Lock()
+	defer services.mutex.Unlock()
+	delete(services.ports, dealID)
+}
+
+func (services *runningServices) get(dealID string) (int, bool) {
+	services.mutex.Lock()
+	defer services.mutex.Unlock()
+	port, ok := services.ports[dealID]
+	return port, ok
+}
+
+// a service without network access still has to take requests
+// so it goes on the service network which cannot reach out
+func applyService(job *ContainerJob, options ServiceOptions) error {
+	if job.Network {
+		return nil
+	}
+	if options.Network == "" {
+		return fmt.Errorf("service %s has no network access and there is no service network to reach it on", job.Name)
+	}
+	job.Network = true
+	job.NetworkName = options.Network
+	return nil
+}
+
+// the container address is only known once the runtime has started it
+func (executor *ContainerExecutor) GetServiceURL(dealID string) (string, error) {
+	port, ok := executor.services.get(dealID)
+	if !ok {
+		return "", fmt.Errorf("deal %s is not running a service", dealID)
+	}
+	name := GetContainerName(dealID)
+	output, err := exec.Command(executor.Options.Runtime, "inspect", "--format", "{{range .NetworkSettings.Networks}}{{.IPAddress}} {{end}}", name).Output()
+	if err != nil {
+		return "", fmt.Errorf("error inspecting container %s: %s", name, err.Error())
+	}
+	addresses := strings.Fields(string(output))
+	if len(addresses) == 0 {
+		return "", fmt.Errorf("the service of deal %s has not started yet", dealID)
+	}
+	return "http://" + net.JoinHostPort(addresses[0], strconv.Itoa(port)), nil
+}
@@ -0,0 +1,33 @@
+package executor
+
+import (
+	"fmt"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+const (
+	RESOURCE_CPU    = "cpu"
+	RESOURCE_MEMORY = "memory"
+	RESOURCE_GPU    = "gpu"
+)
+
+// the job was stopped for using more than its deal allows
+// the message ends up as the error of the result so it says what happened
+type ResourceLimitError struct {
+	DealID   string
+	Resource string
+	// the limit as a person would read it e.g. 2048MB
+	Limit string
+}
+
+func (err *ResourceLimitError) Error() string {
+	if err.Resource == RESOURCE_MEMORY {
+		return fmt.Sprintf("job %s was killed for running out of memory, the deal allows %s", err.DealID, err.Limit)
+	}
+	return fmt.Sprintf("job %s went over its %s limit of %s", err.DealID, err.Resource, err.Limit)
+}
+
+func (err *ResourceLimitError) ErrorCode() data.ErrorCode {
+	return data.ErrInsufficientResources
+}
@@ -0,0 +1,618 @@
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	executorlib "github.com/lilypad-tech/lilypad/pkg/executor"
+	"github.com/lilypad-tech/lilypad/pkg/executor/container"
+	"github.com/lilypad-tech/lilypad/pkg/ipfs"
+	modulelib "github.com/lilypad-tech/lilypad/pkg/module"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	RESULTS_DIR = "kubernetes-results"
+
+	// how long to wait between checking on a job
+	JOB_STATE_POLL_INTERVAL = 2 * time.Second
+	// how long the helper keeps the pod around after the job so we can
+	// copy the outputs out of it
+	HELPER_HOLD_SECONDS = 3600
+
+	JOB_CONTAINER    = "job"
+	INPUTS_CONTAINER = "inputs"
+	HELPER_CONTAINER = "results"
+)
+
+type KubernetesExecutorOptions struct {
+	// empty means the default kubeconfig and context
+	Kubeconfig string
+	Context    string
+	Namespace  string
+	// a small image with sh, wget and tar that fetches the inputs and
+	// holds on to the outputs until we have copied them
+	HelperImage string
+	// where inputs from IPFS are downloaded from inside the cluster
+	IPFSGateway string
+	Sandbox     container.SandboxOptions
+	// keep jobs to their network policy with a kubernetes network policy
+	// this only works if the cluster network plugin enforces them
+	NetworkPolicies bool
+	// the CIDRs every job pod can reach e.g. the IPFS gateway the inputs
+	// are fetched from, DNS is always allowed
+	EgressAllow []string
+}
+
+// runs each job as a kubernetes job through kubectl
+type KubernetesExecutor struct {
+	Options    KubernetesExecutorOptions
+	ipfsClient *ipfs.Client
+	cancelled  *container.Cancellations
+}
+
+func NewKubernetesExecutor(options KubernetesExecutorOptions, ipfsClient *ipfs.Client) (*KubernetesExecutor, error) {
+	if options.Namespace == "" {
+		return nil, fmt.Errorf("a namespace is needed to run kubernetes jobs")
+	}
+	return &KubernetesExecutor{
+		Options:    options,
+		ipfsClient: ipfsClient,
+		cancelled:  container.NewCancellations(),
+	}, nil
+}
+
+func (executor *KubernetesExecutor) kubectl(ctx context.Context, args ...string) *exec.Cmd {
+	global := []string{"--namespace", executor.Options.Namespace}
+	if executor.Options.Kubeconfig != "" {
+		global = append(global, "--kubeconfig", executor.Options.Kubeconfig)
+	}
+	if executor.Options.Context != "" {
+		global = append(global, "--context", executor.Options.Context)
+	}
+	return exec.CommandContext(ctx, "kubectl", append(global, args...)...)
+}
+
+func (executor *KubernetesExecutor) Id() (string, error) {
+	kubeContext := executor.Options.Context
+	if kubeContext == "" {
+		output, err := executor.kubectl(context.Background(), "config", "current-context").Output()
+		if err != nil {
+			return "", fmt.Errorf("error getting kubernetes context: %s", err.Error())
+		}
+		kubeContext = strings.TrimSpace(string(output))
+	}
+	return fmt.Sprintf("kubernetes-%s-%s", kubeContext, executor.Options.Namespace), nil
+}
+
+func (executor *KubernetesExecutor) IsAvailable() (bool, error) {
+	output, err := executor.kubectl(context.Background(), "auth", "can-i", "create", "jobs").CombinedOutput()
+	if err != nil || strings.TrimSpace(string(output)) != "yes" {
+		return false, fmt.Errorf("Kubernetes is not currently available. Please ensure that kubectl can create jobs in namespace %s, then try again. %s", executor.Options.Namespace, strings.TrimSpace(string(output)))
+	}
+	return true, nil
+}
+
+// a spec for each node in the cluster from what it can allocate
+func (executor *KubernetesExecutor) GetMachineSpecs() ([]data.MachineSpec, error) {
+	output, err := executor.kubectl(context.Background(), "get", "nodes", "--output", "json").Output()
+	if err != nil {
+		return nil, fmt.Errorf("error getting kubernetes nodes: %s", err.Error())
+	}
+	return parseNodes(output)
+}
+
+type nodeList struct {
+	Items []struct {
+		Metadata struct {
+			Labels map[string]string `json:"labels"`
+		} `json:"metadata"`
+		Status struct {
+			Allocatable map[string]string `json:"allocatable"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+func parseNodes(output []byte) ([]data.MachineSpec, error) {
+	var nodes nodeList
+	err := json.Unmarshal(output, &nodes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing kubernetes nodes: %s", err.Error())
+	}
+	specs := []data.MachineSpec{}
+	for _, node := range nodes.Items {
+		cpu, err := container.ParseCPU(node.Status.Allocatable["cpu"])
+		if err != nil {
+			return nil, err
+		}
+		memory, err := container.ParseMemory(node.Status.Allocatable["memory"])
+		if err != nil {
+			return nil, err
+		}
+		spec := data.MachineSpec{
+			CPU:  int(cpu * 1000),           //nolint:gomnd
+			RAM:  int(memory / 1024 / 1024), //nolint:gomnd
+			GPUs: []data.GPUSpec{},
+		}
+		// the labels come from the nvidia gpu feature discovery if it is installed
+		gpus, _ := strconv.Atoi(node.Status.Allocatable["nvidia.com/gpu"])
+		vram, _ := strconv.Atoi(node.Metadata.Labels["nvidia.com/gpu.memory"])
+		for i := 0; i < gpus; i++ {
+			spec.GPUs = append(spec.GPUs, data.GPUSpec{
+				Name:   node.Metadata.Labels["nvidia.com/gpu.product"],
+				Vendor: "NVIDIA",
+				VRAM:   vram,
+			})
+		}
+		spec.GPU = gpus * 1000 //nolint:gomnd
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+func (executor *KubernetesExecutor) RunJob(
+	deal data.DealContainer,
+	module data.Module,
+) (*executorlib.ExecutorResults, error) {
+	if executor.ipfsClient == nil {
+		return nil, fmt.Errorf("an IPFS node is needed to publish the results of kubernetes jobs")
+	}
+	variantDigest, err := executorlib.ApplyModuleVariant(executor, &module)
+	if err != nil {
+		return nil, err
+	}
+	job, err := container.NewContainerJob(deal, module)
+	if err != nil {
+		return nil, err
+	}
+	moduleID, _ := data.GetModuleID(deal.Deal.JobOffer.Module)
+	err = container.ApplySandbox(&job, executor.Options.Sandbox, moduleID)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := json.Marshal(GetJobManifest(deal.ID, job, executor.Options))
+	if err != nil {
+		return nil, err
+	}
+	var networkPolicy []byte
+	if executor.Options.NetworkPolicies {
+		networkPolicyManifest, err := GetNetworkPolicyManifest(deal.ID, job, executor.Options)
+		if err != nil {
+			return nil, err
+		}
+		if networkPolicyManifest != nil {
+			networkPolicy, err = json.Marshal(networkPolicyManifest)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	defer executor.cancelled.Forget(job.Name)
+	if executor.cancelled.IsCancelled(job.Name) {
+		return nil, container.GetCancelledError(deal.ID)
+	}
+	ctx := context.Background()
+	// the policy is in place before the pod starts
+	if networkPolicy != nil {
+		policyCmd := executor.kubectl(ctx, "create", "--filename", "-")
+		policyCmd.Stdin = bytes.NewReader(networkPolicy)
+		output, err := policyCmd.CombinedOutput()
+		if err != nil {
+			return nil, fmt.Errorf("error creating kubernetes network policy %s -> %s, %s", deal.ID, err.Error(), output)
+		}
+		defer executor.deleteNetworkPolicy(job.Name)
+	}
+	createCmd := executor.kubectl(ctx, "create", "--filename", "-")
+	createCmd.Stdin = bytes.NewReader(manifest)
+	output, err := createCmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("error creating kubernetes job %s -> %s, %s", deal.ID, err.Error(), output)
+	}
+	defer executor.delete(job.Name)
+
+	resultsDir, err := system.EnsureDataDir(filepath.Join(RESULTS_DIR, deal.ID))
+	if err != nil {
+		return nil, fmt.Errorf("error creating a local folder of results %s -> %s", deal.ID, err.Error())
+	}
+	status, err := executor.waitForJob(ctx, job)
+	if executor.cancelled.IsCancelled(job.Name) {
+		return nil, container.GetCancelledError(deal.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	podName, exitCode := status.PodName, status.ExitCode
+
+	// kubernetes keeps stdout and stderr together
+	logs, err := executor.kubectl(ctx, "logs", podName, "--container", JOB_CONTAINER).Output()
+	if err != nil {
+		log.Warn().Msgf("error getting logs for kubernetes job %s: %s", job.Name, err.Error())
+	}
+	for name, content := range map[string][]byte{"stdout": logs, "stderr": {}, "exitCode": []byte(strconv.Itoa(exitCode))} {
+		err = system.WriteFile(filepath.Join(resultsDir, name), content)
+		if err != nil {
+			return nil, fmt.Errorf("error creating %s file %s -> %s", name, deal.ID, err.Error())
+		}
+	}
+	if status.OOMKilled {
+		return nil, &executorlib.ResourceLimitError{
+			DealID:   deal.ID,
+			Resource: executorlib.RESOURCE_MEMORY,
+			Limit:    fmt.Sprintf("%dMB", job.Memory/1024/1024), //nolint:gomnd
+		}
+	}
+	// the outputs are only copied back for jobs that worked
+	if exitCode != 0 {
+		return nil, modulelib.GetExitCodeError(module, deal.ID, exitCode, nil)
+	}
+
+	// the helper starts once the job has finished
+	if len(job.Outputs) > 0 {
+		waitOutput, err := executor.kubectl(ctx, "wait", "--for=condition=Ready", "pod/"+podName, "--timeout=5m").CombinedOutput()
+		if err != nil {
+			return nil, fmt.Errorf("error waiting for the outputs of %s -> %s, %s", deal.ID, err.Error(), waitOutput)
+		}
+	}
+	for index, output := range job.Outputs {
+		source := fmt.Sprintf("%s/%s:%s/.", executor.Options.Namespace, podName, getHelperOutputPath(index))
+		copyOutput, err := executor.kubectl(ctx, "cp", "--container", HELPER_CONTAINER, source, filepath.Join(resultsDir, output.Name)).CombinedOutput()
+		if err != nil {
+			return nil, fmt.Errorf("error copying output %s of %s -> %s, %s", output.Name, deal.ID, err.Error(), copyOutput)
+		}
+	}
+	_, err = modulelib.CheckModuleResult(module, resultsDir, exitCode)
+	if err != nil {
+		return nil, err
+	}
+
+	resultsCID, err := executor.ipfsClient.Put(ctx, resultsDir)
+	if err != nil {
+		return nil, fmt.Errorf("error adding results to IPFS %s -> %s", deal.ID, err.Error())
+	}
+
+	// TODO: we should think about WASM and instruction count here
+	return &executorlib.ExecutorResults{
+		ResultsDir:       resultsDir,
+		ResultsCID:       resultsCID,
+		InstructionCount: 1,
+		VariantDigest:    variantDigest,
+	}, nil
+}
+
+// poll the pod of the job until the job container has finished
+func (executor *KubernetesExecutor) waitForJob(ctx context.Context, job container.ContainerJob) (jobStatus, error) {
+	if job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, job.Timeout)
+		defer cancel()
+	}
+	for {
+		output, err := executor.kubectl(ctx, "get", "pods", "--selector", "job-name="+job.Name, "--output", "json").Output()
+		if ctx.Err() == context.DeadlineExceeded {
+			return jobStatus{}, data.NewError(data.ErrTimeout, "job %s did not finish within %s", job.Name, job.Timeout)
+		}
+		if err != nil {
+			return jobStatus{}, fmt.Errorf("error getting pods of kubernetes job %s: %s", job.Name, err.Error())
+		}
+		status, err := getJobStatus(output)
+		if err != nil {
+			return jobStatus{}, err
+		}
+		if status.Error != nil {
+			return jobStatus{}, fmt.Errorf("kubernetes job %s failed: %s", job.Name, status.Error.Error())
+		}
+		if status.Done {
+			return status, nil
+		}
+		if executor.cancelled.IsCancelled(job.Name) {
+			return jobStatus{}, fmt.Errorf("kubernetes job %s was deleted", job.Name)
+		}
+		select {
+		case <-ctx.Done():
+			return jobStatus{}, data.NewError(data.ErrTimeout, "job %s did not finish within %s", job.Name, job.Timeout)
+		case <-time.After(JOB_STATE_POLL_INTERVAL):
+		}
+	}
+}
+
+// deleting the kubernetes job stops its pod and the wait sees that it was cancelled
+func (executor *KubernetesExecutor) CancelJob(dealID string) error {
+	name := container.GetContainerName(dealID)
+	executor.cancelled.Cancel(name)
+	output, err := executor.kubectl(context.Background(), "delete", "job", name, "--ignore-not-found", "--wait=false").CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("error deleting kubernetes job %s: %s, %s", name, err.Error(), output)
+	}
+	return nil
+}
+
+func (executor *KubernetesExecutor) delete(name string) {
+	output, err := executor.kubectl(context.Background(), "delete", "job", name, "--ignore-not-found", "--wait=false").CombinedOutput()
+	if err != nil {
+		log.Debug().Msgf("error deleting kubernetes job %s: %s, %s", name, err.Error(), output)
+	}
+}
+
+func (executor *KubernetesExecutor) deleteNetworkPolicy(name string) {
+	output, err := executor.kubectl(context.Background(), "delete", "networkpolicy", name, "--ignore-not-found", "--wait=false").CombinedOutput()
+	if err != nil {
+		log.Debug().Msgf("error deleting kubernetes network policy %s: %s, %s", name, err.Error(), output)
+	}
+}
+
+type jobStatus struct {
+	PodName  string
+	Done     bool
+	ExitCode int
+	// the kubelet killed the job for going over its memory limit
+	OOMKilled bool
+	// set when the job can never finish e.g. the image cannot be pulled
+	Error error
+}
+
+type containerStatus struct {
+	Name  string `json:"name"`
+	State struct {
+		Waiting *struct {
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		} `json:"waiting"`
+		Terminated *struct {
+			ExitCode int    `json:"exitCode"`
+			Reason   string `json:"reason"`
+		} `json:"terminated"`
+	} `json:"state"`
+}
+
+type podList struct {
+	Items []struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+		Status struct {
+			Phase                 string            `json:"phase"`
+			InitContainerStatuses []containerStatus `json:"initContainerStatuses"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+var imageErrors = map[string]bool{
+	"ErrImagePull":     true,
+	"ImagePullBackOff": true,
+	"InvalidImageName": true,
+}
+
+func getJobStatus(output []byte) (jobStatus, error) {
+	var pods podList
+	err := json.Unmarshal(output, &pods)
+	if err != nil {
+		return jobStatus{}, fmt.Errorf("error parsing kubernetes pods: %s", err.Error())
+	}
+	// the job does not retry so there is only ever one pod
+	if len(pods.Items) == 0 {
+		return jobStatus{}, nil
+	}
+	pod := pods.Items[0]
+	status := jobStatus{PodName: pod.Metadata.Name}
+	for _, containerStatus := range pod.Status.InitContainerStatuses {
+		waiting := containerStatus.State.Waiting
+		if waiting != nil && imageErrors[waiting.Reason] {
+			status.Error = fmt.Errorf("%s: %s", waiting.Reason, waiting.Message)
+			return status, nil
+		}
+		terminated := containerStatus.State.Terminated
+		if terminated == nil {
+			continue
+		}
+		if containerStatus.Name == INPUTS_CONTAINER && terminated.ExitCode != 0 {
+			status.Error = fmt.Errorf("fetching inputs exited with code %d", terminated.ExitCode)
+			return status, nil
+		}
+		if containerStatus.Name == JOB_CONTAINER {
+			status.Done = true
+			status.ExitCode = terminated.ExitCode
+			status.OOMKilled = terminated.Reason == "OOMKilled"
+			return status, nil
+		}
+	}
+	if pod.Status.Phase == "Failed" {
+		status.Error = fmt.Errorf("pod %s failed", pod.Metadata.Name)
+	}
+	return status, nil
+}
+
+func getHelperOutputPath(index int) string {
+	return fmt.Sprintf("/lilypad/outputs/%d", index)
+}
+
+// the job runs as an init container so that a helper can hold on to its
+// outputs afterwards, inputs are fetched by another init container before it
+func GetJobManifest(dealID string, job container.ContainerJob, options KubernetesExecutorOptions) map[string]interface{} {
+	volumes := []interface{}{}
+	jobMounts := []interface{}{}
+	helperMounts := []interface{}{}
+	fetches := []string{}
+	inputsMounts := []interface{}{}
+	for index, input := range job.Inputs {
+		name := fmt.Sprintf("input-%d", index)
+		volumes = append(volumes, map[string]interface{}{"name": name, "emptyDir": map[string]interface{}{}})
+		url := input.URL
+		if input.CID != "" {
+			url = fmt.Sprintf("%s/ipfs/%s", strings.TrimRight(options.IPFSGateway, "/"), input.CID)
+		}
+		inputsMounts = append(inputsMounts, map[string]interface{}{"name": name, "mountPath": "/" + name})
+		fetches = append(fetches, fmt.Sprintf("wget -q -O /%s/input '%s'", name, strings.ReplaceAll(url, "'", `'\''`)))
+		jobMounts = append(jobMounts, map[string]interface{}{"name": name, "mountPath": input.Path, "subPath": "input", "readOnly": true})
+	}
+	for index, output := range job.Outputs {
+		name := fmt.Sprintf("output-%d", index)
+		volumes = append(volumes, map[string]interface{}{"name": name, "emptyDir": map[string]interface{}{}})
+		jobMounts = append(jobMounts, map[string]interface{}{"name": name, "mountPath": output.Path})
+		helperMounts = append(helperMounts, map[string]interface{}{"name": name, "mountPath": getHelperOutputPath(index)})
+	}
+
+	env := []interface{}{}
+	for _, variable := range job.Env {
+		name, value, _ := strings.Cut(variable, "=")
+		env = append(env, map[string]interface{}{"name": name, "value": value})
+	}
+	limits := map[string]interface{}{}
+	if job.CPU > 0 {
+		limits["cpu"] = fmt.Sprintf("%dm", int(job.CPU*1000)) //nolint:gomnd
+	}
+	if job.Memory > 0 {
+		limits["memory"] = strconv.FormatInt(job.Memory, 10)
+	}
+	if job.GPU > 0 {
+		limits["nvidia.com/gpu"] = strconv.Itoa(job.GPU)
+	}
+	jobContainer := map[string]interface{}{
+		"name":         JOB_CONTAINER,
+		"image":        job.Image,
+		"env":          env,
+		"volumeMounts": jobMounts,
+		"resources":    map[string]interface{}{"limits": limits, "requests": limits},
+	}
+	if len(job.Entrypoint) > 0 {
+		jobContainer["command"] = job.Entrypoint
+	}
+	if len(job.Parameters) > 0 {
+		jobContainer["args"] = job.Parameters
+	}
+	if job.WorkingDirectory != "" {
+		jobContainer["workingDir"] = job.WorkingDirectory
+	}
+	if job.Sandbox != "" {
+		jobContainer["securityContext"] = map[string]interface{}{
+			"allowPrivilegeEscalation": false,
+			"capabilities":             map[string]interface{}{"drop": []string{"ALL"}},
+		}
+	}
+
+	initContainers := []interface{}{}
+	if len(fetches) > 0 {
+		initContainers = append(initContainers, map[string]interface{}{
+			"name":         INPUTS_CONTAINER,
+			"image":        options.HelperImage,
+			"command":      []string{"sh", "-c", strings.Join(fetches, " && ")},
+			"volumeMounts": inputsMounts,
+		})
+	}
+	initContainers = append(initContainers, jobContainer)
+
+	jobSpec := map[string]interface{}{
+		"backoffLimit":            0,
+		"ttlSecondsAfterFinished": HELPER_HOLD_SECONDS,
+		"template": map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"labels": map[string]interface{}{"lilypad.tech/deal": dealID},
+			},
+			"spec": map[string]interface{}{
+				"restartPolicy":                "Never",
+				"automountServiceAccountToken": false,
+				"volumes":                      volumes,
+				"initContainers":               initContainers,
+				"containers": []interface{}{map[string]interface{}{
+					"name":         HELPER_CONTAINER,
+					"image":        options.HelperImage,
+					"command":      []string{"sleep", strconv.Itoa(HELPER_HOLD_SECONDS)},
+					"volumeMounts": helperMounts,
+				}},
+			},
+		},
+	}
+	// network access is left to the cluster's network policies
+	// unless we make one for the job, see GetNetworkPolicyManifest
+	if job.Runtime != "" {
+		podSpec := jobSpec["template"].(map[string]interface{})["spec"].(map[string]interface{})
+		podSpec["runtimeClassName"] = job.Runtime
+	}
+	if job.Timeout > 0 {
+		// leave the helper time to hand over the outputs
+		jobSpec["activeDeadlineSeconds"] = int(job.Timeout.Seconds()) + HELPER_HOLD_SECONDS
+	}
+
+	return map[string]interface{}{
+		"apiVersion": "batch/v1",
+		"kind":       "Job",
+		"metadata": map[string]interface{}{
+			"name": job.Name,
+			"labels": map[string]interface{}{
+				"app.kubernetes.io/managed-by": "lilypad",
+				"lilypad.tech/deal":            dealID,
+			},
+		},
+		"spec": jobSpec,
+	}
+}
+
+// limit what the pod of the job can connect to
+// kubernetes policies match IPs so an allowlist cannot name domains
+// nil means the job has full network access
+func GetNetworkPolicyManifest(dealID string, job container.ContainerJob, options KubernetesExecutorOptions) (map[string]interface{}, error) {
+	if job.Network && len(job.NetworkAllow) == 0 {
+		return nil, nil
+	}
+	destinations := append([]string{}, options.EgressAllow...)
+	if job.Network {
+		destinations = append(destinations, job.NetworkAllow...)
+	}
+	to := []interface{}{}
+	for _, destination := range destinations {
+		cidr := destination
+		if ip := net.ParseIP(destination); ip != nil {
+			cidr = destination + "/32"
+			if ip.To4() == nil {
+				cidr = destination + "/128"
+			}
+		}
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return nil, fmt.Errorf("the kubernetes executor can only allow IPs and CIDRs not %s", destination)
+		}
+		to = append(to, map[string]interface{}{"ipBlock": map[string]interface{}{"cidr": cidr}})
+	}
+	egress := []interface{}{
+		map[string]interface{}{
+			"ports": []interface{}{
+				map[string]interface{}{"protocol": "UDP", "port": 53},
+				map[string]interface{}{"protocol": "TCP", "port": 53},
+			},
+		},
+	}
+	if len(to) > 0 {
+		egress = append(egress, map[string]interface{}{"to": to})
+	}
+	return map[string]interface{}{
+		"apiVersion": "networking.k8s.io/v1",
+		"kind":       "NetworkPolicy",
+		"metadata": map[string]interface{}{
+			"name": job.Name,
+			"labels": map[string]interface{}{
+				"app.kubernetes.io/managed-by": "lilypad",
+				"lilypad.tech/deal":            dealID,
+			},
+		},
+		"spec": map[string]interface{}{
+			"podSelector": map[string]interface{}{
+				"matchLabels": map[string]interface{}{"lilypad.tech/deal": dealID},
+			},
+			"policyTypes": []string{"Egress"},
+			"egress":      egress,
+		},
+	}, nil
+}
+
+// Compile-time interface checks:
+var _ executorlib.Executor = (*KubernetesExecutor)(nil)
+var _ executorlib.CancellableExecutor = (*KubernetesExecutor)(nil)
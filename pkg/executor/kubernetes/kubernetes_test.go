@@ -0,0 +1,124 @@
+package kubernetes
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/executor/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetJobManifest(t *testing.T) {
+	job := container.ContainerJob{
+		Name:       "lilypad-deal1",
+		Image:      "example/sdxl:v1",
+		Parameters: []string{"--steps", "20"},
+		Env:        []string{"PROMPT=a=b"},
+		CPU:        2,
+		GPU:        1,
+		Timeout:    10 * time.Minute,
+		Inputs:     []container.ContainerInput{{CID: "QmInput", Path: "/inputs/data"}},
+		Outputs:    []container.ContainerOutput{{Name: "outputs", Path: "/outputs"}},
+	}
+	manifest := GetJobManifest("deal1", job, KubernetesExecutorOptions{HelperImage: "busybox", IPFSGateway: "https://ipfs.io/"})
+	manifestBytes, err := json.Marshal(manifest)
+	require.NoError(t, err)
+
+	var parsed struct {
+		Spec struct {
+			ActiveDeadlineSeconds int `json:"activeDeadlineSeconds"`
+			Template              struct {
+				Spec struct {
+					InitContainers []struct {
+						Name      string   `json:"name"`
+						Command   []string `json:"command"`
+						Args      []string `json:"args"`
+						Env       []map[string]string
+						Resources struct {
+							Limits map[string]string `json:"limits"`
+						} `json:"resources"`
+					} `json:"initContainers"`
+					Containers []struct {
+						Name string `json:"name"`
+					} `json:"containers"`
+				} `json:"spec"`
+			} `json:"template"`
+		} `json:"spec"`
+	}
+	require.NoError(t, json.Unmarshal(manifestBytes, &parsed))
+	assert.Equal(t, 600+HELPER_HOLD_SECONDS, parsed.Spec.ActiveDeadlineSeconds)
+	initContainers := parsed.Spec.Template.Spec.InitContainers
+	require.Len(t, initContainers, 2)
+	assert.Equal(t, INPUTS_CONTAINER, initContainers[0].Name)
+	assert.Equal(t, "wget -q -O /input-0/input 'https://ipfs.io/ipfs/QmInput'", initContainers[0].Command[2])
+	assert.Equal(t, JOB_CONTAINER, initContainers[1].Name)
+	assert.Equal(t, []string{"--steps", "20"}, initContainers[1].Args)
+	assert.Equal(t, []map[string]string{{"name": "PROMPT", "value": "a=b"}}, initContainers[1].Env)
+	assert.Equal(t, map[string]string{"cpu": "2000m", "nvidia.com/gpu": "1"}, initContainers[1].Resources.Limits)
+	assert.Equal(t, HELPER_CONTAINER, parsed.Spec.Template.Spec.Containers[0].Name)
+}
+
+func TestGetNetworkPolicyManifest(t *testing.T) {
+	options := KubernetesExecutorOptions{EgressAllow: []string{"10.0.0.0/8"}}
+
+	manifest, err := GetNetworkPolicyManifest("deal1", container.ContainerJob{Name: "lilypad-deal1", Network: true}, options)
+	require.NoError(t, err)
+	assert.Nil(t, manifest)
+
+	_, err = GetNetworkPolicyManifest("deal1", container.ContainerJob{Name: "lilypad-deal1", Network: true, NetworkAllow: []string{"huggingface.co"}}, options)
+	assert.ErrorContains(t, err, "huggingface.co")
+
+	manifest, err = GetNetworkPolicyManifest("deal1", container.ContainerJob{Name: "lilypad-deal1", Network: true, NetworkAllow: []string{"192.168.1.10"}}, options)
+	require.NoError(t, err)
+	manifestBytes, err := json.Marshal(manifest)
+	require.NoError(t, err)
+	var parsed struct {
+		Spec struct {
+			Egress []struct {
+				To []struct {
+					IPBlock struct {
+						CIDR string `json:"cidr"`
+					} `json:"ipBlock"`
+				} `json:"to"`
+			} `json:"egress"`
+		} `json:"spec"`
+	}
+	require.NoError(t, json.Unmarshal(manifestBytes, &parsed))
+	require.Len(t, parsed.Spec.Egress, 2)
+	require.Len(t, parsed.Spec.Egress[1].To, 2)
+	assert.Equal(t, "10.0.0.0/8", parsed.Spec.Egress[1].To[0].IPBlock.CIDR)
+	assert.Equal(t, "192.168.1.10/32", parsed.Spec.Egress[1].To[1].IPBlock.CIDR)
+}
+
+func TestGetJobStatus(t *testing.T) {
+	status, err := getJobStatus([]byte(`{"items":[]}`))
+	require.NoError(t, err)
+	assert.False(t, status.Done)
+
+	status, err = getJobStatus([]byte(`{"items":[{"metadata":{"name":"pod1"},"status":{"phase":"Pending","initContainerStatuses":[
+		{"name":"inputs","state":{"terminated":{"exitCode":0}}},
+		{"name":"job","state":{"terminated":{"exitCode":3}}}]}}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, jobStatus{PodName: "pod1", Done: true, ExitCode: 3}, status)
+
+	status, err = getJobStatus([]byte(`{"items":[{"metadata":{"name":"pod1"},"status":{"phase":"Failed","initContainerStatuses":[
+		{"name":"job","state":{"terminated":{"exitCode":137,"reason":"OOMKilled"}}}]}}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, jobStatus{PodName: "pod1", Done: true, ExitCode: 137, OOMKilled: true}, status)
+
+	status, err = getJobStatus([]byte(`{"items":[{"metadata":{"name":"pod1"},"status":{"phase":"Pending","initContainerStatuses":[
+		{"name":"job","state":{"waiting":{"reason":"ImagePullBackOff","message":"not found"}}}]}}]}`))
+	require.NoError(t, err)
+	assert.EqualError(t, status.Error, "ImagePullBackOff: not found")
+}
+
+func TestParseNodes(t *testing.T) {
+	specs, err := parseNodes([]byte(`{"items":[{"metadata":{"labels":{"nvidia.com/gpu.product":"NVIDIA-A100","nvidia.com/gpu.memory":"81920"}},
+		"status":{"allocatable":{"cpu":"7800m","memory":"32Gi","nvidia.com/gpu":"2"}}}]}`))
+	require.NoError(t, err)
+	gpu := data.GPUSpec{Name: "NVIDIA-A100", Vendor: "NVIDIA", VRAM: 81920}
+	assert.Equal(t, []data.MachineSpec{{CPU: 7800, RAM: 32768, GPU: 2000, GPUs: []data.GPUSpec{gpu, gpu}}}, specs)
+}
@@ -0,0 +1,199 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: types.go
+//
+// Generated by this command:
+//
+//	mockgen -source=types.go -destination=mock_executor/mock_executor.go -package=mock_executor
+//
+
+// Package mock_executor is a generated GoMock package.
+package mock_executor
+
+import (
+	reflect "reflect"
+
+	data "github.com/lilypad-tech/lilypad/pkg/data"
+	executor "github.com/lilypad-tech/lilypad/pkg/executor"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockExecutor is a mock of Executor interface.
+type MockExecutor struct {
+	ctrl     *gomock.Controller
+	recorder *MockExecutorMockRecorder
+}
+
+// MockExecutorMockRecorder is the mock recorder for MockExecutor.
+type MockExecutorMockRecorder struct {
+	mock *MockExecutor
+}
+
+// NewMockExecutor creates a new mock instance.
+func NewMockExecutor(ctrl *gomock.Controller) *MockExecutor {
+	mock := &MockExecutor{ctrl: ctrl}
+	mock.recorder = &MockExecutorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExecutor) EXPECT() *MockExecutorMockRecorder {
+	return m.recorder
+}
+
+// GetMachineSpecs mocks base method.
+func (m *MockExecutor) GetMachineSpecs() ([]data.MachineSpec, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMachineSpecs")
+	ret0, _ := ret[0].([]data.MachineSpec)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMachineSpecs indicates an expected call of GetMachineSpecs.
+func (mr *MockExecutorMockRecorder) GetMachineSpecs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMachineSpecs", reflect.TypeOf((*MockExecutor)(nil).GetMachineSpecs))
+}
+
+// Id mocks base method.
+func (m *MockExecutor) Id() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Id")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Id indicates an expected call of Id.
+func (mr *MockExecutorMockRecorder) Id() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Id", reflect.TypeOf((*MockExecutor)(nil).Id))
+}
+
+// IsAvailable mocks base method.
+func (m *MockExecutor) IsAvailable() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAvailable")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAvailable indicates an expected call of IsAvailable.
+func (mr *MockExecutorMockRecorder) IsAvailable() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAvailable", reflect.TypeOf((*MockExecutor)(nil).IsAvailable))
+}
+
+// RunJob mocks base method.
+func (m *MockExecutor) RunJob(deal data.DealContainer, module data.Module) (*executor.ExecutorResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunJob", deal, module)
+	ret0, _ := ret[0].(*executor.ExecutorResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunJob indicates an expected call of RunJob.
+func (mr *MockExecutorMockRecorder) RunJob(deal, module any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunJob", reflect.TypeOf((*MockExecutor)(nil).RunJob), deal, module)
+}
+
+// MockLogStreamingExecutor is a mock of LogStreamingExecutor interface.
+type MockLogStreamingExecutor struct {
+	ctrl     *gomock.Controller
+	recorder *MockLogStreamingExecutorMockRecorder
+}
+
+// MockLogStreamingExecutorMockRecorder is the mock recorder for MockLogStreamingExecutor.
+type MockLogStreamingExecutorMockRecorder struct {
+	mock *MockLogStreamingExecutor
+}
+
+// NewMockLogStreamingExecutor creates a new mock instance.
+func NewMockLogStreamingExecutor(ctrl *gomock.Controller) *MockLogStreamingExecutor {
+	mock := &MockLogStreamingExecutor{ctrl: ctrl}
+	mock.recorder = &MockLogStreamingExecutorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLogStreamingExecutor) EXPECT() *MockLogStreamingExecutorMockRecorder {
+	return m.recorder
+}
+
+// GetMachineSpecs mocks base method.
+func (m *MockLogStreamingExecutor) GetMachineSpecs() ([]data.MachineSpec, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMachineSpecs")
+	ret0, _ := ret[0].([]data.MachineSpec)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMachineSpecs indicates an expected call of GetMachineSpecs.
+func (mr *MockLogStreamingExecutorMockRecorder) GetMachineSpecs() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMachineSpecs", reflect.TypeOf((*MockLogStreamingExecutor)(nil).GetMachineSpecs))
+}
+
+// Id mocks base method.
+func (m *MockLogStreamingExecutor) Id() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Id")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Id indicates an expected call of Id.
+func (mr *MockLogStreamingExecutorMockRecorder) Id() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Id", reflect.TypeOf((*MockLogStreamingExecutor)(nil).Id))
+}
+
+// IsAvailable mocks base method.
+func (m *MockLogStreamingExecutor) IsAvailable() (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAvailable")
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAvailable indicates an expected call of IsAvailable.
+func (mr *MockLogStreamingExecutorMockRecorder) IsAvailable() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAvailable", reflect.TypeOf((*MockLogStreamingExecutor)(nil).IsAvailable))
+}
+
+// RunJob mocks base method.
+func (m *MockLogStreamingExecutor) RunJob(deal data.DealContainer, module data.Module) (*executor.ExecutorResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunJob", deal, module)
+	ret0, _ := ret[0].(*executor.ExecutorResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunJob indicates an expected call of RunJob.
+func (mr *MockLogStreamingExecutorMockRecorder) RunJob(deal, module any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunJob", reflect.TypeOf((*MockLogStreamingExecutor)(nil).RunJob), deal, module)
+}
+
+// RunJobWithLogs mocks base method.
+func (m *MockLogStreamingExecutor) RunJobWithLogs(deal data.DealContainer, module data.Module, handler executor.LogHandler) (*executor.ExecutorResults, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunJobWithLogs", deal, module, handler)
+	ret0, _ := ret[0].(*executor.ExecutorResults)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunJobWithLogs indicates an expected call of RunJobWithLogs.
+func (mr *MockLogStreamingExecutorMockRecorder) RunJobWithLogs(deal, module, handler any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunJobWithLogs", reflect.TypeOf((*MockLogStreamingExecutor)(nil).RunJobWithLogs), deal, module, handler)
+}
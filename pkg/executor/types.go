@@ -8,8 +8,13 @@ type ExecutorResults struct {
 	ResultsDir       string
 	ResultsCID       string
 	InstructionCount int
+	// the digest of the module variant we ran (if the module has variants)
+	VariantDigest string
+	// what the job used, the method is empty for executors that do not meter
+	Metering data.ResultMetering
 }
 
+//go:generate mockgen -source=types.go -destination=mock_executor/mock_executor.go -package=mock_executor
 type Executor interface {
 	Id() (string, error)
 	IsAvailable() (bool, error)
@@ -21,3 +26,54 @@ type Executor interface {
 		module data.Module,
 	) (*ExecutorResults, error)
 }
+
+// called with output from a running job as it is written
+// stream is data.DealLogStdout or data.DealLogStderr
+// it can be called from more than one goroutine at once
+type LogHandler func(stream string, chunk []byte)
+
+// executors that can follow the output of a job while it runs
+// the resource provider checks for this and falls back to RunJob
+type LogStreamingExecutor interface {
+	Executor
+	RunJobWithLogs(
+		deal data.DealContainer,
+		module data.Module,
+		handler LogHandler,
+	) (*ExecutorResults, error)
+}
+
+// executors that can stop a job part way through
+// RunJob returns an error for the job once it has been stopped
+type CancellableExecutor interface {
+	Executor
+	CancelJob(dealID string) error
+}
+
+// executors that can run a module as a service that takes HTTP requests
+// RunJob keeps the service of a service deal up until its duration is over
+// the resource provider will not run service deals on other executors
+type ServiceExecutor interface {
+	Executor
+	// the base URL of the running service of a deal
+	GetServiceURL(dealID string) (string, error)
+}
+
+// executors that measure the cpu and gpu time of the jobs they run
+// the resource provider only takes metered deals on these
+type MeteringExecutor interface {
+	Executor
+	// the method the results are metered with e.g. data.MeteringCgroup
+	GetMeteringMethod() string
+}
+
+// called with the CID of each checkpoint a running job uploads
+type CheckpointHandler func(dealID string, cid string)
+
+// executors that upload the checkpoints of modules that write them
+// and can start a job from a checkpoint of an earlier deal
+// the resource provider will not run deals with ResumeFrom on other executors
+type CheckpointingExecutor interface {
+	Executor
+	SetCheckpointHandler(handler CheckpointHandler)
+}
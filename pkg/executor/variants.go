@@ -0,0 +1,37 @@
+package executor
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	modulelib "github.com/lilypad-tech/lilypad/pkg/module"
+	"github.com/rs/zerolog/log"
+)
+
+// if the module publishes variants then pick the one that matches
+// the hardware of our compute node and point the job at it
+func ApplyModuleVariant(executor Executor, module *data.Module) (string, error) {
+	if len(module.Variants) == 0 {
+		return "", nil
+	}
+	accelerator := modulelib.AcceleratorCPU
+	specs, err := executor.GetMachineSpecs()
+	if err != nil {
+		return "", fmt.Errorf("error getting machine specs for variant selection: %s", err.Error())
+	}
+	if len(specs) > 0 {
+		accelerator = modulelib.GetAccelerator(specs[0])
+	}
+	variant, err := modulelib.SelectVariant(*module, runtime.GOARCH, accelerator)
+	if err != nil {
+		return "", err
+	}
+	log.Debug().
+		Str("arch", runtime.GOARCH).
+		Str("accelerator", accelerator).
+		Str("image", modulelib.GetVariantImage(*variant)).
+		Msgf("selected module variant")
+	modulelib.ApplyVariant(module, *variant)
+	return variant.Digest, nil
+}
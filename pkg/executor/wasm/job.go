@@ -0,0 +1,162 @@
+package wasm
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/data/bacalhau"
+	"github.com/lilypad-tech/lilypad/pkg/executor/container"
+	modulelib "github.com/lilypad-tech/lilypad/pkg/module"
+)
+
+// WASI programs start at _start so there is nothing to invoke
+const WASI_ENTRY_POINT = "_start"
+
+// the parts of a module job spec wasmtime needs to run it
+// inputs and outputs are mounted the same way as for containers
+type WasmJob struct {
+	// unique to the deal so the job can be cancelled
+	Name string
+	// where the wasm binary comes from, Path is not used
+	Module     container.ContainerInput
+	EntryPoint string
+	Parameters []string
+	Env        map[string]string
+	// bytes of linear memory, zero means no limit
+	Memory int64
+	// zero means the job can run until it finishes
+	Timeout time.Duration
+	Inputs  []container.ContainerInput
+	Outputs []container.ContainerOutput
+}
+
+func NewWasmJob(deal data.DealContainer, module data.Module) (WasmJob, error) {
+	spec := module.Job.Spec
+	wasm, err := modulelib.GetWasmSpec(spec)
+	if err != nil {
+		return WasmJob{}, fmt.Errorf("the wasm executor only runs wasm modules: %s", err.Error())
+	}
+	job := WasmJob{
+		Name:       container.GetContainerName(deal.ID),
+		EntryPoint: wasm.EntryPoint,
+		Parameters: wasm.Parameters,
+		Env:        wasm.EnvironmentVariables,
+		Timeout:    time.Duration(spec.Timeout) * time.Second,
+		Inputs:     []container.ContainerInput{},
+		Outputs:    []container.ContainerOutput{},
+	}
+	job.Module, err = getWasmInput(wasm.EntryModule)
+	if err != nil {
+		return WasmJob{}, fmt.Errorf("entry module: %s", err.Error())
+	}
+	if len(wasm.ImportModules) > 0 {
+		return WasmJob{}, fmt.Errorf("wasm import modules are not supported")
+	}
+
+	// wasi gives a module no sockets so there is no network to hand out
+	network, err := modulelib.GetDealNetworkPolicy(deal, module)
+	if err != nil {
+		return WasmJob{}, err
+	}
+	if network.Mode != data.NetworkPolicyNone {
+		return WasmJob{}, fmt.Errorf("wasm modules cannot have network access")
+	}
+	if deal.Deal.JobOffer.Serve != nil {
+		return WasmJob{}, fmt.Errorf("wasm modules cannot be run as services")
+	}
+	if module.Checkpoint != nil {
+		return WasmJob{}, fmt.Errorf("wasm modules cannot write checkpoints")
+	}
+	if spec.Resources.GPU != "" && spec.Resources.GPU != "0" {
+		return WasmJob{}, fmt.Errorf("wasm modules cannot use gpus")
+	}
+	if maxRuntime := deal.Deal.JobOffer.MaxRuntime; maxRuntime > 0 {
+		job.Timeout = time.Duration(maxRuntime) * time.Second
+	}
+	job.Memory, err = container.ParseMemory(spec.Resources.Memory)
+	if err != nil {
+		return WasmJob{}, err
+	}
+	// the job gets what the deal is paying for
+	if ram := deal.Deal.JobOffer.Spec.RAM; ram > 0 {
+		job.Memory = int64(ram) * 1024 * 1024 //nolint:gomnd
+	}
+
+	for _, input := range spec.Inputs {
+		wasmInput, err := getWasmInput(input)
+		if err != nil {
+			return WasmJob{}, fmt.Errorf("input %s: %s", input.Name, err.Error())
+		}
+		if input.Path == "" {
+			return WasmJob{}, fmt.Errorf("input %s needs a path", input.Name)
+		}
+		wasmInput.Path = input.Path
+		job.Inputs = append(job.Inputs, wasmInput)
+	}
+	for _, output := range spec.Outputs {
+		if output.Name == "" || output.Path == "" {
+			return WasmJob{}, fmt.Errorf("outputs need a name and a path")
+		}
+		job.Outputs = append(job.Outputs, container.ContainerOutput{Name: output.Name, Path: output.Path})
+	}
+	return job, nil
+}
+
+func getWasmInput(storage bacalhau.StorageSpec) (container.ContainerInput, error) {
+	switch storage.StorageSource {
+	case bacalhau.StorageSourceIPFS:
+		if storage.CID == "" {
+			return container.ContainerInput{}, fmt.Errorf("ipfs storage needs a CID")
+		}
+		return container.ContainerInput{CID: storage.CID}, nil
+	case bacalhau.StorageSourceURLDownload:
+		if storage.URL == "" {
+			return container.ContainerInput{}, fmt.Errorf("url storage needs a URL")
+		}
+		return container.ContainerInput{URL: storage.URL}, nil
+	default:
+		return container.ContainerInput{}, fmt.Errorf("%s is not supported by the wasm executor", storage.StorageSource.String())
+	}
+}
+
+// the arguments to run a job with wasmtime
+// the features that can give different answers on different machines
+// are turned off so the same module and inputs give the same results
+// wasi still lets the module read the clock and random numbers
+// so modules that want to be replayed exactly must not depend on them
+// compiling on one thread keeps the metered cpu time within one core
+func GetRunArgs(job WasmJob, modulePath string, dirs map[string]string) []string {
+	args := []string{
+		"run",
+		"-W", "nan-canonicalization=y",
+		"-W", "relaxed-simd-deterministic=y",
+		"-W", "threads=n",
+		"-C", "parallel-compilation=n",
+	}
+	if job.Memory > 0 {
+		args = append(args, "-W", fmt.Sprintf("max-memory-size=%d", job.Memory))
+	}
+	if job.EntryPoint != "" && job.EntryPoint != WASI_ENTRY_POINT {
+		args = append(args, "--invoke", job.EntryPoint)
+	}
+	guests := []string{}
+	for guest := range dirs {
+		guests = append(guests, guest)
+	}
+	sort.Strings(guests)
+	for _, guest := range guests {
+		args = append(args, "--dir", fmt.Sprintf("%s::%s", dirs[guest], guest))
+	}
+	names := []string{}
+	for name := range job.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		args = append(args, "--env", fmt.Sprintf("%s=%s", name, job.Env[name]))
+	}
+	args = append(args, modulePath)
+	return append(args, job.Parameters...)
+}
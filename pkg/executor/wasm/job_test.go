@@ -0,0 +1,62 @@
+package wasm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/data/bacalhau"
+	"github.com/lilypad-tech/lilypad/pkg/executor/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWasmJob(t *testing.T) {
+	module := data.Module{Job: bacalhau.Job{Spec: bacalhau.Spec{
+		EngineSpec: bacalhau.EngineSpec{Type: "wasm", Params: map[string]interface{}{
+			"EntryModule":          map[string]interface{}{"StorageSource": "ipfs", "CID": "QmModule"},
+			"Parameters":           []interface{}{"--steps", "10"},
+			"EnvironmentVariables": map[string]interface{}{"SEED": "42"},
+		}},
+		Resources: bacalhau.ResourceUsageConfig{Memory: "256mb"},
+		Timeout:   600,
+		Inputs:    []bacalhau.StorageSpec{{StorageSource: bacalhau.StorageSourceURLDownload, URL: "https://example.com/data.csv", Path: "/inputs/data.csv"}},
+		Outputs:   []bacalhau.StorageSpec{{Name: "outputs", Path: "/outputs"}},
+	}}}
+	deal := data.DealContainer{ID: "Deal1", Deal: data.Deal{JobOffer: data.JobOffer{MaxRuntime: 120}}}
+
+	job, err := NewWasmJob(deal, module)
+	require.NoError(t, err)
+	assert.Equal(t, WasmJob{
+		Name:       "lilypad-deal1",
+		Module:     container.ContainerInput{CID: "QmModule"},
+		Parameters: []string{"--steps", "10"},
+		Env:        map[string]string{"SEED": "42"},
+		Memory:     256 << 20,
+		Timeout:    120 * time.Second,
+		Inputs:     []container.ContainerInput{{URL: "https://example.com/data.csv", Path: "/inputs/data.csv"}},
+		Outputs:    []container.ContainerOutput{{Name: "outputs", Path: "/outputs"}},
+	}, job)
+
+	assert.Equal(t, []string{
+		"run",
+		"-W", "nan-canonicalization=y",
+		"-W", "relaxed-simd-deterministic=y",
+		"-W", "threads=n",
+		"-C", "parallel-compilation=n",
+		"-W", "max-memory-size=268435456",
+		"--dir", "/data/inputs::/inputs",
+		"--dir", "/data/outputs::/outputs",
+		"--env", "SEED=42",
+		"/data/module.wasm", "--steps", "10",
+	}, GetRunArgs(job, "/data/module.wasm", map[string]string{"/inputs": "/data/inputs", "/outputs": "/data/outputs"}))
+
+	// a module that wants the network cannot be given it
+	module.Job.Spec.Network = bacalhau.NetworkConfig{Type: bacalhau.NetworkFull}
+	_, err = NewWasmJob(deal, module)
+	assert.Error(t, err)
+
+	docker := data.Module{Job: bacalhau.Job{Spec: bacalhau.Spec{Docker: bacalhau.JobSpecDocker{Image: "ubuntu"}}}}
+	_, err = NewWasmJob(deal, docker)
+	assert.Error(t, err, "containers are not run")
+}
@@ -0,0 +1,313 @@
+package wasm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	executorlib "github.com/lilypad-tech/lilypad/pkg/executor"
+	"github.com/lilypad-tech/lilypad/pkg/executor/container"
+	"github.com/lilypad-tech/lilypad/pkg/ipfs"
+	modulelib "github.com/lilypad-tech/lilypad/pkg/module"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	RESULTS_DIR = "wasm-results"
+	INPUTS_DIR  = "wasm-inputs"
+
+	DEFAULT_WASMTIME = "wasmtime"
+)
+
+type WasmExecutorOptions struct {
+	// the wasmtime binary, looked up on the PATH if it is not a path
+	Wasmtime string
+	// the machine we offer as wasmtime cannot tell us
+	// GPUs are left out as modules have no way to reach them
+	Spec data.MachineSpec
+	// run without IPFS and leave the results in the results folder
+	LocalOnly bool
+}
+
+// runs WebAssembly modules with wasmtime
+// there is no container runtime so it works wherever wasmtime does
+type WasmExecutor struct {
+	Options    WasmExecutorOptions
+	ipfsClient *ipfs.Client
+	cancelled  *container.Cancellations
+
+	runningMutex sync.Mutex
+	running      map[string]context.CancelFunc
+}
+
+func NewWasmExecutor(options WasmExecutorOptions, ipfsClient *ipfs.Client) (*WasmExecutor, error) {
+	if options.Wasmtime == "" {
+		options.Wasmtime = DEFAULT_WASMTIME
+	}
+	options.Spec.GPU = 0
+	options.Spec.GPUs = []data.GPUSpec{}
+	return &WasmExecutor{
+		Options:    options,
+		ipfsClient: ipfsClient,
+		cancelled:  container.NewCancellations(),
+		running:    map[string]context.CancelFunc{},
+	}, nil
+}
+
+func (executor *WasmExecutor) Id() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("wasm-%s", hostname), nil
+}
+
+func (executor *WasmExecutor) IsAvailable() (bool, error) {
+	output, err := exec.Command(executor.Options.Wasmtime, "--version").CombinedOutput()
+	if err != nil {
+		return false, fmt.Errorf("%s is not currently available. Please ensure that it is installed, then try again. %s, %s", executor.Options.Wasmtime, err.Error(), output)
+	}
+	return true, nil
+}
+
+func (executor *WasmExecutor) GetMachineSpecs() ([]data.MachineSpec, error) {
+	return []data.MachineSpec{executor.Options.Spec}, nil
+}
+
+func (executor *WasmExecutor) CancelJob(dealID string) error {
+	name := container.GetContainerName(dealID)
+	executor.cancelled.Cancel(name)
+	executor.runningMutex.Lock()
+	defer executor.runningMutex.Unlock()
+	if cancel, ok := executor.running[name]; ok {
+		cancel()
+	}
+	return nil
+}
+
+func (executor *WasmExecutor) RunJob(
+	deal data.DealContainer,
+	module data.Module,
+) (*executorlib.ExecutorResults, error) {
+	return executor.RunJobWithLogs(deal, module, nil)
+}
+
+func (executor *WasmExecutor) RunJobWithLogs(
+	deal data.DealContainer,
+	module data.Module,
+	handler executorlib.LogHandler,
+) (*executorlib.ExecutorResults, error) {
+	if executor.ipfsClient == nil && !executor.Options.LocalOnly {
+		return nil, fmt.Errorf("an IPFS node is needed to publish the results of wasm jobs")
+	}
+	job, err := NewWasmJob(deal, module)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	executor.runningMutex.Lock()
+	executor.running[job.Name] = cancel
+	executor.runningMutex.Unlock()
+	defer func() {
+		executor.runningMutex.Lock()
+		delete(executor.running, job.Name)
+		executor.runningMutex.Unlock()
+	}()
+	defer executor.cancelled.Forget(job.Name)
+	if executor.cancelled.IsCancelled(job.Name) {
+		return nil, container.GetCancelledError(deal.ID)
+	}
+
+	resultsDir, err := system.EnsureDataDir(filepath.Join(RESULTS_DIR, deal.ID))
+	if err != nil {
+		return nil, fmt.Errorf("error creating a local folder of results %s -> %s", deal.ID, err.Error())
+	}
+	modulePath, dirs, err := executor.prepareDirs(ctx, deal.ID, job, resultsDir)
+	if err != nil {
+		return nil, err
+	}
+	exitCode, metering, err := executor.run(ctx, job, modulePath, dirs, resultsDir, handler)
+	if executor.cancelled.IsCancelled(job.Name) {
+		return nil, container.GetCancelledError(deal.ID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = system.WriteFile(filepath.Join(resultsDir, "exitCode"), []byte(strconv.Itoa(exitCode)))
+	if err != nil {
+		return nil, fmt.Errorf("error creating exitCode file %s -> %s", deal.ID, err.Error())
+	}
+	manifest, err := modulelib.CheckModuleResult(module, resultsDir, exitCode)
+	if err != nil {
+		return nil, err
+	}
+	if exitCode != 0 {
+		return nil, modulelib.GetExitCodeError(module, deal.ID, exitCode, manifest)
+	}
+
+	if executor.ipfsClient == nil {
+		return &executorlib.ExecutorResults{
+			ResultsDir:       resultsDir,
+			InstructionCount: 1,
+			Metering:         metering,
+		}, nil
+	}
+	resultsCID, err := executor.ipfsClient.Put(ctx, resultsDir)
+	if err != nil {
+		return nil, fmt.Errorf("error adding results to IPFS %s -> %s", deal.ID, err.Error())
+	}
+	return &executorlib.ExecutorResults{
+		ResultsDir:       resultsDir,
+		ResultsCID:       resultsCID,
+		InstructionCount: 1,
+		Metering:         metering,
+	}, nil
+}
+
+func (executor *WasmExecutor) GetMeteringMethod() string {
+	return data.MeteringProcess
+}
+
+// fetch the module and its inputs and make the output folders
+// returning the module file and the guest:host folders to preopen
+func (executor *WasmExecutor) prepareDirs(ctx context.Context, dealID string, job WasmJob, resultsDir string) (string, map[string]string, error) {
+	inputsDir, err := system.EnsureDataDir(filepath.Join(INPUTS_DIR, dealID))
+	if err != nil {
+		return "", nil, fmt.Errorf("error creating a local folder for inputs %s -> %s", dealID, err.Error())
+	}
+	modulePath := filepath.Join(inputsDir, "module")
+	err = executor.fetch(ctx, job.Module, modulePath)
+	if err != nil {
+		return "", nil, fmt.Errorf("error getting the wasm module -> %s", err.Error())
+	}
+	modulePath, err = findWasmFile(modulePath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	dirs := map[string]string{}
+	for index, input := range job.Inputs {
+		inputPath := filepath.Join(inputsDir, strconv.Itoa(index))
+		err = executor.fetch(ctx, input, inputPath)
+		if err != nil {
+			return "", nil, fmt.Errorf("error getting input for %s -> %s", input.Path, err.Error())
+		}
+		// wasi can only preopen folders so a file is put in one
+		if info, err := os.Stat(inputPath); err == nil && !info.IsDir() {
+			guestDir := filepath.Dir(input.Path)
+			dirPath, ok := dirs[guestDir]
+			if !ok {
+				dirPath = inputPath + "-dir"
+				err = os.MkdirAll(dirPath, 0755) //nolint:gomnd
+				if err != nil {
+					return "", nil, err
+				}
+				dirs[guestDir] = dirPath
+			}
+			err = os.Rename(inputPath, filepath.Join(dirPath, filepath.Base(input.Path)))
+			if err != nil {
+				return "", nil, err
+			}
+			continue
+		}
+		dirs[input.Path] = inputPath
+	}
+	for _, output := range job.Outputs {
+		outputPath := filepath.Join(resultsDir, output.Name)
+		err := os.MkdirAll(outputPath, 0755) //nolint:gomnd
+		if err != nil {
+			return "", nil, fmt.Errorf("error creating output folder %s -> %s", output.Name, err.Error())
+		}
+		dirs[output.Path] = outputPath
+	}
+	return modulePath, dirs, nil
+}
+
+func (executor *WasmExecutor) fetch(ctx context.Context, input container.ContainerInput, path string) error {
+	if input.CID == "" {
+		return container.DownloadInput(ctx, input.URL, path)
+	}
+	if executor.ipfsClient == nil {
+		return fmt.Errorf("an IPFS node is needed to get %s", input.CID)
+	}
+	return executor.ipfsClient.Get(ctx, input.CID, path)
+}
+
+// a CID can be of a folder with the module in it
+func findWasmFile(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return path, nil
+	}
+	matches, err := filepath.Glob(filepath.Join(path, "*.wasm"))
+	if err != nil {
+		return "", err
+	}
+	if len(matches) != 1 {
+		return "", fmt.Errorf("expected one .wasm file in the module folder but found %d", len(matches))
+	}
+	return matches[0], nil
+}
+
+// run wasmtime and wait for it, returning the exit code of the module
+// and the cpu time wasmtime used to compile and run it
+// a trap is reported by wasmtime as a non-zero exit code
+func (executor *WasmExecutor) run(ctx context.Context, job WasmJob, modulePath string, dirs map[string]string, resultsDir string, handler executorlib.LogHandler) (int, data.ResultMetering, error) {
+	metering := data.ResultMetering{Method: data.MeteringProcess}
+	if job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, job.Timeout)
+		defer cancel()
+	}
+	stdoutFile, err := os.Create(filepath.Join(resultsDir, "stdout"))
+	if err != nil {
+		return 0, metering, err
+	}
+	defer stdoutFile.Close()
+	stderrFile, err := os.Create(filepath.Join(resultsDir, "stderr"))
+	if err != nil {
+		return 0, metering, err
+	}
+	defer stderrFile.Close()
+
+	cmd := exec.CommandContext(ctx, executor.Options.Wasmtime, GetRunArgs(job, modulePath, dirs)...)
+	// the module only sees the variables it is given
+	cmd.Env = []string{}
+	cmd.Stdout = container.NewLogWriter(stdoutFile, data.DealLogStdout, handler)
+	cmd.Stderr = container.NewLogWriter(stderrFile, data.DealLogStderr, handler)
+	log.Debug().Msgf("running %s %s", executor.Options.Wasmtime, strings.Join(cmd.Args[1:], " "))
+
+	err = cmd.Run()
+	if cmd.ProcessState != nil {
+		metering.CPUTime = uint64((cmd.ProcessState.UserTime() + cmd.ProcessState.SystemTime()).Milliseconds())
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return 0, metering, data.NewError(data.ErrTimeout, "job %s did not finish within %s", job.Name, job.Timeout)
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), metering, nil
+	}
+	if err != nil {
+		return 0, metering, fmt.Errorf("error running %s: %s", executor.Options.Wasmtime, err.Error())
+	}
+	return 0, metering, nil
+}
+
+// Compile-time interface checks:
+var _ executorlib.LogStreamingExecutor = (*WasmExecutor)(nil)
+var _ executorlib.CancellableExecutor = (*WasmExecutor)(nil)
+var _ executorlib.MeteringExecutor = (*WasmExecutor)(nil)
@@ -0,0 +1,187 @@
+package fiat
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+const ORACLE_REQUEST_TIMEOUT = 10 * time.Second
+
+// the oracle answers can be this big before we give up on them
+const MAX_ORACLE_RESPONSE_SIZE = 1024 * 1024
+
+type Options struct {
+	// the URL we get an exchange rate from, {token} and {currency} are
+	// replaced with the token and the currency we want the rate for
+	// e.g. https://api.coingecko.com/api/v3/simple/price?ids={token}&vs_currencies={currency}
+	// empty turns fiat prices off
+	OracleURL string
+	// the currency to show prices in e.g. usd
+	Currency string
+	// what {token} is for the network token, ERC-20 tokens use their address
+	NetworkToken string
+	// how many seconds a rate is used for before we ask the oracle again
+	CacheTTL int
+	// the fewest seconds between two requests to the oracle
+	// a stale rate is used rather than go faster than this
+	MinInterval int
+}
+
+func (options Options) Enabled() bool {
+	return options.OracleURL != ""
+}
+
+type cachedRate struct {
+	rate      float64
+	fetchedAt time.Time
+}
+
+// turns deal prices into a fiat currency so users can sanity check them
+// the rates are only a guide, nothing is ever paid in fiat
+type Converter struct {
+	options Options
+	client  *http.Client
+	mutex   sync.Mutex
+	rates   map[string]cachedRate
+	// when we last asked the oracle for anything
+	lastRequest time.Time
+	now         func() time.Time
+}
+
+func NewConverter(options Options) *Converter {
+	return &Converter{
+		options: options,
+		client:  &http.Client{Timeout: ORACLE_REQUEST_TIMEOUT},
+		rates:   map[string]cachedRate{},
+		now:     time.Now,
+	}
+}
+
+// the oracle id of the token a deal is paid in
+func (converter *Converter) getTokenID(token *data.PaymentToken) string {
+	if token == nil {
+		return converter.options.NetworkToken
+	}
+	return strings.ToLower(token.Address)
+}
+
+// how much one whole token is worth and when the oracle said so
+func (converter *Converter) GetRate(token *data.PaymentToken) (float64, time.Time, error) {
+	tokenID := converter.getTokenID(token)
+	converter.mutex.Lock()
+	defer converter.mutex.Unlock()
+	now := converter.now()
+	cached, ok := converter.rates[tokenID]
+	if ok && now.Sub(cached.fetchedAt) < time.Duration(converter.options.CacheTTL)*time.Second {
+		return cached.rate, cached.fetchedAt, nil
+	}
+	if now.Sub(converter.lastRequest) < time.Duration(converter.options.MinInterval)*time.Second {
+		if ok {
+			return cached.rate, cached.fetchedAt, nil
+		}
+		return 0, time.Time{}, fmt.Errorf("not asking the %s price oracle again so soon", converter.options.Currency)
+	}
+	converter.lastRequest = now
+	rate, err := converter.fetchRate(tokenID)
+	if err != nil {
+		// an old rate is better than none
+		if ok {
+			return cached.rate, cached.fetchedAt, nil
+		}
+		return 0, time.Time{}, err
+	}
+	converter.rates[tokenID] = cachedRate{rate: rate, fetchedAt: now}
+	return rate, now, nil
+}
+
+func (converter *Converter) fetchRate(tokenID string) (float64, error) {
+	url := strings.NewReplacer(
+		"{token}", tokenID,
+		"{currency}", converter.options.Currency,
+	).Replace(converter.options.OracleURL)
+	res, err := converter.client.Get(url)
+	if err != nil {
+		return 0, fmt.Errorf("error asking the price oracle: %s", err.Error())
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("price oracle returned %s", res.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(res.Body, MAX_ORACLE_RESPONSE_SIZE))
+	if err != nil {
+		return 0, err
+	}
+	return ParseRate(body, converter.options.Currency)
+}
+
+// the rate is either the whole answer or the first number under a key
+// named after the currency, this covers the usual oracle formats
+// e.g. {"lilypad":{"usd":0.012}} or {"price":0.012}
+func ParseRate(body []byte, currency string) (float64, error) {
+	var value interface{}
+	err := json.Unmarshal(body, &value)
+	if err != nil {
+		return 0, fmt.Errorf("price oracle answer is not JSON: %s", err.Error())
+	}
+	rate, ok := findRate(value, currency)
+	if !ok {
+		return 0, fmt.Errorf("price oracle answer has no %s rate", currency)
+	}
+	if rate < 0 {
+		return 0, fmt.Errorf("price oracle gave a negative %s rate", currency)
+	}
+	return rate, nil
+}
+
+func findRate(value interface{}, currency string) (float64, bool) {
+	switch typed := value.(type) {
+	case float64:
+		return typed, true
+	case map[string]interface{}:
+		for _, key := range []string{currency, "price", "rate"} {
+			for name, child := range typed {
+				if number, ok := child.(float64); ok && strings.EqualFold(name, key) {
+					return number, true
+				}
+			}
+		}
+		names := []string{}
+		for name := range typed {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if child, ok := typed[name].(map[string]interface{}); ok {
+				rate, ok := findRate(child, currency)
+				if ok {
+					return rate, true
+				}
+			}
+		}
+	}
+	return 0, false
+}
+
+// the prices of a deal in the fiat currency
+func (converter *Converter) GetFiatPrices(pricing data.DealPricing, token *data.PaymentToken) (*data.FiatPrices, error) {
+	rate, updatedAt, err := converter.GetRate(token)
+	if err != nil {
+		return nil, err
+	}
+	return &data.FiatPrices{
+		Currency:          strings.ToUpper(converter.options.Currency),
+		Rate:              rate,
+		InstructionPrice:  float64(pricing.InstructionPrice) * rate,
+		PaymentCollateral: float64(pricing.PaymentCollateral) * rate,
+		MediationFee:      float64(pricing.MediationFee) * rate,
+		RateUpdatedAt:     updatedAt.Unix(),
+	}, nil
+}
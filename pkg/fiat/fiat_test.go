@@ -0,0 +1,92 @@
+package fiat
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		rate    float64
+		wantErr bool
+	}{
+		{name: "number", body: `0.5`, rate: 0.5},
+		{name: "currency key", body: `{"lilypad":{"usd":0.012}}`, rate: 0.012},
+		{name: "upper case currency", body: `{"USD":2}`, rate: 2},
+		{name: "price key", body: `{"price":1.5,"symbol":"LP"}`, rate: 1.5},
+		{name: "other currency", body: `{"lilypad":{"eur":0.01}}`, wantErr: true},
+		{name: "negative", body: `{"usd":-1}`, wantErr: true},
+		{name: "not json", body: `<html>`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := ParseRate([]byte(tt.body), "usd")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.rate, rate)
+		})
+	}
+}
+
+func TestConverterCachesRates(t *testing.T) {
+	requests := []string{}
+	rate := 2.0
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.URL.Query().Get("ids"))
+		fmt.Fprintf(res, `{"%s":{"usd":%g}}`, req.URL.Query().Get("ids"), rate)
+	}))
+	defer server.Close()
+
+	now := time.Unix(1000, 0)
+	converter := NewConverter(Options{
+		OracleURL:    server.URL + "?ids={token}&vs_currencies={currency}",
+		Currency:     "usd",
+		NetworkToken: "lilypad",
+		CacheTTL:     60,
+		MinInterval:  10,
+	})
+	converter.now = func() time.Time { return now }
+
+	prices, err := converter.GetFiatPrices(data.DealPricing{InstructionPrice: 1, PaymentCollateral: 5, MediationFee: 2}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", prices.Currency)
+	assert.Equal(t, 10.0, prices.PaymentCollateral)
+	assert.Equal(t, 4.0, prices.MediationFee)
+
+	// cached
+	rate = 3
+	now = now.Add(30 * time.Second)
+	got, _, err := converter.GetRate(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2.0, got)
+
+	// another token is rate limited until the interval has passed
+	usdc := &data.PaymentToken{Address: "0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238", Decimals: 6}
+	now = now.Add(time.Second)
+	converter.lastRequest = now
+	_, _, err = converter.GetRate(usdc)
+	assert.Error(t, err)
+	now = now.Add(10 * time.Second)
+	got, _, err = converter.GetRate(usdc)
+	assert.NoError(t, err)
+	assert.Equal(t, 3.0, got)
+
+	// expired so we ask again
+	now = now.Add(time.Minute)
+	got, _, err = converter.GetRate(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3.0, got)
+
+	assert.Equal(t, []string{"lilypad", "0x1c7d4b196cb0c7b01d743fbc6116a902379c7238", "lilypad"}, requests)
+}
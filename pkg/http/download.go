@@ -0,0 +1,146 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+	"github.com/rs/zerolog/log"
+)
+
+// how many times we pick a download back up after the connection drops
+const DOWNLOAD_MAX_ATTEMPTS = 10
+
+// download a file to localPath in a way that survives a dropped connection
+// the bytes are written to localPath.partial and if the connection drops
+// we ask for the rest with a Range request rather than starting again
+// the partial file is kept between calls so a later call also resumes
+// once we have every byte the checksum sent by the server is checked
+func DownloadFile(
+	ctx context.Context,
+	options ClientOptions,
+	path string,
+	localPath string,
+) error {
+	partialPath := localPath + ".partial"
+	etagPath := partialPath + ".etag"
+	client := newRetryClient()
+
+	err := os.MkdirAll(filepath.Dir(localPath), 0755)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= DOWNLOAD_MAX_ATTEMPTS; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Duration(attempt) * time.Second):
+			}
+		}
+		checksum, complete, err := downloadAttempt(ctx, client, URL(options, path), partialPath, etagPath)
+		if httpErr, ok := err.(HTTPError); ok {
+			// the server said no so trying again will not help
+			return httpErr
+		}
+		if err != nil {
+			lastErr = err
+			log.Debug().Msgf("download of %s interrupted (attempt %d): %s", path, attempt, err.Error())
+			continue
+		}
+		if !complete {
+			continue
+		}
+		if checksum != "" {
+			actual, err := system.GetFileChecksum(partialPath)
+			if err != nil {
+				return err
+			}
+			if actual != checksum {
+				// the bytes we have are no good so the next call starts again
+				os.Remove(partialPath)
+				os.Remove(etagPath)
+				return fmt.Errorf("checksum mismatch downloading %s: expected %s got %s", path, checksum, actual)
+			}
+		}
+		os.Remove(etagPath)
+		return os.Rename(partialPath, localPath)
+	}
+	return fmt.Errorf("download of %s failed after %d attempts: %w", path, DOWNLOAD_MAX_ATTEMPTS, lastErr)
+}
+
+// fetch what we are missing of the file and append it to the partial file
+// we return false for complete if the server told us to start again
+func downloadAttempt(
+	ctx context.Context,
+	client *retryablehttp.Client,
+	url string,
+	partialPath string,
+	etagPath string,
+) (string, bool, error) {
+	var offset int64
+	if info, err := os.Stat(partialPath); err == nil {
+		offset = info.Size()
+	}
+	etag, _ := os.ReadFile(etagPath)
+
+	req, err := retryablehttp.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return "", false, err
+	}
+	// If-Range means we get the whole file back if it has changed
+	// since we started rather than the tail of a different file
+	if offset > 0 && len(etag) > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		req.Header.Set("If-Range", string(etag))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", false, err
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		flags |= os.O_APPEND
+	case http.StatusOK:
+		flags |= os.O_TRUNC
+		err = os.WriteFile(etagPath, []byte(resp.Header.Get("ETag")), 0644)
+		if err != nil {
+			return "", false, err
+		}
+	case http.StatusRequestedRangeNotSatisfiable:
+		// our partial file is no use to the server so start again
+		os.Remove(partialPath)
+		os.Remove(etagPath)
+		return "", false, nil
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return "", false, HTTPError{
+			Message:    strings.TrimSpace(string(body)),
+			StatusCode: resp.StatusCode,
+		}
+	}
+
+	file, err := os.OpenFile(partialPath, flags, 0644)
+	if err != nil {
+		return "", false, err
+	}
+	defer file.Close()
+	_, err = io.Copy(file, resp.Body)
+	if err != nil {
+		return "", false, err
+	}
+	return resp.Header.Get(X_LILYPAD_CHECKSUM_HEADER), true, nil
+}
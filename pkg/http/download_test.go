@@ -0,0 +1,68 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadFileResumes(t *testing.T) {
+	content := bytes.Repeat([]byte("lilypad"), 10000)
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+
+	requests := 0
+	var resumedRange string
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		requests++
+		res.Header().Set("ETag", fmt.Sprintf("%q", checksum))
+		res.Header().Set(X_LILYPAD_CHECKSUM_HEADER, checksum)
+		if requests == 1 {
+			// drop the connection half way through the first download
+			res.Header().Set("Content-Length", fmt.Sprintf("%d", len(content)))
+			res.WriteHeader(http.StatusOK)
+			res.Write(content[:len(content)/2])
+			res.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		resumedRange = req.Header.Get("Range")
+		http.ServeContent(res, req, "archive.tar", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	localPath := filepath.Join(t.TempDir(), "archive.tar")
+	err := DownloadFile(context.Background(), ClientOptions{URL: server.URL}, "/files", localPath)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, fmt.Sprintf("bytes=%d-", len(content)/2), resumedRange)
+
+	downloaded, err := os.ReadFile(localPath)
+	assert.NoError(t, err)
+	assert.Equal(t, content, downloaded)
+	_, err = os.Stat(localPath + ".partial")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestDownloadFileChecksumMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		res.Header().Set(X_LILYPAD_CHECKSUM_HEADER, "not-the-checksum")
+		res.Write([]byte("hello"))
+	}))
+	defer server.Close()
+
+	localPath := filepath.Join(t.TempDir(), "archive.tar")
+	err := DownloadFile(context.Background(), ClientOptions{URL: server.URL}, "/files", localPath)
+	assert.ErrorContains(t, err, "checksum mismatch")
+	_, err = os.Stat(localPath + ".partial")
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,103 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+// the status an error code is sent with when the handler did not choose one
+var errorCodeStatus = map[data.ErrorCode]int{
+	data.ErrInvalidRequest:        http.StatusBadRequest,
+	data.ErrUnauthorized:          http.StatusUnauthorized,
+	data.ErrForbidden:             http.StatusForbidden,
+	data.ErrBanned:                http.StatusForbidden,
+	data.ErrNotFound:              http.StatusNotFound,
+	data.ErrConflict:              http.StatusConflict,
+	data.ErrInsufficientResources: http.StatusConflict,
+	data.ErrModuleNotAllowed:      http.StatusBadRequest,
+	data.ErrModuleLoadFailed:      http.StatusBadRequest,
+	data.ErrPriceMismatch:         http.StatusBadRequest,
+	data.ErrBudgetExceeded:        http.StatusPaymentRequired,
+	data.ErrTimeout:               http.StatusGatewayTimeout,
+	data.ErrUnsupportedProtocol:   http.StatusUpgradeRequired,
+	data.ErrReadOnly:              http.StatusMethodNotAllowed,
+	data.ErrInternal:              http.StatusInternalServerError,
+}
+
+// the code an error is sent with when the handler only chose a status
+func getStatusErrorCode(statusCode int) data.ErrorCode {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return data.ErrInvalidRequest
+	case http.StatusUnauthorized:
+		return data.ErrUnauthorized
+	case http.StatusForbidden:
+		return data.ErrForbidden
+	case http.StatusNotFound:
+		return data.ErrNotFound
+	case http.StatusConflict:
+		return data.ErrConflict
+	case http.StatusGatewayTimeout, http.StatusRequestTimeout:
+		return data.ErrTimeout
+	}
+	return data.ErrInternal
+}
+
+// the status and body of the response for an error from a handler
+func GetErrorResponse(err error) (int, data.Error) {
+	var httpError HTTPError
+	var httpErrorPtr *HTTPError
+	switch {
+	case errors.As(err, &httpError):
+	case errors.As(err, &httpErrorPtr):
+		httpError = *httpErrorPtr
+	default:
+		code := data.GetErrorCodeOr(err, data.ErrInternal)
+		statusCode, ok := errorCodeStatus[code]
+		if !ok {
+			statusCode = http.StatusInternalServerError
+		}
+		return statusCode, data.Error{Code: code, Message: err.Error()}
+	}
+	code := httpError.Code
+	if code == "" {
+		code = getStatusErrorCode(httpError.StatusCode)
+	}
+	return httpError.StatusCode, data.Error{Code: code, Message: httpError.Message}
+}
+
+// errors are sent as JSON so clients can tell them apart by code
+// clients on the first protocol version get the plain text they expect
+func WriteError(res http.ResponseWriter, req *http.Request, err error) {
+	statusCode, body := GetErrorResponse(err)
+	if GetProtocolVersion(req) < 2 {
+		http.Error(res, body.Message, statusCode)
+		return
+	}
+	res.Header().Set("Content-Type", "application/json")
+	res.Header().Set("X-Content-Type-Options", "nosniff")
+	res.WriteHeader(statusCode)
+	json.NewEncoder(res).Encode(body) //nolint:errcheck
+}
+
+// read the error the server sent back, servers that send plain text
+// errors get a code from the status
+func ReadErrorResponse(statusCode int, body []byte) *data.Error {
+	var apiError data.Error
+	if json.Unmarshal(body, &apiError) == nil && apiError.Code != "" {
+		return &apiError
+	}
+	message := strings.TrimSpace(string(body))
+	if message == "" {
+		message = fmt.Sprintf("request failed with status %d", statusCode)
+	}
+	return &data.Error{
+		Code:    getStatusErrorCode(statusCode),
+		Message: message,
+	}
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+// the protocol version a client speaks, the solver sends back the one
+// it answered in so both sides know where they stand
+const X_LILYPAD_PROTOCOL_HEADER = "X-Lilypad-Protocol"
+
+// the API is served under a prefix for each protocol version
+// e.g. /api/v1 and /api/v2
+const API_VERSIONED_SUB_PATH = "/api/{api_version:v[0-9]+}"
+
+// where the solver says which protocol versions it serves, this is
+// outside the versioned API so any client can ask before it picks one
+const API_PROTOCOL_PATH = "/api/protocol"
+
+type protocolContextKey struct{}
+
+func GetAPISubPath(protocolVersion int) string {
+	return fmt.Sprintf("/api/v%d", protocolVersion)
+}
+
+// the protocol version to speak, zero means the newest we know
+func getClientProtocolVersion(options ClientOptions) int {
+	if options.ProtocolVersion > 0 {
+		return options.ProtocolVersion
+	}
+	return data.PROTOCOL_VERSION
+}
+
+// the protocol version the request is being answered in, requests that
+// did not come through the versioned API get the newest
+func GetProtocolVersion(req *http.Request) int {
+	if protocolVersion, ok := req.Context().Value(protocolContextKey{}).(int); ok {
+		return protocolVersion
+	}
+	return data.PROTOCOL_VERSION
+}
+
+// work out the protocol version from the path prefix and turn away
+// versions we do not serve, the header is how a client says which
+// version it is on so one too old to be served is told to upgrade
+func ProtocolMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		apiVersion := mux.Vars(req)["api_version"]
+		protocolVersion, err := strconv.Atoi(strings.TrimPrefix(apiVersion, "v"))
+		if err != nil || !data.IsProtocolVersionSupported(protocolVersion) {
+			WriteError(res, req, HTTPError{
+				Message:    fmt.Sprintf("API version %s is not served, this solver serves v%d to v%d", apiVersion, data.MIN_PROTOCOL_VERSION, data.PROTOCOL_VERSION),
+				StatusCode: http.StatusNotFound,
+			})
+			return
+		}
+		if header := req.Header.Get(X_LILYPAD_PROTOCOL_HEADER); header != "" {
+			clientVersion, err := strconv.Atoi(header)
+			if err != nil {
+				WriteError(res, req, HTTPError{
+					Message:    fmt.Sprintf("invalid %s header: %s", X_LILYPAD_PROTOCOL_HEADER, header),
+					StatusCode: http.StatusBadRequest,
+				})
+				return
+			}
+			if clientVersion < data.MIN_PROTOCOL_VERSION {
+				WriteError(res, req, data.NewError(data.ErrUnsupportedProtocol, "protocol version %d is no longer supported, please upgrade to at least %d", clientVersion, data.MIN_PROTOCOL_VERSION))
+				return
+			}
+		}
+		res.Header().Set(X_LILYPAD_PROTOCOL_HEADER, strconv.Itoa(protocolVersion))
+		next.ServeHTTP(res, req.WithContext(context.WithValue(req.Context(), protocolContextKey{}, protocolVersion)))
+	})
+}
+
+// what this side of the protocol can speak
+func GetProtocolInfo(version string) data.ProtocolInfo {
+	return data.ProtocolInfo{
+		Version:            version,
+		ProtocolVersion:    data.PROTOCOL_VERSION,
+		MinProtocolVersion: data.MIN_PROTOCOL_VERSION,
+	}
+}
+
+// ask a server which protocol versions it serves and pick the newest we
+// both speak, servers from before versioning only have the v1 API
+func NegotiateProtocolVersion(options ClientOptions) (int, error) {
+	client := newRetryClient()
+	resp, err := client.Get(fmt.Sprintf("%s%s", options.URL, API_PROTOCOL_PATH))
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return 1, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return 0, ReadErrorResponse(resp.StatusCode, body)
+	}
+	var info data.ProtocolInfo
+	err = json.Unmarshal(body, &info)
+	if err != nil {
+		return 0, err
+	}
+	if info.MinProtocolVersion > data.PROTOCOL_VERSION {
+		return 0, data.NewError(data.ErrUnsupportedProtocol, "the server needs protocol version %d or newer and we speak %d, please upgrade", info.MinProtocolVersion, data.PROTOCOL_VERSION)
+	}
+	return min(info.ProtocolVersion, data.PROTOCOL_VERSION), nil
+}
@@ -0,0 +1,70 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProtocolVersions(t *testing.T) {
+	router := mux.NewRouter()
+	router.HandleFunc(API_PROTOCOL_PATH, GetHandler(func(res http.ResponseWriter, req *http.Request) (data.ProtocolInfo, error) {
+		return GetProtocolInfo("test"), nil
+	}))
+	subrouter := router.PathPrefix(API_VERSIONED_SUB_PATH).Subrouter()
+	subrouter.Use(ProtocolMiddleware)
+	subrouter.HandleFunc("/missing", GetHandler(func(res http.ResponseWriter, req *http.Request) (string, error) {
+		return "", data.NewError(data.ErrNotFound, "no such thing")
+	}))
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	get := func(version int, clientVersion string) (*http.Response, string) {
+		req, err := http.NewRequest("GET", fmt.Sprintf("%s%s/missing", server.URL, GetAPISubPath(version)), nil)
+		assert.NoError(t, err)
+		if clientVersion != "" {
+			req.Header.Set(X_LILYPAD_PROTOCOL_HEADER, clientVersion)
+		}
+		res, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer res.Body.Close()
+		buf := make([]byte, 1024)
+		n, _ := res.Body.Read(buf)
+		return res, string(buf[:n])
+	}
+
+	// the first version gets errors as plain text
+	res, body := get(1, "")
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	assert.Equal(t, "1", res.Header.Get(X_LILYPAD_PROTOCOL_HEADER))
+	assert.Equal(t, "no such thing\n", body)
+
+	res, body = get(2, "2")
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	assert.Equal(t, "2", res.Header.Get(X_LILYPAD_PROTOCOL_HEADER))
+	assert.Equal(t, data.ErrNotFound, ReadErrorResponse(res.StatusCode, []byte(body)).Code)
+
+	res, _ = get(data.PROTOCOL_VERSION+1, "")
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	assert.Empty(t, res.Header.Get(X_LILYPAD_PROTOCOL_HEADER))
+
+	res, body = get(data.PROTOCOL_VERSION, fmt.Sprintf("%d", data.MIN_PROTOCOL_VERSION-1))
+	assert.Equal(t, http.StatusUpgradeRequired, res.StatusCode)
+	assert.Equal(t, data.ErrUnsupportedProtocol, ReadErrorResponse(res.StatusCode, []byte(body)).Code)
+
+	protocolVersion, err := NegotiateProtocolVersion(ClientOptions{URL: server.URL})
+	assert.NoError(t, err)
+	assert.Equal(t, data.PROTOCOL_VERSION, protocolVersion)
+
+	// a solver from before versioning
+	oldServer := httptest.NewServer(http.NotFoundHandler())
+	defer oldServer.Close()
+	protocolVersion, err = NegotiateProtocolVersion(ClientOptions{URL: oldServer.URL})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, protocolVersion)
+}
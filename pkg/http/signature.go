@@ -0,0 +1,151 @@
+package http
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/lilypad-tech/lilypad/pkg/web3"
+)
+
+// the user headers only prove who the sender is so anyone who has seen
+// them can send them again with another request, these headers tie a
+// signature to one request so it cannot be replayed or moved, the solver
+// asks for them on every POST as each one changes some state
+
+// unix seconds of when the request was signed
+const X_LILYPAD_TIMESTAMP_HEADER = "X-Lilypad-Timestamp"
+
+// picked at random for each request so a signature is only accepted once
+const X_LILYPAD_NONCE_HEADER = "X-Lilypad-Nonce"
+
+// the signature of the method, path, body hash, timestamp and nonce
+const X_LILYPAD_REQUEST_SIGNATURE_HEADER = "X-Lilypad-Request-Signature"
+
+// how far the timestamp of a signed request can be from our clock
+const REQUEST_SIGNATURE_MAX_AGE = 5 * time.Minute
+
+const requestNonceSize = 16
+
+// what is signed for a request, the path includes the query
+func GetRequestSignatureMessage(method string, path string, body []byte, timestamp int64, nonce string) []byte {
+	bodyHash := sha256.Sum256(body)
+	return []byte(fmt.Sprintf("%s\n%s\n%s\n%d\n%s", method, path, hex.EncodeToString(bodyHash[:]), timestamp, nonce))
+}
+
+// sign the request as it is about to be sent, a retry sends the same
+// signature so the solver only acts on the first one that reaches it
+func addRequestSignature(req *retryablehttp.Request, signer web3.Signer) error {
+	body, err := req.BodyBytes()
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, requestNonceSize)
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return err
+	}
+	timestamp := time.Now().Unix()
+	message := GetRequestSignatureMessage(req.Method, req.URL.RequestURI(), body, timestamp, hex.EncodeToString(nonce))
+	signature, err := web3.SignMessageWithSigner(signer, message)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(X_LILYPAD_TIMESTAMP_HEADER, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(X_LILYPAD_NONCE_HEADER, hex.EncodeToString(nonce))
+	req.Header.Set(X_LILYPAD_REQUEST_SIGNATURE_HEADER, base64.StdEncoding.EncodeToString(signature))
+	return nil
+}
+
+// checks requests were signed for what they ask for and turns away ones
+// that are too old or have been seen before
+type RequestSignatureChecker struct {
+	maxAge time.Duration
+	now    func() time.Time
+	mutex  sync.Mutex
+	// the nonces we have accepted keyed by signer and nonce with the time
+	// they were signed, they are forgotten once they would be too old anyway
+	seen map[string]int64
+}
+
+func NewRequestSignatureChecker(maxAge time.Duration) *RequestSignatureChecker {
+	return &RequestSignatureChecker{
+		maxAge: maxAge,
+		now:    time.Now,
+		seen:   map[string]int64{},
+	}
+}
+
+func unauthorized(format string, args ...interface{}) HTTPError {
+	return HTTPError{
+		Message:    fmt.Sprintf(format, args...),
+		StatusCode: http.StatusUnauthorized,
+	}
+}
+
+// the address that signed the request, the body is read and put back
+// so the handler can still decode it
+func (checker *RequestSignatureChecker) Check(req *http.Request) (string, error) {
+	address, err := GetAddressFromHeaders(req)
+	if err != nil {
+		return "", err
+	}
+	timestamp, err := strconv.ParseInt(req.Header.Get(X_LILYPAD_TIMESTAMP_HEADER), 10, 64)
+	if err != nil {
+		return "", unauthorized("missing or invalid %s header", X_LILYPAD_TIMESTAMP_HEADER)
+	}
+	nonce := req.Header.Get(X_LILYPAD_NONCE_HEADER)
+	if nonce == "" {
+		return "", unauthorized("missing %s header", X_LILYPAD_NONCE_HEADER)
+	}
+	signature, err := base64.StdEncoding.DecodeString(req.Header.Get(X_LILYPAD_REQUEST_SIGNATURE_HEADER))
+	if err != nil || len(signature) == 0 {
+		return "", unauthorized("missing or invalid %s header", X_LILYPAD_REQUEST_SIGNATURE_HEADER)
+	}
+
+	now := checker.now()
+	age := now.Sub(time.Unix(timestamp, 0))
+	if age > checker.maxAge || age < -checker.maxAge {
+		return "", unauthorized("the request was signed %s ago and only %s is allowed", age.Round(time.Second), checker.maxAge)
+	}
+
+	var body []byte
+	if req.Body != nil {
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return "", err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	signer, err := web3.GetAddressFromSignedMessage(GetRequestSignatureMessage(req.Method, req.URL.RequestURI(), body, timestamp, nonce), signature)
+	if err != nil {
+		return "", unauthorized("invalid request signature: %s", err.Error())
+	}
+	if signer.String() != address {
+		return "", unauthorized("the request signature is not from %s", address)
+	}
+
+	checker.mutex.Lock()
+	defer checker.mutex.Unlock()
+	oldest := now.Add(-checker.maxAge).Unix()
+	for key, signedAt := range checker.seen {
+		if signedAt < oldest {
+			delete(checker.seen, key)
+		}
+	}
+	key := fmt.Sprintf("%s-%s", address, nonce)
+	if _, ok := checker.seen[key]; ok {
+		return "", unauthorized("the request signature has already been used")
+	}
+	checker.seen[key] = timestamp
+	return address, nil
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/lilypad-tech/lilypad/pkg/web3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestSignatureChecker(t *testing.T) {
+	signer, err := web3.NewPrivateKeySigner("b8ca6f1c0ec1c1d2ef0c4fd7e9be9f0d1c0c2a7c2f3e5a1d9b6c4e8f0a2b3c4d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a signed request as the client sends it, turned into the one the server sees
+	newRequest := func(method string, url string, body string) *http.Request {
+		req, err := retryablehttp.NewRequest(method, url, []byte(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = AddHeaders(req, signer, signer.Address().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		serverReq, err := http.NewRequest(method, url, bytes.NewReader([]byte(body)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		serverReq.Header = req.Header.Clone()
+		return serverReq
+	}
+	checker := NewRequestSignatureChecker(time.Minute)
+
+	req := newRequest("POST", "http://solver/api/v2/admin/import?force=true", `{"reason":"restore"}`)
+	address, err := checker.Check(req)
+	assert.NoError(t, err)
+	assert.Equal(t, signer.Address().String(), address)
+	// the handler can still read the body
+	body, err := io.ReadAll(req.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"reason":"restore"}`, string(body))
+
+	// the same signature cannot be sent again
+	replayed := newRequest("POST", "http://solver/api/v2/admin/import?force=true", `{"reason":"restore"}`)
+	replayed.Header = req.Header.Clone()
+	_, err = checker.Check(replayed)
+	assert.ErrorContains(t, err, "already been used")
+
+	// or moved to another route, method or body
+	for _, moved := range []*http.Request{
+		newRequest("POST", "http://solver/api/v2/admin/expire", `{"reason":"restore"}`),
+		newRequest("GET", "http://solver/api/v2/admin/import?force=true", `{"reason":"restore"}`),
+		newRequest("POST", "http://solver/api/v2/admin/import?force=true", `{"reason":"other"}`),
+	} {
+		signed := newRequest("POST", "http://solver/api/v2/admin/import?force=true", `{"reason":"restore"}`)
+		moved.Header = signed.Header.Clone()
+		_, err = checker.Check(moved)
+		assert.ErrorContains(t, err, "request signature is not from")
+	}
+
+	// or used once it is too old
+	stale := newRequest("POST", "http://solver/api/v2/admin/import", `{}`)
+	checker.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	_, err = checker.Check(stale)
+	assert.ErrorContains(t, err, "only 1m0s is allowed")
+
+	// the user headers alone are not enough
+	unsigned := newRequest("POST", "http://solver/api/v2/admin/import", `{}`)
+	unsigned.Header.Del(X_LILYPAD_REQUEST_SIGNATURE_HEADER)
+	checker.now = time.Now
+	_, err = checker.Check(unsigned)
+	assert.ErrorContains(t, err, X_LILYPAD_REQUEST_SIGNATURE_HEADER)
+}
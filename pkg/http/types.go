@@ -1,5 +1,7 @@
 package http
 
+import "github.com/lilypad-tech/lilypad/pkg/web3"
+
 type ServerOptions struct {
 	URL         string
 	Host        string
@@ -13,8 +15,12 @@ type RateLimiterOptions struct {
 }
 
 type ClientOptions struct {
-	URL           string
-	PrivateKey    string
+	URL        string
+	PrivateKey string
+	// signs requests in place of the private key when it is set
+	Signer        web3.Signer
 	PublicAddress string
 	Type          string
+	// the protocol version to speak, zero is the newest we know
+	ProtocolVersion int
 }
@@ -2,7 +2,6 @@ package http
 
 import (
 	"bytes"
-	"crypto/ecdsa"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -11,9 +10,11 @@ import (
 	stdlog "log"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/go-retryablehttp"
+	"github.com/lilypad-tech/lilypad/pkg/data"
 	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/lilypad-tech/lilypad/pkg/web3"
 	"github.com/rs/zerolog/log"
@@ -29,11 +30,24 @@ const X_LILYPAD_SIGNATURE_HEADER = "X-Lilypad-Signature"
 // the version run by the client or service
 const X_LILYPAD_VERSION_HEADER = "X-Lilypad-Version"
 
+// the sha256 of a downloaded file so the client can check it once complete
+const X_LILYPAD_CHECKSUM_HEADER = "X-Lilypad-Checksum"
+
+// the CID a result archive was fetched from
+const X_LILYPAD_CID_HEADER = "X-Lilypad-CID"
+
+// sent as a trailer once every block of a result archive has been checked
+const X_LILYPAD_VERIFIED_HEADER = "X-Lilypad-Verified"
+
+// a key picked by the client so a retried submission is only added once
+// it can be sent in the offer itself as well
+const IDEMPOTENCY_KEY_HEADER = "Idempotency-Key"
+
 // the context name we keep the address
 const CONTEXT_ADDRESS = "address"
 
-// the sub path any API's are served over
-const API_SUB_PATH = "/api/v1"
+// the sub path the newest version of the API is served over
+const API_SUB_PATH = "/api/v2"
 
 // the sub path the websocket server is mounted on
 const WEBSOCKET_SUB_PATH = "/ws"
@@ -41,6 +55,8 @@ const WEBSOCKET_SUB_PATH = "/ws"
 type HTTPError struct {
 	Message    string
 	StatusCode int
+	// what class of error it is, the status picks one if this is empty
+	Code data.ErrorCode
 }
 
 type AuthUser struct {
@@ -78,7 +94,7 @@ func decodeUserAddress(userPayload string, signature string) (string, error) {
 
 // returns userPayload and signature as strings ready to be written into request headers
 // we encode these both as base64 so they can be included in http headers
-func encodeUserAddress(privateKey *ecdsa.PrivateKey, address string) (string, string, error) {
+func encodeUserAddress(signer web3.Signer, address string) (string, string, error) {
 	user := AuthUser{
 		Address: address,
 	}
@@ -86,7 +102,7 @@ func encodeUserAddress(privateKey *ecdsa.PrivateKey, address string) (string, st
 	if err != nil {
 		return "", "", err
 	}
-	userSignature, err := web3.SignMessage(privateKey, userBytes)
+	userSignature, err := web3.SignMessageWithSigner(signer, userBytes)
 	if err != nil {
 		return "", "", err
 	}
@@ -96,17 +112,33 @@ func encodeUserAddress(privateKey *ecdsa.PrivateKey, address string) (string, st
 
 func AddHeaders(
 	req *retryablehttp.Request,
-	privateKey *ecdsa.PrivateKey,
+	signer web3.Signer,
 	address string,
 ) error {
-	userPayload, userSignature, err := encodeUserAddress(privateKey, address)
+	userPayload, userSignature, err := encodeUserAddress(signer, address)
 	if err != nil {
 		return err
 	}
 	req.Header.Add(X_LILYPAD_USER_HEADER, userPayload)
 	req.Header.Add(X_LILYPAD_SIGNATURE_HEADER, userSignature)
 	req.Header.Add(X_LILYPAD_VERSION_HEADER, system.Version)
-	return nil
+	req.Header.Add(X_LILYPAD_PROTOCOL_HEADER, strconv.Itoa(data.PROTOCOL_VERSION))
+	return addRequestSignature(req, signer)
+}
+
+// the user and signature headers as they are sent to the solver
+// for clients that do not use the HTTP client
+func GetUserHeaders(signer web3.Signer, address string) (map[string]string, error) {
+	userPayload, userSignature, err := encodeUserAddress(signer, address)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		X_LILYPAD_USER_HEADER:      userPayload,
+		X_LILYPAD_SIGNATURE_HEADER: userSignature,
+		X_LILYPAD_VERSION_HEADER:   system.Version,
+		X_LILYPAD_PROTOCOL_HEADER:  strconv.Itoa(data.PROTOCOL_VERSION),
+	}, nil
 }
 
 // this will use the client headers to ensure that a message was signed
@@ -115,14 +147,21 @@ func AddHeaders(
 // there is a "X-Lilypad-Signature" header that will contain the signature
 // we use the signature to verify that the message was signed by the private key
 func GetAddressFromHeaders(req *http.Request) (string, error) {
-	userHeader := req.Header.Get(X_LILYPAD_USER_HEADER)
+	return GetAddressFromUserHeaders(
+		req.Header.Get(X_LILYPAD_USER_HEADER),
+		req.Header.Get(X_LILYPAD_SIGNATURE_HEADER),
+	)
+}
+
+// check the values of the user and signature headers however they were sent
+// this is how the gRPC API checks the same headers sent as metadata
+func GetAddressFromUserHeaders(userHeader string, userSignature string) (string, error) {
 	if userHeader == "" {
 		return "", HTTPError{
 			Message:    "missing user header",
 			StatusCode: http.StatusUnauthorized,
 		}
 	}
-	userSignature := req.Header.Get(X_LILYPAD_SIGNATURE_HEADER)
 	if userSignature == "" {
 		return "", HTTPError{
 			Message:    "missing signature header",
@@ -183,7 +222,7 @@ func CorsMiddleware(next http.Handler) http.Handler {
 }
 
 func URL(options ClientOptions, path string) string {
-	return fmt.Sprintf("%s%s%s", options.URL, API_SUB_PATH, path)
+	return fmt.Sprintf("%s%s%s", options.URL, GetAPISubPath(getClientProtocolVersion(options)), path)
 }
 
 func WebsocketURL(options ClientOptions, path string) string {
@@ -193,9 +232,15 @@ func WebsocketURL(options ClientOptions, path string) string {
 type httpGetWrapper[ResultType any] func(res http.ResponseWriter, req *http.Request) (ResultType, error)
 type httpPostWrapper[RequestType any, ResultType any] func(data RequestType, res http.ResponseWriter, req *http.Request) (ResultType, error)
 
+// the body is put back once read so a signature over it can still be checked
 func ReadBody[T any](req *http.Request) (T, error) {
 	var data T
-	err := json.NewDecoder(req.Body).Decode(&data)
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return data, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	err = json.NewDecoder(bytes.NewReader(body)).Decode(&data)
 	if err != nil {
 		return data, err
 	}
@@ -212,12 +257,7 @@ func GetHandler[T any](handler httpGetWrapper[T]) func(res http.ResponseWriter,
 				Str("method GET", req.URL.String()).
 				Err(err).
 				Msgf("")
-			httpError, ok := err.(HTTPError)
-			if ok {
-				http.Error(res, httpError.Error(), httpError.StatusCode)
-			} else {
-				http.Error(res, err.Error(), http.StatusInternalServerError)
-			}
+			WriteError(res, req, err)
 			return
 		} else {
 			// get is trace because it does not mutate
@@ -240,7 +280,10 @@ func PostHandler[RequestType any, ResultType any](handler httpPostWrapper[Reques
 	ret := func(res http.ResponseWriter, req *http.Request) {
 		requestBody, err := ReadBody[RequestType](req)
 		if err != nil {
-			http.Error(res, fmt.Sprintf("Error parsing request body"), http.StatusBadRequest)
+			WriteError(res, req, HTTPError{
+				Message:    fmt.Sprintf("error parsing request body: %s", err.Error()),
+				StatusCode: http.StatusBadRequest,
+			})
 			return
 		}
 		data, err := handler(requestBody, res, req)
@@ -249,12 +292,7 @@ func PostHandler[RequestType any, ResultType any](handler httpPostWrapper[Reques
 				Str("method POST", req.URL.String()).
 				Err(err).
 				Msgf("")
-			httpError, ok := err.(HTTPError)
-			if ok {
-				http.Error(res, httpError.Error(), httpError.StatusCode)
-			} else {
-				http.Error(res, err.Error(), http.StatusInternalServerError)
-			}
+			WriteError(res, req, err)
 			return
 		} else {
 			// post is debug because it does mutate
@@ -297,10 +335,47 @@ func GetRequest[ResultType any](
 	return result, nil
 }
 
+// a GET request with the same user headers as a POST request
+// for routes that only answer to certain addresses
+func SignedGetRequest[ResultType any](
+	options ClientOptions,
+	path string,
+	queryParams map[string]string,
+) (ResultType, error) {
+	var result ResultType
+	buf, err := getRequestBuffer(options, path, queryParams, true)
+	if err != nil {
+		return result, err
+	}
+	err = json.Unmarshal(buf.Bytes(), &result)
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
 func GetRequestBuffer(
 	options ClientOptions,
 	path string,
 	queryParams map[string]string,
+) (*bytes.Buffer, error) {
+	return getRequestBuffer(options, path, queryParams, false)
+}
+
+// for signed routes that answer with something other than JSON
+func SignedGetRequestBuffer(
+	options ClientOptions,
+	path string,
+	queryParams map[string]string,
+) (*bytes.Buffer, error) {
+	return getRequestBuffer(options, path, queryParams, true)
+}
+
+func getRequestBuffer(
+	options ClientOptions,
+	path string,
+	queryParams map[string]string,
+	signed bool,
 ) (*bytes.Buffer, error) {
 	client := newRetryClient()
 
@@ -319,6 +394,18 @@ func GetRequestBuffer(
 	if err != nil {
 		return nil, err
 	}
+	if signed {
+		signer, err := GetClientSigner(options)
+		if err != nil {
+			return nil, err
+		}
+		err = AddHeaders(req, signer, signer.Address().String())
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		req.Header.Add(X_LILYPAD_PROTOCOL_HEADER, strconv.Itoa(data.PROTOCOL_VERSION))
+	}
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -331,6 +418,9 @@ func GetRequestBuffer(
 	if err != nil {
 		return nil, err
 	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return nil, ReadErrorResponse(resp.StatusCode, buf.Bytes())
+	}
 
 	return &buf, nil
 }
@@ -372,6 +462,14 @@ func PostRequest[RequestType any, ResultType any](
 	)
 }
 
+// the signer from the options or one for the private key if there is not one
+func GetClientSigner(options ClientOptions) (web3.Signer, error) {
+	if options.Signer != nil {
+		return options.Signer, nil
+	}
+	return web3.NewPrivateKeySigner(options.PrivateKey)
+}
+
 func PostRequestBuffer[ResultType any](
 	options ClientOptions,
 	path string,
@@ -379,7 +477,7 @@ func PostRequestBuffer[ResultType any](
 ) (ResultType, error) {
 	var result ResultType
 	client := newRetryClient()
-	privateKey, err := web3.ParsePrivateKey(options.PrivateKey)
+	signer, err := GetClientSigner(options)
 	if err != nil {
 		return result, err
 	}
@@ -387,7 +485,10 @@ func PostRequestBuffer[ResultType any](
 	if err != nil {
 		return result, err
 	}
-	AddHeaders(req, privateKey, web3.GetAddress(privateKey).String())
+	err = AddHeaders(req, signer, signer.Address().String())
+	if err != nil {
+		return result, err
+	}
 	resp, err := client.Do(req)
 	if err != nil {
 		return result, err
@@ -399,6 +500,9 @@ func PostRequestBuffer[ResultType any](
 		log.Debug().Msgf("[debug] error while reading. response body: %s", body)
 		return result, err
 	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return result, ReadErrorResponse(resp.StatusCode, body)
+	}
 
 	// parse body as json into result
 	err = json.Unmarshal(body, &result)
@@ -431,6 +535,10 @@ func newRetryClient() *retryablehttp.Client {
 	}
 	// Return custom error with response body
 	retryClient.ErrorHandler = func(resp *http.Response, err error, numTries int) (*http.Response, error) {
+		// there is no response if we never managed to connect
+		if resp == nil {
+			return nil, fmt.Errorf("gave up after %d attempt(s): %w", numTries, err)
+		}
 		body, err := io.ReadAll(resp.Body)
 		defer resp.Body.Close()
 		if err != nil {
@@ -0,0 +1,148 @@
+package ipfs
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	dag "github.com/ipfs/boxo/ipld/merkledag"
+	"github.com/ipfs/boxo/ipld/unixfs"
+	pb "github.com/ipfs/boxo/ipld/unixfs/pb"
+	"github.com/ipfs/go-cid"
+)
+
+// fetches the raw bytes of a block
+type BlockGetter func(ctx context.Context, id cid.Cid) ([]byte, error)
+
+// fetch a block and check its bytes hash to its CID
+// so we do not have to trust whoever handed it to us
+func GetVerifiedBlock(ctx context.Context, getBlock BlockGetter, id cid.Cid) ([]byte, error) {
+	block, err := getBlock(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %s: %w", id, err)
+	}
+	sum, err := id.Prefix().Sum(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash block %s: %w", id, err)
+	}
+	if !sum.Equals(id) {
+		return nil, fmt.Errorf("block %s does not match its CID", id)
+	}
+	return block, nil
+}
+
+// write the unixfs files under root to w as a tar archive
+// each block is checked as it is fetched and we stop at the first
+// one that does not match so a partly written archive cannot be trusted
+func WriteVerifiedTar(ctx context.Context, getBlock BlockGetter, root cid.Cid, w io.Writer) error {
+	tw := tar.NewWriter(w)
+	err := writeTarNode(ctx, getBlock, tw, root, "", true)
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+func writeTarNode(ctx context.Context, getBlock BlockGetter, tw *tar.Writer, id cid.Cid, name string, root bool) error {
+	block, err := GetVerifiedBlock(ctx, getBlock, id)
+	if err != nil {
+		return err
+	}
+	// a root that is a single file is named after its CID
+	if root {
+		name = id.String()
+	}
+
+	if id.Type() == cid.Raw {
+		err = tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Size: int64(len(block)), Mode: 0644})
+		if err != nil {
+			return err
+		}
+		_, err = tw.Write(block)
+		return err
+	}
+	if id.Type() != cid.DagProtobuf {
+		return fmt.Errorf("block %s is not unixfs", id)
+	}
+
+	node, err := dag.DecodeProtobuf(block)
+	if err != nil {
+		return fmt.Errorf("failed to decode block %s: %w", id, err)
+	}
+	fsNode, err := unixfs.FSNodeFromBytes(node.Data())
+	if err != nil {
+		return fmt.Errorf("failed to decode unixfs node %s: %w", id, err)
+	}
+
+	switch fsNode.Type() {
+	case pb.Data_Directory:
+		// the root directory is the archive itself
+		if root {
+			name = ""
+		} else {
+			err = tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: name + "/", Mode: 0755})
+			if err != nil {
+				return err
+			}
+		}
+		for _, link := range node.Links() {
+			if link.Name == "" || link.Name == "." || link.Name == ".." || strings.Contains(link.Name, "/") {
+				return fmt.Errorf("directory %s has an invalid entry %q", id, link.Name)
+			}
+			err = writeTarNode(ctx, getBlock, tw, link.Cid, path.Join(name, link.Name), false)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case pb.Data_File, pb.Data_Raw:
+		err = tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: name, Size: int64(fsNode.FileSize()), Mode: 0644})
+		if err != nil {
+			return err
+		}
+		// the tar writer refuses more bytes than the header said
+		// and errors on the next entry if we wrote fewer
+		return writeFileData(ctx, getBlock, tw, node, fsNode)
+	case pb.Data_Symlink:
+		return tw.WriteHeader(&tar.Header{Typeflag: tar.TypeSymlink, Name: name, Linkname: string(fsNode.Data()), Mode: 0777})
+	default:
+		return fmt.Errorf("unixfs node %s has unsupported type %s", id, fsNode.Type())
+	}
+}
+
+// a file is the data in its own node followed by each of its children in order
+func writeFileData(ctx context.Context, getBlock BlockGetter, w io.Writer, node *dag.ProtoNode, fsNode *unixfs.FSNode) error {
+	_, err := w.Write(fsNode.Data())
+	if err != nil {
+		return err
+	}
+	for _, link := range node.Links() {
+		block, err := GetVerifiedBlock(ctx, getBlock, link.Cid)
+		if err != nil {
+			return err
+		}
+		if link.Cid.Type() == cid.Raw {
+			_, err = w.Write(block)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		child, err := dag.DecodeProtobuf(block)
+		if err != nil {
+			return fmt.Errorf("failed to decode block %s: %w", link.Cid, err)
+		}
+		childFSNode, err := unixfs.FSNodeFromBytes(child.Data())
+		if err != nil {
+			return fmt.Errorf("failed to decode unixfs node %s: %w", link.Cid, err)
+		}
+		err = writeFileData(ctx, getBlock, w, child, childFSNode)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
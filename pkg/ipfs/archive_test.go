@@ -0,0 +1,92 @@
+package ipfs
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	dag "github.com/ipfs/boxo/ipld/merkledag"
+	"github.com/ipfs/boxo/ipld/unixfs"
+	pb "github.com/ipfs/boxo/ipld/unixfs/pb"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+)
+
+// a results folder with a small file and a file split over two raw leaves
+func getTestDAG(t *testing.T) (cid.Cid, map[cid.Cid][]byte, cid.Cid) {
+	blocks := map[cid.Cid][]byte{}
+
+	leaf1 := dag.NewRawNode([]byte("hello "))
+	leaf2 := dag.NewRawNode([]byte("world"))
+	fileNode := unixfs.NewFSNode(pb.Data_File)
+	fileNode.AddBlockSize(6)
+	fileNode.AddBlockSize(5)
+	fileData, err := fileNode.GetBytes()
+	assert.NoError(t, err)
+	file := dag.NodeWithData(fileData)
+	assert.NoError(t, file.AddNodeLink("", leaf1))
+	assert.NoError(t, file.AddNodeLink("", leaf2))
+
+	exitCode := dag.NodeWithData(unixfs.FilePBData([]byte("0"), 1))
+
+	outputs := unixfs.EmptyDirNode()
+	assert.NoError(t, outputs.AddNodeLink("result.txt", file))
+	root := unixfs.EmptyDirNode()
+	assert.NoError(t, root.AddNodeLink("exitCode", exitCode))
+	assert.NoError(t, root.AddNodeLink("outputs", outputs))
+
+	for _, node := range []interface {
+		Cid() cid.Cid
+		RawData() []byte
+	}{leaf1, leaf2, file, exitCode, outputs, root} {
+		blocks[node.Cid()] = node.RawData()
+	}
+	return root.Cid(), blocks, leaf2.Cid()
+}
+
+func getBlocks(blocks map[cid.Cid][]byte) BlockGetter {
+	return func(ctx context.Context, id cid.Cid) ([]byte, error) {
+		block, ok := blocks[id]
+		if !ok {
+			return nil, fmt.Errorf("block not found")
+		}
+		return block, nil
+	}
+}
+
+func TestWriteVerifiedTar(t *testing.T) {
+	root, blocks, _ := getTestDAG(t)
+
+	var buf bytes.Buffer
+	err := WriteVerifiedTar(context.Background(), getBlocks(blocks), root, &buf)
+	assert.NoError(t, err)
+
+	files := map[string]string{}
+	tr := tar.NewReader(&buf)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		assert.NoError(t, err)
+		files[header.Name] = string(content)
+	}
+	assert.Equal(t, map[string]string{
+		"exitCode":           "0",
+		"outputs/":           "",
+		"outputs/result.txt": "hello world",
+	}, files)
+}
+
+func TestWriteVerifiedTarBadBlock(t *testing.T) {
+	root, blocks, leaf := getTestDAG(t)
+	blocks[leaf] = []byte("w0rld")
+
+	err := WriteVerifiedTar(context.Background(), getBlocks(blocks), root, io.Discard)
+	assert.ErrorContains(t, err, "does not match its CID")
+}
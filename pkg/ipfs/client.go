@@ -3,7 +3,9 @@ package ipfs
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
 
 	"github.com/ipfs/boxo/files"
 	boxopath "github.com/ipfs/boxo/path"
@@ -15,6 +17,7 @@ import (
 
 type Client struct {
 	API  coreiface.CoreAPI
+	rpc  *httpapi.HttpApi
 	addr string
 }
 
@@ -39,6 +42,7 @@ func NewClient(ctx context.Context, apiAddr string) (*Client, error) {
 
 	client := &Client{
 		API:  api,
+		rpc:  api,
 		addr: apiAddr,
 	}
 
@@ -62,6 +66,54 @@ func (c *Client) Get(ctx context.Context, cidString string, outputPath string) e
 	return nil
 }
 
+// add the file or folder to the node and return its CID
+func (c *Client) Put(ctx context.Context, localPath string) (string, error) {
+	stat, err := os.Stat(localPath)
+	if err != nil {
+		return "", err
+	}
+	node, err := files.NewSerialFile(localPath, true, stat)
+	if err != nil {
+		return "", fmt.Errorf("failed to read '%s': %w", localPath, err)
+	}
+	defer node.Close()
+	path, err := c.API.Unixfs().Add(ctx, node)
+	if err != nil {
+		return "", fmt.Errorf("failed to add '%s': %w", localPath, err)
+	}
+	return path.RootCid().String(), nil
+}
+
+// the total size in bytes of the file or folder behind the CID
+// the node has to find at least the root of it so this also tells us it can be fetched
+func (c *Client) Stat(ctx context.Context, cidString string) (uint64, error) {
+	path, err := cidToPath(cidString)
+	if err != nil {
+		return 0, err
+	}
+	var stat struct {
+		CumulativeSize uint64
+	}
+	err = c.rpc.Request("files/stat", path.String()).Exec(ctx, &stat)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat '%s': %w", path, err)
+	}
+	return stat.CumulativeSize, nil
+}
+
+// fetch every block of the CID onto the node and keep it there
+func (c *Client) Pin(ctx context.Context, cidString string) error {
+	path, err := cidToPath(cidString)
+	if err != nil {
+		return err
+	}
+	err = c.API.Pin().Add(ctx, path)
+	if err != nil {
+		return fmt.Errorf("failed to pin '%s': %w", path, err)
+	}
+	return nil
+}
+
 func cidToPath(cidString string) (boxopath.Path, error) {
 	c, err := cid.Decode(cidString)
 	if err != nil {
@@ -70,3 +122,13 @@ func cidToPath(cidString string) (boxopath.Path, error) {
 
 	return boxopath.FromCid(c), nil
 }
+
+// the raw bytes of a block from the node
+// use GetVerifiedBlock unless you trust the node
+func (c *Client) GetBlock(ctx context.Context, id cid.Cid) ([]byte, error) {
+	reader, err := c.API.Block().Get(ctx, boxopath.FromCid(id))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(reader)
+}
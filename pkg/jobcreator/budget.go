@@ -0,0 +1,30 @@
+package jobcreator
+
+import (
+	"fmt"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+// what we have spent and have left, held to the lower of
+// our own limits and the ones the solver has for us
+func (controller *JobCreatorController) GetBudget() (data.BudgetStatus, error) {
+	status, err := controller.solverClient.GetBudget(controller.web3SDK.GetAddress().String())
+	if err != nil {
+		return status, err
+	}
+	return status.WithLimits(controller.options.Budget.Daily, controller.options.Budget.Weekly), nil
+}
+
+// refuse to submit jobs that could take us over our own budget
+// the solver checks its budget for us itself
+func (controller *JobCreatorController) checkBudget(cost uint64) error {
+	if controller.options.Budget.Daily == 0 && controller.options.Budget.Weekly == 0 {
+		return nil
+	}
+	status, err := controller.GetBudget()
+	if err != nil {
+		return fmt.Errorf("error getting our spend from the solver: %s", err.Error())
+	}
+	return data.CheckBudget(status, cost)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/lilypad-tech/lilypad/pkg/data"
@@ -30,6 +31,11 @@ type JobCreatorController struct {
 	log                   *system.ServiceLogger
 	jobOfferSubscriptions []JobOfferSubscriber
 	tracer                trace.Tracer
+	parameters            *web3.ProtocolParametersCache
+	// the encrypted inputs of our job offers keyed by job offer ID
+	// these never leave this process unencrypted
+	encryptedInputsMutex sync.Mutex
+	encryptedInputs      map[string]map[string]string
 }
 
 // the background "even if we have not heard of an event" loop
@@ -52,16 +58,28 @@ func NewJobCreatorController(
 	solverClient, err := solver.NewSolverClient(
 		http.ClientOptions{
 			URL:           solverUrl,
-			PrivateKey:    options.Web3.PrivateKey,
+			Signer:        web3SDK.GetSigner(),
 			Type:          "JobCreator",
 			PublicAddress: web3SDK.GetAddress().String(),
 		})
 	if err != nil {
 		return nil, err
 	}
+	if options.Bus.URL != "" {
+		err = solverClient.UseBus(options.Bus)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	metricsDashboard.Init(options.Offer.Services.APIHost)
 
+	parameters := web3.NewProtocolParametersCache()
+	err = parameters.Load(web3SDK)
+	if err != nil {
+		return nil, err
+	}
+
 	controller := &JobCreatorController{
 		solverClient:          solverClient,
 		options:               options,
@@ -70,6 +88,8 @@ func NewJobCreatorController(
 		log:                   system.NewServiceLogger(system.JobCreatorService),
 		jobOfferSubscriptions: []JobOfferSubscriber{},
 		tracer:                tracer,
+		parameters:            parameters,
+		encryptedInputs:       map[string]map[string]string{},
 	}
 	return controller, nil
 }
@@ -87,10 +107,94 @@ func NewJobCreatorController(
 */
 
 func (controller *JobCreatorController) AddJobOffer(offer data.JobOffer) (data.JobOfferContainer, error) {
+	offer = controller.prepareJobOffer(offer)
+	err := controller.checkBudget(data.GetJobOfferReservation(offer))
+	if err != nil {
+		return data.JobOfferContainer{}, err
+	}
 	controller.log.Debug("add job offer", offer)
 	return controller.solverClient.AddJobOffer(offer)
 }
 
+// the inputs are kept until the offer is matched and then
+// sent to the solver encrypted for the resource provider
+func (controller *JobCreatorController) AddJobOfferWithEncryptedInputs(offer data.JobOffer, inputs map[string]string) (data.JobOfferContainer, error) {
+	err := data.CheckEncryptedInputValues(offer, inputs)
+	if err != nil {
+		return data.JobOfferContainer{}, err
+	}
+	container, err := controller.AddJobOffer(offer)
+	if err != nil {
+		return container, err
+	}
+	controller.encryptedInputsMutex.Lock()
+	defer controller.encryptedInputsMutex.Unlock()
+	controller.encryptedInputs[container.ID] = inputs
+	return container, nil
+}
+
+func (controller *JobCreatorController) AddJobGroup(offer data.JobOffer, parameter string, values []string) (data.JobGroup, error) {
+	submission := data.JobGroupSubmission{
+		JobOffer:  controller.prepareJobOffer(offer),
+		Parameter: parameter,
+		Values:    values,
+	}
+	// the group is a job offer for each value
+	err := controller.checkBudget(data.GetJobOfferReservation(submission.JobOffer) * uint64(len(values)))
+	if err != nil {
+		return data.JobGroup{}, err
+	}
+	controller.log.Debug("add job group", submission)
+	return controller.solverClient.AddJobGroup(submission)
+}
+
+func (controller *JobCreatorController) AddJobSchedule(offer data.JobOffer, schedule JobCreatorScheduleOptions) (data.JobSchedule, error) {
+	submission := data.JobScheduleSubmission{
+		JobOffer:      controller.prepareJobOffer(offer),
+		Cron:          schedule.Cron,
+		OverlapPolicy: schedule.OverlapPolicy,
+	}
+	controller.log.Debug("add job schedule", submission)
+	return controller.solverClient.AddJobSchedule(submission)
+}
+
+func (controller *JobCreatorController) AddCapacityReservation(offer data.JobOffer, resourceProvider string, count int, startAt int64, endAt int64) (data.CapacityReservation, error) {
+	submission := data.CapacityReservationSubmission{
+		JobOffer:         controller.prepareJobOffer(offer),
+		ResourceProvider: resourceProvider,
+		Count:            count,
+		StartAt:          startAt,
+		EndAt:            endAt,
+	}
+	controller.log.Debug("add capacity reservation", submission)
+	return controller.solverClient.AddCapacityReservation(submission)
+}
+
+func (controller *JobCreatorController) AddWorkflow(stages []data.WorkflowStage, failurePolicy string) (data.Workflow, error) {
+	submission := data.WorkflowSubmission{
+		JobCreator:    controller.web3SDK.GetAddress().String(),
+		FailurePolicy: failurePolicy,
+	}
+	for _, stage := range stages {
+		stage.JobOffer = controller.prepareJobOffer(stage.JobOffer)
+		submission.Stages = append(submission.Stages, stage)
+	}
+	controller.log.Debug("add workflow", submission)
+	return controller.solverClient.AddWorkflow(submission)
+}
+
+func (controller *JobCreatorController) prepareJobOffer(offer data.JobOffer) data.JobOffer {
+	// the network wide timeouts and pricing floors take precedence
+	params := controller.parameters.Get()
+	offer.Timeouts = params.ApplyTimeouts(offer.Timeouts)
+	offer.Pricing = params.ApplyPricing(offer.Pricing)
+	// the solver may serve more than one chain so say which one we are on
+	if offer.ChainID == 0 {
+		offer.ChainID = controller.options.Web3.ChainID
+	}
+	return offer
+}
+
 func (controller *JobCreatorController) SubscribeToJobOfferUpdates(sub JobOfferSubscriber) {
 	controller.jobOfferSubscriptions = append(controller.jobOfferSubscriptions, sub)
 }
@@ -160,6 +264,7 @@ func (controller *JobCreatorController) subscribeToWeb3() error {
 		system.DumpObjectDebug(ev)
 		controller.loop.Trigger()
 	})
+	controller.parameters.Subscribe(controller.web3SDK, controller.web3Events)
 	return nil
 }
 
@@ -175,6 +280,7 @@ func (controller *JobCreatorController) Start(ctx context.Context, cm *system.Cl
 		errorChan <- err
 		return errorChan
 	}
+	controller.startWebhooks(ctx)
 
 	// this connects the websocket client
 	err = controller.solverClient.Start(ctx, cm)
@@ -224,7 +330,11 @@ func (controller *JobCreatorController) Start(ctx context.Context, cm *system.Cl
 
 func (controller *JobCreatorController) solve() error {
 	controller.log.Debug("solving", "")
-	err := controller.agreeToDeals()
+	err := controller.sendEncryptedInputs()
+	if err != nil {
+		return err
+	}
+	err = controller.agreeToDeals()
 	if err != nil {
 		return err
 	}
@@ -247,6 +357,44 @@ func (controller *JobCreatorController) solve() error {
  *
 */
 
+// send the encrypted inputs of our matched deals
+// the resource provider will not agree to the deal without them
+func (controller *JobCreatorController) sendEncryptedInputs() error {
+	matchedDeals, err := controller.solverClient.GetDealsWithFilter(
+		store.GetDealsQuery{
+			JobCreator: controller.web3SDK.GetAddress().String(),
+			State:      "DealNegotiating",
+		},
+		func(dealContainer data.DealContainer) bool {
+			return len(dealContainer.Deal.JobOffer.EncryptedInputs) > 0 && dealContainer.EncryptedInputs == nil && dealContainer.CancelledAt == 0
+		},
+	)
+	if err != nil {
+		return err
+	}
+	for _, dealContainer := range matchedDeals {
+		controller.encryptedInputsMutex.Lock()
+		inputs, ok := controller.encryptedInputs[dealContainer.JobOffer]
+		controller.encryptedInputsMutex.Unlock()
+		// the offer was added by another process which has the inputs
+		if !ok {
+			continue
+		}
+		encrypted, err := data.EncryptDealInputs(dealContainer, inputs)
+		if err != nil {
+			controller.log.Error("error encrypting inputs for deal", err)
+			continue
+		}
+		_, err = controller.solverClient.AddDealEncryptedInputs(dealContainer.ID, encrypted)
+		if err != nil {
+			controller.log.Error("error sending encrypted inputs for deal", err)
+			continue
+		}
+		controller.log.Debug("sent encrypted inputs", dealContainer.ID)
+	}
+	return nil
+}
+
 // list the deals we have been assigned to that we have not yet posted and agree tx to the contract for
 func (controller *JobCreatorController) agreeToDeals() error {
 	// load the deals that are in DealNegotiating
@@ -257,8 +405,9 @@ func (controller *JobCreatorController) agreeToDeals() error {
 			State:      "DealNegotiating",
 		},
 		// this is where the solver has found us a match and we need to agree to it
+		// unless we have cancelled it since
 		func(dealContainer data.DealContainer) bool {
-			return dealContainer.Transactions.JobCreator.Agree == ""
+			return dealContainer.Transactions.JobCreator.Agree == "" && dealContainer.CancelledAt == 0
 		},
 	)
 	if err != nil {
@@ -270,6 +419,13 @@ func (controller *JobCreatorController) agreeToDeals() error {
 
 	// map over the deals and agree to them
 	for _, dealContainer := range matchedDeals {
+		// a solver that did not choose the mediator the way it says it did
+		// could have put a mediator it controls on the deal
+		err := data.VerifyMediatorSelection(dealContainer.Deal)
+		if err != nil {
+			controller.log.Error("not agreeing to deal", err)
+			continue
+		}
 		controller.log.Debug("agree", dealContainer)
 		txHash, err := controller.web3SDK.Agree(dealContainer.Deal)
 		if err != nil {
@@ -318,7 +474,16 @@ func (controller *JobCreatorController) checkResults() error {
 
 	for _, dealContainer := range completedDeals {
 		result, err := controller.solverClient.GetResult(dealContainer.ID)
-		if err != nil || result.Error != "" {
+		if err == nil && result.Preempted {
+			// the spot capacity was taken back with the notice we asked for
+			// so there is nothing to dispute, accepting pays for the work done
+			controller.log.Info("deal was preempted", dealContainer.ID)
+			err := controller.acceptResult(dealContainer)
+			if err != nil {
+				controller.log.Error("failed to accept results", err)
+				return err
+			}
+		} else if err != nil || result.Error != "" {
 			// there is an error with the job
 			// accept anyway
 			// TODO: trigger mediation here
@@ -0,0 +1,144 @@
+package jobcreator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+	"github.com/lilypad-tech/lilypad/pkg/web3"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// how often we check on an array job in case we missed an update
+const JOB_GROUP_POLL_INTERVAL = 10 * time.Second
+
+type JobGroupSubscriber func(status data.JobGroupStatus)
+
+type RunJobGroupResults struct {
+	Group data.JobGroupStatus
+	// the jobs that finished with results
+	Results []RunJobResults
+}
+
+// split Seed=1..100 into the input name and its values
+func ParseJobArray(array string) (string, []string, error) {
+	parameter, spec, ok := strings.Cut(array, "=")
+	parameter = strings.TrimSpace(parameter)
+	if !ok || parameter == "" {
+		return "", nil, fmt.Errorf("array %q must look like Input=1..10 or Input=a,b,c", array)
+	}
+	values, err := data.ParseJobGroupValues(spec)
+	if err != nil {
+		return "", nil, err
+	}
+	return parameter, values, nil
+}
+
+// submit an array job and wait for every job in it to finish
+// the job creator agrees to each deal and downloads its results as it would for one job
+func RunJobGroup(
+	ctx *system.CommandContext,
+	options JobCreatorOptions,
+	tracer trace.Tracer,
+	groupSub JobGroupSubscriber,
+) (*RunJobGroupResults, error) {
+	parameter, values, err := ParseJobArray(options.Offer.Array)
+	if err != nil {
+		return nil, err
+	}
+
+	web3SDK, err := web3.NewContractSDK(ctx.Ctx, options.Web3, tracer)
+	if err != nil {
+		return nil, err
+	}
+
+	jobCreatorService, err := NewJobCreator(options, web3SDK, tracer)
+	if err != nil {
+		return nil, err
+	}
+
+	updateChan := make(chan struct{}, 1)
+	jobCreatorService.SubscribeToJobOfferUpdates(func(evOffer data.JobOfferContainer) {
+		if evOffer.JobOffer.GroupID == "" {
+			return
+		}
+		select {
+		case updateChan <- struct{}{}:
+		default:
+		}
+	})
+
+	jobCreatorErrors := jobCreatorService.Start(ctx.Ctx, ctx.Cm)
+
+	// the module is checked with the first value so templates that need the input still load
+	offerOptions := options.Offer
+	offerOptions.Inputs = mergeStringMaps(offerOptions.Inputs, map[string]string{parameter: values[0]})
+	offer, err := jobCreatorService.GetJobOfferFromOptions(offerOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	// wait a short period because we've just started the job creator service
+	time.Sleep(100 * time.Millisecond)
+
+	group, err := jobCreatorService.AddJobGroup(offer, parameter, values)
+	if err != nil {
+		return nil, err
+	}
+	jobCreatorService.controller.log.Debug("job group ID", group.ID)
+
+	ticker := time.NewTicker(JOB_GROUP_POLL_INTERVAL)
+	defer ticker.Stop()
+
+	var status data.JobGroupStatus
+	for {
+		status, err = jobCreatorService.GetJobGroup(group.ID)
+		if err != nil {
+			return nil, err
+		}
+		if groupSub != nil {
+			groupSub(status)
+		}
+		if status.Finished {
+			break
+		}
+		select {
+		case err := <-jobCreatorErrors:
+			return nil, err
+		case <-ctx.Ctx.Done():
+			// nobody is waiting for the results so stop the jobs running
+			for _, jobOffer := range status.JobOffers {
+				if data.IsTerminalAgreementState(jobOffer.State) {
+					continue
+				}
+				_, cancelErr := jobCreatorService.CancelJobOffer(jobOffer.ID)
+				if cancelErr != nil {
+					jobCreatorService.controller.log.Error("failed to cancel job offer", cancelErr)
+				}
+			}
+			return nil, errors.New("job group cancelled by closed context")
+		case <-updateChan:
+		case <-ticker.C:
+		}
+	}
+
+	results := &RunJobGroupResults{Group: status}
+	for _, jobOffer := range status.JobOffers {
+		state := data.DealState(jobOffer.State)
+		if state != data.ResultsAccepted && state != data.MediationAccepted {
+			continue
+		}
+		result, err := jobCreatorService.GetResult(jobOffer.DealID)
+		if err != nil {
+			return nil, err
+		}
+		results.Results = append(results.Results, RunJobResults{
+			JobOffer: jobOffer,
+			Result:   result,
+		})
+	}
+	return results, nil
+}
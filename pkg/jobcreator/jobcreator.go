@@ -2,10 +2,15 @@ package jobcreator
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/lilypad-tech/lilypad/pkg/bus"
 	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/fiat"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
 	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/lilypad-tech/lilypad/pkg/web3"
+	"github.com/lilypad-tech/lilypad/pkg/webhooks"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -18,7 +23,8 @@ type JobCreatorOfferOptions struct {
 	// the module that is wanting to be run
 	// this contains the spec that is required to run the module
 	Module data.ModuleConfig
-	// the required spec hoisted from the module
+	// the least machine the job needs
+	// the module can ask for more than this but not less
 	Spec data.MachineSpec
 	// this will normally be MarketPrice for JC's
 	Mode data.PricingMode
@@ -30,17 +36,96 @@ type JobCreatorOfferOptions struct {
 	Timeouts data.DealTimeouts
 	// the inputs to the module
 	Inputs map[string]string
+	// inputs that are only sent once the job is matched
+	// encrypted for the resource provider that will run it
+	EncryptedInputs map[string]string
+	// the declared size of the job inputs in megabytes
+	InputSize int
+	// the IPFS CIDs the job reads so the solver can check they can be fetched
+	InputCIDs []string
+	// the longest the job can run for in seconds
+	// leave this at zero to use the module default
+	MaxRuntime int
 	// which mediators and directories this RP will trust
 	Services data.ServiceConfig
 	// which node(s) (if any) to target
 	Target data.TargetConfig
+	// only let these resource providers run the job
+	TrustedProviders []string
+	// never let these resource providers run the job
+	ExcludedProviders []string
+	// resource offer labels that must match
+	RequiredLabels map[string]string
+	// resource offer labels we would like to match
+	PreferredLabels map[string]string
+	// refuse to run modules on a floating version like a branch
+	RequirePinnedVersion bool
+	// the CID of a checkpoint to start the job from
+	ResumeFrom string
+	// running the job again with the same key gets back the job
+	// offer it added the first time rather than adding another
+	IdempotencyKey string
+	// a zero size leaves mediation to the one mediator the contract picks
+	MediatorQuorum data.MediatorQuorum
+	// a zero max attempts leaves failed deals for us to run again
+	Retry data.RetryPolicy
+	// hand back the result of an earlier deal of ours for the same work
+	ReuseResults bool
+	// pay for the cpu and gpu time the job uses rather than a price per job
+	Metered bool
+	// a zero port runs the module as a batch job rather than a service
+	Serve data.ServiceDealConfig
+	// environment variables to run the job container with
+	Env map[string]string
+	// only run the job inside one of these kinds of TEE
+	// "any" takes any kind that attests
+	TEETypes []string
+	// only run the job inside a TEE with one of these measurements
+	TEEMeasurements []string
+	// the ERC-20 token to pay in, empty pays in the network token
+	PaymentToken string
+	// pay the resource provider in this many milestones as we
+	// accept checkpoints of the job, 0 pays it all with the result
+	Milestones int
+	// the resource offer classes we will run on, empty is reserved only
+	OfferClasses []string
+	// the least notice a spot resource provider must give in seconds
+	MinPreemptionNotice int
+	// run an array job with one job offer for each value of an input
+	// e.g. Seed=1..100 or Size=small,medium,large
+	Array string
+}
+
+// a job the solver adds again at each tick of a cron expression
+type JobCreatorScheduleOptions struct {
+	Cron string
+	// what happens when the last job is still running at the next tick
+	OverlapPolicy string
+}
+
+// the most we spend in the units of the deal pricing, zero is no cap
+type JobCreatorBudgetOptions struct {
+	Daily  uint64
+	Weekly uint64
 }
 
 type JobCreatorOptions struct {
 	Mediation JobCreatorMediationOptions
 	Offer     JobCreatorOfferOptions
 	Web3      web3.Web3Options
+	Bus       bus.BusOptions
 	Telemetry system.TelemetryOptions
+	// lilypad run prints each state of the deal and its logs as they happen
+	Watch bool
+	// a YAML or JSON file describing the job for lilypad run
+	SpecFile string
+	Schedule JobCreatorScheduleOptions
+	// show what jobs cost in a fiat currency
+	Fiat fiat.Options
+	// where the events of our deals are POSTed
+	Webhooks webhooks.WebhookOptions
+	// the most we will spend, on top of any budget the solver holds us to
+	Budget JobCreatorBudgetOptions
 }
 
 type JobCreator struct {
@@ -71,7 +156,23 @@ func (jobCreator *JobCreator) Start(ctx context.Context, cm *system.CleanupManag
 }
 
 func (jobCreator *JobCreator) GetJobOfferFromOptions(options JobCreatorOfferOptions) (data.JobOffer, error) {
-	return getJobOfferFromOptions(options, jobCreator.web3SDK.GetAddress().String())
+	offer, err := getJobOfferFromOptions(options, jobCreator.web3SDK.GetAddress().String())
+	if err != nil {
+		return data.JobOffer{}, err
+	}
+	// the amounts in the offer are whole tokens so we need the decimals
+	// to put them on chain
+	if options.PaymentToken != "" {
+		decimals, err := jobCreator.web3SDK.GetPaymentTokenDecimals(options.PaymentToken)
+		if err != nil {
+			return data.JobOffer{}, err
+		}
+		offer.PaymentToken = &data.PaymentToken{
+			Address:  options.PaymentToken,
+			Decimals: decimals,
+		}
+	}
+	return offer, nil
 }
 
 // adds the job offer to the solver
@@ -79,6 +180,130 @@ func (jobCreator *JobCreator) AddJobOffer(offer data.JobOffer) (data.JobOfferCon
 	return jobCreator.controller.AddJobOffer(offer)
 }
 
+// adds the job offer and sends its encrypted inputs once it is matched
+// the job creator has to keep running until then
+func (jobCreator *JobCreator) AddJobOfferWithEncryptedInputs(offer data.JobOffer, inputs map[string]string) (data.JobOfferContainer, error) {
+	return jobCreator.controller.AddJobOfferWithEncryptedInputs(offer, inputs)
+}
+
+// adds one job offer for each of the values of the parameter input
+func (jobCreator *JobCreator) AddJobGroup(offer data.JobOffer, parameter string, values []string) (data.JobGroup, error) {
+	return jobCreator.controller.AddJobGroup(offer, parameter, values)
+}
+
+func (jobCreator *JobCreator) GetJobGroup(id string) (data.JobGroupStatus, error) {
+	return jobCreator.controller.solverClient.GetJobGroup(id)
+}
+
+// asks the resource provider to fetch the inputs before we submit the job
+func (jobCreator *JobCreator) AddInputStaging(resourceProvider string, cids []string) (data.InputStaging, error) {
+	return jobCreator.controller.solverClient.AddInputStaging(data.InputStagingRequest{
+		JobCreator:       jobCreator.web3SDK.GetAddress().String(),
+		ResourceProvider: resourceProvider,
+		CIDs:             cids,
+	})
+}
+
+func (jobCreator *JobCreator) GetInputStaging(id string) (data.InputStaging, error) {
+	return jobCreator.controller.solverClient.GetInputStaging(id)
+}
+
+// the staging requests of this job creator
+func (jobCreator *JobCreator) GetInputStagings() ([]data.InputStaging, error) {
+	return jobCreator.controller.solverClient.GetInputStagings(store.GetInputStagingsQuery{
+		JobCreator: jobCreator.web3SDK.GetAddress().String(),
+	})
+}
+
+// the deals metered for our address between from and to (unix seconds)
+// either the ones we paid for or the ones we ran as a resource provider
+func (jobCreator *JobCreator) GetBillingRecords(asResourceProvider bool, from int64, to int64) ([]data.BillingRecord, error) {
+	query := store.GetBillingRecordsQuery{
+		From: from,
+		To:   to,
+	}
+	if asResourceProvider {
+		query.ResourceProvider = jobCreator.web3SDK.GetAddress().String()
+	} else {
+		query.JobCreator = jobCreator.web3SDK.GetAddress().String()
+	}
+	return jobCreator.controller.solverClient.GetBillingRecords(query)
+}
+
+func (jobCreator *JobCreator) GetBudget() (data.BudgetStatus, error) {
+	return jobCreator.controller.GetBudget()
+}
+
+// what the resource offers that could run the job are asking for it
+// so a price can be chosen before the job offer is posted
+func (jobCreator *JobCreator) GetCostEstimate(options JobCreatorOfferOptions, instructions uint64) (data.CostEstimate, error) {
+	offer, err := getJobOfferFromOptions(options, jobCreator.web3SDK.GetAddress().String())
+	if err != nil {
+		return data.CostEstimate{}, err
+	}
+	return jobCreator.controller.solverClient.GetCostEstimate(data.CostEstimateQuery{
+		Module:       offer.Module,
+		Spec:         offer.Spec,
+		Instructions: instructions,
+		ChainID:      jobCreator.controller.options.Web3.ChainID,
+	})
+}
+
+// the solver adds the job offer for each stage once the stages it depends on have results
+func (jobCreator *JobCreator) AddWorkflow(stages []data.WorkflowStage, failurePolicy string) (data.Workflow, error) {
+	return jobCreator.controller.AddWorkflow(stages, failurePolicy)
+}
+
+func (jobCreator *JobCreator) GetWorkflow(id string) (data.Workflow, error) {
+	return jobCreator.controller.solverClient.GetWorkflow(id)
+}
+
+func (jobCreator *JobCreator) CancelWorkflow(id string) (data.Workflow, error) {
+	return jobCreator.controller.solverClient.CancelWorkflow(id)
+}
+
+// the solver adds the job offer at each tick of the cron expression
+func (jobCreator *JobCreator) AddJobSchedule(offer data.JobOffer, schedule JobCreatorScheduleOptions) (data.JobSchedule, error) {
+	return jobCreator.controller.AddJobSchedule(offer, schedule)
+}
+
+// the recurring jobs of this job creator
+func (jobCreator *JobCreator) GetJobSchedules() ([]data.JobSchedule, error) {
+	return jobCreator.controller.solverClient.GetJobSchedules(store.GetJobSchedulesQuery{
+		JobCreator: jobCreator.web3SDK.GetAddress().String(),
+	})
+}
+
+func (jobCreator *JobCreator) UpdateJobSchedule(id string, update data.JobScheduleUpdate) (data.JobSchedule, error) {
+	return jobCreator.controller.solverClient.UpdateJobSchedule(id, update)
+}
+
+func (jobCreator *JobCreator) RemoveJobSchedule(id string) (data.JobSchedule, error) {
+	return jobCreator.controller.solverClient.RemoveJobSchedule(id)
+}
+
+// the solver holds count resource offers of the resource provider between
+// startAt and endAt and adds one job offer for each of them at startAt
+func (jobCreator *JobCreator) AddCapacityReservation(offer data.JobOffer, resourceProvider string, count int, startAt int64, endAt int64) (data.CapacityReservation, error) {
+	return jobCreator.controller.AddCapacityReservation(offer, resourceProvider, count, startAt, endAt)
+}
+
+// the capacity reservations of this job creator
+func (jobCreator *JobCreator) GetCapacityReservations(open bool) ([]data.CapacityReservation, error) {
+	return jobCreator.controller.solverClient.GetCapacityReservations(store.GetCapacityReservationsQuery{
+		JobCreator: jobCreator.web3SDK.GetAddress().String(),
+		Open:       open,
+	})
+}
+
+func (jobCreator *JobCreator) GetCapacityReservation(id string) (data.CapacityReservation, error) {
+	return jobCreator.controller.solverClient.GetCapacityReservation(id)
+}
+
+func (jobCreator *JobCreator) CancelCapacityReservation(id string) (data.CapacityReservation, error) {
+	return jobCreator.controller.solverClient.CancelCapacityReservation(id)
+}
+
 func (jobCreator *JobCreator) SubscribeToJobOfferUpdates(sub JobOfferSubscriber) {
 	jobCreator.controller.SubscribeToJobOfferUpdates(sub)
 }
@@ -86,3 +311,53 @@ func (jobCreator *JobCreator) SubscribeToJobOfferUpdates(sub JobOfferSubscriber)
 func (jobCreator *JobCreator) GetResult(dealId string) (data.Result, error) {
 	return jobCreator.controller.solverClient.GetResult(dealId)
 }
+
+// stop the job wherever it has got to
+func (jobCreator *JobCreator) CancelJobOffer(id string) (data.JobOfferContainer, error) {
+	return jobCreator.controller.solverClient.CancelJobOffer(id)
+}
+
+func (jobCreator *JobCreator) GetDeal(dealId string) (data.DealContainer, error) {
+	return jobCreator.controller.solverClient.GetDeal(dealId)
+}
+
+// add a job offer that runs the job of a deal again
+func (jobCreator *JobCreator) RerunDeal(dealId string, sameProvider bool) (data.JobOfferContainer, error) {
+	return jobCreator.controller.solverClient.RerunDeal(dealId, data.DealRerunRequest{SameProvider: sameProvider})
+}
+
+// the deals a deal was run again from and the deals that ran it again
+func (jobCreator *JobCreator) GetDealLineage(dealId string) (data.DealLineage, error) {
+	return jobCreator.controller.solverClient.GetDealLineage(dealId)
+}
+
+// have the solver pay the resource provider for the checkpoint of a milestone
+// we sign the milestone so the solver can release that much of our collateral and no more
+func (jobCreator *JobCreator) AcceptDealMilestone(dealId string, index uint64) (data.DealContainer, error) {
+	deal, err := jobCreator.controller.solverClient.GetDeal(dealId)
+	if err != nil {
+		return data.DealContainer{}, err
+	}
+	milestone := data.GetDealMilestone(deal, index)
+	if milestone == nil {
+		return data.DealContainer{}, fmt.Errorf("deal %s has no milestone %d", dealId, index)
+	}
+	if milestone.Amount != data.GetMilestoneAmount(deal.Deal).String() {
+		return data.DealContainer{}, fmt.Errorf("milestone %d of deal %s is for %s not the agreed share of the collateral", index, dealId, milestone.Amount)
+	}
+	web3SDK := jobCreator.controller.web3SDK
+	hash, err := web3SDK.GetMilestoneHash(*milestone)
+	if err != nil {
+		return data.DealContainer{}, err
+	}
+	signature, err := web3.SignMilestoneHash(web3SDK.GetSigner(), hash)
+	if err != nil {
+		return data.DealContainer{}, err
+	}
+	return jobCreator.controller.solverClient.AcceptDealMilestone(dealId, index, signature)
+}
+
+// send a request to the module a service deal is running and wait for its answer
+func (jobCreator *JobCreator) SendServiceRequest(dealId string, request data.ServiceRequest) (data.ServiceResponse, error) {
+	return jobCreator.controller.solverClient.SendServiceRequest(dealId, request)
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/davecgh/go-spew/spew"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/lilypad-tech/lilypad/pkg/data"
 	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/lilypad-tech/lilypad/pkg/web3"
@@ -47,13 +49,9 @@ func (jobCreator *OnChainJobCreator) Start(ctx context.Context, cm *system.Clean
 	errorChan := jobCreator.controller.Start(ctx, cm)
 
 	// TODO: work out how to do dynamic pricing
-	tx, err := jobCreator.web3SDK.Contracts.JobCreator.SetRequiredDeposit(jobCreator.web3SDK.TransactOpts, web3.EtherToWei(JOB_PRICE))
-	if err != nil {
-		errorChan <- err
-		return errorChan
-	}
-
-	_, err = jobCreator.web3SDK.WaitTx(ctx, tx)
+	_, err := jobCreator.web3SDK.Transact(ctx, "jobcreator.SetRequiredDeposit", "", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return jobCreator.web3SDK.Contracts.JobCreator.SetRequiredDeposit(opts, web3.EtherToWei(JOB_PRICE))
+	})
 	if err != nil {
 		errorChan <- err
 		return errorChan
@@ -84,12 +82,9 @@ func (jobCreator *OnChainJobCreator) Start(ctx context.Context, cm *system.Clean
 		spew.Dump(result)
 		spew.Dump(int64(onChainID))
 
-		tx, err := jobCreator.web3SDK.Contracts.JobCreator.SubmitResults(jobCreator.web3SDK.TransactOpts, big.NewInt(int64(onChainID)), evOffer.DealID, result.DataID)
-		if err != nil {
-			return
-		}
-
-		_, err = jobCreator.web3SDK.WaitTx(ctx, tx)
+		_, err = jobCreator.web3SDK.Transact(ctx, "jobcreator.SubmitResults", evOffer.DealID, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return jobCreator.web3SDK.Contracts.JobCreator.SubmitResults(opts, big.NewInt(int64(onChainID)), evOffer.DealID, result.DataID)
+		})
 		if err != nil {
 			return
 		}
@@ -98,13 +93,9 @@ func (jobCreator *OnChainJobCreator) Start(ctx context.Context, cm *system.Clean
 	jobCreator.web3Events.JobCreator.SubscribeJobAdded(func(ev jobcreatorweb3.JobcreatorJobAdded) {
 
 		// first we need to move the tokens into our account
-		tx, err := jobCreator.web3SDK.Contracts.Token.TransferFrom(jobCreator.web3SDK.TransactOpts, ev.Payee, jobCreator.web3SDK.GetAddress(), web3.EtherToWei(JOB_PRICE))
-		if err != nil {
-			fmt.Printf("error creating job offer: %s\n", err.Error())
-			return
-		}
-
-		_, err = jobCreator.web3SDK.WaitTx(ctx, tx)
+		_, err := jobCreator.web3SDK.Transact(ctx, "token.TransferFrom", "", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+			return jobCreator.web3SDK.Contracts.Token.TransferFrom(opts, ev.Payee, jobCreator.web3SDK.GetAddress(), web3.EtherToWei(JOB_PRICE))
+		})
 		if err != nil {
 			fmt.Printf("error creating job offer: %s\n", err.Error())
 			return
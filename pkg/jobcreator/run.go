@@ -1,11 +1,15 @@
 package jobcreator
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io/fs"
+	"os"
 	"time"
 
 	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver"
 	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/lilypad-tech/lilypad/pkg/web3"
 	"go.opentelemetry.io/otel/attribute"
@@ -23,6 +27,7 @@ func RunJob(
 	options JobCreatorOptions,
 	tracer trace.Tracer,
 	eventSub JobOfferSubscriber,
+	logSub DealLogSubscriber,
 ) (*RunJobResults, error) {
 	web3SDK, err := web3.NewContractSDK(ctx.Ctx, options.Web3, tracer)
 	if err != nil {
@@ -61,7 +66,12 @@ func RunJob(
 	defer span.End()
 
 	span.AddEvent("add_job_offer.start")
-	jobOfferContainer, err := jobCreatorService.AddJobOffer(offer)
+	var jobOfferContainer data.JobOfferContainer
+	if len(options.Offer.EncryptedInputs) > 0 {
+		jobOfferContainer, err = jobCreatorService.AddJobOfferWithEncryptedInputs(offer, options.Offer.EncryptedInputs)
+	} else {
+		jobOfferContainer, err = jobCreatorService.AddJobOffer(offer)
+	}
 	if err != nil {
 		jobCreatorService.controller.log.Error("failed to add job offer", err)
 		span.SetStatus(codes.Error, "failed to add job offer")
@@ -88,11 +98,24 @@ func RunJob(
 		updateChan <- evOffer
 	})
 
-	var finalJobOffer data.JobOfferContainer
+	// a retry with the same idempotency key gets back the offer it already
+	// added which could be over before we subscribed to it
+	finalJobOffer := jobOfferContainer
+
+	// once we have a deal its logs are followed until the job is over
+	logsCtx, stopLogs := context.WithCancel(ctx.Ctx)
+	logsDone := make(chan struct{})
+	followingLogs := false
+	defer func() {
+		stopLogs()
+		if followingLogs {
+			<-logsDone
+		}
+	}()
 
 	// now we wait on the state of the job
 waitloop:
-	for {
+	for !data.IsTerminalAgreementState(finalJobOffer.State) {
 		select {
 		// this means the job was cancelled
 		case err := <-jobCreatorErrors:
@@ -101,16 +124,35 @@ waitloop:
 			return nil, err
 		case <-ctx.Ctx.Done():
 			err = errors.New("job cancelled by closed context")
+			// nobody is waiting for the results so stop the job running
+			_, cancelErr := jobCreatorService.CancelJobOffer(jobOfferContainer.ID)
+			if cancelErr != nil {
+				jobCreatorService.controller.log.Error("failed to cancel job offer", cancelErr)
+			}
 			span.SetStatus(codes.Error, err.Error())
 			span.RecordError(err)
 			return nil, err
 		case finalJobOffer = <-updateChan:
+			if logSub != nil && !followingLogs && finalJobOffer.DealID != "" {
+				followingLogs = true
+				go func(dealId string) {
+					defer close(logsDone)
+					jobCreatorService.FollowDealLogs(logsCtx, dealId, logSub)
+				}(finalJobOffer.DealID)
+			}
 			if data.IsTerminalAgreementState(finalJobOffer.State) {
 				break waitloop
 			}
 		}
 	}
 
+	// print the last of the logs before we say how the job went
+	stopLogs()
+	if followingLogs {
+		<-logsDone
+		followingLogs = false
+	}
+
 	// Check if our job was cancelled
 	if finalJobOffer.State == data.GetAgreementStateIndex("JobOfferCancelled") {
 		span.SetStatus(codes.Error, "job cancelled")
@@ -118,6 +160,19 @@ waitloop:
 		return nil, fmt.Errorf("job was cancelled")
 	}
 
+	// Check if our job timed out
+	if state := data.DealState(finalJobOffer.State); state.IsTimeout() {
+		err = fmt.Errorf("job timed out: %s", state)
+		// the provider got some of the way so the job need not start again
+		deal, dealErr := jobCreatorService.GetDeal(finalJobOffer.DealID)
+		if dealErr == nil && deal.Checkpoint != nil {
+			err = fmt.Errorf("%s, run it again with --resume-from %s to carry on from its last checkpoint", err.Error(), deal.Checkpoint.CID)
+		}
+		span.SetStatus(codes.Error, "job timed out")
+		span.RecordError(err)
+		return nil, err
+	}
+
 	span.AddEvent("get_result.start")
 	result, err := jobCreatorService.GetResult(finalJobOffer.DealID)
 	if err != nil {
@@ -125,6 +180,18 @@ waitloop:
 	}
 	span.AddEvent("get_result.done", trace.WithAttributes(attribute.String("result.deal_id", result.DealID)))
 
+	// nothing ran for this offer so the files were never downloaded
+	// unless we kept them from the deal the result came from
+	if finalJobOffer.ReusedResult {
+		downloadPath := solver.GetDownloadsFilePath(finalJobOffer.DealID)
+		if _, statErr := os.Stat(downloadPath); errors.Is(statErr, fs.ErrNotExist) {
+			err = jobCreatorService.controller.solverClient.DownloadResultFiles(finalJobOffer.DealID, downloadPath)
+			if err != nil {
+				return nil, fmt.Errorf("error downloading reused results: %s", err.Error())
+			}
+		}
+	}
+
 	return &RunJobResults{
 		JobOffer: finalJobOffer,
 		Result:   result,
@@ -0,0 +1,171 @@
+package jobcreator
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"sigs.k8s.io/yaml"
+)
+
+// a job written down in a YAML or JSON file for lilypad run -f
+// so it can be kept in version control rather than in a long command line
+type JobSpecFile struct {
+	// a shortcut name like cowsay:v0.0.4 or a repo, hash and path
+	Module data.ModuleConfig `json:"module"`
+	Inputs map[string]string `json:"inputs"`
+	// set in the job container on top of the module's own
+	Env map[string]string `json:"env"`
+	// raise the machine the module asks for e.g. for a bigger model
+	Resources  data.MachineSpec `json:"resources"`
+	InputSize  int              `json:"input_size"`
+	InputCIDs  []string         `json:"input_cids"`
+	MaxRuntime int              `json:"max_runtime"`
+	// MarketPrice or FixedPrice
+	Mode data.PricingMode `json:"mode"`
+	// the most we will pay and put up
+	Pricing        data.DealPricing     `json:"pricing"`
+	Timeouts       data.DealTimeouts    `json:"timeouts"`
+	Placement      JobSpecPlacement     `json:"placement"`
+	MediatorQuorum *data.MediatorQuorum `json:"mediator_quorum"`
+	Retry          *data.RetryPolicy    `json:"retry"`
+	ReuseResults   bool                 `json:"reuse_results"`
+	Metered        bool                 `json:"metered"`
+	// run the module as a service we send requests to
+	Serve      *data.ServiceDealConfig `json:"serve"`
+	ResumeFrom string                  `json:"resume_from"`
+	// one job for each value of an input e.g. Seed=1..100
+	Array string `json:"array"`
+}
+
+// which resource providers the job will and will not run on
+type JobSpecPlacement struct {
+	TrustedProviders  []string          `json:"trusted_providers"`
+	ExcludedProviders []string          `json:"excluded_providers"`
+	RequiredLabels    map[string]string `json:"required_labels"`
+	PreferredLabels   map[string]string `json:"preferred_labels"`
+	// only run inside an attested TEE
+	TEE *data.TEERequirement `json:"tee"`
+}
+
+// unknown fields are an error so a typo does not quietly run a different job
+func LoadJobSpecFile(path string) (JobSpecFile, error) {
+	var spec JobSpecFile
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return spec, err
+	}
+	err = yaml.UnmarshalStrict(bs, &spec)
+	if err != nil {
+		return spec, fmt.Errorf("error reading job spec %s: %s", path, err.Error())
+	}
+	return spec, nil
+}
+
+// everything the file sets replaces what is in the options
+// apart from inputs, env and labels where the options are added on top
+// so the same file can be run with different -i flags
+func ApplyJobSpecFile(options JobCreatorOfferOptions, spec JobSpecFile) JobCreatorOfferOptions {
+	if spec.Module.Name != "" || spec.Module.Repo != "" {
+		options.Module = spec.Module
+	}
+	options.Inputs = mergeStringMaps(spec.Inputs, options.Inputs)
+	options.Env = mergeStringMaps(spec.Env, options.Env)
+	options.Spec = spec.Resources
+	if spec.InputSize != 0 {
+		options.InputSize = spec.InputSize
+	}
+	if len(spec.InputCIDs) > 0 {
+		options.InputCIDs = spec.InputCIDs
+	}
+	if spec.MaxRuntime != 0 {
+		options.MaxRuntime = spec.MaxRuntime
+	}
+	if spec.Mode != "" {
+		options.Mode = spec.Mode
+	}
+	options.Pricing = applySpecPricing(options.Pricing, spec.Pricing)
+	options.Timeouts = applySpecTimeouts(options.Timeouts, spec.Timeouts)
+	if len(spec.Placement.TrustedProviders) > 0 {
+		options.TrustedProviders = spec.Placement.TrustedProviders
+	}
+	if len(spec.Placement.ExcludedProviders) > 0 {
+		options.ExcludedProviders = spec.Placement.ExcludedProviders
+	}
+	options.RequiredLabels = mergeStringMaps(spec.Placement.RequiredLabels, options.RequiredLabels)
+	options.PreferredLabels = mergeStringMaps(spec.Placement.PreferredLabels, options.PreferredLabels)
+	if spec.Placement.TEE != nil {
+		options.TEETypes = spec.Placement.TEE.Types
+		options.TEEMeasurements = spec.Placement.TEE.Measurements
+		// an empty list of types in the file means any
+		if len(options.TEETypes) == 0 {
+			options.TEETypes = []string{"any"}
+		}
+	}
+	if spec.MediatorQuorum != nil {
+		options.MediatorQuorum = *spec.MediatorQuorum
+	}
+	if spec.Retry != nil {
+		options.Retry = *spec.Retry
+	}
+	if spec.ReuseResults {
+		options.ReuseResults = true
+	}
+	if spec.Metered {
+		options.Metered = true
+	}
+	if spec.Serve != nil {
+		options.Serve = *spec.Serve
+	}
+	if spec.ResumeFrom != "" {
+		options.ResumeFrom = spec.ResumeFrom
+	}
+	if spec.Array != "" {
+		options.Array = spec.Array
+	}
+	return options
+}
+
+func applySpecPricing(pricing data.DealPricing, spec data.DealPricing) data.DealPricing {
+	if spec.InstructionPrice != 0 {
+		pricing.InstructionPrice = spec.InstructionPrice
+	}
+	if spec.PaymentCollateral != 0 {
+		pricing.PaymentCollateral = spec.PaymentCollateral
+	}
+	if spec.ResultsCollateralMultiple != 0 {
+		pricing.ResultsCollateralMultiple = spec.ResultsCollateralMultiple
+	}
+	if spec.MediationFee != 0 {
+		pricing.MediationFee = spec.MediationFee
+	}
+	return pricing
+}
+
+func applySpecTimeouts(timeouts data.DealTimeouts, spec data.DealTimeouts) data.DealTimeouts {
+	apply := func(timeout data.DealTimeout, spec data.DealTimeout) data.DealTimeout {
+		if spec.Timeout != 0 {
+			timeout.Timeout = spec.Timeout
+		}
+		if spec.Collateral != 0 {
+			timeout.Collateral = spec.Collateral
+		}
+		return timeout
+	}
+	timeouts.Agree = apply(timeouts.Agree, spec.Agree)
+	timeouts.SubmitResults = apply(timeouts.SubmitResults, spec.SubmitResults)
+	timeouts.JudgeResults = apply(timeouts.JudgeResults, spec.JudgeResults)
+	timeouts.MediateResults = apply(timeouts.MediateResults, spec.MediateResults)
+	return timeouts
+}
+
+func mergeStringMaps(base map[string]string, overrides map[string]string) map[string]string {
+	merged := map[string]string{}
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range overrides {
+		merged[key] = value
+	}
+	return merged
+}
@@ -0,0 +1,48 @@
+package jobcreator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobSpecFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "job.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+module:
+  name: cowsay:v0.0.4
+inputs:
+  Message: moo
+  Eyes: oo
+env:
+  SEED: "42"
+resources:
+  ram: 8192
+pricing:
+  instruction_price: 5
+placement:
+  required_labels:
+    region: eu-west
+`), 0644))
+
+	spec, err := LoadJobSpecFile(path)
+	require.NoError(t, err)
+
+	options := ApplyJobSpecFile(JobCreatorOfferOptions{
+		Inputs: map[string]string{"Message": "baa"},
+	}, spec)
+	assert.Equal(t, "cowsay:v0.0.4", options.Module.Name)
+	// the inputs given as flags win over the file
+	assert.Equal(t, map[string]string{"Message": "baa", "Eyes": "oo"}, options.Inputs)
+	assert.Equal(t, map[string]string{"SEED": "42"}, options.Env)
+	assert.Equal(t, 8192, options.Spec.RAM)
+	assert.Equal(t, uint64(5), options.Pricing.InstructionPrice)
+	assert.Equal(t, "eu-west", options.RequiredLabels["region"])
+
+	require.NoError(t, os.WriteFile(path, []byte("modul:\n  name: cowsay:v0.0.4\n"), 0644))
+	_, err = LoadJobSpecFile(path)
+	assert.Error(t, err, "a misspelt field should not be ignored")
+}
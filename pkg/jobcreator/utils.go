@@ -2,10 +2,12 @@ package jobcreator
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/lilypad-tech/lilypad/pkg/data"
 	"github.com/lilypad-tech/lilypad/pkg/module"
+	"github.com/lilypad-tech/lilypad/pkg/system"
 )
 
 // this will load the module in the offer options
@@ -13,22 +15,129 @@ import (
 func getJobOfferFromOptions(options JobCreatorOfferOptions, jobCreatorAddress string) (data.JobOffer, error) {
 	// process the given module so we know what spec the job is asking for
 	// this will also validate the module the user is asking for
-	loadedModule, err := module.LoadModule(options.Module, options.Inputs)
+	// the encrypted inputs are only used here, the offer just names them
+	inputs := mergeStringMaps(options.Inputs, options.EncryptedInputs)
+	loadedModule, err := module.LoadModule(options.Module, inputs)
 	if err != nil {
-		return data.JobOffer{}, fmt.Errorf("error loading module: %s opts=%+v", err.Error(), options)
+		return data.JobOffer{}, fmt.Errorf("error loading module: %s", err.Error())
 	}
+	// the resource provider checks again but we can save paying for a job it will refuse
+	err = module.CheckContractInputs(*loadedModule, inputs)
+	if err != nil {
+		return data.JobOffer{}, err
+	}
+	var encryptedInputs []string
+	for name := range options.EncryptedInputs {
+		encryptedInputs = append(encryptedInputs, name)
+	}
+	sort.Strings(encryptedInputs)
+	network := module.GetNetworkPolicy(*loadedModule)
 
 	return data.JobOffer{
 		// assign CreatedAt to the current millisecond timestamp
 		CreatedAt:  int(time.Now().UnixNano() / int64(time.Millisecond)),
 		JobCreator: jobCreatorAddress,
 		Module:     options.Module,
-		Spec:       loadedModule.Machine,
+		Spec:       raiseMachineSpec(loadedModule.Machine, options.Spec),
 		Inputs:     options.Inputs,
+		InputSize:  options.InputSize,
+		InputCIDs:  options.InputCIDs,
+		MaxRuntime: options.MaxRuntime,
 		Mode:       options.Mode,
 		Pricing:    options.Pricing,
 		Timeouts:   options.Timeouts,
 		Services:   options.Services,
 		Target:     options.Target,
+
+		TrustedProviders:  options.TrustedProviders,
+		ExcludedProviders: options.ExcludedProviders,
+		RequiredLabels:    options.RequiredLabels,
+		PreferredLabels:   options.PreferredLabels,
+		ResumeFrom:        options.ResumeFrom,
+		IdempotencyKey:    options.IdempotencyKey,
+		MediatorQuorum:    GetMediatorQuorum(options),
+		Retry:             GetRetryPolicy(options),
+		ReuseResults:      options.ReuseResults,
+		Metered:           options.Metered,
+		Serve:             GetServiceDealConfig(options),
+		Engine:            module.GetModuleEngine(*loadedModule),
+		Env:               options.Env,
+		EncryptedInputs:   encryptedInputs,
+		TEE:               GetTEERequirement(options),
+		Network:           &network,
+		Milestones:        uint64(options.Milestones),
+
+		OfferClasses:        options.OfferClasses,
+		MinPreemptionNotice: options.MinPreemptionNotice,
+		Client: &data.ClientMetadata{
+			Version:         system.Version,
+			ProtocolVersion: data.PROTOCOL_VERSION,
+			Capabilities:    []string{data.CapabilityEncryptedInputs, data.CapabilityLogs},
+		},
 	}, nil
 }
+
+// the bigger of what the module and the job ask for
+func raiseMachineSpec(spec data.MachineSpec, minimum data.MachineSpec) data.MachineSpec {
+	spec.CPU = max(spec.CPU, minimum.CPU)
+	spec.GPU = max(spec.GPU, minimum.GPU)
+	spec.RAM = max(spec.RAM, minimum.RAM)
+	spec.Disk = max(spec.Disk, minimum.Disk)
+	if len(minimum.GPUs) > 0 {
+		spec.GPUs = minimum.GPUs
+	}
+	return spec
+}
+
+// the trusted execution the job offer asks for
+func GetTEERequirement(options JobCreatorOfferOptions) *data.TEERequirement {
+	if len(options.TEETypes) == 0 && len(options.TEEMeasurements) == 0 {
+		return nil
+	}
+	requirement := data.TEERequirement{
+		Measurements: options.TEEMeasurements,
+	}
+	for _, teeType := range options.TEETypes {
+		if teeType == "any" {
+			requirement.Types = nil
+			break
+		}
+		requirement.Types = append(requirement.Types, teeType)
+	}
+	return &requirement
+}
+
+// the retry policy the job offer asks for
+func GetRetryPolicy(options JobCreatorOfferOptions) *data.RetryPolicy {
+	if options.Retry.MaxAttempts <= 0 {
+		return nil
+	}
+	policy := options.Retry
+	// saying neither means retry whatever went wrong
+	if !policy.OnTimeout && !policy.OnError {
+		policy.OnTimeout = true
+		policy.OnError = true
+	}
+	return &policy
+}
+
+// the service the job offer asks for, if it is one
+func GetServiceDealConfig(options JobCreatorOfferOptions) *data.ServiceDealConfig {
+	if options.Serve.Port == 0 {
+		return nil
+	}
+	serve := options.Serve
+	return &serve
+}
+
+// the quorum the job offer asks for, the threshold defaults to a majority
+func GetMediatorQuorum(options JobCreatorOfferOptions) *data.MediatorQuorum {
+	if options.MediatorQuorum.Size <= 0 {
+		return nil
+	}
+	quorum := options.MediatorQuorum
+	if quorum.Threshold == 0 {
+		quorum.Threshold = quorum.Size/2 + 1 //nolint:gomnd
+	}
+	return &quorum
+}
@@ -0,0 +1,50 @@
+package jobcreator
+
+import (
+	"context"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+// how often we ask the solver for more of a deal's logs when following them
+const LOG_FOLLOW_INTERVAL = 1 * time.Second
+
+type DealLogSubscriber func(chunk data.DealLogChunk)
+
+func (jobCreator *JobCreator) GetDealLogs(dealId string, after uint64) ([]data.DealLogChunk, error) {
+	return jobCreator.controller.solverClient.GetDealLogs(dealId, after)
+}
+
+// hand each new log chunk of the deal to the subscriber until the context closes
+// the logs are fetched one last time once it does so the end of them is not missed
+func (jobCreator *JobCreator) FollowDealLogs(ctx context.Context, dealId string, sub DealLogSubscriber) {
+	var after uint64
+	fetch := func() {
+		chunks, err := jobCreator.GetDealLogs(dealId, after)
+		if err != nil {
+			// the resource provider may not have sent any logs yet
+			jobCreator.controller.log.Debug("error getting deal logs", err)
+			return
+		}
+		for _, chunk := range chunks {
+			if chunk.Sequence <= after {
+				continue
+			}
+			sub(chunk)
+			after = chunk.Sequence
+		}
+	}
+
+	ticker := time.NewTicker(LOG_FOLLOW_INTERVAL)
+	defer ticker.Stop()
+	for {
+		fetch()
+		select {
+		case <-ctx.Done():
+			fetch()
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package jobcreator
+
+import (
+	"context"
+
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+	"github.com/lilypad-tech/lilypad/pkg/webhooks"
+)
+
+// POST the events of our own deals to the webhook URLs
+// this is for job creators whose solver does not send webhooks for them
+func (controller *JobCreatorController) startWebhooks(ctx context.Context) {
+	if !controller.options.Webhooks.Enabled() {
+		return
+	}
+	dispatcher := webhooks.NewDispatcher(system.JobCreatorService, controller.options.Webhooks)
+	dispatcher.Start(ctx)
+	controller.solverClient.SubscribeEvents(func(ev solver.SolverEvent) {
+		event := solver.GetWebhookEvent(ev)
+		if event == "" || ev.Deal.JobCreator != controller.web3SDK.GetAddress().String() {
+			return
+		}
+		dispatcher.Send(event, *ev.Deal)
+	})
+}
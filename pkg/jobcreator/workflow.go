@@ -0,0 +1,182 @@
+package jobcreator
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+	"github.com/lilypad-tech/lilypad/pkg/web3"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/yaml"
+)
+
+// how often we check on a workflow in case we missed an update
+const WORKFLOW_POLL_INTERVAL = 10 * time.Second
+
+type WorkflowSubscriber func(workflow data.Workflow)
+
+// a pipeline of jobs written down for lilypad workflow run
+type WorkflowFile struct {
+	// stop or continue when a stage fails
+	FailurePolicy string              `json:"failure_policy"`
+	Stages        []WorkflowFileStage `json:"stages"`
+}
+
+// each stage is a job spec with the stages it comes after
+type WorkflowFileStage struct {
+	Name      string   `json:"name"`
+	DependsOn []string `json:"depends_on"`
+	// input name -> the stage whose results CID it is set to
+	InputsFrom map[string]string `json:"inputs_from"`
+	JobSpecFile
+}
+
+// a stage with the module resolved and ready to turn into a job offer
+type WorkflowStageOptions struct {
+	Name       string
+	DependsOn  []string
+	InputsFrom map[string]string
+	Offer      JobCreatorOfferOptions
+}
+
+type RunWorkflowResults struct {
+	Workflow data.Workflow
+	// the stages that finished with results
+	Results []RunJobResults
+}
+
+func LoadWorkflowFile(path string) (WorkflowFile, error) {
+	var workflow WorkflowFile
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return workflow, err
+	}
+	err = yaml.UnmarshalStrict(bs, &workflow)
+	if err != nil {
+		return workflow, fmt.Errorf("error reading workflow %s: %s", path, err.Error())
+	}
+	for _, stage := range workflow.Stages {
+		if stage.Array != "" {
+			return workflow, fmt.Errorf("workflow stage %s cannot be an array job", stage.Name)
+		}
+	}
+	return workflow, nil
+}
+
+// submit a workflow and wait for it to finish
+// the job creator agrees to each stage's deal and downloads its results as it would for one job
+func RunWorkflow(
+	ctx *system.CommandContext,
+	options JobCreatorOptions,
+	tracer trace.Tracer,
+	stages []WorkflowStageOptions,
+	failurePolicy string,
+	workflowSub WorkflowSubscriber,
+) (*RunWorkflowResults, error) {
+	web3SDK, err := web3.NewContractSDK(ctx.Ctx, options.Web3, tracer)
+	if err != nil {
+		return nil, err
+	}
+
+	jobCreatorService, err := NewJobCreator(options, web3SDK, tracer)
+	if err != nil {
+		return nil, err
+	}
+
+	updateChan := make(chan struct{}, 1)
+	jobCreatorService.SubscribeToJobOfferUpdates(func(evOffer data.JobOfferContainer) {
+		if evOffer.JobOffer.WorkflowID == "" {
+			return
+		}
+		select {
+		case updateChan <- struct{}{}:
+		default:
+		}
+	})
+
+	jobCreatorErrors := jobCreatorService.Start(ctx.Ctx, ctx.Cm)
+
+	workflowStages := []data.WorkflowStage{}
+	for _, stage := range stages {
+		offer, err := jobCreatorService.GetJobOfferFromOptions(stage.Offer)
+		if err != nil {
+			return nil, fmt.Errorf("stage %s: %s", stage.Name, err.Error())
+		}
+		workflowStages = append(workflowStages, data.WorkflowStage{
+			Name:       stage.Name,
+			JobOffer:   offer,
+			DependsOn:  stage.DependsOn,
+			InputsFrom: stage.InputsFrom,
+		})
+	}
+
+	// wait a short period because we've just started the job creator service
+	time.Sleep(100 * time.Millisecond)
+
+	workflow, err := jobCreatorService.AddWorkflow(workflowStages, failurePolicy)
+	if err != nil {
+		return nil, err
+	}
+	jobCreatorService.controller.log.Debug("workflow ID", workflow.ID)
+
+	ticker := time.NewTicker(WORKFLOW_POLL_INTERVAL)
+	defer ticker.Stop()
+
+	for {
+		workflow, err = jobCreatorService.GetWorkflow(workflow.ID)
+		if err != nil {
+			return nil, err
+		}
+		if workflowSub != nil {
+			workflowSub(workflow)
+		}
+		if workflow.State != data.WorkflowStageRunning {
+			break
+		}
+		select {
+		case err := <-jobCreatorErrors:
+			return nil, err
+		case <-ctx.Ctx.Done():
+			// nobody is waiting for the results so stop the stages running
+			_, cancelErr := jobCreatorService.CancelWorkflow(workflow.ID)
+			if cancelErr != nil {
+				jobCreatorService.controller.log.Error("failed to cancel workflow", cancelErr)
+			}
+			return nil, errors.New("workflow cancelled by closed context")
+		case <-updateChan:
+		case <-ticker.C:
+		}
+	}
+
+	jobOffers, err := jobCreatorService.controller.solverClient.GetJobOffers(store.GetJobOffersQuery{
+		JobCreator:       workflow.JobCreator,
+		IncludeCancelled: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	jobOffersByID := map[string]data.JobOfferContainer{}
+	for _, jobOffer := range jobOffers {
+		jobOffersByID[jobOffer.ID] = jobOffer
+	}
+
+	results := &RunWorkflowResults{Workflow: workflow}
+	for _, status := range workflow.StageStates {
+		if status.State != data.WorkflowStageSucceeded {
+			continue
+		}
+		result, err := jobCreatorService.GetResult(status.DealID)
+		if err != nil {
+			return nil, err
+		}
+		results.Results = append(results.Results, RunJobResults{
+			JobOffer: jobOffersByID[status.JobOffer],
+			Result:   result,
+		})
+	}
+	return results, nil
+}
@@ -6,30 +6,33 @@ import (
 	"sync"
 	"time"
 
+	"github.com/lilypad-tech/lilypad/pkg/alerts"
 	"github.com/lilypad-tech/lilypad/pkg/data"
 	"github.com/lilypad-tech/lilypad/pkg/executor"
-	"github.com/lilypad-tech/lilypad/pkg/http"
-	"github.com/lilypad-tech/lilypad/pkg/module"
+	"github.com/lilypad-tech/lilypad/pkg/ipfs"
 	"github.com/lilypad-tech/lilypad/pkg/solver"
 	"github.com/lilypad-tech/lilypad/pkg/solver/store"
 	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/lilypad-tech/lilypad/pkg/web3"
-	"github.com/rs/zerolog/log"
 )
 
 type MediatorController struct {
-	solverClient *solver.SolverClient
+	solverClient solver.SolverAPI
 	options      MediatorOptions
-	web3SDK      *web3.Web3SDK
+	web3SDK      web3.Web3Client
 	web3Events   *web3.EventChannels
 	loop         *system.ControlLoop
 	log          *system.ServiceLogger
 	executor     executor.Executor
+	// used to fetch the results of resource providers we disagree with
+	ipfsClient *ipfs.Client
 	// keep track of which jobs are running
 	// this is because no remote state will change
 	// whilst we are actually running a job
 	runningJobsMutex sync.RWMutex
 	runningJobs      map[string]bool
+	// the quorum deals we have posted the verdict for on-chain
+	settledDeals map[string]bool
 }
 
 // the background "even if we have not heard of an event" loop
@@ -40,29 +43,11 @@ const CONTROL_LOOP_INTERVAL = 10 * time.Second
 
 func NewMediatorController(
 	options MediatorOptions,
-	web3SDK *web3.Web3SDK,
+	web3SDK web3.Web3Client,
+	solverClient solver.SolverAPI,
 	executor executor.Executor,
+	ipfsClient *ipfs.Client,
 ) (*MediatorController, error) {
-	log.Debug().Msgf("begin NewMediatorController")
-	// we know the address of the solver but what is it's url?
-	solverUrl, err := web3SDK.GetSolverUrl(options.Services.Solver)
-	if err != nil {
-		log.Error().Msgf("error GetSolverUrl")
-		return nil, err
-	}
-
-	solverClient, err := solver.NewSolverClient(
-		http.ClientOptions{
-			URL:           solverUrl,
-			PrivateKey:    options.Web3.PrivateKey,
-			Type:          "Mediator",
-			PublicAddress: web3SDK.GetAddress().String(),
-		})
-	if err != nil {
-		log.Error().Msgf("error NewSolverClient")
-		return nil, err
-	}
-
 	controller := &MediatorController{
 		solverClient: solverClient,
 		options:      options,
@@ -70,7 +55,9 @@ func NewMediatorController(
 		web3Events:   web3.NewEventChannels(),
 		log:          system.NewServiceLogger(system.MediatorService),
 		executor:     executor,
+		ipfsClient:   ipfsClient,
 		runningJobs:  map[string]bool{},
+		settledDeals: map[string]bool{},
 	}
 	return controller, nil
 }
@@ -103,14 +90,15 @@ func NewMediatorController(
 func (controller *MediatorController) subscribeToSolver() error {
 	controller.solverClient.SubscribeEvents(func(ev solver.SolverEvent) {
 		// we need to agree to the deal now we've heard about it
-		if ev.EventType == solver.DealMediatorUpdated {
+		// verdicts from the rest of a quorum can let us settle a deal
+		if ev.EventType == solver.DealMediatorUpdated || ev.EventType == solver.MediationVerdictAdded {
 			if ev.Deal == nil {
 				controller.log.Error("solver event", fmt.Errorf("RP received nil deal"))
 				return
 			}
 
 			// check if this deal is for us
-			if ev.Deal.Mediator != controller.web3SDK.GetAddress().String() {
+			if !controller.isDealMediator(*ev.Deal) {
 				return
 			}
 
@@ -137,8 +125,10 @@ func (controller *MediatorController) Start(ctx context.Context, cm *system.Clea
 		errorChan <- err
 		return errorChan
 	}
+	// tell the operator if anything of ours is slashed
+	controller.getSlashingMonitor().Subscribe(controller.web3Events)
 	// activate the web3 event listeners
-	err = controller.web3Events.Start(controller.web3SDK, ctx, cm)
+	err = controller.web3SDK.StartEvents(controller.web3Events, ctx, cm)
 	if err != nil {
 		errorChan <- err
 		return errorChan
@@ -186,9 +176,25 @@ func (controller *MediatorController) solve() error {
 	if err != nil {
 		return err
 	}
+
+	// settle the deals whose quorum has made up its mind
+	err = controller.settleQuorums()
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
+// the deals we judge are the ones the mediation contract gave us
+// and the ones that need a quorum we are part of
+func (controller *MediatorController) isDealMediator(deal data.DealContainer) bool {
+	address := controller.web3SDK.GetAddress().String()
+	if deal.Mediator == address {
+		return true
+	}
+	return deal.Deal.JobOffer.MediatorQuorum != nil && data.IsDealMediator(deal, address)
+}
+
 /*
  *
  *
@@ -204,10 +210,12 @@ func (controller *MediatorController) solve() error {
 func (controller *MediatorController) runJobs() error {
 	checkedDeals, err := controller.solverClient.GetDealsWithFilter(
 		store.GetDealsQuery{
-			Mediator: controller.web3SDK.GetAddress().String(),
-			State:    "ResultsChecked",
+			State: "ResultsChecked",
 		},
 		func(dealContainer data.DealContainer) bool {
+			if !controller.isDealMediator(dealContainer) {
+				return false
+			}
 			controller.runningJobsMutex.RLock()
 			defer controller.runningJobsMutex.RUnlock()
 			_, ok := controller.runningJobs[dealContainer.ID]
@@ -243,23 +251,24 @@ func (controller *MediatorController) runJob(deal data.DealContainer) {
 		DealID: deal.ID,
 		Error:  "",
 	}
-	err := func() error {
-		module, err := module.LoadModule(deal.Deal.JobOffer.Module, deal.Deal.JobOffer.Inputs)
-		if err != nil {
-			return fmt.Errorf("error loading module: %s", err.Error())
-		}
-		executorResult, err := controller.executor.RunJob(deal, *module)
-		if err != nil {
-			return fmt.Errorf("error running job: %s", err.Error())
-		}
+	record := ReplayRecord{
+		DealID: deal.ID,
+		Module: deal.Deal.JobOffer.Module,
+		Inputs: deal.Deal.JobOffer.Inputs,
+	}
+	executorResult, replayErr := controller.replay(deal, &record)
+	if replayErr != nil {
+		mediatorResult.Error = replayErr.Error()
+		record.Error = replayErr.Error()
+	} else {
 		mediatorResult.InstructionCount = uint64(executorResult.InstructionCount)
+		if deal.Deal.JobOffer.Metered {
+			// this is logged next to what the resource provider metered
+			// the machines differ so the counts are not expected to match
+			mediatorResult.InstructionCount = data.GetMeteredInstructionCount(executorResult.Metering)
+		}
 		mediatorResult.DataID = executorResult.ResultsCID
-
-		return nil
-	}()
-
-	if err != nil {
-		mediatorResult.Error = err.Error()
+		mediatorResult.VariantDigest = executorResult.VariantDigest
 	}
 
 	// we should have the same result as the resource provider posted to the solver
@@ -269,12 +278,16 @@ func (controller *MediatorController) runJob(deal data.DealContainer) {
 		controller.log.Error("error loading existing result for deal", err)
 		return
 	}
+	record.ProviderResultsCID = rpResult.DataID
 
-	isResultCorrect := true
-
-	if rpResult.DataID != mediatorResult.DataID {
-		controller.log.Info("mediation data results different", fmt.Sprintf("deal %s, mediator: %s, rp: %s", deal.ID, mediatorResult.DataID, rpResult.DataID))
-		isResultCorrect = false
+	isResultCorrect, err := controller.compareResults(deal, &record)
+	if err != nil {
+		// we cannot give a verdict on results we could not look at
+		controller.log.Error("error comparing results for deal", err)
+		return
+	}
+	if !isResultCorrect {
+		controller.log.Info("mediation data results different", fmt.Sprintf("deal %s, mediator: %s (%s), rp: %s (%s)", deal.ID, mediatorResult.DataID, record.ResultsHash, rpResult.DataID, record.ProviderResultsHash))
 	}
 
 	if rpResult.InstructionCount != mediatorResult.InstructionCount {
@@ -282,6 +295,71 @@ func (controller *MediatorController) runJob(deal data.DealContainer) {
 		isResultCorrect = false
 	}
 
+	record.Verdict = REPLAY_VERDICT_REJECT
+	if isResultCorrect {
+		record.Verdict = REPLAY_VERDICT_ACCEPT
+	} else {
+		record.ErrorCode = getRejectionCode(replayErr)
+	}
+	err = writeReplayRecord(record)
+	if err != nil {
+		controller.log.Error("error writing replay record for deal", err)
+	}
+
+	// a quorum settles once enough of its mediators agree
+	if deal.Deal.JobOffer.MediatorQuorum != nil {
+		_, err = controller.solverClient.AddMediationVerdict(deal.ID, data.MediationVerdict{
+			Mediator:   controller.web3SDK.GetAddress().String(),
+			Accept:     isResultCorrect,
+			ResultsCID: record.ResultsCID,
+			Code:       record.ErrorCode,
+		})
+		if err != nil {
+			controller.log.Error("error adding mediation verdict for deal", err)
+		}
+		return
+	}
+	controller.settle(deal, isResultCorrect)
+}
+
+// the mediator the contract picked posts the verdict of the quorum
+// if the quorum never makes up its mind the deal times out on-chain
+func (controller *MediatorController) settleQuorums() error {
+	deals, err := controller.solverClient.GetDealsWithFilter(
+		store.GetDealsQuery{
+			Mediator: controller.web3SDK.GetAddress().String(),
+			State:    "ResultsChecked",
+		},
+		func(dealContainer data.DealContainer) bool {
+			if dealContainer.Deal.JobOffer.MediatorQuorum == nil {
+				return false
+			}
+			controller.runningJobsMutex.RLock()
+			defer controller.runningJobsMutex.RUnlock()
+			_, ok := controller.settledDeals[dealContainer.ID]
+			return !ok
+		},
+	)
+	if err != nil {
+		return err
+	}
+	for _, deal := range deals {
+		decided, accept := data.GetMediationOutcome(deal)
+		if !decided {
+			continue
+		}
+		func() {
+			controller.runningJobsMutex.Lock()
+			defer controller.runningJobsMutex.Unlock()
+			controller.settledDeals[deal.ID] = true
+		}()
+		controller.log.Info("mediation quorum decided", fmt.Sprintf("deal %s, accept: %t", deal.ID, accept))
+		go controller.settle(deal, accept)
+	}
+	return nil
+}
+
+func (controller *MediatorController) settle(deal data.DealContainer, isResultCorrect bool) {
 	if isResultCorrect {
 		txHash, err := controller.web3SDK.MediationAcceptResult(
 			deal.Deal.ID,
@@ -316,3 +394,16 @@ func (controller *MediatorController) runJob(deal data.DealContainer) {
 		}
 	}
 }
+
+func (controller *MediatorController) getSlashingMonitor() *alerts.SlashingMonitor {
+	var alerter *alerts.Alerter
+	if controller.options.Alerts.Enabled() {
+		alerter = alerts.NewAlerter(controller.options.Alerts)
+	}
+	return alerts.NewSlashingMonitor(
+		system.MediatorService,
+		controller.web3SDK.GetAddress().String(),
+		controller.solverClient.GetDeal,
+		alerter,
+	)
+}
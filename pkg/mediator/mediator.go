@@ -3,10 +3,12 @@ package mediator
 import (
 	"context"
 
+	"github.com/lilypad-tech/lilypad/pkg/alerts"
 	"github.com/lilypad-tech/lilypad/pkg/data"
 	"github.com/lilypad-tech/lilypad/pkg/executor"
 	"github.com/lilypad-tech/lilypad/pkg/executor/bacalhau"
 	"github.com/lilypad-tech/lilypad/pkg/ipfs"
+	"github.com/lilypad-tech/lilypad/pkg/solver"
 	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/lilypad-tech/lilypad/pkg/web3"
 	"github.com/rs/zerolog/log"
@@ -17,20 +19,29 @@ type MediatorOptions struct {
 	Services data.ServiceConfig
 	Web3     web3.Web3Options
 	IPFS     ipfs.IPFSOptions
+	Replay   MediatorReplayOptions
+	// who to tell when our collateral is slashed
+	Alerts alerts.AlertOptions
 }
 
 type Mediator struct {
-	web3SDK    *web3.Web3SDK
+	web3SDK    web3.Web3Client
 	controller *MediatorController
 }
 
 func NewMediator(
 	options MediatorOptions,
-	web3SDK *web3.Web3SDK,
+	web3SDK web3.Web3Client,
 	executor executor.Executor,
+	ipfsClient *ipfs.Client,
 ) (*Mediator, error) {
+	solverClient, err := solver.NewSolverClientFromWeb3(web3SDK, options.Services.Solver, "Mediator")
+	if err != nil {
+		log.Error().Msgf("error NewSolverClient")
+		return nil, err
+	}
 	log.Debug().Msgf("begin NewMediatorController")
-	controller, err := NewMediatorController(options, web3SDK, executor)
+	controller, err := NewMediatorController(options, web3SDK, solverClient, executor, ipfsClient)
 	log.Debug().Msgf("end NewMediatorController")
 	if err != nil {
 		log.Error().Msgf("error NewMediatorController")
@@ -0,0 +1,235 @@
+package mediator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/executor"
+	"github.com/lilypad-tech/lilypad/pkg/executor/bacalhau"
+	"github.com/lilypad-tech/lilypad/pkg/executor/container"
+	"github.com/lilypad-tech/lilypad/pkg/executor/wasm"
+	"github.com/lilypad-tech/lilypad/pkg/ipfs"
+	"github.com/lilypad-tech/lilypad/pkg/module"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+)
+
+const REPLAYS_DIR = "mediation-replays"
+
+const (
+	REPLAY_EXECUTOR_BACALHAU = "bacalhau"
+	REPLAY_EXECUTOR_DOCKER   = container.RUNTIME_DOCKER
+	REPLAY_EXECUTOR_PODMAN   = container.RUNTIME_PODMAN
+)
+
+var ReplayExecutorTypes = []string{REPLAY_EXECUTOR_BACALHAU, REPLAY_EXECUTOR_DOCKER, REPLAY_EXECUTOR_PODMAN}
+
+const (
+	REPLAY_VERDICT_ACCEPT = "accept"
+	REPLAY_VERDICT_REJECT = "reject"
+)
+
+// how disputed deals are run again
+type MediatorReplayOptions struct {
+	// bacalhau, docker or podman
+	Executor string
+	// docker and podman replays run in this sandbox
+	Sandbox container.SandboxOptions
+	// paths in the results that are left out when they are compared
+	// e.g. logs with timestamps in them
+	IgnoreFiles []string
+	// wasm modules are replayed with this wasmtime binary
+	// whatever the executor is
+	Wasmtime string
+}
+
+func NewReplayExecutor(options MediatorOptions, ipfsClient *ipfs.Client) (executor.Executor, error) {
+	switch options.Replay.Executor {
+	case REPLAY_EXECUTOR_BACALHAU:
+		return bacalhau.NewBacalhauExecutor(options.Bacalhau, ipfsClient)
+	case REPLAY_EXECUTOR_DOCKER, REPLAY_EXECUTOR_PODMAN:
+		return container.NewContainerExecutor(container.ContainerExecutorOptions{
+			Runtime: options.Replay.Executor,
+			Sandbox: options.Replay.Sandbox,
+		}, ipfsClient)
+	default:
+		return nil, fmt.Errorf("unknown replay executor %s", options.Replay.Executor)
+	}
+}
+
+// everything a disputed deal was run again with and what came out
+// it is written next to the results so the verdict can be checked by hand
+type ReplayRecord struct {
+	DealID string `json:"deal_id"`
+	// the module with its tag resolved to the commit that was run
+	Module    data.ModuleConfig `json:"module"`
+	Inputs    map[string]string `json:"inputs"`
+	Image     string            `json:"image"`
+	InputCIDs []string          `json:"input_cids"`
+	Env       []string          `json:"env"`
+	// our results and the results the resource provider posted
+	ResultsCID          string `json:"results_cid"`
+	ResultsHash         string `json:"results_hash"`
+	ProviderResultsCID  string `json:"provider_results_cid"`
+	ProviderResultsHash string `json:"provider_results_hash"`
+	// set if the replay itself failed
+	Error string `json:"error"`
+	// the class of failure the verdict rejected the result for
+	ErrorCode data.ErrorCode `json:"error_code,omitempty"`
+	Verdict   string         `json:"verdict"`
+	CreatedAt int64          `json:"created_at"`
+}
+
+// load the deal's module pinned to a commit and run it again
+// the record is filled in with what was run even if running it fails
+func (controller *MediatorController) replay(deal data.DealContainer, record *ReplayRecord) (*executor.ExecutorResults, error) {
+	pinned, err := module.PinModule(deal.Deal.JobOffer.Module)
+	if err != nil {
+		return nil, fmt.Errorf("error pinning module: %s", err.Error())
+	}
+	record.Module = pinned
+	loadedModule, err := module.LoadModule(pinned, deal.Deal.JobOffer.Inputs)
+	if err != nil {
+		return nil, fmt.Errorf("error loading module: %s", err.Error())
+	}
+	err = module.ApplyDealEnv(loadedModule, deal.ID, deal.Deal.JobOffer.Env, deal.Deal.JobOffer.Inputs)
+	if err != nil {
+		return nil, err
+	}
+	spec := loadedModule.Job.Spec
+	docker, err := container.GetDockerSpec(spec)
+	if err == nil {
+		record.Image = docker.Image
+		record.Env = docker.EnvironmentVariables
+	}
+	for _, input := range spec.Inputs {
+		if input.CID != "" {
+			record.InputCIDs = append(record.InputCIDs, input.CID)
+		}
+	}
+	replayExecutor := controller.executor
+	// wasm modules run the same everywhere so they are replayed on wasmtime
+	if module.GetModuleEngine(*loadedModule) == data.ModuleEngineWasm {
+		wasmSpec, err := module.GetWasmSpec(spec)
+		if err != nil {
+			return nil, err
+		}
+		record.Image = wasmSpec.EntryModule.CID
+		if record.Image == "" {
+			record.Image = wasmSpec.EntryModule.URL
+		}
+		replayExecutor, err = wasm.NewWasmExecutor(wasm.WasmExecutorOptions{Wasmtime: controller.options.Replay.Wasmtime}, controller.ipfsClient)
+		if err != nil {
+			return nil, err
+		}
+	}
+	executorResult, err := replayExecutor.RunJob(deal, *loadedModule)
+	if err != nil {
+		return nil, fmt.Errorf("error running job: %s", err.Error())
+	}
+	record.ResultsCID = executorResult.ResultsCID
+	if executorResult.ResultsDir != "" {
+		record.ResultsHash, err = HashResults(executorResult.ResultsDir, controller.options.Replay.IgnoreFiles)
+		if err != nil {
+			return nil, fmt.Errorf("error hashing results: %s", err.Error())
+		}
+	}
+	return executorResult, nil
+}
+
+// the CIDs of two runs can differ when only the ignored files do
+// so when they are not the same we fetch what the resource provider
+// posted and compare the hashes of the files instead
+func (controller *MediatorController) compareResults(deal data.DealContainer, record *ReplayRecord) (bool, error) {
+	if record.ResultsCID == record.ProviderResultsCID {
+		return true, nil
+	}
+	if controller.ipfsClient == nil || record.ResultsHash == "" || record.ProviderResultsCID == "" {
+		return false, nil
+	}
+	providerDir, err := system.EnsureDataDir(filepath.Join(REPLAYS_DIR, deal.ID, "provider"))
+	if err != nil {
+		return false, err
+	}
+	err = controller.ipfsClient.Get(context.Background(), record.ProviderResultsCID, providerDir)
+	if err != nil {
+		return false, fmt.Errorf("error fetching the resource provider results %s: %s", record.ProviderResultsCID, err.Error())
+	}
+	record.ProviderResultsHash, err = HashResults(providerDir, controller.options.Replay.IgnoreFiles)
+	if err != nil {
+		return false, err
+	}
+	return record.ResultsHash == record.ProviderResultsHash, nil
+}
+
+func writeReplayRecord(record ReplayRecord) error {
+	record.CreatedAt = time.Now().Unix()
+	recordDir, err := system.EnsureDataDir(filepath.Join(REPLAYS_DIR, record.DealID))
+	if err != nil {
+		return err
+	}
+	bs, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+	return system.WriteFile(filepath.Join(recordDir, "replay.json"), bs)
+}
+
+// a hash of the paths and contents of every file in a folder of results
+// the paths are walked in lexical order so the same files give the same hash
+func HashResults(dir string, ignoreFiles []string) (string, error) {
+	ignored := map[string]bool{}
+	for _, name := range ignoreFiles {
+		ignored[filepath.ToSlash(filepath.Clean(name))] = true
+	}
+	hash := sha256.New()
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		name = filepath.ToSlash(name)
+		if ignored[name] {
+			return nil
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(hash, "%s\x00%d\x00", name, info.Size())
+		_, err = io.Copy(hash, file)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// a result is rejected because we could not run the job the way the
+// resource provider says it did or because we got something else
+func getRejectionCode(replayErr error) data.ErrorCode {
+	if replayErr != nil {
+		return data.GetErrorCodeOr(replayErr, data.ErrJobFailed)
+	}
+	return data.ErrResultMismatch
+}
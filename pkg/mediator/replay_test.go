@@ -0,0 +1,64 @@
+package mediator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeResults(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestHashResults(t *testing.T) {
+	results := map[string]string{
+		"stdout":          "hello",
+		"stderr":          "started at 10:00",
+		"outputs/a.txt":   "a",
+		"outputs/b/c.txt": "c",
+	}
+	hash, err := HashResults(writeResults(t, results), nil)
+	require.NoError(t, err)
+
+	same, err := HashResults(writeResults(t, results), nil)
+	require.NoError(t, err)
+	assert.Equal(t, hash, same)
+
+	results["outputs/a.txt"] = "b"
+	changed, err := HashResults(writeResults(t, results), nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, changed)
+
+	// a file moving is a different result even with the same content
+	moved, err := HashResults(writeResults(t, map[string]string{
+		"stdout":          "hello",
+		"stderr":          "started at 10:00",
+		"outputs/a.txt":   "a",
+		"outputs/b/d.txt": "c",
+	}), nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, moved)
+}
+
+func TestHashResultsIgnoreFiles(t *testing.T) {
+	first, err := HashResults(writeResults(t, map[string]string{
+		"stdout": "hello",
+		"stderr": "started at 10:00",
+	}), []string{"stderr"})
+	require.NoError(t, err)
+	second, err := HashResults(writeResults(t, map[string]string{
+		"stdout": "hello",
+		"stderr": "started at 10:05",
+	}), []string{"stderr"})
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+}
@@ -0,0 +1,310 @@
+package module
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+// the contract between a module and the resource provider running it
+//
+// a module that sets "contract" in its template is given:
+//   - its inputs as LILYPAD_INPUT_<NAME> environment variables
+//   - LILYPAD_DEAL_ID, LILYPAD_INPUTS_DIR and LILYPAD_OUTPUTS_DIR
+//   - the data it downloads mounted read only under /inputs
+//
+// and must:
+//   - write its results under /outputs, mounted as the "outputs" output
+//   - exit 0 when it worked, EXIT_INVALID_INPUT when the inputs are no good
+//     and anything else when it failed
+//   - write a result manifest to /outputs/lilypad_result.json if it says it will
+const (
+	CONTRACT_INPUTS_PATH         = "/inputs"
+	CONTRACT_OUTPUTS_PATH        = "/outputs"
+	CONTRACT_OUTPUTS_NAME        = "outputs"
+	CONTRACT_RESULT_MANIFEST     = "lilypad_result.json"
+	CONTRACT_ENV_DEAL_ID         = "LILYPAD_DEAL_ID"
+	CONTRACT_ENV_INPUTS_DIR      = "LILYPAD_INPUTS_DIR"
+	CONTRACT_ENV_OUTPUTS_DIR     = "LILYPAD_OUTPUTS_DIR"
+	CONTRACT_ENV_INPUT_PREFIX    = "LILYPAD_INPUT_"
+	CONTRACT_MANIFEST_VERSION    = 1
+	MAX_CONTRACT_MANIFEST_SIZE   = 1024 * 1024
+	MAX_CONTRACT_MANIFEST_OUTPUT = 1000
+)
+
+// the exit codes a module can use, from sysexits.h
+const (
+	EXIT_SUCCESS = 0
+	// the inputs were no good so running the job again will not help
+	EXIT_INVALID_INPUT = 65
+)
+
+const (
+	ResultManifestSuccess = "success"
+	ResultManifestError   = "error"
+)
+
+// written by the module to say what it produced
+type ResultManifest struct {
+	Version int `json:"version"`
+	// success or error, it has to agree with the exit code
+	Status string `json:"status"`
+	// why the module failed
+	Error string `json:"error,omitempty"`
+	// the files the module wrote, relative to /outputs
+	Outputs []ResultManifestOutput `json:"outputs,omitempty"`
+	// anything the module measured e.g. tokens or images generated
+	Metrics map[string]float64 `json:"metrics,omitempty"`
+}
+
+type ResultManifestOutput struct {
+	Path        string `json:"path"`
+	ContentType string `json:"content_type,omitempty"`
+	// checked against the file when set
+	Size   int64  `json:"size,omitempty"`
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+var contractInputName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// the module keeps to the contract it declares
+func CheckModuleContract(module data.Module) error {
+	contract := module.Contract
+	if contract == nil {
+		return nil
+	}
+	seen := map[string]bool{}
+	for _, input := range contract.Inputs {
+		if !contractInputName.MatchString(input.Name) {
+			return fmt.Errorf("module input %q must be letters, digits and underscores", input.Name)
+		}
+		if seen[input.Name] {
+			return fmt.Errorf("module input %s is declared twice", input.Name)
+		}
+		seen[input.Name] = true
+		if input.Pattern != "" {
+			_, err := regexp.Compile(input.Pattern)
+			if err != nil {
+				return fmt.Errorf("module input %s pattern: %s", input.Name, err.Error())
+			}
+		}
+	}
+	spec := module.Job.Spec
+	for _, input := range spec.Inputs {
+		if !isContractPath(input.Path, CONTRACT_INPUTS_PATH) {
+			return fmt.Errorf("module input %s must be mounted under %s not %s", input.Name, CONTRACT_INPUTS_PATH, input.Path)
+		}
+	}
+	hasOutputs := false
+	for _, output := range spec.Outputs {
+		if output.Name == CONTRACT_OUTPUTS_NAME && path.Clean(output.Path) == CONTRACT_OUTPUTS_PATH {
+			hasOutputs = true
+		}
+	}
+	if !hasOutputs {
+		return fmt.Errorf("module must have an output named %s mounted at %s", CONTRACT_OUTPUTS_NAME, CONTRACT_OUTPUTS_PATH)
+	}
+	return nil
+}
+
+func isContractPath(p string, root string) bool {
+	p = path.Clean(p)
+	return p == root || strings.HasPrefix(p, root+"/")
+}
+
+// check the inputs of a job before it runs
+// modules without a contract take whatever inputs they are given
+func CheckContractInputs(module data.Module, inputs map[string]string) error {
+	if module.Contract == nil {
+		return nil
+	}
+	err := CheckModuleContract(module)
+	if err != nil {
+		return data.WrapError(data.ErrModuleLoadFailed, err)
+	}
+	declared := map[string]data.ModuleContractInput{}
+	for _, input := range module.Contract.Inputs {
+		declared[input.Name] = input
+		value, ok := inputs[input.Name]
+		if !ok {
+			if input.Required {
+				return data.NewError(data.ErrInvalidRequest, "the module needs input %s", input.Name)
+			}
+			continue
+		}
+		if input.Pattern != "" && !regexp.MustCompile("^(?:"+input.Pattern+")$").MatchString(value) {
+			return data.NewError(data.ErrInvalidRequest, "input %s does not match %s", input.Name, input.Pattern)
+		}
+	}
+	for name := range inputs {
+		if _, ok := declared[name]; !ok {
+			return data.NewError(data.ErrInvalidRequest, "the module does not take input %s", name)
+		}
+	}
+	return nil
+}
+
+// the environment variables the contract promises the module
+func GetContractEnv(module data.Module, dealID string, inputs map[string]string) map[string]string {
+	if module.Contract == nil {
+		return nil
+	}
+	env := map[string]string{
+		CONTRACT_ENV_DEAL_ID:     dealID,
+		CONTRACT_ENV_INPUTS_DIR:  CONTRACT_INPUTS_PATH,
+		CONTRACT_ENV_OUTPUTS_DIR: CONTRACT_OUTPUTS_PATH,
+	}
+	for _, input := range module.Contract.Inputs {
+		if value, ok := inputs[input.Name]; ok {
+			env[CONTRACT_ENV_INPUT_PREFIX+strings.ToUpper(input.Name)] = value
+		}
+	}
+	return env
+}
+
+// the error for a job that exited with a code other than 0
+// the manifest error says why if the module wrote one
+func GetExitCodeError(module data.Module, dealID string, exitCode int, manifest *ResultManifest) error {
+	message := fmt.Sprintf("job %s exited with code %d", dealID, exitCode)
+	if manifest != nil && manifest.Error != "" {
+		message = fmt.Sprintf("%s: %s", message, manifest.Error)
+	}
+	// modules that never agreed to the contract may use the code for something else
+	if module.Contract != nil && exitCode == EXIT_INVALID_INPUT {
+		return data.NewError(data.ErrInvalidRequest, "%s", message)
+	}
+	return data.NewError(data.ErrJobFailed, "%s", message)
+}
+
+// check what a job left in its results folder once it has exited
+// the manifest is nil if the module did not write one
+func CheckModuleResult(module data.Module, resultsDir string, exitCode int) (*ResultManifest, error) {
+	if module.Contract == nil {
+		return nil, nil
+	}
+	outputsDir := filepath.Join(resultsDir, CONTRACT_OUTPUTS_NAME)
+	manifest, err := readResultManifest(filepath.Join(outputsDir, CONTRACT_RESULT_MANIFEST))
+	if err != nil {
+		return nil, data.WrapError(data.ErrJobFailed, err)
+	}
+	if manifest == nil {
+		if module.Contract.Manifest && exitCode == EXIT_SUCCESS {
+			return nil, data.NewError(data.ErrJobFailed, "the module did not write %s", CONTRACT_RESULT_MANIFEST)
+		}
+		return nil, nil
+	}
+	err = checkResultManifest(*manifest, outputsDir, exitCode)
+	if err != nil {
+		return nil, data.WrapError(data.ErrJobFailed, err)
+	}
+	return manifest, nil
+}
+
+func readResultManifest(manifestPath string) (*ResultManifest, error) {
+	// the job wrote the folder so a link could point anywhere on our host
+	info, err := os.Lstat(manifestPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s is not a file", CONTRACT_RESULT_MANIFEST)
+	}
+	file, err := os.Open(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	bs, err := io.ReadAll(io.LimitReader(file, MAX_CONTRACT_MANIFEST_SIZE+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(bs) > MAX_CONTRACT_MANIFEST_SIZE {
+		return nil, fmt.Errorf("%s is more than %d bytes", CONTRACT_RESULT_MANIFEST, MAX_CONTRACT_MANIFEST_SIZE)
+	}
+	var manifest ResultManifest
+	err = json.Unmarshal(bs, &manifest)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %s", CONTRACT_RESULT_MANIFEST, err.Error())
+	}
+	return &manifest, nil
+}
+
+func checkResultManifest(manifest ResultManifest, outputsDir string, exitCode int) error {
+	if manifest.Version != CONTRACT_MANIFEST_VERSION {
+		return fmt.Errorf("result manifest version %d is not %d", manifest.Version, CONTRACT_MANIFEST_VERSION)
+	}
+	switch manifest.Status {
+	case ResultManifestSuccess:
+		if exitCode != EXIT_SUCCESS {
+			return fmt.Errorf("result manifest says success but the module exited with code %d", exitCode)
+		}
+	case ResultManifestError:
+		if exitCode == EXIT_SUCCESS {
+			return fmt.Errorf("result manifest says error but the module exited with code 0")
+		}
+	default:
+		return fmt.Errorf("result manifest status must be %s or %s", ResultManifestSuccess, ResultManifestError)
+	}
+	if len(manifest.Outputs) > MAX_CONTRACT_MANIFEST_OUTPUT {
+		return fmt.Errorf("result manifest lists more than %d outputs", MAX_CONTRACT_MANIFEST_OUTPUT)
+	}
+	// a failed job may not have written what it meant to
+	if exitCode != EXIT_SUCCESS {
+		return nil
+	}
+	for _, output := range manifest.Outputs {
+		err := checkResultManifestOutput(output, outputsDir)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkResultManifestOutput(output ResultManifestOutput, outputsDir string) error {
+	clean := path.Clean(output.Path)
+	if output.Path == "" || path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return fmt.Errorf("result manifest output %q must be a path inside %s", output.Path, CONTRACT_OUTPUTS_PATH)
+	}
+	filePath := filepath.Join(outputsDir, filepath.FromSlash(clean))
+	info, err := os.Lstat(filePath)
+	if err != nil {
+		return fmt.Errorf("result manifest output %s was not written", output.Path)
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("result manifest output %s is not a file", output.Path)
+	}
+	if output.Size != 0 && info.Size() != output.Size {
+		return fmt.Errorf("result manifest output %s is %d bytes not %d", output.Path, info.Size(), output.Size)
+	}
+	if output.SHA256 == "" {
+		return nil
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return err
+	}
+	if sum := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(sum, output.SHA256) {
+		return fmt.Errorf("result manifest output %s has sha256 %s not %s", output.Path, sum, output.SHA256)
+	}
+	return nil
+}
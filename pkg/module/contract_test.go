@@ -0,0 +1,102 @@
+package module
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/data/bacalhau"
+)
+
+func getContractModule() data.Module {
+	module := data.Module{
+		Contract: &data.ModuleContract{
+			Inputs: []data.ModuleContractInput{
+				{Name: "Prompt", Required: true},
+				{Name: "Steps", Pattern: `[0-9]+`},
+			},
+			Manifest: true,
+		},
+	}
+	module.Job.Spec.Inputs = []bacalhau.StorageSpec{{Name: "weights", Path: "/inputs/weights"}}
+	module.Job.Spec.Outputs = []bacalhau.StorageSpec{{Name: "outputs", Path: "/outputs"}}
+	return module
+}
+
+func TestCheckContractInputs(t *testing.T) {
+	module := getContractModule()
+	assert.NoError(t, CheckContractInputs(module, map[string]string{"Prompt": "a cat", "Steps": "20"}))
+	assert.True(t, errors.Is(CheckContractInputs(module, map[string]string{"Steps": "20"}), data.ErrInvalidRequest))
+	assert.True(t, errors.Is(CheckContractInputs(module, map[string]string{"Prompt": "a cat", "Steps": "many"}), data.ErrInvalidRequest))
+	assert.True(t, errors.Is(CheckContractInputs(module, map[string]string{"Prompt": "a cat", "Seed": "1"}), data.ErrInvalidRequest))
+
+	// modules without a contract take anything
+	assert.NoError(t, CheckContractInputs(data.Module{}, map[string]string{"Seed": "1"}))
+
+	badMount := getContractModule()
+	badMount.Job.Spec.Inputs[0].Path = "/weights"
+	assert.True(t, errors.Is(CheckContractInputs(badMount, map[string]string{"Prompt": "a cat"}), data.ErrModuleLoadFailed))
+
+	noOutputs := getContractModule()
+	noOutputs.Job.Spec.Outputs = nil
+	assert.Error(t, CheckModuleContract(noOutputs))
+
+	env := GetContractEnv(module, "deal1", map[string]string{"Prompt": "a cat"})
+	assert.Equal(t, map[string]string{
+		"LILYPAD_DEAL_ID":      "deal1",
+		"LILYPAD_INPUTS_DIR":   "/inputs",
+		"LILYPAD_OUTPUTS_DIR":  "/outputs",
+		"LILYPAD_INPUT_PROMPT": "a cat",
+	}, env)
+}
+
+func TestCheckModuleResult(t *testing.T) {
+	module := getContractModule()
+	resultsDir := t.TempDir()
+	outputsDir := filepath.Join(resultsDir, "outputs")
+	require.NoError(t, os.MkdirAll(outputsDir, 0755))
+	writeManifest := func(manifest string) {
+		require.NoError(t, os.WriteFile(filepath.Join(outputsDir, CONTRACT_RESULT_MANIFEST), []byte(manifest), 0644))
+	}
+
+	_, err := CheckModuleResult(module, resultsDir, 0)
+	assert.Error(t, err, "the module promised a manifest")
+	// a failed job may not get as far as writing one
+	manifest, err := CheckModuleResult(module, resultsDir, 1)
+	assert.NoError(t, err)
+	assert.Nil(t, manifest)
+
+	image := []byte("png")
+	require.NoError(t, os.WriteFile(filepath.Join(outputsDir, "image.png"), image, 0644))
+	sum := sha256.Sum256(image)
+	writeManifest(`{"version":1,"status":"success","outputs":[{"path":"image.png","size":3,"sha256":"` + hex.EncodeToString(sum[:]) + `"}],"metrics":{"images":1}}`)
+	manifest, err = CheckModuleResult(module, resultsDir, 0)
+	require.NoError(t, err)
+	assert.Equal(t, float64(1), manifest.Metrics["images"])
+
+	_, err = CheckModuleResult(module, resultsDir, 1)
+	assert.Error(t, err, "the manifest says success but the exit code does not")
+
+	writeManifest(`{"version":1,"status":"success","outputs":[{"path":"image.png","size":4}]}`)
+	_, err = CheckModuleResult(module, resultsDir, 0)
+	assert.Error(t, err)
+
+	writeManifest(`{"version":1,"status":"success","outputs":[{"path":"../exitCode"}]}`)
+	_, err = CheckModuleResult(module, resultsDir, 0)
+	assert.Error(t, err)
+
+	writeManifest(`{"version":1,"status":"error","error":"prompt was empty"}`)
+	manifest, err = CheckModuleResult(module, resultsDir, EXIT_INVALID_INPUT)
+	require.NoError(t, err)
+	err = GetExitCodeError(module, "deal1", EXIT_INVALID_INPUT, manifest)
+	assert.True(t, errors.Is(err, data.ErrInvalidRequest))
+	assert.Contains(t, err.Error(), "prompt was empty")
+	assert.True(t, errors.Is(GetExitCodeError(data.Module{}, "deal1", EXIT_INVALID_INPUT, nil), data.ErrJobFailed))
+}
@@ -0,0 +1,45 @@
+package module
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/data/bacalhau"
+)
+
+// the engine the module runs on as the job offer gives it, empty for docker
+func GetModuleEngine(module data.Module) string {
+	spec := module.Job.Spec
+	if spec.EngineSpec.Type == "" && spec.Engine == bacalhau.EngineWasm {
+		return data.ModuleEngineWasm
+	}
+	if strings.EqualFold(spec.EngineSpec.Type, data.ModuleEngineWasm) {
+		return data.ModuleEngineWasm
+	}
+	return ""
+}
+
+// the wasm job spec from either the engine spec or the older wasm field
+func GetWasmSpec(spec bacalhau.Spec) (bacalhau.JobSpecWasm, error) {
+	if spec.EngineSpec.Type == "" {
+		if spec.Engine != bacalhau.EngineWasm {
+			return bacalhau.JobSpecWasm{}, fmt.Errorf("engine %s is not a wasm engine", spec.Engine.String())
+		}
+		return spec.Wasm, nil
+	}
+	if !strings.EqualFold(spec.EngineSpec.Type, data.ModuleEngineWasm) {
+		return bacalhau.JobSpecWasm{}, fmt.Errorf("engine %s is not a wasm engine", spec.EngineSpec.Type)
+	}
+	var wasm bacalhau.JobSpecWasm
+	params, err := json.Marshal(spec.EngineSpec.Params)
+	if err != nil {
+		return wasm, err
+	}
+	err = json.Unmarshal(params, &wasm)
+	if err != nil {
+		return wasm, fmt.Errorf("error reading wasm engine params: %s", err.Error())
+	}
+	return wasm, nil
+}
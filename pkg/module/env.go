@@ -0,0 +1,105 @@
+package module
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/data/bacalhau"
+)
+
+// add the environment variables from the job offer to the loaded module
+// they replace any variable of the same name the module template set
+func ApplyEnv(module *data.Module, env map[string]string) error {
+	if len(env) == 0 {
+		return nil
+	}
+	spec := &module.Job.Spec
+	if spec.EngineSpec.Type == "" {
+		switch spec.Engine {
+		case bacalhau.EngineWasm:
+			if spec.Wasm.EnvironmentVariables == nil {
+				spec.Wasm.EnvironmentVariables = map[string]string{}
+			}
+			for name, value := range env {
+				spec.Wasm.EnvironmentVariables[name] = value
+			}
+		default:
+			spec.Docker.EnvironmentVariables = mergeEnv(spec.Docker.EnvironmentVariables, env)
+		}
+		return nil
+	}
+	if strings.EqualFold(spec.EngineSpec.Type, data.ModuleEngineWasm) {
+		if spec.EngineSpec.Params == nil {
+			spec.EngineSpec.Params = map[string]interface{}{}
+		}
+		variables, ok := spec.EngineSpec.Params["EnvironmentVariables"].(map[string]interface{})
+		if !ok {
+			variables = map[string]interface{}{}
+		}
+		for name, value := range env {
+			variables[name] = value
+		}
+		spec.EngineSpec.Params["EnvironmentVariables"] = variables
+		return nil
+	}
+	if !strings.EqualFold(spec.EngineSpec.Type, "docker") {
+		return fmt.Errorf("cannot set environment variables for the %s engine", spec.EngineSpec.Type)
+	}
+	existing := []string{}
+	if params, ok := spec.EngineSpec.Params["EnvironmentVariables"].([]interface{}); ok {
+		for _, param := range params {
+			if variable, ok := param.(string); ok {
+				existing = append(existing, variable)
+			}
+		}
+	}
+	merged := []interface{}{}
+	for _, variable := range mergeEnv(existing, env) {
+		merged = append(merged, variable)
+	}
+	if spec.EngineSpec.Params == nil {
+		spec.EngineSpec.Params = map[string]interface{}{}
+	}
+	spec.EngineSpec.Params["EnvironmentVariables"] = merged
+	return nil
+}
+
+// set the environment a deal runs the module with
+// the job offer env first and then what the module contract promises
+// the resource provider, mediator replays and local runs all use this
+// so a module sees the same thing wherever it runs
+func ApplyDealEnv(module *data.Module, dealID string, env map[string]string, inputs map[string]string) error {
+	err := ApplyEnv(module, env)
+	if err != nil {
+		return err
+	}
+	err = CheckContractInputs(*module, inputs)
+	if err != nil {
+		return err
+	}
+	// set last so the job offer cannot change what the contract promises
+	return ApplyEnv(module, GetContractEnv(*module, dealID, inputs))
+}
+
+// NAME=value pairs in the order the module gave them followed by the new names in order
+func mergeEnv(existing []string, env map[string]string) []string {
+	merged := []string{}
+	for _, variable := range existing {
+		name, _, _ := strings.Cut(variable, "=")
+		if _, ok := env[name]; ok {
+			continue
+		}
+		merged = append(merged, variable)
+	}
+	names := []string{}
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		merged = append(merged, fmt.Sprintf("%s=%s", name, env[name]))
+	}
+	return merged
+}
@@ -0,0 +1,31 @@
+package module
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/data/bacalhau"
+)
+
+func TestApplyEnv(t *testing.T) {
+	module := data.Module{}
+	module.Job.Spec.Docker.EnvironmentVariables = []string{"MODEL=small", "SEED=1"}
+	err := ApplyEnv(&module, map[string]string{"SEED": "42", "DEBUG": "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"MODEL=small", "DEBUG=1", "SEED=42"}, module.Job.Spec.Docker.EnvironmentVariables)
+
+	module = data.Module{}
+	module.Job.Spec.EngineSpec = bacalhau.EngineSpec{
+		Type:   "docker",
+		Params: map[string]interface{}{"EnvironmentVariables": []interface{}{"SEED=1"}},
+	}
+	err = ApplyEnv(&module, map[string]string{"SEED": "42"})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"SEED=42"}, module.Job.Spec.EngineSpec.Params["EnvironmentVariables"])
+
+	module = data.Module{}
+	module.Job.Spec.EngineSpec = bacalhau.EngineSpec{Type: "python"}
+	assert.Error(t, ApplyEnv(&module, map[string]string{"SEED": "42"}))
+}
@@ -0,0 +1,67 @@
+package module
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+// the image the job spec will run
+func GetModuleImage(module data.Module) string {
+	if image, ok := module.Job.Spec.EngineSpec.Params["Image"].(string); ok && image != "" {
+		return image
+	}
+	return module.Job.Spec.Docker.Image
+}
+
+// point the job spec at another image
+func SetModuleImage(module *data.Module, image string) {
+	module.Job.Spec.Docker.Image = image
+	if module.Job.Spec.EngineSpec.Params != nil {
+		if _, ok := module.Job.Spec.EngineSpec.Params["Image"]; ok {
+			module.Job.Spec.EngineSpec.Params["Image"] = image
+		}
+	}
+}
+
+// the digest an image reference is pinned to, empty if it is only tagged
+func GetImageDigest(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		return image[i+1:]
+	}
+	return ""
+}
+
+// the image name without its tag or digest
+// a colon before the last slash is a registry port not a tag
+func getImageName(image string) string {
+	if i := strings.Index(image, "@"); i >= 0 {
+		image = image[:i]
+	}
+	if i := strings.LastIndex(image, ":"); i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+	return image
+}
+
+// rewrite an image reference to pull by digest
+// the container runtime checks what it pulls against the digest
+// so a moved tag or a tampered registry cannot swap the image
+func PinImageDigest(image string, digest string) (string, error) {
+	if existing := GetImageDigest(image); existing != "" && existing != digest {
+		return "", fmt.Errorf("image %s is pinned to a different digest than %s", image, digest)
+	}
+	return fmt.Sprintf("%s@%s", getImageName(image), digest), nil
+}
+
+// keep only the variants built as the image with the given digest
+func FilterVariantsByDigest(variants []data.ModuleVariant, digest string) []data.ModuleVariant {
+	filtered := []data.ModuleVariant{}
+	for _, variant := range variants {
+		if variant.Digest == digest {
+			filtered = append(filtered, variant)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,52 @@
+package module
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPinImageDigest(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+
+	tests := map[string]struct {
+		image     string
+		expected  string
+		expectErr bool
+	}{
+		"tagged": {
+			image:    "example/sdxl:v1",
+			expected: "example/sdxl@" + digest,
+		},
+		"untagged": {
+			image:    "example/sdxl",
+			expected: "example/sdxl@" + digest,
+		},
+		"registry with a port": {
+			image:    "localhost:5000/sdxl:v1",
+			expected: "localhost:5000/sdxl@" + digest,
+		},
+		"already pinned": {
+			image:    "example/sdxl:v1@" + digest,
+			expected: "example/sdxl@" + digest,
+		},
+		"pinned to another digest": {
+			image:     "example/sdxl@sha256:" + strings.Repeat("b", 64),
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			image, err := PinImageDigest(test.image, digest)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, image)
+			assert.Equal(t, digest, GetImageDigest(image))
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package module
+
+import (
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/data/bacalhau"
+)
+
+// the network access the module asks for in its job spec
+// http networking is an allowlist of the domains it lists
+func GetNetworkPolicy(module data.Module) data.NetworkPolicy {
+	network := module.Job.Spec.Network
+	switch network.Type {
+	case bacalhau.NetworkFull:
+		return data.NetworkPolicy{Mode: data.NetworkPolicyFull}
+	case bacalhau.NetworkHTTP:
+		if len(network.Domains) == 0 {
+			return data.NetworkPolicy{Mode: data.NetworkPolicyNone}
+		}
+		return data.NetworkPolicy{Mode: data.NetworkPolicyEgressAllowlist, Allow: network.Domains}
+	default:
+		return data.NetworkPolicy{Mode: data.NetworkPolicyNone}
+	}
+}
+
+// what the module asks for cut down to what the deal allows
+func GetDealNetworkPolicy(deal data.DealContainer, module data.Module) (data.NetworkPolicy, error) {
+	dealPolicy, err := data.GetDealNetworkPolicy(deal.Deal)
+	if err != nil {
+		return data.NetworkPolicy{}, err
+	}
+	modulePolicy := GetNetworkPolicy(module)
+	return data.GetEffectiveNetworkPolicy(&modulePolicy, dealPolicy), nil
+}
@@ -0,0 +1,86 @@
+package module
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/lilypad-tech/lilypad/pkg/module/shortcuts"
+)
+
+//go:embed scaffold
+var scaffoldFiles embed.FS
+
+// the example inputs lilypad module run-local reads by default
+const SCAFFOLD_INPUTS_FILE = "inputs.example.json"
+
+var scaffoldName = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+type ScaffoldOptions struct {
+	// the module name, used for the folder and the image
+	Name string
+	// the image the template runs, defaults to <name>:latest
+	Image string
+}
+
+// the path run-local loads the module template from
+func GetLocalModuleTemplatePath(dir string) string {
+	return filepath.Join(dir, strings.TrimPrefix(shortcuts.LILYPAD_MODULE_CONFIG_PATH, "/"))
+}
+
+// write a new module that follows the module contract into dir
+// nothing is written if any of the files are already there
+func ScaffoldModule(dir string, options ScaffoldOptions) ([]string, error) {
+	if !scaffoldName.MatchString(options.Name) {
+		return nil, fmt.Errorf("module name %q must be lower case letters, digits, dots, dashes and underscores", options.Name)
+	}
+	if options.Image == "" {
+		options.Image = fmt.Sprintf("%s:latest", options.Name)
+	}
+	replacer := strings.NewReplacer(
+		"__MODULE_NAME__", options.Name,
+		"__IMAGE__", options.Image,
+	)
+
+	files := map[string][]byte{}
+	err := fs.WalkDir(scaffoldFiles, "scaffold", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		content, err := scaffoldFiles.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		files[strings.TrimPrefix(path, "scaffold/")] = []byte(replacer.Replace(string(content)))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for name := range files {
+		names = append(names, name)
+		_, err := os.Stat(filepath.Join(dir, name))
+		if err == nil {
+			return nil, fmt.Errorf("%s already exists", filepath.Join(dir, name))
+		}
+	}
+	sort.Strings(names)
+
+	err = os.MkdirAll(dir, 0755) //nolint:gomnd
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		err = os.WriteFile(filepath.Join(dir, name), files[name], 0644) //nolint:gomnd
+		if err != nil {
+			return nil, err
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,51 @@
+# A lilypad module that follows the module contract.
+#
+# The resource provider gives the module its inputs as LILYPAD_INPUT_<NAME>
+# environment variables and mounts /outputs for the results. Exit 0 when the
+# job worked, 65 when the inputs are no good and anything else when it failed.
+import hashlib
+import json
+import os
+import sys
+
+OUTPUTS_DIR = os.environ.get("LILYPAD_OUTPUTS_DIR", "/outputs")
+EXIT_INVALID_INPUT = 65
+
+
+def write_manifest(status, outputs=None, error=None, metrics=None):
+    manifest = {"version": 1, "status": status}
+    if outputs:
+        manifest["outputs"] = outputs
+    if error:
+        manifest["error"] = error
+    if metrics:
+        manifest["metrics"] = metrics
+    with open(os.path.join(OUTPUTS_DIR, "lilypad_result.json"), "w") as f:
+        json.dump(manifest, f)
+
+
+def main():
+    message = os.environ.get("LILYPAD_INPUT_MESSAGE", "")
+    if not message.strip():
+        write_manifest("error", error="Message cannot be empty")
+        return EXIT_INVALID_INPUT
+
+    result = f"Hello from __MODULE_NAME__: {message}\n".encode()
+    with open(os.path.join(OUTPUTS_DIR, "result.txt"), "wb") as f:
+        f.write(result)
+
+    write_manifest(
+        "success",
+        outputs=[{
+            "path": "result.txt",
+            "content_type": "text/plain",
+            "size": len(result),
+            "sha256": hashlib.sha256(result).hexdigest(),
+        }],
+        metrics={"characters": len(message)},
+    )
+    return 0
+
+
+if __name__ == "__main__":
+    sys.exit(main())
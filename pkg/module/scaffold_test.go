@@ -0,0 +1,29 @@
+package module
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScaffoldModule(t *testing.T) {
+	dir := t.TempDir()
+	files, err := ScaffoldModule(dir, ScaffoldOptions{Name: "hello-world"})
+	require.NoError(t, err)
+	assert.Contains(t, files, "Dockerfile")
+	assert.Contains(t, files, SCAFFOLD_INPUTS_FILE)
+
+	// the module it writes has to keep to the contract it starts authors on
+	inputs := map[string]string{"Message": "world"}
+	loaded, err := LoadLocalModule(GetLocalModuleTemplatePath(dir), inputs)
+	require.NoError(t, err)
+	assert.Equal(t, "hello-world:latest", loaded.Job.Spec.Docker.Image)
+	assert.NoError(t, ApplyDealEnv(loaded, "local", nil, inputs))
+	assert.Contains(t, loaded.Job.Spec.Docker.EnvironmentVariables, "LILYPAD_INPUT_MESSAGE=world")
+
+	_, err = ScaffoldModule(dir, ScaffoldOptions{Name: "hello-world"})
+	assert.Error(t, err, "files are not written over")
+	_, err = ScaffoldModule(t.TempDir(), ScaffoldOptions{Name: "Hello World"})
+	assert.Error(t, err)
+}
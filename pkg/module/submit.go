@@ -0,0 +1,205 @@
+package module
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+type SubmitOptions struct {
+	// the http url the module repo is published at
+	// defaults to the origin remote of the local repo
+	Repo string
+	// the release tag or commit to list, defaults to the tag on HEAD
+	Version string
+	// the digest of the reviewed image, taken from the template if it pins one
+	ImageDigest string
+	// the defaults for the allowlist entry
+	MaxRuntime int
+	Verifiers  []string
+}
+
+// where a module folder sits in its git repo and what it is checked out at
+type ModuleCheckout struct {
+	Repo    string
+	Version string
+	Path    string
+	Commit  string
+}
+
+// work out the repo, version and template path for a module in a local folder
+// the version has to be pinned, checked out and have nothing left uncommitted
+// so the entry points at exactly the code that was run locally
+func GetModuleCheckout(dir string, options SubmitOptions) (ModuleCheckout, error) {
+	checkout := ModuleCheckout{
+		Repo:    options.Repo,
+		Version: options.Version,
+	}
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return checkout, fmt.Errorf("%s is not in a git repo: %s", dir, err.Error())
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return checkout, err
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return checkout, err
+	}
+	rel, err := filepath.Rel(worktree.Filesystem.Root(), GetLocalModuleTemplatePath(absDir))
+	if err != nil {
+		return checkout, err
+	}
+	checkout.Path = "/" + filepath.ToSlash(rel)
+
+	head, err := repo.Head()
+	if err != nil {
+		return checkout, fmt.Errorf("error reading HEAD: %s", err.Error())
+	}
+	checkout.Commit = head.Hash().String()
+
+	if checkout.Repo == "" {
+		remote, err := repo.Remote(git.DefaultRemoteName)
+		if err != nil || len(remote.Config().URLs) == 0 {
+			return checkout, fmt.Errorf("the repo has no %s remote, give the repo url", git.DefaultRemoteName)
+		}
+		checkout.Repo = getRepoHTTPURL(remote.Config().URLs[0])
+	}
+	if !strings.HasPrefix(checkout.Repo, "https://") && !strings.HasPrefix(checkout.Repo, "http://") {
+		return checkout, fmt.Errorf("module repo %s must be a http url that can be cloned without credentials", checkout.Repo)
+	}
+
+	if checkout.Version == "" {
+		checkout.Version, err = getHeadTag(repo, head.Hash())
+		if err != nil {
+			return checkout, err
+		}
+	}
+	err = data.CheckModuleVersionPinned(data.ModuleConfig{Repo: checkout.Repo, Hash: checkout.Version})
+	if err != nil {
+		return checkout, err
+	}
+	versionHash, err := repo.ResolveRevision(plumbing.Revision(checkout.Version))
+	if err != nil {
+		return checkout, fmt.Errorf("version %s is not in the local repo: %s", checkout.Version, err.Error())
+	}
+	if *versionHash != head.Hash() {
+		return checkout, fmt.Errorf("version %s is commit %s but %s is checked out", checkout.Version, versionHash.String(), head.Hash().String())
+	}
+	status, err := worktree.Status()
+	if err != nil {
+		return checkout, err
+	}
+	if !status.IsClean() {
+		return checkout, fmt.Errorf("the repo has uncommitted changes that are not part of version %s", checkout.Version)
+	}
+	return checkout, nil
+}
+
+// the release tag that points at the checked out commit
+func getHeadTag(repo *git.Repository, head plumbing.Hash) (string, error) {
+	tags, err := repo.Tags()
+	if err != nil {
+		return "", err
+	}
+	found := []string{}
+	err = tags.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		hash, err := repo.ResolveRevision(plumbing.Revision(name))
+		if err == nil && *hash == head && data.CheckModuleVersionPinned(data.ModuleConfig{Hash: name}) == nil {
+			found = append(found, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(found) != 1 {
+		return "", fmt.Errorf("found %d release tags on %s, tag the commit or give the version", len(found), head.String())
+	}
+	return found[0], nil
+}
+
+// git@github.com:user/repo.git --> https://github.com/user/repo
+func getRepoHTTPURL(remote string) string {
+	remote = strings.TrimSuffix(remote, ".git")
+	if strings.HasPrefix(remote, "git@") {
+		remote = "https://" + strings.Replace(strings.TrimPrefix(remote, "git@"), ":", "/", 1)
+	}
+	return remote
+}
+
+// the checks a module has to pass before it goes on the allowlist
+// every problem is reported rather than just the first one
+func CheckModuleSubmission(module data.Module) error {
+	problems := []error{}
+	err := CheckModuleContract(module)
+	if err != nil {
+		problems = append(problems, err)
+	}
+	image := GetModuleImage(module)
+	if GetModuleEngine(module) == data.ModuleEngineWasm {
+		wasm, err := GetWasmSpec(module.Job.Spec)
+		if err != nil {
+			problems = append(problems, err)
+		} else if wasm.EntryModule.CID == "" {
+			problems = append(problems, fmt.Errorf("the wasm module must be given by CID, a URL can change after review"))
+		}
+	} else if image == "" {
+		problems = append(problems, fmt.Errorf("the module does not name an image"))
+	} else if GetImageDigest(image) == "" {
+		tag := strings.TrimPrefix(image, getImageName(image))
+		if tag == "" || tag == ":latest" {
+			problems = append(problems, fmt.Errorf("image %s must have a version tag or digest, latest can change after review", image))
+		}
+	}
+	machine := module.Machine
+	if machine.CPU <= 0 || machine.RAM <= 0 {
+		problems = append(problems, fmt.Errorf("the module must declare the cpu and ram it needs in machine"))
+	}
+	if machine.GPU < 0 || machine.Disk < 0 {
+		problems = append(problems, fmt.Errorf("machine gpu and disk cannot be negative"))
+	}
+	if module.Job.Spec.Timeout <= 0 {
+		problems = append(problems, fmt.Errorf("the job spec must set a timeout"))
+	}
+	return errors.Join(problems...)
+}
+
+// the allowlist entry for a module that has passed its checks
+func GetAllowlistEntry(module data.Module, checkout ModuleCheckout, options SubmitOptions) (data.AllowlistItem, error) {
+	config := data.ModuleConfig{
+		Repo: checkout.Repo,
+		Hash: checkout.Version,
+		Path: checkout.Path,
+	}
+	moduleID, err := data.GetModuleID(config)
+	if err != nil {
+		return data.AllowlistItem{}, err
+	}
+	imageDigest := options.ImageDigest
+	if imageDigest == "" {
+		imageDigest = GetImageDigest(GetModuleImage(module))
+	}
+	if imageDigest != "" {
+		err = data.CheckImageDigest(imageDigest)
+		if err != nil {
+			return data.AllowlistItem{}, err
+		}
+	}
+	return data.AllowlistItem{
+		Module:      config,
+		ModuleID:    moduleID,
+		MinimumSpec: module.Machine,
+		Verifiers:   options.Verifiers,
+		MaxRuntime:  options.MaxRuntime,
+		ImageDigest: imageDigest,
+	}, nil
+}
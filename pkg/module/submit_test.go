@@ -0,0 +1,69 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+func TestModuleSubmission(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "hello")
+	_, err := ScaffoldModule(dir, ScaffoldOptions{Name: "hello", Image: "hello:v1.0.0"})
+	require.NoError(t, err)
+	loaded, err := LoadLocalModule(GetLocalModuleTemplatePath(dir), map[string]string{"Message": "world"})
+	require.NoError(t, err)
+	assert.NoError(t, CheckModuleSubmission(*loaded))
+
+	latest := *loaded
+	SetModuleImage(&latest, "hello:latest")
+	assert.Error(t, CheckModuleSubmission(latest), "latest can change after review")
+
+	options := SubmitOptions{Repo: "https://github.com/example/modules"}
+	_, err = GetModuleCheckout(dir, options)
+	assert.Error(t, err, "the folder is not in a git repo")
+
+	repo, err := git.PlainInit(root, false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	_, err = worktree.Add("hello")
+	require.NoError(t, err)
+	commit, err := worktree.Commit("hello", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+
+	_, err = GetModuleCheckout(dir, options)
+	assert.Error(t, err, "the commit has no release tag")
+
+	_, err = repo.CreateTag("v1.0.0", commit, nil)
+	require.NoError(t, err)
+	checkout, err := GetModuleCheckout(dir, options)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", checkout.Version)
+	assert.Equal(t, "/hello/lilypad_module.json.tmpl", checkout.Path)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "run.py"), []byte("changed"), 0644))
+	_, err = GetModuleCheckout(dir, options)
+	assert.Error(t, err, "uncommitted changes are not part of the version")
+
+	entry, err := GetAllowlistEntry(*loaded, checkout, options)
+	require.NoError(t, err)
+	moduleID, err := data.GetModuleID(data.ModuleConfig{
+		Repo: options.Repo,
+		Hash: "v1.0.0",
+		Path: "/hello/lilypad_module.json.tmpl",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, moduleID, entry.ModuleID)
+	assert.Equal(t, loaded.Machine, entry.MinimumSpec)
+}
@@ -46,6 +46,12 @@ func CheckModuleOptions(options data.ModuleConfig) error {
 	if options.Path == "" {
 		return fmt.Errorf("MODULE_PATH is required")
 	}
+	if options.ImageDigest != "" {
+		err := data.CheckImageDigest(options.ImageDigest)
+		if err != nil {
+			return fmt.Errorf("MODULE_IMAGE_DIGEST: %s", err.Error())
+		}
+	}
 	return nil
 }
 
@@ -55,11 +61,18 @@ func ProcessModule(module data.ModuleConfig) (data.ModuleConfig, error) {
 	// we have been given a shortcut
 	// let's try to resolve this shortcut into a full module definition
 	if module.Name != "" {
-		module, err := shortcuts.GetModule(module.Name)
+		resolved, err := shortcuts.GetModule(module.Name)
 		if err != nil {
-			return module, err
+			return resolved, err
+		}
+		if module.ImageDigest != "" {
+			err := data.CheckImageDigest(module.ImageDigest)
+			if err != nil {
+				return resolved, fmt.Errorf("MODULE_IMAGE_DIGEST: %s", err.Error())
+			}
 		}
-		return module, nil
+		resolved.ImageDigest = module.ImageDigest
+		return resolved, nil
 	}
 	err := CheckModuleOptions(module)
 	if err != nil {
@@ -188,6 +201,26 @@ func PrepareModule(module data.ModuleConfig) (string, error) {
 	return string(fileContents), nil
 }
 
+// resolve the tag or branch a module is given by to the commit it points at now
+// so that running the module again is sure to run the same code
+func PinModule(module data.ModuleConfig) (data.ModuleConfig, error) {
+	module, err := ProcessModule(module)
+	if err != nil {
+		return module, err
+	}
+	repo, err := CloneModule(module)
+	if err != nil {
+		return module, err
+	}
+	h, err := repo.ResolveRevision(plumbing.Revision(module.Hash))
+	if err != nil {
+		return module, err
+	}
+	module.Name = ""
+	module.Hash = h.String()
+	return module, nil
+}
+
 func subst(format string, jsonEncodedInputs ...string) string {
 	jsonDecodedInputs := make([]interface{}, 0, len(jsonEncodedInputs))
 
@@ -214,13 +247,26 @@ func LoadModule(module data.ModuleConfig, inputs map[string]string) (*data.Modul
 	if err != nil {
 		return nil, err
 	}
-	// TODO: golang handlebars implementation, with shortcode for string encoding e.g. escape_string
-
 	templateName := fmt.Sprintf("%s-%s-%s", module.Repo, module.Path, module.Hash)
+	return renderModule(templateName, moduleText, inputs)
+}
+
+// load a module template from a folder on disk rather than a git repo
+// so module authors can try changes before they push them
+func LoadLocalModule(templatePath string, inputs map[string]string) (*data.Module, error) {
+	moduleText, err := os.ReadFile(templatePath)
+	if err != nil {
+		return nil, err
+	}
+	return renderModule(templatePath, string(moduleText), inputs)
+}
+
+func renderModule(templateName string, moduleText string, inputs map[string]string) (*data.Module, error) {
+	// TODO: golang handlebars implementation, with shortcode for string encoding e.g. escape_string
 	tmpl := template.New(templateName).Funcs(template.FuncMap{
 		"subst": subst,
 	})
-	tmpl, err = tmpl.Parse(moduleText)
+	tmpl, err := tmpl.Parse(moduleText)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse template: %v", err)
 	}
@@ -0,0 +1,99 @@
+package module
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+const (
+	AcceleratorCPU  = "cpu"
+	AcceleratorCUDA = "cuda"
+	AcceleratorROCm = "rocm"
+)
+
+// work out which accelerator a machine can offer based on the GPUs it reports
+// we fall back to cpu if there are no GPUs we know how to target
+func GetAccelerator(spec data.MachineSpec) string {
+	for _, gpu := range spec.GPUs {
+		vendor := strings.ToLower(gpu.Vendor)
+		switch {
+		case strings.Contains(vendor, "nvidia"):
+			return AcceleratorCUDA
+		case strings.Contains(vendor, "amd"), strings.Contains(vendor, "advanced micro devices"):
+			return AcceleratorROCm
+		}
+	}
+	return AcceleratorCPU
+}
+
+func variantMatches(variant data.ModuleVariant, arch string, accelerator string) bool {
+	if variant.Arch != "" && variant.Arch != arch {
+		return false
+	}
+	if variant.Accelerator != "" && variant.Accelerator != accelerator {
+		return false
+	}
+	return true
+}
+
+// how closely a matching variant fits, an empty arch or accelerator is a
+// wildcard so a variant that names ours is a better fit than one that
+// leaves it open and naming the accelerator counts for more than the arch
+func getVariantRank(variant data.ModuleVariant) int {
+	rank := 0
+	if variant.Accelerator != "" {
+		rank += 2
+	}
+	if variant.Arch != "" {
+		rank++
+	}
+	return rank
+}
+
+// the best fitting variant for the arch and accelerator or nil if none
+// match, the first listed wins a tie
+func getBestVariant(variants []data.ModuleVariant, arch string, accelerator string) *data.ModuleVariant {
+	var best *data.ModuleVariant
+	for i, variant := range variants {
+		if !variantMatches(variant, arch, accelerator) {
+			continue
+		}
+		if best == nil || getVariantRank(variant) > getVariantRank(*best) {
+			best = &variants[i]
+		}
+	}
+	return best
+}
+
+// pick the variant that best fits the given architecture and accelerator
+// a variant built for our accelerator wins over a cpu-only build and
+// we only fall back to cpu-only builds if nothing else matches
+func SelectVariant(module data.Module, arch string, accelerator string) (*data.ModuleVariant, error) {
+	if len(module.Variants) == 0 {
+		return nil, nil
+	}
+	if variant := getBestVariant(module.Variants, arch, accelerator); variant != nil {
+		return variant, nil
+	}
+	if accelerator != AcceleratorCPU {
+		if variant := getBestVariant(module.Variants, arch, AcceleratorCPU); variant != nil {
+			return variant, nil
+		}
+	}
+	return nil, fmt.Errorf("no module variant for arch %s with accelerator %s", arch, accelerator)
+}
+
+// the pinned reference we hand to the container runtime
+func GetVariantImage(variant data.ModuleVariant) string {
+	if variant.Digest == "" {
+		return variant.Image
+	}
+	return fmt.Sprintf("%s@%s", variant.Image, variant.Digest)
+}
+
+// rewrite the image in the job spec to point at the chosen variant
+func ApplyVariant(module *data.Module, variant data.ModuleVariant) {
+	SetModuleImage(module, GetVariantImage(variant))
+}
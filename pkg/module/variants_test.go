@@ -0,0 +1,151 @@
+package module
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+func TestSelectVariant(t *testing.T) {
+	module := data.Module{
+		Variants: []data.ModuleVariant{
+			{Arch: "amd64", Accelerator: AcceleratorCUDA, Image: "example/sdxl", Digest: "sha256:amd64cuda"},
+			{Arch: "amd64", Accelerator: AcceleratorCPU, Image: "example/sdxl", Digest: "sha256:amd64cpu"},
+			{Arch: "arm64", Accelerator: AcceleratorCPU, Image: "example/sdxl", Digest: "sha256:arm64cpu"},
+		},
+	}
+
+	tests := map[string]struct {
+		arch        string
+		accelerator string
+		expected    string
+		expectErr   bool
+	}{
+		"amd64 with cuda": {
+			arch:        "amd64",
+			accelerator: AcceleratorCUDA,
+			expected:    "sha256:amd64cuda",
+		},
+		"amd64 cpu only": {
+			arch:        "amd64",
+			accelerator: AcceleratorCPU,
+			expected:    "sha256:amd64cpu",
+		},
+		"arm64 with rocm falls back to cpu": {
+			arch:        "arm64",
+			accelerator: AcceleratorROCm,
+			expected:    "sha256:arm64cpu",
+		},
+		"unknown arch": {
+			arch:        "riscv64",
+			accelerator: AcceleratorCPU,
+			expectErr:   true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			variant, err := SelectVariant(module, test.arch, test.accelerator)
+			if test.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, variant.Digest)
+		})
+	}
+}
+
+func TestSelectVariantPrefersExactMatches(t *testing.T) {
+	// the wildcards are listed first so the first match would be the wrong one
+	module := data.Module{
+		Variants: []data.ModuleVariant{
+			{Image: "example/sdxl", Digest: "sha256:any"},
+			{Arch: "amd64", Image: "example/sdxl", Digest: "sha256:amd64"},
+			{Accelerator: AcceleratorCUDA, Image: "example/sdxl", Digest: "sha256:cuda"},
+			{Arch: "amd64", Accelerator: AcceleratorCUDA, Image: "example/sdxl", Digest: "sha256:amd64cuda"},
+			{Accelerator: AcceleratorCPU, Image: "example/sdxl", Digest: "sha256:cpu"},
+		},
+	}
+
+	tests := map[string]struct {
+		arch        string
+		accelerator string
+		variants    []data.ModuleVariant
+		expected    string
+	}{
+		"exact arch and accelerator": {
+			arch:        "amd64",
+			accelerator: AcceleratorCUDA,
+			expected:    "sha256:amd64cuda",
+		},
+		"exact accelerator with any arch": {
+			arch:        "arm64",
+			accelerator: AcceleratorCUDA,
+			expected:    "sha256:cuda",
+		},
+		"exact accelerator over exact arch": {
+			arch:        "amd64",
+			accelerator: AcceleratorCUDA,
+			variants: []data.ModuleVariant{
+				{Arch: "amd64", Image: "example/sdxl", Digest: "sha256:amd64"},
+				{Accelerator: AcceleratorCUDA, Image: "example/sdxl", Digest: "sha256:cuda"},
+			},
+			expected: "sha256:cuda",
+		},
+		"exact cpu over any accelerator": {
+			arch:        "arm64",
+			accelerator: AcceleratorCPU,
+			expected:    "sha256:cpu",
+		},
+		"exact arch over a wildcard": {
+			arch:        "amd64",
+			accelerator: AcceleratorROCm,
+			expected:    "sha256:amd64",
+		},
+		"wildcard when nothing is exact": {
+			arch:        "arm64",
+			accelerator: AcceleratorROCm,
+			expected:    "sha256:any",
+		},
+		"the first listed wins a tie": {
+			arch:        "amd64",
+			accelerator: AcceleratorCUDA,
+			variants: []data.ModuleVariant{
+				{Arch: "amd64", Accelerator: AcceleratorCUDA, Image: "example/sdxl", Digest: "sha256:first"},
+				{Arch: "amd64", Accelerator: AcceleratorCUDA, Image: "example/sdxl", Digest: "sha256:second"},
+			},
+			expected: "sha256:first",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			testModule := module
+			if test.variants != nil {
+				testModule = data.Module{Variants: test.variants}
+			}
+			variant, err := SelectVariant(testModule, test.arch, test.accelerator)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, variant.Digest)
+		})
+	}
+}
+
+func TestGetAccelerator(t *testing.T) {
+	assert.Equal(t, AcceleratorCPU, GetAccelerator(data.MachineSpec{}))
+	assert.Equal(t, AcceleratorCUDA, GetAccelerator(data.MachineSpec{
+		GPUs: []data.GPUSpec{{Name: "RTX 4090", Vendor: "NVIDIA"}},
+	}))
+	assert.Equal(t, AcceleratorROCm, GetAccelerator(data.MachineSpec{
+		GPUs: []data.GPUSpec{{Name: "MI300", Vendor: "AMD"}},
+	}))
+}
+
+func TestApplyVariant(t *testing.T) {
+	module := data.Module{}
+	ApplyVariant(&module, data.ModuleVariant{Image: "example/sdxl", Digest: "sha256:abc"})
+	assert.Equal(t, "example/sdxl@sha256:abc", module.Job.Spec.Docker.Image)
+}
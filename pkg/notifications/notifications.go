@@ -0,0 +1,196 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+)
+
+// how many notifications can wait for their sender before we drop them
+const NOTIFICATION_QUEUE_SIZE = 1000
+
+// what an address can ask to be told about
+const (
+	// a resource provider was matched with a job
+	EventDealWon = "deal.won"
+	// the job errored, timed out or lost mediation
+	EventJobFailed = "job.failed"
+	// the results of the job were accepted
+	EventJobCompleted = "job.completed"
+)
+
+var Events = []string{EventDealWon, EventJobFailed, EventJobCompleted}
+
+func IsEvent(event string) bool {
+	for _, known := range Events {
+		if event == known {
+			return true
+		}
+	}
+	return false
+}
+
+type Notification struct {
+	Event string `json:"event"`
+	// the address the notification is for
+	Address string `json:"address"`
+	DealID  string `json:"deal_id"`
+	Title   string `json:"title"`
+	Message string `json:"message"`
+}
+
+// something that can deliver a notification to a target
+// e.g. an email address, a Discord webhook or a Telegram chat
+type Sender interface {
+	Name() string
+	Send(target string, notification Notification) error
+}
+
+// who an address wants told about what
+type Subscription struct {
+	Address string `json:"address"`
+	// the name of the sender e.g. email, discord or telegram
+	Sender string `json:"sender"`
+	// where the sender delivers to, what this is depends on the sender
+	Target string `json:"target"`
+	// only these events, empty means all of them
+	Events []string `json:"events,omitempty"`
+}
+
+func (subscription Subscription) wants(notification Notification) bool {
+	if !strings.EqualFold(subscription.Address, notification.Address) {
+		return false
+	}
+	if len(subscription.Events) == 0 {
+		return true
+	}
+	for _, event := range subscription.Events {
+		if event == notification.Event {
+			return true
+		}
+	}
+	return false
+}
+
+func LoadSubscriptions(path string) ([]Subscription, error) {
+	subscriptions := []Subscription{}
+	if path == "" {
+		return subscriptions, nil
+	}
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading notification subscriptions: %s", err.Error())
+	}
+	err = json.Unmarshal(bs, &subscriptions)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing notification subscriptions: %s", err.Error())
+	}
+	for i, subscription := range subscriptions {
+		if !common.IsHexAddress(subscription.Address) {
+			return nil, fmt.Errorf("notification subscription %d has %q which is not an address", i, subscription.Address)
+		}
+		if subscription.Sender == "" || subscription.Target == "" {
+			return nil, fmt.Errorf("notification subscription %d needs a sender and a target", i)
+		}
+		for _, event := range subscription.Events {
+			if !IsEvent(event) {
+				return nil, fmt.Errorf("notification subscription %d has event %q, it must be one of %s", i, event, strings.Join(Events, ", "))
+			}
+		}
+	}
+	return subscriptions, nil
+}
+
+type delivery struct {
+	sender       Sender
+	target       string
+	notification Notification
+}
+
+// hands notifications to the senders of the subscriptions that want them
+type Notifier struct {
+	subscriptions []Subscription
+	sendersMutex  sync.RWMutex
+	senders       map[string]Sender
+	queue         chan delivery
+	log           *system.ServiceLogger
+}
+
+func NewNotifier(service system.Service, options NotificationOptions, subscriptions []Subscription) *Notifier {
+	notifier := &Notifier{
+		subscriptions: subscriptions,
+		senders:       map[string]Sender{},
+		queue:         make(chan delivery, NOTIFICATION_QUEUE_SIZE),
+		log:           system.NewServiceLogger(service),
+	}
+	for _, sender := range getDefaultSenders(options) {
+		notifier.RegisterSender(sender)
+	}
+	return notifier
+}
+
+// add a sender or replace the one with the same name
+func (notifier *Notifier) RegisterSender(sender Sender) {
+	notifier.sendersMutex.Lock()
+	defer notifier.sendersMutex.Unlock()
+	notifier.senders[sender.Name()] = sender
+}
+
+func (notifier *Notifier) getSender(name string) (Sender, bool) {
+	notifier.sendersMutex.RLock()
+	defer notifier.sendersMutex.RUnlock()
+	sender, ok := notifier.senders[name]
+	return sender, ok
+}
+
+// every subscription must name a sender we have
+// senders can be registered after we are created so this is checked on start
+func (notifier *Notifier) CheckSenders() error {
+	for i, subscription := range notifier.subscriptions {
+		if _, ok := notifier.getSender(subscription.Sender); !ok {
+			return fmt.Errorf("notification subscription %d uses sender %q which is not registered", i, subscription.Sender)
+		}
+	}
+	return nil
+}
+
+// send what is queued until the context is done
+func (notifier *Notifier) Start(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case next := <-notifier.queue:
+				err := next.sender.Send(next.target, next.notification)
+				if err != nil {
+					notifier.log.Error("error sending notification", fmt.Errorf("%s %s with %s: %s", next.notification.Event, next.notification.DealID, next.sender.Name(), err.Error()))
+				}
+			}
+		}
+	}()
+}
+
+// queue the notification for each subscription that wants it, this never blocks
+func (notifier *Notifier) Notify(notification Notification) {
+	for _, subscription := range notifier.subscriptions {
+		if !subscription.wants(notification) {
+			continue
+		}
+		sender, ok := notifier.getSender(subscription.Sender)
+		if !ok {
+			continue
+		}
+		select {
+		case notifier.queue <- delivery{sender: sender, target: subscription.Target, notification: notification}:
+		default:
+			notifier.log.Error("notification queue is full", fmt.Errorf("dropping %s %s for %s", notification.Event, notification.DealID, notification.Address))
+		}
+	}
+}
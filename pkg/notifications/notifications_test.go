@@ -0,0 +1,76 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const provider = "0x1111111111111111111111111111111111111111"
+
+func writeSubscriptions(t *testing.T, subscriptions []Subscription) string {
+	path := filepath.Join(t.TempDir(), "subscriptions.json")
+	bs, err := json.Marshal(subscriptions)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, bs, 0644))
+	return path
+}
+
+func TestLoadSubscriptions(t *testing.T) {
+	_, err := LoadSubscriptions(writeSubscriptions(t, []Subscription{{Address: "nope", Sender: DiscordSender, Target: "http://discord"}}))
+	assert.ErrorContains(t, err, "not an address")
+	_, err = LoadSubscriptions(writeSubscriptions(t, []Subscription{{Address: provider, Sender: DiscordSender, Target: "http://discord", Events: []string{"deal.lost"}}}))
+	assert.ErrorContains(t, err, "deal.lost")
+
+	notifier := NewNotifier(system.SolverService, NotificationOptions{}, []Subscription{{Address: provider, Sender: EmailSender, Target: "ops@example.com"}})
+	assert.ErrorContains(t, notifier.CheckSenders(), "email")
+}
+
+func TestNotifySendsToWantedSubscriptions(t *testing.T) {
+	received := make(chan map[string]string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		body := map[string]string{}
+		assert.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+		body["path"] = req.URL.Path
+		received <- body
+	}))
+	defer server.Close()
+
+	notifier := NewNotifier(system.SolverService, NotificationOptions{
+		TelegramToken: "token",
+		TelegramURL:   server.URL,
+	}, []Subscription{
+		{Address: provider, Sender: DiscordSender, Target: server.URL + "/discord", Events: []string{EventDealWon}},
+		{Address: provider, Sender: TelegramSender, Target: "42", Events: []string{EventJobFailed}},
+		{Address: "0x2222222222222222222222222222222222222222", Sender: DiscordSender, Target: server.URL + "/other"},
+	})
+	require.NoError(t, notifier.CheckSenders())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	notifier.Start(ctx)
+
+	notifier.Notify(Notification{Event: EventDealWon, Address: provider, DealID: "deal", Title: "Deal deal won", Message: "cowsay"})
+	notifier.Notify(Notification{Event: EventJobFailed, Address: provider, DealID: "deal", Title: "Job deal failed", Message: "oops"})
+
+	for _, expected := range []map[string]string{
+		{"path": "/discord", "content": "**Deal deal won**\ncowsay"},
+		{"path": "/bottoken/sendMessage", "chat_id": "42", "text": "Job deal failed\noops"},
+	} {
+		select {
+		case body := <-received:
+			assert.Equal(t, expected, body)
+		case <-time.After(5 * time.Second):
+			t.Fatal("notification was not sent")
+		}
+	}
+	assert.Len(t, received, 0)
+}
@@ -0,0 +1,135 @@
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+const NOTIFICATION_REQUEST_TIMEOUT = 10 * time.Second
+
+const DEFAULT_TELEGRAM_URL = "https://api.telegram.org"
+
+// the senders we have out of the box
+const (
+	EmailSender    = "email"
+	DiscordSender  = "discord"
+	TelegramSender = "telegram"
+)
+
+type NotificationOptions struct {
+	// a JSON file of the subscriptions, see Subscription
+	SubscriptionsPath string
+	// the SMTP server emails are sent through, empty turns email off
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	// who the emails are from
+	SMTPFrom string
+	// the token of the Telegram bot that messages are sent from
+	TelegramToken string
+	// the Telegram Bot API
+	TelegramURL string
+}
+
+func (options NotificationOptions) Enabled() bool {
+	return options.SubscriptionsPath != ""
+}
+
+func getDefaultSenders(options NotificationOptions) []Sender {
+	client := &http.Client{Timeout: NOTIFICATION_REQUEST_TIMEOUT}
+	senders := []Sender{
+		discordSender{client: client},
+	}
+	if options.SMTPHost != "" {
+		senders = append(senders, emailSender{options: options})
+	}
+	if options.TelegramToken != "" {
+		telegramURL := options.TelegramURL
+		if telegramURL == "" {
+			telegramURL = DEFAULT_TELEGRAM_URL
+		}
+		senders = append(senders, telegramSender{
+			url:    fmt.Sprintf("%s/bot%s/sendMessage", strings.TrimSuffix(telegramURL, "/"), options.TelegramToken),
+			client: client,
+		})
+	}
+	return senders
+}
+
+func postJSON(client *http.Client, url string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	res, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("returned %s", res.Status)
+	}
+	return nil
+}
+
+// the target is the URL of a Discord channel webhook
+type discordSender struct {
+	client *http.Client
+}
+
+func (_ discordSender) Name() string { return DiscordSender }
+func (sender discordSender) Send(target string, notification Notification) error {
+	return postJSON(sender.client, target, map[string]string{
+		"content": fmt.Sprintf("**%s**\n%s", notification.Title, notification.Message),
+	})
+}
+
+// the target is the ID of a chat the bot is in
+type telegramSender struct {
+	url    string
+	client *http.Client
+}
+
+func (_ telegramSender) Name() string { return TelegramSender }
+func (sender telegramSender) Send(target string, notification Notification) error {
+	return postJSON(sender.client, sender.url, map[string]string{
+		"chat_id": target,
+		"text":    fmt.Sprintf("%s\n%s", notification.Title, notification.Message),
+	})
+}
+
+// the target is an email address
+type emailSender struct {
+	options NotificationOptions
+}
+
+func (_ emailSender) Name() string { return EmailSender }
+func (sender emailSender) Send(target string, notification Notification) error {
+	addr := net.JoinHostPort(sender.options.SMTPHost, fmt.Sprintf("%d", sender.options.SMTPPort))
+	var auth smtp.Auth
+	if sender.options.SMTPUsername != "" {
+		auth = smtp.PlainAuth("", sender.options.SMTPUsername, sender.options.SMTPPassword, sender.options.SMTPHost)
+	}
+	return smtp.SendMail(addr, auth, sender.options.SMTPFrom, []string{target}, getEmail(sender.options.SMTPFrom, target, notification))
+}
+
+func getEmail(from string, to string, notification Notification) []byte {
+	return []byte(strings.Join([]string{
+		fmt.Sprintf("From: %s", from),
+		fmt.Sprintf("To: %s", to),
+		fmt.Sprintf("Subject: %s", notification.Title),
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		notification.Message,
+		"",
+	}, "\r\n"))
+}
@@ -0,0 +1,41 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultAdminOptions() solver.AdminOptions {
+	return solver.AdminOptions{
+		Operators: GetDefaultServeOptionStringArray("ADMIN_OPERATORS", []string{}),
+		Viewers:   GetDefaultServeOptionStringArray("ADMIN_VIEWERS", []string{}),
+	}
+}
+
+func AddAdminCliFlags(cmd *cobra.Command, adminOptions *solver.AdminOptions) {
+	cmd.PersistentFlags().StringArrayVar(
+		&adminOptions.Operators, "admin-operators", adminOptions.Operators,
+		`The addresses that can sign requests to the admin API to ban addresses, expire offers, trigger matching and refresh the allowlist (ADMIN_OPERATORS).`,
+	)
+	cmd.PersistentFlags().StringArrayVar(
+		&adminOptions.Viewers, "admin-viewers", adminOptions.Viewers,
+		`The addresses that can sign requests to read the store stats, bans and admin log (ADMIN_VIEWERS).`,
+	)
+}
+
+func CheckAdminOptions(options solver.AdminOptions) error {
+	for _, address := range options.Operators {
+		if !common.IsHexAddress(address) {
+			return fmt.Errorf("ADMIN_OPERATORS: %s is not an address", address)
+		}
+	}
+	for _, address := range options.Viewers {
+		if !common.IsHexAddress(address) {
+			return fmt.Errorf("ADMIN_VIEWERS: %s is not an address", address)
+		}
+	}
+	return nil
+}
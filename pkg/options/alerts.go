@@ -0,0 +1,46 @@
+package options
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/lilypad-tech/lilypad/pkg/alerts"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultAlertOptions() alerts.AlertOptions {
+	return alerts.AlertOptions{
+		Webhooks:            GetDefaultServeOptionStringArray("ALERT_WEBHOOKS", []string{}),
+		PagerDutyRoutingKey: GetDefaultServeOptionString("ALERT_PAGERDUTY_ROUTING_KEY", ""),
+		PagerDutyURL:        GetDefaultServeOptionString("ALERT_PAGERDUTY_URL", alerts.DEFAULT_PAGERDUTY_URL),
+	}
+}
+
+func AddAlertCliFlags(cmd *cobra.Command, alertOptions *alerts.AlertOptions) {
+	cmd.PersistentFlags().StringArrayVar(
+		&alertOptions.Webhooks, "alert-webhooks", alertOptions.Webhooks,
+		`Incoming webhook URLs (e.g. Slack) that are sent {"text": ...} when our collateral is slashed (ALERT_WEBHOOKS).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&alertOptions.PagerDutyRoutingKey, "alert-pagerduty-routing-key", alertOptions.PagerDutyRoutingKey,
+		`The routing key of a PagerDuty Events API v2 integration to page when our collateral is slashed (ALERT_PAGERDUTY_ROUTING_KEY).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&alertOptions.PagerDutyURL, "alert-pagerduty-url", alertOptions.PagerDutyURL,
+		`Where PagerDuty events are sent (ALERT_PAGERDUTY_URL).`,
+	)
+}
+
+func CheckAlertOptions(options alerts.AlertOptions) error {
+	for _, webhook := range options.Webhooks {
+		if _, err := url.ParseRequestURI(webhook); err != nil {
+			return fmt.Errorf("ALERT_WEBHOOKS %q is not a url", webhook)
+		}
+	}
+	if options.PagerDutyRoutingKey != "" {
+		if _, err := url.ParseRequestURI(options.PagerDutyURL); err != nil {
+			return fmt.Errorf("ALERT_PAGERDUTY_URL %q is not a url", options.PagerDutyURL)
+		}
+	}
+	return nil
+}
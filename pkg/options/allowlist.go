@@ -0,0 +1,37 @@
+package options
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultAllowlistOptions() solver.AllowlistOptions {
+	return solver.AllowlistOptions{
+		Path:                  GetDefaultServeOptionString("ALLOWLIST_PATH", ""),
+		RequirePinnedVersions: GetDefaultServeOptionBool("ALLOWLIST_REQUIRE_PINNED_VERSIONS", false),
+	}
+}
+
+func AddAllowlistCliFlags(cmd *cobra.Command, allowlistOptions *solver.AllowlistOptions) {
+	cmd.PersistentFlags().StringVar(
+		&allowlistOptions.Path, "allowlist-path", allowlistOptions.Path,
+		`The path to a JSON file listing allowed modules and their minimum specs (ALLOWLIST_PATH).`,
+	)
+	cmd.PersistentFlags().BoolVar(
+		&allowlistOptions.RequirePinnedVersions, "allowlist-require-pinned-versions", allowlistOptions.RequirePinnedVersions,
+		`Reject job offers unless the module is pinned to a commit hash or release tag (ALLOWLIST_REQUIRE_PINNED_VERSIONS).`,
+	)
+}
+
+func CheckAllowlistOptions(options solver.AllowlistOptions) error {
+	if options.Path == "" {
+		return nil
+	}
+	if _, err := os.Stat(options.Path); err != nil {
+		return fmt.Errorf("ALLOWLIST_PATH could not be read: %s", err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package options
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/lilypad-tech/lilypad/pkg/attestation"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultAttestationOptions() attestation.VerifierOptions {
+	return attestation.VerifierOptions{
+		IntelRootCAPath: GetDefaultServeOptionString("TEE_INTEL_ROOT_CA", ""),
+		AMDRootCAPath:   GetDefaultServeOptionString("TEE_AMD_ROOT_CA", ""),
+	}
+}
+
+func AddAttestationCliFlags(cmd *cobra.Command, attestationOptions *attestation.VerifierOptions) {
+	cmd.PersistentFlags().StringVar(
+		&attestationOptions.IntelRootCAPath, "tee-intel-root-ca", attestationOptions.IntelRootCAPath,
+		`A PEM file of the Intel SGX root CA that sgx and tdx quotes are checked against, without it offers that claim sgx or tdx are turned away (TEE_INTEL_ROOT_CA).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&attestationOptions.AMDRootCAPath, "tee-amd-root-ca", attestationOptions.AMDRootCAPath,
+		`A PEM file of the AMD root key (ARK) that sev-snp reports are checked against, without it offers that claim sev-snp are turned away (TEE_AMD_ROOT_CA).`,
+	)
+}
+
+func CheckAttestationOptions(options attestation.VerifierOptions) error {
+	paths := []struct {
+		name string
+		path string
+	}{
+		{"TEE_INTEL_ROOT_CA", options.IntelRootCAPath},
+		{"TEE_AMD_ROOT_CA", options.AMDRootCAPath},
+	}
+	for _, item := range paths {
+		if item.path == "" {
+			continue
+		}
+		if _, err := os.Stat(item.path); err != nil {
+			return fmt.Errorf("%s %s", item.name, err.Error())
+		}
+	}
+	return nil
+}
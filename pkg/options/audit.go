@@ -0,0 +1,33 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultAuditOptions() solver.AuditOptions {
+	return solver.AuditOptions{
+		Percentage: GetDefaultServeOptionInt("AUDIT_PERCENTAGE", 0),
+		Verifiers:  GetDefaultServeOptionStringArray("AUDIT_VERIFIERS", []string{}),
+	}
+}
+
+func AddAuditCliFlags(cmd *cobra.Command, auditOptions *solver.AuditOptions) {
+	cmd.PersistentFlags().IntVar(
+		&auditOptions.Percentage, "audit-percentage", auditOptions.Percentage,
+		`The percentage of completed deals to audit even if they are not disputed (AUDIT_PERCENTAGE).`,
+	)
+	cmd.PersistentFlags().StringArrayVar(
+		&auditOptions.Verifiers, "audit-verifiers", auditOptions.Verifiers,
+		`The verifiers an audit runs, leave empty to run them all (AUDIT_VERIFIERS).`,
+	)
+}
+
+func CheckAuditOptions(options solver.AuditOptions) error {
+	if options.Percentage < 0 || options.Percentage > 100 {
+		return fmt.Errorf("AUDIT_PERCENTAGE must be between 0 and 100")
+	}
+	return nil
+}
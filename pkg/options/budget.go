@@ -0,0 +1,58 @@
+package options
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/lilypad-tech/lilypad/pkg/jobcreator"
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultBudgetOptions() solver.BudgetOptions {
+	return solver.BudgetOptions{
+		Path: GetDefaultServeOptionString("BUDGETS_PATH", ""),
+	}
+}
+
+func AddBudgetCliFlags(cmd *cobra.Command, budgetOptions *solver.BudgetOptions) {
+	cmd.PersistentFlags().StringVar(
+		&budgetOptions.Path, "budgets-path", budgetOptions.Path,
+		`The path to a JSON file of the most each job creator can spend per day and per week (BUDGETS_PATH).`,
+	)
+}
+
+func CheckBudgetOptions(options solver.BudgetOptions) error {
+	if options.Path == "" {
+		return nil
+	}
+	if _, err := os.Stat(options.Path); err != nil {
+		return fmt.Errorf("BUDGETS_PATH could not be read: %s", err.Error())
+	}
+	return nil
+}
+
+func GetDefaultJobCreatorBudgetOptions() jobcreator.JobCreatorBudgetOptions {
+	return jobcreator.JobCreatorBudgetOptions{
+		Daily:  GetDefaultServeOptionUint64("JOB_BUDGET_DAILY", 0),
+		Weekly: GetDefaultServeOptionUint64("JOB_BUDGET_WEEKLY", 0),
+	}
+}
+
+func AddJobCreatorBudgetCliFlags(cmd *cobra.Command, budgetOptions *jobcreator.JobCreatorBudgetOptions) {
+	cmd.PersistentFlags().Uint64Var(
+		&budgetOptions.Daily, "budget-daily", budgetOptions.Daily,
+		`The most to spend on jobs in any 24 hours, 0 is no cap (JOB_BUDGET_DAILY).`,
+	)
+	cmd.PersistentFlags().Uint64Var(
+		&budgetOptions.Weekly, "budget-weekly", budgetOptions.Weekly,
+		`The most to spend on jobs in any 7 days, 0 is no cap (JOB_BUDGET_WEEKLY).`,
+	)
+}
+
+func CheckJobCreatorBudgetOptions(options jobcreator.JobCreatorBudgetOptions) error {
+	if options.Daily > 0 && options.Weekly > 0 && options.Daily > options.Weekly {
+		return fmt.Errorf("JOB_BUDGET_DAILY cannot be more than JOB_BUDGET_WEEKLY")
+	}
+	return nil
+}
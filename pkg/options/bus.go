@@ -0,0 +1,44 @@
+package options
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/lilypad-tech/lilypad/pkg/bus"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultBusOptions() bus.BusOptions {
+	return bus.BusOptions{
+		URL:           GetDefaultServeOptionString("BUS_URL", ""),
+		SubjectPrefix: GetDefaultServeOptionString("BUS_SUBJECT_PREFIX", bus.DEFAULT_SUBJECT_PREFIX),
+	}
+}
+
+func AddBusCliFlags(cmd *cobra.Command, busOptions *bus.BusOptions) {
+	cmd.PersistentFlags().StringVar(
+		&busOptions.URL, "bus-url", busOptions.URL,
+		`The NATS server to send offers and hear solver events through e.g. nats://localhost:4222, leave empty to use HTTP and websockets (BUS_URL).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&busOptions.SubjectPrefix, "bus-subject-prefix", busOptions.SubjectPrefix,
+		`The prefix of the bus subjects, the solver and its clients must agree on it (BUS_SUBJECT_PREFIX).`,
+	)
+}
+
+func CheckBusOptions(options bus.BusOptions) error {
+	if options.URL == "" {
+		return nil
+	}
+	u, err := url.Parse(options.URL)
+	if err != nil {
+		return fmt.Errorf("BUS_URL is not a valid URL: %s", err.Error())
+	}
+	if u.Scheme != "nats" && u.Scheme != "tls" {
+		return fmt.Errorf("BUS_URL must be a nats:// or tls:// URL")
+	}
+	if options.SubjectPrefix == "" {
+		return fmt.Errorf("BUS_SUBJECT_PREFIX is required when BUS_URL is set")
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/lilypad-tech/lilypad/pkg/web3"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultChainsOptions() web3.ChainsOptions {
+	return web3.ChainsOptions{
+		Networks: GetDefaultServeOptionStringArray("WEB3_CHAINS", []string{}),
+	}
+}
+
+func AddChainsCliFlags(cmd *cobra.Command, chainsOptions *web3.ChainsOptions) {
+	cmd.PersistentFlags().StringArrayVar(
+		&chainsOptions.Networks, "web3-chains", chainsOptions.Networks,
+		`The networks to serve as well as the main one e.g. testnet (WEB3_CHAINS).`,
+	)
+}
+
+// load the web3 settings of each network, we sign with the
+// same key on every chain so the signer comes from the main chain
+func ProcessChainsOptions(options web3.ChainsOptions, main web3.Web3Options) (web3.ChainsOptions, error) {
+	seen := map[int]string{main.ChainID: "the main network"}
+	options.Chains = []web3.Web3Options{}
+	for _, network := range options.Networks {
+		config, err := getConfig(network)
+		if err != nil {
+			return options, err
+		}
+		chain := config.Web3
+		chain.PrivateKey = main.PrivateKey
+		chain.Signer = main.Signer
+		chain.KeystorePath = main.KeystorePath
+		chain.KeystorePassword = main.KeystorePassword
+		chain.KMSKeyID = main.KMSKeyID
+		chain.KMSRegion = main.KMSRegion
+		chain.KMSEndpoint = main.KMSEndpoint
+		chain.RemoteSignerURL = main.RemoteSignerURL
+		chain.RemoteSignerToken = main.RemoteSignerToken
+		chain.SignerAddress = main.SignerAddress
+		chain.Service = main.Service
+		if chain.RpcHealthCheckInterval == 0 {
+			chain.RpcHealthCheckInterval = main.RpcHealthCheckInterval
+		}
+		if chain.RpcMaxBackoff == 0 {
+			chain.RpcMaxBackoff = main.RpcMaxBackoff
+		}
+		if chain.RpcMaxBlockLag == 0 {
+			chain.RpcMaxBlockLag = main.RpcMaxBlockLag
+		}
+		// confirmations depend on the chain so are left to its config
+		if chain.SyncPollInterval == 0 {
+			chain.SyncPollInterval = main.SyncPollInterval
+		}
+		if chain.SyncMaxBlockRange == 0 {
+			chain.SyncMaxBlockRange = main.SyncMaxBlockRange
+		}
+		// fee amounts differ from chain to chain so only the
+		// way we replace stuck transactions carries over
+		if chain.GasStrategy == "" {
+			chain.GasStrategy = main.GasStrategy
+		}
+		if chain.GasBumpPercent == 0 {
+			chain.GasBumpPercent = main.GasBumpPercent
+		}
+		if chain.GasStuckTimeout == 0 {
+			chain.GasStuckTimeout = main.GasStuckTimeout
+		}
+		if chain.GasMaxBumps == 0 {
+			chain.GasMaxBumps = main.GasMaxBumps
+		}
+		if chain.ChainID == 0 {
+			return options, fmt.Errorf("WEB3_CHAINS network %s has no chain id", network)
+		}
+		if other, ok := seen[chain.ChainID]; ok {
+			return options, fmt.Errorf("WEB3_CHAINS network %s has the same chain id %d as %s", network, chain.ChainID, other)
+		}
+		seen[chain.ChainID] = network
+		err = CheckWeb3Options(chain)
+		if err != nil {
+			return options, fmt.Errorf("WEB3_CHAINS network %s: %s", network, err.Error())
+		}
+		options.Chains = append(options.Chains, chain)
+	}
+	return options, nil
+}
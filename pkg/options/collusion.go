@@ -0,0 +1,57 @@
+package options
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultCollusionOptions() solver.CollusionOptions {
+	return solver.CollusionOptions{
+		Policy:          GetDefaultServeOptionString("COLLUSION_POLICY", data.COLLUSION_POLICY_FLAG),
+		OperatorsPath:   GetDefaultServeOptionString("COLLUSION_OPERATORS_PATH", ""),
+		FundingLookback: GetDefaultServeOptionUint64("COLLUSION_FUNDING_LOOKBACK", 0),
+		IgnoreFunders:   GetDefaultServeOptionStringArray("COLLUSION_IGNORE_FUNDERS", []string{}),
+	}
+}
+
+func AddCollusionCliFlags(cmd *cobra.Command, collusionOptions *solver.CollusionOptions) {
+	cmd.PersistentFlags().StringVar(
+		&collusionOptions.Policy, "collusion-policy", collusionOptions.Policy,
+		fmt.Sprintf(`What to do with mediators that look to be run by the resource provider of a deal, one of %s (COLLUSION_POLICY).`, strings.Join(data.CollusionPolicies, ", ")),
+	)
+	cmd.PersistentFlags().StringVar(
+		&collusionOptions.OperatorsPath, "collusion-operators-path", collusionOptions.OperatorsPath,
+		`The path to a JSON file listing the addresses each operator runs (COLLUSION_OPERATORS_PATH).`,
+	)
+	cmd.PersistentFlags().Uint64Var(
+		&collusionOptions.FundingLookback, "collusion-funding-lookback", collusionOptions.FundingLookback,
+		`How many blocks back to look for LP token transfers tying a mediator to a resource provider, 0 does not look (COLLUSION_FUNDING_LOOKBACK).`,
+	)
+	cmd.PersistentFlags().StringArrayVar(
+		&collusionOptions.IgnoreFunders, "collusion-ignore-funders", collusionOptions.IgnoreFunders,
+		`Addresses such as faucets and exchanges that sending LP tokens from says nothing about who runs an address (COLLUSION_IGNORE_FUNDERS).`,
+	)
+}
+
+func CheckCollusionOptions(options solver.CollusionOptions) error {
+	if !data.IsCollusionPolicy(options.Policy) {
+		return fmt.Errorf("COLLUSION_POLICY must be one of %s", strings.Join(data.CollusionPolicies, ", "))
+	}
+	if options.OperatorsPath != "" {
+		if _, err := os.Stat(options.OperatorsPath); err != nil {
+			return fmt.Errorf("COLLUSION_OPERATORS_PATH could not be read: %s", err.Error())
+		}
+	}
+	for _, address := range options.IgnoreFunders {
+		if !common.IsHexAddress(address) {
+			return fmt.Errorf("COLLUSION_IGNORE_FUNDERS %q is not an address", address)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package options
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/lilypad-tech/lilypad/pkg/executor/container"
+	"github.com/lilypad-tech/lilypad/pkg/executor/kubernetes"
+	"github.com/lilypad-tech/lilypad/pkg/executor/wasm"
+	"github.com/lilypad-tech/lilypad/pkg/resourceprovider"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultExecutorOptions() resourceprovider.ResourceProviderExecutorOptions {
+	return resourceprovider.ResourceProviderExecutorOptions{
+		Type:               GetDefaultServeOptionString("EXECUTOR", resourceprovider.EXECUTOR_BACALHAU),
+		Kubernetes:         GetDefaultKubernetesOptions(),
+		Wasm:               GetDefaultWasmOptions(),
+		Sandbox:            GetDefaultSandboxOptions(),
+		CheckpointInterval: GetDefaultServeOptionInt("CHECKPOINT_INTERVAL", 600),
+		Egress:             GetDefaultEgressOptions(),
+		Service: container.ServiceOptions{
+			Network: GetDefaultServeOptionString("SERVICE_NETWORK", ""),
+		},
+	}
+}
+
+func GetDefaultWasmOptions() wasm.WasmExecutorOptions {
+	return wasm.WasmExecutorOptions{
+		Wasmtime: GetDefaultServeOptionString("WASMTIME", wasm.DEFAULT_WASMTIME),
+	}
+}
+
+func GetDefaultEgressOptions() container.EgressOptions {
+	return container.EgressOptions{
+		Network:      GetDefaultServeOptionString("EGRESS_NETWORK", ""),
+		ProxyAddress: GetDefaultServeOptionString("EGRESS_PROXY_ADDRESS", ""),
+		ProxyURL:     GetDefaultServeOptionString("EGRESS_PROXY_URL", ""),
+	}
+}
+
+func GetDefaultSandboxOptions() container.SandboxOptions {
+	return container.SandboxOptions{
+		Default: GetDefaultServeOptionString("SANDBOX", container.SANDBOX_NONE),
+		Modules: GetDefaultServeOptionStringMap("SANDBOX_MODULES", map[string]string{}),
+		Runtimes: GetDefaultServeOptionStringMap("SANDBOX_RUNTIMES", map[string]string{
+			container.SANDBOX_GVISOR:      "runsc",
+			container.SANDBOX_FIRECRACKER: "kata-fc",
+		}),
+		AllowNetwork:  GetDefaultServeOptionBool("SANDBOX_ALLOW_NETWORK", false),
+		DefaultCPU:    GetDefaultServeOptionString("SANDBOX_DEFAULT_CPU", "1"),
+		DefaultMemory: GetDefaultServeOptionString("SANDBOX_DEFAULT_MEMORY", "2gb"),
+	}
+}
+
+func GetDefaultKubernetesOptions() kubernetes.KubernetesExecutorOptions {
+	return kubernetes.KubernetesExecutorOptions{
+		Kubeconfig:  GetDefaultServeOptionString("KUBERNETES_KUBECONFIG", ""),
+		Context:     GetDefaultServeOptionString("KUBERNETES_CONTEXT", ""),
+		Namespace:   GetDefaultServeOptionString("KUBERNETES_NAMESPACE", "default"),
+		HelperImage: GetDefaultServeOptionString("KUBERNETES_HELPER_IMAGE", "busybox:1.36"),
+		IPFSGateway: GetDefaultServeOptionString("KUBERNETES_IPFS_GATEWAY", "https://ipfs.io"),
+
+		NetworkPolicies: GetDefaultServeOptionBool("KUBERNETES_NETWORK_POLICIES", false),
+		EgressAllow:     GetDefaultServeOptionStringArray("KUBERNETES_EGRESS_ALLOW", []string{}),
+	}
+}
+
+func AddExecutorCliFlags(cmd *cobra.Command, options *resourceprovider.ResourceProviderExecutorOptions) {
+	cmd.PersistentFlags().StringVar(
+		&options.Type, "executor", options.Type,
+		fmt.Sprintf(`What runs our jobs, one of %s (EXECUTOR).`, strings.Join(resourceprovider.ExecutorTypes, ", ")),
+	)
+	AddKubernetesCliFlags(cmd, &options.Kubernetes)
+	cmd.PersistentFlags().StringVar(
+		&options.Wasm.Wasmtime, "wasmtime", options.Wasm.Wasmtime,
+		`The wasmtime binary the wasm executor runs modules with (WASMTIME).`,
+	)
+	AddSandboxCliFlags(cmd, &options.Sandbox)
+	AddEgressCliFlags(cmd, &options.Egress)
+	cmd.PersistentFlags().StringVar(
+		&options.Service.Network, "service-network", options.Service.Network,
+		`The docker or podman network services without network access are attached to so we can send them requests, leave empty to not run them (SERVICE_NETWORK).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&options.CheckpointInterval, "checkpoint-interval", options.CheckpointInterval,
+		`How often in seconds the checkpoints of running jobs are uploaded, modules can set their own (CHECKPOINT_INTERVAL).`,
+	)
+}
+
+func AddSandboxCliFlags(cmd *cobra.Command, options *container.SandboxOptions) {
+	cmd.PersistentFlags().StringVar(
+		&options.Default, "sandbox", options.Default,
+		fmt.Sprintf(`The sandbox every job runs in, one of %s (SANDBOX).`, strings.Join(container.SandboxTypes, ", ")),
+	)
+	cmd.PersistentFlags().StringToStringVar(
+		&options.Modules, "sandbox-modules", options.Modules,
+		`The sandbox for particular modules as module_id=sandbox, overriding --sandbox (SANDBOX_MODULES).`,
+	)
+	cmd.PersistentFlags().StringToStringVar(
+		&options.Runtimes, "sandbox-runtimes", options.Runtimes,
+		`The OCI runtime, or kubernetes runtime class, for each sandbox e.g. gvisor=runsc,firecracker=kata-fc (SANDBOX_RUNTIMES).`,
+	)
+	cmd.PersistentFlags().BoolVar(
+		&options.AllowNetwork, "sandbox-allow-network", options.AllowNetwork,
+		`Give sandboxed jobs network access when their module asks for it (SANDBOX_ALLOW_NETWORK).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&options.DefaultCPU, "sandbox-default-cpu", options.DefaultCPU,
+		`The cpu limit for sandboxed jobs whose module does not set one e.g. 1 or 500m (SANDBOX_DEFAULT_CPU).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&options.DefaultMemory, "sandbox-default-memory", options.DefaultMemory,
+		`The memory limit for sandboxed jobs whose module does not set one e.g. 2gb (SANDBOX_DEFAULT_MEMORY).`,
+	)
+}
+
+func AddEgressCliFlags(cmd *cobra.Command, options *container.EgressOptions) {
+	cmd.PersistentFlags().StringVar(
+		&options.Network, "egress-network", options.Network,
+		`The docker or podman network that can only reach the egress proxy, jobs with a network allowlist are attached to it (EGRESS_NETWORK).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&options.ProxyAddress, "egress-proxy-address", options.ProxyAddress,
+		`The address the egress proxy listens on e.g. 0.0.0.0:3128 (EGRESS_PROXY_ADDRESS).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&options.ProxyURL, "egress-proxy-url", options.ProxyURL,
+		`The egress proxy as jobs on the egress network reach it e.g. http://lilypad-rp:3128 (EGRESS_PROXY_URL).`,
+	)
+}
+
+func AddKubernetesCliFlags(cmd *cobra.Command, options *kubernetes.KubernetesExecutorOptions) {
+	cmd.PersistentFlags().StringVar(
+		&options.Kubeconfig, "kubernetes-kubeconfig", options.Kubeconfig,
+		`The kubeconfig file kubectl uses, empty for its default (KUBERNETES_KUBECONFIG).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&options.Context, "kubernetes-context", options.Context,
+		`The kubeconfig context to run jobs in, empty for the current one (KUBERNETES_CONTEXT).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&options.Namespace, "kubernetes-namespace", options.Namespace,
+		`The namespace to run jobs in (KUBERNETES_NAMESPACE).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&options.HelperImage, "kubernetes-helper-image", options.HelperImage,
+		`An image with sh, wget and tar used to fetch inputs and collect outputs (KUBERNETES_HELPER_IMAGE).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&options.IPFSGateway, "kubernetes-ipfs-gateway", options.IPFSGateway,
+		`The IPFS gateway jobs download their inputs from inside the cluster (KUBERNETES_IPFS_GATEWAY).`,
+	)
+	cmd.PersistentFlags().BoolVar(
+		&options.NetworkPolicies, "kubernetes-network-policies", options.NetworkPolicies,
+		`Create a network policy for each job that limits what it can reach, the cluster network plugin must enforce them (KUBERNETES_NETWORK_POLICIES).`,
+	)
+	cmd.PersistentFlags().StringArrayVar(
+		&options.EgressAllow, "kubernetes-egress-allow", options.EgressAllow,
+		`CIDRs every job pod can reach under a network policy e.g. the IPFS gateway (KUBERNETES_EGRESS_ALLOW).`,
+	)
+}
+
+func CheckExecutorOptions(options resourceprovider.ResourceProviderExecutorOptions) error {
+	known := false
+	for _, executorType := range resourceprovider.ExecutorTypes {
+		if options.Type == executorType {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return fmt.Errorf("EXECUTOR must be one of %s", strings.Join(resourceprovider.ExecutorTypes, ", "))
+	}
+	if options.Type == resourceprovider.EXECUTOR_KUBERNETES {
+		if options.Kubernetes.Namespace == "" {
+			return fmt.Errorf("KUBERNETES_NAMESPACE is required for the kubernetes executor")
+		}
+		if options.Kubernetes.HelperImage == "" {
+			return fmt.Errorf("KUBERNETES_HELPER_IMAGE is required for the kubernetes executor")
+		}
+	}
+	if options.CheckpointInterval <= 0 {
+		return fmt.Errorf("CHECKPOINT_INTERVAL must be more than zero")
+	}
+	err := container.CheckSandboxOptions(options.Sandbox)
+	if err != nil {
+		return fmt.Errorf("SANDBOX: %s", err.Error())
+	}
+	sandboxed := options.Sandbox.Default != container.SANDBOX_NONE
+	for _, sandbox := range options.Sandbox.Modules {
+		sandboxed = sandboxed || sandbox != container.SANDBOX_NONE
+	}
+	if sandboxed && (options.Type == resourceprovider.EXECUTOR_BACALHAU || options.Type == resourceprovider.EXECUTOR_WASM) {
+		return fmt.Errorf("SANDBOX needs the docker, podman or kubernetes executor")
+	}
+	egress := options.Egress
+	if (egress.Network != "" || egress.ProxyAddress != "" || egress.ProxyURL != "") && !egress.Enabled() {
+		return fmt.Errorf("EGRESS_NETWORK, EGRESS_PROXY_ADDRESS and EGRESS_PROXY_URL must be set together")
+	}
+	for _, cidr := range options.Kubernetes.EgressAllow {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("KUBERNETES_EGRESS_ALLOW: %q is not a CIDR", cidr)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package options
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/lilypad-tech/lilypad/pkg/fiat"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultFiatOptions() fiat.Options {
+	return fiat.Options{
+		OracleURL:    GetDefaultServeOptionString("FIAT_ORACLE_URL", ""),
+		Currency:     GetDefaultServeOptionString("FIAT_CURRENCY", "usd"),
+		NetworkToken: GetDefaultServeOptionString("FIAT_NETWORK_TOKEN", "lilypad"),
+		CacheTTL:     GetDefaultServeOptionInt("FIAT_CACHE_TTL", 300),   //nolint:gomnd
+		MinInterval:  GetDefaultServeOptionInt("FIAT_MIN_INTERVAL", 10), //nolint:gomnd
+	}
+}
+
+func AddFiatCliFlags(cmd *cobra.Command, fiatOptions *fiat.Options) {
+	cmd.PersistentFlags().StringVar(
+		&fiatOptions.OracleURL, "fiat-oracle-url", fiatOptions.OracleURL,
+		`The exchange rate API to show prices in a fiat currency with, {token} and {currency} are filled in, empty shows no fiat prices (FIAT_ORACLE_URL).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&fiatOptions.Currency, "fiat-currency", fiatOptions.Currency,
+		`The fiat currency to show prices in (FIAT_CURRENCY).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&fiatOptions.NetworkToken, "fiat-network-token", fiatOptions.NetworkToken,
+		`What the price oracle calls the network token, ERC-20 payment tokens are looked up by address (FIAT_NETWORK_TOKEN).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&fiatOptions.CacheTTL, "fiat-cache-ttl", fiatOptions.CacheTTL,
+		`How many seconds an exchange rate is used for before asking the oracle again (FIAT_CACHE_TTL).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&fiatOptions.MinInterval, "fiat-min-interval", fiatOptions.MinInterval,
+		`The fewest seconds between requests to the price oracle (FIAT_MIN_INTERVAL).`,
+	)
+}
+
+func CheckFiatOptions(options fiat.Options) error {
+	if !options.Enabled() {
+		return nil
+	}
+	_, err := url.ParseRequestURI(options.OracleURL)
+	if err != nil {
+		return fmt.Errorf("FIAT_ORACLE_URL: %s", err.Error())
+	}
+	if !strings.Contains(options.OracleURL, "{token}") {
+		return fmt.Errorf("FIAT_ORACLE_URL must have {token} in it")
+	}
+	if options.Currency == "" {
+		return fmt.Errorf("FIAT_CURRENCY cannot be empty")
+	}
+	if options.CacheTTL <= 0 {
+		return fmt.Errorf("FIAT_CACHE_TTL must be greater than zero")
+	}
+	if options.MinInterval < 0 {
+		return fmt.Errorf("FIAT_MIN_INTERVAL cannot be negative")
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/lilypad-tech/lilypad/pkg/http"
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultGRPCOptions() solver.GRPCOptions {
+	return solver.GRPCOptions{
+		Port: GetDefaultServeOptionInt("GRPC_PORT", 0),
+	}
+}
+
+func AddGRPCCliFlags(cmd *cobra.Command, grpcOptions *solver.GRPCOptions) {
+	cmd.PersistentFlags().IntVar(
+		&grpcOptions.Port, "grpc-port", grpcOptions.Port,
+		`The port to serve the gRPC API on, it is bound to the server host and zero turns it off (GRPC_PORT).`,
+	)
+}
+
+func CheckGRPCOptions(options solver.GRPCOptions, serverOptions http.ServerOptions) error {
+	if options.Port < 0 {
+		return fmt.Errorf("GRPC_PORT cannot be negative")
+	}
+	if options.Port > 0 && options.Port == serverOptions.Port {
+		return fmt.Errorf("GRPC_PORT must be different to SERVER_PORT")
+	}
+	return nil
+}
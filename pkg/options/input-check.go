@@ -0,0 +1,47 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultInputCheckOptions() solver.InputCheckOptions {
+	return solver.InputCheckOptions{
+		Enabled: GetDefaultServeOptionBool("INPUT_CHECK", false),
+		Timeout: GetDefaultServeOptionInt("INPUT_CHECK_TIMEOUT", 30), //nolint:gomnd
+		MaxSize: GetDefaultServeOptionInt("INPUT_CHECK_MAX_SIZE", 0),
+	}
+}
+
+func AddInputCheckCliFlags(cmd *cobra.Command, inputCheckOptions *solver.InputCheckOptions) {
+	cmd.PersistentFlags().BoolVar(
+		&inputCheckOptions.Enabled, "input-check", inputCheckOptions.Enabled,
+		`Reject job offers whose input CIDs cannot be found on IPFS (INPUT_CHECK).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&inputCheckOptions.Timeout, "input-check-timeout", inputCheckOptions.Timeout,
+		`The number of seconds to wait for each input CID to be found (INPUT_CHECK_TIMEOUT).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&inputCheckOptions.MaxSize, "input-check-max-size", inputCheckOptions.MaxSize,
+		`The largest total input in megabytes a job offer can name, 0 for no limit (INPUT_CHECK_MAX_SIZE).`,
+	)
+}
+
+func CheckInputCheckOptions(options solver.InputCheckOptions, ipfsAddr string) error {
+	if !options.Enabled {
+		return nil
+	}
+	if ipfsAddr == "" {
+		return fmt.Errorf("INPUT_CHECK needs IPFS_CONNECT to look up the inputs")
+	}
+	if options.Timeout <= 0 {
+		return fmt.Errorf("INPUT_CHECK_TIMEOUT must be greater than zero")
+	}
+	if options.MaxSize < 0 {
+		return fmt.Errorf("INPUT_CHECK_MAX_SIZE cannot be negative")
+	}
+	return nil
+}
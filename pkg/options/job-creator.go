@@ -3,6 +3,8 @@ package options
 import (
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ipfs/go-cid"
 	"github.com/lilypad-tech/lilypad/pkg/data"
 	"github.com/lilypad-tech/lilypad/pkg/jobcreator"
 	"github.com/lilypad-tech/lilypad/pkg/system"
@@ -14,12 +16,26 @@ func NewJobCreatorOptions() jobcreator.JobCreatorOptions {
 		Offer:     GetDefaultJobCreatorOfferOptions(),
 		Web3:      GetDefaultWeb3Options(),
 		Mediation: GetDefaultJobCreatorMediationOptions(),
+		Bus:       GetDefaultBusOptions(),
 		Telemetry: GetDefaultTelemetryOptions(),
+		Watch:     GetDefaultServeOptionBool("JOB_WATCH", false),
+		SpecFile:  GetDefaultServeOptionString("JOB_SPEC_FILE", ""),
+		Schedule:  GetDefaultJobCreatorScheduleOptions(),
+		Fiat:      GetDefaultFiatOptions(),
+		Webhooks:  GetDefaultWebhookOptions(),
+		Budget:    GetDefaultJobCreatorBudgetOptions(),
 	}
 	options.Web3.Service = system.JobCreatorService
 	return options
 }
 
+func GetDefaultJobCreatorScheduleOptions() jobcreator.JobCreatorScheduleOptions {
+	return jobcreator.JobCreatorScheduleOptions{
+		Cron:          GetDefaultServeOptionString("JOB_SCHEDULE_CRON", ""),
+		OverlapPolicy: GetDefaultServeOptionString("JOB_SCHEDULE_OVERLAP_POLICY", data.ScheduleOverlapSkip),
+	}
+}
+
 func GetDefaultJobCreatorMediationOptions() jobcreator.JobCreatorMediationOptions {
 	return jobcreator.JobCreatorMediationOptions{
 		CheckResultsPercentage: GetDefaultServeOptionInt("MEDIATION_CHANCE", 0),
@@ -34,7 +50,58 @@ func GetDefaultJobCreatorOfferOptions() jobcreator.JobCreatorOfferOptions {
 		Pricing:  GetDefaultPricingOptions(),
 		Timeouts: GetDefaultTimeoutOptions(),
 		Inputs:   map[string]string{},
-		Services: GetDefaultServicesOptions(),
+		// sent encrypted to the resource provider once the job is matched
+		EncryptedInputs: map[string]string{},
+		// the declared size of the inputs so oversized jobs are rejected up front
+		InputSize:  GetDefaultServeOptionInt("JOB_INPUT_SIZE", 0),
+		InputCIDs:  GetDefaultServeOptionStringArray("JOB_INPUT_CIDS", []string{}),
+		MaxRuntime: GetDefaultServeOptionInt("JOB_MAX_RUNTIME", 0),
+		Services:   GetDefaultServicesOptions(),
+		// restrict which resource providers the solver can match the job with
+		TrustedProviders:  GetDefaultServeOptionStringArray("JOB_TRUSTED_PROVIDERS", []string{}),
+		ExcludedProviders: GetDefaultServeOptionStringArray("JOB_EXCLUDED_PROVIDERS", []string{}),
+		// select resource offers by their labels
+		RequiredLabels:  GetDefaultServeOptionStringMap("JOB_REQUIRED_LABELS", map[string]string{}),
+		PreferredLabels: GetDefaultServeOptionStringMap("JOB_PREFERRED_LABELS", map[string]string{}),
+		// only run the job inside an attested TEE
+		TEETypes:        GetDefaultServeOptionStringArray("JOB_TEE", []string{}),
+		TEEMeasurements: GetDefaultServeOptionStringArray("JOB_TEE_MEASUREMENTS", []string{}),
+		PaymentToken:    GetDefaultServeOptionString("JOB_PAYMENT_TOKEN", ""),
+		// release the payment as checkpoints of a long job are accepted
+		Milestones: GetDefaultServeOptionInt("JOB_MILESTONES", 0),
+		// take cheaper spot capacity as well as reserved
+		OfferClasses:        GetDefaultServeOptionStringArray("JOB_OFFER_CLASSES", []string{}),
+		MinPreemptionNotice: GetDefaultServeOptionInt("JOB_MIN_PREEMPTION_NOTICE", 0),
+		// only run modules pinned to a commit hash or release tag
+		RequirePinnedVersion: GetDefaultServeOptionBool("JOB_REQUIRE_PINNED_VERSION", false),
+		// carry on from a checkpoint of a deal that did not finish
+		ResumeFrom: GetDefaultServeOptionString("JOB_RESUME_FROM", ""),
+		// so running the same job again after an error does not add it twice
+		IdempotencyKey: GetDefaultServeOptionString("JOB_IDEMPOTENCY_KEY", ""),
+		// have disputed results judged by more than one mediator
+		MediatorQuorum: data.MediatorQuorum{
+			Size:      GetDefaultServeOptionInt("JOB_MEDIATOR_QUORUM", 0),
+			Threshold: GetDefaultServeOptionInt("JOB_MEDIATOR_QUORUM_THRESHOLD", 0),
+		},
+		// run the job again when a deal for it fails
+		Retry: data.RetryPolicy{
+			MaxAttempts:            GetDefaultServeOptionInt("JOB_RETRY_MAX_ATTEMPTS", 0),
+			OnTimeout:              GetDefaultServeOptionBool("JOB_RETRY_ON_TIMEOUT", false),
+			OnError:                GetDefaultServeOptionBool("JOB_RETRY_ON_ERROR", false),
+			ExcludeFailedProviders: GetDefaultServeOptionBool("JOB_RETRY_EXCLUDE_FAILED", false),
+		},
+		// skip running the same work twice
+		ReuseResults: GetDefaultServeOptionBool("JOB_REUSE_RESULTS", false),
+		// pay by usage
+		Metered: GetDefaultServeOptionBool("JOB_METERED", false),
+		// keep the module running and send it requests
+		Serve: data.ServiceDealConfig{
+			Port:     GetDefaultServeOptionInt("JOB_SERVICE_PORT", 0),
+			Duration: GetDefaultServeOptionInt("JOB_SERVICE_DURATION", 0),
+		},
+		Env: GetDefaultServeOptionStringMap("JOB_ENV", map[string]string{}),
+		// sweep an input over a range of values
+		Array: GetDefaultServeOptionString("JOB_ARRAY", ""),
 	}
 }
 
@@ -50,6 +117,124 @@ func AddJobCreatorMediationCliFlags(cmd *cobra.Command, mediationOptions *jobcre
 func AddJobCreatorOfferCliFlags(cmd *cobra.Command, offerOptions *jobcreator.JobCreatorOfferOptions) {
 	// add the inputs that we will merge into the module template file
 	cmd.PersistentFlags().StringToStringVarP(&offerOptions.Inputs, "input", "i", offerOptions.Inputs, "Input key-value pairs")
+	cmd.PersistentFlags().StringToStringVar(
+		&offerOptions.EncryptedInputs, "encrypted-input", offerOptions.EncryptedInputs,
+		`Input key-value pairs that are only sent to the matched resource provider, encrypted for its key.`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&offerOptions.InputSize, "input-size", offerOptions.InputSize,
+		`The total size in megabytes of the data the job will download (JOB_INPUT_SIZE).`,
+	)
+	cmd.PersistentFlags().StringArrayVar(
+		&offerOptions.InputCIDs, "input-cid", offerOptions.InputCIDs,
+		`The IPFS CID of an input the job reads so the solver can check it can be fetched, can be given more than once (JOB_INPUT_CIDS).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&offerOptions.MaxRuntime, "max-runtime", offerOptions.MaxRuntime,
+		`The number of seconds the job can run for, leave at 0 to use the module default (JOB_MAX_RUNTIME).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&offerOptions.ResumeFrom, "resume-from", offerOptions.ResumeFrom,
+		`The CID of a checkpoint from an earlier run of the module to start the job from (JOB_RESUME_FROM).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&offerOptions.IdempotencyKey, "idempotency-key", offerOptions.IdempotencyKey,
+		`A key of your choosing, running the job again with the same key follows the job offer it already added rather than adding another (JOB_IDEMPOTENCY_KEY).`,
+	)
+
+	cmd.PersistentFlags().IntVar(
+		&offerOptions.MediatorQuorum.Size, "mediator-quorum", offerOptions.MediatorQuorum.Size,
+		`How many of our trusted mediators judge disputed results, leave at 0 for the one the contract picks (JOB_MEDIATOR_QUORUM).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&offerOptions.MediatorQuorum.Threshold, "mediator-quorum-threshold", offerOptions.MediatorQuorum.Threshold,
+		`How many of the quorum have to accept a result for it to stand, leave at 0 for a majority (JOB_MEDIATOR_QUORUM_THRESHOLD).`,
+	)
+
+	cmd.PersistentFlags().BoolVar(
+		&offerOptions.ReuseResults, "reuse-results", offerOptions.ReuseResults,
+		`If you already ran the same pinned module version on the same inputs get that result back rather than paying to run it again (JOB_REUSE_RESULTS).`,
+	)
+	cmd.PersistentFlags().BoolVar(
+		&offerOptions.Metered, "metered", offerOptions.Metered,
+		`Pay the instruction price for each cpu second and tenth of a gpu second the job uses rather than once for the job (JOB_METERED).`,
+	)
+
+	cmd.PersistentFlags().IntVar(
+		&offerOptions.Retry.MaxAttempts, "retry-max-attempts", offerOptions.Retry.MaxAttempts,
+		`Have the solver run the job again when a deal for it fails, up to this many deals in all, leave at 0 to not retry (JOB_RETRY_MAX_ATTEMPTS).`,
+	)
+	cmd.PersistentFlags().BoolVar(
+		&offerOptions.Retry.OnTimeout, "retry-on-timeout", offerOptions.Retry.OnTimeout,
+		`Retry deals that time out, with neither this nor --retry-on-error every failure is retried (JOB_RETRY_ON_TIMEOUT).`,
+	)
+	cmd.PersistentFlags().BoolVar(
+		&offerOptions.Retry.OnError, "retry-on-error", offerOptions.Retry.OnError,
+		`Retry deals whose result is an error, is preempted or loses mediation (JOB_RETRY_ON_ERROR).`,
+	)
+	cmd.PersistentFlags().BoolVar(
+		&offerOptions.Retry.ExcludeFailedProviders, "retry-exclude-failed", offerOptions.Retry.ExcludeFailedProviders,
+		`Do not retry on a resource provider that already failed the job (JOB_RETRY_EXCLUDE_FAILED).`,
+	)
+
+	cmd.PersistentFlags().IntVar(
+		&offerOptions.Serve.Port, "service-port", offerOptions.Serve.Port,
+		`Run the module as a service listening on this port and send it requests with lilypad service-request, leave at 0 for a batch job (JOB_SERVICE_PORT).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&offerOptions.Serve.Duration, "service-duration", offerOptions.Serve.Duration,
+		`The number of seconds to keep the service running for (JOB_SERVICE_DURATION).`,
+	)
+
+	cmd.PersistentFlags().StringToStringVar(
+		&offerOptions.Env, "env", offerOptions.Env,
+		`Environment variables to run the job container with e.g. SEED=42 (JOB_ENV).`,
+	)
+
+	cmd.PersistentFlags().StringArrayVar(
+		&offerOptions.TrustedProviders, "trusted-providers", offerOptions.TrustedProviders,
+		`Only these resource provider addresses can run the job (JOB_TRUSTED_PROVIDERS).`,
+	)
+	cmd.PersistentFlags().StringArrayVar(
+		&offerOptions.ExcludedProviders, "excluded-providers", offerOptions.ExcludedProviders,
+		`These resource provider addresses will never run the job (JOB_EXCLUDED_PROVIDERS).`,
+	)
+	cmd.PersistentFlags().StringToStringVar(
+		&offerOptions.RequiredLabels, "required-labels", offerOptions.RequiredLabels,
+		`Resource offer labels that must match e.g. region=eu-west (JOB_REQUIRED_LABELS).`,
+	)
+	cmd.PersistentFlags().StringToStringVar(
+		&offerOptions.PreferredLabels, "preferred-labels", offerOptions.PreferredLabels,
+		`Resource offer labels to prefer over a cheaper price e.g. tier=datacenter (JOB_PREFERRED_LABELS).`,
+	)
+	cmd.PersistentFlags().StringArrayVar(
+		&offerOptions.TEETypes, "tee", offerOptions.TEETypes,
+		`Only run the job inside an attested TEE of this type, one of sgx, sev-snp, tdx or any (JOB_TEE).`,
+	)
+	cmd.PersistentFlags().StringArrayVar(
+		&offerOptions.TEEMeasurements, "tee-measurement", offerOptions.TEEMeasurements,
+		`Only run the job inside a TEE with this hex measurement, can be given more than once (JOB_TEE_MEASUREMENTS).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&offerOptions.PaymentToken, "payment-token", offerOptions.PaymentToken,
+		`The address of an ERC-20 token to pay in instead of the network token, the prices are in whole units of it (JOB_PAYMENT_TOKEN).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&offerOptions.Milestones, "milestones", offerOptions.Milestones,
+		`Pay the resource provider in this many equal shares of the payment collateral as you accept checkpoints of the job, the last share is paid with the result (JOB_MILESTONES).`,
+	)
+	cmd.PersistentFlags().StringArrayVar(
+		&offerOptions.OfferClasses, "offer-classes", offerOptions.OfferClasses,
+		`The resource offer classes the job can run on, reserved or spot, leave empty for reserved only (JOB_OFFER_CLASSES).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&offerOptions.MinPreemptionNotice, "min-preemption-notice", offerOptions.MinPreemptionNotice,
+		`The least notice in seconds a spot resource provider must give before stopping the job (JOB_MIN_PREEMPTION_NOTICE).`,
+	)
+	cmd.PersistentFlags().BoolVar(
+		&offerOptions.RequirePinnedVersion, "require-pinned-version", offerOptions.RequirePinnedVersion,
+		`Refuse to run a module unless it is pinned to a commit hash or release tag (JOB_REQUIRE_PINNED_VERSION).`,
+	)
 
 	AddPricingModeCliFlags(cmd, &offerOptions.Mode)
 	AddPricingCliFlags(cmd, &offerOptions.Pricing)
@@ -59,11 +244,42 @@ func AddJobCreatorOfferCliFlags(cmd *cobra.Command, offerOptions *jobcreator.Job
 	AddTargetCliFlags(cmd, &offerOptions.Target)
 }
 
+// only lilypad run takes a job file and stays around to watch the job
+func AddJobCreatorRunCliFlags(cmd *cobra.Command, options *jobcreator.JobCreatorOptions) {
+	cmd.PersistentFlags().StringVarP(
+		&options.SpecFile, "file", "f", options.SpecFile,
+		`A YAML or JSON file with the module, inputs, env, resources, pricing and placement of the job (JOB_SPEC_FILE).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&options.Offer.Array, "array", options.Offer.Array,
+		`Run one job for each value of an input e.g. Seed=1..100 or Size=small,medium,large (JOB_ARRAY).`,
+	)
+	cmd.PersistentFlags().BoolVar(
+		&options.Watch, "watch", options.Watch,
+		`Print each state of the deal and stream the job's logs until the results are downloaded (JOB_WATCH).`,
+	)
+}
+
+func AddJobCreatorScheduleCliFlags(cmd *cobra.Command, scheduleOptions *jobcreator.JobCreatorScheduleOptions) {
+	cmd.PersistentFlags().StringVar(
+		&scheduleOptions.Cron, "cron", scheduleOptions.Cron,
+		`A cron expression in UTC for when the job runs e.g. "0 * * * *" or @daily (JOB_SCHEDULE_CRON).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&scheduleOptions.OverlapPolicy, "overlap-policy", scheduleOptions.OverlapPolicy,
+		`What to do when the last job is still running at the next tick - allow, skip or replace (JOB_SCHEDULE_OVERLAP_POLICY).`,
+	)
+}
+
 func AddJobCreatorCliFlags(cmd *cobra.Command, options *jobcreator.JobCreatorOptions) {
 	AddJobCreatorMediationCliFlags(cmd, &options.Mediation)
 	AddWeb3CliFlags(cmd, &options.Web3)
 	AddJobCreatorOfferCliFlags(cmd, &options.Offer)
+	AddBusCliFlags(cmd, &options.Bus)
 	AddTelemetryCliFlags(cmd, &options.Telemetry)
+	AddFiatCliFlags(cmd, &options.Fiat)
+	AddWebhookCliFlags(cmd, &options.Webhooks)
+	AddJobCreatorBudgetCliFlags(cmd, &options.Budget)
 }
 
 func CheckJobCreatorOptions(options jobcreator.JobCreatorOptions) error {
@@ -79,10 +295,162 @@ func CheckJobCreatorOptions(options jobcreator.JobCreatorOptions) error {
 	if err != nil {
 		return err
 	}
+	err = CheckBusOptions(options.Bus)
+	if err != nil {
+		return err
+	}
 	err = CheckTelemetryOptions(options.Telemetry)
 	if err != nil {
 		return err
 	}
+	err = CheckFiatOptions(options.Fiat)
+	if err != nil {
+		return err
+	}
+	err = CheckWebhookOptions(options.Webhooks)
+	if err != nil {
+		return err
+	}
+	err = CheckJobCreatorBudgetOptions(options.Budget)
+	if err != nil {
+		return err
+	}
+
+	if options.Offer.InputSize < 0 {
+		return fmt.Errorf("JOB_INPUT_SIZE cannot be negative")
+	}
+
+	if options.Offer.MaxRuntime < 0 {
+		return fmt.Errorf("JOB_MAX_RUNTIME cannot be negative")
+	}
+
+	if options.Offer.ResumeFrom != "" {
+		_, err = cid.Decode(options.Offer.ResumeFrom)
+		if err != nil {
+			return fmt.Errorf("JOB_RESUME_FROM is not a CID: %s", err.Error())
+		}
+	}
+
+	err = data.CheckIdempotencyKey(options.Offer.IdempotencyKey)
+	if err != nil {
+		return fmt.Errorf("JOB_IDEMPOTENCY_KEY: %s", err.Error())
+	}
+
+	if options.Offer.MediatorQuorum.Size < 0 || options.Offer.MediatorQuorum.Threshold < 0 {
+		return fmt.Errorf("JOB_MEDIATOR_QUORUM and JOB_MEDIATOR_QUORUM_THRESHOLD cannot be negative")
+	}
+	if quorum := jobcreator.GetMediatorQuorum(options.Offer); quorum != nil {
+		err = data.CheckMediatorQuorum(*quorum)
+		if err != nil {
+			return fmt.Errorf("JOB_MEDIATOR_QUORUM: %s", err.Error())
+		}
+	}
+
+	if options.Offer.Retry.MaxAttempts < 0 {
+		return fmt.Errorf("JOB_RETRY_MAX_ATTEMPTS cannot be negative")
+	}
+	if policy := jobcreator.GetRetryPolicy(options.Offer); policy != nil {
+		err = data.CheckRetryPolicy(data.JobOffer{Retry: policy, Target: options.Offer.Target})
+		if err != nil {
+			return fmt.Errorf("JOB_RETRY_MAX_ATTEMPTS: %s", err.Error())
+		}
+	}
+
+	err = data.CheckInputCIDs(options.Offer.InputCIDs)
+	if err != nil {
+		return fmt.Errorf("JOB_INPUT_CIDS: %s", err.Error())
+	}
+
+	for _, teeType := range options.Offer.TEETypes {
+		if teeType != "any" {
+			err = data.CheckTEEType(teeType)
+			if err != nil {
+				return fmt.Errorf("JOB_TEE: %s", err.Error())
+			}
+		}
+	}
+	if requirement := jobcreator.GetTEERequirement(options.Offer); requirement != nil {
+		err = data.CheckTEERequirement(*requirement)
+		if err != nil {
+			return fmt.Errorf("JOB_TEE_MEASUREMENTS: %s", err.Error())
+		}
+	}
+
+	if options.Offer.PaymentToken != "" && !common.IsHexAddress(options.Offer.PaymentToken) {
+		return fmt.Errorf("JOB_PAYMENT_TOKEN: %s is not an address", options.Offer.PaymentToken)
+	}
+
+	if options.Offer.Milestones < 0 || options.Offer.Milestones > data.MAX_DEAL_MILESTONES {
+		return fmt.Errorf("JOB_MILESTONES must be between 0 and %d", data.MAX_DEAL_MILESTONES)
+	}
+
+	for _, class := range options.Offer.OfferClasses {
+		err = data.CheckOfferClass(class)
+		if err != nil {
+			return fmt.Errorf("JOB_OFFER_CLASSES: %s", err.Error())
+		}
+	}
+	if options.Offer.MinPreemptionNotice < 0 {
+		return fmt.Errorf("JOB_MIN_PREEMPTION_NOTICE cannot be negative")
+	}
+
+	for name := range options.Offer.EncryptedInputs {
+		if _, ok := options.Offer.Inputs[name]; ok {
+			return fmt.Errorf("input %s cannot be both encrypted and in the clear", name)
+		}
+	}
+
+	if options.Offer.Array != "" {
+		_, _, err = jobcreator.ParseJobArray(options.Offer.Array)
+		if err != nil {
+			return fmt.Errorf("JOB_ARRAY: %s", err.Error())
+		}
+		// the solver adds the offers of an array job so we cannot send their inputs
+		if len(options.Offer.EncryptedInputs) > 0 {
+			return fmt.Errorf("JOB_ARRAY cannot be used with encrypted inputs")
+		}
+	}
+
+	if options.Offer.RequirePinnedVersion {
+		err = data.CheckModuleVersionPinned(options.Offer.Module)
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.Offer.ReuseResults {
+		if len(options.Offer.EncryptedInputs) > 0 {
+			return fmt.Errorf("JOB_REUSE_RESULTS cannot be used with encrypted inputs")
+		}
+		err = data.CheckModuleVersionPinned(options.Offer.Module)
+		if err != nil {
+			return fmt.Errorf("JOB_REUSE_RESULTS: %s", err.Error())
+		}
+	}
+
+	if serve := jobcreator.GetServiceDealConfig(options.Offer); serve != nil {
+		err = data.CheckServiceDealConfig(data.JobOffer{
+			Serve:        serve,
+			Timeouts:     options.Offer.Timeouts,
+			ReuseResults: options.Offer.ReuseResults,
+			Metered:      options.Offer.Metered,
+		})
+		if err != nil {
+			return fmt.Errorf("JOB_SERVICE_PORT: %s", err.Error())
+		}
+	}
+
+	for _, address := range options.Offer.TrustedProviders {
+		if !common.IsHexAddress(address) {
+			return fmt.Errorf("JOB_TRUSTED_PROVIDERS %s is not a valid address", address)
+		}
+	}
+
+	for _, address := range options.Offer.ExcludedProviders {
+		if !common.IsHexAddress(address) {
+			return fmt.Errorf("JOB_EXCLUDED_PROVIDERS %s is not a valid address", address)
+		}
+	}
 
 	if options.Mediation.CheckResultsPercentage < 0 || options.Mediation.CheckResultsPercentage > 100 {
 		return fmt.Errorf("mediation-chance must be between 0 and 100")
@@ -97,6 +465,15 @@ func ProcessJobCreatorOptions(options jobcreator.JobCreatorOptions, args []strin
 		name = args[0]
 	}
 
+	// the module named on the command line wins over the one in the file
+	if options.SpecFile != "" {
+		spec, err := jobcreator.LoadJobSpecFile(options.SpecFile)
+		if err != nil {
+			return options, err
+		}
+		options.Offer = jobcreator.ApplyJobSpecFile(options.Offer, spec)
+	}
+
 	if name != "" {
 		options.Offer.Module.Name = name
 	}
@@ -134,6 +511,53 @@ func ProcessJobCreatorOptions(options jobcreator.JobCreatorOptions, args []strin
 	return options, CheckJobCreatorOptions(options)
 }
 
+func CheckJobCreatorScheduleOptions(options jobcreator.JobCreatorScheduleOptions) error {
+	if options.Cron == "" {
+		return fmt.Errorf("JOB_SCHEDULE_CRON is required")
+	}
+	_, err := data.ParseCron(options.Cron)
+	if err != nil {
+		return fmt.Errorf("JOB_SCHEDULE_CRON: %s", err.Error())
+	}
+	err = data.CheckScheduleOverlapPolicy(options.OverlapPolicy)
+	if err != nil {
+		return fmt.Errorf("JOB_SCHEDULE_OVERLAP_POLICY: %s", err.Error())
+	}
+	return nil
+}
+
+// each stage is the job creator offer options with its job spec applied
+// and its module resolved the same way lilypad run does
+func ProcessWorkflowFile(options jobcreator.JobCreatorOptions, workflow jobcreator.WorkflowFile) ([]jobcreator.WorkflowStageOptions, error) {
+	if workflow.FailurePolicy != "" {
+		err := data.CheckWorkflowFailurePolicy(workflow.FailurePolicy)
+		if err != nil {
+			return nil, err
+		}
+	}
+	stages := []jobcreator.WorkflowStageOptions{}
+	for _, stage := range workflow.Stages {
+		offer := jobcreator.ApplyJobSpecFile(options.Offer, stage.JobSpecFile)
+		moduleOptions, err := ProcessModuleOptions(offer.Module)
+		if err != nil {
+			return nil, fmt.Errorf("stage %s: %s", stage.Name, err.Error())
+		}
+		offer.Module = moduleOptions
+		targetOptions, err := ProcessTargetOptions(offer.Target)
+		if err != nil {
+			return nil, fmt.Errorf("stage %s: %s", stage.Name, err.Error())
+		}
+		offer.Target = targetOptions
+		stages = append(stages, jobcreator.WorkflowStageOptions{
+			Name:       stage.Name,
+			DependsOn:  stage.DependsOn,
+			InputsFrom: stage.InputsFrom,
+			Offer:      offer,
+		})
+	}
+	return stages, nil
+}
+
 func ProcessOnChainJobCreatorOptions(options jobcreator.JobCreatorOptions, args []string, network string) (jobcreator.JobCreatorOptions, error) {
 	newWeb3Options, err := ProcessWeb3Options(options.Web3, network)
 	if err != nil {
@@ -0,0 +1,36 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultLogRelayOptions() solver.LogRelayOptions {
+	return solver.LogRelayOptions{
+		BufferSize: GetDefaultServeOptionInt("LOG_RELAY_BUFFER_SIZE", 1024*1024), //nolint:gomnd
+		MaxDeals:   GetDefaultServeOptionInt("LOG_RELAY_MAX_DEALS", 256),         //nolint:gomnd
+	}
+}
+
+func AddLogRelayCliFlags(cmd *cobra.Command, relayOptions *solver.LogRelayOptions) {
+	cmd.PersistentFlags().IntVar(
+		&relayOptions.BufferSize, "log-relay-buffer-size", relayOptions.BufferSize,
+		`The number of bytes of job output to keep for each deal (LOG_RELAY_BUFFER_SIZE).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&relayOptions.MaxDeals, "log-relay-max-deals", relayOptions.MaxDeals,
+		`The number of deals to keep job output for (LOG_RELAY_MAX_DEALS).`,
+	)
+}
+
+func CheckLogRelayOptions(options solver.LogRelayOptions) error {
+	if options.BufferSize <= 0 {
+		return fmt.Errorf("LOG_RELAY_BUFFER_SIZE must be greater than zero")
+	}
+	if options.MaxDeals <= 0 {
+		return fmt.Errorf("LOG_RELAY_MAX_DEALS must be greater than zero")
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package options
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultMatchingOptions() solver.MatchingOptions {
+	return solver.MatchingOptions{
+		Interval:       GetDefaultServeOptionInt("MATCHING_INTERVAL", 10),
+		TriggerOnOffer: GetDefaultServeOptionBool("MATCHING_TRIGGER_ON_OFFER", true),
+		Debounce:       GetDefaultServeOptionInt("MATCHING_DEBOUNCE", 100), //nolint:gomnd
+		CounterOffers:  GetDefaultServeOptionBool("MATCHING_COUNTER_OFFERS", true),
+		MediatorPolicy: GetDefaultServeOptionString("MATCHING_MEDIATOR_POLICY", data.MEDIATOR_POLICY_RANDOM),
+	}
+}
+
+func AddMatchingCliFlags(cmd *cobra.Command, matchingOptions *solver.MatchingOptions) {
+	cmd.PersistentFlags().IntVar(
+		&matchingOptions.Interval, "matching-interval", matchingOptions.Interval,
+		`The number of seconds between matching passes (MATCHING_INTERVAL).`,
+	)
+	cmd.PersistentFlags().BoolVar(
+		&matchingOptions.TriggerOnOffer, "matching-trigger-on-offer", matchingOptions.TriggerOnOffer,
+		`Run a matching pass as soon as a new offer arrives instead of waiting for the interval (MATCHING_TRIGGER_ON_OFFER).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&matchingOptions.Debounce, "matching-debounce", matchingOptions.Debounce,
+		`The number of milliseconds to wait after an offer arrives so offers arriving together are matched in one pass, 0 matches straight away (MATCHING_DEBOUNCE).`,
+	)
+	cmd.PersistentFlags().BoolVar(
+		&matchingOptions.CounterOffers, "matching-counter-offers", matchingOptions.CounterOffers,
+		`Suggest counter-offers for offers that only failed to match on price (MATCHING_COUNTER_OFFERS).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&matchingOptions.MediatorPolicy, "matching-mediator-policy", matchingOptions.MediatorPolicy,
+		fmt.Sprintf(`How the mediator of each deal is chosen from the mutual mediators, one of %s (MATCHING_MEDIATOR_POLICY).`, strings.Join(data.MediatorPolicies, ", ")),
+	)
+}
+
+func CheckMatchingOptions(options solver.MatchingOptions) error {
+	if options.Interval <= 0 {
+		return fmt.Errorf("MATCHING_INTERVAL must be greater than zero")
+	}
+	if options.Debounce < 0 {
+		return fmt.Errorf("MATCHING_DEBOUNCE cannot be negative")
+	}
+	if !data.IsMediatorPolicy(options.MediatorPolicy) {
+		return fmt.Errorf("MATCHING_MEDIATOR_POLICY must be one of %s", strings.Join(data.MediatorPolicies, ", "))
+	}
+	return nil
+}
@@ -2,7 +2,10 @@ package options
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/lilypad-tech/lilypad/pkg/executor/container"
+	"github.com/lilypad-tech/lilypad/pkg/executor/wasm"
 	"github.com/lilypad-tech/lilypad/pkg/mediator"
 	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/spf13/cobra"
@@ -14,16 +17,70 @@ func NewMediatorOptions() mediator.MediatorOptions {
 		Web3:     GetDefaultWeb3Options(),
 		Services: GetDefaultServicesOptions(),
 		IPFS:     GetDefaultIPFSOptions(),
+		Replay:   GetDefaultMediatorReplayOptions(),
+		Alerts:   GetDefaultAlertOptions(),
 	}
 	options.Web3.Service = system.MediatorService
 	return options
 }
 
+func GetDefaultMediatorReplayOptions() mediator.MediatorReplayOptions {
+	return mediator.MediatorReplayOptions{
+		Executor:    GetDefaultServeOptionString("MEDIATOR_REPLAY_EXECUTOR", mediator.REPLAY_EXECUTOR_BACALHAU),
+		Sandbox:     GetDefaultSandboxOptions(),
+		IgnoreFiles: GetDefaultServeOptionStringArray("MEDIATOR_REPLAY_IGNORE_FILES", []string{}),
+		Wasmtime:    GetDefaultServeOptionString("WASMTIME", wasm.DEFAULT_WASMTIME),
+	}
+}
+
+func AddMediatorReplayCliFlags(cmd *cobra.Command, options *mediator.MediatorReplayOptions) {
+	cmd.PersistentFlags().StringVar(
+		&options.Executor, "replay-executor", options.Executor,
+		fmt.Sprintf(`What disputed jobs are run again on, one of %s (MEDIATOR_REPLAY_EXECUTOR).`, strings.Join(mediator.ReplayExecutorTypes, ", ")),
+	)
+	cmd.PersistentFlags().StringArrayVar(
+		&options.IgnoreFiles, "replay-ignore-files", options.IgnoreFiles,
+		`Paths in the results that are left out when comparing them e.g. stderr (MEDIATOR_REPLAY_IGNORE_FILES).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&options.Wasmtime, "wasmtime", options.Wasmtime,
+		`The wasmtime binary disputed wasm jobs are run again with (WASMTIME).`,
+	)
+	AddSandboxCliFlags(cmd, &options.Sandbox)
+}
+
+func CheckMediatorReplayOptions(options mediator.MediatorReplayOptions) error {
+	known := false
+	for _, executorType := range mediator.ReplayExecutorTypes {
+		if options.Executor == executorType {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return fmt.Errorf("MEDIATOR_REPLAY_EXECUTOR must be one of %s", strings.Join(mediator.ReplayExecutorTypes, ", "))
+	}
+	err := container.CheckSandboxOptions(options.Sandbox)
+	if err != nil {
+		return fmt.Errorf("SANDBOX: %s", err.Error())
+	}
+	sandboxed := options.Sandbox.Default != container.SANDBOX_NONE
+	for _, sandbox := range options.Sandbox.Modules {
+		sandboxed = sandboxed || sandbox != container.SANDBOX_NONE
+	}
+	if sandboxed && options.Executor == mediator.REPLAY_EXECUTOR_BACALHAU {
+		return fmt.Errorf("SANDBOX needs the docker or podman replay executor")
+	}
+	return nil
+}
+
 func AddMediatorCliFlags(cmd *cobra.Command, options *mediator.MediatorOptions) {
 	AddBacalhauCliFlags(cmd, &options.Bacalhau)
 	AddWeb3CliFlags(cmd, &options.Web3)
 	AddServicesCliFlags(cmd, &options.Services)
 	AddIPFSCliFlags(cmd, &options.IPFS)
+	AddMediatorReplayCliFlags(cmd, &options.Replay)
+	AddAlertCliFlags(cmd, &options.Alerts)
 }
 
 func CheckMediatorOptions(options mediator.MediatorOptions) error {
@@ -39,6 +96,14 @@ func CheckMediatorOptions(options mediator.MediatorOptions) error {
 	if err != nil {
 		return err
 	}
+	err = CheckMediatorReplayOptions(options.Replay)
+	if err != nil {
+		return err
+	}
+	err = CheckAlertOptions(options.Alerts)
+	if err != nil {
+		return err
+	}
 	// only check the solver because we are the mediator
 	if options.Services.Solver == "" {
 		return fmt.Errorf("No solver service specified - please use SERVICE_SOLVER or --service-solver")
@@ -0,0 +1,41 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultModuleResolverOptions() solver.ModuleResolverOptions {
+	return solver.ModuleResolverOptions{
+		Enabled:     GetDefaultServeOptionBool("MODULE_RESOLVER_ENABLED", false),
+		CacheSize:   GetDefaultServeOptionInt("MODULE_RESOLVER_CACHE_SIZE", 256),   //nolint:gomnd
+		FloatingTTL: GetDefaultServeOptionInt("MODULE_RESOLVER_FLOATING_TTL", 300), //nolint:gomnd
+	}
+}
+
+func AddModuleResolverCliFlags(cmd *cobra.Command, resolverOptions *solver.ModuleResolverOptions) {
+	cmd.PersistentFlags().BoolVar(
+		&resolverOptions.Enabled, "module-resolver-enabled", resolverOptions.Enabled,
+		`Fetch the module of each job offer and reject offers that ask for less than the module declares (MODULE_RESOLVER_ENABLED).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&resolverOptions.CacheSize, "module-resolver-cache-size", resolverOptions.CacheSize,
+		`The number of resolved modules to keep in memory (MODULE_RESOLVER_CACHE_SIZE).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&resolverOptions.FloatingTTL, "module-resolver-floating-ttl", resolverOptions.FloatingTTL,
+		`The number of seconds to cache modules on a floating version such as a branch (MODULE_RESOLVER_FLOATING_TTL).`,
+	)
+}
+
+func CheckModuleResolverOptions(options solver.ModuleResolverOptions) error {
+	if options.CacheSize <= 0 {
+		return fmt.Errorf("MODULE_RESOLVER_CACHE_SIZE must be greater than zero")
+	}
+	if options.FloatingTTL < 0 {
+		return fmt.Errorf("MODULE_RESOLVER_FLOATING_TTL cannot be negative")
+	}
+	return nil
+}
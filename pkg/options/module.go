@@ -16,6 +16,8 @@ func GetDefaultModuleOptions() data.ModuleConfig {
 		Hash: GetDefaultServeOptionString("MODULE_HASH", ""),
 		// the path to the go template file
 		Path: GetDefaultServeOptionString("MODULE_PATH", ""),
+		// the digest the module image must have
+		ImageDigest: GetDefaultServeOptionString("MODULE_IMAGE_DIGEST", ""),
 	}
 }
 
@@ -36,6 +38,10 @@ func AddModuleCliFlags(cmd *cobra.Command, moduleConfig *data.ModuleConfig) {
 		&moduleConfig.Path, "module-path", moduleConfig.Path,
 		`The path in the repo to the go template (MODULE_PATH)`,
 	)
+	cmd.PersistentFlags().StringVar(
+		&moduleConfig.ImageDigest, "module-image-digest", moduleConfig.ImageDigest,
+		`The sha256 digest the module image must have, resource providers will not run any other (MODULE_IMAGE_DIGEST)`,
+	)
 }
 
 // see if we have a shortcut and fill in the other values if we do
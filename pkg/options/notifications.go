@@ -0,0 +1,76 @@
+package options
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/lilypad-tech/lilypad/pkg/notifications"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultNotificationOptions() notifications.NotificationOptions {
+	return notifications.NotificationOptions{
+		SubscriptionsPath: GetDefaultServeOptionString("NOTIFICATIONS_SUBSCRIPTIONS_PATH", ""),
+		SMTPHost:          GetDefaultServeOptionString("NOTIFICATIONS_SMTP_HOST", ""),
+		SMTPPort:          GetDefaultServeOptionInt("NOTIFICATIONS_SMTP_PORT", 587), //nolint:gomnd
+		SMTPUsername:      GetDefaultServeOptionString("NOTIFICATIONS_SMTP_USERNAME", ""),
+		SMTPPassword:      GetDefaultServeOptionString("NOTIFICATIONS_SMTP_PASSWORD", ""),
+		SMTPFrom:          GetDefaultServeOptionString("NOTIFICATIONS_SMTP_FROM", ""),
+		TelegramToken:     GetDefaultServeOptionString("NOTIFICATIONS_TELEGRAM_TOKEN", ""),
+		TelegramURL:       GetDefaultServeOptionString("NOTIFICATIONS_TELEGRAM_URL", notifications.DEFAULT_TELEGRAM_URL),
+	}
+}
+
+func AddNotificationCliFlags(cmd *cobra.Command, notificationOptions *notifications.NotificationOptions) {
+	cmd.PersistentFlags().StringVar(
+		&notificationOptions.SubscriptionsPath, "notifications-subscriptions-path", notificationOptions.SubscriptionsPath,
+		`The path to a JSON file of who to notify about the deals of each address, empty turns notifications off (NOTIFICATIONS_SUBSCRIPTIONS_PATH).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&notificationOptions.SMTPHost, "notifications-smtp-host", notificationOptions.SMTPHost,
+		`The SMTP server notification emails are sent through (NOTIFICATIONS_SMTP_HOST).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&notificationOptions.SMTPPort, "notifications-smtp-port", notificationOptions.SMTPPort,
+		`The port of the SMTP server (NOTIFICATIONS_SMTP_PORT).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&notificationOptions.SMTPUsername, "notifications-smtp-username", notificationOptions.SMTPUsername,
+		`The username for the SMTP server, empty does not log in (NOTIFICATIONS_SMTP_USERNAME).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&notificationOptions.SMTPPassword, "notifications-smtp-password", notificationOptions.SMTPPassword,
+		`The password for the SMTP server (NOTIFICATIONS_SMTP_PASSWORD).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&notificationOptions.SMTPFrom, "notifications-smtp-from", notificationOptions.SMTPFrom,
+		`The address notification emails are from (NOTIFICATIONS_SMTP_FROM).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&notificationOptions.TelegramToken, "notifications-telegram-token", notificationOptions.TelegramToken,
+		`The token of the Telegram bot notifications are sent from (NOTIFICATIONS_TELEGRAM_TOKEN).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&notificationOptions.TelegramURL, "notifications-telegram-url", notificationOptions.TelegramURL,
+		`The Telegram Bot API (NOTIFICATIONS_TELEGRAM_URL).`,
+	)
+}
+
+func CheckNotificationOptions(options notifications.NotificationOptions) error {
+	if !options.Enabled() {
+		return nil
+	}
+	if _, err := os.Stat(options.SubscriptionsPath); err != nil {
+		return fmt.Errorf("NOTIFICATIONS_SUBSCRIPTIONS_PATH could not be read: %s", err.Error())
+	}
+	if options.SMTPHost != "" && options.SMTPFrom == "" {
+		return fmt.Errorf("NOTIFICATIONS_SMTP_FROM is needed to send emails")
+	}
+	if options.TelegramToken != "" {
+		if _, err := url.ParseRequestURI(options.TelegramURL); err != nil {
+			return fmt.Errorf("NOTIFICATIONS_TELEGRAM_URL %q is not a url", options.TelegramURL)
+		}
+	}
+	return nil
+}
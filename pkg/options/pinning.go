@@ -0,0 +1,52 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultPinningOptions() solver.PinningOptions {
+	return solver.PinningOptions{
+		Services: GetDefaultServeOptionStringArray("PINNING_SERVICES", []string{}),
+		Tokens:   GetDefaultServeOptionStringArray("PINNING_TOKENS", []string{}),
+		Replicas: GetDefaultServeOptionInt("PINNING_REPLICAS", 2),   //nolint:gomnd
+		Interval: GetDefaultServeOptionInt("PINNING_INTERVAL", 300), //nolint:gomnd
+	}
+}
+
+func AddPinningCliFlags(cmd *cobra.Command, pinningOptions *solver.PinningOptions) {
+	cmd.PersistentFlags().StringArrayVar(
+		&pinningOptions.Services, "pinning-services", pinningOptions.Services,
+		`The IPFS pinning services to keep results on as name=url (PINNING_SERVICES).`,
+	)
+	cmd.PersistentFlags().StringArrayVar(
+		&pinningOptions.Tokens, "pinning-tokens", pinningOptions.Tokens,
+		`The access token for each pinning service as name=token (PINNING_TOKENS).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&pinningOptions.Replicas, "pinning-replicas", pinningOptions.Replicas,
+		`The number of pinning services that should keep each result (PINNING_REPLICAS).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&pinningOptions.Interval, "pinning-interval", pinningOptions.Interval,
+		`The number of seconds between checking the result pins are still there (PINNING_INTERVAL).`,
+	)
+}
+
+func CheckPinningOptions(options solver.PinningOptions) error {
+	if len(options.Services) == 0 {
+		return nil
+	}
+	if options.Replicas <= 0 {
+		return fmt.Errorf("PINNING_REPLICAS must be greater than zero")
+	}
+	if options.Replicas > len(options.Services) {
+		return fmt.Errorf("PINNING_REPLICAS cannot be more than the number of PINNING_SERVICES")
+	}
+	if options.Interval < 0 {
+		return fmt.Errorf("PINNING_INTERVAL cannot be negative")
+	}
+	return nil
+}
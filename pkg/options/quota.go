@@ -0,0 +1,36 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultQuotaOptions() solver.QuotaOptions {
+	return solver.QuotaOptions{
+		MaxInputSize:        GetDefaultServeOptionInt("QUOTA_MAX_INPUT_SIZE", 0),
+		MaxPendingInputSize: GetDefaultServeOptionInt("QUOTA_MAX_PENDING_INPUT_SIZE", 0),
+	}
+}
+
+func AddQuotaCliFlags(cmd *cobra.Command, quotaOptions *solver.QuotaOptions) {
+	cmd.PersistentFlags().IntVar(
+		&quotaOptions.MaxInputSize, "quota-max-input-size", quotaOptions.MaxInputSize,
+		`The largest input in megabytes a job offer can declare, 0 for no limit (QUOTA_MAX_INPUT_SIZE).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&quotaOptions.MaxPendingInputSize, "quota-max-pending-input-size", quotaOptions.MaxPendingInputSize,
+		`The most input in megabytes a job creator can have in unmatched job offers, 0 for no limit (QUOTA_MAX_PENDING_INPUT_SIZE).`,
+	)
+}
+
+func CheckQuotaOptions(options solver.QuotaOptions) error {
+	if options.MaxInputSize < 0 {
+		return fmt.Errorf("QUOTA_MAX_INPUT_SIZE cannot be negative")
+	}
+	if options.MaxPendingInputSize < 0 {
+		return fmt.Errorf("QUOTA_MAX_PENDING_INPUT_SIZE cannot be negative")
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package options
+
+import (
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultReadOnlyOptions() solver.ReadOnlyOptions {
+	return solver.ReadOnlyOptions{
+		Enabled: GetDefaultServeOptionBool("READ_ONLY", false),
+	}
+}
+
+func AddReadOnlyCliFlags(cmd *cobra.Command, readOnlyOptions *solver.ReadOnlyOptions) {
+	cmd.PersistentFlags().BoolVar(
+		&readOnlyOptions.Enabled, "read-only", readOnlyOptions.Enabled,
+		`Serve reads from a store another solver writes to and refuse changes, without matching or following the chain (READ_ONLY).`,
+	)
+}
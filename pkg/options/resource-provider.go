@@ -3,7 +3,10 @@ package options
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/lilypad-tech/lilypad/pkg/data"
 	"github.com/lilypad-tech/lilypad/pkg/resourceprovider"
 	"github.com/lilypad-tech/lilypad/pkg/system"
@@ -12,12 +15,21 @@ import (
 
 func NewResourceProviderOptions() resourceprovider.ResourceProviderOptions {
 	options := resourceprovider.ResourceProviderOptions{
+		Executor:  GetDefaultExecutorOptions(),
 		Bacalhau:  GetDefaultBacalhauOptions(),
 		Offers:    GetDefaultResourceProviderOfferOptions(),
 		Web3:      GetDefaultWeb3Options(),
 		Pow:       GetDefaultResourceProviderPowOptions(),
+		Health:    GetDefaultResourceProviderHealthOptions(),
+		Throttle:  GetDefaultResourceProviderThrottleOptions(),
+		Queue:     GetDefaultResourceProviderQueueOptions(),
+		Logs:      GetDefaultResourceProviderLogOptions(),
+		Image:     GetDefaultResourceProviderImageOptions(),
 		IPFS:      GetDefaultIPFSOptions(),
+		Storage:   GetDefaultStorageOptions(),
+		Bus:       GetDefaultBusOptions(),
 		Telemetry: GetDefaultTelemetryOptions(),
+		Alerts:    GetDefaultAlertOptions(),
 	}
 	options.Web3.Service = system.ResourceProviderService
 	return options
@@ -34,6 +46,46 @@ func GetDefaultResourceProviderPowOptions() resourceprovider.ResourceProviderPow
 	}
 }
 
+func GetDefaultResourceProviderHealthOptions() resourceprovider.ResourceProviderHealthOptions {
+	return resourceprovider.ResourceProviderHealthOptions{
+		DiskPath:          GetDefaultServeOptionString("HEALTH_DISK_PATH", "/"),
+		MinFreeDisk:       GetDefaultServeOptionInt("HEALTH_MIN_FREE_DISK", 0),
+		MinFreeRAM:        GetDefaultServeOptionInt("HEALTH_MIN_FREE_RAM", 0),
+		MaxGPUTemperature: GetDefaultServeOptionInt("HEALTH_MAX_GPU_TEMPERATURE", 0),
+	}
+}
+
+func GetDefaultResourceProviderThrottleOptions() resourceprovider.ResourceProviderThrottleOptions {
+	return resourceprovider.ResourceProviderThrottleOptions{
+		MaxLoad:      GetDefaultServeOptionInt("THROTTLE_MAX_LOAD", 0),
+		MaxGPUMemory: GetDefaultServeOptionInt("THROTTLE_MAX_GPU_MEMORY", 0),
+		MaxDisk:      GetDefaultServeOptionInt("THROTTLE_MAX_DISK", 0),
+	}
+}
+
+func GetDefaultResourceProviderQueueOptions() resourceprovider.ResourceProviderQueueOptions {
+	return resourceprovider.ResourceProviderQueueOptions{
+		MaxConcurrentDeals: GetDefaultServeOptionInt("QUEUE_MAX_CONCURRENT_DEALS", 0),
+		MaxQueuedDeals:     GetDefaultServeOptionInt("QUEUE_MAX_QUEUED_DEALS", 0),
+		ModuleLimits:       GetDefaultServeOptionStringArray("QUEUE_MODULE_LIMITS", []string{}),
+		ModuleConcurrency:  map[string]int{},
+	}
+}
+
+func GetDefaultResourceProviderLogOptions() resourceprovider.ResourceProviderLogOptions {
+	return resourceprovider.ResourceProviderLogOptions{
+		Enabled:       GetDefaultServeOptionBool("LOG_STREAMING_ENABLED", true),
+		FlushInterval: GetDefaultServeOptionInt("LOG_STREAMING_FLUSH_INTERVAL", 1000), //nolint:gomnd
+	}
+}
+
+func GetDefaultResourceProviderImageOptions() resourceprovider.ResourceProviderImageOptions {
+	return resourceprovider.ResourceProviderImageOptions{
+		RequireDigest: GetDefaultServeOptionBool("IMAGE_REQUIRE_DIGEST", false),
+		CosignKey:     GetDefaultServeOptionString("IMAGE_COSIGN_KEY", ""),
+	}
+}
+
 func GetDefaultResourceProviderOfferOptions() resourceprovider.ResourceProviderOfferOptions {
 	return resourceprovider.ResourceProviderOfferOptions{
 		// by default let's offer 1 CPU, 0 GPU and 1GB RAM
@@ -42,7 +94,12 @@ func GetDefaultResourceProviderOfferOptions() resourceprovider.ResourceProviderO
 			GPU: GetDefaultServeOptionInt("OFFER_GPU", 0),    //nolint:gomnd
 			RAM: GetDefaultServeOptionInt("OFFER_RAM", 1024), //nolint:gomnd
 		},
-		OfferCount: GetDefaultServeOptionInt("OFFER_COUNT", 1), //nolint:gomnd
+		// by default we offer what the executor reports
+		Autodetect:    GetDefaultServeOptionBool("OFFER_AUTODETECT", false),
+		SpecOverrides: GetDefaultServeOptionStringMap("OFFER_SPEC_OVERRIDES", map[string]string{}),
+		OfferCount:    GetDefaultServeOptionInt("OFFER_COUNT", 1), //nolint:gomnd
+		// by default we will download inputs of any size
+		MaxInputSize: GetDefaultServeOptionInt("OFFER_MAX_INPUT_SIZE", 0),
 		// this can be populated by a config file
 		Specs: []data.MachineSpec{},
 		// if an RP wants to only run certain modules they list them here
@@ -56,7 +113,28 @@ func GetDefaultResourceProviderOfferOptions() resourceprovider.ResourceProviderO
 		// allows an RP to list specific prices for each module
 		ModulePricing:  map[string]data.DealPricing{},
 		ModuleTimeouts: map[string]data.DealTimeouts{},
+		ModulePrices:   GetDefaultServeOptionStringArray("OFFER_MODULE_PRICES", []string{}),
 		Services:       GetDefaultServicesOptions(),
+		// keep this capacity for certain job creators
+		AllowedJobCreators: GetDefaultServeOptionStringArray("OFFER_ALLOWED_JOB_CREATORS", []string{}),
+		// where and what the hardware is e.g. region=eu-west,tier=datacenter
+		Labels: GetDefaultServeOptionStringMap("OFFER_LABELS", map[string]string{}),
+		// lets job creators send us inputs only we can read
+		EncryptionKey: GetDefaultServeOptionString("OFFER_ENCRYPTION_KEY", ""),
+		// sgx, sev-snp or tdx if we run inside a TEE
+		TEE: GetDefaultServeOptionString("OFFER_TEE", ""),
+		// the VCEK and ASK of our sev-snp chip from the AMD KDS
+		TEECertificatesPath: GetDefaultServeOptionString("OFFER_TEE_CERTIFICATES", ""),
+		// what jobs can connect to
+		Network:        GetDefaultServeOptionString("OFFER_NETWORK", data.NetworkPolicyFull),
+		NetworkAllow:   GetDefaultServeOptionStringArray("OFFER_NETWORK_ALLOW", []string{}),
+		NetworkModules: GetDefaultServeOptionStringMap("OFFER_NETWORK_MODULES", map[string]string{}),
+		// tokens other than the network token we can be paid in
+		PaymentTokens: GetDefaultServeOptionStringArray("OFFER_PAYMENT_TOKENS", []string{}),
+		// reserved or spot capacity
+		Class:            GetDefaultServeOptionString("OFFER_CLASS", data.OfferClassReserved),
+		PreemptionNotice: GetDefaultServeOptionInt("OFFER_PREEMPTION_NOTICE", 60), //nolint:gomnd
+		ReservedDuration: GetDefaultServeOptionInt("OFFER_RESERVED_DURATION", 0),
 	}
 }
 
@@ -73,14 +151,78 @@ func AddResourceProviderOfferCliFlags(cmd *cobra.Command, offerOptions *resource
 		&offerOptions.OfferSpec.RAM, "offer-ram", offerOptions.OfferSpec.RAM,
 		`How many megabytes of RAM to offer the network (OFFER_RAM).`,
 	)
+	cmd.PersistentFlags().BoolVar(
+		&offerOptions.Autodetect, "offer-autodetect", offerOptions.Autodetect,
+		`Detect the CPUs, RAM, GPUs and disk of this machine and offer those (OFFER_AUTODETECT).`,
+	)
+	cmd.PersistentFlags().StringToStringVar(
+		&offerOptions.SpecOverrides, "offer-spec-overrides", offerOptions.SpecOverrides,
+		`Values that replace detected ones as cpu, gpu, ram and disk in milli-units and megabytes e.g. gpu=0,ram=16384 (OFFER_SPEC_OVERRIDES).`,
+	)
 	cmd.PersistentFlags().IntVar(
 		&offerOptions.OfferCount, "offer-count", offerOptions.OfferCount,
 		`How many machines will we offer using the cpu, ram and gpu settings (OFFER_COUNT).`,
 	)
+	cmd.PersistentFlags().IntVar(
+		&offerOptions.MaxInputSize, "offer-max-input-size", offerOptions.MaxInputSize,
+		`The largest job input in megabytes we will accept, 0 for no limit (OFFER_MAX_INPUT_SIZE).`,
+	)
 	cmd.PersistentFlags().StringArrayVar(
 		&offerOptions.Modules, "offer-modules", offerOptions.Modules,
 		`The modules you are willing to run (OFFER_MODULES).`,
 	)
+	cmd.PersistentFlags().StringArrayVar(
+		&offerOptions.ModulePrices, "offer-module-prices", offerOptions.ModulePrices,
+		`Instruction prices for specific modules as module_id=price, other modules use the default pricing (OFFER_MODULE_PRICES).`,
+	)
+	cmd.PersistentFlags().StringArrayVar(
+		&offerOptions.PaymentTokens, "offer-payment-tokens", offerOptions.PaymentTokens,
+		`ERC-20 token addresses we take payment in as well as the network token, our prices are in whole units of the token (OFFER_PAYMENT_TOKENS).`,
+	)
+	cmd.PersistentFlags().StringArrayVar(
+		&offerOptions.AllowedJobCreators, "offer-allowed-job-creators", offerOptions.AllowedJobCreators,
+		`Only take jobs from these job creator addresses, leave empty to take jobs from anyone (OFFER_ALLOWED_JOB_CREATORS).`,
+	)
+	cmd.PersistentFlags().StringToStringVar(
+		&offerOptions.Labels, "offer-labels", offerOptions.Labels,
+		`Labels job offers can select on e.g. region=eu-west,tier=datacenter (OFFER_LABELS).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&offerOptions.EncryptionKey, "offer-encryption-key", offerOptions.EncryptionKey,
+		`A hex private key that job creators encrypt private inputs for, use a different key to your wallet (OFFER_ENCRYPTION_KEY).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&offerOptions.TEE, "offer-tee", offerOptions.TEE,
+		`The TEE we run inside and attest to in our offers, one of sgx, sev-snp or tdx (OFFER_TEE).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&offerOptions.TEECertificatesPath, "offer-tee-certificates", offerOptions.TEECertificatesPath,
+		`A PEM file of the VCEK and then the ASK our sev-snp reports are signed with, from the AMD KDS (OFFER_TEE_CERTIFICATES).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&offerOptions.Network, "offer-network", offerOptions.Network,
+		fmt.Sprintf(`The network access we give jobs, one of %s (OFFER_NETWORK).`, strings.Join(data.NetworkPolicyModes, ", ")),
+	)
+	cmd.PersistentFlags().StringArrayVar(
+		&offerOptions.NetworkAllow, "offer-network-allow", offerOptions.NetworkAllow,
+		`A domain, IP or CIDR jobs can reach under the egress-allowlist network policy (OFFER_NETWORK_ALLOW).`,
+	)
+	cmd.PersistentFlags().StringToStringVar(
+		&offerOptions.NetworkModules, "offer-network-modules", offerOptions.NetworkModules,
+		`The network access for particular modules as module_id=policy, overriding --offer-network (OFFER_NETWORK_MODULES).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&offerOptions.Class, "offer-class", offerOptions.Class,
+		`Offer reserved capacity that runs every job to the end or cheaper spot capacity we can preempt (OFFER_CLASS).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&offerOptions.PreemptionNotice, "offer-preemption-notice", offerOptions.PreemptionNotice,
+		`The seconds a spot job keeps running after we preempt it (OFFER_PREEMPTION_NOTICE).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&offerOptions.ReservedDuration, "offer-reserved-duration", offerOptions.ReservedDuration,
+		`The longest job in seconds a reserved offer takes, 0 for no limit (OFFER_RESERVED_DURATION).`,
+	)
 	AddPricingModeCliFlags(cmd, &offerOptions.Mode)
 	AddPricingCliFlags(cmd, &offerOptions.DefaultPricing)
 	AddTimeoutCliFlags(cmd, &offerOptions.DefaultTimeouts)
@@ -113,13 +255,93 @@ func AddResourceProviderPowCliFlags(cmd *cobra.Command, options *resourceprovide
 	cmd.ParseFlags(os.Args)
 }
 
+func AddResourceProviderHealthCliFlags(cmd *cobra.Command, options *resourceprovider.ResourceProviderHealthOptions) {
+	cmd.PersistentFlags().StringVar(
+		&options.DiskPath, "health-disk-path", options.DiskPath,
+		`The path whose filesystem is checked for free disk space (HEALTH_DISK_PATH).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&options.MinFreeDisk, "health-min-free-disk", options.MinFreeDisk,
+		`Stop taking new deals when free disk space in megabytes drops below this, 0 to disable (HEALTH_MIN_FREE_DISK).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&options.MinFreeRAM, "health-min-free-ram", options.MinFreeRAM,
+		`Stop taking new deals when available memory in megabytes drops below this, 0 to disable (HEALTH_MIN_FREE_RAM).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&options.MaxGPUTemperature, "health-max-gpu-temperature", options.MaxGPUTemperature,
+		`Stop taking new deals when any GPU is hotter than this in celsius, 0 to disable (HEALTH_MAX_GPU_TEMPERATURE).`,
+	)
+}
+
+func AddResourceProviderThrottleCliFlags(cmd *cobra.Command, options *resourceprovider.ResourceProviderThrottleOptions) {
+	cmd.PersistentFlags().IntVar(
+		&options.MaxLoad, "throttle-max-load", options.MaxLoad,
+		`Offer less of the machine as the load average approaches this percentage of the cores, 0 to disable (THROTTLE_MAX_LOAD).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&options.MaxGPUMemory, "throttle-max-gpu-memory", options.MaxGPUMemory,
+		`Offer less of the machine as the GPU memory in use approaches this percentage, 0 to disable (THROTTLE_MAX_GPU_MEMORY).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&options.MaxDisk, "throttle-max-disk", options.MaxDisk,
+		`Offer less of the machine as the disk used at the health disk path approaches this percentage, 0 to disable (THROTTLE_MAX_DISK).`,
+	)
+}
+
+func AddResourceProviderQueueCliFlags(cmd *cobra.Command, options *resourceprovider.ResourceProviderQueueOptions) {
+	cmd.PersistentFlags().IntVar(
+		&options.MaxConcurrentDeals, "queue-max-concurrent-deals", options.MaxConcurrentDeals,
+		`The most deals to run at once, 0 for no limit (QUEUE_MAX_CONCURRENT_DEALS).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&options.MaxQueuedDeals, "queue-max-queued-deals", options.MaxQueuedDeals,
+		`How many agreed deals can wait for a free slot before we stop taking more (QUEUE_MAX_QUEUED_DEALS).`,
+	)
+	cmd.PersistentFlags().StringArrayVar(
+		&options.ModuleLimits, "queue-module-limits", options.ModuleLimits,
+		`The most deals of a module to run at once as module_id=limit (QUEUE_MODULE_LIMITS).`,
+	)
+}
+
+func AddResourceProviderLogCliFlags(cmd *cobra.Command, options *resourceprovider.ResourceProviderLogOptions) {
+	cmd.PersistentFlags().BoolVar(
+		&options.Enabled, "log-streaming-enabled", options.Enabled,
+		`Send the output of running jobs to the solver so job creators can follow it (LOG_STREAMING_ENABLED).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&options.FlushInterval, "log-streaming-flush-interval", options.FlushInterval,
+		`The number of milliseconds between sending batches of job output to the solver (LOG_STREAMING_FLUSH_INTERVAL).`,
+	)
+}
+
+func AddResourceProviderImageCliFlags(cmd *cobra.Command, options *resourceprovider.ResourceProviderImageOptions) {
+	cmd.PersistentFlags().BoolVar(
+		&options.RequireDigest, "image-require-digest", options.RequireDigest,
+		`Refuse to run module images that are not pinned by digest (IMAGE_REQUIRE_DIGEST).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&options.CosignKey, "image-cosign-key", options.CosignKey,
+		`The cosign public key module images must be signed with, this needs the cosign binary (IMAGE_COSIGN_KEY).`,
+	)
+}
+
 func AddResourceProviderCliFlags(cmd *cobra.Command, options *resourceprovider.ResourceProviderOptions) {
+	AddExecutorCliFlags(cmd, &options.Executor)
 	AddBacalhauCliFlags(cmd, &options.Bacalhau)
 	AddWeb3CliFlags(cmd, &options.Web3)
 	AddResourceProviderOfferCliFlags(cmd, &options.Offers)
 	AddResourceProviderPowCliFlags(cmd, &options.Pow)
+	AddResourceProviderHealthCliFlags(cmd, &options.Health)
+	AddResourceProviderThrottleCliFlags(cmd, &options.Throttle)
+	AddResourceProviderQueueCliFlags(cmd, &options.Queue)
+	AddResourceProviderLogCliFlags(cmd, &options.Logs)
+	AddResourceProviderImageCliFlags(cmd, &options.Image)
 	AddIPFSCliFlags(cmd, &options.IPFS)
+	AddStorageCliFlags(cmd, &options.Storage)
+	AddBusCliFlags(cmd, &options.Bus)
 	AddTelemetryCliFlags(cmd, &options.Telemetry)
+	AddAlertCliFlags(cmd, &options.Alerts)
 }
 
 func AddPowSignalCliFlags(cmd *cobra.Command, options *PowSignalOptions) {
@@ -147,6 +369,151 @@ func CheckResourceProviderOfferOptions(options resourceprovider.ResourceProvider
 		return fmt.Errorf("OFFER_RAM cannot be zero")
 	}
 
+	err := resourceprovider.CheckMachineSpecOverrides(options.SpecOverrides)
+	if err != nil {
+		return fmt.Errorf("OFFER_SPEC_OVERRIDES: %s", err.Error())
+	}
+
+	if options.MaxInputSize < 0 {
+		return fmt.Errorf("OFFER_MAX_INPUT_SIZE cannot be negative")
+	}
+
+	for _, address := range options.AllowedJobCreators {
+		if !common.IsHexAddress(address) {
+			return fmt.Errorf("OFFER_ALLOWED_JOB_CREATORS %s is not a valid address", address)
+		}
+	}
+
+	for _, address := range options.PaymentTokens {
+		if !common.IsHexAddress(address) {
+			return fmt.Errorf("OFFER_PAYMENT_TOKENS %s is not a valid address", address)
+		}
+	}
+
+	if options.EncryptionKey != "" {
+		_, err = data.ParseInputEncryptionKey(options.EncryptionKey)
+		if err != nil {
+			return fmt.Errorf("OFFER_ENCRYPTION_KEY: %s", err.Error())
+		}
+	}
+
+	if options.TEE != "" {
+		err = data.CheckTEEType(options.TEE)
+		if err != nil {
+			return fmt.Errorf("OFFER_TEE: %s", err.Error())
+		}
+		if options.TEE == data.TEETypeSEVSNP && options.TEECertificatesPath == "" {
+			return fmt.Errorf("OFFER_TEE_CERTIFICATES is required for sev-snp")
+		}
+	}
+
+	err = data.CheckOfferClass(options.Class)
+	if err != nil {
+		return fmt.Errorf("OFFER_CLASS: %s", err.Error())
+	}
+	if options.PreemptionNotice < 0 {
+		return fmt.Errorf("OFFER_PREEMPTION_NOTICE cannot be negative")
+	}
+	if options.ReservedDuration < 0 {
+		return fmt.Errorf("OFFER_RESERVED_DURATION cannot be negative")
+	}
+
+	return nil
+}
+
+func CheckResourceProviderNetworkOptions(options resourceprovider.ResourceProviderOfferOptions, executorOptions resourceprovider.ResourceProviderExecutorOptions) error {
+	network, moduleNetwork := resourceprovider.GetOfferNetworkPolicies(options)
+	if network != nil {
+		err := data.CheckNetworkPolicy(*network)
+		if err != nil {
+			return fmt.Errorf("OFFER_NETWORK: %s", err.Error())
+		}
+		err = resourceprovider.CheckNetworkPolicySupported(*network, executorOptions)
+		if err != nil {
+			return fmt.Errorf("OFFER_NETWORK: %s", err.Error())
+		}
+	}
+	for moduleID, policy := range moduleNetwork {
+		err := data.CheckNetworkPolicy(policy)
+		if err != nil {
+			return fmt.Errorf("OFFER_NETWORK_MODULES %s: %s", moduleID, err.Error())
+		}
+		err = resourceprovider.CheckNetworkPolicySupported(policy, executorOptions)
+		if err != nil {
+			return fmt.Errorf("OFFER_NETWORK_MODULES %s: %s", moduleID, err.Error())
+		}
+	}
+	return nil
+}
+
+func CheckResourceProviderHealthOptions(options resourceprovider.ResourceProviderHealthOptions) error {
+	if options.MinFreeDisk < 0 {
+		return fmt.Errorf("HEALTH_MIN_FREE_DISK cannot be negative")
+	}
+	if options.MinFreeRAM < 0 {
+		return fmt.Errorf("HEALTH_MIN_FREE_RAM cannot be negative")
+	}
+	if options.MaxGPUTemperature < 0 {
+		return fmt.Errorf("HEALTH_MAX_GPU_TEMPERATURE cannot be negative")
+	}
+	if options.MinFreeDisk > 0 && options.DiskPath == "" {
+		return fmt.Errorf("HEALTH_DISK_PATH is required when HEALTH_MIN_FREE_DISK is set")
+	}
+	return nil
+}
+
+func CheckResourceProviderThrottleOptions(options resourceprovider.ResourceProviderThrottleOptions, diskPath string) error {
+	// the load can go above the number of cores so it has no upper bound
+	if options.MaxLoad < 0 {
+		return fmt.Errorf("THROTTLE_MAX_LOAD cannot be negative")
+	}
+	checks := []struct {
+		name  string
+		value int
+	}{
+		{name: "THROTTLE_MAX_GPU_MEMORY", value: options.MaxGPUMemory},
+		{name: "THROTTLE_MAX_DISK", value: options.MaxDisk},
+	}
+	for _, check := range checks {
+		if check.value < 0 || check.value > 100 {
+			return fmt.Errorf("%s must be a percentage between 0 and 100", check.name)
+		}
+	}
+	if options.MaxDisk > 0 && diskPath == "" {
+		return fmt.Errorf("HEALTH_DISK_PATH is required when THROTTLE_MAX_DISK is set")
+	}
+	return nil
+}
+
+func CheckResourceProviderQueueOptions(options resourceprovider.ResourceProviderQueueOptions) error {
+	if options.MaxConcurrentDeals < 0 {
+		return fmt.Errorf("QUEUE_MAX_CONCURRENT_DEALS cannot be negative")
+	}
+	if options.MaxQueuedDeals < 0 {
+		return fmt.Errorf("QUEUE_MAX_QUEUED_DEALS cannot be negative")
+	}
+	for moduleID, limit := range options.ModuleConcurrency {
+		if limit <= 0 {
+			return fmt.Errorf("QUEUE_MODULE_LIMITS limit for %s must be greater than zero", moduleID)
+		}
+	}
+	return nil
+}
+
+func CheckResourceProviderLogOptions(options resourceprovider.ResourceProviderLogOptions) error {
+	if options.Enabled && options.FlushInterval <= 0 {
+		return fmt.Errorf("LOG_STREAMING_FLUSH_INTERVAL must be greater than zero")
+	}
+	return nil
+}
+
+func CheckResourceProviderImageOptions(options resourceprovider.ResourceProviderImageOptions) error {
+	if options.CosignKey == "" {
+		return nil
+	}
+	if _, err := os.Stat(options.CosignKey); err != nil {
+		return fmt.Errorf("IMAGE_COSIGN_KEY cannot be read: %s", err.Error())
+	}
 	return nil
 }
 
@@ -163,28 +530,99 @@ func CheckResourceProviderOptions(options resourceprovider.ResourceProviderOptio
 	if err != nil {
 		return err
 	}
-	err = CheckBacalhauOptions(options.Bacalhau)
+	err = CheckResourceProviderHealthOptions(options.Health)
+	if err != nil {
+		return err
+	}
+	err = CheckResourceProviderThrottleOptions(options.Throttle, options.Health.DiskPath)
+	if err != nil {
+		return err
+	}
+	err = CheckResourceProviderQueueOptions(options.Queue)
+	if err != nil {
+		return err
+	}
+	err = CheckResourceProviderLogOptions(options.Logs)
+	if err != nil {
+		return err
+	}
+	err = CheckResourceProviderImageOptions(options.Image)
 	if err != nil {
 		return err
 	}
+	err = CheckExecutorOptions(options.Executor)
+	if err != nil {
+		return err
+	}
+	err = CheckResourceProviderNetworkOptions(options.Offers, options.Executor)
+	if err != nil {
+		return err
+	}
+	if options.Executor.Type == resourceprovider.EXECUTOR_BACALHAU {
+		err = CheckBacalhauOptions(options.Bacalhau)
+		if err != nil {
+			return err
+		}
+	}
 	err = CheckIPFSOptions(options.IPFS)
 	if err != nil {
 		return err
 	}
+	err = CheckStorageOptions(options.Storage, options.IPFS.Addr)
+	if err != nil {
+		return err
+	}
+	err = CheckBusOptions(options.Bus)
+	if err != nil {
+		return err
+	}
 	err = CheckTelemetryOptions(options.Telemetry)
 	if err != nil {
 		return err
 	}
+	err = CheckAlertOptions(options.Alerts)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
-func ProcessResourceProviderOfferOptions(options resourceprovider.ResourceProviderOfferOptions, network string) (resourceprovider.ResourceProviderOfferOptions, error) {
+func ProcessResourceProviderOfferOptions(options resourceprovider.ResourceProviderOfferOptions, network string, diskPath string) (resourceprovider.ResourceProviderOfferOptions, error) {
 	newServicesOptions, err := ProcessServicesOptions(options.Services, network)
 	if err != nil {
 		return options, err
 	}
 	options.Services = newServicesOptions
 
+	// module prices only change the instruction price
+	// so everything else comes from the default pricing
+	if options.ModulePricing == nil {
+		options.ModulePricing = map[string]data.DealPricing{}
+	}
+	for _, modulePrice := range options.ModulePrices {
+		moduleID, priceString, ok := strings.Cut(modulePrice, "=")
+		if !ok || moduleID == "" {
+			return options, fmt.Errorf("OFFER_MODULE_PRICES entry %q must be module_id=price", modulePrice)
+		}
+		price, err := strconv.ParseUint(priceString, 10, 64)
+		if err != nil {
+			return options, fmt.Errorf("OFFER_MODULE_PRICES entry %q has an invalid price: %s", modulePrice, err.Error())
+		}
+		pricing := options.DefaultPricing
+		pricing.InstructionPrice = price
+		options.ModulePricing[moduleID] = pricing
+	}
+
+	// the detected spec replaces the one from the flags
+	if options.Autodetect {
+		spec, err := resourceprovider.ApplyMachineSpecOverrides(resourceprovider.DetectMachineSpec(diskPath), options.SpecOverrides)
+		if err != nil {
+			return options, fmt.Errorf("OFFER_SPEC_OVERRIDES: %s", err.Error())
+		}
+		options.OfferSpec = spec
+		options.Specs = []data.MachineSpec{}
+	}
+
 	// if there are no specs then populate with the single spec
 	if len(options.Specs) == 0 {
 		// loop the number of machines we want to offer
@@ -195,12 +633,35 @@ func ProcessResourceProviderOfferOptions(options resourceprovider.ResourceProvid
 	return options, nil
 }
 
+func ProcessResourceProviderQueueOptions(options resourceprovider.ResourceProviderQueueOptions) (resourceprovider.ResourceProviderQueueOptions, error) {
+	if options.ModuleConcurrency == nil {
+		options.ModuleConcurrency = map[string]int{}
+	}
+	for _, moduleLimit := range options.ModuleLimits {
+		moduleID, limitString, ok := strings.Cut(moduleLimit, "=")
+		if !ok || moduleID == "" {
+			return options, fmt.Errorf("QUEUE_MODULE_LIMITS entry %q must be module_id=limit", moduleLimit)
+		}
+		limit, err := strconv.Atoi(limitString)
+		if err != nil {
+			return options, fmt.Errorf("QUEUE_MODULE_LIMITS entry %q has an invalid limit: %s", moduleLimit, err.Error())
+		}
+		options.ModuleConcurrency[moduleID] = limit
+	}
+	return options, nil
+}
+
 func ProcessResourceProviderOptions(options resourceprovider.ResourceProviderOptions, network string) (resourceprovider.ResourceProviderOptions, error) {
-	newOfferOptions, err := ProcessResourceProviderOfferOptions(options.Offers, network)
+	newOfferOptions, err := ProcessResourceProviderOfferOptions(options.Offers, network, options.Health.DiskPath)
 	if err != nil {
 		return options, err
 	}
 	options.Offers = newOfferOptions
+	newQueueOptions, err := ProcessResourceProviderQueueOptions(options.Queue)
+	if err != nil {
+		return options, err
+	}
+	options.Queue = newQueueOptions
 	newWeb3Options, err := ProcessWeb3Options(options.Web3, network)
 	if err != nil {
 		return options, err
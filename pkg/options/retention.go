@@ -0,0 +1,44 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultRetentionOptions() solver.RetentionOptions {
+	return solver.RetentionOptions{
+		Days:       GetDefaultServeOptionInt("RETENTION_DAYS", 0),
+		ArchiveDir: GetDefaultServeOptionString("RETENTION_ARCHIVE_DIR", "/var/tmp/lilypad_archive"),
+		Interval:   GetDefaultServeOptionInt("RETENTION_INTERVAL", 3600), //nolint:gomnd
+	}
+}
+
+func AddRetentionCliFlags(cmd *cobra.Command, retentionOptions *solver.RetentionOptions) {
+	cmd.PersistentFlags().IntVar(
+		&retentionOptions.Days, "retention-days", retentionOptions.Days,
+		`Archive finished deals this many days after they finish, 0 keeps everything (RETENTION_DAYS).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&retentionOptions.ArchiveDir, "retention-archive-dir", retentionOptions.ArchiveDir,
+		`The directory archived deals are written to as gzipped jsonl (RETENTION_ARCHIVE_DIR).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&retentionOptions.Interval, "retention-interval", retentionOptions.Interval,
+		`The number of seconds between looking for deals to archive (RETENTION_INTERVAL).`,
+	)
+}
+
+func CheckRetentionOptions(options solver.RetentionOptions) error {
+	if options.Days < 0 {
+		return fmt.Errorf("RETENTION_DAYS cannot be negative")
+	}
+	if options.Days > 0 && options.ArchiveDir == "" {
+		return fmt.Errorf("RETENTION_ARCHIVE_DIR is required when RETENTION_DAYS is set")
+	}
+	if options.Interval < 0 {
+		return fmt.Errorf("RETENTION_INTERVAL cannot be negative")
+	}
+	return nil
+}
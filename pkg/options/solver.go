@@ -8,10 +8,36 @@ import (
 
 func NewSolverOptions() solver.SolverOptions {
 	options := solver.SolverOptions{
-		Server:    GetDefaultServerOptions(),
-		Web3:      GetDefaultWeb3Options(),
-		Services:  GetDefaultServicesOptions(),
-		Telemetry: GetDefaultTelemetryOptions(),
+		Server:         GetDefaultServerOptions(),
+		GRPC:           GetDefaultGRPCOptions(),
+		Bus:            GetDefaultBusOptions(),
+		Web3:           GetDefaultWeb3Options(),
+		Chains:         GetDefaultChainsOptions(),
+		Services:       GetDefaultServicesOptions(),
+		Allowlist:      GetDefaultAllowlistOptions(),
+		ModuleResolver: GetDefaultModuleResolverOptions(),
+		Quota:          GetDefaultQuotaOptions(),
+		Budget:         GetDefaultBudgetOptions(),
+		InputCheck:     GetDefaultInputCheckOptions(),
+		Verification:   GetDefaultVerificationOptions(),
+		Audit:          GetDefaultAuditOptions(),
+		Matching:       GetDefaultMatchingOptions(),
+		Collusion:      GetDefaultCollusionOptions(),
+		Watchdog:       GetDefaultWatchdogOptions(),
+		Retention:      GetDefaultRetentionOptions(),
+		LogRelay:       GetDefaultLogRelayOptions(),
+		IPFS:           GetDefaultIPFSOptions(),
+		Storage:        GetDefaultStorageOptions(),
+		Pinning:        GetDefaultPinningOptions(),
+		Stats:          GetDefaultStatsOptions(),
+		Fiat:           GetDefaultFiatOptions(),
+		Admin:          GetDefaultAdminOptions(),
+		ReadOnly:       GetDefaultReadOnlyOptions(),
+		Telemetry:      GetDefaultTelemetryOptions(),
+		Webhooks:       GetDefaultWebhookOptions(),
+		Notifications:  GetDefaultNotificationOptions(),
+		Attestation:    GetDefaultAttestationOptions(),
+		Store:          GetDefaultStoreOptions(),
 	}
 	options.Web3.Service = system.SolverService
 	return options
@@ -19,9 +45,37 @@ func NewSolverOptions() solver.SolverOptions {
 
 func AddSolverCliFlags(cmd *cobra.Command, options *solver.SolverOptions) {
 	AddWeb3CliFlags(cmd, &options.Web3)
+	AddChainsCliFlags(cmd, &options.Chains)
 	AddServerCliFlags(cmd, &options.Server)
+	AddGRPCCliFlags(cmd, &options.GRPC)
+	AddBusCliFlags(cmd, &options.Bus)
 	AddServicesCliFlags(cmd, &options.Services)
+	AddAllowlistCliFlags(cmd, &options.Allowlist)
+	AddModuleResolverCliFlags(cmd, &options.ModuleResolver)
+	AddQuotaCliFlags(cmd, &options.Quota)
+	AddBudgetCliFlags(cmd, &options.Budget)
+	AddInputCheckCliFlags(cmd, &options.InputCheck)
+	AddVerificationCliFlags(cmd, &options.Verification)
+	AddAuditCliFlags(cmd, &options.Audit)
+	AddMatchingCliFlags(cmd, &options.Matching)
+	AddCollusionCliFlags(cmd, &options.Collusion)
+	AddWatchdogCliFlags(cmd, &options.Watchdog)
+	AddRetentionCliFlags(cmd, &options.Retention)
+	AddLogRelayCliFlags(cmd, &options.LogRelay)
+	// the solver only needs IPFS to serve verified result archives
+	// and to mirror results into ipfs
+	AddIPFSCliFlags(cmd, &options.IPFS)
+	AddStorageCliFlags(cmd, &options.Storage)
+	AddPinningCliFlags(cmd, &options.Pinning)
+	AddStatsCliFlags(cmd, &options.Stats)
+	AddFiatCliFlags(cmd, &options.Fiat)
+	AddAdminCliFlags(cmd, &options.Admin)
+	AddReadOnlyCliFlags(cmd, &options.ReadOnly)
 	AddTelemetryCliFlags(cmd, &options.Telemetry)
+	AddWebhookCliFlags(cmd, &options.Webhooks)
+	AddNotificationCliFlags(cmd, &options.Notifications)
+	AddAttestationCliFlags(cmd, &options.Attestation)
+	AddStoreCliFlags(cmd, &options.Store)
 }
 
 func CheckSolverOptions(options solver.SolverOptions) error {
@@ -33,10 +87,102 @@ func CheckSolverOptions(options solver.SolverOptions) error {
 	if err != nil {
 		return err
 	}
+	err = CheckGRPCOptions(options.GRPC, options.Server)
+	if err != nil {
+		return err
+	}
+	err = CheckBusOptions(options.Bus)
+	if err != nil {
+		return err
+	}
+	err = CheckAllowlistOptions(options.Allowlist)
+	if err != nil {
+		return err
+	}
+	err = CheckModuleResolverOptions(options.ModuleResolver)
+	if err != nil {
+		return err
+	}
+	err = CheckQuotaOptions(options.Quota)
+	if err != nil {
+		return err
+	}
+	err = CheckBudgetOptions(options.Budget)
+	if err != nil {
+		return err
+	}
+	err = CheckInputCheckOptions(options.InputCheck, options.IPFS.Addr)
+	if err != nil {
+		return err
+	}
+	err = CheckFiatOptions(options.Fiat)
+	if err != nil {
+		return err
+	}
+	err = CheckWebhookOptions(options.Webhooks)
+	if err != nil {
+		return err
+	}
+	err = CheckNotificationOptions(options.Notifications)
+	if err != nil {
+		return err
+	}
+	err = CheckAttestationOptions(options.Attestation)
+	if err != nil {
+		return err
+	}
+	err = CheckAdminOptions(options.Admin)
+	if err != nil {
+		return err
+	}
+	err = CheckVerificationOptions(options.Verification)
+	if err != nil {
+		return err
+	}
+	err = CheckAuditOptions(options.Audit)
+	if err != nil {
+		return err
+	}
+	err = CheckMatchingOptions(options.Matching)
+	if err != nil {
+		return err
+	}
+	err = CheckCollusionOptions(options.Collusion)
+	if err != nil {
+		return err
+	}
+	err = CheckWatchdogOptions(options.Watchdog)
+	if err != nil {
+		return err
+	}
+	err = CheckRetentionOptions(options.Retention)
+	if err != nil {
+		return err
+	}
+	err = CheckLogRelayOptions(options.LogRelay)
+	if err != nil {
+		return err
+	}
+	err = CheckStorageOptions(options.Storage, options.IPFS.Addr)
+	if err != nil {
+		return err
+	}
+	err = CheckPinningOptions(options.Pinning)
+	if err != nil {
+		return err
+	}
+	err = CheckStatsOptions(options.Stats)
+	if err != nil {
+		return err
+	}
 	err = CheckTelemetryOptions(options.Telemetry)
 	if err != nil {
 		return err
 	}
+	err = CheckStoreOptions(options.Store)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -46,6 +192,11 @@ func ProcessSolverOptions(options solver.SolverOptions, network string) (solver.
 		return options, err
 	}
 	options.Web3 = newWeb3Options
+	newChainsOptions, err := ProcessChainsOptions(options.Chains, options.Web3)
+	if err != nil {
+		return options, err
+	}
+	options.Chains = newChainsOptions
 	newTelemetryOptions, err := ProcessTelemetryOptions(options.Telemetry, network)
 	if err != nil {
 		return options, err
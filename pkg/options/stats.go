@@ -0,0 +1,37 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/lilypad-tech/lilypad/pkg/solver/stats"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultStatsOptions() stats.StatsOptions {
+	return stats.StatsOptions{
+		MinCount: GetDefaultServeOptionInt("STATS_MIN_COUNT", 5),
+		// 1 LP
+		EarningsBucketSize: GetDefaultServeOptionUint64("STATS_EARNINGS_BUCKET_SIZE", 1000000000000000000),
+	}
+}
+
+func AddStatsCliFlags(cmd *cobra.Command, statsOptions *stats.StatsOptions) {
+	cmd.PersistentFlags().IntVar(
+		&statsOptions.MinCount, "stats-min-count", statsOptions.MinCount,
+		`Counts below this are suppressed in the public stats (STATS_MIN_COUNT).`,
+	)
+	cmd.PersistentFlags().Uint64Var(
+		&statsOptions.EarningsBucketSize, "stats-earnings-bucket-size", statsOptions.EarningsBucketSize,
+		`The width in wei of the buckets provider earnings are grouped into in the public stats (STATS_EARNINGS_BUCKET_SIZE).`,
+	)
+}
+
+func CheckStatsOptions(options stats.StatsOptions) error {
+	if options.MinCount < 0 {
+		return fmt.Errorf("STATS_MIN_COUNT cannot be negative")
+	}
+	if options.EarningsBucketSize == 0 {
+		return fmt.Errorf("STATS_EARNINGS_BUCKET_SIZE must be greater than zero")
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/lilypad-tech/lilypad/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultStorageOptions() storage.StorageOptions {
+	return storage.StorageOptions{
+		Backends: GetDefaultServeOptionStringArray("RESULTS_STORAGE", []string{}),
+		S3: storage.S3Options{
+			Endpoint:  GetDefaultServeOptionString("RESULTS_S3_ENDPOINT", ""),
+			Region:    GetDefaultServeOptionString("RESULTS_S3_REGION", "us-east-1"),
+			Bucket:    GetDefaultServeOptionString("RESULTS_S3_BUCKET", ""),
+			Prefix:    GetDefaultServeOptionString("RESULTS_S3_PREFIX", ""),
+			AccessKey: GetDefaultServeOptionString("RESULTS_S3_ACCESS_KEY", ""),
+			SecretKey: GetDefaultServeOptionString("RESULTS_S3_SECRET_KEY", ""),
+		},
+		HTTP: storage.HTTPOptions{
+			URL:   GetDefaultServeOptionString("RESULTS_HTTP_URL", ""),
+			Token: GetDefaultServeOptionString("RESULTS_HTTP_TOKEN", ""),
+		},
+	}
+}
+
+func AddStorageCliFlags(cmd *cobra.Command, storageOptions *storage.StorageOptions) {
+	cmd.PersistentFlags().StringArrayVar(
+		&storageOptions.Backends, "results-storage", storageOptions.Backends,
+		`The backends to put result files in, any of ipfs, s3 and http (RESULTS_STORAGE).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&storageOptions.S3.Endpoint, "results-s3-endpoint", storageOptions.S3.Endpoint,
+		`The S3 compatible endpoint to put results in (RESULTS_S3_ENDPOINT).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&storageOptions.S3.Region, "results-s3-region", storageOptions.S3.Region,
+		`The region of the S3 bucket (RESULTS_S3_REGION).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&storageOptions.S3.Bucket, "results-s3-bucket", storageOptions.S3.Bucket,
+		`The S3 bucket to put results in (RESULTS_S3_BUCKET).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&storageOptions.S3.Prefix, "results-s3-prefix", storageOptions.S3.Prefix,
+		`Prepended to the S3 key of each result archive (RESULTS_S3_PREFIX).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&storageOptions.S3.AccessKey, "results-s3-access-key", storageOptions.S3.AccessKey,
+		`The S3 access key ID (RESULTS_S3_ACCESS_KEY).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&storageOptions.S3.SecretKey, "results-s3-secret-key", storageOptions.S3.SecretKey,
+		`The S3 secret access key (RESULTS_S3_SECRET_KEY).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&storageOptions.HTTP.URL, "results-http-url", storageOptions.HTTP.URL,
+		`The URL result archives are PUT under as <deal id>.tar (RESULTS_HTTP_URL).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&storageOptions.HTTP.Token, "results-http-token", storageOptions.HTTP.Token,
+		`The bearer token sent to the results URL (RESULTS_HTTP_TOKEN).`,
+	)
+}
+
+func CheckStorageOptions(options storage.StorageOptions, ipfsAddr string) error {
+	for _, backend := range options.Backends {
+		switch backend {
+		case storage.IPFSBackend:
+			if ipfsAddr == "" {
+				return fmt.Errorf("IPFS_CONNECT is required to put results in ipfs")
+			}
+		case storage.S3Backend:
+			if options.S3.Endpoint == "" || options.S3.Bucket == "" {
+				return fmt.Errorf("RESULTS_S3_ENDPOINT and RESULTS_S3_BUCKET are required to put results in s3")
+			}
+			if options.S3.AccessKey == "" || options.S3.SecretKey == "" {
+				return fmt.Errorf("RESULTS_S3_ACCESS_KEY and RESULTS_S3_SECRET_KEY are required to put results in s3")
+			}
+		case storage.HTTPBackend:
+			if options.HTTP.URL == "" {
+				return fmt.Errorf("RESULTS_HTTP_URL is required to put results in http")
+			}
+		default:
+			return fmt.Errorf("RESULTS_STORAGE has an unknown backend %s, use ipfs, s3 or http", backend)
+		}
+	}
+	return nil
+}
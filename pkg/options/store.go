@@ -0,0 +1,36 @@
+package options
+
+import (
+	"fmt"
+
+	memorystore "github.com/lilypad-tech/lilypad/pkg/solver/store/memory"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultStoreOptions() memorystore.SolverStoreMemoryOptions {
+	return memorystore.SolverStoreMemoryOptions{
+		LogDir:    GetDefaultServeOptionString("STORE_LOG_DIR", "/var/tmp"),
+		MaxEvents: GetDefaultServeOptionInt("STORE_MAX_EVENTS", 100000), //nolint:gomnd
+	}
+}
+
+func AddStoreCliFlags(cmd *cobra.Command, storeOptions *memorystore.SolverStoreMemoryOptions) {
+	cmd.PersistentFlags().StringVar(
+		&storeOptions.LogDir, "store-log-dir", storeOptions.LogDir,
+		`The directory the solver store logs to and reads pending transactions, checkpoints, schedules, reservations and bans back from after a restart (STORE_LOG_DIR).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&storeOptions.MaxEvents, "store-max-events", storeOptions.MaxEvents,
+		`The most store events to keep, the oldest are dropped from memory and the events log past this, 0 keeps them all (STORE_MAX_EVENTS).`,
+	)
+}
+
+func CheckStoreOptions(options memorystore.SolverStoreMemoryOptions) error {
+	if options.LogDir == "" {
+		return fmt.Errorf("STORE_LOG_DIR is required")
+	}
+	if options.MaxEvents < 0 {
+		return fmt.Errorf("STORE_MAX_EVENTS cannot be negative")
+	}
+	return nil
+}
@@ -10,6 +10,10 @@ func AddTargetCliFlags(cmd *cobra.Command, targetConfig *data.TargetConfig) {
 		&targetConfig.Address, "target", "t", targetConfig.Address,
 		`The address to target (TARGET)`,
 	)
+	cmd.PersistentFlags().StringVar(
+		&targetConfig.ResourceOffer, "target-offer", targetConfig.ResourceOffer,
+		`The ID of the resource offer to run the job on (TARGET_OFFER)`,
+	)
 }
 
 func ProcessTargetOptions(options data.TargetConfig) (data.TargetConfig, error) {
@@ -35,6 +35,23 @@ func GetDefaultServeOptionStringArray(envName string, defaultValue []string) []s
 	return defaultValue
 }
 
+// parse key=value pairs separated by commas
+// entries without an = are ignored
+func GetDefaultServeOptionStringMap(envName string, defaultValue map[string]string) map[string]string {
+	envValue := os.Getenv(envName)
+	if envValue != "" {
+		values := map[string]string{}
+		for _, pair := range strings.Split(envValue, ",") {
+			key, value, ok := strings.Cut(pair, "=")
+			if ok {
+				values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+			}
+		}
+		return values
+	}
+	return defaultValue
+}
+
 func GetDefaultServeOptionInt(envName string, defaultValue int) int {
 	envValue := os.Getenv(envName)
 	if envValue != "" {
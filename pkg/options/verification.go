@@ -0,0 +1,33 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultVerificationOptions() solver.VerificationOptions {
+	return solver.VerificationOptions{
+		Verifiers:     GetDefaultServeOptionStringArray("RESULT_VERIFIERS", []string{}),
+		MaxResultSize: GetDefaultServeOptionInt("RESULT_MAX_SIZE", 0),
+	}
+}
+
+func AddVerificationCliFlags(cmd *cobra.Command, verificationOptions *solver.VerificationOptions) {
+	cmd.PersistentFlags().StringArrayVar(
+		&verificationOptions.Verifiers, "result-verifiers", verificationOptions.Verifiers,
+		`The verifiers to run against posted results e.g. files,size (RESULT_VERIFIERS).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&verificationOptions.MaxResultSize, "result-max-size", verificationOptions.MaxResultSize,
+		`The largest result in megabytes the size verifier will accept, 0 for no limit (RESULT_MAX_SIZE).`,
+	)
+}
+
+func CheckVerificationOptions(options solver.VerificationOptions) error {
+	if options.MaxResultSize < 0 {
+		return fmt.Errorf("RESULT_MAX_SIZE cannot be negative")
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultWatchdogOptions() solver.WatchdogOptions {
+	return solver.WatchdogOptions{
+		Enabled:     GetDefaultServeOptionBool("WATCHDOG_ENABLED", true),
+		GracePeriod: GetDefaultServeOptionInt("WATCHDOG_GRACE_PERIOD", 60),
+	}
+}
+
+func AddWatchdogCliFlags(cmd *cobra.Command, watchdogOptions *solver.WatchdogOptions) {
+	cmd.PersistentFlags().BoolVar(
+		&watchdogOptions.Enabled, "watchdog-enabled", watchdogOptions.Enabled,
+		`Send the on-chain timeout for deals that are stuck past their timeouts (WATCHDOG_ENABLED).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&watchdogOptions.GracePeriod, "watchdog-grace-period", watchdogOptions.GracePeriod,
+		`The number of seconds to wait after a timeout before sending it, to allow for clock drift (WATCHDOG_GRACE_PERIOD).`,
+	)
+}
+
+func CheckWatchdogOptions(options solver.WatchdogOptions) error {
+	if options.GracePeriod < 0 {
+		return fmt.Errorf("WATCHDOG_GRACE_PERIOD cannot be negative")
+	}
+	return nil
+}
@@ -3,6 +3,8 @@ package options
 import (
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/lilypad-tech/lilypad/pkg/web3"
@@ -17,6 +19,35 @@ func GetDefaultWeb3Options() web3.Web3Options {
 		PrivateKey: GetDefaultServeOptionString("WEB3_PRIVATE_KEY", ""),
 		ChainID:    GetDefaultServeOptionInt("WEB3_CHAIN_ID", 0), //nolint:gomnd
 
+		// signing
+		Signer:            GetDefaultServeOptionString("WEB3_SIGNER", web3.SIGNER_KEY),
+		KeystorePath:      GetDefaultServeOptionString("WEB3_KEYSTORE_PATH", ""),
+		KeystorePassword:  GetDefaultServeOptionString("WEB3_KEYSTORE_PASSWORD", ""),
+		KMSKeyID:          GetDefaultServeOptionString("WEB3_KMS_KEY_ID", ""),
+		KMSRegion:         GetDefaultServeOptionString("WEB3_KMS_REGION", ""),
+		KMSEndpoint:       GetDefaultServeOptionString("WEB3_KMS_ENDPOINT", ""),
+		RemoteSignerURL:   GetDefaultServeOptionString("WEB3_REMOTE_SIGNER_URL", ""),
+		RemoteSignerToken: GetDefaultServeOptionString("WEB3_REMOTE_SIGNER_TOKEN", ""),
+		SignerAddress:     GetDefaultServeOptionString("WEB3_SIGNER_ADDRESS", ""),
+
+		// rpc failover
+		RpcHealthCheckInterval: GetDefaultServeOptionInt("WEB3_RPC_HEALTH_CHECK_INTERVAL", web3.DEFAULT_RPC_HEALTH_CHECK_INTERVAL),
+		RpcMaxBackoff:          GetDefaultServeOptionInt("WEB3_RPC_MAX_BACKOFF", web3.DEFAULT_RPC_MAX_BACKOFF),
+		RpcMaxBlockLag:         GetDefaultServeOptionInt("WEB3_RPC_MAX_BLOCK_LAG", web3.DEFAULT_RPC_MAX_BLOCK_LAG),
+
+		// gas
+		GasStrategy:     GetDefaultServeOptionString("WEB3_GAS_STRATEGY", web3.GAS_STRATEGY_AUTO),
+		GasMaxFeeCap:    GetDefaultServeOptionUint64("WEB3_GAS_MAX_FEE_CAP", 0),
+		GasPriorityFee:  GetDefaultServeOptionUint64("WEB3_GAS_PRIORITY_FEE", 0),
+		GasBumpPercent:  GetDefaultServeOptionInt("WEB3_GAS_BUMP_PERCENT", web3.DEFAULT_GAS_BUMP_PERCENT),
+		GasStuckTimeout: GetDefaultServeOptionInt("WEB3_GAS_STUCK_TIMEOUT", web3.DEFAULT_GAS_STUCK_TIMEOUT),
+		GasMaxBumps:     GetDefaultServeOptionInt("WEB3_GAS_MAX_BUMPS", web3.DEFAULT_GAS_MAX_BUMPS),
+
+		// chain sync
+		SyncConfirmations: GetDefaultServeOptionInt("WEB3_SYNC_CONFIRMATIONS", 0), //nolint:gomnd
+		SyncPollInterval:  GetDefaultServeOptionInt("WEB3_SYNC_POLL_INTERVAL", web3.DEFAULT_SYNC_POLL_INTERVAL),
+		SyncMaxBlockRange: GetDefaultServeOptionInt("WEB3_SYNC_MAX_BLOCK_RANGE", web3.DEFAULT_SYNC_MAX_BLOCK_RANGE),
+
 		// contract addresses
 		ControllerAddress: GetDefaultServeOptionString("WEB3_CONTROLLER_ADDRESS", ""),
 		PaymentsAddress:   GetDefaultServeOptionString("WEB3_PAYMENTS_ADDRESS", ""),
@@ -26,6 +57,7 @@ func GetDefaultWeb3Options() web3.Web3Options {
 		MediationAddress:  GetDefaultServeOptionString("WEB3_MEDIATION_ADDRESS", ""),
 		JobCreatorAddress: GetDefaultServeOptionString("WEB3_JOBCREATOR_ADDRESS", ""),
 		PowAddress:        GetDefaultServeOptionString("WEB3_POW_ADDRESS", ""),
+		ParametersAddress: GetDefaultServeOptionString("WEB3_PARAMETERS_ADDRESS", ""),
 
 		// misc
 		Service: system.DefaultService,
@@ -35,7 +67,55 @@ func GetDefaultWeb3Options() web3.Web3Options {
 func AddWeb3CliFlags(cmd *cobra.Command, web3Options *web3.Web3Options) {
 	cmd.PersistentFlags().StringVar(
 		&web3Options.RpcURL, "web3-rpc-url", web3Options.RpcURL,
-		`The URL of the web3 RPC server (WEB3_RPC_URL).`,
+		`The URL of the web3 RPC server, a comma separated list fails over between them (WEB3_RPC_URL).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&web3Options.RpcHealthCheckInterval, "web3-rpc-health-check-interval", web3Options.RpcHealthCheckInterval,
+		`How many seconds between checking the health of each web3 RPC server (WEB3_RPC_HEALTH_CHECK_INTERVAL).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&web3Options.RpcMaxBackoff, "web3-rpc-max-backoff", web3Options.RpcMaxBackoff,
+		`The most seconds to stop using a failing or rate limited web3 RPC server for (WEB3_RPC_MAX_BACKOFF).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&web3Options.RpcMaxBlockLag, "web3-rpc-max-block-lag", web3Options.RpcMaxBlockLag,
+		`How many blocks a web3 RPC server can be behind the others before we stop using it (WEB3_RPC_MAX_BLOCK_LAG).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&web3Options.GasStrategy, "web3-gas-strategy", web3Options.GasStrategy,
+		fmt.Sprintf(`How to price transactions, one of %s (WEB3_GAS_STRATEGY).`, strings.Join(web3.GAS_STRATEGIES, ", ")),
+	)
+	cmd.PersistentFlags().Uint64Var(
+		&web3Options.GasMaxFeeCap, "web3-gas-max-fee-cap", web3Options.GasMaxFeeCap,
+		`The most wei per gas we will pay, 0 for no cap (WEB3_GAS_MAX_FEE_CAP).`,
+	)
+	cmd.PersistentFlags().Uint64Var(
+		&web3Options.GasPriorityFee, "web3-gas-priority-fee", web3Options.GasPriorityFee,
+		`The priority fee in wei per gas, 0 to use what the node suggests (WEB3_GAS_PRIORITY_FEE).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&web3Options.GasBumpPercent, "web3-gas-bump-percent", web3Options.GasBumpPercent,
+		`How many percent to bump the fees of a stuck transaction by (WEB3_GAS_BUMP_PERCENT).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&web3Options.GasStuckTimeout, "web3-gas-stuck-timeout", web3Options.GasStuckTimeout,
+		`How many seconds before a transaction that has not been mined is sent again with higher fees, 0 to never (WEB3_GAS_STUCK_TIMEOUT).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&web3Options.GasMaxBumps, "web3-gas-max-bumps", web3Options.GasMaxBumps,
+		`How many times to bump the fees of a stuck transaction (WEB3_GAS_MAX_BUMPS).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&web3Options.SyncConfirmations, "web3-sync-confirmations", web3Options.SyncConfirmations,
+		`How many blocks must be on top of an event before the chain sync handles it (WEB3_SYNC_CONFIRMATIONS).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&web3Options.SyncPollInterval, "web3-sync-poll-interval", web3Options.SyncPollInterval,
+		`How many seconds between the chain sync looking for new blocks (WEB3_SYNC_POLL_INTERVAL).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&web3Options.SyncMaxBlockRange, "web3-sync-max-block-range", web3Options.SyncMaxBlockRange,
+		`The most blocks the chain sync asks for the logs of at once (WEB3_SYNC_MAX_BLOCK_RANGE).`,
 	)
 
 	// don't use the env as the default here because otherwise it will show when --help is used
@@ -44,6 +124,42 @@ func AddWeb3CliFlags(cmd *cobra.Command, web3Options *web3.Web3Options) {
 		&web3Options.PrivateKey, "web3-private-key", "",
 		`The private key to use for signing web3 transactions (WEB3_PRIVATE_KEY).`,
 	)
+	cmd.PersistentFlags().StringVar(
+		&web3Options.KeystorePassword, "web3-keystore-password", "",
+		`The password of the keystore file, or @ and the path of a file holding it (WEB3_KEYSTORE_PASSWORD).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&web3Options.RemoteSignerToken, "web3-remote-signer-token", "",
+		`The bearer token to send to the remote signer (WEB3_REMOTE_SIGNER_TOKEN).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&web3Options.Signer, "web3-signer", web3Options.Signer,
+		fmt.Sprintf(`What signs web3 transactions, one of %s (WEB3_SIGNER).`, strings.Join(web3.SIGNERS, ", ")),
+	)
+	cmd.PersistentFlags().StringVar(
+		&web3Options.KeystorePath, "web3-keystore-path", web3Options.KeystorePath,
+		`The encrypted keystore file for the keystore signer (WEB3_KEYSTORE_PATH).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&web3Options.KMSKeyID, "web3-kms-key-id", web3Options.KMSKeyID,
+		`The KMS key id for aws-kms or the key version name for gcp-kms (WEB3_KMS_KEY_ID).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&web3Options.KMSRegion, "web3-kms-region", web3Options.KMSRegion,
+		`The AWS region of the KMS key (WEB3_KMS_REGION).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&web3Options.KMSEndpoint, "web3-kms-endpoint", web3Options.KMSEndpoint,
+		`Use this KMS endpoint rather than the default for the cloud (WEB3_KMS_ENDPOINT).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&web3Options.RemoteSignerURL, "web3-remote-signer-url", web3Options.RemoteSignerURL,
+		`The URL of the remote signer (WEB3_REMOTE_SIGNER_URL).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&web3Options.SignerAddress, "web3-signer-address", web3Options.SignerAddress,
+		`The address the remote signer signs for (WEB3_SIGNER_ADDRESS).`,
+	)
 	cmd.PersistentFlags().IntVar(
 		&web3Options.ChainID, "web3-chain-id", web3Options.ChainID,
 		`The chain id for the web3 RPC server (WEB3_CHAIN_ID).`,
@@ -72,6 +188,10 @@ func AddWeb3CliFlags(cmd *cobra.Command, web3Options *web3.Web3Options) {
 		&web3Options.PowAddress, "web3-pow-address", web3Options.PowAddress,
 		`The address of the pow contract (WEB3_POW_ADDRESS).`,
 	)
+	cmd.PersistentFlags().StringVar(
+		&web3Options.ParametersAddress, "web3-parameters-address", web3Options.ParametersAddress,
+		`The address of the protocol parameters contract (WEB3_PARAMETERS_ADDRESS).`,
+	)
 }
 
 func CheckWeb3Options(options web3.Web3Options) error {
@@ -79,8 +199,49 @@ func CheckWeb3Options(options web3.Web3Options) error {
 	if options.RpcURL == "" {
 		return fmt.Errorf("WEB3_RPC_URL is required")
 	}
-	if options.PrivateKey == "" {
-		return fmt.Errorf("WEB3_PRIVATE_KEY is required")
+	switch options.Signer {
+	case "", web3.SIGNER_KEY:
+		if options.PrivateKey == "" {
+			return fmt.Errorf("WEB3_PRIVATE_KEY is required")
+		}
+	case web3.SIGNER_KEYSTORE:
+		if options.KeystorePath == "" {
+			return fmt.Errorf("WEB3_KEYSTORE_PATH is required for the keystore signer")
+		}
+	case web3.SIGNER_AWS_KMS, web3.SIGNER_GCP_KMS:
+		if options.KMSKeyID == "" {
+			return fmt.Errorf("WEB3_KMS_KEY_ID is required for the %s signer", options.Signer)
+		}
+	case web3.SIGNER_REMOTE:
+		if options.RemoteSignerURL == "" || options.SignerAddress == "" {
+			return fmt.Errorf("WEB3_REMOTE_SIGNER_URL and WEB3_SIGNER_ADDRESS are required for the remote signer")
+		}
+	default:
+		return fmt.Errorf("WEB3_SIGNER must be one of %s", strings.Join(web3.SIGNERS, ", "))
+	}
+	if options.RpcHealthCheckInterval < 0 {
+		return fmt.Errorf("WEB3_RPC_HEALTH_CHECK_INTERVAL cannot be negative")
+	}
+	if options.RpcMaxBackoff < 0 {
+		return fmt.Errorf("WEB3_RPC_MAX_BACKOFF cannot be negative")
+	}
+	if options.RpcMaxBlockLag < 0 {
+		return fmt.Errorf("WEB3_RPC_MAX_BLOCK_LAG cannot be negative")
+	}
+	if options.GasStrategy != "" && !slices.Contains(web3.GAS_STRATEGIES, options.GasStrategy) {
+		return fmt.Errorf("WEB3_GAS_STRATEGY must be one of %s", strings.Join(web3.GAS_STRATEGIES, ", "))
+	}
+	if options.GasPriorityFee > 0 && options.GasMaxFeeCap > 0 && options.GasPriorityFee > options.GasMaxFeeCap {
+		return fmt.Errorf("WEB3_GAS_PRIORITY_FEE cannot be more than WEB3_GAS_MAX_FEE_CAP")
+	}
+	if options.GasStuckTimeout > 0 && options.GasBumpPercent < web3.MIN_GAS_BUMP_PERCENT {
+		return fmt.Errorf("WEB3_GAS_BUMP_PERCENT must be at least %d for a replacement to be accepted", web3.MIN_GAS_BUMP_PERCENT)
+	}
+	if options.GasStuckTimeout < 0 || options.GasMaxBumps < 0 {
+		return fmt.Errorf("WEB3_GAS_STUCK_TIMEOUT and WEB3_GAS_MAX_BUMPS cannot be negative")
+	}
+	if options.SyncConfirmations < 0 || options.SyncPollInterval < 0 || options.SyncMaxBlockRange < 0 {
+		return fmt.Errorf("WEB3_SYNC_CONFIRMATIONS, WEB3_SYNC_POLL_INTERVAL and WEB3_SYNC_MAX_BLOCK_RANGE cannot be negative")
 	}
 
 	// this is the only address we actually need
@@ -129,9 +290,18 @@ func ProcessWeb3Options(options web3.Web3Options, network string) (web3.Web3Opti
 	if options.PowAddress == "" {
 		options.PowAddress = config.Web3.PowAddress
 	}
+	if options.ParametersAddress == "" {
+		options.ParametersAddress = config.Web3.ParametersAddress
+	}
 
 	if options.PrivateKey == "" {
 		options.PrivateKey = os.Getenv("WEB3_PRIVATE_KEY")
 	}
+	if options.KeystorePassword == "" {
+		options.KeystorePassword = os.Getenv("WEB3_KEYSTORE_PASSWORD")
+	}
+	if options.RemoteSignerToken == "" {
+		options.RemoteSignerToken = os.Getenv("WEB3_REMOTE_SIGNER_TOKEN")
+	}
 	return options, nil
 }
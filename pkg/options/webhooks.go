@@ -0,0 +1,63 @@
+package options
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/lilypad-tech/lilypad/pkg/webhooks"
+	"github.com/spf13/cobra"
+)
+
+func GetDefaultWebhookOptions() webhooks.WebhookOptions {
+	return webhooks.WebhookOptions{
+		URLs:       GetDefaultServeOptionStringArray("WEBHOOK_URLS", []string{}),
+		Secret:     GetDefaultServeOptionString("WEBHOOK_SECRET", ""),
+		Events:     GetDefaultServeOptionStringArray("WEBHOOK_EVENTS", []string{}),
+		Retries:    GetDefaultServeOptionInt("WEBHOOK_RETRIES", 5),        //nolint:gomnd
+		RetryDelay: GetDefaultServeOptionInt("WEBHOOK_RETRY_DELAY", 1000), //nolint:gomnd
+	}
+}
+
+func AddWebhookCliFlags(cmd *cobra.Command, webhookOptions *webhooks.WebhookOptions) {
+	cmd.PersistentFlags().StringArrayVar(
+		&webhookOptions.URLs, "webhook-urls", webhookOptions.URLs,
+		`The URLs deal events are POSTed to as JSON (WEBHOOK_URLS).`,
+	)
+	cmd.PersistentFlags().StringVar(
+		&webhookOptions.Secret, "webhook-secret", webhookOptions.Secret,
+		`The key webhook payloads are signed with, the HMAC-SHA256 signature is in the X-Lilypad-Webhook-Signature header (WEBHOOK_SECRET).`,
+	)
+	cmd.PersistentFlags().StringArrayVar(
+		&webhookOptions.Events, "webhook-events", webhookOptions.Events,
+		fmt.Sprintf(`Only send these events, empty sends all of %s (WEBHOOK_EVENTS).`, strings.Join(webhooks.Events, ", ")),
+	)
+	cmd.PersistentFlags().IntVar(
+		&webhookOptions.Retries, "webhook-retries", webhookOptions.Retries,
+		`How many more times to try a webhook that failed (WEBHOOK_RETRIES).`,
+	)
+	cmd.PersistentFlags().IntVar(
+		&webhookOptions.RetryDelay, "webhook-retry-delay", webhookOptions.RetryDelay,
+		`Milliseconds before retrying a webhook, this doubles with each retry (WEBHOOK_RETRY_DELAY).`,
+	)
+}
+
+func CheckWebhookOptions(options webhooks.WebhookOptions) error {
+	for _, webhookURL := range options.URLs {
+		if _, err := url.ParseRequestURI(webhookURL); err != nil {
+			return fmt.Errorf("WEBHOOK_URLS %q is not a url", webhookURL)
+		}
+	}
+	for _, event := range options.Events {
+		if !webhooks.IsEvent(event) {
+			return fmt.Errorf("WEBHOOK_EVENTS %q must be one of %s", event, strings.Join(webhooks.Events, ", "))
+		}
+	}
+	if options.Retries < 0 {
+		return fmt.Errorf("WEBHOOK_RETRIES cannot be negative")
+	}
+	if options.RetryDelay < 0 {
+		return fmt.Errorf("WEBHOOK_RETRY_DELAY cannot be negative")
+	}
+	return nil
+}
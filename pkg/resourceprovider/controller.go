@@ -3,15 +3,21 @@ package resourceprovider
 import (
 	"context"
 	"fmt"
+	"os"
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/crypto/ecies"
+	"github.com/lilypad-tech/lilypad/pkg/alerts"
+	"github.com/lilypad-tech/lilypad/pkg/attestation"
 	"github.com/lilypad-tech/lilypad/pkg/data"
 	"github.com/lilypad-tech/lilypad/pkg/executor"
-	"github.com/lilypad-tech/lilypad/pkg/http"
+	"github.com/lilypad-tech/lilypad/pkg/executor/wasm"
+	"github.com/lilypad-tech/lilypad/pkg/ipfs"
 	"github.com/lilypad-tech/lilypad/pkg/module"
 	"github.com/lilypad-tech/lilypad/pkg/solver"
 	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+	resultstorage "github.com/lilypad-tech/lilypad/pkg/storage"
 	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/lilypad-tech/lilypad/pkg/web3"
 	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/storage"
@@ -21,9 +27,9 @@ import (
 )
 
 type ResourceProviderController struct {
-	solverClient *solver.SolverClient
+	solverClient solver.SolverAPI
 	options      ResourceProviderOptions
-	web3SDK      *web3.Web3SDK
+	web3SDK      web3.Web3Client
 	web3Events   *web3.EventChannels
 	loop         *system.ControlLoop
 	log          *system.ServiceLogger
@@ -32,8 +38,39 @@ type ResourceProviderController struct {
 	// keep track of which jobs are running
 	// this is because no remote state will change
 	// whilst we are actually running a job
+	// a job that has finished is kept as false so it is not run again
 	runningJobsMutex sync.RWMutex
 	runningJobs      map[string]bool
+	// the deals of ours that the job creator has cancelled
+	cancelledDeals map[string]bool
+	// the spot deals we stopped once their preemption notice was up
+	preemptedDeals map[string]bool
+	// the deals we have taken on that are running or waiting for a slot
+	queue *jobQueue
+	// set when the machine is low on disk, memory or running hot
+	// whilst paused we do not post offers or agree to new deals
+	pausedMutex sync.RWMutex
+	paused      bool
+	// how many of our offers the machine has room for when it is busy
+	// -1 means we offer everything
+	offerLimitMutex sync.RWMutex
+	offerLimit      int
+	// checks in a row that would have let us offer more
+	offerLimitRecoveries int
+	parameters           *web3.ProtocolParametersCache
+	// where we put a copy of our results as well as the solver
+	resultsStorage []resultstorage.ResultsStorage
+	// our IPFS node if we have one, inputs are staged onto it
+	ipfsClient *ipfs.Client
+	// the input staging requests being fetched right now
+	stagingMutex sync.Mutex
+	staging      map[string]bool
+	// the key job creators encrypt private inputs for
+	encryptionKey *ecies.PrivateKey
+	// evidence of the TEE we run in for our resource offers
+	teeAttestation *data.TEEAttestation
+	// our offers and deals as of the last sync with the solver
+	solverSync *solverSync
 }
 
 // the background "even if we have not heard of an event" loop
@@ -50,38 +87,83 @@ var lastResourceOfferPost time.Time
 
 func NewResourceProviderController(
 	options ResourceProviderOptions,
-	web3SDK *web3.Web3SDK,
+	web3SDK web3.Web3Client,
+	solverClient solver.SolverAPI,
 	executor executor.Executor,
 	tracer trace.Tracer,
 ) (*ResourceProviderController, error) {
-	// we know the address of the solver but what is it's url?
-	solverUrl, err := web3SDK.GetSolverUrl(options.Offers.Services.Solver)
+	parameters := web3.NewProtocolParametersCache()
+	err := parameters.Load(web3SDK)
 	if err != nil {
 		return nil, err
 	}
 
-	solverClient, err := solver.NewSolverClient(
-		http.ClientOptions{
-			URL:           solverUrl,
-			PrivateKey:    options.Web3.PrivateKey,
-			Type:          "ResourceProvider",
-			PublicAddress: web3SDK.GetAddress().String(),
-		})
+	controller := &ResourceProviderController{
+		solverClient:   solverClient,
+		options:        options,
+		web3SDK:        web3SDK,
+		web3Events:     web3.NewEventChannels(),
+		log:            system.NewServiceLogger(system.ResourceProviderService),
+		tracer:         tracer,
+		executor:       executor,
+		runningJobs:    map[string]bool{},
+		cancelledDeals: map[string]bool{},
+		preemptedDeals: map[string]bool{},
+		queue:          newJobQueue(options.Queue),
+		offerLimit:     -1,
+		parameters:     parameters,
+		staging:        map[string]bool{},
+		solverSync:     newSolverSync(),
+	}
+	if options.Offers.EncryptionKey != "" {
+		controller.encryptionKey, err = data.ParseInputEncryptionKey(options.Offers.EncryptionKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if options.Offers.TEE != "" {
+		certificates := ""
+		if options.Offers.TEECertificatesPath != "" {
+			bs, err := os.ReadFile(options.Offers.TEECertificatesPath)
+			if err != nil {
+				return nil, fmt.Errorf("error reading our TEE certificates: %s", err.Error())
+			}
+			certificates = string(bs)
+		}
+		teeAttestation, err := attestation.NewAttestation(options.Offers.TEE, web3SDK.GetAddress().String(), certificates, time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("error attesting to our %s TEE: %s", options.Offers.TEE, err.Error())
+		}
+		controller.teeAttestation = &teeAttestation
+	}
+	if options.IPFS.Addr != "" {
+		controller.ipfsClient, err = ipfs.NewClient(context.Background(), options.IPFS.Addr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	controller.resultsStorage, err = resultstorage.NewResultsStorage(options.Storage, controller.ipfsClient)
 	if err != nil {
 		return nil, err
 	}
+	controller.watchCheckpoints()
+	return controller, nil
+}
 
-	controller := &ResourceProviderController{
-		solverClient: solverClient,
-		options:      options,
-		web3SDK:      web3SDK,
-		web3Events:   web3.NewEventChannels(),
-		log:          system.NewServiceLogger(system.ResourceProviderService),
-		tracer:       tracer,
-		executor:     executor,
-		runningJobs:  map[string]bool{},
+func (controller *ResourceProviderController) watchCheckpoints() {
+	if checkpointingExecutor, ok := controller.executor.(executor.CheckpointingExecutor); ok {
+		checkpointingExecutor.SetCheckpointHandler(controller.recordCheckpoint)
+	}
+}
+
+// tell the solver where a running job has got to so the job creator
+// can start again from there if we go away
+func (controller *ResourceProviderController) recordCheckpoint(dealID string, cid string) {
+	controller.log.Info("uploaded checkpoint", fmt.Sprintf("%s %s", dealID, cid))
+	_, err := controller.solverClient.UpdateDealCheckpoint(dealID, cid)
+	if err != nil {
+		controller.log.Error("error recording checkpoint", err)
 	}
-	return controller, nil
 }
 
 /*
@@ -98,7 +180,8 @@ func NewResourceProviderController(
 func (controller *ResourceProviderController) subscribeToSolver() error {
 	controller.solverClient.SubscribeEvents(func(ev solver.SolverEvent) {
 		// we need to agree to the deal now we've heard about it
-		if ev.EventType == solver.DealAdded {
+		// or stop its job if it has been cancelled
+		if ev.EventType == solver.DealAdded || ev.EventType == solver.DealCancelled || ev.EventType == solver.DealPreempted {
 			if ev.Deal == nil {
 				controller.log.Error("solver event", fmt.Errorf("RP received nil deal"))
 				return
@@ -132,6 +215,8 @@ func (controller *ResourceProviderController) subscribeToWeb3() error {
 		system.DumpObjectDebug(ev)
 		controller.loop.Trigger()
 	})
+	controller.parameters.Subscribe(controller.web3SDK, controller.web3Events)
+	controller.getSlashingMonitor().Subscribe(controller.web3Events)
 	return nil
 }
 
@@ -152,7 +237,7 @@ func (controller *ResourceProviderController) Start(ctx context.Context, cm *sys
 		errorChan <- err
 		return errorChan
 	}
-	err = controller.web3Events.Start(controller.web3SDK, ctx, cm)
+	err = controller.web3SDK.StartEvents(controller.web3Events, ctx, cm)
 	if err != nil {
 		errorChan <- err
 		return errorChan
@@ -163,6 +248,9 @@ func (controller *ResourceProviderController) Start(ctx context.Context, cm *sys
 		ctx,
 		CONTROL_LOOP_INTERVAL,
 		func() error {
+			controller.checkHealth()
+			controller.checkUtilization()
+			controller.syncWithSolver()
 			err := controller.checkResourceoffers()
 			if err != nil {
 				errorChan <- err
@@ -200,14 +288,30 @@ func (controller *ResourceProviderController) solve(ctx context.Context) error {
 	controller.log.Debug("solving", "")
 
 	// if there are deals that have been matched and we have not agreed
-	// then we should agree to them
-	err := controller.agreeToDeals()
+	// then we should agree to them - unless we are paused in which case
+	// we leave them to time out rather than fail them mid-execution
+	// the same goes for when we are too busy to offer anything
+	if !controller.isPaused() && controller.getOfferLimit() != 0 {
+		err := controller.agreeToDeals()
+		if err != nil {
+			return err
+		}
+	}
+
+	// if there are jobs that have had both sides agree then we should run the job
+	err := controller.runJobs(ctx)
 	if err != nil {
 		return err
 	}
 
-	// if there are jobs that have had both sides agree then we should run the job
-	err = controller.runJobs(ctx)
+	// stop the jobs the job creators no longer want
+	err = controller.cancelJobs(ctx)
+	if err != nil {
+		return err
+	}
+
+	// fetch the inputs job creators want ready before they submit their jobs
+	err = controller.stageInputs(ctx)
 	if err != nil {
 		return err
 	}
@@ -215,6 +319,120 @@ func (controller *ResourceProviderController) solve(ctx context.Context) error {
 	return nil
 }
 
+/*
+ *
+ *
+ *
+
+ Health
+
+ *
+ *
+ *
+*/
+
+func (controller *ResourceProviderController) isPaused() bool {
+	controller.pausedMutex.RLock()
+	defer controller.pausedMutex.RUnlock()
+	return controller.paused
+}
+
+func (controller *ResourceProviderController) setPaused(paused bool) {
+	controller.pausedMutex.Lock()
+	defer controller.pausedMutex.Unlock()
+	controller.paused = paused
+}
+
+// pause when the machine crosses one of the health thresholds and
+// withdraw any offers that have not been matched yet
+// we resume automatically once the machine is healthy again
+func (controller *ResourceProviderController) checkHealth() {
+	status := checkHealth(controller.options.Health)
+	paused := controller.isPaused()
+
+	if !status.Healthy() && !paused {
+		controller.log.Info("pausing resource provider", status.String())
+		controller.setPaused(true)
+		controller.preemptSpotDeals(status.String())
+		_, err := controller.solverClient.WithdrawResourceOffers(controller.web3SDK.GetAddress().String())
+		if err != nil {
+			controller.log.Error("error withdrawing resource offers", err)
+		}
+		return
+	}
+
+	if status.Healthy() && paused {
+		controller.log.Info("resuming resource provider", status.String())
+		controller.setPaused(false)
+		// post our offers again on this loop iteration
+		lastResourceOfferPost = time.Time{}
+	}
+}
+
+func (controller *ResourceProviderController) getOfferLimit() int {
+	controller.offerLimitMutex.RLock()
+	defer controller.offerLimitMutex.RUnlock()
+	return controller.offerLimit
+}
+
+func (controller *ResourceProviderController) setOfferLimit(limit int) {
+	controller.offerLimitMutex.Lock()
+	defer controller.offerLimitMutex.Unlock()
+	controller.offerLimit = limit
+}
+
+// offer less of the machine when it is busy and more once it frees up
+// we withdraw our unmatched offers and post the ones we have room for
+// straight away but wait a few checks before offering more again
+func (controller *ResourceProviderController) checkUtilization() {
+	options := controller.options.Throttle
+	if options.MaxLoad <= 0 && options.MaxGPUMemory <= 0 && options.MaxDisk <= 0 {
+		return
+	}
+	if controller.isPaused() {
+		return
+	}
+
+	specs, err := controller.getMachineSpecs()
+	if err != nil {
+		controller.log.Error("error getting machine specs", err)
+		return
+	}
+	usage := readUtilization(options, controller.options.Health.DiskPath)
+	limit := getOfferLimit(len(specs), usage, options)
+	current := controller.getOfferLimit()
+	if current < 0 {
+		current = len(specs)
+	}
+
+	if limit < current {
+		controller.log.Info("throttling resource offers", fmt.Sprintf("offering %d of %d: %s", limit, len(specs), usage.String()))
+		controller.offerLimitRecoveries = 0
+		controller.setOfferLimit(limit)
+		_, err := controller.solverClient.WithdrawResourceOffers(controller.web3SDK.GetAddress().String())
+		if err != nil {
+			controller.log.Error("error withdrawing resource offers", err)
+		}
+		// post the offers we still have room for on this loop iteration
+		lastResourceOfferPost = time.Time{}
+		return
+	}
+
+	if limit > current {
+		controller.offerLimitRecoveries++
+		if controller.offerLimitRecoveries < UTILIZATION_RECOVERY_CHECKS {
+			return
+		}
+		controller.log.Info("un-throttling resource offers", fmt.Sprintf("offering %d of %d: %s", limit, len(specs), usage.String()))
+		controller.offerLimitRecoveries = 0
+		controller.setOfferLimit(limit)
+		lastResourceOfferPost = time.Time{}
+		return
+	}
+
+	controller.offerLimitRecoveries = 0
+}
+
 /*
  *
  *
@@ -232,23 +450,81 @@ Ensure resource offers are posted to the solver
 */
 
 func (controller *ResourceProviderController) getResourceOffer(index int, spec data.MachineSpec) data.ResourceOffer {
+	// the network wide timeouts and pricing floors take precedence
+	params := controller.parameters.Get()
+	modulePricing := map[string]data.DealPricing{}
+	for moduleID, pricing := range controller.options.Offers.ModulePricing {
+		modulePricing[moduleID] = params.ApplyPricing(pricing)
+	}
+	moduleTimeouts := map[string]data.DealTimeouts{}
+	for moduleID, timeouts := range controller.options.Offers.ModuleTimeouts {
+		moduleTimeouts[moduleID] = params.ApplyTimeouts(timeouts)
+	}
+	encryptionKey := ""
+	if controller.encryptionKey != nil {
+		encryptionKey = data.GetInputEncryptionPublicKey(controller.encryptionKey)
+	}
+	network, moduleNetwork := GetOfferNetworkPolicies(controller.options.Offers)
 	return data.ResourceOffer{
 		// assign CreatedAt to the current millisecond timestamp
 		CreatedAt:        int(time.Now().UnixNano() / int64(time.Millisecond)),
 		ResourceProvider: controller.web3SDK.GetAddress().String(),
+		ChainID:          controller.options.Web3.ChainID,
 		Index:            index,
 		Spec:             spec,
+		MaxInputSize:     controller.options.Offers.MaxInputSize,
 		Modules:          controller.options.Offers.Modules,
 		Mode:             controller.options.Offers.Mode,
-		DefaultPricing:   controller.options.Offers.DefaultPricing,
-		DefaultTimeouts:  controller.options.Offers.DefaultTimeouts,
-		ModulePricing:    map[string]data.DealPricing{},
-		ModuleTimeouts:   map[string]data.DealTimeouts{},
+		DefaultPricing:   params.ApplyPricing(controller.options.Offers.DefaultPricing),
+		DefaultTimeouts:  params.ApplyTimeouts(controller.options.Offers.DefaultTimeouts),
+		ModulePricing:    modulePricing,
+		ModuleTimeouts:   moduleTimeouts,
 		Services:         controller.options.Offers.Services,
+
+		AllowedJobCreators: controller.options.Offers.AllowedJobCreators,
+		Labels:             controller.options.Offers.Labels,
+		EncryptionKey:      encryptionKey,
+		TEE:                controller.teeAttestation,
+		Network:            network,
+		ModuleNetwork:      moduleNetwork,
+		PaymentTokens:      controller.options.Offers.PaymentTokens,
+		Class:              controller.options.Offers.Class,
+		PreemptionNotice:   GetOfferPreemptionNotice(controller.options.Offers),
+		ReservedDuration:   GetOfferReservedDuration(controller.options.Offers),
+		Client:             controller.getClientMetadata(),
+	}
+}
+
+// what we can do depends on our keys and the executor we were given
+func (controller *ResourceProviderController) getClientMetadata() *data.ClientMetadata {
+	capabilities := []string{data.CapabilityDeltaSync}
+	if controller.encryptionKey != nil {
+		capabilities = append(capabilities, data.CapabilityEncryptedInputs)
+	}
+	if _, ok := controller.executor.(executor.CheckpointingExecutor); ok {
+		capabilities = append(capabilities, data.CapabilityCheckpoints)
+	}
+	if _, ok := controller.executor.(executor.LogStreamingExecutor); ok {
+		capabilities = append(capabilities, data.CapabilityLogs)
+	}
+	if _, ok := controller.executor.(*wasm.WasmExecutor); ok {
+		capabilities = append(capabilities, data.CapabilityWasm)
+	}
+	if _, ok := controller.executor.(executor.MeteringExecutor); ok {
+		capabilities = append(capabilities, data.CapabilityMetering)
+	}
+	return &data.ClientMetadata{
+		Version:         system.Version,
+		ProtocolVersion: data.PROTOCOL_VERSION,
+		Capabilities:    capabilities,
 	}
 }
 
 func (controller *ResourceProviderController) checkResourceoffers() error {
+	if controller.isPaused() {
+		return nil
+	}
+
 	// We only want to run this every RESOURCE_OFFER_INTERVAL
 	if !lastResourceOfferPost.IsZero() && time.Since(lastResourceOfferPost) < RESOURCE_OFFER_INTERVAL {
 		return nil
@@ -265,10 +541,7 @@ func (controller *ResourceProviderController) checkResourceoffers() error {
 
 func (controller *ResourceProviderController) ensureResourceOffers() error {
 	// load the resource offers that are currently active and so should not be replaced
-	activeResourceOffers, err := controller.solverClient.GetResourceOffers(store.GetResourceOffersQuery{
-		ResourceProvider: controller.web3SDK.GetAddress().String(),
-		Active:           true,
-	})
+	activeResourceOffers, err := controller.getActiveResourceOffers()
 	if err != nil {
 		return err
 	}
@@ -284,13 +557,23 @@ func (controller *ResourceProviderController) ensureResourceOffers() error {
 
 	addResourceOffers := []data.ResourceOffer{}
 
-	// get the specs from our available compute node(s)
-	computeNodes, err := controller.executor.GetMachineSpecs()
+	computeNodes, err := controller.getMachineSpecs()
 	if err != nil {
 		controller.log.Error("error getting machine specs", err)
 		return err
 	}
 
+	// post nothing until the job queue has room
+	if controller.queue.full() {
+		return nil
+	}
+
+	// only offer what the machine has room for right now
+	limit := controller.getOfferLimit()
+	if limit >= 0 && limit < len(computeNodes) {
+		computeNodes = computeNodes[:limit]
+	}
+
 	// map over the specs we have
 	for index, spec := range computeNodes {
 
@@ -315,6 +598,15 @@ func (controller *ResourceProviderController) ensureResourceOffers() error {
 	return err
 }
 
+// get the specs from our available compute node(s)
+// unless we have probed the hardware ourselves
+func (controller *ResourceProviderController) getMachineSpecs() ([]data.MachineSpec, error) {
+	if controller.options.Offers.Autodetect {
+		return controller.options.Offers.Specs, nil
+	}
+	return controller.executor.GetMachineSpecs()
+}
+
 /*
  *
  *
@@ -330,14 +622,19 @@ func (controller *ResourceProviderController) ensureResourceOffers() error {
 // list the deals we have been assigned to that we have not yet posted and agree tx to the contract for
 func (controller *ResourceProviderController) agreeToDeals() error {
 	// load all deals that are in DealAgreed state and are for us
-	matchedDeals, err := controller.solverClient.GetDealsWithFilter(
+	matchedDeals, err := controller.getDealsWithFilter(
 		store.GetDealsQuery{
 			ResourceProvider: controller.web3SDK.GetAddress().String(),
 			State:            "DealNegotiating",
 		},
 		// if we have already submitted an agree tx then don't do it again
+		// and a cancelled deal is left to time out
+		// we wait for the job creator to send any encrypted inputs
 		func(dealContainer data.DealContainer) bool {
-			return dealContainer.Transactions.ResourceProvider.Agree == ""
+			if len(dealContainer.Deal.JobOffer.EncryptedInputs) > 0 && dealContainer.EncryptedInputs == nil {
+				return false
+			}
+			return dealContainer.Transactions.ResourceProvider.Agree == "" && dealContainer.CancelledAt == 0
 		},
 	)
 	if err != nil {
@@ -347,8 +644,22 @@ func (controller *ResourceProviderController) agreeToDeals() error {
 		return nil
 	}
 
+	// leave the deals we have no room for to time out
+	// rather than agree to more than we can run
+	room := controller.queue.room()
+	if room >= 0 && room < len(matchedDeals) {
+		controller.log.Info("job queue is full", fmt.Sprintf("agreeing to %d of %d deals", room, len(matchedDeals)))
+		matchedDeals = matchedDeals[:room]
+	}
+
 	// map over the deals and agree to them
 	for _, dealContainer := range matchedDeals {
+		// make sure we can read the inputs before we commit to the job
+		_, err := controller.getDealInputs(dealContainer)
+		if err != nil {
+			controller.log.Error("not agreeing to deal", err)
+			continue
+		}
 		controller.log.Info("agree", dealContainer)
 		txHash, err := controller.web3SDK.Agree(dealContainer.Deal)
 		if err != nil {
@@ -391,13 +702,15 @@ func (controller *ResourceProviderController) agreeToDeals() error {
 */
 
 func (controller *ResourceProviderController) runJobs(ctx context.Context) error {
-	agreedDeals, err := controller.solverClient.GetDealsWithFilter(
+	agreed := map[string]bool{}
+	agreedDeals, err := controller.getDealsWithFilter(
 		store.GetDealsQuery{
 			ResourceProvider: controller.web3SDK.GetAddress().String(),
 			State:            "DealAgreed",
 		},
 		// this is where the solver has found us a match and we need to agree to it
 		func(dealContainer data.DealContainer) bool {
+			agreed[dealContainer.ID] = true
 			controller.runningJobsMutex.RLock()
 			defer controller.runningJobsMutex.RUnlock()
 			_, ok := controller.runningJobs[dealContainer.ID]
@@ -407,31 +720,170 @@ func (controller *ResourceProviderController) runJobs(ctx context.Context) error
 	if err != nil {
 		return err
 	}
+	controller.pruneRunningJobs(agreed)
 
-	if len(agreedDeals) <= 0 {
-		return nil
-	}
-
-	// TODO - we are relying on the rate at which we post resource offers
-	// as our capacity manager right now
-	// this will work because we only post resource offers as fast we handle jobs
-	// but it would be worth putting some kind of queue here that is also aware
-	// of the underlying capacity of the machine
-
-	// map over the deals and run them
+	// put the deals in the queue and start the ones there is a slot for
+	wasFull := controller.queue.full()
 	for _, dealContainer := range agreedDeals {
 		func() {
 			controller.runningJobsMutex.Lock()
 			defer controller.runningJobsMutex.Unlock()
 			controller.runningJobs[dealContainer.ID] = true
 		}()
-
+		controller.queue.push(dealContainer)
+	}
+	for _, dealContainer := range controller.queue.next() {
 		go controller.runJob(ctx, dealContainer)
 	}
 
+	// stop the solver matching us with more deals until there is room
+	if !wasFull && controller.queue.full() {
+		controller.log.Info("job queue is full", "withdrawing resource offers")
+		_, err := controller.solverClient.WithdrawResourceOffers(controller.web3SDK.GetAddress().String())
+		if err != nil {
+			controller.log.Error("error withdrawing resource offers", err)
+		}
+	}
+
 	return err
 }
 
+// stop the jobs of deals the job creator has cancelled and of spot
+// deals we preempted once the notice is up
+// a job still waiting for a slot is taken out of the queue instead
+// either way runJob settles the deal by submitting an error result
+func (controller *ResourceProviderController) cancelJobs(ctx context.Context) error {
+	now := time.Now().Unix()
+	cancelledDeals, err := controller.getDealsWithFilter(
+		store.GetDealsQuery{
+			ResourceProvider: controller.web3SDK.GetAddress().String(),
+			State:            "DealAgreed",
+		},
+		func(dealContainer data.DealContainer) bool {
+			if dealContainer.CancelledAt == 0 && !data.IsPreemptionDue(dealContainer, now) {
+				return false
+			}
+			controller.runningJobsMutex.RLock()
+			defer controller.runningJobsMutex.RUnlock()
+			return controller.runningJobs[dealContainer.ID] && !controller.cancelledDeals[dealContainer.ID]
+		},
+	)
+	if err != nil {
+		return err
+	}
+	for _, dealContainer := range cancelledDeals {
+		// a job creator cancelling wins over our preemption
+		preempted := dealContainer.CancelledAt == 0
+		if preempted {
+			controller.log.Info("deal preempted", dealContainer.ID)
+		} else {
+			controller.log.Info("deal cancelled", dealContainer.ID)
+		}
+		func() {
+			controller.runningJobsMutex.Lock()
+			defer controller.runningJobsMutex.Unlock()
+			controller.cancelledDeals[dealContainer.ID] = true
+			controller.preemptedDeals[dealContainer.ID] = preempted
+		}()
+		if controller.queue.remove(dealContainer.ID) {
+			go controller.runJob(ctx, dealContainer)
+			continue
+		}
+		cancellableExecutor, ok := controller.executor.(executor.CancellableExecutor)
+		if !ok {
+			controller.log.Info("the executor cannot stop jobs", fmt.Sprintf("%s will run until it finishes", dealContainer.ID))
+			continue
+		}
+		err := cancellableExecutor.CancelJob(dealContainer.ID)
+		if err != nil {
+			controller.log.Error("error cancelling job", err)
+		}
+	}
+	return nil
+}
+
+// the job has stopped so there is nothing left to cancel or preempt
+// the deal stays in runningJobs while it is agreed so it is not run again
+func (controller *ResourceProviderController) forgetJob(dealID string) {
+	controller.runningJobsMutex.Lock()
+	defer controller.runningJobsMutex.Unlock()
+	controller.runningJobs[dealID] = false
+	delete(controller.cancelledDeals, dealID)
+	delete(controller.preemptedDeals, dealID)
+}
+
+// a stopped job whose deal has moved on from DealAgreed cannot be
+// picked up again so we no longer need to remember it
+func (controller *ResourceProviderController) pruneRunningJobs(agreed map[string]bool) {
+	controller.runningJobsMutex.Lock()
+	defer controller.runningJobsMutex.Unlock()
+	for dealID, running := range controller.runningJobs {
+		if !running && !agreed[dealID] {
+			delete(controller.runningJobs, dealID)
+		}
+	}
+}
+
+func (controller *ResourceProviderController) isCancelled(deal data.DealContainer) bool {
+	controller.runningJobsMutex.RLock()
+	defer controller.runningJobsMutex.RUnlock()
+	return deal.CancelledAt != 0 || controller.cancelledDeals[deal.ID]
+}
+
+func (controller *ResourceProviderController) isPreempted(deal data.DealContainer) bool {
+	controller.runningJobsMutex.RLock()
+	defer controller.runningJobsMutex.RUnlock()
+	return controller.preemptedDeals[deal.ID]
+}
+
+// run the job on the executor and send its output to the
+// solver as it runs if both we and the executor support it
+func (controller *ResourceProviderController) runExecutorJob(deal data.DealContainer, module data.Module) (*executor.ExecutorResults, error) {
+	streamingExecutor, ok := controller.executor.(executor.LogStreamingExecutor)
+	if !controller.options.Logs.Enabled || !ok {
+		return controller.executor.RunJob(deal, module)
+	}
+	forwarder := newDealLogForwarder(
+		deal.ID,
+		controller.solverClient,
+		controller.log,
+		time.Duration(controller.options.Logs.FlushInterval)*time.Millisecond,
+	)
+	defer forwarder.close()
+	return streamingExecutor.RunJobWithLogs(deal, module, forwarder.handle)
+}
+
+// the job offer inputs along with any the job creator sent encrypted
+// the decrypted inputs only ever go to the executor
+func (controller *ResourceProviderController) getDealInputs(deal data.DealContainer) (map[string]string, error) {
+	jobOffer := deal.Deal.JobOffer
+	if len(jobOffer.EncryptedInputs) == 0 {
+		return jobOffer.Inputs, nil
+	}
+	if controller.encryptionKey == nil {
+		return nil, fmt.Errorf("deal %s has encrypted inputs but we have no encryption key", deal.ID)
+	}
+	if deal.EncryptedInputs == nil {
+		return nil, fmt.Errorf("the job creator has not sent the encrypted inputs for deal %s", deal.ID)
+	}
+	encryptedInputs, err := data.DecryptDealInputs(controller.encryptionKey, *deal.EncryptedInputs)
+	if err != nil {
+		return nil, err
+	}
+	err = data.CheckEncryptedInputValues(jobOffer, encryptedInputs)
+	if err != nil {
+		return nil, err
+	}
+	inputs := map[string]string{}
+	for name, value := range jobOffer.Inputs {
+		inputs[name] = value
+	}
+	for name, value := range encryptedInputs {
+		inputs[name] = value
+	}
+	return inputs, nil
+}
+
 // this is run in it's own go-routine
 // we've already updated controller.runningJobs so we know this will only
 // run once
@@ -464,27 +916,93 @@ func (controller *ResourceProviderController) runJob(ctx context.Context, deal d
 		Error:  "",
 	}
 	err := func() error {
+		if controller.isCancelled(deal) {
+			span.AddEvent("job.cancelled")
+			return data.NewError(data.ErrJobCancelled, "job %s was cancelled by the job creator before it started", deal.ID)
+		}
 		controller.log.Info("loading module", "")
 		span.AddEvent("module.load")
-		module, err := module.LoadModule(deal.Deal.JobOffer.Module, deal.Deal.JobOffer.Inputs)
+		inputs, err := controller.getDealInputs(deal)
+		if err != nil {
+			return err
+		}
+		loadedModule, err := module.LoadModule(deal.Deal.JobOffer.Module, inputs)
 		if err != nil {
 			span.SetStatus(codes.Error, "load module failed")
 			span.RecordError(err)
-			return fmt.Errorf("error loading module: %s", err.Error())
+			return data.NewError(data.ErrModuleLoadFailed, "error loading module: %s", err.Error())
+		}
+		err = module.ApplyDealEnv(loadedModule, deal.ID, deal.Deal.JobOffer.Env, inputs)
+		if err != nil {
+			return err
 		}
-		controller.log.Info("module loaded", module)
+		controller.log.Info("module loaded", loadedModule)
 		span.AddEvent("module.loaded")
 
+		span.AddEvent("module.image.verify")
+		err = controller.verifyModuleImage(ctx, deal, loadedModule, &result)
+		if err != nil {
+			span.SetStatus(codes.Error, "verify module image failed")
+			span.RecordError(err)
+			return err
+		}
+		span.AddEvent("module.image.verified", trace.WithAttributes(attribute.String("image.verification", result.ImageVerification)))
+
+		if resumeFrom := deal.Deal.JobOffer.ResumeFrom; resumeFrom != "" {
+			if _, ok := controller.executor.(executor.CheckpointingExecutor); !ok {
+				return fmt.Errorf("the %s executor cannot resume jobs from checkpoint %s", controller.options.Executor.Type, resumeFrom)
+			}
+		}
+
+		if deal.Deal.JobOffer.Metered {
+			if _, ok := controller.executor.(executor.MeteringExecutor); !ok {
+				return fmt.Errorf("the %s executor cannot meter jobs", controller.options.Executor.Type)
+			}
+		}
+
+		var relay *serviceRelay
+		if data.IsServiceDeal(deal) {
+			serviceExecutor, ok := controller.executor.(executor.ServiceExecutor)
+			if !ok {
+				return fmt.Errorf("the %s executor cannot run service deals", controller.options.Executor.Type)
+			}
+			relay = newServiceRelay(deal.ID, controller.solverClient, serviceExecutor, controller.log)
+		}
+
 		span.AddEvent("executor.job.start")
-		executorResult, err := controller.executor.RunJob(deal, *module)
+		startedAt := time.Now()
+		executorResult, err := controller.runExecutorJob(deal, *loadedModule)
+		if relay != nil {
+			relay.close()
+		}
+		// the solver meters the deal from this so it is sent even if the job fails
+		result.Runtime = uint64(time.Since(startedAt).Milliseconds())
 		if err != nil {
 			controller.log.Error("error running job", err)
 			span.SetStatus(codes.Error, "job execution failed")
 			span.RecordError(err)
-			return fmt.Errorf("error running job: %s", err.Error())
+			return fmt.Errorf("error running job: %w", err)
 		}
 		result.InstructionCount = uint64(executorResult.InstructionCount)
+		if executorResult.Metering.Method != "" {
+			metering := executorResult.Metering
+			result.Metering = &metering
+		}
+		if deal.Deal.JobOffer.Metered {
+			// a metered deal is billed for what the executor measured
+			result.InstructionCount = data.GetMeteredInstructionCount(executorResult.Metering)
+		}
+		if relay != nil {
+			// a service deal is paid for the requests the solver saw answered
+			result.InstructionCount, err = controller.getServiceRequestCount(deal.ID)
+			if err != nil {
+				return fmt.Errorf("error getting service usage: %w", err)
+			}
+		}
 		result.DataID = executorResult.ResultsCID
+		if executorResult.VariantDigest != "" {
+			result.VariantDigest = executorResult.VariantDigest
+		}
 		controller.log.Info("got result", result)
 		span.AddEvent("executor.job.complete")
 
@@ -495,10 +1013,21 @@ func (controller *ResourceProviderController) runJob(ctx context.Context, deal d
 			controller.log.Debug("[debug] error uploading results. response was ", response)
 			span.SetStatus(codes.Error, "upload results failed")
 			span.RecordError(err)
-			return fmt.Errorf("error uploading results: %s", err.Error())
+			return data.NewError(data.ErrResultsUploadFailed, "error uploading results: %s", err.Error())
 		}
 		span.AddEvent("solver.files.uploaded", trace.WithAttributes(attribute.String("result.deal.id", result.DealID)))
 
+		// the solver has the results so a backend failing is not the job's fault
+		if len(controller.resultsStorage) > 0 {
+			span.AddEvent("storage.results.put")
+			locations, err := resultstorage.PutResults(ctx, controller.resultsStorage, deal.ID, executorResult.ResultsDir)
+			if err != nil {
+				controller.log.Error("error putting results in storage", err)
+			}
+			result.Locations = locations
+			span.AddEvent("storage.results.done")
+		}
+
 		return nil
 	}()
 
@@ -506,8 +1035,20 @@ func (controller *ResourceProviderController) runJob(ctx context.Context, deal d
 	// and we expect a mediator to get the same error
 	if err != nil {
 		result.Error = err.Error()
+		result.ErrorCode = data.GetErrorCodeOr(err, data.ErrJobFailed)
+	}
+	// we took the capacity back so the job creator knows not to hold it against us
+	if controller.isPreempted(deal) {
+		result.Preempted = true
+		result.Error = fmt.Sprintf("job %s was preempted by the resource provider", deal.ID)
+		result.ErrorCode = data.ErrPreempted
 	}
 
+	// the machine is free again so start whatever is waiting for a slot
+	controller.queue.done(deal.ID)
+	controller.forgetJob(deal.ID)
+	controller.loop.Trigger()
+
 	// the tarball of the results has been uploaded
 	// now let's post the result data itself to the solver
 	// then we will post the results on-chain
@@ -555,7 +1096,23 @@ func (controller *ResourceProviderController) runJob(ctx context.Context, deal d
 	}
 	span.AddEvent("solver.transaction_hash.added")
 
-	controller.ensureResourceOffers()
+	if !controller.isPaused() {
+		controller.ensureResourceOffers()
+	}
 
 	span.AddEvent("done")
 }
+
+// tells us when collateral we put up for a deal is slashed
+func (controller *ResourceProviderController) getSlashingMonitor() *alerts.SlashingMonitor {
+	var alerter *alerts.Alerter
+	if controller.options.Alerts.Enabled() {
+		alerter = alerts.NewAlerter(controller.options.Alerts)
+	}
+	return alerts.NewSlashingMonitor(
+		system.ResourceProviderService,
+		controller.web3SDK.GetAddress().String(),
+		controller.solverClient.GetDeal,
+		alerter,
+	)
+}
@@ -0,0 +1,121 @@
+package resourceprovider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/executor/mock_executor"
+	"github.com/lilypad-tech/lilypad/pkg/solver/mock_solver"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+	"github.com/lilypad-tech/lilypad/pkg/web3"
+	"github.com/lilypad-tech/lilypad/pkg/web3/mock_web3"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestController(t *testing.T) (*ResourceProviderController, *mock_web3.MockWeb3Client, *mock_solver.MockSolverAPI) {
+	ctrl := gomock.NewController(t)
+	web3SDK := mock_web3.NewMockWeb3Client(ctrl)
+	solverClient := mock_solver.NewMockSolverAPI(ctrl)
+	executor := mock_executor.NewMockExecutor(ctrl)
+
+	web3SDK.EXPECT().GetProtocolParameters().Return(web3.ProtocolParameters{}, nil)
+	web3SDK.EXPECT().GetAddress().Return(common.HexToAddress("0x1")).AnyTimes()
+
+	controller, err := NewResourceProviderController(
+		ResourceProviderOptions{},
+		web3SDK,
+		solverClient,
+		executor,
+		noop.NewTracerProvider().Tracer("test"),
+	)
+	assert.NoError(t, err)
+	return controller, web3SDK, solverClient
+}
+
+func TestAgreeToDeals(t *testing.T) {
+	controller, web3SDK, solverClient := newTestController(t)
+
+	deals := []data.DealContainer{
+		{ID: "deal1", Deal: data.Deal{ID: "deal1"}},
+		{ID: "deal2", Deal: data.Deal{ID: "deal2"}},
+	}
+	solverClient.EXPECT().GetDealsWithFilter(gomock.Any(), gomock.Any()).Return(deals, nil)
+
+	// the first agree tx fails so only the second deal is updated
+	web3SDK.EXPECT().Agree(deals[0].Deal).Return("", fmt.Errorf("agree failed"))
+	web3SDK.EXPECT().Agree(deals[1].Deal).Return("0xabc", nil)
+	solverClient.EXPECT().UpdateTransactionsResourceProvider("deal2", data.DealTransactionsResourceProvider{
+		Agree: "0xabc",
+	}).Return(deals[1], nil)
+
+	err := controller.agreeToDeals()
+	assert.NoError(t, err)
+}
+
+func TestForgetJob(t *testing.T) {
+	controller, _, _ := newTestController(t)
+	controller.runningJobs["deal1"] = true
+	controller.cancelledDeals["deal1"] = true
+	controller.preemptedDeals["deal1"] = true
+
+	controller.forgetJob("deal1")
+	running, ok := controller.runningJobs["deal1"]
+	assert.True(t, ok)
+	assert.False(t, running)
+	assert.Empty(t, controller.cancelledDeals)
+	assert.Empty(t, controller.preemptedDeals)
+
+	// it is kept until the deal is no longer agreed
+	controller.runningJobs["deal2"] = true
+	controller.pruneRunningJobs(map[string]bool{"deal1": true, "deal2": true})
+	assert.Contains(t, controller.runningJobs, "deal1")
+	controller.pruneRunningJobs(map[string]bool{})
+	assert.NotContains(t, controller.runningJobs, "deal1")
+	assert.Contains(t, controller.runningJobs, "deal2")
+}
+
+func TestSyncWithSolver(t *testing.T) {
+	controller, _, solverClient := newTestController(t)
+	address := common.HexToAddress("0x1").String()
+	negotiating := data.GetAgreementStateIndex("DealNegotiating")
+	agreed := data.GetAgreementStateIndex("DealAgreed")
+
+	solverClient.EXPECT().SyncResourceProvider(address, uint64(0), "").Return(data.ResourceProviderSync{
+		Version: 10,
+		Epoch:   "epoch",
+		Reset:   true,
+		Deals: []data.DealContainer{
+			{ID: "deal1", State: negotiating},
+			{ID: "deal2", State: negotiating},
+		},
+	}, nil)
+	controller.syncWithSolver()
+
+	// only what changed comes back and the rest is kept
+	solverClient.EXPECT().SyncResourceProvider(address, uint64(10), "epoch").Return(data.ResourceProviderSync{
+		Version:      12,
+		Epoch:        "epoch",
+		Deals:        []data.DealContainer{{ID: "deal1", State: agreed}},
+		RemovedDeals: []string{"deal2"},
+	}, nil)
+	controller.syncWithSolver()
+
+	all := func(data.DealContainer) bool { return true }
+	deals, err := controller.getDealsWithFilter(store.GetDealsQuery{State: "DealAgreed"}, all)
+	assert.NoError(t, err)
+	assert.Len(t, deals, 1)
+	deals, err = controller.getDealsWithFilter(store.GetDealsQuery{State: "DealNegotiating"}, all)
+	assert.NoError(t, err)
+	assert.Empty(t, deals)
+
+	// a solver that cannot sync is asked for each thing instead
+	solverClient.EXPECT().SyncResourceProvider(address, uint64(12), "epoch").Return(data.ResourceProviderSync{}, fmt.Errorf("not found"))
+	controller.syncWithSolver()
+	solverClient.EXPECT().GetDealsWithFilter(gomock.Any(), gomock.Any()).Return([]data.DealContainer{}, nil)
+	_, err = controller.getDealsWithFilter(store.GetDealsQuery{State: "DealAgreed"}, all)
+	assert.NoError(t, err)
+}
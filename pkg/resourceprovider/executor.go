@@ -0,0 +1,50 @@
+package resourceprovider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/executor"
+	"github.com/lilypad-tech/lilypad/pkg/executor/bacalhau"
+	"github.com/lilypad-tech/lilypad/pkg/executor/container"
+	"github.com/lilypad-tech/lilypad/pkg/executor/kubernetes"
+	"github.com/lilypad-tech/lilypad/pkg/executor/wasm"
+	"github.com/lilypad-tech/lilypad/pkg/ipfs"
+)
+
+const (
+	EXECUTOR_BACALHAU   = "bacalhau"
+	EXECUTOR_DOCKER     = container.RUNTIME_DOCKER
+	EXECUTOR_PODMAN     = container.RUNTIME_PODMAN
+	EXECUTOR_KUBERNETES = "kubernetes"
+	EXECUTOR_WASM       = data.ModuleEngineWasm
+)
+
+var ExecutorTypes = []string{EXECUTOR_BACALHAU, EXECUTOR_DOCKER, EXECUTOR_PODMAN, EXECUTOR_KUBERNETES, EXECUTOR_WASM}
+
+// create the executor the options ask for
+func NewExecutor(options ResourceProviderOptions, ipfsClient *ipfs.Client) (executor.Executor, error) {
+	switch options.Executor.Type {
+	case EXECUTOR_BACALHAU:
+		return bacalhau.NewBacalhauExecutor(options.Bacalhau, ipfsClient)
+	case EXECUTOR_DOCKER, EXECUTOR_PODMAN:
+		return container.NewContainerExecutor(container.ContainerExecutorOptions{
+			Runtime:            options.Executor.Type,
+			Sandbox:            options.Executor.Sandbox,
+			CheckpointInterval: time.Duration(options.Executor.CheckpointInterval) * time.Second,
+			Egress:             options.Executor.Egress,
+			Service:            options.Executor.Service,
+		}, ipfsClient)
+	case EXECUTOR_KUBERNETES:
+		kubernetesOptions := options.Executor.Kubernetes
+		kubernetesOptions.Sandbox = options.Executor.Sandbox
+		return kubernetes.NewKubernetesExecutor(kubernetesOptions, ipfsClient)
+	case EXECUTOR_WASM:
+		wasmOptions := options.Executor.Wasm
+		wasmOptions.Spec = DetectMachineSpec("")
+		return wasm.NewWasmExecutor(wasmOptions, ipfsClient)
+	default:
+		return nil, fmt.Errorf("unknown executor %s", options.Executor.Type)
+	}
+}
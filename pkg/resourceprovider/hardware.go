@@ -0,0 +1,134 @@
+package resourceprovider
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/rs/zerolog/log"
+)
+
+// the keys that can be given to override what we detected
+var machineSpecOverrideKeys = []string{"cpu", "gpu", "ram", "disk"}
+
+// probe the machine we are running on for the spec we can offer
+// anything we are unable to read is left at zero and logged so that it
+// can be set by hand with an override
+func DetectMachineSpec(diskPath string) data.MachineSpec {
+	spec := data.MachineSpec{
+		CPU:  runtime.NumCPU() * 1000, //nolint:gomnd
+		GPUs: []data.GPUSpec{},
+	}
+
+	ram, err := getTotalRAM()
+	if err != nil {
+		log.Warn().Msgf("unable to detect memory: %s", err.Error())
+	}
+	spec.RAM = ram
+
+	if diskPath != "" {
+		disk, err := getFreeDisk(diskPath)
+		if err != nil {
+			log.Warn().Msgf("unable to detect free disk at %s: %s", diskPath, err.Error())
+		}
+		spec.Disk = disk
+	}
+
+	gpus, err := getNvidiaGPUs()
+	if err != nil {
+		log.Debug().Msgf("no nvidia gpus detected: %s", err.Error())
+	}
+	spec.GPUs = append(spec.GPUs, gpus...)
+	spec.GPU = len(spec.GPUs) * 1000 //nolint:gomnd
+
+	return spec
+}
+
+// nvidia-smi reads the cards through NVML so we get the same names and
+// memory sizes the driver reports without linking against it
+func getNvidiaGPUs() ([]data.GPUSpec, error) {
+	output, err := exec.Command(
+		"nvidia-smi",
+		"--query-gpu=name,memory.total",
+		"--format=csv,noheader,nounits",
+	).Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseNvidiaGPUs(string(output))
+}
+
+func parseNvidiaGPUs(output string) ([]data.GPUSpec, error) {
+	gpus := []data.GPUSpec{}
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		index := strings.LastIndex(line, ",")
+		if index < 0 {
+			return nil, fmt.Errorf("error parsing gpu %q: expected name and memory", line)
+		}
+		vram, err := strconv.Atoi(strings.TrimSpace(line[index+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing gpu memory %q: %s", line, err.Error())
+		}
+		gpus = append(gpus, data.GPUSpec{
+			Name:   strings.TrimSpace(line[:index]),
+			Vendor: "NVIDIA",
+			VRAM:   vram,
+		})
+	}
+	return gpus, nil
+}
+
+// check that overrides only name fields we know with whole numbers
+func CheckMachineSpecOverrides(overrides map[string]string) error {
+	for key, value := range overrides {
+		known := false
+		for _, overrideKey := range machineSpecOverrideKeys {
+			if key == overrideKey {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown spec override %q, expected one of %s", key, strings.Join(machineSpecOverrideKeys, ", "))
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil || number < 0 {
+			return fmt.Errorf("spec override %s=%s must be a whole number", key, value)
+		}
+	}
+	return nil
+}
+
+// replace detected values with the ones given by hand
+// a gpu override of zero also drops the detected cards so that
+// a machine can keep its GPUs to itself
+func ApplyMachineSpecOverrides(spec data.MachineSpec, overrides map[string]string) (data.MachineSpec, error) {
+	err := CheckMachineSpecOverrides(overrides)
+	if err != nil {
+		return spec, err
+	}
+	for key, value := range overrides {
+		number, _ := strconv.Atoi(value)
+		switch key {
+		case "cpu":
+			spec.CPU = number
+		case "gpu":
+			spec.GPU = number
+			if number == 0 {
+				spec.GPUs = []data.GPUSpec{}
+			}
+		case "ram":
+			spec.RAM = number
+		case "disk":
+			spec.Disk = number
+		}
+	}
+	return spec, nil
+}
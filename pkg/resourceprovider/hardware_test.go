@@ -0,0 +1,56 @@
+package resourceprovider
+
+import (
+	"testing"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNvidiaGPUs(t *testing.T) {
+	gpus, err := parseNvidiaGPUs("NVIDIA GeForce RTX 4090, 24564\nNVIDIA A100-SXM4-80GB, 81920\n")
+	require.NoError(t, err)
+	assert.Equal(t, []data.GPUSpec{
+		{Name: "NVIDIA GeForce RTX 4090", Vendor: "NVIDIA", VRAM: 24564},
+		{Name: "NVIDIA A100-SXM4-80GB", Vendor: "NVIDIA", VRAM: 81920},
+	}, gpus)
+
+	_, err = parseNvidiaGPUs("NVIDIA GeForce RTX 4090, [N/A]")
+	assert.Error(t, err)
+}
+
+func TestApplyMachineSpecOverrides(t *testing.T) {
+	detected := data.MachineSpec{
+		CPU:  8000,
+		GPU:  1000,
+		GPUs: []data.GPUSpec{{Name: "NVIDIA GeForce RTX 4090", Vendor: "NVIDIA", VRAM: 24564}},
+		RAM:  32000,
+		Disk: 500000,
+	}
+
+	testCases := []struct {
+		name      string
+		overrides map[string]string
+		expected  data.MachineSpec
+		expectErr bool
+	}{
+		{name: "No overrides", overrides: map[string]string{}, expected: detected},
+		{name: "Less RAM", overrides: map[string]string{"ram": "16000"}, expected: data.MachineSpec{CPU: 8000, GPU: 1000, GPUs: detected.GPUs, RAM: 16000, Disk: 500000}},
+		{name: "Keep the GPU", overrides: map[string]string{"gpu": "0"}, expected: data.MachineSpec{CPU: 8000, GPUs: []data.GPUSpec{}, RAM: 32000, Disk: 500000}},
+		{name: "Unknown key", overrides: map[string]string{"tpu": "1"}, expectErr: true},
+		{name: "Negative value", overrides: map[string]string{"cpu": "-1"}, expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spec, err := ApplyMachineSpecOverrides(detected, tc.overrides)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, spec)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package resourceprovider
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// the result of checking the local machine against the health thresholds
+// if there are any reasons then we are not healthy
+type healthStatus struct {
+	Reasons []string
+}
+
+func (status healthStatus) Healthy() bool {
+	return len(status.Reasons) == 0
+}
+
+func (status healthStatus) String() string {
+	if status.Healthy() {
+		return "healthy"
+	}
+	return strings.Join(status.Reasons, ", ")
+}
+
+// check free disk, free memory and gpu temperature against the configured thresholds
+// if we are unable to read a value then we skip that check rather than
+// pausing the provider because of a missing tool or unsupported platform
+func checkHealth(options ResourceProviderHealthOptions) healthStatus {
+	status := healthStatus{Reasons: []string{}}
+
+	if options.MinFreeDisk > 0 {
+		freeDisk, err := getFreeDisk(options.DiskPath)
+		if err == nil && freeDisk < options.MinFreeDisk {
+			status.Reasons = append(status.Reasons, fmt.Sprintf("free disk %dMB is below %dMB", freeDisk, options.MinFreeDisk))
+		}
+	}
+
+	if options.MinFreeRAM > 0 {
+		freeRAM, err := getFreeRAM()
+		if err == nil && freeRAM < options.MinFreeRAM {
+			status.Reasons = append(status.Reasons, fmt.Sprintf("free memory %dMB is below %dMB", freeRAM, options.MinFreeRAM))
+		}
+	}
+
+	if options.MaxGPUTemperature > 0 {
+		temperatures, err := getGPUTemperatures()
+		if err == nil {
+			for index, temperature := range temperatures {
+				if temperature > options.MaxGPUTemperature {
+					status.Reasons = append(status.Reasons, fmt.Sprintf("gpu %d temperature %dC is above %dC", index, temperature, options.MaxGPUTemperature))
+				}
+			}
+		}
+	}
+
+	return status
+}
+
+// we only know how to read temperatures from nvidia cards for now
+func getGPUTemperatures() ([]int, error) {
+	output, err := exec.Command(
+		"nvidia-smi",
+		"--query-gpu=temperature.gpu",
+		"--format=csv,noheader,nounits",
+	).Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseGPUTemperatures(string(output))
+}
+
+func parseGPUTemperatures(output string) ([]int, error) {
+	temperatures := []int{}
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		temperature, err := strconv.Atoi(line)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing gpu temperature %q: %s", line, err.Error())
+		}
+		temperatures = append(temperatures, temperature)
+	}
+	return temperatures, nil
+}
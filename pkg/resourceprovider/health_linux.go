@@ -0,0 +1,87 @@
+//go:build linux
+// +build linux
+
+package resourceprovider
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// returns the free space in megabytes for the filesystem the path lives on
+func getFreeDisk(path string) (int, error) {
+	var stat syscall.Statfs_t
+	err := syscall.Statfs(path, &stat)
+	if err != nil {
+		return 0, err
+	}
+	return int(stat.Bavail * uint64(stat.Bsize) / 1024 / 1024), nil
+}
+
+// returns how much of the filesystem the path lives on is used as a percentage
+func getDiskUsed(path string) (float64, error) {
+	var stat syscall.Statfs_t
+	err := syscall.Statfs(path, &stat)
+	if err != nil {
+		return 0, err
+	}
+	if stat.Blocks == 0 {
+		return 0, fmt.Errorf("filesystem at %s has no blocks", path)
+	}
+	return float64(stat.Blocks-stat.Bfree) * 100 / float64(stat.Blocks), nil //nolint:gomnd
+}
+
+// returns the one minute load average as a percentage of the cores we have
+func getLoad() (float64, error) {
+	content, err := os.ReadFile("/proc/loadavg")
+	if err != nil {
+		return 0, err
+	}
+	fields := strings.Fields(string(content))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("/proc/loadavg is empty")
+	}
+	load, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, err
+	}
+	return load * 100 / float64(runtime.NumCPU()), nil //nolint:gomnd
+}
+
+// returns the available memory in megabytes
+func getFreeRAM() (int, error) {
+	return readMeminfo("MemAvailable:")
+}
+
+// returns the installed memory in megabytes
+func getTotalRAM() (int, error) {
+	return readMeminfo("MemTotal:")
+}
+
+// returns a field of /proc/meminfo in megabytes
+func readMeminfo(field string) (int, error) {
+	file, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != field {
+			continue
+		}
+		kb, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return 0, err
+		}
+		return kb / 1024, nil
+	}
+	return 0, fmt.Errorf("%s not found in /proc/meminfo", strings.TrimSuffix(field, ":"))
+}
@@ -0,0 +1,26 @@
+//go:build !linux
+// +build !linux
+
+package resourceprovider
+
+import "fmt"
+
+func getFreeDisk(path string) (int, error) {
+	return 0, fmt.Errorf("free disk check is not supported on this platform")
+}
+
+func getFreeRAM() (int, error) {
+	return 0, fmt.Errorf("free memory check is not supported on this platform")
+}
+
+func getTotalRAM() (int, error) {
+	return 0, fmt.Errorf("total memory check is not supported on this platform")
+}
+
+func getDiskUsed(path string) (float64, error) {
+	return 0, fmt.Errorf("disk usage check is not supported on this platform")
+}
+
+func getLoad() (float64, error) {
+	return 0, fmt.Errorf("load check is not supported on this platform")
+}
@@ -0,0 +1,107 @@
+package resourceprovider
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/executor"
+	"github.com/lilypad-tech/lilypad/pkg/module"
+)
+
+// make sure the module runs the image the deal was made for
+// the variant is chosen here rather than by the executor so the image
+// it picks is pinned and checked like any other
+// what we checked is recorded on the result
+func (controller *ResourceProviderController) verifyModuleImage(
+	ctx context.Context,
+	deal data.DealContainer,
+	loadedModule *data.Module,
+	result *data.Result,
+) error {
+	if module.GetModuleEngine(*loadedModule) == data.ModuleEngineWasm {
+		return controller.verifyWasmModule(deal, *loadedModule, result)
+	}
+	expectedDigest := deal.Deal.JobOffer.Module.ImageDigest
+	if len(loadedModule.Variants) > 0 {
+		if expectedDigest != "" {
+			loadedModule.Variants = module.FilterVariantsByDigest(loadedModule.Variants, expectedDigest)
+			if len(loadedModule.Variants) == 0 {
+				return fmt.Errorf("module has no variant with image %s", expectedDigest)
+			}
+		}
+		variantDigest, err := executor.ApplyModuleVariant(controller.executor, loadedModule)
+		if err != nil {
+			return err
+		}
+		loadedModule.Variants = nil
+		result.VariantDigest = variantDigest
+	}
+
+	image := module.GetModuleImage(*loadedModule)
+	if expectedDigest != "" {
+		pinned, err := module.PinImageDigest(image, expectedDigest)
+		if err != nil {
+			return err
+		}
+		module.SetModuleImage(loadedModule, pinned)
+		image = pinned
+	}
+
+	digest := module.GetImageDigest(image)
+	if digest == "" {
+		if controller.options.Image.RequireDigest {
+			return fmt.Errorf("module image %s is not pinned by digest", image)
+		}
+		result.ImageVerification = data.ImageVerificationUnverified
+		return nil
+	}
+	result.ImageDigest = digest
+	result.ImageVerification = data.ImageVerificationDigest
+
+	if controller.options.Image.CosignKey != "" {
+		err := verifyImageSignature(ctx, image, controller.options.Image.CosignKey)
+		if err != nil {
+			return err
+		}
+		result.ImageVerification = data.ImageVerificationSigned
+	}
+	return nil
+}
+
+// wasm modules have no image, a module fetched by CID is pinned by it
+// and one fetched by URL could be anything
+func (controller *ResourceProviderController) verifyWasmModule(
+	deal data.DealContainer,
+	loadedModule data.Module,
+	result *data.Result,
+) error {
+	if deal.Deal.JobOffer.Module.ImageDigest != "" {
+		return fmt.Errorf("wasm modules have no image to pin to %s", deal.Deal.JobOffer.Module.ImageDigest)
+	}
+	wasm, err := module.GetWasmSpec(loadedModule.Job.Spec)
+	if err != nil {
+		return err
+	}
+	if wasm.EntryModule.CID == "" {
+		if controller.options.Image.RequireDigest {
+			return fmt.Errorf("wasm module %s is not pinned by CID", wasm.EntryModule.URL)
+		}
+		result.ImageVerification = data.ImageVerificationUnverified
+		return nil
+	}
+	result.ImageDigest = wasm.EntryModule.CID
+	result.ImageVerification = data.ImageVerificationDigest
+	return nil
+}
+
+// cosign checks the signature is for this exact digest
+func verifyImageSignature(ctx context.Context, image string, key string) error {
+	output, err := exec.CommandContext(ctx, "cosign", "verify", "--key", key, image).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("module image %s signature check failed: %s %s", image, err.Error(), strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package resourceprovider
+
+import (
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+)
+
+// the most chunks we hold on to whilst the solver is slow to take them
+// past this the oldest output is dropped rather than using up memory
+const LOG_FORWARDER_MAX_PENDING = 256
+
+// collects the output of a running job and posts it to the solver
+// in batches so a chatty job does not mean a request per line
+type dealLogForwarder struct {
+	dealID       string
+	solverClient solver.SolverAPI
+	log          *system.ServiceLogger
+	mutex        sync.Mutex
+	pending      []data.DealLogChunk
+	// the start of a character that was split across two reads
+	partial map[string][]byte
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+func newDealLogForwarder(
+	dealID string,
+	solverClient solver.SolverAPI,
+	log *system.ServiceLogger,
+	interval time.Duration,
+) *dealLogForwarder {
+	forwarder := &dealLogForwarder{
+		dealID:       dealID,
+		solverClient: solverClient,
+		log:          log,
+		pending:      []data.DealLogChunk{},
+		partial:      map[string][]byte{},
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+	go func() {
+		defer close(forwarder.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-forwarder.stop:
+				forwarder.flush()
+				return
+			case <-ticker.C:
+				forwarder.flush()
+			}
+		}
+	}()
+	return forwarder
+}
+
+// split output into chunks the solver will take without breaking
+// a character in two, any incomplete character at the end is returned
+// so it can be joined to the next piece of output
+func splitLogOutput(output []byte) ([]string, []byte) {
+	// find the start of the last character and hold it back if it is cut off
+	end := len(output)
+	for i := len(output) - 1; i >= 0 && i >= len(output)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(output[i]) {
+			if !utf8.FullRune(output[i:]) {
+				end = i
+			}
+			break
+		}
+	}
+	rest := output[end:]
+	output = output[:end]
+
+	chunks := []string{}
+	for len(output) > 0 {
+		size := len(output)
+		if size > data.DEAL_LOG_MAX_CHUNK_SIZE {
+			size = data.DEAL_LOG_MAX_CHUNK_SIZE
+			for size > 0 && !utf8.RuneStart(output[size]) {
+				size--
+			}
+			// not utf-8 so just cut it at the limit
+			if size == 0 {
+				size = data.DEAL_LOG_MAX_CHUNK_SIZE
+			}
+		}
+		chunks = append(chunks, string(output[:size]))
+		output = output[size:]
+	}
+	return chunks, rest
+}
+
+// this is the executor.LogHandler for the job
+func (forwarder *dealLogForwarder) handle(stream string, output []byte) {
+	forwarder.mutex.Lock()
+	defer forwarder.mutex.Unlock()
+
+	output = append(forwarder.partial[stream], output...)
+	chunks, rest := splitLogOutput(output)
+	forwarder.partial[stream] = rest
+	for _, chunk := range chunks {
+		forwarder.pending = append(forwarder.pending, data.DealLogChunk{
+			Stream: stream,
+			Data:   chunk,
+		})
+	}
+	if len(forwarder.pending) > LOG_FORWARDER_MAX_PENDING {
+		forwarder.pending = forwarder.pending[len(forwarder.pending)-LOG_FORWARDER_MAX_PENDING:]
+	}
+}
+
+// the logs are only there to watch the job so if the solver
+// will not take them we drop them rather than retrying
+func (forwarder *dealLogForwarder) flush() {
+	forwarder.mutex.Lock()
+	chunks := forwarder.pending
+	forwarder.pending = []data.DealLogChunk{}
+	forwarder.mutex.Unlock()
+
+	if len(chunks) == 0 {
+		return
+	}
+	_, err := forwarder.solverClient.AddDealLogs(forwarder.dealID, chunks)
+	if err != nil {
+		forwarder.log.Error("error sending logs for deal "+forwarder.dealID, err)
+	}
+}
+
+// send whatever is left and stop
+func (forwarder *dealLogForwarder) close() {
+	close(forwarder.stop)
+	<-forwarder.done
+}
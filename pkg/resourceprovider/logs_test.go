@@ -0,0 +1,33 @@
+package resourceprovider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitLogOutput(t *testing.T) {
+	euro := []byte("€")
+	long := strings.Repeat("x", data.DEAL_LOG_MAX_CHUNK_SIZE-1) + "€"
+
+	testCases := []struct {
+		name           string
+		output         []byte
+		expectedChunks []string
+		expectedRest   []byte
+	}{
+		{name: "Plain output", output: []byte("hello\n"), expectedChunks: []string{"hello\n"}, expectedRest: []byte{}},
+		{name: "Character cut off", output: append([]byte("a"), euro[:2]...), expectedChunks: []string{"a"}, expectedRest: euro[:2]},
+		{name: "Split on a character", output: []byte(long), expectedChunks: []string{long[:data.DEAL_LOG_MAX_CHUNK_SIZE-1], "€"}, expectedRest: []byte{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			chunks, rest := splitLogOutput(tc.output)
+			assert.Equal(t, tc.expectedChunks, chunks)
+			assert.Equal(t, tc.expectedRest, rest)
+		})
+	}
+}
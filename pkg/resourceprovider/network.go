@@ -0,0 +1,62 @@
+package resourceprovider
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+func getNetworkPolicy(mode string, allow []string) data.NetworkPolicy {
+	policy := data.NetworkPolicy{Mode: mode}
+	if mode == data.NetworkPolicyEgressAllowlist {
+		policy.Allow = allow
+	}
+	return policy
+}
+
+// the network access our resource offers give jobs by default and for each module
+func GetOfferNetworkPolicies(options ResourceProviderOfferOptions) (*data.NetworkPolicy, map[string]data.NetworkPolicy) {
+	var moduleNetwork map[string]data.NetworkPolicy
+	for moduleID, mode := range options.NetworkModules {
+		if moduleNetwork == nil {
+			moduleNetwork = map[string]data.NetworkPolicy{}
+		}
+		moduleNetwork[moduleID] = getNetworkPolicy(mode, options.NetworkAllow)
+	}
+	if options.Network == "" {
+		return nil, moduleNetwork
+	}
+	policy := getNetworkPolicy(options.Network, options.NetworkAllow)
+	return &policy, moduleNetwork
+}
+
+// we only offer what our executor can hold jobs to
+func CheckNetworkPolicySupported(policy data.NetworkPolicy, options ResourceProviderExecutorOptions) error {
+	switch options.Type {
+	case EXECUTOR_BACALHAU:
+		for _, entry := range policy.Allow {
+			if net.ParseIP(entry) != nil || strings.Contains(entry, "/") {
+				return fmt.Errorf("the bacalhau executor can only allow domains not %s", entry)
+			}
+		}
+	case EXECUTOR_DOCKER, EXECUTOR_PODMAN:
+		if policy.Mode == data.NetworkPolicyEgressAllowlist && !options.Egress.Enabled() {
+			return fmt.Errorf("the %s executor needs EGRESS_NETWORK, EGRESS_PROXY_ADDRESS and EGRESS_PROXY_URL for the %s network policy", options.Type, policy.Mode)
+		}
+	case EXECUTOR_KUBERNETES:
+		if policy.Mode == data.NetworkPolicyFull {
+			return nil
+		}
+		if !options.Kubernetes.NetworkPolicies {
+			return fmt.Errorf("the kubernetes executor needs KUBERNETES_NETWORK_POLICIES for the %s network policy", policy.Mode)
+		}
+		for _, entry := range policy.Allow {
+			if net.ParseIP(entry) == nil && !strings.Contains(entry, "/") {
+				return fmt.Errorf("the kubernetes executor can only allow IPs and CIDRs not %s", entry)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package resourceprovider
+
+import (
+	"fmt"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+)
+
+// only spot offers give notice, reserved offers are never preempted
+func GetOfferPreemptionNotice(options ResourceProviderOfferOptions) int {
+	if options.Class != data.OfferClassSpot {
+		return 0
+	}
+	return options.PreemptionNotice
+}
+
+// only reserved offers promise a duration
+func GetOfferReservedDuration(options ResourceProviderOfferOptions) int {
+	if options.Class == data.OfferClassSpot {
+		return 0
+	}
+	return options.ReservedDuration
+}
+
+// take back the capacity of our spot deals that are running or waiting
+// for a slot, the jobs keep going until the notice in the offer is up
+// and cancelJobs then stops them
+func (controller *ResourceProviderController) preemptSpotDeals(reason string) {
+	deals, err := controller.solverClient.GetDealsWithFilter(
+		store.GetDealsQuery{
+			ResourceProvider: controller.web3SDK.GetAddress().String(),
+			State:            "DealAgreed",
+		},
+		func(dealContainer data.DealContainer) bool {
+			if !data.IsSpotDeal(dealContainer) || dealContainer.Preemption != nil || dealContainer.CancelledAt != 0 {
+				return false
+			}
+			controller.runningJobsMutex.RLock()
+			defer controller.runningJobsMutex.RUnlock()
+			return controller.runningJobs[dealContainer.ID]
+		},
+	)
+	if err != nil {
+		controller.log.Error("error listing spot deals to preempt", err)
+		return
+	}
+	for _, dealContainer := range deals {
+		_, err := controller.solverClient.PreemptDeal(dealContainer.ID, reason)
+		if err != nil {
+			controller.log.Error("error preempting deal", fmt.Errorf("%s: %s", dealContainer.ID, err.Error()))
+			continue
+		}
+		controller.log.Info("preempted deal", fmt.Sprintf("%s stops in %ds", dealContainer.ID, dealContainer.Deal.ResourceOffer.PreemptionNotice))
+	}
+}
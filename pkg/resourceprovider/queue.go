@@ -0,0 +1,117 @@
+package resourceprovider
+
+import (
+	"sync"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+// decides which of the deals we have agreed to run now and which wait
+// for a slot so that we never run more at once than the machine can take
+type jobQueue struct {
+	mutex   sync.Mutex
+	options ResourceProviderQueueOptions
+	// deals waiting for a slot in the order they were agreed
+	queued []queuedDeal
+	// deal id -> module id of the deals that are running
+	running map[string]string
+	// module id -> how many of its deals are running
+	runningModules map[string]int
+}
+
+type queuedDeal struct {
+	deal     data.DealContainer
+	moduleID string
+}
+
+func newJobQueue(options ResourceProviderQueueOptions) *jobQueue {
+	return &jobQueue{
+		options:        options,
+		queued:         []queuedDeal{},
+		running:        map[string]string{},
+		runningModules: map[string]int{},
+	}
+}
+
+func (queue *jobQueue) push(deal data.DealContainer) {
+	// a module we cannot identify only counts towards the overall limit
+	moduleID, _ := data.GetModuleID(deal.Deal.JobOffer.Module)
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	queue.queued = append(queue.queued, queuedDeal{deal: deal, moduleID: moduleID})
+}
+
+// take the deals that can start now and count them as running
+// a deal whose module is at its limit does not hold up the ones behind it
+func (queue *jobQueue) next() []data.DealContainer {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	start := []data.DealContainer{}
+	waiting := []queuedDeal{}
+	for _, item := range queue.queued {
+		if !queue.canStart(item.moduleID) {
+			waiting = append(waiting, item)
+			continue
+		}
+		queue.running[item.deal.ID] = item.moduleID
+		queue.runningModules[item.moduleID]++
+		start = append(start, item.deal)
+	}
+	queue.queued = waiting
+	return start
+}
+
+func (queue *jobQueue) canStart(moduleID string) bool {
+	if queue.options.MaxConcurrentDeals > 0 && len(queue.running) >= queue.options.MaxConcurrentDeals {
+		return false
+	}
+	limit, ok := queue.options.ModuleConcurrency[moduleID]
+	return !ok || queue.runningModules[moduleID] < limit
+}
+
+// take a deal out of the queue before it starts
+// false means it was not waiting, it may be running already
+func (queue *jobQueue) remove(dealID string) bool {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	for index, item := range queue.queued {
+		if item.deal.ID == dealID {
+			queue.queued = append(queue.queued[:index], queue.queued[index+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (queue *jobQueue) done(dealID string) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	moduleID, ok := queue.running[dealID]
+	if !ok {
+		return
+	}
+	delete(queue.running, dealID)
+	queue.runningModules[moduleID]--
+	if queue.runningModules[moduleID] <= 0 {
+		delete(queue.runningModules, moduleID)
+	}
+}
+
+// how many more deals we can take on before we have to say no
+// -1 means there is no limit
+func (queue *jobQueue) room() int {
+	if queue.options.MaxConcurrentDeals <= 0 {
+		return -1
+	}
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+	room := queue.options.MaxConcurrentDeals + queue.options.MaxQueuedDeals - len(queue.running) - len(queue.queued)
+	if room < 0 {
+		return 0
+	}
+	return room
+}
+
+func (queue *jobQueue) full() bool {
+	return queue.room() == 0
+}
@@ -0,0 +1,64 @@
+package resourceprovider
+
+import (
+	"testing"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobQueue(t *testing.T) {
+	diffusion := data.ModuleConfig{Name: "stable-diffusion", Repo: "https://github.com/lilypad-tech/lilypad-module-sdxl", Hash: "v0.9.7"}
+	cowsay := data.ModuleConfig{Name: "cowsay", Repo: "https://github.com/lilypad-tech/lilypad-module-cowsay", Hash: "v0.0.4"}
+	diffusionID, err := data.GetModuleID(diffusion)
+	require.NoError(t, err)
+
+	queue := newJobQueue(ResourceProviderQueueOptions{
+		MaxConcurrentDeals: 3,
+		MaxQueuedDeals:     2,
+		ModuleConcurrency:  map[string]int{diffusionID: 1},
+	})
+	deal := func(id string, module data.ModuleConfig) data.DealContainer {
+		return data.DealContainer{ID: id, Deal: data.Deal{JobOffer: data.JobOffer{Module: module}}}
+	}
+	ids := func(deals []data.DealContainer) []string {
+		result := []string{}
+		for _, deal := range deals {
+			result = append(result, deal.ID)
+		}
+		return result
+	}
+
+	assert.Equal(t, 5, queue.room())
+	queue.push(deal("sd1", diffusion))
+	queue.push(deal("sd2", diffusion))
+	queue.push(deal("cow1", cowsay))
+	queue.push(deal("cow2", cowsay))
+	queue.push(deal("cow3", cowsay))
+	assert.True(t, queue.full())
+
+	// only one diffusion job runs and it does not hold up the rest
+	assert.Equal(t, []string{"sd1", "cow1", "cow2"}, ids(queue.next()))
+	assert.Empty(t, queue.next())
+	assert.Equal(t, 0, queue.room())
+
+	queue.done("cow1")
+	assert.Equal(t, []string{"cow3"}, ids(queue.next()))
+	queue.done("sd1")
+	assert.Equal(t, []string{"sd2"}, ids(queue.next()))
+	queue.done("cow2")
+	assert.Empty(t, queue.next())
+	assert.Equal(t, 3, queue.room())
+
+	// a cancelled deal leaves the queue before it starts
+	queue.push(deal("sd3", diffusion))
+	assert.True(t, queue.remove("sd3"))
+	assert.False(t, queue.remove("sd2"))
+	queue.done("sd2")
+	assert.Empty(t, queue.next())
+
+	unlimited := newJobQueue(ResourceProviderQueueOptions{})
+	assert.Equal(t, -1, unlimited.room())
+	assert.False(t, unlimited.full())
+}
@@ -9,14 +9,23 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/google/uuid"
 	"github.com/holiman/uint256"
+	"github.com/lilypad-tech/lilypad/pkg/alerts"
+	"github.com/lilypad-tech/lilypad/pkg/bus"
 	"github.com/lilypad-tech/lilypad/pkg/data"
 	"github.com/lilypad-tech/lilypad/pkg/executor"
 	"github.com/lilypad-tech/lilypad/pkg/executor/bacalhau"
+	"github.com/lilypad-tech/lilypad/pkg/executor/container"
+	"github.com/lilypad-tech/lilypad/pkg/executor/kubernetes"
+	"github.com/lilypad-tech/lilypad/pkg/executor/wasm"
 	"github.com/lilypad-tech/lilypad/pkg/ipfs"
 	"github.com/lilypad-tech/lilypad/pkg/powLogs"
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/lilypad-tech/lilypad/pkg/storage"
 	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/lilypad-tech/lilypad/pkg/web3"
 	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/pow"
@@ -29,8 +38,16 @@ type ResourceProviderOfferOptions struct {
 	// if we are configuring a single machine then
 	// these values are populated by the flags
 	OfferSpec data.MachineSpec
+	// probe the machine for its spec instead of using OfferSpec
+	// or what the executor reports
+	Autodetect bool
+	// cpu, gpu, ram and disk values that win over what was detected
+	SpecOverrides map[string]string
 	// we can dupliate the single spec to create a list of specs
 	OfferCount int
+	// the largest job input we will download in megabytes
+	// zero means there is no limit
+	MaxInputSize int
 	// this represents how many machines we will keep
 	// offering to the network
 	// we can configure this with a config file
@@ -52,9 +69,50 @@ type ResourceProviderOfferOptions struct {
 	// allow different pricing for different modules
 	ModulePricing  map[string]data.DealPricing
 	ModuleTimeouts map[string]data.DealTimeouts
+	// module_id=instruction_price pairs from the CLI
+	// these are added to ModulePricing on top of the default pricing
+	ModulePrices []string
 
 	// which mediators and directories this RP will trust
 	Services data.ServiceConfig
+
+	// only take jobs from these job creators
+	// an empty list means anyone
+	AllowedJobCreators []string
+
+	// labels job offers can select on e.g. region=eu-west
+	Labels map[string]string
+
+	// the hex private key job creators encrypt private inputs for
+	// we publish its public key in our resource offers
+	// leave empty to not take jobs with encrypted inputs
+	EncryptionKey string
+
+	// the TEE our jobs run in, we attest to it in our resource offers
+	// leave empty if we are not running in one
+	TEE string
+	// a PEM file of the VCEK and then the ASK our sev-snp reports are
+	// signed with, solvers cannot check our reports without them
+	TEECertificatesPath string
+
+	// the network access we give jobs, none, egress-allowlist or full
+	Network string
+	// the domains, IPs and CIDRs jobs can reach under egress-allowlist
+	NetworkAllow []string
+	// module id -> network policy, this wins over Network
+	NetworkModules map[string]string
+
+	// the ERC-20 tokens we take payment in as well as the network token
+	// our prices are in whole units of whichever token the deal is paid in
+	PaymentTokens []string
+
+	// reserved runs every job to the end, spot is preempted
+	// when the machine is needed back
+	Class string
+	// the seconds a spot job keeps running after we preempt it
+	PreemptionNotice int
+	// the longest job a reserved offer will take in seconds, 0 for no limit
+	ReservedDuration int
 }
 
 // this configures the pow we will keep track of
@@ -67,28 +125,119 @@ type ResourceProviderPowOptions struct {
 	CudaHashsPerThread int
 }
 
+// this configures the thresholds at which we stop taking on new work
+// a zero value disables that particular check
+type ResourceProviderHealthOptions struct {
+	// the path whose filesystem we check for free space
+	DiskPath string
+	// Megabytes
+	MinFreeDisk int
+	// Megabytes
+	MinFreeRAM int
+	// degrees celsius
+	MaxGPUTemperature int
+}
+
+// this configures how busy the machine can get before we offer less of it
+// each value is a percentage and zero disables that particular check
+type ResourceProviderThrottleOptions struct {
+	// the one minute load average over the number of cores
+	MaxLoad int
+	// GPU memory in use across all cards
+	MaxGPUMemory int
+	// disk used on the health disk path
+	MaxDisk int
+}
+
+// this configures how many deals we run at once
+// deals we have agreed to beyond that wait in a local queue
+type ResourceProviderQueueOptions struct {
+	// zero means there is no limit
+	MaxConcurrentDeals int
+	// how many deals can wait for a slot before we stop taking more
+	MaxQueuedDeals int
+	// module_id=limit pairs from the CLI
+	ModuleLimits []string
+	// module id -> how many of its deals can run at once
+	ModuleConcurrency map[string]int
+}
+
+// sending the output of running jobs to the solver
+// so job creators can watch them
+type ResourceProviderLogOptions struct {
+	Enabled bool
+	// milliseconds between sending batches of output
+	FlushInterval int
+}
+
+// the checks on module images before we run them
+type ResourceProviderImageOptions struct {
+	// refuse to run images that are not pinned by digest
+	RequireDigest bool
+	// the cosign public key images must be signed with
+	// leave empty to not check signatures
+	CosignKey string
+}
+
+// which runtime runs our jobs
+type ResourceProviderExecutorOptions struct {
+	// bacalhau, docker, podman, kubernetes or wasm
+	Type       string
+	Kubernetes kubernetes.KubernetesExecutorOptions
+	Wasm       wasm.WasmExecutorOptions
+	// applies to the docker, podman and kubernetes executors
+	Sandbox container.SandboxOptions
+	// how often the checkpoints of running jobs are uploaded (seconds)
+	// modules can ask for their own interval
+	CheckpointInterval int
+	// how docker and podman keep jobs to a network allowlist
+	Egress container.EgressOptions
+	// how docker and podman reach the services of service deals
+	Service container.ServiceOptions
+}
+
 type ResourceProviderOptions struct {
+	Executor  ResourceProviderExecutorOptions
 	Bacalhau  bacalhau.BacalhauExecutorOptions
 	Offers    ResourceProviderOfferOptions
 	Web3      web3.Web3Options
 	Pow       ResourceProviderPowOptions
+	Health    ResourceProviderHealthOptions
+	Throttle  ResourceProviderThrottleOptions
+	Queue     ResourceProviderQueueOptions
+	Logs      ResourceProviderLogOptions
+	Image     ResourceProviderImageOptions
 	IPFS      ipfs.IPFSOptions
+	Storage   storage.StorageOptions
+	Bus       bus.BusOptions
 	Telemetry system.TelemetryOptions
+	// who to tell when our collateral is slashed
+	Alerts alerts.AlertOptions
 }
 
 type ResourceProvider struct {
-	web3SDK    *web3.Web3SDK
+	web3SDK    web3.Web3Client
 	options    ResourceProviderOptions
 	controller *ResourceProviderController
 }
 
 func NewResourceProvider(
 	options ResourceProviderOptions,
-	web3SDK *web3.Web3SDK,
+	web3SDK web3.Web3Client,
 	executor executor.Executor,
 	tracer trace.Tracer,
 ) (*ResourceProvider, error) {
-	controller, err := NewResourceProviderController(options, web3SDK, executor, tracer)
+	solverClient, err := solver.NewSolverClientFromWeb3(web3SDK, options.Offers.Services.Solver, "ResourceProvider")
+	if err != nil {
+		return nil, err
+	}
+	if options.Bus.URL != "" {
+		err = solverClient.UseBus(options.Bus)
+		if err != nil {
+			return nil, err
+		}
+	}
+	controller, err := NewResourceProviderController(options, web3SDK, solverClient, executor, tracer)
 	if err != nil {
 		return nil, err
 	}
@@ -177,18 +326,15 @@ func (resourceProvider *ResourceProvider) StartMineLoop(ctx context.Context) cha
 }
 
 func TriggerNewPowRound(ctx context.Context, web3SDK *web3.Web3SDK) (common.Hash, error) {
-	tx, err := web3SDK.Contracts.Pow.TriggerNewPowRound(web3SDK.TransactOpts)
-	if err != nil {
-		return common.Hash{}, err
-	}
-
-	receipt, err := web3SDK.WaitTx(ctx, tx)
+	receipt, err := web3SDK.Transact(ctx, "pow.TriggerNewPowRound", "", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return web3SDK.Contracts.Pow.TriggerNewPowRound(opts)
+	})
 	if err != nil {
 		return common.Hash{}, err
 	}
 
 	if receipt.Status != 1 {
-		return tx.Hash(), fmt.Errorf("trigger new pow round")
+		return receipt.TxHash, fmt.Errorf("trigger new pow round")
 	}
-	return tx.Hash(), nil
+	return receipt.TxHash, nil
 }
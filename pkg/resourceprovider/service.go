@@ -0,0 +1,156 @@
+package resourceprovider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/executor"
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+)
+
+// how long the service has to answer a request, less than the solver
+// waits so the job creator hears why rather than just timing out
+const SERVICE_RELAY_TIMEOUT = 100 * time.Second
+
+// how long we wait before asking the solver for requests again after an error
+const SERVICE_RELAY_RETRY = 5 * time.Second
+
+// picks up the requests for the service of a deal from the solver
+// sends them to the service and posts its answers back
+type serviceRelay struct {
+	dealID       string
+	solverClient solver.SolverAPI
+	executor     executor.ServiceExecutor
+	log          *system.ServiceLogger
+	client       *http.Client
+	stop         chan struct{}
+	// the requests being sent to the service
+	forwarding sync.WaitGroup
+}
+
+func newServiceRelay(
+	dealID string,
+	solverClient solver.SolverAPI,
+	serviceExecutor executor.ServiceExecutor,
+	log *system.ServiceLogger,
+) *serviceRelay {
+	relay := &serviceRelay{
+		dealID:       dealID,
+		solverClient: solverClient,
+		executor:     serviceExecutor,
+		log:          log,
+		client:       &http.Client{Timeout: SERVICE_RELAY_TIMEOUT},
+		stop:         make(chan struct{}),
+	}
+	go relay.run()
+	return relay
+}
+
+func (relay *serviceRelay) run() {
+	for {
+		select {
+		case <-relay.stop:
+			return
+		default:
+		}
+		// the solver holds on to this until there are requests
+		requests, err := relay.solverClient.GetServiceRequests(relay.dealID)
+		if err != nil {
+			relay.log.Error("error getting service requests", err)
+			select {
+			case <-relay.stop:
+				return
+			case <-time.After(SERVICE_RELAY_RETRY):
+			}
+			continue
+		}
+		for _, request := range requests {
+			relay.forwarding.Add(1)
+			go func(request data.ServiceRequest) {
+				defer relay.forwarding.Done()
+				relay.forward(request)
+			}(request)
+		}
+	}
+}
+
+func (relay *serviceRelay) forward(request data.ServiceRequest) {
+	response := data.ServiceResponse{RequestID: request.ID}
+	url, err := relay.executor.GetServiceURL(relay.dealID)
+	if err == nil {
+		response, err = SendServiceRequest(context.Background(), relay.client, url, request)
+	}
+	if err != nil {
+		response = data.ServiceResponse{
+			RequestID: request.ID,
+			Error:     err.Error(),
+		}
+	}
+	_, err = relay.solverClient.AddServiceResponse(relay.dealID, response)
+	if err != nil {
+		relay.log.Error("error sending service response", err)
+	}
+}
+
+// stop picking up requests and wait for the answers to the ones we have
+// the last request to the solver is left to finish on its own
+func (relay *serviceRelay) close() {
+	close(relay.stop)
+	relay.forwarding.Wait()
+}
+
+// send a request to the service at the base URL and read its answer
+func SendServiceRequest(ctx context.Context, client *http.Client, baseURL string, request data.ServiceRequest) (data.ServiceResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, request.Method, baseURL+request.Path, bytes.NewReader(request.Body))
+	if err != nil {
+		return data.ServiceResponse{}, err
+	}
+	for name, value := range request.Headers {
+		req.Header.Set(name, value)
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return data.ServiceResponse{}, fmt.Errorf("error sending request to service: %s", err.Error())
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(res.Body, data.MAX_SERVICE_RESPONSE_SIZE+1))
+	if err != nil {
+		return data.ServiceResponse{}, fmt.Errorf("error reading service response: %s", err.Error())
+	}
+	if len(body) > data.MAX_SERVICE_RESPONSE_SIZE {
+		return data.ServiceResponse{}, fmt.Errorf("service response is more than %d bytes", data.MAX_SERVICE_RESPONSE_SIZE)
+	}
+	headers := map[string]string{}
+	for name := range res.Header {
+		if len(headers) >= data.MAX_SERVICE_HEADERS {
+			break
+		}
+		headers[name] = res.Header.Get(name)
+	}
+	return data.ServiceResponse{
+		RequestID:  request.ID,
+		StatusCode: res.StatusCode,
+		Headers:    headers,
+		Body:       body,
+	}, nil
+}
+
+// the requests the solver metered for the service of a deal
+// this is what the deal is paid for
+func (controller *ResourceProviderController) getServiceRequestCount(dealID string) (uint64, error) {
+	deal, err := controller.solverClient.GetDeal(dealID)
+	if err != nil {
+		return 0, err
+	}
+	if deal.ServiceUsage == nil {
+		return 0, nil
+	}
+	return deal.ServiceUsage.Requests, nil
+}
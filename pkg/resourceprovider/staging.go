@@ -0,0 +1,82 @@
+package resourceprovider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+)
+
+// pin the inputs job creators have asked us to fetch ahead of their jobs
+// so the deal timer is not spent downloading a large dataset
+// each request is fetched in the background so the control loop carries on
+func (controller *ResourceProviderController) stageInputs(ctx context.Context) error {
+	if controller.ipfsClient == nil {
+		return nil
+	}
+	stagings, err := controller.solverClient.GetInputStagings(store.GetInputStagingsQuery{
+		ResourceProvider: controller.web3SDK.GetAddress().String(),
+		State:            data.InputStagingRequested,
+	})
+	if err != nil {
+		return err
+	}
+	for _, staging := range stagings {
+		controller.stagingMutex.Lock()
+		if controller.staging[staging.ID] {
+			controller.stagingMutex.Unlock()
+			continue
+		}
+		controller.staging[staging.ID] = true
+		controller.stagingMutex.Unlock()
+
+		go func(staging data.InputStaging) {
+			defer func() {
+				controller.stagingMutex.Lock()
+				delete(controller.staging, staging.ID)
+				controller.stagingMutex.Unlock()
+			}()
+			update := controller.stageInput(ctx, staging)
+			_, err := controller.solverClient.UpdateInputStaging(staging.ID, update)
+			if err != nil {
+				controller.log.Error("error updating input staging", err)
+			}
+		}(staging)
+	}
+	return nil
+}
+
+func (controller *ResourceProviderController) stageInput(ctx context.Context, staging data.InputStaging) data.InputStagingUpdate {
+	failed := func(err error) data.InputStagingUpdate {
+		controller.log.Error(fmt.Sprintf("error staging inputs %s", staging.ID), err)
+		return data.InputStagingUpdate{
+			State: data.InputStagingFailed,
+			Error: err.Error(),
+		}
+	}
+	// the same limit as the input size we offer to take on
+	total := uint64(0)
+	for _, id := range staging.CIDs {
+		size, err := controller.ipfsClient.Stat(ctx, id)
+		if err != nil {
+			return failed(err)
+		}
+		total += size
+	}
+	maxInputSize := controller.options.Offers.MaxInputSize
+	if maxInputSize > 0 && total > uint64(maxInputSize)*1024*1024 {
+		return failed(fmt.Errorf("inputs are %d bytes which is more than our limit of %dMB", total, maxInputSize))
+	}
+	for _, id := range staging.CIDs {
+		err := controller.ipfsClient.Pin(ctx, id)
+		if err != nil {
+			return failed(err)
+		}
+	}
+	controller.log.Info("staged inputs", staging.ID)
+	return data.InputStagingUpdate{
+		State: data.InputStagingStaged,
+		Size:  total,
+	}
+}
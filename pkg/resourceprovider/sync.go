@@ -0,0 +1,132 @@
+package resourceprovider
+
+import (
+	"sync"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+)
+
+// our offers and deals as the solver last told us about them
+// each loop we ask the solver for what changed since the version we
+// have so coming back after being away does not mean fetching everything
+type solverSync struct {
+	mutex sync.RWMutex
+	// false until the first sync works, until then we ask the solver
+	// each time, which is also what happens with a solver that cannot sync
+	synced         bool
+	version        uint64
+	epoch          string
+	deals          map[string]data.DealContainer
+	resourceOffers map[string]data.ResourceOfferContainer
+}
+
+func newSolverSync() *solverSync {
+	return &solverSync{
+		deals:          map[string]data.DealContainer{},
+		resourceOffers: map[string]data.ResourceOfferContainer{},
+	}
+}
+
+func (s *solverSync) apply(changes data.ResourceProviderSync) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if changes.Reset {
+		s.deals = map[string]data.DealContainer{}
+		s.resourceOffers = map[string]data.ResourceOfferContainer{}
+	}
+	for _, deal := range changes.Deals {
+		s.deals[deal.ID] = deal
+	}
+	for _, resourceOffer := range changes.ResourceOffers {
+		s.resourceOffers[resourceOffer.ID] = resourceOffer
+	}
+	for _, id := range changes.RemovedDeals {
+		delete(s.deals, id)
+	}
+	for _, id := range changes.RemovedResourceOffers {
+		delete(s.resourceOffers, id)
+	}
+	s.version = changes.Version
+	s.epoch = changes.Epoch
+	s.synced = true
+}
+
+func (s *solverSync) getVersion() (uint64, string, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.version, s.epoch, s.synced
+}
+
+// the next sync starts from nothing
+func (s *solverSync) reset() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.synced = false
+	s.version = 0
+	s.epoch = ""
+}
+
+func (controller *ResourceProviderController) syncWithSolver() {
+	version, epoch, _ := controller.solverSync.getVersion()
+	changes, err := controller.solverClient.SyncResourceProvider(controller.web3SDK.GetAddress().String(), version, epoch)
+	if err != nil {
+		// a solver from before syncing gets asked for each thing as it always was
+		controller.log.Debug("error syncing with solver", err.Error())
+		controller.solverSync.reset()
+		return
+	}
+	if changes.Reset && version > 0 {
+		controller.log.Info("solver sync started over", changes.Version)
+	}
+	controller.solverSync.apply(changes)
+}
+
+// our deals in the state of the query that pass the filter
+// from what we have synced or from the solver if we have not
+func (controller *ResourceProviderController) getDealsWithFilter(query store.GetDealsQuery, filter func(data.DealContainer) bool) ([]data.DealContainer, error) {
+	s := controller.solverSync
+	if _, _, synced := s.getVersion(); !synced {
+		return controller.solverClient.GetDealsWithFilter(query, filter)
+	}
+	state, err := data.GetAgreementState(query.State)
+	if err != nil {
+		return nil, err
+	}
+	s.mutex.RLock()
+	deals := []data.DealContainer{}
+	for _, deal := range s.deals {
+		if deal.State == state {
+			deals = append(deals, deal)
+		}
+	}
+	s.mutex.RUnlock()
+	// the filters look at our running jobs so are called without the lock
+	ret := []data.DealContainer{}
+	for _, deal := range deals {
+		if filter(deal) {
+			ret = append(ret, deal)
+		}
+	}
+	return ret, nil
+}
+
+// our resource offers that are free or in a deal that is still going
+func (controller *ResourceProviderController) getActiveResourceOffers() ([]data.ResourceOfferContainer, error) {
+	s := controller.solverSync
+	if _, _, synced := s.getVersion(); !synced {
+		return controller.solverClient.GetResourceOffers(store.GetResourceOffersQuery{
+			ResourceProvider: controller.web3SDK.GetAddress().String(),
+			Active:           true,
+		})
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	resourceOffers := []data.ResourceOfferContainer{}
+	for _, resourceOffer := range s.resourceOffers {
+		if data.IsActiveAgreementState(resourceOffer.State) {
+			resourceOffers = append(resourceOffers, resourceOffer)
+		}
+	}
+	return resourceOffers, nil
+}
@@ -0,0 +1,127 @@
+package resourceprovider
+
+import (
+	"fmt"
+	"math"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// how many checks in a row must allow more offers before we post them again
+// so that a machine hovering around a threshold does not keep flapping
+const UTILIZATION_RECOVERY_CHECKS = 3
+
+// how busy the machine is, each value is a percentage
+// a value of -1 means we were unable to read it
+type utilization struct {
+	// the one minute load average over the number of cores
+	Load float64
+	// the share of GPU memory in use across all cards
+	GPUMemory float64
+	// the share of the disk that is used
+	Disk float64
+}
+
+func readUtilization(options ResourceProviderThrottleOptions, diskPath string) utilization {
+	usage := utilization{Load: -1, GPUMemory: -1, Disk: -1}
+	if options.MaxLoad > 0 {
+		load, err := getLoad()
+		if err == nil {
+			usage.Load = load
+		}
+	}
+	if options.MaxGPUMemory > 0 {
+		gpuMemory, err := getGPUMemoryUsed()
+		if err == nil {
+			usage.GPUMemory = gpuMemory
+		}
+	}
+	if options.MaxDisk > 0 && diskPath != "" {
+		disk, err := getDiskUsed(diskPath)
+		if err == nil {
+			usage.Disk = disk
+		}
+	}
+	return usage
+}
+
+// work out how many of our offers the machine has room for
+// each threshold scales the offers by the headroom left below it and the
+// tightest one wins, a value we could not read does not throttle anything
+func getOfferLimit(total int, usage utilization, options ResourceProviderThrottleOptions) int {
+	headroom := 1.0
+	checks := []struct {
+		used float64
+		max  int
+	}{
+		{used: usage.Load, max: options.MaxLoad},
+		{used: usage.GPUMemory, max: options.MaxGPUMemory},
+		{used: usage.Disk, max: options.MaxDisk},
+	}
+	for _, check := range checks {
+		if check.max <= 0 || check.used < 0 {
+			continue
+		}
+		headroom = math.Min(headroom, (float64(check.max)-check.used)/float64(check.max))
+	}
+	if headroom <= 0 {
+		return 0
+	}
+	return int(math.Ceil(float64(total) * headroom))
+}
+
+func (usage utilization) String() string {
+	parts := []string{}
+	if usage.Load >= 0 {
+		parts = append(parts, fmt.Sprintf("load %.0f%%", usage.Load))
+	}
+	if usage.GPUMemory >= 0 {
+		parts = append(parts, fmt.Sprintf("gpu memory %.0f%%", usage.GPUMemory))
+	}
+	if usage.Disk >= 0 {
+		parts = append(parts, fmt.Sprintf("disk %.0f%%", usage.Disk))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// like the temperatures we only know how to read nvidia cards
+func getGPUMemoryUsed() (float64, error) {
+	output, err := exec.Command(
+		"nvidia-smi",
+		"--query-gpu=memory.used,memory.total",
+		"--format=csv,noheader,nounits",
+	).Output()
+	if err != nil {
+		return 0, err
+	}
+	return parseGPUMemoryUsed(string(output))
+}
+
+func parseGPUMemoryUsed(output string) (float64, error) {
+	used, total := 0, 0
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, ",")
+		if len(fields) != 2 {
+			return 0, fmt.Errorf("error parsing gpu memory %q: expected used and total", line)
+		}
+		lineUsed, err := strconv.Atoi(strings.TrimSpace(fields[0]))
+		if err != nil {
+			return 0, fmt.Errorf("error parsing gpu memory %q: %s", line, err.Error())
+		}
+		lineTotal, err := strconv.Atoi(strings.TrimSpace(fields[1]))
+		if err != nil {
+			return 0, fmt.Errorf("error parsing gpu memory %q: %s", line, err.Error())
+		}
+		used += lineUsed
+		total += lineTotal
+	}
+	if total == 0 {
+		return 0, fmt.Errorf("no gpu memory found")
+	}
+	return float64(used) * 100 / float64(total), nil //nolint:gomnd
+}
@@ -0,0 +1,42 @@
+package resourceprovider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOfferLimit(t *testing.T) {
+	options := ResourceProviderThrottleOptions{MaxLoad: 80, MaxGPUMemory: 90}
+
+	testCases := []struct {
+		name     string
+		total    int
+		usage    utilization
+		expected int
+	}{
+		{name: "Idle", total: 4, usage: utilization{Load: 0, GPUMemory: 0, Disk: -1}, expected: 4},
+		{name: "Half loaded", total: 4, usage: utilization{Load: 40, GPUMemory: 0, Disk: -1}, expected: 2},
+		{name: "GPU memory is tighter", total: 4, usage: utilization{Load: 40, GPUMemory: 80, Disk: -1}, expected: 1},
+		{name: "Over a threshold", total: 4, usage: utilization{Load: 95, GPUMemory: 0, Disk: -1}, expected: 0},
+		{name: "Single offer stays until the threshold", total: 1, usage: utilization{Load: 70, GPUMemory: 0, Disk: -1}, expected: 1},
+		{name: "Unreadable values are ignored", total: 2, usage: utilization{Load: -1, GPUMemory: -1, Disk: -1}, expected: 2},
+		{name: "Disabled checks are ignored", total: 2, usage: utilization{Load: 0, GPUMemory: 0, Disk: 99}, expected: 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, getOfferLimit(tc.total, tc.usage, options))
+		})
+	}
+}
+
+func TestParseGPUMemoryUsed(t *testing.T) {
+	used, err := parseGPUMemoryUsed("1024, 4096\n3072, 4096\n")
+	require.NoError(t, err)
+	assert.Equal(t, 50.0, used)
+
+	_, err = parseGPUMemoryUsed("")
+	assert.Error(t, err)
+}
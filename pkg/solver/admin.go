@@ -0,0 +1,487 @@
+package solver
+
+import (
+	"encoding/json"
+	"fmt"
+	corehttp "net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/http"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// can read the store stats, bans and admin log
+	AdminRoleViewer = "viewer"
+	// can also change what the solver is doing
+	AdminRoleOperator = "operator"
+)
+
+// what is recorded in the admin log
+const (
+	AdminActionExpireJobOffer      = "expire_job_offer"
+	AdminActionExpireResourceOffer = "expire_resource_offer"
+	AdminActionBanAddress          = "ban_address"
+	AdminActionUnbanAddress        = "unban_address"
+	AdminActionTriggerMatch        = "trigger_match"
+	AdminActionRefreshAllowlist    = "refresh_allowlist"
+	AdminActionExportState         = "export_state"
+	AdminActionImportState         = "import_state"
+)
+
+// the addresses that can use the admin API, requests are signed
+// the same way as every other request to the solver
+// with no addresses set the admin API turns every request away
+type AdminOptions struct {
+	Operators []string
+	Viewers   []string
+}
+
+// the role of an address, empty if it is not an admin
+func (options AdminOptions) GetRole(address string) string {
+	for _, operator := range options.Operators {
+		if strings.EqualFold(operator, address) {
+			return AdminRoleOperator
+		}
+	}
+	for _, viewer := range options.Viewers {
+		if strings.EqualFold(viewer, address) {
+			return AdminRoleViewer
+		}
+	}
+	return ""
+}
+
+// operators can do everything viewers can
+func HasAdminRole(role string, required string) bool {
+	switch required {
+	case AdminRoleViewer:
+		return role == AdminRoleViewer || role == AdminRoleOperator
+	case AdminRoleOperator:
+		return role == AdminRoleOperator
+	}
+	return false
+}
+
+func (controller *SolverController) getAllowlist() map[string]data.AllowlistItem {
+	controller.allowlistMutex.RLock()
+	defer controller.allowlistMutex.RUnlock()
+	return controller.allowlist
+}
+
+// read the allowlist file again, the one we have is kept if the new one is broken
+func (controller *SolverController) refreshAllowlist() (int, error) {
+	allowlist, err := LoadAllowlist(controller.options.Allowlist.Path)
+	if err != nil {
+		return 0, err
+	}
+	for moduleID, item := range allowlist {
+		for _, name := range item.Verifiers {
+			if _, ok := controller.verifiers[name]; !ok {
+				return 0, fmt.Errorf("module %s names unknown result verifier %s", moduleID, name)
+			}
+		}
+	}
+	controller.allowlistMutex.Lock()
+	controller.allowlist = allowlist
+	controller.allowlistMutex.Unlock()
+	controller.log.Info("refreshed allowlist", fmt.Sprintf("%d modules", len(allowlist)))
+	return len(allowlist), nil
+}
+
+// offers from a banned address are turned away when they are added
+// the matcher leaves out the ones added before the ban
+func (controller *SolverController) checkBannedAddress(address string) error {
+	ban, err := controller.store.GetBannedAddress(address)
+	if err != nil {
+		return err
+	}
+	if ban != nil && data.IsBanActive(*ban, time.Now().Unix()) {
+		if ban.ExpiresAt > 0 {
+			return data.NewError(data.ErrBanned, "address %s is banned by the solver until %s (%s): %s", address, time.Unix(ban.ExpiresAt, 0).UTC().Format(time.RFC3339), ban.Code, ban.Reason)
+		}
+		return data.NewError(data.ErrBanned, "address %s is banned by the solver (%s): %s", address, ban.Code, ban.Reason)
+	}
+	return nil
+}
+
+// the unmatched offers of a banned address are expired straight away
+// the deals it is already in are left to finish
+func (controller *SolverController) banAddress(submission data.BanSubmission, admin string) (*data.BannedAddress, error) {
+	ban, err := controller.store.AddBannedAddress(data.NewBannedAddress(submission, admin, time.Now().Unix()))
+	if err != nil {
+		return nil, err
+	}
+	address := submission.Address
+	jobOffers, err := controller.store.GetJobOffers(store.GetJobOffersQuery{
+		JobCreator: address,
+		NotMatched: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, jobOffer := range jobOffers {
+		_, err = controller.expireJobOffer(jobOffer)
+		if err != nil {
+			return nil, err
+		}
+	}
+	resourceOffers, err := controller.store.GetResourceOffers(store.GetResourceOffersQuery{
+		ResourceProvider: address,
+		NotMatched:       true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, resourceOffer := range resourceOffers {
+		err = controller.expireResourceOffer(resourceOffer)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ban, nil
+}
+
+// take a job offer out of matching before it would otherwise go
+func (controller *SolverController) expireJobOffer(jobOffer data.JobOfferContainer) (*data.JobOfferContainer, error) {
+	if jobOffer.DealID != "" {
+		return nil, data.NewError(data.ErrConflict, "job offer %s is in deal %s, cancel the job offer instead", jobOffer.ID, jobOffer.DealID)
+	}
+	return controller.cancelJobOffer(jobOffer)
+}
+
+func (controller *SolverController) expireResourceOffer(resourceOffer data.ResourceOfferContainer) error {
+	if resourceOffer.DealID != "" {
+		return data.NewError(data.ErrConflict, "resource offer %s is in deal %s and cannot be expired", resourceOffer.ID, resourceOffer.DealID)
+	}
+	controller.log.Info("expire resource offer", resourceOffer.ID)
+	err := controller.store.RemoveResourceOffer(resourceOffer.ID)
+	if err != nil {
+		return err
+	}
+	controller.writeEvent(SolverEvent{
+		EventType:     ResourceOfferRemoved,
+		ResourceOffer: &resourceOffer,
+	})
+	return nil
+}
+
+// the state of another solver is only imported into one that has not
+// taken any offers yet, merging two sets of deals is not something we do
+func (controller *SolverController) importState(snapshot store.StoreSnapshot) error {
+	stats, err := controller.store.GetStats()
+	if err != nil {
+		return err
+	}
+	for _, table := range []string{"job_offers", "resource_offers", "deals"} {
+		if stats.Counts[table] > 0 {
+			return data.NewError(data.ErrConflict, "the store already has %d %s, import into a solver with an empty store", stats.Counts[table], table)
+		}
+	}
+	err = controller.store.ImportSnapshot(snapshot)
+	if err != nil {
+		return err
+	}
+	controller.log.Info("imported state", fmt.Sprintf("%d job offers, %d resource offers, %d deals", len(snapshot.JobOffers), len(snapshot.ResourceOffers), len(snapshot.Deals)))
+	controller.loop.Trigger()
+	return nil
+}
+
+// every admin action is logged whether or not it worked
+func (controller *SolverController) logAdminAction(action data.AdminAction, actionErr error) *data.AdminAction {
+	action.CreatedAt = time.Now().UnixMilli()
+	if actionErr != nil {
+		action.Error = actionErr.Error()
+	}
+	id, err := data.CalculateCID(action)
+	if err != nil {
+		controller.log.Error("error getting admin action ID", err)
+		return &action
+	}
+	action.ID = id
+	_, err = controller.store.AddAdminAction(action)
+	if err != nil {
+		controller.log.Error("error logging admin action", err)
+		return &action
+	}
+	controller.log.Info("admin action", fmt.Sprintf("%s %s %s %s", action.Admin, action.Action, action.Target, action.Error))
+	return &action
+}
+
+// the signer of an admin request has to have the role the route needs
+// and have signed this request, so a captured one cannot be sent again
+func (solverServer *solverServer) checkAdmin(req *corehttp.Request, required string) (data.AdminAction, error) {
+	signerAddress, err := solverServer.signatures.Check(req)
+	if err != nil {
+		return data.AdminAction{}, http.HTTPError{
+			Message:    err.Error(),
+			StatusCode: corehttp.StatusUnauthorized,
+		}
+	}
+	role := solverServer.controller.options.Admin.GetRole(signerAddress)
+	if !HasAdminRole(role, required) {
+		log.Warn().Msgf("%s tried to use %s without the %s role", signerAddress, req.URL.Path, required)
+		return data.AdminAction{}, http.HTTPError{
+			Message:    fmt.Sprintf("%s does not have the %s role", signerAddress, required),
+			StatusCode: corehttp.StatusForbidden,
+		}
+	}
+	return data.AdminAction{Admin: signerAddress, Role: role}, nil
+}
+
+// what an address spends or earns is for it and the admins to see
+func (solverServer *solverServer) checkAddressOrAdmin(req *corehttp.Request, address string) error {
+	signerAddress, err := solverServer.signatures.Check(req)
+	if err != nil {
+		return http.HTTPError{
+			Message:    err.Error(),
+			StatusCode: corehttp.StatusUnauthorized,
+		}
+	}
+	if address != "" && strings.EqualFold(signerAddress, address) {
+		return nil
+	}
+	if !HasAdminRole(solverServer.controller.options.Admin.GetRole(signerAddress), AdminRoleViewer) {
+		return http.HTTPError{
+			Message:    fmt.Sprintf("%s is not %s and does not have the %s role", signerAddress, address, AdminRoleViewer),
+			StatusCode: corehttp.StatusForbidden,
+		}
+	}
+	return nil
+}
+
+func (solverServer *solverServer) getAdminStats(res corehttp.ResponseWriter, req *corehttp.Request) (store.StoreStats, error) {
+	_, err := solverServer.checkAdmin(req, AdminRoleViewer)
+	if err != nil {
+		return store.StoreStats{}, err
+	}
+	return solverServer.store.GetStats()
+}
+
+func (solverServer *solverServer) getAdminActions(res corehttp.ResponseWriter, req *corehttp.Request) ([]data.AdminAction, error) {
+	_, err := solverServer.checkAdmin(req, AdminRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	query := store.GetAdminActionsQuery{
+		Admin: req.URL.Query().Get("admin"),
+	}
+	if limit := req.URL.Query().Get("limit"); limit != "" {
+		query.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			return nil, http.HTTPError{
+				Message:    fmt.Sprintf("invalid limit %s", limit),
+				StatusCode: corehttp.StatusBadRequest,
+			}
+		}
+	}
+	return solverServer.store.GetAdminActions(query)
+}
+
+func (solverServer *solverServer) getBannedAddresses(res corehttp.ResponseWriter, req *corehttp.Request) ([]data.BannedAddress, error) {
+	_, err := solverServer.checkAdmin(req, AdminRoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	return solverServer.store.GetBannedAddresses()
+}
+
+func (solverServer *solverServer) banAddress(submission data.BanSubmission, res corehttp.ResponseWriter, req *corehttp.Request) (*data.BannedAddress, error) {
+	action, err := solverServer.checkAdmin(req, AdminRoleOperator)
+	if err != nil {
+		return nil, err
+	}
+	err = data.CheckBanSubmission(submission)
+	if err != nil {
+		return nil, http.HTTPError{
+			Message:    err.Error(),
+			StatusCode: corehttp.StatusBadRequest,
+		}
+	}
+	action.Action = AdminActionBanAddress
+	action.Target = submission.Address
+	action.Reason = fmt.Sprintf("%s: %s", submission.Code, submission.Reason)
+	banned, err := solverServer.controller.banAddress(submission, action.Admin)
+	solverServer.controller.logAdminAction(action, err)
+	return banned, err
+}
+
+func (solverServer *solverServer) unbanAddress(request data.AdminRequest, res corehttp.ResponseWriter, req *corehttp.Request) (*data.BannedAddress, error) {
+	action, err := solverServer.checkAdmin(req, AdminRoleOperator)
+	if err != nil {
+		return nil, err
+	}
+	address := mux.Vars(req)["address"]
+	ban, err := solverServer.store.GetBannedAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	if ban == nil {
+		return nil, http.HTTPError{
+			Message:    fmt.Sprintf("address is not banned: %s", address),
+			StatusCode: corehttp.StatusNotFound,
+		}
+	}
+	action.Action = AdminActionUnbanAddress
+	action.Target = address
+	action.Reason = request.Reason
+	err = solverServer.store.RemoveBannedAddress(address)
+	solverServer.controller.logAdminAction(action, err)
+	if err != nil {
+		return nil, err
+	}
+	return ban, nil
+}
+
+func (solverServer *solverServer) expireJobOffer(request data.AdminRequest, res corehttp.ResponseWriter, req *corehttp.Request) (*data.JobOfferContainer, error) {
+	action, err := solverServer.checkAdmin(req, AdminRoleOperator)
+	if err != nil {
+		return nil, err
+	}
+	id := mux.Vars(req)["id"]
+	jobOffer, err := solverServer.store.GetJobOffer(id)
+	if err != nil {
+		return nil, err
+	}
+	if jobOffer == nil {
+		return nil, http.HTTPError{
+			Message:    fmt.Sprintf("job offer not found: %s", id),
+			StatusCode: corehttp.StatusNotFound,
+		}
+	}
+	action.Action = AdminActionExpireJobOffer
+	action.Target = jobOffer.ID
+	action.Reason = request.Reason
+	expired, err := solverServer.controller.expireJobOffer(*jobOffer)
+	solverServer.controller.logAdminAction(action, err)
+	if err != nil {
+		return nil, http.HTTPError{
+			Message:    err.Error(),
+			StatusCode: corehttp.StatusBadRequest,
+		}
+	}
+	return expired, nil
+}
+
+func (solverServer *solverServer) expireResourceOffer(request data.AdminRequest, res corehttp.ResponseWriter, req *corehttp.Request) (*data.ResourceOfferContainer, error) {
+	action, err := solverServer.checkAdmin(req, AdminRoleOperator)
+	if err != nil {
+		return nil, err
+	}
+	id := mux.Vars(req)["id"]
+	resourceOffer, err := solverServer.store.GetResourceOffer(id)
+	if err != nil {
+		return nil, err
+	}
+	if resourceOffer == nil {
+		return nil, http.HTTPError{
+			Message:    fmt.Sprintf("resource offer not found: %s", id),
+			StatusCode: corehttp.StatusNotFound,
+		}
+	}
+	action.Action = AdminActionExpireResourceOffer
+	action.Target = resourceOffer.ID
+	action.Reason = request.Reason
+	err = solverServer.controller.expireResourceOffer(*resourceOffer)
+	solverServer.controller.logAdminAction(action, err)
+	if err != nil {
+		return nil, http.HTTPError{
+			Message:    err.Error(),
+			StatusCode: corehttp.StatusBadRequest,
+		}
+	}
+	return resourceOffer, nil
+}
+
+// run a matching pass now rather than waiting for the next one
+func (solverServer *solverServer) triggerMatch(request data.AdminRequest, res corehttp.ResponseWriter, req *corehttp.Request) (*data.AdminAction, error) {
+	action, err := solverServer.checkAdmin(req, AdminRoleOperator)
+	if err != nil {
+		return nil, err
+	}
+	action.Action = AdminActionTriggerMatch
+	action.Reason = request.Reason
+	solverServer.controller.loop.Trigger()
+	return solverServer.controller.logAdminAction(action, nil), nil
+}
+
+func (solverServer *solverServer) refreshAllowlist(request data.AdminRequest, res corehttp.ResponseWriter, req *corehttp.Request) (*data.AdminAction, error) {
+	action, err := solverServer.checkAdmin(req, AdminRoleOperator)
+	if err != nil {
+		return nil, err
+	}
+	action.Action = AdminActionRefreshAllowlist
+	action.Target = solverServer.controller.options.Allowlist.Path
+	action.Reason = request.Reason
+	_, err = solverServer.controller.refreshAllowlist()
+	logged := solverServer.controller.logAdminAction(action, err)
+	if err != nil {
+		return nil, http.HTTPError{
+			Message:    err.Error(),
+			StatusCode: corehttp.StatusBadRequest,
+		}
+	}
+	return logged, nil
+}
+
+// every table of the store as a zstd compressed tar that another
+// solver can import, whichever store it is using
+func (solverServer *solverServer) exportState(res corehttp.ResponseWriter, req *corehttp.Request) {
+	action, err := solverServer.checkAdmin(req, AdminRoleOperator)
+	if err != nil {
+		http.WriteError(res, req, err)
+		return
+	}
+	action.Action = AdminActionExportState
+	snapshot, err := solverServer.store.ExportSnapshot()
+	if err != nil {
+		solverServer.controller.logAdminAction(action, err)
+		http.WriteError(res, req, err)
+		return
+	}
+	manifest := snapshot.GetManifest(system.Version, time.Now())
+	res.Header().Set("Content-Type", "application/zstd")
+	res.Header().Set("Content-Disposition", `attachment; filename="state.tar.zst"`)
+	err = store.WriteSnapshotArchive(res, *snapshot, manifest)
+	if err != nil {
+		log.Error().Err(err).Msgf("error writing state export")
+	}
+	solverServer.controller.logAdminAction(action, err)
+}
+
+func (solverServer *solverServer) importState(res corehttp.ResponseWriter, req *corehttp.Request) {
+	action, err := solverServer.checkAdmin(req, AdminRoleOperator)
+	if err != nil {
+		http.WriteError(res, req, err)
+		return
+	}
+	action.Action = AdminActionImportState
+	snapshot, manifest, err := store.ReadSnapshotArchive(req.Body)
+	if err != nil {
+		solverServer.controller.logAdminAction(action, err)
+		http.WriteError(res, req, http.HTTPError{
+			Message:    fmt.Sprintf("error reading state export: %s", err.Error()),
+			StatusCode: corehttp.StatusBadRequest,
+		})
+		return
+	}
+	action.Target = manifest.SolverVersion
+	action.Reason = fmt.Sprintf("%d job offers, %d resource offers, %d deals", manifest.Tables["job_offers"], manifest.Tables["resource_offers"], manifest.Tables["deals"])
+	err = solverServer.controller.importState(*snapshot)
+	logged := solverServer.controller.logAdminAction(action, err)
+	if err != nil {
+		http.WriteError(res, req, err)
+		return
+	}
+	res.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(res).Encode(logged)
+	if err != nil {
+		log.Error().Err(err).Msgf("error writing import response")
+	}
+}
@@ -0,0 +1,33 @@
+package solver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdminRoles(t *testing.T) {
+	options := AdminOptions{
+		Operators: []string{"0xAbC"},
+		Viewers:   []string{"0xdef"},
+	}
+
+	testCases := []struct {
+		name     string
+		address  string
+		required string
+		allowed  bool
+	}{
+		{name: "Operator can operate", address: "0xabc", required: AdminRoleOperator, allowed: true},
+		{name: "Operator can view", address: "0xABC", required: AdminRoleViewer, allowed: true},
+		{name: "Viewer can view", address: "0xDEF", required: AdminRoleViewer, allowed: true},
+		{name: "Viewer cannot operate", address: "0xdef", required: AdminRoleOperator, allowed: false},
+		{name: "Anyone else cannot view", address: "0x123", required: AdminRoleViewer, allowed: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.allowed, HasAdminRole(options.GetRole(tc.address), tc.required))
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package solver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+type AllowlistOptions struct {
+	// path to a JSON file containing a list of allowlist items
+	// an empty path means no allowlist is loaded
+	Path string
+	// reject job offers for modules on a floating version like a branch
+	RequirePinnedVersions bool
+}
+
+// load the allowlist from disk and key it by module ID
+func LoadAllowlist(path string) (map[string]data.AllowlistItem, error) {
+	allowlist := map[string]data.AllowlistItem{}
+	if path == "" {
+		return allowlist, nil
+	}
+
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading allowlist: %s", err.Error())
+	}
+
+	items := []data.AllowlistItem{}
+	err = json.Unmarshal(bs, &items)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing allowlist: %s", err.Error())
+	}
+
+	for _, item := range items {
+		if item.ModuleID == "" {
+			moduleID, err := data.GetModuleID(item.Module)
+			if err != nil {
+				return nil, fmt.Errorf("error calculating module ID for %s: %s", item.Module.Repo, err.Error())
+			}
+			item.ModuleID = moduleID
+		}
+		allowlist[item.ModuleID] = item
+	}
+
+	return allowlist, nil
+}
+
+// check an allowlist file for the mistakes LoadAllowlist lets through
+// every problem is reported rather than just the first one
+func ValidateAllowlist(path string) ([]data.AllowlistItem, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading allowlist: %s", err.Error())
+	}
+
+	items := []data.AllowlistItem{}
+	decoder := json.NewDecoder(bytes.NewReader(bs))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&items)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing allowlist: %s", err.Error())
+	}
+
+	problems := []error{}
+	seen := map[string]int{}
+	for i, item := range items {
+		// job offers are sent with shortcuts already resolved
+		// so an entry using a shortcut name would never match one
+		if item.Module.Name != "" {
+			problems = append(problems, fmt.Errorf("entry %d: use the repo, hash and path of module %s rather than its name", i, item.Module.Name))
+		}
+		if item.Module.Repo == "" || item.Module.Hash == "" {
+			problems = append(problems, fmt.Errorf("entry %d: module repo and hash are required", i))
+		}
+		moduleID, err := data.GetModuleID(item.Module)
+		if err != nil {
+			problems = append(problems, fmt.Errorf("entry %d: error calculating module ID: %s", i, err.Error()))
+			continue
+		}
+		if item.ModuleID != "" && item.ModuleID != moduleID {
+			problems = append(problems, fmt.Errorf("entry %d: module_id %s does not match the module ID %s", i, item.ModuleID, moduleID))
+		}
+		if first, ok := seen[moduleID]; ok {
+			problems = append(problems, fmt.Errorf("entry %d: module %s is already listed in entry %d", i, moduleID, first))
+		} else {
+			seen[moduleID] = i
+		}
+		if item.MinimumSpec.CPU < 0 || item.MinimumSpec.GPU < 0 || item.MinimumSpec.RAM < 0 {
+			problems = append(problems, fmt.Errorf("entry %d: minimum spec cannot be negative", i))
+		}
+		if item.MaxRuntime < 0 {
+			problems = append(problems, fmt.Errorf("entry %d: max runtime cannot be negative", i))
+		}
+		if item.ImageDigest != "" {
+			err := data.CheckImageDigest(item.ImageDigest)
+			if err != nil {
+				problems = append(problems, fmt.Errorf("entry %d: %s", i, err.Error()))
+			}
+		}
+		if item.Module.ImageDigest != "" && item.Module.ImageDigest != item.ImageDigest {
+			problems = append(problems, fmt.Errorf("entry %d: set the image digest on the entry rather than its module", i))
+		}
+	}
+	return items, errors.Join(problems...)
+}
+
+// fill in the max runtime from the allowlist if the job offer has not set one
+// so runaway modules are stopped at the same point whichever client sent them
+func applyModuleMaxRuntime(deal data.Deal, allowlist map[string]data.AllowlistItem) (data.Deal, error) {
+	if deal.JobOffer.MaxRuntime > 0 {
+		return deal, nil
+	}
+	moduleID, err := data.GetModuleID(deal.JobOffer.Module)
+	if err != nil {
+		return deal, err
+	}
+	if item, ok := allowlist[moduleID]; ok && item.MaxRuntime > 0 {
+		deal.JobOffer.MaxRuntime = item.MaxRuntime
+	}
+	return deal, nil
+}
+
+// pin the module image to the digest the allowlist has for it
+// a job offer that asks for a different image is refused
+func applyModuleImageDigest(deal data.Deal, allowlist map[string]data.AllowlistItem) (data.Deal, error) {
+	moduleID, err := data.GetModuleID(deal.JobOffer.Module)
+	if err != nil {
+		return deal, err
+	}
+	item, ok := allowlist[moduleID]
+	if !ok || item.ImageDigest == "" {
+		return deal, nil
+	}
+	if deal.JobOffer.Module.ImageDigest != "" && deal.JobOffer.Module.ImageDigest != item.ImageDigest {
+		return deal, data.NewError(data.ErrModuleNotAllowed, "job offer asks for image %s of module %s but the allowlist has %s", deal.JobOffer.Module.ImageDigest, moduleID, item.ImageDigest)
+	}
+	deal.JobOffer.Module.ImageDigest = item.ImageDigest
+	return deal, nil
+}
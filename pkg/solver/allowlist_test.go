@@ -0,0 +1,124 @@
+package solver
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyModuleMaxRuntime(t *testing.T) {
+	module := data.ModuleConfig{Repo: "https://github.com/lilypad-tech/lilypad-module-cowsay", Hash: "v0.0.1"}
+	moduleID, err := data.GetModuleID(module)
+	assert.NoError(t, err)
+	allowlist := map[string]data.AllowlistItem{
+		moduleID: {Module: module, ModuleID: moduleID, MaxRuntime: 600},
+	}
+
+	testCases := []struct {
+		name       string
+		module     data.ModuleConfig
+		maxRuntime int
+		expected   int
+	}{
+		{name: "Module default", module: module, maxRuntime: 0, expected: 600},
+		{name: "Job offer sets its own", module: module, maxRuntime: 60, expected: 60},
+		{name: "Module not in allowlist", module: data.ModuleConfig{Name: "other"}, maxRuntime: 0, expected: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			deal := data.Deal{JobOffer: data.JobOffer{Module: tc.module, MaxRuntime: tc.maxRuntime}}
+			deal, err := applyModuleMaxRuntime(deal, allowlist)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, deal.JobOffer.MaxRuntime)
+		})
+	}
+}
+
+func TestApplyModuleImageDigest(t *testing.T) {
+	module := data.ModuleConfig{Repo: "https://github.com/lilypad-tech/lilypad-module-cowsay", Hash: "v0.0.1"}
+	moduleID, err := data.GetModuleID(module)
+	assert.NoError(t, err)
+	digest := "sha256:" + strings.Repeat("a", 64)
+	allowlist := map[string]data.AllowlistItem{
+		moduleID: {Module: module, ModuleID: moduleID, ImageDigest: digest},
+	}
+
+	testCases := []struct {
+		name        string
+		imageDigest string
+		expected    string
+		err         string
+	}{
+		{name: "Filled in from the allowlist", imageDigest: "", expected: digest},
+		{name: "Job offer asks for the same image", imageDigest: digest, expected: digest},
+		{name: "Job offer asks for another image", imageDigest: "sha256:" + strings.Repeat("b", 64), err: "the allowlist has"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jobModule := module
+			jobModule.ImageDigest = tc.imageDigest
+			deal := data.Deal{JobOffer: data.JobOffer{Module: jobModule}}
+			deal, err := applyModuleImageDigest(deal, allowlist)
+			if tc.err != "" {
+				assert.ErrorContains(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, deal.JobOffer.Module.ImageDigest)
+		})
+	}
+}
+
+func TestValidateAllowlist(t *testing.T) {
+	testCases := []struct {
+		name     string
+		contents string
+		problems []string
+	}{
+		{
+			name:     "Valid",
+			contents: `[{"module": {"repo": "https://github.com/lilypad-tech/lilypad-module-cowsay", "hash": "v0.0.4"}, "max_runtime": 60}]`,
+		},
+		{
+			name:     "Unknown field",
+			contents: `[{"module": {"repo": "r", "hash": "h"}, "maxruntime": 60}]`,
+			problems: []string{"unknown field"},
+		},
+		{
+			name:     "Shortcut name",
+			contents: `[{"module": {"name": "cowsay:v0.0.4"}}]`,
+			problems: []string{"rather than its name", "repo and hash are required"},
+		},
+		{
+			name:     "Duplicate and mismatched ID",
+			contents: `[{"module": {"repo": "r", "hash": "h"}}, {"module": {"repo": "r", "hash": "h"}, "module_id": "QmWrong"}]`,
+			problems: []string{"already listed in entry 0", "does not match the module ID"},
+		},
+		{
+			name:     "Bad image digest",
+			contents: `[{"module": {"repo": "r", "hash": "h"}, "image_digest": "latest"}]`,
+			problems: []string{"must be sha256:"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "allowlist.json")
+			assert.NoError(t, os.WriteFile(path, []byte(tc.contents), 0644))
+			_, err := ValidateAllowlist(path)
+			if len(tc.problems) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			for _, problem := range tc.problems {
+				assert.ErrorContains(t, err, problem)
+			}
+		})
+	}
+}
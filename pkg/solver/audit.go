@@ -0,0 +1,148 @@
+package solver
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+)
+
+type AuditOptions struct {
+	// the percentage of deals with results that we audit (0-100)
+	Percentage int
+	// the verifiers an audit runs, leave this empty to run every registered verifier
+	Verifiers []string
+}
+
+// roll the dice for a deal that has just had its result accepted
+// every deal gets an audit record so we only ever decide once
+// a preempted job has nothing to audit
+func (controller *SolverController) scheduleAudit(deal data.DealContainer, result data.Result) (*data.Audit, error) {
+	existing, err := controller.store.GetAudit(deal.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+	state := data.AuditSkipped
+	if !result.Preempted && rand.Intn(100) < controller.options.Audit.Percentage {
+		state = data.AuditPending
+	}
+	return controller.store.AddAudit(data.Audit{
+		DealID:           deal.ID,
+		ResourceProvider: deal.ResourceProvider,
+		JobCreator:       deal.JobCreator,
+		State:            state,
+		CreatedAt:        time.Now().Unix(),
+	})
+}
+
+// the names of the verifiers an audit runs
+func (controller *SolverController) getAuditVerifiers() []string {
+	if len(controller.options.Audit.Verifiers) > 0 {
+		return controller.options.Audit.Verifiers
+	}
+	names := []string{}
+	for name := range controller.verifiers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// run the audit verifiers against a deal and its result
+// unlike verifyResult this ignores the module policy so
+// every provider is held to the same standard
+func (controller *SolverController) runAudit(deal data.DealContainer, result data.Result) error {
+	for _, name := range controller.getAuditVerifiers() {
+		verifier, ok := controller.verifiers[name]
+		if !ok {
+			return fmt.Errorf("unknown result verifier %s", name)
+		}
+		err := verifier.Verify(deal, result)
+		if err != nil {
+			return fmt.Errorf("audit failed %s verifier: %s", name, err.Error())
+		}
+	}
+	return nil
+}
+
+// process any audits that have been scheduled but not yet run
+// this is called from the control loop so posting results stays fast
+func (controller *SolverController) processAudits() error {
+	audits, err := controller.store.GetAudits(store.GetAuditsQuery{
+		State: data.AuditPending,
+	})
+	if err != nil {
+		return err
+	}
+	for _, audit := range audits {
+		deal, err := controller.store.GetDeal(audit.DealID)
+		if err != nil {
+			return err
+		}
+		result, err := controller.store.GetResult(audit.DealID)
+		if err != nil {
+			return err
+		}
+		if deal == nil || result == nil {
+			_, err = controller.store.UpdateAuditState(audit.DealID, data.AuditFailed, "deal or result is missing")
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		state := data.AuditPassed
+		message := ""
+		auditErr := controller.runAudit(*deal, *result)
+		if auditErr != nil {
+			state = data.AuditFailed
+			message = auditErr.Error()
+			controller.log.Error("audit failed", fmt.Errorf("deal %s: %s", deal.ID, message))
+		} else {
+			controller.log.Info("audit passed", deal.ID)
+		}
+		_, err = controller.store.UpdateAuditState(audit.DealID, state, message)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// summarise the audits we have run for a resource provider
+func (controller *SolverController) getReputation(resourceProvider string) (data.ResourceProviderReputation, error) {
+	reputation := data.ResourceProviderReputation{
+		ResourceProvider: resourceProvider,
+	}
+	audits, err := controller.store.GetAudits(store.GetAuditsQuery{
+		ResourceProvider: resourceProvider,
+	})
+	if err != nil {
+		return reputation, err
+	}
+	for _, audit := range audits {
+		switch audit.State {
+		case data.AuditPassed:
+			reputation.AuditsPassed++
+		case data.AuditFailed:
+			reputation.AuditsFailed++
+		}
+	}
+	deals, err := controller.store.GetDeals(store.GetDealsQuery{
+		ResourceProvider: resourceProvider,
+	})
+	if err != nil {
+		return reputation, err
+	}
+	for _, deal := range deals {
+		if deal.Preemption != nil {
+			reputation.Preemptions++
+		}
+	}
+	return reputation, nil
+}
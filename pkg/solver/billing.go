@@ -0,0 +1,80 @@
+package solver
+
+import (
+	"fmt"
+	corehttp "net/http"
+	"strconv"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/http"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+	"github.com/rs/zerolog/log"
+)
+
+// metering the deal should not stop the result being accepted
+func (controller *SolverController) addBillingRecord(deal data.DealContainer, result data.Result) {
+	_, err := controller.store.AddBillingRecord(data.GetBillingRecord(deal, result, time.Now()))
+	if err != nil {
+		controller.log.Error("error adding billing record", err)
+	}
+}
+
+func getBillingRecordsQuery(req *corehttp.Request) (store.GetBillingRecordsQuery, error) {
+	query := store.GetBillingRecordsQuery{
+		JobCreator:       req.URL.Query().Get("job_creator"),
+		ResourceProvider: req.URL.Query().Get("resource_provider"),
+	}
+	for name, value := range map[string]*int64{"from": &query.From, "to": &query.To} {
+		param := req.URL.Query().Get(name)
+		if param == "" {
+			continue
+		}
+		seconds, err := strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			return query, http.HTTPError{
+				Message:    fmt.Sprintf("%s must be unix seconds: %s", name, param),
+				StatusCode: corehttp.StatusBadRequest,
+			}
+		}
+		*value = seconds
+	}
+	return query, nil
+}
+
+// the job creator can read the records of the deals it paid for and
+// a resource provider the ones it ran, without either they are for admins
+func (solverServer *solverServer) getSignedBillingRecords(req *corehttp.Request) ([]data.BillingRecord, error) {
+	query, err := getBillingRecordsQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	owner := query.JobCreator
+	if owner == "" {
+		owner = query.ResourceProvider
+	}
+	err = solverServer.checkAddressOrAdmin(req, owner)
+	if err != nil {
+		return nil, err
+	}
+	return solverServer.store.GetBillingRecords(query)
+}
+
+func (solverServer *solverServer) getBillingRecords(res corehttp.ResponseWriter, req *corehttp.Request) ([]data.BillingRecord, error) {
+	return solverServer.getSignedBillingRecords(req)
+}
+
+// the same records as /billing written as CSV for spreadsheets
+func (solverServer *solverServer) exportBillingRecords(res corehttp.ResponseWriter, req *corehttp.Request) {
+	records, err := solverServer.getSignedBillingRecords(req)
+	if err != nil {
+		http.WriteError(res, req, err)
+		return
+	}
+	res.Header().Set("Content-Type", "text/csv")
+	res.Header().Set("Content-Disposition", `attachment; filename="billing.csv"`)
+	err = data.WriteBillingCSV(res, records)
+	if err != nil {
+		log.Error().Err(err).Msgf("error writing billing csv")
+	}
+}
@@ -0,0 +1,105 @@
+package solver
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+)
+
+type BudgetOptions struct {
+	// a JSON file of the most each job creator can spend, see data.Budget
+	// job creators that are not in it have no cap
+	Path string
+}
+
+// the budgets keyed by lower case address
+func LoadBudgets(path string) (map[string]data.Budget, error) {
+	budgets := map[string]data.Budget{}
+	if path == "" {
+		return budgets, nil
+	}
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading budgets: %s", err.Error())
+	}
+	list := []data.Budget{}
+	err = json.Unmarshal(bs, &list)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing budgets: %s", err.Error())
+	}
+	err = data.CheckBudgets(list)
+	if err != nil {
+		return nil, err
+	}
+	for _, budget := range list {
+		budgets[strings.ToLower(budget.Address)] = budget
+	}
+	return budgets, nil
+}
+
+// what the address has spent and has left, this works without a budget
+// so a job creator can hold the spend to limits of its own
+func (controller *SolverController) getBudgetStatus(address string) (data.BudgetStatus, error) {
+	budget, ok := controller.budgets[strings.ToLower(address)]
+	if !ok {
+		budget = data.Budget{Address: address}
+	}
+	now := time.Now()
+	records, err := controller.store.GetBillingRecords(store.GetBillingRecordsQuery{
+		JobCreator: address,
+		From:       now.Add(-data.BUDGET_WEEK).Unix(),
+	})
+	if err != nil {
+		return data.BudgetStatus{}, err
+	}
+	reserved, err := controller.getBudgetReserved(address)
+	if err != nil {
+		return data.BudgetStatus{}, err
+	}
+	return data.GetBudgetStatus(budget, records, reserved, now), nil
+}
+
+// the most the job offers and deals of the address that have not been billed could cost
+func (controller *SolverController) getBudgetReserved(address string) (uint64, error) {
+	reserved := uint64(0)
+	jobOffers, err := controller.store.GetJobOffers(store.GetJobOffersQuery{
+		JobCreator: address,
+		NotMatched: true,
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, jobOffer := range jobOffers {
+		reserved += data.GetJobOfferReservation(jobOffer.JobOffer)
+	}
+	deals, err := controller.store.GetDeals(store.GetDealsQuery{
+		JobCreator: address,
+	})
+	if err != nil {
+		return 0, err
+	}
+	// a deal is billed when its result is added
+	for _, deal := range deals {
+		if data.IsActiveAgreementState(deal.State) {
+			reserved += deal.Deal.Pricing.PaymentCollateral
+		}
+	}
+	return reserved, nil
+}
+
+// refuse a job offer that could take the job creator over its budget
+func (controller *SolverController) checkBudget(jobOffer data.JobOffer) error {
+	if _, ok := controller.budgets[strings.ToLower(jobOffer.JobCreator)]; !ok {
+		return nil
+	}
+	status, err := controller.getBudgetStatus(jobOffer.JobCreator)
+	if err != nil {
+		return err
+	}
+	return data.CheckBudget(status, data.GetJobOfferReservation(jobOffer))
+}
@@ -0,0 +1,257 @@
+package solver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/bus"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/http"
+	"github.com/lilypad-tech/lilypad/pkg/web3"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// how often a resource provider on the bus tells us it is still there
+	BUS_HEARTBEAT_INTERVAL = 10 * time.Second
+	// a resource provider we have not heard from in this many heartbeats
+	// is treated like one whose websocket has disconnected
+	BUS_HEARTBEAT_MISSES = 3
+)
+
+// what is sent to the solver over the bus, it is signed like an HTTP request
+type BusRequest struct {
+	User      string          `json:"user"`
+	Signature string          `json:"signature"`
+	Data      json.RawMessage `json:"data"`
+}
+
+type BusResponse struct {
+	Data  json.RawMessage `json:"data,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+func NewBusRequest(signer web3.Signer, payload interface{}) ([]byte, error) {
+	if signer == nil {
+		return nil, fmt.Errorf("a signer is needed to send requests over the bus")
+	}
+	headers, err := http.GetUserHeaders(signer, signer.Address().String())
+	if err != nil {
+		return nil, err
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(BusRequest{
+		User:      headers[http.X_LILYPAD_USER_HEADER],
+		Signature: headers[http.X_LILYPAD_SIGNATURE_HEADER],
+		Data:      payloadBytes,
+	})
+}
+
+// send a request to the solver over the bus and wait for its answer
+func BusRequestResponse[ResultType any](ctx context.Context, b bus.Bus, subject string, signer web3.Signer, payload interface{}) (ResultType, error) {
+	var result ResultType
+	request, err := NewBusRequest(signer, payload)
+	if err != nil {
+		return result, err
+	}
+	answer, err := b.Request(ctx, subject, request)
+	if err != nil {
+		return result, err
+	}
+	var response BusResponse
+	err = json.Unmarshal(answer, &response)
+	if err != nil {
+		return result, err
+	}
+	if response.Error != "" {
+		return result, fmt.Errorf("solver returned error: %s", response.Error)
+	}
+	if len(response.Data) == 0 {
+		return result, nil
+	}
+	err = json.Unmarshal(response.Data, &result)
+	return result, err
+}
+
+// answers offers sent over the bus and broadcasts our events to it, the
+// offers go through the same checks as the ones posted over HTTP
+type solverBusServer struct {
+	bus        bus.Bus
+	subjects   bus.Subjects
+	controller *SolverController
+
+	mutex sync.Mutex
+	// when we last heard from each resource provider on the bus
+	heartbeats map[string]time.Time
+}
+
+func newSolverBusServer(b bus.Bus, subjects bus.Subjects, controller *SolverController) *solverBusServer {
+	server := &solverBusServer{
+		bus:        b,
+		subjects:   subjects,
+		controller: controller,
+		heartbeats: map[string]time.Time{},
+	}
+	controller.subscribeEvents(server.publish)
+	return server
+}
+
+func (server *solverBusServer) Start(ctx context.Context) error {
+	handlers := map[string]func(address string, payload json.RawMessage) (interface{}, error){
+		server.subjects.JobOffers():      server.addJobOffer,
+		server.subjects.ResourceOffers(): server.addResourceOffer,
+		server.subjects.Heartbeats():     server.heartbeat,
+	}
+	unsubscribes := []func(){}
+	for subject, handler := range handlers {
+		handler := handler
+		unsubscribe, err := server.bus.Subscribe(subject, func(msg bus.Message) {
+			server.handle(msg, handler)
+		})
+		if err != nil {
+			for _, unsubscribe := range unsubscribes {
+				unsubscribe()
+			}
+			return err
+		}
+		unsubscribes = append(unsubscribes, unsubscribe)
+	}
+	go func() {
+		ticker := time.NewTicker(BUS_HEARTBEAT_INTERVAL)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				for _, unsubscribe := range unsubscribes {
+					unsubscribe()
+				}
+				return
+			case <-ticker.C:
+				server.expireHeartbeats()
+			}
+		}
+	}()
+	return nil
+}
+
+func (server *solverBusServer) handle(msg bus.Message, handler func(address string, payload json.RawMessage) (interface{}, error)) {
+	var response BusResponse
+	result, err := server.handleRequest(msg.Data, handler)
+	if err != nil {
+		response.Error = err.Error()
+	} else if result != nil {
+		response.Data, err = json.Marshal(result)
+		if err != nil {
+			response.Error = err.Error()
+		}
+	}
+	if msg.Reply == "" {
+		return
+	}
+	responseBytes, err := json.Marshal(response)
+	if err != nil {
+		log.Error().Msgf("error marshalling bus response: %s", err.Error())
+		return
+	}
+	err = server.bus.Publish(msg.Reply, responseBytes)
+	if err != nil {
+		log.Error().Msgf("error answering bus request: %s", err.Error())
+	}
+}
+
+func (server *solverBusServer) handleRequest(requestBytes []byte, handler func(address string, payload json.RawMessage) (interface{}, error)) (interface{}, error) {
+	var request BusRequest
+	err := json.Unmarshal(requestBytes, &request)
+	if err != nil {
+		return nil, err
+	}
+	address, err := http.GetAddressFromUserHeaders(request.User, request.Signature)
+	if err != nil {
+		return nil, err
+	}
+	return handler(address, request.Data)
+}
+
+func (server *solverBusServer) addJobOffer(address string, payload json.RawMessage) (interface{}, error) {
+	var jobOffer data.JobOffer
+	err := json.Unmarshal(payload, &jobOffer)
+	if err != nil {
+		return nil, err
+	}
+	// only the job creator can post a job offer
+	if address != jobOffer.JobCreator {
+		return nil, fmt.Errorf("job creator address does not match signer address")
+	}
+	err = data.CheckJobOffer(jobOffer)
+	if err != nil {
+		return nil, err
+	}
+	return server.controller.addJobOffer(context.Background(), jobOffer)
+}
+
+func (server *solverBusServer) addResourceOffer(address string, payload json.RawMessage) (interface{}, error) {
+	var resourceOffer data.ResourceOffer
+	err := json.Unmarshal(payload, &resourceOffer)
+	if err != nil {
+		return nil, err
+	}
+	// only the resource provider can post a resource offer
+	if address != resourceOffer.ResourceProvider {
+		return nil, fmt.Errorf("resource provider address does not match signer address")
+	}
+	err = data.CheckResourceOffer(resourceOffer)
+	if err != nil {
+		return nil, err
+	}
+	server.touch(address)
+	return server.controller.addResourceOffer(context.Background(), resourceOffer)
+}
+
+func (server *solverBusServer) heartbeat(address string, payload json.RawMessage) (interface{}, error) {
+	server.touch(address)
+	return nil, nil
+}
+
+func (server *solverBusServer) touch(address string) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.heartbeats[address] = time.Now()
+}
+
+// a resource provider that has gone quiet loses its offers the same way
+// one does when its websocket disconnects
+func (server *solverBusServer) expireHeartbeats() {
+	expired := []string{}
+	server.mutex.Lock()
+	for address, last := range server.heartbeats {
+		if time.Since(last) > BUS_HEARTBEAT_INTERVAL*BUS_HEARTBEAT_MISSES {
+			expired = append(expired, address)
+			delete(server.heartbeats, address)
+		}
+	}
+	server.mutex.Unlock()
+	for _, address := range expired {
+		err := server.controller.removeResourceOfferByResourceProvider(address)
+		if err != nil {
+			log.Error().Msgf("error removing offers of quiet resource provider %s: %s", address, err.Error())
+		}
+	}
+}
+
+func (server *solverBusServer) publish(ev SolverEvent) {
+	evBytes, err := json.Marshal(ev)
+	if err != nil {
+		log.Error().Msgf("error marshalling event: %s", err.Error())
+		return
+	}
+	err = server.bus.Publish(server.subjects.Events(), evBytes)
+	if err != nil {
+		log.Debug().Msgf("error publishing event to the bus: %s", err.Error())
+	}
+}
@@ -0,0 +1,51 @@
+package solver
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lilypad-tech/lilypad/pkg/bus"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	memorystore "github.com/lilypad-tech/lilypad/pkg/solver/store/memory"
+	"github.com/lilypad-tech/lilypad/pkg/web3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBusServer(t *testing.T) {
+	s, err := memorystore.NewSolverStoreMemory(memorystore.SolverStoreMemoryOptions{LogDir: t.TempDir()})
+	require.NoError(t, err)
+	b := bus.NewMemoryBus()
+	defer b.Close()
+	subjects := bus.NewSubjects("")
+	server := newSolverBusServer(b, subjects, &SolverController{store: s})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, server.Start(ctx))
+
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer, err := web3.NewPrivateKeySigner(hex.EncodeToString(crypto.FromECDSA(privateKey)))
+	require.NoError(t, err)
+
+	// offers must be signed by whoever made them
+	offer := data.JobOffer{JobCreator: "0x1111111111111111111111111111111111111111"}
+	_, err = BusRequestResponse[data.JobOfferContainer](ctx, b, subjects.JobOffers(), signer, offer)
+	assert.ErrorContains(t, err, "does not match signer address")
+
+	_, err = BusRequestResponse[interface{}](ctx, b, subjects.Heartbeats(), signer, nil)
+	require.NoError(t, err)
+	server.mutex.Lock()
+	assert.Contains(t, server.heartbeats, signer.Address().String())
+	server.mutex.Unlock()
+
+	events := make(chan string, 1)
+	_, err = b.Subscribe(subjects.Events(), func(msg bus.Message) {
+		events <- string(msg.Data)
+	})
+	require.NoError(t, err)
+	server.publish(SolverEvent{EventType: DealAdded, Deal: &data.DealContainer{ID: "deal1"}})
+	assert.Contains(t, <-events, "deal1")
+}
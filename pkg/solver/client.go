@@ -1,20 +1,107 @@
 package solver
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"time"
 
+	"github.com/lilypad-tech/lilypad/pkg/bus"
 	"github.com/lilypad-tech/lilypad/pkg/data"
 	"github.com/lilypad-tech/lilypad/pkg/http"
+	"github.com/lilypad-tech/lilypad/pkg/solver/stats"
 	"github.com/lilypad-tech/lilypad/pkg/solver/store"
 	"github.com/lilypad-tech/lilypad/pkg/system"
+	"github.com/lilypad-tech/lilypad/pkg/web3"
 	"github.com/rs/zerolog/log"
 )
 
+// the solver api as seen by the other services
+// they take this rather than a *SolverClient so that
+// unit tests can swap in the mock from mock_solver
+//
+//go:generate mockgen -source=client.go -destination=mock_solver/mock_solver.go -package=mock_solver
+type SolverAPI interface {
+	Start(ctx context.Context, cm *system.CleanupManager) error
+	SubscribeEvents(handler func(SolverEvent))
+	GetJobOffers(query store.GetJobOffersQuery) ([]data.JobOfferContainer, error)
+	GetResourceOffers(query store.GetResourceOffersQuery) ([]data.ResourceOfferContainer, error)
+	GetDeals(query store.GetDealsQuery) ([]data.DealContainer, error)
+	GetDeal(id string) (data.DealContainer, error)
+	GetResult(id string) (data.Result, error)
+	GetAudit(id string) (data.Audit, error)
+	GetReputation(resourceProvider string) (data.ResourceProviderReputation, error)
+	GetBudget(jobCreator string) (data.BudgetStatus, error)
+	GetCostEstimate(query data.CostEstimateQuery) (data.CostEstimate, error)
+	SyncResourceProvider(resourceProvider string, since uint64, epoch string) (data.ResourceProviderSync, error)
+	GetStats() (stats.NetworkStats, error)
+	GetClientRecords(query store.GetClientRecordsQuery) ([]data.ClientRecord, error)
+	GetDealsWithFilter(query store.GetDealsQuery, filter func(data.DealContainer) bool) ([]data.DealContainer, error)
+	AddJobOffer(jobOffer data.JobOffer) (data.JobOfferContainer, error)
+	AddResourceOffer(resourceOffer data.ResourceOffer) (data.ResourceOfferContainer, error)
+	WithdrawResourceOffers(resourceProvider string) ([]data.ResourceOfferContainer, error)
+	AddResult(result data.Result) (data.Result, error)
+	UpdateTransactionsResourceProvider(id string, payload data.DealTransactionsResourceProvider) (data.DealContainer, error)
+	UpdateTransactionsJobCreator(id string, payload data.DealTransactionsJobCreator) (data.DealContainer, error)
+	UpdateTransactionsMediator(id string, payload data.DealTransactionsMediator) (data.DealContainer, error)
+	UploadResultFiles(id string, localPath string) (data.Result, error)
+	DownloadResultFiles(id string, localPath string) error
+	AddDealLogs(id string, chunks []data.DealLogChunk) ([]data.DealLogChunk, error)
+	GetDealLogs(id string, after uint64) ([]data.DealLogChunk, error)
+	UpdateDealCheckpoint(id string, cid string) (data.DealContainer, error)
+	AddDealEncryptedInputs(id string, encrypted data.DealEncryptedInputs) (data.DealContainer, error)
+	AcceptDealMilestone(id string, index uint64, signature string) (data.DealContainer, error)
+	PreemptDeal(id string, reason string) (data.DealContainer, error)
+	CancelJobOffer(id string) (data.JobOfferContainer, error)
+	RerunDeal(id string, request data.DealRerunRequest) (data.JobOfferContainer, error)
+	GetDealLineage(id string) (data.DealLineage, error)
+	SendServiceRequest(id string, request data.ServiceRequest) (data.ServiceResponse, error)
+	GetServiceRequests(id string) ([]data.ServiceRequest, error)
+	AddServiceResponse(id string, response data.ServiceResponse) (data.DealContainer, error)
+	AddMediationVerdict(id string, verdict data.MediationVerdict) (data.DealContainer, error)
+	AddJobGroup(submission data.JobGroupSubmission) (data.JobGroup, error)
+	GetJobGroup(id string) (data.JobGroupStatus, error)
+	AddJobSchedule(submission data.JobScheduleSubmission) (data.JobSchedule, error)
+	GetJobSchedules(query store.GetJobSchedulesQuery) ([]data.JobSchedule, error)
+	GetJobSchedule(id string) (data.JobSchedule, error)
+	UpdateJobSchedule(id string, update data.JobScheduleUpdate) (data.JobSchedule, error)
+	RemoveJobSchedule(id string) (data.JobSchedule, error)
+	AddCapacityReservation(submission data.CapacityReservationSubmission) (data.CapacityReservation, error)
+	GetCapacityReservations(query store.GetCapacityReservationsQuery) ([]data.CapacityReservation, error)
+	GetCapacityReservation(id string) (data.CapacityReservation, error)
+	CancelCapacityReservation(id string) (data.CapacityReservation, error)
+	GetAdminStats() (store.StoreStats, error)
+	GetAdminActions(query store.GetAdminActionsQuery) ([]data.AdminAction, error)
+	GetBannedAddresses() ([]data.BannedAddress, error)
+	BanAddress(submission data.BanSubmission) (data.BannedAddress, error)
+	UnbanAddress(address string, reason string) (data.BannedAddress, error)
+	ExpireJobOffer(id string, reason string) (data.JobOfferContainer, error)
+	ExpireResourceOffer(id string, reason string) (data.ResourceOfferContainer, error)
+	TriggerMatch(reason string) (data.AdminAction, error)
+	RefreshAllowlist(reason string) (data.AdminAction, error)
+	ExportState(localPath string) error
+	ImportState(localPath string) (data.AdminAction, error)
+	AddInputStaging(request data.InputStagingRequest) (data.InputStaging, error)
+	GetInputStagings(query store.GetInputStagingsQuery) ([]data.InputStaging, error)
+	GetInputStaging(id string) (data.InputStaging, error)
+	UpdateInputStaging(id string, update data.InputStagingUpdate) (data.InputStaging, error)
+	GetBillingRecords(query store.GetBillingRecordsQuery) ([]data.BillingRecord, error)
+	GetSlashings(query store.GetSlashingsQuery) ([]data.EscrowPayment, error)
+	AddWorkflow(submission data.WorkflowSubmission) (data.Workflow, error)
+	GetWorkflows(query store.GetWorkflowsQuery) ([]data.Workflow, error)
+	GetWorkflow(id string) (data.Workflow, error)
+	CancelWorkflow(id string) (data.Workflow, error)
+}
+
 type SolverClient struct {
 	options         http.ClientOptions
 	solverEventSubs []func(SolverEvent)
+	// when set offers are sent and events heard over the bus
+	bus      bus.Bus
+	subjects bus.Subjects
 }
 
 func NewSolverClient(
@@ -27,8 +114,70 @@ func NewSolverClient(
 	return client, nil
 }
 
+// we know the address of the solver but what is it's url?
+// look it up on chain and point a client at it
+func NewSolverClientFromWeb3(
+	web3SDK web3.Web3Client,
+	solverAddress string,
+	clientType string,
+) (*SolverClient, error) {
+	solverUrl, err := web3SDK.GetSolverUrl(solverAddress)
+	if err != nil {
+		return nil, err
+	}
+	return NewSolverClient(
+		http.ClientOptions{
+			URL:           solverUrl,
+			Signer:        web3SDK.GetSigner(),
+			Type:          clientType,
+			PublicAddress: web3SDK.GetAddress().String(),
+		})
+}
+
+// send offers and hear events over the bus rather than HTTP and the
+// websocket - this must be called before Start
+func (client *SolverClient) UseBus(options bus.BusOptions) error {
+	b, err := bus.NewBus(options)
+	if err != nil {
+		return err
+	}
+	client.bus = b
+	client.subjects = bus.NewSubjects(options.SubjectPrefix)
+	return nil
+}
+
+// speak the newest protocol version the solver serves so we keep working
+// with a solver that has not been upgraded yet
+// a solver we cannot reach gets the newest and we find out when we call it
+func (client *SolverClient) negotiateProtocol() error {
+	if client.options.ProtocolVersion > 0 {
+		return nil
+	}
+	protocolVersion, err := http.NegotiateProtocolVersion(client.options)
+	if err != nil {
+		if errors.Is(err, data.ErrUnsupportedProtocol) {
+			return err
+		}
+		log.Warn().Msgf("could not get the protocol versions the solver serves: %s", err.Error())
+		return nil
+	}
+	if protocolVersion < data.PROTOCOL_VERSION {
+		log.Info().Msgf("the solver serves protocol version %d, we will use it until it is upgraded", protocolVersion)
+	}
+	client.options.ProtocolVersion = protocolVersion
+	return nil
+}
+
 // connect the websocket to the solver server
 func (client *SolverClient) Start(ctx context.Context, cm *system.CleanupManager) error {
+	err := client.negotiateProtocol()
+	if err != nil {
+		return err
+	}
+
+	if client.bus != nil {
+		return client.startBus(ctx, cm)
+	}
 
 	websocketURL := fmt.Sprintf("%s%s%s%s%s", http.WEBSOCKET_SUB_PATH, "?&Type=", client.options.Type, "&ID=", client.options.PublicAddress)
 	websocketEventChannel := http.ConnectWebSocket(http.WebsocketURL(client.options, websocketURL), ctx)
@@ -55,6 +204,51 @@ func (client *SolverClient) Start(ctx context.Context, cm *system.CleanupManager
 	return nil
 }
 
+func (client *SolverClient) startBus(ctx context.Context, cm *system.CleanupManager) error {
+	cm.RegisterCallback(client.bus.Close)
+	_, err := client.bus.Subscribe(client.subjects.Events(), func(msg bus.Message) {
+		var ev SolverEvent
+		if err := json.Unmarshal(msg.Data, &ev); err != nil {
+			log.Error().Msgf("Error unmarshalling event: %s", err.Error())
+			return
+		}
+		for _, handler := range client.solverEventSubs {
+			go handler(ev)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	// the solver drops the offers of a resource provider it stops hearing from
+	// in the same way as when the websocket disconnects
+	if client.options.Type != "ResourceProvider" {
+		return nil
+	}
+	go func() {
+		ticker := time.NewTicker(BUS_HEARTBEAT_INTERVAL)
+		defer ticker.Stop()
+		for {
+			signer, err := http.GetClientSigner(client.options)
+			var request []byte
+			if err == nil {
+				request, err = NewBusRequest(signer, nil)
+			}
+			if err == nil {
+				err = client.bus.Publish(client.subjects.Heartbeats(), request)
+			}
+			if err != nil {
+				log.Debug().Msgf("error sending heartbeat to the bus: %s", err.Error())
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
 func (client *SolverClient) SubscribeEvents(handler func(SolverEvent)) {
 	client.solverEventSubs = append(client.solverEventSubs, handler)
 }
@@ -67,6 +261,12 @@ func (client *SolverClient) GetJobOffers(query store.GetJobOffersQuery) ([]data.
 	if query.NotMatched {
 		queryParams["not_matched"] = "true"
 	}
+	if query.IncludeCancelled {
+		queryParams["include_cancelled"] = "true"
+	}
+	if query.ChainID != 0 {
+		queryParams["chain_id"] = fmt.Sprintf("%d", query.ChainID)
+	}
 	return http.GetRequest[[]data.JobOfferContainer](client.options, "/job_offers", queryParams)
 }
 
@@ -81,6 +281,9 @@ func (client *SolverClient) GetResourceOffers(query store.GetResourceOffersQuery
 	if query.NotMatched {
 		queryParams["not_matched"] = "true"
 	}
+	if query.ChainID != 0 {
+		queryParams["chain_id"] = fmt.Sprintf("%d", query.ChainID)
+	}
 	return http.GetRequest[[]data.ResourceOfferContainer](client.options, "/resource_offers", queryParams)
 }
 
@@ -95,6 +298,12 @@ func (client *SolverClient) GetDeals(query store.GetDealsQuery) ([]data.DealCont
 	if query.State != "" {
 		queryParams["state"] = query.State
 	}
+	if query.ChainID != 0 {
+		queryParams["chain_id"] = fmt.Sprintf("%d", query.ChainID)
+	}
+	if query.ParentDealID != "" {
+		queryParams["parent_deal_id"] = query.ParentDealID
+	}
 	return http.GetRequest[[]data.DealContainer](client.options, "/deals", queryParams)
 }
 
@@ -106,6 +315,72 @@ func (client *SolverClient) GetResult(id string) (data.Result, error) {
 	return http.GetRequest[data.Result](client.options, fmt.Sprintf("/deals/%s/result", id), map[string]string{})
 }
 
+func (client *SolverClient) GetAudit(id string) (data.Audit, error) {
+	return http.GetRequest[data.Audit](client.options, fmt.Sprintf("/deals/%s/audit", id), map[string]string{})
+}
+
+func (client *SolverClient) GetReputation(resourceProvider string) (data.ResourceProviderReputation, error) {
+	return http.GetRequest[data.ResourceProviderReputation](client.options, fmt.Sprintf("/resource_providers/%s/reputation", resourceProvider), map[string]string{})
+}
+
+func (client *SolverClient) GetBudget(jobCreator string) (data.BudgetStatus, error) {
+	return http.SignedGetRequest[data.BudgetStatus](client.options, fmt.Sprintf("/job_creators/%s/budget", jobCreator), map[string]string{})
+}
+
+func (client *SolverClient) GetCostEstimate(query data.CostEstimateQuery) (data.CostEstimate, error) {
+	queryParams := map[string]string{}
+	if query.Module.Name != "" {
+		queryParams["module"] = query.Module.Name
+	}
+	if query.Module.Repo != "" {
+		queryParams["repo"] = query.Module.Repo
+	}
+	if query.Module.Hash != "" {
+		queryParams["hash"] = query.Module.Hash
+	}
+	if query.Module.Path != "" {
+		queryParams["path"] = query.Module.Path
+	}
+	if query.Spec.CPU != 0 {
+		queryParams["cpu"] = fmt.Sprintf("%d", query.Spec.CPU)
+	}
+	if query.Spec.GPU != 0 {
+		queryParams["gpu"] = fmt.Sprintf("%d", query.Spec.GPU)
+	}
+	if query.Spec.RAM != 0 {
+		queryParams["ram"] = fmt.Sprintf("%d", query.Spec.RAM)
+	}
+	if query.Instructions != 0 {
+		queryParams["instructions"] = fmt.Sprintf("%d", query.Instructions)
+	}
+	if query.ChainID != 0 {
+		queryParams["chain_id"] = fmt.Sprintf("%d", query.ChainID)
+	}
+	return http.GetRequest[data.CostEstimate](client.options, "/estimate", queryParams)
+}
+
+func (client *SolverClient) SyncResourceProvider(resourceProvider string, since uint64, epoch string) (data.ResourceProviderSync, error) {
+	return http.GetRequest[data.ResourceProviderSync](client.options, fmt.Sprintf("/resource_providers/%s/sync", resourceProvider), map[string]string{
+		"since": fmt.Sprintf("%d", since),
+		"epoch": epoch,
+	})
+}
+
+func (client *SolverClient) GetStats() (stats.NetworkStats, error) {
+	return http.GetRequest[stats.NetworkStats](client.options, "/stats", map[string]string{})
+}
+
+func (client *SolverClient) GetClientRecords(query store.GetClientRecordsQuery) ([]data.ClientRecord, error) {
+	queryParams := map[string]string{}
+	if query.Role != "" {
+		queryParams["role"] = query.Role
+	}
+	if query.Since > 0 {
+		queryParams["since"] = fmt.Sprintf("%d", query.Since)
+	}
+	return http.GetRequest[[]data.ClientRecord](client.options, "/clients", queryParams)
+}
+
 func (client *SolverClient) GetDealsWithFilter(query store.GetDealsQuery, filter func(data.DealContainer) bool) ([]data.DealContainer, error) {
 	deals, err := client.GetDeals(query)
 	if err != nil {
@@ -121,13 +396,34 @@ func (client *SolverClient) GetDealsWithFilter(query store.GetDealsQuery, filter
 }
 
 func (client *SolverClient) AddJobOffer(jobOffer data.JobOffer) (data.JobOfferContainer, error) {
+	if client.bus != nil {
+		signer, err := http.GetClientSigner(client.options)
+		if err != nil {
+			return data.JobOfferContainer{}, err
+		}
+		return BusRequestResponse[data.JobOfferContainer](context.Background(), client.bus, client.subjects.JobOffers(), signer, jobOffer)
+	}
 	return http.PostRequest[data.JobOffer, data.JobOfferContainer](client.options, "/job_offers", jobOffer)
 }
 
 func (client *SolverClient) AddResourceOffer(resourceOffer data.ResourceOffer) (data.ResourceOfferContainer, error) {
+	if client.bus != nil {
+		signer, err := http.GetClientSigner(client.options)
+		if err != nil {
+			return data.ResourceOfferContainer{}, err
+		}
+		return BusRequestResponse[data.ResourceOfferContainer](context.Background(), client.bus, client.subjects.ResourceOffers(), signer, resourceOffer)
+	}
 	return http.PostRequest[data.ResourceOffer, data.ResourceOfferContainer](client.options, "/resource_offers", resourceOffer)
 }
 
+// remove all of our resource offers that have not yet been matched
+func (client *SolverClient) WithdrawResourceOffers(resourceProvider string) ([]data.ResourceOfferContainer, error) {
+	return http.PostRequest[store.GetResourceOffersQuery, []data.ResourceOfferContainer](client.options, "/resource_offers/withdraw", store.GetResourceOffersQuery{
+		ResourceProvider: resourceProvider,
+	})
+}
+
 func (client *SolverClient) AddResult(result data.Result) (data.Result, error) {
 	return http.PostRequest[data.Result, data.Result](client.options, fmt.Sprintf("/deals/%s/result", result.DealID), result)
 }
@@ -152,10 +448,273 @@ func (client *SolverClient) UploadResultFiles(id string, localPath string) (data
 	return http.PostRequestBuffer[data.Result](client.options, fmt.Sprintf("/deals/%s/files", id), buf)
 }
 
+// download the result archive and expand it into localPath
+// if the download is interrupted it resumes from where it got to
 func (client *SolverClient) DownloadResultFiles(id string, localPath string) error {
-	buf, err := http.GetRequestBuffer(client.options, fmt.Sprintf("/deals/%s/files", id), map[string]string{})
+	archivePath := localPath + ".tar"
+	err := http.DownloadFile(context.Background(), client.options, fmt.Sprintf("/deals/%s/files", id), archivePath)
+	if err != nil {
+		return err
+	}
+	err = system.ExpandTarFile(archivePath, localPath)
+	if err != nil {
+		return err
+	}
+	return os.Remove(archivePath)
+}
+
+func (client *SolverClient) AddDealLogs(id string, chunks []data.DealLogChunk) ([]data.DealLogChunk, error) {
+	return http.PostRequest[[]data.DealLogChunk, []data.DealLogChunk](client.options, fmt.Sprintf("/deals/%s/logs", id), chunks)
+}
+
+func (client *SolverClient) GetDealLogs(id string, after uint64) ([]data.DealLogChunk, error) {
+	queryParams := map[string]string{}
+	if after > 0 {
+		queryParams["after"] = fmt.Sprintf("%d", after)
+	}
+	return http.SignedGetRequest[[]data.DealLogChunk](client.options, fmt.Sprintf("/deals/%s/logs", id), queryParams)
+}
+
+func (client *SolverClient) UpdateDealCheckpoint(id string, cid string) (data.DealContainer, error) {
+	return http.PostRequest[data.DealCheckpoint, data.DealContainer](client.options, fmt.Sprintf("/deals/%s/checkpoint", id), data.DealCheckpoint{DealID: id, CID: cid})
+}
+
+func (client *SolverClient) AddDealEncryptedInputs(id string, encrypted data.DealEncryptedInputs) (data.DealContainer, error) {
+	return http.PostRequest[data.DealEncryptedInputs, data.DealContainer](client.options, fmt.Sprintf("/deals/%s/encrypted_inputs", id), encrypted)
+}
+
+func (client *SolverClient) AcceptDealMilestone(id string, index uint64, signature string) (data.DealContainer, error) {
+	return http.PostRequest[data.DealMilestoneAcceptance, data.DealContainer](client.options, fmt.Sprintf("/deals/%s/milestones/accept", id), data.DealMilestoneAcceptance{DealID: id, Index: index, Signature: signature})
+}
+
+func (client *SolverClient) PreemptDeal(id string, reason string) (data.DealContainer, error) {
+	return http.PostRequest[data.DealPreemption, data.DealContainer](client.options, fmt.Sprintf("/deals/%s/preempt", id), data.DealPreemption{DealID: id, Reason: reason})
+}
+
+func (client *SolverClient) CancelJobOffer(id string) (data.JobOfferContainer, error) {
+	return http.PostRequest[data.JobOfferCancellation, data.JobOfferContainer](client.options, fmt.Sprintf("/job_offers/%s/cancel", id), data.JobOfferCancellation{JobOfferID: id})
+}
+
+func (client *SolverClient) RerunDeal(id string, request data.DealRerunRequest) (data.JobOfferContainer, error) {
+	return http.PostRequest[data.DealRerunRequest, data.JobOfferContainer](client.options, fmt.Sprintf("/deals/%s/rerun", id), request)
+}
+
+func (client *SolverClient) GetDealLineage(id string) (data.DealLineage, error) {
+	return http.GetRequest[data.DealLineage](client.options, fmt.Sprintf("/deals/%s/lineage", id), map[string]string{})
+}
+
+func (client *SolverClient) SendServiceRequest(id string, request data.ServiceRequest) (data.ServiceResponse, error) {
+	return http.PostRequest[data.ServiceRequest, data.ServiceResponse](client.options, fmt.Sprintf("/deals/%s/service/requests", id), request)
+}
+
+func (client *SolverClient) GetServiceRequests(id string) ([]data.ServiceRequest, error) {
+	return http.SignedGetRequest[[]data.ServiceRequest](client.options, fmt.Sprintf("/deals/%s/service/requests", id), map[string]string{})
+}
+
+func (client *SolverClient) AddServiceResponse(id string, response data.ServiceResponse) (data.DealContainer, error) {
+	return http.PostRequest[data.ServiceResponse, data.DealContainer](client.options, fmt.Sprintf("/deals/%s/service/responses", id), response)
+}
+
+func (client *SolverClient) AddMediationVerdict(id string, verdict data.MediationVerdict) (data.DealContainer, error) {
+	return http.PostRequest[data.MediationVerdict, data.DealContainer](client.options, fmt.Sprintf("/deals/%s/mediation_verdicts", id), verdict)
+}
+
+func (client *SolverClient) AddJobGroup(submission data.JobGroupSubmission) (data.JobGroup, error) {
+	return http.PostRequest[data.JobGroupSubmission, data.JobGroup](client.options, "/job_groups", submission)
+}
+
+func (client *SolverClient) GetJobGroup(id string) (data.JobGroupStatus, error) {
+	return http.GetRequest[data.JobGroupStatus](client.options, fmt.Sprintf("/job_groups/%s", id), map[string]string{})
+}
+
+func (client *SolverClient) AddJobSchedule(submission data.JobScheduleSubmission) (data.JobSchedule, error) {
+	return http.PostRequest[data.JobScheduleSubmission, data.JobSchedule](client.options, "/job_schedules", submission)
+}
+
+func (client *SolverClient) GetJobSchedules(query store.GetJobSchedulesQuery) ([]data.JobSchedule, error) {
+	queryParams := map[string]string{}
+	if query.JobCreator != "" {
+		queryParams["job_creator"] = query.JobCreator
+	}
+	return http.GetRequest[[]data.JobSchedule](client.options, "/job_schedules", queryParams)
+}
+
+func (client *SolverClient) GetJobSchedule(id string) (data.JobSchedule, error) {
+	return http.GetRequest[data.JobSchedule](client.options, fmt.Sprintf("/job_schedules/%s", id), map[string]string{})
+}
+
+func (client *SolverClient) UpdateJobSchedule(id string, update data.JobScheduleUpdate) (data.JobSchedule, error) {
+	return http.PostRequest[data.JobScheduleUpdate, data.JobSchedule](client.options, fmt.Sprintf("/job_schedules/%s", id), update)
+}
+
+func (client *SolverClient) RemoveJobSchedule(id string) (data.JobSchedule, error) {
+	return http.PostRequest[data.JobScheduleRemoval, data.JobSchedule](client.options, fmt.Sprintf("/job_schedules/%s/remove", id), data.JobScheduleRemoval{JobScheduleID: id})
+}
+
+func (client *SolverClient) AddCapacityReservation(submission data.CapacityReservationSubmission) (data.CapacityReservation, error) {
+	return http.PostRequest[data.CapacityReservationSubmission, data.CapacityReservation](client.options, "/capacity_reservations", submission)
+}
+
+func (client *SolverClient) GetCapacityReservations(query store.GetCapacityReservationsQuery) ([]data.CapacityReservation, error) {
+	queryParams := map[string]string{}
+	if query.JobCreator != "" {
+		queryParams["job_creator"] = query.JobCreator
+	}
+	if query.ResourceProvider != "" {
+		queryParams["resource_provider"] = query.ResourceProvider
+	}
+	if query.Open {
+		queryParams["open"] = "true"
+	}
+	return http.GetRequest[[]data.CapacityReservation](client.options, "/capacity_reservations", queryParams)
+}
+
+func (client *SolverClient) GetCapacityReservation(id string) (data.CapacityReservation, error) {
+	return http.GetRequest[data.CapacityReservation](client.options, fmt.Sprintf("/capacity_reservations/%s", id), map[string]string{})
+}
+
+func (client *SolverClient) CancelCapacityReservation(id string) (data.CapacityReservation, error) {
+	return http.PostRequest[data.CapacityReservationCancellation, data.CapacityReservation](client.options, fmt.Sprintf("/capacity_reservations/%s/cancel", id), data.CapacityReservationCancellation{ReservationID: id})
+}
+
+// the admin requests are signed by the client key like every other request
+// so the key has to be one of the solver's admin addresses
+func (client *SolverClient) GetAdminStats() (store.StoreStats, error) {
+	return http.SignedGetRequest[store.StoreStats](client.options, "/admin/stats", map[string]string{})
+}
+
+func (client *SolverClient) GetAdminActions(query store.GetAdminActionsQuery) ([]data.AdminAction, error) {
+	queryParams := map[string]string{}
+	if query.Admin != "" {
+		queryParams["admin"] = query.Admin
+	}
+	if query.Limit > 0 {
+		queryParams["limit"] = fmt.Sprintf("%d", query.Limit)
+	}
+	return http.SignedGetRequest[[]data.AdminAction](client.options, "/admin/actions", queryParams)
+}
+
+func (client *SolverClient) GetBannedAddresses() ([]data.BannedAddress, error) {
+	return http.SignedGetRequest[[]data.BannedAddress](client.options, "/admin/banned_addresses", map[string]string{})
+}
+
+func (client *SolverClient) BanAddress(submission data.BanSubmission) (data.BannedAddress, error) {
+	return http.PostRequest[data.BanSubmission, data.BannedAddress](client.options, "/admin/banned_addresses", submission)
+}
+
+func (client *SolverClient) UnbanAddress(address string, reason string) (data.BannedAddress, error) {
+	return http.PostRequest[data.AdminRequest, data.BannedAddress](client.options, fmt.Sprintf("/admin/banned_addresses/%s/remove", address), data.AdminRequest{Reason: reason})
+}
+
+func (client *SolverClient) ExpireJobOffer(id string, reason string) (data.JobOfferContainer, error) {
+	return http.PostRequest[data.AdminRequest, data.JobOfferContainer](client.options, fmt.Sprintf("/admin/job_offers/%s/expire", id), data.AdminRequest{Reason: reason})
+}
+
+func (client *SolverClient) ExpireResourceOffer(id string, reason string) (data.ResourceOfferContainer, error) {
+	return http.PostRequest[data.AdminRequest, data.ResourceOfferContainer](client.options, fmt.Sprintf("/admin/resource_offers/%s/expire", id), data.AdminRequest{Reason: reason})
+}
+
+func (client *SolverClient) TriggerMatch(reason string) (data.AdminAction, error) {
+	return http.PostRequest[data.AdminRequest, data.AdminAction](client.options, "/admin/match", data.AdminRequest{Reason: reason})
+}
+
+func (client *SolverClient) RefreshAllowlist(reason string) (data.AdminAction, error) {
+	return http.PostRequest[data.AdminRequest, data.AdminAction](client.options, "/admin/allowlist/refresh", data.AdminRequest{Reason: reason})
+}
+
+// write the state of the solver to localPath as a zstd compressed tar
+func (client *SolverClient) ExportState(localPath string) error {
+	buf, err := http.SignedGetRequestBuffer(client.options, "/admin/export", map[string]string{})
 	if err != nil {
 		return err
 	}
-	return system.ExpandTarBuffer(buf, localPath)
+	return os.WriteFile(localPath, buf.Bytes(), 0644)
 }
+
+func (client *SolverClient) ImportState(localPath string) (data.AdminAction, error) {
+	contents, err := os.ReadFile(localPath)
+	if err != nil {
+		return data.AdminAction{}, err
+	}
+	return http.PostRequestBuffer[data.AdminAction](client.options, "/admin/import", bytes.NewBuffer(contents))
+}
+
+func (client *SolverClient) AddInputStaging(request data.InputStagingRequest) (data.InputStaging, error) {
+	return http.PostRequest[data.InputStagingRequest, data.InputStaging](client.options, "/input_stagings", request)
+}
+
+func (client *SolverClient) GetInputStagings(query store.GetInputStagingsQuery) ([]data.InputStaging, error) {
+	queryParams := map[string]string{}
+	if query.JobCreator != "" {
+		queryParams["job_creator"] = query.JobCreator
+	}
+	if query.ResourceProvider != "" {
+		queryParams["resource_provider"] = query.ResourceProvider
+	}
+	if query.State != "" {
+		queryParams["state"] = query.State
+	}
+	return http.GetRequest[[]data.InputStaging](client.options, "/input_stagings", queryParams)
+}
+
+func (client *SolverClient) GetInputStaging(id string) (data.InputStaging, error) {
+	return http.GetRequest[data.InputStaging](client.options, fmt.Sprintf("/input_stagings/%s", id), map[string]string{})
+}
+
+func (client *SolverClient) UpdateInputStaging(id string, update data.InputStagingUpdate) (data.InputStaging, error) {
+	return http.PostRequest[data.InputStagingUpdate, data.InputStaging](client.options, fmt.Sprintf("/input_stagings/%s", id), update)
+}
+
+func (client *SolverClient) GetBillingRecords(query store.GetBillingRecordsQuery) ([]data.BillingRecord, error) {
+	queryParams := map[string]string{}
+	if query.JobCreator != "" {
+		queryParams["job_creator"] = query.JobCreator
+	}
+	if query.ResourceProvider != "" {
+		queryParams["resource_provider"] = query.ResourceProvider
+	}
+	if query.From != 0 {
+		queryParams["from"] = fmt.Sprintf("%d", query.From)
+	}
+	if query.To != 0 {
+		queryParams["to"] = fmt.Sprintf("%d", query.To)
+	}
+	return http.SignedGetRequest[[]data.BillingRecord](client.options, "/billing", queryParams)
+}
+
+func (client *SolverClient) GetSlashings(query store.GetSlashingsQuery) ([]data.EscrowPayment, error) {
+	queryParams := map[string]string{}
+	if query.Payee != "" {
+		queryParams["payee"] = query.Payee
+	}
+	if query.From != 0 {
+		queryParams["from"] = fmt.Sprintf("%d", query.From)
+	}
+	return http.GetRequest[[]data.EscrowPayment](client.options, "/slashings", queryParams)
+}
+
+func (client *SolverClient) AddWorkflow(submission data.WorkflowSubmission) (data.Workflow, error) {
+	return http.PostRequest[data.WorkflowSubmission, data.Workflow](client.options, "/workflows", submission)
+}
+
+func (client *SolverClient) GetWorkflows(query store.GetWorkflowsQuery) ([]data.Workflow, error) {
+	queryParams := map[string]string{}
+	if query.JobCreator != "" {
+		queryParams["job_creator"] = query.JobCreator
+	}
+	if query.Running {
+		queryParams["running"] = "true"
+	}
+	return http.GetRequest[[]data.Workflow](client.options, "/workflows", queryParams)
+}
+
+func (client *SolverClient) GetWorkflow(id string) (data.Workflow, error) {
+	return http.GetRequest[data.Workflow](client.options, fmt.Sprintf("/workflows/%s", id), map[string]string{})
+}
+
+func (client *SolverClient) CancelWorkflow(id string) (data.Workflow, error) {
+	return http.PostRequest[data.WorkflowCancellation, data.Workflow](client.options, fmt.Sprintf("/workflows/%s/cancel", id), data.WorkflowCancellation{WorkflowID: id})
+}
+
+// Compile-time interface check:
+var _ SolverAPI = (*SolverClient)(nil)
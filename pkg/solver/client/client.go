@@ -0,0 +1,378 @@
+// Package client is a typed Go client for the solver HTTP API.
+//
+// It is for programs outside of this repo that want to post offers to a
+// solver and follow their deals without hand writing the HTTP calls:
+//
+//	c, err := client.New(client.Options{URL: "http://localhost:8080", PrivateKey: key})
+//	jobOffer, err := c.SubmitJobOffer(ctx, offer)
+//	events, err := c.StreamEvents(ctx)
+//	logs, err := c.FollowDealLogs(ctx, jobOffer.DealID)
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	stdlog "log"
+	corehttp "net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/http"
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+	"github.com/lilypad-tech/lilypad/pkg/web3"
+)
+
+type RetryOptions struct {
+	// how many times we retry a request that failed with a
+	// connection error or a 5xx before giving up
+	MaxRetries int
+	// the backoff between attempts doubles from MinWait up to MaxWait
+	MinWait time.Duration
+	MaxWait time.Duration
+}
+
+type Options struct {
+	// the base URL of the solver e.g. http://localhost:8080
+	URL string
+	// the hex private key we sign requests with
+	// it is only needed to submit offers, reads work without it
+	PrivateKey string
+	// signs requests in place of the private key when it is set
+	Signer web3.Signer
+	// the kind of client we tell the solver we are when streaming events
+	// the solver only treats "ResourceProvider" specially
+	Type  string
+	Retry RetryOptions
+}
+
+func GetDefaultRetryOptions() RetryOptions {
+	return RetryOptions{
+		MaxRetries: 10,
+		MinWait:    time.Second,
+		MaxWait:    30 * time.Second,
+	}
+}
+
+// a request the solver answered with an error status
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("solver returned %d: %s", e.StatusCode, e.Message)
+}
+
+type Client struct {
+	options    Options
+	httpClient *retryablehttp.Client
+	signer     web3.Signer
+	address    string
+}
+
+func New(options Options) (*Client, error) {
+	if options.URL == "" {
+		return nil, fmt.Errorf("solver URL is required")
+	}
+	if options.Retry == (RetryOptions{}) {
+		options.Retry = GetDefaultRetryOptions()
+	}
+	if options.Type == "" {
+		options.Type = "Client"
+	}
+
+	httpClient := retryablehttp.NewClient()
+	httpClient.RetryMax = options.Retry.MaxRetries
+	httpClient.RetryWaitMin = options.Retry.MinWait
+	httpClient.RetryWaitMax = options.Retry.MaxWait
+	httpClient.Logger = stdlog.New(io.Discard, "", stdlog.LstdFlags)
+	// hand back the last response so we can turn it into an *Error
+	httpClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+
+	client := &Client{
+		options:    options,
+		httpClient: httpClient,
+	}
+	if options.Signer != nil {
+		client.signer = options.Signer
+	} else if options.PrivateKey != "" {
+		signer, err := web3.NewPrivateKeySigner(options.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
+		client.signer = signer
+	}
+	if client.signer != nil {
+		client.address = client.signer.Address().String()
+	}
+	return client, nil
+}
+
+// the address requests are signed as, empty for a read only client
+func (client *Client) Address() string {
+	return client.address
+}
+
+func (client *Client) clientOptions() http.ClientOptions {
+	return http.ClientOptions{
+		URL:           client.options.URL,
+		PrivateKey:    client.options.PrivateKey,
+		Signer:        client.signer,
+		PublicAddress: client.address,
+		Type:          client.options.Type,
+	}
+}
+
+func (client *Client) do(ctx context.Context, method string, path string, query url.Values, body interface{}, result interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(bs)
+	}
+
+	reqURL := http.URL(client.clientOptions(), path)
+	if len(query) > 0 {
+		reqURL = fmt.Sprintf("%s?%s", reqURL, query.Encode())
+	}
+	req, err := retryablehttp.NewRequestWithContext(ctx, method, reqURL, reqBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	// the solver uses the signed address to check the offer belongs to us
+	if client.signer != nil {
+		err = http.AddHeaders(req, client.signer, client.address)
+		if err != nil {
+			return err
+		}
+	} else if method != corehttp.MethodGet {
+		return fmt.Errorf("a private key or signer is required for %s %s", method, path)
+	}
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 400 {
+		return &Error{
+			StatusCode: resp.StatusCode,
+			Message:    string(bytes.TrimSpace(respBody)),
+		}
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(respBody, result)
+}
+
+// post a job offer, it must be signed by the job creator it names
+func (client *Client) SubmitJobOffer(ctx context.Context, jobOffer data.JobOffer) (data.JobOfferContainer, error) {
+	var result data.JobOfferContainer
+	err := client.do(ctx, corehttp.MethodPost, "/job_offers", nil, jobOffer, &result)
+	return result, err
+}
+
+// post a resource offer, it must be signed by the resource provider it names
+func (client *Client) SubmitResourceOffer(ctx context.Context, resourceOffer data.ResourceOffer) (data.ResourceOfferContainer, error) {
+	var result data.ResourceOfferContainer
+	err := client.do(ctx, corehttp.MethodPost, "/resource_offers", nil, resourceOffer, &result)
+	return result, err
+}
+
+func (client *Client) GetDeals(ctx context.Context, query store.GetDealsQuery) ([]data.DealContainer, error) {
+	params := url.Values{}
+	if query.JobCreator != "" {
+		params.Set("job_creator", query.JobCreator)
+	}
+	if query.ResourceProvider != "" {
+		params.Set("resource_provider", query.ResourceProvider)
+	}
+	if query.State != "" {
+		params.Set("state", query.State)
+	}
+	if query.ChainID != 0 {
+		params.Set("chain_id", fmt.Sprintf("%d", query.ChainID))
+	}
+	result := []data.DealContainer{}
+	err := client.do(ctx, corehttp.MethodGet, "/deals", params, nil, &result)
+	return result, err
+}
+
+func (client *Client) GetDeal(ctx context.Context, id string) (data.DealContainer, error) {
+	var result data.DealContainer
+	err := client.do(ctx, corehttp.MethodGet, fmt.Sprintf("/deals/%s", id), nil, nil, &result)
+	return result, err
+}
+
+func (client *Client) GetResult(ctx context.Context, dealID string) (data.Result, error) {
+	var result data.Result
+	err := client.do(ctx, corehttp.MethodGet, fmt.Sprintf("/deals/%s/result", dealID), nil, nil, &result)
+	return result, err
+}
+
+// the receipt the solver signed for a deal, this checks the signature
+// is from the solver the receipt names but not that it is the one you expect
+func (client *Client) GetDealReceipt(ctx context.Context, dealID string) (data.DealReceipt, error) {
+	var receipt data.DealReceipt
+	err := client.do(ctx, corehttp.MethodGet, fmt.Sprintf("/deals/%s/receipt", dealID), nil, nil, &receipt)
+	if err != nil {
+		return receipt, err
+	}
+	return receipt, web3.VerifyDealReceipt(receipt)
+}
+
+// the transactions the solver sent to the chain
+func (client *Client) GetTransactions(ctx context.Context, query store.GetTransactionsQuery) ([]data.Transaction, error) {
+	params := url.Values{}
+	if query.DealID != "" {
+		params.Set("deal_id", query.DealID)
+	}
+	if query.Status != "" {
+		params.Set("status", query.Status)
+	}
+	if query.ChainID != 0 {
+		params.Set("chain_id", fmt.Sprintf("%d", query.ChainID))
+	}
+	result := []data.Transaction{}
+	err := client.do(ctx, corehttp.MethodGet, "/transactions", params, nil, &result)
+	return result, err
+}
+
+func (client *Client) GetTransaction(ctx context.Context, id string) (data.Transaction, error) {
+	var result data.Transaction
+	err := client.do(ctx, corehttp.MethodGet, fmt.Sprintf("/transactions/%s", id), nil, nil, &result)
+	return result, err
+}
+
+// the pinning services keeping a copy of a deal's result
+func (client *Client) GetResultPins(ctx context.Context, dealID string) ([]data.ResultPin, error) {
+	result := []data.ResultPin{}
+	err := client.do(ctx, corehttp.MethodGet, fmt.Sprintf("/deals/%s/result/pins", dealID), nil, nil, &result)
+	return result, err
+}
+
+// download the result files for a deal and expand them into localPath
+// an interrupted download is resumed rather than started again
+// and the archive is checked against the solver's checksum
+func (client *Client) DownloadResult(ctx context.Context, dealID string, localPath string) error {
+	archivePath := localPath + ".tar"
+	err := http.DownloadFile(ctx, client.clientOptions(), fmt.Sprintf("/deals/%s/files", dealID), archivePath)
+	if err != nil {
+		return err
+	}
+	err = system.ExpandTarFile(archivePath, localPath)
+	if err != nil {
+		return err
+	}
+	return os.Remove(archivePath)
+}
+
+// download the result files for a deal by way of the solver's IPFS node
+// the solver checks every block against the result CID as it streams
+// and we only expand the archive if it tells us they all matched
+func (client *Client) DownloadVerifiedResult(ctx context.Context, dealID string, localPath string) error {
+	req, err := corehttp.NewRequestWithContext(ctx, corehttp.MethodGet, http.URL(client.clientOptions(), fmt.Sprintf("/deals/%s/result/archive", dealID)), nil)
+	if err != nil {
+		return err
+	}
+	// the archive can be large and slow to fetch from IPFS so this
+	// does not go through the retrying client which has a timeout
+	resp, err := corehttp.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return &Error{
+			StatusCode: resp.StatusCode,
+			Message:    string(bytes.TrimSpace(respBody)),
+		}
+	}
+
+	archivePath := localPath + ".tar"
+	archive, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(archive, resp.Body)
+	closeErr := archive.Close()
+	if err == nil {
+		err = closeErr
+	}
+	// trailers are only filled in once the body has been read
+	if err == nil && resp.Trailer.Get(http.X_LILYPAD_VERIFIED_HEADER) != "true" {
+		err = fmt.Errorf("result for deal %s could not be verified against %s", dealID, resp.Header.Get(http.X_LILYPAD_CID_HEADER))
+	}
+	if err != nil {
+		os.Remove(archivePath)
+		return err
+	}
+	err = system.ExpandTarFile(archivePath, localPath)
+	if err != nil {
+		return err
+	}
+	return os.Remove(archivePath)
+}
+
+// stream the solver events until the context is done
+// we reconnect with the retry backoff if the connection drops
+// so the channel is only closed once the context is cancelled
+func (client *Client) StreamEvents(ctx context.Context) (<-chan solver.SolverEvent, error) {
+	params := url.Values{}
+	params.Set("Type", client.options.Type)
+	params.Set("ID", client.address)
+	wsURL := fmt.Sprintf("%s?%s", http.WebsocketURL(client.clientOptions(), http.WEBSOCKET_SUB_PATH), params.Encode())
+
+	// connect once up front so a bad URL is reported to the caller
+	conn, err := dialWebsocket(ctx, wsURL)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan solver.SolverEvent)
+	go func() {
+		defer close(events)
+		wait := client.options.Retry.MinWait
+		for {
+			readEvents(ctx, conn, events)
+			conn.Close()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(wait):
+				}
+				conn, err = dialWebsocket(ctx, wsURL)
+				if err == nil {
+					wait = client.options.Retry.MinWait
+					break
+				}
+				wait *= 2
+				if wait > client.options.Retry.MaxWait {
+					wait = client.options.Retry.MaxWait
+				}
+			}
+		}
+	}()
+	return events, nil
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	corehttp "net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/http"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+	"github.com/stretchr/testify/assert"
+)
+
+// the well known first hardhat account
+const testPrivateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+var testRetry = RetryOptions{MaxRetries: 2, MinWait: time.Millisecond, MaxWait: time.Millisecond}
+
+func TestGetDealsRetries(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(corehttp.HandlerFunc(func(res corehttp.ResponseWriter, req *corehttp.Request) {
+		attempts++
+		if attempts == 1 {
+			corehttp.Error(res, "try again", corehttp.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, http.API_SUB_PATH+"/deals", req.URL.Path)
+		assert.Equal(t, "0xjc", req.URL.Query().Get("job_creator"))
+		json.NewEncoder(res).Encode([]data.DealContainer{{ID: "deal"}})
+	}))
+	defer server.Close()
+
+	client, err := New(Options{URL: server.URL, Retry: testRetry})
+	assert.NoError(t, err)
+	deals, err := client.GetDeals(context.Background(), store.GetDealsQuery{JobCreator: "0xjc"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, []data.DealContainer{{ID: "deal"}}, deals)
+}
+
+func TestSubmitJobOfferSigned(t *testing.T) {
+	server := httptest.NewServer(corehttp.HandlerFunc(func(res corehttp.ResponseWriter, req *corehttp.Request) {
+		address, err := http.GetAddressFromHeaders(req)
+		if err != nil {
+			corehttp.Error(res, err.Error(), corehttp.StatusUnauthorized)
+			return
+		}
+		jobOffer, err := http.ReadBody[data.JobOffer](req)
+		assert.NoError(t, err)
+		if address != jobOffer.JobCreator {
+			corehttp.Error(res, "job creator address does not match signer", corehttp.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(res).Encode(data.JobOfferContainer{ID: "offer", JobCreator: address})
+	}))
+	defer server.Close()
+
+	client, err := New(Options{URL: server.URL, PrivateKey: testPrivateKey, Retry: testRetry})
+	assert.NoError(t, err)
+
+	container, err := client.SubmitJobOffer(context.Background(), data.JobOffer{JobCreator: client.Address()})
+	assert.NoError(t, err)
+	assert.Equal(t, "offer", container.ID)
+
+	_, err = client.SubmitJobOffer(context.Background(), data.JobOffer{JobCreator: "0xsomeoneelse"})
+	solverErr, ok := err.(*Error)
+	assert.True(t, ok)
+	assert.Equal(t, corehttp.StatusBadRequest, solverErr.StatusCode)
+
+	readOnly, err := New(Options{URL: server.URL, Retry: testRetry})
+	assert.NoError(t, err)
+	_, err = readOnly.SubmitJobOffer(context.Background(), data.JobOffer{})
+	assert.Error(t, err)
+}
+
+func TestDownloadVerifiedResult(t *testing.T) {
+	for _, verified := range []bool{true, false} {
+		server := httptest.NewServer(corehttp.HandlerFunc(func(res corehttp.ResponseWriter, req *corehttp.Request) {
+			assert.Equal(t, http.API_SUB_PATH+"/deals/deal/result/archive", req.URL.Path)
+			res.Header().Set("Trailer", http.X_LILYPAD_VERIFIED_HEADER)
+			tw := tar.NewWriter(res)
+			tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: "stdout", Size: 5, Mode: 0644})
+			tw.Write([]byte("hello"))
+			tw.Close()
+			if verified {
+				res.Header().Set(http.X_LILYPAD_VERIFIED_HEADER, "true")
+			}
+		}))
+
+		client, err := New(Options{URL: server.URL, Retry: testRetry})
+		assert.NoError(t, err)
+		localPath := filepath.Join(t.TempDir(), "results")
+		err = client.DownloadVerifiedResult(context.Background(), "deal", localPath)
+		if verified {
+			assert.NoError(t, err)
+			stdout, err := os.ReadFile(filepath.Join(localPath, "stdout"))
+			assert.NoError(t, err)
+			assert.Equal(t, "hello", string(stdout))
+		} else {
+			assert.Error(t, err)
+			assert.NoFileExists(t, localPath+".tar")
+		}
+		server.Close()
+	}
+}
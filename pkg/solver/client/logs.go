@@ -0,0 +1,151 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	corehttp "net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/http"
+)
+
+// a log event holds a JSON encoded chunk which is escaped
+// so it can be a good deal longer than the chunk itself
+const maxLogEventSize = 1024 * 1024
+
+// the output of a deal's job that the solver still has
+// after is the sequence of the last chunk you have seen
+func (client *Client) GetDealLogs(ctx context.Context, dealID string, after uint64) ([]data.DealLogChunk, error) {
+	params := url.Values{}
+	if after > 0 {
+		params.Set("after", fmt.Sprintf("%d", after))
+	}
+	result := []data.DealLogChunk{}
+	err := client.do(ctx, corehttp.MethodGet, fmt.Sprintf("/deals/%s/logs", dealID), params, nil, &result)
+	return result, err
+}
+
+// follow the output of a deal's job until the deal is over
+// if the connection drops we reconnect with the retry backoff and
+// carry on from the last chunk we saw, the channel is closed once
+// the deal is over or the context is cancelled
+func (client *Client) FollowDealLogs(ctx context.Context, dealID string) (<-chan data.DealLogChunk, error) {
+	// connect once up front so a bad deal ID is reported to the caller
+	body, err := client.openLogStream(ctx, dealID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks := make(chan data.DealLogChunk)
+	go func() {
+		defer close(chunks)
+		var after uint64
+		wait := client.options.Retry.MinWait
+		for {
+			var ended bool
+			after, ended = readLogStream(ctx, body, after, chunks)
+			body.Close()
+			if ended {
+				return
+			}
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(wait):
+				}
+				body, err = client.openLogStream(ctx, dealID, after)
+				if err == nil {
+					wait = client.options.Retry.MinWait
+					break
+				}
+				wait *= 2
+				if wait > client.options.Retry.MaxWait {
+					wait = client.options.Retry.MaxWait
+				}
+			}
+		}
+	}()
+	return chunks, nil
+}
+
+func (client *Client) openLogStream(ctx context.Context, dealID string, after uint64) (io.ReadCloser, error) {
+	path := fmt.Sprintf("/deals/%s/logs/stream", dealID)
+	// only the parties to the deal can follow its logs
+	if client.signer == nil {
+		return nil, fmt.Errorf("a private key or signer is required for %s %s", corehttp.MethodGet, path)
+	}
+	req, err := retryablehttp.NewRequestWithContext(ctx, corehttp.MethodGet, http.URL(client.clientOptions(), path), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if after > 0 {
+		req.Header.Set("Last-Event-ID", fmt.Sprintf("%d", after))
+	}
+	err = http.AddHeaders(req, client.signer, client.address)
+	if err != nil {
+		return nil, err
+	}
+	// the stream stays open for as long as the job runs so it
+	// cannot go through the retrying client which has a timeout
+	resp, err := corehttp.DefaultClient.Do(req.Request)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, &Error{
+			StatusCode: resp.StatusCode,
+			Message:    string(bytes.TrimSpace(respBody)),
+		}
+	}
+	return resp.Body, nil
+}
+
+// read server sent events until the stream ends returning the
+// sequence of the last chunk and whether the solver said it was the end
+func readLogStream(ctx context.Context, body io.Reader, after uint64, chunks chan<- data.DealLogChunk) (uint64, bool) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLogEventSize)
+	event := ""
+	eventData := ""
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			eventData = strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		case line == "":
+			// a blank line dispatches the event
+			if event == "end" {
+				return after, true
+			}
+			if event == "log" {
+				var chunk data.DealLogChunk
+				err := json.Unmarshal([]byte(eventData), &chunk)
+				if err == nil && chunk.Sequence > after {
+					select {
+					case chunks <- chunk:
+					case <-ctx.Done():
+						return after, true
+					}
+					after = chunk.Sequence
+				}
+			}
+			event = ""
+			eventData = ""
+		}
+	}
+	return after, false
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/gorilla/websocket"
+	"github.com/lilypad-tech/lilypad/pkg/solver"
+	"github.com/rs/zerolog/log"
+)
+
+func dialWebsocket(ctx context.Context, wsURL string) (*websocket.Conn, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, wsURL, nil)
+	return conn, err
+}
+
+// read events off the connection until it errors or the context is done
+func readEvents(ctx context.Context, conn *websocket.Conn, events chan<- solver.SolverEvent) {
+	// unblock the read below when the context is cancelled
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	for {
+		messageType, payload, err := conn.ReadMessage()
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Debug().Msgf("solver event stream disconnected: %s", err.Error())
+			}
+			return
+		}
+		if messageType != websocket.TextMessage {
+			continue
+		}
+		var ev solver.SolverEvent
+		if err := json.Unmarshal(payload, &ev); err != nil {
+			log.Error().Msgf("error unmarshalling solver event: %s", err.Error())
+			continue
+		}
+		select {
+		case events <- ev:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
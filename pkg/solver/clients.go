@@ -0,0 +1,26 @@
+package solver
+
+import (
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+// keep track of the software each job creator and resource provider says it
+// runs, clients from before metadata are recorded with none
+// a failure here is logged and does not stop the offer going in
+func (controller *SolverController) recordClient(address string, role string, client *data.ClientMetadata) {
+	record := data.ClientRecord{
+		Address:   address,
+		Role:      role,
+		FirstSeen: time.Now().Unix(),
+		LastSeen:  time.Now().Unix(),
+	}
+	if client != nil {
+		record.ClientMetadata = *client
+	}
+	_, err := controller.store.UpdateClientRecord(record)
+	if err != nil {
+		controller.log.Error("error recording client", err)
+	}
+}
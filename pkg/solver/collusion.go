@@ -0,0 +1,148 @@
+package solver
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+// how long the LP token senders of an address are kept before we look again
+const FUNDERS_CACHE_TTL = 10 * time.Minute
+
+type CollusionOptions struct {
+	// what to do with mediators that look to be run by the resource provider
+	// see data.CollusionPolicies
+	Policy string
+	// a JSON file listing the addresses each operator runs
+	OperatorsPath string
+	// how many blocks back to look for LP token transfers between
+	// the resource provider and the mediator, 0 turns this off
+	FundingLookback uint64
+	// addresses that fund lots of unrelated users e.g. faucets and exchanges
+	IgnoreFunders []string
+}
+
+func LoadOperatorGroups(path string) ([]data.OperatorGroup, error) {
+	groups := []data.OperatorGroup{}
+	if path == "" {
+		return groups, nil
+	}
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading operator groups: %s", err.Error())
+	}
+	err = json.Unmarshal(bs, &groups)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing operator groups: %s", err.Error())
+	}
+	err = data.CheckOperatorGroups(groups)
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+type fundersCacheEntry struct {
+	funders   []string
+	expiresAt time.Time
+}
+
+// the LP token senders of addresses so each match does not query the chain
+type fundersCache struct {
+	mutex   sync.Mutex
+	entries map[string]fundersCacheEntry
+}
+
+func newFundersCache() *fundersCache {
+	return &fundersCache{
+		entries: map[string]fundersCacheEntry{},
+	}
+}
+
+func (cache *fundersCache) get(key string, load func() ([]string, error)) ([]string, error) {
+	cache.mutex.Lock()
+	entry, ok := cache.entries[key]
+	cache.mutex.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.funders, nil
+	}
+	funders, err := load()
+	if err != nil {
+		return nil, err
+	}
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.entries[key] = fundersCacheEntry{
+		funders:   funders,
+		expiresAt: time.Now().Add(FUNDERS_CACHE_TTL),
+	}
+	return funders, nil
+}
+
+// the mutual mediators of a pair of offers that look to be run by the
+// resource provider so a dispute would be judged by the party it is about
+func (controller *SolverController) getMediatorRelations(jobOffer data.JobOffer, resourceOffer data.ResourceOffer, mediators []string) []data.MediatorRelation {
+	relations := []data.MediatorRelation{}
+	if controller.options.Collusion.Policy == data.COLLUSION_POLICY_OFF {
+		return relations
+	}
+	funders := controller.getFunders(jobOffer.ChainID, append([]string{resourceOffer.ResourceProvider}, mediators...))
+	for _, mediator := range mediators {
+		relation := data.GetMediatorRelation(resourceOffer.ResourceProvider, mediator, controller.operatorGroups, funders)
+		if relation == nil {
+			continue
+		}
+		controller.log.Info("mediator related to resource provider", fmt.Sprintf("resource offer %s, mediator %s, reason %s %s", resourceOffer.ID, mediator, relation.Reason, relation.Detail))
+		relations = append(relations, *relation)
+	}
+	return relations
+}
+
+// the LP token senders of each address that we could find
+// funding is only a heuristic so an address we could not look up is left out
+func (controller *SolverController) getFunders(chainID int, addresses []string) map[string][]string {
+	funders := map[string][]string{}
+	lookback := controller.options.Collusion.FundingLookback
+	if lookback == 0 {
+		return funders
+	}
+	chainSDK, err := controller.chains.Get(chainID)
+	if err != nil {
+		controller.log.Error("error getting chain to look up funders", err)
+		return funders
+	}
+	for _, address := range addresses {
+		address = strings.ToLower(address)
+		senders, err := controller.funders.get(fmt.Sprintf("%d/%s", chainID, address), func() ([]string, error) {
+			return chainSDK.GetLPTokenSenders(address, lookback)
+		})
+		if err != nil {
+			controller.log.Error("error getting funders", fmt.Errorf("%s: %s", address, err.Error()))
+			continue
+		}
+		funders[address] = controller.withoutIgnoredFunders(senders)
+	}
+	return funders
+}
+
+func (controller *SolverController) withoutIgnoredFunders(senders []string) []string {
+	kept := []string{}
+	for _, sender := range senders {
+		ignored := false
+		for _, ignore := range controller.options.Collusion.IgnoreFunders {
+			if strings.EqualFold(sender, ignore) {
+				ignored = true
+				break
+			}
+		}
+		if !ignored {
+			kept = append(kept, sender)
+		}
+	}
+	return kept
+}
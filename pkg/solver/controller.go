@@ -3,15 +3,23 @@ package solver
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/lilypad-tech/lilypad/pkg/attestation"
 	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/fiat"
+	"github.com/lilypad-tech/lilypad/pkg/ipfs"
 	"github.com/lilypad-tech/lilypad/pkg/metricsDashboard"
+	"github.com/lilypad-tech/lilypad/pkg/notifications"
 	"github.com/lilypad-tech/lilypad/pkg/solver/matcher"
 	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+	resultstorage "github.com/lilypad-tech/lilypad/pkg/storage"
 	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/lilypad-tech/lilypad/pkg/web3"
 	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/mediation"
+	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/payments"
 	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/storage"
 	"github.com/rs/zerolog/log"
 	"go.opentelemetry.io/otel/attribute"
@@ -31,9 +39,16 @@ const (
 	ResourceOfferStateUpdated           SolverEventType = "ResourceOfferStateUpdated"
 	DealStateUpdated                    SolverEventType = "DealStateUpdated"
 	DealMediatorUpdated                 SolverEventType = "DealMediatorUpdated"
+	DealCheckpointUpdated               SolverEventType = "DealCheckpointUpdated"
+	DealMilestoneUpdated                SolverEventType = "DealMilestoneUpdated"
+	DealEncryptedInputsAdded            SolverEventType = "DealEncryptedInputsAdded"
+	DealCancelled                       SolverEventType = "DealCancelled"
+	DealPreempted                       SolverEventType = "DealPreempted"
+	MediationVerdictAdded               SolverEventType = "MediationVerdictAdded"
 	ResourceProviderTransactionsUpdated SolverEventType = "ResourceProviderTransactionsUpdated"
 	JobCreatorTransactionsUpdated       SolverEventType = "JobCreatorTransactionsUpdated"
 	MediatorTransactionsUpdated         SolverEventType = "MediatorTransactionsUpdated"
+	PriceGapFound                       SolverEventType = "PriceGapFound"
 )
 
 type SolverEvent struct {
@@ -41,10 +56,11 @@ type SolverEvent struct {
 	JobOffer      *data.JobOfferContainer      `json:"job_offer"`
 	ResourceOffer *data.ResourceOfferContainer `json:"resource_offer"`
 	Deal          *data.DealContainer          `json:"deal"`
+	PriceGap      *data.PriceGap               `json:"price_gap,omitempty"`
 }
 
 type SolverController struct {
-	web3SDK         *web3.Web3SDK
+	web3SDK         web3.Web3Client
 	web3Events      *web3.EventChannels
 	store           store.SolverStore
 	loop            *system.ControlLoop
@@ -52,44 +68,197 @@ type SolverController struct {
 	options         SolverOptions
 	log             *system.ServiceLogger
 	tracer          trace.Tracer
+	// the modules we know about keyed by module ID
+	// an admin can reload it while the solver is running
+	allowlistMutex sync.RWMutex
+	allowlist      map[string]data.AllowlistItem
+	// the result verifiers we can run keyed by name
+	verifiers map[string]ResultVerifier
+	// network wide tunables from the parameter registry
+	parameters *web3.ProtocolParametersCache
+	// when we last looked for deals to archive
+	lastArchive time.Time
+	// unix time of the last solve that finished without error
+	lastSolve atomic.Int64
+	// the spans that offers and deals were created in
+	spanLinks spanLinks
+	// nil unless module resolution is turned on
+	modules *moduleResolver
+	// nil unless there are notification subscriptions
+	notifier *notifications.Notifier
+	// the most each job creator can spend keyed by lower case address
+	budgets map[string]data.Budget
+	// the output of running jobs
+	logs *logRelay
+	// the requests for the services of service deals
+	services *serviceRouter
+	// nil unless we have an IPFS node to fetch results from
+	ipfs *ipfs.Client
+	// where we mirror the results we are sent
+	resultsStorage []resultstorage.ResultsStorage
+	// the services we ask to pin results keyed by name
+	pinningServices map[string]*pinningService
+	// when we last checked on the result pins
+	lastPinCheck time.Time
+	// signs the receipts for the deals we make, nil without a signer
+	signer web3.Signer
+	// a client for each chain we match offers on
+	chains *web3.ChainRegistry
+	// the next offset for the round-robin mediator policy
+	mediatorRoundRobinMutex sync.Mutex
+	mediatorRoundRobin      int
+	// so a milestone is only accepted and paid once
+	milestoneMutex sync.Mutex
+	// held from the last look for an offer until it is added
+	// so two copies of a submission sent at once cannot both get in
+	offerSubmitMutex sync.Mutex
+	// the store event sequence starts over each time the solver does
+	// so sync versions from before then mean nothing
+	syncEpoch string
+	// nil unless we show prices in a fiat currency
+	fiat *fiat.Converter
+	// the addresses each operator runs, see CollusionOptions
+	operatorGroups []data.OperatorGroup
+	funders        *fundersCache
+	// checks the TEE attestations of resource offers
+	attestationVerifier *attestation.Verifier
 }
 
 // the background "even if we have not heard of an event" loop
 // i.e. things will not wait 10 seconds - the control loop
 // reacts to events in the system - this 10 second background
 // loop is just for in case we miss any events
+// this is the default, MatchingOptions.Interval overrides it
 const CONTROL_LOOP_INTERVAL = 10 * time.Second
 const REQUIRED_BALANCE_IN_WEI = 0.0006
 
+type MatchingOptions struct {
+	// how many seconds between matching passes
+	Interval int
+	// run a matching pass as soon as a new offer arrives
+	// rather than waiting for the next interval
+	TriggerOnOffer bool
+	// how many milliseconds to wait after an offer arrives before
+	// matching so that offers arriving together are matched together
+	Debounce int
+	// record offers that only failed to match on price and
+	// suggest the counter-offers that would match them
+	CounterOffers bool
+	// how the mediators of each deal are chosen, see data.MediatorPolicies
+	MediatorPolicy string
+}
+
 func NewSolverController(
-	web3SDK *web3.Web3SDK,
+	web3SDK web3.Web3Client,
 	store store.SolverStore,
 	options SolverOptions,
 	tracer trace.Tracer,
 ) (*SolverController, error) {
+	allowlist, err := LoadAllowlist(options.Allowlist.Path)
+	if err != nil {
+		return nil, err
+	}
+	operatorGroups, err := LoadOperatorGroups(options.Collusion.OperatorsPath)
+	if err != nil {
+		return nil, err
+	}
+	budgets, err := LoadBudgets(options.Budget.Path)
+	if err != nil {
+		return nil, err
+	}
+	parameters := web3.NewProtocolParametersCache()
+	err = parameters.Load(web3SDK)
+	if err != nil {
+		return nil, err
+	}
+	attestationVerifier, err := attestation.NewVerifier(options.Attestation)
+	if err != nil {
+		return nil, err
+	}
 	controller := &SolverController{
 		web3SDK:    web3SDK,
 		web3Events: web3.NewEventChannels(),
 		store:      store,
+		syncEpoch:  fmt.Sprintf("%d", time.Now().UnixNano()),
 		options:    options,
 		log:        system.NewServiceLogger(system.SolverService),
 		tracer:     tracer,
+		allowlist:  allowlist,
+		verifiers:  getDefaultResultVerifiers(options.Verification),
+		parameters: parameters,
+		logs:       newLogRelay(options.LogRelay),
+		services:   newServiceRouter(),
+		chains:     web3.NewChainRegistry(options.Web3.ChainID, web3SDK),
+
+		operatorGroups: operatorGroups,
+		funders:        newFundersCache(),
+		budgets:        budgets,
+
+		attestationVerifier: attestationVerifier,
+	}
+	if options.ModuleResolver.Enabled {
+		controller.modules = newModuleResolver(options.ModuleResolver)
+	}
+	if options.Fiat.Enabled() {
+		controller.fiat = fiat.NewConverter(options.Fiat)
+	}
+	if options.Notifications.Enabled() {
+		subscriptions, err := notifications.LoadSubscriptions(options.Notifications.SubscriptionsPath)
+		if err != nil {
+			return nil, err
+		}
+		controller.notifier = notifications.NewNotifier(system.SolverService, options.Notifications, subscriptions)
+	}
+	if options.IPFS.Addr != "" {
+		controller.ipfs, err = ipfs.NewClient(context.Background(), options.IPFS.Addr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	controller.resultsStorage, err = resultstorage.NewResultsStorage(options.Storage, controller.ipfs)
+	if err != nil {
+		return nil, err
+	}
+	controller.pinningServices, err = newPinningServices(options.Pinning)
+	if err != nil {
+		return nil, err
+	}
+	if options.Web3.PrivateKey != "" || (options.Web3.Signer != "" && options.Web3.Signer != web3.SIGNER_KEY) {
+		controller.signer = web3SDK.GetSigner()
 	}
 	return controller, nil
 }
 
 func (controller *SolverController) Start(ctx context.Context, cm *system.CleanupManager) chan error {
 	errorChan := make(chan error, 1)
+	// verifiers can be registered after we are created so check them now
+	err := controller.checkResultVerifiers()
+	if err != nil {
+		errorChan <- err
+		return errorChan
+	}
+	// the matching solver does all of this and we read what it writes
+	if controller.isReadOnly() {
+		log.Info().Msgf("solver is read-only, not matching or following the chain")
+		return errorChan
+	}
+	// tell outside systems about our deals as they happen
+	controller.startWebhooks(ctx)
+	err = controller.startNotifications(ctx)
+	if err != nil {
+		errorChan <- err
+		return errorChan
+	}
 	// get the local subscriptions setup
-	err := controller.subscribeToWeb3()
+	err = controller.subscribeToWeb3()
 	if err != nil {
 		errorChan <- err
 		return errorChan
 	}
 
-	// activate the web3 event listeners
-	log.Debug().Msgf("controller.web3Events.Start")
-	err = controller.web3Events.Start(controller.web3SDK, ctx, cm)
+	// keep our transactions in the store and pick up the ones
+	// we were waiting on when we stopped
+	err = controller.resumeTransactions(ctx)
 	if err != nil {
 		errorChan <- err
 		return errorChan
@@ -98,7 +267,7 @@ func (controller *SolverController) Start(ctx context.Context, cm *system.Cleanu
 	// make sure we are registered as a solver
 	// so that users can lookup our URL
 	log.Debug().Msgf("controller.registerAsSolver")
-	err = controller.registerAsSolver()
+	err = controller.registerAsSolver(ctx)
 	if err != nil {
 		errorChan <- err
 		return errorChan
@@ -107,15 +276,45 @@ func (controller *SolverController) Start(ctx context.Context, cm *system.Cleanu
 	controller.loop = system.NewControlLoop(
 		system.SolverService,
 		ctx,
-		CONTROL_LOOP_INTERVAL,
+		controller.getMatchingInterval(),
 		func() error {
 			err := controller.solve(ctx)
 			if err != nil {
 				errorChan <- err
+				return err
 			}
-			return err
+			controller.lastSolve.Store(time.Now().Unix())
+			return nil
 		},
 	)
+
+	// follow the chain from where we got to last time so events
+	// missed while we were down are replayed and reorgs undone
+	// the handlers trigger the loop so this waits until there is one
+	log.Debug().Msgf("controller.web3Events.Sync")
+	err = controller.web3SDK.SyncEvents(controller.web3Events, controller.store, ctx)
+	if err != nil {
+		errorChan <- err
+		return errorChan
+	}
+
+	// deals on the other chains change state there so listen to them too
+	for _, chainID := range controller.chains.ChainIDs()[1:] {
+		chainSDK, err := controller.chains.Get(chainID)
+		if err != nil {
+			errorChan <- err
+			return errorChan
+		}
+		chainEvents := web3.NewEventChannels()
+		controller.subscribeToChainEvents(chainSDK, chainEvents)
+		log.Debug().Msgf("controller.web3Events.Sync chain %d", chainID)
+		err = chainSDK.SyncEvents(chainEvents, controller.store, ctx)
+		if err != nil {
+			errorChan <- err
+			return errorChan
+		}
+	}
+
 	log.Debug().Msgf("controller.loop.Start")
 	err = controller.loop.Start(true)
 	if err != nil {
@@ -126,6 +325,27 @@ func (controller *SolverController) Start(ctx context.Context, cm *system.Cleanu
 	return errorChan
 }
 
+func (controller *SolverController) resumeTransactions(ctx context.Context) error {
+	pending, err := controller.store.GetTransactions(store.GetTransactionsQuery{
+		Status: data.TransactionPending,
+	})
+	if err != nil {
+		return err
+	}
+	for _, chainID := range controller.chains.ChainIDs() {
+		chainSDK, err := controller.chains.Get(chainID)
+		if err != nil {
+			return err
+		}
+		chainSDK.SetTransactionHandler(func(tx data.Transaction) error {
+			_, err := controller.store.AddTransaction(tx)
+			return err
+		})
+		chainSDK.ResumeTransactions(ctx, pending)
+	}
+	return nil
+}
+
 /*
  *
  *
@@ -143,10 +363,39 @@ func (controller *SolverController) Start(ctx context.Context, cm *system.Cleanu
 // * update the deal state locally
 func (controller *SolverController) subscribeToWeb3() error {
 
+	// keep the protocol parameters up to date
+	controller.parameters.Subscribe(controller.web3SDK, controller.web3Events)
+	controller.subscribeToChainEvents(controller.web3SDK, controller.web3Events)
+	return nil
+}
+
+// the deal events we need from each chain we work on
+// deal IDs are content hashes so they cannot clash across chains
+// events a reorg took back come round again with Raw.Removed set
+func (controller *SolverController) subscribeToChainEvents(chainSDK web3.Web3Client, events *web3.EventChannels) {
 	// change the deal state
-	controller.web3Events.Storage.SubscribeDealStateChange(func(ev storage.StorageDealStateChange) {
-		_, err := controller.updateDealState(ev.DealId, ev.State)
+	events.Storage.SubscribeDealStateChange(func(ev storage.StorageDealStateChange) {
+		if ev.Raw.Removed {
+			_, err := controller.rollbackDealState(chainSDK, ev.DealId)
+			if err != nil {
+				controller.log.Error("error rolling back deal state", err)
+			}
+			return
+		}
+		ctx, span := controller.tracer.Start(context.Background(), "web3.deal_state_change",
+			trace.WithLinks(controller.spanLinks.get(ev.DealId)...),
+			trace.WithAttributes(
+				attribute.String("deal.id", ev.DealId),
+				attribute.String("deal.state", data.GetAgreementStateString(ev.State)),
+				attribute.String("web3.tx_hash", ev.Raw.TxHash.String()),
+				attribute.Int64("web3.block_number", int64(ev.Raw.BlockNumber)),
+			),
+		)
+		defer span.End()
+		_, err := controller.updateDealState(ctx, ev.DealId, ev.State)
 		if err != nil {
+			span.SetStatus(codes.Error, "update deal state failed")
+			span.RecordError(err)
 			controller.log.Error("error updating deal state", err)
 			return
 		}
@@ -157,10 +406,14 @@ func (controller *SolverController) subscribeToWeb3() error {
 	})
 
 	// update the mediator
-	controller.web3Events.Mediation.SubscribeMediationRequested(func(ev mediation.MediationMediationRequested) {
+	events.Mediation.SubscribeMediationRequested(func(ev mediation.MediationMediationRequested) {
 		controller.log.Info("MediationMediationRequested", "")
 		system.DumpObjectDebug(ev)
-		_, err := controller.updateDealMediator(ev.DealId, ev.Mediator.String())
+		mediator := ev.Mediator.String()
+		if ev.Raw.Removed {
+			mediator = ""
+		}
+		_, err := controller.updateDealMediator(ev.DealId, mediator)
 		if err != nil {
 			controller.log.Error("error updating deal state", err)
 			return
@@ -170,7 +423,20 @@ func (controller *SolverController) subscribeToWeb3() error {
 		controller.loop.Trigger()
 	})
 
-	return nil
+	// keep track of escrow so operators can reconcile it
+	events.Payment.SubscribePayment(func(ev payments.PaymentsPayment) {
+		if ev.Raw.Removed {
+			err := controller.store.RemoveEscrowPayment(ev.DealId, ev.Raw.TxHash.String(), ev.Raw.Index)
+			if err != nil {
+				controller.log.Error("error removing escrow payment", err)
+			}
+			return
+		}
+		err := controller.recordEscrowPayment(ev)
+		if err != nil {
+			controller.log.Error("error recording escrow payment", err)
+		}
+	})
 }
 
 // return a new event channel that will hear about events
@@ -180,10 +446,24 @@ func (controller *SolverController) subscribeEvents(handler func(SolverEvent)) {
 }
 
 func (controller *SolverController) reactToEvent(ev SolverEvent) {
+	if !controller.options.Matching.TriggerOnOffer {
+		return
+	}
 	// both of these should trigger a solve
 	if ev.EventType == ResourceOfferAdded || ev.EventType == JobOfferAdded {
-		controller.loop.Trigger()
+		if controller.options.Matching.Debounce > 0 {
+			controller.loop.Debounce(time.Duration(controller.options.Matching.Debounce) * time.Millisecond)
+		} else {
+			controller.loop.Trigger()
+		}
+	}
+}
+
+func (controller *SolverController) getMatchingInterval() time.Duration {
+	if controller.options.Matching.Interval <= 0 {
+		return CONTROL_LOOP_INTERVAL
 	}
+	return time.Duration(controller.options.Matching.Interval) * time.Second
 }
 
 // write the given event to all generated event channels
@@ -206,15 +486,35 @@ func (controller *SolverController) writeEvent(ev SolverEvent) {
  *
 */
 
-func (controller *SolverController) registerAsSolver() error {
-	selfAddress := controller.web3SDK.GetAddress()
+// users on every chain we serve need to be able to find us
+func (controller *SolverController) registerAsSolver(ctx context.Context) error {
+	for _, chainID := range controller.chains.ChainIDs() {
+		chainSDK, err := controller.chains.Get(chainID)
+		if err != nil {
+			return err
+		}
+		err = controller.registerAsSolverOnChain(ctx, chainID, chainSDK)
+		if err != nil {
+			return fmt.Errorf("error registering as solver on chain %d: %w", chainID, err)
+		}
+	}
+	return nil
+}
+
+func (controller *SolverController) registerAsSolverOnChain(ctx context.Context, chainID int, web3SDK web3.Web3Client) error {
+	_, span := controller.tracer.Start(ctx, "register_as_solver",
+		trace.WithAttributes(attribute.Int("web3.chain_id", chainID)),
+	)
+	defer span.End()
+
+	selfAddress := web3SDK.GetAddress()
 	solverType, err := data.GetServiceType("Solver")
 	if err != nil {
 		return err
 	}
 
 	log.Debug().Msgf("GetUser with selfAddress: %s", selfAddress.String())
-	selfUser, err := controller.web3SDK.GetUser(selfAddress)
+	selfUser, err := web3SDK.GetUser(selfAddress)
 	if err != nil {
 		return err
 	}
@@ -224,19 +524,23 @@ func (controller *SolverController) registerAsSolver() error {
 	log.Debug().Msgf("controller.options.Server.URL: %s", controller.options.Server.URL)
 	if selfUser.Url != controller.options.Server.URL {
 		controller.log.Info("url change", fmt.Sprintf("solver will be updated because URL has changed: %s %s != %s", selfAddress.String(), selfUser.Url, controller.options.Server.URL))
-		err = controller.web3SDK.UpdateUser(
+		span.AddEvent("web3.update_user.start")
+		err = web3SDK.UpdateUser(
 			"",
 			controller.options.Server.URL,
 			[]uint8{solverType},
 		)
 		if err != nil {
+			span.SetStatus(codes.Error, "update user failed")
+			span.RecordError(err)
 			return err
 		}
+		span.AddEvent("web3.update_user.done")
 	} else {
 		controller.log.Info("url same", fmt.Sprintf("solver url already correct: %s %s", selfAddress.String(), controller.options.Server.URL))
 	}
 
-	existingSolvers, err := controller.web3SDK.GetSolverAddresses()
+	existingSolvers, err := web3SDK.GetSolverAddresses()
 	if err != nil {
 		return err
 	}
@@ -251,12 +555,16 @@ func (controller *SolverController) registerAsSolver() error {
 	if !foundSolver {
 		controller.log.Info("solver registering", "")
 		// add the solver to the storage contract
-		err = controller.web3SDK.AddUserToList(
+		span.AddEvent("web3.add_user_to_list.start")
+		err = web3SDK.AddUserToList(
 			solverType,
 		)
 		if err != nil {
+			span.SetStatus(codes.Error, "add user to list failed")
+			span.RecordError(err)
 			return err
 		}
+		span.AddEvent("web3.add_user_to_list.done")
 		controller.log.Info("solver registered", selfAddress.String())
 	}
 	return nil
@@ -279,7 +587,11 @@ func (controller *SolverController) solve(ctx context.Context) error {
 	defer span.End()
 
 	// find out which deals we can make from matching the offers
-	deals, err := matcher.GetMatchingDeals(ctx, controller.store, controller.updateJobOfferState, controller.tracer)
+	var addPriceGap func(data.PriceGap) error
+	if controller.options.Matching.CounterOffers {
+		addPriceGap = controller.addPriceGap
+	}
+	deals, err := matcher.GetMatchingDeals(ctx, controller.store, controller.getAllowlist(), controller.updateJobOfferState, addPriceGap, controller.getMediatorPolicy, controller.chains.Resolve, controller.tracer)
 	if err != nil {
 		span.SetStatus(codes.Error, "get matching deals failed")
 		span.RecordError(err)
@@ -300,6 +612,79 @@ func (controller *SolverController) solve(ctx context.Context) error {
 	}
 	span.AddEvent("add_deals.done")
 
+	// add the job offers for recurring jobs that are due
+	// they are matched on the next solve
+	span.AddEvent("run_job_schedules.start")
+	err = controller.runJobSchedules(ctx, time.Now())
+	if err != nil {
+		span.SetStatus(codes.Error, "run job schedules failed")
+		span.RecordError(err)
+		return err
+	}
+	span.AddEvent("run_job_schedules.done")
+
+	// turn reservations whose window has started into job offers
+	span.AddEvent("run_capacity_reservations.start")
+	err = controller.runCapacityReservations(ctx, time.Now())
+	if err != nil {
+		span.SetStatus(codes.Error, "run capacity reservations failed")
+		span.RecordError(err)
+		return err
+	}
+	span.AddEvent("run_capacity_reservations.done")
+
+	// move workflows on to the stages whose upstream stages have results
+	span.AddEvent("run_workflows.start")
+	err = controller.runWorkflows(ctx, time.Now())
+	if err != nil {
+		span.SetStatus(codes.Error, "run workflows failed")
+		span.RecordError(err)
+		return err
+	}
+	span.AddEvent("run_workflows.done")
+
+	// re-verify the results of any deals that were picked for an audit
+	span.AddEvent("process_audits.start")
+	err = controller.processAudits()
+	if err != nil {
+		span.SetStatus(codes.Error, "process audits failed")
+		span.RecordError(err)
+		return err
+	}
+	span.AddEvent("process_audits.done")
+
+	// time out any deals that have been stuck for too long
+	if controller.options.Watchdog.Enabled {
+		span.AddEvent("check_deal_timeouts.start")
+		err = controller.checkDealTimeouts()
+		if err != nil {
+			span.SetStatus(codes.Error, "check deal timeouts failed")
+			span.RecordError(err)
+			return err
+		}
+		span.AddEvent("check_deal_timeouts.done")
+	}
+
+	// move finished deals out of the store once they are old enough
+	span.AddEvent("check_retention.start")
+	err = controller.checkRetention(time.Now())
+	if err != nil {
+		span.SetStatus(codes.Error, "check retention failed")
+		span.RecordError(err)
+		return err
+	}
+	span.AddEvent("check_retention.done")
+
+	// keep enough copies of each result pinned
+	span.AddEvent("check_result_pins.start")
+	err = controller.checkResultPins(ctx, time.Now())
+	if err != nil {
+		span.SetStatus(codes.Error, "check result pins failed")
+		span.RecordError(err)
+		return err
+	}
+	span.AddEvent("check_result_pins.done")
+
 	return nil
 }
 
@@ -314,19 +699,151 @@ func (controller *SolverController) solve(ctx context.Context) error {
 *
 *
 */
-func (controller *SolverController) addJobOffer(jobOffer data.JobOffer) (*data.JobOfferContainer, error) {
+func (controller *SolverController) addJobOffer(ctx context.Context, jobOffer data.JobOffer) (*data.JobOfferContainer, error) {
+	_, span := controller.tracer.Start(ctx, "add_job_offer",
+		trace.WithAttributes(
+			attribute.String("job_offer.job_creator", jobOffer.JobCreator),
+			attribute.String("job_offer.module", jobOffer.Module.Name),
+		),
+	)
+	defer span.End()
+
 	id, err := data.GetJobOfferID(jobOffer)
 	if err != nil {
+		span.SetStatus(codes.Error, "get job offer ID failed")
+		span.RecordError(err)
 		return nil, err
 	}
 	jobOffer.ID = id
+	span.SetAttributes(attribute.String("job_offer.id", jobOffer.ID))
+
+	existing, err := controller.getExistingJobOffer(jobOffer)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		span.AddEvent("job_offer.already_added", trace.WithAttributes(attribute.String("job_offer.existing_id", existing.ID)))
+		controller.log.Debug("job offer already added", existing.ID)
+		return existing, nil
+	}
+
+	chainSDK, err := controller.chains.Get(jobOffer.ChainID)
+	if err != nil {
+		span.SetStatus(codes.Error, "unsupported chain")
+		span.RecordError(err)
+		return nil, err
+	}
+	// store the chain zero stands for so it matches offers that name it
+	jobOffer.ChainID = controller.chains.Resolve(jobOffer.ChainID)
+
+	// catch a token the payments contract will not take before it is matched
+	if jobOffer.PaymentToken != nil {
+		err = chainSDK.CheckPaymentToken(*jobOffer.PaymentToken)
+		if err != nil {
+			span.SetStatus(codes.Error, "check payment token failed")
+			span.RecordError(err)
+			return nil, err
+		}
+	}
+
+	err = controller.checkBannedAddress(jobOffer.JobCreator)
+	if err != nil {
+		return nil, err
+	}
+
+	err = controller.checkParentDeal(jobOffer)
+	if err != nil {
+		return nil, err
+	}
+
+	err = controller.checkInputQuota(jobOffer)
+	if err != nil {
+		return nil, err
+	}
+
+	err = controller.checkBudget(jobOffer)
+	if err != nil {
+		return nil, err
+	}
+
+	span.AddEvent("check_job_offer_inputs.start")
+	err = controller.checkJobOfferInputs(ctx, jobOffer)
+	if err != nil {
+		err = data.WrapError(data.ErrInvalidRequest, err)
+		controller.log.Error("job offer rejected", err)
+		span.SetStatus(codes.Error, "check job offer inputs failed")
+		span.RecordError(err)
+		return nil, err
+	}
+	span.AddEvent("check_job_offer_inputs.done")
+
+	if controller.options.Allowlist.RequirePinnedVersions {
+		err = data.CheckModuleVersionPinned(jobOffer.Module)
+		if err != nil {
+			err = data.WrapError(data.ErrModuleNotAllowed, err)
+			controller.log.Error("job offer rejected", err)
+			span.SetStatus(codes.Error, "module version not pinned")
+			span.RecordError(err)
+			return nil, err
+		}
+	}
+
+	span.AddEvent("check_job_offer_module.start")
+	err = controller.checkJobOfferModule(jobOffer)
+	if err != nil {
+		err = data.WrapError(data.ErrModuleNotAllowed, err)
+		controller.log.Error("job offer rejected", err)
+		span.SetStatus(codes.Error, "check job offer module failed")
+		span.RecordError(err)
+		return nil, err
+	}
+	span.AddEvent("check_job_offer_module.done")
+
+	err = checkPricingParameters(controller.parameters.Get(), jobOffer.Mode, jobOffer.Pricing)
+	if err != nil {
+		span.SetStatus(codes.Error, "check pricing parameters failed")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	jobOfferContainer := data.GetJobOfferContainer(jobOffer)
+	if jobOffer.ReuseResults {
+		reused, err := controller.getReusableDeal(jobOffer)
+		if err != nil {
+			span.SetStatus(codes.Error, "get reusable deal failed")
+			span.RecordError(err)
+			return nil, err
+		}
+		// the offer is never matched, the job creator gets the earlier result
+		if reused != nil {
+			jobOfferContainer.DealID = reused.ID
+			jobOfferContainer.State = reused.State
+			jobOfferContainer.ReusedResult = true
+			span.AddEvent("job_offer.reused_result", trace.WithAttributes(attribute.String("deal.id", reused.ID)))
+		}
+	}
 
 	controller.log.Info("add job offer", jobOffer)
 
-	ret, err := controller.store.AddJobOffer(data.GetJobOfferContainer(jobOffer))
+	// a copy of this offer could have got in while we were checking it
+	controller.offerSubmitMutex.Lock()
+	existing, err = controller.getExistingJobOffer(jobOffer)
+	if err != nil || existing != nil {
+		controller.offerSubmitMutex.Unlock()
+		return existing, err
+	}
+	span.AddEvent("store.add_job_offer.start")
+	ret, err := controller.store.AddJobOffer(jobOfferContainer)
+	controller.offerSubmitMutex.Unlock()
 	if err != nil {
+		span.SetStatus(codes.Error, "add job offer to store failed")
+		span.RecordError(err)
 		return nil, err
 	}
+	span.AddEvent("store.add_job_offer.done")
+	controller.spanLinks.remember(ret.ID, span)
+	controller.recordClient(ret.JobCreator, data.ClientRoleJobCreator, jobOffer.Client)
+
 	controller.writeEvent(SolverEvent{
 		EventType: JobOfferAdded,
 		JobOffer:  ret,
@@ -334,18 +851,86 @@ func (controller *SolverController) addJobOffer(jobOffer data.JobOffer) (*data.J
 	return ret, nil
 }
 
-func (controller *SolverController) addResourceOffer(resourceOffer data.ResourceOffer) (*data.ResourceOfferContainer, error) {
+func (controller *SolverController) addResourceOffer(ctx context.Context, resourceOffer data.ResourceOffer) (*data.ResourceOfferContainer, error) {
+	_, span := controller.tracer.Start(ctx, "add_resource_offer",
+		trace.WithAttributes(attribute.String("resource_offer.resource_provider", resourceOffer.ResourceProvider)),
+	)
+	defer span.End()
+
 	id, err := data.GetResourceOfferID(resourceOffer)
 	if err != nil {
+		span.SetStatus(codes.Error, "get resource offer ID failed")
+		span.RecordError(err)
 		return nil, err
 	}
 	resourceOffer.ID = id
+	span.SetAttributes(attribute.String("resource_offer.id", resourceOffer.ID))
+
+	existing, err := controller.getExistingResourceOffer(resourceOffer)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		span.AddEvent("resource_offer.already_added", trace.WithAttributes(attribute.String("resource_offer.existing_id", existing.ID)))
+		controller.log.Debug("resource offer already added", existing.ID)
+		return existing, nil
+	}
+
+	// the balances we check are the ones on the chain the offer is for
+	chainSDK, err := controller.chains.Get(resourceOffer.ChainID)
+	if err != nil {
+		span.SetStatus(codes.Error, "unsupported chain")
+		span.RecordError(err)
+		return nil, err
+	}
+	resourceOffer.ChainID = controller.chains.Resolve(resourceOffer.ChainID)
+
+	err = controller.checkBannedAddress(resourceOffer.ResourceProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	params := controller.parameters.Get()
+	err = checkPricingParameters(params, resourceOffer.Mode, resourceOffer.DefaultPricing)
+	if err != nil {
+		return nil, err
+	}
+	for moduleID, pricing := range resourceOffer.ModulePricing {
+		err = checkPricingParameters(params, resourceOffer.Mode, pricing)
+		if err != nil {
+			return nil, data.NewError(data.ErrPriceMismatch, "module %s: %s", moduleID, err.Error())
+		}
+	}
+
+	// an offer that claims a TEE has to show evidence for it
+	// without the root CA of the TEE type we cannot tell real evidence
+	// from forged so the claim cannot be accepted
+	if resourceOffer.TEE != nil && !controller.attestationVerifier.CanVerify(resourceOffer.TEE.Type) {
+		err := fmt.Errorf("resource offer attestation: no root CA is configured for %s", resourceOffer.TEE.Type)
+		span.SetStatus(codes.Error, "attestation cannot be verified")
+		span.RecordError(err)
+		return nil, err
+	}
+	if resourceOffer.TEE != nil {
+		span.AddEvent("verify_attestation.start")
+		err = controller.attestationVerifier.Verify(*resourceOffer.TEE, resourceOffer.ResourceProvider)
+		if err != nil {
+			span.SetStatus(codes.Error, "verify attestation failed")
+			span.RecordError(err)
+			return nil, fmt.Errorf("resource offer attestation: %s", err.Error())
+		}
+		span.AddEvent("verify_attestation.done")
+	}
 
 	// Check the resource provider's ETH balance
-	balance, err := controller.web3SDK.GetBalance(resourceOffer.ResourceProvider)
+	span.AddEvent("web3.get_balance.start")
+	balance, err := chainSDK.GetBalance(resourceOffer.ResourceProvider)
 	if err != nil {
+		span.SetStatus(codes.Error, "get balance failed")
+		span.RecordError(err)
 		return nil, fmt.Errorf("failed to retrieve ETH balance for resource provider: %v", err)
 	}
+	span.AddEvent("web3.get_balance.done")
 	// Convert InstructionPrice from ETH to Wei
 	requiredBalanceWei := web3.EtherToWei(REQUIRED_BALANCE_IN_WEI) // 0.0006 based on the required balance for a job
 
@@ -358,7 +943,9 @@ func (controller *SolverController) addResourceOffer(resourceOffer data.Resource
 
 	// required LP balance
 	requiredBalanceLp := web3.EtherToWei(float64(resourceOffer.DefaultPricing.InstructionPrice)) // based on the required LP balance for a job
-	balanceLp, err := controller.web3SDK.GetLPBalance(resourceOffer.ResourceProvider)
+	span.AddEvent("web3.get_lp_balance.start")
+	balanceLp, err := chainSDK.GetLPBalance(resourceOffer.ResourceProvider)
+	span.AddEvent("web3.get_lp_balance.done")
 	if err != nil {
 		err := fmt.Errorf("failed to retrieve LP balance for resource provider: %v", err)
 		controller.log.Error("LP Balance error", err)
@@ -374,10 +961,23 @@ func (controller *SolverController) addResourceOffer(resourceOffer data.Resource
 
 	metricsDashboard.TrackNodeInfo(resourceOffer)
 
+	controller.offerSubmitMutex.Lock()
+	existing, err = controller.getExistingResourceOffer(resourceOffer)
+	if err != nil || existing != nil {
+		controller.offerSubmitMutex.Unlock()
+		return existing, err
+	}
+	span.AddEvent("store.add_resource_offer.start")
 	ret, err := controller.store.AddResourceOffer(data.GetResourceOfferContainer(resourceOffer))
+	controller.offerSubmitMutex.Unlock()
 	if err != nil {
+		span.SetStatus(codes.Error, "add resource offer to store failed")
+		span.RecordError(err)
 		return nil, err
 	}
+	span.AddEvent("store.add_resource_offer.done")
+	controller.spanLinks.remember(ret.ID, span)
+	controller.recordClient(ret.ResourceProvider, data.ClientRoleResourceProvider, resourceOffer.Client)
 
 	controller.writeEvent(SolverEvent{
 		EventType:     ResourceOfferAdded,
@@ -386,6 +986,161 @@ func (controller *SolverController) addResourceOffer(resourceOffer data.Resource
 	return ret, nil
 }
 
+func (controller *SolverController) addResult(ctx context.Context, deal data.DealContainer, result data.Result) (*data.Result, error) {
+	_, span := controller.tracer.Start(ctx, "add_result",
+		trace.WithLinks(controller.spanLinks.get(deal.ID)...),
+		trace.WithAttributes(attribute.String("deal.id", deal.ID)),
+	)
+	defer span.End()
+
+	// a preempted result is not held against the resource provider
+	// so it has to be for a deal that really was preempted
+	if result.Preempted && deal.Preemption == nil {
+		err := fmt.Errorf("deal %s was not preempted", deal.ID)
+		span.SetStatus(codes.Error, "result preempted without a preemption")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	err := data.CheckServiceResult(deal, result)
+	if err != nil {
+		span.SetStatus(codes.Error, "service result over metered requests")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	err = data.CheckResultMetering(deal, result, time.Now().Unix())
+	if err != nil {
+		span.SetStatus(codes.Error, "result metering not plausible")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	err = controller.verifyResult(deal, result)
+	if err != nil {
+		span.SetStatus(codes.Error, "verify result failed")
+		span.RecordError(err)
+		return nil, err
+	}
+	span.AddEvent("store.add_result.start")
+	addedResult, err := controller.store.AddResult(result)
+	if err != nil {
+		span.SetStatus(codes.Error, "add result to store failed")
+		span.RecordError(err)
+		return nil, err
+	}
+	span.AddEvent("store.add_result.done")
+	controller.addBillingRecord(deal, result)
+	_, err = controller.scheduleAudit(deal, result)
+	if err != nil {
+		span.SetStatus(codes.Error, "schedule audit failed")
+		span.RecordError(err)
+		return nil, err
+	}
+	if len(controller.resultsStorage) > 0 && result.Error == "" {
+		go controller.mirrorResult(deal.ID)
+	}
+	return addedResult, nil
+}
+
+// put the result files we were sent into each of our backends that
+// the resource provider did not already use and record where they went
+func (controller *SolverController) mirrorResult(dealID string) {
+	result, err := controller.store.GetResult(dealID)
+	if err != nil || result == nil {
+		controller.log.Error("error loading result to mirror", fmt.Errorf("deal %s: %v", dealID, err))
+		return
+	}
+	backends := []resultstorage.ResultsStorage{}
+	for _, backend := range controller.resultsStorage {
+		stored := false
+		for _, location := range result.Locations {
+			if location.Backend == backend.Name() {
+				stored = true
+				break
+			}
+		}
+		if !stored {
+			backends = append(backends, backend)
+		}
+	}
+	if len(backends) == 0 {
+		return
+	}
+
+	locations, err := resultstorage.PutResults(context.Background(), backends, dealID, GetDealsFilePath(dealID))
+	if err != nil {
+		controller.log.Error("error mirroring result", err)
+	}
+	if len(locations) == 0 {
+		return
+	}
+	// the result may have changed whilst we were uploading
+	result, err = controller.store.GetResult(dealID)
+	if err != nil || result == nil {
+		controller.log.Error("error loading result to mirror", fmt.Errorf("deal %s: %v", dealID, err))
+		return
+	}
+	// copy rather than change the result the store handed us
+	updated := *result
+	updated.Locations = append(append([]data.ResultLocation{}, result.Locations...), locations...)
+	_, err = controller.store.AddResult(updated)
+	if err != nil {
+		controller.log.Error("error saving mirrored result locations", err)
+		return
+	}
+	controller.log.Info("mirrored result", locations)
+}
+
+// sign the terms of a deal so both sides can prove what was matched
+func (controller *SolverController) addDealReceipt(deal data.DealContainer) (*data.DealReceipt, error) {
+	if controller.signer == nil {
+		return nil, nil
+	}
+	receipt, err := web3.SignDealReceipt(controller.signer, data.DealReceipt{
+		DealID:           deal.ID,
+		JobOffer:         deal.JobOffer,
+		ResourceOffer:    deal.ResourceOffer,
+		JobCreator:       deal.JobCreator,
+		ResourceProvider: deal.ResourceProvider,
+		Solver:           controller.signer.Address().String(),
+		Pricing:          deal.Deal.Pricing,
+		ChainID:          int64(controller.chains.Resolve(deal.ChainID)),
+		CreatedAt:        time.Now().Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return controller.store.AddDealReceipt(receipt)
+}
+
+// tell both sides what price would have got their offers matched
+func (controller *SolverController) addPriceGap(gap data.PriceGap) error {
+	gap.CreatedAt = time.Now().Unix()
+	controller.log.Info("price gap", gap)
+	ret, err := controller.store.AddPriceGap(gap)
+	if err != nil {
+		return err
+	}
+	controller.writeEvent(SolverEvent{
+		EventType: PriceGapFound,
+		PriceGap:  ret,
+	})
+	return nil
+}
+
+// reject offers that are priced below the network minimums
+// market priced offers have no price of their own to check
+func checkPricingParameters(params web3.ProtocolParameters, mode data.PricingMode, pricing data.DealPricing) error {
+	if mode == data.FixedPrice && pricing.InstructionPrice < params.MinInstructionPrice {
+		return data.NewError(data.ErrPriceMismatch, "instruction price %d is below the network minimum of %d", pricing.InstructionPrice, params.MinInstructionPrice)
+	}
+	if pricing.ResultsCollateralMultiple < params.ResultsCollateralMultiple {
+		return data.NewError(data.ErrPriceMismatch, "results collateral multiple %d is below the network value of %d", pricing.ResultsCollateralMultiple, params.ResultsCollateralMultiple)
+	}
+	return nil
+}
+
 func (controller *SolverController) removeResourceOfferByResourceProvider(ID string) error {
 	controller.log.Info("remove resource offer", ID)
 	resourceOffers, err := controller.store.GetResourceOffers(store.GetResourceOffersQuery{
@@ -411,10 +1166,69 @@ func (controller *SolverController) removeResourceOfferByResourceProvider(ID str
 	return nil
 }
 
+// remove any resource offers from the given resource provider that have
+// not been matched yet - offers that are already part of a deal are left alone
+// this is used by resource providers that need to stop taking on new work
+func (controller *SolverController) withdrawResourceOffers(resourceProvider string) ([]data.ResourceOfferContainer, error) {
+	resourceOffers, err := controller.store.GetResourceOffers(store.GetResourceOffersQuery{
+		ResourceProvider: resourceProvider,
+		NotMatched:       true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawn := []data.ResourceOfferContainer{}
+	for _, resourceOffer := range resourceOffers {
+		err = controller.store.RemoveResourceOffer(resourceOffer.ID)
+		if err != nil {
+			return withdrawn, err
+		}
+		controller.log.Info("withdraw resource offer", resourceOffer.ID)
+		withdrawn = append(withdrawn, resourceOffer)
+	}
+
+	if len(withdrawn) > 0 {
+		controller.writeEvent(SolverEvent{
+			EventType:     ResourceOfferRemoved,
+			ResourceOffer: nil,
+		})
+	}
+	return withdrawn, nil
+}
+
 func (controller *SolverController) addDeal(ctx context.Context, deal data.Deal) (*data.DealContainer, error) {
-	ctx, span := controller.tracer.Start(ctx, "add_deal")
+	ctx, span := controller.tracer.Start(ctx, "add_deal",
+		trace.WithLinks(controller.spanLinks.get(deal.JobOffer.ID, deal.ResourceOffer.ID)...),
+		trace.WithAttributes(
+			attribute.String("job_offer.id", deal.JobOffer.ID),
+			attribute.String("resource_offer.id", deal.ResourceOffer.ID),
+		),
+	)
 	defer span.End()
 
+	allowlist := controller.getAllowlist()
+	deal, err := applyModuleMaxRuntime(deal, allowlist)
+	if err != nil {
+		span.SetStatus(codes.Error, "apply module max runtime failed")
+		span.RecordError(err)
+		return nil, err
+	}
+	deal, err = applyModuleImageDigest(deal, allowlist)
+	if err != nil {
+		span.SetStatus(codes.Error, "apply module image digest failed")
+		span.RecordError(err)
+		return nil, err
+	}
+	network, err := data.GetDealNetworkPolicy(deal)
+	if err != nil {
+		span.SetStatus(codes.Error, "get deal network policy failed")
+		span.RecordError(err)
+		return nil, err
+	}
+	deal.Network = &network
+	span.SetAttributes(attribute.String("deal.network.mode", network.Mode))
+
 	span.AddEvent("data.get_deal_id.start")
 	id, err := data.GetDealID(deal)
 	if err != nil {
@@ -462,6 +1276,20 @@ func (controller *SolverController) addDeal(ctx context.Context, deal data.Deal)
 	}
 	span.AddEvent("update_resource_offer_state.done")
 
+	// the deal stands without a receipt so we only log if it fails
+	span.AddEvent("add_deal_receipt.start")
+	_, err = controller.addDealReceipt(*ret)
+	if err != nil {
+		controller.log.Error("error adding deal receipt", err)
+		span.RecordError(err)
+	}
+	span.AddEvent("add_deal_receipt.done")
+
+	// later spans for the deal link back to here and through
+	// this span to the offers so we no longer need theirs
+	controller.spanLinks.remember(ret.ID, span)
+	controller.spanLinks.forget(ret.JobOffer, ret.ResourceOffer)
+
 	return ret, nil
 }
 
@@ -505,13 +1333,47 @@ func (controller *SolverController) updateResourceOfferState(id string, dealID s
 }
 
 // this will also update the job and resource offer states
-func (controller *SolverController) updateDealState(id string, state uint8) (*data.DealContainer, error) {
+func (controller *SolverController) updateDealState(ctx context.Context, id string, state uint8) (*data.DealContainer, error) {
+	_, span := controller.tracer.Start(ctx, "update_deal_state",
+		trace.WithAttributes(
+			attribute.String("deal.id", id),
+			attribute.String("deal.state", data.GetAgreementStateString(state)),
+		),
+	)
+	defer span.End()
+
 	controller.log.Info("update deal", fmt.Sprintf("%s %s", id, data.GetAgreementStateString(state)))
 
+	// the store also enforces this but we want to know about
+	// events that would move a deal backwards before we touch anything
+	existingDeal, err := controller.store.GetDeal(id)
+	if err != nil {
+		return nil, err
+	}
+	if existingDeal == nil {
+		return nil, fmt.Errorf("deal not found: %s", id)
+	}
+	err = data.ValidateDealStateTransition(existingDeal.State, state)
+	if err != nil {
+		span.SetStatus(codes.Error, "invalid deal state transition")
+		span.RecordError(err)
+		return nil, err
+	}
+
+	span.AddEvent("store.update_deal_state.start")
 	dealContainer, err := controller.store.UpdateDealState(id, state)
 	if err != nil {
+		span.SetStatus(codes.Error, "update deal state in store failed")
+		span.RecordError(err)
 		return nil, err
 	}
+	span.AddEvent("store.update_deal_state.done")
+	// nothing else will happen to the deal that we want to trace
+	if data.DealState(dealContainer.State).IsTerminal() {
+		controller.spanLinks.forget(id)
+		controller.logs.finish(id)
+		controller.services.finish(id)
+	}
 
 	controller.writeEvent(SolverEvent{
 		EventType: DealStateUpdated,
@@ -525,6 +1387,37 @@ func (controller *SolverController) updateDealState(id string, state uint8) (*da
 	if err != nil {
 		return nil, err
 	}
+	if dealContainer.Deal.JobOffer.Retry != nil && data.DealState(dealContainer.State).IsTerminal() {
+		go controller.retryDeal(*dealContainer)
+	}
+	return dealContainer, nil
+}
+
+// a reorg took back a state change so set the deal to whatever
+// state the chain has for it now, the offers go back with it
+func (controller *SolverController) rollbackDealState(chainSDK web3.Web3Client, id string) (*data.DealContainer, error) {
+	existingDeal, err := controller.store.GetDeal(id)
+	if err != nil {
+		return nil, err
+	}
+	// this is a deal from another solver
+	if existingDeal == nil {
+		return nil, nil
+	}
+	state, err := chainSDK.GetDealState(id)
+	if err != nil {
+		return nil, err
+	}
+	controller.log.Info("rollback deal", fmt.Sprintf("%s %s to %s", id, data.GetAgreementStateString(existingDeal.State), data.GetAgreementStateString(state)))
+	dealContainer, err := controller.store.RollbackDealState(id, state)
+	if err != nil {
+		return nil, err
+	}
+	controller.writeEvent(SolverEvent{
+		EventType: DealStateUpdated,
+		Deal:      dealContainer,
+	})
+	controller.loop.Trigger()
 	return dealContainer, nil
 }
 
@@ -542,6 +1435,68 @@ func (controller *SolverController) updateDealMediator(id string, mediator strin
 	return dealContainer, nil
 }
 
+// an unmatched job offer is simply cancelled, a deal that has been made
+// is marked so the resource provider stops the job and settles it
+func (controller *SolverController) cancelJobOffer(jobOffer data.JobOfferContainer) (*data.JobOfferContainer, error) {
+	if jobOffer.DealID == "" {
+		if data.DealState(jobOffer.State) != data.DealNegotiating {
+			return nil, fmt.Errorf("job offer %s is %s and cannot be cancelled", jobOffer.ID, data.GetAgreementStateString(jobOffer.State))
+		}
+		return controller.updateJobOfferState(jobOffer.ID, "", data.GetAgreementStateIndex("JobOfferCancelled"))
+	}
+	deal, err := controller.store.GetDeal(jobOffer.DealID)
+	if err != nil {
+		return nil, err
+	}
+	if deal == nil {
+		return nil, fmt.Errorf("deal not found: %s", jobOffer.DealID)
+	}
+	// once there are results the deal settles the usual way
+	if state := data.DealState(deal.State); state != data.DealNegotiating && state != data.DealAgreed {
+		return nil, fmt.Errorf("deal %s is %s so there is nothing left to cancel", deal.ID, state)
+	}
+	controller.log.Info("cancel deal", deal.ID)
+	dealContainer, err := controller.store.CancelDeal(deal.ID, time.Now().Unix())
+	if err != nil {
+		return nil, err
+	}
+	controller.writeEvent(SolverEvent{
+		EventType: DealCancelled,
+		Deal:      dealContainer,
+	})
+	return &jobOffer, nil
+}
+
+// only spot capacity can be taken back and only while the job is running
+// the job keeps going for the notice the resource offer promised
+func (controller *SolverController) preemptDeal(deal data.DealContainer, reason string) (*data.DealContainer, error) {
+	if !data.IsSpotDeal(deal) {
+		return nil, fmt.Errorf("deal %s is on reserved capacity and cannot be preempted", deal.ID)
+	}
+	if data.DealState(deal.State) != data.DealAgreed {
+		return nil, fmt.Errorf("deal %s is %s and has no job to preempt", deal.ID, data.GetAgreementStateString(deal.State))
+	}
+	if deal.Preemption != nil {
+		return &deal, nil
+	}
+	now := time.Now().Unix()
+	controller.log.Info("preempt deal", fmt.Sprintf("%s %s", deal.ID, reason))
+	dealContainer, err := controller.store.PreemptDeal(deal.ID, data.DealPreemption{
+		DealID:    deal.ID,
+		Reason:    reason,
+		CreatedAt: now,
+		StopAt:    now + int64(deal.Deal.ResourceOffer.PreemptionNotice),
+	})
+	if err != nil {
+		return nil, err
+	}
+	controller.writeEvent(SolverEvent{
+		EventType: DealPreempted,
+		Deal:      dealContainer,
+	})
+	return dealContainer, nil
+}
+
 /*
 *
 *
@@ -553,10 +1508,18 @@ func (controller *SolverController) updateDealMediator(id string, mediator strin
 *
 *
 */
-func (controller *SolverController) updateDealTransactionsResourceProvider(id string, payload data.DealTransactionsResourceProvider) (*data.DealContainer, error) {
+func (controller *SolverController) updateDealTransactionsResourceProvider(ctx context.Context, id string, payload data.DealTransactionsResourceProvider) (*data.DealContainer, error) {
+	_, span := controller.tracer.Start(ctx, "update_deal_transactions.resource_provider",
+		trace.WithLinks(controller.spanLinks.get(id)...),
+		trace.WithAttributes(attribute.String("deal.id", id)),
+	)
+	defer span.End()
+
 	controller.log.Info("update resource provider txs", payload)
 	dealContainer, err := controller.store.UpdateDealTransactionsResourceProvider(id, payload)
 	if err != nil {
+		span.SetStatus(codes.Error, "update transactions in store failed")
+		span.RecordError(err)
 		return nil, err
 	}
 	controller.writeEvent(SolverEvent{
@@ -566,10 +1529,18 @@ func (controller *SolverController) updateDealTransactionsResourceProvider(id st
 	return dealContainer, nil
 }
 
-func (controller *SolverController) updateDealTransactionsJobCreator(id string, payload data.DealTransactionsJobCreator) (*data.DealContainer, error) {
+func (controller *SolverController) updateDealTransactionsJobCreator(ctx context.Context, id string, payload data.DealTransactionsJobCreator) (*data.DealContainer, error) {
+	_, span := controller.tracer.Start(ctx, "update_deal_transactions.job_creator",
+		trace.WithLinks(controller.spanLinks.get(id)...),
+		trace.WithAttributes(attribute.String("deal.id", id)),
+	)
+	defer span.End()
+
 	controller.log.Info("update job creator txs", payload)
 	dealContainer, err := controller.store.UpdateDealTransactionsJobCreator(id, payload)
 	if err != nil {
+		span.SetStatus(codes.Error, "update transactions in store failed")
+		span.RecordError(err)
 		return nil, err
 	}
 	controller.writeEvent(SolverEvent{
@@ -579,10 +1550,18 @@ func (controller *SolverController) updateDealTransactionsJobCreator(id string,
 	return dealContainer, nil
 }
 
-func (controller *SolverController) updateDealTransactionsMediator(id string, payload data.DealTransactionsMediator) (*data.DealContainer, error) {
+func (controller *SolverController) updateDealTransactionsMediator(ctx context.Context, id string, payload data.DealTransactionsMediator) (*data.DealContainer, error) {
+	_, span := controller.tracer.Start(ctx, "update_deal_transactions.mediator",
+		trace.WithLinks(controller.spanLinks.get(id)...),
+		trace.WithAttributes(attribute.String("deal.id", id)),
+	)
+	defer span.End()
+
 	controller.log.Info("update mediator txs", payload)
 	dealContainer, err := controller.store.UpdateDealTransactionsMediator(id, payload)
 	if err != nil {
+		span.SetStatus(codes.Error, "update transactions in store failed")
+		span.RecordError(err)
 		return nil, err
 	}
 	controller.writeEvent(SolverEvent{
@@ -0,0 +1,78 @@
+package solver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/http"
+	"github.com/lilypad-tech/lilypad/pkg/web3"
+	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/users"
+	"github.com/lilypad-tech/lilypad/pkg/web3/mock_web3"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRegisterAsSolver(t *testing.T) {
+	solverAddress := common.HexToAddress("0x1")
+	solverType, err := data.GetServiceType("Solver")
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name            string
+		registeredUrl   string
+		existingSolvers []common.Address
+		expectUpdate    bool
+		expectAdd       bool
+	}{
+		{
+			name:            "New solver",
+			registeredUrl:   "",
+			existingSolvers: []common.Address{},
+			expectUpdate:    true,
+			expectAdd:       true,
+		},
+		{
+			name:            "URL changed",
+			registeredUrl:   "http://old:8080",
+			existingSolvers: []common.Address{solverAddress},
+			expectUpdate:    true,
+			expectAdd:       false,
+		},
+		{
+			name:            "Already registered",
+			registeredUrl:   "http://solver:8080",
+			existingSolvers: []common.Address{solverAddress},
+			expectUpdate:    false,
+			expectAdd:       false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			web3SDK := mock_web3.NewMockWeb3Client(ctrl)
+
+			web3SDK.EXPECT().GetProtocolParameters().Return(web3.ProtocolParameters{}, nil)
+			web3SDK.EXPECT().GetAddress().Return(solverAddress).AnyTimes()
+			web3SDK.EXPECT().GetUser(solverAddress).Return(users.SharedStructsUser{Url: tc.registeredUrl}, nil)
+			web3SDK.EXPECT().GetSolverAddresses().Return(tc.existingSolvers, nil)
+			if tc.expectUpdate {
+				web3SDK.EXPECT().UpdateUser("", "http://solver:8080", []uint8{solverType}).Return(nil)
+			}
+			if tc.expectAdd {
+				web3SDK.EXPECT().AddUserToList(solverType).Return(nil)
+			}
+
+			controller, err := NewSolverController(web3SDK, nil, SolverOptions{
+				Server: http.ServerOptions{URL: "http://solver:8080"},
+			}, noop.NewTracerProvider().Tracer("test"))
+			assert.NoError(t, err)
+
+			err = controller.registerAsSolver(context.Background())
+			assert.NoError(t, err)
+		})
+	}
+}
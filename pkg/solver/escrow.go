@@ -0,0 +1,77 @@
+package solver
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/escrow"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/payments"
+)
+
+// keep the payments the contract makes for our deals so we can
+// check them against what the deal says should have been paid
+func (controller *SolverController) recordEscrowPayment(ev payments.PaymentsPayment) error {
+	deal, err := controller.store.GetDeal(ev.DealId)
+	if err != nil {
+		return err
+	}
+	// this is a deal from another solver
+	if deal == nil {
+		return nil
+	}
+	_, err = controller.store.AddEscrowPayment(data.EscrowPayment{
+		DealID:          ev.DealId,
+		Payee:           ev.Payee.String(),
+		Amount:          ev.Amount.String(),
+		Reason:          ev.Reason,
+		Direction:       ev.Direction,
+		TransactionHash: ev.Raw.TxHash.String(),
+		LogIndex:        ev.Raw.Index,
+		CreatedAt:       time.Now().Unix(),
+	})
+	return err
+}
+
+func (controller *SolverController) getEscrowAccount(dealID string) (escrow.DealAccount, error) {
+	deal, err := controller.store.GetDeal(dealID)
+	if err != nil {
+		return escrow.DealAccount{}, err
+	}
+	if deal == nil {
+		return escrow.DealAccount{}, fmt.Errorf("deal not found")
+	}
+	return controller.reconcileDeal(*deal)
+}
+
+func (controller *SolverController) reconcileDeal(deal data.DealContainer) (escrow.DealAccount, error) {
+	result, err := controller.store.GetResult(deal.ID)
+	if err != nil {
+		return escrow.DealAccount{}, err
+	}
+	payments, err := controller.store.GetEscrowPayments(deal.ID)
+	if err != nil {
+		return escrow.DealAccount{}, err
+	}
+	return escrow.Reconcile(deal, result, payments), nil
+}
+
+// the accounts of every deal whose payments do not add up
+func (controller *SolverController) getEscrowDiscrepancies() ([]escrow.DealAccount, error) {
+	deals, err := controller.store.GetDeals(store.GetDealsQuery{})
+	if err != nil {
+		return nil, err
+	}
+	accounts := []escrow.DealAccount{}
+	for _, deal := range deals {
+		account, err := controller.reconcileDeal(deal)
+		if err != nil {
+			return nil, err
+		}
+		if !account.Reconciled {
+			accounts = append(accounts, account)
+		}
+	}
+	return accounts, nil
+}
@@ -0,0 +1,24 @@
+package solver
+
+import (
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/module"
+	"github.com/lilypad-tech/lilypad/pkg/solver/matcher"
+)
+
+// what the open resource offers that could run the job are asking for it
+func (controller *SolverController) getCostEstimate(query data.CostEstimateQuery) (data.CostEstimate, error) {
+	moduleConfig, err := module.ProcessModule(query.Module)
+	if err != nil {
+		return data.CostEstimate{}, data.NewError(data.ErrInvalidRequest, "invalid module: %s", err.Error())
+	}
+	instructions := query.Instructions
+	if instructions == 0 {
+		instructions = 1
+	}
+	return matcher.GetCostEstimate(controller.store, data.JobOffer{
+		Module:  moduleConfig,
+		Spec:    query.Spec,
+		ChainID: query.ChainID,
+	}, instructions)
+}
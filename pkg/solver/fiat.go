@@ -0,0 +1,47 @@
+package solver
+
+import (
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+// the prices of a job offer in the fiat currency, nil if we do not
+// have a price oracle or it cannot give us a rate right now
+func (controller *SolverController) getFiatPrices(jobOffer data.JobOffer) *data.FiatPrices {
+	if controller.fiat == nil {
+		return nil
+	}
+	prices, err := controller.fiat.GetFiatPrices(jobOffer.Pricing, jobOffer.PaymentToken)
+	if err != nil {
+		controller.log.Debug("no fiat prices", err)
+		return nil
+	}
+	return prices
+}
+
+// copies so the containers in the store are not changed
+func (controller *SolverController) addFiatToJobOffers(jobOffers []data.JobOfferContainer) []data.JobOfferContainer {
+	if controller.fiat == nil {
+		return jobOffers
+	}
+	annotated := make([]data.JobOfferContainer, 0, len(jobOffers))
+	for _, jobOffer := range jobOffers {
+		jobOffer.Fiat = controller.getFiatPrices(jobOffer.JobOffer)
+		annotated = append(annotated, jobOffer)
+	}
+	return annotated
+}
+
+func (controller *SolverController) addFiatToDeals(deals []data.DealContainer) []data.DealContainer {
+	if controller.fiat == nil {
+		return deals
+	}
+	annotated := make([]data.DealContainer, 0, len(deals))
+	for _, deal := range deals {
+		// the deal pricing is what was agreed so use it over the job offer
+		jobOffer := deal.Deal.JobOffer
+		jobOffer.Pricing = deal.Deal.Pricing
+		deal.Fiat = controller.getFiatPrices(jobOffer)
+		annotated = append(annotated, deal)
+	}
+	return annotated
+}
@@ -0,0 +1,338 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/http"
+	"github.com/lilypad-tech/lilypad/pkg/solver/pb"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+	"github.com/lilypad-tech/lilypad/pkg/web3"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// how many deal events a watcher can fall behind by before it is dropped
+const GRPC_WATCH_BUFFER = 256
+
+type GRPCOptions struct {
+	// the port to serve the gRPC API on alongside the HTTP one
+	// zero means the gRPC API is not served
+	Port int
+}
+
+type dealWatcher struct {
+	query    *pb.WatchDealsRequest
+	events   chan SolverEvent
+	overflow chan struct{}
+	once     sync.Once
+}
+
+func (watcher *dealWatcher) matches(deal data.DealContainer) bool {
+	if watcher.query.DealId != "" && watcher.query.DealId != deal.ID {
+		return false
+	}
+	if watcher.query.JobCreator != "" && watcher.query.JobCreator != deal.JobCreator {
+		return false
+	}
+	if watcher.query.ResourceProvider != "" && watcher.query.ResourceProvider != deal.ResourceProvider {
+		return false
+	}
+	return true
+}
+
+// serves the pb.SolverServer API over the same controller and store as the
+// HTTP server, offers go through the same checks whichever way they arrive
+type solverGRPCServer struct {
+	pb.UnimplementedSolverServer
+	options    GRPCOptions
+	host       string
+	controller *SolverController
+	store      store.SolverStore
+
+	mutex    sync.Mutex
+	watchers map[*dealWatcher]struct{}
+}
+
+func NewSolverGRPCServer(
+	options GRPCOptions,
+	host string,
+	controller *SolverController,
+	store store.SolverStore,
+) *solverGRPCServer {
+	server := &solverGRPCServer{
+		options:    options,
+		host:       host,
+		controller: controller,
+		store:      store,
+		watchers:   map[*dealWatcher]struct{}{},
+	}
+	controller.subscribeEvents(server.publish)
+	return server
+}
+
+func (server *solverGRPCServer) ListenAndServe(ctx context.Context) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", server.host, server.options.Port))
+	if err != nil {
+		return err
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterSolverServer(grpcServer, server)
+
+	serverErrors := make(chan error, 1)
+	go func() {
+		serverErrors <- grpcServer.Serve(listener)
+	}()
+
+	select {
+	case err := <-serverErrors:
+		return err
+	case <-ctx.Done():
+		grpcServer.GracefulStop()
+	}
+	return nil
+}
+
+// the signed address from the same headers the HTTP API uses
+func getAddressFromMetadata(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "missing user header")
+	}
+	get := func(key string) string {
+		values := md.Get(key)
+		if len(values) == 0 {
+			return ""
+		}
+		return values[0]
+	}
+	address, err := http.GetAddressFromUserHeaders(get(http.X_LILYPAD_USER_HEADER), get(http.X_LILYPAD_SIGNATURE_HEADER))
+	if err != nil {
+		return "", status.Error(codes.Unauthenticated, err.Error())
+	}
+	return address, nil
+}
+
+// the gRPC status for the class of an error, the message says which code it was
+func getGRPCError(err error) error {
+	code := data.GetErrorCode(err)
+	grpcCode := codes.Unknown
+	switch code {
+	case data.ErrInvalidRequest, data.ErrModuleNotAllowed, data.ErrModuleLoadFailed, data.ErrPriceMismatch:
+		grpcCode = codes.InvalidArgument
+	case data.ErrUnauthorized:
+		grpcCode = codes.Unauthenticated
+	case data.ErrForbidden, data.ErrBanned:
+		grpcCode = codes.PermissionDenied
+	case data.ErrNotFound:
+		grpcCode = codes.NotFound
+	case data.ErrConflict:
+		grpcCode = codes.FailedPrecondition
+	case data.ErrInsufficientResources, data.ErrBudgetExceeded:
+		grpcCode = codes.ResourceExhausted
+	case data.ErrTimeout:
+		grpcCode = codes.DeadlineExceeded
+	case data.ErrReadOnly:
+		grpcCode = codes.Unimplemented
+	case "":
+		return status.Error(codes.Unknown, err.Error())
+	}
+	return status.Errorf(grpcCode, "%s: %s", code, err.Error())
+}
+
+func (server *solverGRPCServer) SubmitJobOffer(ctx context.Context, req *pb.SubmitJobOfferRequest) (*pb.JobOfferContainer, error) {
+	err := server.controller.checkWritable()
+	if err != nil {
+		return nil, getGRPCError(err)
+	}
+	signerAddress, err := getAddressFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	jobOffer := jobOfferFromProto(req.JobOffer)
+	// only the job creator can post a job offer
+	if signerAddress != jobOffer.JobCreator {
+		return nil, status.Error(codes.PermissionDenied, "job creator address does not match signer address")
+	}
+	err = data.CheckJobOffer(jobOffer)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ret, err := server.controller.addJobOffer(ctx, jobOffer)
+	if err != nil {
+		return nil, getGRPCError(err)
+	}
+	return jobOfferContainerToProto(*ret), nil
+}
+
+func (server *solverGRPCServer) SubmitResourceOffer(ctx context.Context, req *pb.SubmitResourceOfferRequest) (*pb.ResourceOfferContainer, error) {
+	err := server.controller.checkWritable()
+	if err != nil {
+		return nil, getGRPCError(err)
+	}
+	signerAddress, err := getAddressFromMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resourceOffer := resourceOfferFromProto(req.ResourceOffer)
+	// only the resource provider can post a resource offer
+	if signerAddress != resourceOffer.ResourceProvider {
+		return nil, status.Error(codes.PermissionDenied, "resource provider address does not match signer address")
+	}
+	err = data.CheckResourceOffer(resourceOffer)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	ret, err := server.controller.addResourceOffer(ctx, resourceOffer)
+	if err != nil {
+		return nil, getGRPCError(err)
+	}
+	return resourceOfferContainerToProto(*ret), nil
+}
+
+func (server *solverGRPCServer) GetResults(ctx context.Context, req *pb.GetResultsRequest) (*pb.GetResultsResponse, error) {
+	results := []*pb.Result{}
+	for _, id := range req.DealIds {
+		result, err := server.store.GetResult(id)
+		if err != nil {
+			return nil, err
+		}
+		if result == nil {
+			continue
+		}
+		results = append(results, resultToProto(*result))
+	}
+	return &pb.GetResultsResponse{Results: results}, nil
+}
+
+// hand deal changes to the watchers, a watcher that is not keeping up
+// is dropped rather than holding up the controller
+func (server *solverGRPCServer) publish(ev SolverEvent) {
+	if ev.Deal == nil {
+		return
+	}
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	for watcher := range server.watchers {
+		if !watcher.matches(*ev.Deal) {
+			continue
+		}
+		select {
+		case watcher.events <- ev:
+		default:
+			watcher.once.Do(func() {
+				close(watcher.overflow)
+			})
+		}
+	}
+}
+
+func (server *solverGRPCServer) WatchDeals(req *pb.WatchDealsRequest, stream pb.Solver_WatchDealsServer) error {
+	watcher := &dealWatcher{
+		query:    req,
+		events:   make(chan SolverEvent, GRPC_WATCH_BUFFER),
+		overflow: make(chan struct{}),
+	}
+	// start listening before we list the existing deals so nothing falls
+	// between the two, a deal may be sent twice but it will not be missed
+	server.mutex.Lock()
+	server.watchers[watcher] = struct{}{}
+	server.mutex.Unlock()
+	defer func() {
+		server.mutex.Lock()
+		delete(server.watchers, watcher)
+		server.mutex.Unlock()
+	}()
+
+	if req.IncludeExisting {
+		deals, err := server.existingDeals(req)
+		if err != nil {
+			return err
+		}
+		for _, deal := range deals {
+			err := stream.Send(&pb.DealEvent{
+				EventType: string(DealAdded),
+				Deal:      dealContainerToProto(deal),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-watcher.overflow:
+			log.Debug().Msgf("dropping deal watcher that fell behind")
+			return status.Error(codes.ResourceExhausted, "deal watcher fell behind")
+		case ev := <-watcher.events:
+			err := stream.Send(&pb.DealEvent{
+				EventType: string(ev.EventType),
+				Deal:      dealContainerToProto(*ev.Deal),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (server *solverGRPCServer) existingDeals(req *pb.WatchDealsRequest) ([]data.DealContainer, error) {
+	if req.DealId != "" {
+		deal, err := server.store.GetDeal(req.DealId)
+		if err != nil {
+			return nil, err
+		}
+		if deal == nil {
+			return nil, nil
+		}
+		watcher := &dealWatcher{query: req}
+		if !watcher.matches(*deal) {
+			return nil, nil
+		}
+		return []data.DealContainer{*deal}, nil
+	}
+	return server.store.GetDeals(store.GetDealsQuery{
+		JobCreator:       req.JobCreator,
+		ResourceProvider: req.ResourceProvider,
+	})
+}
+
+// signs each call to the gRPC API the same way the HTTP client signs its requests
+type grpcCredentials struct {
+	signer  web3.Signer
+	address string
+}
+
+func NewGRPCCredentials(signer web3.Signer) grpc.CallOption {
+	return grpc.PerRPCCredentials(&grpcCredentials{
+		signer:  signer,
+		address: signer.Address().String(),
+	})
+}
+
+func (credentials *grpcCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	headers, err := http.GetUserHeaders(credentials.signer, credentials.address)
+	if err != nil {
+		return nil, err
+	}
+	// gRPC metadata keys are lower case
+	md := map[string]string{}
+	for key, value := range headers {
+		md[strings.ToLower(key)] = value
+	}
+	return md, nil
+}
+
+func (credentials *grpcCredentials) RequireTransportSecurity() bool {
+	return false
+}
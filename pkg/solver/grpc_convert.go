@@ -0,0 +1,722 @@
+package solver
+
+import (
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/pb"
+)
+
+// the gRPC messages mirror the data types field for field so these only
+// copy across, the solver always works with the data types
+
+func machineSpecFromProto(spec *pb.MachineSpec) data.MachineSpec {
+	if spec == nil {
+		return data.MachineSpec{}
+	}
+	var gpus []data.GPUSpec
+	for _, gpu := range spec.Gpus {
+		gpus = append(gpus, data.GPUSpec{
+			Name:   gpu.Name,
+			Vendor: gpu.Vendor,
+			VRAM:   int(gpu.Vram),
+		})
+	}
+	return data.MachineSpec{
+		GPU:  int(spec.Gpu),
+		GPUs: gpus,
+		CPU:  int(spec.Cpu),
+		RAM:  int(spec.Ram),
+		Disk: int(spec.Disk),
+	}
+}
+
+func machineSpecToProto(spec data.MachineSpec) *pb.MachineSpec {
+	var gpus []*pb.GPUSpec
+	for _, gpu := range spec.GPUs {
+		gpus = append(gpus, &pb.GPUSpec{
+			Name:   gpu.Name,
+			Vendor: gpu.Vendor,
+			Vram:   int64(gpu.VRAM),
+		})
+	}
+	return &pb.MachineSpec{
+		Gpu:  int64(spec.GPU),
+		Gpus: gpus,
+		Cpu:  int64(spec.CPU),
+		Ram:  int64(spec.RAM),
+		Disk: int64(spec.Disk),
+	}
+}
+
+func moduleConfigFromProto(module *pb.ModuleConfig) data.ModuleConfig {
+	if module == nil {
+		return data.ModuleConfig{}
+	}
+	return data.ModuleConfig{
+		Name:        module.Name,
+		Repo:        module.Repo,
+		Hash:        module.Hash,
+		Path:        module.Path,
+		ImageDigest: module.ImageDigest,
+	}
+}
+
+func moduleConfigToProto(module data.ModuleConfig) *pb.ModuleConfig {
+	return &pb.ModuleConfig{
+		Name:        module.Name,
+		Repo:        module.Repo,
+		Hash:        module.Hash,
+		Path:        module.Path,
+		ImageDigest: module.ImageDigest,
+	}
+}
+
+func dealPricingFromProto(pricing *pb.DealPricing) data.DealPricing {
+	if pricing == nil {
+		return data.DealPricing{}
+	}
+	return data.DealPricing{
+		InstructionPrice:          pricing.InstructionPrice,
+		PaymentCollateral:         pricing.PaymentCollateral,
+		ResultsCollateralMultiple: pricing.ResultsCollateralMultiple,
+		MediationFee:              pricing.MediationFee,
+	}
+}
+
+func dealPricingToProto(pricing data.DealPricing) *pb.DealPricing {
+	return &pb.DealPricing{
+		InstructionPrice:          pricing.InstructionPrice,
+		PaymentCollateral:         pricing.PaymentCollateral,
+		ResultsCollateralMultiple: pricing.ResultsCollateralMultiple,
+		MediationFee:              pricing.MediationFee,
+	}
+}
+
+func dealTimeoutFromProto(timeout *pb.DealTimeout) data.DealTimeout {
+	if timeout == nil {
+		return data.DealTimeout{}
+	}
+	return data.DealTimeout{
+		Timeout:    timeout.Timeout,
+		Collateral: timeout.Collateral,
+	}
+}
+
+func dealTimeoutToProto(timeout data.DealTimeout) *pb.DealTimeout {
+	return &pb.DealTimeout{
+		Timeout:    timeout.Timeout,
+		Collateral: timeout.Collateral,
+	}
+}
+
+func dealTimeoutsFromProto(timeouts *pb.DealTimeouts) data.DealTimeouts {
+	if timeouts == nil {
+		return data.DealTimeouts{}
+	}
+	return data.DealTimeouts{
+		Agree:          dealTimeoutFromProto(timeouts.Agree),
+		SubmitResults:  dealTimeoutFromProto(timeouts.SubmitResults),
+		JudgeResults:   dealTimeoutFromProto(timeouts.JudgeResults),
+		MediateResults: dealTimeoutFromProto(timeouts.MediateResults),
+	}
+}
+
+func dealTimeoutsToProto(timeouts data.DealTimeouts) *pb.DealTimeouts {
+	return &pb.DealTimeouts{
+		Agree:          dealTimeoutToProto(timeouts.Agree),
+		SubmitResults:  dealTimeoutToProto(timeouts.SubmitResults),
+		JudgeResults:   dealTimeoutToProto(timeouts.JudgeResults),
+		MediateResults: dealTimeoutToProto(timeouts.MediateResults),
+	}
+}
+
+func serviceConfigFromProto(services *pb.ServiceConfig) data.ServiceConfig {
+	if services == nil {
+		return data.ServiceConfig{}
+	}
+	return data.ServiceConfig{
+		Solver:   services.Solver,
+		Mediator: services.Mediator,
+		APIHost:  services.ApiHost,
+	}
+}
+
+func serviceConfigToProto(services data.ServiceConfig) *pb.ServiceConfig {
+	return &pb.ServiceConfig{
+		Solver:   services.Solver,
+		Mediator: services.Mediator,
+		ApiHost:  services.APIHost,
+	}
+}
+
+func jobOfferFromProto(offer *pb.JobOffer) data.JobOffer {
+	if offer == nil {
+		return data.JobOffer{}
+	}
+	target := data.TargetConfig{}
+	if offer.Target != nil {
+		target.Address = offer.Target.Address
+		target.ResourceOffer = offer.Target.ResourceOffer
+	}
+	return data.JobOffer{
+		ID:                  offer.Id,
+		CreatedAt:           int(offer.CreatedAt),
+		JobCreator:          offer.JobCreator,
+		ChainID:             int(offer.ChainId),
+		Module:              moduleConfigFromProto(offer.Module),
+		Spec:                machineSpecFromProto(offer.Spec),
+		Inputs:              offer.Inputs,
+		InputSize:           int(offer.InputSize),
+		MaxRuntime:          int(offer.MaxRuntime),
+		Mode:                data.PricingMode(offer.Mode),
+		Pricing:             dealPricingFromProto(offer.Pricing),
+		Timeouts:            dealTimeoutsFromProto(offer.Timeouts),
+		Services:            serviceConfigFromProto(offer.Services),
+		Target:              target,
+		TrustedProviders:    offer.TrustedProviders,
+		ExcludedProviders:   offer.ExcludedProviders,
+		RequiredLabels:      offer.RequiredLabels,
+		PreferredLabels:     offer.PreferredLabels,
+		ResumeFrom:          offer.ResumeFrom,
+		MediatorQuorum:      mediatorQuorumFromProto(offer.MediatorQuorum),
+		Env:                 offer.Env,
+		GroupID:             offer.GroupId,
+		ScheduleID:          offer.ScheduleId,
+		WorkflowID:          offer.WorkflowId,
+		InputCIDs:           offer.InputCids,
+		EncryptedInputs:     offer.EncryptedInputs,
+		TEE:                 teeRequirementFromProto(offer.Tee),
+		Network:             networkPolicyFromProto(offer.Network),
+		PaymentToken:        paymentTokenFromProto(offer.PaymentToken),
+		Milestones:          offer.Milestones,
+		OfferClasses:        offer.OfferClasses,
+		MinPreemptionNotice: int(offer.MinPreemptionNotice),
+		IdempotencyKey:      offer.IdempotencyKey,
+		Client:              clientMetadataFromProto(offer.Client),
+		ParentDealID:        offer.ParentDealId,
+		Retry:               retryPolicyFromProto(offer.Retry),
+		ReuseResults:        offer.ReuseResults,
+		Serve:               serviceDealConfigFromProto(offer.Serve),
+		Engine:              offer.Engine,
+		Metered:             offer.Metered,
+	}
+}
+
+func jobOfferToProto(offer data.JobOffer) *pb.JobOffer {
+	return &pb.JobOffer{
+		Id:                  offer.ID,
+		CreatedAt:           int64(offer.CreatedAt),
+		JobCreator:          offer.JobCreator,
+		ChainId:             int64(offer.ChainID),
+		Module:              moduleConfigToProto(offer.Module),
+		Spec:                machineSpecToProto(offer.Spec),
+		Inputs:              offer.Inputs,
+		InputSize:           int64(offer.InputSize),
+		MaxRuntime:          int64(offer.MaxRuntime),
+		Mode:                string(offer.Mode),
+		Pricing:             dealPricingToProto(offer.Pricing),
+		Timeouts:            dealTimeoutsToProto(offer.Timeouts),
+		Services:            serviceConfigToProto(offer.Services),
+		Target:              &pb.TargetConfig{Address: offer.Target.Address, ResourceOffer: offer.Target.ResourceOffer},
+		TrustedProviders:    offer.TrustedProviders,
+		ExcludedProviders:   offer.ExcludedProviders,
+		RequiredLabels:      offer.RequiredLabels,
+		PreferredLabels:     offer.PreferredLabels,
+		ResumeFrom:          offer.ResumeFrom,
+		MediatorQuorum:      mediatorQuorumToProto(offer.MediatorQuorum),
+		Env:                 offer.Env,
+		GroupId:             offer.GroupID,
+		ScheduleId:          offer.ScheduleID,
+		WorkflowId:          offer.WorkflowID,
+		InputCids:           offer.InputCIDs,
+		EncryptedInputs:     offer.EncryptedInputs,
+		Tee:                 teeRequirementToProto(offer.TEE),
+		Network:             networkPolicyToProto(offer.Network),
+		PaymentToken:        paymentTokenToProto(offer.PaymentToken),
+		Milestones:          offer.Milestones,
+		OfferClasses:        offer.OfferClasses,
+		MinPreemptionNotice: int64(offer.MinPreemptionNotice),
+		IdempotencyKey:      offer.IdempotencyKey,
+		Client:              clientMetadataToProto(offer.Client),
+		ParentDealId:        offer.ParentDealID,
+		Retry:               retryPolicyToProto(offer.Retry),
+		ReuseResults:        offer.ReuseResults,
+		Serve:               serviceDealConfigToProto(offer.Serve),
+		Engine:              offer.Engine,
+		Metered:             offer.Metered,
+	}
+}
+
+func clientMetadataFromProto(client *pb.ClientMetadata) *data.ClientMetadata {
+	if client == nil {
+		return nil
+	}
+	return &data.ClientMetadata{
+		Version:         client.Version,
+		ProtocolVersion: int(client.ProtocolVersion),
+		Capabilities:    client.Capabilities,
+	}
+}
+
+func clientMetadataToProto(client *data.ClientMetadata) *pb.ClientMetadata {
+	if client == nil {
+		return nil
+	}
+	return &pb.ClientMetadata{
+		Version:         client.Version,
+		ProtocolVersion: int64(client.ProtocolVersion),
+		Capabilities:    client.Capabilities,
+	}
+}
+
+func mediatorQuorumFromProto(quorum *pb.MediatorQuorum) *data.MediatorQuorum {
+	if quorum == nil {
+		return nil
+	}
+	return &data.MediatorQuorum{
+		Size:      int(quorum.Size),
+		Threshold: int(quorum.Threshold),
+	}
+}
+
+func mediatorQuorumToProto(quorum *data.MediatorQuorum) *pb.MediatorQuorum {
+	if quorum == nil {
+		return nil
+	}
+	return &pb.MediatorQuorum{
+		Size:      int64(quorum.Size),
+		Threshold: int64(quorum.Threshold),
+	}
+}
+
+func retryPolicyFromProto(policy *pb.RetryPolicy) *data.RetryPolicy {
+	if policy == nil {
+		return nil
+	}
+	return &data.RetryPolicy{
+		MaxAttempts:            int(policy.MaxAttempts),
+		OnTimeout:              policy.OnTimeout,
+		OnError:                policy.OnError,
+		ExcludeFailedProviders: policy.ExcludeFailedProviders,
+	}
+}
+
+func retryPolicyToProto(policy *data.RetryPolicy) *pb.RetryPolicy {
+	if policy == nil {
+		return nil
+	}
+	return &pb.RetryPolicy{
+		MaxAttempts:            int64(policy.MaxAttempts),
+		OnTimeout:              policy.OnTimeout,
+		OnError:                policy.OnError,
+		ExcludeFailedProviders: policy.ExcludeFailedProviders,
+	}
+}
+
+func serviceDealConfigFromProto(serve *pb.ServiceDealConfig) *data.ServiceDealConfig {
+	if serve == nil {
+		return nil
+	}
+	return &data.ServiceDealConfig{
+		Port:     int(serve.Port),
+		Duration: int(serve.Duration),
+	}
+}
+
+func serviceDealConfigToProto(serve *data.ServiceDealConfig) *pb.ServiceDealConfig {
+	if serve == nil {
+		return nil
+	}
+	return &pb.ServiceDealConfig{
+		Port:     int64(serve.Port),
+		Duration: int64(serve.Duration),
+	}
+}
+
+func jobOfferContainerToProto(container data.JobOfferContainer) *pb.JobOfferContainer {
+	return &pb.JobOfferContainer{
+		Id:           container.ID,
+		DealId:       container.DealID,
+		JobCreator:   container.JobCreator,
+		State:        uint32(container.State),
+		JobOffer:     jobOfferToProto(container.JobOffer),
+		ReusedResult: container.ReusedResult,
+	}
+}
+
+func teeRequirementFromProto(requirement *pb.TEERequirement) *data.TEERequirement {
+	if requirement == nil {
+		return nil
+	}
+	return &data.TEERequirement{
+		Types:        requirement.Types,
+		Measurements: requirement.Measurements,
+	}
+}
+
+func teeRequirementToProto(requirement *data.TEERequirement) *pb.TEERequirement {
+	if requirement == nil {
+		return nil
+	}
+	return &pb.TEERequirement{
+		Types:        requirement.Types,
+		Measurements: requirement.Measurements,
+	}
+}
+
+func teeAttestationFromProto(attestation *pb.TEEAttestation) *data.TEEAttestation {
+	if attestation == nil {
+		return nil
+	}
+	return &data.TEEAttestation{
+		Type:         attestation.Type,
+		Measurement:  attestation.Measurement,
+		Evidence:     attestation.Evidence,
+		Certificates: attestation.Certificates,
+		CreatedAt:    attestation.CreatedAt,
+	}
+}
+
+func teeAttestationToProto(attestation *data.TEEAttestation) *pb.TEEAttestation {
+	if attestation == nil {
+		return nil
+	}
+	return &pb.TEEAttestation{
+		Type:         attestation.Type,
+		Measurement:  attestation.Measurement,
+		Evidence:     attestation.Evidence,
+		Certificates: attestation.Certificates,
+		CreatedAt:    attestation.CreatedAt,
+	}
+}
+
+func networkPolicyFromProto(policy *pb.NetworkPolicy) *data.NetworkPolicy {
+	if policy == nil {
+		return nil
+	}
+	return &data.NetworkPolicy{
+		Mode:  policy.Mode,
+		Allow: policy.Allow,
+	}
+}
+
+func networkPolicyToProto(policy *data.NetworkPolicy) *pb.NetworkPolicy {
+	if policy == nil {
+		return nil
+	}
+	return &pb.NetworkPolicy{
+		Mode:  policy.Mode,
+		Allow: policy.Allow,
+	}
+}
+
+func paymentTokenFromProto(token *pb.PaymentToken) *data.PaymentToken {
+	if token == nil {
+		return nil
+	}
+	return &data.PaymentToken{
+		Address:  token.Address,
+		Decimals: uint8(token.Decimals),
+	}
+}
+
+func paymentTokenToProto(token *data.PaymentToken) *pb.PaymentToken {
+	if token == nil {
+		return nil
+	}
+	return &pb.PaymentToken{
+		Address:  token.Address,
+		Decimals: uint32(token.Decimals),
+	}
+}
+
+func resourceOfferFromProto(offer *pb.ResourceOffer) data.ResourceOffer {
+	if offer == nil {
+		return data.ResourceOffer{}
+	}
+	var modulePricing map[string]data.DealPricing
+	if offer.ModulePricing != nil {
+		modulePricing = map[string]data.DealPricing{}
+		for module, pricing := range offer.ModulePricing {
+			modulePricing[module] = dealPricingFromProto(pricing)
+		}
+	}
+	var moduleTimeouts map[string]data.DealTimeouts
+	if offer.ModuleTimeouts != nil {
+		moduleTimeouts = map[string]data.DealTimeouts{}
+		for module, timeouts := range offer.ModuleTimeouts {
+			moduleTimeouts[module] = dealTimeoutsFromProto(timeouts)
+		}
+	}
+	var moduleNetwork map[string]data.NetworkPolicy
+	if offer.ModuleNetwork != nil {
+		moduleNetwork = map[string]data.NetworkPolicy{}
+		for module, policy := range offer.ModuleNetwork {
+			moduleNetwork[module] = *networkPolicyFromProto(policy)
+		}
+	}
+	return data.ResourceOffer{
+		ID:                 offer.Id,
+		CreatedAt:          int(offer.CreatedAt),
+		ResourceProvider:   offer.ResourceProvider,
+		ChainID:            int(offer.ChainId),
+		Index:              int(offer.Index),
+		Spec:               machineSpecFromProto(offer.Spec),
+		MaxInputSize:       int(offer.MaxInputSize),
+		Modules:            offer.Modules,
+		Mode:               data.PricingMode(offer.Mode),
+		DefaultPricing:     dealPricingFromProto(offer.DefaultPricing),
+		DefaultTimeouts:    dealTimeoutsFromProto(offer.DefaultTimeouts),
+		ModulePricing:      modulePricing,
+		ModuleTimeouts:     moduleTimeouts,
+		Services:           serviceConfigFromProto(offer.Services),
+		AllowedJobCreators: offer.AllowedJobCreators,
+		Labels:             offer.Labels,
+		EncryptionKey:      offer.EncryptionKey,
+		TEE:                teeAttestationFromProto(offer.Tee),
+		Network:            networkPolicyFromProto(offer.Network),
+		ModuleNetwork:      moduleNetwork,
+		PaymentTokens:      offer.PaymentTokens,
+		Class:              offer.Class,
+		PreemptionNotice:   int(offer.PreemptionNotice),
+		ReservedDuration:   int(offer.ReservedDuration),
+		IdempotencyKey:     offer.IdempotencyKey,
+		Client:             clientMetadataFromProto(offer.Client),
+	}
+}
+
+func resourceOfferToProto(offer data.ResourceOffer) *pb.ResourceOffer {
+	var modulePricing map[string]*pb.DealPricing
+	if offer.ModulePricing != nil {
+		modulePricing = map[string]*pb.DealPricing{}
+		for module, pricing := range offer.ModulePricing {
+			modulePricing[module] = dealPricingToProto(pricing)
+		}
+	}
+	var moduleTimeouts map[string]*pb.DealTimeouts
+	if offer.ModuleTimeouts != nil {
+		moduleTimeouts = map[string]*pb.DealTimeouts{}
+		for module, timeouts := range offer.ModuleTimeouts {
+			moduleTimeouts[module] = dealTimeoutsToProto(timeouts)
+		}
+	}
+	var moduleNetwork map[string]*pb.NetworkPolicy
+	if offer.ModuleNetwork != nil {
+		moduleNetwork = map[string]*pb.NetworkPolicy{}
+		for module, policy := range offer.ModuleNetwork {
+			moduleNetwork[module] = networkPolicyToProto(&policy)
+		}
+	}
+	return &pb.ResourceOffer{
+		Id:                 offer.ID,
+		CreatedAt:          int64(offer.CreatedAt),
+		ResourceProvider:   offer.ResourceProvider,
+		ChainId:            int64(offer.ChainID),
+		Index:              int64(offer.Index),
+		Spec:               machineSpecToProto(offer.Spec),
+		MaxInputSize:       int64(offer.MaxInputSize),
+		Modules:            offer.Modules,
+		Mode:               string(offer.Mode),
+		DefaultPricing:     dealPricingToProto(offer.DefaultPricing),
+		DefaultTimeouts:    dealTimeoutsToProto(offer.DefaultTimeouts),
+		ModulePricing:      modulePricing,
+		ModuleTimeouts:     moduleTimeouts,
+		Services:           serviceConfigToProto(offer.Services),
+		AllowedJobCreators: offer.AllowedJobCreators,
+		Labels:             offer.Labels,
+		EncryptionKey:      offer.EncryptionKey,
+		Tee:                teeAttestationToProto(offer.TEE),
+		Network:            networkPolicyToProto(offer.Network),
+		ModuleNetwork:      moduleNetwork,
+		PaymentTokens:      offer.PaymentTokens,
+		Class:              offer.Class,
+		PreemptionNotice:   int64(offer.PreemptionNotice),
+		ReservedDuration:   int64(offer.ReservedDuration),
+		IdempotencyKey:     offer.IdempotencyKey,
+		Client:             clientMetadataToProto(offer.Client),
+	}
+}
+
+func resourceOfferContainerToProto(container data.ResourceOfferContainer) *pb.ResourceOfferContainer {
+	return &pb.ResourceOfferContainer{
+		Id:               container.ID,
+		DealId:           container.DealID,
+		ResourceProvider: container.ResourceProvider,
+		State:            uint32(container.State),
+		ResourceOffer:    resourceOfferToProto(container.ResourceOffer),
+	}
+}
+
+func dealContainerToProto(deal data.DealContainer) *pb.DealContainer {
+	return &pb.DealContainer{
+		Id:               deal.ID,
+		JobCreator:       deal.JobCreator,
+		ResourceProvider: deal.ResourceProvider,
+		JobOffer:         deal.JobOffer,
+		ResourceOffer:    deal.ResourceOffer,
+		State:            uint32(deal.State),
+		Deal: &pb.Deal{
+			Id: deal.Deal.ID,
+			Members: &pb.DealMembers{
+				Solver:           deal.Deal.Members.Solver,
+				JobCreator:       deal.Deal.Members.JobCreator,
+				ResourceProvider: deal.Deal.Members.ResourceProvider,
+				Mediators:        deal.Deal.Members.Mediators,
+			},
+			Pricing:           dealPricingToProto(deal.Deal.Pricing),
+			Timeouts:          dealTimeoutsToProto(deal.Deal.Timeouts),
+			JobOffer:          jobOfferToProto(deal.Deal.JobOffer),
+			ResourceOffer:     resourceOfferToProto(deal.Deal.ResourceOffer),
+			Mediator:          deal.Deal.Mediator,
+			MediatorSelection: mediatorSelectionToProto(deal.Deal.MediatorSelection),
+			Network:           networkPolicyToProto(deal.Deal.Network),
+		},
+		Mediator:          deal.Mediator,
+		ChainId:           int64(deal.ChainID),
+		StateUpdatedAt:    deal.StateUpdatedAt,
+		Checkpoint:        dealCheckpointToProto(deal.Checkpoint),
+		CancelledAt:       deal.CancelledAt,
+		MediationVerdicts: mediationVerdictsToProto(deal.MediationVerdicts),
+		EncryptedInputs:   dealEncryptedInputsToProto(deal.EncryptedInputs),
+		Milestones:        dealMilestonesToProto(deal.Milestones),
+		Preemption:        dealPreemptionToProto(deal.Preemption),
+		ServiceUsage:      serviceUsageToProto(deal.ServiceUsage),
+	}
+}
+
+func mediatorSelectionToProto(selection *data.MediatorSelection) *pb.MediatorSelection {
+	if selection == nil {
+		return nil
+	}
+	var related []*pb.MediatorRelation
+	for _, relation := range selection.Related {
+		related = append(related, &pb.MediatorRelation{
+			Mediator: relation.Mediator,
+			Reason:   relation.Reason,
+			Detail:   relation.Detail,
+			Excluded: relation.Excluded,
+		})
+	}
+	return &pb.MediatorSelection{
+		Policy:     selection.Policy,
+		Candidates: selection.Candidates,
+		Weights:    selection.Weights,
+		Offset:     int64(selection.Offset),
+		Seed:       selection.Seed,
+		Mediators:  selection.Mediators,
+		Related:    related,
+	}
+}
+
+func mediationVerdictsToProto(verdicts []data.MediationVerdict) []*pb.MediationVerdict {
+	var protoVerdicts []*pb.MediationVerdict
+	for _, verdict := range verdicts {
+		protoVerdicts = append(protoVerdicts, &pb.MediationVerdict{
+			DealId:     verdict.DealID,
+			Mediator:   verdict.Mediator,
+			Accept:     verdict.Accept,
+			ResultsCid: verdict.ResultsCID,
+			CreatedAt:  verdict.CreatedAt,
+			Code:       string(verdict.Code),
+		})
+	}
+	return protoVerdicts
+}
+
+func dealMilestonesToProto(milestones []data.DealMilestone) []*pb.DealMilestone {
+	var protoMilestones []*pb.DealMilestone
+	for _, milestone := range milestones {
+		protoMilestones = append(protoMilestones, &pb.DealMilestone{
+			DealId:          milestone.DealID,
+			Index:           milestone.Index,
+			CheckpointCid:   milestone.CheckpointCID,
+			Amount:          milestone.Amount,
+			State:           milestone.State,
+			Signature:       milestone.Signature,
+			TransactionHash: milestone.TransactionHash,
+			Error:           milestone.Error,
+			CreatedAt:       milestone.CreatedAt,
+			UpdatedAt:       milestone.UpdatedAt,
+		})
+	}
+	return protoMilestones
+}
+
+func dealCheckpointToProto(checkpoint *data.DealCheckpoint) *pb.DealCheckpoint {
+	if checkpoint == nil {
+		return nil
+	}
+	return &pb.DealCheckpoint{
+		DealId:    checkpoint.DealID,
+		Cid:       checkpoint.CID,
+		CreatedAt: checkpoint.CreatedAt,
+	}
+}
+
+func dealPreemptionToProto(preemption *data.DealPreemption) *pb.DealPreemption {
+	if preemption == nil {
+		return nil
+	}
+	return &pb.DealPreemption{
+		DealId:    preemption.DealID,
+		Reason:    preemption.Reason,
+		CreatedAt: preemption.CreatedAt,
+		StopAt:    preemption.StopAt,
+	}
+}
+
+func serviceUsageToProto(usage *data.ServiceUsage) *pb.ServiceUsage {
+	if usage == nil {
+		return nil
+	}
+	return &pb.ServiceUsage{
+		Requests:  usage.Requests,
+		Failed:    usage.Failed,
+		UpdatedAt: usage.UpdatedAt,
+	}
+}
+
+func dealEncryptedInputsToProto(encrypted *data.DealEncryptedInputs) *pb.DealEncryptedInputs {
+	if encrypted == nil {
+		return nil
+	}
+	return &pb.DealEncryptedInputs{
+		DealId:        encrypted.DealID,
+		Scheme:        encrypted.Scheme,
+		EncryptionKey: encrypted.EncryptionKey,
+		Ciphertext:    encrypted.Ciphertext,
+		CreatedAt:     encrypted.CreatedAt,
+	}
+}
+
+func resultToProto(result data.Result) *pb.Result {
+	var locations []*pb.ResultLocation
+	for _, location := range result.Locations {
+		locations = append(locations, &pb.ResultLocation{
+			Backend: location.Backend,
+			Uri:     location.URI,
+		})
+	}
+	var metering *pb.ResultMetering
+	if result.Metering != nil {
+		metering = &pb.ResultMetering{
+			Method:  result.Metering.Method,
+			CpuTime: result.Metering.CPUTime,
+			GpuTime: result.Metering.GPUTime,
+		}
+	}
+	return &pb.Result{
+		Id:                result.ID,
+		DealId:            result.DealID,
+		DataId:            result.DataID,
+		Error:             result.Error,
+		InstructionCount:  result.InstructionCount,
+		VariantDigest:     result.VariantDigest,
+		ImageDigest:       result.ImageDigest,
+		ImageVerification: result.ImageVerification,
+		Runtime:           result.Runtime,
+		Preempted:         result.Preempted,
+		ErrorCode:         string(result.ErrorCode),
+		Locations:         locations,
+		Metering:          metering,
+	}
+}
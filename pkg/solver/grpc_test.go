@@ -0,0 +1,85 @@
+package solver
+
+import (
+	"context"
+	"encoding/hex"
+	"net"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/pb"
+	memorystore "github.com/lilypad-tech/lilypad/pkg/solver/store/memory"
+	"github.com/lilypad-tech/lilypad/pkg/web3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestGRPCServer(t *testing.T) {
+	s, err := memorystore.NewSolverStoreMemory(memorystore.SolverStoreMemoryOptions{LogDir: t.TempDir()})
+	require.NoError(t, err)
+	server := NewSolverGRPCServer(GRPCOptions{}, "", &SolverController{store: s}, s)
+
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	pb.RegisterSolverServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///solver",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+	client := pb.NewSolverClient(conn)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer, err := web3.NewPrivateKeySigner(hex.EncodeToString(crypto.FromECDSA(privateKey)))
+	require.NoError(t, err)
+
+	// offers must be signed by whoever made them
+	offer := &pb.SubmitJobOfferRequest{JobOffer: &pb.JobOffer{JobCreator: "0x1111111111111111111111111111111111111111"}}
+	_, err = client.SubmitJobOffer(ctx, offer)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.SubmitJobOffer(ctx, offer, NewGRPCCredentials(signer))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	deal := data.DealContainer{ID: "deal1", JobCreator: signer.Address().String(), State: data.GetAgreementStateIndex("DealNegotiating")}
+	_, err = s.AddDeal(deal)
+	require.NoError(t, err)
+	_, err = s.AddResult(data.Result{ID: "result1", DealID: "deal1", DataID: "data1"})
+	require.NoError(t, err)
+
+	results, err := client.GetResults(ctx, &pb.GetResultsRequest{DealIds: []string{"deal1", "deal2"}})
+	require.NoError(t, err)
+	require.Len(t, results.Results, 1)
+	assert.Equal(t, "data1", results.Results[0].DataId)
+
+	stream, err := client.WatchDeals(ctx, &pb.WatchDealsRequest{JobCreator: deal.JobCreator, IncludeExisting: true})
+	require.NoError(t, err)
+	ev, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, string(DealAdded), ev.EventType)
+	assert.Equal(t, "deal1", ev.Deal.Id)
+
+	// only the deals the watcher asked for are sent
+	server.publish(SolverEvent{EventType: DealAdded, Deal: &data.DealContainer{ID: "deal2", JobCreator: "0x2"}})
+	deal.State = data.GetAgreementStateIndex("DealAgreed")
+	server.publish(SolverEvent{EventType: DealStateUpdated, Deal: &deal})
+	ev, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, string(DealStateUpdated), ev.EventType)
+	assert.Equal(t, "deal1", ev.Deal.Id)
+	assert.Equal(t, uint32(deal.State), ev.Deal.State)
+}
@@ -0,0 +1,117 @@
+package solver
+
+import (
+	"encoding/json"
+	"fmt"
+	corehttp "net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// how many matching intervals can pass without a clean run
+// before we stop reporting ourselves as healthy
+const MATCHING_LOOP_STALE_INTERVALS = 3
+
+type HealthCheck struct {
+	Name  string `json:"name"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+type HealthStatus struct {
+	OK     bool          `json:"ok"`
+	Checks []HealthCheck `json:"checks"`
+}
+
+type healthCheckFunc struct {
+	name  string
+	check func() error
+}
+
+func runHealthChecks(checks []healthCheckFunc) HealthStatus {
+	status := HealthStatus{OK: true, Checks: []HealthCheck{}}
+	for _, c := range checks {
+		result := HealthCheck{Name: c.name, OK: true}
+		err := c.check()
+		if err != nil {
+			result.OK = false
+			result.Error = err.Error()
+			status.OK = false
+		}
+		status.Checks = append(status.Checks, result)
+	}
+	return status
+}
+
+func (controller *SolverController) checkStoreHealth() error {
+	// any read will do, we only care that the store answers
+	_, err := controller.store.GetDeal("")
+	return err
+}
+
+func (controller *SolverController) checkWeb3Health() error {
+	_, err := controller.web3SDK.GetBlockNumber()
+	return err
+}
+
+func (controller *SolverController) checkAllowlistHealth() error {
+	if controller.options.Allowlist.Path == "" {
+		return nil
+	}
+	if len(controller.getAllowlist()) == 0 {
+		return fmt.Errorf("no modules loaded from %s", controller.options.Allowlist.Path)
+	}
+	return nil
+}
+
+func (controller *SolverController) checkMatchingLoopHealth(now time.Time) error {
+	if controller.isReadOnly() {
+		return nil
+	}
+	lastSolve := controller.lastSolve.Load()
+	if lastSolve == 0 {
+		return fmt.Errorf("matching loop has not run yet")
+	}
+	since := now.Sub(time.Unix(lastSolve, 0))
+	if since > MATCHING_LOOP_STALE_INTERVALS*controller.getMatchingInterval() {
+		return fmt.Errorf("matching loop last ran %s ago", since.Round(time.Second))
+	}
+	return nil
+}
+
+// liveness - things that will not fix themselves without a restart
+func (controller *SolverController) getHealth() HealthStatus {
+	return runHealthChecks([]healthCheckFunc{
+		{name: "store", check: controller.checkStoreHealth},
+		{name: "matching_loop", check: func() error { return controller.checkMatchingLoopHealth(time.Now()) }},
+	})
+}
+
+// readiness - everything we need to be able to take on work
+func (controller *SolverController) getReadiness() HealthStatus {
+	return runHealthChecks([]healthCheckFunc{
+		{name: "store", check: controller.checkStoreHealth},
+		{name: "web3", check: controller.checkWeb3Health},
+		{name: "allowlist", check: controller.checkAllowlistHealth},
+		{name: "matching_loop", check: func() error { return controller.checkMatchingLoopHealth(time.Now()) }},
+	})
+}
+
+// write the status with a 503 if any of the checks failed
+// so that load balancers and orchestrators can act on it
+func healthHandler(getStatus func() HealthStatus) corehttp.HandlerFunc {
+	return func(res corehttp.ResponseWriter, req *corehttp.Request) {
+		status := getStatus()
+		res.Header().Set("Content-Type", "application/json")
+		if status.OK {
+			res.WriteHeader(corehttp.StatusOK)
+		} else {
+			res.WriteHeader(corehttp.StatusServiceUnavailable)
+		}
+		err := json.NewEncoder(res).Encode(status)
+		if err != nil {
+			log.Error().Msgf("error writing health status: %s", err.Error())
+		}
+	}
+}
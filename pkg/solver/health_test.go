@@ -0,0 +1,81 @@
+package solver
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	memorystore "github.com/lilypad-tech/lilypad/pkg/solver/store/memory"
+	"github.com/lilypad-tech/lilypad/pkg/web3/mock_web3"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReadiness(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		name          string
+		blockErr      error
+		allowlistPath string
+		allowlist     map[string]data.AllowlistItem
+		lastSolve     time.Time
+		failed        []string
+	}{
+		{name: "Ready", lastSolve: now},
+		{name: "Web3 down", blockErr: errors.New("connection refused"), lastSolve: now, failed: []string{"web3"}},
+		{name: "Allowlist not loaded", allowlistPath: "allowlist.json", lastSolve: now, failed: []string{"allowlist"}},
+		{
+			name:          "Allowlist loaded",
+			allowlistPath: "allowlist.json",
+			allowlist:     map[string]data.AllowlistItem{"cowsay:v0.0.4": {}},
+			lastSolve:     now,
+		},
+		{name: "Matching loop not run", failed: []string{"matching_loop"}},
+		{name: "Matching loop stale", lastSolve: now.Add(-MATCHING_LOOP_STALE_INTERVALS * 2 * CONTROL_LOOP_INTERVAL), failed: []string{"matching_loop"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			web3SDK := mock_web3.NewMockWeb3Client(ctrl)
+			web3SDK.EXPECT().GetBlockNumber().Return(uint64(1), tc.blockErr)
+
+			s, err := memorystore.NewSolverStoreMemory(memorystore.SolverStoreMemoryOptions{LogDir: t.TempDir()})
+			if err != nil {
+				t.Fatal(err)
+			}
+			controller := &SolverController{
+				web3SDK:   web3SDK,
+				store:     s,
+				allowlist: tc.allowlist,
+				options:   SolverOptions{Allowlist: AllowlistOptions{Path: tc.allowlistPath}},
+			}
+			if !tc.lastSolve.IsZero() {
+				controller.lastSolve.Store(tc.lastSolve.Unix())
+			}
+
+			status := controller.getReadiness()
+			failed := []string{}
+			for _, check := range status.Checks {
+				if !check.OK {
+					failed = append(failed, check.Name)
+				}
+			}
+			assert.Equal(t, len(tc.failed) == 0, status.OK)
+			if len(tc.failed) > 0 {
+				assert.Equal(t, tc.failed, failed)
+			}
+
+			res := httptest.NewRecorder()
+			healthHandler(func() HealthStatus { return status })(res, httptest.NewRequest("GET", "/readyz", nil))
+			if status.OK {
+				assert.Equal(t, 200, res.Code)
+			} else {
+				assert.Equal(t, 503, res.Code)
+			}
+		})
+	}
+}
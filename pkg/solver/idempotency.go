@@ -0,0 +1,47 @@
+package solver
+
+import (
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+)
+
+// clients retry a submission when the response is lost so an offer we
+// already have is handed back as it is, adding it again would reset
+// its deal and let the matcher use it twice
+// offers are the same if they have the same ID or the same idempotency key
+func (controller *SolverController) getExistingJobOffer(jobOffer data.JobOffer) (*data.JobOfferContainer, error) {
+	existing, err := controller.store.GetJobOffer(jobOffer.ID)
+	if err != nil || existing != nil {
+		return existing, err
+	}
+	if jobOffer.IdempotencyKey == "" {
+		return nil, nil
+	}
+	jobOffers, err := controller.store.GetJobOffers(store.GetJobOffersQuery{
+		JobCreator:       jobOffer.JobCreator,
+		IdempotencyKey:   jobOffer.IdempotencyKey,
+		IncludeCancelled: true,
+	})
+	if err != nil || len(jobOffers) == 0 {
+		return nil, err
+	}
+	return &jobOffers[0], nil
+}
+
+func (controller *SolverController) getExistingResourceOffer(resourceOffer data.ResourceOffer) (*data.ResourceOfferContainer, error) {
+	existing, err := controller.store.GetResourceOffer(resourceOffer.ID)
+	if err != nil || existing != nil {
+		return existing, err
+	}
+	if resourceOffer.IdempotencyKey == "" {
+		return nil, nil
+	}
+	resourceOffers, err := controller.store.GetResourceOffers(store.GetResourceOffersQuery{
+		ResourceProvider: resourceOffer.ResourceProvider,
+		IdempotencyKey:   resourceOffer.IdempotencyKey,
+	})
+	if err != nil || len(resourceOffers) == 0 {
+		return nil, err
+	}
+	return &resourceOffers[0], nil
+}
@@ -0,0 +1,115 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+// checks on the input CIDs a job offer names before it can be matched
+// so a resource provider is not left waiting on data nobody can fetch
+type InputCheckOptions struct {
+	// look up each input CID on our IPFS node
+	Enabled bool
+	// how long to wait for each CID to be found (seconds)
+	Timeout int
+	// the largest total input a job offer can name (Megabytes)
+	// zero means no limit
+	MaxSize int
+}
+
+// the inputs must be found and fit within both the solver limit
+// and the size the job offer declared, which is what resource providers go on
+func (controller *SolverController) checkJobOfferInputs(ctx context.Context, jobOffer data.JobOffer) error {
+	size, err := controller.statInputs(ctx, jobOffer.InputCIDs)
+	if err != nil {
+		return err
+	}
+	megabytes := int((size + 1024*1024 - 1) / (1024 * 1024))
+	if jobOffer.InputSize > 0 && megabytes > jobOffer.InputSize {
+		return fmt.Errorf("job offer inputs are %dMB which is more than the %dMB it declares", megabytes, jobOffer.InputSize)
+	}
+	return nil
+}
+
+// the total size of the CIDs in bytes
+// nothing is looked up when input checks are turned off
+func (controller *SolverController) statInputs(ctx context.Context, cids []string) (uint64, error) {
+	options := controller.options.InputCheck
+	if !options.Enabled || controller.ipfs == nil || len(cids) == 0 {
+		return 0, nil
+	}
+	total := uint64(0)
+	for _, id := range cids {
+		statCtx, cancel := context.WithTimeout(ctx, time.Duration(options.Timeout)*time.Second)
+		size, err := controller.ipfs.Stat(statCtx, id)
+		cancel()
+		if err != nil {
+			return 0, fmt.Errorf("input %s could not be found: %s", id, err.Error())
+		}
+		total += size
+	}
+	if options.MaxSize > 0 && total > uint64(options.MaxSize)*1024*1024 {
+		return 0, fmt.Errorf("inputs are %d bytes which is more than the solver limit of %dMB", total, options.MaxSize)
+	}
+	return total, nil
+}
+
+func (controller *SolverController) addInputStaging(ctx context.Context, request data.InputStagingRequest) (*data.InputStaging, error) {
+	staging, err := data.NewInputStaging(request, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	existing, err := controller.store.GetInputStaging(staging.ID)
+	if err != nil {
+		return nil, err
+	}
+	// asking again after a failure has the resource provider try again
+	if existing != nil && existing.State != data.InputStagingFailed {
+		return existing, nil
+	}
+	_, err = controller.statInputs(ctx, request.CIDs)
+	if err != nil {
+		return nil, err
+	}
+	controller.log.Info("add input staging", staging)
+	return controller.store.AddInputStaging(staging)
+}
+
+func (controller *SolverController) updateInputStaging(staging data.InputStaging, update data.InputStagingUpdate) (*data.InputStaging, error) {
+	staging.State = update.State
+	staging.Error = update.Error
+	staging.Size = update.Size
+	staging.UpdatedAt = time.Now().Unix()
+	controller.log.Info("update input staging", staging)
+	return controller.store.AddInputStaging(staging)
+}
+
+// the job creator sends the encrypted inputs once the deal is matched
+// and the resource provider waits for them before it agrees
+func (controller *SolverController) addDealEncryptedInputs(deal data.DealContainer, encrypted data.DealEncryptedInputs) (*data.DealContainer, error) {
+	if deal.State != data.GetAgreementStateIndex("DealNegotiating") {
+		return nil, fmt.Errorf("deal %s is %s so it is too late to send its inputs", deal.ID, data.GetAgreementStateString(deal.State))
+	}
+	if deal.EncryptedInputs != nil {
+		return nil, fmt.Errorf("deal %s already has encrypted inputs", deal.ID)
+	}
+	err := data.CheckDealEncryptedInputs(deal, encrypted)
+	if err != nil {
+		return nil, err
+	}
+	encrypted.DealID = deal.ID
+	encrypted.CreatedAt = time.Now().Unix()
+	controller.log.Info("add encrypted inputs", deal.ID)
+	dealContainer, err := controller.store.AddDealEncryptedInputs(deal.ID, encrypted)
+	if err != nil {
+		return nil, err
+	}
+	controller.writeEvent(SolverEvent{
+		EventType: DealEncryptedInputsAdded,
+		Deal:      dealContainer,
+	})
+	return dealContainer, nil
+}
@@ -0,0 +1,66 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+// add a job offer for each value of an array job and keep them together as a group
+// if one of them is turned down the ones already added are cancelled
+// so the job creator is not left with half a sweep running
+func (controller *SolverController) addJobGroup(ctx context.Context, submission data.JobGroupSubmission) (*data.JobGroup, error) {
+	id, err := data.GetJobGroupID(submission)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := controller.store.GetJobGroup(id)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	group := data.JobGroup{
+		ID:         id,
+		JobCreator: submission.JobOffer.JobCreator,
+		Parameter:  submission.Parameter,
+		Values:     submission.Values,
+		CreatedAt:  int(time.Now().UnixMilli()),
+	}
+	added := []data.JobOfferContainer{}
+	for i, jobOffer := range data.ExpandJobGroup(submission, id) {
+		jobOfferContainer, err := controller.addJobOffer(ctx, jobOffer)
+		if err != nil {
+			for _, addedJobOffer := range added {
+				_, cancelErr := controller.cancelJobOffer(addedJobOffer)
+				if cancelErr != nil {
+					controller.log.Error("error cancelling job offer of a failed group", cancelErr)
+				}
+			}
+			return nil, fmt.Errorf("job offer for %s=%s: %s", submission.Parameter, submission.Values[i], err.Error())
+		}
+		added = append(added, *jobOfferContainer)
+		group.JobOffers = append(group.JobOffers, jobOfferContainer.ID)
+	}
+
+	controller.log.Info("add job group", group)
+	return controller.store.AddJobGroup(group)
+}
+
+func (controller *SolverController) getJobGroupStatus(group data.JobGroup) (data.JobGroupStatus, error) {
+	jobOffers := []data.JobOfferContainer{}
+	for _, id := range group.JobOffers {
+		jobOffer, err := controller.store.GetJobOffer(id)
+		if err != nil {
+			return data.JobGroupStatus{}, err
+		}
+		if jobOffer != nil {
+			jobOffers = append(jobOffers, *jobOffer)
+		}
+	}
+	return data.GetJobGroupStatus(group, jobOffers), nil
+}
@@ -0,0 +1,178 @@
+package solver
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+type LogRelayOptions struct {
+	// how many bytes of output to keep for each deal
+	// the oldest chunks are dropped once a deal goes over
+	BufferSize int
+	// how many deals to keep output for, the deal we
+	// heard about first is dropped once we go over
+	MaxDeals int
+}
+
+// the recent output of one deal
+type dealLogs struct {
+	chunks []data.DealLogChunk
+	size   int
+	// the sequence of the last chunk we were sent
+	sequence uint64
+	// the deal is over and no more output will come
+	finished bool
+	// closed and replaced each time something changes
+	// so followers can wait on it rather than polling
+	changed chan struct{}
+}
+
+func (logs *dealLogs) notify() {
+	close(logs.changed)
+	logs.changed = make(chan struct{})
+}
+
+// resource providers push the output of running jobs here and
+// job creators follow it - we only keep it in memory because it
+// is no use once the results are in
+type logRelay struct {
+	options LogRelayOptions
+	mutex   sync.Mutex
+	deals   map[string]*dealLogs
+	// deal IDs in the order we first saw them, oldest first
+	order []string
+	// closed and replaced when a deal gets its first output or a deal
+	// with none finishes so followers of deals we have nothing for
+	// yet know to look again
+	waiting chan struct{}
+}
+
+func newLogRelay(options LogRelayOptions) *logRelay {
+	return &logRelay{
+		options: options,
+		deals:   map[string]*dealLogs{},
+		order:   []string{},
+		waiting: make(chan struct{}),
+	}
+}
+
+// must be called with the lock held
+func (relay *logRelay) notifyWaiting() {
+	close(relay.waiting)
+	relay.waiting = make(chan struct{})
+}
+
+// only the resource provider adding output makes room for a deal
+// must be called with the lock held
+func (relay *logRelay) getOrAddDealLogs(dealID string) *dealLogs {
+	logs, ok := relay.deals[dealID]
+	if ok {
+		return logs
+	}
+	logs = &dealLogs{
+		chunks:  []data.DealLogChunk{},
+		changed: make(chan struct{}),
+	}
+	relay.deals[dealID] = logs
+	relay.order = append(relay.order, dealID)
+	for len(relay.order) > relay.options.MaxDeals && len(relay.order) > 0 {
+		evicted := relay.deals[relay.order[0]]
+		// wake anyone following the deal so they can stop
+		evicted.finished = true
+		evicted.notify()
+		delete(relay.deals, relay.order[0])
+		relay.order = relay.order[1:]
+	}
+	relay.notifyWaiting()
+	return logs
+}
+
+// number the chunks, add them to the deal and drop the oldest
+// chunks if that takes the deal over the buffer size
+func (relay *logRelay) add(dealID string, chunks []data.DealLogChunk, now time.Time) ([]data.DealLogChunk, error) {
+	relay.mutex.Lock()
+	defer relay.mutex.Unlock()
+
+	logs := relay.getOrAddDealLogs(dealID)
+	if logs.finished {
+		return nil, fmt.Errorf("deal %s has finished, no more logs can be added", dealID)
+	}
+
+	added := []data.DealLogChunk{}
+	for _, chunk := range chunks {
+		logs.sequence++
+		chunk.DealID = dealID
+		chunk.Sequence = logs.sequence
+		chunk.CreatedAt = now.UnixMilli()
+		logs.chunks = append(logs.chunks, chunk)
+		logs.size += len(chunk.Data)
+		added = append(added, chunk)
+	}
+	for logs.size > relay.options.BufferSize && len(logs.chunks) > 0 {
+		logs.size -= len(logs.chunks[0].Data)
+		logs.chunks = logs.chunks[1:]
+	}
+	logs.notify()
+	return added, nil
+}
+
+// the chunks after the given sequence, whether the deal has finished
+// and a channel that is closed the next time either of those change
+// reading a deal we have no output for does not make room for it
+func (relay *logRelay) get(dealID string, after uint64) ([]data.DealLogChunk, bool, <-chan struct{}) {
+	relay.mutex.Lock()
+	defer relay.mutex.Unlock()
+
+	chunks := []data.DealLogChunk{}
+	logs, ok := relay.deals[dealID]
+	if !ok {
+		return chunks, false, relay.waiting
+	}
+	for _, chunk := range logs.chunks {
+		if chunk.Sequence > after {
+			chunks = append(chunks, chunk)
+		}
+	}
+	return chunks, logs.finished, logs.changed
+}
+
+// the deal is over so tell anyone following it
+// we keep the output so it can still be read
+func (relay *logRelay) finish(dealID string) {
+	relay.mutex.Lock()
+	defer relay.mutex.Unlock()
+
+	logs, ok := relay.deals[dealID]
+	if !ok {
+		relay.notifyWaiting()
+		return
+	}
+	if logs.finished {
+		return
+	}
+	logs.finished = true
+	logs.notify()
+}
+
+func (controller *SolverController) addDealLogs(deal data.DealContainer, chunks []data.DealLogChunk) ([]data.DealLogChunk, error) {
+	if data.DealState(deal.State).IsTerminal() {
+		return nil, fmt.Errorf("deal %s has finished, no more logs can be added", deal.ID)
+	}
+	for _, chunk := range chunks {
+		err := data.CheckDealLogChunk(chunk)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return controller.logs.add(deal.ID, chunks, time.Now())
+}
+
+// the output we have for a deal along with whether the deal is over
+// a deal that finished before we saw any output is finished straight away
+func (controller *SolverController) getDealLogs(deal data.DealContainer, after uint64) ([]data.DealLogChunk, bool, <-chan struct{}) {
+	chunks, finished, changed := controller.logs.get(deal.ID, after)
+	return chunks, finished || data.DealState(deal.State).IsTerminal(), changed
+}
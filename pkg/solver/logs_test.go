@@ -0,0 +1,78 @@
+package solver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogRelay(t *testing.T) {
+	relay := newLogRelay(LogRelayOptions{BufferSize: 10, MaxDeals: 2})
+	now := time.Now()
+
+	added, err := relay.add("deal1", []data.DealLogChunk{
+		{Stream: data.DealLogStdout, Data: "hello"},
+		{Stream: data.DealLogStderr, Data: "world"},
+	}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), added[0].Sequence)
+	assert.Equal(t, uint64(2), added[1].Sequence)
+	assert.Equal(t, "deal1", added[1].DealID)
+
+	chunks, finished, changed := relay.get("deal1", 1)
+	assert.False(t, finished)
+	assert.Equal(t, []string{"world"}, getChunkData(chunks))
+
+	// going over the buffer drops the oldest chunk
+	_, err = relay.add("deal1", []data.DealLogChunk{{Stream: data.DealLogStdout, Data: "again"}}, now)
+	assert.NoError(t, err)
+	assertClosed(t, changed)
+	chunks, _, changed = relay.get("deal1", 0)
+	assert.Equal(t, []string{"world", "again"}, getChunkData(chunks))
+	assert.Equal(t, uint64(3), chunks[1].Sequence)
+
+	relay.finish("deal1")
+	assertClosed(t, changed)
+	chunks, finished, _ = relay.get("deal1", 0)
+	assert.True(t, finished)
+	assert.Len(t, chunks, 2)
+	_, err = relay.add("deal1", []data.DealLogChunk{{Stream: data.DealLogStdout, Data: "late"}}, now)
+	assert.Error(t, err)
+
+	// reading a deal with no output does not make room for it
+	chunks, finished, waiting := relay.get("deal2", 0)
+	assert.False(t, finished)
+	assert.Len(t, chunks, 0)
+	relay.get("deal3", 0)
+	assert.NotContains(t, relay.deals, "deal2")
+	assert.NotContains(t, relay.deals, "deal3")
+
+	// a third deal with output pushes out the first
+	_, err = relay.add("deal2", []data.DealLogChunk{{Stream: data.DealLogStdout, Data: "two"}}, now)
+	assert.NoError(t, err)
+	assertClosed(t, waiting)
+	_, err = relay.add("deal3", []data.DealLogChunk{{Stream: data.DealLogStdout, Data: "three"}}, now)
+	assert.NoError(t, err)
+	chunks, finished, _ = relay.get("deal1", 0)
+	assert.False(t, finished)
+	assert.Len(t, chunks, 0)
+	assert.NotContains(t, relay.deals, "deal1")
+}
+
+func getChunkData(chunks []data.DealLogChunk) []string {
+	ret := []string{}
+	for _, chunk := range chunks {
+		ret = append(ret, chunk.Data)
+	}
+	return ret
+}
+
+func assertClosed(t *testing.T, changed <-chan struct{}) {
+	select {
+	case <-changed:
+	default:
+		t.Fatal("expected the changed channel to be closed")
+	}
+}
@@ -0,0 +1,184 @@
+package matcher
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// the shape of the synthetic offer pool a benchmark matches
+type BenchOptions struct {
+	JobOffers      int
+	ResourceOffers int
+	// the offers are spread over this many modules and each resource
+	// offer supports a random half of them
+	Modules int
+	// the same seed makes the same pool, the IDs of the offers have
+	// the seed in them so pools with different seeds can share a store
+	Seed int64
+}
+
+type BenchResult struct {
+	JobOffers      int           `json:"job_offers"`
+	ResourceOffers int           `json:"resource_offers"`
+	Deals          int           `json:"deals"`
+	Duration       time.Duration `json:"duration"`
+	// job and resource offer pairs looked at each second
+	PairsPerSecond float64 `json:"pairs_per_second"`
+	// the records the matching pass added to each table of the store
+	Writes map[string]int `json:"writes"`
+	// the records written for each deal found
+	WritesPerDeal float64 `json:"writes_per_deal"`
+}
+
+var benchServices = data.ServiceConfig{
+	Solver:   "0xbench-solver",
+	Mediator: []string{"0xbench-mediator"},
+}
+
+func getBenchModule(index int) data.ModuleConfig {
+	return data.ModuleConfig{
+		Name: fmt.Sprintf("bench-%d", index),
+		Repo: "https://github.com/lilypad-tech/lilypad-module-bench",
+		Hash: fmt.Sprintf("v0.0.%d", index),
+		Path: "/lilypad_module.json.tmpl",
+	}
+}
+
+// job offers ask for a mix of specs and prices so some pairs match,
+// some fail on spec or module and some only fail on price
+func GenerateOfferPool(options BenchOptions) ([]data.JobOfferContainer, []data.ResourceOfferContainer, error) {
+	if options.JobOffers <= 0 || options.ResourceOffers <= 0 {
+		return nil, nil, fmt.Errorf("there must be at least one job offer and one resource offer")
+	}
+	modules := max(options.Modules, 1)
+	moduleIDs := make([]string, modules)
+	for i := range moduleIDs {
+		moduleID, err := data.GetModuleID(getBenchModule(i))
+		if err != nil {
+			return nil, nil, err
+		}
+		moduleIDs[i] = moduleID
+	}
+	random := rand.New(rand.NewSource(options.Seed))
+	cpus := []int{1000, 2000, 4000, 8000}
+	gpus := []int{0, 0, 1000, 2000}
+	rams := []int{1024, 4096, 8192, 16384}
+
+	resourceOffers := make([]data.ResourceOfferContainer, options.ResourceOffers)
+	for i := range resourceOffers {
+		supported := []string{}
+		for _, moduleID := range moduleIDs {
+			if random.Intn(2) == 0 {
+				supported = append(supported, moduleID)
+			}
+		}
+		if len(supported) == 0 {
+			supported = append(supported, moduleIDs[random.Intn(modules)])
+		}
+		resourceOffers[i] = data.GetResourceOfferContainer(data.ResourceOffer{
+			ID:               fmt.Sprintf("bench-%d-resource-offer-%d", options.Seed, i),
+			ResourceProvider: fmt.Sprintf("0xbench-rp-%d", i),
+			Spec: data.MachineSpec{
+				CPU: cpus[random.Intn(len(cpus))],
+				GPU: gpus[random.Intn(len(gpus))],
+				RAM: rams[random.Intn(len(rams))],
+			},
+			Modules:        supported,
+			Mode:           data.FixedPrice,
+			DefaultPricing: data.DealPricing{InstructionPrice: uint64(1 + random.Intn(20))},
+			Services:       benchServices,
+		})
+	}
+
+	jobOffers := make([]data.JobOfferContainer, options.JobOffers)
+	for i := range jobOffers {
+		jobOffers[i] = data.GetJobOfferContainer(data.JobOffer{
+			ID:         fmt.Sprintf("bench-%d-job-offer-%d", options.Seed, i),
+			JobCreator: fmt.Sprintf("0xbench-jc-%d", i),
+			Module:     getBenchModule(random.Intn(modules)),
+			Spec: data.MachineSpec{
+				CPU: cpus[random.Intn(len(cpus))],
+				GPU: gpus[random.Intn(len(gpus))],
+				RAM: rams[random.Intn(len(rams))],
+			},
+			Mode:     data.FixedPrice,
+			Pricing:  data.DealPricing{InstructionPrice: uint64(5 + random.Intn(20))},
+			Services: benchServices,
+		})
+	}
+	return jobOffers, resourceOffers, nil
+}
+
+// add a synthetic pool to the store and time one matching pass over it
+// the store should be empty so the writes are only the ones the pass made
+func RunBench(ctx context.Context, db store.SolverStore, options BenchOptions) (BenchResult, error) {
+	jobOffers, resourceOffers, err := GenerateOfferPool(options)
+	if err != nil {
+		return BenchResult{}, err
+	}
+	for _, jobOffer := range jobOffers {
+		_, err = db.AddJobOffer(jobOffer)
+		if err != nil {
+			return BenchResult{}, err
+		}
+	}
+	for _, resourceOffer := range resourceOffers {
+		_, err = db.AddResourceOffer(resourceOffer)
+		if err != nil {
+			return BenchResult{}, err
+		}
+	}
+	before, err := db.GetStats()
+	if err != nil {
+		return BenchResult{}, err
+	}
+
+	start := time.Now()
+	deals, err := GetMatchingDeals(
+		ctx,
+		db,
+		nil,
+		db.UpdateJobOfferState,
+		func(gap data.PriceGap) error {
+			_, err := db.AddPriceGap(gap)
+			return err
+		},
+		nil,
+		nil,
+		noop.NewTracerProvider().Tracer("bench"),
+	)
+	if err != nil {
+		return BenchResult{}, err
+	}
+	duration := time.Since(start)
+
+	after, err := db.GetStats()
+	if err != nil {
+		return BenchResult{}, err
+	}
+	result := BenchResult{
+		JobOffers:      len(jobOffers),
+		ResourceOffers: len(resourceOffers),
+		Deals:          len(deals),
+		Duration:       duration,
+		PairsPerSecond: float64(len(jobOffers)*len(resourceOffers)) / duration.Seconds(),
+		Writes:         map[string]int{},
+	}
+	total := 0
+	for table, count := range after.Counts {
+		if written := count - before.Counts[table]; written > 0 {
+			result.Writes[table] = written
+			total += written
+		}
+	}
+	if len(deals) > 0 {
+		result.WritesPerDeal = float64(total) / float64(len(deals))
+	}
+	return result, nil
+}
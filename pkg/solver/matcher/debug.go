@@ -0,0 +1,52 @@
+package matcher
+
+import "github.com/lilypad-tech/lilypad/pkg/data"
+
+// the outcome of a single check between a job offer and a resource offer
+type MatchCheck struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message"`
+}
+
+// run every check between a job offer and a resource offer
+// unlike matchOffers this does not stop at the first failure
+// so operators can see everything that would stop a match
+func DebugMatch(
+	resourceOffer data.ResourceOffer,
+	jobOffer data.JobOffer,
+	allowlist map[string]data.AllowlistItem,
+) []MatchCheck {
+	checks := []MatchCheck{}
+
+	minimumSpecResult := matchModuleMinimumSpec(jobOffer, allowlist)
+	checks = append(checks, MatchCheck{
+		Name:    "allowlist",
+		Passed:  minimumSpecResult.matched(),
+		Message: minimumSpecResult.message(),
+	})
+
+	for _, offerCheck := range offerChecks {
+		check := MatchCheck{
+			Name:   offerCheck.name,
+			Passed: true,
+		}
+		if result := offerCheck.check(resourceOffer, jobOffer); result != nil {
+			check.Passed = false
+			check.Message = result.message()
+		}
+		checks = append(checks, check)
+	}
+
+	return checks
+}
+
+// true if every check passed
+func AllChecksPassed(checks []MatchCheck) bool {
+	for _, check := range checks {
+		if !check.Passed {
+			return false
+		}
+	}
+	return true
+}
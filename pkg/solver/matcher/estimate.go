@@ -0,0 +1,61 @@
+package matcher
+
+import (
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+)
+
+// the checks that depend on who is asking or what they would pay
+// an estimate is made before there is a job creator or a price so these are skipped
+var estimateSkippedChecks = map[string]bool{
+	"chain":        true,
+	"client":       true,
+	"price":        true,
+	"mediators":    true,
+	"solver":       true,
+	"providers":    true,
+	"job creators": true,
+}
+
+// could the resource offer run the job offer at some price
+func couldRun(resourceOffer data.ResourceOffer, jobOffer data.JobOffer) bool {
+	for _, offerCheck := range offerChecks {
+		if estimateSkippedChecks[offerCheck.name] {
+			continue
+		}
+		if offerCheck.check(resourceOffer, jobOffer) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// price the job offer from the unmatched resource offers that could run it
+// only the module, spec and chain of the job offer are looked at, a zero chain means any
+func GetCostEstimate(db store.SolverStore, jobOffer data.JobOffer, instructions uint64) (data.CostEstimate, error) {
+	moduleID, err := data.GetModuleID(jobOffer.Module)
+	if err != nil {
+		return data.CostEstimate{}, err
+	}
+	resourceOffers, err := db.GetResourceOffers(store.GetResourceOffersQuery{
+		NotMatched: true,
+		ChainID:    jobOffer.ChainID,
+	})
+	if err != nil {
+		return data.CostEstimate{}, err
+	}
+	resourceOffers, _, err = removeBannedOffers(db, resourceOffers, nil, time.Now().Unix())
+	if err != nil {
+		return data.CostEstimate{}, err
+	}
+	prices := []uint64{}
+	for _, resourceOffer := range resourceOffers {
+		if !couldRun(resourceOffer.ResourceOffer, jobOffer) {
+			continue
+		}
+		prices = append(prices, data.GetResourceOfferPricing(resourceOffer.ResourceOffer, moduleID).InstructionPrice)
+	}
+	return data.GetCostEstimate(moduleID, instructions, prices), nil
+}
@@ -81,6 +81,23 @@ func (result ramMismatch) attributes() []attribute.KeyValue {
 	}
 }
 
+type inputSizeMismatch struct {
+	resourceOffer data.ResourceOffer
+	jobOffer      data.JobOffer
+}
+
+func (_ inputSizeMismatch) matched() bool   { return false }
+func (_ inputSizeMismatch) message() string { return "job input size exceeds resource offer limit" }
+func (result inputSizeMismatch) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("match_result", fmt.Sprintf("%T", result)),
+		attribute.Bool("match_result.matched", result.matched()),
+		attribute.String("match_result.message", result.message()),
+		attribute.Int("match_result.job_offer.input_size", result.jobOffer.InputSize),
+		attribute.Int("match_result.resource_offer.max_input_size", result.resourceOffer.MaxInputSize),
+	}
+}
+
 type moduleIDError struct {
 	resourceOffer data.ResourceOffer
 	jobOffer      data.JobOffer
@@ -184,6 +201,43 @@ func (result mediatorMismatch) attributes() []attribute.KeyValue {
 	}
 }
 
+type mediatorQuorumMismatch struct {
+	resourceOffer data.ResourceOffer
+	jobOffer      data.JobOffer
+	mutual        int
+}
+
+func (_ mediatorQuorumMismatch) matched() bool { return false }
+func (result mediatorQuorumMismatch) message() string {
+	return fmt.Sprintf("%d mutual mediators is not enough for a quorum of %d", result.mutual, result.jobOffer.MediatorQuorum.Size)
+}
+func (result mediatorQuorumMismatch) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("match_result", fmt.Sprintf("%T", result)),
+		attribute.Bool("match_result.matched", result.matched()),
+		attribute.String("match_result.message", result.message()),
+		attribute.Int("match_result.mutual_mediators", result.mutual),
+		attribute.Int("match_result.job_offer.mediator_quorum.size", result.jobOffer.MediatorQuorum.Size),
+	}
+}
+
+type chainMismatch struct {
+	resourceOffer data.ResourceOffer
+	jobOffer      data.JobOffer
+}
+
+func (_ chainMismatch) matched() bool   { return false }
+func (_ chainMismatch) message() string { return "offers are for different chains" }
+func (result chainMismatch) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("match_result", fmt.Sprintf("%T", result)),
+		attribute.Bool("match_result.matched", result.matched()),
+		attribute.String("match_result.message", result.message()),
+		attribute.Int("match_result.job_offer.chain_id", result.jobOffer.ChainID),
+		attribute.Int("match_result.resource_offer.chain_id", result.resourceOffer.ChainID),
+	}
+}
+
 type solverMismatch struct {
 	resourceOffer data.ResourceOffer
 	jobOffer      data.JobOffer
@@ -201,32 +255,441 @@ func (result solverMismatch) attributes() []attribute.KeyValue {
 	}
 }
 
-// the most basic of matchers
-// basically just check if the resource offer >= job offer cpu, gpu & ram
-// if the job offer is zero then it will match any resource offer
-func matchOffers(
-	resourceOffer data.ResourceOffer,
+type moduleMinimumSpecMismatch struct {
+	jobOffer    data.JobOffer
+	moduleID    string
+	minimumSpec data.MachineSpec
+}
+
+func (_ moduleMinimumSpecMismatch) matched() bool { return false }
+func (_ moduleMinimumSpecMismatch) message() string {
+	return "job offer spec is below the module minimum spec"
+}
+func (result moduleMinimumSpecMismatch) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("match_result", fmt.Sprintf("%T", result)),
+		attribute.Bool("match_result.matched", result.matched()),
+		attribute.String("match_result.message", result.message()),
+		attribute.String("match_result.module_id", result.moduleID),
+		attribute.Int("match_result.job_offer.spec.cpu", result.jobOffer.Spec.CPU),
+		attribute.Int("match_result.job_offer.spec.gpu", result.jobOffer.Spec.GPU),
+		attribute.Int("match_result.job_offer.spec.ram", result.jobOffer.Spec.RAM),
+		attribute.Int("match_result.module.minimum_spec.cpu", result.minimumSpec.CPU),
+		attribute.Int("match_result.module.minimum_spec.gpu", result.minimumSpec.GPU),
+		attribute.Int("match_result.module.minimum_spec.ram", result.minimumSpec.RAM),
+	}
+}
+
+type moduleMinimumSpecMatched struct {
+	jobOffer data.JobOffer
+}
+
+func (_ moduleMinimumSpecMatched) matched() bool   { return true }
+func (_ moduleMinimumSpecMatched) message() string { return "job offer meets the module minimum spec" }
+func (result moduleMinimumSpecMatched) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("match_result", fmt.Sprintf("%T", result)),
+		attribute.Bool("match_result.matched", result.matched()),
+		attribute.String("match_result.message", result.message()),
+	}
+}
+
+type moduleDisabled struct {
+	jobOffer data.JobOffer
+	moduleID string
+}
+
+func (_ moduleDisabled) matched() bool   { return false }
+func (_ moduleDisabled) message() string { return "module is disabled in the allowlist" }
+func (result moduleDisabled) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("match_result", fmt.Sprintf("%T", result)),
+		attribute.Bool("match_result.matched", result.matched()),
+		attribute.String("match_result.message", result.message()),
+		attribute.String("match_result.module_id", result.moduleID),
+	}
+}
+
+type moduleImageDigestMismatch struct {
+	jobOffer    data.JobOffer
+	moduleID    string
+	imageDigest string
+}
+
+func (_ moduleImageDigestMismatch) matched() bool { return false }
+func (_ moduleImageDigestMismatch) message() string {
+	return "job offer asks for a different module image to the allowlist"
+}
+func (result moduleImageDigestMismatch) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("match_result", fmt.Sprintf("%T", result)),
+		attribute.Bool("match_result.matched", result.matched()),
+		attribute.String("match_result.message", result.message()),
+		attribute.String("match_result.module_id", result.moduleID),
+		attribute.String("match_result.job_offer.module.image_digest", result.jobOffer.Module.ImageDigest),
+		attribute.String("match_result.module.image_digest", result.imageDigest),
+	}
+}
+
+// can jobs for this module be matched at all
+// modules that are not listed are allowed, the allowlist
+// only restricts the modules it has entries for
+func IsModuleAllowed(
+	module data.ModuleConfig,
+	allowlist map[string]data.AllowlistItem,
+) (bool, string, error) {
+	if len(allowlist) == 0 {
+		return true, "no allowlist is configured", nil
+	}
+	moduleID, err := data.GetModuleID(module)
+	if err != nil {
+		return false, "", err
+	}
+	item, ok := allowlist[moduleID]
+	if !ok {
+		return true, fmt.Sprintf("module %s is not listed so it is not restricted", moduleID), nil
+	}
+	if item.Disabled {
+		return false, fmt.Sprintf("module %s is disabled", moduleID), nil
+	}
+	return true, fmt.Sprintf("module %s is enabled", moduleID), nil
+}
+
+// check the job offer asks for at least the minimum spec the allowlist
+// declares for its module, that the module is not disabled and that
+// it does not pin an image other than the one the allowlist has
+// modules not in the allowlist are not checked
+func matchModuleMinimumSpec(
 	jobOffer data.JobOffer,
+	allowlist map[string]data.AllowlistItem,
 ) matchResult {
+	if len(allowlist) == 0 {
+		return &moduleMinimumSpecMatched{jobOffer: jobOffer}
+	}
+	moduleID, err := data.GetModuleID(jobOffer.Module)
+	if err != nil {
+		return &moduleIDError{
+			jobOffer: jobOffer,
+			err:      err,
+		}
+	}
+	item, ok := allowlist[moduleID]
+	if !ok {
+		return &moduleMinimumSpecMatched{jobOffer: jobOffer}
+	}
+	if item.Disabled {
+		return &moduleDisabled{
+			jobOffer: jobOffer,
+			moduleID: moduleID,
+		}
+	}
+	if item.ImageDigest != "" && jobOffer.Module.ImageDigest != "" && jobOffer.Module.ImageDigest != item.ImageDigest {
+		return &moduleImageDigestMismatch{
+			jobOffer:    jobOffer,
+			moduleID:    moduleID,
+			imageDigest: item.ImageDigest,
+		}
+	}
+	if jobOffer.Spec.CPU < item.MinimumSpec.CPU ||
+		jobOffer.Spec.GPU < item.MinimumSpec.GPU ||
+		jobOffer.Spec.RAM < item.MinimumSpec.RAM {
+		return &moduleMinimumSpecMismatch{
+			jobOffer:    jobOffer,
+			moduleID:    moduleID,
+			minimumSpec: item.MinimumSpec,
+		}
+	}
+	return &moduleMinimumSpecMatched{jobOffer: jobOffer}
+}
+
+// a single check between a resource offer and a job offer
+type providerNotTrusted struct {
+	resourceOffer data.ResourceOffer
+	jobOffer      data.JobOffer
+}
+
+func (_ providerNotTrusted) matched() bool { return false }
+func (_ providerNotTrusted) message() string {
+	return "resource provider is not in the job offer trusted providers"
+}
+func (result providerNotTrusted) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("match_result", fmt.Sprintf("%T", result)),
+		attribute.Bool("match_result.matched", result.matched()),
+		attribute.String("match_result.message", result.message()),
+		attribute.String("match_result.resource_offer.resource_provider", result.resourceOffer.ResourceProvider),
+		attribute.StringSlice("match_result.job_offer.trusted_providers", result.jobOffer.TrustedProviders),
+	}
+}
+
+type providerExcluded struct {
+	resourceOffer data.ResourceOffer
+	jobOffer      data.JobOffer
+}
+
+func (_ providerExcluded) matched() bool { return false }
+func (_ providerExcluded) message() string {
+	return "resource provider is in the job offer excluded providers"
+}
+func (result providerExcluded) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("match_result", fmt.Sprintf("%T", result)),
+		attribute.Bool("match_result.matched", result.matched()),
+		attribute.String("match_result.message", result.message()),
+		attribute.String("match_result.resource_offer.resource_provider", result.resourceOffer.ResourceProvider),
+		attribute.StringSlice("match_result.job_offer.excluded_providers", result.jobOffer.ExcludedProviders),
+	}
+}
+
+type jobCreatorNotAllowed struct {
+	resourceOffer data.ResourceOffer
+	jobOffer      data.JobOffer
+}
+
+func (_ jobCreatorNotAllowed) matched() bool { return false }
+func (_ jobCreatorNotAllowed) message() string {
+	return "job creator is not in the resource offer allowed job creators"
+}
+func (result jobCreatorNotAllowed) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("match_result", fmt.Sprintf("%T", result)),
+		attribute.Bool("match_result.matched", result.matched()),
+		attribute.String("match_result.message", result.message()),
+		attribute.String("match_result.job_offer.job_creator", result.jobOffer.JobCreator),
+		attribute.StringSlice("match_result.resource_offer.allowed_job_creators", result.resourceOffer.AllowedJobCreators),
+	}
+}
+
+type labelMismatch struct {
+	resourceOffer data.ResourceOffer
+	jobOffer      data.JobOffer
+	label         string
+}
+
+func (_ labelMismatch) matched() bool   { return false }
+func (_ labelMismatch) message() string { return "resource offer does not have a required label" }
+func (result labelMismatch) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("match_result", fmt.Sprintf("%T", result)),
+		attribute.Bool("match_result.matched", result.matched()),
+		attribute.String("match_result.message", result.message()),
+		attribute.String("match_result.label", result.label),
+		attribute.String("match_result.job_offer.required_labels", data.FormatLabels(result.jobOffer.RequiredLabels)),
+		attribute.String("match_result.resource_offer.labels", data.FormatLabels(result.resourceOffer.Labels)),
+	}
+}
+
+type encryptionMismatch struct {
+	resourceOffer data.ResourceOffer
+	jobOffer      data.JobOffer
+}
+
+func (_ encryptionMismatch) matched() bool { return false }
+func (_ encryptionMismatch) message() string {
+	return "resource offer has no key to encrypt the job inputs for"
+}
+func (result encryptionMismatch) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("match_result", fmt.Sprintf("%T", result)),
+		attribute.Bool("match_result.matched", result.matched()),
+		attribute.String("match_result.message", result.message()),
+		attribute.StringSlice("match_result.job_offer.encrypted_inputs", result.jobOffer.EncryptedInputs),
+	}
+}
+
+type teeMismatch struct {
+	resourceOffer data.ResourceOffer
+	jobOffer      data.JobOffer
+}
+
+func (_ teeMismatch) matched() bool { return false }
+func (_ teeMismatch) message() string {
+	return "resource offer does not attest to a TEE the job offer trusts"
+}
+func (result teeMismatch) attributes() []attribute.KeyValue {
+	attributes := []attribute.KeyValue{
+		attribute.String("match_result", fmt.Sprintf("%T", result)),
+		attribute.Bool("match_result.matched", result.matched()),
+		attribute.String("match_result.message", result.message()),
+		attribute.StringSlice("match_result.job_offer.tee.types", result.jobOffer.TEE.Types),
+		attribute.StringSlice("match_result.job_offer.tee.measurements", result.jobOffer.TEE.Measurements),
+	}
+	if result.resourceOffer.TEE != nil {
+		attributes = append(attributes,
+			attribute.String("match_result.resource_offer.tee.type", result.resourceOffer.TEE.Type),
+			attribute.String("match_result.resource_offer.tee.measurement", result.resourceOffer.TEE.Measurement),
+		)
+	}
+	return attributes
+}
+
+type networkMismatch struct {
+	resourceOffer data.ResourceOffer
+	jobOffer      data.JobOffer
+	policy        data.NetworkPolicy
+}
+
+func (_ networkMismatch) matched() bool { return false }
+func (_ networkMismatch) message() string {
+	return "resource offer does not give jobs the network access the job offer needs"
+}
+func (result networkMismatch) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("match_result", fmt.Sprintf("%T", result)),
+		attribute.Bool("match_result.matched", result.matched()),
+		attribute.String("match_result.message", result.message()),
+		attribute.String("match_result.job_offer.network.mode", result.jobOffer.Network.Mode),
+		attribute.StringSlice("match_result.job_offer.network.allow", result.jobOffer.Network.Allow),
+		attribute.String("match_result.resource_offer.network.mode", result.policy.Mode),
+		attribute.StringSlice("match_result.resource_offer.network.allow", result.policy.Allow),
+	}
+}
+
+type paymentTokenMismatch struct {
+	resourceOffer data.ResourceOffer
+	jobOffer      data.JobOffer
+}
+
+func (_ paymentTokenMismatch) matched() bool { return false }
+func (_ paymentTokenMismatch) message() string {
+	return "resource offer does not take payment in the job offer payment token"
+}
+func (result paymentTokenMismatch) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("match_result", fmt.Sprintf("%T", result)),
+		attribute.Bool("match_result.matched", result.matched()),
+		attribute.String("match_result.message", result.message()),
+		attribute.String("match_result.job_offer.payment_token", result.jobOffer.PaymentToken.Address),
+		attribute.StringSlice("match_result.resource_offer.payment_tokens", result.resourceOffer.PaymentTokens),
+	}
+}
+
+type offerClassMismatch struct {
+	resourceOffer data.ResourceOffer
+	jobOffer      data.JobOffer
+}
+
+func (_ offerClassMismatch) matched() bool { return false }
+func (_ offerClassMismatch) message() string {
+	return "resource offer class does not meet the job offer tolerances"
+}
+func (result offerClassMismatch) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("match_result", fmt.Sprintf("%T", result)),
+		attribute.Bool("match_result.matched", result.matched()),
+		attribute.String("match_result.message", result.message()),
+		attribute.StringSlice("match_result.job_offer.offer_classes", data.GetJobOfferClasses(result.jobOffer)),
+		attribute.Int("match_result.job_offer.min_preemption_notice", result.jobOffer.MinPreemptionNotice),
+		attribute.Int("match_result.job_offer.max_runtime", result.jobOffer.MaxRuntime),
+		attribute.String("match_result.resource_offer.class", data.GetOfferClass(result.resourceOffer)),
+		attribute.Int("match_result.resource_offer.preemption_notice", result.resourceOffer.PreemptionNotice),
+		attribute.Int("match_result.resource_offer.reserved_duration", result.resourceOffer.ReservedDuration),
+	}
+}
+
+type clientMismatch struct {
+	resourceOffer data.ResourceOffer
+	jobOffer      data.JobOffer
+	err           error
+}
+
+func (_ clientMismatch) matched() bool { return false }
+func (_ clientMismatch) message() string {
+	return "job creator and resource provider clients are not compatible"
+}
+func (result clientMismatch) attributes() []attribute.KeyValue {
+	attributes := []attribute.KeyValue{
+		attribute.String("match_result", fmt.Sprintf("%T", result)),
+		attribute.Bool("match_result.matched", result.matched()),
+		attribute.String("match_result.message", result.message()),
+		attribute.String("match_result.err", result.err.Error()),
+	}
+	if result.jobOffer.Client != nil {
+		attributes = append(attributes,
+			attribute.String("match_result.job_offer.client.version", result.jobOffer.Client.Version),
+			attribute.Int("match_result.job_offer.client.protocol_version", result.jobOffer.Client.ProtocolVersion),
+		)
+	}
+	if result.resourceOffer.Client != nil {
+		attributes = append(attributes,
+			attribute.String("match_result.resource_offer.client.version", result.resourceOffer.Client.Version),
+			attribute.Int("match_result.resource_offer.client.protocol_version", result.resourceOffer.Client.ProtocolVersion),
+			attribute.StringSlice("match_result.resource_offer.client.capabilities", result.resourceOffer.Client.Capabilities),
+		)
+	}
+	return attributes
+}
+
+// returning nil means the check passed
+type offerCheck struct {
+	name  string
+	check func(resourceOffer data.ResourceOffer, jobOffer data.JobOffer) matchResult
+}
+
+// the checks matchOffers runs in the order it runs them
+var offerChecks = []offerCheck{
+	{name: "chain", check: checkChain},
+	{name: "client", check: checkClients},
+	{name: "cpu", check: checkCPU},
+	{name: "gpu", check: checkGPU},
+	{name: "ram", check: checkRAM},
+	{name: "input size", check: checkInputSize},
+	{name: "module", check: checkModule},
+	{name: "pricing mode", check: checkPricingMode},
+	{name: "price", check: checkPrice},
+	{name: "mediators", check: checkMediators},
+	{name: "solver", check: checkSolver},
+	{name: "providers", check: checkProviders},
+	{name: "job creators", check: checkJobCreators},
+	{name: "labels", check: checkLabels},
+	{name: "encryption", check: checkEncryption},
+	{name: "tee", check: checkTEE},
+	{name: "network", check: checkNetwork},
+	{name: "payment token", check: checkPaymentToken},
+	{name: "offer class", check: checkOfferClass},
+}
+
+func checkCPU(resourceOffer data.ResourceOffer, jobOffer data.JobOffer) matchResult {
 	if resourceOffer.Spec.CPU < jobOffer.Spec.CPU {
 		return &cpuMismatch{
 			jobOffer:      jobOffer,
 			resourceOffer: resourceOffer,
 		}
 	}
+	return nil
+}
+
+func checkGPU(resourceOffer data.ResourceOffer, jobOffer data.JobOffer) matchResult {
 	if resourceOffer.Spec.GPU < jobOffer.Spec.GPU {
 		return &gpuMismatch{
 			jobOffer:      jobOffer,
 			resourceOffer: resourceOffer,
 		}
 	}
+	return nil
+}
+
+func checkRAM(resourceOffer data.ResourceOffer, jobOffer data.JobOffer) matchResult {
 	if resourceOffer.Spec.RAM < jobOffer.Spec.RAM {
 		return &ramMismatch{
 			jobOffer:      jobOffer,
 			resourceOffer: resourceOffer,
 		}
 	}
+	return nil
+}
+
+func checkInputSize(resourceOffer data.ResourceOffer, jobOffer data.JobOffer) matchResult {
+	if resourceOffer.MaxInputSize > 0 && jobOffer.InputSize > resourceOffer.MaxInputSize {
+		return &inputSizeMismatch{
+			jobOffer:      jobOffer,
+			resourceOffer: resourceOffer,
+		}
+	}
+	return nil
+}
 
+func checkModule(resourceOffer data.ResourceOffer, jobOffer data.JobOffer) matchResult {
 	moduleID, err := data.GetModuleID(jobOffer.Module)
 	if err != nil {
 		return &moduleIDError{
@@ -255,17 +718,26 @@ func matchOffers(
 			}
 		}
 	}
+	return nil
+}
 
-	// we don't currently support market priced resource offers
+// we don't currently support market priced resource offers
+func checkPricingMode(resourceOffer data.ResourceOffer, jobOffer data.JobOffer) matchResult {
 	if resourceOffer.Mode == data.MarketPrice {
 		return &marketPriceUnavailable{
 			resourceOffer: resourceOffer,
 		}
 	}
+	return nil
+}
 
-	// if both are fixed price then we filter out "cannot afford"
+// if both are fixed price then we filter out "cannot afford"
+func checkPrice(resourceOffer data.ResourceOffer, jobOffer data.JobOffer) matchResult {
 	if resourceOffer.Mode == data.FixedPrice && jobOffer.Mode == data.FixedPrice {
-		if resourceOffer.DefaultPricing.InstructionPrice > jobOffer.Pricing.InstructionPrice {
+		// checkModule has already failed if the module ID cannot be computed
+		moduleID, _ := data.GetModuleID(jobOffer.Module)
+		pricing := data.GetResourceOfferPricing(resourceOffer, moduleID)
+		if pricing.InstructionPrice > jobOffer.Pricing.InstructionPrice {
 			return &priceMismatch{
 				jobOffer:      jobOffer,
 				resourceOffer: resourceOffer,
@@ -273,7 +745,39 @@ func matchOffers(
 			}
 		}
 	}
+	return nil
+}
+
+// if price is the only thing stopping the offers matching then
+// return the gap so both sides can be told what would close it
+func getPriceGap(resourceOffer data.ResourceOffer, jobOffer data.JobOffer) *data.PriceGap {
+	result, ok := checkPrice(resourceOffer, jobOffer).(*priceMismatch)
+	if !ok {
+		return nil
+	}
+	for _, offerCheck := range offerChecks {
+		if offerCheck.name == "price" {
+			continue
+		}
+		if offerCheck.check(resourceOffer, jobOffer) != nil {
+			return nil
+		}
+	}
+	pricing := data.GetResourceOfferPricing(resourceOffer, result.moduleID)
+	return &data.PriceGap{
+		JobOffer:                    jobOffer.ID,
+		ResourceOffer:               resourceOffer.ID,
+		JobCreator:                  jobOffer.JobCreator,
+		ResourceProvider:            resourceOffer.ResourceProvider,
+		ModuleID:                    result.moduleID,
+		JobOfferPrice:               jobOffer.Pricing.InstructionPrice,
+		ResourceOfferPrice:          pricing.InstructionPrice,
+		SuggestedJobOfferPrice:      pricing.InstructionPrice,
+		SuggestedResourceOfferPrice: jobOffer.Pricing.InstructionPrice,
+	}
+}
 
+func checkMediators(resourceOffer data.ResourceOffer, jobOffer data.JobOffer) matchResult {
 	mutualMediators := data.GetMutualServices(resourceOffer.Services.Mediator, jobOffer.Services.Mediator)
 	if len(mutualMediators) == 0 {
 		return &mediatorMismatch{
@@ -281,13 +785,183 @@ func matchOffers(
 			resourceOffer: resourceOffer,
 		}
 	}
+	if jobOffer.MediatorQuorum != nil && len(mutualMediators) < jobOffer.MediatorQuorum.Size {
+		return &mediatorQuorumMismatch{
+			jobOffer:      jobOffer,
+			resourceOffer: resourceOffer,
+			mutual:        len(mutualMediators),
+		}
+	}
+	return nil
+}
 
+// a deal can only be made on one chain so both offers have to be for it
+func checkChain(resourceOffer data.ResourceOffer, jobOffer data.JobOffer) matchResult {
+	if resourceOffer.ChainID != jobOffer.ChainID {
+		return &chainMismatch{
+			jobOffer:      jobOffer,
+			resourceOffer: resourceOffer,
+		}
+	}
+	return nil
+}
+
+func checkSolver(resourceOffer data.ResourceOffer, jobOffer data.JobOffer) matchResult {
 	if resourceOffer.Services.Solver != jobOffer.Services.Solver {
 		return &solverMismatch{
 			jobOffer:      jobOffer,
 			resourceOffer: resourceOffer,
 		}
 	}
+	return nil
+}
+
+// addresses are compared case insensitively because
+// they can arrive checksummed or all lower case
+func containsAddress(addresses []string, address string) bool {
+	for _, a := range addresses {
+		if strings.EqualFold(a, address) {
+			return true
+		}
+	}
+	return false
+}
+
+// the job creator can restrict which resource providers run the job
+func checkProviders(resourceOffer data.ResourceOffer, jobOffer data.JobOffer) matchResult {
+	if containsAddress(jobOffer.ExcludedProviders, resourceOffer.ResourceProvider) {
+		return &providerExcluded{
+			jobOffer:      jobOffer,
+			resourceOffer: resourceOffer,
+		}
+	}
+	if len(jobOffer.TrustedProviders) > 0 && !containsAddress(jobOffer.TrustedProviders, resourceOffer.ResourceProvider) {
+		return &providerNotTrusted{
+			jobOffer:      jobOffer,
+			resourceOffer: resourceOffer,
+		}
+	}
+	return nil
+}
+
+// the resource provider can keep its capacity for certain job creators
+func checkJobCreators(resourceOffer data.ResourceOffer, jobOffer data.JobOffer) matchResult {
+	if len(resourceOffer.AllowedJobCreators) > 0 && !containsAddress(resourceOffer.AllowedJobCreators, jobOffer.JobCreator) {
+		return &jobCreatorNotAllowed{
+			jobOffer:      jobOffer,
+			resourceOffer: resourceOffer,
+		}
+	}
+	return nil
+}
+
+func checkLabels(resourceOffer data.ResourceOffer, jobOffer data.JobOffer) matchResult {
+	for key, value := range jobOffer.RequiredLabels {
+		if actual, ok := resourceOffer.Labels[key]; !ok || actual != value {
+			return &labelMismatch{
+				jobOffer:      jobOffer,
+				resourceOffer: resourceOffer,
+				label:         key,
+			}
+		}
+	}
+	return nil
+}
+
+// only a resource provider with a key can be sent encrypted inputs
+func checkEncryption(resourceOffer data.ResourceOffer, jobOffer data.JobOffer) matchResult {
+	if len(jobOffer.EncryptedInputs) > 0 && resourceOffer.EncryptionKey == "" {
+		return &encryptionMismatch{
+			jobOffer:      jobOffer,
+			resourceOffer: resourceOffer,
+		}
+	}
+	return nil
+}
+
+// the solver verified the attestation when it took the resource offer
+func checkTEE(resourceOffer data.ResourceOffer, jobOffer data.JobOffer) matchResult {
+	if jobOffer.TEE != nil && !data.MeetsTEERequirement(*jobOffer.TEE, resourceOffer.TEE) {
+		return &teeMismatch{
+			jobOffer:      jobOffer,
+			resourceOffer: resourceOffer,
+		}
+	}
+	return nil
+}
+
+// a module ID error is reported by checkModule
+func checkNetwork(resourceOffer data.ResourceOffer, jobOffer data.JobOffer) matchResult {
+	if jobOffer.Network == nil {
+		return nil
+	}
+	moduleID, _ := data.GetModuleID(jobOffer.Module)
+	policy := data.GetResourceOfferNetworkPolicy(resourceOffer, moduleID)
+	if !data.AllowsNetworkPolicy(policy, *jobOffer.Network) {
+		return &networkMismatch{
+			jobOffer:      jobOffer,
+			resourceOffer: resourceOffer,
+			policy:        policy,
+		}
+	}
+	return nil
+}
+
+func checkPaymentToken(resourceOffer data.ResourceOffer, jobOffer data.JobOffer) matchResult {
+	if !data.AcceptsPaymentToken(resourceOffer, jobOffer.PaymentToken) {
+		return &paymentTokenMismatch{
+			jobOffer:      jobOffer,
+			resourceOffer: resourceOffer,
+		}
+	}
+	return nil
+}
+
+func checkOfferClass(resourceOffer data.ResourceOffer, jobOffer data.JobOffer) matchResult {
+	if !data.AcceptsOfferClass(resourceOffer, jobOffer) {
+		return &offerClassMismatch{
+			jobOffer:      jobOffer,
+			resourceOffer: resourceOffer,
+		}
+	}
+	return nil
+}
+
+func checkClients(resourceOffer data.ResourceOffer, jobOffer data.JobOffer) matchResult {
+	err := data.CheckClientsCompatible(resourceOffer, jobOffer)
+	if err != nil {
+		return &clientMismatch{
+			jobOffer:      jobOffer,
+			resourceOffer: resourceOffer,
+			err:           err,
+		}
+	}
+	return nil
+}
+
+// how many of the job offer preferred labels the resource offer has
+func countPreferredLabels(resourceOffer data.ResourceOffer, jobOffer data.JobOffer) int {
+	count := 0
+	for key, value := range jobOffer.PreferredLabels {
+		if actual, ok := resourceOffer.Labels[key]; ok && actual == value {
+			count++
+		}
+	}
+	return count
+}
+
+// the most basic of matchers
+// basically just check if the resource offer >= job offer cpu, gpu & ram
+// if the job offer is zero then it will match any resource offer
+func matchOffers(
+	resourceOffer data.ResourceOffer,
+	jobOffer data.JobOffer,
+) matchResult {
+	for _, offerCheck := range offerChecks {
+		if result := offerCheck.check(resourceOffer, jobOffer); result != nil {
+			return result
+		}
+	}
 
 	return &offersMatched{
 		jobOffer:      jobOffer,
@@ -297,63 +971,117 @@ func matchOffers(
 
 func logMatch(result matchResult) {
 	switch r := result.(type) {
-	case offersMatched:
+	case *offersMatched:
 		log.Trace().
 			Str("resource offer", r.resourceOffer.ID).
 			Str("job offer", r.jobOffer.ID).
 			Msg(r.message())
-	case cpuMismatch:
+	case *cpuMismatch:
 		log.Trace().
 			Str("resource offer", r.resourceOffer.ID).
 			Str("job offer", r.jobOffer.ID).
 			Int("resource CPU", r.resourceOffer.Spec.CPU).
 			Int("job CPU", r.jobOffer.Spec.CPU).
 			Msg(r.message())
-	case gpuMismatch:
+	case *gpuMismatch:
 		log.Trace().
 			Str("resource offer", r.resourceOffer.ID).
 			Str("job offer", r.jobOffer.ID).
 			Int("resource GPU", r.resourceOffer.Spec.GPU).
 			Int("job GPU", r.jobOffer.Spec.GPU).
 			Msg(r.message())
-	case ramMismatch:
+	case *ramMismatch:
 		log.Trace().
 			Str("resource offer", r.resourceOffer.ID).
 			Str("job offer", r.jobOffer.ID).
 			Int("resource RAM", r.resourceOffer.Spec.RAM).
 			Int("job RAM", r.jobOffer.Spec.RAM).
 			Msg(r.message())
-	case moduleIDError:
+	case *inputSizeMismatch:
+		log.Trace().
+			Str("resource offer", r.resourceOffer.ID).
+			Str("job offer", r.jobOffer.ID).
+			Int("resource max input size", r.resourceOffer.MaxInputSize).
+			Int("job input size", r.jobOffer.InputSize).
+			Msg(r.message())
+	case *moduleIDError:
 		log.Error().
 			Str("resource offer", r.resourceOffer.ID).
 			Str("job offer", r.jobOffer.ID).
 			Err(r.err).
 			Msg(r.message())
-	case moduleMismatch:
+	case *moduleMismatch:
 		log.Trace().
 			Str("resource offer", r.resourceOffer.ID).
 			Str("job offer", r.jobOffer.ID).
 			Str("modules", strings.Join(r.resourceOffer.Modules, ", ")).
 			Msg(r.message())
-	case marketPriceUnavailable:
+	case *marketPriceUnavailable:
 		log.Trace().
 			Str("resource offer", r.resourceOffer.ID).
 			Str("pricing mode", string(r.resourceOffer.Mode)).
 			Msg(r.message())
-	case priceMismatch:
+	case *priceMismatch:
+		log.Trace().
+			Str("resource offer", r.resourceOffer.ID).
+			Str("job offer", r.jobOffer.ID).
+			Msg(r.message())
+	case *mediatorMismatch:
+		log.Trace().
+			Str("resource offer", r.resourceOffer.ID).
+			Str("job offer", r.jobOffer.ID).
+			Msg(r.message())
+	case *moduleMinimumSpecMismatch:
+		log.Debug().
+			Str("job offer", r.jobOffer.ID).
+			Str("module", r.moduleID).
+			Msg(r.message())
+	case *moduleDisabled:
+		log.Debug().
+			Str("job offer", r.jobOffer.ID).
+			Str("module", r.moduleID).
+			Msg(r.message())
+	case *moduleImageDigestMismatch:
+		log.Debug().
+			Str("job offer", r.jobOffer.ID).
+			Str("module", r.moduleID).
+			Str("image digest", r.imageDigest).
+			Msg(r.message())
+	case *chainMismatch:
+		log.Trace().
+			Str("resource offer", r.resourceOffer.ID).
+			Str("job offer", r.jobOffer.ID).
+			Int("resource chain", r.resourceOffer.ChainID).
+			Int("job chain", r.jobOffer.ChainID).
+			Msg(r.message())
+	case *solverMismatch:
+		log.Trace().
+			Str("resource offer", r.resourceOffer.ID).
+			Str("job offer", r.jobOffer.ID).
+			Msg(r.message())
+	case *providerNotTrusted:
+		log.Trace().
+			Str("resource offer", r.resourceOffer.ID).
+			Str("job offer", r.jobOffer.ID).
+			Str("resource provider", r.resourceOffer.ResourceProvider).
+			Msg(r.message())
+	case *providerExcluded:
 		log.Trace().
 			Str("resource offer", r.resourceOffer.ID).
 			Str("job offer", r.jobOffer.ID).
+			Str("resource provider", r.resourceOffer.ResourceProvider).
 			Msg(r.message())
-	case mediatorMismatch:
+	case *labelMismatch:
 		log.Trace().
 			Str("resource offer", r.resourceOffer.ID).
 			Str("job offer", r.jobOffer.ID).
+			Str("label", r.label).
 			Msg(r.message())
-	case solverMismatch:
+	case *jobCreatorNotAllowed:
 		log.Trace().
 			Str("resource offer", r.resourceOffer.ID).
 			Str("job offer", r.jobOffer.ID).
+			Str("job creator", r.jobOffer.JobCreator).
 			Msg(r.message())
 	default:
 		log.Trace().
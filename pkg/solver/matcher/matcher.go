@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/lilypad-tech/lilypad/pkg/data"
 	"github.com/lilypad-tech/lilypad/pkg/solver/store"
@@ -14,18 +16,130 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
-type ListOfResourceOffers []data.ResourceOffer
+// order resource offers by how many of the job offer preferred labels
+// they have, then by their price for the module and then by how many
+// audits the resource provider has failed so that equally priced
+// providers with a better track record are preferred
+func sortResourceOffers(resourceOffers []data.ResourceOffer, jobOffer data.JobOffer, failedAudits map[string]int) {
+	// every match has passed checkModule so the module ID is good
+	moduleID, _ := data.GetModuleID(jobOffer.Module)
+	sort.SliceStable(resourceOffers, func(i, j int) bool {
+		labelsI := countPreferredLabels(resourceOffers[i], jobOffer)
+		labelsJ := countPreferredLabels(resourceOffers[j], jobOffer)
+		if labelsI != labelsJ {
+			return labelsI > labelsJ
+		}
+		priceI := data.GetResourceOfferPricing(resourceOffers[i], moduleID).InstructionPrice
+		priceJ := data.GetResourceOfferPricing(resourceOffers[j], moduleID).InstructionPrice
+		if priceI != priceJ {
+			return priceI < priceJ
+		}
+		return failedAudits[resourceOffers[i].ResourceProvider] < failedAudits[resourceOffers[j].ResourceProvider]
+	})
+}
 
-func (a ListOfResourceOffers) Len() int { return len(a) }
-func (a ListOfResourceOffers) Less(i, j int) bool {
-	return a[i].DefaultPricing.InstructionPrice < a[j].DefaultPricing.InstructionPrice
+// count the failed audits for each resource provider
+func getFailedAudits(db store.SolverStore) (map[string]int, error) {
+	audits, err := db.GetAudits(store.GetAuditsQuery{
+		State: data.AuditFailed,
+	})
+	if err != nil {
+		return nil, err
+	}
+	failedAudits := map[string]int{}
+	for _, audit := range audits {
+		failedAudits[audit.ResourceProvider]++
+	}
+	return failedAudits, nil
+}
+
+// the resource offers held by open reservations, an active reservation
+// holds one for each of its job offers that is still waiting for a match
+func getReservationHolds(
+	db store.SolverStore,
+	resourceOffers []data.ResourceOfferContainer,
+	jobOffers []data.JobOfferContainer,
+) (map[string]data.CapacityReservation, error) {
+	reservations, err := db.GetCapacityReservations(store.GetCapacityReservationsQuery{
+		Open: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(reservations) == 0 {
+		return map[string]data.CapacityReservation{}, nil
+	}
+	waiting := map[string]int{}
+	for _, jobOffer := range jobOffers {
+		if jobOffer.JobOffer.ReservationID != "" {
+			waiting[jobOffer.JobOffer.ReservationID]++
+		}
+	}
+	offers := []data.ResourceOffer{}
+	for _, resourceOffer := range resourceOffers {
+		offers = append(offers, resourceOffer.ResourceOffer)
+	}
+	return data.GetReservationHolds(reservations, offers, waiting), nil
+}
+
+// leave out the offers of banned addresses, banning an address expires the
+// offers it has waiting but one can be added between the check and the ban
+func removeBannedOffers(
+	db store.SolverStore,
+	resourceOffers []data.ResourceOfferContainer,
+	jobOffers []data.JobOfferContainer,
+	now int64,
+) ([]data.ResourceOfferContainer, []data.JobOfferContainer, error) {
+	bans, err := db.GetBannedAddresses()
+	if err != nil {
+		return nil, nil, err
+	}
+	active := data.GetActiveBans(bans, now)
+	if len(active) == 0 {
+		return resourceOffers, jobOffers, nil
+	}
+	allowedResourceOffers := []data.ResourceOfferContainer{}
+	for _, resourceOffer := range resourceOffers {
+		if _, ok := active[strings.ToLower(resourceOffer.ResourceProvider)]; !ok {
+			allowedResourceOffers = append(allowedResourceOffers, resourceOffer)
+		}
+	}
+	allowedJobOffers := []data.JobOfferContainer{}
+	for _, jobOffer := range jobOffers {
+		if _, ok := active[strings.ToLower(jobOffer.JobCreator)]; !ok {
+			allowedJobOffers = append(allowedJobOffers, jobOffer)
+		}
+	}
+	return allowedResourceOffers, allowedJobOffers, nil
+}
+
+func getDeal(
+	jobOffer data.JobOffer,
+	resourceOffer data.ResourceOffer,
+	getMediatorPolicy func(data.JobOffer, data.ResourceOffer, []string) (data.MediatorPolicy, error),
+) (data.Deal, error) {
+	policy := data.MediatorPolicy{Name: data.MEDIATOR_POLICY_RANDOM}
+	if getMediatorPolicy != nil {
+		var err error
+		policy, err = getMediatorPolicy(jobOffer, resourceOffer, data.GetMutualServices(resourceOffer.Services.Mediator, jobOffer.Services.Mediator))
+		if err != nil {
+			return data.Deal{}, err
+		}
+	}
+	return data.GetDeal(jobOffer, resourceOffer, policy)
 }
-func (a ListOfResourceOffers) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
 
 func GetMatchingDeals(
 	ctx context.Context,
 	db store.SolverStore,
+	allowlist map[string]data.AllowlistItem,
 	updateJobOfferState func(string, string, uint8) (*data.JobOfferContainer, error),
+	// called for pairs that only failed on price, nil turns counter-offers off
+	addPriceGap func(data.PriceGap) error,
+	// how to choose between the mutual mediators, nil chooses at random
+	getMediatorPolicy func(data.JobOffer, data.ResourceOffer, []string) (data.MediatorPolicy, error),
+	// the chain an offer with no chain ID is for, nil compares them as they are
+	resolveChain func(int) int,
 	tracer trace.Tracer,
 ) ([]data.Deal, error) {
 	ctx, span := tracer.Start(ctx, "get_matching_deals")
@@ -63,32 +177,99 @@ func GetMatchingDeals(
 		Key:   "job_offers",
 		Value: attribute.StringSliceValue(data.GetJobOfferContainerIDs(jobOffers)),
 	})
-	span.AddEvent("db.get_resource_offers.done")
+	span.AddEvent("db.get_job_offers.done")
+
+	// offers stored before their chain ID was filled in still say zero
+	if resolveChain != nil {
+		for i := range resourceOffers {
+			resourceOffers[i].ResourceOffer.ChainID = resolveChain(resourceOffers[i].ResourceOffer.ChainID)
+		}
+		for i := range jobOffers {
+			jobOffers[i].JobOffer.ChainID = resolveChain(jobOffers[i].JobOffer.ChainID)
+		}
+	}
+
+	// Get failed audits so we can prefer reputable resource providers
+	span.AddEvent("db.get_failed_audits.start")
+	failedAudits, err := getFailedAudits(db)
+	if err != nil {
+		span.SetStatus(codes.Error, "get failed audits failed")
+		span.RecordError(err)
+		return nil, err
+	}
+	span.AddEvent("db.get_failed_audits.done")
+
+	now := time.Now().Unix()
+
+	span.AddEvent("db.get_banned_addresses.start")
+	resourceOffers, jobOffers, err = removeBannedOffers(db, resourceOffers, jobOffers, now)
+	if err != nil {
+		span.SetStatus(codes.Error, "get banned addresses failed")
+		span.RecordError(err)
+		return nil, err
+	}
+	span.AddEvent("db.get_banned_addresses.done")
+
+	// capacity reserved for a future window is kept from jobs that would run into it
+	span.AddEvent("db.get_capacity_reservations.start")
+	holds, err := getReservationHolds(db, resourceOffers, jobOffers)
+	if err != nil {
+		span.SetStatus(codes.Error, "get capacity reservations failed")
+		span.RecordError(err)
+		return nil, err
+	}
+	span.AddEvent("db.get_capacity_reservations.done")
 
 	// loop over job offers
 	for _, jobOffer := range jobOffers {
 
-		// Check for targeted jobs
-		if jobOffer.JobOffer.Target.Address != "" {
-			deal, err := getTargetedDeal(ctx, db, jobOffer, updateJobOfferState, tracer)
+		// reject job offers that ask for less than the module needs
+		// no resource offer can make these succeed so we cancel them
+		if result := matchModuleMinimumSpec(jobOffer.JobOffer, allowlist); !result.matched() {
+			logMatch(result)
+			span.AddEvent("module_minimum_spec_rejected", trace.WithAttributes(result.attributes()...))
+			_, err := updateJobOfferState(jobOffer.ID, "", data.GetAgreementStateIndex("JobOfferCancelled"))
 			if err != nil {
 				return nil, err
 			}
+			continue
+		}
 
-			if deal != nil {
-				deals = append(deals, *deal)
+		// targeted jobs are only matched with the offers of their target
+		// these still have to satisfy the job but are not weighed against
+		// the rest of the market
+		candidateResourceOffers := resourceOffers
+		if data.HasTarget(jobOffer.JobOffer) {
+			targeted, cancel, err := getTargetedResourceOffers(ctx, db, resourceOffers, jobOffer, tracer)
+			if err != nil {
+				return nil, err
 			}
-			continue
+			if cancel {
+				_, err := updateJobOfferState(jobOffer.ID, "", data.GetAgreementStateIndex("JobOfferCancelled"))
+				if err != nil {
+					return nil, err
+				}
+				continue
+			}
+			candidateResourceOffers = targeted
 		}
 
 		// loop over resource offers
 		matchingResourceOffers := []data.ResourceOffer{}
-		for _, resourceOffer := range resourceOffers {
+		for _, resourceOffer := range candidateResourceOffers {
 			_, matchSpan := tracer.Start(ctx, "match",
 				trace.WithAttributes(attribute.String("job_offer.id", jobOffer.ID),
 					attribute.String("resource_offer.id", resourceOffer.ID)),
 			)
 
+			// no decision is recorded so the offers can match once the hold is over
+			if reservation, held := holds[resourceOffer.ID]; held && data.ReservationConflicts(reservation, jobOffer.JobOffer, now) {
+				matchSpan.AddEvent("held_for_reservation",
+					trace.WithAttributes(attribute.String("reservation.id", reservation.ID)))
+				matchSpan.End()
+				continue
+			}
+
 			matchSpan.AddEvent("db.get_match_decision.start")
 			decision, err := db.GetMatchDecision(resourceOffer.ID, jobOffer.ID)
 			if err != nil {
@@ -127,16 +308,29 @@ func GetMatchingDeals(
 					return nil, err
 				}
 				matchSpan.AddEvent("add_match_decision.done")
+
+				if addPriceGap != nil {
+					if gap := getPriceGap(resourceOffer.ResourceOffer, jobOffer.JobOffer); gap != nil {
+						matchSpan.AddEvent("add_price_gap.start")
+						err := addPriceGap(*gap)
+						if err != nil {
+							matchSpan.SetStatus(codes.Error, "unable to record price gap")
+							matchSpan.RecordError(err)
+							return nil, err
+						}
+						matchSpan.AddEvent("add_price_gap.done")
+					}
+				}
 			}
 
 			matchSpan.End()
 		}
 
 		// yay - we've got some matching resource offers
-		// let's choose the cheapest one
+		// let's choose the best one
 		if len(matchingResourceOffers) > 0 {
-			// now let's order the matching resource offers by price
-			sort.Sort(ListOfResourceOffers(matchingResourceOffers))
+			// now let's order the matching resource offers by preferred labels and price
+			sortResourceOffers(matchingResourceOffers, jobOffer.JobOffer, failedAudits)
 			cheapestResourceOffer := matchingResourceOffers[0]
 
 			span.AddEvent("get_deal.start", trace.WithAttributes(attribute.String("cheapest_resource_offer", cheapestResourceOffer.ID),
@@ -144,11 +338,27 @@ func GetMatchingDeals(
 					Key:   "matching_resource_offers",
 					Value: attribute.StringSliceValue(data.GetResourceOfferIDs(matchingResourceOffers)),
 				}))
-			deal, err := data.GetDeal(jobOffer.JobOffer, cheapestResourceOffer)
-			if err != nil {
-				span.SetStatus(codes.Error, "unable to get deal")
-				span.RecordError(err)
-				return nil, err
+			var deal data.Deal
+			found := false
+			for _, resourceOffer := range matchingResourceOffers {
+				deal, err = getDeal(jobOffer.JobOffer, resourceOffer, getMediatorPolicy)
+				// the mediators of this offer are all related to its
+				// resource provider so we try the next best one
+				if errors.Is(err, data.ErrForbidden) {
+					span.AddEvent("get_deal.refused", trace.WithAttributes(attribute.String("resource_offer", resourceOffer.ID)))
+					continue
+				}
+				if err != nil {
+					span.SetStatus(codes.Error, "unable to get deal")
+					span.RecordError(err)
+					return nil, err
+				}
+				cheapestResourceOffer = resourceOffer
+				found = true
+				break
+			}
+			if !found {
+				continue
 			}
 			span.AddEvent("get_deal.done", trace.WithAttributes(attribute.String("deal.id", deal.ID)))
 
@@ -188,47 +398,86 @@ func GetMatchingDeals(
 	return deals, nil
 }
 
-// See if our jobOffer targets a specific address. If so, we will create a deal automatically
-// with the matcing resourceOffer.
-func getTargetedDeal(
+// The unmatched resource offers a targeted job offer can be matched with.
+// cancel is set when the target will never be able to take the job,
+// if the resource provider only has offers that are in deals the job offer
+// waits for its next one so a job can be run again on the same machine.
+func getTargetedResourceOffers(
 	ctx context.Context,
 	db store.SolverStore,
+	resourceOffers []data.ResourceOfferContainer,
 	jobOffer data.JobOfferContainer,
-	updateJobOfferState func(string, string, uint8) (*data.JobOfferContainer, error),
 	tracer trace.Tracer,
-) (*data.Deal, error) {
-	ctx, span := tracer.Start(ctx, "get_targeted_deal",
-		trace.WithAttributes(attribute.String("job_offer.target.address", jobOffer.JobOffer.Target.Address)))
+) (targeted []data.ResourceOfferContainer, cancel bool, err error) {
+	target := jobOffer.JobOffer.Target
+	_, span := tracer.Start(ctx, "get_targeted_resource_offers",
+		trace.WithAttributes(attribute.String("job_offer.target.address", target.Address),
+			attribute.String("job_offer.target.resource_offer", target.ResourceOffer)))
 	defer span.End()
 
+	for _, resourceOffer := range resourceOffers {
+		if target.ResourceOffer != "" && resourceOffer.ID != target.ResourceOffer {
+			continue
+		}
+		if target.Address != "" && !strings.EqualFold(resourceOffer.ResourceProvider, target.Address) {
+			continue
+		}
+		targeted = append(targeted, resourceOffer)
+	}
+
+	if target.ResourceOffer != "" {
+		// the offer has gone, been matched or belongs to another resource provider
+		if len(targeted) == 0 {
+			log.Trace().
+				Str("job offer", jobOffer.ID).
+				Str("target offer", target.ResourceOffer).
+				Msgf("Targeted resource offer is not available")
+			span.SetStatus(codes.Error, "targeted resource offer is not available")
+			return nil, true, nil
+		}
+
+		// the offer does not change so once it has failed to match it never will
+		span.AddEvent("db.get_match_decision.start")
+		decision, err := db.GetMatchDecision(target.ResourceOffer, jobOffer.ID)
+		if err != nil {
+			span.SetStatus(codes.Error, "unable to retrieve match decision")
+			span.RecordError(err)
+			return nil, false, err
+		}
+		span.AddEvent("db.get_match_decision.done")
+		if decision != nil && !decision.Result {
+			span.SetStatus(codes.Error, "targeted resource offer does not satisfy the job")
+			return nil, true, nil
+		}
+		return targeted, false, nil
+	}
+
+	if len(targeted) > 0 {
+		span.AddEvent("targeted_resource_offers", trace.WithAttributes(attribute.StringSlice("resource_offer.ids", data.GetResourceOfferContainerIDs(targeted))))
+		return targeted, false, nil
+	}
+
 	span.AddEvent("db.get_resource_offer_by_address.start")
-	resourceOffer, err := db.GetResourceOfferByAddress(jobOffer.JobOffer.Target.Address)
+	resourceOffer, err := db.GetResourceOfferByAddress(target.Address)
 	if err != nil {
-		return nil, err
+		span.SetStatus(codes.Error, "get resource offer by address failed")
+		span.RecordError(err)
+		return nil, false, err
 	}
+	span.AddEvent("db.get_resource_offer_by_address.done")
 
 	// We don't have a resource provider for this address
 	if resourceOffer == nil {
 		log.Trace().
 			Str("job offer", jobOffer.ID).
-			Str("target address", jobOffer.JobOffer.Target.Address).
+			Str("target address", target.Address).
 			Msgf("No resource provider found for address")
 		span.SetStatus(codes.Error, "no resource provider found for address")
 		span.RecordError(errors.New("no resource provider found for address"))
-
-		updateJobOfferState(jobOffer.ID, "", data.GetAgreementStateIndex("JobOfferCancelled"))
-		return nil, nil
-	}
-	span.AddEvent("db.get_resource_offer_by_address.found", trace.WithAttributes(attribute.String("resource_offer.id", resourceOffer.ID)))
-
-	span.AddEvent("get_deal.start")
-	deal, err := data.GetDeal(jobOffer.JobOffer, resourceOffer.ResourceOffer)
-	if err != nil {
-		span.SetStatus(codes.Error, "get deal failed")
-		span.RecordError(err)
-		return nil, err
+		return nil, true, nil
 	}
-	span.AddEvent("get_deal.done", trace.WithAttributes(attribute.String("deal.id", deal.ID)))
 
-	return &deal, nil
+	// the resource provider is busy so wait for it to post another offer
+	span.AddEvent("target_busy")
+	return nil, false, nil
 }
@@ -1,9 +1,16 @@
 package matcher
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/lilypad-tech/lilypad/pkg/data"
+	memorystore "github.com/lilypad-tech/lilypad/pkg/solver/store/memory"
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestMatchOffers(t *testing.T) {
@@ -98,6 +105,30 @@ func TestMatchOffers(t *testing.T) {
 			},
 			shouldMatch: false,
 		},
+		{
+			name: "Input size within limit",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.MaxInputSize = 1024
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.InputSize = 512
+				return offer
+			},
+			shouldMatch: true,
+		},
+		{
+			name: "Input size exceeds limit",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.MaxInputSize = 1024
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.InputSize = 2048
+				return offer
+			},
+			shouldMatch: false,
+		},
 		{
 			name: "Resource provider supports module",
 			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
@@ -160,6 +191,57 @@ func TestMatchOffers(t *testing.T) {
 			},
 			shouldMatch: true,
 		},
+		{
+			name: "Fixed price - module price too expensive",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				moduleID, _ := data.GetModuleID(cowsayModuleConfig)
+				offer.ModulePricing = map[string]data.DealPricing{
+					moduleID: {InstructionPrice: 20},
+				}
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.Module = cowsayModuleConfig
+				offer.Mode = data.FixedPrice
+				offer.Pricing.InstructionPrice = 11
+				return offer
+			},
+			shouldMatch: false,
+		},
+		{
+			name: "Fixed price - can afford module price",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				moduleID, _ := data.GetModuleID(cowsayModuleConfig)
+				offer.ModulePricing = map[string]data.DealPricing{
+					moduleID: {InstructionPrice: 5},
+				}
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.Module = cowsayModuleConfig
+				offer.Mode = data.FixedPrice
+				offer.Pricing.InstructionPrice = 9
+				return offer
+			},
+			shouldMatch: true,
+		},
+		{
+			name: "Fixed price - module price for another module",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				moduleID, _ := data.GetModuleID(lilysayModuleConfig)
+				offer.ModulePricing = map[string]data.DealPricing{
+					moduleID: {InstructionPrice: 5},
+				}
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.Module = cowsayModuleConfig
+				offer.Mode = data.FixedPrice
+				offer.Pricing.InstructionPrice = 9
+				return offer
+			},
+			shouldMatch: false,
+		},
 		{
 			name: "Resource provider using unimplemented market pricing",
 			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
@@ -193,6 +275,330 @@ func TestMatchOffers(t *testing.T) {
 			},
 			shouldMatch: true,
 		},
+		{
+			name: "Trusted provider",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.ResourceProvider = "0xAbC"
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.TrustedProviders = []string{"0xabc"}
+				return offer
+			},
+			shouldMatch: true,
+		},
+		{
+			name: "Provider not trusted",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.ResourceProvider = "0xdef"
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.TrustedProviders = []string{"0xabc"}
+				return offer
+			},
+			shouldMatch: false,
+		},
+		{
+			name: "Excluded provider",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.ResourceProvider = "0xabc"
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.ExcludedProviders = []string{"0xABC"}
+				return offer
+			},
+			shouldMatch: false,
+		},
+		{
+			name: "Excluded wins over trusted",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.ResourceProvider = "0xabc"
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.TrustedProviders = []string{"0xabc"}
+				offer.ExcludedProviders = []string{"0xabc"}
+				return offer
+			},
+			shouldMatch: false,
+		},
+		{
+			name: "Allowed job creator",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.AllowedJobCreators = []string{"0xABC"}
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.JobCreator = "0xabc"
+				return offer
+			},
+			shouldMatch: true,
+		},
+		{
+			name: "Job creator not allowed",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.AllowedJobCreators = []string{"0xabc"}
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.JobCreator = "0xdef"
+				return offer
+			},
+			shouldMatch: false,
+		},
+		{
+			name: "Encrypted inputs with a resource offer key",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.EncryptionKey = "02abc"
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.EncryptedInputs = []string{"Secret"}
+				return offer
+			},
+			shouldMatch: true,
+		},
+		{
+			name: "Encrypted inputs without a resource offer key",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.EncryptedInputs = []string{"Secret"}
+				return offer
+			},
+			shouldMatch: false,
+		},
+		{
+			name: "Same protocol version",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.Client = &data.ClientMetadata{ProtocolVersion: 1}
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.Client = &data.ClientMetadata{ProtocolVersion: 1}
+				return offer
+			},
+			shouldMatch: true,
+		},
+		{
+			name: "Different supported protocol versions",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.Client = &data.ClientMetadata{ProtocolVersion: data.PROTOCOL_VERSION}
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.Client = &data.ClientMetadata{ProtocolVersion: data.MIN_PROTOCOL_VERSION}
+				return offer
+			},
+			shouldMatch: true,
+		},
+		{
+			name: "Unsupported protocol version",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.Client = &data.ClientMetadata{ProtocolVersion: data.PROTOCOL_VERSION + 1}
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.Client = &data.ClientMetadata{ProtocolVersion: data.PROTOCOL_VERSION}
+				return offer
+			},
+			shouldMatch: false,
+		},
+		{
+			name: "Resource provider without the checkpoints capability",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.Client = &data.ClientMetadata{ProtocolVersion: 1, Capabilities: []string{data.CapabilityLogs}}
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.Milestones = 2
+				return offer
+			},
+			shouldMatch: false,
+		},
+		{
+			name: "Attested TEE",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.TEE = &data.TEEAttestation{Type: data.TEETypeSEVSNP, Measurement: "ABCD"}
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.TEE = &data.TEERequirement{Types: []string{data.TEETypeSEVSNP}, Measurements: []string{"0xabcd"}}
+				return offer
+			},
+			shouldMatch: true,
+		},
+		{
+			name: "No TEE",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.TEE = &data.TEERequirement{}
+				return offer
+			},
+			shouldMatch: false,
+		},
+		{
+			name: "TEE measurement not trusted",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.TEE = &data.TEEAttestation{Type: data.TEETypeTDX, Measurement: "abcd"}
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.TEE = &data.TEERequirement{Measurements: []string{"1234"}}
+				return offer
+			},
+			shouldMatch: false,
+		},
+		{
+			name: "Network allowlist within the provider allowlist",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.Network = &data.NetworkPolicy{Mode: data.NetworkPolicyEgressAllowlist, Allow: []string{"huggingface.co", "10.0.0.0/8"}}
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.Network = &data.NetworkPolicy{Mode: data.NetworkPolicyEgressAllowlist, Allow: []string{"cdn.huggingface.co", "10.1.0.0/16"}}
+				return offer
+			},
+			shouldMatch: true,
+		},
+		{
+			name: "Full network on a provider without network",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.Network = &data.NetworkPolicy{Mode: data.NetworkPolicyNone}
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.Network = &data.NetworkPolicy{Mode: data.NetworkPolicyFull}
+				return offer
+			},
+			shouldMatch: false,
+		},
+		{
+			name: "Payment token the provider takes",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.PaymentTokens = []string{"0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238"}
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.PaymentToken = &data.PaymentToken{Address: "0x1c7d4b196cb0c7b01d743fbc6116a902379c7238", Decimals: 6}
+				return offer
+			},
+			shouldMatch: true,
+		},
+		{
+			name: "Payment token the provider does not take",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.PaymentToken = &data.PaymentToken{Address: "0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238", Decimals: 6}
+				return offer
+			},
+			shouldMatch: false,
+		},
+		{
+			name: "Spot offer for a job that takes spot with enough notice",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.Class = data.OfferClassSpot
+				offer.PreemptionNotice = 120
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.OfferClasses = []string{data.OfferClassSpot, data.OfferClassReserved}
+				offer.MinPreemptionNotice = 60
+				return offer
+			},
+			shouldMatch: true,
+		},
+		{
+			name: "Spot offer for a job that only takes reserved",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.Class = data.OfferClassSpot
+				offer.PreemptionNotice = 120
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				return offer
+			},
+			shouldMatch: false,
+		},
+		{
+			name: "Spot offer gives less notice than the job needs",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.Class = data.OfferClassSpot
+				offer.PreemptionNotice = 30
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.OfferClasses = []string{data.OfferClassSpot}
+				offer.MinPreemptionNotice = 60
+				return offer
+			},
+			shouldMatch: false,
+		},
+		{
+			name: "Reserved offer too short for the job",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.ReservedDuration = 300
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.MaxRuntime = 600
+				return offer
+			},
+			shouldMatch: false,
+		},
+		{
+			name: "Required labels match",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.Labels = map[string]string{"region": "eu-west", "tier": "datacenter"}
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.RequiredLabels = map[string]string{"region": "eu-west"}
+				return offer
+			},
+			shouldMatch: true,
+		},
+		{
+			name: "Required label has a different value",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.Labels = map[string]string{"region": "us-east"}
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.RequiredLabels = map[string]string{"region": "eu-west"}
+				return offer
+			},
+			shouldMatch: false,
+		},
+		{
+			name: "Required label missing",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.RequiredLabels = map[string]string{"region": "eu-west"}
+				return offer
+			},
+			shouldMatch: false,
+		},
+		{
+			name: "Preferred labels do not have to match",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.PreferredLabels = map[string]string{"region": "eu-west"}
+				return offer
+			},
+			shouldMatch: true,
+		},
 		{
 			name: "Different solver",
 			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
@@ -204,6 +610,30 @@ func TestMatchOffers(t *testing.T) {
 			},
 			shouldMatch: false,
 		},
+		{
+			name: "Different chain",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.ChainID = 421614
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.ChainID = 1337
+				return offer
+			},
+			shouldMatch: false,
+		},
+		{
+			name: "Same chain",
+			resourceOffer: func(offer data.ResourceOffer) data.ResourceOffer {
+				offer.ChainID = 421614
+				return offer
+			},
+			jobOffer: func(offer data.JobOffer) data.JobOffer {
+				offer.ChainID = 421614
+				return offer
+			},
+			shouldMatch: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -215,3 +645,657 @@ func TestMatchOffers(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchModuleMinimumSpec(t *testing.T) {
+	cowsayModuleConfig := data.ModuleConfig{
+		Name: "cowsay",
+		Repo: "https://github.com/Lilypad-Tech/lilypad-module-cowsay",
+		Hash: "v0.0.4",
+		Path: "/lilypad_module.json.tmpl",
+	}
+	cowsayModuleID, _ := data.GetModuleID(cowsayModuleConfig)
+
+	allowlist := map[string]data.AllowlistItem{
+		cowsayModuleID: {
+			Module:   cowsayModuleConfig,
+			ModuleID: cowsayModuleID,
+			MinimumSpec: data.MachineSpec{
+				CPU: 1000,
+				RAM: 2048,
+			},
+		},
+	}
+
+	pinnedDigest := "sha256:" + strings.Repeat("a", 64)
+	pinnedAllowlist := map[string]data.AllowlistItem{
+		cowsayModuleID: {
+			Module:      cowsayModuleConfig,
+			ModuleID:    cowsayModuleID,
+			ImageDigest: pinnedDigest,
+		},
+	}
+	pinnedModuleConfig := func(digest string) data.ModuleConfig {
+		module := cowsayModuleConfig
+		module.ImageDigest = digest
+		return module
+	}
+
+	testCases := []struct {
+		name        string
+		allowlist   map[string]data.AllowlistItem
+		module      data.ModuleConfig
+		spec        data.MachineSpec
+		shouldMatch bool
+	}{
+		{
+			name:        "No allowlist",
+			allowlist:   map[string]data.AllowlistItem{},
+			module:      cowsayModuleConfig,
+			spec:        data.MachineSpec{},
+			shouldMatch: true,
+		},
+		{
+			name:        "Meets minimum spec",
+			allowlist:   allowlist,
+			module:      cowsayModuleConfig,
+			spec:        data.MachineSpec{CPU: 1000, RAM: 4096},
+			shouldMatch: true,
+		},
+		{
+			name:        "Below minimum RAM",
+			allowlist:   allowlist,
+			module:      cowsayModuleConfig,
+			spec:        data.MachineSpec{CPU: 1000, RAM: 1024},
+			shouldMatch: false,
+		},
+		{
+			name:      "Module not in allowlist",
+			allowlist: allowlist,
+			module: data.ModuleConfig{
+				Name: "lilysay",
+				Repo: "https://github.com/Lilypad-Tech/lilypad-module-lilysay",
+				Hash: "v0.5.2",
+				Path: "/lilypad_module.json.tmpl",
+			},
+			spec:        data.MachineSpec{},
+			shouldMatch: true,
+		},
+		{
+			name: "Module disabled",
+			allowlist: map[string]data.AllowlistItem{
+				cowsayModuleID: {
+					Module:   cowsayModuleConfig,
+					ModuleID: cowsayModuleID,
+					Disabled: true,
+				},
+			},
+			module:      cowsayModuleConfig,
+			spec:        data.MachineSpec{CPU: 1000, RAM: 4096},
+			shouldMatch: false,
+		},
+		{
+			name:        "Image digest matches allowlist",
+			allowlist:   pinnedAllowlist,
+			module:      pinnedModuleConfig(pinnedDigest),
+			spec:        data.MachineSpec{CPU: 1000, RAM: 4096},
+			shouldMatch: true,
+		},
+		{
+			name:        "Image digest differs from allowlist",
+			allowlist:   pinnedAllowlist,
+			module:      pinnedModuleConfig("sha256:" + strings.Repeat("b", 64)),
+			spec:        data.MachineSpec{CPU: 1000, RAM: 4096},
+			shouldMatch: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			jobOffer := data.JobOffer{
+				Module: tc.module,
+				Spec:   tc.spec,
+			}
+			result := matchModuleMinimumSpec(jobOffer, tc.allowlist)
+			if result.matched() != tc.shouldMatch {
+				t.Errorf("Expected match to be %v, but got %v", tc.shouldMatch, result)
+			}
+		})
+	}
+}
+
+func TestIsModuleAllowed(t *testing.T) {
+	enabledModule := data.ModuleConfig{Repo: "https://github.com/Lilypad-Tech/lilypad-module-cowsay", Hash: "v0.0.4"}
+	disabledModule := data.ModuleConfig{Repo: "https://github.com/Lilypad-Tech/lilypad-module-cowsay", Hash: "v0.0.3"}
+	unlistedModule := data.ModuleConfig{Repo: "https://github.com/Lilypad-Tech/lilypad-module-lilysay", Hash: "v0.5.2"}
+	enabledModuleID, _ := data.GetModuleID(enabledModule)
+	disabledModuleID, _ := data.GetModuleID(disabledModule)
+
+	allowlist := map[string]data.AllowlistItem{
+		enabledModuleID:  {Module: enabledModule, ModuleID: enabledModuleID},
+		disabledModuleID: {Module: disabledModule, ModuleID: disabledModuleID, Disabled: true},
+	}
+
+	testCases := []struct {
+		name      string
+		allowlist map[string]data.AllowlistItem
+		module    data.ModuleConfig
+		allowed   bool
+	}{
+		{name: "No allowlist", allowlist: map[string]data.AllowlistItem{}, module: disabledModule, allowed: true},
+		{name: "Enabled", allowlist: allowlist, module: enabledModule, allowed: true},
+		{name: "Disabled", allowlist: allowlist, module: disabledModule, allowed: false},
+		{name: "Not listed", allowlist: allowlist, module: unlistedModule, allowed: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			allowed, reason, err := IsModuleAllowed(tc.module, tc.allowlist)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if allowed != tc.allowed {
+				t.Errorf("Expected allowed to be %v, but got %v (%s)", tc.allowed, allowed, reason)
+			}
+		})
+	}
+}
+
+func TestSortResourceOffers(t *testing.T) {
+	offer := func(id string, resourceProvider string, price uint64) data.ResourceOffer {
+		return data.ResourceOffer{
+			ID:               id,
+			ResourceProvider: resourceProvider,
+			DefaultPricing:   data.DealPricing{InstructionPrice: price},
+		}
+	}
+	moduleID, _ := data.GetModuleID(data.ModuleConfig{})
+	withModulePrice := func(offer data.ResourceOffer, price uint64) data.ResourceOffer {
+		offer.ModulePricing = map[string]data.DealPricing{
+			moduleID: {InstructionPrice: price},
+		}
+		return offer
+	}
+	withLabels := func(offer data.ResourceOffer, labels map[string]string) data.ResourceOffer {
+		offer.Labels = labels
+		return offer
+	}
+	jobOffer := data.JobOffer{
+		PreferredLabels: map[string]string{"region": "eu-west"},
+	}
+
+	testCases := []struct {
+		name         string
+		offers       []data.ResourceOffer
+		failedAudits map[string]int
+		expectedID   string
+	}{
+		{
+			name:         "Cheapest wins",
+			offers:       []data.ResourceOffer{offer("a", "rp1", 20), offer("b", "rp2", 10)},
+			failedAudits: map[string]int{},
+			expectedID:   "b",
+		},
+		{
+			name:         "Fewer failed audits wins on equal price",
+			offers:       []data.ResourceOffer{offer("a", "rp1", 10), offer("b", "rp2", 10)},
+			failedAudits: map[string]int{"rp1": 2},
+			expectedID:   "b",
+		},
+		{
+			name:         "Price beats reputation",
+			offers:       []data.ResourceOffer{offer("a", "rp1", 5), offer("b", "rp2", 10)},
+			failedAudits: map[string]int{"rp1": 2},
+			expectedID:   "a",
+		},
+		{
+			name: "Module price is used over the default price",
+			offers: []data.ResourceOffer{
+				offer("a", "rp1", 5),
+				withModulePrice(offer("b", "rp2", 10), 1),
+			},
+			failedAudits: map[string]int{},
+			expectedID:   "b",
+		},
+		{
+			name: "Preferred labels beat price",
+			offers: []data.ResourceOffer{
+				offer("a", "rp1", 5),
+				withLabels(offer("b", "rp2", 10), map[string]string{"region": "eu-west"}),
+			},
+			failedAudits: map[string]int{},
+			expectedID:   "b",
+		},
+		{
+			name: "Price breaks a preferred labels tie",
+			offers: []data.ResourceOffer{
+				withLabels(offer("a", "rp1", 10), map[string]string{"region": "eu-west"}),
+				withLabels(offer("b", "rp2", 5), map[string]string{"region": "eu-west", "tier": "edge"}),
+			},
+			failedAudits: map[string]int{},
+			expectedID:   "b",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sortResourceOffers(tc.offers, jobOffer, tc.failedAudits)
+			if tc.offers[0].ID != tc.expectedID {
+				t.Errorf("Expected %s to be first, but got %s", tc.expectedID, tc.offers[0].ID)
+			}
+		})
+	}
+}
+
+func TestDebugMatch(t *testing.T) {
+	resourceOffer := data.ResourceOffer{
+		Spec: data.MachineSpec{CPU: 1000, RAM: 1024},
+		Mode: data.FixedPrice,
+		Services: data.ServiceConfig{
+			Solver:   "solver",
+			Mediator: []string{"mediator"},
+		},
+	}
+	jobOffer := data.JobOffer{
+		Spec: data.MachineSpec{CPU: 2000, RAM: 4096},
+		Mode: data.MarketPrice,
+		Services: data.ServiceConfig{
+			Solver:   "solver",
+			Mediator: []string{"mediator"},
+		},
+	}
+
+	checks := DebugMatch(resourceOffer, jobOffer, map[string]data.AllowlistItem{})
+	failed := map[string]bool{}
+	for _, check := range checks {
+		if !check.Passed {
+			failed[check.Name] = true
+		}
+	}
+
+	// every failing check is reported rather than just the first
+	if !failed["cpu"] || !failed["ram"] || len(failed) != 2 {
+		t.Errorf("Expected cpu and ram checks to fail, but got %+v", checks)
+	}
+	if AllChecksPassed(checks) {
+		t.Errorf("Expected checks not to all pass")
+	}
+}
+
+func TestGetPriceGap(t *testing.T) {
+	services := data.ServiceConfig{
+		Solver:   "solver",
+		Mediator: []string{"mediator"},
+	}
+	resourceOffer := data.ResourceOffer{
+		ID:               "resource_offer",
+		ResourceProvider: "rp",
+		Spec:             data.MachineSpec{CPU: 1000, RAM: 1024},
+		Mode:             data.FixedPrice,
+		DefaultPricing:   data.DealPricing{InstructionPrice: 10},
+		Services:         services,
+	}
+	jobOffer := data.JobOffer{
+		ID:         "job_offer",
+		JobCreator: "jc",
+		Spec:       data.MachineSpec{CPU: 1000, RAM: 1024},
+		Mode:       data.FixedPrice,
+		Pricing:    data.DealPricing{InstructionPrice: 6},
+		Services:   services,
+	}
+
+	gap := getPriceGap(resourceOffer, jobOffer)
+	if gap == nil {
+		t.Fatalf("Expected a price gap")
+	}
+	if gap.SuggestedJobOfferPrice != 10 || gap.SuggestedResourceOfferPrice != 6 {
+		t.Errorf("Expected counter-offers of 10 and 6, but got %+v", gap)
+	}
+
+	// there is nothing to negotiate if they already match
+	affordable := jobOffer
+	affordable.Pricing.InstructionPrice = 10
+	if gap := getPriceGap(resourceOffer, affordable); gap != nil {
+		t.Errorf("Expected no price gap, but got %+v", gap)
+	}
+
+	// or if price is not the only problem
+	tooBig := jobOffer
+	tooBig.Spec.CPU = 2000
+	if gap := getPriceGap(resourceOffer, tooBig); gap != nil {
+		t.Errorf("Expected no price gap, but got %+v", gap)
+	}
+}
+
+func TestGetCostEstimate(t *testing.T) {
+	db, err := memorystore.NewSolverStoreMemory(memorystore.SolverStoreMemoryOptions{LogDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	module := data.ModuleConfig{Repo: "https://github.com/lilypad-tech/lilypad-module-cowsay", Hash: "v0.0.4", Path: "/lilypad_module.json.tmpl"}
+	moduleID, err := data.GetModuleID(module)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addResourceOffer := func(provider string, cpu int, price uint64, modulePrice uint64) {
+		offer := data.ResourceOffer{
+			ResourceProvider: provider,
+			Spec:             data.MachineSpec{CPU: cpu, RAM: 1024},
+			DefaultPricing:   data.DealPricing{InstructionPrice: price},
+			Mode:             data.FixedPrice,
+			// the services never stop an estimate
+			Services: data.ServiceConfig{Solver: provider},
+		}
+		if modulePrice > 0 {
+			offer.ModulePricing = map[string]data.DealPricing{moduleID: {InstructionPrice: modulePrice}}
+		}
+		offer.ID, err = data.GetResourceOfferID(offer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = db.AddResourceOffer(data.GetResourceOfferContainer(offer))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	addResourceOffer("0xrp1", 1000, 10, 0)
+	addResourceOffer("0xrp2", 1000, 30, 0)
+	// this one charges less for the module than its default
+	addResourceOffer("0xrp3", 1000, 50, 20)
+	// too small to run the job
+	addResourceOffer("0xrp4", 500, 1, 0)
+	// banned so it cannot be matched
+	addResourceOffer("0xrp5", 1000, 2, 0)
+	_, err = db.AddBannedAddress(data.BannedAddress{Address: "0xrp5"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	estimate, err := GetCostEstimate(db, data.JobOffer{
+		Module: module,
+		Spec:   data.MachineSpec{CPU: 1000, RAM: 1024},
+	}, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if estimate.ResourceOffers != 3 {
+		t.Errorf("Expected 3 resource offers, but got %d", estimate.ResourceOffers)
+	}
+	if estimate.MinInstructionPrice != 10 || estimate.MedianInstructionPrice != 20 || estimate.P90InstructionPrice != 30 {
+		t.Errorf("Expected prices of 10, 20 and 30, but got %+v", estimate)
+	}
+	if estimate.ExpectedCost != (data.CostRange{Min: 1000, Max: 3000}) {
+		t.Errorf("Expected a cost of 1000 to 3000, but got %+v", estimate.ExpectedCost)
+	}
+}
+
+func TestGetMatchingDealsTargeted(t *testing.T) {
+	db, err := memorystore.NewSolverStoreMemory(memorystore.SolverStoreMemoryOptions{LogDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracer := noop.NewTracerProvider().Tracer("test")
+	services := data.ServiceConfig{Solver: "oranges", Mediator: []string{"apples"}}
+
+	addResourceOffer := func(provider string, price uint64) data.ResourceOffer {
+		offer := data.ResourceOffer{
+			ResourceProvider: provider,
+			Spec:             data.MachineSpec{CPU: 1000, RAM: 1024},
+			DefaultPricing:   data.DealPricing{InstructionPrice: price},
+			Mode:             data.FixedPrice,
+			Services:         services,
+		}
+		offer.ID, err = data.GetResourceOfferID(offer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = db.AddResourceOffer(data.GetResourceOfferContainer(offer))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return offer
+	}
+	addJobOffer := func(target data.TargetConfig, cpu int) data.JobOffer {
+		offer := data.JobOffer{
+			JobCreator: "0xjc",
+			Spec:       data.MachineSpec{CPU: cpu, RAM: 1024},
+			Mode:       data.MarketPrice,
+			Services:   services,
+			Target:     target,
+		}
+		offer.ID, err = data.GetJobOfferID(offer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = db.AddJobOffer(data.GetJobOfferContainer(offer))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return offer
+	}
+	getState := func(id string) uint8 {
+		jobOffer, err := db.GetJobOffer(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return jobOffer.State
+	}
+
+	cheap := addResourceOffer("0xcheap", 1)
+	expensive := addResourceOffer("0xexpensive", 100)
+	byAddress := addJobOffer(data.TargetConfig{Address: "0xEXPENSIVE"}, 1000)
+	tooBig := addJobOffer(data.TargetConfig{ResourceOffer: cheap.ID}, 2000)
+	unknown := addJobOffer(data.TargetConfig{Address: "0xunknown"}, 1000)
+	wrongProvider := addJobOffer(data.TargetConfig{Address: "0xcheap", ResourceOffer: expensive.ID}, 1000)
+
+	deals, err := GetMatchingDeals(context.Background(), db, nil, db.UpdateJobOfferState, nil, nil, nil, tracer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deals) != 1 {
+		t.Fatalf("Expected 1 deal, got %d", len(deals))
+	}
+	if deals[0].JobOffer.ID != byAddress.ID || deals[0].ResourceOffer.ID != expensive.ID {
+		t.Errorf("Expected the targeted job offer to match the expensive resource offer, got %s and %s", deals[0].JobOffer.ID, deals[0].ResourceOffer.ID)
+	}
+	cancelled := data.GetAgreementStateIndex("JobOfferCancelled")
+	if getState(unknown.ID) != cancelled {
+		t.Errorf("Expected the job offer targeting an unknown address to be cancelled")
+	}
+	if getState(wrongProvider.ID) != cancelled {
+		t.Errorf("Expected the job offer targeting another provider's offer to be cancelled")
+	}
+	// the offer failed to match so it is cancelled on the next pass
+	if getState(tooBig.ID) == cancelled {
+		t.Errorf("Expected the job offer too big for its target to wait for the next pass")
+	}
+
+	_, err = GetMatchingDeals(context.Background(), db, nil, db.UpdateJobOfferState, nil, nil, nil, tracer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if getState(tooBig.ID) != cancelled {
+		t.Errorf("Expected the job offer too big for its target to be cancelled")
+	}
+}
+
+// any pair of offers that passes the solver checks must be safe to match
+func FuzzMatchOffers(f *testing.F) {
+	f.Add([]byte(`{"spec":{"cpu":1000},"mode":"MarketPrice","trusted_parties":{"solver":"a","mediator":["b"]}}`),
+		[]byte(`{"spec":{"cpu":1000},"mode":"FixedPrice","modules":["cowsay:v0.0.4"],"trusted_parties":{"solver":"a","mediator":["b"]}}`))
+	f.Add([]byte(`{"module":{"name":"cowsay","hash":"v0.0.4"},"trusted_parties":{"solver":"a","mediator":["b"]},"offer_classes":["spot"]}`),
+		[]byte(`{"class":"spot","module_pricing":{"cowsay:v0.0.4":{"instruction_price":1}},"trusted_parties":{"solver":"a","mediator":["b"]}}`))
+	f.Fuzz(func(t *testing.T, jobPayload []byte, resourcePayload []byte) {
+		var jobOffer data.JobOffer
+		var resourceOffer data.ResourceOffer
+		if json.Unmarshal(jobPayload, &jobOffer) != nil || json.Unmarshal(resourcePayload, &resourceOffer) != nil {
+			return
+		}
+		if data.CheckJobOffer(jobOffer) != nil || data.CheckResourceOffer(resourceOffer) != nil {
+			return
+		}
+		result := matchOffers(resourceOffer, jobOffer)
+		_ = result.message()
+		_ = result.attributes()
+		_ = getPriceGap(resourceOffer, jobOffer)
+	})
+}
+
+func BenchmarkMatchOffers(b *testing.B) {
+	jobOffers, resourceOffers, err := GenerateOfferPool(BenchOptions{JobOffers: 100, ResourceOffers: 100, Modules: 10})
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		jobOffer := jobOffers[i%len(jobOffers)]
+		resourceOffer := resourceOffers[(i/len(jobOffers))%len(resourceOffers)]
+		matchOffers(resourceOffer.ResourceOffer, jobOffer.JobOffer)
+	}
+}
+
+// each pass gets a new pool so none of its pairs have been decided yet
+func TestGetMatchingDealsDefaultChain(t *testing.T) {
+	tracer := noop.NewTracerProvider().Tracer("test")
+	services := data.ServiceConfig{Solver: "oranges", Mediator: []string{"apples"}}
+
+	// one offer names the default chain and the other leaves it out
+	getDeals := func(resolveChain func(int) int) []data.Deal {
+		db, err := memorystore.NewSolverStoreMemory(memorystore.SolverStoreMemoryOptions{LogDir: t.TempDir()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		resourceOffer := data.ResourceOffer{
+			ResourceProvider: "0xrp",
+			Spec:             data.MachineSpec{CPU: 1000, RAM: 1024},
+			DefaultPricing:   data.DealPricing{InstructionPrice: 1},
+			Mode:             data.FixedPrice,
+			Services:         services,
+			ChainID:          421614,
+		}
+		resourceOffer.ID, err = data.GetResourceOfferID(resourceOffer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = db.AddResourceOffer(data.GetResourceOfferContainer(resourceOffer))
+		if err != nil {
+			t.Fatal(err)
+		}
+		jobOffer := data.JobOffer{
+			JobCreator: "0xjc",
+			Spec:       data.MachineSpec{CPU: 1000, RAM: 1024},
+			Mode:       data.MarketPrice,
+			Services:   services,
+		}
+		jobOffer.ID, err = data.GetJobOfferID(jobOffer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = db.AddJobOffer(data.GetJobOfferContainer(jobOffer))
+		if err != nil {
+			t.Fatal(err)
+		}
+		deals, err := GetMatchingDeals(context.Background(), db, nil, db.UpdateJobOfferState, nil, nil, resolveChain, tracer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return deals
+	}
+
+	// without the default chain zero is a chain of its own
+	deals := getDeals(nil)
+	if len(deals) != 0 {
+		t.Fatalf("Expected no deals, got %d", len(deals))
+	}
+
+	deals = getDeals(func(chainID int) int {
+		if chainID == 0 {
+			return 421614
+		}
+		return chainID
+	})
+	if len(deals) != 1 {
+		t.Fatalf("Expected 1 deal, got %d", len(deals))
+	}
+	if deals[0].JobOffer.ChainID != 421614 {
+		t.Errorf("Expected the deal to be on chain 421614, got %d", deals[0].JobOffer.ChainID)
+	}
+}
+
+func BenchmarkGetMatchingDeals(b *testing.B) {
+	// every pair is logged at trace level
+	level := zerolog.GlobalLevel()
+	zerolog.SetGlobalLevel(zerolog.WarnLevel)
+	defer zerolog.SetGlobalLevel(level)
+	for _, size := range []struct{ jobOffers, resourceOffers int }{{10, 10}, {100, 100}, {500, 100}} {
+		b.Run(fmt.Sprintf("%dx%d", size.jobOffers, size.resourceOffers), func(b *testing.B) {
+			db, err := memorystore.NewSolverStoreMemory(memorystore.SolverStoreMemoryOptions{LogDir: b.TempDir()})
+			if err != nil {
+				b.Fatal(err)
+			}
+			tracer := noop.NewTracerProvider().Tracer("bench")
+			writes := 0
+			deals := 0
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				jobOffers, resourceOffers, err := GenerateOfferPool(BenchOptions{
+					JobOffers:      size.jobOffers,
+					ResourceOffers: size.resourceOffers,
+					Modules:        10,
+					Seed:           int64(i),
+				})
+				if err != nil {
+					b.Fatal(err)
+				}
+				for _, jobOffer := range jobOffers {
+					_, err = db.AddJobOffer(jobOffer)
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+				for _, resourceOffer := range resourceOffers {
+					_, err = db.AddResourceOffer(resourceOffer)
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+				before, err := db.GetStats()
+				if err != nil {
+					b.Fatal(err)
+				}
+				b.StartTimer()
+
+				found, err := GetMatchingDeals(context.Background(), db, nil, db.UpdateJobOfferState, nil, nil, nil, tracer)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				b.StopTimer()
+				after, err := db.GetStats()
+				if err != nil {
+					b.Fatal(err)
+				}
+				writes += after.Counts["decisions"] - before.Counts["decisions"]
+				deals += len(found)
+				for _, jobOffer := range jobOffers {
+					err = db.RemoveJobOffer(jobOffer.ID)
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+				for _, resourceOffer := range resourceOffers {
+					err = db.RemoveResourceOffer(resourceOffer.ID)
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.StartTimer()
+			}
+			if deals > 0 {
+				b.ReportMetric(float64(writes)/float64(deals), "decisions/deal")
+			}
+			b.ReportMetric(float64(size.jobOffers*size.resourceOffers*b.N)/b.Elapsed().Seconds(), "pairs/s")
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package solver
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+)
+
+// the weight a mediator with no history starts with so that
+// new mediators still get picked under the reputation policy
+const MEDIATOR_REPUTATION_BASE = 100
+
+// what the matcher needs to choose between the mutual mediators of a deal
+// the weights are recorded on the deal so it does not matter that
+// balances and reputations change afterwards
+func (controller *SolverController) getMediatorPolicy(jobOffer data.JobOffer, resourceOffer data.ResourceOffer, mediators []string) (data.MediatorPolicy, error) {
+	policy := data.MediatorPolicy{
+		Name:          controller.options.Matching.MediatorPolicy,
+		Weights:       map[string]*big.Int{},
+		Relations:     controller.getMediatorRelations(jobOffer, resourceOffer, mediators),
+		RefuseRelated: controller.options.Collusion.Policy == data.COLLUSION_POLICY_REFUSE,
+	}
+	switch policy.Name {
+	case data.MEDIATOR_POLICY_STAKE:
+		chainSDK, err := controller.chains.Get(jobOffer.ChainID)
+		if err != nil {
+			return policy, err
+		}
+		for _, mediator := range mediators {
+			balance, err := chainSDK.GetLPBalance(mediator)
+			if err != nil {
+				return policy, err
+			}
+			policy.Weights[mediator] = balance
+		}
+	case data.MEDIATOR_POLICY_REPUTATION:
+		for _, mediator := range mediators {
+			weight, err := controller.getMediatorReputation(mediator)
+			if err != nil {
+				return policy, err
+			}
+			policy.Weights[mediator] = weight
+		}
+	case data.MEDIATOR_POLICY_ROUND_ROBIN:
+		controller.mediatorRoundRobinMutex.Lock()
+		defer controller.mediatorRoundRobinMutex.Unlock()
+		policy.Offset = controller.mediatorRoundRobin
+		controller.mediatorRoundRobin++
+	}
+	return policy, nil
+}
+
+// mediators that see their mediations through gain weight and
+// mediators that leave deals to time out lose it
+func (controller *SolverController) getMediatorReputation(mediator string) (*big.Int, error) {
+	deals, err := controller.store.GetDeals(store.GetDealsQuery{
+		// the mediation contract gives us checksummed addresses
+		Mediator: common.HexToAddress(mediator).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	mediated := int64(0)
+	timedOut := int64(0)
+	for _, deal := range deals {
+		switch data.DealState(deal.State) {
+		case data.MediationAccepted, data.MediationRejected:
+			mediated++
+		case data.TimeoutMediateResults:
+			timedOut++
+		}
+	}
+	weight := (mediated + 1) * MEDIATOR_REPUTATION_BASE / (timedOut + 1)
+	return big.NewInt(weight), nil
+}
+
+// verdicts are only taken from the quorum the solver put on the deal
+// and only while the deal is waiting on mediation
+func (controller *SolverController) addMediationVerdict(deal data.DealContainer, verdict data.MediationVerdict) (*data.DealContainer, error) {
+	if deal.Deal.JobOffer.MediatorQuorum == nil {
+		return nil, data.NewError(data.ErrConflict, "deal %s is mediated by a single mediator", deal.ID)
+	}
+	if !data.IsDealMediator(deal, verdict.Mediator) {
+		return nil, data.NewError(data.ErrForbidden, "%s is not a mediator for deal %s", verdict.Mediator, deal.ID)
+	}
+	if data.DealState(deal.State) != data.ResultsChecked {
+		return nil, data.NewError(data.ErrConflict, "deal %s is %s and is not being mediated", deal.ID, data.GetAgreementStateString(deal.State))
+	}
+	// mediators from before error codes only say whether they accepted
+	if !verdict.Accept && verdict.Code == "" {
+		verdict.Code = data.ErrResultMismatch
+	}
+	if verdict.Accept {
+		verdict.Code = ""
+	}
+	verdict.DealID = deal.ID
+	verdict.CreatedAt = time.Now().Unix()
+	controller.log.Info("add mediation verdict", fmt.Sprintf("%s %s accept=%t", deal.ID, verdict.Mediator, verdict.Accept))
+	dealContainer, err := controller.store.AddMediationVerdict(deal.ID, verdict)
+	if err != nil {
+		return nil, err
+	}
+	controller.writeEvent(SolverEvent{
+		EventType: MediationVerdictAdded,
+		Deal:      dealContainer,
+	})
+	return dealContainer, nil
+}
@@ -0,0 +1,183 @@
+package solver
+
+import (
+	"fmt"
+	"math/big"
+	corehttp "net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/http"
+	"github.com/lilypad-tech/lilypad/pkg/web3"
+	"github.com/rs/zerolog/log"
+)
+
+// each checkpoint of a deal paid in milestones is put to the job creator
+// as the next milestone until only the share paid with the result is left
+func (controller *SolverController) addCheckpointMilestone(deal data.DealContainer, cid string) (*data.DealContainer, error) {
+	controller.milestoneMutex.Lock()
+	defer controller.milestoneMutex.Unlock()
+	milestone := data.GetCheckpointMilestone(deal, cid, time.Now().Unix())
+	if milestone == nil {
+		return &deal, nil
+	}
+	return controller.updateDealMilestone(*milestone)
+}
+
+// the job creator accepts the checkpoint of a milestone and we pay the
+// resource provider on chain, a milestone that failed to pay can be accepted again
+// the signature is the job creator's over the milestone hash, without it
+// the controller contract will not let us pay out of their collateral
+func (controller *SolverController) acceptDealMilestone(deal data.DealContainer, index uint64, signature string) (*data.DealContainer, error) {
+	if deal.State != data.GetAgreementStateIndex("DealAgreed") {
+		return nil, fmt.Errorf("deal %s is %s so its milestones can no longer be paid", deal.ID, data.GetAgreementStateString(deal.State))
+	}
+	controller.milestoneMutex.Lock()
+	defer controller.milestoneMutex.Unlock()
+	// the deal we were handed may be older than the milestone
+	current, err := controller.store.GetDeal(deal.ID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, fmt.Errorf("deal not found")
+	}
+	milestone := data.GetDealMilestone(*current, index)
+	if milestone == nil {
+		return nil, fmt.Errorf("deal %s has no milestone %d", deal.ID, index)
+	}
+	if milestone.State != data.DealMilestoneSubmitted && milestone.State != data.DealMilestoneFailed {
+		return nil, fmt.Errorf("milestone %d of deal %s is %s", index, deal.ID, milestone.State)
+	}
+	err = controller.checkMilestoneSignature(*current, *milestone, signature)
+	if err != nil {
+		return nil, err
+	}
+	milestone.Signature = signature
+	milestone.State = data.DealMilestoneAccepted
+	milestone.Error = ""
+	milestone.UpdatedAt = time.Now().Unix()
+	dealContainer, err := controller.updateDealMilestone(*milestone)
+	if err != nil {
+		return nil, err
+	}
+	go controller.payDealMilestone(*dealContainer, *milestone)
+	return dealContainer, nil
+}
+
+// check the job creator signed the milestone before we send it
+// a bad signature would only be turned down by the contract
+func (controller *SolverController) checkMilestoneSignature(deal data.DealContainer, milestone data.DealMilestone, signature string) error {
+	chainSDK, err := controller.chains.Get(deal.ChainID)
+	if err != nil {
+		return err
+	}
+	hash, err := chainSDK.GetMilestoneHash(milestone)
+	if err != nil {
+		return err
+	}
+	signer, err := web3.RecoverMilestoneSigner(hash, signature)
+	if err != nil {
+		return err
+	}
+	if signer != common.HexToAddress(deal.JobCreator) {
+		return fmt.Errorf("milestone %d of deal %s was signed by %s not the job creator", milestone.Index, deal.ID, signer.Hex())
+	}
+	return nil
+}
+
+func (controller *SolverController) payDealMilestone(deal data.DealContainer, milestone data.DealMilestone) {
+	controller.log.Info("pay milestone", fmt.Sprintf("%s %d %s", deal.ID, milestone.Index, milestone.Amount))
+	txHash, err := controller.sendMilestonePayment(deal, milestone)
+	milestone.UpdatedAt = time.Now().Unix()
+	if err != nil {
+		controller.log.Error(fmt.Sprintf("error paying milestone %d of deal %s", milestone.Index, deal.ID), err)
+		milestone.State = data.DealMilestoneFailed
+		milestone.Error = err.Error()
+	} else {
+		milestone.State = data.DealMilestonePaid
+		milestone.TransactionHash = txHash
+	}
+	controller.milestoneMutex.Lock()
+	defer controller.milestoneMutex.Unlock()
+	_, err = controller.updateDealMilestone(milestone)
+	if err != nil {
+		controller.log.Error("error saving milestone", err)
+	}
+}
+
+func (controller *SolverController) sendMilestonePayment(deal data.DealContainer, milestone data.DealMilestone) (string, error) {
+	amount, ok := new(big.Int).SetString(milestone.Amount, 10) //nolint:gomnd
+	if !ok {
+		return "", fmt.Errorf("milestone amount %q is not a number", milestone.Amount)
+	}
+	chainSDK, err := controller.chains.Get(deal.ChainID)
+	if err != nil {
+		return "", err
+	}
+	return chainSDK.PayMilestone(deal.ID, milestone.Index, amount, milestone.Signature)
+}
+
+func (controller *SolverController) updateDealMilestone(milestone data.DealMilestone) (*data.DealContainer, error) {
+	dealContainer, err := controller.store.UpdateDealMilestone(milestone.DealID, milestone)
+	if err != nil {
+		return nil, err
+	}
+	controller.writeEvent(SolverEvent{
+		EventType: DealMilestoneUpdated,
+		Deal:      dealContainer,
+	})
+	return dealContainer, nil
+}
+
+// only the job of an agreed deal is running and so has checkpoints
+func (controller *SolverController) updateDealCheckpoint(deal data.DealContainer, cid string) (*data.DealContainer, error) {
+	if cid == "" {
+		return nil, fmt.Errorf("checkpoint for deal %s has no CID", deal.ID)
+	}
+	if deal.State != data.GetAgreementStateIndex("DealAgreed") {
+		return nil, fmt.Errorf("deal %s is %s so its job is not running", deal.ID, data.GetAgreementStateString(deal.State))
+	}
+	controller.log.Info("update checkpoint", fmt.Sprintf("%s %s", deal.ID, cid))
+	dealContainer, err := controller.store.UpdateDealCheckpoint(deal.ID, data.DealCheckpoint{
+		DealID:    deal.ID,
+		CID:       cid,
+		CreatedAt: time.Now().Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	controller.writeEvent(SolverEvent{
+		EventType: DealCheckpointUpdated,
+		Deal:      dealContainer,
+	})
+	if deal.Deal.JobOffer.Milestones > 0 {
+		return controller.addCheckpointMilestone(*dealContainer, cid)
+	}
+	return dealContainer, nil
+}
+
+func (solverServer *solverServer) acceptDealMilestone(acceptance data.DealMilestoneAcceptance, res corehttp.ResponseWriter, req *corehttp.Request) (*data.DealContainer, error) {
+	deal, err := solverServer.getDealOr404(req)
+	if err != nil {
+		return nil, err
+	}
+	signerAddress, err := solverServer.signatures.Check(req)
+	if err != nil {
+		log.Error().Err(err).Msgf("have error parsing user address")
+		return nil, data.WrapError(data.ErrUnauthorized, err)
+	}
+	// the milestones are paid out of the job creator's collateral
+	if signerAddress != deal.JobCreator {
+		return nil, data.NewError(data.ErrForbidden, "job creator address does not match signer address")
+	}
+	dealContainer, err := solverServer.controller.acceptDealMilestone(*deal, acceptance.Index, acceptance.Signature)
+	if err != nil {
+		return nil, http.HTTPError{
+			Message:    err.Error(),
+			StatusCode: corehttp.StatusBadRequest,
+		}
+	}
+	return dealContainer, nil
+}
@@ -0,0 +1,1074 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: client.go
+//
+// Generated by this command:
+//
+//	mockgen -source=client.go -destination=mock_solver/mock_solver.go -package=mock_solver
+//
+
+// Package mock_solver is a generated GoMock package.
+package mock_solver
+
+import (
+	context "context"
+	reflect "reflect"
+
+	data "github.com/lilypad-tech/lilypad/pkg/data"
+	solver "github.com/lilypad-tech/lilypad/pkg/solver"
+	stats "github.com/lilypad-tech/lilypad/pkg/solver/stats"
+	store "github.com/lilypad-tech/lilypad/pkg/solver/store"
+	system "github.com/lilypad-tech/lilypad/pkg/system"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSolverAPI is a mock of SolverAPI interface.
+type MockSolverAPI struct {
+	ctrl     *gomock.Controller
+	recorder *MockSolverAPIMockRecorder
+}
+
+// MockSolverAPIMockRecorder is the mock recorder for MockSolverAPI.
+type MockSolverAPIMockRecorder struct {
+	mock *MockSolverAPI
+}
+
+// NewMockSolverAPI creates a new mock instance.
+func NewMockSolverAPI(ctrl *gomock.Controller) *MockSolverAPI {
+	mock := &MockSolverAPI{ctrl: ctrl}
+	mock.recorder = &MockSolverAPIMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSolverAPI) EXPECT() *MockSolverAPIMockRecorder {
+	return m.recorder
+}
+
+// AcceptDealMilestone mocks base method.
+func (m *MockSolverAPI) AcceptDealMilestone(id string, index uint64, signature string) (data.DealContainer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptDealMilestone", id, index, signature)
+	ret0, _ := ret[0].(data.DealContainer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptDealMilestone indicates an expected call of AcceptDealMilestone.
+func (mr *MockSolverAPIMockRecorder) AcceptDealMilestone(id, index, signature any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptDealMilestone", reflect.TypeOf((*MockSolverAPI)(nil).AcceptDealMilestone), id, index, signature)
+}
+
+// AddCapacityReservation mocks base method.
+func (m *MockSolverAPI) AddCapacityReservation(submission data.CapacityReservationSubmission) (data.CapacityReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCapacityReservation", submission)
+	ret0, _ := ret[0].(data.CapacityReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCapacityReservation indicates an expected call of AddCapacityReservation.
+func (mr *MockSolverAPIMockRecorder) AddCapacityReservation(submission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCapacityReservation", reflect.TypeOf((*MockSolverAPI)(nil).AddCapacityReservation), submission)
+}
+
+// AddDealEncryptedInputs mocks base method.
+func (m *MockSolverAPI) AddDealEncryptedInputs(id string, encrypted data.DealEncryptedInputs) (data.DealContainer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDealEncryptedInputs", id, encrypted)
+	ret0, _ := ret[0].(data.DealContainer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddDealEncryptedInputs indicates an expected call of AddDealEncryptedInputs.
+func (mr *MockSolverAPIMockRecorder) AddDealEncryptedInputs(id, encrypted any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDealEncryptedInputs", reflect.TypeOf((*MockSolverAPI)(nil).AddDealEncryptedInputs), id, encrypted)
+}
+
+// AddDealLogs mocks base method.
+func (m *MockSolverAPI) AddDealLogs(id string, chunks []data.DealLogChunk) ([]data.DealLogChunk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDealLogs", id, chunks)
+	ret0, _ := ret[0].([]data.DealLogChunk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddDealLogs indicates an expected call of AddDealLogs.
+func (mr *MockSolverAPIMockRecorder) AddDealLogs(id, chunks any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDealLogs", reflect.TypeOf((*MockSolverAPI)(nil).AddDealLogs), id, chunks)
+}
+
+// AddInputStaging mocks base method.
+func (m *MockSolverAPI) AddInputStaging(request data.InputStagingRequest) (data.InputStaging, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddInputStaging", request)
+	ret0, _ := ret[0].(data.InputStaging)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddInputStaging indicates an expected call of AddInputStaging.
+func (mr *MockSolverAPIMockRecorder) AddInputStaging(request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddInputStaging", reflect.TypeOf((*MockSolverAPI)(nil).AddInputStaging), request)
+}
+
+// AddJobGroup mocks base method.
+func (m *MockSolverAPI) AddJobGroup(submission data.JobGroupSubmission) (data.JobGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddJobGroup", submission)
+	ret0, _ := ret[0].(data.JobGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddJobGroup indicates an expected call of AddJobGroup.
+func (mr *MockSolverAPIMockRecorder) AddJobGroup(submission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddJobGroup", reflect.TypeOf((*MockSolverAPI)(nil).AddJobGroup), submission)
+}
+
+// AddJobOffer mocks base method.
+func (m *MockSolverAPI) AddJobOffer(jobOffer data.JobOffer) (data.JobOfferContainer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddJobOffer", jobOffer)
+	ret0, _ := ret[0].(data.JobOfferContainer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddJobOffer indicates an expected call of AddJobOffer.
+func (mr *MockSolverAPIMockRecorder) AddJobOffer(jobOffer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddJobOffer", reflect.TypeOf((*MockSolverAPI)(nil).AddJobOffer), jobOffer)
+}
+
+// AddJobSchedule mocks base method.
+func (m *MockSolverAPI) AddJobSchedule(submission data.JobScheduleSubmission) (data.JobSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddJobSchedule", submission)
+	ret0, _ := ret[0].(data.JobSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddJobSchedule indicates an expected call of AddJobSchedule.
+func (mr *MockSolverAPIMockRecorder) AddJobSchedule(submission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddJobSchedule", reflect.TypeOf((*MockSolverAPI)(nil).AddJobSchedule), submission)
+}
+
+// AddMediationVerdict mocks base method.
+func (m *MockSolverAPI) AddMediationVerdict(id string, verdict data.MediationVerdict) (data.DealContainer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMediationVerdict", id, verdict)
+	ret0, _ := ret[0].(data.DealContainer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMediationVerdict indicates an expected call of AddMediationVerdict.
+func (mr *MockSolverAPIMockRecorder) AddMediationVerdict(id, verdict any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMediationVerdict", reflect.TypeOf((*MockSolverAPI)(nil).AddMediationVerdict), id, verdict)
+}
+
+// AddResourceOffer mocks base method.
+func (m *MockSolverAPI) AddResourceOffer(resourceOffer data.ResourceOffer) (data.ResourceOfferContainer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddResourceOffer", resourceOffer)
+	ret0, _ := ret[0].(data.ResourceOfferContainer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddResourceOffer indicates an expected call of AddResourceOffer.
+func (mr *MockSolverAPIMockRecorder) AddResourceOffer(resourceOffer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddResourceOffer", reflect.TypeOf((*MockSolverAPI)(nil).AddResourceOffer), resourceOffer)
+}
+
+// AddResult mocks base method.
+func (m *MockSolverAPI) AddResult(result data.Result) (data.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddResult", result)
+	ret0, _ := ret[0].(data.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddResult indicates an expected call of AddResult.
+func (mr *MockSolverAPIMockRecorder) AddResult(result any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddResult", reflect.TypeOf((*MockSolverAPI)(nil).AddResult), result)
+}
+
+// AddServiceResponse mocks base method.
+func (m *MockSolverAPI) AddServiceResponse(id string, response data.ServiceResponse) (data.DealContainer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddServiceResponse", id, response)
+	ret0, _ := ret[0].(data.DealContainer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddServiceResponse indicates an expected call of AddServiceResponse.
+func (mr *MockSolverAPIMockRecorder) AddServiceResponse(id, response any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddServiceResponse", reflect.TypeOf((*MockSolverAPI)(nil).AddServiceResponse), id, response)
+}
+
+// AddWorkflow mocks base method.
+func (m *MockSolverAPI) AddWorkflow(submission data.WorkflowSubmission) (data.Workflow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWorkflow", submission)
+	ret0, _ := ret[0].(data.Workflow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWorkflow indicates an expected call of AddWorkflow.
+func (mr *MockSolverAPIMockRecorder) AddWorkflow(submission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWorkflow", reflect.TypeOf((*MockSolverAPI)(nil).AddWorkflow), submission)
+}
+
+// BanAddress mocks base method.
+func (m *MockSolverAPI) BanAddress(submission data.BanSubmission) (data.BannedAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BanAddress", submission)
+	ret0, _ := ret[0].(data.BannedAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BanAddress indicates an expected call of BanAddress.
+func (mr *MockSolverAPIMockRecorder) BanAddress(submission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanAddress", reflect.TypeOf((*MockSolverAPI)(nil).BanAddress), submission)
+}
+
+// CancelCapacityReservation mocks base method.
+func (m *MockSolverAPI) CancelCapacityReservation(id string) (data.CapacityReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelCapacityReservation", id)
+	ret0, _ := ret[0].(data.CapacityReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelCapacityReservation indicates an expected call of CancelCapacityReservation.
+func (mr *MockSolverAPIMockRecorder) CancelCapacityReservation(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelCapacityReservation", reflect.TypeOf((*MockSolverAPI)(nil).CancelCapacityReservation), id)
+}
+
+// CancelJobOffer mocks base method.
+func (m *MockSolverAPI) CancelJobOffer(id string) (data.JobOfferContainer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelJobOffer", id)
+	ret0, _ := ret[0].(data.JobOfferContainer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelJobOffer indicates an expected call of CancelJobOffer.
+func (mr *MockSolverAPIMockRecorder) CancelJobOffer(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelJobOffer", reflect.TypeOf((*MockSolverAPI)(nil).CancelJobOffer), id)
+}
+
+// CancelWorkflow mocks base method.
+func (m *MockSolverAPI) CancelWorkflow(id string) (data.Workflow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelWorkflow", id)
+	ret0, _ := ret[0].(data.Workflow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelWorkflow indicates an expected call of CancelWorkflow.
+func (mr *MockSolverAPIMockRecorder) CancelWorkflow(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelWorkflow", reflect.TypeOf((*MockSolverAPI)(nil).CancelWorkflow), id)
+}
+
+// DownloadResultFiles mocks base method.
+func (m *MockSolverAPI) DownloadResultFiles(id, localPath string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DownloadResultFiles", id, localPath)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DownloadResultFiles indicates an expected call of DownloadResultFiles.
+func (mr *MockSolverAPIMockRecorder) DownloadResultFiles(id, localPath any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DownloadResultFiles", reflect.TypeOf((*MockSolverAPI)(nil).DownloadResultFiles), id, localPath)
+}
+
+// ExpireJobOffer mocks base method.
+func (m *MockSolverAPI) ExpireJobOffer(id, reason string) (data.JobOfferContainer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireJobOffer", id, reason)
+	ret0, _ := ret[0].(data.JobOfferContainer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireJobOffer indicates an expected call of ExpireJobOffer.
+func (mr *MockSolverAPIMockRecorder) ExpireJobOffer(id, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireJobOffer", reflect.TypeOf((*MockSolverAPI)(nil).ExpireJobOffer), id, reason)
+}
+
+// ExpireResourceOffer mocks base method.
+func (m *MockSolverAPI) ExpireResourceOffer(id, reason string) (data.ResourceOfferContainer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireResourceOffer", id, reason)
+	ret0, _ := ret[0].(data.ResourceOfferContainer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireResourceOffer indicates an expected call of ExpireResourceOffer.
+func (mr *MockSolverAPIMockRecorder) ExpireResourceOffer(id, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireResourceOffer", reflect.TypeOf((*MockSolverAPI)(nil).ExpireResourceOffer), id, reason)
+}
+
+// ExportState mocks base method.
+func (m *MockSolverAPI) ExportState(localPath string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportState", localPath)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportState indicates an expected call of ExportState.
+func (mr *MockSolverAPIMockRecorder) ExportState(localPath any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportState", reflect.TypeOf((*MockSolverAPI)(nil).ExportState), localPath)
+}
+
+// GetAdminActions mocks base method.
+func (m *MockSolverAPI) GetAdminActions(query store.GetAdminActionsQuery) ([]data.AdminAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdminActions", query)
+	ret0, _ := ret[0].([]data.AdminAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdminActions indicates an expected call of GetAdminActions.
+func (mr *MockSolverAPIMockRecorder) GetAdminActions(query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminActions", reflect.TypeOf((*MockSolverAPI)(nil).GetAdminActions), query)
+}
+
+// GetAdminStats mocks base method.
+func (m *MockSolverAPI) GetAdminStats() (store.StoreStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdminStats")
+	ret0, _ := ret[0].(store.StoreStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdminStats indicates an expected call of GetAdminStats.
+func (mr *MockSolverAPIMockRecorder) GetAdminStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdminStats", reflect.TypeOf((*MockSolverAPI)(nil).GetAdminStats))
+}
+
+// GetAudit mocks base method.
+func (m *MockSolverAPI) GetAudit(id string) (data.Audit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAudit", id)
+	ret0, _ := ret[0].(data.Audit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAudit indicates an expected call of GetAudit.
+func (mr *MockSolverAPIMockRecorder) GetAudit(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAudit", reflect.TypeOf((*MockSolverAPI)(nil).GetAudit), id)
+}
+
+// GetBannedAddresses mocks base method.
+func (m *MockSolverAPI) GetBannedAddresses() ([]data.BannedAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBannedAddresses")
+	ret0, _ := ret[0].([]data.BannedAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBannedAddresses indicates an expected call of GetBannedAddresses.
+func (mr *MockSolverAPIMockRecorder) GetBannedAddresses() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBannedAddresses", reflect.TypeOf((*MockSolverAPI)(nil).GetBannedAddresses))
+}
+
+// GetBillingRecords mocks base method.
+func (m *MockSolverAPI) GetBillingRecords(query store.GetBillingRecordsQuery) ([]data.BillingRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBillingRecords", query)
+	ret0, _ := ret[0].([]data.BillingRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBillingRecords indicates an expected call of GetBillingRecords.
+func (mr *MockSolverAPIMockRecorder) GetBillingRecords(query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBillingRecords", reflect.TypeOf((*MockSolverAPI)(nil).GetBillingRecords), query)
+}
+
+// GetBudget mocks base method.
+func (m *MockSolverAPI) GetBudget(jobCreator string) (data.BudgetStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBudget", jobCreator)
+	ret0, _ := ret[0].(data.BudgetStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBudget indicates an expected call of GetBudget.
+func (mr *MockSolverAPIMockRecorder) GetBudget(jobCreator any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBudget", reflect.TypeOf((*MockSolverAPI)(nil).GetBudget), jobCreator)
+}
+
+// GetCapacityReservation mocks base method.
+func (m *MockSolverAPI) GetCapacityReservation(id string) (data.CapacityReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCapacityReservation", id)
+	ret0, _ := ret[0].(data.CapacityReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCapacityReservation indicates an expected call of GetCapacityReservation.
+func (mr *MockSolverAPIMockRecorder) GetCapacityReservation(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCapacityReservation", reflect.TypeOf((*MockSolverAPI)(nil).GetCapacityReservation), id)
+}
+
+// GetCapacityReservations mocks base method.
+func (m *MockSolverAPI) GetCapacityReservations(query store.GetCapacityReservationsQuery) ([]data.CapacityReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCapacityReservations", query)
+	ret0, _ := ret[0].([]data.CapacityReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCapacityReservations indicates an expected call of GetCapacityReservations.
+func (mr *MockSolverAPIMockRecorder) GetCapacityReservations(query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCapacityReservations", reflect.TypeOf((*MockSolverAPI)(nil).GetCapacityReservations), query)
+}
+
+// GetClientRecords mocks base method.
+func (m *MockSolverAPI) GetClientRecords(query store.GetClientRecordsQuery) ([]data.ClientRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetClientRecords", query)
+	ret0, _ := ret[0].([]data.ClientRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetClientRecords indicates an expected call of GetClientRecords.
+func (mr *MockSolverAPIMockRecorder) GetClientRecords(query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClientRecords", reflect.TypeOf((*MockSolverAPI)(nil).GetClientRecords), query)
+}
+
+// GetCostEstimate mocks base method.
+func (m *MockSolverAPI) GetCostEstimate(query data.CostEstimateQuery) (data.CostEstimate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCostEstimate", query)
+	ret0, _ := ret[0].(data.CostEstimate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCostEstimate indicates an expected call of GetCostEstimate.
+func (mr *MockSolverAPIMockRecorder) GetCostEstimate(query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCostEstimate", reflect.TypeOf((*MockSolverAPI)(nil).GetCostEstimate), query)
+}
+
+// GetDeal mocks base method.
+func (m *MockSolverAPI) GetDeal(id string) (data.DealContainer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeal", id)
+	ret0, _ := ret[0].(data.DealContainer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeal indicates an expected call of GetDeal.
+func (mr *MockSolverAPIMockRecorder) GetDeal(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeal", reflect.TypeOf((*MockSolverAPI)(nil).GetDeal), id)
+}
+
+// GetDealLineage mocks base method.
+func (m *MockSolverAPI) GetDealLineage(id string) (data.DealLineage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDealLineage", id)
+	ret0, _ := ret[0].(data.DealLineage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDealLineage indicates an expected call of GetDealLineage.
+func (mr *MockSolverAPIMockRecorder) GetDealLineage(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDealLineage", reflect.TypeOf((*MockSolverAPI)(nil).GetDealLineage), id)
+}
+
+// GetDealLogs mocks base method.
+func (m *MockSolverAPI) GetDealLogs(id string, after uint64) ([]data.DealLogChunk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDealLogs", id, after)
+	ret0, _ := ret[0].([]data.DealLogChunk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDealLogs indicates an expected call of GetDealLogs.
+func (mr *MockSolverAPIMockRecorder) GetDealLogs(id, after any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDealLogs", reflect.TypeOf((*MockSolverAPI)(nil).GetDealLogs), id, after)
+}
+
+// GetDeals mocks base method.
+func (m *MockSolverAPI) GetDeals(query store.GetDealsQuery) ([]data.DealContainer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeals", query)
+	ret0, _ := ret[0].([]data.DealContainer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeals indicates an expected call of GetDeals.
+func (mr *MockSolverAPIMockRecorder) GetDeals(query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeals", reflect.TypeOf((*MockSolverAPI)(nil).GetDeals), query)
+}
+
+// GetDealsWithFilter mocks base method.
+func (m *MockSolverAPI) GetDealsWithFilter(query store.GetDealsQuery, filter func(data.DealContainer) bool) ([]data.DealContainer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDealsWithFilter", query, filter)
+	ret0, _ := ret[0].([]data.DealContainer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDealsWithFilter indicates an expected call of GetDealsWithFilter.
+func (mr *MockSolverAPIMockRecorder) GetDealsWithFilter(query, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDealsWithFilter", reflect.TypeOf((*MockSolverAPI)(nil).GetDealsWithFilter), query, filter)
+}
+
+// GetInputStaging mocks base method.
+func (m *MockSolverAPI) GetInputStaging(id string) (data.InputStaging, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInputStaging", id)
+	ret0, _ := ret[0].(data.InputStaging)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInputStaging indicates an expected call of GetInputStaging.
+func (mr *MockSolverAPIMockRecorder) GetInputStaging(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInputStaging", reflect.TypeOf((*MockSolverAPI)(nil).GetInputStaging), id)
+}
+
+// GetInputStagings mocks base method.
+func (m *MockSolverAPI) GetInputStagings(query store.GetInputStagingsQuery) ([]data.InputStaging, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInputStagings", query)
+	ret0, _ := ret[0].([]data.InputStaging)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInputStagings indicates an expected call of GetInputStagings.
+func (mr *MockSolverAPIMockRecorder) GetInputStagings(query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInputStagings", reflect.TypeOf((*MockSolverAPI)(nil).GetInputStagings), query)
+}
+
+// GetJobGroup mocks base method.
+func (m *MockSolverAPI) GetJobGroup(id string) (data.JobGroupStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobGroup", id)
+	ret0, _ := ret[0].(data.JobGroupStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobGroup indicates an expected call of GetJobGroup.
+func (mr *MockSolverAPIMockRecorder) GetJobGroup(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobGroup", reflect.TypeOf((*MockSolverAPI)(nil).GetJobGroup), id)
+}
+
+// GetJobOffers mocks base method.
+func (m *MockSolverAPI) GetJobOffers(query store.GetJobOffersQuery) ([]data.JobOfferContainer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobOffers", query)
+	ret0, _ := ret[0].([]data.JobOfferContainer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobOffers indicates an expected call of GetJobOffers.
+func (mr *MockSolverAPIMockRecorder) GetJobOffers(query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobOffers", reflect.TypeOf((*MockSolverAPI)(nil).GetJobOffers), query)
+}
+
+// GetJobSchedule mocks base method.
+func (m *MockSolverAPI) GetJobSchedule(id string) (data.JobSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobSchedule", id)
+	ret0, _ := ret[0].(data.JobSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobSchedule indicates an expected call of GetJobSchedule.
+func (mr *MockSolverAPIMockRecorder) GetJobSchedule(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobSchedule", reflect.TypeOf((*MockSolverAPI)(nil).GetJobSchedule), id)
+}
+
+// GetJobSchedules mocks base method.
+func (m *MockSolverAPI) GetJobSchedules(query store.GetJobSchedulesQuery) ([]data.JobSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobSchedules", query)
+	ret0, _ := ret[0].([]data.JobSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobSchedules indicates an expected call of GetJobSchedules.
+func (mr *MockSolverAPIMockRecorder) GetJobSchedules(query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobSchedules", reflect.TypeOf((*MockSolverAPI)(nil).GetJobSchedules), query)
+}
+
+// GetReputation mocks base method.
+func (m *MockSolverAPI) GetReputation(resourceProvider string) (data.ResourceProviderReputation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReputation", resourceProvider)
+	ret0, _ := ret[0].(data.ResourceProviderReputation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReputation indicates an expected call of GetReputation.
+func (mr *MockSolverAPIMockRecorder) GetReputation(resourceProvider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReputation", reflect.TypeOf((*MockSolverAPI)(nil).GetReputation), resourceProvider)
+}
+
+// GetResourceOffers mocks base method.
+func (m *MockSolverAPI) GetResourceOffers(query store.GetResourceOffersQuery) ([]data.ResourceOfferContainer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResourceOffers", query)
+	ret0, _ := ret[0].([]data.ResourceOfferContainer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResourceOffers indicates an expected call of GetResourceOffers.
+func (mr *MockSolverAPIMockRecorder) GetResourceOffers(query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResourceOffers", reflect.TypeOf((*MockSolverAPI)(nil).GetResourceOffers), query)
+}
+
+// GetResult mocks base method.
+func (m *MockSolverAPI) GetResult(id string) (data.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetResult", id)
+	ret0, _ := ret[0].(data.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetResult indicates an expected call of GetResult.
+func (mr *MockSolverAPIMockRecorder) GetResult(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetResult", reflect.TypeOf((*MockSolverAPI)(nil).GetResult), id)
+}
+
+// GetServiceRequests mocks base method.
+func (m *MockSolverAPI) GetServiceRequests(id string) ([]data.ServiceRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceRequests", id)
+	ret0, _ := ret[0].([]data.ServiceRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceRequests indicates an expected call of GetServiceRequests.
+func (mr *MockSolverAPIMockRecorder) GetServiceRequests(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceRequests", reflect.TypeOf((*MockSolverAPI)(nil).GetServiceRequests), id)
+}
+
+// GetSlashings mocks base method.
+func (m *MockSolverAPI) GetSlashings(query store.GetSlashingsQuery) ([]data.EscrowPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSlashings", query)
+	ret0, _ := ret[0].([]data.EscrowPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSlashings indicates an expected call of GetSlashings.
+func (mr *MockSolverAPIMockRecorder) GetSlashings(query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlashings", reflect.TypeOf((*MockSolverAPI)(nil).GetSlashings), query)
+}
+
+// GetStats mocks base method.
+func (m *MockSolverAPI) GetStats() (stats.NetworkStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats")
+	ret0, _ := ret[0].(stats.NetworkStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockSolverAPIMockRecorder) GetStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockSolverAPI)(nil).GetStats))
+}
+
+// GetWorkflow mocks base method.
+func (m *MockSolverAPI) GetWorkflow(id string) (data.Workflow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkflow", id)
+	ret0, _ := ret[0].(data.Workflow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkflow indicates an expected call of GetWorkflow.
+func (mr *MockSolverAPIMockRecorder) GetWorkflow(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkflow", reflect.TypeOf((*MockSolverAPI)(nil).GetWorkflow), id)
+}
+
+// GetWorkflows mocks base method.
+func (m *MockSolverAPI) GetWorkflows(query store.GetWorkflowsQuery) ([]data.Workflow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkflows", query)
+	ret0, _ := ret[0].([]data.Workflow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkflows indicates an expected call of GetWorkflows.
+func (mr *MockSolverAPIMockRecorder) GetWorkflows(query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkflows", reflect.TypeOf((*MockSolverAPI)(nil).GetWorkflows), query)
+}
+
+// ImportState mocks base method.
+func (m *MockSolverAPI) ImportState(localPath string) (data.AdminAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportState", localPath)
+	ret0, _ := ret[0].(data.AdminAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportState indicates an expected call of ImportState.
+func (mr *MockSolverAPIMockRecorder) ImportState(localPath any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportState", reflect.TypeOf((*MockSolverAPI)(nil).ImportState), localPath)
+}
+
+// PreemptDeal mocks base method.
+func (m *MockSolverAPI) PreemptDeal(id, reason string) (data.DealContainer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreemptDeal", id, reason)
+	ret0, _ := ret[0].(data.DealContainer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreemptDeal indicates an expected call of PreemptDeal.
+func (mr *MockSolverAPIMockRecorder) PreemptDeal(id, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreemptDeal", reflect.TypeOf((*MockSolverAPI)(nil).PreemptDeal), id, reason)
+}
+
+// RefreshAllowlist mocks base method.
+func (m *MockSolverAPI) RefreshAllowlist(reason string) (data.AdminAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshAllowlist", reason)
+	ret0, _ := ret[0].(data.AdminAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshAllowlist indicates an expected call of RefreshAllowlist.
+func (mr *MockSolverAPIMockRecorder) RefreshAllowlist(reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshAllowlist", reflect.TypeOf((*MockSolverAPI)(nil).RefreshAllowlist), reason)
+}
+
+// RemoveJobSchedule mocks base method.
+func (m *MockSolverAPI) RemoveJobSchedule(id string) (data.JobSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveJobSchedule", id)
+	ret0, _ := ret[0].(data.JobSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveJobSchedule indicates an expected call of RemoveJobSchedule.
+func (mr *MockSolverAPIMockRecorder) RemoveJobSchedule(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveJobSchedule", reflect.TypeOf((*MockSolverAPI)(nil).RemoveJobSchedule), id)
+}
+
+// RerunDeal mocks base method.
+func (m *MockSolverAPI) RerunDeal(id string, request data.DealRerunRequest) (data.JobOfferContainer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RerunDeal", id, request)
+	ret0, _ := ret[0].(data.JobOfferContainer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RerunDeal indicates an expected call of RerunDeal.
+func (mr *MockSolverAPIMockRecorder) RerunDeal(id, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RerunDeal", reflect.TypeOf((*MockSolverAPI)(nil).RerunDeal), id, request)
+}
+
+// SendServiceRequest mocks base method.
+func (m *MockSolverAPI) SendServiceRequest(id string, request data.ServiceRequest) (data.ServiceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendServiceRequest", id, request)
+	ret0, _ := ret[0].(data.ServiceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendServiceRequest indicates an expected call of SendServiceRequest.
+func (mr *MockSolverAPIMockRecorder) SendServiceRequest(id, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendServiceRequest", reflect.TypeOf((*MockSolverAPI)(nil).SendServiceRequest), id, request)
+}
+
+// Start mocks base method.
+func (m *MockSolverAPI) Start(ctx context.Context, cm *system.CleanupManager) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, cm)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockSolverAPIMockRecorder) Start(ctx, cm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockSolverAPI)(nil).Start), ctx, cm)
+}
+
+// SubscribeEvents mocks base method.
+func (m *MockSolverAPI) SubscribeEvents(handler func(solver.SolverEvent)) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SubscribeEvents", handler)
+}
+
+// SubscribeEvents indicates an expected call of SubscribeEvents.
+func (mr *MockSolverAPIMockRecorder) SubscribeEvents(handler any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribeEvents", reflect.TypeOf((*MockSolverAPI)(nil).SubscribeEvents), handler)
+}
+
+// SyncResourceProvider mocks base method.
+func (m *MockSolverAPI) SyncResourceProvider(resourceProvider string, since uint64, epoch string) (data.ResourceProviderSync, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncResourceProvider", resourceProvider, since, epoch)
+	ret0, _ := ret[0].(data.ResourceProviderSync)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SyncResourceProvider indicates an expected call of SyncResourceProvider.
+func (mr *MockSolverAPIMockRecorder) SyncResourceProvider(resourceProvider, since, epoch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncResourceProvider", reflect.TypeOf((*MockSolverAPI)(nil).SyncResourceProvider), resourceProvider, since, epoch)
+}
+
+// TriggerMatch mocks base method.
+func (m *MockSolverAPI) TriggerMatch(reason string) (data.AdminAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TriggerMatch", reason)
+	ret0, _ := ret[0].(data.AdminAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TriggerMatch indicates an expected call of TriggerMatch.
+func (mr *MockSolverAPIMockRecorder) TriggerMatch(reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TriggerMatch", reflect.TypeOf((*MockSolverAPI)(nil).TriggerMatch), reason)
+}
+
+// UnbanAddress mocks base method.
+func (m *MockSolverAPI) UnbanAddress(address, reason string) (data.BannedAddress, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnbanAddress", address, reason)
+	ret0, _ := ret[0].(data.BannedAddress)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnbanAddress indicates an expected call of UnbanAddress.
+func (mr *MockSolverAPIMockRecorder) UnbanAddress(address, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbanAddress", reflect.TypeOf((*MockSolverAPI)(nil).UnbanAddress), address, reason)
+}
+
+// UpdateDealCheckpoint mocks base method.
+func (m *MockSolverAPI) UpdateDealCheckpoint(id, cid string) (data.DealContainer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDealCheckpoint", id, cid)
+	ret0, _ := ret[0].(data.DealContainer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDealCheckpoint indicates an expected call of UpdateDealCheckpoint.
+func (mr *MockSolverAPIMockRecorder) UpdateDealCheckpoint(id, cid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDealCheckpoint", reflect.TypeOf((*MockSolverAPI)(nil).UpdateDealCheckpoint), id, cid)
+}
+
+// UpdateInputStaging mocks base method.
+func (m *MockSolverAPI) UpdateInputStaging(id string, update data.InputStagingUpdate) (data.InputStaging, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateInputStaging", id, update)
+	ret0, _ := ret[0].(data.InputStaging)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateInputStaging indicates an expected call of UpdateInputStaging.
+func (mr *MockSolverAPIMockRecorder) UpdateInputStaging(id, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateInputStaging", reflect.TypeOf((*MockSolverAPI)(nil).UpdateInputStaging), id, update)
+}
+
+// UpdateJobSchedule mocks base method.
+func (m *MockSolverAPI) UpdateJobSchedule(id string, update data.JobScheduleUpdate) (data.JobSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJobSchedule", id, update)
+	ret0, _ := ret[0].(data.JobSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateJobSchedule indicates an expected call of UpdateJobSchedule.
+func (mr *MockSolverAPIMockRecorder) UpdateJobSchedule(id, update any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJobSchedule", reflect.TypeOf((*MockSolverAPI)(nil).UpdateJobSchedule), id, update)
+}
+
+// UpdateTransactionsJobCreator mocks base method.
+func (m *MockSolverAPI) UpdateTransactionsJobCreator(id string, payload data.DealTransactionsJobCreator) (data.DealContainer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransactionsJobCreator", id, payload)
+	ret0, _ := ret[0].(data.DealContainer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTransactionsJobCreator indicates an expected call of UpdateTransactionsJobCreator.
+func (mr *MockSolverAPIMockRecorder) UpdateTransactionsJobCreator(id, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransactionsJobCreator", reflect.TypeOf((*MockSolverAPI)(nil).UpdateTransactionsJobCreator), id, payload)
+}
+
+// UpdateTransactionsMediator mocks base method.
+func (m *MockSolverAPI) UpdateTransactionsMediator(id string, payload data.DealTransactionsMediator) (data.DealContainer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransactionsMediator", id, payload)
+	ret0, _ := ret[0].(data.DealContainer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTransactionsMediator indicates an expected call of UpdateTransactionsMediator.
+func (mr *MockSolverAPIMockRecorder) UpdateTransactionsMediator(id, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransactionsMediator", reflect.TypeOf((*MockSolverAPI)(nil).UpdateTransactionsMediator), id, payload)
+}
+
+// UpdateTransactionsResourceProvider mocks base method.
+func (m *MockSolverAPI) UpdateTransactionsResourceProvider(id string, payload data.DealTransactionsResourceProvider) (data.DealContainer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTransactionsResourceProvider", id, payload)
+	ret0, _ := ret[0].(data.DealContainer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTransactionsResourceProvider indicates an expected call of UpdateTransactionsResourceProvider.
+func (mr *MockSolverAPIMockRecorder) UpdateTransactionsResourceProvider(id, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTransactionsResourceProvider", reflect.TypeOf((*MockSolverAPI)(nil).UpdateTransactionsResourceProvider), id, payload)
+}
+
+// UploadResultFiles mocks base method.
+func (m *MockSolverAPI) UploadResultFiles(id, localPath string) (data.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadResultFiles", id, localPath)
+	ret0, _ := ret[0].(data.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadResultFiles indicates an expected call of UploadResultFiles.
+func (mr *MockSolverAPIMockRecorder) UploadResultFiles(id, localPath any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadResultFiles", reflect.TypeOf((*MockSolverAPI)(nil).UploadResultFiles), id, localPath)
+}
+
+// WithdrawResourceOffers mocks base method.
+func (m *MockSolverAPI) WithdrawResourceOffers(resourceProvider string) ([]data.ResourceOfferContainer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithdrawResourceOffers", resourceProvider)
+	ret0, _ := ret[0].([]data.ResourceOfferContainer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WithdrawResourceOffers indicates an expected call of WithdrawResourceOffers.
+func (mr *MockSolverAPIMockRecorder) WithdrawResourceOffers(resourceProvider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithdrawResourceOffers", reflect.TypeOf((*MockSolverAPI)(nil).WithdrawResourceOffers), resourceProvider)
+}
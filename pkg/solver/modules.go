@@ -0,0 +1,122 @@
+package solver
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/module"
+)
+
+type ModuleResolverOptions struct {
+	// fetch the module of each job offer and check its declared spec
+	Enabled bool
+	// how many resolved modules to keep in memory
+	CacheSize int
+	// how many seconds to trust a module on a floating version
+	// such as a branch before fetching it again
+	// pinned versions are kept until they are evicted
+	FloatingTTL int
+}
+
+type resolvedModule struct {
+	module     *data.Module
+	resolvedAt time.Time
+	pinned     bool
+}
+
+// fetches module definitions from their git repos and keeps the parsed
+// module keyed by module ID so we only clone and template each once
+type moduleResolver struct {
+	options ModuleResolverOptions
+	// loading holds the lock so two job offers for the same module
+	// do not clone into the same directory at once
+	mutex sync.Mutex
+	cache map[string]resolvedModule
+	// module IDs in the order they were added, oldest first
+	order []string
+	load  func(module data.ModuleConfig, inputs map[string]string) (*data.Module, error)
+}
+
+func newModuleResolver(options ModuleResolverOptions) *moduleResolver {
+	return &moduleResolver{
+		options: options,
+		cache:   map[string]resolvedModule{},
+		order:   []string{},
+		load:    loadModule,
+	}
+}
+
+// the module template can panic on inputs it does not expect
+// and that should not take the solver down with it
+func loadModule(config data.ModuleConfig, inputs map[string]string) (mod *data.Module, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("module template failed: %v", r)
+		}
+	}()
+	return module.LoadModule(config, inputs)
+}
+
+func (resolver *moduleResolver) resolve(config data.ModuleConfig, inputs map[string]string, now time.Time) (*data.Module, error) {
+	moduleID, err := data.GetModuleID(config)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver.mutex.Lock()
+	defer resolver.mutex.Unlock()
+
+	cached, ok := resolver.cache[moduleID]
+	ttl := time.Duration(resolver.options.FloatingTTL) * time.Second
+	if ok && (cached.pinned || now.Sub(cached.resolvedAt) < ttl) {
+		return cached.module, nil
+	}
+
+	mod, err := resolver.load(config, inputs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve module %s: %s", moduleID, err.Error())
+	}
+
+	if !ok {
+		resolver.order = append(resolver.order, moduleID)
+	}
+	resolver.cache[moduleID] = resolvedModule{
+		module:     mod,
+		resolvedAt: now,
+		pinned:     data.CheckModuleVersionPinned(config) == nil,
+	}
+	for len(resolver.order) > resolver.options.CacheSize && len(resolver.order) > 0 {
+		delete(resolver.cache, resolver.order[0])
+		resolver.order = resolver.order[1:]
+	}
+	return mod, nil
+}
+
+// the job offer must ask for at least what the module template declares
+// otherwise it would be matched with machines that cannot run it
+func checkModuleSpec(jobOffer data.JobOffer, mod *data.Module) error {
+	if jobOffer.Spec.CPU < mod.Machine.CPU ||
+		jobOffer.Spec.GPU < mod.Machine.GPU ||
+		jobOffer.Spec.RAM < mod.Machine.RAM {
+		return fmt.Errorf(
+			"job offer spec (cpu %d, gpu %d, ram %d) is below what module %s declares (cpu %d, gpu %d, ram %d)",
+			jobOffer.Spec.CPU, jobOffer.Spec.GPU, jobOffer.Spec.RAM,
+			jobOffer.Module.Repo,
+			mod.Machine.CPU, mod.Machine.GPU, mod.Machine.RAM,
+		)
+	}
+	return nil
+}
+
+func (controller *SolverController) checkJobOfferModule(jobOffer data.JobOffer) error {
+	if controller.modules == nil {
+		return nil
+	}
+	mod, err := controller.modules.resolve(jobOffer.Module, jobOffer.Inputs, time.Now())
+	if err != nil {
+		return err
+	}
+	return checkModuleSpec(jobOffer, mod)
+}
@@ -0,0 +1,64 @@
+package solver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModuleResolver(t *testing.T) {
+	loads := 0
+	resolver := newModuleResolver(ModuleResolverOptions{CacheSize: 1, FloatingTTL: 60})
+	resolver.load = func(config data.ModuleConfig, inputs map[string]string) (*data.Module, error) {
+		loads++
+		return &data.Module{Machine: data.MachineSpec{CPU: 1000, RAM: 1024}}, nil
+	}
+
+	pinned := data.ModuleConfig{Repo: "https://github.com/Lilypad-Tech/lilypad-module-cowsay", Hash: "v0.0.4", Path: "/lilypad_module.json.tmpl"}
+	floating := data.ModuleConfig{Repo: "https://github.com/Lilypad-Tech/lilypad-module-cowsay", Hash: "main", Path: "/lilypad_module.json.tmpl"}
+	now := time.Now()
+
+	_, err := resolver.resolve(pinned, nil, now)
+	assert.NoError(t, err)
+	_, err = resolver.resolve(pinned, nil, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, loads, "pinned modules are cached")
+
+	// the cache only holds one module so this evicts the pinned one
+	_, err = resolver.resolve(floating, nil, now)
+	assert.NoError(t, err)
+	_, err = resolver.resolve(floating, nil, now.Add(30*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, loads, "floating modules are cached until the ttl")
+	_, err = resolver.resolve(floating, nil, now.Add(2*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, loads, "floating modules are fetched again after the ttl")
+
+	_, err = resolver.resolve(pinned, nil, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, loads, "evicted modules are fetched again")
+}
+
+func TestCheckModuleSpec(t *testing.T) {
+	mod := &data.Module{Machine: data.MachineSpec{CPU: 1000, GPU: 1, RAM: 1024}}
+
+	testCases := []struct {
+		name  string
+		spec  data.MachineSpec
+		valid bool
+	}{
+		{name: "Matches the module", spec: data.MachineSpec{CPU: 1000, GPU: 1, RAM: 1024}, valid: true},
+		{name: "Asks for more", spec: data.MachineSpec{CPU: 2000, GPU: 2, RAM: 2048}, valid: true},
+		{name: "Missing the GPU", spec: data.MachineSpec{CPU: 1000, RAM: 1024}, valid: false},
+		{name: "Too little RAM", spec: data.MachineSpec{CPU: 1000, GPU: 1, RAM: 512}, valid: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkModuleSpec(data.JobOffer{Spec: tc.spec}, mod)
+			assert.Equal(t, tc.valid, err == nil)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package solver
+
+import (
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/stats"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+)
+
+// aggregate everything in the store into stats that are safe to publish
+// small counts are suppressed and provider earnings are bucketed
+func (controller *SolverController) getNetworkStats() (stats.NetworkStats, error) {
+	jobOffers, err := controller.store.GetJobOffers(store.GetJobOffersQuery{
+		IncludeCancelled: true,
+	})
+	if err != nil {
+		return stats.NetworkStats{}, err
+	}
+	resourceOffers, err := controller.store.GetResourceOffers(store.GetResourceOffersQuery{})
+	if err != nil {
+		return stats.NetworkStats{}, err
+	}
+	deals, err := controller.store.GetDeals(store.GetDealsQuery{})
+	if err != nil {
+		return stats.NetworkStats{}, err
+	}
+	results := map[string]data.Result{}
+	for _, deal := range deals {
+		result, err := controller.store.GetResult(deal.ID)
+		if err != nil {
+			return stats.NetworkStats{}, err
+		}
+		if result != nil {
+			results[deal.ID] = *result
+		}
+	}
+	return stats.GetNetworkStats(stats.StatsInput{
+		JobOffers:      jobOffers,
+		ResourceOffers: resourceOffers,
+		Deals:          deals,
+		Results:        results,
+	}, controller.options.Stats), nil
+}
@@ -0,0 +1,96 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/notifications"
+)
+
+// add a way to send notifications alongside email, discord and telegram
+// this must be called before the solver is started
+func (controller *SolverController) RegisterNotificationSender(sender notifications.Sender) error {
+	if controller.notifier == nil {
+		return fmt.Errorf("notifications are not turned on")
+	}
+	controller.notifier.RegisterSender(sender)
+	return nil
+}
+
+func (controller *SolverController) startNotifications(ctx context.Context) error {
+	if controller.notifier == nil {
+		return nil
+	}
+	err := controller.notifier.CheckSenders()
+	if err != nil {
+		return err
+	}
+	controller.notifier.Start(ctx)
+	controller.subscribeEvents(func(ev SolverEvent) {
+		for _, notification := range getNotifications(ev, controller.getNotificationResult) {
+			controller.notifier.Notify(notification)
+		}
+	})
+	return nil
+}
+
+func (controller *SolverController) getNotificationResult(dealID string) *data.Result {
+	result, err := controller.store.GetResult(dealID)
+	if err != nil {
+		controller.log.Error("error getting result for notification", err)
+		return nil
+	}
+	return result
+}
+
+// who to tell about a solver event and what to tell them
+// getResult is only called for the states that depend on the result
+func getNotifications(ev SolverEvent, getResult func(dealID string) *data.Result) []notifications.Notification {
+	if ev.Deal == nil {
+		return nil
+	}
+	deal := *ev.Deal
+	module := data.GetModuleLabel(deal.Deal.JobOffer.Module)
+	both := func(event string, title string, message string) []notifications.Notification {
+		return []notifications.Notification{
+			{Event: event, Address: deal.JobCreator, DealID: deal.ID, Title: title, Message: message},
+			{Event: event, Address: deal.ResourceProvider, DealID: deal.ID, Title: title, Message: message},
+		}
+	}
+	switch ev.EventType {
+	case DealAdded:
+		return []notifications.Notification{{
+			Event:   notifications.EventDealWon,
+			Address: deal.ResourceProvider,
+			DealID:  deal.ID,
+			Title:   fmt.Sprintf("Deal %s won", deal.ID),
+			Message: fmt.Sprintf("You were matched to run %s for %s at %d wei per instruction.", module, deal.JobCreator, deal.Deal.Pricing.InstructionPrice),
+		}}
+	case DealStateUpdated:
+	default:
+		return nil
+	}
+	state := data.GetAgreementStateString(deal.State)
+	switch state {
+	case "ResultsSubmitted", "ResultsAccepted", "MediationAccepted":
+		result := getResult(deal.ID)
+		if result == nil {
+			return nil
+		}
+		// an errored result fails the job as soon as it is posted
+		if result.Error != "" {
+			if state != "ResultsSubmitted" {
+				return nil
+			}
+			return both(notifications.EventJobFailed, fmt.Sprintf("Job %s failed", deal.ID), fmt.Sprintf("%s failed: %s", module, result.Error))
+		}
+		if state == "ResultsSubmitted" {
+			return nil
+		}
+		return both(notifications.EventJobCompleted, fmt.Sprintf("Job %s completed", deal.ID), fmt.Sprintf("The results of %s were accepted (%s).", module, state))
+	case "MediationRejected", "TimeoutAgree", "TimeoutSubmitResults", "TimeoutJudgeResults", "TimeoutMediateResults":
+		return both(notifications.EventJobFailed, fmt.Sprintf("Job %s failed", deal.ID), fmt.Sprintf("%s ended in %s.", module, state))
+	}
+	return nil
+}
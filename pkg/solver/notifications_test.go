@@ -0,0 +1,41 @@
+package solver
+
+import (
+	"testing"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/notifications"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNotifications(t *testing.T) {
+	deal := data.DealContainer{ID: "deal", JobCreator: "jc", ResourceProvider: "rp"}
+	result := &data.Result{}
+	getResult := func(dealID string) *data.Result { return result }
+	inState := func(state string) SolverEvent {
+		stateDeal := deal
+		stateDeal.State = data.GetAgreementStateIndex(state)
+		return SolverEvent{EventType: DealStateUpdated, Deal: &stateDeal}
+	}
+
+	won := getNotifications(SolverEvent{EventType: DealAdded, Deal: &deal}, getResult)
+	assert.Len(t, won, 1)
+	assert.Equal(t, notifications.EventDealWon, won[0].Event)
+	assert.Equal(t, "rp", won[0].Address)
+
+	assert.Empty(t, getNotifications(inState("ResultsSubmitted"), getResult))
+	completed := getNotifications(inState("ResultsAccepted"), getResult)
+	assert.Len(t, completed, 2)
+	assert.Equal(t, notifications.EventJobCompleted, completed[0].Event)
+
+	timedOut := getNotifications(inState("TimeoutSubmitResults"), getResult)
+	assert.Len(t, timedOut, 2)
+	assert.Equal(t, notifications.EventJobFailed, timedOut[1].Event)
+
+	// an errored result fails the job when it is posted and not again when accepted
+	result.Error = "out of memory"
+	failed := getNotifications(inState("ResultsSubmitted"), getResult)
+	assert.Len(t, failed, 2)
+	assert.Contains(t, failed[0].Message, "out of memory")
+	assert.Empty(t, getNotifications(inState("ResultsAccepted"), getResult))
+}
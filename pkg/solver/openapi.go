@@ -0,0 +1,379 @@
+package solver
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/escrow"
+	"github.com/lilypad-tech/lilypad/pkg/http"
+	"github.com/lilypad-tech/lilypad/pkg/solver/stats"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+)
+
+// a route as it appears in the OpenAPI document
+// Request and Response are zero values of the JSON body types
+// and are nil if the route does not send or return JSON
+type apiRoute struct {
+	Method  string
+	Path    string
+	Summary string
+	Query   []string
+	Signed  bool
+	// the signature has to be made for this one request and is only
+	// accepted once, see http.RequestSignatureChecker
+	RequestSigned bool
+	Request       interface{}
+	Response      interface{}
+	ContentType   string
+}
+
+// every route served by the solver
+// TestOpenAPIRoutes checks this against the router so keep them in step
+var solverAPIRoutes = []apiRoute{
+	{Method: "GET", Path: "/job_offers", Summary: "List job offers", Query: []string{"job_creator", "not_matched", "include_cancelled", "chain_id"}, Response: []data.JobOfferContainer{}},
+	{Method: "POST", Path: "/job_offers", Summary: "Add a job offer signed by its job creator", Signed: true, RequestSigned: true, Request: data.JobOffer{}, Response: data.JobOfferContainer{}},
+	{Method: "POST", Path: "/job_offers/{id}/cancel", Summary: "Cancel a job offer, stopping its job if it is running, signed by its job creator", Signed: true, RequestSigned: true, Request: data.JobOfferCancellation{}, Response: data.JobOfferContainer{}},
+	{Method: "POST", Path: "/job_groups", Summary: "Add an array job as one job offer for each value of its parameter, signed by its job creator", Signed: true, RequestSigned: true, Request: data.JobGroupSubmission{}, Response: data.JobGroup{}},
+	{Method: "GET", Path: "/billing", Summary: "List the billing records metered for each deal when its result was added, from and to are unix seconds, signed by the job creator, the resource provider if there is no job creator, or an admin", Signed: true, RequestSigned: true, Query: []string{"job_creator", "resource_provider", "from", "to"}, Response: []data.BillingRecord{}},
+	{Method: "GET", Path: "/billing/csv", Summary: "Export the billing records as CSV, signed in the same way as /billing", Signed: true, RequestSigned: true, Query: []string{"job_creator", "resource_provider", "from", "to"}, ContentType: "text/csv"},
+	{Method: "GET", Path: "/input_stagings", Summary: "List requests for resource providers to fetch inputs ahead of a job", Query: []string{"job_creator", "resource_provider", "state"}, Response: []data.InputStaging{}},
+	{Method: "POST", Path: "/input_stagings", Summary: "Ask a resource provider to fetch input CIDs before a job that uses them is submitted, signed by the job creator", Signed: true, RequestSigned: true, Request: data.InputStagingRequest{}, Response: data.InputStaging{}},
+	{Method: "GET", Path: "/input_stagings/{id}", Summary: "Get a request to stage inputs", Response: data.InputStaging{}},
+	{Method: "POST", Path: "/input_stagings/{id}", Summary: "Say whether the inputs were staged, signed by the resource provider", Signed: true, RequestSigned: true, Request: data.InputStagingUpdate{}, Response: data.InputStaging{}},
+	{Method: "GET", Path: "/workflows", Summary: "List workflows", Query: []string{"job_creator", "running"}, Response: []data.Workflow{}},
+	{Method: "POST", Path: "/workflows", Summary: "Run a workflow whose stages are added as job offers once the stages they take results from succeed, signed by its job creator", Signed: true, RequestSigned: true, Request: data.WorkflowSubmission{}, Response: data.Workflow{}},
+	{Method: "GET", Path: "/workflows/{id}", Summary: "Get a workflow and the state of each of its stages", Response: data.Workflow{}},
+	{Method: "POST", Path: "/workflows/{id}/cancel", Summary: "Cancel the running stages of a workflow and skip the rest, signed by its job creator", Signed: true, RequestSigned: true, Request: data.WorkflowCancellation{}, Response: data.Workflow{}},
+	{Method: "GET", Path: "/job_schedules", Summary: "List recurring jobs", Query: []string{"job_creator"}, Response: []data.JobSchedule{}},
+	{Method: "POST", Path: "/job_schedules", Summary: "Add a job offer the solver adds again at each tick of a cron expression, signed by its job creator", Signed: true, RequestSigned: true, Request: data.JobScheduleSubmission{}, Response: data.JobSchedule{}},
+	{Method: "GET", Path: "/job_schedules/{id}", Summary: "Get a recurring job", Response: data.JobSchedule{}},
+	{Method: "POST", Path: "/job_schedules/{id}", Summary: "Change the cron expression or overlap policy of a recurring job or pause it, signed by its job creator", Signed: true, RequestSigned: true, Request: data.JobScheduleUpdate{}, Response: data.JobSchedule{}},
+	{Method: "POST", Path: "/job_schedules/{id}/remove", Summary: "Stop a recurring job leaving the job offers it added to finish, signed by its job creator", Signed: true, RequestSigned: true, Request: data.JobScheduleRemoval{}, Response: data.JobSchedule{}},
+	{Method: "GET", Path: "/capacity_reservations", Summary: "List capacity reservations", Query: []string{"job_creator", "resource_provider", "open"}, Response: []data.CapacityReservation{}},
+	{Method: "POST", Path: "/capacity_reservations", Summary: "Reserve resource offers of a resource provider for a future window, the solver holds them and adds the job offers when the window starts, signed by the job creator", Signed: true, RequestSigned: true, Request: data.CapacityReservationSubmission{}, Response: data.CapacityReservation{}},
+	{Method: "GET", Path: "/capacity_reservations/{id}", Summary: "Get a capacity reservation", Response: data.CapacityReservation{}},
+	{Method: "POST", Path: "/capacity_reservations/{id}/cancel", Summary: "Cancel a capacity reservation and its job offers that have no deal, signed by its job creator", Signed: true, RequestSigned: true, Request: data.CapacityReservationCancellation{}, Response: data.CapacityReservation{}},
+	{Method: "GET", Path: "/admin/stats", Summary: "Count the records the solver store is holding, signed by an admin", Signed: true, RequestSigned: true, Response: store.StoreStats{}},
+	{Method: "GET", Path: "/admin/actions", Summary: "List the actions admins have taken with the most recent first, signed by an admin", Signed: true, RequestSigned: true, Query: []string{"admin", "limit"}, Response: []data.AdminAction{}},
+	{Method: "GET", Path: "/admin/banned_addresses", Summary: "List the addresses that cannot add offers, signed by an admin", Signed: true, RequestSigned: true, Response: []data.BannedAddress{}},
+	{Method: "POST", Path: "/admin/banned_addresses", Summary: "Stop an address adding offers and expire its unmatched ones, for good or for a duration in seconds, signed by an operator", Signed: true, RequestSigned: true, Request: data.BanSubmission{}, Response: data.BannedAddress{}},
+	{Method: "POST", Path: "/admin/banned_addresses/{address}/remove", Summary: "Lift the ban on an address, signed by an operator", Signed: true, RequestSigned: true, Request: data.AdminRequest{}, Response: data.BannedAddress{}},
+	{Method: "POST", Path: "/admin/job_offers/{id}/expire", Summary: "Take an unmatched job offer out of matching, signed by an operator", Signed: true, RequestSigned: true, Request: data.AdminRequest{}, Response: data.JobOfferContainer{}},
+	{Method: "POST", Path: "/admin/resource_offers/{id}/expire", Summary: "Remove an unmatched resource offer, signed by an operator", Signed: true, RequestSigned: true, Request: data.AdminRequest{}, Response: data.ResourceOfferContainer{}},
+	{Method: "POST", Path: "/admin/match", Summary: "Run a matching pass now, signed by an operator", Signed: true, RequestSigned: true, Request: data.AdminRequest{}, Response: data.AdminAction{}},
+	{Method: "POST", Path: "/admin/allowlist/refresh", Summary: "Read the allowlist file again, signed by an operator", Signed: true, RequestSigned: true, Request: data.AdminRequest{}, Response: data.AdminAction{}},
+	{Method: "GET", Path: "/admin/export", Summary: "Export every table of the store as a zstd compressed tar, signed by an operator", Signed: true, RequestSigned: true, ContentType: "application/zstd"},
+	{Method: "POST", Path: "/admin/import", Summary: "Import a state export into a solver that has no offers or deals yet, signed by an operator", Signed: true, RequestSigned: true, ContentType: "application/zstd"},
+	{Method: "GET", Path: "/job_groups/{id}", Summary: "Get the job offers of an array job and how many are in each state", Response: data.JobGroupStatus{}},
+	{Method: "GET", Path: "/resource_offers", Summary: "List resource offers", Query: []string{"resource_provider", "active", "not_matched", "chain_id"}, Response: []data.ResourceOfferContainer{}},
+	{Method: "POST", Path: "/resource_offers", Summary: "Add a resource offer signed by its resource provider", Signed: true, RequestSigned: true, Request: data.ResourceOffer{}, Response: data.ResourceOfferContainer{}},
+	{Method: "POST", Path: "/resource_offers/withdraw", Summary: "Withdraw the unmatched resource offers of the signer", Signed: true, RequestSigned: true, Request: store.GetResourceOffersQuery{}, Response: []data.ResourceOfferContainer{}},
+	{Method: "GET", Path: "/deals", Summary: "List deals", Query: []string{"job_creator", "resource_provider", "state", "chain_id", "parent_deal_id"}, Response: []data.DealContainer{}},
+	{Method: "GET", Path: "/deals/{id}", Summary: "Get a deal", Response: data.DealContainer{}},
+	{Method: "GET", Path: "/deals/{id}/files", Summary: "Download the result files as a tar archive, Range requests are supported", ContentType: "application/x-tar"},
+	{Method: "POST", Path: "/deals/{id}/files", Summary: "Upload the result files as a tar archive", Signed: true, RequestSigned: true, ContentType: "application/x-tar"},
+	{Method: "GET", Path: "/deals/{id}/logs", Summary: "Get the recent output of a deal's job, pass the last sequence you saw as after, signed by the deal's job creator, resource provider or a mediator", Signed: true, RequestSigned: true, Query: []string{"after"}, Response: []data.DealLogChunk{}},
+	{Method: "POST", Path: "/deals/{id}/logs", Summary: "Add output from a running job, signed by the deal's resource provider", Signed: true, RequestSigned: true, Request: []data.DealLogChunk{}, Response: []data.DealLogChunk{}},
+	{Method: "GET", Path: "/deals/{id}/logs/stream", Summary: "Follow the output of a deal's job as server sent events until the deal is over, signed by the deal's job creator, resource provider or a mediator", Signed: true, RequestSigned: true, Query: []string{"after"}, ContentType: "text/event-stream"},
+	{Method: "POST", Path: "/deals/{id}/checkpoint", Summary: "Record the latest checkpoint of a running job, signed by the deal's resource provider", Signed: true, RequestSigned: true, Request: data.DealCheckpoint{}, Response: data.DealContainer{}},
+	{Method: "POST", Path: "/deals/{id}/encrypted_inputs", Summary: "Send the private inputs of a matched deal encrypted for the resource offer key, signed by the deal's job creator", Signed: true, RequestSigned: true, Request: data.DealEncryptedInputs{}, Response: data.DealContainer{}},
+	{Method: "POST", Path: "/deals/{id}/milestones/accept", Summary: "Accept a milestone of a deal paid in milestones so the solver pays it on chain, signed by the deal's job creator", Signed: true, RequestSigned: true, Request: data.DealMilestoneAcceptance{}, Response: data.DealContainer{}},
+	{Method: "POST", Path: "/deals/{id}/preempt", Summary: "Take back the capacity of a spot deal, the job is stopped once the resource offer's preemption notice is up, signed by the deal's resource provider", Signed: true, RequestSigned: true, Request: data.DealPreemption{}, Response: data.DealContainer{}},
+	{Method: "POST", Path: "/deals/{id}/mediation_verdicts", Summary: "Add a mediator's verdict on the result of a deal that needs a mediator quorum, signed by the mediator", Signed: true, RequestSigned: true, Request: data.MediationVerdict{}, Response: data.DealContainer{}},
+	{Method: "GET", Path: "/deals/{id}/receipt", Summary: "Get the EIP-712 receipt the solver signed for the terms of a deal", Response: data.DealReceipt{}},
+	{Method: "POST", Path: "/deals/{id}/rerun", Summary: "Add a job offer that runs the job of a deal again, optionally on the same resource provider, signed by the deal's job creator", Signed: true, RequestSigned: true, Request: data.DealRerunRequest{}, Response: data.JobOfferContainer{}},
+	{Method: "GET", Path: "/deals/{id}/lineage", Summary: "Get the deals a deal was run again from and every deal that ran it again", Response: data.DealLineage{}},
+	{Method: "POST", Path: "/deals/{id}/service/requests", Summary: "Send a request to the service of a service deal and wait for its answer, signed by the deal's job creator", Signed: true, RequestSigned: true, Request: data.ServiceRequest{}, Response: data.ServiceResponse{}},
+	{Method: "GET", Path: "/deals/{id}/service/requests", Summary: "Pick up the requests waiting for the service of a deal, waits a while if there are none, signed by the deal's resource provider", Signed: true, Response: []data.ServiceRequest{}},
+	{Method: "POST", Path: "/deals/{id}/service/responses", Summary: "Answer a request to the service of a deal, signed by the deal's resource provider", Signed: true, RequestSigned: true, Request: data.ServiceResponse{}, Response: data.DealContainer{}},
+	{Method: "GET", Path: "/deals/{id}/result", Summary: "Get the result of a deal", Response: data.Result{}},
+	{Method: "POST", Path: "/deals/{id}/result", Summary: "Add the result of a deal", Signed: true, RequestSigned: true, Request: data.Result{}, Response: data.Result{}},
+	{Method: "GET", Path: "/deals/{id}/result/archive", Summary: "Stream the result files from IPFS as a tar archive checking every block against the result CID, the X-Lilypad-Verified trailer is set once they all match", ContentType: "application/x-tar"},
+	{Method: "GET", Path: "/deals/{id}/result/pins", Summary: "List the pinning services asked to keep the result of a deal and the health of each pin", Response: []data.ResultPin{}},
+	{Method: "GET", Path: "/deals/{id}/audit", Summary: "Get the audit of a deal", Response: data.Audit{}},
+	{Method: "GET", Path: "/deals/{id}/escrow", Summary: "Get the escrow payments of a deal reconciled against what the deal should have paid", Response: escrow.DealAccount{}},
+	{Method: "GET", Path: "/escrow/discrepancies", Summary: "List the escrow accounts of deals whose payments do not add up", Response: []escrow.DealAccount{}},
+	{Method: "GET", Path: "/slashings", Summary: "List the escrow payments that slashed collateral, from is unix seconds", Query: []string{"payee", "from"}, Response: []data.EscrowPayment{}},
+	{Method: "GET", Path: "/price_gaps", Summary: "List open offers that only failed to match on price with the counter-offers that would match them", Query: []string{"job_offer", "resource_offer", "job_creator", "resource_provider"}, Response: []data.PriceGap{}},
+	{Method: "GET", Path: "/estimate", Summary: "Estimate what a job would cost from the instruction prices of the unmatched resource offers that could run it", Query: []string{"module", "repo", "hash", "path", "cpu", "gpu", "ram", "instructions", "chain_id"}, Response: data.CostEstimate{}},
+	{Method: "GET", Path: "/resource_providers/{address}/reputation", Summary: "Get the audit reputation of a resource provider", Response: data.ResourceProviderReputation{}},
+	{Method: "GET", Path: "/job_creators/{address}/budget", Summary: "Get what a job creator has spent, has reserved in open offers and deals, and has left of its budget, signed by the job creator or an admin", Signed: true, RequestSigned: true, Response: data.BudgetStatus{}},
+	{Method: "GET", Path: "/resource_providers/{address}/sync", Summary: "Get the offers, deals and match decisions of a resource provider that changed since a version", Query: []string{"since", "epoch"}, Response: data.ResourceProviderSync{}},
+	{Method: "GET", Path: "/events", Summary: "Page through the log of changes to the solver store, pass next_cursor back as cursor", Query: []string{"cursor", "limit", "type", "object_id"}, Response: data.StoreEventPage{}},
+	{Method: "GET", Path: "/transactions", Summary: "List the transactions the solver sent and whether each was mined", Query: []string{"deal_id", "status", "chain_id"}, Response: []data.Transaction{}},
+	{Method: "GET", Path: "/transactions/{id}", Summary: "Get a transaction the solver sent by its chain, sender and nonce", Response: data.Transaction{}},
+	{Method: "GET", Path: "/stats", Summary: "Get aggregated network stats", Response: stats.NetworkStats{}},
+	{Method: "GET", Path: "/clients", Summary: "List the versions and capabilities of the job creators and resource providers that have sent offers", Query: []string{"role", "since"}, Response: []data.ClientRecord{}},
+	{Method: "POST", Path: "/deals/{id}/txs/resource_provider", Summary: "Record the resource provider transactions for a deal", Signed: true, RequestSigned: true, Request: data.DealTransactionsResourceProvider{}, Response: data.DealContainer{}},
+	{Method: "POST", Path: "/deals/{id}/txs/job_creator", Summary: "Record the job creator transactions for a deal", Signed: true, RequestSigned: true, Request: data.DealTransactionsJobCreator{}, Response: data.DealContainer{}},
+	{Method: "POST", Path: "/deals/{id}/txs/mediator", Summary: "Record the mediator transactions for a deal", Signed: true, RequestSigned: true, Request: data.DealTransactionsMediator{}, Response: data.DealContainer{}},
+	{Method: "GET", Path: "/openapi.json", Summary: "This document", Response: map[string]interface{}{}},
+}
+
+var (
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	timeType          = reflect.TypeOf(time.Time{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+)
+
+// builds JSON schemas for Go types and collects the named
+// struct types under components/schemas so they are only written once
+type schemaBuilder struct {
+	schemas map[string]interface{}
+}
+
+// the schema name for a named type, types outside of pkg/data
+// are prefixed with their package so the names cannot clash
+func schemaName(t reflect.Type) string {
+	pkg := path.Base(t.PkgPath())
+	if pkg == "data" {
+		return t.Name()
+	}
+	runes := []rune(pkg)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes) + t.Name()
+}
+
+func (builder *schemaBuilder) schema(t reflect.Type) map[string]interface{} {
+	if t.Kind() == reflect.Pointer {
+		return builder.schema(t.Elem())
+	}
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+	// raw JSON can be anything
+	if t == rawMessageType {
+		return map[string]interface{}{}
+	}
+	if t.Implements(textMarshalerType) {
+		return map[string]interface{}{"type": "string"}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		// encoding/json writes byte slices as base64
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": builder.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": builder.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return builder.structSchema(t)
+		}
+		name := schemaName(t)
+		if _, ok := builder.schemas[name]; !ok {
+			// reserve the name first in case the type refers to itself
+			builder.schemas[name] = map[string]interface{}{}
+			builder.schemas[name] = builder.structSchema(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+	}
+	// interfaces can hold anything
+	return map[string]interface{}{}
+}
+
+// the properties of a struct as encoding/json would write them
+func (builder *schemaBuilder) structSchema(t reflect.Type) map[string]interface{} {
+	properties := map[string]interface{}{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Name
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			tagName := strings.Split(tag, ",")[0]
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+		// embedded structs without a tag have their fields hoisted
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && name == field.Name {
+			embedded := builder.structSchema(field.Type)
+			for key, value := range embedded["properties"].(map[string]interface{}) {
+				properties[key] = value
+			}
+			continue
+		}
+		properties[name] = builder.schema(field.Type)
+	}
+	return map[string]interface{}{"type": "object", "properties": properties}
+}
+
+func jsonContent(schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"application/json": map[string]interface{}{"schema": schema},
+	}
+}
+
+func binaryContent(contentType string) map[string]interface{} {
+	return map[string]interface{}{
+		contentType: map[string]interface{}{
+			"schema": map[string]interface{}{"type": "string", "format": "binary"},
+		},
+	}
+}
+
+func (builder *schemaBuilder) operation(route apiRoute) map[string]interface{} {
+	parameters := []interface{}{}
+	for _, segment := range strings.Split(route.Path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			parameters = append(parameters, map[string]interface{}{
+				"name":     strings.Trim(segment, "{}"),
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]interface{}{"type": "string"},
+			})
+		}
+	}
+	for _, name := range route.Query {
+		parameters = append(parameters, map[string]interface{}{
+			"name":   name,
+			"in":     "query",
+			"schema": map[string]interface{}{"type": "string"},
+		})
+	}
+
+	op := map[string]interface{}{
+		"summary":    route.Summary,
+		"parameters": parameters,
+	}
+
+	response := map[string]interface{}{"description": "OK"}
+	if route.Response != nil {
+		response["content"] = jsonContent(builder.schema(reflect.TypeOf(route.Response)))
+	} else if route.ContentType != "" && route.Method == "GET" {
+		response["content"] = binaryContent(route.ContentType)
+	}
+	op["responses"] = map[string]interface{}{"200": response}
+
+	if route.Request != nil {
+		op["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content":  jsonContent(builder.schema(reflect.TypeOf(route.Request))),
+		}
+	} else if route.ContentType != "" && route.Method == "POST" {
+		op["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content":  binaryContent(route.ContentType),
+		}
+	}
+	if route.RequestSigned {
+		op["security"] = []interface{}{
+			map[string]interface{}{
+				"lilypadUser":             []string{},
+				"lilypadSignature":        []string{},
+				"lilypadTimestamp":        []string{},
+				"lilypadNonce":            []string{},
+				"lilypadRequestSignature": []string{},
+			},
+		}
+	} else if route.Signed {
+		op["security"] = []interface{}{
+			map[string]interface{}{"lilypadUser": []string{}, "lilypadSignature": []string{}},
+		}
+	}
+	return op
+}
+
+// build the OpenAPI 3 document for the solver API
+func buildOpenAPISpec() map[string]interface{} {
+	builder := &schemaBuilder{schemas: map[string]interface{}{}}
+	paths := map[string]interface{}{}
+	for _, route := range solverAPIRoutes {
+		item, ok := paths[route.Path].(map[string]interface{})
+		if !ok {
+			item = map[string]interface{}{}
+			paths[route.Path] = item
+		}
+		item[strings.ToLower(route.Method)] = builder.operation(route)
+	}
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "Lilypad Solver API",
+			"version": system.Version,
+		},
+		"servers": getOpenAPIServers(),
+		"paths":   paths,
+		"components": map[string]interface{}{
+			"schemas": builder.schemas,
+			// see http.AddHeaders for how these are made
+			"securitySchemes": map[string]interface{}{
+				"lilypadUser": map[string]interface{}{
+					"type": "apiKey",
+					"in":   "header",
+					"name": http.X_LILYPAD_USER_HEADER,
+				},
+				"lilypadSignature": map[string]interface{}{
+					"type": "apiKey",
+					"in":   "header",
+					"name": http.X_LILYPAD_SIGNATURE_HEADER,
+				},
+				"lilypadTimestamp": map[string]interface{}{
+					"type": "apiKey",
+					"in":   "header",
+					"name": http.X_LILYPAD_TIMESTAMP_HEADER,
+				},
+				"lilypadNonce": map[string]interface{}{
+					"type": "apiKey",
+					"in":   "header",
+					"name": http.X_LILYPAD_NONCE_HEADER,
+				},
+				"lilypadRequestSignature": map[string]interface{}{
+					"type": "apiKey",
+					"in":   "header",
+					"name": http.X_LILYPAD_REQUEST_SIGNATURE_HEADER,
+				},
+			},
+		},
+	}
+}
+
+var (
+	openAPISpec     []byte
+	openAPISpecErr  error
+	openAPISpecOnce sync.Once
+)
+
+// the OpenAPI document as JSON, it only depends on the types so we build it once
+func GetOpenAPISpec() ([]byte, error) {
+	openAPISpecOnce.Do(func() {
+		openAPISpec, openAPISpecErr = json.MarshalIndent(buildOpenAPISpec(), "", "  ")
+	})
+	return openAPISpec, openAPISpecErr
+}
+
+// the newest version first, older ones only differ in how errors are sent
+func getOpenAPIServers() []interface{} {
+	servers := []interface{}{}
+	for version := data.PROTOCOL_VERSION; version >= data.MIN_PROTOCOL_VERSION; version-- {
+		servers = append(servers, map[string]interface{}{
+			"url":         http.GetAPISubPath(version),
+			"description": fmt.Sprintf("protocol version %d", version),
+		})
+	}
+	return servers
+}
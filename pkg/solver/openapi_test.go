@@ -0,0 +1,63 @@
+package solver
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+// every route the server handles must be in the OpenAPI document
+func TestOpenAPIRoutes(t *testing.T) {
+	bs, err := GetOpenAPISpec()
+	assert.NoError(t, err)
+	var spec struct {
+		Paths map[string]map[string]interface{} `json:"paths"`
+	}
+	err = json.Unmarshal(bs, &spec)
+	assert.NoError(t, err)
+
+	router := mux.NewRouter()
+	(&solverServer{}).addRoutes(router)
+	routes := 0
+	err = router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return err
+		}
+		for _, method := range methods {
+			routes++
+			_, ok := spec.Paths[path][strings.ToLower(method)]
+			assert.True(t, ok, "%s %s is not in the OpenAPI spec", method, path)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, len(solverAPIRoutes), routes)
+}
+
+func TestOpenAPISchemas(t *testing.T) {
+	bs, err := GetOpenAPISpec()
+	assert.NoError(t, err)
+	var spec struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]map[string]interface{} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	err = json.Unmarshal(bs, &spec)
+	assert.NoError(t, err)
+
+	jobOffer, ok := spec.Components.Schemas["JobOffer"]
+	assert.True(t, ok)
+	assert.Equal(t, "#/components/schemas/ModuleConfig", jobOffer.Properties["module"]["$ref"])
+	assert.Equal(t, "integer", jobOffer.Properties["input_size"]["type"])
+	assert.Equal(t, "object", jobOffer.Properties["inputs"]["type"])
+}
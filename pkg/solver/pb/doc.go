@@ -0,0 +1,4 @@
+// the generated types and stubs for the solver gRPC API
+package pb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative solver.proto
@@ -0,0 +1,266 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             v5.27.2
+// source: solver.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	Solver_SubmitJobOffer_FullMethodName      = "/lilypad.solver.v1.Solver/SubmitJobOffer"
+	Solver_SubmitResourceOffer_FullMethodName = "/lilypad.solver.v1.Solver/SubmitResourceOffer"
+	Solver_WatchDeals_FullMethodName          = "/lilypad.solver.v1.Solver/WatchDeals"
+	Solver_GetResults_FullMethodName          = "/lilypad.solver.v1.Solver/GetResults"
+)
+
+// SolverClient is the client API for Solver service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// the solver API for clients that would rather have typed messages and
+// streams than JSON over HTTP, it is served by the same controller
+//
+// calls that post offers are signed the same way as the HTTP API, with the
+// x-lilypad-user and x-lilypad-signature headers sent as request metadata
+type SolverClient interface {
+	SubmitJobOffer(ctx context.Context, in *SubmitJobOfferRequest, opts ...grpc.CallOption) (*JobOfferContainer, error)
+	SubmitResourceOffer(ctx context.Context, in *SubmitResourceOfferRequest, opts ...grpc.CallOption) (*ResourceOfferContainer, error)
+	// stream changes to deals as the solver makes them
+	WatchDeals(ctx context.Context, in *WatchDealsRequest, opts ...grpc.CallOption) (Solver_WatchDealsClient, error)
+	GetResults(ctx context.Context, in *GetResultsRequest, opts ...grpc.CallOption) (*GetResultsResponse, error)
+}
+
+type solverClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSolverClient(cc grpc.ClientConnInterface) SolverClient {
+	return &solverClient{cc}
+}
+
+func (c *solverClient) SubmitJobOffer(ctx context.Context, in *SubmitJobOfferRequest, opts ...grpc.CallOption) (*JobOfferContainer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JobOfferContainer)
+	err := c.cc.Invoke(ctx, Solver_SubmitJobOffer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *solverClient) SubmitResourceOffer(ctx context.Context, in *SubmitResourceOfferRequest, opts ...grpc.CallOption) (*ResourceOfferContainer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResourceOfferContainer)
+	err := c.cc.Invoke(ctx, Solver_SubmitResourceOffer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *solverClient) WatchDeals(ctx context.Context, in *WatchDealsRequest, opts ...grpc.CallOption) (Solver_WatchDealsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Solver_ServiceDesc.Streams[0], Solver_WatchDeals_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &solverWatchDealsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Solver_WatchDealsClient interface {
+	Recv() (*DealEvent, error)
+	grpc.ClientStream
+}
+
+type solverWatchDealsClient struct {
+	grpc.ClientStream
+}
+
+func (x *solverWatchDealsClient) Recv() (*DealEvent, error) {
+	m := new(DealEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *solverClient) GetResults(ctx context.Context, in *GetResultsRequest, opts ...grpc.CallOption) (*GetResultsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetResultsResponse)
+	err := c.cc.Invoke(ctx, Solver_GetResults_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SolverServer is the server API for Solver service.
+// All implementations must embed UnimplementedSolverServer
+// for forward compatibility
+//
+// the solver API for clients that would rather have typed messages and
+// streams than JSON over HTTP, it is served by the same controller
+//
+// calls that post offers are signed the same way as the HTTP API, with the
+// x-lilypad-user and x-lilypad-signature headers sent as request metadata
+type SolverServer interface {
+	SubmitJobOffer(context.Context, *SubmitJobOfferRequest) (*JobOfferContainer, error)
+	SubmitResourceOffer(context.Context, *SubmitResourceOfferRequest) (*ResourceOfferContainer, error)
+	// stream changes to deals as the solver makes them
+	WatchDeals(*WatchDealsRequest, Solver_WatchDealsServer) error
+	GetResults(context.Context, *GetResultsRequest) (*GetResultsResponse, error)
+	mustEmbedUnimplementedSolverServer()
+}
+
+// UnimplementedSolverServer must be embedded to have forward compatible implementations.
+type UnimplementedSolverServer struct {
+}
+
+func (UnimplementedSolverServer) SubmitJobOffer(context.Context, *SubmitJobOfferRequest) (*JobOfferContainer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitJobOffer not implemented")
+}
+func (UnimplementedSolverServer) SubmitResourceOffer(context.Context, *SubmitResourceOfferRequest) (*ResourceOfferContainer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitResourceOffer not implemented")
+}
+func (UnimplementedSolverServer) WatchDeals(*WatchDealsRequest, Solver_WatchDealsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchDeals not implemented")
+}
+func (UnimplementedSolverServer) GetResults(context.Context, *GetResultsRequest) (*GetResultsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResults not implemented")
+}
+func (UnimplementedSolverServer) mustEmbedUnimplementedSolverServer() {}
+
+// UnsafeSolverServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SolverServer will
+// result in compilation errors.
+type UnsafeSolverServer interface {
+	mustEmbedUnimplementedSolverServer()
+}
+
+func RegisterSolverServer(s grpc.ServiceRegistrar, srv SolverServer) {
+	s.RegisterService(&Solver_ServiceDesc, srv)
+}
+
+func _Solver_SubmitJobOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitJobOfferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SolverServer).SubmitJobOffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Solver_SubmitJobOffer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SolverServer).SubmitJobOffer(ctx, req.(*SubmitJobOfferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Solver_SubmitResourceOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitResourceOfferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SolverServer).SubmitResourceOffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Solver_SubmitResourceOffer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SolverServer).SubmitResourceOffer(ctx, req.(*SubmitResourceOfferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Solver_WatchDeals_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchDealsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SolverServer).WatchDeals(m, &solverWatchDealsServer{ServerStream: stream})
+}
+
+type Solver_WatchDealsServer interface {
+	Send(*DealEvent) error
+	grpc.ServerStream
+}
+
+type solverWatchDealsServer struct {
+	grpc.ServerStream
+}
+
+func (x *solverWatchDealsServer) Send(m *DealEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Solver_GetResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SolverServer).GetResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Solver_GetResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SolverServer).GetResults(ctx, req.(*GetResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Solver_ServiceDesc is the grpc.ServiceDesc for Solver service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Solver_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "lilypad.solver.v1.Solver",
+	HandlerType: (*SolverServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SubmitJobOffer",
+			Handler:    _Solver_SubmitJobOffer_Handler,
+		},
+		{
+			MethodName: "SubmitResourceOffer",
+			Handler:    _Solver_SubmitResourceOffer_Handler,
+		},
+		{
+			MethodName: "GetResults",
+			Handler:    _Solver_GetResults_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchDeals",
+			Handler:       _Solver_WatchDeals_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "solver.proto",
+}
@@ -0,0 +1,294 @@
+package solver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	corehttp "net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+)
+
+type PinningOptions struct {
+	// the pinning services we can ask to keep results as name=url
+	// each url is the root of an IPFS pinning service API
+	Services []string
+	// the bearer token for each service as name=token
+	Tokens []string
+	// how many services should be pinning each result
+	Replicas int
+	// how many seconds between checking on the pins
+	Interval int
+}
+
+const PINNING_REQUEST_TIMEOUT = 30 * time.Second
+
+// a remote that speaks the IPFS pinning service API
+// https://ipfs.github.io/pinning-services-api-spec/
+type pinningService struct {
+	name   string
+	url    string
+	token  string
+	client *corehttp.Client
+}
+
+type pinningServiceStatus struct {
+	RequestID string `json:"requestid"`
+	Status    string `json:"status"`
+}
+
+// parse name=value entries into a map
+func parsePinningEntries(entries []string, kind string) (map[string]string, error) {
+	values := map[string]string{}
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok || name == "" || value == "" {
+			return nil, fmt.Errorf("pinning %s %q must be name=%s", kind, entry, kind)
+		}
+		if _, ok := values[name]; ok {
+			return nil, fmt.Errorf("pinning %s %s is given twice", kind, name)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+func newPinningServices(options PinningOptions) (map[string]*pinningService, error) {
+	urls, err := parsePinningEntries(options.Services, "url")
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := parsePinningEntries(options.Tokens, "token")
+	if err != nil {
+		return nil, err
+	}
+	services := map[string]*pinningService{}
+	for name, serviceURL := range urls {
+		_, err := url.ParseRequestURI(serviceURL)
+		if err != nil {
+			return nil, fmt.Errorf("pinning service %s has an invalid url: %w", name, err)
+		}
+		services[name] = &pinningService{
+			name:   name,
+			url:    strings.TrimSuffix(serviceURL, "/"),
+			token:  tokens[name],
+			client: &corehttp.Client{Timeout: PINNING_REQUEST_TIMEOUT},
+		}
+	}
+	for name := range tokens {
+		if _, ok := services[name]; !ok {
+			return nil, fmt.Errorf("pinning token given for unknown service %s", name)
+		}
+	}
+	return services, nil
+}
+
+// make a request and decode the pin status in the response
+// a pin the service does not know about is reported as failed
+func (service *pinningService) do(ctx context.Context, method string, path string, body interface{}) (*pinningServiceStatus, error) {
+	var reqBody io.Reader
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(bs)
+	}
+	req, err := corehttp.NewRequestWithContext(ctx, method, service.url+path, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if service.token != "" {
+		req.Header.Set("Authorization", "Bearer "+service.token)
+	}
+	resp, err := service.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == corehttp.StatusNotFound {
+		return &pinningServiceStatus{Status: data.ResultPinFailed}, nil
+	}
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("pinning service %s returned %d: %s", service.name, resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	var status pinningServiceStatus
+	err = json.NewDecoder(resp.Body).Decode(&status)
+	if err != nil {
+		return nil, fmt.Errorf("pinning service %s returned an invalid status: %w", service.name, err)
+	}
+	return &status, nil
+}
+
+func (service *pinningService) pin(ctx context.Context, id string, name string) (*pinningServiceStatus, error) {
+	return service.do(ctx, corehttp.MethodPost, "/pins", map[string]string{
+		"cid":  id,
+		"name": name,
+	})
+}
+
+func (service *pinningService) status(ctx context.Context, requestID string) (*pinningServiceStatus, error) {
+	return service.do(ctx, corehttp.MethodGet, "/pins/"+url.PathEscape(requestID), nil)
+}
+
+// pick services to add pins on until there are enough healthy ones
+// services that have never been asked are tried before ones that failed
+func choosePinningServices(services []string, pins []data.ResultPin, replicas int) []string {
+	healthy := 0
+	pinned := map[string]data.ResultPin{}
+	for _, pin := range pins {
+		pinned[pin.Service] = pin
+		if pin.IsHealthy() {
+			healthy++
+		}
+	}
+	candidates := []string{}
+	for _, service := range services {
+		pin, ok := pinned[service]
+		if !ok || !pin.IsHealthy() {
+			candidates = append(candidates, service)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		_, iTried := pinned[candidates[i]]
+		_, jTried := pinned[candidates[j]]
+		if iTried != jTried {
+			return !iTried
+		}
+		// retry the pin that failed longest ago first
+		return pinned[candidates[i]].UpdatedAt < pinned[candidates[j]].UpdatedAt
+	})
+	needed := replicas - healthy
+	if needed <= 0 {
+		return []string{}
+	}
+	if needed > len(candidates) {
+		needed = len(candidates)
+	}
+	return candidates[:needed]
+}
+
+// ask for the latest status of the pins we think are healthy
+func (controller *SolverController) refreshResultPins(ctx context.Context, pins []data.ResultPin, now time.Time) ([]data.ResultPin, error) {
+	refreshed := []data.ResultPin{}
+	for _, pin := range pins {
+		service, ok := controller.pinningServices[pin.Service]
+		if !ok || !pin.IsHealthy() {
+			refreshed = append(refreshed, pin)
+			continue
+		}
+		status, err := service.status(ctx, pin.RequestID)
+		if err != nil {
+			// we cannot tell so leave the pin alone until next time
+			controller.log.Error("error checking result pin", err)
+			refreshed = append(refreshed, pin)
+			continue
+		}
+		if status.Status == pin.Status {
+			refreshed = append(refreshed, pin)
+			continue
+		}
+		pin.Status = status.Status
+		pin.Error = ""
+		if status.Status == data.ResultPinFailed {
+			pin.Error = "the pinning service dropped the pin"
+		}
+		pin.UpdatedAt = now.Unix()
+		updated, err := controller.store.AddResultPin(pin)
+		if err != nil {
+			return nil, err
+		}
+		controller.log.Info("result pin updated", *updated)
+		refreshed = append(refreshed, *updated)
+	}
+	return refreshed, nil
+}
+
+// check the pins of one result and ask for more if it is short
+func (controller *SolverController) replicateResult(ctx context.Context, result data.Result, services []string, now time.Time) error {
+	pins, err := controller.store.GetResultPins(store.GetResultPinsQuery{DealID: result.DealID})
+	if err != nil {
+		return err
+	}
+	pins, err = controller.refreshResultPins(ctx, pins, now)
+	if err != nil {
+		return err
+	}
+	for _, name := range choosePinningServices(services, pins, controller.options.Pinning.Replicas) {
+		pin := data.ResultPin{
+			DealID:    result.DealID,
+			CID:       result.DataID,
+			Service:   name,
+			CreatedAt: now.Unix(),
+			UpdatedAt: now.Unix(),
+		}
+		status, err := controller.pinningServices[name].pin(ctx, result.DataID, "lilypad-"+result.DealID)
+		if err != nil {
+			pin.Status = data.ResultPinFailed
+			pin.Error = err.Error()
+		} else {
+			pin.RequestID = status.RequestID
+			pin.Status = status.Status
+		}
+		_, err = controller.store.AddResultPin(pin)
+		if err != nil {
+			return err
+		}
+		controller.log.Info("result pin requested", pin)
+	}
+	return nil
+}
+
+// make sure every result we know about is pinned by enough services
+func (controller *SolverController) checkResultPins(ctx context.Context, now time.Time) error {
+	if len(controller.pinningServices) == 0 {
+		return nil
+	}
+	interval := time.Duration(controller.options.Pinning.Interval) * time.Second
+	if !controller.lastPinCheck.IsZero() && now.Sub(controller.lastPinCheck) < interval {
+		return nil
+	}
+	controller.lastPinCheck = now
+
+	services := []string{}
+	for name := range controller.pinningServices {
+		services = append(services, name)
+	}
+	sort.Strings(services)
+
+	deals, err := controller.store.GetDeals(store.GetDealsQuery{})
+	if err != nil {
+		return err
+	}
+	for _, deal := range deals {
+		result, err := controller.store.GetResult(deal.ID)
+		if err != nil {
+			return err
+		}
+		if result == nil || result.Error != "" {
+			continue
+		}
+		// only a CID can be pinned
+		_, err = cid.Decode(result.DataID)
+		if err != nil {
+			continue
+		}
+		err = controller.replicateResult(ctx, *result, services, now)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
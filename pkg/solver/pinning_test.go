@@ -0,0 +1,93 @@
+package solver
+
+import (
+	"context"
+	"encoding/json"
+	corehttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChoosePinningServices(t *testing.T) {
+	services := []string{"a", "b", "c"}
+	pin := func(service string, status string, updatedAt int64) data.ResultPin {
+		return data.ResultPin{Service: service, Status: status, UpdatedAt: updatedAt}
+	}
+
+	testCases := []struct {
+		name     string
+		pins     []data.ResultPin
+		replicas int
+		expected []string
+	}{
+		{name: "No pins yet", pins: []data.ResultPin{}, replicas: 2, expected: []string{"a", "b"}},
+		{name: "Enough healthy pins", pins: []data.ResultPin{pin("a", data.ResultPinPinned, 1), pin("b", data.ResultPinQueued, 1)}, replicas: 2, expected: []string{}},
+		{name: "Untried services first", pins: []data.ResultPin{pin("a", data.ResultPinFailed, 1), pin("b", data.ResultPinPinned, 1)}, replicas: 2, expected: []string{"c"}},
+		{name: "Retry the oldest failure", pins: []data.ResultPin{pin("a", data.ResultPinFailed, 5), pin("b", data.ResultPinFailed, 1), pin("c", data.ResultPinPinned, 1)}, replicas: 2, expected: []string{"b"}},
+		{name: "Not enough services", pins: []data.ResultPin{pin("a", data.ResultPinPinned, 1)}, replicas: 5, expected: []string{"b", "c"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, choosePinningServices(services, tc.pins, tc.replicas))
+		})
+	}
+}
+
+func TestNewPinningServices(t *testing.T) {
+	_, err := newPinningServices(PinningOptions{Services: []string{"pinata"}})
+	assert.Error(t, err)
+	_, err = newPinningServices(PinningOptions{Services: []string{"a=https://a.example"}, Tokens: []string{"b=secret"}})
+	assert.Error(t, err)
+
+	services, err := newPinningServices(PinningOptions{
+		Services: []string{"a=https://a.example/psa/"},
+		Tokens:   []string{"a=secret"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://a.example/psa", services["a"].url)
+	assert.Equal(t, "secret", services["a"].token)
+}
+
+func TestPinningService(t *testing.T) {
+	server := httptest.NewServer(corehttp.HandlerFunc(func(w corehttp.ResponseWriter, r *corehttp.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		switch {
+		case r.Method == corehttp.MethodPost && r.URL.Path == "/pins":
+			var body map[string]string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "bafy", body["cid"])
+			w.WriteHeader(corehttp.StatusAccepted)
+			w.Write([]byte(`{"requestid":"req1","status":"queued"}`))
+		case r.Method == corehttp.MethodGet && r.URL.Path == "/pins/req1":
+			w.Write([]byte(`{"requestid":"req1","status":"pinned"}`))
+		default:
+			corehttp.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	services, err := newPinningServices(PinningOptions{
+		Services: []string{"test=" + server.URL},
+		Tokens:   []string{"test=secret"},
+	})
+	assert.NoError(t, err)
+	service := services["test"]
+
+	status, err := service.pin(context.Background(), "bafy", "lilypad-deal")
+	assert.NoError(t, err)
+	assert.Equal(t, "req1", status.RequestID)
+	assert.Equal(t, data.ResultPinQueued, status.Status)
+
+	status, err = service.status(context.Background(), "req1")
+	assert.NoError(t, err)
+	assert.Equal(t, data.ResultPinPinned, status.Status)
+
+	// a pin the service has forgotten about has dropped
+	status, err = service.status(context.Background(), "gone")
+	assert.NoError(t, err)
+	assert.Equal(t, data.ResultPinFailed, status.Status)
+}
@@ -0,0 +1,48 @@
+package solver
+
+import (
+	"fmt"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+)
+
+// solver wide limits on the job inputs we are willing to broker
+// a zero value disables that particular limit
+type QuotaOptions struct {
+	// the largest input a single job offer can declare (Megabytes)
+	MaxInputSize int
+	// the most input a single job creator can have waiting
+	// in unmatched job offers at any one time (Megabytes)
+	MaxPendingInputSize int
+}
+
+// check the declared input size of a job offer against the solver quotas
+// this happens when the job offer is submitted so oversized jobs are rejected
+// before any resource provider has agreed to download them
+func (controller *SolverController) checkInputQuota(jobOffer data.JobOffer) error {
+	quota := controller.options.Quota
+
+	if quota.MaxInputSize > 0 && jobOffer.InputSize > quota.MaxInputSize {
+		return fmt.Errorf("job offer input size %dMB exceeds the solver limit of %dMB", jobOffer.InputSize, quota.MaxInputSize)
+	}
+
+	if quota.MaxPendingInputSize > 0 {
+		pendingJobOffers, err := controller.store.GetJobOffers(store.GetJobOffersQuery{
+			JobCreator: jobOffer.JobCreator,
+			NotMatched: true,
+		})
+		if err != nil {
+			return err
+		}
+		pendingInputSize := jobOffer.InputSize
+		for _, pendingJobOffer := range pendingJobOffers {
+			pendingInputSize += pendingJobOffer.JobOffer.InputSize
+		}
+		if pendingInputSize > quota.MaxPendingInputSize {
+			return fmt.Errorf("job creator %s would have %dMB of pending inputs which exceeds the solver limit of %dMB", jobOffer.JobCreator, pendingInputSize, quota.MaxPendingInputSize)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package solver
+
+import (
+	corehttp "net/http"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/http"
+)
+
+// a read-only solver serves the API from a store that a matching solver
+// writes to so explorers and dashboards do not load the matching node
+// it does not match, send transactions or follow the chain
+type ReadOnlyOptions struct {
+	Enabled bool
+}
+
+func (controller *SolverController) isReadOnly() bool {
+	return controller.options.ReadOnly.Enabled
+}
+
+func (controller *SolverController) checkWritable() error {
+	if controller.isReadOnly() {
+		return data.NewError(data.ErrReadOnly, "this solver is read-only, send changes to the matching solver")
+	}
+	return nil
+}
+
+// turn away anything that is not a read before it gets to a handler
+func (solverServer *solverServer) readOnlyMiddleware(next corehttp.Handler) corehttp.Handler {
+	return corehttp.HandlerFunc(func(res corehttp.ResponseWriter, req *corehttp.Request) {
+		switch req.Method {
+		case corehttp.MethodGet, corehttp.MethodHead, corehttp.MethodOptions:
+			next.ServeHTTP(res, req)
+			return
+		}
+		err := solverServer.controller.checkWritable()
+		if err != nil {
+			http.WriteError(res, req, err)
+			return
+		}
+		next.ServeHTTP(res, req)
+	})
+}
@@ -0,0 +1,42 @@
+package solver
+
+import (
+	corehttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/http"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadOnlyMiddleware(t *testing.T) {
+	handler := corehttp.HandlerFunc(func(res corehttp.ResponseWriter, req *corehttp.Request) {
+		res.WriteHeader(corehttp.StatusOK)
+	})
+
+	for _, readOnly := range []bool{false, true} {
+		solverServer := &solverServer{
+			controller: &SolverController{options: SolverOptions{ReadOnly: ReadOnlyOptions{Enabled: readOnly}}},
+		}
+		server := httptest.NewServer(solverServer.readOnlyMiddleware(handler))
+
+		res, err := corehttp.Get(server.URL + "/deals")
+		assert.NoError(t, err)
+		res.Body.Close()
+		assert.Equal(t, corehttp.StatusOK, res.StatusCode)
+
+		res, err = corehttp.Post(server.URL+"/job_offers", "application/json", nil)
+		assert.NoError(t, err)
+		if readOnly {
+			assert.Equal(t, corehttp.StatusMethodNotAllowed, res.StatusCode)
+			body := make([]byte, 1024)
+			n, _ := res.Body.Read(body)
+			assert.Equal(t, data.ErrReadOnly, http.ReadErrorResponse(res.StatusCode, body[:n]).Code)
+		} else {
+			assert.Equal(t, corehttp.StatusOK, res.StatusCode)
+		}
+		res.Body.Close()
+		server.Close()
+	}
+}
@@ -0,0 +1,96 @@
+package solver
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+)
+
+// add a job offer that runs the job of a deal again
+// the deal it makes records the deal it came from
+func (controller *SolverController) rerunDeal(ctx context.Context, deal data.DealContainer, request data.DealRerunRequest, idempotencyKey string) (*data.JobOfferContainer, error) {
+	jobOffer := data.NewRerunJobOffer(deal, request, time.Now())
+	jobOffer.IdempotencyKey = idempotencyKey
+	err := data.CheckJobOffer(jobOffer)
+	if err != nil {
+		return nil, data.WrapError(data.ErrInvalidRequest, err)
+	}
+	controller.log.Info("rerun deal", deal.ID)
+	return controller.addJobOffer(ctx, jobOffer)
+}
+
+// a job offer can only say it runs again a deal of the same job creator
+// so the lineage of a deal cannot be made up by someone else
+func (controller *SolverController) checkParentDeal(jobOffer data.JobOffer) error {
+	if jobOffer.ParentDealID == "" {
+		return nil
+	}
+	parent, err := controller.store.GetDeal(jobOffer.ParentDealID)
+	if err != nil {
+		return err
+	}
+	if parent == nil {
+		return data.NewError(data.ErrNotFound, "parent deal not found: %s", jobOffer.ParentDealID)
+	}
+	if !strings.EqualFold(parent.JobCreator, jobOffer.JobCreator) {
+		return data.NewError(data.ErrForbidden, "parent deal %s belongs to another job creator", jobOffer.ParentDealID)
+	}
+	return nil
+}
+
+// walk up the parent deals to the original and down every deal run again from this one
+func (controller *SolverController) getDealLineage(deal data.DealContainer) (data.DealLineage, error) {
+	lineage := data.DealLineage{
+		Deal:        deal,
+		Ancestors:   []data.DealContainer{},
+		Descendants: []data.DealContainer{},
+	}
+
+	parentID := deal.ParentDealID
+	for depth := 0; parentID != "" && depth < data.MAX_DEAL_LINEAGE_DEPTH; depth++ {
+		parent, err := controller.store.GetDeal(parentID)
+		if err != nil {
+			return data.DealLineage{}, err
+		}
+		// the parent was removed by the retention policy
+		if parent == nil {
+			break
+		}
+		lineage.Ancestors = append([]data.DealContainer{*parent}, lineage.Ancestors...)
+		parentID = parent.ParentDealID
+	}
+
+	seen := map[string]bool{deal.ID: true}
+	next := []string{deal.ID}
+	for depth := 0; len(next) > 0 && depth < data.MAX_DEAL_LINEAGE_DEPTH; depth++ {
+		children := []string{}
+		for _, id := range next {
+			deals, err := controller.store.GetDeals(store.GetDealsQuery{ParentDealID: id})
+			if err != nil {
+				return data.DealLineage{}, err
+			}
+			for _, child := range deals {
+				if seen[child.ID] {
+					continue
+				}
+				seen[child.ID] = true
+				lineage.Descendants = append(lineage.Descendants, child)
+				children = append(children, child.ID)
+			}
+		}
+		next = children
+	}
+	sort.SliceStable(lineage.Descendants, func(i, j int) bool {
+		a := lineage.Descendants[i].Deal.JobOffer
+		b := lineage.Descendants[j].Deal.JobOffer
+		if a.CreatedAt != b.CreatedAt {
+			return a.CreatedAt < b.CreatedAt
+		}
+		return lineage.Descendants[i].ID < lineage.Descendants[j].ID
+	})
+	return lineage, nil
+}
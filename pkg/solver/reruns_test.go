@@ -0,0 +1,77 @@
+package solver
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	memorystore "github.com/lilypad-tech/lilypad/pkg/solver/store/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDealLineage(t *testing.T) {
+	s, err := memorystore.NewSolverStoreMemory(memorystore.SolverStoreMemoryOptions{LogDir: t.TempDir()})
+	require.NoError(t, err)
+	controller := &SolverController{store: s}
+
+	// the store keeps its log between runs so the records need their own IDs
+	suffix := fmt.Sprintf("%d", time.Now().UnixNano())
+	jobCreator := "0xlineagejc" + suffix
+	addDeal := func(name string, parent string, createdAt int) data.DealContainer {
+		parentID := ""
+		if parent != "" {
+			parentID = parent + suffix
+		}
+		deal := data.GetDealContainer(data.Deal{
+			ID: name + suffix,
+			JobOffer: data.JobOffer{
+				JobCreator:   jobCreator,
+				CreatedAt:    createdAt,
+				ParentDealID: parentID,
+			},
+			ResourceOffer: data.ResourceOffer{ResourceProvider: "0xrp"},
+		})
+		_, err := s.AddDeal(deal)
+		require.NoError(t, err)
+		return deal
+	}
+	getIDs := func(deals []data.DealContainer) []string {
+		ids := []string{}
+		for _, deal := range deals {
+			ids = append(ids, deal.ID)
+		}
+		return ids
+	}
+
+	original := addDeal("original", "", 1)
+	retry := addDeal("retry", "original", 2)
+	second := addDeal("second", "retry", 4)
+	addDeal("other", "original", 3)
+
+	attempts, err := controller.getDealAttempts(second)
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+
+	lineage, err := controller.getDealLineage(retry)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"original" + suffix}, getIDs(lineage.Ancestors))
+	assert.Equal(t, []string{"second" + suffix}, getIDs(lineage.Descendants))
+
+	lineage, err = controller.getDealLineage(original)
+	require.NoError(t, err)
+	assert.Empty(t, lineage.Ancestors)
+	assert.Equal(t, []string{"retry" + suffix, "other" + suffix, "second" + suffix}, getIDs(lineage.Descendants))
+
+	rerun := data.NewRerunJobOffer(retry, data.DealRerunRequest{SameProvider: true}, time.Now())
+	assert.Equal(t, retry.ID, rerun.ParentDealID)
+	assert.Equal(t, "0xrp", rerun.Target.Address)
+	assert.NoError(t, controller.checkParentDeal(rerun))
+
+	rerun.JobCreator = "0xsomeoneelse"
+	assert.True(t, errors.Is(controller.checkParentDeal(rerun), data.ErrForbidden))
+	rerun.ParentDealID = "missing" + suffix
+	assert.True(t, errors.Is(controller.checkParentDeal(rerun), data.ErrNotFound))
+}
@@ -0,0 +1,154 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+)
+
+// the most reservations one job creator can have open at once
+const MAX_CAPACITY_RESERVATIONS_PER_JOB_CREATOR = 20
+
+// a resource provider cannot have more reserved in one window than
+// it has resource offers with us
+func (controller *SolverController) addCapacityReservation(submission data.CapacityReservationSubmission) (*data.CapacityReservation, error) {
+	reservation, err := data.NewCapacityReservation(submission, time.Now().Unix())
+	if err != nil {
+		return nil, err
+	}
+	existing, err := controller.store.GetCapacityReservation(reservation.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+	reservations, err := controller.store.GetCapacityReservations(store.GetCapacityReservationsQuery{
+		JobCreator: reservation.JobCreator,
+		Open:       true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(reservations) >= MAX_CAPACITY_RESERVATIONS_PER_JOB_CREATOR {
+		return nil, fmt.Errorf("job creator %s already has %d open reservations", reservation.JobCreator, len(reservations))
+	}
+
+	resourceOffers, err := controller.store.GetResourceOffers(store.GetResourceOffersQuery{
+		ResourceProvider: reservation.ResourceProvider,
+		Active:           true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	reserved, err := controller.store.GetCapacityReservations(store.GetCapacityReservationsQuery{
+		ResourceProvider: reservation.ResourceProvider,
+		Open:             true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	held := reservation.Count
+	for _, other := range reserved {
+		if other.StartAt < reservation.EndAt && reservation.StartAt < other.EndAt {
+			held += other.Count
+		}
+	}
+	if held > len(resourceOffers) {
+		return nil, fmt.Errorf("resource provider %s has %d resource offers and cannot hold %d in that window", reservation.ResourceProvider, len(resourceOffers), held)
+	}
+
+	controller.log.Info("add capacity reservation", reservation)
+	return controller.store.AddCapacityReservation(reservation)
+}
+
+// the job offers already matched are left to finish
+func (controller *SolverController) cancelCapacityReservation(reservation data.CapacityReservation) (*data.CapacityReservation, error) {
+	if !data.IsReservationOpen(reservation) {
+		return nil, fmt.Errorf("reservation %s is %s", reservation.ID, reservation.State)
+	}
+	controller.log.Info("cancel capacity reservation", reservation.ID)
+	err := controller.cancelReservationJobOffers(reservation)
+	if err != nil {
+		return nil, err
+	}
+	return controller.updateCapacityReservation(reservation, data.ReservationCancelled, "")
+}
+
+// the job offers of a reservation that never got a deal
+func (controller *SolverController) cancelReservationJobOffers(reservation data.CapacityReservation) error {
+	for _, id := range reservation.JobOffers {
+		jobOffer, err := controller.store.GetJobOffer(id)
+		if err != nil {
+			return err
+		}
+		if jobOffer == nil || jobOffer.DealID != "" || data.DealState(jobOffer.State) != data.DealNegotiating {
+			continue
+		}
+		_, err = controller.cancelJobOffer(*jobOffer)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (controller *SolverController) updateCapacityReservation(reservation data.CapacityReservation, state string, message string) (*data.CapacityReservation, error) {
+	reservation.State = state
+	reservation.Error = message
+	reservation.UpdatedAt = time.Now().Unix()
+	return controller.store.AddCapacityReservation(reservation)
+}
+
+// add the job offers of reservations whose window has started
+// and let go of the ones whose window is over
+func (controller *SolverController) runCapacityReservations(ctx context.Context, now time.Time) error {
+	reservations, err := controller.store.GetCapacityReservations(store.GetCapacityReservationsQuery{
+		Open: true,
+	})
+	if err != nil {
+		return err
+	}
+	for _, reservation := range reservations {
+		switch {
+		case now.Unix() >= reservation.EndAt && reservation.State == data.ReservationPending:
+			// the solver was down for the whole window
+			_, err = controller.updateCapacityReservation(reservation, data.ReservationFailed, "the window was over before it was started")
+		case now.Unix() >= reservation.EndAt:
+			err = controller.cancelReservationJobOffers(reservation)
+			if err == nil {
+				_, err = controller.updateCapacityReservation(reservation, data.ReservationCompleted, "")
+			}
+		case now.Unix() >= reservation.StartAt && reservation.State == data.ReservationPending:
+			err = controller.startCapacityReservation(ctx, reservation)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// a job offer that cannot be added fails the reservation rather than
+// stopping the solve so one bad reservation does not hold up the rest
+func (controller *SolverController) startCapacityReservation(ctx context.Context, reservation data.CapacityReservation) error {
+	controller.log.Info("start capacity reservation", reservation.ID)
+	for i := 0; i < reservation.Count; i++ {
+		jobOffer, addErr := controller.addJobOffer(ctx, data.GetReservationJobOffer(reservation, i))
+		if addErr != nil {
+			controller.log.Error(fmt.Sprintf("capacity reservation %s", reservation.ID), addErr)
+			err := controller.cancelReservationJobOffers(reservation)
+			if err != nil {
+				return err
+			}
+			_, err = controller.updateCapacityReservation(reservation, data.ReservationFailed, addErr.Error())
+			return err
+		}
+		reservation.JobOffers = append(reservation.JobOffers, jobOffer.ID)
+	}
+	_, err := controller.updateCapacityReservation(reservation, data.ReservationActive, "")
+	return err
+}
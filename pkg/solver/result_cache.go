@@ -0,0 +1,44 @@
+package solver
+
+import (
+	"os"
+	"sort"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+)
+
+// the newest deal of the job creator that ran the same work as the job offer
+// and whose result we still have the files for
+func (controller *SolverController) getReusableDeal(jobOffer data.JobOffer) (*data.DealContainer, error) {
+	key := data.GetJobResultKey(jobOffer)
+	if key == "" {
+		return nil, nil
+	}
+	deals, err := controller.store.GetDeals(store.GetDealsQuery{
+		JobCreator: jobOffer.JobCreator,
+		ResultKey:  key,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(deals, func(i, j int) bool {
+		return deals[i].Deal.JobOffer.CreatedAt > deals[j].Deal.JobOffer.CreatedAt
+	})
+	for _, deal := range deals {
+		result, err := controller.store.GetResult(deal.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !data.IsReusableDeal(deal, result, jobOffer) {
+			continue
+		}
+		// the retention policy can have removed the files
+		if _, err := os.Stat(GetDealsFilePath(deal.ID)); err != nil {
+			continue
+		}
+		controller.log.Info("reuse result", deal.ID)
+		return &deal, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,122 @@
+package solver
+
+import (
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/jsonl"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+)
+
+type RetentionOptions struct {
+	// archive deals this many days after they finished
+	// zero keeps everything in the store
+	Days int
+	// where the compressed jsonl archives are written
+	ArchiveDir string
+	// how many seconds between looking for deals to archive
+	Interval int
+}
+
+// a deal can be archived once it is finished, nothing is still
+// waiting on it and it finished before the cutoff
+func isDealArchivable(records *store.DealRecords, cutoff int64) bool {
+	if !data.DealState(records.Deal.State).IsTerminal() {
+		return false
+	}
+	if records.Deal.StateUpdatedAt == 0 || records.Deal.StateUpdatedAt > cutoff {
+		return false
+	}
+	// the audit needs the deal until it has run
+	if records.Audit != nil && records.Audit.State == data.AuditPending {
+		return false
+	}
+	return true
+}
+
+// write the records to a new gzipped jsonl file in the archive directory
+func writeDealArchive(dir string, now time.Time, records []*store.DealRecords) (string, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("lilypad_deals_%s.jsonl.gz", now.UTC().Format("20060102T150405Z")))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	writer := jsonl.NewWriter(gz)
+	for _, record := range records {
+		err = writer.Write(record)
+		if err != nil {
+			return "", err
+		}
+	}
+	err = gz.Close()
+	if err != nil {
+		return "", err
+	}
+	// make sure the archive is on disk before we remove anything
+	err = file.Sync()
+	if err != nil {
+		return "", err
+	}
+	return path, file.Close()
+}
+
+// archive finished deals older than the retention period
+// and remove them from the store
+func (controller *SolverController) archiveDeals(now time.Time) error {
+	cutoff := now.Add(-time.Duration(controller.options.Retention.Days) * 24 * time.Hour).Unix()
+	deals, err := controller.store.GetDeals(store.GetDealsQuery{})
+	if err != nil {
+		return err
+	}
+
+	archivable := []*store.DealRecords{}
+	for _, deal := range deals {
+		records, err := controller.store.GetDealRecords(deal.ID)
+		if err != nil {
+			return err
+		}
+		if records != nil && isDealArchivable(records, cutoff) {
+			archivable = append(archivable, records)
+		}
+	}
+	if len(archivable) == 0 {
+		return nil
+	}
+
+	path, err := writeDealArchive(controller.options.Retention.ArchiveDir, now, archivable)
+	if err != nil {
+		return err
+	}
+	for _, records := range archivable {
+		err = controller.store.RemoveDealRecords(records.Deal.ID)
+		if err != nil {
+			return err
+		}
+	}
+	controller.log.Info("archived deals", fmt.Sprintf("%d deals to %s", len(archivable), path))
+	return nil
+}
+
+// run the archive if retention is on and it is time to
+func (controller *SolverController) checkRetention(now time.Time) error {
+	if controller.options.Retention.Days <= 0 {
+		return nil
+	}
+	interval := time.Duration(controller.options.Retention.Interval) * time.Second
+	if !controller.lastArchive.IsZero() && now.Sub(controller.lastArchive) < interval {
+		return nil
+	}
+	controller.lastArchive = now
+	return controller.archiveDeals(now)
+}
@@ -0,0 +1,76 @@
+package solver
+
+import (
+	"compress/gzip"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/jsonl"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsDealArchivable(t *testing.T) {
+	getRecords := func(state data.DealState, updatedAt int64, audit *data.Audit) *store.DealRecords {
+		return &store.DealRecords{
+			Deal: data.DealContainer{
+				ID:             "deal",
+				State:          uint8(state),
+				StateUpdatedAt: updatedAt,
+			},
+			Audit: audit,
+		}
+	}
+
+	testCases := []struct {
+		name     string
+		records  *store.DealRecords
+		expected bool
+	}{
+		{name: "Finished before the cutoff", records: getRecords(data.ResultsAccepted, 50, nil), expected: true},
+		{name: "Timed out before the cutoff", records: getRecords(data.TimeoutSubmitResults, 50, nil), expected: true},
+		{name: "Finished after the cutoff", records: getRecords(data.ResultsAccepted, 150, nil), expected: false},
+		{name: "Still running", records: getRecords(data.DealAgreed, 50, nil), expected: false},
+		{name: "Unknown state change time", records: getRecords(data.ResultsAccepted, 0, nil), expected: false},
+		{name: "Audit pending", records: getRecords(data.ResultsAccepted, 50, &data.Audit{State: data.AuditPending}), expected: false},
+		{name: "Audit done", records: getRecords(data.ResultsAccepted, 50, &data.Audit{State: data.AuditPassed}), expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isDealArchivable(tc.records, 100))
+		})
+	}
+}
+
+func TestWriteDealArchive(t *testing.T) {
+	records := []*store.DealRecords{
+		{Deal: data.DealContainer{ID: "deal1"}},
+		{Deal: data.DealContainer{ID: "deal2"}},
+	}
+	path, err := writeDealArchive(t.TempDir(), time.Now(), records)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reader := jsonl.NewReader(gz)
+	for _, expected := range records {
+		var actual store.DealRecords
+		err = reader.ReadSingleLine(&actual)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, expected.Deal.ID, actual.Deal.ID)
+	}
+}
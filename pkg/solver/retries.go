@@ -0,0 +1,64 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+// a deal of a job offer with a retry policy has finished
+// so add the job offer again if the deal failed in a way the policy retries
+func (controller *SolverController) retryDeal(deal data.DealContainer) {
+	result, err := controller.store.GetResult(deal.ID)
+	if err != nil {
+		controller.log.Error("error loading result to retry deal", fmt.Errorf("deal %s: %v", deal.ID, err))
+		return
+	}
+	if !data.IsRetryableDeal(deal, result) {
+		return
+	}
+	attempts, err := controller.getDealAttempts(deal)
+	if err != nil {
+		controller.log.Error("error counting deal attempts", fmt.Errorf("deal %s: %v", deal.ID, err))
+		return
+	}
+	if !data.ShouldRetryDeal(deal, result, attempts) {
+		controller.log.Info("deal out of retries", fmt.Sprintf("%s after %d attempts", deal.ID, attempts))
+		return
+	}
+
+	jobOffer := data.NewRetryJobOffer(deal, time.Now())
+	err = data.CheckJobOffer(jobOffer)
+	if err != nil {
+		controller.log.Error("error checking retry job offer", fmt.Errorf("deal %s: %v", deal.ID, err))
+		return
+	}
+	jobOfferContainer, err := controller.addJobOffer(context.Background(), jobOffer)
+	if err != nil {
+		controller.log.Error("error adding retry job offer", fmt.Errorf("deal %s: %v", deal.ID, err))
+		return
+	}
+	controller.log.Info("retry deal", fmt.Sprintf("%s attempt %d as %s", deal.ID, attempts+1, jobOfferContainer.ID))
+}
+
+// how many deals the job has had up to and including this one
+// each retry records the deal it came from so we count the parents
+func (controller *SolverController) getDealAttempts(deal data.DealContainer) (int, error) {
+	attempts := 1
+	parentID := deal.ParentDealID
+	for parentID != "" && attempts < data.MAX_DEAL_LINEAGE_DEPTH {
+		attempts++
+		parent, err := controller.store.GetDeal(parentID)
+		if err != nil {
+			return 0, err
+		}
+		// the parent was removed by the retention policy
+		if parent == nil {
+			break
+		}
+		parentID = parent.ParentDealID
+	}
+	return attempts, nil
+}
@@ -0,0 +1,143 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+)
+
+// the most recurring jobs one job creator can have at once
+const MAX_JOB_SCHEDULES_PER_JOB_CREATOR = 100
+
+func (controller *SolverController) addJobSchedule(submission data.JobScheduleSubmission) (*data.JobSchedule, error) {
+	schedule, err := data.NewJobSchedule(submission, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	existing, err := controller.store.GetJobSchedule(schedule.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+	schedules, err := controller.store.GetJobSchedules(store.GetJobSchedulesQuery{
+		JobCreator: schedule.JobCreator,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(schedules) >= MAX_JOB_SCHEDULES_PER_JOB_CREATOR {
+		return nil, fmt.Errorf("job creator %s already has %d recurring jobs", schedule.JobCreator, len(schedules))
+	}
+	controller.log.Info("add job schedule", schedule)
+	return controller.store.AddJobSchedule(schedule)
+}
+
+func (controller *SolverController) updateJobSchedule(schedule data.JobSchedule, update data.JobScheduleUpdate) (*data.JobSchedule, error) {
+	if update.OverlapPolicy != "" {
+		err := data.CheckScheduleOverlapPolicy(update.OverlapPolicy)
+		if err != nil {
+			return nil, err
+		}
+		schedule.OverlapPolicy = update.OverlapPolicy
+	}
+	if update.Paused != nil {
+		schedule.Paused = *update.Paused
+	}
+	// a new expression or coming back from a pause starts from now
+	if update.Cron != "" || update.Paused != nil {
+		if update.Cron != "" {
+			schedule.Cron = update.Cron
+		}
+		err := data.ScheduleNextRun(&schedule, time.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+	controller.log.Info("update job schedule", schedule)
+	return controller.store.AddJobSchedule(schedule)
+}
+
+// the job offers already added are left to finish
+func (controller *SolverController) removeJobSchedule(schedule data.JobSchedule) (*data.JobSchedule, error) {
+	controller.log.Info("remove job schedule", schedule.ID)
+	err := controller.store.RemoveJobSchedule(schedule.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// add a job offer for each schedule whose tick has come
+func (controller *SolverController) runJobSchedules(ctx context.Context, now time.Time) error {
+	schedules, err := controller.store.GetJobSchedules(store.GetJobSchedulesQuery{})
+	if err != nil {
+		return err
+	}
+	for _, schedule := range schedules {
+		if schedule.Paused || schedule.NextRunAt > now.Unix() {
+			continue
+		}
+		err = controller.runJobSchedule(ctx, schedule, now)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// a job offer that cannot be added is recorded on the schedule
+// rather than stopping the solve so one bad schedule does not hold up the rest
+func (controller *SolverController) runJobSchedule(ctx context.Context, schedule data.JobSchedule, now time.Time) error {
+	tick := time.Unix(schedule.NextRunAt, 0)
+	schedule.LastError = ""
+
+	run := true
+	if schedule.LastJobOffer != "" && schedule.OverlapPolicy != data.ScheduleOverlapAllow {
+		last, err := controller.store.GetJobOffer(schedule.LastJobOffer)
+		if err != nil {
+			return err
+		}
+		if last != nil && !data.IsTerminalAgreementState(last.State) {
+			switch schedule.OverlapPolicy {
+			case data.ScheduleOverlapReplace:
+				_, err = controller.cancelJobOffer(*last)
+				if err != nil {
+					schedule.LastError = fmt.Sprintf("could not replace job offer %s: %s", last.ID, err.Error())
+					run = false
+				}
+			default:
+				run = false
+			}
+		}
+	}
+
+	if run {
+		jobOffer, err := controller.addJobOffer(ctx, data.GetScheduledJobOffer(schedule, tick))
+		if err != nil {
+			schedule.LastError = err.Error()
+		} else {
+			schedule.LastJobOffer = jobOffer.ID
+			schedule.LastRunAt = now.Unix()
+			schedule.Runs++
+		}
+	} else {
+		schedule.Skipped++
+	}
+	if schedule.LastError != "" {
+		controller.log.Error(fmt.Sprintf("job schedule %s", schedule.ID), fmt.Errorf("%s", schedule.LastError))
+	}
+
+	// ticks we missed while the solver was down are not made up
+	err := data.ScheduleNextRun(&schedule, now)
+	if err != nil {
+		schedule.Paused = true
+		schedule.LastError = err.Error()
+	}
+	_, err = controller.store.AddJobSchedule(schedule)
+	return err
+}
@@ -9,13 +9,19 @@ import (
 	corehttp "net/http"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/httprate"
 	"github.com/gorilla/mux"
+	"github.com/ipfs/go-cid"
 	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/escrow"
 	"github.com/lilypad-tech/lilypad/pkg/http"
+	"github.com/lilypad-tech/lilypad/pkg/ipfs"
 	"github.com/lilypad-tech/lilypad/pkg/metricsDashboard"
+	"github.com/lilypad-tech/lilypad/pkg/solver/stats"
 	"github.com/lilypad-tech/lilypad/pkg/solver/store"
 	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/rs/zerolog/log"
@@ -28,6 +34,9 @@ type solverServer struct {
 	controller *SolverController
 	store      store.SolverStore
 	services   data.ServiceConfig
+	// admin requests and job offer cancellations have to be signed
+	// for the request they are
+	signatures *http.RequestSignatureChecker
 }
 
 func NewSolverServer(
@@ -40,6 +49,7 @@ func NewSolverServer(
 		options:    options,
 		controller: controller,
 		store:      store,
+		signatures: http.NewRequestSignatureChecker(http.REQUEST_SIGNATURE_MAX_AGE),
 	}
 
 	metricsDashboard.Init(services.APIHost)
@@ -62,8 +72,18 @@ func NewSolverServer(
 func (solverServer *solverServer) ListenAndServe(ctx context.Context, cm *system.CleanupManager, tracerProvider *trace.TracerProvider) error {
 	router := mux.NewRouter()
 
-	subrouter := router.PathPrefix(http.API_SUB_PATH).Subrouter()
+	// health checks sit outside the API so they are not rate limited
+	router.HandleFunc("/healthz", healthHandler(solverServer.controller.getHealth)).Methods("GET")
+	router.HandleFunc("/readyz", healthHandler(solverServer.controller.getReadiness)).Methods("GET")
 
+	// clients ask this before they pick which version of the API to use
+	router.HandleFunc(http.API_PROTOCOL_PATH, http.GetHandler(solverServer.getProtocolInfo)).Methods("GET")
+
+	// the same routes are served for each protocol version we support
+	// the handlers answer in the version of the path they were called on
+	subrouter := router.PathPrefix(http.API_VERSIONED_SUB_PATH).Subrouter()
+
+	subrouter.Use(http.ProtocolMiddleware)
 	subrouter.Use(http.CorsMiddleware)
 	subrouter.Use(otelmux.Middleware("solver", otelmux.WithTracerProvider(tracerProvider)))
 	subrouter.Use(httprate.Limit(
@@ -72,24 +92,9 @@ func (solverServer *solverServer) ListenAndServe(ctx context.Context, cm *system
 		httprate.WithKeyFuncs(httprate.KeyByRealIP, httprate.KeyByEndpoint),
 	))
 
-	subrouter.HandleFunc("/job_offers", http.GetHandler(solverServer.getJobOffers)).Methods("GET")
-	subrouter.HandleFunc("/job_offers", http.PostHandler(solverServer.addJobOffer)).Methods("POST")
-
-	subrouter.HandleFunc("/resource_offers", http.GetHandler(solverServer.getResourceOffers)).Methods("GET")
-	subrouter.HandleFunc("/resource_offers", http.PostHandler(solverServer.addResourceOffer)).Methods("POST")
-
-	subrouter.HandleFunc("/deals", http.GetHandler(solverServer.getDeals)).Methods("GET")
-	subrouter.HandleFunc("/deals/{id}", http.GetHandler(solverServer.getDeal)).Methods("GET")
-
-	subrouter.HandleFunc("/deals/{id}/files", solverServer.downloadFiles).Methods("GET")
-	subrouter.HandleFunc("/deals/{id}/files", solverServer.uploadFiles).Methods("POST")
-
-	subrouter.HandleFunc("/deals/{id}/result", http.GetHandler(solverServer.getResult)).Methods("GET")
-	subrouter.HandleFunc("/deals/{id}/result", http.PostHandler(solverServer.addResult)).Methods("POST")
+	subrouter.Use(solverServer.readOnlyMiddleware)
 
-	subrouter.HandleFunc("/deals/{id}/txs/resource_provider", http.PostHandler(solverServer.updateTransactionsResourceProvider)).Methods("POST")
-	subrouter.HandleFunc("/deals/{id}/txs/job_creator", http.PostHandler(solverServer.updateTransactionsJobCreator)).Methods("POST")
-	subrouter.HandleFunc("/deals/{id}/txs/mediator", http.PostHandler(solverServer.updateTransactionsMediator)).Methods("POST")
+	solverServer.addRoutes(subrouter)
 
 	// this will fan out to all connected web socket connections
 	// we read all events coming from inside the solver controller
@@ -148,6 +153,120 @@ func (solverServer *solverServer) ListenAndServe(ctx context.Context, cm *system
 	return nil
 }
 
+// every route that is documented in the OpenAPI spec
+func (solverServer *solverServer) addRoutes(subrouter *mux.Router) {
+	subrouter.HandleFunc("/job_offers", http.GetHandler(solverServer.getJobOffers)).Methods("GET")
+	subrouter.HandleFunc("/job_offers", http.PostHandler(solverServer.addJobOffer)).Methods("POST")
+	subrouter.HandleFunc("/job_offers/{id}/cancel", http.PostHandler(solverServer.cancelJobOffer)).Methods("POST")
+
+	subrouter.HandleFunc("/job_groups", http.PostHandler(solverServer.addJobGroup)).Methods("POST")
+	subrouter.HandleFunc("/job_groups/{id}", http.GetHandler(solverServer.getJobGroup)).Methods("GET")
+
+	subrouter.HandleFunc("/job_schedules", http.GetHandler(solverServer.getJobSchedules)).Methods("GET")
+	subrouter.HandleFunc("/job_schedules", http.PostHandler(solverServer.addJobSchedule)).Methods("POST")
+	subrouter.HandleFunc("/job_schedules/{id}", http.GetHandler(solverServer.getJobSchedule)).Methods("GET")
+	subrouter.HandleFunc("/job_schedules/{id}", http.PostHandler(solverServer.updateJobSchedule)).Methods("POST")
+	subrouter.HandleFunc("/job_schedules/{id}/remove", http.PostHandler(solverServer.removeJobSchedule)).Methods("POST")
+
+	// the admin routes check the role of the signer themselves
+	subrouter.HandleFunc("/admin/stats", http.GetHandler(solverServer.getAdminStats)).Methods("GET")
+	subrouter.HandleFunc("/admin/actions", http.GetHandler(solverServer.getAdminActions)).Methods("GET")
+	subrouter.HandleFunc("/admin/banned_addresses", http.GetHandler(solverServer.getBannedAddresses)).Methods("GET")
+	subrouter.HandleFunc("/admin/banned_addresses", http.PostHandler(solverServer.banAddress)).Methods("POST")
+	subrouter.HandleFunc("/admin/banned_addresses/{address}/remove", http.PostHandler(solverServer.unbanAddress)).Methods("POST")
+	subrouter.HandleFunc("/admin/job_offers/{id}/expire", http.PostHandler(solverServer.expireJobOffer)).Methods("POST")
+	subrouter.HandleFunc("/admin/resource_offers/{id}/expire", http.PostHandler(solverServer.expireResourceOffer)).Methods("POST")
+	subrouter.HandleFunc("/admin/match", http.PostHandler(solverServer.triggerMatch)).Methods("POST")
+	subrouter.HandleFunc("/admin/allowlist/refresh", http.PostHandler(solverServer.refreshAllowlist)).Methods("POST")
+	subrouter.HandleFunc("/admin/export", solverServer.exportState).Methods("GET")
+	subrouter.HandleFunc("/admin/import", solverServer.importState).Methods("POST")
+
+	subrouter.HandleFunc("/capacity_reservations", http.GetHandler(solverServer.getCapacityReservations)).Methods("GET")
+	subrouter.HandleFunc("/capacity_reservations", http.PostHandler(solverServer.addCapacityReservation)).Methods("POST")
+	subrouter.HandleFunc("/capacity_reservations/{id}", http.GetHandler(solverServer.getCapacityReservation)).Methods("GET")
+	subrouter.HandleFunc("/capacity_reservations/{id}/cancel", http.PostHandler(solverServer.cancelCapacityReservation)).Methods("POST")
+
+	subrouter.HandleFunc("/input_stagings", http.GetHandler(solverServer.getInputStagings)).Methods("GET")
+	subrouter.HandleFunc("/input_stagings", http.PostHandler(solverServer.addInputStaging)).Methods("POST")
+	subrouter.HandleFunc("/input_stagings/{id}", http.GetHandler(solverServer.getInputStaging)).Methods("GET")
+	subrouter.HandleFunc("/input_stagings/{id}", http.PostHandler(solverServer.updateInputStaging)).Methods("POST")
+
+	subrouter.HandleFunc("/billing", http.GetHandler(solverServer.getBillingRecords)).Methods("GET")
+	subrouter.HandleFunc("/billing/csv", solverServer.exportBillingRecords).Methods("GET")
+
+	subrouter.HandleFunc("/workflows", http.GetHandler(solverServer.getWorkflows)).Methods("GET")
+	subrouter.HandleFunc("/workflows", http.PostHandler(solverServer.addWorkflow)).Methods("POST")
+	subrouter.HandleFunc("/workflows/{id}", http.GetHandler(solverServer.getWorkflow)).Methods("GET")
+	subrouter.HandleFunc("/workflows/{id}/cancel", http.PostHandler(solverServer.cancelWorkflow)).Methods("POST")
+
+	subrouter.HandleFunc("/resource_offers", http.GetHandler(solverServer.getResourceOffers)).Methods("GET")
+	subrouter.HandleFunc("/resource_offers", http.PostHandler(solverServer.addResourceOffer)).Methods("POST")
+	subrouter.HandleFunc("/resource_offers/withdraw", http.PostHandler(solverServer.withdrawResourceOffers)).Methods("POST")
+
+	subrouter.HandleFunc("/deals", http.GetHandler(solverServer.getDeals)).Methods("GET")
+	subrouter.HandleFunc("/deals/{id}", http.GetHandler(solverServer.getDeal)).Methods("GET")
+
+	subrouter.HandleFunc("/deals/{id}/receipt", http.GetHandler(solverServer.getDealReceipt)).Methods("GET")
+
+	subrouter.HandleFunc("/deals/{id}/rerun", http.PostHandler(solverServer.rerunDeal)).Methods("POST")
+	subrouter.HandleFunc("/deals/{id}/lineage", http.GetHandler(solverServer.getDealLineage)).Methods("GET")
+
+	subrouter.HandleFunc("/deals/{id}/files", solverServer.downloadFiles).Methods("GET")
+	subrouter.HandleFunc("/deals/{id}/files", solverServer.uploadFiles).Methods("POST")
+
+	subrouter.HandleFunc("/deals/{id}/logs", http.GetHandler(solverServer.getDealLogs)).Methods("GET")
+	subrouter.HandleFunc("/deals/{id}/logs", http.PostHandler(solverServer.addDealLogs)).Methods("POST")
+	subrouter.HandleFunc("/deals/{id}/logs/stream", solverServer.streamDealLogs).Methods("GET")
+
+	subrouter.HandleFunc("/deals/{id}/checkpoint", http.PostHandler(solverServer.updateDealCheckpoint)).Methods("POST")
+
+	subrouter.HandleFunc("/deals/{id}/service/requests", http.PostHandler(solverServer.routeServiceRequest)).Methods("POST")
+	subrouter.HandleFunc("/deals/{id}/service/requests", http.GetHandler(solverServer.getServiceRequests)).Methods("GET")
+	subrouter.HandleFunc("/deals/{id}/service/responses", http.PostHandler(solverServer.addServiceResponse)).Methods("POST")
+
+	subrouter.HandleFunc("/deals/{id}/encrypted_inputs", http.PostHandler(solverServer.addDealEncryptedInputs)).Methods("POST")
+
+	subrouter.HandleFunc("/deals/{id}/milestones/accept", http.PostHandler(solverServer.acceptDealMilestone)).Methods("POST")
+
+	subrouter.HandleFunc("/deals/{id}/preempt", http.PostHandler(solverServer.preemptDeal)).Methods("POST")
+
+	subrouter.HandleFunc("/deals/{id}/mediation_verdicts", http.PostHandler(solverServer.addMediationVerdict)).Methods("POST")
+
+	subrouter.HandleFunc("/deals/{id}/result", http.GetHandler(solverServer.getResult)).Methods("GET")
+	subrouter.HandleFunc("/deals/{id}/result", http.PostHandler(solverServer.addResult)).Methods("POST")
+	subrouter.HandleFunc("/deals/{id}/result/archive", solverServer.downloadVerifiedResult).Methods("GET")
+	subrouter.HandleFunc("/deals/{id}/result/pins", http.GetHandler(solverServer.getResultPins)).Methods("GET")
+
+	subrouter.HandleFunc("/deals/{id}/audit", http.GetHandler(solverServer.getAudit)).Methods("GET")
+
+	subrouter.HandleFunc("/deals/{id}/escrow", http.GetHandler(solverServer.getEscrowAccount)).Methods("GET")
+	subrouter.HandleFunc("/escrow/discrepancies", http.GetHandler(solverServer.getEscrowDiscrepancies)).Methods("GET")
+	subrouter.HandleFunc("/slashings", http.GetHandler(solverServer.getSlashings)).Methods("GET")
+
+	subrouter.HandleFunc("/price_gaps", http.GetHandler(solverServer.getPriceGaps)).Methods("GET")
+	subrouter.HandleFunc("/estimate", http.GetHandler(solverServer.getCostEstimate)).Methods("GET")
+
+	subrouter.HandleFunc("/resource_providers/{address}/reputation", http.GetHandler(solverServer.getReputation)).Methods("GET")
+	subrouter.HandleFunc("/job_creators/{address}/budget", http.GetHandler(solverServer.getBudget)).Methods("GET")
+	subrouter.HandleFunc("/resource_providers/{address}/sync", http.GetHandler(solverServer.getResourceProviderSync)).Methods("GET")
+
+	subrouter.HandleFunc("/stats", http.GetHandler(solverServer.getStats)).Methods("GET")
+
+	subrouter.HandleFunc("/clients", http.GetHandler(solverServer.getClientRecords)).Methods("GET")
+
+	subrouter.HandleFunc("/events", http.GetHandler(solverServer.getStoreEvents)).Methods("GET")
+
+	subrouter.HandleFunc("/transactions", http.GetHandler(solverServer.getTransactions)).Methods("GET")
+	subrouter.HandleFunc("/transactions/{id}", http.GetHandler(solverServer.getTransaction)).Methods("GET")
+
+	subrouter.HandleFunc("/deals/{id}/txs/resource_provider", http.PostHandler(solverServer.updateTransactionsResourceProvider)).Methods("POST")
+	subrouter.HandleFunc("/deals/{id}/txs/job_creator", http.PostHandler(solverServer.updateTransactionsJobCreator)).Methods("POST")
+	subrouter.HandleFunc("/deals/{id}/txs/mediator", http.PostHandler(solverServer.updateTransactionsMediator)).Methods("POST")
+
+	// keep this in step with solverAPIRoutes in openapi.go
+	subrouter.HandleFunc("/openapi.json", http.GetHandler(solverServer.getOpenAPISpec)).Methods("GET")
+}
+
 // WS connect events
 func (solverServer *solverServer) connectCB(connParams http.WSConnectionParams) {
 	if connParams.Type == "ResourceProvider" {
@@ -168,7 +287,10 @@ func (solverServer *solverServer) disconnectCB(connParams http.WSConnectionParam
 			CountryCode: connParams.CountryCode,
 			IP:          connParams.IP,
 		})
-		solverServer.controller.removeResourceOfferByResourceProvider(connParams.ID)
+		// the matching solver sees the disconnect and removes the offers
+		if !solverServer.controller.isReadOnly() {
+			solverServer.controller.removeResourceOfferByResourceProvider(connParams.ID)
+		}
 	}
 }
 
@@ -195,7 +317,16 @@ func (solverServer *solverServer) getJobOffers(res corehttp.ResponseWriter, req
 	if includeCancelled := req.URL.Query().Get("include_cancelled"); includeCancelled == "true" {
 		query.IncludeCancelled = true
 	}
-	return solverServer.store.GetJobOffers(query)
+	chainID, err := getChainIDQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	query.ChainID = chainID
+	jobOffers, err := solverServer.store.GetJobOffers(query)
+	if err != nil {
+		return nil, err
+	}
+	return solverServer.controller.addFiatToJobOffers(jobOffers), nil
 }
 
 func (solverServer *solverServer) getResourceOffers(res corehttp.ResponseWriter, req *corehttp.Request) ([]data.ResourceOfferContainer, error) {
@@ -210,6 +341,11 @@ func (solverServer *solverServer) getResourceOffers(res corehttp.ResponseWriter,
 	if notMatched := req.URL.Query().Get("not_matched"); notMatched == "true" {
 		query.NotMatched = true
 	}
+	chainID, err := getChainIDQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	query.ChainID = chainID
 	return solverServer.store.GetResourceOffers(query)
 }
 
@@ -225,7 +361,141 @@ func (solverServer *solverServer) getDeals(res corehttp.ResponseWriter, req *cor
 	if state := req.URL.Query().Get("state"); state != "" {
 		query.State = state
 	}
-	return solverServer.store.GetDeals(query)
+	if parentDealID := req.URL.Query().Get("parent_deal_id"); parentDealID != "" {
+		query.ParentDealID = parentDealID
+	}
+	chainID, err := getChainIDQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	query.ChainID = chainID
+	deals, err := solverServer.store.GetDeals(query)
+	if err != nil {
+		return nil, err
+	}
+	return solverServer.controller.addFiatToDeals(deals), nil
+}
+
+// the chain_id query param, zero if it is not given
+func getChainIDQuery(req *corehttp.Request) (int, error) {
+	chainID := req.URL.Query().Get("chain_id")
+	if chainID == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(chainID)
+	if err != nil || n < 0 {
+		return 0, http.HTTPError{
+			Message:    fmt.Sprintf("invalid chain_id: %s", chainID),
+			StatusCode: corehttp.StatusBadRequest,
+		}
+	}
+	return n, nil
+}
+
+func (solverServer *solverServer) getPriceGaps(res corehttp.ResponseWriter, req *corehttp.Request) ([]data.PriceGap, error) {
+	query := store.GetPriceGapsQuery{
+		JobOffer:         req.URL.Query().Get("job_offer"),
+		ResourceOffer:    req.URL.Query().Get("resource_offer"),
+		JobCreator:       req.URL.Query().Get("job_creator"),
+		ResourceProvider: req.URL.Query().Get("resource_provider"),
+	}
+	return solverServer.store.GetPriceGaps(query)
+}
+
+func (solverServer *solverServer) getCostEstimate(res corehttp.ResponseWriter, req *corehttp.Request) (data.CostEstimate, error) {
+	query := data.CostEstimateQuery{
+		Module: data.ModuleConfig{
+			Name: req.URL.Query().Get("module"),
+			Repo: req.URL.Query().Get("repo"),
+			Hash: req.URL.Query().Get("hash"),
+			Path: req.URL.Query().Get("path"),
+		},
+	}
+	specs := []struct {
+		name  string
+		value *int
+	}{
+		{"cpu", &query.Spec.CPU},
+		{"gpu", &query.Spec.GPU},
+		{"ram", &query.Spec.RAM},
+	}
+	for _, spec := range specs {
+		value := req.URL.Query().Get(spec.name)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return data.CostEstimate{}, http.HTTPError{
+				Message:    fmt.Sprintf("invalid %s: %s", spec.name, value),
+				StatusCode: corehttp.StatusBadRequest,
+			}
+		}
+		*spec.value = n
+	}
+	if instructions := req.URL.Query().Get("instructions"); instructions != "" {
+		n, err := strconv.ParseUint(instructions, 10, 64)
+		if err != nil {
+			return data.CostEstimate{}, http.HTTPError{
+				Message:    fmt.Sprintf("invalid instructions: %s", instructions),
+				StatusCode: corehttp.StatusBadRequest,
+			}
+		}
+		query.Instructions = n
+	}
+	chainID, err := getChainIDQuery(req)
+	if err != nil {
+		return data.CostEstimate{}, err
+	}
+	query.ChainID = chainID
+	return solverServer.controller.getCostEstimate(query)
+}
+
+// the default and largest page of store events
+const (
+	STORE_EVENTS_PAGE_SIZE     = 100
+	STORE_EVENTS_MAX_PAGE_SIZE = 1000
+)
+
+func (solverServer *solverServer) getStoreEvents(res corehttp.ResponseWriter, req *corehttp.Request) (data.StoreEventPage, error) {
+	query := store.GetStoreEventsQuery{
+		Limit:    STORE_EVENTS_PAGE_SIZE,
+		Type:     req.URL.Query().Get("type"),
+		ObjectID: req.URL.Query().Get("object_id"),
+	}
+	if cursor := req.URL.Query().Get("cursor"); cursor != "" {
+		after, err := strconv.ParseUint(cursor, 10, 64)
+		if err != nil {
+			return data.StoreEventPage{}, http.HTTPError{
+				Message:    fmt.Sprintf("invalid cursor: %s", cursor),
+				StatusCode: corehttp.StatusBadRequest,
+			}
+		}
+		query.After = after
+	}
+	if limit := req.URL.Query().Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 || n > STORE_EVENTS_MAX_PAGE_SIZE {
+			return data.StoreEventPage{}, http.HTTPError{
+				Message:    fmt.Sprintf("limit must be between 1 and %d", STORE_EVENTS_MAX_PAGE_SIZE),
+				StatusCode: corehttp.StatusBadRequest,
+			}
+		}
+		query.Limit = n
+	}
+	events, err := solverServer.store.GetStoreEvents(query)
+	if err != nil {
+		return data.StoreEventPage{}, err
+	}
+	// an empty page hands back the same cursor so clients can poll with it
+	page := data.StoreEventPage{
+		Events:     events,
+		NextCursor: query.After,
+	}
+	if len(events) > 0 {
+		page.NextCursor = events[len(events)-1].Sequence
+	}
+	return page, nil
 }
 
 /*
@@ -239,80 +509,726 @@ func (solverServer *solverServer) getDeals(res corehttp.ResponseWriter, req *cor
 *
 *
 */
+// the deal named by the id in the path, a missing one is a 404
+func (solverServer *solverServer) getDealOr404(req *corehttp.Request) (*data.DealContainer, error) {
+	id := mux.Vars(req)["id"]
+	deal, err := solverServer.store.GetDeal(id)
+	if err != nil {
+		return nil, err
+	}
+	if deal == nil {
+		return nil, http.HTTPError{
+			Message:    fmt.Sprintf("deal not found: %s", id),
+			StatusCode: corehttp.StatusNotFound,
+		}
+	}
+	return deal, nil
+}
+
 func (solverServer *solverServer) getDeal(res corehttp.ResponseWriter, req *corehttp.Request) (data.DealContainer, error) {
+	deal, err := solverServer.getDealOr404(req)
+	if err != nil {
+		return data.DealContainer{}, err
+	}
+	return solverServer.controller.addFiatToDeals([]data.DealContainer{*deal})[0], nil
+}
+
+func (solverServer *solverServer) getDealLineage(res corehttp.ResponseWriter, req *corehttp.Request) (data.DealLineage, error) {
+	id := mux.Vars(req)["id"]
+	deal, err := solverServer.store.GetDeal(id)
+	if err != nil {
+		return data.DealLineage{}, err
+	}
+	if deal == nil {
+		return data.DealLineage{}, data.NewError(data.ErrNotFound, "deal not found: %s", id)
+	}
+	return solverServer.controller.getDealLineage(*deal)
+}
+
+func (solverServer *solverServer) getResult(res corehttp.ResponseWriter, req *corehttp.Request) (data.Result, error) {
 	vars := mux.Vars(req)
 	id := vars["id"]
-	deal, err := solverServer.store.GetDeal(id)
+	result, err := solverServer.store.GetResult(id)
+	if err != nil {
+		return data.Result{}, err
+	}
+	if result == nil {
+		return data.Result{}, fmt.Errorf("result not found")
+	}
+	return *result, nil
+}
+
+func (solverServer *solverServer) getDealReceipt(res corehttp.ResponseWriter, req *corehttp.Request) (data.DealReceipt, error) {
+	vars := mux.Vars(req)
+	id := vars["id"]
+	receipt, err := solverServer.store.GetDealReceipt(id)
+	if err != nil {
+		return data.DealReceipt{}, err
+	}
+	if receipt == nil {
+		return data.DealReceipt{}, http.HTTPError{
+			Message:    fmt.Sprintf("no receipt for deal %s", id),
+			StatusCode: corehttp.StatusNotFound,
+		}
+	}
+	return *receipt, nil
+}
+
+func (solverServer *solverServer) getTransactions(res corehttp.ResponseWriter, req *corehttp.Request) ([]data.Transaction, error) {
+	query := store.GetTransactionsQuery{
+		DealID: req.URL.Query().Get("deal_id"),
+		Status: req.URL.Query().Get("status"),
+	}
+	chainID, err := getChainIDQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	query.ChainID = chainID
+	return solverServer.store.GetTransactions(query)
+}
+
+func (solverServer *solverServer) getTransaction(res corehttp.ResponseWriter, req *corehttp.Request) (data.Transaction, error) {
+	vars := mux.Vars(req)
+	id := vars["id"]
+	tx, err := solverServer.store.GetTransaction(id)
+	if err != nil {
+		return data.Transaction{}, err
+	}
+	if tx == nil {
+		return data.Transaction{}, http.HTTPError{
+			Message:    fmt.Sprintf("transaction not found: %s", id),
+			StatusCode: corehttp.StatusNotFound,
+		}
+	}
+	return *tx, nil
+}
+
+func (solverServer *solverServer) getResultPins(res corehttp.ResponseWriter, req *corehttp.Request) ([]data.ResultPin, error) {
+	vars := mux.Vars(req)
+	id := vars["id"]
+	return solverServer.store.GetResultPins(store.GetResultPinsQuery{
+		DealID: id,
+	})
+}
+
+func (solverServer *solverServer) getAudit(res corehttp.ResponseWriter, req *corehttp.Request) (data.Audit, error) {
+	vars := mux.Vars(req)
+	id := vars["id"]
+	audit, err := solverServer.store.GetAudit(id)
+	if err != nil {
+		return data.Audit{}, err
+	}
+	if audit == nil {
+		return data.Audit{}, fmt.Errorf("audit not found")
+	}
+	return *audit, nil
+}
+
+func (solverServer *solverServer) getStats(res corehttp.ResponseWriter, req *corehttp.Request) (stats.NetworkStats, error) {
+	return solverServer.controller.getNetworkStats()
+}
+
+func (solverServer *solverServer) getOpenAPISpec(res corehttp.ResponseWriter, req *corehttp.Request) (json.RawMessage, error) {
+	return GetOpenAPISpec()
+}
+
+func (solverServer *solverServer) getEscrowAccount(res corehttp.ResponseWriter, req *corehttp.Request) (escrow.DealAccount, error) {
+	vars := mux.Vars(req)
+	return solverServer.controller.getEscrowAccount(vars["id"])
+}
+
+func (solverServer *solverServer) getEscrowDiscrepancies(res corehttp.ResponseWriter, req *corehttp.Request) ([]escrow.DealAccount, error) {
+	return solverServer.controller.getEscrowDiscrepancies()
+}
+
+func (solverServer *solverServer) getSlashings(res corehttp.ResponseWriter, req *corehttp.Request) ([]data.EscrowPayment, error) {
+	query := store.GetSlashingsQuery{
+		Payee: req.URL.Query().Get("payee"),
+	}
+	if from := req.URL.Query().Get("from"); from != "" {
+		seconds, err := strconv.ParseInt(from, 10, 64)
+		if err != nil {
+			return nil, http.HTTPError{
+				Message:    fmt.Sprintf("from must be unix seconds: %s", from),
+				StatusCode: corehttp.StatusBadRequest,
+			}
+		}
+		query.From = seconds
+	}
+	return solverServer.store.GetSlashings(query)
+}
+
+func (solverServer *solverServer) getBudget(res corehttp.ResponseWriter, req *corehttp.Request) (data.BudgetStatus, error) {
+	vars := mux.Vars(req)
+	err := solverServer.checkAddressOrAdmin(req, vars["address"])
+	if err != nil {
+		return data.BudgetStatus{}, err
+	}
+	return solverServer.controller.getBudgetStatus(vars["address"])
+}
+
+func (solverServer *solverServer) getReputation(res corehttp.ResponseWriter, req *corehttp.Request) (data.ResourceProviderReputation, error) {
+	vars := mux.Vars(req)
+	return solverServer.controller.getReputation(vars["address"])
+}
+
+// a resource provider coming back sends the version and epoch it last
+// synced and gets only what changed since, no version gets everything
+func (solverServer *solverServer) getResourceProviderSync(res corehttp.ResponseWriter, req *corehttp.Request) (data.ResourceProviderSync, error) {
+	vars := mux.Vars(req)
+	since := uint64(0)
+	if version := req.URL.Query().Get("since"); version != "" {
+		parsed, err := strconv.ParseUint(version, 10, 64)
+		if err != nil {
+			return data.ResourceProviderSync{}, http.HTTPError{
+				Message:    fmt.Sprintf("invalid since version: %s", version),
+				StatusCode: corehttp.StatusBadRequest,
+			}
+		}
+		since = parsed
+	}
+	return solverServer.controller.getResourceProviderSync(vars["address"], since, req.URL.Query().Get("epoch"))
+}
+
+func (solverServer *solverServer) getProtocolInfo(res corehttp.ResponseWriter, req *corehttp.Request) (data.ProtocolInfo, error) {
+	return http.GetProtocolInfo(system.Version), nil
+}
+
+// the versions the job creators and resource providers we have seen are running
+func (solverServer *solverServer) getClientRecords(res corehttp.ResponseWriter, req *corehttp.Request) ([]data.ClientRecord, error) {
+	query := store.GetClientRecordsQuery{
+		Role: req.URL.Query().Get("role"),
+	}
+	if query.Role != "" && query.Role != data.ClientRoleJobCreator && query.Role != data.ClientRoleResourceProvider {
+		return nil, http.HTTPError{
+			Message:    fmt.Sprintf("invalid role: %s", query.Role),
+			StatusCode: corehttp.StatusBadRequest,
+		}
+	}
+	if since := req.URL.Query().Get("since"); since != "" {
+		parsed, err := strconv.ParseInt(since, 10, 64)
+		if err != nil {
+			return nil, http.HTTPError{
+				Message:    fmt.Sprintf("invalid since: %s", since),
+				StatusCode: corehttp.StatusBadRequest,
+			}
+		}
+		query.Since = parsed
+	}
+	return solverServer.store.GetClientRecords(query)
+}
+
+/*
+*
+*
+*
+
+	Adders
+
+*
+*
+*
+*/
+func (solverServer *solverServer) addJobOffer(jobOffer data.JobOffer, res corehttp.ResponseWriter, req *corehttp.Request) (*data.JobOfferContainer, error) {
+	signerAddress, err := solverServer.signatures.Check(req)
+	if err != nil {
+		log.Error().Err(err).Msgf("have error parsing user address")
+		return nil, data.WrapError(data.ErrUnauthorized, err)
+	}
+	// only the job creator can post a job offer
+	if signerAddress != jobOffer.JobCreator {
+		return nil, data.NewError(data.ErrForbidden, "job creator address does not match signer address")
+	}
+	err = solverServer.checkBanned(jobOffer.JobCreator)
+	if err != nil {
+		return nil, err
+	}
+	jobOffer.IdempotencyKey, err = getIdempotencyKey(req, jobOffer.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	err = data.CheckJobOffer(jobOffer)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error checking job offer")
+		return nil, data.WrapError(data.ErrInvalidRequest, err)
+	}
+	return solverServer.controller.addJobOffer(req.Context(), jobOffer)
+}
+
+func (solverServer *solverServer) addJobGroup(submission data.JobGroupSubmission, res corehttp.ResponseWriter, req *corehttp.Request) (*data.JobGroup, error) {
+	signerAddress, err := solverServer.signatures.Check(req)
+	if err != nil {
+		log.Error().Err(err).Msgf("have error parsing user address")
+		return nil, data.WrapError(data.ErrUnauthorized, err)
+	}
+	// only the job creator can post an array job
+	if signerAddress != submission.JobOffer.JobCreator {
+		return nil, data.NewError(data.ErrForbidden, "job creator address does not match signer address")
+	}
+	err = solverServer.checkBanned(submission.JobOffer.JobCreator)
+	if err != nil {
+		return nil, err
+	}
+	err = data.CheckJobGroupSubmission(submission)
+	if err != nil {
+		log.Error().Err(err).Msgf("Error checking job group")
+		return nil, http.HTTPError{
+			Message:    err.Error(),
+			StatusCode: corehttp.StatusBadRequest,
+		}
+	}
+	return solverServer.controller.addJobGroup(req.Context(), submission)
+}
+
+func (solverServer *solverServer) getJobGroup(res corehttp.ResponseWriter, req *corehttp.Request) (data.JobGroupStatus, error) {
+	id := mux.Vars(req)["id"]
+	group, err := solverServer.store.GetJobGroup(id)
+	if err != nil {
+		return data.JobGroupStatus{}, err
+	}
+	if group == nil {
+		return data.JobGroupStatus{}, http.HTTPError{
+			Message:    fmt.Sprintf("job group not found: %s", id),
+			StatusCode: corehttp.StatusNotFound,
+		}
+	}
+	return solverServer.controller.getJobGroupStatus(*group)
+}
+
+func (solverServer *solverServer) getJobSchedules(res corehttp.ResponseWriter, req *corehttp.Request) ([]data.JobSchedule, error) {
+	return solverServer.store.GetJobSchedules(store.GetJobSchedulesQuery{
+		JobCreator: req.URL.Query().Get("job_creator"),
+	})
+}
+
+func (solverServer *solverServer) addJobSchedule(submission data.JobScheduleSubmission, res corehttp.ResponseWriter, req *corehttp.Request) (*data.JobSchedule, error) {
+	signerAddress, err := solverServer.signatures.Check(req)
+	if err != nil {
+		log.Error().Err(err).Msgf("have error parsing user address")
+		return nil, data.WrapError(data.ErrUnauthorized, err)
+	}
+	// only the job creator can schedule their jobs
+	if signerAddress != submission.JobOffer.JobCreator {
+		return nil, data.NewError(data.ErrForbidden, "job creator address does not match signer address")
+	}
+	err = solverServer.checkBanned(submission.JobOffer.JobCreator)
+	if err != nil {
+		return nil, err
+	}
+	err = data.CheckJobScheduleSubmission(submission)
+	if err != nil {
+		return nil, http.HTTPError{
+			Message:    err.Error(),
+			StatusCode: corehttp.StatusBadRequest,
+		}
+	}
+	return solverServer.controller.addJobSchedule(submission)
+}
+
+func (solverServer *solverServer) getJobSchedule(res corehttp.ResponseWriter, req *corehttp.Request) (data.JobSchedule, error) {
+	schedule, err := solverServer.loadJobSchedule(req)
+	if err != nil {
+		return data.JobSchedule{}, err
+	}
+	return *schedule, nil
+}
+
+func (solverServer *solverServer) updateJobSchedule(update data.JobScheduleUpdate, res corehttp.ResponseWriter, req *corehttp.Request) (*data.JobSchedule, error) {
+	schedule, err := solverServer.loadSignedJobSchedule(req)
+	if err != nil {
+		return nil, err
+	}
+	if update.Cron != "" {
+		_, err = data.ParseCron(update.Cron)
+		if err != nil {
+			return nil, http.HTTPError{
+				Message:    err.Error(),
+				StatusCode: corehttp.StatusBadRequest,
+			}
+		}
+	}
+	return solverServer.controller.updateJobSchedule(*schedule, update)
+}
+
+func (solverServer *solverServer) removeJobSchedule(removal data.JobScheduleRemoval, res corehttp.ResponseWriter, req *corehttp.Request) (*data.JobSchedule, error) {
+	schedule, err := solverServer.loadSignedJobSchedule(req)
+	if err != nil {
+		return nil, err
+	}
+	return solverServer.controller.removeJobSchedule(*schedule)
+}
+
+func (solverServer *solverServer) loadJobSchedule(req *corehttp.Request) (*data.JobSchedule, error) {
+	id := mux.Vars(req)["id"]
+	schedule, err := solverServer.store.GetJobSchedule(id)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		return nil, http.HTTPError{
+			Message:    fmt.Sprintf("job schedule not found: %s", id),
+			StatusCode: corehttp.StatusNotFound,
+		}
+	}
+	return schedule, nil
+}
+
+// only the job creator can change their recurring jobs
+func (solverServer *solverServer) loadSignedJobSchedule(req *corehttp.Request) (*data.JobSchedule, error) {
+	schedule, err := solverServer.loadJobSchedule(req)
+	if err != nil {
+		return nil, err
+	}
+	// the signature has to be for this request so a change to a
+	// schedule cannot be sent again or applied to another one
+	signerAddress, err := solverServer.signatures.Check(req)
+	if err != nil {
+		log.Error().Err(err).Msgf("have error parsing user address")
+		return nil, data.WrapError(data.ErrUnauthorized, err)
+	}
+	if signerAddress != schedule.JobCreator {
+		return nil, data.NewError(data.ErrForbidden, "job creator address does not match signer address")
+	}
+	return schedule, nil
+}
+
+// a banned address cannot add anything that would become an offer
+func (solverServer *solverServer) checkBanned(address string) error {
+	err := solverServer.controller.checkBannedAddress(address)
+	if err != nil {
+		return http.HTTPError{
+			Message:    err.Error(),
+			StatusCode: corehttp.StatusForbidden,
+			Code:       data.GetErrorCodeOr(err, data.ErrBanned),
+		}
+	}
+	return nil
+}
+
+// the key can come in the header or the offer, if both are sent they have to agree
+func getIdempotencyKey(req *corehttp.Request, offerKey string) (string, error) {
+	headerKey := req.Header.Get(http.IDEMPOTENCY_KEY_HEADER)
+	if headerKey == "" {
+		return offerKey, nil
+	}
+	if offerKey != "" && offerKey != headerKey {
+		return "", data.NewError(data.ErrInvalidRequest, "%s header %q does not match the idempotency key %q of the offer", http.IDEMPOTENCY_KEY_HEADER, headerKey, offerKey)
+	}
+	return headerKey, nil
+}
+
+func (solverServer *solverServer) getCapacityReservations(res corehttp.ResponseWriter, req *corehttp.Request) ([]data.CapacityReservation, error) {
+	return solverServer.store.GetCapacityReservations(store.GetCapacityReservationsQuery{
+		JobCreator:       req.URL.Query().Get("job_creator"),
+		ResourceProvider: req.URL.Query().Get("resource_provider"),
+		Open:             req.URL.Query().Get("open") == "true",
+	})
+}
+
+func (solverServer *solverServer) addCapacityReservation(submission data.CapacityReservationSubmission, res corehttp.ResponseWriter, req *corehttp.Request) (*data.CapacityReservation, error) {
+	signerAddress, err := solverServer.signatures.Check(req)
+	if err != nil {
+		log.Error().Err(err).Msgf("have error parsing user address")
+		return nil, data.WrapError(data.ErrUnauthorized, err)
+	}
+	// only the job creator can reserve capacity for their jobs
+	if signerAddress != submission.JobOffer.JobCreator {
+		return nil, data.NewError(data.ErrForbidden, "job creator address does not match signer address")
+	}
+	err = solverServer.checkBanned(submission.JobOffer.JobCreator)
+	if err != nil {
+		return nil, err
+	}
+	err = data.CheckCapacityReservationSubmission(submission, time.Now().Unix())
+	if err != nil {
+		return nil, http.HTTPError{
+			Message:    err.Error(),
+			StatusCode: corehttp.StatusBadRequest,
+		}
+	}
+	reservation, err := solverServer.controller.addCapacityReservation(submission)
+	if err != nil {
+		return nil, http.HTTPError{
+			Message:    err.Error(),
+			StatusCode: corehttp.StatusConflict,
+			Code:       data.ErrInsufficientResources,
+		}
+	}
+	return reservation, nil
+}
+
+func (solverServer *solverServer) getCapacityReservation(res corehttp.ResponseWriter, req *corehttp.Request) (data.CapacityReservation, error) {
+	reservation, err := solverServer.loadCapacityReservation(req)
+	if err != nil {
+		return data.CapacityReservation{}, err
+	}
+	return *reservation, nil
+}
+
+func (solverServer *solverServer) cancelCapacityReservation(cancellation data.CapacityReservationCancellation, res corehttp.ResponseWriter, req *corehttp.Request) (*data.CapacityReservation, error) {
+	reservation, err := solverServer.loadCapacityReservation(req)
+	if err != nil {
+		return nil, err
+	}
+	signerAddress, err := solverServer.signatures.Check(req)
+	if err != nil {
+		log.Error().Err(err).Msgf("have error parsing user address")
+		return nil, data.WrapError(data.ErrUnauthorized, err)
+	}
+	// only the job creator can cancel their reservations
+	if signerAddress != reservation.JobCreator {
+		return nil, data.NewError(data.ErrForbidden, "job creator address does not match signer address")
+	}
+	cancelled, err := solverServer.controller.cancelCapacityReservation(*reservation)
+	if err != nil {
+		return nil, http.HTTPError{
+			Message:    err.Error(),
+			StatusCode: corehttp.StatusBadRequest,
+		}
+	}
+	return cancelled, nil
+}
+
+func (solverServer *solverServer) loadCapacityReservation(req *corehttp.Request) (*data.CapacityReservation, error) {
+	id := mux.Vars(req)["id"]
+	reservation, err := solverServer.store.GetCapacityReservation(id)
+	if err != nil {
+		return nil, err
+	}
+	if reservation == nil {
+		return nil, http.HTTPError{
+			Message:    fmt.Sprintf("capacity reservation not found: %s", id),
+			StatusCode: corehttp.StatusNotFound,
+		}
+	}
+	return reservation, nil
+}
+
+func (solverServer *solverServer) getInputStagings(res corehttp.ResponseWriter, req *corehttp.Request) ([]data.InputStaging, error) {
+	return solverServer.store.GetInputStagings(store.GetInputStagingsQuery{
+		JobCreator:       req.URL.Query().Get("job_creator"),
+		ResourceProvider: req.URL.Query().Get("resource_provider"),
+		State:            req.URL.Query().Get("state"),
+	})
+}
+
+func (solverServer *solverServer) addInputStaging(request data.InputStagingRequest, res corehttp.ResponseWriter, req *corehttp.Request) (*data.InputStaging, error) {
+	signerAddress, err := solverServer.signatures.Check(req)
+	if err != nil {
+		log.Error().Err(err).Msgf("have error parsing user address")
+		return nil, data.WrapError(data.ErrUnauthorized, err)
+	}
+	// only the job creator can ask for their inputs to be staged
+	if signerAddress != request.JobCreator {
+		return nil, data.NewError(data.ErrForbidden, "job creator address does not match signer address")
+	}
+	err = data.CheckInputStagingRequest(request)
+	if err != nil {
+		return nil, http.HTTPError{
+			Message:    err.Error(),
+			StatusCode: corehttp.StatusBadRequest,
+		}
+	}
+	return solverServer.controller.addInputStaging(req.Context(), request)
+}
+
+func (solverServer *solverServer) getInputStaging(res corehttp.ResponseWriter, req *corehttp.Request) (data.InputStaging, error) {
+	id := mux.Vars(req)["id"]
+	staging, err := solverServer.store.GetInputStaging(id)
+	if err != nil {
+		return data.InputStaging{}, err
+	}
+	if staging == nil {
+		return data.InputStaging{}, http.HTTPError{
+			Message:    fmt.Sprintf("input staging not found: %s", id),
+			StatusCode: corehttp.StatusNotFound,
+		}
+	}
+	return *staging, nil
+}
+
+func (solverServer *solverServer) updateInputStaging(update data.InputStagingUpdate, res corehttp.ResponseWriter, req *corehttp.Request) (*data.InputStaging, error) {
+	staging, err := solverServer.getInputStaging(res, req)
+	if err != nil {
+		return nil, err
+	}
+	signerAddress, err := solverServer.signatures.Check(req)
+	if err != nil {
+		log.Error().Err(err).Msgf("have error parsing user address")
+		return nil, data.WrapError(data.ErrUnauthorized, err)
+	}
+	// only the resource provider doing the staging can say how it went
+	if signerAddress != staging.ResourceProvider {
+		return nil, data.NewError(data.ErrForbidden, "resource provider address does not match signer address")
+	}
+	err = data.CheckInputStagingUpdate(update)
+	if err != nil {
+		return nil, http.HTTPError{
+			Message:    err.Error(),
+			StatusCode: corehttp.StatusBadRequest,
+		}
+	}
+	return solverServer.controller.updateInputStaging(staging, update)
+}
+
+func (solverServer *solverServer) getWorkflows(res corehttp.ResponseWriter, req *corehttp.Request) ([]data.Workflow, error) {
+	return solverServer.store.GetWorkflows(store.GetWorkflowsQuery{
+		JobCreator: req.URL.Query().Get("job_creator"),
+		Running:    req.URL.Query().Get("running") == "true",
+	})
+}
+
+func (solverServer *solverServer) addWorkflow(submission data.WorkflowSubmission, res corehttp.ResponseWriter, req *corehttp.Request) (*data.Workflow, error) {
+	signerAddress, err := solverServer.signatures.Check(req)
+	if err != nil {
+		log.Error().Err(err).Msgf("have error parsing user address")
+		return nil, data.WrapError(data.ErrUnauthorized, err)
+	}
+	// only the job creator can run their workflows
+	if signerAddress != submission.JobCreator {
+		return nil, data.NewError(data.ErrForbidden, "job creator address does not match signer address")
+	}
+	err = solverServer.checkBanned(submission.JobCreator)
+	if err != nil {
+		return nil, err
+	}
+	err = data.CheckWorkflowSubmission(submission)
+	if err != nil {
+		return nil, http.HTTPError{
+			Message:    err.Error(),
+			StatusCode: corehttp.StatusBadRequest,
+		}
+	}
+	return solverServer.controller.addWorkflow(submission)
+}
+
+func (solverServer *solverServer) getWorkflow(res corehttp.ResponseWriter, req *corehttp.Request) (data.Workflow, error) {
+	id := mux.Vars(req)["id"]
+	workflow, err := solverServer.store.GetWorkflow(id)
+	if err != nil {
+		return data.Workflow{}, err
+	}
+	if workflow == nil {
+		return data.Workflow{}, http.HTTPError{
+			Message:    fmt.Sprintf("workflow not found: %s", id),
+			StatusCode: corehttp.StatusNotFound,
+		}
+	}
+	return *workflow, nil
+}
+
+func (solverServer *solverServer) cancelWorkflow(cancellation data.WorkflowCancellation, res corehttp.ResponseWriter, req *corehttp.Request) (*data.Workflow, error) {
+	workflow, err := solverServer.getWorkflow(res, req)
+	if err != nil {
+		return nil, err
+	}
+	signerAddress, err := solverServer.signatures.Check(req)
+	if err != nil {
+		log.Error().Err(err).Msgf("have error parsing user address")
+		return nil, data.WrapError(data.ErrUnauthorized, err)
+	}
+	// only the job creator can cancel their workflows
+	if signerAddress != workflow.JobCreator {
+		return nil, data.NewError(data.ErrForbidden, "job creator address does not match signer address")
+	}
+	return solverServer.controller.cancelWorkflow(workflow)
+}
+
+func (solverServer *solverServer) cancelJobOffer(cancellation data.JobOfferCancellation, res corehttp.ResponseWriter, req *corehttp.Request) (*data.JobOfferContainer, error) {
+	id := mux.Vars(req)["id"]
+	jobOffer, err := solverServer.store.GetJobOffer(id)
+	if err != nil {
+		return nil, err
+	}
+	if jobOffer == nil {
+		return nil, http.HTTPError{
+			Message:    fmt.Sprintf("job offer not found: %s", id),
+			StatusCode: corehttp.StatusNotFound,
+		}
+	}
+	// a cancellation stops a running job so the signature has to be for
+	// this request, one seen before cannot be sent again
+	signerAddress, err := solverServer.signatures.Check(req)
 	if err != nil {
-		return data.DealContainer{}, err
+		log.Error().Err(err).Msgf("have error parsing user address")
+		return nil, data.WrapError(data.ErrUnauthorized, err)
 	}
-	if deal == nil {
-		return data.DealContainer{}, fmt.Errorf("deal not found")
+	// only the job creator can cancel their job
+	if signerAddress != jobOffer.JobCreator {
+		return nil, data.NewError(data.ErrForbidden, "job creator address does not match signer address")
 	}
-	return *deal, nil
+	return solverServer.controller.cancelJobOffer(*jobOffer)
 }
 
-func (solverServer *solverServer) getResult(res corehttp.ResponseWriter, req *corehttp.Request) (data.Result, error) {
-	vars := mux.Vars(req)
-	id := vars["id"]
-	result, err := solverServer.store.GetResult(id)
+func (solverServer *solverServer) rerunDeal(request data.DealRerunRequest, res corehttp.ResponseWriter, req *corehttp.Request) (*data.JobOfferContainer, error) {
+	id := mux.Vars(req)["id"]
+	deal, err := solverServer.store.GetDeal(id)
 	if err != nil {
-		return data.Result{}, err
+		return nil, err
 	}
-	if result == nil {
-		return data.Result{}, fmt.Errorf("result not found")
+	if deal == nil {
+		return nil, data.NewError(data.ErrNotFound, "deal not found: %s", id)
 	}
-	return *result, nil
-}
-
-/*
-*
-*
-*
-
-	Adders
-
-*
-*
-*
-*/
-func (solverServer *solverServer) addJobOffer(jobOffer data.JobOffer, res corehttp.ResponseWriter, req *corehttp.Request) (*data.JobOfferContainer, error) {
-	signerAddress, err := http.GetAddressFromHeaders(req)
+	signerAddress, err := solverServer.signatures.Check(req)
 	if err != nil {
 		log.Error().Err(err).Msgf("have error parsing user address")
-		return nil, err
+		return nil, data.WrapError(data.ErrUnauthorized, err)
 	}
-	// only the job creator can post a job offer
-	if signerAddress != jobOffer.JobCreator {
-		return nil, fmt.Errorf("job creator address does not match signer address")
+	// only the job creator can run their job again
+	if signerAddress != deal.JobCreator {
+		return nil, data.NewError(data.ErrForbidden, "job creator address does not match signer address")
 	}
-	err = data.CheckJobOffer(jobOffer)
+	err = solverServer.checkBanned(deal.JobCreator)
+	if err != nil {
+		return nil, err
+	}
+	idempotencyKey, err := getIdempotencyKey(req, "")
 	if err != nil {
-		log.Error().Err(err).Msgf("Error checking job offer")
 		return nil, err
 	}
-	return solverServer.controller.addJobOffer(jobOffer)
+	return solverServer.controller.rerunDeal(req.Context(), *deal, request, idempotencyKey)
 }
 
 func (solverServer *solverServer) addResourceOffer(resourceOffer data.ResourceOffer, res corehttp.ResponseWriter, req *corehttp.Request) (*data.ResourceOfferContainer, error) {
 	versionHeader, _ := http.GetVersionFromHeaders(req)
 	log.Debug().Msgf("resource provider adding offer with version header %s", versionHeader)
 
-	signerAddress, err := http.GetAddressFromHeaders(req)
+	signerAddress, err := solverServer.signatures.Check(req)
 	if err != nil {
 		log.Error().Err(err).Msgf("have error parsing user address")
-		return nil, err
+		return nil, data.WrapError(data.ErrUnauthorized, err)
 	}
 	// only the job creator can post a job offer
 	if signerAddress != resourceOffer.ResourceProvider {
-		return nil, fmt.Errorf("resource provider address does not match signer address")
+		return nil, data.NewError(data.ErrForbidden, "resource provider address does not match signer address")
+	}
+	err = solverServer.checkBanned(resourceOffer.ResourceProvider)
+	if err != nil {
+		return nil, err
+	}
+	resourceOffer.IdempotencyKey, err = getIdempotencyKey(req, resourceOffer.IdempotencyKey)
+	if err != nil {
+		return nil, err
 	}
 	err = data.CheckResourceOffer(resourceOffer)
 	if err != nil {
 		log.Error().Err(err).Msgf("Error checking resource offer")
-		return nil, err
+		return nil, data.WrapError(data.ErrInvalidRequest, err)
+	}
+	return solverServer.controller.addResourceOffer(req.Context(), resourceOffer)
+}
+
+func (solverServer *solverServer) withdrawResourceOffers(query store.GetResourceOffersQuery, res corehttp.ResponseWriter, req *corehttp.Request) ([]data.ResourceOfferContainer, error) {
+	signerAddress, err := solverServer.signatures.Check(req)
+	if err != nil {
+		log.Error().Err(err).Msgf("have error parsing user address")
+		return nil, data.WrapError(data.ErrUnauthorized, err)
 	}
-	return solverServer.controller.addResourceOffer(resourceOffer)
+	// only the resource provider can withdraw their own offers
+	if signerAddress != query.ResourceProvider {
+		return nil, data.NewError(data.ErrForbidden, "resource provider address does not match signer address")
+	}
+	return solverServer.controller.withdrawResourceOffers(query.ResourceProvider)
 }
 
 func (solverServer *solverServer) addResult(results data.Result, res corehttp.ResponseWriter, req *corehttp.Request) (*data.Result, error) {
@@ -326,14 +1242,14 @@ func (solverServer *solverServer) addResult(results data.Result, res corehttp.Re
 	if deal == nil {
 		return nil, fmt.Errorf("deal not found")
 	}
-	signerAddress, err := http.GetAddressFromHeaders(req)
+	signerAddress, err := solverServer.signatures.Check(req)
 	if err != nil {
 		log.Error().Err(err).Msgf("have error parsing user address")
-		return nil, err
+		return nil, data.WrapError(data.ErrUnauthorized, err)
 	}
 	// only the resource provider can add a result
 	if signerAddress != deal.ResourceProvider {
-		return nil, fmt.Errorf("resource provider address does not match signer address")
+		return nil, data.NewError(data.ErrForbidden, "resource provider address does not match signer address")
 	}
 	err = data.CheckResult(results)
 	if err != nil {
@@ -341,7 +1257,7 @@ func (solverServer *solverServer) addResult(results data.Result, res corehttp.Re
 		return nil, err
 	}
 	results.DealID = id
-	return solverServer.store.AddResult(results)
+	return solverServer.controller.addResult(req.Context(), *deal, results)
 }
 
 /*
@@ -367,16 +1283,16 @@ func (solverServer *solverServer) updateTransactionsResourceProvider(payload dat
 		log.Error().Err(err).Msgf("deal not found")
 		return nil, fmt.Errorf("deal not found")
 	}
-	signerAddress, err := http.GetAddressFromHeaders(req)
+	signerAddress, err := solverServer.signatures.Check(req)
 	if err != nil {
 		log.Error().Err(err).Msgf("have error parsing user address")
-		return nil, err
+		return nil, data.WrapError(data.ErrUnauthorized, err)
 	}
 	// only the job creator can post a job offer
 	if signerAddress != deal.ResourceProvider {
-		return nil, fmt.Errorf("resource provider address does not match signer address")
+		return nil, data.NewError(data.ErrForbidden, "resource provider address does not match signer address")
 	}
-	return solverServer.controller.updateDealTransactionsResourceProvider(id, payload)
+	return solverServer.controller.updateDealTransactionsResourceProvider(req.Context(), id, payload)
 }
 
 func (solverServer *solverServer) updateTransactionsJobCreator(payload data.DealTransactionsJobCreator, res corehttp.ResponseWriter, req *corehttp.Request) (*data.DealContainer, error) {
@@ -391,16 +1307,16 @@ func (solverServer *solverServer) updateTransactionsJobCreator(payload data.Deal
 		log.Error().Err(err).Msgf("deal not found")
 		return nil, fmt.Errorf("deal not found")
 	}
-	signerAddress, err := http.GetAddressFromHeaders(req)
+	signerAddress, err := solverServer.signatures.Check(req)
 	if err != nil {
 		log.Error().Err(err).Msgf("have error parsing user address")
-		return nil, err
+		return nil, data.WrapError(data.ErrUnauthorized, err)
 	}
 	// only the job creator can post a job offer
 	if signerAddress != deal.JobCreator {
-		return nil, fmt.Errorf("job creator address does not match signer address")
+		return nil, data.NewError(data.ErrForbidden, "job creator address does not match signer address")
 	}
-	return solverServer.controller.updateDealTransactionsJobCreator(id, payload)
+	return solverServer.controller.updateDealTransactionsJobCreator(req.Context(), id, payload)
 }
 
 func (solverServer *solverServer) updateTransactionsMediator(payload data.DealTransactionsMediator, res corehttp.ResponseWriter, req *corehttp.Request) (*data.DealContainer, error) {
@@ -415,16 +1331,16 @@ func (solverServer *solverServer) updateTransactionsMediator(payload data.DealTr
 		log.Error().Err(err).Msgf("deal not found")
 		return nil, fmt.Errorf("deal not found")
 	}
-	signerAddress, err := http.GetAddressFromHeaders(req)
+	signerAddress, err := solverServer.signatures.Check(req)
 	if err != nil {
 		log.Error().Err(err).Msgf("have error parsing user address")
-		return nil, err
+		return nil, data.WrapError(data.ErrUnauthorized, err)
 	}
 	// only the job creator can post a job offer
 	if signerAddress != deal.Mediator {
 		return nil, fmt.Errorf("job creator address does not match mediator address")
 	}
-	return solverServer.controller.updateDealTransactionsMediator(id, payload)
+	return solverServer.controller.updateDealTransactionsMediator(req.Context(), id, payload)
 }
 
 /*
@@ -466,7 +1382,22 @@ func (solverServer *solverServer) downloadFiles(res corehttp.ResponseWriter, req
 				StatusCode: corehttp.StatusNotFound,
 			}
 		}
-		buf, err := system.GetTarBuffer(filesPath)
+		archivePath, checksum, err := EnsureDealsArchive(id)
+		if err != nil {
+			return &http.HTTPError{
+				Message:    err.Error(),
+				StatusCode: corehttp.StatusInternalServerError,
+			}
+		}
+		archive, err := os.Open(archivePath)
+		if err != nil {
+			return &http.HTTPError{
+				Message:    err.Error(),
+				StatusCode: corehttp.StatusInternalServerError,
+			}
+		}
+		defer archive.Close()
+		info, err := archive.Stat()
 		if err != nil {
 			return &http.HTTPError{
 				Message:    err.Error(),
@@ -475,13 +1406,18 @@ func (solverServer *solverServer) downloadFiles(res corehttp.ResponseWriter, req
 		}
 		res.Header().Set("Content-Disposition", "attachment; filename=archive.tar")
 		res.Header().Set("Content-Type", "application/x-tar")
-		io.Copy(res, buf)
+		// the ETag lets a client resume with If-Range and the checksum
+		// lets it check the whole archive once it has every byte
+		res.Header().Set("ETag", fmt.Sprintf("%q", checksum))
+		res.Header().Set(http.X_LILYPAD_CHECKSUM_HEADER, checksum)
+		// ServeContent answers Range requests for us
+		corehttp.ServeContent(res, req, "archive.tar", info.ModTime(), archive)
 		return nil
 	}()
 
 	if err != nil {
 		log.Ctx(req.Context()).Error().Msgf("error for route: %s", err.Error())
-		corehttp.Error(res, err.Error(), err.StatusCode)
+		http.WriteError(res, req, err)
 		return
 	}
 }
@@ -500,14 +1436,18 @@ func (solverServer *solverServer) uploadFiles(res corehttp.ResponseWriter, req *
 			log.Error().Msgf("deal not found")
 			return err
 		}
-		signerAddress, err := http.GetAddressFromHeaders(req)
+		signerAddress, err := solverServer.signatures.Check(req)
 		if err != nil {
 			log.Error().Err(err).Msgf("have error parsing user address")
-			return err
+			return data.WrapError(data.ErrUnauthorized, err)
 		}
 		// only the resource provider can add a result
 		if signerAddress != deal.ResourceProvider {
-			return fmt.Errorf("resource provider address does not match signer address")
+			return data.NewError(data.ErrForbidden, "resource provider address does not match signer address")
+		}
+		err = RemoveDealsArchive(id)
+		if err != nil {
+			return err
 		}
 		tr := tar.NewReader(req.Body)
 		uploadPath, err := EnsureDealsFilePath(id)
@@ -544,7 +1484,7 @@ func (solverServer *solverServer) uploadFiles(res corehttp.ResponseWriter, req *
 
 	if err != nil {
 		log.Ctx(req.Context()).Error().Msgf("error for route: %s", err.Error())
-		corehttp.Error(res, err.Error(), corehttp.StatusInternalServerError)
+		http.WriteError(res, req, err)
 		return
 	}
 
@@ -558,3 +1498,361 @@ func (solverServer *solverServer) uploadFiles(res corehttp.ResponseWriter, req *
 		return
 	}
 }
+
+// stream the result files from IPFS as a tar archive checking every
+// block against the result CID so the job creator does not need to
+// reach IPFS or trust us - a bad block cuts the response off and the
+// X-Lilypad-Verified trailer is only sent if every block matched
+func (solverServer *solverServer) downloadVerifiedResult(res corehttp.ResponseWriter, req *corehttp.Request) {
+	vars := mux.Vars(req)
+	id := vars["id"]
+
+	root, err := func() (cid.Cid, *http.HTTPError) {
+		result, err := solverServer.store.GetResult(id)
+		if err != nil {
+			return cid.Undef, &http.HTTPError{
+				Message:    err.Error(),
+				StatusCode: corehttp.StatusInternalServerError,
+			}
+		}
+		if result == nil {
+			return cid.Undef, &http.HTTPError{
+				Message:    fmt.Sprintf("result not found: %s", id),
+				StatusCode: corehttp.StatusNotFound,
+			}
+		}
+		if solverServer.controller.ipfs == nil {
+			return cid.Undef, &http.HTTPError{
+				Message:    "the solver has no IPFS node to fetch results from",
+				StatusCode: corehttp.StatusServiceUnavailable,
+			}
+		}
+		root, err := cid.Decode(result.DataID)
+		if err != nil {
+			return cid.Undef, &http.HTTPError{
+				Message:    fmt.Sprintf("result data ID is not a CID: %s", result.DataID),
+				StatusCode: corehttp.StatusUnprocessableEntity,
+			}
+		}
+		// check we can get the root before we commit to a 200
+		_, err = ipfs.GetVerifiedBlock(req.Context(), solverServer.controller.ipfs.GetBlock, root)
+		if err != nil {
+			return cid.Undef, &http.HTTPError{
+				Message:    err.Error(),
+				StatusCode: corehttp.StatusBadGateway,
+			}
+		}
+		return root, nil
+	}()
+
+	if err != nil {
+		log.Ctx(req.Context()).Error().Msgf("error for route: %s", err.Error())
+		http.WriteError(res, req, err)
+		return
+	}
+
+	res.Header().Set("Content-Disposition", "attachment; filename=archive.tar")
+	res.Header().Set("Content-Type", "application/x-tar")
+	res.Header().Set(http.X_LILYPAD_CID_HEADER, root.String())
+	res.Header().Set("Trailer", http.X_LILYPAD_VERIFIED_HEADER)
+	res.WriteHeader(corehttp.StatusOK)
+
+	verifyErr := ipfs.WriteVerifiedTar(req.Context(), solverServer.controller.ipfs.GetBlock, root, res)
+	if verifyErr != nil {
+		log.Ctx(req.Context()).Error().Msgf("error streaming result %s: %s", id, verifyErr.Error())
+		// the headers are gone so the only way to tell the client is to drop the connection
+		panic(corehttp.ErrAbortHandler)
+	}
+	res.Header().Set(http.X_LILYPAD_VERIFIED_HEADER, "true")
+}
+
+/*
+*
+*
+*
+
+	Logs
+
+*
+*
+*
+*/
+
+// how often we write a comment to an idle log stream
+// so proxies do not close the connection on us
+const LOG_STREAM_KEEPALIVE = 15 * time.Second
+
+// the sequence to read logs after, an EventSource that reconnects
+// sends the last one it saw in the Last-Event-ID header
+func getLogsAfter(req *corehttp.Request) (uint64, error) {
+	after := req.Header.Get("Last-Event-ID")
+	if after == "" {
+		after = req.URL.Query().Get("after")
+	}
+	if after == "" {
+		return 0, nil
+	}
+	sequence, err := strconv.ParseUint(after, 10, 64)
+	if err != nil {
+		return 0, http.HTTPError{
+			Message:    fmt.Sprintf("invalid log sequence: %s", after),
+			StatusCode: corehttp.StatusBadRequest,
+		}
+	}
+	return sequence, nil
+}
+
+func (solverServer *solverServer) addDealLogs(chunks []data.DealLogChunk, res corehttp.ResponseWriter, req *corehttp.Request) ([]data.DealLogChunk, error) {
+	deal, err := solverServer.getDealOr404(req)
+	if err != nil {
+		return nil, err
+	}
+	signerAddress, err := solverServer.signatures.Check(req)
+	if err != nil {
+		log.Error().Err(err).Msgf("have error parsing user address")
+		return nil, data.WrapError(data.ErrUnauthorized, err)
+	}
+	// only the resource provider running the job can add to its logs
+	if signerAddress != deal.ResourceProvider {
+		return nil, data.NewError(data.ErrForbidden, "resource provider address does not match signer address")
+	}
+	return solverServer.controller.addDealLogs(*deal, chunks)
+}
+
+func (solverServer *solverServer) updateDealCheckpoint(checkpoint data.DealCheckpoint, res corehttp.ResponseWriter, req *corehttp.Request) (*data.DealContainer, error) {
+	deal, err := solverServer.getDealOr404(req)
+	if err != nil {
+		return nil, err
+	}
+	signerAddress, err := solverServer.signatures.Check(req)
+	if err != nil {
+		log.Error().Err(err).Msgf("have error parsing user address")
+		return nil, data.WrapError(data.ErrUnauthorized, err)
+	}
+	// only the resource provider running the job has checkpoints for it
+	if signerAddress != deal.ResourceProvider {
+		return nil, data.NewError(data.ErrForbidden, "resource provider address does not match signer address")
+	}
+	return solverServer.controller.updateDealCheckpoint(*deal, checkpoint.CID)
+}
+
+func (solverServer *solverServer) routeServiceRequest(request data.ServiceRequest, res corehttp.ResponseWriter, req *corehttp.Request) (*data.ServiceResponse, error) {
+	deal, err := solverServer.getDealOr404(req)
+	if err != nil {
+		return nil, err
+	}
+	signerAddress, err := solverServer.signatures.Check(req)
+	if err != nil {
+		log.Error().Err(err).Msgf("have error parsing user address")
+		return nil, data.WrapError(data.ErrUnauthorized, err)
+	}
+	// the job creator pays for each request so only they can send them
+	if signerAddress != deal.JobCreator {
+		return nil, data.NewError(data.ErrForbidden, "job creator address does not match signer address")
+	}
+	return solverServer.controller.routeServiceRequest(req.Context(), *deal, request)
+}
+
+func (solverServer *solverServer) getServiceRequests(res corehttp.ResponseWriter, req *corehttp.Request) ([]data.ServiceRequest, error) {
+	deal, err := solverServer.getDealOr404(req)
+	if err != nil {
+		return nil, err
+	}
+	signerAddress, err := http.GetAddressFromHeaders(req)
+	if err != nil {
+		log.Error().Err(err).Msgf("have error parsing user address")
+		return nil, data.WrapError(data.ErrUnauthorized, err)
+	}
+	// only the resource provider running the service can pick up its requests
+	if signerAddress != deal.ResourceProvider {
+		return nil, data.NewError(data.ErrForbidden, "resource provider address does not match signer address")
+	}
+	return solverServer.controller.getServiceRequests(req.Context(), *deal)
+}
+
+func (solverServer *solverServer) addServiceResponse(response data.ServiceResponse, res corehttp.ResponseWriter, req *corehttp.Request) (*data.DealContainer, error) {
+	deal, err := solverServer.getDealOr404(req)
+	if err != nil {
+		return nil, err
+	}
+	signerAddress, err := solverServer.signatures.Check(req)
+	if err != nil {
+		log.Error().Err(err).Msgf("have error parsing user address")
+		return nil, data.WrapError(data.ErrUnauthorized, err)
+	}
+	if signerAddress != deal.ResourceProvider {
+		return nil, data.NewError(data.ErrForbidden, "resource provider address does not match signer address")
+	}
+	return solverServer.controller.addServiceResponse(*deal, response)
+}
+
+func (solverServer *solverServer) addDealEncryptedInputs(encrypted data.DealEncryptedInputs, res corehttp.ResponseWriter, req *corehttp.Request) (*data.DealContainer, error) {
+	deal, err := solverServer.getDealOr404(req)
+	if err != nil {
+		return nil, err
+	}
+	signerAddress, err := solverServer.signatures.Check(req)
+	if err != nil {
+		log.Error().Err(err).Msgf("have error parsing user address")
+		return nil, data.WrapError(data.ErrUnauthorized, err)
+	}
+	// only the job creator has the inputs
+	if signerAddress != deal.JobCreator {
+		return nil, data.NewError(data.ErrForbidden, "job creator address does not match signer address")
+	}
+	dealContainer, err := solverServer.controller.addDealEncryptedInputs(*deal, encrypted)
+	if err != nil {
+		return nil, http.HTTPError{
+			Message:    err.Error(),
+			StatusCode: corehttp.StatusBadRequest,
+		}
+	}
+	return dealContainer, nil
+}
+
+func (solverServer *solverServer) preemptDeal(preemption data.DealPreemption, res corehttp.ResponseWriter, req *corehttp.Request) (*data.DealContainer, error) {
+	deal, err := solverServer.getDealOr404(req)
+	if err != nil {
+		return nil, err
+	}
+	signerAddress, err := solverServer.signatures.Check(req)
+	if err != nil {
+		log.Error().Err(err).Msgf("have error parsing user address")
+		return nil, data.WrapError(data.ErrUnauthorized, err)
+	}
+	// only the resource provider can take its capacity back
+	if signerAddress != deal.ResourceProvider {
+		return nil, data.NewError(data.ErrForbidden, "resource provider address does not match signer address")
+	}
+	dealContainer, err := solverServer.controller.preemptDeal(*deal, preemption.Reason)
+	if err != nil {
+		return nil, http.HTTPError{
+			Message:    err.Error(),
+			StatusCode: corehttp.StatusBadRequest,
+		}
+	}
+	return dealContainer, nil
+}
+
+func (solverServer *solverServer) addMediationVerdict(verdict data.MediationVerdict, res corehttp.ResponseWriter, req *corehttp.Request) (*data.DealContainer, error) {
+	deal, err := solverServer.getDealOr404(req)
+	if err != nil {
+		return nil, err
+	}
+	signerAddress, err := solverServer.signatures.Check(req)
+	if err != nil {
+		log.Error().Err(err).Msgf("have error parsing user address")
+		return nil, data.WrapError(data.ErrUnauthorized, err)
+	}
+	// a mediator can only give its own verdict
+	verdict.Mediator = signerAddress
+	return solverServer.controller.addMediationVerdict(*deal, verdict)
+}
+
+// the output of a job is only for the parties to its deal
+func (solverServer *solverServer) checkDealLogsSigner(req *corehttp.Request, deal data.DealContainer) error {
+	signerAddress, err := solverServer.signatures.Check(req)
+	if err != nil {
+		log.Error().Err(err).Msgf("have error parsing user address")
+		return data.WrapError(data.ErrUnauthorized, err)
+	}
+	if signerAddress == deal.JobCreator ||
+		signerAddress == deal.ResourceProvider ||
+		signerAddress == deal.Mediator ||
+		slices.Contains(deal.Deal.Members.Mediators, signerAddress) {
+		return nil
+	}
+	return data.NewError(data.ErrForbidden, "signer address is not the job creator, resource provider or a mediator of the deal")
+}
+
+func (solverServer *solverServer) getDealLogs(res corehttp.ResponseWriter, req *corehttp.Request) ([]data.DealLogChunk, error) {
+	deal, err := solverServer.getDealOr404(req)
+	if err != nil {
+		return nil, err
+	}
+	err = solverServer.checkDealLogsSigner(req, *deal)
+	if err != nil {
+		return nil, err
+	}
+	after, err := getLogsAfter(req)
+	if err != nil {
+		return nil, err
+	}
+	chunks, _, _ := solverServer.controller.getDealLogs(*deal, after)
+	return chunks, nil
+}
+
+// follow the logs of a deal as server sent events until the deal is over
+// each chunk is a "log" event with its sequence as the event ID
+// and an "end" event is sent once there will be no more
+func (solverServer *solverServer) streamDealLogs(res corehttp.ResponseWriter, req *corehttp.Request) {
+	deal, err := solverServer.getDealOr404(req)
+	if err == nil {
+		err = solverServer.checkDealLogsSigner(req, *deal)
+	}
+	if err == nil {
+		var after uint64
+		after, err = getLogsAfter(req)
+		if err == nil {
+			solverServer.writeDealLogs(res, req, *deal, after)
+			return
+		}
+	}
+	log.Ctx(req.Context()).Error().Msgf("error for route: %s", err.Error())
+	http.WriteError(res, req, err)
+}
+
+func (solverServer *solverServer) writeDealLogs(res corehttp.ResponseWriter, req *corehttp.Request, deal data.DealContainer, after uint64) {
+	flusher, ok := res.(corehttp.Flusher)
+	if !ok {
+		corehttp.Error(res, "streaming is not supported", corehttp.StatusInternalServerError)
+		return
+	}
+	res.Header().Set("Content-Type", "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.WriteHeader(corehttp.StatusOK)
+	flusher.Flush()
+
+	keepalive := time.NewTicker(LOG_STREAM_KEEPALIVE)
+	defer keepalive.Stop()
+
+	for {
+		// the deal can end without us ever having output for it
+		current, err := solverServer.store.GetDeal(deal.ID)
+		if err == nil && current != nil {
+			deal = *current
+		}
+		chunks, finished, changed := solverServer.controller.getDealLogs(deal, after)
+		for _, chunk := range chunks {
+			chunkBytes, err := json.Marshal(chunk)
+			if err != nil {
+				log.Error().Msgf("error marshalling log chunk: %s", err.Error())
+				return
+			}
+			_, err = fmt.Fprintf(res, "id: %d\nevent: log\ndata: %s\n\n", chunk.Sequence, chunkBytes)
+			if err != nil {
+				return
+			}
+			after = chunk.Sequence
+		}
+		if finished {
+			fmt.Fprint(res, "event: end\ndata: {}\n\n")
+			flusher.Flush()
+			return
+		}
+		flusher.Flush()
+
+		select {
+		case <-req.Context().Done():
+			return
+		case <-keepalive.C:
+			_, err := fmt.Fprint(res, ": keepalive\n\n")
+			if err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-changed:
+		}
+	}
+}
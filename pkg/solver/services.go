@@ -0,0 +1,255 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+const (
+	// how long a job creator waits for the service to answer a request
+	SERVICE_REQUEST_TIMEOUT = 2 * time.Minute
+	// how long a resource provider waits for requests before asking again
+	SERVICE_POLL_TIMEOUT = 30 * time.Second
+	// the most requests that can wait for the service of one deal
+	SERVICE_MAX_PENDING_REQUESTS = 256
+)
+
+// a request waiting for the service of a deal to answer it
+type routedRequest struct {
+	request  data.ServiceRequest
+	response chan data.ServiceResponse
+}
+
+// the requests for the service of one deal
+type dealService struct {
+	// not yet picked up by the resource provider
+	pending []*routedRequest
+	// picked up and waiting for an answer keyed by request ID
+	sent map[string]*routedRequest
+	// closed and replaced each time a request is added
+	changed chan struct{}
+}
+
+// job creators send requests for the service of a deal here and the
+// resource provider running it picks them up and sends back the answers
+// we only keep them in memory as nobody is waiting for them after a restart
+type serviceRouter struct {
+	mutex sync.Mutex
+	deals map[string]*dealService
+	// held while the usage of a deal is read and written back
+	usageMutex sync.Mutex
+}
+
+func newServiceRouter() *serviceRouter {
+	return &serviceRouter{
+		deals: map[string]*dealService{},
+	}
+}
+
+// must be called with the lock held
+func (router *serviceRouter) getDealService(dealID string) *dealService {
+	service, ok := router.deals[dealID]
+	if ok {
+		return service
+	}
+	service = &dealService{
+		pending: []*routedRequest{},
+		sent:    map[string]*routedRequest{},
+		changed: make(chan struct{}),
+	}
+	router.deals[dealID] = service
+	return service
+}
+
+func (router *serviceRouter) add(request data.ServiceRequest) (*routedRequest, error) {
+	router.mutex.Lock()
+	defer router.mutex.Unlock()
+
+	service := router.getDealService(request.DealID)
+	if len(service.pending)+len(service.sent) >= SERVICE_MAX_PENDING_REQUESTS {
+		return nil, data.NewError(data.ErrConflict, "the service of deal %s has %d requests waiting", request.DealID, SERVICE_MAX_PENDING_REQUESTS)
+	}
+	routed := &routedRequest{
+		request:  request,
+		response: make(chan data.ServiceResponse, 1),
+	}
+	service.pending = append(service.pending, routed)
+	close(service.changed)
+	service.changed = make(chan struct{})
+	return routed, nil
+}
+
+// hand the pending requests of a deal to the resource provider
+// along with a channel that is closed when more come in
+func (router *serviceRouter) take(dealID string) ([]data.ServiceRequest, <-chan struct{}) {
+	router.mutex.Lock()
+	defer router.mutex.Unlock()
+
+	service := router.getDealService(dealID)
+	requests := []data.ServiceRequest{}
+	for _, routed := range service.pending {
+		requests = append(requests, routed.request)
+		service.sent[routed.request.ID] = routed
+	}
+	service.pending = []*routedRequest{}
+	return requests, service.changed
+}
+
+// pass the answer to whoever is waiting for it
+func (router *serviceRouter) respond(dealID string, response data.ServiceResponse) error {
+	router.mutex.Lock()
+	defer router.mutex.Unlock()
+
+	service, ok := router.deals[dealID]
+	if !ok {
+		return data.NewError(data.ErrNotFound, "no requests are waiting for the service of deal %s", dealID)
+	}
+	routed, ok := service.sent[response.RequestID]
+	if !ok {
+		return data.NewError(data.ErrNotFound, "service request %s is not waiting for an answer", response.RequestID)
+	}
+	delete(service.sent, response.RequestID)
+	routed.response <- response
+	return nil
+}
+
+// nobody is waiting for the answer any more
+func (router *serviceRouter) abandon(dealID string, requestID string) {
+	router.mutex.Lock()
+	defer router.mutex.Unlock()
+
+	service, ok := router.deals[dealID]
+	if !ok {
+		return
+	}
+	delete(service.sent, requestID)
+	for i, routed := range service.pending {
+		if routed.request.ID == requestID {
+			service.pending = append(service.pending[:i], service.pending[i+1:]...)
+			break
+		}
+	}
+}
+
+// the deal is over so answer everything still waiting with an error
+func (router *serviceRouter) finish(dealID string) {
+	router.mutex.Lock()
+	defer router.mutex.Unlock()
+
+	service, ok := router.deals[dealID]
+	if !ok {
+		return
+	}
+	waiting := append([]*routedRequest{}, service.pending...)
+	for _, routed := range service.sent {
+		waiting = append(waiting, routed)
+	}
+	for _, routed := range waiting {
+		routed.response <- data.ServiceResponse{
+			RequestID: routed.request.ID,
+			Error:     fmt.Sprintf("deal %s is over", dealID),
+		}
+	}
+	close(service.changed)
+	delete(router.deals, dealID)
+}
+
+// send a request to the service of a deal and wait for the answer
+// a request the service does not answer in time gets a gateway timeout
+func (controller *SolverController) routeServiceRequest(ctx context.Context, deal data.DealContainer, request data.ServiceRequest) (*data.ServiceResponse, error) {
+	if !data.IsServiceDeal(deal) {
+		return nil, data.NewError(data.ErrInvalidRequest, "deal %s is not a service deal", deal.ID)
+	}
+	if data.DealState(deal.State) != data.DealAgreed || deal.CancelledAt != 0 {
+		return nil, data.NewError(data.ErrConflict, "the service of deal %s is not running", deal.ID)
+	}
+	err := data.CheckServiceRequest(request)
+	if err != nil {
+		return nil, data.WrapError(data.ErrInvalidRequest, err)
+	}
+	request.ID = uuid.NewString()
+	request.DealID = deal.ID
+	request.CreatedAt = time.Now().UnixMilli()
+
+	routed, err := controller.services.add(request)
+	if err != nil {
+		return nil, err
+	}
+	timer := time.NewTimer(SERVICE_REQUEST_TIMEOUT)
+	defer timer.Stop()
+	select {
+	case response := <-routed.response:
+		return &response, nil
+	case <-timer.C:
+		controller.services.abandon(deal.ID, request.ID)
+		return &data.ServiceResponse{
+			RequestID:  request.ID,
+			StatusCode: 504, //nolint:gomnd
+			Error:      fmt.Sprintf("the service of deal %s did not answer within %s", deal.ID, SERVICE_REQUEST_TIMEOUT),
+		}, nil
+	case <-ctx.Done():
+		controller.services.abandon(deal.ID, request.ID)
+		return nil, ctx.Err()
+	}
+}
+
+// the requests waiting for the service of a deal
+// waits a while for some to come in if there are none
+func (controller *SolverController) getServiceRequests(ctx context.Context, deal data.DealContainer) ([]data.ServiceRequest, error) {
+	if !data.IsServiceDeal(deal) {
+		return nil, data.NewError(data.ErrInvalidRequest, "deal %s is not a service deal", deal.ID)
+	}
+	timer := time.NewTimer(SERVICE_POLL_TIMEOUT)
+	defer timer.Stop()
+	for {
+		// nothing more will come for a deal that is over
+		current, err := controller.store.GetDeal(deal.ID)
+		if err != nil {
+			return nil, err
+		}
+		if current == nil || data.DealState(current.State).IsTerminal() {
+			return []data.ServiceRequest{}, nil
+		}
+		requests, changed := controller.services.take(deal.ID)
+		if len(requests) > 0 {
+			return requests, nil
+		}
+		select {
+		case <-changed:
+		case <-timer.C:
+			return requests, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// pass the answer of the service on and meter the request
+// only requests someone was still waiting for are counted
+func (controller *SolverController) addServiceResponse(deal data.DealContainer, response data.ServiceResponse) (*data.DealContainer, error) {
+	err := data.CheckServiceResponse(response)
+	if err != nil {
+		return nil, data.WrapError(data.ErrInvalidRequest, err)
+	}
+	err = controller.services.respond(deal.ID, response)
+	if err != nil {
+		return nil, err
+	}
+
+	controller.services.usageMutex.Lock()
+	defer controller.services.usageMutex.Unlock()
+	current, err := controller.store.GetDeal(deal.ID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, data.NewError(data.ErrNotFound, "deal not found: %s", deal.ID)
+	}
+	usage := data.AddServiceUsage(current.ServiceUsage, response, time.Now().UnixMilli())
+	return controller.store.UpdateDealServiceUsage(deal.ID, usage)
+}
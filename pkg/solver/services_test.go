@@ -0,0 +1,78 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	memorystore "github.com/lilypad-tech/lilypad/pkg/solver/store/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceRequestRouting(t *testing.T) {
+	s, err := memorystore.NewSolverStoreMemory(memorystore.SolverStoreMemoryOptions{LogDir: t.TempDir()})
+	require.NoError(t, err)
+	controller := &SolverController{store: s, services: newServiceRouter()}
+
+	// the store keeps its log between runs so the records need their own IDs
+	deal := data.GetDealContainer(data.Deal{
+		ID: fmt.Sprintf("service%d", time.Now().UnixNano()),
+		JobOffer: data.JobOffer{
+			JobCreator: "0xjc",
+			Serve:      &data.ServiceDealConfig{Port: 8080, Duration: 60},
+		},
+		ResourceOffer: data.ResourceOffer{ResourceProvider: "0xrp"},
+	})
+	deal.State = data.GetAgreementStateIndex("DealAgreed")
+	_, err = s.AddDeal(deal)
+	require.NoError(t, err)
+
+	// the resource provider answers every request it picks up
+	go func() {
+		for answered := 0; answered < 2; {
+			requests, err := controller.getServiceRequests(context.Background(), deal)
+			if err != nil {
+				return
+			}
+			for _, request := range requests {
+				response := data.ServiceResponse{RequestID: request.ID, StatusCode: 200, Body: request.Body}
+				if string(request.Body) == "fail" {
+					response = data.ServiceResponse{RequestID: request.ID, Error: "connection refused"}
+				}
+				_, err = controller.addServiceResponse(deal, response)
+				if err != nil {
+					return
+				}
+				answered++
+			}
+		}
+	}()
+
+	response, err := controller.routeServiceRequest(context.Background(), deal, data.ServiceRequest{Method: "POST", Path: "/", Body: []byte("hello")})
+	require.NoError(t, err)
+	assert.Equal(t, 200, response.StatusCode)
+	assert.Equal(t, "hello", string(response.Body))
+
+	response, err = controller.routeServiceRequest(context.Background(), deal, data.ServiceRequest{Method: "POST", Path: "/", Body: []byte("fail")})
+	require.NoError(t, err)
+	assert.Equal(t, "connection refused", response.Error)
+
+	stored, err := s.GetDeal(deal.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.ServiceUsage)
+	assert.Equal(t, uint64(1), stored.ServiceUsage.Requests)
+	assert.Equal(t, uint64(1), stored.ServiceUsage.Failed)
+
+	// an answer nobody is waiting for is not metered
+	_, err = controller.addServiceResponse(deal, data.ServiceResponse{RequestID: "made-up", StatusCode: 200})
+	assert.True(t, errors.Is(err, data.ErrNotFound))
+
+	batch := deal
+	batch.Deal.JobOffer.Serve = nil
+	_, err = controller.routeServiceRequest(context.Background(), batch, data.ServiceRequest{Method: "GET", Path: "/"})
+	assert.True(t, errors.Is(err, data.ErrInvalidRequest))
+}
@@ -2,27 +2,63 @@ package solver
 
 import (
 	"context"
+	"github.com/lilypad-tech/lilypad/pkg/attestation"
 
+	"github.com/lilypad-tech/lilypad/pkg/bus"
 	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/fiat"
 	"github.com/lilypad-tech/lilypad/pkg/http"
+	"github.com/lilypad-tech/lilypad/pkg/ipfs"
+	"github.com/lilypad-tech/lilypad/pkg/notifications"
+	"github.com/lilypad-tech/lilypad/pkg/solver/stats"
 	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+	memorystore "github.com/lilypad-tech/lilypad/pkg/solver/store/memory"
+	"github.com/lilypad-tech/lilypad/pkg/storage"
 	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/lilypad-tech/lilypad/pkg/web3"
+	"github.com/lilypad-tech/lilypad/pkg/webhooks"
 	"github.com/rs/zerolog/log"
 	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 )
 
 type SolverOptions struct {
-	Web3      web3.Web3Options
-	Server    http.ServerOptions
-	Services  data.ServiceConfig
-	Telemetry system.TelemetryOptions
+	Web3           web3.Web3Options
+	Chains         web3.ChainsOptions
+	Server         http.ServerOptions
+	GRPC           GRPCOptions
+	Bus            bus.BusOptions
+	Services       data.ServiceConfig
+	Allowlist      AllowlistOptions
+	ModuleResolver ModuleResolverOptions
+	Quota          QuotaOptions
+	Budget         BudgetOptions
+	InputCheck     InputCheckOptions
+	Verification   VerificationOptions
+	Audit          AuditOptions
+	Matching       MatchingOptions
+	Collusion      CollusionOptions
+	Watchdog       WatchdogOptions
+	Retention      RetentionOptions
+	LogRelay       LogRelayOptions
+	IPFS           ipfs.IPFSOptions
+	Storage        storage.StorageOptions
+	Pinning        PinningOptions
+	Stats          stats.StatsOptions
+	Fiat           fiat.Options
+	Admin          AdminOptions
+	ReadOnly       ReadOnlyOptions
+	Telemetry      system.TelemetryOptions
+	Webhooks       webhooks.WebhookOptions
+	Notifications  notifications.NotificationOptions
+	Attestation    attestation.VerifierOptions
+	Store          memorystore.SolverStoreMemoryOptions
 }
 
 type Solver struct {
-	web3SDK    *web3.Web3SDK
+	web3SDK    web3.Web3Client
 	server     *solverServer
+	grpcServer *solverGRPCServer
 	controller *SolverController
 	store      store.SolverStore
 	options    SolverOptions
@@ -31,7 +67,7 @@ type Solver struct {
 func NewSolver(
 	options SolverOptions,
 	store store.SolverStore,
-	web3SDK *web3.Web3SDK,
+	web3SDK web3.Web3Client,
 	tracer trace.Tracer,
 ) (*Solver, error) {
 	controller, err := NewSolverController(web3SDK, store, options, tracer)
@@ -49,10 +85,33 @@ func NewSolver(
 		web3SDK:    web3SDK,
 		options:    options,
 	}
+	if options.GRPC.Port > 0 {
+		solver.grpcServer = NewSolverGRPCServer(options.GRPC, options.Server.Host, controller, store)
+	}
 	return solver, nil
 }
 
+// serve offers on another chain as well as the main one - this must be called before Start
+func (solver *Solver) AddChain(chainID int, web3SDK web3.Web3Client) error {
+	return solver.controller.chains.Add(chainID, web3SDK)
+}
+
+// plug in a custom result verifier - this must be called before Start
+func (solver *Solver) RegisterResultVerifier(verifier ResultVerifier) {
+	solver.controller.RegisterResultVerifier(verifier)
+}
+
 func (solver *Solver) Start(ctx context.Context, cm *system.CleanupManager, tracerProvider *sdkTrace.TracerProvider) chan error {
+	// the bus is joined first so that it hears every event the controller has
+	// offers come over the bus so a read-only solver has no use for it
+	if solver.options.Bus.URL != "" && !solver.options.ReadOnly.Enabled {
+		err := solver.startBus(ctx, cm)
+		if err != nil {
+			errorChan := make(chan error, 1)
+			errorChan <- err
+			return errorChan
+		}
+	}
 	errorChan := solver.controller.Start(ctx, cm)
 	log.Debug().Msgf("solver.server.ListenAndServe")
 	go func() {
@@ -61,5 +120,24 @@ func (solver *Solver) Start(ctx context.Context, cm *system.CleanupManager, trac
 			errorChan <- err
 		}
 	}()
+	if solver.grpcServer != nil {
+		log.Debug().Msgf("solver.grpcServer.ListenAndServe")
+		go func() {
+			err := solver.grpcServer.ListenAndServe(ctx)
+			if err != nil {
+				errorChan <- err
+			}
+		}()
+	}
 	return errorChan
 }
+
+func (solver *Solver) startBus(ctx context.Context, cm *system.CleanupManager) error {
+	b, err := bus.NewBus(solver.options.Bus)
+	if err != nil {
+		return err
+	}
+	cm.RegisterCallback(b.Close)
+	busServer := newSolverBusServer(b, bus.NewSubjects(solver.options.Bus.SubjectPrefix), solver.controller)
+	return busServer.Start(ctx)
+}
@@ -0,0 +1,154 @@
+package stats
+
+import (
+	"sort"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+type StatsOptions struct {
+	// any count below this is suppressed so that a small group
+	// of users or a single provider cannot be picked out
+	MinCount int
+	// the width of the buckets that provider earnings are grouped into
+	EarningsBucketSize uint64
+}
+
+// a range of provider earnings and how many providers fall inside it
+type EarningsBucket struct {
+	Min       uint64 `json:"min"`
+	Max       uint64 `json:"max"`
+	Providers int    `json:"providers"`
+}
+
+// aggregated stats that are safe to expose publicly
+// a count of zero means there were none or too few to report
+type NetworkStats struct {
+	JobOffers         int              `json:"job_offers"`
+	ResourceOffers    int              `json:"resource_offers"`
+	ResourceProviders int              `json:"resource_providers"`
+	JobCreators       int              `json:"job_creators"`
+	Deals             int              `json:"deals"`
+	DealsByState      map[string]int   `json:"deals_by_state"`
+	DealsByModule     map[string]int   `json:"deals_by_module"`
+	ProviderEarnings  []EarningsBucket `json:"provider_earnings"`
+	// the threshold that was applied to the counts above
+	MinCount int `json:"min_count"`
+}
+
+// the raw data the stats are aggregated from
+type StatsInput struct {
+	JobOffers      []data.JobOfferContainer
+	ResourceOffers []data.ResourceOfferContainer
+	Deals          []data.DealContainer
+	// results keyed by deal ID
+	Results map[string]data.Result
+}
+
+// the label we group the remaining small groups under
+const OtherLabel = "other"
+
+func suppress(count int, minCount int) int {
+	if count < minCount {
+		return 0
+	}
+	return count
+}
+
+// drop any group below the threshold, rolling them up into
+// a single "other" group if that is large enough on its own
+func suppressGroups(groups map[string]int, minCount int) map[string]int {
+	ret := map[string]int{}
+	other := 0
+	for label, count := range groups {
+		if count < minCount {
+			other += count
+			continue
+		}
+		ret[label] = count
+	}
+	if other > 0 && other >= minCount {
+		ret[OtherLabel] += other
+	}
+	return ret
+}
+
+// the resource provider is only paid once the results are accepted
+func isPaid(deal data.DealContainer) bool {
+	return deal.State == data.GetAgreementStateIndex("ResultsAccepted") ||
+		deal.State == data.GetAgreementStateIndex("MediationAccepted")
+}
+
+// group the total each provider has earned into buckets
+// so no single provider's exact earnings are exposed
+func getEarningsBuckets(input StatsInput, options StatsOptions) []EarningsBucket {
+	bucketSize := options.EarningsBucketSize
+	if bucketSize == 0 {
+		bucketSize = 1
+	}
+	earnings := map[string]uint64{}
+	for _, deal := range input.Deals {
+		if !isPaid(deal) {
+			continue
+		}
+		result, ok := input.Results[deal.ID]
+		if !ok {
+			continue
+		}
+		earnings[deal.ResourceProvider] += deal.Deal.Pricing.InstructionPrice * result.InstructionCount
+	}
+
+	counts := map[uint64]int{}
+	for _, total := range earnings {
+		counts[total/bucketSize]++
+	}
+
+	buckets := []EarningsBucket{}
+	for index, count := range counts {
+		if count < options.MinCount {
+			continue
+		}
+		buckets = append(buckets, EarningsBucket{
+			Min:       index * bucketSize,
+			Max:       (index+1)*bucketSize - 1,
+			Providers: count,
+		})
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Min < buckets[j].Min
+	})
+	return buckets
+}
+
+// aggregate the raw solver data into stats that can be published
+func GetNetworkStats(input StatsInput, options StatsOptions) NetworkStats {
+	minCount := options.MinCount
+
+	resourceProviders := map[string]bool{}
+	for _, resourceOffer := range input.ResourceOffers {
+		resourceProviders[resourceOffer.ResourceProvider] = true
+	}
+	jobCreators := map[string]bool{}
+	for _, jobOffer := range input.JobOffers {
+		jobCreators[jobOffer.JobCreator] = true
+	}
+
+	dealsByState := map[string]int{}
+	dealsByModule := map[string]int{}
+	for _, deal := range input.Deals {
+		dealsByState[data.GetAgreementStateString(deal.State)]++
+		dealsByModule[data.GetModuleLabel(deal.Deal.JobOffer.Module)]++
+	}
+
+	return NetworkStats{
+		JobOffers:         suppress(len(input.JobOffers), minCount),
+		ResourceOffers:    suppress(len(input.ResourceOffers), minCount),
+		ResourceProviders: suppress(len(resourceProviders), minCount),
+		JobCreators:       suppress(len(jobCreators), minCount),
+		Deals:             suppress(len(input.Deals), minCount),
+		DealsByState:      suppressGroups(dealsByState, minCount),
+		DealsByModule:     suppressGroups(dealsByModule, minCount),
+		ProviderEarnings:  getEarningsBuckets(input, options),
+		MinCount:          minCount,
+	}
+}
@@ -0,0 +1,59 @@
+package stats
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+func TestGetNetworkStats(t *testing.T) {
+	input := StatsInput{
+		Results: map[string]data.Result{},
+	}
+	addDeal := func(id string, resourceProvider string, module string, earned uint64) {
+		input.Deals = append(input.Deals, data.DealContainer{
+			ID:               id,
+			ResourceProvider: resourceProvider,
+			State:            data.GetAgreementStateIndex("ResultsAccepted"),
+			Deal: data.Deal{
+				JobOffer: data.JobOffer{Module: data.ModuleConfig{Name: module}},
+				Pricing:  data.DealPricing{InstructionPrice: 1},
+			},
+		})
+		input.Results[id] = data.Result{DealID: id, InstructionCount: earned}
+	}
+
+	// three providers earn similar amounts for a popular module
+	for i := 0; i < 3; i++ {
+		addDeal(fmt.Sprintf("popular-%d", i), fmt.Sprintf("rp%d", i), "cowsay", 150)
+	}
+	// one provider earns a lot from a rare module
+	addDeal("rare", "rp-rare", "rare", 1000)
+
+	stats := GetNetworkStats(input, StatsOptions{MinCount: 3, EarningsBucketSize: 100})
+
+	if stats.Deals != 4 {
+		t.Errorf("Expected 4 deals, but got %d", stats.Deals)
+	}
+	if stats.DealsByModule["cowsay"] != 3 {
+		t.Errorf("Expected 3 cowsay deals, but got %d", stats.DealsByModule["cowsay"])
+	}
+	if _, ok := stats.DealsByModule["rare"]; ok {
+		t.Errorf("Expected the rare module to be suppressed, but got %+v", stats.DealsByModule)
+	}
+	if _, ok := stats.DealsByModule[OtherLabel]; ok {
+		t.Errorf("Expected the other group to be suppressed, but got %+v", stats.DealsByModule)
+	}
+	if len(stats.ProviderEarnings) != 1 {
+		t.Fatalf("Expected a single earnings bucket, but got %+v", stats.ProviderEarnings)
+	}
+	bucket := stats.ProviderEarnings[0]
+	if bucket.Min != 100 || bucket.Max != 199 || bucket.Providers != 3 {
+		t.Errorf("Expected 3 providers in the 100-199 bucket, but got %+v", bucket)
+	}
+	// job offers and resource offers are below the threshold
+	if stats.JobOffers != 0 || stats.ResourceProviders != 0 {
+		t.Errorf("Expected small counts to be suppressed, but got %+v", stats)
+	}
+}
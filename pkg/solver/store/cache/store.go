@@ -0,0 +1,282 @@
+package store
+
+import (
+	"sync"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+)
+
+// past this many cached queries or deals we stop adding more until
+// the next write clears them, this only happens if the API is asked
+// for many different job creators or resource providers at once
+const CACHE_MAX_QUERIES = 1024
+const CACHE_MAX_DEALS = 10000
+
+// the names the cache stats are kept under
+const (
+	cacheJobOffers      = "job_offers"
+	cacheResourceOffers = "resource_offers"
+	cacheDeals          = "deals"
+)
+
+// keeps the answers to the queries the matcher and the API make over and
+// over in front of another store, the unmatched offers and deals by ID
+// every write that could change an answer throws the cached ones away
+type SolverStoreCache struct {
+	store.SolverStore
+	mutex sync.Mutex
+	// bumped by every write so an answer read before a write is not
+	// kept after it
+	generation     uint64
+	jobOffers      map[store.GetJobOffersQuery][]data.JobOfferContainer
+	resourceOffers map[store.GetResourceOffersQuery][]data.ResourceOfferContainer
+	deals          map[string]data.DealContainer
+	hits           map[string]uint64
+	misses         map[string]uint64
+}
+
+func NewSolverStoreCache(backend store.SolverStore) *SolverStoreCache {
+	return &SolverStoreCache{
+		SolverStore:    backend,
+		jobOffers:      map[store.GetJobOffersQuery][]data.JobOfferContainer{},
+		resourceOffers: map[store.GetResourceOffersQuery][]data.ResourceOfferContainer{},
+		deals:          map[string]data.DealContainer{},
+		hits:           map[string]uint64{},
+		misses:         map[string]uint64{},
+	}
+}
+
+// look up a cached answer and count the hit or miss
+// the generation is passed to put so it can tell if there was a write
+func getCached[K comparable, V any](s *SolverStoreCache, name string, cache map[K]V, key K) (V, bool, uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	value, ok := cache[key]
+	if ok {
+		s.hits[name]++
+	} else {
+		s.misses[name]++
+	}
+	return value, ok, s.generation
+}
+
+func putCached[K comparable, V any](s *SolverStoreCache, cache map[K]V, key K, value V, generation uint64, max int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.generation != generation || len(cache) >= max {
+		return
+	}
+	cache[key] = value
+}
+
+func (s *SolverStoreCache) invalidate(jobOffers bool, resourceOffers bool, dealIDs ...string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.generation++
+	if jobOffers {
+		s.jobOffers = map[store.GetJobOffersQuery][]data.JobOfferContainer{}
+	}
+	if resourceOffers {
+		s.resourceOffers = map[store.GetResourceOffersQuery][]data.ResourceOfferContainer{}
+	}
+	for _, id := range dealIDs {
+		delete(s.deals, id)
+	}
+}
+
+func (s *SolverStoreCache) invalidateAll() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.generation++
+	s.jobOffers = map[store.GetJobOffersQuery][]data.JobOfferContainer{}
+	s.resourceOffers = map[store.GetResourceOffersQuery][]data.ResourceOfferContainer{}
+	s.deals = map[string]data.DealContainer{}
+}
+
+// the caller gets its own slice so it cannot change what is cached
+func copyRecords[T any](records []T) []T {
+	return append([]T{}, records...)
+}
+
+func (s *SolverStoreCache) GetJobOffers(query store.GetJobOffersQuery) ([]data.JobOfferContainer, error) {
+	if !query.NotMatched {
+		return s.SolverStore.GetJobOffers(query)
+	}
+	jobOffers, ok, generation := getCached(s, cacheJobOffers, s.jobOffers, query)
+	if ok {
+		return copyRecords(jobOffers), nil
+	}
+	jobOffers, err := s.SolverStore.GetJobOffers(query)
+	if err != nil {
+		return nil, err
+	}
+	putCached(s, s.jobOffers, query, copyRecords(jobOffers), generation, CACHE_MAX_QUERIES)
+	return jobOffers, nil
+}
+
+func (s *SolverStoreCache) GetResourceOffers(query store.GetResourceOffersQuery) ([]data.ResourceOfferContainer, error) {
+	if !query.NotMatched {
+		return s.SolverStore.GetResourceOffers(query)
+	}
+	resourceOffers, ok, generation := getCached(s, cacheResourceOffers, s.resourceOffers, query)
+	if ok {
+		return copyRecords(resourceOffers), nil
+	}
+	resourceOffers, err := s.SolverStore.GetResourceOffers(query)
+	if err != nil {
+		return nil, err
+	}
+	putCached(s, s.resourceOffers, query, copyRecords(resourceOffers), generation, CACHE_MAX_QUERIES)
+	return resourceOffers, nil
+}
+
+// deals that are not there are not cached so the store is still
+// asked when the health check looks for one
+func (s *SolverStoreCache) GetDeal(id string) (*data.DealContainer, error) {
+	deal, ok, generation := getCached(s, cacheDeals, s.deals, id)
+	if ok {
+		return &deal, nil
+	}
+	found, err := s.SolverStore.GetDeal(id)
+	if err != nil || found == nil {
+		return found, err
+	}
+	putCached(s, s.deals, id, *found, generation, CACHE_MAX_DEALS)
+	return found, nil
+}
+
+func (s *SolverStoreCache) GetStats() (store.StoreStats, error) {
+	stats, err := s.SolverStore.GetStats()
+	if err != nil {
+		return stats, err
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	cacheStats := &store.CacheStats{
+		Hits:   map[string]uint64{},
+		Misses: map[string]uint64{},
+	}
+	for name, count := range s.hits {
+		cacheStats.Hits[name] = count
+	}
+	for name, count := range s.misses {
+		cacheStats.Misses[name] = count
+	}
+	stats.Cache = cacheStats
+	return stats, nil
+}
+
+func (s *SolverStoreCache) AddJobOffer(jobOffer data.JobOfferContainer) (*data.JobOfferContainer, error) {
+	defer s.invalidate(true, false)
+	return s.SolverStore.AddJobOffer(jobOffer)
+}
+
+func (s *SolverStoreCache) UpdateJobOfferState(id string, dealID string, state uint8) (*data.JobOfferContainer, error) {
+	defer s.invalidate(true, false)
+	return s.SolverStore.UpdateJobOfferState(id, dealID, state)
+}
+
+func (s *SolverStoreCache) RemoveJobOffer(id string) error {
+	defer s.invalidate(true, false)
+	return s.SolverStore.RemoveJobOffer(id)
+}
+
+func (s *SolverStoreCache) AddResourceOffer(resourceOffer data.ResourceOfferContainer) (*data.ResourceOfferContainer, error) {
+	defer s.invalidate(false, true)
+	return s.SolverStore.AddResourceOffer(resourceOffer)
+}
+
+func (s *SolverStoreCache) UpdateResourceOfferState(id string, dealID string, state uint8) (*data.ResourceOfferContainer, error) {
+	defer s.invalidate(false, true)
+	return s.SolverStore.UpdateResourceOfferState(id, dealID, state)
+}
+
+func (s *SolverStoreCache) RemoveResourceOffer(id string) error {
+	defer s.invalidate(false, true)
+	return s.SolverStore.RemoveResourceOffer(id)
+}
+
+func (s *SolverStoreCache) AddDeal(deal data.DealContainer) (*data.DealContainer, error) {
+	defer s.invalidate(false, false, deal.ID)
+	return s.SolverStore.AddDeal(deal)
+}
+
+func (s *SolverStoreCache) UpdateDealState(id string, state uint8) (*data.DealContainer, error) {
+	defer s.invalidate(false, false, id)
+	return s.SolverStore.UpdateDealState(id, state)
+}
+
+func (s *SolverStoreCache) UpdateDealMediator(id string, mediator string) (*data.DealContainer, error) {
+	defer s.invalidate(false, false, id)
+	return s.SolverStore.UpdateDealMediator(id, mediator)
+}
+
+func (s *SolverStoreCache) UpdateDealCheckpoint(id string, checkpoint data.DealCheckpoint) (*data.DealContainer, error) {
+	defer s.invalidate(false, false, id)
+	return s.SolverStore.UpdateDealCheckpoint(id, checkpoint)
+}
+
+func (s *SolverStoreCache) UpdateDealServiceUsage(id string, usage data.ServiceUsage) (*data.DealContainer, error) {
+	defer s.invalidate(false, false, id)
+	return s.SolverStore.UpdateDealServiceUsage(id, usage)
+}
+
+func (s *SolverStoreCache) UpdateDealMilestone(id string, milestone data.DealMilestone) (*data.DealContainer, error) {
+	defer s.invalidate(false, false, id)
+	return s.SolverStore.UpdateDealMilestone(id, milestone)
+}
+
+func (s *SolverStoreCache) AddDealEncryptedInputs(id string, encrypted data.DealEncryptedInputs) (*data.DealContainer, error) {
+	defer s.invalidate(false, false, id)
+	return s.SolverStore.AddDealEncryptedInputs(id, encrypted)
+}
+
+func (s *SolverStoreCache) CancelDeal(id string, cancelledAt int64) (*data.DealContainer, error) {
+	defer s.invalidate(false, false, id)
+	return s.SolverStore.CancelDeal(id, cancelledAt)
+}
+
+func (s *SolverStoreCache) PreemptDeal(id string, preemption data.DealPreemption) (*data.DealContainer, error) {
+	defer s.invalidate(false, false, id)
+	return s.SolverStore.PreemptDeal(id, preemption)
+}
+
+func (s *SolverStoreCache) AddMediationVerdict(id string, verdict data.MediationVerdict) (*data.DealContainer, error) {
+	defer s.invalidate(false, false, id)
+	return s.SolverStore.AddMediationVerdict(id, verdict)
+}
+
+func (s *SolverStoreCache) UpdateDealTransactionsJobCreator(id string, txs data.DealTransactionsJobCreator) (*data.DealContainer, error) {
+	defer s.invalidate(false, false, id)
+	return s.SolverStore.UpdateDealTransactionsJobCreator(id, txs)
+}
+
+func (s *SolverStoreCache) UpdateDealTransactionsResourceProvider(id string, txs data.DealTransactionsResourceProvider) (*data.DealContainer, error) {
+	defer s.invalidate(false, false, id)
+	return s.SolverStore.UpdateDealTransactionsResourceProvider(id, txs)
+}
+
+func (s *SolverStoreCache) UpdateDealTransactionsMediator(id string, txs data.DealTransactionsMediator) (*data.DealContainer, error) {
+	defer s.invalidate(false, false, id)
+	return s.SolverStore.UpdateDealTransactionsMediator(id, txs)
+}
+
+// these change the offers of the deal as well as the deal
+func (s *SolverStoreCache) RollbackDealState(id string, state uint8) (*data.DealContainer, error) {
+	defer s.invalidate(true, true, id)
+	return s.SolverStore.RollbackDealState(id, state)
+}
+
+func (s *SolverStoreCache) RemoveDealRecords(id string) error {
+	defer s.invalidate(true, true, id)
+	return s.SolverStore.RemoveDealRecords(id)
+}
+
+func (s *SolverStoreCache) ImportSnapshot(snapshot store.StoreSnapshot) error {
+	defer s.invalidateAll()
+	return s.SolverStore.ImportSnapshot(snapshot)
+}
+
+// Compile-time interface check:
+var _ store.SolverStore = (*SolverStoreCache)(nil)
@@ -0,0 +1,58 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+	memorystore "github.com/lilypad-tech/lilypad/pkg/solver/store/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolverStoreCache(t *testing.T) {
+	backend, err := memorystore.NewSolverStoreMemory(memorystore.SolverStoreMemoryOptions{LogDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewSolverStoreCache(backend)
+	notMatched := store.GetResourceOffersQuery{NotMatched: true}
+
+	_, err = s.AddResourceOffer(data.ResourceOfferContainer{ID: "a", ResourceProvider: "0xrp"})
+	assert.NoError(t, err)
+	offers, err := s.GetResourceOffers(notMatched)
+	assert.NoError(t, err)
+	assert.Len(t, offers, 1)
+	offers, err = s.GetResourceOffers(notMatched)
+	assert.NoError(t, err)
+	assert.Len(t, offers, 1)
+
+	// a match takes the offer out of the unmatched ones
+	_, err = s.UpdateResourceOfferState("a", "deal", data.GetAgreementStateIndex("DealNegotiating"))
+	assert.NoError(t, err)
+	offers, err = s.GetResourceOffers(notMatched)
+	assert.NoError(t, err)
+	assert.Empty(t, offers)
+
+	_, err = s.AddDeal(data.DealContainer{ID: "deal", State: data.GetAgreementStateIndex("DealNegotiating")})
+	assert.NoError(t, err)
+	deal, err := s.GetDeal("deal")
+	assert.NoError(t, err)
+	assert.Equal(t, data.GetAgreementStateIndex("DealNegotiating"), deal.State)
+	_, err = s.UpdateDealState("deal", data.GetAgreementStateIndex("DealAgreed"))
+	assert.NoError(t, err)
+	deal, err = s.GetDeal("deal")
+	assert.NoError(t, err)
+	assert.Equal(t, data.GetAgreementStateIndex("DealAgreed"), deal.State)
+	deal, err = s.GetDeal("deal")
+	assert.NoError(t, err)
+	assert.Equal(t, data.GetAgreementStateIndex("DealAgreed"), deal.State)
+
+	stats, err := s.GetStats()
+	assert.NoError(t, err)
+	if assert.NotNil(t, stats.Cache) {
+		assert.Equal(t, uint64(1), stats.Cache.Hits[cacheResourceOffers])
+		assert.Equal(t, uint64(2), stats.Cache.Misses[cacheResourceOffers])
+		assert.Equal(t, uint64(1), stats.Cache.Hits[cacheDeals])
+		assert.Equal(t, uint64(2), stats.Cache.Misses[cacheDeals])
+	}
+}
@@ -0,0 +1,181 @@
+package store
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+)
+
+// the records of a table sorted by the key we keep them under
+func getSortedRecords[T any](records map[string]*T) []T {
+	keys := make([]string, 0, len(records))
+	for key := range records {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	ret := make([]T, 0, len(keys))
+	for _, key := range keys {
+		ret = append(ret, *records[key])
+	}
+	return ret
+}
+
+func (s *SolverStoreMemory) ExportSnapshot() (*store.StoreSnapshot, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	escrowPayments := []data.EscrowPayment{}
+	dealIDs := make([]string, 0, len(s.escrowPaymentMap))
+	for dealID := range s.escrowPaymentMap {
+		dealIDs = append(dealIDs, dealID)
+	}
+	sort.Strings(dealIDs)
+	for _, dealID := range dealIDs {
+		escrowPayments = append(escrowPayments, s.escrowPaymentMap[dealID]...)
+	}
+	return &store.StoreSnapshot{
+		JobOffers:            getSortedRecords(s.jobOfferMap),
+		ResourceOffers:       getSortedRecords(s.resourceOfferMap),
+		Deals:                getSortedRecords(s.dealMap),
+		MatchDecisions:       getSortedRecords(s.matchDecisionMap),
+		Results:              getSortedRecords(s.resultMap),
+		Audits:               getSortedRecords(s.auditMap),
+		TimeoutEvents:        getSortedRecords(s.timeoutEventMap),
+		EscrowPayments:       escrowPayments,
+		PriceGaps:            getSortedRecords(s.priceGapMap),
+		ResultPins:           getSortedRecords(s.resultPinMap),
+		DealReceipts:         getSortedRecords(s.receiptMap),
+		Transactions:         getSortedRecords(s.transactionMap),
+		ChainCheckpoints:     getSortedRecords(s.checkpointMap),
+		JobGroups:            getSortedRecords(s.jobGroupMap),
+		JobSchedules:         getSortedRecords(s.jobScheduleMap),
+		CapacityReservations: getSortedRecords(s.reservationMap),
+		Workflows:            getSortedRecords(s.workflowMap),
+		InputStagings:        getSortedRecords(s.inputStagingMap),
+		BillingRecords:       getSortedRecords(s.billingMap),
+		BannedAddresses:      getSortedRecords(s.bannedMap),
+		AdminActions:         getSortedRecords(s.adminActionMap),
+		ClientRecords:        getSortedRecords(s.clientMap),
+	}, nil
+}
+
+// records are keyed the same way as when they are added and written to
+// the logs, no store events are recorded so clients following the events
+// should start again from the full state
+func (s *SolverStoreMemory) ImportSnapshot(snapshot store.StoreSnapshot) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for i := range snapshot.JobOffers {
+		record := snapshot.JobOffers[i]
+		s.jobOfferMap[record.ID] = &record
+		s.logWriters["job_offers"].Write(record)
+	}
+	for i := range snapshot.ResourceOffers {
+		record := snapshot.ResourceOffers[i]
+		s.resourceOfferMap[record.ID] = &record
+		s.logWriters["resource_offers"].Write(record)
+	}
+	for i := range snapshot.Deals {
+		record := snapshot.Deals[i]
+		s.dealMap[record.ID] = &record
+		s.logWriters["deals"].Write(record)
+	}
+	for i := range snapshot.MatchDecisions {
+		record := snapshot.MatchDecisions[i]
+		s.matchDecisionMap[getMatchID(record.ResourceOffer, record.JobOffer)] = &record
+		s.logWriters["decisions"].Write(record)
+	}
+	for i := range snapshot.Results {
+		record := snapshot.Results[i]
+		s.resultMap[record.DealID] = &record
+		s.logWriters["results"].Write(record)
+	}
+	for i := range snapshot.Audits {
+		record := snapshot.Audits[i]
+		s.auditMap[record.DealID] = &record
+		s.logWriters["audits"].Write(record)
+	}
+	for i := range snapshot.TimeoutEvents {
+		record := snapshot.TimeoutEvents[i]
+		s.timeoutEventMap[record.DealID] = &record
+		s.logWriters["timeouts"].Write(record)
+	}
+	s.escrowPaymentMap = map[string][]data.EscrowPayment{}
+	for _, record := range snapshot.EscrowPayments {
+		s.escrowPaymentMap[record.DealID] = append(s.escrowPaymentMap[record.DealID], record)
+		s.logWriters["escrow"].Write(record)
+	}
+	for i := range snapshot.PriceGaps {
+		record := snapshot.PriceGaps[i]
+		s.priceGapMap[getMatchID(record.ResourceOffer, record.JobOffer)] = &record
+		s.logWriters["price_gaps"].Write(record)
+	}
+	for i := range snapshot.ResultPins {
+		record := snapshot.ResultPins[i]
+		s.resultPinMap[getResultPinID(record.DealID, record.Service)] = &record
+		s.logWriters["result_pins"].Write(record)
+	}
+	for i := range snapshot.DealReceipts {
+		record := snapshot.DealReceipts[i]
+		s.receiptMap[record.DealID] = &record
+		s.logWriters["receipts"].Write(record)
+	}
+	for i := range snapshot.Transactions {
+		record := snapshot.Transactions[i]
+		s.transactionMap[record.ID] = &record
+		s.logWriters["transactions"].Write(record)
+	}
+	for i := range snapshot.ChainCheckpoints {
+		record := snapshot.ChainCheckpoints[i]
+		record.ID = data.GetChainCheckpointID(record.ChainID, record.Contract)
+		s.checkpointMap[record.ID] = &record
+		s.logWriters["checkpoints"].Write(record)
+	}
+	for i := range snapshot.JobGroups {
+		record := snapshot.JobGroups[i]
+		s.jobGroupMap[record.ID] = &record
+		s.logWriters["job_groups"].Write(record)
+	}
+	for i := range snapshot.JobSchedules {
+		record := snapshot.JobSchedules[i]
+		s.jobScheduleMap[record.ID] = &record
+		s.logWriters["job_schedules"].Write(record)
+	}
+	for i := range snapshot.CapacityReservations {
+		record := snapshot.CapacityReservations[i]
+		s.reservationMap[record.ID] = &record
+		s.logWriters["reservations"].Write(record)
+	}
+	for i := range snapshot.Workflows {
+		record := snapshot.Workflows[i]
+		s.workflowMap[record.ID] = &record
+		s.logWriters["workflows"].Write(record)
+	}
+	for i := range snapshot.InputStagings {
+		record := snapshot.InputStagings[i]
+		s.inputStagingMap[record.ID] = &record
+		s.logWriters["input_stagings"].Write(record)
+	}
+	for i := range snapshot.BillingRecords {
+		record := snapshot.BillingRecords[i]
+		s.billingMap[record.DealID] = &record
+		s.logWriters["billing"].Write(record)
+	}
+	for i := range snapshot.BannedAddresses {
+		record := snapshot.BannedAddresses[i]
+		s.bannedMap[strings.ToLower(record.Address)] = &record
+		s.logWriters["banned_addresses"].Write(record)
+	}
+	for i := range snapshot.AdminActions {
+		record := snapshot.AdminActions[i]
+		s.adminActionMap[record.ID] = &record
+		s.logWriters["admin_actions"].Write(record)
+	}
+	for i := range snapshot.ClientRecords {
+		record := snapshot.ClientRecords[i]
+		s.clientMap[data.GetClientRecordID(record.Address, record.Role)] = &record
+		s.logWriters["clients"].Write(record)
+	}
+	return nil
+}
@@ -1,14 +1,21 @@
 package store
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/escrow"
 	"github.com/lilypad-tech/lilypad/pkg/jsonl"
 	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+	"github.com/rs/zerolog/log"
 )
 
 type SolverStoreMemory struct {
@@ -17,20 +24,270 @@ type SolverStoreMemory struct {
 	dealMap          map[string]*data.DealContainer
 	resultMap        map[string]*data.Result
 	matchDecisionMap map[string]*data.MatchDecision
-	mutex            sync.RWMutex
-	logWriters       map[string]jsonl.Writer
+	auditMap         map[string]*data.Audit
+	timeoutEventMap  map[string]*data.DealTimeoutEvent
+	escrowPaymentMap map[string][]data.EscrowPayment
+	priceGapMap      map[string]*data.PriceGap
+	resultPinMap     map[string]*data.ResultPin
+	receiptMap       map[string]*data.DealReceipt
+	transactionMap   map[string]*data.Transaction
+	checkpointMap    map[string]*data.ChainCheckpoint
+	jobGroupMap      map[string]*data.JobGroup
+	jobScheduleMap   map[string]*data.JobSchedule
+	reservationMap   map[string]*data.CapacityReservation
+	workflowMap      map[string]*data.Workflow
+	inputStagingMap  map[string]*data.InputStaging
+	billingMap       map[string]*data.BillingRecord
+	bannedMap        map[string]*data.BannedAddress
+	adminActionMap   map[string]*data.AdminAction
+	clientMap        map[string]*data.ClientRecord
+	events           []data.StoreEvent
+	// the sequence of the last event, older events are pruned so this
+	// can be more than the number we hold
+	eventSequence uint64
+	options       SolverStoreMemoryOptions
+	mutex         sync.RWMutex
+	logWriters    map[string]jsonl.Writer
 }
 
 func getMatchID(resourceOffer string, jobOffer string) string {
 	return fmt.Sprintf("%s-%s", resourceOffer, jobOffer)
 }
 
-func NewSolverStoreMemory() (*SolverStoreMemory, error) {
+func getResultPinID(dealID string, service string) string {
+	return fmt.Sprintf("%s-%s", dealID, service)
+}
+
+// record a change, this must be called with the lock held
+func (s *SolverStoreMemory) addEvent(eventType data.StoreEventType, objectID string, actor string, value interface{}) {
+	// we snapshot the object because the maps hold pointers that later updates change
+	bs, err := json.Marshal(value)
+	if err != nil {
+		bs = []byte("null")
+	}
+	s.eventSequence++
+	event := data.StoreEvent{
+		Sequence:  s.eventSequence,
+		Type:      eventType,
+		ObjectID:  objectID,
+		Actor:     actor,
+		Data:      bs,
+		CreatedAt: time.Now().UnixMilli(),
+	}
+	s.events = append(s.events, event)
+	s.logWriters["events"].Write(event)
+	s.pruneEvents()
+}
+
+// drop the oldest events once there are too many, this is done in batches
+// so the events log is not rewritten on every change
+// this must be called with the lock held
+func (s *SolverStoreMemory) pruneEvents() {
+	maxEvents := s.options.MaxEvents
+	if maxEvents <= 0 || len(s.events) < maxEvents+getEventPruneBatch(maxEvents) {
+		return
+	}
+	s.events = append([]data.StoreEvent{}, s.events[len(s.events)-maxEvents:]...)
+	err := s.rewriteEventLog()
+	if err != nil {
+		log.Error().Msgf("error pruning the events log: %s", err.Error())
+	}
+}
+
+func getEventPruneBatch(maxEvents int) int {
+	return max(maxEvents/10, 1) //nolint:gomnd
+}
+
+// replace the events log with the events we still hold
+// this must be called with the lock held
+func (s *SolverStoreMemory) rewriteEventLog() error {
+	path := getLogPath(s.options.LogDir, "events")
+	file, err := os.Create(path + ".tmp")
+	if err != nil {
+		return err
+	}
+	writer := jsonl.NewWriter(file)
+	for _, event := range s.events {
+		err = writer.Write(event)
+		if err != nil {
+			file.Close()
+			return err
+		}
+	}
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+	err = os.Rename(path+".tmp", path)
+	if err != nil {
+		return err
+	}
+	logfile, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	s.logWriters["events"].Close()
+	s.logWriters["events"] = jsonl.NewWriter(logfile)
+	return nil
+}
+
+// the solver acting on an offer or deal, this must be called with the lock held
+func (s *SolverStoreMemory) getDealSolver(dealID string) string {
+	if deal, ok := s.dealMap[dealID]; ok {
+		return deal.Deal.Members.Solver
+	}
+	return ""
+}
+
+type SolverStoreMemoryOptions struct {
+	// the directory every change is logged to as jsonl, the records
+	// that outlive a restart are read back from here
+	LogDir string
+	// the most store events to hold, the oldest are dropped from memory
+	// and the events log past this, zero keeps them all
+	MaxEvents int
+}
+
+func getLogPath(dir string, kind string) string {
+	return filepath.Join(dir, fmt.Sprintf("lilypad_%s.jsonl", kind))
+}
+
+// read back the latest version of each record in a log
+// the last line for each ID wins
+func loadLog[T any](path string, getID func(*T) string) (map[string]*T, error) {
+	records := map[string]*T{}
+	logfile, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return records, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	reader := jsonl.NewReader(logfile)
+	defer reader.Close()
+	err = reader.ReadLines(func(line []byte) error {
+		var record T
+		// a line cut short by a crash is skipped
+		if json.Unmarshal(line, &record) != nil {
+			return nil
+		}
+		records[getID(&record)] = &record
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// everything else starts afresh but the transactions that were
+// still pending when we stopped are read back from their log so
+// that they can be picked up again
+func loadPendingTransactions(path string) (map[string]*data.Transaction, error) {
+	transactions, err := loadLog(path, func(tx *data.Transaction) string { return tx.ID })
+	if err != nil {
+		return nil, err
+	}
+	for id, tx := range transactions {
+		if tx.Status != data.TransactionPending {
+			delete(transactions, id)
+		}
+	}
+	return transactions, nil
+}
+
+// the chain sync carries on from where it got to before we stopped
+func loadChainCheckpoints(path string) (map[string]*data.ChainCheckpoint, error) {
+	return loadLog(path, func(checkpoint *data.ChainCheckpoint) string { return checkpoint.ID })
+}
+
+// recurring jobs carry on after a restart
+// a removed schedule is logged without its cron expression
+func loadJobSchedules(path string) (map[string]*data.JobSchedule, error) {
+	schedules, err := loadLog(path, func(schedule *data.JobSchedule) string { return schedule.ID })
+	if err != nil {
+		return nil, err
+	}
+	for id, schedule := range schedules {
+		if schedule.Cron == "" {
+			delete(schedules, id)
+		}
+	}
+	return schedules, nil
+}
+
+// reservations are held across a restart so the window still starts
+func loadCapacityReservations(path string) (map[string]*data.CapacityReservation, error) {
+	return loadLog(path, func(reservation *data.CapacityReservation) string { return reservation.ID })
+}
+
+// bans stay in place after a restart
+// a lifted ban is logged again with the time it was lifted
+func loadBannedAddresses(path string) (map[string]*data.BannedAddress, error) {
+	bans, err := loadLog(path, func(ban *data.BannedAddress) string { return strings.ToLower(ban.Address) })
+	if err != nil {
+		return nil, err
+	}
+	for address, ban := range bans {
+		if ban.LiftedAt != 0 {
+			delete(bans, address)
+		}
+	}
+	return bans, nil
+}
+
+// the admin log is kept for as long as the log file is
+func loadAdminActions(path string) (map[string]*data.AdminAction, error) {
+	return loadLog(path, func(action *data.AdminAction) string { return action.ID })
+}
+
+// so the clients list covers the ones that have not been back since a restart
+func loadClientRecords(path string) (map[string]*data.ClientRecord, error) {
+	return loadLog(path, func(record *data.ClientRecord) string { return data.GetClientRecordID(record.Address, record.Role) })
+}
+
+func NewSolverStoreMemory(options SolverStoreMemoryOptions) (*SolverStoreMemory, error) {
+	if options.LogDir == "" {
+		return nil, fmt.Errorf("the memory store needs a directory to log to")
+	}
+	err := os.MkdirAll(options.LogDir, 0755) //nolint:gomnd
+	if err != nil {
+		return nil, err
+	}
+	transactionMap, err := loadPendingTransactions(getLogPath(options.LogDir, "transactions"))
+	if err != nil {
+		return nil, err
+	}
+	checkpointMap, err := loadChainCheckpoints(getLogPath(options.LogDir, "checkpoints"))
+	if err != nil {
+		return nil, err
+	}
+	jobScheduleMap, err := loadJobSchedules(getLogPath(options.LogDir, "job_schedules"))
+	if err != nil {
+		return nil, err
+	}
+	reservationMap, err := loadCapacityReservations(getLogPath(options.LogDir, "reservations"))
+	if err != nil {
+		return nil, err
+	}
+	bannedMap, err := loadBannedAddresses(getLogPath(options.LogDir, "banned_addresses"))
+	if err != nil {
+		return nil, err
+	}
+	adminActionMap, err := loadAdminActions(getLogPath(options.LogDir, "admin_actions"))
+	if err != nil {
+		return nil, err
+	}
+	clientMap, err := loadClientRecords(getLogPath(options.LogDir, "clients"))
+	if err != nil {
+		return nil, err
+	}
+
 	logWriters := make(map[string]jsonl.Writer)
 
-	kinds := []string{"job_offers", "resource_offers", "deals", "decisions", "results"}
+	kinds := []string{"job_offers", "resource_offers", "deals", "decisions", "results", "audits", "timeouts", "escrow", "price_gaps", "result_pins", "receipts", "transactions", "checkpoints", "job_groups", "job_schedules", "reservations", "workflows", "input_stagings", "billing", "banned_addresses", "admin_actions", "clients", "events"}
 	for k := range kinds {
-		logfile, err := os.OpenFile(fmt.Sprintf("/var/tmp/lilypad_%s.jsonl", kinds[k]), os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
+		logfile, err := os.OpenFile(getLogPath(options.LogDir, kinds[k]), os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0644)
 		if err != nil {
 			return nil, err
 		}
@@ -43,6 +300,24 @@ func NewSolverStoreMemory() (*SolverStoreMemory, error) {
 		dealMap:          map[string]*data.DealContainer{},
 		resultMap:        map[string]*data.Result{},
 		matchDecisionMap: map[string]*data.MatchDecision{},
+		auditMap:         map[string]*data.Audit{},
+		timeoutEventMap:  map[string]*data.DealTimeoutEvent{},
+		escrowPaymentMap: map[string][]data.EscrowPayment{},
+		priceGapMap:      map[string]*data.PriceGap{},
+		resultPinMap:     map[string]*data.ResultPin{},
+		receiptMap:       map[string]*data.DealReceipt{},
+		transactionMap:   transactionMap,
+		checkpointMap:    checkpointMap,
+		jobGroupMap:      map[string]*data.JobGroup{},
+		jobScheduleMap:   jobScheduleMap,
+		reservationMap:   reservationMap,
+		workflowMap:      map[string]*data.Workflow{},
+		inputStagingMap:  map[string]*data.InputStaging{},
+		billingMap:       map[string]*data.BillingRecord{},
+		bannedMap:        bannedMap,
+		adminActionMap:   adminActionMap,
+		clientMap:        clientMap,
+		options:          options,
 		logWriters:       logWriters,
 	}, nil
 }
@@ -53,6 +328,7 @@ func (s *SolverStoreMemory) AddJobOffer(jobOffer data.JobOfferContainer) (*data.
 	s.jobOfferMap[jobOffer.ID] = &jobOffer
 
 	s.logWriters["job_offers"].Write(jobOffer)
+	s.addEvent(data.JobOfferAddedEvent, jobOffer.ID, jobOffer.JobCreator, jobOffer)
 	return &jobOffer, nil
 }
 
@@ -62,14 +338,19 @@ func (s *SolverStoreMemory) AddResourceOffer(resourceOffer data.ResourceOfferCon
 	s.resourceOfferMap[resourceOffer.ID] = &resourceOffer
 
 	s.logWriters["resource_offers"].Write(resourceOffer)
+	s.addEvent(data.ResourceOfferAddedEvent, resourceOffer.ID, resourceOffer.ResourceProvider, resourceOffer)
 	return &resourceOffer, nil
 }
 
 func (s *SolverStoreMemory) AddDeal(deal data.DealContainer) (*data.DealContainer, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
+	if deal.StateUpdatedAt == 0 {
+		deal.StateUpdatedAt = time.Now().Unix()
+	}
 	s.dealMap[deal.ID] = &deal
 	s.logWriters["deals"].Write(deal)
+	s.addEvent(data.DealAddedEvent, deal.ID, deal.Deal.Members.Solver, deal)
 	return &deal, nil
 }
 
@@ -78,6 +359,11 @@ func (s *SolverStoreMemory) AddResult(result data.Result) (*data.Result, error)
 	defer s.mutex.Unlock()
 	s.resultMap[result.DealID] = &result
 	s.logWriters["results"].Write(result)
+	actor := ""
+	if deal, ok := s.dealMap[result.DealID]; ok {
+		actor = deal.ResourceProvider
+	}
+	s.addEvent(data.ResultAddedEvent, result.DealID, actor, result)
 	return &result, nil
 }
 
@@ -97,9 +383,192 @@ func (s *SolverStoreMemory) AddMatchDecision(resourceOffer string, jobOffer stri
 	}
 	s.matchDecisionMap[id] = decision
 	s.logWriters["decisions"].Write(decision)
+	actor := ""
+	if offer, ok := s.jobOfferMap[jobOffer]; ok {
+		actor = offer.JobOffer.Services.Solver
+	}
+	s.addEvent(data.MatchDecisionAddedEvent, id, actor, decision)
 	return decision, nil
 }
 
+func (s *SolverStoreMemory) AddAudit(audit data.Audit) (*data.Audit, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.auditMap[audit.DealID] = &audit
+	s.logWriters["audits"].Write(audit)
+	s.addEvent(data.AuditAddedEvent, audit.DealID, s.getDealSolver(audit.DealID), audit)
+	return &audit, nil
+}
+
+func (s *SolverStoreMemory) AddTimeoutEvent(event data.DealTimeoutEvent) (*data.DealTimeoutEvent, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.timeoutEventMap[event.DealID] = &event
+	s.logWriters["timeouts"].Write(event)
+	s.addEvent(data.TimeoutEventAddedEvent, event.DealID, s.getDealSolver(event.DealID), event)
+	return &event, nil
+}
+
+func (s *SolverStoreMemory) AddEscrowPayment(payment data.EscrowPayment) (*data.EscrowPayment, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	// a resubscribe can replay events we have already seen
+	for _, existing := range s.escrowPaymentMap[payment.DealID] {
+		if existing.TransactionHash == payment.TransactionHash && existing.LogIndex == payment.LogIndex {
+			return &existing, nil
+		}
+	}
+	s.escrowPaymentMap[payment.DealID] = append(s.escrowPaymentMap[payment.DealID], payment)
+	s.logWriters["escrow"].Write(payment)
+	s.addEvent(data.EscrowPaymentAddedEvent, payment.DealID, "", payment)
+	return &payment, nil
+}
+
+func (s *SolverStoreMemory) AddPriceGap(gap data.PriceGap) (*data.PriceGap, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	id := getMatchID(gap.ResourceOffer, gap.JobOffer)
+	s.priceGapMap[id] = &gap
+	s.logWriters["price_gaps"].Write(gap)
+	actor := ""
+	if offer, ok := s.jobOfferMap[gap.JobOffer]; ok {
+		actor = offer.JobOffer.Services.Solver
+	}
+	s.addEvent(data.PriceGapAddedEvent, id, actor, gap)
+	return &gap, nil
+}
+
+// there is one pin for each deal and service, adding it again updates it
+func (s *SolverStoreMemory) AddResultPin(pin data.ResultPin) (*data.ResultPin, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	id := getResultPinID(pin.DealID, pin.Service)
+	s.resultPinMap[id] = &pin
+	s.logWriters["result_pins"].Write(pin)
+	s.addEvent(data.ResultPinUpdatedEvent, id, s.getDealSolver(pin.DealID), pin)
+	return &pin, nil
+}
+
+func (s *SolverStoreMemory) AddDealReceipt(receipt data.DealReceipt) (*data.DealReceipt, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.receiptMap[receipt.DealID] = &receipt
+	s.logWriters["receipts"].Write(receipt)
+	s.addEvent(data.DealReceiptAddedEvent, receipt.DealID, receipt.Solver, receipt)
+	return &receipt, nil
+}
+
+func (s *SolverStoreMemory) AddBillingRecord(record data.BillingRecord) (*data.BillingRecord, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.billingMap[record.DealID] = &record
+	s.logWriters["billing"].Write(record)
+	s.addEvent(data.BillingRecordAddedEvent, record.DealID, s.getDealSolver(record.DealID), record)
+	return &record, nil
+}
+
+// there is one record for each nonce, adding it again updates it
+func (s *SolverStoreMemory) AddTransaction(tx data.Transaction) (*data.Transaction, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.transactionMap[tx.ID] = &tx
+	s.logWriters["transactions"].Write(tx)
+	s.addEvent(data.TransactionUpdatedEvent, tx.ID, tx.From, tx)
+	return &tx, nil
+}
+
+func (s *SolverStoreMemory) AddJobGroup(group data.JobGroup) (*data.JobGroup, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.jobGroupMap[group.ID] = &group
+	s.logWriters["job_groups"].Write(group)
+	s.addEvent(data.JobGroupAddedEvent, group.ID, group.JobCreator, group)
+	return &group, nil
+}
+
+// there is one record for each schedule, adding it again updates it
+func (s *SolverStoreMemory) AddJobSchedule(schedule data.JobSchedule) (*data.JobSchedule, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.jobScheduleMap[schedule.ID] = &schedule
+	s.logWriters["job_schedules"].Write(schedule)
+	s.addEvent(data.JobScheduleUpdatedEvent, schedule.ID, schedule.JobCreator, schedule)
+	return &schedule, nil
+}
+
+// there is one record for each reservation, adding it again updates it
+func (s *SolverStoreMemory) AddCapacityReservation(reservation data.CapacityReservation) (*data.CapacityReservation, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.reservationMap[reservation.ID] = &reservation
+	s.logWriters["reservations"].Write(reservation)
+	s.addEvent(data.CapacityReservationUpdatedEvent, reservation.ID, reservation.JobCreator, reservation)
+	return &reservation, nil
+}
+
+// banning an address again replaces the reason
+func (s *SolverStoreMemory) AddBannedAddress(ban data.BannedAddress) (*data.BannedAddress, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.bannedMap[strings.ToLower(ban.Address)] = &ban
+	s.logWriters["banned_addresses"].Write(ban)
+	s.addEvent(data.AddressBannedEvent, ban.Address, ban.BannedBy, ban)
+	return &ban, nil
+}
+
+func (s *SolverStoreMemory) AddAdminAction(action data.AdminAction) (*data.AdminAction, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.adminActionMap[action.ID] = &action
+	s.logWriters["admin_actions"].Write(action)
+	s.addEvent(data.AdminActionAddedEvent, action.ID, action.Admin, action)
+	return &action, nil
+}
+
+// there is one record for each workflow, adding it again updates it
+func (s *SolverStoreMemory) AddWorkflow(workflow data.Workflow) (*data.Workflow, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.workflowMap[workflow.ID] = &workflow
+	s.logWriters["workflows"].Write(workflow)
+	s.addEvent(data.WorkflowUpdatedEvent, workflow.ID, workflow.JobCreator, workflow)
+	return &workflow, nil
+}
+
+// there is one record for each staging request, adding it again updates it
+func (s *SolverStoreMemory) AddInputStaging(staging data.InputStaging) (*data.InputStaging, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.inputStagingMap[staging.ID] = &staging
+	s.logWriters["input_stagings"].Write(staging)
+	s.addEvent(data.InputStagingUpdatedEvent, staging.ID, staging.JobCreator, staging)
+	return &staging, nil
+}
+
+// every offer updates these so they are not recorded as store events
+// the first time we saw the client is kept
+func (s *SolverStoreMemory) UpdateClientRecord(record data.ClientRecord) (*data.ClientRecord, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	id := data.GetClientRecordID(record.Address, record.Role)
+	if existing, ok := s.clientMap[id]; ok && existing.FirstSeen > 0 {
+		record.FirstSeen = existing.FirstSeen
+	}
+	s.clientMap[id] = &record
+	s.logWriters["clients"].Write(record)
+	return &record, nil
+}
+
+// the sync saves these often so they are not recorded as store events
+func (s *SolverStoreMemory) UpdateChainCheckpoint(checkpoint data.ChainCheckpoint) (*data.ChainCheckpoint, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	checkpoint.ID = data.GetChainCheckpointID(checkpoint.ChainID, checkpoint.Contract)
+	s.checkpointMap[checkpoint.ID] = &checkpoint
+	s.logWriters["checkpoints"].Write(checkpoint)
+	return &checkpoint, nil
+}
+
 func (s *SolverStoreMemory) GetJobOffers(query store.GetJobOffersQuery) ([]data.JobOfferContainer, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
@@ -109,6 +578,12 @@ func (s *SolverStoreMemory) GetJobOffers(query store.GetJobOffersQuery) ([]data.
 		if query.JobCreator != "" && jobOffer.JobCreator != query.JobCreator {
 			matching = false
 		}
+		if query.ChainID != 0 && jobOffer.JobOffer.ChainID != query.ChainID {
+			matching = false
+		}
+		if query.IdempotencyKey != "" && jobOffer.JobOffer.IdempotencyKey != query.IdempotencyKey {
+			matching = false
+		}
 		if query.NotMatched {
 			if jobOffer.DealID != "" {
 				matching = false
@@ -133,6 +608,12 @@ func (s *SolverStoreMemory) GetResourceOffers(query store.GetResourceOffersQuery
 		if query.ResourceProvider != "" && resourceOffer.ResourceProvider != query.ResourceProvider {
 			matching = false
 		}
+		if query.ChainID != 0 && resourceOffer.ResourceOffer.ChainID != query.ChainID {
+			matching = false
+		}
+		if query.IdempotencyKey != "" && resourceOffer.ResourceOffer.IdempotencyKey != query.IdempotencyKey {
+			matching = false
+		}
 		if query.Active && !data.IsActiveAgreementState(resourceOffer.State) {
 			matching = false
 		}
@@ -171,6 +652,15 @@ func (s *SolverStoreMemory) GetDeals(query store.GetDealsQuery) ([]data.DealCont
 		if query.Mediator != "" && deal.Mediator != query.Mediator {
 			matching = false
 		}
+		if query.ChainID != 0 && deal.ChainID != query.ChainID {
+			matching = false
+		}
+		if query.ParentDealID != "" && deal.ParentDealID != query.ParentDealID {
+			matching = false
+		}
+		if query.ResultKey != "" && deal.ResultKey != query.ResultKey {
+			matching = false
+		}
 		if query.State != "" && deal.State != queryState {
 			matching = false
 		}
@@ -243,6 +733,472 @@ func (s *SolverStoreMemory) GetMatchDecision(resourceOffer string, jobOffer stri
 	return decision, nil
 }
 
+func (s *SolverStoreMemory) GetAudit(dealID string) (*data.Audit, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	audit, ok := s.auditMap[dealID]
+	if !ok {
+		return nil, nil
+	}
+	return audit, nil
+}
+
+func (s *SolverStoreMemory) GetTimeoutEvent(dealID string) (*data.DealTimeoutEvent, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	event, ok := s.timeoutEventMap[dealID]
+	if !ok {
+		return nil, nil
+	}
+	return event, nil
+}
+
+func (s *SolverStoreMemory) GetDealReceipt(dealID string) (*data.DealReceipt, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	receipt, ok := s.receiptMap[dealID]
+	if !ok {
+		return nil, nil
+	}
+	return receipt, nil
+}
+
+func (s *SolverStoreMemory) GetChainCheckpoint(chainID int, contract string) (*data.ChainCheckpoint, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	checkpoint, ok := s.checkpointMap[data.GetChainCheckpointID(chainID, contract)]
+	if !ok {
+		return nil, nil
+	}
+	return checkpoint, nil
+}
+
+func (s *SolverStoreMemory) GetTransaction(id string) (*data.Transaction, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	tx, ok := s.transactionMap[id]
+	if !ok {
+		return nil, nil
+	}
+	return tx, nil
+}
+
+func (s *SolverStoreMemory) GetJobGroup(id string) (*data.JobGroup, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	group, ok := s.jobGroupMap[id]
+	if !ok {
+		return nil, nil
+	}
+	return group, nil
+}
+
+func (s *SolverStoreMemory) GetJobSchedule(id string) (*data.JobSchedule, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	schedule, ok := s.jobScheduleMap[id]
+	if !ok {
+		return nil, nil
+	}
+	return schedule, nil
+}
+
+func (s *SolverStoreMemory) GetJobSchedules(query store.GetJobSchedulesQuery) ([]data.JobSchedule, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	schedules := []data.JobSchedule{}
+	for _, schedule := range s.jobScheduleMap {
+		if query.JobCreator != "" && schedule.JobCreator != query.JobCreator {
+			continue
+		}
+		schedules = append(schedules, *schedule)
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].CreatedAt < schedules[j].CreatedAt
+	})
+	return schedules, nil
+}
+
+func (s *SolverStoreMemory) GetCapacityReservation(id string) (*data.CapacityReservation, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	reservation, ok := s.reservationMap[id]
+	if !ok {
+		return nil, nil
+	}
+	return reservation, nil
+}
+
+func (s *SolverStoreMemory) GetCapacityReservations(query store.GetCapacityReservationsQuery) ([]data.CapacityReservation, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	reservations := []data.CapacityReservation{}
+	for _, reservation := range s.reservationMap {
+		if query.JobCreator != "" && reservation.JobCreator != query.JobCreator {
+			continue
+		}
+		if query.ResourceProvider != "" && !strings.EqualFold(reservation.ResourceProvider, query.ResourceProvider) {
+			continue
+		}
+		if query.Open && !data.IsReservationOpen(*reservation) {
+			continue
+		}
+		reservations = append(reservations, *reservation)
+	}
+	sort.Slice(reservations, func(i, j int) bool {
+		return reservations[i].StartAt < reservations[j].StartAt
+	})
+	return reservations, nil
+}
+
+func (s *SolverStoreMemory) GetWorkflow(id string) (*data.Workflow, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	workflow, ok := s.workflowMap[id]
+	if !ok {
+		return nil, nil
+	}
+	return workflow, nil
+}
+
+func (s *SolverStoreMemory) GetWorkflows(query store.GetWorkflowsQuery) ([]data.Workflow, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	workflows := []data.Workflow{}
+	for _, workflow := range s.workflowMap {
+		if query.JobCreator != "" && workflow.JobCreator != query.JobCreator {
+			continue
+		}
+		if query.Running && workflow.State != data.WorkflowStageRunning {
+			continue
+		}
+		workflows = append(workflows, *workflow)
+	}
+	sort.Slice(workflows, func(i, j int) bool {
+		return workflows[i].CreatedAt < workflows[j].CreatedAt
+	})
+	return workflows, nil
+}
+
+func (s *SolverStoreMemory) GetInputStaging(id string) (*data.InputStaging, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	staging, ok := s.inputStagingMap[id]
+	if !ok {
+		return nil, nil
+	}
+	return staging, nil
+}
+
+func (s *SolverStoreMemory) GetInputStagings(query store.GetInputStagingsQuery) ([]data.InputStaging, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	stagings := []data.InputStaging{}
+	for _, staging := range s.inputStagingMap {
+		if query.JobCreator != "" && staging.JobCreator != query.JobCreator {
+			continue
+		}
+		if query.ResourceProvider != "" && staging.ResourceProvider != query.ResourceProvider {
+			continue
+		}
+		if query.State != "" && staging.State != query.State {
+			continue
+		}
+		stagings = append(stagings, *staging)
+	}
+	sort.Slice(stagings, func(i, j int) bool {
+		return stagings[i].CreatedAt < stagings[j].CreatedAt
+	})
+	return stagings, nil
+}
+
+func (s *SolverStoreMemory) GetBillingRecords(query store.GetBillingRecordsQuery) ([]data.BillingRecord, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	records := []data.BillingRecord{}
+	for _, record := range s.billingMap {
+		if query.JobCreator != "" && record.JobCreator != query.JobCreator {
+			continue
+		}
+		if query.ResourceProvider != "" && record.ResourceProvider != query.ResourceProvider {
+			continue
+		}
+		if record.CreatedAt < query.From {
+			continue
+		}
+		if query.To != 0 && record.CreatedAt >= query.To {
+			continue
+		}
+		records = append(records, *record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].CreatedAt < records[j].CreatedAt
+	})
+	return records, nil
+}
+
+func (s *SolverStoreMemory) GetTransactions(query store.GetTransactionsQuery) ([]data.Transaction, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	transactions := []data.Transaction{}
+	for _, tx := range s.transactionMap {
+		if query.DealID != "" && tx.DealID != query.DealID {
+			continue
+		}
+		if query.Status != "" && tx.Status != query.Status {
+			continue
+		}
+		if query.ChainID != 0 && tx.ChainID != query.ChainID {
+			continue
+		}
+		transactions = append(transactions, *tx)
+	}
+	sort.Slice(transactions, func(i, j int) bool {
+		if transactions[i].CreatedAt == transactions[j].CreatedAt {
+			return transactions[i].Nonce < transactions[j].Nonce
+		}
+		return transactions[i].CreatedAt < transactions[j].CreatedAt
+	})
+	return transactions, nil
+}
+
+func (s *SolverStoreMemory) GetEscrowPayments(dealID string) ([]data.EscrowPayment, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	payments := []data.EscrowPayment{}
+	payments = append(payments, s.escrowPaymentMap[dealID]...)
+	return payments, nil
+}
+
+// the payments across every deal that slashed collateral
+func (s *SolverStoreMemory) GetSlashings(query store.GetSlashingsQuery) ([]data.EscrowPayment, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	slashings := []data.EscrowPayment{}
+	for _, payments := range s.escrowPaymentMap {
+		for _, payment := range payments {
+			if payment.Direction != escrow.DirectionSlashed {
+				continue
+			}
+			if query.Payee != "" && !strings.EqualFold(payment.Payee, query.Payee) {
+				continue
+			}
+			if payment.CreatedAt < query.From {
+				continue
+			}
+			slashings = append(slashings, payment)
+		}
+	}
+	sort.Slice(slashings, func(i, j int) bool {
+		return slashings[i].CreatedAt < slashings[j].CreatedAt
+	})
+	return slashings, nil
+}
+
+func (s *SolverStoreMemory) GetPriceGaps(query store.GetPriceGapsQuery) ([]data.PriceGap, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	gaps := []data.PriceGap{}
+	for _, gap := range s.priceGapMap {
+		// a counter-offer is only worth making while both offers are still open
+		jobOffer, ok := s.jobOfferMap[gap.JobOffer]
+		if !ok || jobOffer.DealID != "" || jobOffer.State == data.GetAgreementStateIndex("JobOfferCancelled") {
+			continue
+		}
+		resourceOffer, ok := s.resourceOfferMap[gap.ResourceOffer]
+		if !ok || resourceOffer.DealID != "" {
+			continue
+		}
+		if query.JobOffer != "" && gap.JobOffer != query.JobOffer {
+			continue
+		}
+		if query.ResourceOffer != "" && gap.ResourceOffer != query.ResourceOffer {
+			continue
+		}
+		if query.JobCreator != "" && !strings.EqualFold(gap.JobCreator, query.JobCreator) {
+			continue
+		}
+		if query.ResourceProvider != "" && !strings.EqualFold(gap.ResourceProvider, query.ResourceProvider) {
+			continue
+		}
+		gaps = append(gaps, *gap)
+	}
+	sort.Slice(gaps, func(i, j int) bool {
+		return gaps[i].CreatedAt < gaps[j].CreatedAt
+	})
+	return gaps, nil
+}
+
+func (s *SolverStoreMemory) GetResultPins(query store.GetResultPinsQuery) ([]data.ResultPin, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	pins := []data.ResultPin{}
+	for _, pin := range s.resultPinMap {
+		if query.DealID != "" && pin.DealID != query.DealID {
+			continue
+		}
+		if query.Service != "" && pin.Service != query.Service {
+			continue
+		}
+		if query.Status != "" && pin.Status != query.Status {
+			continue
+		}
+		pins = append(pins, *pin)
+	}
+	sort.Slice(pins, func(i, j int) bool {
+		if pins[i].CreatedAt == pins[j].CreatedAt {
+			return pins[i].Service < pins[j].Service
+		}
+		return pins[i].CreatedAt < pins[j].CreatedAt
+	})
+	return pins, nil
+}
+
+func (s *SolverStoreMemory) GetStoreEvents(query store.GetStoreEventsQuery) ([]data.StoreEvent, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	events := []data.StoreEvent{}
+	// sequences go up by one so the position follows from the first one we hold
+	start := 0
+	if len(s.events) > 0 && query.After >= s.events[0].Sequence {
+		start = int(query.After - s.events[0].Sequence + 1)
+	}
+	if start > len(s.events) {
+		start = len(s.events)
+	}
+	for _, event := range s.events[start:] {
+		if query.Type != "" && string(event.Type) != query.Type {
+			continue
+		}
+		if query.ObjectID != "" && event.ObjectID != query.ObjectID {
+			continue
+		}
+		events = append(events, event)
+		if query.Limit > 0 && len(events) >= query.Limit {
+			break
+		}
+	}
+	return events, nil
+}
+
+func (s *SolverStoreMemory) GetBannedAddress(address string) (*data.BannedAddress, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	ban, ok := s.bannedMap[strings.ToLower(address)]
+	if !ok {
+		return nil, nil
+	}
+	return ban, nil
+}
+
+func (s *SolverStoreMemory) GetBannedAddresses() ([]data.BannedAddress, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	bans := []data.BannedAddress{}
+	for _, ban := range s.bannedMap {
+		bans = append(bans, *ban)
+	}
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].CreatedAt < bans[j].CreatedAt
+	})
+	return bans, nil
+}
+
+// the clients seen most recently come first
+func (s *SolverStoreMemory) GetClientRecords(query store.GetClientRecordsQuery) ([]data.ClientRecord, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	records := []data.ClientRecord{}
+	for _, record := range s.clientMap {
+		if query.Role != "" && record.Role != query.Role {
+			continue
+		}
+		if query.Since > 0 && record.LastSeen < query.Since {
+			continue
+		}
+		records = append(records, *record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].LastSeen > records[j].LastSeen
+	})
+	return records, nil
+}
+
+// the most recent actions come first
+func (s *SolverStoreMemory) GetAdminActions(query store.GetAdminActionsQuery) ([]data.AdminAction, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	actions := []data.AdminAction{}
+	for _, action := range s.adminActionMap {
+		if query.Admin != "" && !strings.EqualFold(action.Admin, query.Admin) {
+			continue
+		}
+		actions = append(actions, *action)
+	}
+	sort.Slice(actions, func(i, j int) bool {
+		return actions[i].CreatedAt > actions[j].CreatedAt
+	})
+	if query.Limit > 0 && len(actions) > query.Limit {
+		actions = actions[:query.Limit]
+	}
+	return actions, nil
+}
+
+func (s *SolverStoreMemory) GetStats() (store.StoreStats, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	escrowPayments := 0
+	for _, payments := range s.escrowPaymentMap {
+		escrowPayments += len(payments)
+	}
+	return store.StoreStats{
+		Counts: map[string]int{
+			"job_offers":       len(s.jobOfferMap),
+			"resource_offers":  len(s.resourceOfferMap),
+			"deals":            len(s.dealMap),
+			"results":          len(s.resultMap),
+			"decisions":        len(s.matchDecisionMap),
+			"audits":           len(s.auditMap),
+			"timeouts":         len(s.timeoutEventMap),
+			"escrow":           escrowPayments,
+			"price_gaps":       len(s.priceGapMap),
+			"result_pins":      len(s.resultPinMap),
+			"receipts":         len(s.receiptMap),
+			"transactions":     len(s.transactionMap),
+			"checkpoints":      len(s.checkpointMap),
+			"job_groups":       len(s.jobGroupMap),
+			"job_schedules":    len(s.jobScheduleMap),
+			"reservations":     len(s.reservationMap),
+			"workflows":        len(s.workflowMap),
+			"input_stagings":   len(s.inputStagingMap),
+			"billing":          len(s.billingMap),
+			"banned_addresses": len(s.bannedMap),
+			"admin_actions":    len(s.adminActionMap),
+			"clients":          len(s.clientMap),
+		},
+		Events: uint64(len(s.events)),
+	}, nil
+}
+
+func (s *SolverStoreMemory) GetAudits(query store.GetAuditsQuery) ([]data.Audit, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	audits := []data.Audit{}
+	for _, audit := range s.auditMap {
+		matching := true
+		if query.ResourceProvider != "" && strings.ToLower(audit.ResourceProvider) != strings.ToLower(query.ResourceProvider) {
+			matching = false
+		}
+		if query.State != "" && audit.State != query.State {
+			matching = false
+		}
+		if matching {
+			audits = append(audits, *audit)
+		}
+	}
+	return audits, nil
+}
+
 func (s *SolverStoreMemory) UpdateJobOfferState(id string, dealID string, state uint8) (*data.JobOfferContainer, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
@@ -250,9 +1206,14 @@ func (s *SolverStoreMemory) UpdateJobOfferState(id string, dealID string, state
 	if !ok {
 		return nil, fmt.Errorf("job offer not found: %s", id)
 	}
+	err := data.ValidateDealStateTransition(jobOffer.State, state)
+	if err != nil {
+		return nil, fmt.Errorf("job offer %s: %w", id, err)
+	}
 	jobOffer.DealID = dealID
 	jobOffer.State = state
 	s.jobOfferMap[id] = jobOffer
+	s.addEvent(data.JobOfferStateUpdatedEvent, id, jobOffer.JobOffer.Services.Solver, jobOffer)
 	return jobOffer, nil
 }
 
@@ -263,9 +1224,14 @@ func (s *SolverStoreMemory) UpdateResourceOfferState(id string, dealID string, s
 	if !ok {
 		return nil, fmt.Errorf("resource offer not found: %s", id)
 	}
+	err := data.ValidateDealStateTransition(resourceOffer.State, state)
+	if err != nil {
+		return nil, fmt.Errorf("resource offer %s: %w", id, err)
+	}
 	resourceOffer.DealID = dealID
 	resourceOffer.State = state
 	s.resourceOfferMap[id] = resourceOffer
+	s.addEvent(data.ResourceOfferStateUpdatedEvent, id, resourceOffer.ResourceOffer.Services.Solver, resourceOffer)
 	return resourceOffer, nil
 }
 
@@ -276,8 +1242,49 @@ func (s *SolverStoreMemory) UpdateDealState(id string, state uint8) (*data.DealC
 	if !ok {
 		return nil, fmt.Errorf("deal not found: %s", id)
 	}
+	err := data.ValidateDealStateTransition(deal.State, state)
+	if err != nil {
+		return nil, fmt.Errorf("deal %s: %w", id, err)
+	}
+	changed := deal.State != state
+	if changed {
+		deal.StateUpdatedAt = time.Now().Unix()
+	}
 	deal.State = state
 	s.dealMap[id] = deal
+	// deal states come from the chain so there is no actor
+	if changed {
+		s.addEvent(data.DealStateUpdatedEvent, id, "", deal)
+	}
+	return deal, nil
+}
+
+// the one way a deal can go backwards, for when a reorg takes back the change
+// the offers of the deal are moved back with it
+func (s *SolverStoreMemory) RollbackDealState(id string, state uint8) (*data.DealContainer, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	deal, ok := s.dealMap[id]
+	if !ok {
+		return nil, fmt.Errorf("deal not found: %s", id)
+	}
+	if !data.DealState(state).IsValid() {
+		return nil, fmt.Errorf("unknown deal state %d", state)
+	}
+	if deal.State == state {
+		return deal, nil
+	}
+	deal.State = state
+	deal.StateUpdatedAt = time.Now().Unix()
+	s.addEvent(data.DealStateRolledBackEvent, id, "", deal)
+	if jobOffer, ok := s.jobOfferMap[deal.JobOffer]; ok {
+		jobOffer.State = state
+		s.addEvent(data.JobOfferStateUpdatedEvent, jobOffer.ID, jobOffer.JobOffer.Services.Solver, jobOffer)
+	}
+	if resourceOffer, ok := s.resourceOfferMap[deal.ResourceOffer]; ok {
+		resourceOffer.State = state
+		s.addEvent(data.ResourceOfferStateUpdatedEvent, resourceOffer.ID, resourceOffer.ResourceOffer.Services.Solver, resourceOffer)
+	}
 	return deal, nil
 }
 
@@ -290,6 +1297,122 @@ func (s *SolverStoreMemory) UpdateDealMediator(id string, mediator string) (*dat
 	}
 	deal.Mediator = mediator
 	s.dealMap[id] = deal
+	s.addEvent(data.DealMediatorUpdatedEvent, id, "", deal)
+	return deal, nil
+}
+
+func (s *SolverStoreMemory) UpdateDealCheckpoint(id string, checkpoint data.DealCheckpoint) (*data.DealContainer, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	deal, ok := s.dealMap[id]
+	if !ok {
+		return nil, fmt.Errorf("deal not found: %s", id)
+	}
+	deal.Checkpoint = &checkpoint
+	s.dealMap[id] = deal
+	s.addEvent(data.DealCheckpointUpdatedEvent, id, "", deal)
+	return deal, nil
+}
+
+func (s *SolverStoreMemory) UpdateDealServiceUsage(id string, usage data.ServiceUsage) (*data.DealContainer, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	deal, ok := s.dealMap[id]
+	if !ok {
+		return nil, fmt.Errorf("deal not found: %s", id)
+	}
+	deal.ServiceUsage = &usage
+	s.dealMap[id] = deal
+	s.addEvent(data.DealServiceUsageUpdatedEvent, id, "", deal)
+	return deal, nil
+}
+
+func (s *SolverStoreMemory) UpdateDealMilestone(id string, milestone data.DealMilestone) (*data.DealContainer, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	deal, ok := s.dealMap[id]
+	if !ok {
+		return nil, fmt.Errorf("deal not found: %s", id)
+	}
+	milestones := []data.DealMilestone{}
+	found := false
+	for _, existing := range deal.Milestones {
+		if existing.Index == milestone.Index {
+			existing = milestone
+			found = true
+		}
+		milestones = append(milestones, existing)
+	}
+	if !found {
+		milestones = append(milestones, milestone)
+	}
+	deal.Milestones = milestones
+	s.dealMap[id] = deal
+	s.addEvent(data.DealMilestoneUpdatedEvent, id, "", deal)
+	return deal, nil
+}
+
+func (s *SolverStoreMemory) AddDealEncryptedInputs(id string, encrypted data.DealEncryptedInputs) (*data.DealContainer, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	deal, ok := s.dealMap[id]
+	if !ok {
+		return nil, fmt.Errorf("deal not found: %s", id)
+	}
+	deal.EncryptedInputs = &encrypted
+	s.dealMap[id] = deal
+	s.addEvent(data.DealEncryptedInputsAddedEvent, id, deal.JobCreator, deal)
+	return deal, nil
+}
+
+func (s *SolverStoreMemory) CancelDeal(id string, cancelledAt int64) (*data.DealContainer, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	deal, ok := s.dealMap[id]
+	if !ok {
+		return nil, fmt.Errorf("deal not found: %s", id)
+	}
+	// the first cancellation is the one that counts
+	if deal.CancelledAt == 0 {
+		deal.CancelledAt = cancelledAt
+		s.dealMap[id] = deal
+		s.addEvent(data.DealCancelledEvent, id, "", deal)
+	}
+	return deal, nil
+}
+
+func (s *SolverStoreMemory) PreemptDeal(id string, preemption data.DealPreemption) (*data.DealContainer, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	deal, ok := s.dealMap[id]
+	if !ok {
+		return nil, fmt.Errorf("deal not found: %s", id)
+	}
+	// the notice runs from the first preemption
+	if deal.Preemption == nil {
+		deal.Preemption = &preemption
+		s.dealMap[id] = deal
+		s.addEvent(data.DealPreemptedEvent, id, "", deal)
+	}
+	return deal, nil
+}
+
+func (s *SolverStoreMemory) AddMediationVerdict(id string, verdict data.MediationVerdict) (*data.DealContainer, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	deal, ok := s.dealMap[id]
+	if !ok {
+		return nil, fmt.Errorf("deal not found: %s", id)
+	}
+	// a mediator cannot change its mind once its verdict is in
+	for _, existing := range deal.MediationVerdicts {
+		if strings.EqualFold(existing.Mediator, verdict.Mediator) {
+			return deal, nil
+		}
+	}
+	deal.MediationVerdicts = append(deal.MediationVerdicts, verdict)
+	s.dealMap[id] = deal
+	s.addEvent(data.MediationVerdictAddedEvent, id, "", deal)
 	return deal, nil
 }
 
@@ -316,6 +1439,7 @@ func (s *SolverStoreMemory) UpdateDealTransactionsResourceProvider(id string, da
 	if data.TimeoutMediateResult != "" {
 		txs.TimeoutMediateResult = data.TimeoutMediateResult
 	}
+	s.addEvent(resourceProviderTransactionsUpdatedEvent, id, deal.ResourceProvider, deal)
 	return deal, nil
 }
 func (s *SolverStoreMemory) UpdateDealTransactionsJobCreator(id string, data data.DealTransactionsJobCreator) (*data.DealContainer, error) {
@@ -345,6 +1469,7 @@ func (s *SolverStoreMemory) UpdateDealTransactionsJobCreator(id string, data dat
 		txs.TimeoutMediateResult = data.TimeoutMediateResult
 	}
 	s.dealMap[id] = deal
+	s.addEvent(jobCreatorTransactionsUpdatedEvent, id, deal.JobCreator, deal)
 	return deal, nil
 }
 
@@ -363,22 +1488,148 @@ func (s *SolverStoreMemory) UpdateDealTransactionsMediator(id string, data data.
 		txs.MediationRejectResult = data.MediationRejectResult
 	}
 	s.dealMap[id] = deal
+	s.addEvent(mediatorTransactionsUpdatedEvent, id, deal.Mediator, deal)
 	return deal, nil
 }
 
+func (s *SolverStoreMemory) UpdateAuditState(dealID string, state string, message string) (*data.Audit, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	audit, ok := s.auditMap[dealID]
+	if !ok {
+		return nil, fmt.Errorf("audit not found: %s", dealID)
+	}
+	audit.State = state
+	audit.Message = message
+	s.logWriters["audits"].Write(audit)
+	s.addEvent(data.AuditStateUpdatedEvent, dealID, s.getDealSolver(dealID), audit)
+	return audit, nil
+}
+
 func (s *SolverStoreMemory) RemoveJobOffer(id string) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	delete(s.jobOfferMap, id)
+	if jobOffer, ok := s.jobOfferMap[id]; ok {
+		delete(s.jobOfferMap, id)
+		s.addEvent(data.JobOfferRemovedEvent, id, jobOffer.JobOffer.Services.Solver, jobOffer)
+	}
+	return nil
+}
+
+func (s *SolverStoreMemory) RemoveJobSchedule(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if schedule, ok := s.jobScheduleMap[id]; ok {
+		delete(s.jobScheduleMap, id)
+		s.logWriters["job_schedules"].Write(data.JobSchedule{ID: id})
+		s.addEvent(data.JobScheduleRemovedEvent, id, schedule.JobCreator, schedule)
+	}
+	return nil
+}
+
+func (s *SolverStoreMemory) RemoveEscrowPayment(dealID string, transactionHash string, logIndex uint) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	payments := []data.EscrowPayment{}
+	for _, payment := range s.escrowPaymentMap[dealID] {
+		if payment.TransactionHash == transactionHash && payment.LogIndex == logIndex {
+			s.addEvent(data.EscrowPaymentRemovedEvent, dealID, "", payment)
+			continue
+		}
+		payments = append(payments, payment)
+	}
+	s.escrowPaymentMap[dealID] = payments
 	return nil
 }
 
 func (s *SolverStoreMemory) RemoveResourceOffer(id string) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	delete(s.resourceOfferMap, id)
+	if resourceOffer, ok := s.resourceOfferMap[id]; ok {
+		delete(s.resourceOfferMap, id)
+		s.addEvent(data.ResourceOfferRemovedEvent, id, resourceOffer.ResourceOffer.Services.Solver, resourceOffer)
+	}
+	return nil
+}
+
+func (s *SolverStoreMemory) RemoveBannedAddress(address string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if ban, ok := s.bannedMap[strings.ToLower(address)]; ok {
+		delete(s.bannedMap, strings.ToLower(address))
+		lifted := *ban
+		lifted.LiftedAt = time.Now().Unix()
+		s.logWriters["banned_addresses"].Write(lifted)
+		s.addEvent(data.AddressUnbannedEvent, ban.Address, "", lifted)
+	}
 	return nil
 }
 
+// this must be called with the lock held
+func (s *SolverStoreMemory) getDealRecords(deal *data.DealContainer) *store.DealRecords {
+	records := &store.DealRecords{
+		Deal:           *deal,
+		JobOffer:       s.jobOfferMap[deal.JobOffer],
+		ResourceOffer:  s.resourceOfferMap[deal.ResourceOffer],
+		Result:         s.resultMap[deal.ID],
+		Audit:          s.auditMap[deal.ID],
+		TimeoutEvent:   s.timeoutEventMap[deal.ID],
+		EscrowPayments: append([]data.EscrowPayment{}, s.escrowPaymentMap[deal.ID]...),
+		MatchDecisions: []data.MatchDecision{},
+		Receipt:        s.receiptMap[deal.ID],
+		Billing:        s.billingMap[deal.ID],
+	}
+	// every decision about the job offer is finished with once it has a deal
+	for _, decision := range s.matchDecisionMap {
+		if decision.JobOffer == deal.JobOffer {
+			records.MatchDecisions = append(records.MatchDecisions, *decision)
+		}
+	}
+	return records
+}
+
+func (s *SolverStoreMemory) GetDealRecords(id string) (*store.DealRecords, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	deal, ok := s.dealMap[id]
+	if !ok {
+		return nil, nil
+	}
+	return s.getDealRecords(deal), nil
+}
+
+// remove a deal and everything kept for it
+// the store event log is kept so the history is still there
+func (s *SolverStoreMemory) RemoveDealRecords(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	deal, ok := s.dealMap[id]
+	if !ok {
+		return fmt.Errorf("deal not found: %s", id)
+	}
+	records := s.getDealRecords(deal)
+	for _, decision := range records.MatchDecisions {
+		delete(s.matchDecisionMap, getMatchID(decision.ResourceOffer, decision.JobOffer))
+	}
+	delete(s.jobOfferMap, deal.JobOffer)
+	delete(s.resourceOfferMap, deal.ResourceOffer)
+	delete(s.resultMap, id)
+	delete(s.auditMap, id)
+	delete(s.timeoutEventMap, id)
+	delete(s.receiptMap, id)
+	delete(s.escrowPaymentMap, id)
+	// the billing record is kept so invoices can still be reconciled
+	delete(s.dealMap, id)
+	s.addEvent(data.DealArchivedEvent, id, deal.Deal.Members.Solver, deal)
+	return nil
+}
+
+// the transaction updaters shadow the data package with their argument
+var (
+	resourceProviderTransactionsUpdatedEvent = data.ResourceProviderTransactionsUpdatedEvent
+	jobCreatorTransactionsUpdatedEvent       = data.JobCreatorTransactionsUpdatedEvent
+	mediatorTransactionsUpdatedEvent         = data.MediatorTransactionsUpdatedEvent
+)
+
 // Compile-time interface check:
 var _ store.SolverStore = (*SolverStoreMemory)(nil)
@@ -0,0 +1,488 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStoreEvents(t *testing.T) {
+	s, err := NewSolverStoreMemory(SolverStoreMemoryOptions{LogDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jobCreator := "0xbDA5747bFD65F08deb54cb465eB87D40e51B197E"
+	for _, id := range []string{"a", "b", "c"} {
+		_, err := s.AddJobOffer(data.JobOfferContainer{ID: id, JobCreator: jobCreator})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err = s.UpdateJobOfferState("b", "deal", data.GetAgreementStateIndex("DealAgreed"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a failed update is not a change so it is not recorded
+	_, err = s.UpdateJobOfferState("missing", "deal", data.GetAgreementStateIndex("DealAgreed"))
+	assert.Error(t, err)
+
+	events, err := s.GetStoreEvents(store.GetStoreEventsQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, events, 4)
+	assert.Equal(t, data.JobOfferAddedEvent, events[0].Type)
+	assert.Equal(t, jobCreator, events[0].Actor)
+	assert.Equal(t, data.JobOfferStateUpdatedEvent, events[3].Type)
+
+	// page through two at a time
+	page, err := s.GetStoreEvents(store.GetStoreEventsQuery{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []uint64{1, 2}, []uint64{page[0].Sequence, page[1].Sequence})
+	page, err = s.GetStoreEvents(store.GetStoreEventsQuery{After: page[1].Sequence, Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []uint64{3, 4}, []uint64{page[0].Sequence, page[1].Sequence})
+	page, err = s.GetStoreEvents(store.GetStoreEventsQuery{After: 4, Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, page)
+
+	filtered, err := s.GetStoreEvents(store.GetStoreEventsQuery{ObjectID: "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, filtered, 2)
+}
+
+func TestPruneStoreEvents(t *testing.T) {
+	options := SolverStoreMemoryOptions{LogDir: t.TempDir(), MaxEvents: 20}
+	s, err := NewSolverStoreMemory(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// nothing is pruned until there is a batch of 2 over the cap
+	for i := 0; i < 21; i++ {
+		_, err := s.AddJobOffer(data.JobOfferContainer{ID: fmt.Sprintf("prune-%d", i)})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	events, err := s.GetStoreEvents(store.GetStoreEventsQuery{})
+	assert.NoError(t, err)
+	assert.Len(t, events, 21)
+
+	for i := 21; i < 25; i++ {
+		_, err := s.AddJobOffer(data.JobOfferContainer{ID: fmt.Sprintf("prune-%d", i)})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	events, err = s.GetStoreEvents(store.GetStoreEventsQuery{})
+	assert.NoError(t, err)
+	assert.Len(t, events, 21)
+	// the sequences carry on from before the pruning
+	assert.Equal(t, uint64(5), events[0].Sequence)
+	assert.Equal(t, uint64(25), events[len(events)-1].Sequence)
+
+	// paging from before the oldest event we hold starts at the oldest
+	page, err := s.GetStoreEvents(store.GetStoreEventsQuery{After: 3, Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{5, 6}, []uint64{page[0].Sequence, page[1].Sequence})
+	page, err = s.GetStoreEvents(store.GetStoreEventsQuery{After: 20, Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{21, 22}, []uint64{page[0].Sequence, page[1].Sequence})
+
+	// the events log only has the events we hold
+	bs, err := os.ReadFile(getLogPath(options.LogDir, "events"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(bs)), "\n")
+	assert.Len(t, lines, 21)
+	assert.Contains(t, lines[0], `"sequence":5,`)
+}
+
+func TestLoadPendingTransactions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "transactions.jsonl")
+	lines := []string{
+		`{"id":"1-0xabc-1","nonce":1,"status":"pending"}`,
+		`{"id":"1-0xabc-2","nonce":2,"status":"pending"}`,
+		`{"id":"1-0xabc-1","nonce":1,"status":"mined"}`,
+		`{"id":"1-0xabc-3","nonce":3,"sta`,
+	}
+	err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transactions, err := loadPendingTransactions(path)
+	assert.NoError(t, err)
+	assert.Len(t, transactions, 1)
+	assert.Equal(t, uint64(2), transactions["1-0xabc-2"].Nonce)
+
+	// nothing has been sent yet
+	transactions, err = loadPendingTransactions(filepath.Join(t.TempDir(), "missing.jsonl"))
+	assert.NoError(t, err)
+	assert.Empty(t, transactions)
+}
+
+// schedules and checkpoints are read back from the logs of the same directory
+func TestReloadSchedulesAndCheckpoints(t *testing.T) {
+	options := SolverStoreMemoryOptions{LogDir: t.TempDir()}
+	s, err := NewSolverStoreMemory(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, schedule := range []data.JobSchedule{
+		{ID: "schedule-kept", JobCreator: "0xschedules-test", Cron: "*/5 * * * *", NextRunAt: 100, CreatedAt: 1},
+		{ID: "schedule-removed", JobCreator: "0xschedules-test", Cron: "0 * * * *", CreatedAt: 2},
+	} {
+		_, err = s.AddJobSchedule(schedule)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the later run replaces the first record of the schedule
+	_, err = s.AddJobSchedule(data.JobSchedule{ID: "schedule-kept", JobCreator: "0xschedules-test", Cron: "*/5 * * * *", NextRunAt: 400, Runs: 1, CreatedAt: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.RemoveJobSchedule("schedule-removed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, blockNumber := range []uint64{10, 20} {
+		_, err = s.UpdateChainCheckpoint(data.ChainCheckpoint{ChainID: 1, Contract: "controller", BlockNumber: blockNumber})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reloaded, err := NewSolverStoreMemory(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schedules, err := reloaded.GetJobSchedules(store.GetJobSchedulesQuery{})
+	assert.NoError(t, err)
+	if assert.Len(t, schedules, 1) {
+		assert.Equal(t, "schedule-kept", schedules[0].ID)
+		assert.Equal(t, int64(400), schedules[0].NextRunAt)
+		assert.Equal(t, 1, schedules[0].Runs)
+	}
+	checkpoint, err := reloaded.GetChainCheckpoint(1, "controller")
+	assert.NoError(t, err)
+	if assert.NotNil(t, checkpoint) {
+		assert.Equal(t, uint64(20), checkpoint.BlockNumber)
+	}
+
+	// a store logging somewhere else starts empty
+	other, err := NewSolverStoreMemory(SolverStoreMemoryOptions{LogDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	schedules, err = other.GetJobSchedules(store.GetJobSchedulesQuery{})
+	assert.NoError(t, err)
+	assert.Empty(t, schedules)
+	checkpoint, err = other.GetChainCheckpoint(1, "controller")
+	assert.NoError(t, err)
+	assert.Nil(t, checkpoint)
+}
+
+func TestGetCapacityReservations(t *testing.T) {
+	s, err := NewSolverStoreMemory(SolverStoreMemoryOptions{LogDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobCreator := "0xreservations-test"
+	for _, reservation := range []data.CapacityReservation{
+		{ID: "reservation-later", JobCreator: jobCreator, ResourceProvider: "0xAbC", StartAt: 200, State: data.ReservationPending},
+		{ID: "reservation-sooner", JobCreator: jobCreator, ResourceProvider: "0xabc", StartAt: 100, State: data.ReservationActive},
+		{ID: "reservation-done", JobCreator: jobCreator, ResourceProvider: "0xabc", StartAt: 50, State: data.ReservationCompleted},
+	} {
+		_, err = s.AddCapacityReservation(reservation)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	open, err := s.GetCapacityReservations(store.GetCapacityReservationsQuery{JobCreator: jobCreator, ResourceProvider: "0xABC", Open: true})
+	assert.NoError(t, err)
+	if assert.Len(t, open, 2) {
+		assert.Equal(t, "reservation-sooner", open[0].ID)
+	}
+
+	reservation, err := s.GetCapacityReservation("reservation-done")
+	assert.NoError(t, err)
+	assert.Equal(t, data.ReservationCompleted, reservation.State)
+}
+
+func TestBannedAddresses(t *testing.T) {
+	options := SolverStoreMemoryOptions{LogDir: t.TempDir()}
+	s, err := NewSolverStoreMemory(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := "0xBanned-Test"
+	_, err = s.AddBannedAddress(data.BannedAddress{Address: address, Code: data.BanReasonSpam, Reason: "spam", BannedBy: "0xadmin", CreatedAt: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// bans are looked up whatever the case of the address
+	ban, err := s.GetBannedAddress("0xbanned-test")
+	assert.NoError(t, err)
+	if assert.NotNil(t, ban) {
+		assert.Equal(t, "spam", ban.Reason)
+	}
+
+	// the ban is read back from the log after a restart
+	reloaded, err := NewSolverStoreMemory(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ban, err = reloaded.GetBannedAddress(address)
+	assert.NoError(t, err)
+	if assert.NotNil(t, ban) {
+		assert.Equal(t, int64(100), ban.CreatedAt)
+		assert.Zero(t, ban.LiftedAt)
+	}
+
+	err = s.RemoveBannedAddress(address)
+	assert.NoError(t, err)
+	ban, err = s.GetBannedAddress(address)
+	assert.NoError(t, err)
+	assert.Nil(t, ban)
+
+	// and so is the lifting of it
+	reloaded, err = NewSolverStoreMemory(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ban, err = reloaded.GetBannedAddress(address)
+	assert.NoError(t, err)
+	assert.Nil(t, ban)
+}
+
+func TestRollbackDealState(t *testing.T) {
+	s, err := NewSolverStoreMemory(SolverStoreMemoryOptions{LogDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	agreed := data.GetAgreementStateIndex("DealAgreed")
+	submitted := data.GetAgreementStateIndex("ResultsSubmitted")
+	_, err = s.AddJobOffer(data.JobOfferContainer{ID: "job"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.AddDeal(data.DealContainer{ID: "deal", JobOffer: "job", ResourceOffer: "missing", State: agreed})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.UpdateDealState("deal", submitted)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// only a rollback can move the deal backwards
+	_, err = s.UpdateDealState("deal", agreed)
+	assert.Error(t, err)
+	deal, err := s.RollbackDealState("deal", agreed)
+	assert.NoError(t, err)
+	assert.Equal(t, agreed, deal.State)
+	jobOffer, err := s.GetJobOffer("job")
+	assert.NoError(t, err)
+	assert.Equal(t, agreed, jobOffer.State)
+
+	_, err = s.RollbackDealState("missing", agreed)
+	assert.Error(t, err)
+}
+
+func TestUpdateDealCheckpoint(t *testing.T) {
+	s, err := NewSolverStoreMemory(SolverStoreMemoryOptions{LogDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.AddDeal(data.DealContainer{ID: "deal"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.UpdateDealCheckpoint("deal", data.DealCheckpoint{DealID: "deal", CID: "QmFirst"})
+	assert.NoError(t, err)
+	deal, err := s.UpdateDealCheckpoint("deal", data.DealCheckpoint{DealID: "deal", CID: "QmSecond"})
+	assert.NoError(t, err)
+	assert.Equal(t, "QmSecond", deal.Checkpoint.CID)
+
+	events, err := s.GetStoreEvents(store.GetStoreEventsQuery{Type: string(data.DealCheckpointUpdatedEvent)})
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+
+	_, err = s.UpdateDealCheckpoint("missing", data.DealCheckpoint{CID: "QmFirst"})
+	assert.Error(t, err)
+}
+
+func TestUpdateDealMilestone(t *testing.T) {
+	s, err := NewSolverStoreMemory(SolverStoreMemoryOptions{LogDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.AddDeal(data.DealContainer{ID: "deal"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.UpdateDealMilestone("deal", data.DealMilestone{DealID: "deal", Index: 0, State: data.DealMilestoneSubmitted})
+	assert.NoError(t, err)
+	deal, err := s.UpdateDealMilestone("deal", data.DealMilestone{DealID: "deal", Index: 0, State: data.DealMilestonePaid})
+	assert.NoError(t, err)
+	assert.Len(t, deal.Milestones, 1)
+	assert.Equal(t, data.DealMilestonePaid, deal.Milestones[0].State)
+
+	deal, err = s.UpdateDealMilestone("deal", data.DealMilestone{DealID: "deal", Index: 1, State: data.DealMilestoneSubmitted})
+	assert.NoError(t, err)
+	assert.Len(t, deal.Milestones, 2)
+
+	_, err = s.UpdateDealMilestone("missing", data.DealMilestone{})
+	assert.Error(t, err)
+}
+
+func TestCancelDeal(t *testing.T) {
+	s, err := NewSolverStoreMemory(SolverStoreMemoryOptions{LogDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.AddDeal(data.DealContainer{ID: "deal"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deal, err := s.CancelDeal("deal", 100)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), deal.CancelledAt)
+
+	// cancelling again keeps the first time
+	deal, err = s.CancelDeal("deal", 200)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), deal.CancelledAt)
+	events, err := s.GetStoreEvents(store.GetStoreEventsQuery{Type: string(data.DealCancelledEvent)})
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+}
+
+func TestPreemptDeal(t *testing.T) {
+	s, err := NewSolverStoreMemory(SolverStoreMemoryOptions{LogDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.AddDeal(data.DealContainer{ID: "deal"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deal, err := s.PreemptDeal("deal", data.DealPreemption{DealID: "deal", CreatedAt: 100, StopAt: 160})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(160), deal.Preemption.StopAt)
+
+	// preempting again does not move the deadline
+	deal, err = s.PreemptDeal("deal", data.DealPreemption{DealID: "deal", CreatedAt: 200, StopAt: 260})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(160), deal.Preemption.StopAt)
+	events, err := s.GetStoreEvents(store.GetStoreEventsQuery{Type: string(data.DealPreemptedEvent)})
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+
+	_, err = s.PreemptDeal("missing", data.DealPreemption{})
+	assert.Error(t, err)
+}
+
+func TestAddMediationVerdict(t *testing.T) {
+	s, err := NewSolverStoreMemory(SolverStoreMemoryOptions{LogDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.AddDeal(data.DealContainer{ID: "deal"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deal, err := s.AddMediationVerdict("deal", data.MediationVerdict{DealID: "deal", Mediator: "0xAB", Accept: true})
+	assert.NoError(t, err)
+	assert.Len(t, deal.MediationVerdicts, 1)
+
+	// the first verdict from a mediator is the one that counts
+	deal, err = s.AddMediationVerdict("deal", data.MediationVerdict{DealID: "deal", Mediator: "0xab", Accept: false})
+	assert.NoError(t, err)
+	assert.Len(t, deal.MediationVerdicts, 1)
+	assert.True(t, deal.MediationVerdicts[0].Accept)
+
+	deal, err = s.AddMediationVerdict("deal", data.MediationVerdict{DealID: "deal", Mediator: "0xcd", Accept: false})
+	assert.NoError(t, err)
+	assert.Len(t, deal.MediationVerdicts, 2)
+	events, err := s.GetStoreEvents(store.GetStoreEventsQuery{Type: string(data.MediationVerdictAddedEvent)})
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	s, err := NewSolverStoreMemory(SolverStoreMemoryOptions{LogDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.AddJobOffer(data.JobOfferContainer{ID: "job", DealID: "deal", JobCreator: "0xjc"})
+	assert.NoError(t, err)
+	_, err = s.AddResourceOffer(data.ResourceOfferContainer{ID: "resource", DealID: "deal", ResourceProvider: "0xrp"})
+	assert.NoError(t, err)
+	_, err = s.AddDeal(data.DealContainer{ID: "deal", JobCreator: "0xjc", ResourceProvider: "0xrp"})
+	assert.NoError(t, err)
+	for i, amount := range []string{"1", "2"} {
+		_, err = s.AddEscrowPayment(data.EscrowPayment{DealID: "deal", Amount: amount, TransactionHash: "0xtx", LogIndex: uint(i)})
+		assert.NoError(t, err)
+	}
+	_, err = s.AddBannedAddress(data.BannedAddress{Address: "0xSnapshot-Ban", Reason: "spam"})
+	assert.NoError(t, err)
+
+	snapshot, err := s.ExportSnapshot()
+	assert.NoError(t, err)
+	manifest := snapshot.GetManifest("test", time.Unix(100, 0))
+	assert.Equal(t, 1, manifest.Tables["deals"])
+	assert.Equal(t, 2, manifest.Tables["escrow"])
+
+	var buf bytes.Buffer
+	err = store.WriteSnapshotArchive(&buf, *snapshot, manifest)
+	assert.NoError(t, err)
+	read, readManifest, err := store.ReadSnapshotArchive(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, manifest, *readManifest)
+
+	imported, err := NewSolverStoreMemory(SolverStoreMemoryOptions{LogDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = imported.ImportSnapshot(*read)
+	assert.NoError(t, err)
+	deal, err := imported.GetDeal("deal")
+	assert.NoError(t, err)
+	if assert.NotNil(t, deal) {
+		assert.Equal(t, "0xrp", deal.ResourceProvider)
+	}
+	payments, err := imported.GetEscrowPayments("deal")
+	assert.NoError(t, err)
+	assert.Len(t, payments, 2)
+	ban, err := imported.GetBannedAddress("0xsnapshot-ban")
+	assert.NoError(t, err)
+	assert.NotNil(t, ban)
+
+	// the same state exports the same way
+	again, err := imported.ExportSnapshot()
+	assert.NoError(t, err)
+	assert.Equal(t, snapshot, again)
+}
@@ -0,0 +1,214 @@
+package store
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// bumped when a table changes in a way an older solver cannot import
+const SNAPSHOT_FORMAT_VERSION = 1
+
+const snapshotManifestName = "manifest.json"
+
+// the first file in a snapshot archive
+type SnapshotManifest struct {
+	FormatVersion int `json:"format_version"`
+	// the release of lilypad the snapshot was exported from
+	SolverVersion string `json:"solver_version"`
+	// unix seconds
+	CreatedAt int64 `json:"created_at"`
+	// the number of records in each table
+	Tables map[string]int `json:"tables"`
+}
+
+type snapshotTable struct {
+	name  string
+	count func() int
+	write func(w io.Writer) error
+	read  func(r io.Reader) error
+}
+
+// each record is a line of JSON so a table can be read a record at a time
+func newSnapshotTable[T any](name string, records *[]T) snapshotTable {
+	return snapshotTable{
+		name:  name,
+		count: func() int { return len(*records) },
+		write: func(w io.Writer) error {
+			encoder := json.NewEncoder(w)
+			for _, record := range *records {
+				err := encoder.Encode(record)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		read: func(r io.Reader) error {
+			decoder := json.NewDecoder(r)
+			for {
+				var record T
+				err := decoder.Decode(&record)
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				*records = append(*records, record)
+			}
+		},
+	}
+}
+
+// the tables in the order they are written, the names match the store logs
+func (snapshot *StoreSnapshot) tables() []snapshotTable {
+	return []snapshotTable{
+		newSnapshotTable("job_offers", &snapshot.JobOffers),
+		newSnapshotTable("resource_offers", &snapshot.ResourceOffers),
+		newSnapshotTable("deals", &snapshot.Deals),
+		newSnapshotTable("decisions", &snapshot.MatchDecisions),
+		newSnapshotTable("results", &snapshot.Results),
+		newSnapshotTable("audits", &snapshot.Audits),
+		newSnapshotTable("timeouts", &snapshot.TimeoutEvents),
+		newSnapshotTable("escrow", &snapshot.EscrowPayments),
+		newSnapshotTable("price_gaps", &snapshot.PriceGaps),
+		newSnapshotTable("result_pins", &snapshot.ResultPins),
+		newSnapshotTable("receipts", &snapshot.DealReceipts),
+		newSnapshotTable("transactions", &snapshot.Transactions),
+		newSnapshotTable("checkpoints", &snapshot.ChainCheckpoints),
+		newSnapshotTable("job_groups", &snapshot.JobGroups),
+		newSnapshotTable("job_schedules", &snapshot.JobSchedules),
+		newSnapshotTable("reservations", &snapshot.CapacityReservations),
+		newSnapshotTable("workflows", &snapshot.Workflows),
+		newSnapshotTable("input_stagings", &snapshot.InputStagings),
+		newSnapshotTable("billing", &snapshot.BillingRecords),
+		newSnapshotTable("banned_addresses", &snapshot.BannedAddresses),
+		newSnapshotTable("admin_actions", &snapshot.AdminActions),
+		newSnapshotTable("clients", &snapshot.ClientRecords),
+	}
+}
+
+func (snapshot *StoreSnapshot) GetManifest(solverVersion string, createdAt time.Time) SnapshotManifest {
+	manifest := SnapshotManifest{
+		FormatVersion: SNAPSHOT_FORMAT_VERSION,
+		SolverVersion: solverVersion,
+		CreatedAt:     createdAt.Unix(),
+		Tables:        map[string]int{},
+	}
+	for _, table := range snapshot.tables() {
+		manifest.Tables[table.name] = table.count()
+	}
+	return manifest
+}
+
+// a zstd compressed tar of the manifest and then a JSON lines file for each table
+func WriteSnapshotArchive(w io.Writer, snapshot StoreSnapshot, manifest SnapshotManifest) error {
+	compressed, err := zstd.NewWriter(w)
+	if err != nil {
+		return err
+	}
+	archive := tar.NewWriter(compressed)
+	addFile := func(name string, contents []byte) error {
+		err := archive.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    int64(len(contents)),
+			ModTime: time.Unix(manifest.CreatedAt, 0),
+		})
+		if err != nil {
+			return err
+		}
+		_, err = archive.Write(contents)
+		return err
+	}
+
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = addFile(snapshotManifestName, manifestBytes)
+	if err != nil {
+		return err
+	}
+	for _, table := range snapshot.tables() {
+		var buf bytes.Buffer
+		err = table.write(&buf)
+		if err != nil {
+			return fmt.Errorf("error writing %s: %s", table.name, err.Error())
+		}
+		err = addFile(table.name+".jsonl", buf.Bytes())
+		if err != nil {
+			return err
+		}
+	}
+	err = archive.Close()
+	if err != nil {
+		return err
+	}
+	return compressed.Close()
+}
+
+// tables missing from the archive are left empty so a snapshot from
+// before a table was added can still be imported
+func ReadSnapshotArchive(r io.Reader) (*StoreSnapshot, *SnapshotManifest, error) {
+	compressed, err := zstd.NewReader(bufio.NewReader(r))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer compressed.Close()
+	archive := tar.NewReader(compressed)
+
+	snapshot := &StoreSnapshot{}
+	tables := map[string]snapshotTable{}
+	for _, table := range snapshot.tables() {
+		tables[table.name+".jsonl"] = table
+	}
+	var manifest *SnapshotManifest
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if header.Name == snapshotManifestName {
+			manifest = &SnapshotManifest{}
+			err = json.NewDecoder(archive).Decode(manifest)
+			if err != nil {
+				return nil, nil, fmt.Errorf("error reading manifest: %s", err.Error())
+			}
+			if manifest.FormatVersion > SNAPSHOT_FORMAT_VERSION {
+				return nil, nil, fmt.Errorf("snapshot format version %d is newer than %d, upgrade the solver to import it", manifest.FormatVersion, SNAPSHOT_FORMAT_VERSION)
+			}
+			continue
+		}
+		if manifest == nil {
+			return nil, nil, fmt.Errorf("snapshot does not start with a manifest")
+		}
+		table, ok := tables[header.Name]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown table in snapshot: %s", strings.TrimSuffix(header.Name, ".jsonl"))
+		}
+		err = table.read(archive)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading %s: %s", table.name, err.Error())
+		}
+		if table.count() != manifest.Tables[table.name] {
+			return nil, nil, fmt.Errorf("%s has %d records but the manifest says %d", table.name, table.count(), manifest.Tables[table.name])
+		}
+	}
+	if manifest == nil {
+		return nil, nil, fmt.Errorf("snapshot has no manifest")
+	}
+	return snapshot, manifest, nil
+}
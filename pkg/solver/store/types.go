@@ -12,6 +12,12 @@ type GetJobOffersQuery struct {
 
 	// this will include cancelled job offers in the results
 	IncludeCancelled bool `json:"include_cancelled"`
+
+	// only job offers for this chain, zero means every chain
+	ChainID int `json:"chain_id"`
+
+	// only the job offer the job creator added with this key
+	IdempotencyKey string `json:"idempotency_key"`
 }
 
 type GetResourceOffersQuery struct {
@@ -34,6 +40,12 @@ type GetResourceOffersQuery struct {
 
 	// we use the DealID property of the resourceOfferContainer to tell if it's been matched
 	NotMatched bool `json:"not_matched"`
+
+	// only resource offers for this chain, zero means every chain
+	ChainID int `json:"chain_id"`
+
+	// only the resource offer the resource provider added with this key
+	IdempotencyKey string `json:"idempotency_key"`
 }
 
 type GetDealsQuery struct {
@@ -43,6 +55,163 @@ type GetDealsQuery struct {
 
 	// only deals that are in this state will be returned
 	State string `json:"state"`
+
+	// only deals on this chain, zero means every chain
+	ChainID int `json:"chain_id"`
+
+	// only deals that run the job of this deal again
+	ParentDealID string `json:"parent_deal_id"`
+
+	// only deals that ran the same module version on the same inputs
+	ResultKey string `json:"result_key"`
+}
+
+type GetAuditsQuery struct {
+	ResourceProvider string `json:"resource_provider"`
+
+	// only audits that are in this state will be returned
+	State string `json:"state"`
+}
+
+type GetPriceGapsQuery struct {
+	JobOffer         string `json:"job_offer"`
+	ResourceOffer    string `json:"resource_offer"`
+	JobCreator       string `json:"job_creator"`
+	ResourceProvider string `json:"resource_provider"`
+}
+
+type GetResultPinsQuery struct {
+	DealID  string `json:"deal_id"`
+	Service string `json:"service"`
+	Status  string `json:"status"`
+}
+
+type GetTransactionsQuery struct {
+	DealID string `json:"deal_id"`
+	Status string `json:"status"`
+	// only transactions on this chain, zero means every chain
+	ChainID int `json:"chain_id"`
+}
+
+type GetJobSchedulesQuery struct {
+	JobCreator string `json:"job_creator"`
+}
+
+type GetCapacityReservationsQuery struct {
+	JobCreator       string `json:"job_creator"`
+	ResourceProvider string `json:"resource_provider"`
+	// only reservations that are pending or active
+	Open bool `json:"open"`
+}
+
+type GetWorkflowsQuery struct {
+	JobCreator string `json:"job_creator"`
+	// only the workflows that have not finished
+	Running bool `json:"running"`
+}
+
+type GetInputStagingsQuery struct {
+	JobCreator       string `json:"job_creator"`
+	ResourceProvider string `json:"resource_provider"`
+	State            string `json:"state"`
+}
+
+type GetBillingRecordsQuery struct {
+	JobCreator       string `json:"job_creator"`
+	ResourceProvider string `json:"resource_provider"`
+	// records created at or after this, unix seconds
+	From int64 `json:"from"`
+	// records created before this, unix seconds, zero means now
+	To int64 `json:"to"`
+}
+
+type GetSlashingsQuery struct {
+	// the address whose collateral was slashed
+	Payee string `json:"payee"`
+	// slashings recorded at or after this, unix seconds
+	From int64 `json:"from"`
+}
+
+type GetStoreEventsQuery struct {
+	// only events with a sequence after this are returned
+	After uint64 `json:"after"`
+	// the most events to return, zero means all of them
+	Limit int `json:"limit"`
+
+	Type     string `json:"type"`
+	ObjectID string `json:"object_id"`
+}
+
+type GetClientRecordsQuery struct {
+	// job_creator or resource_provider, empty means both
+	Role string `json:"role"`
+	// only clients seen at or after this, unix seconds
+	Since int64 `json:"since"`
+}
+
+type GetAdminActionsQuery struct {
+	Admin string `json:"admin"`
+	// the most recent actions to return, zero means all of them
+	Limit int `json:"limit"`
+}
+
+// how many of each kind of record the store is holding
+type StoreStats struct {
+	Counts map[string]int `json:"counts"`
+	// the sequence of the latest store event
+	Events uint64 `json:"events"`
+	// set when there is a cache in front of the store
+	Cache *CacheStats `json:"cache,omitempty"`
+}
+
+// how often the cache answered each kind of query without the store
+type CacheStats struct {
+	Hits   map[string]uint64 `json:"hits"`
+	Misses map[string]uint64 `json:"misses"`
+}
+
+// every table of a store, the events are left out as they are only
+// kept until the solver restarts and the sequence starts again
+// this is what is exported to move the state of a solver to another
+// store or host
+type StoreSnapshot struct {
+	JobOffers            []data.JobOfferContainer      `json:"job_offers"`
+	ResourceOffers       []data.ResourceOfferContainer `json:"resource_offers"`
+	Deals                []data.DealContainer          `json:"deals"`
+	MatchDecisions       []data.MatchDecision          `json:"decisions"`
+	Results              []data.Result                 `json:"results"`
+	Audits               []data.Audit                  `json:"audits"`
+	TimeoutEvents        []data.DealTimeoutEvent       `json:"timeouts"`
+	EscrowPayments       []data.EscrowPayment          `json:"escrow"`
+	PriceGaps            []data.PriceGap               `json:"price_gaps"`
+	ResultPins           []data.ResultPin              `json:"result_pins"`
+	DealReceipts         []data.DealReceipt            `json:"receipts"`
+	Transactions         []data.Transaction            `json:"transactions"`
+	ChainCheckpoints     []data.ChainCheckpoint        `json:"checkpoints"`
+	JobGroups            []data.JobGroup               `json:"job_groups"`
+	JobSchedules         []data.JobSchedule            `json:"job_schedules"`
+	CapacityReservations []data.CapacityReservation    `json:"reservations"`
+	Workflows            []data.Workflow               `json:"workflows"`
+	InputStagings        []data.InputStaging           `json:"input_stagings"`
+	BillingRecords       []data.BillingRecord          `json:"billing"`
+	BannedAddresses      []data.BannedAddress          `json:"banned_addresses"`
+	AdminActions         []data.AdminAction            `json:"admin_actions"`
+	ClientRecords        []data.ClientRecord           `json:"clients"`
+}
+
+// everything the store keeps for a deal
+// this is what gets written out when a deal is archived
+type DealRecords struct {
+	Deal           data.DealContainer           `json:"deal"`
+	JobOffer       *data.JobOfferContainer      `json:"job_offer"`
+	ResourceOffer  *data.ResourceOfferContainer `json:"resource_offer"`
+	Result         *data.Result                 `json:"result"`
+	Audit          *data.Audit                  `json:"audit"`
+	TimeoutEvent   *data.DealTimeoutEvent       `json:"timeout_event"`
+	EscrowPayments []data.EscrowPayment         `json:"escrow_payments"`
+	MatchDecisions []data.MatchDecision         `json:"match_decisions"`
+	Receipt        *data.DealReceipt            `json:"receipt"`
+	Billing        *data.BillingRecord          `json:"billing"`
 }
 
 type SolverStore interface {
@@ -51,6 +220,22 @@ type SolverStore interface {
 	AddDeal(deal data.DealContainer) (*data.DealContainer, error)
 	AddResult(result data.Result) (*data.Result, error)
 	AddMatchDecision(resourceOffer string, jobOffer string, deal string, result bool) (*data.MatchDecision, error)
+	AddAudit(audit data.Audit) (*data.Audit, error)
+	AddTimeoutEvent(event data.DealTimeoutEvent) (*data.DealTimeoutEvent, error)
+	AddEscrowPayment(payment data.EscrowPayment) (*data.EscrowPayment, error)
+	AddPriceGap(gap data.PriceGap) (*data.PriceGap, error)
+	AddResultPin(pin data.ResultPin) (*data.ResultPin, error)
+	AddDealReceipt(receipt data.DealReceipt) (*data.DealReceipt, error)
+	AddTransaction(tx data.Transaction) (*data.Transaction, error)
+	AddJobGroup(group data.JobGroup) (*data.JobGroup, error)
+	AddJobSchedule(schedule data.JobSchedule) (*data.JobSchedule, error)
+	AddCapacityReservation(reservation data.CapacityReservation) (*data.CapacityReservation, error)
+	AddWorkflow(workflow data.Workflow) (*data.Workflow, error)
+	AddInputStaging(staging data.InputStaging) (*data.InputStaging, error)
+	AddBillingRecord(record data.BillingRecord) (*data.BillingRecord, error)
+	AddBannedAddress(ban data.BannedAddress) (*data.BannedAddress, error)
+	AddAdminAction(action data.AdminAction) (*data.AdminAction, error)
+	UpdateClientRecord(record data.ClientRecord) (*data.ClientRecord, error)
 	GetJobOffers(query GetJobOffersQuery) ([]data.JobOfferContainer, error)
 	GetResourceOffers(query GetResourceOffersQuery) ([]data.ResourceOfferContainer, error)
 	GetDeals(query GetDealsQuery) ([]data.DealContainer, error)
@@ -60,13 +245,60 @@ type SolverStore interface {
 	GetDeal(id string) (*data.DealContainer, error)
 	GetResult(id string) (*data.Result, error)
 	GetMatchDecision(resourceOffer string, jobOffer string) (*data.MatchDecision, error)
+	GetAudit(dealID string) (*data.Audit, error)
+	GetAudits(query GetAuditsQuery) ([]data.Audit, error)
+	GetTimeoutEvent(dealID string) (*data.DealTimeoutEvent, error)
+	GetEscrowPayments(dealID string) ([]data.EscrowPayment, error)
+	GetSlashings(query GetSlashingsQuery) ([]data.EscrowPayment, error)
+	GetPriceGaps(query GetPriceGapsQuery) ([]data.PriceGap, error)
+	GetResultPins(query GetResultPinsQuery) ([]data.ResultPin, error)
+	GetDealReceipt(dealID string) (*data.DealReceipt, error)
+	GetTransaction(id string) (*data.Transaction, error)
+	GetTransactions(query GetTransactionsQuery) ([]data.Transaction, error)
+	GetJobGroup(id string) (*data.JobGroup, error)
+	GetJobSchedule(id string) (*data.JobSchedule, error)
+	GetJobSchedules(query GetJobSchedulesQuery) ([]data.JobSchedule, error)
+	GetCapacityReservation(id string) (*data.CapacityReservation, error)
+	GetCapacityReservations(query GetCapacityReservationsQuery) ([]data.CapacityReservation, error)
+	GetWorkflow(id string) (*data.Workflow, error)
+	GetWorkflows(query GetWorkflowsQuery) ([]data.Workflow, error)
+	GetInputStaging(id string) (*data.InputStaging, error)
+	GetInputStagings(query GetInputStagingsQuery) ([]data.InputStaging, error)
+	GetBillingRecords(query GetBillingRecordsQuery) ([]data.BillingRecord, error)
+	GetStoreEvents(query GetStoreEventsQuery) ([]data.StoreEvent, error)
+	GetBannedAddress(address string) (*data.BannedAddress, error)
+	GetBannedAddresses() ([]data.BannedAddress, error)
+	GetAdminActions(query GetAdminActionsQuery) ([]data.AdminAction, error)
+	GetClientRecords(query GetClientRecordsQuery) ([]data.ClientRecord, error)
+	// every record in the store sorted so the same state exports the same way
+	ExportSnapshot() (*StoreSnapshot, error)
+	// add every record of a snapshot, records with the same ID are replaced
+	ImportSnapshot(snapshot StoreSnapshot) error
+	GetStats() (StoreStats, error)
+	GetChainCheckpoint(chainID int, contract string) (*data.ChainCheckpoint, error)
+	UpdateChainCheckpoint(checkpoint data.ChainCheckpoint) (*data.ChainCheckpoint, error)
 	UpdateJobOfferState(id string, dealID string, state uint8) (*data.JobOfferContainer, error)
 	UpdateResourceOfferState(id string, dealID string, state uint8) (*data.ResourceOfferContainer, error)
 	UpdateDealState(id string, state uint8) (*data.DealContainer, error)
 	UpdateDealMediator(id string, mediator string) (*data.DealContainer, error)
+	UpdateDealCheckpoint(id string, checkpoint data.DealCheckpoint) (*data.DealContainer, error)
+	// add the milestone or replace the one with the same index
+	UpdateDealMilestone(id string, milestone data.DealMilestone) (*data.DealContainer, error)
+	UpdateDealServiceUsage(id string, usage data.ServiceUsage) (*data.DealContainer, error)
+	AddDealEncryptedInputs(id string, encrypted data.DealEncryptedInputs) (*data.DealContainer, error)
+	CancelDeal(id string, cancelledAt int64) (*data.DealContainer, error)
+	PreemptDeal(id string, preemption data.DealPreemption) (*data.DealContainer, error)
+	AddMediationVerdict(id string, verdict data.MediationVerdict) (*data.DealContainer, error)
+	RollbackDealState(id string, state uint8) (*data.DealContainer, error)
 	UpdateDealTransactionsJobCreator(id string, data data.DealTransactionsJobCreator) (*data.DealContainer, error)
 	UpdateDealTransactionsResourceProvider(id string, data data.DealTransactionsResourceProvider) (*data.DealContainer, error)
 	UpdateDealTransactionsMediator(id string, data data.DealTransactionsMediator) (*data.DealContainer, error)
+	UpdateAuditState(dealID string, state string, message string) (*data.Audit, error)
 	RemoveJobOffer(id string) error
+	RemoveJobSchedule(id string) error
 	RemoveResourceOffer(id string) error
+	RemoveBannedAddress(address string) error
+	RemoveEscrowPayment(dealID string, transactionHash string, logIndex uint) error
+	GetDealRecords(id string) (*DealRecords, error)
+	RemoveDealRecords(id string) error
 }
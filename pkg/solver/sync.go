@@ -0,0 +1,183 @@
+package solver
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+)
+
+// the most store events a sync will read through, a resource provider
+// that has been away for longer than this gets everything again
+const RESOURCE_PROVIDER_SYNC_MAX_EVENTS = 10000
+
+// the store events that carry a deal ID as their object ID
+var dealSyncEvents = map[data.StoreEventType]bool{
+	data.DealAddedEvent:                           true,
+	data.DealStateUpdatedEvent:                    true,
+	data.DealStateRolledBackEvent:                 true,
+	data.DealMediatorUpdatedEvent:                 true,
+	data.DealCheckpointUpdatedEvent:               true,
+	data.DealMilestoneUpdatedEvent:                true,
+	data.DealEncryptedInputsAddedEvent:            true,
+	data.DealCancelledEvent:                       true,
+	data.DealPreemptedEvent:                       true,
+	data.DealServiceUsageUpdatedEvent:             true,
+	data.DealArchivedEvent:                        true,
+	data.MediationVerdictAddedEvent:               true,
+	data.ResourceProviderTransactionsUpdatedEvent: true,
+	data.JobCreatorTransactionsUpdatedEvent:       true,
+	data.MediatorTransactionsUpdatedEvent:         true,
+}
+
+var resourceOfferSyncEvents = map[data.StoreEventType]bool{
+	data.ResourceOfferAddedEvent:        true,
+	data.ResourceOfferStateUpdatedEvent: true,
+	data.ResourceOfferRemovedEvent:      true,
+}
+
+// the changes to the offers and deals of a resource provider after a version
+// the version is the sequence of the last store event the resource provider saw
+// we walk the events since then and send each record they touched as it is now
+// or a tombstone if we no longer have it
+func (controller *SolverController) getResourceProviderSync(resourceProvider string, since uint64, epoch string) (data.ResourceProviderSync, error) {
+	stats, err := controller.store.GetStats()
+	if err != nil {
+		return data.ResourceProviderSync{}, err
+	}
+	// a version from before we started or that we have not got to
+	// cannot be walked on from
+	if since == 0 || epoch != controller.syncEpoch || since > stats.Events {
+		return controller.getResourceProviderFullSync(resourceProvider, stats.Events)
+	}
+	events, err := controller.store.GetStoreEvents(store.GetStoreEventsQuery{
+		After: since,
+		Limit: RESOURCE_PROVIDER_SYNC_MAX_EVENTS + 1,
+	})
+	if err != nil {
+		return data.ResourceProviderSync{}, err
+	}
+	if len(events) > RESOURCE_PROVIDER_SYNC_MAX_EVENTS {
+		return controller.getResourceProviderFullSync(resourceProvider, stats.Events)
+	}
+
+	sync := data.ResourceProviderSync{
+		Version:               since,
+		Epoch:                 controller.syncEpoch,
+		ResourceOffers:        []data.ResourceOfferContainer{},
+		Deals:                 []data.DealContainer{},
+		MatchDecisions:        []data.MatchDecision{},
+		RemovedResourceOffers: []string{},
+		RemovedDeals:          []string{},
+	}
+	// the IDs in the order we first saw them changed
+	dealIDs := []string{}
+	resourceOfferIDs := []string{}
+	decisions := []data.MatchDecision{}
+	seen := map[string]bool{}
+	touch := func(ids []string, id string) []string {
+		if id == "" || seen[id] {
+			return ids
+		}
+		seen[id] = true
+		return append(ids, id)
+	}
+	for _, event := range events {
+		sync.Version = event.Sequence
+		switch {
+		case dealSyncEvents[event.Type], resourceOfferSyncEvents[event.Type]:
+			var owner struct {
+				ResourceProvider string `json:"resource_provider"`
+				ResourceOffer    string `json:"resource_offer"`
+			}
+			if json.Unmarshal(event.Data, &owner) != nil || !strings.EqualFold(owner.ResourceProvider, resourceProvider) {
+				continue
+			}
+			if resourceOfferSyncEvents[event.Type] {
+				resourceOfferIDs = touch(resourceOfferIDs, event.ObjectID)
+				continue
+			}
+			dealIDs = touch(dealIDs, event.ObjectID)
+			// archiving a deal takes its resource offer with it
+			if event.Type == data.DealArchivedEvent {
+				resourceOfferIDs = touch(resourceOfferIDs, owner.ResourceOffer)
+			}
+		case event.Type == data.MatchDecisionAddedEvent:
+			var decision data.MatchDecision
+			if json.Unmarshal(event.Data, &decision) != nil || seen[decision.ResourceOffer+"/"+decision.JobOffer] {
+				continue
+			}
+			seen[decision.ResourceOffer+"/"+decision.JobOffer] = true
+			decisions = append(decisions, decision)
+		}
+	}
+
+	for _, id := range dealIDs {
+		deal, err := controller.store.GetDeal(id)
+		if err != nil {
+			return data.ResourceProviderSync{}, err
+		}
+		if deal == nil {
+			sync.RemovedDeals = append(sync.RemovedDeals, id)
+			continue
+		}
+		sync.Deals = append(sync.Deals, *deal)
+	}
+	for _, id := range resourceOfferIDs {
+		resourceOffer, err := controller.store.GetResourceOffer(id)
+		if err != nil {
+			return data.ResourceProviderSync{}, err
+		}
+		if resourceOffer == nil {
+			sync.RemovedResourceOffers = append(sync.RemovedResourceOffers, id)
+			continue
+		}
+		sync.ResourceOffers = append(sync.ResourceOffers, *resourceOffer)
+	}
+	// the event does not say whose offer it was so we look it up
+	for _, decision := range decisions {
+		resourceOffer, err := controller.store.GetResourceOffer(decision.ResourceOffer)
+		if err != nil {
+			return data.ResourceProviderSync{}, err
+		}
+		if resourceOffer == nil || !strings.EqualFold(resourceOffer.ResourceProvider, resourceProvider) {
+			continue
+		}
+		current, err := controller.store.GetMatchDecision(decision.ResourceOffer, decision.JobOffer)
+		if err != nil {
+			return data.ResourceProviderSync{}, err
+		}
+		if current != nil {
+			sync.MatchDecisions = append(sync.MatchDecisions, *current)
+		}
+	}
+	return sync, nil
+}
+
+// everything we have for a resource provider as of a version
+// match decisions are only sent as they change
+func (controller *SolverController) getResourceProviderFullSync(resourceProvider string, version uint64) (data.ResourceProviderSync, error) {
+	resourceOffers, err := controller.store.GetResourceOffers(store.GetResourceOffersQuery{
+		ResourceProvider: resourceProvider,
+	})
+	if err != nil {
+		return data.ResourceProviderSync{}, err
+	}
+	deals, err := controller.store.GetDeals(store.GetDealsQuery{
+		ResourceProvider: resourceProvider,
+	})
+	if err != nil {
+		return data.ResourceProviderSync{}, err
+	}
+	return data.ResourceProviderSync{
+		Version:               version,
+		Epoch:                 controller.syncEpoch,
+		Reset:                 true,
+		ResourceOffers:        resourceOffers,
+		Deals:                 deals,
+		MatchDecisions:        []data.MatchDecision{},
+		RemovedResourceOffers: []string{},
+		RemovedDeals:          []string{},
+	}, nil
+}
@@ -0,0 +1,65 @@
+package solver
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	memorystore "github.com/lilypad-tech/lilypad/pkg/solver/store/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceProviderSync(t *testing.T) {
+	s, err := memorystore.NewSolverStoreMemory(memorystore.SolverStoreMemoryOptions{LogDir: t.TempDir()})
+	require.NoError(t, err)
+	controller := &SolverController{store: s, syncEpoch: "epoch"}
+
+	// the store keeps its log between runs so the records need their own IDs
+	suffix := fmt.Sprintf("%d", time.Now().UnixNano())
+	rp := "0xsyncrp" + suffix
+	otherRP := "0xotherrp" + suffix
+
+	// a version of zero is always a full sync so start after an event
+	_, err = s.AddResourceOffer(data.ResourceOfferContainer{ID: "before" + suffix, ResourceProvider: otherRP})
+	require.NoError(t, err)
+	stats, err := s.GetStats()
+	require.NoError(t, err)
+	since := stats.Events
+
+	_, err = s.AddResourceOffer(data.ResourceOfferContainer{ID: "kept" + suffix, ResourceProvider: rp})
+	require.NoError(t, err)
+	_, err = s.AddResourceOffer(data.ResourceOfferContainer{ID: "removed" + suffix, ResourceProvider: rp})
+	require.NoError(t, err)
+	_, err = s.AddResourceOffer(data.ResourceOfferContainer{ID: "other" + suffix, ResourceProvider: otherRP})
+	require.NoError(t, err)
+	_, err = s.AddDeal(data.DealContainer{ID: "deal" + suffix, ResourceProvider: rp, ResourceOffer: "kept" + suffix})
+	require.NoError(t, err)
+	require.NoError(t, s.RemoveResourceOffer("removed"+suffix))
+
+	sync, err := controller.getResourceProviderSync(rp, since, "epoch")
+	require.NoError(t, err)
+	assert.False(t, sync.Reset)
+	assert.Equal(t, since+5, sync.Version)
+	require.Len(t, sync.ResourceOffers, 1)
+	assert.Equal(t, "kept"+suffix, sync.ResourceOffers[0].ID)
+	require.Len(t, sync.Deals, 1)
+	assert.Equal(t, "deal"+suffix, sync.Deals[0].ID)
+	assert.Equal(t, []string{"removed" + suffix}, sync.RemovedResourceOffers)
+
+	// nothing has changed since the version we were given
+	sync, err = controller.getResourceProviderSync(rp, sync.Version, sync.Epoch)
+	require.NoError(t, err)
+	assert.Empty(t, sync.ResourceOffers)
+	assert.Empty(t, sync.Deals)
+	assert.Equal(t, since+5, sync.Version)
+
+	// a version from before the solver started over gets everything
+	sync, err = controller.getResourceProviderSync(rp, since, "earlier")
+	require.NoError(t, err)
+	assert.True(t, sync.Reset)
+	assert.Equal(t, "epoch", sync.Epoch)
+	assert.Len(t, sync.ResourceOffers, 1)
+	assert.Len(t, sync.Deals, 1)
+}
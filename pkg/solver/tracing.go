@@ -0,0 +1,50 @@
+package solver
+
+import (
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// offers arrive over HTTP, are matched by the control loop and the deal
+// is agreed on chain - each of those happens in its own trace so we keep
+// the span that started each object and link to it from later spans
+// which lets a job offer be followed from ingestion to on-chain agreement
+type spanLinks struct {
+	mutex sync.Mutex
+	spans map[string]trace.SpanContext
+}
+
+func (links *spanLinks) remember(id string, span trace.Span) {
+	spanContext := span.SpanContext()
+	if !spanContext.IsValid() {
+		return
+	}
+	links.mutex.Lock()
+	defer links.mutex.Unlock()
+	if links.spans == nil {
+		links.spans = map[string]trace.SpanContext{}
+	}
+	links.spans[id] = spanContext
+}
+
+func (links *spanLinks) get(ids ...string) []trace.Link {
+	links.mutex.Lock()
+	defer links.mutex.Unlock()
+	ret := []trace.Link{}
+	for _, id := range ids {
+		spanContext, ok := links.spans[id]
+		if ok {
+			ret = append(ret, trace.Link{SpanContext: spanContext})
+		}
+	}
+	return ret
+}
+
+func (links *spanLinks) forget(ids ...string) {
+	links.mutex.Lock()
+	defer links.mutex.Unlock()
+	for _, id := range ids {
+		delete(links.spans, id)
+	}
+}
@@ -0,0 +1,30 @@
+package solver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestSpanLinks(t *testing.T) {
+	tracer := sdktrace.NewTracerProvider().Tracer("test")
+	links := spanLinks{}
+
+	_, jobOfferSpan := tracer.Start(context.Background(), "add_job_offer")
+	jobOfferSpan.End()
+	links.remember("job_offer", jobOfferSpan)
+
+	// spans that are not recorded have nothing to link to
+	_, noopSpan := noop.NewTracerProvider().Tracer("test").Start(context.Background(), "add_resource_offer")
+	links.remember("resource_offer", noopSpan)
+
+	found := links.get("job_offer", "resource_offer", "missing")
+	assert.Len(t, found, 1)
+	assert.Equal(t, jobOfferSpan.SpanContext().TraceID(), found[0].SpanContext.TraceID())
+
+	links.forget("job_offer")
+	assert.Empty(t, links.get("job_offer"))
+}
@@ -2,6 +2,7 @@ package solver
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 
 	"github.com/lilypad-tech/lilypad/pkg/system"
@@ -60,6 +61,49 @@ func EnsureDealsFilePath(id string) (string, error) {
 	return system.EnsureDataDir(filepath.Join(FILES_DIR, id))
 }
 
+// the archive of a deal's result files that we serve for download
+// it is built once so that ranged requests always see the same bytes
+func GetDealsArchivePath(id string) string {
+	return GetDealsFilePath(id) + ".tar"
+}
+
+// build the result archive and its checksum if we have not already
+func EnsureDealsArchive(id string) (string, string, error) {
+	archivePath := GetDealsArchivePath(id)
+	checksumPath := archivePath + ".sha256"
+	checksum, err := os.ReadFile(checksumPath)
+	if err == nil {
+		if _, err := os.Stat(archivePath); err == nil {
+			return archivePath, string(checksum), nil
+		}
+	}
+	err = system.WriteTarFile(GetDealsFilePath(id), archivePath)
+	if err != nil {
+		return "", "", err
+	}
+	sum, err := system.GetFileChecksum(archivePath)
+	if err != nil {
+		return "", "", err
+	}
+	err = system.WriteFile(checksumPath, []byte(sum))
+	if err != nil {
+		return "", "", err
+	}
+	return archivePath, sum, nil
+}
+
+// throw away the result archive so it is rebuilt from the latest upload
+func RemoveDealsArchive(id string) error {
+	archivePath := GetDealsArchivePath(id)
+	for _, path := range []string{archivePath, archivePath + ".sha256"} {
+		err := os.Remove(path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
 func GetDownloadsFilePath(id string) string {
 	return system.GetDataDir(filepath.Join(DOWNLOADS_DIR, id))
 }
@@ -0,0 +1,133 @@
+package solver
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+// checks a result posted by a resource provider before the solver accepts it
+// returning an error rejects the result
+type ResultVerifier interface {
+	Name() string
+	Verify(deal data.DealContainer, result data.Result) error
+}
+
+type VerificationOptions struct {
+	// the names of the verifiers to run for modules that do not list their own
+	Verifiers []string
+	// the largest result the size verifier will accept (Megabytes)
+	MaxResultSize int
+}
+
+const (
+	FilesResultVerifier = "files"
+	SizeResultVerifier  = "size"
+)
+
+// the result files must have been uploaded before the result is posted
+type filesVerifier struct{}
+
+func (_ filesVerifier) Name() string { return FilesResultVerifier }
+func (_ filesVerifier) Verify(deal data.DealContainer, result data.Result) error {
+	// a job that errored has nothing to upload
+	if result.Error != "" {
+		return nil
+	}
+	if _, err := os.Stat(GetDealsFilePath(deal.ID)); err != nil {
+		return fmt.Errorf("result files for deal %s have not been uploaded", deal.ID)
+	}
+	return nil
+}
+
+// the uploaded result files must be below the configured size
+type sizeVerifier struct {
+	maxSize int
+}
+
+func (_ sizeVerifier) Name() string { return SizeResultVerifier }
+func (verifier sizeVerifier) Verify(deal data.DealContainer, result data.Result) error {
+	if verifier.maxSize <= 0 {
+		return nil
+	}
+	var totalSize int64
+	err := filepath.WalkDir(GetDealsFilePath(deal.ID), func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		totalSize += info.Size()
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	sizeMB := int(totalSize / 1024 / 1024)
+	if sizeMB > verifier.maxSize {
+		return fmt.Errorf("result for deal %s is %dMB which exceeds the limit of %dMB", deal.ID, sizeMB, verifier.maxSize)
+	}
+	return nil
+}
+
+func getDefaultResultVerifiers(options VerificationOptions) map[string]ResultVerifier {
+	return map[string]ResultVerifier{
+		FilesResultVerifier: filesVerifier{},
+		SizeResultVerifier:  sizeVerifier{maxSize: options.MaxResultSize},
+	}
+}
+
+// add a verifier that can then be named in the options or in an allowlist item
+func (controller *SolverController) RegisterResultVerifier(verifier ResultVerifier) {
+	controller.verifiers[verifier.Name()] = verifier
+}
+
+// make sure every verifier named in the options or the allowlist exists
+// so we find out about typos at startup rather than when a result is posted
+func (controller *SolverController) checkResultVerifiers() error {
+	names := append([]string{}, controller.options.Verification.Verifiers...)
+	names = append(names, controller.options.Audit.Verifiers...)
+	for _, item := range controller.getAllowlist() {
+		names = append(names, item.Verifiers...)
+	}
+	for _, name := range names {
+		if _, ok := controller.verifiers[name]; !ok {
+			return fmt.Errorf("unknown result verifier %s", name)
+		}
+	}
+	return nil
+}
+
+// run the verifiers configured for the deal's module against the result
+// modules in the allowlist can name their own verifiers otherwise we use the defaults
+func (controller *SolverController) verifyResult(deal data.DealContainer, result data.Result) error {
+	names := controller.options.Verification.Verifiers
+	moduleID, err := data.GetModuleID(deal.Deal.JobOffer.Module)
+	if err != nil {
+		return err
+	}
+	if item, ok := controller.getAllowlist()[moduleID]; ok && item.Verifiers != nil {
+		names = item.Verifiers
+	}
+
+	for _, name := range names {
+		verifier, ok := controller.verifiers[name]
+		if !ok {
+			return fmt.Errorf("unknown result verifier %s", name)
+		}
+		err := verifier.Verify(deal, result)
+		if err != nil {
+			controller.log.Error(fmt.Sprintf("result verifier %s rejected result", name), err)
+			return fmt.Errorf("result rejected by %s verifier: %s", name, err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package solver
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+)
+
+type WatchdogOptions struct {
+	// send the on-chain timeout for deals that are stuck past their timeouts
+	Enabled bool
+	// how many seconds past the timeout we wait before sending it
+	// the contract checks against the block time so this allows for drift
+	GracePeriod int
+}
+
+// the timeout that applies to a deal in its current state
+// and the state the controller contract moves it to once it has passed
+func getDealTimeout(deal data.DealContainer) (uint64, data.DealState, bool) {
+	timeouts := deal.Deal.Timeouts
+	switch data.DealState(deal.State) {
+	case data.DealNegotiating:
+		return timeouts.Agree.Timeout, data.TimeoutAgree, true
+	case data.DealAgreed:
+		return timeouts.SubmitResults.Timeout, data.TimeoutSubmitResults, true
+	case data.ResultsSubmitted:
+		return timeouts.JudgeResults.Timeout, data.TimeoutJudgeResults, true
+	case data.ResultsChecked:
+		return timeouts.MediateResults.Timeout, data.TimeoutMediateResults, true
+	}
+	return 0, 0, false
+}
+
+// has the deal been in its current state for longer than its timeout
+func isDealTimedOut(deal data.DealContainer, gracePeriod int, now int64) bool {
+	timeout, _, ok := getDealTimeout(deal)
+	if !ok || deal.StateUpdatedAt == 0 {
+		return false
+	}
+	return now > deal.StateUpdatedAt+int64(timeout)+int64(gracePeriod)
+}
+
+// send the controller contract transaction for a timeout state
+// on the chain the deal was made on
+func (controller *SolverController) triggerDealTimeout(deal data.DealContainer, state data.DealState) (string, error) {
+	chainSDK, err := controller.chains.Get(deal.ChainID)
+	if err != nil {
+		return "", err
+	}
+	switch state {
+	case data.TimeoutAgree:
+		return chainSDK.TimeoutAgree(deal.ID)
+	case data.TimeoutSubmitResults:
+		return chainSDK.TimeoutSubmitResult(deal.ID)
+	case data.TimeoutJudgeResults:
+		return chainSDK.TimeoutJudgeResult(deal.ID)
+	case data.TimeoutMediateResults:
+		return chainSDK.TimeoutMediateResult(deal.ID)
+	}
+	return "", fmt.Errorf("%s is not a timeout state", state)
+}
+
+// look for deals stuck past their timeouts and send the on-chain timeout for them
+// we try once per deal state and record the outcome - the deal itself only
+// moves to the timeout state once we see the state change event from the chain
+func (controller *SolverController) checkDealTimeouts() error {
+	deals, err := controller.store.GetDeals(store.GetDealsQuery{})
+	if err != nil {
+		return err
+	}
+	now := time.Now().Unix()
+	for _, deal := range deals {
+		if !isDealTimedOut(deal, controller.options.Watchdog.GracePeriod, now) {
+			continue
+		}
+		existing, err := controller.store.GetTimeoutEvent(deal.ID)
+		if err != nil {
+			return err
+		}
+		if existing != nil && existing.FromState == deal.State {
+			continue
+		}
+		_, state, _ := getDealTimeout(deal)
+		event := data.DealTimeoutEvent{
+			DealID:    deal.ID,
+			FromState: deal.State,
+			State:     uint8(state),
+			CreatedAt: now,
+		}
+		txHash, txErr := controller.triggerDealTimeout(deal, state)
+		if txErr != nil {
+			event.Error = txErr.Error()
+			controller.log.Error("error sending deal timeout", fmt.Errorf("deal %s %s: %s", deal.ID, state, txErr.Error()))
+		} else {
+			event.TransactionHash = txHash
+			controller.log.Info("deal timed out", event)
+		}
+		_, err = controller.store.AddTimeoutEvent(event)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
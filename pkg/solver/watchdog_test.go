@@ -0,0 +1,48 @@
+package solver
+
+import (
+	"testing"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsDealTimedOut(t *testing.T) {
+	timeouts := data.DealTimeouts{
+		Agree:          data.DealTimeout{Timeout: 10},
+		SubmitResults:  data.DealTimeout{Timeout: 20},
+		JudgeResults:   data.DealTimeout{Timeout: 30},
+		MediateResults: data.DealTimeout{Timeout: 40},
+	}
+	getDeal := func(state data.DealState, updatedAt int64) data.DealContainer {
+		return data.DealContainer{
+			ID:             "deal",
+			State:          uint8(state),
+			StateUpdatedAt: updatedAt,
+			Deal:           data.Deal{Timeouts: timeouts},
+		}
+	}
+
+	testCases := []struct {
+		name        string
+		deal        data.DealContainer
+		gracePeriod int
+		now         int64
+		expected    bool
+	}{
+		{name: "Agree not yet timed out", deal: getDeal(data.DealNegotiating, 100), now: 110, expected: false},
+		{name: "Agree timed out", deal: getDeal(data.DealNegotiating, 100), now: 111, expected: true},
+		{name: "Submit results timed out", deal: getDeal(data.DealAgreed, 100), now: 121, expected: true},
+		{name: "Judge results within grace period", deal: getDeal(data.ResultsSubmitted, 100), gracePeriod: 5, now: 133, expected: false},
+		{name: "Judge results past grace period", deal: getDeal(data.ResultsSubmitted, 100), gracePeriod: 5, now: 136, expected: true},
+		{name: "Mediate results timed out", deal: getDeal(data.ResultsChecked, 100), now: 141, expected: true},
+		{name: "Terminal state", deal: getDeal(data.ResultsAccepted, 100), now: 1000, expected: false},
+		{name: "Unknown state change time", deal: getDeal(data.DealAgreed, 0), now: 1000, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isDealTimedOut(tc.deal, tc.gracePeriod, tc.now))
+		})
+	}
+}
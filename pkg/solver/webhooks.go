@@ -0,0 +1,43 @@
+package solver
+
+import (
+	"context"
+
+	"github.com/lilypad-tech/lilypad/pkg/system"
+	"github.com/lilypad-tech/lilypad/pkg/webhooks"
+)
+
+// the webhook event a solver event is, empty if it is not one
+// the job creator uses this for the events of its own deals
+func GetWebhookEvent(ev SolverEvent) string {
+	if ev.Deal == nil {
+		return ""
+	}
+	switch ev.EventType {
+	case DealAdded:
+		return webhooks.EventDealMatched
+	case DealStateUpdated:
+		return webhooks.GetDealStateEvent(ev.Deal.State)
+	case MediationVerdictAdded:
+		return webhooks.EventMediationVerdict
+	case DealCancelled:
+		return webhooks.EventDealCancelled
+	}
+	return ""
+}
+
+// POST every deal event to the webhook URLs so nobody has to poll us
+func (controller *SolverController) startWebhooks(ctx context.Context) {
+	if !controller.options.Webhooks.Enabled() {
+		return
+	}
+	dispatcher := webhooks.NewDispatcher(system.SolverService, controller.options.Webhooks)
+	dispatcher.Start(ctx)
+	controller.subscribeEvents(func(ev SolverEvent) {
+		event := GetWebhookEvent(ev)
+		if event == "" {
+			return
+		}
+		dispatcher.Send(event, *ev.Deal)
+	})
+}
@@ -0,0 +1,158 @@
+package solver
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/solver/store"
+)
+
+// the stages with nothing upstream are added on the next solve
+func (controller *SolverController) addWorkflow(submission data.WorkflowSubmission) (*data.Workflow, error) {
+	workflow, err := data.NewWorkflow(submission, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	existing, err := controller.store.GetWorkflow(workflow.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+	controller.log.Info("add workflow", workflow)
+	return controller.store.AddWorkflow(workflow)
+}
+
+// the stages that are still to run are skipped and the running ones cancelled
+func (controller *SolverController) cancelWorkflow(workflow data.Workflow) (*data.Workflow, error) {
+	if workflow.State != data.WorkflowStageRunning {
+		return &workflow, nil
+	}
+	workflow.StageStates = append([]data.WorkflowStageStatus{}, workflow.StageStates...)
+	for i := range workflow.StageStates {
+		status := &workflow.StageStates[i]
+		switch status.State {
+		case data.WorkflowStageRunning:
+			controller.cancelWorkflowStage(workflow, *status)
+			fallthrough
+		case data.WorkflowStagePending:
+			status.State = data.WorkflowStageSkipped
+			status.Error = "workflow cancelled"
+		}
+	}
+	workflow.State = data.WorkflowStageFailed
+	workflow.FinishedAt = time.Now().Unix()
+	controller.log.Info("cancel workflow", workflow.ID)
+	return controller.store.AddWorkflow(workflow)
+}
+
+// check on the running stages of each workflow
+// and add the job offers for the stages whose upstream stages have succeeded
+func (controller *SolverController) runWorkflows(ctx context.Context, now time.Time) error {
+	workflows, err := controller.store.GetWorkflows(store.GetWorkflowsQuery{
+		Running: true,
+	})
+	if err != nil {
+		return err
+	}
+	for _, workflow := range workflows {
+		err = controller.runWorkflow(ctx, workflow, now)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (controller *SolverController) runWorkflow(ctx context.Context, workflow data.Workflow, now time.Time) error {
+	// the store hands out what it holds so copy the states before changing them
+	original := workflow.StageStates
+	workflow.StageStates = append([]data.WorkflowStageStatus{}, original...)
+
+	for i := range workflow.StageStates {
+		status := &workflow.StageStates[i]
+		if status.State != data.WorkflowStageRunning {
+			continue
+		}
+		err := controller.updateWorkflowStage(status)
+		if err != nil {
+			return err
+		}
+	}
+
+	ready, cancel := data.PlanWorkflow(&workflow, now)
+	for _, i := range cancel {
+		controller.cancelWorkflowStage(workflow, workflow.StageStates[i])
+	}
+
+	// a stage that cannot be added fails like one whose job did
+	// the failure policy decides what happens to the rest on the next solve
+	for _, i := range ready {
+		status := &workflow.StageStates[i]
+		jobOffer, err := controller.addJobOffer(ctx, data.GetWorkflowStageJobOffer(workflow, i, now))
+		if err != nil {
+			status.State = data.WorkflowStageFailed
+			status.Error = err.Error()
+			controller.log.Error(fmt.Sprintf("workflow %s stage %s", workflow.ID, status.Name), err)
+			continue
+		}
+		status.State = data.WorkflowStageRunning
+		status.JobOffer = jobOffer.ID
+	}
+
+	// most solves there is nothing new so there is nothing to write
+	if workflow.State == data.WorkflowStageRunning && slices.Equal(original, workflow.StageStates) {
+		return nil
+	}
+	_, err := controller.store.AddWorkflow(workflow)
+	return err
+}
+
+// bring a running stage up to date with its job offer
+func (controller *SolverController) updateWorkflowStage(status *data.WorkflowStageStatus) error {
+	jobOffer, err := controller.store.GetJobOffer(status.JobOffer)
+	if err != nil {
+		return err
+	}
+	if jobOffer == nil {
+		status.State = data.WorkflowStageFailed
+		status.Error = fmt.Sprintf("job offer %s is no longer known to the solver", status.JobOffer)
+		return nil
+	}
+	status.DealID = jobOffer.DealID
+	state := data.DealState(jobOffer.State)
+	if state == data.ResultsAccepted || state == data.MediationAccepted {
+		result, err := controller.store.GetResult(jobOffer.DealID)
+		if err != nil {
+			return err
+		}
+		if result == nil || result.DataID == "" {
+			status.State = data.WorkflowStageFailed
+			status.Error = "the deal has no results CID to pass on"
+			return nil
+		}
+		status.State = data.WorkflowStageSucceeded
+		status.ResultsID = result.DataID
+		return nil
+	}
+	if data.IsTerminalAgreementState(jobOffer.State) {
+		status.State = data.WorkflowStageFailed
+		status.Error = fmt.Sprintf("the deal ended in %s", data.GetAgreementStateString(jobOffer.State))
+	}
+	return nil
+}
+
+func (controller *SolverController) cancelWorkflowStage(workflow data.Workflow, status data.WorkflowStageStatus) {
+	jobOffer, err := controller.store.GetJobOffer(status.JobOffer)
+	if err != nil || jobOffer == nil || data.IsTerminalAgreementState(jobOffer.State) {
+		return
+	}
+	_, err = controller.cancelJobOffer(*jobOffer)
+	if err != nil {
+		controller.log.Error(fmt.Sprintf("error cancelling stage %s of workflow %s", status.Name, workflow.ID), err)
+	}
+}
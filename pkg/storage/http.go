@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	corehttp "net/http"
+	"os"
+	"strings"
+
+	"github.com/lilypad-tech/lilypad/pkg/system"
+)
+
+// results are PUT as a tar file to a plain HTTPS server
+// and fetched back with a GET of the same URL
+type httpStorage struct {
+	options HTTPOptions
+	client  *corehttp.Client
+}
+
+func newHTTPStorage(options HTTPOptions) *httpStorage {
+	return &httpStorage{
+		options: options,
+		client:  &corehttp.Client{},
+	}
+}
+
+func (storage *httpStorage) Name() string {
+	return HTTPBackend
+}
+
+func (storage *httpStorage) addAuth(req *corehttp.Request) {
+	if storage.options.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+storage.options.Token)
+	}
+}
+
+func (storage *httpStorage) Put(ctx context.Context, dealID string, localPath string) (string, error) {
+	archivePath, cleanup, err := writeTempArchive(localPath)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+	archive, err := os.Open(archivePath)
+	if err != nil {
+		return "", err
+	}
+	defer archive.Close()
+	info, err := archive.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	uri := fmt.Sprintf("%s/%s.tar", strings.TrimSuffix(storage.options.URL, "/"), dealID)
+	req, err := corehttp.NewRequestWithContext(ctx, corehttp.MethodPut, uri, archive)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = info.Size()
+	req.Header.Set("Content-Type", "application/x-tar")
+	storage.addAuth(req)
+	resp, err := storage.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("PUT %s returned %d: %s", uri, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return uri, nil
+}
+
+func (storage *httpStorage) Get(ctx context.Context, uri string, localPath string) error {
+	req, err := corehttp.NewRequestWithContext(ctx, corehttp.MethodGet, uri, nil)
+	if err != nil {
+		return err
+	}
+	storage.addAuth(req)
+	resp, err := storage.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != corehttp.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("GET %s returned %d: %s", uri, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return expandArchive(resp.Body, localPath)
+}
+
+// write the tar next to where it is going and expand it there
+func expandArchive(reader io.Reader, localPath string) error {
+	archivePath := localPath + ".tar"
+	archive, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(archive, reader)
+	closeErr := archive.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(archivePath)
+		return err
+	}
+	err = system.ExpandTarFile(archivePath, localPath)
+	if err != nil {
+		return err
+	}
+	return os.Remove(archivePath)
+}
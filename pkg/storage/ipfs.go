@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/lilypad-tech/lilypad/pkg/ipfs"
+)
+
+// results are added to the IPFS node and found again by CID
+type ipfsStorage struct {
+	client *ipfs.Client
+}
+
+func (storage *ipfsStorage) Name() string {
+	return IPFSBackend
+}
+
+func (storage *ipfsStorage) Put(ctx context.Context, dealID string, localPath string) (string, error) {
+	return storage.client.Put(ctx, localPath)
+}
+
+func (storage *ipfsStorage) Get(ctx context.Context, uri string, localPath string) error {
+	return storage.client.Get(ctx, uri, localPath)
+}
@@ -0,0 +1,185 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	corehttp "net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/system"
+)
+
+// the sha256 of an empty body
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// results are PUT as a tar object to an S3 compatible bucket
+// requests are signed with AWS signature version 4 so we do not
+// need to pull in an SDK for two calls
+type s3Storage struct {
+	options S3Options
+	client  *corehttp.Client
+	now     func() time.Time
+}
+
+func newS3Storage(options S3Options) *s3Storage {
+	return &s3Storage{
+		options: options,
+		client:  &corehttp.Client{},
+		now:     time.Now,
+	}
+}
+
+func (storage *s3Storage) Name() string {
+	return S3Backend
+}
+
+func (storage *s3Storage) objectURL(bucket string, key string) string {
+	return fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(storage.options.Endpoint, "/"), bucket, key)
+}
+
+func (storage *s3Storage) Put(ctx context.Context, dealID string, localPath string) (string, error) {
+	archivePath, cleanup, err := writeTempArchive(localPath)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+	payloadHash, err := system.GetFileChecksum(archivePath)
+	if err != nil {
+		return "", err
+	}
+	archive, err := os.Open(archivePath)
+	if err != nil {
+		return "", err
+	}
+	defer archive.Close()
+	info, err := archive.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	key := storage.options.Prefix + dealID + ".tar"
+	req, err := corehttp.NewRequestWithContext(ctx, corehttp.MethodPut, storage.objectURL(storage.options.Bucket, key), archive)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = info.Size()
+	req.Header.Set("Content-Type", "application/x-tar")
+	signS3Request(req, storage.options, payloadHash, storage.now())
+	resp, err := storage.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("S3 PUT %s returned %d: %s", key, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return fmt.Sprintf("s3://%s/%s", storage.options.Bucket, key), nil
+}
+
+func (storage *s3Storage) Get(ctx context.Context, uri string, localPath string) error {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme != "s3" {
+		return fmt.Errorf("not an s3 location: %s", uri)
+	}
+	key := strings.TrimPrefix(parsed.Path, "/")
+	req, err := corehttp.NewRequestWithContext(ctx, corehttp.MethodGet, storage.objectURL(parsed.Host, key), nil)
+	if err != nil {
+		return err
+	}
+	signS3Request(req, storage.options, emptyPayloadHash, storage.now())
+	resp, err := storage.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != corehttp.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("S3 GET %s returned %d: %s", key, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return expandArchive(resp.Body, localPath)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// encode a path as S3 expects, every byte apart from the
+// unreserved characters and the slashes between segments
+func s3EscapePath(path string) string {
+	var builder strings.Builder
+	for _, b := range []byte(path) {
+		if (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') ||
+			b == '-' || b == '_' || b == '.' || b == '~' || b == '/' {
+			builder.WriteByte(b)
+		} else {
+			fmt.Fprintf(&builder, "%%%02X", b)
+		}
+	}
+	return builder.String()
+}
+
+// add the AWS signature version 4 headers for the request
+// every header already on the request is signed along with the host
+func signS3Request(req *corehttp.Request, options S3Options, payloadHash string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := []string{}
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	canonicalHeaders := ""
+	for _, name := range names {
+		canonicalHeaders += name + ":" + headers[name] + "\n"
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	// url.Values.Encode sorts by key which is what the signature wants
+	canonicalQuery := strings.ReplaceAll(req.URL.Query().Encode(), "+", "%20")
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3EscapePath(req.URL.Path),
+		canonicalQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, options.Region)
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+options.SecretKey), date)
+	signingKey = hmacSHA256(signingKey, options.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		options.AccessKey, scope, signedHeaders, signature,
+	))
+}
@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/ipfs"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+)
+
+const (
+	IPFSBackend = "ipfs"
+	S3Backend   = "s3"
+	HTTPBackend = "http"
+)
+
+type S3Options struct {
+	// the S3 compatible endpoint e.g. https://s3.us-east-1.amazonaws.com
+	// buckets are addressed by path so this works with minio and friends
+	Endpoint string
+	Region   string
+	Bucket   string
+	// prepended to the object keys
+	Prefix    string
+	AccessKey string
+	SecretKey string
+}
+
+type HTTPOptions struct {
+	// results are PUT to and fetched from URL/<deal id>.tar
+	URL string
+	// sent as a bearer token if set
+	Token string
+}
+
+type StorageOptions struct {
+	// the backends results are put in, any of ipfs, s3 and http
+	Backends []string
+	S3       S3Options
+	HTTP     HTTPOptions
+}
+
+// somewhere we can put the results of a deal and fetch them back from
+type ResultsStorage interface {
+	// the name the backend is selected by and that locations are recorded against
+	Name() string
+	// store the folder of results and return where they went
+	Put(ctx context.Context, dealID string, localPath string) (string, error)
+	// fetch the results at uri into the folder at localPath
+	Get(ctx context.Context, uri string, localPath string) error
+}
+
+// the backends named in the options
+// the IPFS client is only needed if the ipfs backend is selected
+func NewResultsStorage(options StorageOptions, ipfsClient *ipfs.Client) ([]ResultsStorage, error) {
+	backends := []ResultsStorage{}
+	for _, name := range options.Backends {
+		switch name {
+		case IPFSBackend:
+			if ipfsClient == nil {
+				return nil, fmt.Errorf("the ipfs results storage needs an IPFS node")
+			}
+			backends = append(backends, &ipfsStorage{client: ipfsClient})
+		case S3Backend:
+			backends = append(backends, newS3Storage(options.S3))
+		case HTTPBackend:
+			backends = append(backends, newHTTPStorage(options.HTTP))
+		default:
+			return nil, fmt.Errorf("unknown results storage backend: %s", name)
+		}
+	}
+	return backends, nil
+}
+
+// put the results in every backend, a backend failing does not stop
+// the others so we return the locations that worked along with the errors
+func PutResults(ctx context.Context, backends []ResultsStorage, dealID string, localPath string) ([]data.ResultLocation, error) {
+	locations := []data.ResultLocation{}
+	errs := []error{}
+	for _, backend := range backends {
+		uri, err := backend.Put(ctx, dealID, localPath)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error putting results for deal %s in %s: %w", dealID, backend.Name(), err))
+			continue
+		}
+		locations = append(locations, data.ResultLocation{
+			Backend: backend.Name(),
+			URI:     uri,
+		})
+	}
+	return locations, errors.Join(errs...)
+}
+
+// fetch the results from the first location we have a backend for that works
+func GetResults(ctx context.Context, backends []ResultsStorage, locations []data.ResultLocation, localPath string) error {
+	errs := []error{}
+	for _, location := range locations {
+		for _, backend := range backends {
+			if backend.Name() != location.Backend {
+				continue
+			}
+			err := backend.Get(ctx, location.URI, localPath)
+			if err == nil {
+				return nil
+			}
+			errs = append(errs, fmt.Errorf("error getting results from %s: %w", location.URI, err))
+		}
+	}
+	if len(errs) == 0 {
+		return fmt.Errorf("no results storage backend for any of the result locations")
+	}
+	return errors.Join(errs...)
+}
+
+// the http and s3 backends move results as a single tar file
+func writeTempArchive(localPath string) (string, func(), error) {
+	file, err := os.CreateTemp("", "lilypad-results-*.tar")
+	if err != nil {
+		return "", nil, err
+	}
+	archivePath := file.Name()
+	file.Close()
+	cleanup := func() { os.Remove(archivePath) }
+	err = system.WriteTarFile(localPath, archivePath)
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return archivePath, cleanup, nil
+}
@@ -15,6 +15,8 @@ type ControlLoop struct {
 	handler      func() error
 	running      bool
 	counter      int
+	// set while a debounced trigger is waiting to fire
+	debouncing bool
 }
 
 func NewControlLoop(
@@ -57,6 +59,27 @@ func (loop *ControlLoop) Trigger() {
 	}
 }
 
+// trigger the loop once the delay has passed - anything else that
+// asks for a debounced trigger in the meantime shares that run
+// so a burst of events results in one pass rather than one each
+func (loop *ControlLoop) Debounce(delay time.Duration) {
+	loop.triggerMutex.Lock()
+	defer loop.triggerMutex.Unlock()
+	if loop.debouncing {
+		return
+	}
+	loop.debouncing = true
+	time.AfterFunc(delay, func() {
+		loop.triggerMutex.Lock()
+		loop.debouncing = false
+		loop.triggerMutex.Unlock()
+		if loop.ctx.Err() != nil {
+			return
+		}
+		loop.Trigger()
+	})
+}
+
 func (loop *ControlLoop) run() {
 	// this means that only 1 version this of function can be running at a time
 	loop.runMutex.Lock()
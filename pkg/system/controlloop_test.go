@@ -0,0 +1,28 @@
+package system
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestControlLoopDebounce(t *testing.T) {
+	var runs atomic.Int32
+	loop := NewControlLoop(SolverService, context.Background(), time.Hour, func() error {
+		runs.Add(1)
+		return nil
+	})
+
+	for i := 0; i < 5; i++ {
+		loop.Debounce(20 * time.Millisecond)
+	}
+	assert.Equal(t, int32(0), runs.Load())
+	assert.Eventually(t, func() bool { return runs.Load() == 1 }, time.Second, 5*time.Millisecond)
+
+	// once it has fired the next trigger waits again
+	loop.Debounce(20 * time.Millisecond)
+	assert.Eventually(t, func() bool { return runs.Load() == 2 }, time.Second, 5*time.Millisecond)
+}
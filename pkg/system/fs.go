@@ -3,6 +3,8 @@ package system
 import (
 	"archive/tar"
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"io"
 	"os"
 	"path/filepath"
@@ -45,7 +47,36 @@ func WriteFile(path string, data []byte) error {
 
 func GetTarBuffer(localPath string) (*bytes.Buffer, error) {
 	var buf bytes.Buffer
-	tw := tar.NewWriter(&buf)
+	err := writeTar(&buf, localPath)
+	if err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
+
+// write the tar of a folder to a file rather than holding it in memory
+// the file is written to a temporary path first so a half written
+// archive is never left at archivePath
+func WriteTarFile(localPath string, archivePath string) error {
+	tmpPath := archivePath + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	err = writeTar(file, localPath)
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return os.Rename(tmpPath, archivePath)
+}
+
+func writeTar(w io.Writer, localPath string) error {
+	tw := tar.NewWriter(w)
 
 	err := filepath.Walk(localPath, func(file string, fi os.FileInfo, err error) error {
 		// Handle errors
@@ -89,19 +120,42 @@ func GetTarBuffer(localPath string) (*bytes.Buffer, error) {
 	})
 
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	if err := tw.Close(); err != nil {
-		return nil, err
+	return tw.Close()
+}
+
+func ExpandTarBuffer(buf *bytes.Buffer, localPath string) error {
+	return expandTar(buf, localPath)
+}
+
+func ExpandTarFile(archivePath string, localPath string) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return err
 	}
+	defer file.Close()
+	return expandTar(file, localPath)
+}
 
-	return &buf, nil
+// the hex sha256 of a file
+func GetFileChecksum(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
 }
 
-func ExpandTarBuffer(buf *bytes.Buffer, localPath string) error {
+func expandTar(r io.Reader, localPath string) error {
 	// Create a new tar reader
-	tr := tar.NewReader(buf)
+	tr := tar.NewReader(r)
 
 	// Iterate through tar headers (files)
 	for {
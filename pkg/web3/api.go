@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/lilypad-tech/lilypad/pkg/data"
-	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/pow"
+	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/storage"
 	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/users"
 	"github.com/rs/zerolog/log"
 )
@@ -42,45 +44,27 @@ func (sdk *Web3SDK) UpdateUser(
 	url string,
 	roles []uint8,
 ) error {
-	tx, err := sdk.Contracts.Users.UpdateUser(
-		sdk.TransactOpts,
-		metadataCID,
-		url,
-		roles,
-	)
-	if err != nil {
-		system.Error(sdk.Options.Service, "error submitting Users.UpdateUser", err)
-		return err
-	} else {
-		system.Info(sdk.Options.Service, "submitted users.UpdateUser", tx.Hash().String())
-		system.DumpObjectDebug(tx)
-	}
-	_, err = sdk.WaitTx(context.Background(), tx)
-	if err != nil {
-		return err
-	}
-	return nil
+	_, err := sdk.Transact(context.Background(), "users.UpdateUser", "", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return sdk.Contracts.Users.UpdateUser(
+			opts,
+			metadataCID,
+			url,
+			roles,
+		)
+	})
+	return err
 }
 
 func (sdk *Web3SDK) AddUserToList(
 	serviceType uint8,
 ) error {
-	tx, err := sdk.Contracts.Users.AddUserToList(
-		sdk.TransactOpts,
-		serviceType,
-	)
-	if err != nil {
-		system.Error(sdk.Options.Service, "error submitting Users.AddUserToList", err)
-		return err
-	} else {
-		system.Info(sdk.Options.Service, "submitted users.AddUserToList", tx.Hash().String())
-		system.DumpObjectDebug(tx)
-	}
-	_, err = sdk.WaitTx(context.Background(), tx)
-	if err != nil {
-		return err
-	}
-	return nil
+	_, err := sdk.Transact(context.Background(), "users.AddUserToList", "", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return sdk.Contracts.Users.AddUserToList(
+			opts,
+			serviceType,
+		)
+	})
+	return err
 }
 
 func (sdk *Web3SDK) GetSolverUrl(address string) (string, error) {
@@ -101,32 +85,42 @@ func (sdk *Web3SDK) GetSolverUrl(address string) (string, error) {
 	return solver.Url, nil
 }
 
+// the state of the deal on chain now, used to undo state a reorg took back
+func (sdk *Web3SDK) GetDealState(dealId string) (uint8, error) {
+	agreement, err := sdk.Contracts.Storage.GetAgreement(sdk.CallOpts, dealId)
+	if err != nil {
+		return 0, err
+	}
+	return agreement.State, nil
+}
+
 func (sdk *Web3SDK) Agree(
 	deal data.Deal,
 ) (string, error) {
+	if deal.JobOffer.PaymentToken != nil {
+		receipt, err := sdk.agreeWithPaymentToken(context.Background(), deal)
+		if err != nil {
+			return "", err
+		}
+		return receipt.TxHash.String(), nil
+	}
 	mediators := []common.Address{}
 	for _, mediator := range deal.Members.Mediators {
 		mediators = append(mediators, common.HexToAddress(mediator))
 	}
-	tx, err := sdk.Contracts.Controller.Agree(
-		sdk.TransactOpts,
-		deal.ID,
-		data.ConvertDealMembers(deal.Members),
-		data.ConvertDealTimeouts(deal.Timeouts),
-		data.ConvertDealPricing(deal.Pricing),
-	)
+	receipt, err := sdk.Transact(context.Background(), "controller.Agree", deal.ID, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return sdk.Contracts.Controller.Agree(
+			opts,
+			deal.ID,
+			data.ConvertDealMembers(deal.Members),
+			data.ConvertDealTimeouts(deal.Timeouts, data.GetDealDecimals(deal)),
+			data.ConvertDealPricing(deal.Pricing, data.GetDealDecimals(deal)),
+		)
+	})
 	if err != nil {
-		system.Error(sdk.Options.Service, "error submitting controller.Agree() tx", err)
 		return "", err
-	} else {
-		system.Debug(sdk.Options.Service, "submitted controller.Agree() tx", tx.Hash().String())
-		system.DumpObjectDebug(tx)
 	}
-	_, err = sdk.WaitTx(context.Background(), tx)
-	if err != nil {
-		return "", err
-	}
-	return tx.Hash().String(), nil
+	return receipt.TxHash.String(), nil
 }
 
 func (sdk *Web3SDK) AddResult(
@@ -135,140 +129,178 @@ func (sdk *Web3SDK) AddResult(
 	dataId string,
 	instructionCount uint64,
 ) (string, error) {
-	tx, err := sdk.Contracts.Controller.AddResult(
-		sdk.TransactOpts,
-		dealId,
-		resultsId,
-		dataId,
-		big.NewInt(int64(instructionCount)),
-	)
+	// the results collateral is a multiple of the job cost
+	err := sdk.approveDealPayment(context.Background(), dealId, func(deal storage.SharedStructsDeal) *big.Int {
+		jobCost := new(big.Int).Mul(deal.Pricing.InstructionPrice, new(big.Int).SetUint64(instructionCount))
+		return new(big.Int).Mul(deal.Pricing.ResultsCollateralMultiple, jobCost)
+	})
 	if err != nil {
-		system.Error(sdk.Options.Service, "error submitting controller.AddResult", err)
 		return "", err
-	} else {
-		system.Debug(sdk.Options.Service, "submitted controller.AddResult", tx.Hash().String())
-		system.DumpObjectDebug(tx)
 	}
-	_, err = sdk.WaitTx(context.Background(), tx)
+	receipt, err := sdk.Transact(context.Background(), "controller.AddResult", dealId, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return sdk.Contracts.Controller.AddResult(
+			opts,
+			dealId,
+			resultsId,
+			dataId,
+			big.NewInt(int64(instructionCount)),
+		)
+	})
 	if err != nil {
 		return "", err
 	}
-	return tx.Hash().String(), nil
+	return receipt.TxHash.String(), nil
 }
 
 func (sdk *Web3SDK) AcceptResult(
 	dealId string,
 ) (string, error) {
-	tx, err := sdk.Contracts.Controller.AcceptResult(
-		sdk.TransactOpts,
-		dealId,
-	)
+	receipt, err := sdk.Transact(context.Background(), "controller.AcceptResult", dealId, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return sdk.Contracts.Controller.AcceptResult(
+			opts,
+			dealId,
+		)
+	})
 	if err != nil {
-		system.Error(sdk.Options.Service, "error submitting controller.AcceptResult", err)
 		return "", err
-	} else {
-		system.Debug(sdk.Options.Service, "submitted controller.AcceptResult", tx.Hash().String())
-		system.DumpObjectDebug(tx)
 	}
-	_, err = sdk.WaitTx(context.Background(), tx)
+	return receipt.TxHash.String(), nil
+}
+
+func (sdk *Web3SDK) CheckResult(
+	dealId string,
+) (string, error) {
+	err := sdk.approveDealPayment(context.Background(), dealId, func(deal storage.SharedStructsDeal) *big.Int {
+		return deal.Pricing.MediationFee
+	})
 	if err != nil {
 		return "", err
 	}
-	return tx.Hash().String(), nil
+	receipt, err := sdk.Transact(context.Background(), "controller.CheckResult", dealId, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return sdk.Contracts.Controller.CheckResult(
+			opts,
+			dealId,
+		)
+	})
+	if err != nil {
+		return "", err
+	}
+	return receipt.TxHash.String(), nil
 }
 
-func (sdk *Web3SDK) CheckResult(
+func (sdk *Web3SDK) MediationAcceptResult(
 	dealId string,
 ) (string, error) {
-	tx, err := sdk.Contracts.Controller.CheckResult(
-		sdk.TransactOpts,
-		dealId,
-	)
+	receipt, err := sdk.Transact(context.Background(), "controller.MediationAcceptResult", dealId, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return sdk.Contracts.Controller.MediationAcceptResult(
+			opts,
+			dealId,
+		)
+	})
 	if err != nil {
-		system.Error(sdk.Options.Service, "error submitting controller.CheckResult", err)
 		return "", err
-	} else {
-		system.Debug(sdk.Options.Service, "submitted controller.CheckResult", tx.Hash().String())
-		system.DumpObjectDebug(tx)
 	}
-	_, err = sdk.WaitTx(context.Background(), tx)
+	return receipt.TxHash.String(), nil
+}
+
+func (sdk *Web3SDK) MediationRejectResult(
+	dealId string,
+) (string, error) {
+	receipt, err := sdk.Transact(context.Background(), "controller.MediationRejectResult", dealId, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return sdk.Contracts.Controller.MediationRejectResult(
+			opts,
+			dealId,
+		)
+	})
 	if err != nil {
 		return "", err
 	}
-	return tx.Hash().String(), nil
+	return receipt.TxHash.String(), nil
 }
 
-func (sdk *Web3SDK) MediationAcceptResult(
+func (sdk *Web3SDK) TimeoutAgree(
 	dealId string,
 ) (string, error) {
-	tx, err := sdk.Contracts.Controller.MediationAcceptResult(
-		sdk.TransactOpts,
-		dealId,
-	)
+	receipt, err := sdk.Transact(context.Background(), "controller.TimeoutAgree", dealId, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return sdk.Contracts.Controller.TimeoutAgree(
+			opts,
+			dealId,
+		)
+	})
 	if err != nil {
-		system.Error(sdk.Options.Service, "error submitting controller.MediationAcceptResult", err)
 		return "", err
-	} else {
-		system.Debug(sdk.Options.Service, "submitted controller.MediationAcceptResult", tx.Hash().String())
-		system.DumpObjectDebug(tx)
 	}
-	_, err = sdk.WaitTx(context.Background(), tx)
+	return receipt.TxHash.String(), nil
+}
+
+func (sdk *Web3SDK) TimeoutSubmitResult(
+	dealId string,
+) (string, error) {
+	receipt, err := sdk.Transact(context.Background(), "controller.TimeoutSubmitResult", dealId, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return sdk.Contracts.Controller.TimeoutSubmitResult(
+			opts,
+			dealId,
+		)
+	})
 	if err != nil {
 		return "", err
 	}
-	return tx.Hash().String(), nil
+	return receipt.TxHash.String(), nil
 }
 
-func (sdk *Web3SDK) MediationRejectResult(
+func (sdk *Web3SDK) TimeoutJudgeResult(
 	dealId string,
 ) (string, error) {
-	tx, err := sdk.Contracts.Controller.MediationRejectResult(
-		sdk.TransactOpts,
-		dealId,
-	)
+	receipt, err := sdk.Transact(context.Background(), "controller.TimeoutJudgeResult", dealId, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return sdk.Contracts.Controller.TimeoutJudgeResult(
+			opts,
+			dealId,
+		)
+	})
 	if err != nil {
-		system.Error(sdk.Options.Service, "error submitting controller.MediationRejectResult", err)
 		return "", err
-	} else {
-		system.Debug(sdk.Options.Service, "submitted controller.MediationRejectResult", tx.Hash().String())
-		system.DumpObjectDebug(tx)
 	}
-	_, err = sdk.WaitTx(context.Background(), tx)
+	return receipt.TxHash.String(), nil
+}
+
+func (sdk *Web3SDK) TimeoutMediateResult(
+	dealId string,
+) (string, error) {
+	receipt, err := sdk.Transact(context.Background(), "controller.TimeoutMediateResult", dealId, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return sdk.Contracts.Controller.TimeoutMediateResult(
+			opts,
+			dealId,
+		)
+	})
 	if err != nil {
 		return "", err
 	}
-	return tx.Hash().String(), nil
+	return receipt.TxHash.String(), nil
 }
 
 func (sdk *Web3SDK) GetGenerateChallenge(
 	ctx context.Context,
 	nodeId string,
 ) (string, *pow.PowGenerateChallenge, error) {
-	tx, err := sdk.Contracts.Pow.GenerateChallenge(
-		sdk.TransactOpts,
-		nodeId,
-	)
-	if err != nil {
-		system.Error(sdk.Options.Service, "error submitting pow.GenerateChallenge", err)
-		return "", nil, err
-	} else {
-		system.Debug(sdk.Options.Service, "submitted pow.GenerateChallenge", tx.Hash().String())
-		system.DumpObjectDebug(tx)
-	}
-	receipt, err := sdk.WaitTx(context.Background(), tx)
+	receipt, err := sdk.Transact(context.Background(), "pow.GenerateChallenge", "", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return sdk.Contracts.Pow.GenerateChallenge(
+			opts,
+			nodeId,
+		)
+	})
 	if err != nil {
 		return "", nil, err
 	}
 
 	if receipt.Status == 0 {
-		return tx.Hash().String(), nil, fmt.Errorf("execute challenge fail")
+		return receipt.TxHash.String(), nil, fmt.Errorf("execute challenge fail")
 	}
 
 	challenge, err := sdk.Contracts.Pow.ParseGenerateChallenge(*receipt.Logs[0])
 	if err != nil {
 		return "", nil, err
 	}
-	return tx.Hash().String(), challenge, nil
+	return receipt.TxHash.String(), challenge, nil
 }
 
 func (sdk *Web3SDK) SubmitWork(
@@ -276,21 +308,18 @@ func (sdk *Web3SDK) SubmitWork(
 	nonce *big.Int,
 	nodeId string,
 ) (common.Hash, error) {
-	tx, err := sdk.Contracts.Pow.SubmitWork(sdk.TransactOpts, nonce, nodeId)
-	if err != nil {
-		return common.Hash{}, err
-	}
-
-	receipt, err := sdk.WaitTx(ctx, tx)
+	receipt, err := sdk.Transact(ctx, "pow.SubmitWork", "", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return sdk.Contracts.Pow.SubmitWork(opts, nonce, nodeId)
+	})
 	if err != nil {
 		return common.Hash{}, err
 	}
 
 	if receipt.Status == 0 {
-		return tx.Hash(), fmt.Errorf("excute transaction fail")
+		return receipt.TxHash, fmt.Errorf("excute transaction fail")
 	}
 
-	return tx.Hash(), nil
+	return receipt.TxHash, nil
 }
 
 type PowValidPOWSubmission struct {
@@ -304,23 +333,20 @@ type PowValidPOWSubmission struct {
 }
 
 func (sdk *Web3SDK) SendPowSignal(ctx context.Context) (*pow.PowNewPowRound, error) {
-	tx, err := sdk.Contracts.Pow.TriggerNewPowRound(sdk.TransactOpts)
-	if err != nil {
-		return nil, err
-	}
-
-	receipt, err := sdk.WaitTx(ctx, tx)
+	receipt, err := sdk.Transact(ctx, "pow.TriggerNewPowRound", "", func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return sdk.Contracts.Pow.TriggerNewPowRound(opts)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("wait new pow siganl tx(%s) %w", tx.Hash(), err)
+		return nil, fmt.Errorf("wait new pow siganl tx %w", err)
 	}
 
 	if receipt.Status == 0 {
-		return nil, fmt.Errorf("send new pow signal successfully but execute fail status(%d) tx(%s)", receipt.Status, tx.Hash())
+		return nil, fmt.Errorf("send new pow signal successfully but execute fail status(%d) tx(%s)", receipt.Status, receipt.TxHash)
 	}
 
 	newPowRoundEvent, err := sdk.Contracts.Pow.ParseNewPowRound(*receipt.Logs[0])
 	if err != nil {
-		return nil, fmt.Errorf("parse new pow round event fail tx(%s) %w", tx.Hash(), err)
+		return nil, fmt.Errorf("parse new pow round event fail tx(%s) %w", receipt.TxHash, err)
 	}
 	return newPowRoundEvent, nil
 }
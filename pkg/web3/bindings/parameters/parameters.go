@@ -0,0 +1,780 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package parameters
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// ParametersMetaData contains all meta data concerning the Parameters contract.
+var ParametersMetaData = &bind.MetaData{
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint8\",\"name\":\"version\",\"type\":\"uint8\"}],\"name\":\"Initialized\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"key\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"ParameterSet\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"getKeys\",\"outputs\":[{\"internalType\":\"string[]\",\"name\":\"\",\"type\":\"string[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"key\",\"type\":\"string\"}],\"name\":\"getParameter\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"initialize\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"renounceOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"key\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"setParameter\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// ParametersABI is the input ABI used to generate the binding from.
+// Deprecated: Use ParametersMetaData.ABI instead.
+var ParametersABI = ParametersMetaData.ABI
+
+// Parameters is an auto generated Go binding around an Ethereum contract.
+type Parameters struct {
+	ParametersCaller     // Read-only binding to the contract
+	ParametersTransactor // Write-only binding to the contract
+	ParametersFilterer   // Log filterer for contract events
+}
+
+// ParametersCaller is an auto generated read-only Go binding around an Ethereum contract.
+type ParametersCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ParametersTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type ParametersTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ParametersFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type ParametersFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// ParametersSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type ParametersSession struct {
+	Contract     *Parameters       // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// ParametersCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type ParametersCallerSession struct {
+	Contract *ParametersCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts     // Call options to use throughout this session
+}
+
+// ParametersTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type ParametersTransactorSession struct {
+	Contract     *ParametersTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts     // Transaction auth options to use throughout this session
+}
+
+// ParametersRaw is an auto generated low-level Go binding around an Ethereum contract.
+type ParametersRaw struct {
+	Contract *Parameters // Generic contract binding to access the raw methods on
+}
+
+// ParametersCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type ParametersCallerRaw struct {
+	Contract *ParametersCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// ParametersTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type ParametersTransactorRaw struct {
+	Contract *ParametersTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewParameters creates a new instance of Parameters, bound to a specific deployed contract.
+func NewParameters(address common.Address, backend bind.ContractBackend) (*Parameters, error) {
+	contract, err := bindParameters(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &Parameters{ParametersCaller: ParametersCaller{contract: contract}, ParametersTransactor: ParametersTransactor{contract: contract}, ParametersFilterer: ParametersFilterer{contract: contract}}, nil
+}
+
+// NewParametersCaller creates a new read-only instance of Parameters, bound to a specific deployed contract.
+func NewParametersCaller(address common.Address, caller bind.ContractCaller) (*ParametersCaller, error) {
+	contract, err := bindParameters(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ParametersCaller{contract: contract}, nil
+}
+
+// NewParametersTransactor creates a new write-only instance of Parameters, bound to a specific deployed contract.
+func NewParametersTransactor(address common.Address, transactor bind.ContractTransactor) (*ParametersTransactor, error) {
+	contract, err := bindParameters(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ParametersTransactor{contract: contract}, nil
+}
+
+// NewParametersFilterer creates a new log filterer instance of Parameters, bound to a specific deployed contract.
+func NewParametersFilterer(address common.Address, filterer bind.ContractFilterer) (*ParametersFilterer, error) {
+	contract, err := bindParameters(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &ParametersFilterer{contract: contract}, nil
+}
+
+// bindParameters binds a generic wrapper to an already deployed contract.
+func bindParameters(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := ParametersMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Parameters *ParametersRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Parameters.Contract.ParametersCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Parameters *ParametersRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Parameters.Contract.ParametersTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Parameters *ParametersRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Parameters.Contract.ParametersTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_Parameters *ParametersCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _Parameters.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_Parameters *ParametersTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Parameters.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_Parameters *ParametersTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _Parameters.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetKeys is a free data retrieval call binding the contract method 0x2150c518.
+//
+// Solidity: function getKeys() view returns(string[])
+func (_Parameters *ParametersCaller) GetKeys(opts *bind.CallOpts) ([]string, error) {
+	var out []interface{}
+	err := _Parameters.contract.Call(opts, &out, "getKeys")
+
+	if err != nil {
+		return *new([]string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]string)).(*[]string)
+
+	return out0, err
+
+}
+
+// GetKeys is a free data retrieval call binding the contract method 0x2150c518.
+//
+// Solidity: function getKeys() view returns(string[])
+func (_Parameters *ParametersSession) GetKeys() ([]string, error) {
+	return _Parameters.Contract.GetKeys(&_Parameters.CallOpts)
+}
+
+// GetKeys is a free data retrieval call binding the contract method 0x2150c518.
+//
+// Solidity: function getKeys() view returns(string[])
+func (_Parameters *ParametersCallerSession) GetKeys() ([]string, error) {
+	return _Parameters.Contract.GetKeys(&_Parameters.CallOpts)
+}
+
+// GetParameter is a free data retrieval call binding the contract method 0x9e647aac.
+//
+// Solidity: function getParameter(string key) view returns(uint256)
+func (_Parameters *ParametersCaller) GetParameter(opts *bind.CallOpts, key string) (*big.Int, error) {
+	var out []interface{}
+	err := _Parameters.contract.Call(opts, &out, "getParameter", key)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetParameter is a free data retrieval call binding the contract method 0x9e647aac.
+//
+// Solidity: function getParameter(string key) view returns(uint256)
+func (_Parameters *ParametersSession) GetParameter(key string) (*big.Int, error) {
+	return _Parameters.Contract.GetParameter(&_Parameters.CallOpts, key)
+}
+
+// GetParameter is a free data retrieval call binding the contract method 0x9e647aac.
+//
+// Solidity: function getParameter(string key) view returns(uint256)
+func (_Parameters *ParametersCallerSession) GetParameter(key string) (*big.Int, error) {
+	return _Parameters.Contract.GetParameter(&_Parameters.CallOpts, key)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_Parameters *ParametersCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _Parameters.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_Parameters *ParametersSession) Owner() (common.Address, error) {
+	return _Parameters.Contract.Owner(&_Parameters.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_Parameters *ParametersCallerSession) Owner() (common.Address, error) {
+	return _Parameters.Contract.Owner(&_Parameters.CallOpts)
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0x8129fc1c.
+//
+// Solidity: function initialize() returns()
+func (_Parameters *ParametersTransactor) Initialize(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Parameters.contract.Transact(opts, "initialize")
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0x8129fc1c.
+//
+// Solidity: function initialize() returns()
+func (_Parameters *ParametersSession) Initialize() (*types.Transaction, error) {
+	return _Parameters.Contract.Initialize(&_Parameters.TransactOpts)
+}
+
+// Initialize is a paid mutator transaction binding the contract method 0x8129fc1c.
+//
+// Solidity: function initialize() returns()
+func (_Parameters *ParametersTransactorSession) Initialize() (*types.Transaction, error) {
+	return _Parameters.Contract.Initialize(&_Parameters.TransactOpts)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_Parameters *ParametersTransactor) RenounceOwnership(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _Parameters.contract.Transact(opts, "renounceOwnership")
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_Parameters *ParametersSession) RenounceOwnership() (*types.Transaction, error) {
+	return _Parameters.Contract.RenounceOwnership(&_Parameters.TransactOpts)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_Parameters *ParametersTransactorSession) RenounceOwnership() (*types.Transaction, error) {
+	return _Parameters.Contract.RenounceOwnership(&_Parameters.TransactOpts)
+}
+
+// SetParameter is a paid mutator transaction binding the contract method 0x1c124e51.
+//
+// Solidity: function setParameter(string key, uint256 value) returns()
+func (_Parameters *ParametersTransactor) SetParameter(opts *bind.TransactOpts, key string, value *big.Int) (*types.Transaction, error) {
+	return _Parameters.contract.Transact(opts, "setParameter", key, value)
+}
+
+// SetParameter is a paid mutator transaction binding the contract method 0x1c124e51.
+//
+// Solidity: function setParameter(string key, uint256 value) returns()
+func (_Parameters *ParametersSession) SetParameter(key string, value *big.Int) (*types.Transaction, error) {
+	return _Parameters.Contract.SetParameter(&_Parameters.TransactOpts, key, value)
+}
+
+// SetParameter is a paid mutator transaction binding the contract method 0x1c124e51.
+//
+// Solidity: function setParameter(string key, uint256 value) returns()
+func (_Parameters *ParametersTransactorSession) SetParameter(key string, value *big.Int) (*types.Transaction, error) {
+	return _Parameters.Contract.SetParameter(&_Parameters.TransactOpts, key, value)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_Parameters *ParametersTransactor) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return _Parameters.contract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_Parameters *ParametersSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _Parameters.Contract.TransferOwnership(&_Parameters.TransactOpts, newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_Parameters *ParametersTransactorSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _Parameters.Contract.TransferOwnership(&_Parameters.TransactOpts, newOwner)
+}
+
+// ParametersInitializedIterator is returned from FilterInitialized and is used to iterate over the raw logs and unpacked data for Initialized events raised by the Parameters contract.
+type ParametersInitializedIterator struct {
+	Event *ParametersInitialized // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ParametersInitializedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ParametersInitialized)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ParametersInitialized)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ParametersInitializedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ParametersInitializedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ParametersInitialized represents a Initialized event raised by the Parameters contract.
+type ParametersInitialized struct {
+	Version uint8
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterInitialized is a free log retrieval operation binding the contract event 0x7f26b83ff96e1f2b6a682f133852f6798a09c465da95921460cefb3847402498.
+//
+// Solidity: event Initialized(uint8 version)
+func (_Parameters *ParametersFilterer) FilterInitialized(opts *bind.FilterOpts) (*ParametersInitializedIterator, error) {
+
+	logs, sub, err := _Parameters.contract.FilterLogs(opts, "Initialized")
+	if err != nil {
+		return nil, err
+	}
+	return &ParametersInitializedIterator{contract: _Parameters.contract, event: "Initialized", logs: logs, sub: sub}, nil
+}
+
+// WatchInitialized is a free log subscription operation binding the contract event 0x7f26b83ff96e1f2b6a682f133852f6798a09c465da95921460cefb3847402498.
+//
+// Solidity: event Initialized(uint8 version)
+func (_Parameters *ParametersFilterer) WatchInitialized(opts *bind.WatchOpts, sink chan<- *ParametersInitialized) (event.Subscription, error) {
+
+	logs, sub, err := _Parameters.contract.WatchLogs(opts, "Initialized")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ParametersInitialized)
+				if err := _Parameters.contract.UnpackLog(event, "Initialized", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseInitialized is a log parse operation binding the contract event 0x7f26b83ff96e1f2b6a682f133852f6798a09c465da95921460cefb3847402498.
+//
+// Solidity: event Initialized(uint8 version)
+func (_Parameters *ParametersFilterer) ParseInitialized(log types.Log) (*ParametersInitialized, error) {
+	event := new(ParametersInitialized)
+	if err := _Parameters.contract.UnpackLog(event, "Initialized", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ParametersOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the Parameters contract.
+type ParametersOwnershipTransferredIterator struct {
+	Event *ParametersOwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ParametersOwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ParametersOwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ParametersOwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ParametersOwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ParametersOwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ParametersOwnershipTransferred represents a OwnershipTransferred event raised by the Parameters contract.
+type ParametersOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_Parameters *ParametersFilterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*ParametersOwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _Parameters.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ParametersOwnershipTransferredIterator{contract: _Parameters.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_Parameters *ParametersFilterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *ParametersOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _Parameters.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ParametersOwnershipTransferred)
+				if err := _Parameters.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_Parameters *ParametersFilterer) ParseOwnershipTransferred(log types.Log) (*ParametersOwnershipTransferred, error) {
+	event := new(ParametersOwnershipTransferred)
+	if err := _Parameters.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ParametersParameterSetIterator is returned from FilterParameterSet and is used to iterate over the raw logs and unpacked data for ParameterSet events raised by the Parameters contract.
+type ParametersParameterSetIterator struct {
+	Event *ParametersParameterSet // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ParametersParameterSetIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ParametersParameterSet)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ParametersParameterSet)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ParametersParameterSetIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ParametersParameterSetIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ParametersParameterSet represents a ParameterSet event raised by the Parameters contract.
+type ParametersParameterSet struct {
+	Key   string
+	Value *big.Int
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterParameterSet is a free log retrieval operation binding the contract event 0xc6eeddce4b4af1253d6c284283a236f83c854ba2163447903920eeec1842903b.
+//
+// Solidity: event ParameterSet(string key, uint256 value)
+func (_Parameters *ParametersFilterer) FilterParameterSet(opts *bind.FilterOpts) (*ParametersParameterSetIterator, error) {
+
+	logs, sub, err := _Parameters.contract.FilterLogs(opts, "ParameterSet")
+	if err != nil {
+		return nil, err
+	}
+	return &ParametersParameterSetIterator{contract: _Parameters.contract, event: "ParameterSet", logs: logs, sub: sub}, nil
+}
+
+// WatchParameterSet is a free log subscription operation binding the contract event 0xc6eeddce4b4af1253d6c284283a236f83c854ba2163447903920eeec1842903b.
+//
+// Solidity: event ParameterSet(string key, uint256 value)
+func (_Parameters *ParametersFilterer) WatchParameterSet(opts *bind.WatchOpts, sink chan<- *ParametersParameterSet) (event.Subscription, error) {
+
+	logs, sub, err := _Parameters.contract.WatchLogs(opts, "ParameterSet")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ParametersParameterSet)
+				if err := _Parameters.contract.UnpackLog(event, "ParameterSet", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseParameterSet is a log parse operation binding the contract event 0xc6eeddce4b4af1253d6c284283a236f83c854ba2163447903920eeec1842903b.
+//
+// Solidity: event ParameterSet(string key, uint256 value)
+func (_Parameters *ParametersFilterer) ParseParameterSet(log types.Log) (*ParametersParameterSet, error) {
+	event := new(ParametersParameterSet)
+	if err := _Parameters.contract.UnpackLog(event, "ParameterSet", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,78 @@
+package web3
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// the chains a service works on as well as its main one
+type ChainsOptions struct {
+	// the built in networks to load e.g. testnet
+	Networks []string
+	// filled in from the networks when the options are processed
+	Chains []Web3Options `json:"-" toml:"-"`
+}
+
+// a client for each chain we work on keyed by chain ID
+// chain ID zero is whatever the main chain is so offers from
+// clients that do not say which chain they are on still work
+type ChainRegistry struct {
+	mutex        sync.RWMutex
+	defaultChain int
+	clients      map[int]Web3Client
+}
+
+func NewChainRegistry(defaultChain int, client Web3Client) *ChainRegistry {
+	return &ChainRegistry{
+		defaultChain: defaultChain,
+		clients: map[int]Web3Client{
+			defaultChain: client,
+		},
+	}
+}
+
+func (registry *ChainRegistry) Add(chainID int, client Web3Client) error {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	if chainID == 0 {
+		return fmt.Errorf("a chain must have an ID")
+	}
+	if _, ok := registry.clients[chainID]; ok {
+		return fmt.Errorf("chain %d has already been added", chainID)
+	}
+	registry.clients[chainID] = client
+	return nil
+}
+
+// the chain ID that zero means
+func (registry *ChainRegistry) Resolve(chainID int) int {
+	if chainID == 0 {
+		return registry.defaultChain
+	}
+	return chainID
+}
+
+func (registry *ChainRegistry) Get(chainID int) (Web3Client, error) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	client, ok := registry.clients[registry.Resolve(chainID)]
+	if !ok {
+		return nil, fmt.Errorf("chain %d is not supported", chainID)
+	}
+	return client, nil
+}
+
+// every chain with the main one first
+func (registry *ChainRegistry) ChainIDs() []int {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	ids := []int{}
+	for id := range registry.clients {
+		if id != registry.defaultChain {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	return append([]int{registry.defaultChain}, ids...)
+}
@@ -0,0 +1,26 @@
+package web3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChainRegistry(t *testing.T) {
+	registry := NewChainRegistry(421614, nil)
+	assert.NoError(t, registry.Add(1337, nil))
+	assert.NoError(t, registry.Add(42, nil))
+	assert.Error(t, registry.Add(1337, nil))
+	assert.Error(t, registry.Add(0, nil))
+
+	assert.Equal(t, []int{421614, 42, 1337}, registry.ChainIDs())
+	assert.Equal(t, 421614, registry.Resolve(0))
+	assert.Equal(t, 42, registry.Resolve(42))
+
+	_, err := registry.Get(0)
+	assert.NoError(t, err)
+	_, err = registry.Get(1337)
+	assert.NoError(t, err)
+	_, err = registry.Get(10)
+	assert.Error(t, err)
+}
@@ -4,10 +4,37 @@ import (
 	"context"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/rs/zerolog/log"
 )
 
+// a collection that the chain sync can hand logs to rather
+// than it subscribing to them itself
+type EventLogCollection interface {
+	EventChannelCollection
+	// the name of the contract whose logs it handles e.g. CONTRACT_STORAGE
+	Contract() string
+	// there is no point following a contract nobody is listening to
+	Subscribed() bool
+	// logs taken back by a reorg are handed over again with Removed set
+	HandleLog(sdk *Web3SDK, entry types.Log) error
+}
+
+// is the log the named event of the contract
+func isEvent(entry types.Log, metadata *bind.MetaData, name string) bool {
+	if len(entry.Topics) == 0 {
+		return false
+	}
+	parsed, err := metadata.GetAbi()
+	if err != nil {
+		return false
+	}
+	event, ok := parsed.Events[name]
+	return ok && entry.Topics[0] == event.ID
+}
+
 type EventChannels struct {
 	Token       *TokenEventChannels
 	Payment     *PaymentEventChannels
@@ -15,6 +42,7 @@ type EventChannels struct {
 	JobCreator  *JobCreatorEventChannels
 	Mediation   *MediationEventChannels
 	Pow         *PowEventChannels
+	Parameters  *ParametersEventChannels
 	collections []EventChannelCollection
 }
 
@@ -25,6 +53,7 @@ func NewEventChannels() *EventChannels {
 	jobCreatorChannels := NewJobCreatorEventChannels()
 	mediationChannels := NewMediationEventChannels()
 	powChannels := NewPowEventChannels()
+	parametersChannels := NewParametersEventChannels()
 	collections := []EventChannelCollection{
 		tokenChannels,
 		paymentChannels,
@@ -32,6 +61,7 @@ func NewEventChannels() *EventChannels {
 		jobCreatorChannels,
 		mediationChannels,
 		powChannels,
+		parametersChannels,
 	}
 	return &EventChannels{
 		Token:       tokenChannels,
@@ -40,6 +70,7 @@ func NewEventChannels() *EventChannels {
 		JobCreator:  jobCreatorChannels,
 		Mediation:   mediationChannels,
 		Pow:         powChannels,
+		Parameters:  parametersChannels,
 		collections: collections,
 	}
 }
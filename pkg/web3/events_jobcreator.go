@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/jobcreator"
@@ -28,7 +29,7 @@ func (s *JobCreatorEventChannels) Start(
 	ctx context.Context,
 	cm *system.CleanupManager,
 ) error {
-	blockNumber, err := sdk.getBlockNumber()
+	blockNumber, err := sdk.GetBlockNumber()
 	if err != nil {
 		return err
 	}
@@ -71,3 +72,29 @@ func (s *JobCreatorEventChannels) Start(
 func (t *JobCreatorEventChannels) SubscribeJobAdded(handler func(jobcreator.JobcreatorJobAdded)) {
 	t.jobAddedSubs = append(t.jobAddedSubs, handler)
 }
+
+func (s *JobCreatorEventChannels) Contract() string {
+	return CONTRACT_JOBCREATOR
+}
+
+func (s *JobCreatorEventChannels) Subscribed() bool {
+	return len(s.jobAddedSubs) > 0
+}
+
+func (s *JobCreatorEventChannels) HandleLog(sdk *Web3SDK, entry types.Log) error {
+	if !isEvent(entry, jobcreator.JobcreatorMetaData, "JobAdded") {
+		return nil
+	}
+	event, err := sdk.Contracts.JobCreator.ParseJobAdded(entry)
+	if err != nil {
+		return err
+	}
+	log.Debug().
+		Str("jobcreator->log", "JobAdded").
+		Bool("removed", entry.Removed).
+		Msgf("%+v", event)
+	for _, handler := range s.jobAddedSubs {
+		handler(*event)
+	}
+	return nil
+}
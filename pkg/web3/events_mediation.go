@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/mediation"
@@ -28,7 +29,7 @@ func (m *MediationEventChannels) Start(
 	ctx context.Context,
 	cm *system.CleanupManager,
 ) error {
-	blockNumber, err := sdk.getBlockNumber()
+	blockNumber, err := sdk.GetBlockNumber()
 	if err != nil {
 		return err
 	}
@@ -71,3 +72,29 @@ func (m *MediationEventChannels) Start(
 func (m *MediationEventChannels) SubscribeMediationRequested(handler func(mediation.MediationMediationRequested)) {
 	m.mediationRequestedSubs = append(m.mediationRequestedSubs, handler)
 }
+
+func (m *MediationEventChannels) Contract() string {
+	return CONTRACT_MEDIATION
+}
+
+func (m *MediationEventChannels) Subscribed() bool {
+	return len(m.mediationRequestedSubs) > 0
+}
+
+func (m *MediationEventChannels) HandleLog(sdk *Web3SDK, entry types.Log) error {
+	if !isEvent(entry, mediation.MediationMetaData, "MediationRequested") {
+		return nil
+	}
+	event, err := sdk.Contracts.Mediation.ParseMediationRequested(entry)
+	if err != nil {
+		return err
+	}
+	log.Debug().
+		Str("mediation->log", "MediationRequested").
+		Bool("removed", entry.Removed).
+		Msgf("%+v", event)
+	for _, handler := range m.mediationRequestedSubs {
+		handler(*event)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package web3
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/parameters"
+	"github.com/rs/zerolog/log"
+)
+
+type ParametersEventChannels struct {
+	parameterSetChan chan *parameters.ParametersParameterSet
+	parameterSetSubs []func(parameters.ParametersParameterSet)
+}
+
+func NewParametersEventChannels() *ParametersEventChannels {
+	return &ParametersEventChannels{
+		parameterSetChan: make(chan *parameters.ParametersParameterSet),
+		parameterSetSubs: []func(parameters.ParametersParameterSet){},
+	}
+}
+
+func (p *ParametersEventChannels) Start(
+	sdk *Web3SDK,
+	ctx context.Context,
+	cm *system.CleanupManager,
+) error {
+	// the parameter registry is optional
+	// without it we just wait for the context to finish
+	if sdk.Contracts.Parameters == nil {
+		<-ctx.Done()
+		return fmt.Errorf("cancel by context")
+	}
+
+	blockNumber, err := sdk.GetBlockNumber()
+	if err != nil {
+		return err
+	}
+
+	var parameterSetSub event.Subscription
+
+	connectParameterSetSub := func() (event.Subscription, error) {
+		log.Debug().
+			Str("parameters->connect", "ParameterSet").
+			Msgf("")
+		return sdk.Contracts.Parameters.WatchParameterSet(
+			&bind.WatchOpts{Start: &blockNumber, Context: ctx},
+			p.parameterSetChan,
+		)
+	}
+
+	parameterSetSub, err = connectParameterSetSub()
+	if err != nil {
+		return err
+	}
+	cm.RegisterCallback(unsubscribeSub(parameterSetSub))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("cancel by context")
+		case event := <-p.parameterSetChan:
+			log.Debug().
+				Str("parameters->event", "ParameterSet").
+				Msgf("%+v", event)
+			for _, handler := range p.parameterSetSubs {
+				go handler(*event)
+			}
+		case err := <-parameterSetSub.Err():
+			return fmt.Errorf("cancel by parameters ParameterSet event subscribe error %w", err)
+		}
+	}
+}
+
+func (p *ParametersEventChannels) SubscribeParameterSet(handler func(parameters.ParametersParameterSet)) {
+	p.parameterSetSubs = append(p.parameterSetSubs, handler)
+}
+
+func (p *ParametersEventChannels) Contract() string {
+	return CONTRACT_PARAMETERS
+}
+
+func (p *ParametersEventChannels) Subscribed() bool {
+	return len(p.parameterSetSubs) > 0
+}
+
+func (p *ParametersEventChannels) HandleLog(sdk *Web3SDK, entry types.Log) error {
+	if !isEvent(entry, parameters.ParametersMetaData, "ParameterSet") {
+		return nil
+	}
+	event, err := sdk.Contracts.Parameters.ParseParameterSet(entry)
+	if err != nil {
+		return err
+	}
+	log.Debug().
+		Str("parameters->log", "ParameterSet").
+		Bool("removed", entry.Removed).
+		Msgf("%+v", event)
+	for _, handler := range p.parameterSetSubs {
+		handler(*event)
+	}
+	return nil
+}
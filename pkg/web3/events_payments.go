@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/payments"
@@ -28,7 +29,7 @@ func (p *PaymentEventChannels) Start(
 	ctx context.Context,
 	cm *system.CleanupManager,
 ) error {
-	blockNumber, err := sdk.getBlockNumber()
+	blockNumber, err := sdk.GetBlockNumber()
 	if err != nil {
 		return err
 	}
@@ -71,3 +72,29 @@ func (p *PaymentEventChannels) Start(
 func (p *PaymentEventChannels) SubscribePayment(handler func(payments.PaymentsPayment)) {
 	p.paymentSubs = append(p.paymentSubs, handler)
 }
+
+func (p *PaymentEventChannels) Contract() string {
+	return CONTRACT_PAYMENTS
+}
+
+func (p *PaymentEventChannels) Subscribed() bool {
+	return len(p.paymentSubs) > 0
+}
+
+func (p *PaymentEventChannels) HandleLog(sdk *Web3SDK, entry types.Log) error {
+	if !isEvent(entry, payments.PaymentsMetaData, "Payment") {
+		return nil
+	}
+	event, err := sdk.Contracts.Payments.ParsePayment(entry)
+	if err != nil {
+		return err
+	}
+	log.Debug().
+		Str("payments->log", "Payment").
+		Bool("removed", entry.Removed).
+		Msgf("%+v", event)
+	for _, handler := range p.paymentSubs {
+		handler(*event)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/pow"
@@ -28,7 +29,7 @@ func (s *PowEventChannels) Start(
 	ctx context.Context,
 	cm *system.CleanupManager,
 ) error {
-	blockNumber, err := sdk.getBlockNumber()
+	blockNumber, err := sdk.GetBlockNumber()
 	if err != nil {
 		return err
 	}
@@ -73,3 +74,29 @@ func (s *PowEventChannels) Start(
 func (t *PowEventChannels) SubscribenewPowRound(handler func(pow.PowNewPowRound)) {
 	t.newPowRoundSubs = append(t.newPowRoundSubs, handler)
 }
+
+func (s *PowEventChannels) Contract() string {
+	return CONTRACT_POW
+}
+
+func (s *PowEventChannels) Subscribed() bool {
+	return len(s.newPowRoundSubs) > 0
+}
+
+func (s *PowEventChannels) HandleLog(sdk *Web3SDK, entry types.Log) error {
+	if !isEvent(entry, pow.PowMetaData, "NewPowRound") {
+		return nil
+	}
+	event, err := sdk.Contracts.Pow.ParseNewPowRound(entry)
+	if err != nil {
+		return err
+	}
+	log.Debug().
+		Str("pow->log", "NewPowRound").
+		Bool("removed", entry.Removed).
+		Msgf("%+v", event)
+	for _, handler := range s.newPowRoundSubs {
+		handler(*event)
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/storage"
@@ -28,7 +29,7 @@ func (s *StorageEventChannels) Start(
 	ctx context.Context,
 	cm *system.CleanupManager,
 ) error {
-	blockNumber, err := sdk.getBlockNumber()
+	blockNumber, err := sdk.GetBlockNumber()
 	if err != nil {
 		return err
 	}
@@ -71,3 +72,29 @@ func (s *StorageEventChannels) Start(
 func (t *StorageEventChannels) SubscribeDealStateChange(handler func(storage.StorageDealStateChange)) {
 	t.dealStateChangeSubs = append(t.dealStateChangeSubs, handler)
 }
+
+func (s *StorageEventChannels) Contract() string {
+	return CONTRACT_STORAGE
+}
+
+func (s *StorageEventChannels) Subscribed() bool {
+	return len(s.dealStateChangeSubs) > 0
+}
+
+func (s *StorageEventChannels) HandleLog(sdk *Web3SDK, entry types.Log) error {
+	if !isEvent(entry, storage.StorageMetaData, "DealStateChange") {
+		return nil
+	}
+	event, err := sdk.Contracts.Storage.ParseDealStateChange(entry)
+	if err != nil {
+		return err
+	}
+	log.Debug().
+		Str("storage->log", "DealStateChange").
+		Bool("removed", entry.Removed).
+		Msgf("%+v", event)
+	for _, handler := range s.dealStateChangeSubs {
+		handler(*event)
+	}
+	return nil
+}
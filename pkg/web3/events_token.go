@@ -6,6 +6,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/token"
@@ -29,7 +30,7 @@ func (t *TokenEventChannels) Start(
 	ctx context.Context,
 	cm *system.CleanupManager,
 ) error {
-	blockNumber, err := sdk.getBlockNumber()
+	blockNumber, err := sdk.GetBlockNumber()
 	if err != nil {
 		return err
 	}
@@ -74,3 +75,29 @@ func (t *TokenEventChannels) Start(
 func (t *TokenEventChannels) SubscribeTransfer(handler func(token.TokenTransfer)) {
 	t.transferSubs = append(t.transferSubs, handler)
 }
+
+func (t *TokenEventChannels) Contract() string {
+	return CONTRACT_TOKEN
+}
+
+func (t *TokenEventChannels) Subscribed() bool {
+	return len(t.transferSubs) > 0
+}
+
+func (t *TokenEventChannels) HandleLog(sdk *Web3SDK, entry types.Log) error {
+	if !isEvent(entry, token.TokenMetaData, "Transfer") {
+		return nil
+	}
+	event, err := sdk.Contracts.Token.ParseTransfer(entry)
+	if err != nil {
+		return err
+	}
+	log.Debug().
+		Str("token->log", "Transfer").
+		Bool("removed", entry.Removed).
+		Msgf("%+v", event)
+	for _, handler := range t.transferSubs {
+		handler(*event)
+	}
+	return nil
+}
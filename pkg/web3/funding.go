@@ -0,0 +1,65 @@
+package web3
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// the most blocks asked for in one go, RPC providers limit log queries
+const FUNDING_QUERY_BLOCK_RANGE = 10000
+
+// the addresses that sent an address LP tokens in the last lookback blocks
+// contracts are left out as the protocol contracts pay everyone
+func (sdk *Web3SDK) GetLPTokenSenders(address string, lookback uint64) ([]string, error) {
+	ctx := context.Background()
+	latest, err := sdk.GetBlockNumber()
+	if err != nil {
+		return nil, err
+	}
+	start := uint64(0)
+	if latest > lookback {
+		start = latest - lookback
+	}
+	to := []common.Address{common.HexToAddress(address)}
+	seen := map[common.Address]bool{}
+	senders := []string{}
+	for from := start; from <= latest; from += FUNDING_QUERY_BLOCK_RANGE {
+		end := from + FUNDING_QUERY_BLOCK_RANGE - 1
+		if end > latest {
+			end = latest
+		}
+		iterator, err := sdk.Contracts.Token.FilterTransfer(&bind.FilterOpts{Start: from, End: &end, Context: ctx}, nil, to)
+		if err != nil {
+			return nil, err
+		}
+		for iterator.Next() {
+			sender := iterator.Event.From
+			if seen[sender] {
+				continue
+			}
+			seen[sender] = true
+			// minting comes from the zero address
+			if sender == (common.Address{}) {
+				continue
+			}
+			code, err := sdk.Client.CodeAt(ctx, sender, nil)
+			if err != nil {
+				iterator.Close()
+				return nil, err
+			}
+			if len(code) > 0 {
+				continue
+			}
+			senders = append(senders, strings.ToLower(sender.Hex()))
+		}
+		err = iterator.Error()
+		iterator.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return senders, nil
+}
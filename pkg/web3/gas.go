@@ -0,0 +1,208 @@
+package web3
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+)
+
+const (
+	// use EIP-1559 fees when the chain supports them and legacy ones otherwise
+	GAS_STRATEGY_AUTO = "auto"
+	// always send a legacy gas price
+	GAS_STRATEGY_LEGACY = "legacy"
+	// always send a fee cap and priority fee
+	GAS_STRATEGY_EIP1559 = "eip1559"
+
+	// nodes refuse to replace a transaction for less than a 10% bump
+	MIN_GAS_BUMP_PERCENT = 10
+
+	DEFAULT_GAS_BUMP_PERCENT  = 20
+	DEFAULT_GAS_STUCK_TIMEOUT = 60
+	DEFAULT_GAS_MAX_BUMPS     = 5
+)
+
+var GAS_STRATEGIES = []string{GAS_STRATEGY_AUTO, GAS_STRATEGY_LEGACY, GAS_STRATEGY_EIP1559}
+
+// the fees to send a transaction with
+// either GasPrice is set or GasFeeCap and GasTipCap are
+type GasFees struct {
+	GasPrice  *big.Int
+	GasFeeCap *big.Int
+	GasTipCap *big.Int
+}
+
+func (fees GasFees) IsDynamic() bool {
+	return fees.GasPrice == nil
+}
+
+func capFee(fee *big.Int, maxFee uint64) *big.Int {
+	if maxFee > 0 && fee.Cmp(new(big.Int).SetUint64(maxFee)) > 0 {
+		return new(big.Int).SetUint64(maxFee)
+	}
+	return fee
+}
+
+// work out the fees for a new transaction from what the node suggests
+// baseFee is nil on chains that do not support EIP-1559
+func GetGasFees(options Web3Options, baseFee *big.Int, suggestedTip *big.Int, suggestedPrice *big.Int) (GasFees, error) {
+	strategy := options.GasStrategy
+	if strategy == "" || strategy == GAS_STRATEGY_AUTO {
+		strategy = GAS_STRATEGY_EIP1559
+		if baseFee == nil {
+			strategy = GAS_STRATEGY_LEGACY
+		}
+	}
+	switch strategy {
+	case GAS_STRATEGY_LEGACY:
+		return GasFees{GasPrice: capFee(suggestedPrice, options.GasMaxFeeCap)}, nil
+	case GAS_STRATEGY_EIP1559:
+		if baseFee == nil {
+			return GasFees{}, fmt.Errorf("chain %d does not support EIP-1559 fees", options.ChainID)
+		}
+		tip := suggestedTip
+		if options.GasPriorityFee > 0 {
+			tip = new(big.Int).SetUint64(options.GasPriorityFee)
+		}
+		// leave room for the base fee to double before we are priced out
+		feeCap := new(big.Int).Add(tip, new(big.Int).Mul(baseFee, big.NewInt(2)))
+		feeCap = capFee(feeCap, options.GasMaxFeeCap)
+		if tip.Cmp(feeCap) > 0 {
+			tip = feeCap
+		}
+		return GasFees{GasFeeCap: feeCap, GasTipCap: tip}, nil
+	default:
+		return GasFees{}, fmt.Errorf("unknown gas strategy %s", strategy)
+	}
+}
+
+func bumpFee(fee *big.Int, percent int) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(int64(100+percent)))
+	bumped.Div(bumped, big.NewInt(100))
+	// tiny fees would otherwise round down to the same value
+	if bumped.Cmp(fee) <= 0 {
+		bumped.Add(fee, big.NewInt(1))
+	}
+	return bumped
+}
+
+func maxFee(a *big.Int, b *big.Int) *big.Int {
+	if b != nil && b.Cmp(a) > 0 {
+		return b
+	}
+	return a
+}
+
+// the fees to replace a stuck transaction with, we bump what it was sent
+// with or use the current fees if they have gone up by more than that
+// an error means we cannot bump it without going over the cap
+func GetBumpedGasFees(options Web3Options, sent GasFees, current GasFees) (GasFees, error) {
+	percent := options.GasBumpPercent
+	if percent < MIN_GAS_BUMP_PERCENT {
+		percent = MIN_GAS_BUMP_PERCENT
+	}
+	var maxCap *big.Int
+	if options.GasMaxFeeCap > 0 {
+		maxCap = new(big.Int).SetUint64(options.GasMaxFeeCap)
+	}
+	if !sent.IsDynamic() {
+		price := maxFee(bumpFee(sent.GasPrice, percent), current.GasPrice)
+		if maxCap != nil && price.Cmp(maxCap) > 0 {
+			return GasFees{}, fmt.Errorf("bumping the gas price to %s would go over the cap of %s", price, maxCap)
+		}
+		return GasFees{GasPrice: price}, nil
+	}
+	tip := maxFee(bumpFee(sent.GasTipCap, percent), current.GasTipCap)
+	feeCap := maxFee(bumpFee(sent.GasFeeCap, percent), current.GasFeeCap)
+	if maxCap != nil && feeCap.Cmp(maxCap) > 0 {
+		return GasFees{}, fmt.Errorf("bumping the fee cap to %s would go over the cap of %s", feeCap, maxCap)
+	}
+	if tip.Cmp(feeCap) > 0 {
+		feeCap = tip
+	}
+	return GasFees{GasFeeCap: feeCap, GasTipCap: tip}, nil
+}
+
+// ask the node what the fees for a new transaction should be
+func (sdk *Web3SDK) getGasFees(ctx context.Context) (GasFees, error) {
+	head, err := sdk.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return GasFees{}, err
+	}
+	var suggestedTip, suggestedPrice *big.Int
+	if head.BaseFee != nil && sdk.Options.GasStrategy != GAS_STRATEGY_LEGACY {
+		suggestedTip, err = sdk.Client.SuggestGasTipCap(ctx)
+	} else {
+		suggestedPrice, err = sdk.Client.SuggestGasPrice(ctx)
+	}
+	if err != nil {
+		return GasFees{}, err
+	}
+	return GetGasFees(sdk.Options, head.BaseFee, suggestedTip, suggestedPrice)
+}
+
+// the options to send a transaction with priced by the gas strategy
+// if the node cannot tell us the fees we leave it to the bindings
+func (sdk *Web3SDK) GetTransactOpts() *bind.TransactOpts {
+	opts := *sdk.TransactOpts
+	fees, err := sdk.getGasFees(context.Background())
+	if err != nil {
+		system.Error(sdk.Options.Service, "error getting gas fees", err)
+		return &opts
+	}
+	opts.GasPrice = fees.GasPrice
+	opts.GasFeeCap = fees.GasFeeCap
+	opts.GasTipCap = fees.GasTipCap
+	return &opts
+}
+
+// sign and send the same transaction again with higher fees
+func (sdk *Web3SDK) replaceTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	sent := GasFees{GasFeeCap: tx.GasFeeCap(), GasTipCap: tx.GasTipCap()}
+	if tx.Type() == types.LegacyTxType {
+		sent = GasFees{GasPrice: tx.GasPrice()}
+	}
+	current, err := sdk.getGasFees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fees, err := GetBumpedGasFees(sdk.Options, sent, current)
+	if err != nil {
+		return nil, err
+	}
+	var replacement *types.Transaction
+	if fees.IsDynamic() {
+		replacement = types.NewTx(&types.DynamicFeeTx{
+			ChainID:   tx.ChainId(),
+			Nonce:     tx.Nonce(),
+			GasTipCap: fees.GasTipCap,
+			GasFeeCap: fees.GasFeeCap,
+			Gas:       tx.Gas(),
+			To:        tx.To(),
+			Value:     tx.Value(),
+			Data:      tx.Data(),
+		})
+	} else {
+		replacement = types.NewTx(&types.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: fees.GasPrice,
+			Gas:      tx.Gas(),
+			To:       tx.To(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		})
+	}
+	signed, err := sdk.TransactOpts.Signer(sdk.TransactOpts.From, replacement)
+	if err != nil {
+		return nil, err
+	}
+	err = sdk.Client.SendTransaction(ctx, signed)
+	if err != nil {
+		return nil, err
+	}
+	return signed, nil
+}
@@ -0,0 +1,64 @@
+package web3
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetGasFees(t *testing.T) {
+	baseFee := big.NewInt(100)
+	tip := big.NewInt(10)
+	price := big.NewInt(150)
+
+	testCases := []struct {
+		name     string
+		options  Web3Options
+		baseFee  *big.Int
+		expected GasFees
+		err      bool
+	}{
+		{name: "Auto uses EIP-1559 when there is a base fee", options: Web3Options{GasStrategy: GAS_STRATEGY_AUTO}, baseFee: baseFee, expected: GasFees{GasFeeCap: big.NewInt(210), GasTipCap: big.NewInt(10)}},
+		{name: "Auto falls back to legacy", options: Web3Options{GasStrategy: GAS_STRATEGY_AUTO}, baseFee: nil, expected: GasFees{GasPrice: big.NewInt(150)}},
+		{name: "Legacy", options: Web3Options{GasStrategy: GAS_STRATEGY_LEGACY}, baseFee: baseFee, expected: GasFees{GasPrice: big.NewInt(150)}},
+		{name: "Legacy with a cap", options: Web3Options{GasStrategy: GAS_STRATEGY_LEGACY, GasMaxFeeCap: 120}, baseFee: baseFee, expected: GasFees{GasPrice: big.NewInt(120)}},
+		{name: "Fixed priority fee", options: Web3Options{GasStrategy: GAS_STRATEGY_EIP1559, GasPriorityFee: 50}, baseFee: baseFee, expected: GasFees{GasFeeCap: big.NewInt(250), GasTipCap: big.NewInt(50)}},
+		{name: "Capped fee cap", options: Web3Options{GasStrategy: GAS_STRATEGY_EIP1559, GasPriorityFee: 50, GasMaxFeeCap: 40}, baseFee: baseFee, expected: GasFees{GasFeeCap: big.NewInt(40), GasTipCap: big.NewInt(40)}},
+		{name: "EIP-1559 without a base fee", options: Web3Options{GasStrategy: GAS_STRATEGY_EIP1559}, baseFee: nil, err: true},
+		{name: "Unknown strategy", options: Web3Options{GasStrategy: "cheap"}, baseFee: baseFee, err: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			fees, err := GetGasFees(tc.options, tc.baseFee, tip, price)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, fees)
+		})
+	}
+}
+
+func TestGetBumpedGasFees(t *testing.T) {
+	options := Web3Options{GasBumpPercent: 20}
+
+	fees, err := GetBumpedGasFees(options, GasFees{GasPrice: big.NewInt(100)}, GasFees{GasPrice: big.NewInt(90)})
+	assert.NoError(t, err)
+	assert.Equal(t, GasFees{GasPrice: big.NewInt(120)}, fees)
+
+	// the current fees win if they have gone up by more than the bump
+	fees, err = GetBumpedGasFees(options, GasFees{GasFeeCap: big.NewInt(100), GasTipCap: big.NewInt(10)}, GasFees{GasFeeCap: big.NewInt(300), GasTipCap: big.NewInt(5)})
+	assert.NoError(t, err)
+	assert.Equal(t, GasFees{GasFeeCap: big.NewInt(300), GasTipCap: big.NewInt(12)}, fees)
+
+	// a bump below what nodes accept is raised to the minimum
+	fees, err = GetBumpedGasFees(Web3Options{GasBumpPercent: 1}, GasFees{GasPrice: big.NewInt(100)}, GasFees{})
+	assert.NoError(t, err)
+	assert.Equal(t, GasFees{GasPrice: big.NewInt(110)}, fees)
+
+	_, err = GetBumpedGasFees(Web3Options{GasBumpPercent: 20, GasMaxFeeCap: 110}, GasFees{GasFeeCap: big.NewInt(100), GasTipCap: big.NewInt(10)}, GasFees{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package web3
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+// only the solver of the deal can pay a milestone and only while the
+// job is running, the amount comes out of the job creator's payment
+// collateral so the job creator has to have signed the milestone
+func (sdk *Web3SDK) PayMilestone(
+	dealId string,
+	index uint64,
+	amount *big.Int,
+	jobCreatorSignature string,
+) (string, error) {
+	signature, err := hexutil.Decode(jobCreatorSignature)
+	if err != nil {
+		return "", fmt.Errorf("invalid milestone signature: %w", err)
+	}
+	receipt, err := sdk.Transact(context.Background(), "controller.PayMilestone", dealId, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return sdk.Contracts.Controller.PayMilestone(opts, dealId, new(big.Int).SetUint64(index), amount, signature)
+	})
+	if err != nil {
+		return "", err
+	}
+	return receipt.TxHash.String(), nil
+}
+
+// the hash the job creator signs to release a milestone, the same as
+// controller.getMilestoneHash so the signature is only good for this
+// milestone of this deal on the chain and controller of the sdk
+func (sdk *Web3SDK) GetMilestoneHash(milestone data.DealMilestone) ([]byte, error) {
+	return GetMilestoneHash(
+		big.NewInt(int64(sdk.Options.ChainID)),
+		sdk.Contracts.Addresses[CONTRACT_CONTROLLER],
+		milestone,
+	)
+}
+
+func GetMilestoneHash(chainID *big.Int, controller common.Address, milestone data.DealMilestone) ([]byte, error) {
+	amount, ok := new(big.Int).SetString(milestone.Amount, 10) //nolint:gomnd
+	if !ok {
+		return nil, fmt.Errorf("milestone amount %q is not a number", milestone.Amount)
+	}
+	uint256Type, _ := abi.NewType("uint256", "", nil)
+	addressType, _ := abi.NewType("address", "", nil)
+	stringType, _ := abi.NewType("string", "", nil)
+	arguments := abi.Arguments{
+		{Type: uint256Type},
+		{Type: addressType},
+		{Type: stringType},
+		{Type: uint256Type},
+		{Type: uint256Type},
+	}
+	encoded, err := arguments.Pack(chainID, controller, milestone.DealID, new(big.Int).SetUint64(milestone.Index), amount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode milestone: %w", err)
+	}
+	return crypto.Keccak256(encoded), nil
+}
+
+// the controller checks the signature against the eth_sign form of the hash
+func SignMilestoneHash(signer Signer, hash []byte) (string, error) {
+	sig, err := signer.SignHash(accounts.TextHash(hash))
+	if err != nil {
+		return "", err
+	}
+	// the contract expects the recovery ID as 27 or 28
+	sig[crypto.RecoveryIDOffset] += 27
+	return hexutil.Encode(sig), nil
+}
+
+// the address that signed the milestone hash, compare this to the job creator
+func RecoverMilestoneSigner(hash []byte, signature string) (common.Address, error) {
+	sig, err := hexutil.Decode(signature)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid milestone signature: %w", err)
+	}
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("milestone signature must be %d bytes", crypto.SignatureLength)
+	}
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	publicKey, err := crypto.SigToPub(accounts.TextHash(hash), sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
@@ -0,0 +1,58 @@
+package web3
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignMilestoneHash(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate private key: %v", err)
+	}
+	chainID := big.NewInt(1337)
+	controller := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	milestone := data.DealMilestone{
+		DealID: "deal",
+		Index:  1,
+		Amount: "1000000000000000000",
+	}
+
+	hash, err := GetMilestoneHash(chainID, controller, milestone)
+	assert.NoError(t, err)
+	signature, err := SignMilestoneHash(&PrivateKeySigner{privateKey: privateKey}, hash)
+	assert.NoError(t, err)
+
+	signer, err := RecoverMilestoneSigner(hash, signature)
+	assert.NoError(t, err)
+	assert.Equal(t, GetAddress(privateKey), signer)
+
+	// the signature is only good for the same milestone on the same chain and controller
+	tampered := milestone
+	tampered.Index = 2
+	assertOtherSigner(t, chainID, controller, tampered, signature, signer)
+	tampered = milestone
+	tampered.Amount = "2000000000000000000"
+	assertOtherSigner(t, chainID, controller, tampered, signature, signer)
+	assertOtherSigner(t, big.NewInt(1), controller, milestone, signature, signer)
+	assertOtherSigner(t, chainID, common.HexToAddress("0x2222222222222222222222222222222222222222"), milestone, signature, signer)
+
+	_, err = RecoverMilestoneSigner(hash, "0x1234")
+	assert.Error(t, err)
+	_, err = GetMilestoneHash(chainID, controller, data.DealMilestone{DealID: "deal", Amount: "lots"})
+	assert.Error(t, err)
+}
+
+func assertOtherSigner(t *testing.T, chainID *big.Int, controller common.Address, milestone data.DealMilestone, signature string, signer common.Address) {
+	hash, err := GetMilestoneHash(chainID, controller, milestone)
+	assert.NoError(t, err)
+	recovered, err := RecoverMilestoneSigner(hash, signature)
+	if err == nil {
+		assert.NotEqual(t, signer, recovered)
+	}
+}
@@ -0,0 +1,552 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: types.go
+//
+// Generated by this command:
+//
+//	mockgen -source=types.go -destination=mock_web3/mock_web3.go -package=mock_web3
+//
+
+// Package mock_web3 is a generated GoMock package.
+package mock_web3
+
+import (
+	context "context"
+	big "math/big"
+	reflect "reflect"
+
+	common "github.com/ethereum/go-ethereum/common"
+	data "github.com/lilypad-tech/lilypad/pkg/data"
+	system "github.com/lilypad-tech/lilypad/pkg/system"
+	web3 "github.com/lilypad-tech/lilypad/pkg/web3"
+	pow "github.com/lilypad-tech/lilypad/pkg/web3/bindings/pow"
+	users "github.com/lilypad-tech/lilypad/pkg/web3/bindings/users"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEventChannelCollection is a mock of EventChannelCollection interface.
+type MockEventChannelCollection struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventChannelCollectionMockRecorder
+}
+
+// MockEventChannelCollectionMockRecorder is the mock recorder for MockEventChannelCollection.
+type MockEventChannelCollectionMockRecorder struct {
+	mock *MockEventChannelCollection
+}
+
+// NewMockEventChannelCollection creates a new mock instance.
+func NewMockEventChannelCollection(ctrl *gomock.Controller) *MockEventChannelCollection {
+	mock := &MockEventChannelCollection{ctrl: ctrl}
+	mock.recorder = &MockEventChannelCollectionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventChannelCollection) EXPECT() *MockEventChannelCollectionMockRecorder {
+	return m.recorder
+}
+
+// Start mocks base method.
+func (m *MockEventChannelCollection) Start(sdk *web3.Web3SDK, ctx context.Context, cm *system.CleanupManager) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", sdk, ctx, cm)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockEventChannelCollectionMockRecorder) Start(sdk, ctx, cm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockEventChannelCollection)(nil).Start), sdk, ctx, cm)
+}
+
+// MockWeb3Client is a mock of Web3Client interface.
+type MockWeb3Client struct {
+	ctrl     *gomock.Controller
+	recorder *MockWeb3ClientMockRecorder
+}
+
+// MockWeb3ClientMockRecorder is the mock recorder for MockWeb3Client.
+type MockWeb3ClientMockRecorder struct {
+	mock *MockWeb3Client
+}
+
+// NewMockWeb3Client creates a new mock instance.
+func NewMockWeb3Client(ctrl *gomock.Controller) *MockWeb3Client {
+	mock := &MockWeb3Client{ctrl: ctrl}
+	mock.recorder = &MockWeb3ClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWeb3Client) EXPECT() *MockWeb3ClientMockRecorder {
+	return m.recorder
+}
+
+// AcceptResult mocks base method.
+func (m *MockWeb3Client) AcceptResult(dealId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptResult", dealId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptResult indicates an expected call of AcceptResult.
+func (mr *MockWeb3ClientMockRecorder) AcceptResult(dealId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptResult", reflect.TypeOf((*MockWeb3Client)(nil).AcceptResult), dealId)
+}
+
+// AddResult mocks base method.
+func (m *MockWeb3Client) AddResult(dealId, resultsId, dataId string, instructionCount uint64) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddResult", dealId, resultsId, dataId, instructionCount)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddResult indicates an expected call of AddResult.
+func (mr *MockWeb3ClientMockRecorder) AddResult(dealId, resultsId, dataId, instructionCount any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddResult", reflect.TypeOf((*MockWeb3Client)(nil).AddResult), dealId, resultsId, dataId, instructionCount)
+}
+
+// AddUserToList mocks base method.
+func (m *MockWeb3Client) AddUserToList(serviceType uint8) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddUserToList", serviceType)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddUserToList indicates an expected call of AddUserToList.
+func (mr *MockWeb3ClientMockRecorder) AddUserToList(serviceType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserToList", reflect.TypeOf((*MockWeb3Client)(nil).AddUserToList), serviceType)
+}
+
+// Agree mocks base method.
+func (m *MockWeb3Client) Agree(deal data.Deal) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Agree", deal)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Agree indicates an expected call of Agree.
+func (mr *MockWeb3ClientMockRecorder) Agree(deal any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Agree", reflect.TypeOf((*MockWeb3Client)(nil).Agree), deal)
+}
+
+// CheckPaymentToken mocks base method.
+func (m *MockWeb3Client) CheckPaymentToken(token data.PaymentToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckPaymentToken", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckPaymentToken indicates an expected call of CheckPaymentToken.
+func (mr *MockWeb3ClientMockRecorder) CheckPaymentToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPaymentToken", reflect.TypeOf((*MockWeb3Client)(nil).CheckPaymentToken), token)
+}
+
+// CheckResult mocks base method.
+func (m *MockWeb3Client) CheckResult(dealId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckResult", dealId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckResult indicates an expected call of CheckResult.
+func (mr *MockWeb3ClientMockRecorder) CheckResult(dealId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckResult", reflect.TypeOf((*MockWeb3Client)(nil).CheckResult), dealId)
+}
+
+// GetAddress mocks base method.
+func (m *MockWeb3Client) GetAddress() common.Address {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAddress")
+	ret0, _ := ret[0].(common.Address)
+	return ret0
+}
+
+// GetAddress indicates an expected call of GetAddress.
+func (mr *MockWeb3ClientMockRecorder) GetAddress() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAddress", reflect.TypeOf((*MockWeb3Client)(nil).GetAddress))
+}
+
+// GetBalance mocks base method.
+func (m *MockWeb3Client) GetBalance(address string) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalance", address)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalance indicates an expected call of GetBalance.
+func (mr *MockWeb3ClientMockRecorder) GetBalance(address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockWeb3Client)(nil).GetBalance), address)
+}
+
+// GetBlockNumber mocks base method.
+func (m *MockWeb3Client) GetBlockNumber() (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlockNumber")
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlockNumber indicates an expected call of GetBlockNumber.
+func (mr *MockWeb3ClientMockRecorder) GetBlockNumber() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlockNumber", reflect.TypeOf((*MockWeb3Client)(nil).GetBlockNumber))
+}
+
+// GetDealState mocks base method.
+func (m *MockWeb3Client) GetDealState(dealId string) (uint8, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDealState", dealId)
+	ret0, _ := ret[0].(uint8)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDealState indicates an expected call of GetDealState.
+func (mr *MockWeb3ClientMockRecorder) GetDealState(dealId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDealState", reflect.TypeOf((*MockWeb3Client)(nil).GetDealState), dealId)
+}
+
+// GetGenerateChallenge mocks base method.
+func (m *MockWeb3Client) GetGenerateChallenge(ctx context.Context, nodeId string) (string, *pow.PowGenerateChallenge, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenerateChallenge", ctx, nodeId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*pow.PowGenerateChallenge)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetGenerateChallenge indicates an expected call of GetGenerateChallenge.
+func (mr *MockWeb3ClientMockRecorder) GetGenerateChallenge(ctx, nodeId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenerateChallenge", reflect.TypeOf((*MockWeb3Client)(nil).GetGenerateChallenge), ctx, nodeId)
+}
+
+// GetLPBalance mocks base method.
+func (m *MockWeb3Client) GetLPBalance(address string) (*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLPBalance", address)
+	ret0, _ := ret[0].(*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLPBalance indicates an expected call of GetLPBalance.
+func (mr *MockWeb3ClientMockRecorder) GetLPBalance(address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLPBalance", reflect.TypeOf((*MockWeb3Client)(nil).GetLPBalance), address)
+}
+
+// GetLPTokenSenders mocks base method.
+func (m *MockWeb3Client) GetLPTokenSenders(address string, lookback uint64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLPTokenSenders", address, lookback)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLPTokenSenders indicates an expected call of GetLPTokenSenders.
+func (mr *MockWeb3ClientMockRecorder) GetLPTokenSenders(address, lookback any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLPTokenSenders", reflect.TypeOf((*MockWeb3Client)(nil).GetLPTokenSenders), address, lookback)
+}
+
+// GetMilestoneHash mocks base method.
+func (m *MockWeb3Client) GetMilestoneHash(milestone data.DealMilestone) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMilestoneHash", milestone)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMilestoneHash indicates an expected call of GetMilestoneHash.
+func (mr *MockWeb3ClientMockRecorder) GetMilestoneHash(milestone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMilestoneHash", reflect.TypeOf((*MockWeb3Client)(nil).GetMilestoneHash), milestone)
+}
+
+// GetProtocolParameters mocks base method.
+func (m *MockWeb3Client) GetProtocolParameters() (web3.ProtocolParameters, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProtocolParameters")
+	ret0, _ := ret[0].(web3.ProtocolParameters)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProtocolParameters indicates an expected call of GetProtocolParameters.
+func (mr *MockWeb3ClientMockRecorder) GetProtocolParameters() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProtocolParameters", reflect.TypeOf((*MockWeb3Client)(nil).GetProtocolParameters))
+}
+
+// GetSigner mocks base method.
+func (m *MockWeb3Client) GetSigner() web3.Signer {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSigner")
+	ret0, _ := ret[0].(web3.Signer)
+	return ret0
+}
+
+// GetSigner indicates an expected call of GetSigner.
+func (mr *MockWeb3ClientMockRecorder) GetSigner() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSigner", reflect.TypeOf((*MockWeb3Client)(nil).GetSigner))
+}
+
+// GetSolverAddresses mocks base method.
+func (m *MockWeb3Client) GetSolverAddresses() ([]common.Address, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSolverAddresses")
+	ret0, _ := ret[0].([]common.Address)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSolverAddresses indicates an expected call of GetSolverAddresses.
+func (mr *MockWeb3ClientMockRecorder) GetSolverAddresses() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSolverAddresses", reflect.TypeOf((*MockWeb3Client)(nil).GetSolverAddresses))
+}
+
+// GetSolverUrl mocks base method.
+func (m *MockWeb3Client) GetSolverUrl(address string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSolverUrl", address)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSolverUrl indicates an expected call of GetSolverUrl.
+func (mr *MockWeb3ClientMockRecorder) GetSolverUrl(address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSolverUrl", reflect.TypeOf((*MockWeb3Client)(nil).GetSolverUrl), address)
+}
+
+// GetUser mocks base method.
+func (m *MockWeb3Client) GetUser(address common.Address) (users.SharedStructsUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", address)
+	ret0, _ := ret[0].(users.SharedStructsUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockWeb3ClientMockRecorder) GetUser(address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockWeb3Client)(nil).GetUser), address)
+}
+
+// MediationAcceptResult mocks base method.
+func (m *MockWeb3Client) MediationAcceptResult(dealId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MediationAcceptResult", dealId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MediationAcceptResult indicates an expected call of MediationAcceptResult.
+func (mr *MockWeb3ClientMockRecorder) MediationAcceptResult(dealId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MediationAcceptResult", reflect.TypeOf((*MockWeb3Client)(nil).MediationAcceptResult), dealId)
+}
+
+// MediationRejectResult mocks base method.
+func (m *MockWeb3Client) MediationRejectResult(dealId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MediationRejectResult", dealId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MediationRejectResult indicates an expected call of MediationRejectResult.
+func (mr *MockWeb3ClientMockRecorder) MediationRejectResult(dealId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MediationRejectResult", reflect.TypeOf((*MockWeb3Client)(nil).MediationRejectResult), dealId)
+}
+
+// PayMilestone mocks base method.
+func (m *MockWeb3Client) PayMilestone(dealId string, index uint64, amount *big.Int, jobCreatorSignature string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PayMilestone", dealId, index, amount, jobCreatorSignature)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PayMilestone indicates an expected call of PayMilestone.
+func (mr *MockWeb3ClientMockRecorder) PayMilestone(dealId, index, amount, jobCreatorSignature any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayMilestone", reflect.TypeOf((*MockWeb3Client)(nil).PayMilestone), dealId, index, amount, jobCreatorSignature)
+}
+
+// ResumeTransactions mocks base method.
+func (m *MockWeb3Client) ResumeTransactions(ctx context.Context, records []data.Transaction) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResumeTransactions", ctx, records)
+}
+
+// ResumeTransactions indicates an expected call of ResumeTransactions.
+func (mr *MockWeb3ClientMockRecorder) ResumeTransactions(ctx, records any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeTransactions", reflect.TypeOf((*MockWeb3Client)(nil).ResumeTransactions), ctx, records)
+}
+
+// SetTransactionHandler mocks base method.
+func (m *MockWeb3Client) SetTransactionHandler(handler web3.TransactionHandler) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTransactionHandler", handler)
+}
+
+// SetTransactionHandler indicates an expected call of SetTransactionHandler.
+func (mr *MockWeb3ClientMockRecorder) SetTransactionHandler(handler any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTransactionHandler", reflect.TypeOf((*MockWeb3Client)(nil).SetTransactionHandler), handler)
+}
+
+// StartEvents mocks base method.
+func (m *MockWeb3Client) StartEvents(events *web3.EventChannels, ctx context.Context, cm *system.CleanupManager) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartEvents", events, ctx, cm)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartEvents indicates an expected call of StartEvents.
+func (mr *MockWeb3ClientMockRecorder) StartEvents(events, ctx, cm any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartEvents", reflect.TypeOf((*MockWeb3Client)(nil).StartEvents), events, ctx, cm)
+}
+
+// SubmitWork mocks base method.
+func (m *MockWeb3Client) SubmitWork(ctx context.Context, nonce *big.Int, nodeId string) (common.Hash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitWork", ctx, nonce, nodeId)
+	ret0, _ := ret[0].(common.Hash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitWork indicates an expected call of SubmitWork.
+func (mr *MockWeb3ClientMockRecorder) SubmitWork(ctx, nonce, nodeId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitWork", reflect.TypeOf((*MockWeb3Client)(nil).SubmitWork), ctx, nonce, nodeId)
+}
+
+// SyncEvents mocks base method.
+func (m *MockWeb3Client) SyncEvents(events *web3.EventChannels, checkpoints web3.CheckpointStore, ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncEvents", events, checkpoints, ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncEvents indicates an expected call of SyncEvents.
+func (mr *MockWeb3ClientMockRecorder) SyncEvents(events, checkpoints, ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncEvents", reflect.TypeOf((*MockWeb3Client)(nil).SyncEvents), events, checkpoints, ctx)
+}
+
+// TimeoutAgree mocks base method.
+func (m *MockWeb3Client) TimeoutAgree(dealId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TimeoutAgree", dealId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TimeoutAgree indicates an expected call of TimeoutAgree.
+func (mr *MockWeb3ClientMockRecorder) TimeoutAgree(dealId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TimeoutAgree", reflect.TypeOf((*MockWeb3Client)(nil).TimeoutAgree), dealId)
+}
+
+// TimeoutJudgeResult mocks base method.
+func (m *MockWeb3Client) TimeoutJudgeResult(dealId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TimeoutJudgeResult", dealId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TimeoutJudgeResult indicates an expected call of TimeoutJudgeResult.
+func (mr *MockWeb3ClientMockRecorder) TimeoutJudgeResult(dealId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TimeoutJudgeResult", reflect.TypeOf((*MockWeb3Client)(nil).TimeoutJudgeResult), dealId)
+}
+
+// TimeoutMediateResult mocks base method.
+func (m *MockWeb3Client) TimeoutMediateResult(dealId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TimeoutMediateResult", dealId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TimeoutMediateResult indicates an expected call of TimeoutMediateResult.
+func (mr *MockWeb3ClientMockRecorder) TimeoutMediateResult(dealId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TimeoutMediateResult", reflect.TypeOf((*MockWeb3Client)(nil).TimeoutMediateResult), dealId)
+}
+
+// TimeoutSubmitResult mocks base method.
+func (m *MockWeb3Client) TimeoutSubmitResult(dealId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TimeoutSubmitResult", dealId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TimeoutSubmitResult indicates an expected call of TimeoutSubmitResult.
+func (mr *MockWeb3ClientMockRecorder) TimeoutSubmitResult(dealId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TimeoutSubmitResult", reflect.TypeOf((*MockWeb3Client)(nil).TimeoutSubmitResult), dealId)
+}
+
+// UpdateUser mocks base method.
+func (m *MockWeb3Client) UpdateUser(metadataCID, url string, roles []uint8) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", metadataCID, url, roles)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockWeb3ClientMockRecorder) UpdateUser(metadataCID, url, roles any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockWeb3Client)(nil).UpdateUser), metadataCID, url, roles)
+}
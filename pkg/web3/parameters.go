@@ -0,0 +1,130 @@
+package web3
+
+import (
+	"sync"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/parameters"
+	"github.com/rs/zerolog/log"
+)
+
+// the keys we read from the on-chain parameter registry
+const (
+	ParameterTimeoutAgree              = "timeout.agree"
+	ParameterTimeoutSubmitResults      = "timeout.submit_results"
+	ParameterTimeoutJudgeResults       = "timeout.judge_results"
+	ParameterTimeoutMediateResults     = "timeout.mediate_results"
+	ParameterResultsCollateralMultiple = "pricing.results_collateral_multiple"
+	ParameterMinInstructionPrice       = "pricing.min_instruction_price"
+)
+
+// network wide tunables loaded from the parameter registry contract
+// a zero value means the registry has not set it and we keep our local value
+type ProtocolParameters struct {
+	TimeoutAgree              uint64 `json:"timeout_agree"`
+	TimeoutSubmitResults      uint64 `json:"timeout_submit_results"`
+	TimeoutJudgeResults       uint64 `json:"timeout_judge_results"`
+	TimeoutMediateResults     uint64 `json:"timeout_mediate_results"`
+	ResultsCollateralMultiple uint64 `json:"results_collateral_multiple"`
+	MinInstructionPrice       uint64 `json:"min_instruction_price"`
+}
+
+// overwrite the timeouts with any that are set in the registry
+func (params ProtocolParameters) ApplyTimeouts(timeouts data.DealTimeouts) data.DealTimeouts {
+	if params.TimeoutAgree > 0 {
+		timeouts.Agree.Timeout = params.TimeoutAgree
+	}
+	if params.TimeoutSubmitResults > 0 {
+		timeouts.SubmitResults.Timeout = params.TimeoutSubmitResults
+	}
+	if params.TimeoutJudgeResults > 0 {
+		timeouts.JudgeResults.Timeout = params.TimeoutJudgeResults
+	}
+	if params.TimeoutMediateResults > 0 {
+		timeouts.MediateResults.Timeout = params.TimeoutMediateResults
+	}
+	return timeouts
+}
+
+// overwrite the collateral multiple and raise the instruction price
+// to the network minimum if it is below it
+func (params ProtocolParameters) ApplyPricing(pricing data.DealPricing) data.DealPricing {
+	if params.ResultsCollateralMultiple > 0 {
+		pricing.ResultsCollateralMultiple = params.ResultsCollateralMultiple
+	}
+	if pricing.InstructionPrice < params.MinInstructionPrice {
+		pricing.InstructionPrice = params.MinInstructionPrice
+	}
+	return pricing
+}
+
+// the parameters a service is currently using
+// this is kept up to date by watching the registry for changes
+type ProtocolParametersCache struct {
+	mutex  sync.RWMutex
+	params ProtocolParameters
+}
+
+func NewProtocolParametersCache() *ProtocolParametersCache {
+	return &ProtocolParametersCache{}
+}
+
+func (cache *ProtocolParametersCache) Get() ProtocolParameters {
+	cache.mutex.RLock()
+	defer cache.mutex.RUnlock()
+	return cache.params
+}
+
+// load the parameters from the registry into the cache
+// if there is no registry configured we keep the zero values
+func (cache *ProtocolParametersCache) Load(sdk Web3Client) error {
+	params, err := sdk.GetProtocolParameters()
+	if err != nil {
+		return err
+	}
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.params = params
+	log.Debug().
+		Str("protocol parameters", "loaded").
+		Msgf("%+v", params)
+	return nil
+}
+
+// reload the cache whenever a parameter is changed on-chain
+func (cache *ProtocolParametersCache) Subscribe(sdk Web3Client, events *EventChannels) {
+	events.Parameters.SubscribeParameterSet(func(ev parameters.ParametersParameterSet) {
+		log.Info().
+			Str("protocol parameter", ev.Key).
+			Str("value", ev.Value.String()).
+			Msgf("protocol parameter changed")
+		err := cache.Load(sdk)
+		if err != nil {
+			log.Error().Msgf("error reloading protocol parameters: %s", err.Error())
+		}
+	})
+}
+
+// read all of the parameters we know about from the registry
+func (sdk *Web3SDK) GetProtocolParameters() (ProtocolParameters, error) {
+	params := ProtocolParameters{}
+	if sdk.Contracts.Parameters == nil {
+		return params, nil
+	}
+	fields := map[string]*uint64{
+		ParameterTimeoutAgree:              &params.TimeoutAgree,
+		ParameterTimeoutSubmitResults:      &params.TimeoutSubmitResults,
+		ParameterTimeoutJudgeResults:       &params.TimeoutJudgeResults,
+		ParameterTimeoutMediateResults:     &params.TimeoutMediateResults,
+		ParameterResultsCollateralMultiple: &params.ResultsCollateralMultiple,
+		ParameterMinInstructionPrice:       &params.MinInstructionPrice,
+	}
+	for key, field := range fields {
+		value, err := sdk.Contracts.Parameters.GetParameter(sdk.CallOpts, key)
+		if err != nil {
+			return params, err
+		}
+		*field = value.Uint64()
+	}
+	return params, nil
+}
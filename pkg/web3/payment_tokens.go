@@ -0,0 +1,163 @@
+package web3
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/storage"
+)
+
+// the ERC-20 calls we make on the tokens deals can be paid in
+const paymentTokenABI = `[{"inputs":[{"name":"owner","type":"address"},{"name":"spender","type":"address"}],"name":"allowance","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}],"name":"approve","outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"name":"account","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"decimals","outputs":[{"name":"","type":"uint8"}],"stateMutability":"view","type":"function"}]`
+
+func (sdk *Web3SDK) bindContract(address common.Address, abiJSON string) (*bind.BoundContract, error) {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, parsed, sdk.Client, sdk.Client, sdk.Client), nil
+}
+
+func (sdk *Web3SDK) callContract(address common.Address, abiJSON string, method string, args ...interface{}) ([]interface{}, error) {
+	contract, err := sdk.bindContract(address, abiJSON)
+	if err != nil {
+		return nil, err
+	}
+	var out []interface{}
+	err = contract.Call(sdk.CallOpts, &out, method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error calling %s on %s: %s", method, address, err.Error())
+	}
+	return out, nil
+}
+
+// whether the payments contract lets deals be paid in the token
+func (sdk *Web3SDK) IsPaymentToken(token string) (bool, error) {
+	return sdk.Contracts.Payments.IsPaymentToken(sdk.CallOpts, common.HexToAddress(token))
+}
+
+func (sdk *Web3SDK) GetPaymentTokenDecimals(token string) (uint8, error) {
+	out, err := sdk.callContract(common.HexToAddress(token), paymentTokenABI, "decimals")
+	if err != nil {
+		return 0, err
+	}
+	return *abi.ConvertType(out[0], new(uint8)).(*uint8), nil
+}
+
+func (sdk *Web3SDK) GetPaymentTokenBalance(token string, address string) (*big.Int, error) {
+	out, err := sdk.callContract(common.HexToAddress(token), paymentTokenABI, "balanceOf", common.HexToAddress(address))
+	if err != nil {
+		return nil, err
+	}
+	return abi.ConvertType(out[0], new(big.Int)).(*big.Int), nil
+}
+
+// the zero address means the deal is paid in the network token
+func (sdk *Web3SDK) GetDealPaymentToken(dealId string) (common.Address, error) {
+	return sdk.Contracts.Payments.GetDealPaymentToken(sdk.CallOpts, dealId)
+}
+
+// check a token a job offer names is one deals can be paid in
+// and that the offer has its decimals right
+func (sdk *Web3SDK) CheckPaymentToken(token data.PaymentToken) error {
+	allowed, err := sdk.IsPaymentToken(token.Address)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return fmt.Errorf("deals cannot be paid in %s", token.Address)
+	}
+	decimals, err := sdk.GetPaymentTokenDecimals(token.Address)
+	if err != nil {
+		return err
+	}
+	if decimals != token.Decimals {
+		return fmt.Errorf("payment token %s has %d decimals not %d", token.Address, decimals, token.Decimals)
+	}
+	return nil
+}
+
+// the network token is moved by the token contract itself but for any
+// other token the payments contract needs an allowance to take the collateral
+func (sdk *Web3SDK) approvePaymentToken(ctx context.Context, dealId string, token common.Address, amount *big.Int) error {
+	if amount.Sign() <= 0 {
+		return nil
+	}
+	spender := sdk.Contracts.Addresses[CONTRACT_PAYMENTS]
+	out, err := sdk.callContract(token, paymentTokenABI, "allowance", sdk.GetAddress(), spender)
+	if err != nil {
+		return err
+	}
+	allowance := abi.ConvertType(out[0], new(big.Int)).(*big.Int)
+	if allowance.Cmp(amount) >= 0 {
+		return nil
+	}
+	contract, err := sdk.bindContract(token, paymentTokenABI)
+	if err != nil {
+		return err
+	}
+	_, err = sdk.Transact(ctx, "token.Approve", dealId, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return contract.Transact(opts, "approve", spender, amount)
+	})
+	return err
+}
+
+// approve what the deal on chain needs us to put in for the next step
+// this does nothing for deals paid in the network token
+func (sdk *Web3SDK) approveDealPayment(ctx context.Context, dealId string, getAmount func(deal storage.SharedStructsDeal) *big.Int) error {
+	token, err := sdk.GetDealPaymentToken(dealId)
+	if err != nil {
+		return err
+	}
+	if token == (common.Address{}) {
+		return nil
+	}
+	deal, err := sdk.Contracts.Storage.GetDeal(sdk.CallOpts, dealId)
+	if err != nil {
+		return err
+	}
+	return sdk.approvePaymentToken(ctx, dealId, token, getAmount(deal))
+}
+
+// the collateral we put in when we agree to a deal, the same
+// amounts the controller contract has the payments contract take
+func getAgreeCollateral(deal data.Deal, address common.Address) *big.Int {
+	decimals := data.GetDealDecimals(deal)
+	timeouts := data.ConvertDealTimeouts(deal.Timeouts, decimals)
+	if address == common.HexToAddress(deal.Members.ResourceProvider) {
+		return timeouts.SubmitResults.Collateral
+	}
+	pricing := data.ConvertDealPricing(deal.Pricing, decimals)
+	return new(big.Int).Add(pricing.PaymentCollateral, timeouts.JudgeResults.Collateral)
+}
+
+func (sdk *Web3SDK) agreeWithPaymentToken(ctx context.Context, deal data.Deal) (*types.Receipt, error) {
+	paymentToken := *deal.JobOffer.PaymentToken
+	err := sdk.CheckPaymentToken(paymentToken)
+	if err != nil {
+		return nil, err
+	}
+	token := common.HexToAddress(paymentToken.Address)
+	err = sdk.approvePaymentToken(ctx, deal.ID, token, getAgreeCollateral(deal, sdk.GetAddress()))
+	if err != nil {
+		return nil, err
+	}
+	decimals := data.GetDealDecimals(deal)
+	return sdk.Transact(ctx, "controller.AgreeWithPaymentToken", deal.ID, func(opts *bind.TransactOpts) (*types.Transaction, error) {
+		return sdk.Contracts.Controller.AgreeWithPaymentToken(
+			opts,
+			deal.ID,
+			data.ConvertDealMembers(deal.Members),
+			data.ConvertDealTimeouts(deal.Timeouts, decimals),
+			data.ConvertDealPricing(deal.Pricing, decimals),
+			token,
+		)
+	})
+}
@@ -0,0 +1,122 @@
+package web3
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+)
+
+const DEAL_RECEIPT_DOMAIN_NAME = "Lilypad"
+const DEAL_RECEIPT_DOMAIN_VERSION = "1"
+
+// the EIP-712 typed data for a receipt, the signature is not part of it
+func GetDealReceiptTypedData(receipt data.DealReceipt) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+			},
+			"DealReceipt": {
+				{Name: "dealId", Type: "string"},
+				{Name: "jobOffer", Type: "string"},
+				{Name: "resourceOffer", Type: "string"},
+				{Name: "jobCreator", Type: "address"},
+				{Name: "resourceProvider", Type: "address"},
+				{Name: "solver", Type: "address"},
+				{Name: "instructionPrice", Type: "uint256"},
+				{Name: "paymentCollateral", Type: "uint256"},
+				{Name: "resultsCollateralMultiple", Type: "uint256"},
+				{Name: "mediationFee", Type: "uint256"},
+				{Name: "createdAt", Type: "uint256"},
+			},
+		},
+		PrimaryType: "DealReceipt",
+		Domain: apitypes.TypedDataDomain{
+			Name:    DEAL_RECEIPT_DOMAIN_NAME,
+			Version: DEAL_RECEIPT_DOMAIN_VERSION,
+			ChainId: math.NewHexOrDecimal256(receipt.ChainID),
+		},
+		Message: apitypes.TypedDataMessage{
+			"dealId":                    receipt.DealID,
+			"jobOffer":                  receipt.JobOffer,
+			"resourceOffer":             receipt.ResourceOffer,
+			"jobCreator":                common.HexToAddress(receipt.JobCreator).Hex(),
+			"resourceProvider":          common.HexToAddress(receipt.ResourceProvider).Hex(),
+			"solver":                    common.HexToAddress(receipt.Solver).Hex(),
+			"instructionPrice":          new(big.Int).SetUint64(receipt.Pricing.InstructionPrice),
+			"paymentCollateral":         new(big.Int).SetUint64(receipt.Pricing.PaymentCollateral),
+			"resultsCollateralMultiple": new(big.Int).SetUint64(receipt.Pricing.ResultsCollateralMultiple),
+			"mediationFee":              new(big.Int).SetUint64(receipt.Pricing.MediationFee),
+			"createdAt":                 big.NewInt(receipt.CreatedAt),
+		},
+	}
+}
+
+// the hash that is signed, the same as eth_signTypedData_v4 produces
+func GetDealReceiptHash(receipt data.DealReceipt) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(GetDealReceiptTypedData(receipt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash deal receipt: %w", err)
+	}
+	return hash, nil
+}
+
+// sign the receipt and return it with the signature set
+func SignDealReceipt(signer Signer, receipt data.DealReceipt) (data.DealReceipt, error) {
+	hash, err := GetDealReceiptHash(receipt)
+	if err != nil {
+		return receipt, err
+	}
+	sig, err := signer.SignHash(hash)
+	if err != nil {
+		return receipt, err
+	}
+	// wallets and contracts expect the recovery ID as 27 or 28
+	sig[crypto.RecoveryIDOffset] += 27
+	receipt.Signature = hexutil.Encode(sig)
+	return receipt, nil
+}
+
+// the address that signed the receipt, compare this to receipt.Solver
+func RecoverDealReceiptSigner(receipt data.DealReceipt) (common.Address, error) {
+	sig, err := hexutil.Decode(receipt.Signature)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid deal receipt signature: %w", err)
+	}
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("deal receipt signature must be %d bytes", crypto.SignatureLength)
+	}
+	hash, err := GetDealReceiptHash(receipt)
+	if err != nil {
+		return common.Address{}, err
+	}
+	sig = append([]byte{}, sig...)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	publicKey, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
+
+// check the receipt was signed by the solver it names
+func VerifyDealReceipt(receipt data.DealReceipt) error {
+	signer, err := RecoverDealReceiptSigner(receipt)
+	if err != nil {
+		return err
+	}
+	if signer != common.HexToAddress(receipt.Solver) {
+		return fmt.Errorf("deal receipt was signed by %s not the solver %s", signer.Hex(), receipt.Solver)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package web3
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignDealReceipt(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate private key: %v", err)
+	}
+
+	receipt, err := SignDealReceipt(&PrivateKeySigner{privateKey: privateKey}, data.DealReceipt{
+		DealID:           "deal",
+		JobOffer:         "job_offer",
+		ResourceOffer:    "resource_offer",
+		JobCreator:       "0x1111111111111111111111111111111111111111",
+		ResourceProvider: "0x2222222222222222222222222222222222222222",
+		Solver:           GetAddress(privateKey).String(),
+		Pricing:          data.DealPricing{InstructionPrice: 10, PaymentCollateral: 20},
+		ChainID:          1337,
+		CreatedAt:        1700000000,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, VerifyDealReceipt(receipt))
+
+	signer, err := RecoverDealReceiptSigner(receipt)
+	assert.NoError(t, err)
+	assert.Equal(t, GetAddress(privateKey), signer)
+
+	// changing any of the terms breaks the signature
+	tampered := receipt
+	tampered.Pricing.InstructionPrice = 1
+	assert.Error(t, VerifyDealReceipt(tampered))
+
+	tampered = receipt
+	tampered.ChainID = 1
+	assert.Error(t, VerifyDealReceipt(tampered))
+
+	tampered = receipt
+	tampered.Signature = "0x1234"
+	assert.Error(t, VerifyDealReceipt(tampered))
+}
@@ -0,0 +1,453 @@
+package web3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// the first backoff after an endpoint fails, it doubles each time
+	RPC_MIN_BACKOFF = time.Second
+	// rate limits usually last a while so we back off harder
+	RPC_RATE_LIMIT_BACKOFF = 5 * time.Second
+	// how long a health check waits for an endpoint to answer
+	RPC_HEALTH_CHECK_TIMEOUT = 10 * time.Second
+
+	DEFAULT_RPC_HEALTH_CHECK_INTERVAL = 30
+	DEFAULT_RPC_MAX_BACKOFF           = 60
+	DEFAULT_RPC_MAX_BLOCK_LAG         = 10
+)
+
+// the JSON-RPC error code some providers use when we hit their rate limit
+const rpcLimitExceededCode = -32005
+
+type rpcEndpoint struct {
+	url string
+	// the host is what we log so that api keys in the path are not leaked
+	host         string
+	client       *ethclient.Client
+	failures     int
+	backoffUntil time.Time
+	lastError    string
+}
+
+// a chain backend over several RPC endpoints
+// calls go to the current endpoint and move on to the next one when it
+// fails or is rate limited, failed endpoints are backed off exponentially
+// and a health check in the background drops endpoints that stop
+// answering or fall behind the others
+type RpcClient struct {
+	mutex     sync.Mutex
+	endpoints []*rpcEndpoint
+	current   int
+	// how many seconds between health checks
+	healthCheckInterval int
+	// the longest we back off an endpoint for in seconds
+	maxBackoff int
+	// how many blocks an endpoint can be behind the best one
+	maxBlockLag uint64
+	dial        func(ctx context.Context, rawurl string) (*ethclient.Client, error)
+	now         func() time.Time
+}
+
+func NewRpcClient(options Web3Options) (*RpcClient, error) {
+	endpoints := []*rpcEndpoint{}
+	for _, rawurl := range strings.Split(options.RpcURL, ",") {
+		rawurl = strings.TrimSpace(rawurl)
+		if rawurl == "" {
+			continue
+		}
+		parsedURL, err := url.Parse(rawurl)
+		if err != nil {
+			log.Warn().Msgf("Unable to parse web3 RPC URL: %v", err)
+			continue
+		}
+		endpoints = append(endpoints, &rpcEndpoint{
+			url:  rawurl,
+			host: parsedURL.Host,
+		})
+	}
+	if len(endpoints) == 0 {
+		return nil, errors.New("no valid web3 RPC URL was given")
+	}
+	client := &RpcClient{
+		endpoints:           endpoints,
+		healthCheckInterval: options.RpcHealthCheckInterval,
+		maxBackoff:          options.RpcMaxBackoff,
+		maxBlockLag:         uint64(options.RpcMaxBlockLag),
+		dial:                ethclient.DialContext,
+		now:                 time.Now,
+	}
+	if client.healthCheckInterval <= 0 {
+		client.healthCheckInterval = DEFAULT_RPC_HEALTH_CHECK_INTERVAL
+	}
+	if client.maxBackoff <= 0 {
+		client.maxBackoff = DEFAULT_RPC_MAX_BACKOFF
+	}
+	if client.maxBlockLag == 0 {
+		client.maxBlockLag = DEFAULT_RPC_MAX_BLOCK_LAG
+	}
+	return client, nil
+}
+
+// connect to the first endpoint that will have us
+func (client *RpcClient) Connect(ctx context.Context) error {
+	var err error
+	for _, endpoint := range client.endpoints {
+		_, err = client.endpointClient(ctx, endpoint)
+		if err != nil {
+			log.Warn().Msgf("Failed to connect to %s: %v", endpoint.host, err)
+			client.markFailure(endpoint, err)
+			continue
+		}
+		log.Info().Msgf("Connected to %s", endpoint.host)
+		client.mutex.Lock()
+		client.current = client.indexOf(endpoint)
+		client.mutex.Unlock()
+		return nil
+	}
+	return fmt.Errorf("Failed to connect to a web3 RPC provider: %w", err)
+}
+
+// check the endpoints on an interval until the context is done
+// there is nothing to rotate to with a single endpoint so we do not bother
+func (client *RpcClient) Start(ctx context.Context) {
+	if len(client.endpoints) < 2 {
+		return
+	}
+	ticker := time.NewTicker(time.Duration(client.healthCheckInterval) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			client.checkHealth(ctx)
+		}
+	}
+}
+
+// ask every endpoint for its head and back off the ones that do not
+// answer or are too far behind the best head we saw
+func (client *RpcClient) checkHealth(ctx context.Context) {
+	heads := make([]uint64, len(client.endpoints))
+	errs := make([]error, len(client.endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range client.endpoints {
+		wg.Add(1)
+		go func(i int, endpoint *rpcEndpoint) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, RPC_HEALTH_CHECK_TIMEOUT)
+			defer cancel()
+			ethClient, err := client.endpointClient(checkCtx, endpoint)
+			if err == nil {
+				heads[i], err = ethClient.BlockNumber(checkCtx)
+			}
+			errs[i] = err
+		}(i, endpoint)
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+
+	var best uint64
+	for i := range client.endpoints {
+		if errs[i] == nil && heads[i] > best {
+			best = heads[i]
+		}
+	}
+	for i, endpoint := range client.endpoints {
+		switch {
+		case errs[i] != nil:
+			log.Warn().Msgf("web3 RPC %s failed its health check: %v", endpoint.host, errs[i])
+			client.markFailure(endpoint, errs[i])
+		case best-heads[i] > client.maxBlockLag:
+			err := fmt.Errorf("%d blocks behind", best-heads[i])
+			log.Warn().Msgf("web3 RPC %s failed its health check: %v", endpoint.host, err)
+			client.markFailure(endpoint, err)
+		default:
+			client.markSuccess(endpoint)
+		}
+	}
+}
+
+func (client *RpcClient) indexOf(endpoint *rpcEndpoint) int {
+	for i, e := range client.endpoints {
+		if e == endpoint {
+			return i
+		}
+	}
+	return 0
+}
+
+func (client *RpcClient) endpointClient(ctx context.Context, endpoint *rpcEndpoint) (*ethclient.Client, error) {
+	client.mutex.Lock()
+	ethClient := endpoint.client
+	client.mutex.Unlock()
+	if ethClient != nil {
+		return ethClient, nil
+	}
+	ethClient, err := client.dial(ctx, endpoint.url)
+	if err != nil {
+		return nil, err
+	}
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	// someone else got there first
+	if endpoint.client != nil {
+		ethClient.Close()
+		return endpoint.client, nil
+	}
+	endpoint.client = ethClient
+	return ethClient, nil
+}
+
+// pick the endpoint to use starting from the current one
+// if every endpoint is backing off we wait for the first one to come back
+func (client *RpcClient) pick(ctx context.Context) (*rpcEndpoint, error) {
+	client.mutex.Lock()
+	now := client.now()
+	var soonest *rpcEndpoint
+	for i := range client.endpoints {
+		index := (client.current + i) % len(client.endpoints)
+		endpoint := client.endpoints[index]
+		if !endpoint.backoffUntil.After(now) {
+			if index != client.current {
+				log.Info().Msgf("web3 RPC failing over from %s to %s", client.endpoints[client.current].host, endpoint.host)
+				client.current = index
+			}
+			client.mutex.Unlock()
+			return endpoint, nil
+		}
+		if soonest == nil || endpoint.backoffUntil.Before(soonest.backoffUntil) {
+			soonest = endpoint
+		}
+	}
+	wait := soonest.backoffUntil.Sub(now)
+	client.current = client.indexOf(soonest)
+	client.mutex.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(wait):
+		return soonest, nil
+	}
+}
+
+func (client *RpcClient) markSuccess(endpoint *rpcEndpoint) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	endpoint.failures = 0
+	endpoint.backoffUntil = time.Time{}
+	endpoint.lastError = ""
+}
+
+// back the endpoint off for twice as long as last time
+// the connection is dropped so that a broken websocket is dialled again
+func (client *RpcClient) markFailure(endpoint *rpcEndpoint, err error) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	endpoint.failures++
+	endpoint.lastError = err.Error()
+	endpoint.backoffUntil = client.now().Add(getRpcBackoff(endpoint.failures, isRateLimitError(err), time.Duration(client.maxBackoff)*time.Second))
+	if endpoint.client != nil {
+		endpoint.client.Close()
+		endpoint.client = nil
+	}
+}
+
+func getRpcBackoff(failures int, rateLimited bool, maxBackoff time.Duration) time.Duration {
+	backoff := RPC_MIN_BACKOFF
+	if rateLimited {
+		backoff = RPC_RATE_LIMIT_BACKOFF
+	}
+	for i := 1; i < failures && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
+}
+
+func isRateLimitError(err error) bool {
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == 429 {
+		return true
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == rpcLimitExceededCode {
+		return true
+	}
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "rate limit") || strings.Contains(message, "too many requests")
+}
+
+// whether an error means the endpoint is in trouble rather than the call
+// an error the node answered with, like a revert, would be the same anywhere
+func isEndpointError(err error) bool {
+	if isRateLimitError(err) {
+		return true
+	}
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return true
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return false
+	}
+	if errors.Is(err, ethereum.NotFound) {
+		return false
+	}
+	return true
+}
+
+// run a call against the endpoints until one of them answers
+// each endpoint is tried at most once
+func rpcCall[T any](ctx context.Context, client *RpcClient, call func(*ethclient.Client) (T, error)) (T, error) {
+	var result T
+	var err error
+	for attempt := 0; attempt < len(client.endpoints); attempt++ {
+		var endpoint *rpcEndpoint
+		endpoint, err = client.pick(ctx)
+		if err != nil {
+			return result, err
+		}
+		var ethClient *ethclient.Client
+		ethClient, err = client.endpointClient(ctx, endpoint)
+		if err == nil {
+			result, err = call(ethClient)
+		}
+		if err == nil {
+			client.markSuccess(endpoint)
+			return result, nil
+		}
+		// the caller gave up so the endpoint is not to blame
+		if ctx.Err() != nil || !isEndpointError(err) {
+			return result, err
+		}
+		log.Warn().Msgf("web3 RPC %s failed: %v", endpoint.host, err)
+		client.markFailure(endpoint, err)
+	}
+	return result, err
+}
+
+func (client *RpcClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return rpcCall(ctx, client, func(c *ethclient.Client) (uint64, error) {
+		return c.BlockNumber(ctx)
+	})
+}
+
+func (client *RpcClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return rpcCall(ctx, client, func(c *ethclient.Client) (*big.Int, error) {
+		return c.BalanceAt(ctx, account, blockNumber)
+	})
+}
+
+func (client *RpcClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return rpcCall(ctx, client, func(c *ethclient.Client) (uint64, error) {
+		return c.NonceAt(ctx, account, blockNumber)
+	})
+}
+
+func (client *RpcClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return rpcCall(ctx, client, func(c *ethclient.Client) ([]byte, error) {
+		return c.CodeAt(ctx, contract, blockNumber)
+	})
+}
+
+func (client *RpcClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return rpcCall(ctx, client, func(c *ethclient.Client) ([]byte, error) {
+		return c.CallContract(ctx, call, blockNumber)
+	})
+}
+
+func (client *RpcClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return rpcCall(ctx, client, func(c *ethclient.Client) (*types.Header, error) {
+		return c.HeaderByNumber(ctx, number)
+	})
+}
+
+func (client *RpcClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return rpcCall(ctx, client, func(c *ethclient.Client) ([]byte, error) {
+		return c.PendingCodeAt(ctx, account)
+	})
+}
+
+func (client *RpcClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return rpcCall(ctx, client, func(c *ethclient.Client) (uint64, error) {
+		return c.PendingNonceAt(ctx, account)
+	})
+}
+
+func (client *RpcClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return rpcCall(ctx, client, func(c *ethclient.Client) (*big.Int, error) {
+		return c.SuggestGasPrice(ctx)
+	})
+}
+
+func (client *RpcClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return rpcCall(ctx, client, func(c *ethclient.Client) (*big.Int, error) {
+		return c.SuggestGasTipCap(ctx)
+	})
+}
+
+func (client *RpcClient) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return rpcCall(ctx, client, func(c *ethclient.Client) (uint64, error) {
+		return c.EstimateGas(ctx, call)
+	})
+}
+
+// sending the same signed transaction to another endpoint is safe
+// as it has the same hash, a node that already has it says so
+func (client *RpcClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	_, err := rpcCall(ctx, client, func(c *ethclient.Client) (struct{}, error) {
+		err := c.SendTransaction(ctx, tx)
+		if err != nil && strings.Contains(strings.ToLower(err.Error()), "already known") {
+			return struct{}{}, nil
+		}
+		return struct{}{}, err
+	})
+	return err
+}
+
+func (client *RpcClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return rpcCall(ctx, client, func(c *ethclient.Client) (*types.Receipt, error) {
+		return c.TransactionReceipt(ctx, txHash)
+	})
+}
+
+func (client *RpcClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	return rpcCall(ctx, client, func(c *ethclient.Client) ([]types.Log, error) {
+		return c.FilterLogs(ctx, query)
+	})
+}
+
+// a subscription stays on the endpoint it was made on, when it errors
+// the event collections subscribe again and get the current endpoint
+func (client *RpcClient) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return rpcCall(ctx, client, func(c *ethclient.Client) (ethereum.Subscription, error) {
+		return c.SubscribeFilterLogs(ctx, query, ch)
+	})
+}
+
+// Compile-time interface checks:
+var _ bind.ContractBackend = (*RpcClient)(nil)
+var _ bind.DeployBackend = (*RpcClient)(nil)
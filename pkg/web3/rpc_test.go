@@ -0,0 +1,88 @@
+package web3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// an RPC server that answers eth_blockNumber with a fixed head
+// or with the given status code if it is not 200
+func newTestRpcServer(t *testing.T, head uint64, statusCode *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if *statusCode != http.StatusOK {
+			w.WriteHeader(*statusCode)
+			return
+		}
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":"0x%x"}`, req.ID, head)
+	}))
+}
+
+func TestRpcClientFailover(t *testing.T) {
+	firstStatus := http.StatusTooManyRequests
+	secondStatus := http.StatusOK
+	first := newTestRpcServer(t, 10, &firstStatus)
+	defer first.Close()
+	second := newTestRpcServer(t, 20, &secondStatus)
+	defer second.Close()
+
+	client, err := NewRpcClient(Web3Options{RpcURL: first.URL + "," + second.URL})
+	assert.NoError(t, err)
+	now := time.Unix(1000, 0)
+	client.now = func() time.Time { return now }
+
+	// the first endpoint is rate limited so we get the second
+	blockNumber, err := client.BlockNumber(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(20), blockNumber)
+	assert.Equal(t, 1, client.endpoints[0].failures)
+	assert.Equal(t, now.Add(RPC_RATE_LIMIT_BACKOFF), client.endpoints[0].backoffUntil)
+
+	// we stay on the second endpoint once it has failed too
+	// and go back to the first as its backoff is over
+	secondStatus = http.StatusBadGateway
+	firstStatus = http.StatusOK
+	now = now.Add(RPC_RATE_LIMIT_BACKOFF)
+	blockNumber, err = client.BlockNumber(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), blockNumber)
+	assert.Equal(t, 0, client.endpoints[0].failures)
+	assert.Equal(t, 1, client.endpoints[1].failures)
+}
+
+func TestRpcClientHealthCheck(t *testing.T) {
+	ok := http.StatusOK
+	ahead := newTestRpcServer(t, 100, &ok)
+	defer ahead.Close()
+	behind := newTestRpcServer(t, 50, &ok)
+	defer behind.Close()
+
+	client, err := NewRpcClient(Web3Options{RpcURL: behind.URL + "," + ahead.URL})
+	assert.NoError(t, err)
+	client.checkHealth(context.Background())
+	assert.Equal(t, 1, client.endpoints[0].failures)
+	assert.Equal(t, 0, client.endpoints[1].failures)
+
+	blockNumber, err := client.BlockNumber(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), blockNumber)
+}
+
+func TestGetRpcBackoff(t *testing.T) {
+	maxBackoff := time.Minute
+	assert.Equal(t, RPC_MIN_BACKOFF, getRpcBackoff(1, false, maxBackoff))
+	assert.Equal(t, 4*RPC_MIN_BACKOFF, getRpcBackoff(3, false, maxBackoff))
+	assert.Equal(t, 2*RPC_RATE_LIMIT_BACKOFF, getRpcBackoff(2, true, maxBackoff))
+	assert.Equal(t, maxBackoff, getRpcBackoff(20, true, maxBackoff))
+}
@@ -3,22 +3,18 @@ package web3
 import (
 	"context"
 	"crypto/ecdsa"
-	"errors"
-	"fmt"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"math/big"
-	"net/url"
-	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/lilypad-tech/lilypad/pkg/system"
 	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/controller"
 	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/jobcreator"
 	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/mediation"
+	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/parameters"
 	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/payments"
 	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/pow"
 	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/storage"
@@ -33,6 +29,19 @@ import (
 // LP token ABI
 const erc20ABI = `[{"constant":true,"inputs":[{"name":"_owner","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256","internalType": "uint256"}],"type":"function"}]`
 
+// the names we give each contract e.g. in chain sync checkpoints
+const (
+	CONTRACT_TOKEN      = "token"
+	CONTRACT_PAYMENTS   = "payments"
+	CONTRACT_STORAGE    = "storage"
+	CONTRACT_USERS      = "users"
+	CONTRACT_JOBCREATOR = "jobcreator"
+	CONTRACT_MEDIATION  = "mediation"
+	CONTRACT_CONTROLLER = "controller"
+	CONTRACT_POW        = "pow"
+	CONTRACT_PARAMETERS = "parameters"
+)
+
 // these are the go-binding wrappers for the various deployed contracts
 type Contracts struct {
 	Token      *token.Token
@@ -43,20 +52,32 @@ type Contracts struct {
 	Mediation  *mediation.Mediation
 	Controller *controller.Controller
 	Pow        *pow.Pow
+	// this is nil if no parameter registry is configured
+	Parameters *parameters.Parameters
+	// where each of the above is deployed keyed by contract name
+	Addresses map[string]common.Address
 }
 
 type Web3SDK struct {
-	Options      Web3Options
+	Options Web3Options
+	// this is only set when we hold the key ourselves, everything
+	// that signs should go through the Signer
 	PrivateKey   *ecdsa.PrivateKey
-	Client       *ethclient.Client
+	Signer       Signer
+	Client       *RpcClient
 	CallOpts     *bind.CallOpts
 	TransactOpts *bind.TransactOpts
 	Contracts    *Contracts
+
+	// sends are queued on this so each gets the next nonce
+	txMutex   sync.Mutex
+	nextNonce *uint64
+	txHandler TransactionHandler
 }
 
 func NewContracts(
 	options Web3Options,
-	client *ethclient.Client,
+	client bind.ContractBackend,
 	callOpts *bind.CallOpts,
 ) (*Contracts, error) {
 	controller, err := controller.NewController(common.HexToAddress(options.ControllerAddress), client)
@@ -189,6 +210,27 @@ func NewContracts(
 		return nil, err
 	}
 
+	addresses := map[string]common.Address{
+		CONTRACT_TOKEN:      common.HexToAddress(tokenAddress),
+		CONTRACT_PAYMENTS:   common.HexToAddress(paymentsAddress),
+		CONTRACT_STORAGE:    common.HexToAddress(storageAddress),
+		CONTRACT_USERS:      common.HexToAddress(usersAddress),
+		CONTRACT_JOBCREATOR: common.HexToAddress(jobcreatorAddress),
+		CONTRACT_MEDIATION:  common.HexToAddress(mediationAddress),
+		CONTRACT_CONTROLLER: common.HexToAddress(options.ControllerAddress),
+		CONTRACT_POW:        common.HexToAddress(powAddress),
+	}
+
+	var parametersContract *parameters.Parameters
+	log.Debug().Msgf("ParametersAddress: %s", options.ParametersAddress)
+	if options.ParametersAddress != "" {
+		parametersContract, err = parameters.NewParameters(common.HexToAddress(options.ParametersAddress), client)
+		if err != nil {
+			return nil, err
+		}
+		addresses[CONTRACT_PARAMETERS] = common.HexToAddress(options.ParametersAddress)
+	}
+
 	return &Contracts{
 		Token:      token,
 		Payments:   payments,
@@ -198,23 +240,31 @@ func NewContracts(
 		Mediation:  mediation,
 		Controller: controller,
 		Pow:        pow,
+		Parameters: parametersContract,
+		Addresses:  addresses,
 	}, nil
 }
 
 func NewContractSDK(ctx context.Context, options Web3Options, tracer trace.Tracer) (*Web3SDK, error) {
 	displayOpts := options
 	displayOpts.PrivateKey = "*********"
+	displayOpts.KeystorePassword = "*********"
+	displayOpts.RemoteSignerToken = "*********"
 	log.Debug().Msgf("NewContractSDK: %+v", displayOpts)
 
-	client, err := getEthClient(ctx, options, tracer)
+	client, err := getRpcClient(ctx, options, tracer)
 	if err != nil {
 		return nil, err
 	}
 
-	privateKey, err := ParsePrivateKey(options.PrivateKey)
+	signer, err := NewSigner(options)
 	if err != nil {
 		return nil, err
 	}
+	var privateKey *ecdsa.PrivateKey
+	if keySigner, ok := signer.(*PrivateKeySigner); ok {
+		privateKey = keySigner.privateKey
+	}
 
 	callOpts := &bind.CallOpts{
 		Pending:     false,
@@ -223,7 +273,7 @@ func NewContractSDK(ctx context.Context, options Web3Options, tracer trace.Trace
 		Context:     nil,
 	}
 
-	transactOpts, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(int64(options.ChainID)))
+	transactOpts, err := NewSignerTransactOpts(signer, big.NewInt(int64(options.ChainID)))
 	if err != nil {
 		return nil, err
 	}
@@ -234,6 +284,7 @@ func NewContractSDK(ctx context.Context, options Web3Options, tracer trace.Trace
 
 	web3SDK := &Web3SDK{
 		PrivateKey:   privateKey,
+		Signer:       signer,
 		Options:      options,
 		Client:       client,
 		CallOpts:     callOpts,
@@ -245,59 +296,48 @@ func NewContractSDK(ctx context.Context, options Web3Options, tracer trace.Trace
 	return web3SDK, nil
 }
 
-func getEthClient(ctx context.Context, options Web3Options, tracer trace.Tracer) (*ethclient.Client, error) {
+func getRpcClient(ctx context.Context, options Web3Options, tracer trace.Tracer) (*RpcClient, error) {
 	ctx, span := tracer.Start(ctx, "get_ethclient", trace.WithAttributes(attribute.Int("web3.chain_id", options.ChainID)))
 	defer span.End()
 
-	rpcs := strings.Split(options.RpcURL, ",")
-	var err error
-	var parsedURL *url.URL
-	var client *ethclient.Client
-	for _, u := range rpcs {
-		parsedURL, err = url.Parse(u)
-		if err != nil {
-			log.Warn().Msgf("Unable to parse web3 RPC URL: %v", err)
-			span.RecordError(errors.New("Unable to parse web3 RPC URL"))
-			continue
-		}
-
-		span.AddEvent("ethclient.dial", trace.WithAttributes(attribute.String("web3.rpc_url", parsedURL.Host)))
-		client, err = ethclient.Dial(u)
-		if err != nil {
-			log.Warn().Msgf("Failed to connect to %s: %v", parsedURL.Host, err)
-			span.RecordError(fmt.Errorf("Failed to connect to %s", parsedURL.Host))
-			continue
-		} else {
-			log.Info().Msgf("Connected to %s", parsedURL.Host)
-			span.AddEvent("ethclient.connected")
-			break
-		}
+	client, err := NewRpcClient(options)
+	if err != nil {
+		span.SetStatus(codes.Error, "Unable to parse web3 RPC URL")
+		return nil, err
 	}
-	if client == nil {
+	span.AddEvent("ethclient.dial", trace.WithAttributes(attribute.Int("web3.rpc_urls", len(client.endpoints))))
+	err = client.Connect(ctx)
+	if err != nil {
+		span.RecordError(err)
 		span.SetStatus(codes.Error, "Failed to connect with web3 RPC URL")
-		return nil, errors.New("Failed to connect to a web3 RPC provider")
+		return nil, err
 	}
+	span.AddEvent("ethclient.connected")
 
+	go client.Start(ctx)
 	return client, nil
 }
 
-func (sdk *Web3SDK) getBlockNumber() (uint64, error) {
-	var blockNumberHex string
-	err := sdk.Client.Client().Call(&blockNumberHex, "eth_blockNumber")
+func (sdk *Web3SDK) GetBlockNumber() (uint64, error) {
+	blockNumber, err := sdk.Client.BlockNumber(context.Background())
 	if err != nil {
-		log.Error().Msgf("error for getBlockNumber: %s", err.Error())
+		log.Error().Msgf("error for GetBlockNumber: %s", err.Error())
 		return 0, err
 	}
-	blockNumberHex = strings.TrimPrefix(blockNumberHex, "0x")
-	return strconv.ParseUint(blockNumberHex, 16, 64)
+	return blockNumber, nil
 }
 
-func (sdk *Web3SDK) WaitTx(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
-	return bind.WaitMined(ctx, sdk.Client, tx)
+// start watching the chain for the events the channels are subscribed to
+func (sdk *Web3SDK) StartEvents(events *EventChannels, ctx context.Context, cm *system.CleanupManager) error {
+	return events.Start(sdk, ctx, cm)
 }
 
 func (sdk *Web3SDK) GetAddress() common.Address {
-	return crypto.PubkeyToAddress(GetPublicKey(sdk.PrivateKey))
+	return sdk.Signer.Address()
+}
+
+func (sdk *Web3SDK) GetSigner() Signer {
+	return sdk.Signer
 }
 
 func (sdk *Web3SDK) GetBalance(address string) (*big.Int, error) {
@@ -342,3 +382,6 @@ func (sdk *Web3SDK) GetLPBalance(address string) (*big.Int, error) {
 	}
 	return &lpBalance, nil
 }
+
+// Compile-time interface check:
+var _ Web3Client = (*Web3SDK)(nil)
@@ -118,13 +118,12 @@ func TestGetBlockNumber(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to create Web3SDK: %v", err)
 	}
-	var blockNumberHex string
-	err = sdk.Client.Client().Call(&blockNumberHex, "eth_blockNumber")
+	blockNumber, err := sdk.Client.BlockNumber(context.Background())
 	if err != nil {
 		t.Fatalf("error for getBlockNumber: %s", err.Error())
 	}
 
-	t.Logf("Block number: %s\n", blockNumberHex)
+	t.Logf("Block number: %d\n", blockNumber)
 }
 
 func generateNewAddressWoLp() string {
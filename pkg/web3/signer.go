@@ -0,0 +1,158 @@
+package web3
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// a hex private key in WEB3_PRIVATE_KEY
+	SIGNER_KEY = "key"
+	// an encrypted keystore file as written by geth or clef
+	SIGNER_KEYSTORE = "keystore"
+	// a secp256k1 key held in AWS KMS
+	SIGNER_AWS_KMS = "aws-kms"
+	// a secp256k1 key held in Google Cloud KMS
+	SIGNER_GCP_KMS = "gcp-kms"
+	// an HTTP endpoint that signs for us e.g. in front of a hardware wallet
+	SIGNER_REMOTE = "remote"
+)
+
+var SIGNERS = []string{SIGNER_KEY, SIGNER_KEYSTORE, SIGNER_AWS_KMS, SIGNER_GCP_KMS, SIGNER_REMOTE}
+
+// something that holds the key for an address and signs hashes with it
+// so the key itself never has to be in our memory or environment
+// signatures are 65 bytes of [R || S || V] with V as 0 or 1
+type Signer interface {
+	Address() common.Address
+	SignHash(hash []byte) ([]byte, error)
+}
+
+// the signer the web3 options ask for
+func NewSigner(options Web3Options) (Signer, error) {
+	switch options.Signer {
+	case "", SIGNER_KEY:
+		return NewPrivateKeySigner(options.PrivateKey)
+	case SIGNER_KEYSTORE:
+		return NewKeystoreSigner(options.KeystorePath, options.KeystorePassword)
+	case SIGNER_AWS_KMS:
+		return NewAWSKMSSigner(options.KMSKeyID, options.KMSRegion, options.KMSEndpoint)
+	case SIGNER_GCP_KMS:
+		return NewGCPKMSSigner(options.KMSKeyID, options.KMSEndpoint)
+	case SIGNER_REMOTE:
+		return NewRemoteSigner(options.RemoteSignerURL, options.RemoteSignerToken, options.SignerAddress)
+	default:
+		return nil, fmt.Errorf("unknown signer %s", options.Signer)
+	}
+}
+
+// the address the web3 options sign as, for when we need it
+// before the SDK is made and would rather not unlock the key
+func GetSignerAddress(options Web3Options) (common.Address, error) {
+	switch options.Signer {
+	case SIGNER_KEYSTORE:
+		keyJSON, err := os.ReadFile(options.KeystorePath)
+		if err != nil {
+			return common.Address{}, err
+		}
+		var key struct {
+			Address string `json:"address"`
+		}
+		err = json.Unmarshal(keyJSON, &key)
+		if err != nil || !common.IsHexAddress(key.Address) {
+			break
+		}
+		return common.HexToAddress(key.Address), nil
+	case SIGNER_REMOTE:
+		if common.IsHexAddress(options.SignerAddress) {
+			return common.HexToAddress(options.SignerAddress), nil
+		}
+	}
+	signer, err := NewSigner(options)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return signer.Address(), nil
+}
+
+type PrivateKeySigner struct {
+	privateKey *ecdsa.PrivateKey
+}
+
+func NewPrivateKeySigner(privateKey string) (*PrivateKeySigner, error) {
+	key, err := ParsePrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return &PrivateKeySigner{privateKey: key}, nil
+}
+
+func (signer *PrivateKeySigner) Address() common.Address {
+	return GetAddress(signer.privateKey)
+}
+
+func (signer *PrivateKeySigner) SignHash(hash []byte) ([]byte, error) {
+	return crypto.Sign(hash, signer.privateKey)
+}
+
+// the key is only ever decrypted in memory, the password
+// can be given directly or as the path of a file holding it
+func NewKeystoreSigner(path string, password string) (*PrivateKeySigner, error) {
+	if path == "" {
+		return nil, errors.New("the keystore signer needs a keystore file")
+	}
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if passwordFile, ok := strings.CutPrefix(password, "@"); ok {
+		bs, err := os.ReadFile(passwordFile)
+		if err != nil {
+			return nil, err
+		}
+		password = strings.TrimRight(string(bs), "\r\n")
+	}
+	key, err := keystore.DecryptKey(keyJSON, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore %s: %w", path, err)
+	}
+	return &PrivateKeySigner{privateKey: key.PrivateKey}, nil
+}
+
+func SignMessageWithSigner(signer Signer, message []byte) ([]byte, error) {
+	hash := crypto.Keccak256Hash(message)
+	return signer.SignHash(hash.Bytes())
+}
+
+// transact options that sign with the signer rather than a key we hold
+func NewSignerTransactOpts(signer Signer, chainID *big.Int) (*bind.TransactOpts, error) {
+	if chainID == nil {
+		return nil, bind.ErrNoChainID
+	}
+	txSigner := types.LatestSignerForChainID(chainID)
+	from := signer.Address()
+	return &bind.TransactOpts{
+		From: from,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != from {
+				return nil, bind.ErrNotAuthorized
+			}
+			signature, err := signer.SignHash(txSigner.Hash(tx).Bytes())
+			if err != nil {
+				return nil, err
+			}
+			return tx.WithSignature(txSigner, signature)
+		},
+	}, nil
+}
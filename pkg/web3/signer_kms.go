@@ -0,0 +1,401 @@
+package web3
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// how long we give a KMS to answer
+	KMS_REQUEST_TIMEOUT = 30 * time.Second
+
+	GCP_KMS_ENDPOINT       = "https://cloudkms.googleapis.com"
+	GCP_METADATA_TOKEN_URL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+)
+
+var secp256k1N = crypto.S256().Params().N
+var secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+type derSignature struct {
+	R *big.Int
+	S *big.Int
+}
+
+// KMS hands back public keys as DER SubjectPublicKeyInfo
+func parseKMSPublicKey(der []byte) (*ecdsa.PublicKey, error) {
+	var info subjectPublicKeyInfo
+	_, err := asn1.Unmarshal(der, &info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse KMS public key: %w", err)
+	}
+	publicKey, err := crypto.UnmarshalPubkey(info.PublicKey.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("KMS key is not a secp256k1 key: %w", err)
+	}
+	return publicKey, nil
+}
+
+// turn the DER signature a KMS gives us into the [R || S || V] form ethereum uses
+// S is moved into the lower half of the curve as ethereum requires and
+// the recovery ID is whichever one gives back our public key
+func convertDERSignature(hash []byte, der []byte, publicKey *ecdsa.PublicKey) ([]byte, error) {
+	var parsed derSignature
+	_, err := asn1.Unmarshal(der, &parsed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse KMS signature: %w", err)
+	}
+	if parsed.R == nil || parsed.S == nil || parsed.R.Sign() <= 0 || parsed.S.Sign() <= 0 {
+		return nil, errors.New("invalid KMS signature")
+	}
+	s := parsed.S
+	if s.Cmp(secp256k1HalfN) > 0 {
+		s = new(big.Int).Sub(secp256k1N, s)
+	}
+	signature := make([]byte, crypto.SignatureLength)
+	parsed.R.FillBytes(signature[0:32])
+	s.FillBytes(signature[32:64])
+	expected := crypto.FromECDSAPub(publicKey)
+	for v := byte(0); v < 2; v++ {
+		signature[crypto.RecoveryIDOffset] = v
+		recovered, err := crypto.Ecrecover(hash, signature)
+		if err == nil && bytes.Equal(recovered, expected) {
+			return signature, nil
+		}
+	}
+	return nil, errors.New("KMS signature does not match the key")
+}
+
+func doKMSRequest(req *http.Request, out interface{}) error {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("KMS request to %s failed with %d: %s", req.URL.Host, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, out)
+}
+
+// a key in AWS KMS with the ECC_SECG_P256K1 key spec, credentials are
+// read from the usual AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and
+// AWS_SESSION_TOKEN variables
+type AWSKMSSigner struct {
+	keyID     string
+	region    string
+	endpoint  string
+	publicKey *ecdsa.PublicKey
+	address   common.Address
+	// so the tests can pin the time the requests are signed at
+	now func() time.Time
+}
+
+func NewAWSKMSSigner(keyID string, region string, endpoint string) (*AWSKMSSigner, error) {
+	if keyID == "" {
+		return nil, errors.New("the aws-kms signer needs a KMS key id")
+	}
+	if region == "" {
+		region = os.Getenv("AWS_REGION")
+	}
+	if region == "" {
+		return nil, errors.New("the aws-kms signer needs a KMS region")
+	}
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://kms.%s.amazonaws.com", region)
+	}
+	signer := &AWSKMSSigner{
+		keyID:    keyID,
+		region:   region,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		now:      time.Now,
+	}
+	var res struct {
+		PublicKey string `json:"PublicKey"`
+	}
+	err := signer.call("GetPublicKey", map[string]string{"KeyId": keyID}, &res)
+	if err != nil {
+		return nil, err
+	}
+	der, err := base64.StdEncoding.DecodeString(res.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	signer.publicKey, err = parseKMSPublicKey(der)
+	if err != nil {
+		return nil, err
+	}
+	signer.address = crypto.PubkeyToAddress(*signer.publicKey)
+	return signer, nil
+}
+
+func (signer *AWSKMSSigner) Address() common.Address {
+	return signer.address
+}
+
+func (signer *AWSKMSSigner) SignHash(hash []byte) ([]byte, error) {
+	var res struct {
+		Signature string `json:"Signature"`
+	}
+	err := signer.call("Sign", map[string]string{
+		"KeyId":            signer.keyID,
+		"Message":          base64.StdEncoding.EncodeToString(hash),
+		"MessageType":      "DIGEST",
+		"SigningAlgorithm": "ECDSA_SHA_256",
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	der, err := base64.StdEncoding.DecodeString(res.Signature)
+	if err != nil {
+		return nil, err
+	}
+	return convertDERSignature(hash, der, signer.publicKey)
+}
+
+func (signer *AWSKMSSigner) call(action string, payload interface{}, out interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), KMS_REQUEST_TIMEOUT)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, signer.endpoint+"/", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService."+action)
+	err = signAWSRequest(req, body, signer.region, "kms", signer.now())
+	if err != nil {
+		return err
+	}
+	return doKMSRequest(req, out)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// add an AWS signature version 4 to the request
+func signAWSRequest(req *http.Request, body []byte, region string, service string, now time.Time) error {
+	accessKey := os.Getenv("AWS_ACCESS_KEY_ID")
+	secretKey := os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if accessKey == "" || secretKey == "" {
+		return errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set to use AWS KMS")
+	}
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	if token := os.Getenv("AWS_SESSION_TOKEN"); token != "" {
+		req.Header.Set("X-Amz-Security-Token", token)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := []string{}
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	canonicalHeaders := ""
+	for _, name := range names {
+		canonicalHeaders += name + ":" + headers[name] + "\n"
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		sha256Hex(body),
+	}, "\n")
+	scope := fmt.Sprintf("%s/%s/%s/aws4_request", date, region, service)
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signedHeaders, signature,
+	))
+	return nil
+}
+
+// a key version in Google Cloud KMS with the EC_SIGN_SECP256K1_SHA256 algorithm
+// keyName is the full projects/.../cryptoKeyVersions/N resource name
+// we use GOOGLE_OAUTH_ACCESS_TOKEN if it is set and otherwise ask the
+// metadata server for a token, as is done on GCE and GKE
+type GCPKMSSigner struct {
+	keyName   string
+	endpoint  string
+	publicKey *ecdsa.PublicKey
+	address   common.Address
+
+	tokenMutex   sync.Mutex
+	token        string
+	tokenExpires time.Time
+	tokenURL     string
+}
+
+func NewGCPKMSSigner(keyName string, endpoint string) (*GCPKMSSigner, error) {
+	if keyName == "" {
+		return nil, errors.New("the gcp-kms signer needs a KMS key version name")
+	}
+	if endpoint == "" {
+		endpoint = GCP_KMS_ENDPOINT
+	}
+	signer := &GCPKMSSigner{
+		keyName:  strings.TrimPrefix(keyName, "/"),
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		tokenURL: GCP_METADATA_TOKEN_URL,
+	}
+	var res struct {
+		Pem string `json:"pem"`
+	}
+	err := signer.call(http.MethodGet, "/publicKey", nil, &res)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode([]byte(res.Pem))
+	if block == nil {
+		return nil, errors.New("KMS public key is not PEM encoded")
+	}
+	signer.publicKey, err = parseKMSPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	signer.address = crypto.PubkeyToAddress(*signer.publicKey)
+	return signer, nil
+}
+
+func (signer *GCPKMSSigner) Address() common.Address {
+	return signer.address
+}
+
+func (signer *GCPKMSSigner) SignHash(hash []byte) ([]byte, error) {
+	var res struct {
+		Signature string `json:"signature"`
+	}
+	err := signer.call(http.MethodPost, ":asymmetricSign", map[string]interface{}{
+		"digest": map[string]string{
+			"sha256": base64.StdEncoding.EncodeToString(hash),
+		},
+	}, &res)
+	if err != nil {
+		return nil, err
+	}
+	der, err := base64.StdEncoding.DecodeString(res.Signature)
+	if err != nil {
+		return nil, err
+	}
+	return convertDERSignature(hash, der, signer.publicKey)
+}
+
+func (signer *GCPKMSSigner) getToken(ctx context.Context) (string, error) {
+	if token := os.Getenv("GOOGLE_OAUTH_ACCESS_TOKEN"); token != "" {
+		return token, nil
+	}
+	signer.tokenMutex.Lock()
+	defer signer.tokenMutex.Unlock()
+	if signer.token != "" && time.Now().Before(signer.tokenExpires) {
+		return signer.token, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, signer.tokenURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	var res struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	err = doKMSRequest(req, &res)
+	if err != nil {
+		return "", fmt.Errorf("failed to get a GCP access token: %w", err)
+	}
+	signer.token = res.AccessToken
+	// refresh a minute before it runs out
+	signer.tokenExpires = time.Now().Add(time.Duration(res.ExpiresIn)*time.Second - time.Minute)
+	return signer.token, nil
+}
+
+func (signer *GCPKMSSigner) call(method string, suffix string, payload interface{}, out interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), KMS_REQUEST_TIMEOUT)
+	defer cancel()
+	token, err := signer.getToken(ctx)
+	if err != nil {
+		return err
+	}
+	var body io.Reader
+	if payload != nil {
+		bs, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(bs)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/v1/%s%s", signer.endpoint, signer.keyName, suffix), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return doKMSRequest(req, out)
+}
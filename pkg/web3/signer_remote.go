@@ -0,0 +1,112 @@
+package web3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+type RemoteSignRequest struct {
+	Address string `json:"address"`
+	Hash    string `json:"hash"`
+}
+
+type RemoteSignResponse struct {
+	Signature string `json:"signature"`
+}
+
+// hands hashes to a signing service over HTTP, this is how a hardware
+// wallet such as a Ledger or any other signer we do not speak to directly
+// can be used
+//
+// the service is sent POST {url}/sign with a RemoteSignRequest and answers
+// with a RemoteSignResponse, the signature is checked against the address
+// before we use it
+type RemoteSigner struct {
+	url     string
+	token   string
+	address common.Address
+}
+
+func NewRemoteSigner(url string, token string, address string) (*RemoteSigner, error) {
+	if url == "" {
+		return nil, errors.New("the remote signer needs a signer URL")
+	}
+	if !common.IsHexAddress(address) {
+		return nil, fmt.Errorf("the remote signer needs the address it signs for, got %q", address)
+	}
+	return &RemoteSigner{
+		url:     strings.TrimSuffix(url, "/"),
+		token:   token,
+		address: common.HexToAddress(address),
+	}, nil
+}
+
+func (signer *RemoteSigner) Address() common.Address {
+	return signer.address
+}
+
+func (signer *RemoteSigner) SignHash(hash []byte) ([]byte, error) {
+	body, err := json.Marshal(RemoteSignRequest{
+		Address: signer.address.Hex(),
+		Hash:    hexutil.Encode(hash),
+	})
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), KMS_REQUEST_TIMEOUT)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, signer.url+"/sign", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if signer.token != "" {
+		req.Header.Set("Authorization", "Bearer "+signer.token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	resBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("remote signer failed with %d: %s", resp.StatusCode, strings.TrimSpace(string(resBody)))
+	}
+	var res RemoteSignResponse
+	err = json.Unmarshal(resBody, &res)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := hexutil.Decode(res.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature from remote signer: %w", err)
+	}
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("remote signer signature must be %d bytes", crypto.SignatureLength)
+	}
+	// wallets tend to give the recovery ID as 27 or 28
+	if signature[crypto.RecoveryIDOffset] >= 27 {
+		signature[crypto.RecoveryIDOffset] -= 27
+	}
+	publicKey, err := crypto.SigToPub(hash, signature)
+	if err != nil {
+		return nil, err
+	}
+	if crypto.PubkeyToAddress(*publicKey) != signer.address {
+		return nil, fmt.Errorf("remote signer signed with %s not %s", crypto.PubkeyToAddress(*publicKey).Hex(), signer.address.Hex())
+	}
+	return signature, nil
+}
@@ -0,0 +1,220 @@
+package web3
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeystoreSigner(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	keyJSON, err := keystore.EncryptKey(&keystore.Key{
+		Id:         uuid.New(),
+		Address:    GetAddress(privateKey),
+		PrivateKey: privateKey,
+	}, "secret", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "key.json")
+	require.NoError(t, os.WriteFile(keyPath, keyJSON, 0600))
+	passwordPath := filepath.Join(dir, "password")
+	require.NoError(t, os.WriteFile(passwordPath, []byte("secret\n"), 0600))
+
+	signer, err := NewKeystoreSigner(keyPath, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, GetAddress(privateKey), signer.Address())
+
+	signer, err = NewKeystoreSigner(keyPath, "@"+passwordPath)
+	require.NoError(t, err)
+	assert.Equal(t, GetAddress(privateKey), signer.Address())
+
+	_, err = NewKeystoreSigner(keyPath, "wrong")
+	assert.Error(t, err)
+
+	address, err := GetSignerAddress(Web3Options{Signer: SIGNER_KEYSTORE, KeystorePath: keyPath})
+	require.NoError(t, err)
+	assert.Equal(t, GetAddress(privateKey), address)
+}
+
+func TestSignerTransactOpts(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := &PrivateKeySigner{privateKey: privateKey}
+	chainID := big.NewInt(1337)
+	opts, err := NewSignerTransactOpts(signer, chainID)
+	require.NoError(t, err)
+	assert.Equal(t, signer.Address(), opts.From)
+
+	to := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	tx, err := opts.Signer(opts.From, types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     1,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Gas:       21000,
+		To:        &to,
+	}))
+	require.NoError(t, err)
+	from, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+	require.NoError(t, err)
+	assert.Equal(t, signer.Address(), from)
+
+	_, err = opts.Signer(to, tx)
+	assert.Error(t, err)
+}
+
+// sign like a KMS would, as DER and without caring which half S is in
+func signDER(t *testing.T, privateKey *ecdsa.PrivateKey, hash []byte, highS bool) []byte {
+	sig, err := crypto.Sign(hash, privateKey)
+	require.NoError(t, err)
+	s := new(big.Int).SetBytes(sig[32:64])
+	if highS {
+		s = new(big.Int).Sub(secp256k1N, s)
+	}
+	der, err := asn1.Marshal(derSignature{R: new(big.Int).SetBytes(sig[0:32]), S: s})
+	require.NoError(t, err)
+	return der
+}
+
+func marshalPublicKey(t *testing.T, publicKey *ecdsa.PublicKey) []byte {
+	curve, err := asn1.Marshal(asn1.ObjectIdentifier{1, 3, 132, 0, 10})
+	require.NoError(t, err)
+	point := crypto.FromECDSAPub(publicKey)
+	der, err := asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1},
+			Parameters: asn1.RawValue{FullBytes: curve},
+		},
+		PublicKey: asn1.BitString{Bytes: point, BitLength: len(point) * 8},
+	})
+	require.NoError(t, err)
+	return der
+}
+
+func TestConvertDERSignature(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	hash := crypto.Keccak256([]byte("hello"))
+
+	publicKey, err := parseKMSPublicKey(marshalPublicKey(t, &privateKey.PublicKey))
+	require.NoError(t, err)
+	assert.Equal(t, GetAddress(privateKey), crypto.PubkeyToAddress(*publicKey))
+
+	for _, highS := range []bool{false, true} {
+		sig, err := convertDERSignature(hash, signDER(t, privateKey, hash, highS), publicKey)
+		require.NoError(t, err)
+		recovered, err := crypto.SigToPub(hash, sig)
+		require.NoError(t, err)
+		assert.Equal(t, GetAddress(privateKey), crypto.PubkeyToAddress(*recovered))
+		assert.True(t, new(big.Int).SetBytes(sig[32:64]).Cmp(secp256k1HalfN) <= 0)
+	}
+
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	_, err = convertDERSignature(hash, signDER(t, other, hash, false), publicKey)
+	assert.Error(t, err)
+}
+
+func TestAWSKMSSigner(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_SESSION_TOKEN", "")
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var req map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "alias/lilypad", req["KeyId"])
+		switch r.Header.Get("X-Amz-Target") {
+		case "TrentService.GetPublicKey":
+			json.NewEncoder(w).Encode(map[string]string{
+				"PublicKey": base64.StdEncoding.EncodeToString(marshalPublicKey(t, &privateKey.PublicKey)),
+			})
+		case "TrentService.Sign":
+			assert.Equal(t, "DIGEST", req["MessageType"])
+			hash, err := base64.StdEncoding.DecodeString(req["Message"])
+			require.NoError(t, err)
+			json.NewEncoder(w).Encode(map[string]string{
+				"Signature": base64.StdEncoding.EncodeToString(signDER(t, privateKey, hash, true)),
+			})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	signer, err := NewSigner(Web3Options{Signer: SIGNER_AWS_KMS, KMSKeyID: "alias/lilypad", KMSRegion: "us-east-1", KMSEndpoint: server.URL})
+	require.NoError(t, err)
+	assert.Equal(t, GetAddress(privateKey), signer.Address())
+
+	message := []byte("hello")
+	sig, err := SignMessageWithSigner(signer, message)
+	require.NoError(t, err)
+	address, err := GetAddressFromSignedMessage(message, sig)
+	require.NoError(t, err)
+	assert.Equal(t, GetAddress(privateKey), address)
+}
+
+func TestRemoteSigner(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sign" || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req RemoteSignRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		hash, err := hexutil.Decode(req.Hash)
+		require.NoError(t, err)
+		sig, err := crypto.Sign(hash, privateKey)
+		require.NoError(t, err)
+		// answer the way a wallet would
+		sig[crypto.RecoveryIDOffset] += 27
+		json.NewEncoder(w).Encode(RemoteSignResponse{Signature: hexutil.Encode(sig)})
+	}))
+	defer server.Close()
+
+	signer, err := NewRemoteSigner(server.URL, "token", GetAddress(privateKey).Hex())
+	require.NoError(t, err)
+	message := []byte("hello")
+	sig, err := SignMessageWithSigner(signer, message)
+	require.NoError(t, err)
+	address, err := GetAddressFromSignedMessage(message, sig)
+	require.NoError(t, err)
+	assert.Equal(t, GetAddress(privateKey), address)
+
+	// a signature for another address is refused
+	signer, err = NewRemoteSigner(server.URL, "token", "0x1111111111111111111111111111111111111111")
+	require.NoError(t, err)
+	_, err = SignMessageWithSigner(signer, message)
+	assert.Error(t, err)
+
+	_, err = NewRemoteSigner(server.URL, "token", "")
+	assert.Error(t, err)
+}
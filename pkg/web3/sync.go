@@ -0,0 +1,326 @@
+package web3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+)
+
+const (
+	// how many of the blocks we synced up to we remember
+	// a reorg deeper than this cannot be rolled back cleanly
+	SYNC_RECENT_BLOCKS = 64
+	// the checkpoint is saved whenever we handle logs and
+	// at least this often when there are none
+	SYNC_CHECKPOINT_INTERVAL = time.Minute
+
+	DEFAULT_SYNC_POLL_INTERVAL   = 2
+	DEFAULT_SYNC_MAX_BLOCK_RANGE = 1000
+)
+
+// where the chain sync keeps how far it has got
+type CheckpointStore interface {
+	GetChainCheckpoint(chainID int, contract string) (*data.ChainCheckpoint, error)
+	UpdateChainCheckpoint(checkpoint data.ChainCheckpoint) (*data.ChainCheckpoint, error)
+}
+
+// the calls the chain sync makes, this is the RpcClient outside of tests
+type syncBackend interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// follows the logs of one contract by asking for each range of blocks in
+// turn rather than subscribing, so nothing is lost while we are disconnected
+// or stopped and a reorg can be noticed and rolled back
+type contractSync struct {
+	chainID       int
+	contract      string
+	address       common.Address
+	backend       syncBackend
+	store         CheckpointStore
+	handle        func(entry types.Log) error
+	confirmations uint64
+	maxBlockRange uint64
+	service       system.Service
+
+	checkpoint *data.ChainCheckpoint
+	lastSaved  time.Time
+	// the logs we have handled that a reorg could still take back, oldest first
+	// these are not kept across restarts so a reorg we find just after one
+	// only replays the logs of the new chain
+	recent []types.Log
+}
+
+func (s *contractSync) header(ctx context.Context, number uint64) (*types.Header, error) {
+	return s.backend.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+}
+
+// the newest block we are willing to handle the logs of
+func (s *contractSync) safeHead(ctx context.Context) (uint64, error) {
+	head, err := s.backend.BlockNumber(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if head < s.confirmations {
+		return 0, nil
+	}
+	return head - s.confirmations, nil
+}
+
+func (s *contractSync) save() error {
+	s.checkpoint.UpdatedAt = time.Now().Unix()
+	// the store keeps what we give it so it gets its own copy of the blocks
+	saved := *s.checkpoint
+	saved.Blocks = append([]data.ChainBlock{}, s.checkpoint.Blocks...)
+	_, err := s.store.UpdateChainCheckpoint(saved)
+	if err != nil {
+		return err
+	}
+	s.lastSaved = time.Now()
+	return nil
+}
+
+// carry on from the checkpoint or start from the head if there is not one
+// like the subscriptions do, a contract that has moved starts again too
+func (s *contractSync) load(ctx context.Context) error {
+	checkpoint, err := s.store.GetChainCheckpoint(s.chainID, s.contract)
+	if err != nil {
+		return err
+	}
+	if checkpoint != nil && common.HexToAddress(checkpoint.Address) == s.address && len(checkpoint.Blocks) > 0 {
+		copied := *checkpoint
+		copied.Blocks = append([]data.ChainBlock{}, checkpoint.Blocks...)
+		s.checkpoint = &copied
+		system.Info(s.service, "resuming chain sync", fmt.Sprintf("%s on chain %d from block %d", s.contract, s.chainID, copied.BlockNumber))
+		return nil
+	}
+	head, err := s.safeHead(ctx)
+	if err != nil {
+		return err
+	}
+	header, err := s.header(ctx, head)
+	if err != nil {
+		return err
+	}
+	block := data.ChainBlock{Number: head, Hash: header.Hash().Hex()}
+	s.checkpoint = &data.ChainCheckpoint{
+		ChainID:     s.chainID,
+		Contract:    s.contract,
+		Address:     s.address.Hex(),
+		BlockNumber: block.Number,
+		BlockHash:   block.Hash,
+		Blocks:      []data.ChainBlock{block},
+	}
+	return s.save()
+}
+
+// if the block we synced up to is no longer on the chain go back to the newest
+// one that is and hand back the logs after it marked as removed, newest first
+func (s *contractSync) rollback(ctx context.Context) error {
+	header, err := s.header(ctx, s.checkpoint.BlockNumber)
+	if err != nil {
+		return err
+	}
+	if header.Hash() == common.HexToHash(s.checkpoint.BlockHash) {
+		return nil
+	}
+
+	blocks := s.checkpoint.Blocks
+	ancestor := -1
+	for i := len(blocks) - 1; i >= 0; i-- {
+		header, err := s.header(ctx, blocks[i].Number)
+		if err != nil {
+			return err
+		}
+		if header.Hash() == common.HexToHash(blocks[i].Hash) {
+			ancestor = i
+			break
+		}
+	}
+	var block data.ChainBlock
+	if ancestor >= 0 {
+		block = blocks[ancestor]
+		blocks = blocks[:ancestor+1]
+	} else {
+		// all we can do is start again from before the oldest block we know
+		number := uint64(0)
+		if len(blocks) > 0 && blocks[0].Number > 0 {
+			number = blocks[0].Number - 1
+		}
+		header, err := s.header(ctx, number)
+		if err != nil {
+			return err
+		}
+		block = data.ChainBlock{Number: number, Hash: header.Hash().Hex()}
+		blocks = []data.ChainBlock{block}
+		system.Error(s.service, fmt.Sprintf("reorg of %s on chain %d is deeper than the blocks we remember", s.contract, s.chainID), fmt.Errorf("going back to block %d", number))
+	}
+
+	removed := 0
+	for len(s.recent) > 0 && s.recent[len(s.recent)-1].BlockNumber > block.Number {
+		entry := s.recent[len(s.recent)-1]
+		s.recent = s.recent[:len(s.recent)-1]
+		entry.Removed = true
+		err := s.handle(entry)
+		if err != nil {
+			system.Error(s.service, fmt.Sprintf("error handling removed %s log", s.contract), err)
+		}
+		removed++
+	}
+	system.Info(s.service, "rolled back reorg", fmt.Sprintf("%s on chain %d from block %d to %d with %d logs removed", s.contract, s.chainID, s.checkpoint.BlockNumber, block.Number, removed))
+
+	s.checkpoint.BlockNumber = block.Number
+	s.checkpoint.BlockHash = block.Hash
+	s.checkpoint.Blocks = blocks
+	return s.save()
+}
+
+// handle the logs of the next range of blocks
+func (s *contractSync) step(ctx context.Context) error {
+	err := s.rollback(ctx)
+	if err != nil {
+		return err
+	}
+	head, err := s.safeHead(ctx)
+	if err != nil {
+		return err
+	}
+	if head <= s.checkpoint.BlockNumber {
+		return nil
+	}
+	from := s.checkpoint.BlockNumber + 1
+	to := head
+	if s.maxBlockRange > 0 && to-from+1 > s.maxBlockRange {
+		to = from + s.maxBlockRange - 1
+	}
+	// the header is fetched first so that if the chain changes while we are
+	// getting the logs the next rollback will notice and take them back
+	header, err := s.header(ctx, to)
+	if err != nil {
+		return err
+	}
+	logs, err := s.backend.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(to),
+		Addresses: []common.Address{s.address},
+	})
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(logs, func(i, j int) bool {
+		if logs[i].BlockNumber == logs[j].BlockNumber {
+			return logs[i].Index < logs[j].Index
+		}
+		return logs[i].BlockNumber < logs[j].BlockNumber
+	})
+	for _, entry := range logs {
+		err := s.handle(entry)
+		if err != nil {
+			system.Error(s.service, fmt.Sprintf("error handling %s log", s.contract), err)
+		}
+		s.recent = append(s.recent, entry)
+	}
+
+	block := data.ChainBlock{Number: to, Hash: header.Hash().Hex()}
+	s.checkpoint.BlockNumber = block.Number
+	s.checkpoint.BlockHash = block.Hash
+	s.checkpoint.Blocks = append(s.checkpoint.Blocks, block)
+	if len(s.checkpoint.Blocks) > SYNC_RECENT_BLOCKS {
+		s.checkpoint.Blocks = s.checkpoint.Blocks[len(s.checkpoint.Blocks)-SYNC_RECENT_BLOCKS:]
+	}
+	// a reorg cannot take back logs from before the oldest block we remember
+	oldest := s.checkpoint.Blocks[0].Number
+	for len(s.recent) > 0 && s.recent[0].BlockNumber <= oldest {
+		s.recent = s.recent[1:]
+	}
+
+	if len(logs) > 0 || time.Since(s.lastSaved) >= SYNC_CHECKPOINT_INTERVAL {
+		return s.save()
+	}
+	return nil
+}
+
+func (s *contractSync) run(ctx context.Context, pollInterval time.Duration) error {
+	err := s.load(ctx)
+	if err != nil {
+		return err
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		err := s.step(ctx)
+		if err != nil && ctx.Err() == nil {
+			system.Error(s.service, fmt.Sprintf("error syncing %s on chain %d", s.contract, s.chainID), err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// follow the events of each contract with subscribers from where we got to
+// last time, this is used in place of StartEvents by services with a store
+// so that events missed while we were down are replayed and reorgs undone
+//
+// the handlers are called in the order the logs are on the chain
+// and a handler will see a log again with Raw.Removed set if a reorg took it back
+func (sdk *Web3SDK) SyncEvents(events *EventChannels, checkpoints CheckpointStore, ctx context.Context) error {
+	if checkpoints == nil {
+		return errors.New("the chain sync needs somewhere to keep checkpoints")
+	}
+	pollInterval := time.Duration(sdk.Options.SyncPollInterval) * time.Second
+	if pollInterval <= 0 {
+		pollInterval = DEFAULT_SYNC_POLL_INTERVAL * time.Second
+	}
+	maxBlockRange := uint64(sdk.Options.SyncMaxBlockRange)
+	if maxBlockRange == 0 {
+		maxBlockRange = DEFAULT_SYNC_MAX_BLOCK_RANGE
+	}
+	for _, collection := range events.collections {
+		c, ok := collection.(EventLogCollection)
+		if !ok || !c.Subscribed() {
+			continue
+		}
+		address, ok := sdk.Contracts.Addresses[c.Contract()]
+		if !ok {
+			continue
+		}
+		s := &contractSync{
+			chainID:  sdk.Options.ChainID,
+			contract: c.Contract(),
+			address:  address,
+			backend:  sdk.Client,
+			store:    checkpoints,
+			handle: func(entry types.Log) error {
+				return c.HandleLog(sdk, entry)
+			},
+			confirmations: uint64(sdk.Options.SyncConfirmations),
+			maxBlockRange: maxBlockRange,
+			service:       sdk.Options.Service,
+		}
+		go func() {
+			for {
+				err := s.run(ctx, pollInterval)
+				if ctx.Err() != nil {
+					return
+				}
+				system.Error(sdk.Options.Service, fmt.Sprintf("error starting chain sync of %s, retry in %s", s.contract, pollInterval), err)
+				time.Sleep(pollInterval)
+			}
+		}()
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package web3
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeChain struct {
+	mutex   sync.Mutex
+	headers []*types.Header
+	logs    map[uint64][]types.Log
+}
+
+func newFakeChain(length int) *fakeChain {
+	chain := &fakeChain{logs: map[uint64][]types.Log{}}
+	chain.extend(length, "main")
+	return chain
+}
+
+// add blocks on top of the chain, the fork name makes their hashes differ
+func (chain *fakeChain) extend(count int, fork string) {
+	chain.mutex.Lock()
+	defer chain.mutex.Unlock()
+	for i := 0; i < count; i++ {
+		number := uint64(len(chain.headers))
+		parent := common.Hash{}
+		if number > 0 {
+			parent = chain.headers[number-1].Hash()
+		}
+		chain.headers = append(chain.headers, &types.Header{
+			Number:     new(big.Int).SetUint64(number),
+			ParentHash: parent,
+			Extra:      []byte(fork),
+		})
+	}
+}
+
+// drop the blocks from number up along with their logs
+func (chain *fakeChain) truncate(number uint64) {
+	chain.mutex.Lock()
+	defer chain.mutex.Unlock()
+	chain.headers = chain.headers[:number]
+	for block := range chain.logs {
+		if block >= number {
+			delete(chain.logs, block)
+		}
+	}
+}
+
+func (chain *fakeChain) addLog(number uint64, name string) {
+	chain.mutex.Lock()
+	defer chain.mutex.Unlock()
+	chain.logs[number] = append(chain.logs[number], types.Log{
+		BlockNumber: number,
+		BlockHash:   chain.headers[number].Hash(),
+		Index:       uint(len(chain.logs[number])),
+		Data:        []byte(name),
+	})
+}
+
+func (chain *fakeChain) BlockNumber(ctx context.Context) (uint64, error) {
+	chain.mutex.Lock()
+	defer chain.mutex.Unlock()
+	return uint64(len(chain.headers) - 1), nil
+}
+
+func (chain *fakeChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	chain.mutex.Lock()
+	defer chain.mutex.Unlock()
+	if number.Uint64() >= uint64(len(chain.headers)) {
+		return nil, ethereum.NotFound
+	}
+	return chain.headers[number.Uint64()], nil
+}
+
+func (chain *fakeChain) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	chain.mutex.Lock()
+	defer chain.mutex.Unlock()
+	logs := []types.Log{}
+	for number := query.FromBlock.Uint64(); number <= query.ToBlock.Uint64(); number++ {
+		logs = append(logs, chain.logs[number]...)
+	}
+	return logs, nil
+}
+
+type fakeCheckpointStore struct {
+	checkpoints map[string]data.ChainCheckpoint
+}
+
+func (store *fakeCheckpointStore) GetChainCheckpoint(chainID int, contract string) (*data.ChainCheckpoint, error) {
+	checkpoint, ok := store.checkpoints[data.GetChainCheckpointID(chainID, contract)]
+	if !ok {
+		return nil, nil
+	}
+	return &checkpoint, nil
+}
+
+func (store *fakeCheckpointStore) UpdateChainCheckpoint(checkpoint data.ChainCheckpoint) (*data.ChainCheckpoint, error) {
+	checkpoint.ID = data.GetChainCheckpointID(checkpoint.ChainID, checkpoint.Contract)
+	store.checkpoints[checkpoint.ID] = checkpoint
+	return &checkpoint, nil
+}
+
+func newTestContractSync(chain *fakeChain, store CheckpointStore, handled *[]string) *contractSync {
+	return &contractSync{
+		chainID:  1337,
+		contract: CONTRACT_STORAGE,
+		address:  common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		backend:  chain,
+		store:    store,
+		handle: func(entry types.Log) error {
+			name := string(entry.Data)
+			if entry.Removed {
+				name = "-" + name
+			}
+			*handled = append(*handled, name)
+			return nil
+		},
+		maxBlockRange: 4,
+	}
+}
+
+func TestContractSync(t *testing.T) {
+	ctx := context.Background()
+	chain := newFakeChain(10)
+	store := &fakeCheckpointStore{checkpoints: map[string]data.ChainCheckpoint{}}
+	handled := []string{}
+
+	// we start from the head so what came before is not replayed
+	chain.addLog(5, "old")
+	s := newTestContractSync(chain, store, &handled)
+	require.NoError(t, s.load(ctx))
+	assert.Equal(t, uint64(9), s.checkpoint.BlockNumber)
+
+	chain.extend(2, "main")
+	chain.addLog(10, "a")
+	chain.addLog(11, "b")
+	require.NoError(t, s.step(ctx))
+	assert.Equal(t, []string{"a", "b"}, handled)
+
+	// events while we are stopped are replayed from the checkpoint
+	// a few blocks at a time
+	chain.extend(6, "main")
+	chain.addLog(12, "c")
+	chain.addLog(17, "d")
+	handled = []string{}
+	s = newTestContractSync(chain, store, &handled)
+	require.NoError(t, s.load(ctx))
+	require.NoError(t, s.step(ctx))
+	assert.Equal(t, []string{"c"}, handled)
+	assert.Equal(t, uint64(15), s.checkpoint.BlockNumber)
+	require.NoError(t, s.step(ctx))
+	assert.Equal(t, []string{"c", "d"}, handled)
+	assert.Equal(t, uint64(17), s.checkpoint.BlockNumber)
+
+	// a reorg back to block 16 takes back d and replays the new chain
+	chain.extend(2, "main")
+	chain.addLog(18, "e")
+	require.NoError(t, s.step(ctx))
+	handled = []string{}
+	chain.truncate(16)
+	chain.extend(4, "fork")
+	chain.addLog(17, "f")
+	chain.addLog(19, "g")
+	require.NoError(t, s.step(ctx))
+	assert.Equal(t, []string{"-e", "-d", "f", "g"}, handled)
+	assert.Equal(t, chain.headers[19].Hash().Hex(), store.checkpoints[data.GetChainCheckpointID(1337, CONTRACT_STORAGE)].BlockHash)
+}
+
+func TestContractSyncConfirmations(t *testing.T) {
+	ctx := context.Background()
+	chain := newFakeChain(10)
+	store := &fakeCheckpointStore{checkpoints: map[string]data.ChainCheckpoint{}}
+	handled := []string{}
+	s := newTestContractSync(chain, store, &handled)
+	s.confirmations = 2
+	require.NoError(t, s.load(ctx))
+	assert.Equal(t, uint64(7), s.checkpoint.BlockNumber)
+
+	chain.extend(2, "main")
+	chain.addLog(10, "a")
+	chain.addLog(11, "b")
+	require.NoError(t, s.step(ctx))
+	assert.Empty(t, handled)
+	assert.Equal(t, uint64(9), s.checkpoint.BlockNumber)
+
+	// b does not have enough blocks on top of it yet
+	chain.extend(1, "main")
+	require.NoError(t, s.step(ctx))
+	assert.Equal(t, []string{"a"}, handled)
+	assert.Equal(t, uint64(10), s.checkpoint.BlockNumber)
+}
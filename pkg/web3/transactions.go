@@ -0,0 +1,250 @@
+package web3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+)
+
+const (
+	// how often we look for the receipt of a transaction we sent
+	TX_POLL_INTERVAL = time.Second
+	// how many polls the nonce has to be used without a receipt of ours
+	// before we say the transaction was dropped
+	TX_DROPPED_POLLS = 3
+)
+
+// called each time one of our transactions changes so it can be kept
+// somewhere that survives a restart
+type TransactionHandler func(tx data.Transaction) error
+
+func (sdk *Web3SDK) SetTransactionHandler(handler TransactionHandler) {
+	sdk.txMutex.Lock()
+	defer sdk.txMutex.Unlock()
+	sdk.txHandler = handler
+}
+
+func (sdk *Web3SDK) saveTransaction(record data.Transaction) {
+	sdk.txMutex.Lock()
+	handler := sdk.txHandler
+	sdk.txMutex.Unlock()
+	if handler == nil {
+		return
+	}
+	err := handler(record)
+	if err != nil {
+		system.Error(sdk.Options.Service, fmt.Sprintf("error saving transaction %s", record.ID), err)
+	}
+}
+
+// the nonce for our next transaction, this must be called with the tx lock held
+// we count up from what the chain told us until a send goes wrong
+func (sdk *Web3SDK) getNextNonce(ctx context.Context) (uint64, error) {
+	if sdk.nextNonce != nil {
+		return *sdk.nextNonce, nil
+	}
+	return sdk.Client.PendingNonceAt(ctx, sdk.GetAddress())
+}
+
+// send a contract call and wait for it to be mined
+// sends are queued so that each gets its own nonce even when
+// the services make calls from several goroutines at once
+func (sdk *Web3SDK) Transact(
+	ctx context.Context,
+	method string,
+	dealID string,
+	send func(opts *bind.TransactOpts) (*types.Transaction, error),
+) (*types.Receipt, error) {
+	sdk.txMutex.Lock()
+	nonce, err := sdk.getNextNonce(ctx)
+	if err != nil {
+		sdk.txMutex.Unlock()
+		system.Error(sdk.Options.Service, fmt.Sprintf("error getting nonce for %s", method), err)
+		return nil, err
+	}
+	opts := sdk.GetTransactOpts()
+	opts.Nonce = new(big.Int).SetUint64(nonce)
+	opts.Context = ctx
+	tx, err := send(opts)
+	if err != nil {
+		// we cannot tell if the nonce was used so ask the chain next time
+		sdk.nextNonce = nil
+		sdk.txMutex.Unlock()
+		system.Error(sdk.Options.Service, fmt.Sprintf("error submitting %s", method), err)
+		return nil, err
+	}
+	nextNonce := nonce + 1
+	sdk.nextNonce = &nextNonce
+	sdk.txMutex.Unlock()
+
+	system.Debug(sdk.Options.Service, fmt.Sprintf("submitted %s", method), tx.Hash().String())
+	system.DumpObjectDebug(tx)
+
+	now := time.Now().Unix()
+	record := data.Transaction{
+		ID:        data.GetTransactionID(sdk.Options.ChainID, sdk.GetAddress().String(), nonce),
+		ChainID:   sdk.Options.ChainID,
+		From:      sdk.GetAddress().String(),
+		Nonce:     nonce,
+		Method:    method,
+		DealID:    dealID,
+		Status:    data.TransactionPending,
+		CreatedAt: now,
+	}
+	return sdk.waitTransaction(ctx, record, tx)
+}
+
+// keep the record up to date while we wait for any version of it to be mined
+func (sdk *Web3SDK) waitTransaction(ctx context.Context, record data.Transaction, tx *types.Transaction) (*types.Receipt, error) {
+	hashes := []common.Hash{}
+	for _, hash := range record.Hashes {
+		hashes = append(hashes, common.HexToHash(hash))
+	}
+	onSent := func(sent *types.Transaction) {
+		raw, err := sent.MarshalBinary()
+		if err != nil {
+			system.Error(sdk.Options.Service, fmt.Sprintf("error encoding transaction %s", record.ID), err)
+		}
+		record.Hash = sent.Hash().String()
+		record.Hashes = append(record.Hashes, record.Hash)
+		record.RawTx = hexutil.Encode(raw)
+		record.UpdatedAt = time.Now().Unix()
+		sdk.saveTransaction(record)
+	}
+	if len(hashes) == 0 || hashes[len(hashes)-1] != tx.Hash() {
+		hashes = append(hashes, tx.Hash())
+		onSent(tx)
+	}
+
+	receipt, err := sdk.waitTx(ctx, hashes, tx, onSent)
+	if err != nil {
+		// if we were stopped the transaction is still pending
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		record.Status = data.TransactionDropped
+		record.Error = err.Error()
+	} else {
+		record.Hash = receipt.TxHash.String()
+		record.Status = data.TransactionMined
+		if receipt.Status == types.ReceiptStatusFailed {
+			record.Status = data.TransactionReverted
+		}
+	}
+	record.UpdatedAt = time.Now().Unix()
+	sdk.saveTransaction(record)
+	return receipt, err
+}
+
+// pick up the transactions that were still pending when we stopped
+// each is sent again in case the node forgot about it and then waited on
+func (sdk *Web3SDK) ResumeTransactions(ctx context.Context, records []data.Transaction) {
+	for _, record := range records {
+		if record.Status != data.TransactionPending ||
+			record.ChainID != sdk.Options.ChainID ||
+			!strings.EqualFold(record.From, sdk.GetAddress().String()) {
+			continue
+		}
+		raw, err := hexutil.Decode(record.RawTx)
+		if err != nil {
+			system.Error(sdk.Options.Service, fmt.Sprintf("error decoding transaction %s", record.ID), err)
+			continue
+		}
+		tx := new(types.Transaction)
+		err = tx.UnmarshalBinary(raw)
+		if err != nil {
+			system.Error(sdk.Options.Service, fmt.Sprintf("error decoding transaction %s", record.ID), err)
+			continue
+		}
+		// a node that has it or has already mined it will say so
+		err = sdk.Client.SendTransaction(ctx, tx)
+		if err != nil {
+			system.Debug(sdk.Options.Service, fmt.Sprintf("resending transaction %s", record.ID), err.Error())
+		}
+		system.Info(sdk.Options.Service, "resuming transaction", record)
+		go func(record data.Transaction) {
+			_, err := sdk.waitTransaction(ctx, record, tx)
+			if err != nil {
+				system.Error(sdk.Options.Service, fmt.Sprintf("error resuming transaction %s", record.ID), err)
+			}
+		}(record)
+	}
+}
+
+// wait for a transaction to be mined, if it has not been after the
+// stuck timeout we send it again with bumped fees up to the max bumps
+// the receipt is for whichever of the transactions made it
+func (sdk *Web3SDK) WaitTx(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	return sdk.waitTx(ctx, []common.Hash{tx.Hash()}, tx, nil)
+}
+
+// hashes are every version of the transaction we have sent and latest is
+// the one to bump, onReplace is told about each replacement we send
+func (sdk *Web3SDK) waitTx(ctx context.Context, hashes []common.Hash, latest *types.Transaction, onReplace func(*types.Transaction)) (*types.Receipt, error) {
+	stuckTimeout := time.Duration(sdk.Options.GasStuckTimeout) * time.Second
+	lastSent := time.Now()
+	bumps := 0
+	// an RPC behind the others can miss our receipt so we
+	// only give up on a used nonce after a few polls
+	usedPolls := 0
+	ticker := time.NewTicker(TX_POLL_INTERVAL)
+	defer ticker.Stop()
+	for {
+		// look at the nonce first so that if it has been used
+		// one of ours would already show up below
+		mined, err := sdk.Client.NonceAt(ctx, sdk.GetAddress(), nil)
+		nonceUsed := err == nil && mined > latest.Nonce()
+		for _, hash := range hashes {
+			receipt, err := sdk.Client.TransactionReceipt(ctx, hash)
+			if err == nil {
+				return receipt, nil
+			}
+			if !errors.Is(err, ethereum.NotFound) {
+				system.Debug(sdk.Options.Service, "error getting tx receipt", err)
+			}
+		}
+		if nonceUsed {
+			usedPolls++
+			if usedPolls >= TX_DROPPED_POLLS {
+				return nil, fmt.Errorf("nonce %d was used by a transaction we did not send", latest.Nonce())
+			}
+		} else {
+			usedPolls = 0
+		}
+
+		if stuckTimeout > 0 && bumps < sdk.Options.GasMaxBumps && time.Since(lastSent) >= stuckTimeout {
+			replacement, err := sdk.replaceTx(ctx, latest)
+			if err != nil {
+				// the original may have been mined in the meantime
+				// so we carry on waiting for it either way
+				system.Error(sdk.Options.Service, fmt.Sprintf("error replacing stuck tx %s", latest.Hash()), err)
+			} else {
+				system.Info(sdk.Options.Service, fmt.Sprintf("replaced stuck tx %s", latest.Hash()), replacement.Hash().String())
+				hashes = append(hashes, replacement.Hash())
+				latest = replacement
+				bumps++
+				if onReplace != nil {
+					onReplace(replacement)
+				}
+			}
+			lastSent = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -2,8 +2,13 @@ package web3
 
 import (
 	"context"
+	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lilypad-tech/lilypad/pkg/data"
 	"github.com/lilypad-tech/lilypad/pkg/system"
+	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/pow"
+	"github.com/lilypad-tech/lilypad/pkg/web3/bindings/users"
 )
 
 type Web3Options struct {
@@ -13,6 +18,39 @@ type Web3Options struct {
 	PrivateKey string `json:"private_key" toml:"private_key"`
 	ChainID    int    `json:"chain_id" toml:"chain_id"`
 
+	// what signs our transactions, by default the PrivateKey above
+	// the rest are only read by the signer that needs them
+	Signer            string `json:"signer" toml:"signer"`
+	KeystorePath      string `json:"keystore_path" toml:"keystore_path"`
+	KeystorePassword  string `json:"keystore_password" toml:"keystore_password"`
+	KMSKeyID          string `json:"kms_key_id" toml:"kms_key_id"`
+	KMSRegion         string `json:"kms_region" toml:"kms_region"`
+	KMSEndpoint       string `json:"kms_endpoint" toml:"kms_endpoint"`
+	RemoteSignerURL   string `json:"remote_signer_url" toml:"remote_signer_url"`
+	RemoteSignerToken string `json:"remote_signer_token" toml:"remote_signer_token"`
+	SignerAddress     string `json:"signer_address" toml:"signer_address"`
+
+	// failover between the endpoints in RpcURL
+	RpcHealthCheckInterval int `json:"rpc_health_check_interval" toml:"rpc_health_check_interval"`
+	RpcMaxBackoff          int `json:"rpc_max_backoff" toml:"rpc_max_backoff"`
+	RpcMaxBlockLag         int `json:"rpc_max_block_lag" toml:"rpc_max_block_lag"`
+
+	// how we price transactions, the fees are in wei and 0 means
+	// no cap or whatever priority fee the node suggests
+	GasStrategy    string `json:"gas_strategy" toml:"gas_strategy"`
+	GasMaxFeeCap   uint64 `json:"gas_max_fee_cap" toml:"gas_max_fee_cap"`
+	GasPriorityFee uint64 `json:"gas_priority_fee" toml:"gas_priority_fee"`
+	// stuck transactions are sent again with fees bumped by this percent
+	GasBumpPercent  int `json:"gas_bump_percent" toml:"gas_bump_percent"`
+	GasStuckTimeout int `json:"gas_stuck_timeout" toml:"gas_stuck_timeout"`
+	GasMaxBumps     int `json:"gas_max_bumps" toml:"gas_max_bumps"`
+
+	// how services with a store follow events, see SyncEvents
+	// logs are only handled once they have this many blocks on top of them
+	SyncConfirmations int `json:"sync_confirmations" toml:"sync_confirmations"`
+	SyncPollInterval  int `json:"sync_poll_interval" toml:"sync_poll_interval"`
+	SyncMaxBlockRange int `json:"sync_max_block_range" toml:"sync_max_block_range"`
+
 	// contract addresses
 	ControllerAddress string `json:"controller_address" toml:"controller_address"`
 	PaymentsAddress   string `json:"payments_address" toml:"payments_address"`
@@ -22,6 +60,8 @@ type Web3Options struct {
 	MediationAddress  string `json:"mediation_address" toml:"mediation_address"`
 	JobCreatorAddress string `json:"jobcreator_address" toml:"jobcreator_address"`
 	PowAddress        string `json:"pow_address" toml:"pow_address"`
+	// the parameter registry is optional - it is not known by the controller
+	ParametersAddress string `json:"parameters_address" toml:"parameters_address"`
 	// this is injected by whatever service we are running
 	// it's used for logging tx's
 	Service system.Service `json:"-" toml:"-"`
@@ -34,3 +74,43 @@ type EventChannelCollection interface {
 		cm *system.CleanupManager,
 	) error
 }
+
+// the chain operations the services depend on
+// services take this rather than a *Web3SDK so that
+// unit tests can swap in the mock from mock_web3
+//
+//go:generate mockgen -source=types.go -destination=mock_web3/mock_web3.go -package=mock_web3
+type Web3Client interface {
+	GetAddress() common.Address
+	GetSigner() Signer
+	GetBalance(address string) (*big.Int, error)
+	GetLPBalance(address string) (*big.Int, error)
+	GetLPTokenSenders(address string, lookback uint64) ([]string, error)
+	CheckPaymentToken(token data.PaymentToken) error
+	GetSolverUrl(address string) (string, error)
+	GetSolverAddresses() ([]common.Address, error)
+	GetUser(address common.Address) (users.SharedStructsUser, error)
+	UpdateUser(metadataCID string, url string, roles []uint8) error
+	AddUserToList(serviceType uint8) error
+	GetProtocolParameters() (ProtocolParameters, error)
+	GetBlockNumber() (uint64, error)
+	GetDealState(dealId string) (uint8, error)
+	Agree(deal data.Deal) (string, error)
+	AddResult(dealId string, resultsId string, dataId string, instructionCount uint64) (string, error)
+	AcceptResult(dealId string) (string, error)
+	GetMilestoneHash(milestone data.DealMilestone) ([]byte, error)
+	PayMilestone(dealId string, index uint64, amount *big.Int, jobCreatorSignature string) (string, error)
+	CheckResult(dealId string) (string, error)
+	MediationAcceptResult(dealId string) (string, error)
+	MediationRejectResult(dealId string) (string, error)
+	TimeoutAgree(dealId string) (string, error)
+	TimeoutSubmitResult(dealId string) (string, error)
+	TimeoutJudgeResult(dealId string) (string, error)
+	TimeoutMediateResult(dealId string) (string, error)
+	GetGenerateChallenge(ctx context.Context, nodeId string) (string, *pow.PowGenerateChallenge, error)
+	SubmitWork(ctx context.Context, nonce *big.Int, nodeId string) (common.Hash, error)
+	StartEvents(events *EventChannels, ctx context.Context, cm *system.CleanupManager) error
+	SyncEvents(events *EventChannels, checkpoints CheckpointStore, ctx context.Context) error
+	SetTransactionHandler(handler TransactionHandler)
+	ResumeTransactions(ctx context.Context, records []data.Transaction)
+}
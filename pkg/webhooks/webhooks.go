@@ -0,0 +1,282 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+)
+
+const (
+	WEBHOOK_REQUEST_TIMEOUT = 10 * time.Second
+	// how many deliveries can wait for the sender before we drop them
+	WEBHOOK_QUEUE_SIZE = 1000
+	// a receiver should refuse signatures older than this
+	// so a captured delivery cannot be replayed later
+	WEBHOOK_SIGNATURE_TOLERANCE = 5 * time.Minute
+)
+
+const (
+	WEBHOOK_EVENT_HEADER     = "X-Lilypad-Webhook-Event"
+	WEBHOOK_ID_HEADER        = "X-Lilypad-Webhook-Id"
+	WEBHOOK_SIGNATURE_HEADER = "X-Lilypad-Webhook-Signature"
+)
+
+// the deal lifecycle events a webhook can be sent for
+const (
+	EventDealMatched        = "deal.matched"
+	EventDealAgreed         = "deal.agreed"
+	EventResultsPosted      = "deal.results_posted"
+	EventResultsAccepted    = "deal.results_accepted"
+	EventMediationRequested = "deal.mediation_requested"
+	EventMediationVerdict   = "deal.mediation_verdict"
+	EventMediationAccepted  = "deal.mediation_accepted"
+	EventMediationRejected  = "deal.mediation_rejected"
+	EventDealTimedOut       = "deal.timed_out"
+	EventDealCancelled      = "deal.cancelled"
+)
+
+var Events = []string{
+	EventDealMatched,
+	EventDealAgreed,
+	EventResultsPosted,
+	EventResultsAccepted,
+	EventMediationRequested,
+	EventMediationVerdict,
+	EventMediationAccepted,
+	EventMediationRejected,
+	EventDealTimedOut,
+	EventDealCancelled,
+}
+
+func IsEvent(event string) bool {
+	for _, known := range Events {
+		if event == known {
+			return true
+		}
+	}
+	return false
+}
+
+// the event for a deal moving into a state, empty if there is none
+func GetDealStateEvent(state uint8) string {
+	switch data.GetAgreementStateString(state) {
+	case "DealAgreed":
+		return EventDealAgreed
+	case "ResultsSubmitted":
+		return EventResultsPosted
+	case "ResultsAccepted":
+		return EventResultsAccepted
+	case "ResultsChecked":
+		return EventMediationRequested
+	case "MediationAccepted":
+		return EventMediationAccepted
+	case "MediationRejected":
+		return EventMediationRejected
+	case "TimeoutAgree", "TimeoutSubmitResults", "TimeoutJudgeResults", "TimeoutMediateResults":
+		return EventDealTimedOut
+	}
+	return ""
+}
+
+type WebhookOptions struct {
+	// where each event is POSTed
+	URLs []string
+	// the key the payloads are signed with using HMAC-SHA256
+	Secret string
+	// only send these events, empty means all of them
+	Events []string
+	// how many more times to try a delivery that failed
+	Retries int
+	// milliseconds before the first retry, this doubles for each retry
+	RetryDelay int
+}
+
+func (options WebhookOptions) Enabled() bool {
+	return len(options.URLs) > 0
+}
+
+func (options WebhookOptions) wants(event string) bool {
+	if len(options.Events) == 0 {
+		return true
+	}
+	for _, wanted := range options.Events {
+		if wanted == event {
+			return true
+		}
+	}
+	return false
+}
+
+type Payload struct {
+	// the same for every attempt so a receiver can ignore repeats
+	ID        string             `json:"id"`
+	Event     string             `json:"event"`
+	CreatedAt int64              `json:"created_at"`
+	Deal      data.DealContainer `json:"deal"`
+}
+
+type delivery struct {
+	url     string
+	payload Payload
+}
+
+// POSTs deal events to the webhook URLs in the background
+type Dispatcher struct {
+	options WebhookOptions
+	client  *http.Client
+	queue   chan delivery
+	log     *system.ServiceLogger
+}
+
+func NewDispatcher(service system.Service, options WebhookOptions) *Dispatcher {
+	return &Dispatcher{
+		options: options,
+		client:  &http.Client{Timeout: WEBHOOK_REQUEST_TIMEOUT},
+		queue:   make(chan delivery, WEBHOOK_QUEUE_SIZE),
+		log:     system.NewServiceLogger(service),
+	}
+}
+
+// deliver what is sent until the context is done
+// deliveries are made one at a time so a receiver sees events in order
+func (dispatcher *Dispatcher) Start(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case next := <-dispatcher.queue:
+				err := dispatcher.Deliver(ctx, next.url, next.payload)
+				if err != nil {
+					dispatcher.log.Error("error delivering webhook", fmt.Errorf("%s %s to %s: %s", next.payload.Event, next.payload.Deal.ID, next.url, err.Error()))
+				}
+			}
+		}
+	}()
+}
+
+// queue the event for each URL, this never blocks the caller
+func (dispatcher *Dispatcher) Send(event string, deal data.DealContainer) {
+	if event == "" || !dispatcher.options.wants(event) {
+		return
+	}
+	payload := Payload{
+		ID:        uuid.New().String(),
+		Event:     event,
+		CreatedAt: time.Now().Unix(),
+		Deal:      deal,
+	}
+	for _, url := range dispatcher.options.URLs {
+		select {
+		case dispatcher.queue <- delivery{url: url, payload: payload}:
+		default:
+			dispatcher.log.Error("webhook queue is full", fmt.Errorf("dropping %s %s to %s", event, deal.ID, url))
+		}
+	}
+}
+
+// POST the payload and try again with backoff until it is taken
+func (dispatcher *Dispatcher) Deliver(ctx context.Context, url string, payload Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	delay := time.Duration(dispatcher.options.RetryDelay) * time.Millisecond
+	for attempt := 0; ; attempt++ {
+		retry, err := dispatcher.post(url, payload, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= dispatcher.options.Retries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// whether it is worth trying again if this fails
+func (dispatcher *Dispatcher) post(url string, payload Payload, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WEBHOOK_EVENT_HEADER, payload.Event)
+	req.Header.Set(WEBHOOK_ID_HEADER, payload.ID)
+	if dispatcher.options.Secret != "" {
+		req.Header.Set(WEBHOOK_SIGNATURE_HEADER, Sign(dispatcher.options.Secret, time.Now().Unix(), body))
+	}
+	res, err := dispatcher.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return false, nil
+	}
+	// the receiver does not want this and asking again will not change that
+	retry := res.StatusCode >= 500 || res.StatusCode == http.StatusTooManyRequests || res.StatusCode == http.StatusRequestTimeout
+	return retry, fmt.Errorf("returned %s", res.Status)
+}
+
+// t=<unix seconds>,v1=<hex HMAC-SHA256 of "<unix seconds>.<body>">
+// the timestamp is signed so an old delivery cannot be sent again
+func Sign(secret string, timestamp int64, body []byte) string {
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, getSignature(secret, timestamp, body))
+}
+
+func getSignature(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%d.", timestamp)))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// for receivers written in Go, the signature must be no older than tolerance
+func VerifySignature(secret string, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var timestamp int64
+	signature := ""
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			parsed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("webhook signature has an invalid timestamp")
+			}
+			timestamp = parsed
+		case "v1":
+			signature = value
+		}
+	}
+	if timestamp == 0 || signature == "" {
+		return fmt.Errorf("webhook signature is missing its timestamp or signature")
+	}
+	age := now.Sub(time.Unix(timestamp, 0))
+	if age > tolerance || age < -tolerance {
+		return fmt.Errorf("webhook signature is too old")
+	}
+	if !hmac.Equal([]byte(signature), []byte(getSignature(secret, timestamp, body))) {
+		return fmt.Errorf("webhook signature does not match")
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package webhooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lilypad-tech/lilypad/pkg/data"
+	"github.com/lilypad-tech/lilypad/pkg/system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"event":"deal.agreed"}`)
+	now := time.Unix(1700000000, 0)
+	header := Sign("secret", now.Unix(), body)
+
+	require.NoError(t, VerifySignature("secret", header, body, now, WEBHOOK_SIGNATURE_TOLERANCE))
+	assert.Error(t, VerifySignature("other", header, body, now, WEBHOOK_SIGNATURE_TOLERANCE))
+	assert.Error(t, VerifySignature("secret", header, []byte(`{"event":"deal.matched"}`), now, WEBHOOK_SIGNATURE_TOLERANCE))
+	assert.Error(t, VerifySignature("secret", header, body, now.Add(time.Hour), WEBHOOK_SIGNATURE_TOLERANCE))
+	assert.Error(t, VerifySignature("secret", "v1=abc", body, now, WEBHOOK_SIGNATURE_TOLERANCE))
+}
+
+func TestDeliverRetries(t *testing.T) {
+	attempts := atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.NoError(t, VerifySignature("secret", req.Header.Get(WEBHOOK_SIGNATURE_HEADER), body, time.Now(), WEBHOOK_SIGNATURE_TOLERANCE))
+		assert.Equal(t, EventDealAgreed, req.Header.Get(WEBHOOK_EVENT_HEADER))
+		assert.Equal(t, "payload", req.Header.Get(WEBHOOK_ID_HEADER))
+		switch req.URL.Path {
+		case "/flaky":
+			if attempts.Add(1) < 3 {
+				res.WriteHeader(http.StatusServiceUnavailable)
+			}
+		case "/refuses":
+			attempts.Add(1)
+			res.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	dispatcher := NewDispatcher(system.SolverService, WebhookOptions{
+		URLs:       []string{server.URL},
+		Secret:     "secret",
+		Retries:    3,
+		RetryDelay: 1,
+	})
+	payload := Payload{ID: "payload", Event: EventDealAgreed, Deal: data.DealContainer{ID: "deal"}}
+
+	require.NoError(t, dispatcher.Deliver(context.Background(), server.URL+"/flaky", payload))
+	assert.Equal(t, int32(3), attempts.Load())
+
+	// asking again will not change a 4xx
+	attempts.Store(0)
+	assert.Error(t, dispatcher.Deliver(context.Background(), server.URL+"/refuses", payload))
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func TestSendOnlyWantedEvents(t *testing.T) {
+	dispatcher := NewDispatcher(system.SolverService, WebhookOptions{
+		URLs:   []string{"http://one", "http://two"},
+		Events: []string{EventResultsPosted},
+	})
+	deal := data.DealContainer{ID: "deal"}
+	dispatcher.Send(EventDealAgreed, deal)
+	dispatcher.Send(EventResultsPosted, deal)
+	dispatcher.Send("", deal)
+	assert.Len(t, dispatcher.queue, 2)
+
+	assert.Equal(t, EventResultsPosted, GetDealStateEvent(data.GetAgreementStateIndex("ResultsSubmitted")))
+	assert.Equal(t, EventDealTimedOut, GetDealStateEvent(data.GetAgreementStateIndex("TimeoutJudgeResults")))
+	assert.Equal(t, "", GetDealStateEvent(data.GetAgreementStateIndex("DealNegotiating")))
+}
@@ -54,7 +54,7 @@ func getMediator(
 		return nil, err
 	}
 
-	return mediator.NewMediator(mediatorOptions, web3SDK, executor)
+	return mediator.NewMediator(mediatorOptions, web3SDK, executor, nil)
 }
 
 func getJobCreatorOptions(options testOptions) (jobcreator.JobCreatorOptions, error) {
@@ -103,7 +103,7 @@ func testStackWithOptions(
 	noopTracer := traceNoop.NewTracerProvider().Tracer(system.GetOTelServiceName(system.DefaultService))
 	result, err := jobcreator.RunJob(commandCtx, jobCreatorOptions, noopTracer, func(evOffer data.JobOfferContainer) {
 
-	})
+	}, nil)
 	if err != nil {
 		return nil, err
 	}